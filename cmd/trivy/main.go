@@ -9,6 +9,7 @@ import (
 	"github.com/zhanglimao/trivy/pkg/commands"
 	"github.com/zhanglimao/trivy/pkg/log"
 	"github.com/zhanglimao/trivy/pkg/plugin"
+	"github.com/zhanglimao/trivy/pkg/tracing"
 
 	_ "modernc.org/sqlite" // sqlite driver for RPM DB and Java DB
 )
@@ -36,6 +37,7 @@ func run() error {
 	}
 
 	app := commands.NewApp(version)
+	defer tracing.Shutdown(context.Background())
 	if err := app.Execute(); err != nil {
 		return err
 	}
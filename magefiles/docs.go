@@ -19,6 +19,7 @@ func main() {
 	// Set a dummy path for the documents
 	flag.CacheDirFlag.Value = "/path/to/cache"
 	flag.ModuleDirFlag.Value = "$HOME/.trivy/modules"
+	flag.AnalyzerPluginDirFlag.Value = "$HOME/.trivy/analyzer-plugins"
 
 	cmd := commands.NewApp(ver)
 	cmd.DisableAutoGenTag = true
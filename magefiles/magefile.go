@@ -371,6 +371,11 @@ func (Docs) Generate() error {
 	return sh.RunWith(ENV, "go", "run", "-tags=mage_docs", "./magefiles")
 }
 
+// Schema generates the JSON schema of the report
+func Schema() error {
+	return sh.RunWithV(ENV, "go", "test", "./pkg/report/schema", "-run", "TestGenerate", "-update")
+}
+
 func findProtoFiles() ([]string, error) {
 	var files []string
 	err := filepath.WalkDir("rpc", func(path string, d fs.DirEntry, err error) error {
@@ -0,0 +1,256 @@
+// Package advisory loads custom advisories, e.g. internal advisories of enterprises,
+// which are merged with trivy-db in the detectors at scan time.
+package advisory
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy-db/pkg/vulnsrc/vulnerability"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+const (
+	// SchemaVersion is the version of the advisory files
+	SchemaVersion = 1
+
+	// DefaultSourceID is used when the file doesn't have the source
+	DefaultSourceID dbTypes.SourceID = "custom"
+)
+
+// File is the JSON schema of custom advisory files.
+// Advisories are keyed by ecosystem and package name.
+//
+//	{
+//	  "schema_version": 1,
+//	  "source": {"id": "acme", "name": "ACME Security Advisories", "url": "https://security.acme.example"},
+//	  "advisories": {
+//	    "npm": {
+//	      "@acme/auth": [
+//	        {"id": "ACME-2023-0001", "vulnerable_versions": ["<1.4.2"], "patched_versions": ["1.4.2"], "severity": "HIGH"}
+//	      ]
+//	    },
+//	    "debian": {
+//	      "acme-agent": [
+//	        {"id": "ACME-2023-0002", "fixed_version": "2.0.1-1", "severity": "CRITICAL"}
+//	      ]
+//	    }
+//	  }
+//	}
+type File struct {
+	SchemaVersion int                              `json:"schema_version"`
+	Source        Source                           `json:"source"`
+	Advisories    map[string]map[string][]Advisory `json:"advisories"`
+}
+
+// Source is the data source shown in the report
+type Source struct {
+	ID   dbTypes.SourceID `json:"id"`
+	Name string           `json:"name"`
+	URL  string           `json:"url"`
+}
+
+// Advisory is a vulnerability of a package
+type Advisory struct {
+	ID string `json:"id"`
+
+	// For language-specific packages, in the same format as trivy-db, e.g. ">=1.0.0, <1.4.2"
+	VulnerableVersions []string `json:"vulnerable_versions,omitempty"`
+	PatchedVersions    []string `json:"patched_versions,omitempty"`
+
+	// For OS packages. All the versions are vulnerable if empty.
+	FixedVersion string `json:"fixed_version,omitempty"`
+
+	Severity    string   `json:"severity,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	References  []string `json:"references,omitempty"`
+
+	// Populated from the file
+	DataSource *dbTypes.DataSource `json:"-"`
+}
+
+// store holds the loaded advisories
+type store struct {
+	// ecosystem => package name => advisories
+	advisories map[string]map[string][]Advisory
+
+	// source ID => vulnerability ID => details
+	vulnerabilities map[dbTypes.SourceID]map[string]dbTypes.Vulnerability
+}
+
+var (
+	mu      sync.RWMutex
+	current *store
+
+	// reservedSourceIDs are the data sources of trivy-db.
+	// Custom advisories can't use them as their details would override the upstream ones.
+	reservedSourceIDs = []dbTypes.SourceID{
+		vulnerability.NVD,
+		vulnerability.RedHat,
+		vulnerability.RedHatOVAL,
+		vulnerability.Debian,
+		vulnerability.Ubuntu,
+		vulnerability.CentOS,
+		vulnerability.Rocky,
+		vulnerability.Fedora,
+		vulnerability.Amazon,
+		vulnerability.OracleOVAL,
+		vulnerability.SuseCVRF,
+		vulnerability.Alpine,
+		vulnerability.ArchLinux,
+		vulnerability.Alma,
+		vulnerability.CBLMariner,
+		vulnerability.Photon,
+		vulnerability.RubySec,
+		vulnerability.PhpSecurityAdvisories,
+		vulnerability.NodejsSecurityWg,
+		vulnerability.GHSA,
+		vulnerability.GLAD,
+		vulnerability.GoVulnDB,
+		vulnerability.OSV,
+		vulnerability.Wolfi,
+		vulnerability.Chainguard,
+	}
+)
+
+// Load loads the advisory files (*.json) in the directories.
+// A file can be passed instead of a directory, which is loaded regardless of the extension.
+// The advisories loaded previously are replaced.
+func Load(dirs ...string) error {
+	s := &store{
+		advisories:      map[string]map[string][]Advisory{},
+		vulnerabilities: map[dbTypes.SourceID]map[string]dbTypes.Vulnerability{},
+	}
+	var count int
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			} else if d.IsDir() || (path != dir && filepath.Ext(path) != ".json") {
+				return nil
+			}
+			n, err := s.loadFile(path)
+			if err != nil {
+				return xerrors.Errorf("%s: %w", path, err)
+			}
+			count += n
+			return nil
+		})
+		if err != nil {
+			return xerrors.Errorf("custom advisory load error: %w", err)
+		}
+	}
+	log.Logger.Infof("%d custom advisories loaded", count)
+
+	mu.Lock()
+	defer mu.Unlock()
+	current = s
+	return nil
+}
+
+// Reset unloads the advisories
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	current = nil
+}
+
+func (s *store) loadFile(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	var file File
+	if err = json.NewDecoder(f).Decode(&file); err != nil {
+		return 0, xerrors.Errorf("json decode error: %w", err)
+	} else if file.SchemaVersion != SchemaVersion {
+		return 0, xerrors.Errorf("unsupported schema version %d (expected: %d)", file.SchemaVersion, SchemaVersion)
+	}
+
+	source := &dbTypes.DataSource{
+		ID:   file.Source.ID,
+		Name: file.Source.Name,
+		URL:  file.Source.URL,
+	}
+	if source.ID == "" {
+		source.ID = DefaultSourceID
+	} else if slices.Contains(reservedSourceIDs, dbTypes.SourceID(strings.ToLower(string(source.ID)))) {
+		return 0, xerrors.Errorf("source id %q is reserved for trivy-db", source.ID)
+	}
+	if s.vulnerabilities[source.ID] == nil {
+		s.vulnerabilities[source.ID] = map[string]dbTypes.Vulnerability{}
+	}
+
+	var count int
+	for ecosystem, pkgs := range file.Advisories {
+		ecosystem = strings.ToLower(ecosystem)
+		if s.advisories[ecosystem] == nil {
+			s.advisories[ecosystem] = map[string][]Advisory{}
+		}
+		for name, advs := range pkgs {
+			name = NormalizePkgName(ecosystem, name)
+			for _, adv := range advs {
+				if adv.ID == "" {
+					return 0, xerrors.Errorf("id is required for the advisory of %s", name)
+				}
+				if _, err = dbTypes.NewSeverity(strings.ToUpper(adv.Severity)); adv.Severity != "" && err != nil {
+					return 0, xerrors.Errorf("invalid severity of %s: %w", adv.ID, err)
+				}
+				adv.DataSource = source
+				s.advisories[ecosystem][name] = append(s.advisories[ecosystem][name], adv)
+				s.vulnerabilities[source.ID][adv.ID] = dbTypes.Vulnerability{
+					Title:       adv.Title,
+					Description: adv.Description,
+					Severity:    severity(adv.Severity),
+					References:  adv.References,
+				}
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+// NormalizePkgName normalizes the package name in the same way as trivy-db, e.g. lowercase for pip
+func NormalizePkgName(ecosystem, pkgName string) string {
+	return vulnerability.NormalizePkgName(dbTypes.Ecosystem(ecosystem), pkgName)
+}
+
+func severity(s string) string {
+	if s == "" {
+		return dbTypes.SeverityUnknown.String()
+	}
+	return strings.ToUpper(s)
+}
+
+// Get returns the custom advisories of the package
+func Get(ecosystem, pkgName string) []Advisory {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil {
+		return nil
+	}
+	return current.advisories[ecosystem][NormalizePkgName(ecosystem, pkgName)]
+}
+
+// Vulnerability returns the details of the vulnerability in the custom advisories
+func Vulnerability(source dbTypes.SourceID, vulnID string) (dbTypes.Vulnerability, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil {
+		return dbTypes.Vulnerability{}, false
+	}
+	v, ok := current.vulnerabilities[source][vulnID]
+	return v, ok
+}
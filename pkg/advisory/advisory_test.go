@@ -0,0 +1,220 @@
+package advisory_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/advisory"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+var acmeSource = &dbTypes.DataSource{
+	ID:   "acme",
+	Name: "ACME Security Advisories",
+	URL:  "https://security.acme.example",
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		dir     string
+		wantErr string
+	}{
+		{
+			name: "happy path",
+			dir:  "testdata/happy",
+		},
+		{
+			name: "file",
+			dir:  "testdata/happy/os.json",
+		},
+		{
+			name:    "invalid severity",
+			dir:     "testdata/sad",
+			wantErr: "invalid severity of ACME-2023-0004",
+		},
+		{
+			name:    "reserved source",
+			dir:     "testdata/reserved",
+			wantErr: `source id "ghsa" is reserved for trivy-db`,
+		},
+		{
+			name:    "no such directory",
+			dir:     "testdata/unknown",
+			wantErr: "custom advisory load error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer advisory.Reset()
+
+			err := advisory.Load(tt.dir)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	require.NoError(t, advisory.Load("testdata/happy"))
+	defer advisory.Reset()
+
+	tests := []struct {
+		name      string
+		ecosystem string
+		pkgName   string
+		want      []advisory.Advisory
+	}{
+		{
+			name:      "normalized name",
+			ecosystem: "pip",
+			pkgName:   "acme-client",
+			want: []advisory.Advisory{
+				{
+					ID:                 "ACME-2023-0001",
+					VulnerableVersions: []string{"<1.4.2"},
+					PatchedVersions:    []string{"1.4.2"},
+					DataSource:         acmeSource,
+				},
+			},
+		},
+		{
+			name:      "unknown package",
+			ecosystem: "pip",
+			pkgName:   "requests",
+		},
+		{
+			name:      "unknown ecosystem",
+			ecosystem: "npm",
+			pkgName:   "acme-client",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, advisory.Get(tt.ecosystem, tt.pkgName))
+		})
+	}
+}
+
+func TestVulnerability(t *testing.T) {
+	require.NoError(t, advisory.Load("testdata/happy"))
+	defer advisory.Reset()
+
+	got, ok := advisory.Vulnerability(advisory.DefaultSourceID, "ACME-2023-0002")
+	require.True(t, ok)
+	assert.Equal(t, dbTypes.Vulnerability{
+		Title:      "Remote code execution in acme-agent",
+		Severity:   "CRITICAL",
+		References: []string{"https://security.acme.example/ACME-2023-0002"},
+	}, got)
+
+	got, ok = advisory.Vulnerability("acme", "ACME-2023-0001")
+	require.True(t, ok)
+	assert.Equal(t, "UNKNOWN", got.Severity)
+
+	_, ok = advisory.Vulnerability("acme", "ACME-2023-0002")
+	assert.False(t, ok)
+}
+
+func TestDetectOS(t *testing.T) {
+	require.NoError(t, advisory.Load("testdata/happy"))
+	defer advisory.Reset()
+
+	customSource := &dbTypes.DataSource{ID: advisory.DefaultSourceID}
+	tests := []struct {
+		name     string
+		family   string
+		pkgs     []ftypes.Package
+		detected []types.DetectedVulnerability
+		want     []types.DetectedVulnerability
+	}{
+		{
+			name:   "binary and source packages",
+			family: "debian",
+			pkgs: []ftypes.Package{
+				{
+					ID:      "acme-agent@2.0.0-3",
+					Name:    "acme-agent",
+					Version: "2.0.0",
+					Release: "3",
+				},
+				{
+					Name:       "libssl3",
+					Version:    "3.0.9",
+					Release:    "1",
+					SrcName:    "openssl",
+					SrcVersion: "3.0.9",
+					SrcRelease: "1",
+				},
+			},
+			want: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "ACME-2023-0002",
+					PkgID:            "acme-agent@2.0.0-3",
+					PkgName:          "acme-agent",
+					InstalledVersion: "2.0.0-3",
+					FixedVersion:     "2.0.1-1",
+					DataSource:       customSource,
+				},
+				{
+					VulnerabilityID:  "ACME-2023-0003",
+					PkgName:          "libssl3",
+					InstalledVersion: "3.0.9-1",
+					DataSource:       customSource,
+				},
+			},
+		},
+		{
+			name:   "fixed",
+			family: "debian",
+			pkgs: []ftypes.Package{
+				{
+					Name:    "acme-agent",
+					Version: "2.0.1",
+					Release: "1",
+				},
+			},
+		},
+		{
+			name:   "already detected",
+			family: "debian",
+			pkgs: []ftypes.Package{
+				{
+					Name:    "openssl",
+					Version: "3.0.9",
+					Release: "1",
+				},
+			},
+			detected: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "ACME-2023-0003",
+					PkgName:         "openssl",
+				},
+			},
+		},
+		{
+			name:   "another family",
+			family: "ubuntu",
+			pkgs: []ftypes.Package{
+				{
+					Name:    "acme-agent",
+					Version: "2.0.0",
+					Release: "3",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := advisory.DetectOS(tt.family, tt.pkgs, tt.detected)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package advisory
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/oci"
+)
+
+// Dir returns the directory where the custom advisories downloaded from the OCI repository are stored
+func Dir(cacheDir string) string {
+	return filepath.Join(cacheDir, "custom-advisories")
+}
+
+// Download downloads the custom advisories from the OCI repository into the cache directory.
+// The artifact must have a single layer with a JSON file or an archive of JSON files, e.g. tar.gz.
+// The cached advisories are used as is if skipUpdate is true.
+func Download(ctx context.Context, repo, cacheDir string, quiet, skipUpdate bool, opt ftypes.RegistryOptions) (string, error) {
+	dir := Dir(cacheDir)
+	if skipUpdate {
+		if _, err := os.Stat(dir); err != nil {
+			return "", xerrors.Errorf("--skip-db-update cannot be specified on the first run: %w", err)
+		}
+		log.Logger.Debug("Skipping the custom advisory update")
+		return dir, nil
+	}
+
+	log.Logger.Infof("Downloading custom advisories from %s...", repo)
+	art, err := oci.NewArtifact(repo, quiet, opt, oci.WithCacheDir(cacheDir))
+	if err != nil {
+		return "", xerrors.Errorf("OCI artifact error: %w", err)
+	}
+
+	// The advisories downloaded previously are replaced
+	if err = art.Download(ctx, dir, oci.DownloadOption{}); err != nil {
+		return "", xerrors.Errorf("custom advisory download error: %w", err)
+	}
+	return dir, nil
+}
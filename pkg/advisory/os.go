@@ -0,0 +1,109 @@
+package advisory
+
+import (
+	apkver "github.com/knqyf263/go-apk-version"
+	debver "github.com/knqyf263/go-deb-version"
+	rpmver "github.com/knqyf263/go-rpm-version"
+
+	fos "github.com/zhanglimao/trivy/pkg/fanal/analyzer/os"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/scanner/utils"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// lessThan reports if the installed version is older than the fixed version
+type lessThan func(installed, fixed string) (bool, error)
+
+func apkLessThan(installed, fixed string) (bool, error) {
+	i, err := apkver.NewVersion(installed)
+	if err != nil {
+		return false, err
+	}
+	f, err := apkver.NewVersion(fixed)
+	if err != nil {
+		return false, err
+	}
+	return i.LessThan(f), nil
+}
+
+func debLessThan(installed, fixed string) (bool, error) {
+	i, err := debver.NewVersion(installed)
+	if err != nil {
+		return false, err
+	}
+	f, err := debver.NewVersion(fixed)
+	if err != nil {
+		return false, err
+	}
+	return i.LessThan(f), nil
+}
+
+func rpmLessThan(installed, fixed string) (bool, error) {
+	return rpmver.NewVersion(installed).LessThan(rpmver.NewVersion(fixed)), nil
+}
+
+func osComparer(family string) lessThan {
+	switch family {
+	case fos.Alpine, fos.Wolfi, fos.Chainguard:
+		return apkLessThan
+	case fos.Debian, fos.Ubuntu:
+		return debLessThan
+	default:
+		return rpmLessThan
+	}
+}
+
+// DetectOS returns the vulnerabilities of the OS packages in the custom advisories keyed by the OS family.
+// The package name matches the binary or source package.
+// Vulnerabilities already detected with the same ID are skipped so that trivy-db takes precedence.
+func DetectOS(family string, pkgs []ftypes.Package, detected []types.DetectedVulnerability) []types.DetectedVulnerability {
+	mu.RLock()
+	loaded := current != nil && len(current.advisories[family]) > 0
+	mu.RUnlock()
+	if !loaded {
+		return nil
+	}
+
+	seen := map[[2]string]struct{}{}
+	for _, v := range detected {
+		seen[[2]string{v.PkgName, v.VulnerabilityID}] = struct{}{}
+	}
+
+	compare := osComparer(family)
+	var vulns []types.DetectedVulnerability
+	for _, pkg := range pkgs {
+		advisories := Get(family, pkg.Name)
+		installed := utils.FormatVersion(pkg)
+		if len(advisories) == 0 && pkg.SrcName != "" && pkg.SrcName != pkg.Name {
+			advisories = Get(family, pkg.SrcName)
+			installed = utils.FormatSrcVersion(pkg)
+		}
+
+		for _, adv := range advisories {
+			if _, ok := seen[[2]string{pkg.Name, adv.ID}]; ok {
+				continue
+			}
+			if adv.FixedVersion != "" {
+				vulnerable, err := compare(installed, adv.FixedVersion)
+				if err != nil {
+					log.Logger.Debugf("Failed to compare versions of %s in %s: %s", pkg.Name, adv.ID, err)
+					continue
+				} else if !vulnerable {
+					continue
+				}
+			}
+			vulns = append(vulns, types.DetectedVulnerability{
+				VulnerabilityID:  adv.ID,
+				PkgID:            pkg.ID,
+				PkgName:          pkg.Name,
+				InstalledVersion: utils.FormatVersion(pkg),
+				FixedVersion:     adv.FixedVersion,
+				Layer:            pkg.Layer,
+				PkgRef:           pkg.Ref,
+				DataSource:       adv.DataSource,
+			})
+		}
+	}
+	return vulns
+}
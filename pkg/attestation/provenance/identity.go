@@ -0,0 +1,54 @@
+package provenance
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+
+	"golang.org/x/xerrors"
+)
+
+var (
+	// OIDs of the OIDC issuer in Fulcio certificates
+	// cf. https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md
+	oidIssuer   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1} // deprecated, the raw string
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8} // DER-encoded UTF8String
+)
+
+func parseCertificate(b []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, xerrors.New("no PEM data found")
+	} else if block.Type != "CERTIFICATE" {
+		return nil, xerrors.Errorf("signed with a %s, not a certificate", block.Type)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, xerrors.Errorf("certificate parse error: %w", err)
+	}
+	return cert, nil
+}
+
+// identity returns the OIDC issuer and the subject of the signing certificate issued by Fulcio.
+// The subject is the URI for workloads such as GitHub Actions, or the email address for users.
+func identity(cert *x509.Certificate) (issuer, subject string) {
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(oidIssuerV2):
+			var s string
+			if _, err := asn1.Unmarshal(ext.Value, &s); err == nil {
+				issuer = s
+			}
+		case ext.Id.Equal(oidIssuer) && issuer == "":
+			issuer = string(ext.Value)
+		}
+	}
+
+	switch {
+	case len(cert.URIs) > 0:
+		subject = cert.URIs[0].String()
+	case len(cert.EmailAddresses) > 0:
+		subject = cert.EmailAddresses[0]
+	}
+	return issuer, subject
+}
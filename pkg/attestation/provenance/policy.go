@@ -0,0 +1,260 @@
+package provenance
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// Policy defines who is trusted to sign images and provenance, e.g.
+//
+//	identities:
+//	  - issuer: https://token.actions.githubusercontent.com
+//	    subjectRegExp: ^https://github.com/acme/app/.github/workflows/release.yml@refs/tags/
+//	keys:
+//	  - cosign.pub
+//	builders:
+//	  - https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v1.9.0
+//	roots: fulcio.pem
+//	rekorKey: rekor.pub
+type Policy struct {
+	// Identities are the signers allowed in signing certificates. Any of them must match.
+	Identities []Identity `yaml:"identities"`
+
+	// Keys are the paths to the PEM-encoded public keys allowed to sign, relative to the policy file
+	Keys []string `yaml:"keys"`
+
+	// Builders are the builder IDs allowed in provenance. Any builder is allowed if empty.
+	Builders []string `yaml:"builders"`
+
+	// Roots is the path to the PEM-encoded CA certificates the signing certificates must chain to,
+	// relative to the policy file. It is required with identities.
+	Roots string `yaml:"roots"`
+
+	// RekorKey is the path to the PEM-encoded public key of Rekor, relative to the policy file.
+	// It is required with identities, as signing certificates are valid only at the time recorded in Rekor.
+	RekorKey string `yaml:"rekorKey"`
+
+	keys     []publicKey
+	roots    *x509.CertPool
+	rekorKey crypto.PublicKey
+}
+
+// Identity is a signer in the signing certificate
+type Identity struct {
+	Issuer        string `yaml:"issuer"`
+	Subject       string `yaml:"subject"`
+	SubjectRegExp string `yaml:"subjectRegExp"`
+
+	subjectRegExp *regexp.Regexp
+}
+
+type publicKey struct {
+	path string // as written in the policy
+	der  []byte
+	pub  crypto.PublicKey
+}
+
+// LoadPolicy loads the policy file in YAML
+func LoadPolicy(filePath string) (Policy, error) {
+	b, err := os.ReadFile(filePath)
+	if err != nil {
+		return Policy{}, xerrors.Errorf("file read error: %w", err)
+	}
+
+	var policy Policy
+	if err = yaml.Unmarshal(b, &policy); err != nil {
+		return Policy{}, xerrors.Errorf("yaml decode error: %w", err)
+	}
+	if len(policy.Identities) == 0 && len(policy.Keys) == 0 {
+		return Policy{}, xerrors.New("no identities or keys in the policy")
+	}
+
+	for i, identity := range policy.Identities {
+		switch {
+		case identity.Issuer == "":
+			return Policy{}, xerrors.Errorf("identities[%d]: empty issuer", i)
+		case (identity.Subject == "") == (identity.SubjectRegExp == ""):
+			return Policy{}, xerrors.Errorf("identities[%d]: either subject or subjectRegExp must be specified", i)
+		case identity.SubjectRegExp != "":
+			re, err := regexp.Compile(identity.SubjectRegExp)
+			if err != nil {
+				return Policy{}, xerrors.Errorf("identities[%d]: invalid subjectRegExp: %w", i, err)
+			}
+			policy.Identities[i].subjectRegExp = re
+		}
+	}
+
+	dir := filepath.Dir(filePath)
+	for i, keyPath := range policy.Keys {
+		key, err := loadPublicKey(resolvePath(dir, keyPath))
+		if err != nil {
+			return Policy{}, xerrors.Errorf("keys[%d]: %w", i, err)
+		}
+		key.path = keyPath
+		policy.keys = append(policy.keys, key)
+	}
+
+	// Anyone can make a certificate with any identity, so identities are trusted only in certificates chained to the roots
+	if len(policy.Identities) > 0 && (policy.Roots == "" || policy.RekorKey == "") {
+		return Policy{}, xerrors.New("roots and rekorKey are required to trust identities")
+	}
+
+	if policy.Roots != "" {
+		rootsPath := resolvePath(dir, policy.Roots)
+		pem, err := os.ReadFile(rootsPath)
+		if err != nil {
+			return Policy{}, xerrors.Errorf("unable to read the roots: %w", err)
+		}
+		policy.roots = x509.NewCertPool()
+		if !policy.roots.AppendCertsFromPEM(pem) {
+			return Policy{}, xerrors.Errorf("no certificates found in %s", rootsPath)
+		}
+	}
+
+	if policy.RekorKey != "" {
+		key, err := loadPublicKey(resolvePath(dir, policy.RekorKey))
+		if err != nil {
+			return Policy{}, xerrors.Errorf("rekorKey: %w", err)
+		}
+		policy.rekorKey = key.pub
+	}
+	return policy, nil
+}
+
+func resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func loadPublicKey(path string) (publicKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return publicKey{}, xerrors.Errorf("unable to read the key: %w", err)
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return publicKey{}, xerrors.Errorf("no PEM data found: %s", path)
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return publicKey{}, xerrors.Errorf("public key parse error: %w", err)
+	}
+	return publicKey{
+		der: block.Bytes,
+		pub: pub,
+	}, nil
+}
+
+func (i Identity) match(issuer, subject string) bool {
+	if i.Issuer != issuer {
+		return false
+	}
+	if i.subjectRegExp != nil {
+		return i.subjectRegExp.MatchString(subject)
+	}
+	return i.Subject == subject
+}
+
+// signer is the identity in the signing certificate, or the allowed key which made the signature
+type signer struct {
+	issuer  string
+	subject string // the subject of the certificate, or the path of the key in the policy
+}
+
+// checkSigner verifies the signature and that it is made by a trusted signer.
+// signerPEM holds the signing certificate or the public key, and is empty for signatures made with keys by cosign.
+// verifySignature is nil if the source has verified the signature, e.g. Rekor.
+// signedAt returns the time of signing proven by the transparency log, which is required for certificates
+// and for signatures verified by the source.
+// The returned signer is filled as far as it is known even on errors.
+func (p Policy) checkSigner(signerPEM []byte, verifySignature func(crypto.PublicKey) error,
+	signedAt func() (time.Time, error)) (signer, error) {
+	// The source is trusted only if the entry is signed by it
+	if verifySignature == nil {
+		if _, err := signedAt(); err != nil {
+			return signer{}, err
+		}
+	}
+
+	block, _ := pem.Decode(signerPEM)
+	switch {
+	case block == nil:
+		if verifySignature == nil {
+			return signer{}, xerrors.New("no signer found")
+		}
+		// The signature must be made by any of the keys
+		for _, key := range p.keys {
+			if verifySignature(key.pub) == nil {
+				return signer{subject: key.path}, nil
+			}
+		}
+		return signer{}, xerrors.New("no valid signature by the allowed keys found")
+	case block.Type == "PUBLIC KEY":
+		for _, key := range p.keys {
+			if !bytes.Equal(key.der, block.Bytes) {
+				continue
+			}
+			if verifySignature != nil {
+				if err := verifySignature(key.pub); err != nil {
+					return signer{subject: key.path}, xerrors.New("no valid signature found")
+				}
+			}
+			return signer{subject: key.path}, nil
+		}
+		return signer{}, xerrors.New("the public key is not allowed")
+	}
+
+	cert, err := parseCertificate(signerPEM)
+	if err != nil {
+		return signer{}, xerrors.Errorf("invalid signing certificate: %w", err)
+	}
+	issuer, subject := identity(cert)
+	s := signer{
+		issuer:  issuer,
+		subject: subject,
+	}
+
+	if verifySignature != nil {
+		if err = verifySignature(cert.PublicKey); err != nil {
+			return s, xerrors.New("no valid signature found")
+		}
+	}
+
+	if p.roots == nil {
+		return s, xerrors.New("signing certificates are not trusted without roots in the policy")
+	}
+
+	// Fulcio certificates are valid only for minutes, so the chain is verified at the time recorded in Rekor
+	t, err := signedAt()
+	if err != nil {
+		return s, err
+	}
+	if _, err = cert.Verify(x509.VerifyOptions{
+		Roots:       p.roots,
+		CurrentTime: t,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		log.Logger.Debugf("Certificate verification error: %s", err)
+		return s, xerrors.New("the signing certificate is not issued by the trusted roots")
+	}
+
+	for _, i := range p.Identities {
+		if i.match(issuer, subject) {
+			return s, nil
+		}
+	}
+	return s, xerrors.Errorf("the signer %s (%s) is not allowed", subject, issuer)
+}
@@ -0,0 +1,81 @@
+package provenance
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		filePath     string
+		wantSubjects []string
+		wantKeys     []string
+		wantErr      string
+	}{
+		{
+			name:     "happy path",
+			filePath: "testdata/policy.yaml",
+			wantSubjects: []string{
+				"https://github.com/acme/app/.github/workflows/release.yml@refs/tags/v1.0.0",
+				"release@acme.example.com",
+			},
+			wantKeys: []string{"cosign.pub"},
+		},
+		{
+			name:     "no subject",
+			filePath: "testdata/no-subject.yaml",
+			wantErr:  "identities[0]: either subject or subjectRegExp must be specified",
+		},
+		{
+			name:     "invalid regexp",
+			filePath: "testdata/invalid-regexp.yaml",
+			wantErr:  "identities[0]: invalid subjectRegExp",
+		},
+		{
+			name:     "identities without roots",
+			filePath: "testdata/no-roots.yaml",
+			wantErr:  "roots and rekorKey are required to trust identities",
+		},
+		{
+			name:     "missing key",
+			filePath: "testdata/missing-key.yaml",
+			wantErr:  "keys[0]: unable to read the key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadPolicy(tt.filePath)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, got.roots)
+			assert.NotNil(t, got.rekorKey)
+			require.Len(t, got.Identities, len(tt.wantSubjects))
+			for i, subject := range tt.wantSubjects {
+				assert.True(t, got.Identities[i].match(got.Identities[i].Issuer, subject), subject)
+			}
+			require.Len(t, got.keys, len(tt.wantKeys))
+			for i, key := range tt.wantKeys {
+				assert.Equal(t, key, got.keys[i].path)
+				assert.NotNil(t, got.keys[i].pub)
+			}
+		})
+	}
+}
+
+func TestIdentity(t *testing.T) {
+	b, err := os.ReadFile("testdata/certificate.pem")
+	require.NoError(t, err)
+	cert, err := parseCertificate(b)
+	require.NoError(t, err)
+
+	issuer, subject := identity(cert)
+	assert.Equal(t, "https://accounts.google.com", issuer)
+	assert.Equal(t, "sasoakira6114@gmail.com", subject)
+}
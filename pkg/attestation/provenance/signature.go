@@ -0,0 +1,162 @@
+package provenance
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"golang.org/x/xerrors"
+
+	attest "github.com/zhanglimao/trivy/pkg/attestation"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/signature"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+const (
+	cosignSignatureArtifactType = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	cosignSignatureAnnotation   = "dev.cosignproject.cosign/signature"
+
+	signatureControlTitle = "Image is signed by a trusted signer"
+)
+
+// cosignSignature is a signature of the image which cosign pushes
+type cosignSignature struct {
+	// payload holds the simple signing payload referring to the image digest
+	payload   []byte
+	signature []byte
+
+	// certificate holds the PEM-encoded signing certificate, and is empty for signatures with keys
+	certificate []byte
+
+	// bundle is the entry in Rekor, which proves the time of signing
+	bundle *rekorBundle
+}
+
+// VerifySignature verifies that any of the digests is signed by cosign with an allowed identity or key.
+// The digests are the ones of the scanned image, e.g. the repo digests recorded by the daemon.
+// The control fails without digests, e.g. for image archives, as signatures are stored in registries.
+func VerifySignature(ctx context.Context, digests []name.Digest, policy Policy,
+	option ftypes.RegistryOptions) (types.SupplyChainControl, error) {
+	control := types.SupplyChainControl{
+		ID:      types.SupplyChainControlImageSignature,
+		Title:   signatureControlTitle,
+		Status:  types.StatusFailure,
+		Message: "no cosign signature found",
+	}
+	if len(digests) == 0 {
+		control.Message = "no digest of the image in a registry found"
+		return control, nil
+	}
+
+	for _, digest := range digests {
+		signatures, err := ociSignatures(ctx, digest, option)
+		if err != nil {
+			return types.SupplyChainControl{}, xerrors.Errorf("signature error (%s): %w", digest, err)
+		}
+		log.Logger.Debugf("Found %d signatures of %s", len(signatures), digest)
+
+		for _, sig := range signatures {
+			signer, err := policy.verifySignature(sig, digest.DigestStr())
+			if err != nil {
+				// Keep the reason of the last invalid signature
+				control.Signer, control.Message = signer, err.Error()
+				continue
+			}
+			control.Status, control.Signer, control.Message = types.StatusPassed, signer, ""
+			return control, nil
+		}
+	}
+	return control, nil
+}
+
+// verifySignature verifies the signature of the image digest, e.g. "sha256:...", and returns the signer
+func (p Policy) verifySignature(sig cosignSignature, digest string) (string, error) {
+	s, err := p.checkSigner(sig.certificate, func(pub crypto.PublicKey) error {
+		return signature.VerifyWithKey(pub, sig.payload, sig.signature)
+	}, func() (time.Time, error) {
+		return p.verifyBundle(sig.bundle, sig.certificate, sig.payload)
+	})
+	signer := s.subject
+	if s.issuer != "" {
+		signer += " (" + s.issuer + ")"
+	}
+	if err != nil {
+		return signer, err
+	}
+
+	if err = attest.VerifyPayloadDigest(sig.payload, digest); err != nil {
+		return signer, err
+	}
+	return signer, nil
+}
+
+// ociSignatures returns the signatures attached to the image as OCI referrers,
+// and in the ".sig" tag which cosign pushes signatures to by default.
+func ociSignatures(ctx context.Context, digest name.Digest, option ftypes.RegistryOptions) ([]cosignSignature, error) {
+	images, err := ociArtifacts(ctx, digest, cosignSignatureArtifactType, ".sig", option)
+	if err != nil {
+		return nil, err
+	}
+
+	var signatures []cosignSignature
+	for _, img := range images {
+		sigs, err := imageSignatures(img.image)
+		if err != nil {
+			return nil, xerrors.Errorf("%s: %w", img.ref, err)
+		}
+		signatures = append(signatures, sigs...)
+	}
+	return signatures, nil
+}
+
+// imageSignatures returns the signed payloads in the layers with the signatures and the certificates in the annotations
+func imageSignatures(img v1.Image) ([]cosignSignature, error) {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, xerrors.Errorf("OCI manifest error: %w", err)
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, xerrors.Errorf("OCI layer error: %w", err)
+	}
+
+	var signatures []cosignSignature
+	for i, layer := range layers {
+		annotations := manifest.Layers[i].Annotations
+		b64Sig, ok := annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(b64Sig))
+		if err != nil {
+			log.Logger.Debugf("Invalid signature in %s: %s", manifest.Layers[i].Digest, err)
+			continue
+		}
+
+		// The payload is signed as it is stored
+		rc, err := layer.Compressed()
+		if err != nil {
+			return nil, xerrors.Errorf("OCI layer error: %w", err)
+		}
+		payload, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, xerrors.Errorf("read error: %w", err)
+		}
+
+		signatures = append(signatures, cosignSignature{
+			payload:     payload,
+			signature:   sig,
+			certificate: []byte(annotations[certificateAnnotation]),
+			bundle:      parseBundle(annotations),
+		})
+	}
+	return signatures, nil
+}
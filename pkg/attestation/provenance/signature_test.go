@@ -0,0 +1,116 @@
+package provenance
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const otherDigest = "sha256:3f0e5d1a2b9f8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d"
+
+func (s testSigner) signImage(t *testing.T, digest string) cosignSignature {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"ghcr.io/acme/app"},`+
+		`"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, digest))
+	h := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, s.key, h[:])
+	require.NoError(t, err)
+	return cosignSignature{
+		payload:   payload,
+		signature: sig,
+	}
+}
+
+func TestPolicy_verifySignature(t *testing.T) {
+	signer := newTestSigner(t, testSubject)
+	otherSigner := newTestSigner(t, "https://github.com/evil/app/.github/workflows/release.yml@refs/heads/main")
+	rekor := newTestRekor(t)
+
+	policy := Policy{
+		Identities: []Identity{
+			{
+				Issuer:        testIssuer,
+				SubjectRegExp: "^https://github.com/acme/app/",
+				subjectRegExp: regexp.MustCompile("^https://github.com/acme/app/"),
+			},
+		},
+		Keys:     []string{"cosign.pub"},
+		keys:     []publicKey{signer.publicKey(t, "cosign.pub")},
+		roots:    signer.rootPool,
+		rekorKey: &rekor.key.PublicKey,
+	}
+
+	withCertificate := func(sig cosignSignature, s testSigner) cosignSignature {
+		sig.certificate = s.certPEM
+		sig.bundle = rekor.bundle(t, "hashedrekord", s.certPEM, sig.payload, time.Now())
+		return sig
+	}
+	noBundle := withCertificate(signer.signImage(t, testDigest), signer)
+	noBundle.bundle = nil
+	tampered := signer.signImage(t, testDigest)
+	tampered.signature = otherSigner.signImage(t, testDigest).signature
+
+	tests := []struct {
+		name       string
+		sig        cosignSignature
+		wantSigner string
+		wantErr    string
+	}{
+		{
+			name:       "keyless",
+			sig:        withCertificate(signer.signImage(t, testDigest), signer),
+			wantSigner: testSubject + " (" + testIssuer + ")",
+		},
+		{
+			name:       "key",
+			sig:        signer.signImage(t, testDigest),
+			wantSigner: "cosign.pub",
+		},
+		{
+			name:       "untrusted root",
+			sig:        withCertificate(otherSigner.signImage(t, testDigest), otherSigner),
+			wantSigner: "https://github.com/evil/app/.github/workflows/release.yml@refs/heads/main (" + testIssuer + ")",
+			wantErr:    "the signing certificate is not issued by the trusted roots",
+		},
+		{
+			name:       "no transparency log entry",
+			sig:        noBundle,
+			wantSigner: testSubject + " (" + testIssuer + ")",
+			wantErr:    "no transparency log entry found",
+		},
+		{
+			name:    "key not allowed",
+			sig:     otherSigner.signImage(t, testDigest),
+			wantErr: "no valid signature by the allowed keys found",
+		},
+		{
+			name:       "tampered signature",
+			sig:        withCertificate(tampered, signer),
+			wantSigner: testSubject + " (" + testIssuer + ")",
+			wantErr:    "no valid signature found",
+		},
+		{
+			name:       "another image",
+			sig:        signer.signImage(t, otherDigest),
+			wantSigner: "cosign.pub",
+			wantErr:    "the signature is for " + otherDigest + ", not " + testDigest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := policy.verifySignature(tt.sig, testDigest)
+			assert.Equal(t, tt.wantSigner, got)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
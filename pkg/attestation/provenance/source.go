@@ -0,0 +1,189 @@
+package provenance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/samber/lo"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/rekor"
+	"github.com/zhanglimao/trivy/pkg/remote"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+const (
+	dsseArtifactType      = "application/vnd.dsse.envelope.v1+json"
+	certificateAnnotation = "dev.sigstore.cosign/certificate"
+	bundleAnnotation      = "dev.sigstore.cosign/bundle"
+)
+
+// ociAttestations returns the attestations attached to the image as OCI referrers,
+// and in the ".att" tag which cosign pushes attestations to by default.
+func ociAttestations(ctx context.Context, digest name.Digest, option ftypes.RegistryOptions) ([]attestation, error) {
+	images, err := ociArtifacts(ctx, digest, dsseArtifactType, ".att", option)
+	if err != nil {
+		return nil, err
+	}
+
+	var attestations []attestation
+	for _, img := range images {
+		atts, err := imageAttestations(img.image)
+		if err != nil {
+			return nil, xerrors.Errorf("%s: %w", img.ref, err)
+		}
+		attestations = append(attestations, atts...)
+	}
+	return attestations, nil
+}
+
+type ociArtifact struct {
+	ref   name.Reference
+	image v1.Image
+}
+
+// ociArtifacts returns the artifacts of the type attached to the image as OCI referrers,
+// and in the tag with the suffix which cosign pushes them to, e.g. "sha256-<hex>.sig".
+func ociArtifacts(ctx context.Context, digest name.Digest, artifactType, tagSuffix string,
+	option ftypes.RegistryOptions) ([]ociArtifact, error) {
+	// The subject is the manifest specified by the digest, not the one of the platform
+	option.Platform = ftypes.Platform{}
+
+	index, err := remote.Referrers(ctx, digest, option)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to fetch referrers: %w", err)
+	}
+
+	var artifacts []ociArtifact
+	for _, m := range lo.FromPtr(index).Manifests {
+		if m.ArtifactType != artifactType {
+			continue
+		}
+		ref := digest.Context().Digest(m.Digest.String())
+		img, err := remote.Image(ctx, ref, option)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to get the referrer %s: %w", m.Digest, err)
+		}
+		artifacts = append(artifacts, ociArtifact{
+			ref:   ref,
+			image: img,
+		})
+	}
+
+	tag := digest.Context().Tag(strings.Replace(digest.DigestStr(), ":", "-", 1) + tagSuffix)
+	img, err := remote.Image(ctx, tag, option)
+	var terr *transport.Error
+	if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+		return artifacts, nil
+	} else if err != nil {
+		return nil, xerrors.Errorf("unable to get %s: %w", tag, err)
+	}
+	return append(artifacts, ociArtifact{
+		ref:   tag,
+		image: img,
+	}), nil
+}
+
+// imageAttestations returns the DSSE envelopes in the layers with the signing certificates in the annotations
+func imageAttestations(img v1.Image) ([]attestation, error) {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, xerrors.Errorf("OCI manifest error: %w", err)
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, xerrors.Errorf("OCI layer error: %w", err)
+	}
+
+	var attestations []attestation
+	for i, layer := range layers {
+		if manifest.Layers[i].MediaType != dsseArtifactType {
+			continue
+		}
+		rc, err := layer.Uncompressed()
+		if err != nil {
+			return nil, xerrors.Errorf("OCI layer error: %w", err)
+		}
+		b, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, xerrors.Errorf("read error: %w", err)
+		}
+
+		var envelope dsse.Envelope
+		if err = json.Unmarshal(b, &envelope); err != nil {
+			return nil, xerrors.Errorf("failed to decode as a dsse envelope: %w", err)
+		}
+		attestations = append(attestations, attestation{
+			source:    types.ProvenanceSourceOCI,
+			envelope:  &envelope,
+			publicKey: []byte(manifest.Layers[i].Annotations[certificateAnnotation]),
+			bundle:    parseBundle(manifest.Layers[i].Annotations),
+		})
+	}
+	return attestations, nil
+}
+
+// parseBundle returns the Rekor entry in the annotations, or nil if not found
+func parseBundle(annotations map[string]string) *rekorBundle {
+	s, ok := annotations[bundleAnnotation]
+	if !ok {
+		return nil
+	}
+	var bundle rekorBundle
+	if err := json.Unmarshal([]byte(s), &bundle); err != nil {
+		log.Logger.Debugf("Invalid Rekor bundle: %s", err)
+		return nil
+	}
+	return &bundle
+}
+
+// rekorAttestations returns the attestations of the digest in the transparency log.
+// Rekor verifies the signatures on upload, so the statements and the certificates are returned with the entries,
+// which must be signed by Rekor to be trusted.
+func rekorAttestations(ctx context.Context, digest, rekorURL string) ([]attestation, error) {
+	client, err := rekor.NewClient(rekorURL)
+	if err != nil {
+		return nil, xerrors.Errorf("rekor client error: %w", err)
+	}
+
+	entryIDs, err := client.Search(ctx, digest)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to search rekor records: %w", err)
+	}
+
+	var attestations []attestation
+	for _, ids := range lo.Chunk[rekor.EntryID](entryIDs, rekor.MaxGetEntriesLimit) {
+		entries, err := client.GetEntries(ctx, ids)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to get entries: %w", err)
+		}
+		for _, entry := range entries {
+			attestations = append(attestations, attestation{
+				source:    types.ProvenanceSourceRekor,
+				statement: entry.Statement,
+				publicKey: entry.PublicKey,
+				bundle: &rekorBundle{
+					SignedEntryTimestamp: entry.SignedEntryTimestamp,
+					Payload: rekorPayload{
+						Body:           entry.Body,
+						IntegratedTime: entry.IntegratedTime,
+						LogID:          entry.LogID,
+						LogIndex:       entry.LogIndex,
+					},
+				},
+			})
+		}
+	}
+	return attestations, nil
+}
@@ -0,0 +1,116 @@
+package provenance
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/signature"
+)
+
+// rekorBundle is the entry of the signature in Rekor with the signed entry timestamp (SET),
+// as cosign attaches to signatures and attestations in the "dev.sigstore.cosign/bundle" annotation
+type rekorBundle struct {
+	SignedEntryTimestamp []byte
+	Payload              rekorPayload
+}
+
+// rekorPayload is the part of the entry which Rekor signs in the SET.
+// The fields are in the order of the canonical JSON, and the values have no characters to be escaped,
+// so json.Marshal produces the signed bytes.
+type rekorPayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+// entryBody is the body of hashedrekord and intoto entries
+type entryBody struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		// hashedrekord
+		Signature struct {
+			PublicKey struct {
+				Content []byte `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+		Data struct {
+			Hash entryHash `json:"hash"`
+		} `json:"data"`
+
+		// intoto, where the public key is in the spec in v0.0.1 and in the signatures of the envelope in v0.0.2
+		PublicKey []byte `json:"publicKey"`
+		Content   struct {
+			PayloadHash entryHash `json:"payloadHash"`
+			Envelope    struct {
+				Signatures []struct {
+					PublicKey []byte `json:"publicKey"`
+				} `json:"signatures"`
+			} `json:"envelope"`
+		} `json:"content"`
+	} `json:"spec"`
+}
+
+type entryHash struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"`
+}
+
+// verifyBundle verifies that the entry is signed by Rekor and records the payload signed by the signer,
+// and returns the time when the entry was integrated into the log, i.e. the time of signing.
+func (p Policy) verifyBundle(bundle *rekorBundle, signerPEM, payload []byte) (time.Time, error) {
+	if bundle == nil {
+		return time.Time{}, xerrors.New("no transparency log entry found")
+	} else if p.rekorKey == nil {
+		return time.Time{}, xerrors.New("transparency log entries are not trusted without rekorKey in the policy")
+	}
+
+	b, err := json.Marshal(bundle.Payload)
+	if err != nil {
+		return time.Time{}, xerrors.Errorf("json encode error: %w", err)
+	}
+	if err = signature.VerifyWithKey(p.rekorKey, b, bundle.SignedEntryTimestamp); err != nil {
+		return time.Time{}, xerrors.New("the transparency log entry is not signed by the trusted Rekor")
+	}
+
+	var body entryBody
+	if b, err = base64.StdEncoding.DecodeString(bundle.Payload.Body); err != nil {
+		return time.Time{}, xerrors.New("invalid transparency log entry")
+	} else if err = json.Unmarshal(b, &body); err != nil {
+		return time.Time{}, xerrors.New("invalid transparency log entry")
+	}
+
+	var hash entryHash
+	var signers [][]byte
+	switch body.Kind {
+	case "hashedrekord":
+		hash = body.Spec.Data.Hash
+		signers = append(signers, body.Spec.Signature.PublicKey.Content)
+	case "intoto":
+		hash = body.Spec.Content.PayloadHash
+		signers = append(signers, body.Spec.PublicKey)
+		for _, sig := range body.Spec.Content.Envelope.Signatures {
+			signers = append(signers, sig.PublicKey)
+		}
+	default:
+		return time.Time{}, xerrors.Errorf("unsupported transparency log entry: %s", body.Kind)
+	}
+
+	digest := sha256.Sum256(payload)
+	if hash.Algorithm != "sha256" || hash.Value != hex.EncodeToString(digest[:]) {
+		return time.Time{}, xerrors.New("the transparency log entry is not for the signed payload")
+	}
+	if !slices.ContainsFunc(signers, func(s []byte) bool {
+		return len(s) != 0 && bytes.Equal(bytes.TrimSpace(s), bytes.TrimSpace(signerPEM))
+	}) {
+		return time.Time{}, xerrors.New("the transparency log entry is not for the signer")
+	}
+	return time.Unix(bundle.Payload.IntegratedTime, 0), nil
+}
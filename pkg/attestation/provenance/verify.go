@@ -0,0 +1,200 @@
+package provenance
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/signature"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// predicateTypes are the SLSA provenance versions to be verified
+var predicateTypes = []string{
+	"https://slsa.dev/provenance/v0.2",
+	"https://slsa.dev/provenance/v1",
+}
+
+// attestation is an attestation of the image found in the source
+type attestation struct {
+	source string
+
+	// envelope is nil if the source has verified the signature, i.e. Rekor, and statement is set instead
+	envelope  *dsse.Envelope
+	statement []byte
+
+	// publicKey holds the PEM-encoded signing certificate or public key, and is empty for signatures with keys in OCI
+	publicKey []byte
+
+	// bundle is the entry in Rekor, which proves the time of signing
+	bundle *rekorBundle
+}
+
+// predicate holds the builder ID of SLSA provenance v0.2 and v1
+type predicate struct {
+	Builder struct {
+		ID string `json:"id"`
+	} `json:"builder"`
+	RunDetails struct {
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+	} `json:"runDetails"`
+}
+
+type Option struct {
+	Sources         []string
+	RekorURL        string
+	RegistryOptions ftypes.RegistryOptions
+}
+
+// Verify verifies the provenance attestations of the image found in the sources against the policy.
+// A failure is returned if no provenance attestation is found.
+func Verify(ctx context.Context, digest name.Digest, policy Policy, opt Option) ([]types.ProvenanceVerification, error) {
+	var results []types.ProvenanceVerification
+	for _, source := range opt.Sources {
+		var attestations []attestation
+		var err error
+		switch source {
+		case types.ProvenanceSourceOCI:
+			attestations, err = ociAttestations(ctx, digest, opt.RegistryOptions)
+		case types.ProvenanceSourceRekor:
+			attestations, err = rekorAttestations(ctx, digest.DigestStr(), opt.RekorURL)
+		default:
+			return nil, xerrors.Errorf("unknown attestation source: %s", source)
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("%s error: %w", source, err)
+		}
+		log.Logger.Debugf("Found %d attestations in %s", len(attestations), source)
+
+		for _, att := range attestations {
+			if result := policy.verify(att, digest.DigestStr()); result != nil {
+				results = append(results, *result)
+			}
+		}
+	}
+
+	if len(results) == 0 {
+		return []types.ProvenanceVerification{
+			{
+				Status:  types.ProvenanceFailed,
+				Message: fmt.Sprintf("no provenance attestation found in %s", strings.Join(opt.Sources, ", ")),
+			},
+		}, nil
+	}
+	return results, nil
+}
+
+// verify verifies the attestation of the image digest, e.g. "sha256:...".
+// It returns nil if the attestation is not provenance, such as SBOM attestations.
+func (p Policy) verify(att attestation, digest string) *types.ProvenanceVerification {
+	payload := att.statement
+	if att.envelope != nil {
+		var err error
+		if payload, err = att.envelope.DecodeB64Payload(); err != nil {
+			log.Logger.Debugf("Invalid DSSE payload in %s: %s", att.source, err)
+			return nil
+		}
+	} else if bytes.HasPrefix(payload, []byte(`eyJ`)) {
+		// base64-encoded attestation
+		decoded, err := base64.StdEncoding.DecodeString(string(payload))
+		if err != nil {
+			log.Logger.Debugf("Invalid attestation in %s: %s", att.source, err)
+			return nil
+		}
+		payload = decoded
+	}
+
+	var pred predicate
+	statement := in_toto.Statement{
+		Predicate: &pred,
+	}
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		log.Logger.Debugf("Invalid in-toto statement in %s: %s", att.source, err)
+		return nil
+	} else if !slices.Contains(predicateTypes, statement.PredicateType) {
+		return nil
+	}
+
+	result := &types.ProvenanceVerification{
+		Status:        types.ProvenanceFailed,
+		Source:        att.source,
+		PredicateType: statement.PredicateType,
+		BuilderID:     pred.Builder.ID,
+	}
+	if result.BuilderID == "" {
+		result.BuilderID = pred.RunDetails.Builder.ID
+	}
+
+	if !matchSubject(statement.Subject, digest) {
+		result.Message = fmt.Sprintf("the subject doesn't include %s", digest)
+		return result
+	}
+
+	var verifySignature func(crypto.PublicKey) error
+	if att.envelope != nil {
+		verifySignature = func(pub crypto.PublicKey) error {
+			return verifyEnvelope(att.envelope, pub)
+		}
+	}
+	signer, err := p.checkSigner(att.publicKey, verifySignature, func() (time.Time, error) {
+		return p.verifyBundle(att.bundle, att.publicKey, payload)
+	})
+	result.Issuer, result.Subject = signer.issuer, signer.subject
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	if len(p.Builders) > 0 && !slices.Contains(p.Builders, result.BuilderID) {
+		result.Message = fmt.Sprintf("the builder %q is not allowed", result.BuilderID)
+		return result
+	}
+
+	result.Status = types.ProvenancePassed
+	return result
+}
+
+// matchSubject returns whether the subjects of the statement include the digest, e.g. "sha256:..."
+func matchSubject(subjects []in_toto.Subject, digest string) bool {
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok {
+		return false
+	}
+	return slices.ContainsFunc(subjects, func(s in_toto.Subject) bool {
+		return s.Digest[algorithm] == hex
+	})
+}
+
+// verifyEnvelope verifies that any signature of the envelope is made by the key
+func verifyEnvelope(envelope *dsse.Envelope, pub crypto.PublicKey) error {
+	payload, err := envelope.DecodeB64Payload()
+	if err != nil {
+		return xerrors.Errorf("payload decode error: %w", err)
+	}
+	pae := dsse.PAE(envelope.PayloadType, payload)
+	for _, s := range envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			continue
+		}
+		if err = signature.VerifyWithKey(pub, pae, sig); err == nil {
+			return nil
+		}
+	}
+	return xerrors.New("no valid signature found")
+}
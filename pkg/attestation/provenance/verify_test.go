@@ -0,0 +1,521 @@
+package provenance
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+const (
+	testDigest   = "sha256:20d3f693dcffa44d6b24eae88783324d25cc132c22089f70e4fbfb858625b062"
+	testIssuer   = "https://token.actions.githubusercontent.com"
+	testSubject  = "https://github.com/acme/app/.github/workflows/release.yml@refs/tags/v1.0.0"
+	testBuilder  = "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v1.9.0"
+	slsaV02      = "https://slsa.dev/provenance/v0.2"
+	otherBuilder = "https://example.com/builder"
+)
+
+type testSigner struct {
+	key      *ecdsa.PrivateKey
+	cert     *x509.Certificate
+	certPEM  []byte
+	rootPool *x509.CertPool
+}
+
+func newTestSigner(t *testing.T, subject string) testSigner {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	issuer, err := asn1.Marshal(testIssuer)
+	require.NoError(t, err)
+	u, err := url.Parse(subject)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(10 * time.Minute),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:         []*url.URL{u},
+		ExtraExtensions: []pkix.Extension{
+			{
+				Id:    oidIssuerV2,
+				Value: issuer,
+			},
+		},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	return testSigner{
+		key:      key,
+		cert:     cert,
+		certPEM:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		rootPool: pool,
+	}
+}
+
+// testRekor signs entries in the transparency log
+type testRekor struct {
+	key *ecdsa.PrivateKey
+}
+
+func newTestRekor(t *testing.T) testRekor {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return testRekor{key: key}
+}
+
+// bundle returns the entry of the payload signed by the signer, integrated into the log at the time
+func (r testRekor) bundle(t *testing.T, kind string, signerPEM, payload []byte, integratedTime time.Time) *rekorBundle {
+	digest := sha256.Sum256(payload)
+	hash := map[string]string{
+		"algorithm": "sha256",
+		"value":     hex.EncodeToString(digest[:]),
+	}
+
+	var spec map[string]any
+	switch kind {
+	case "hashedrekord":
+		spec = map[string]any{
+			"data":      map[string]any{"hash": hash},
+			"signature": map[string]any{"publicKey": map[string]any{"content": signerPEM}},
+		}
+	case "intoto":
+		spec = map[string]any{
+			"content":   map[string]any{"payloadHash": hash},
+			"publicKey": signerPEM,
+		}
+	}
+	body, err := json.Marshal(map[string]any{
+		"apiVersion": "0.0.1",
+		"kind":       kind,
+		"spec":       spec,
+	})
+	require.NoError(t, err)
+
+	payload, err = json.Marshal(rekorPayload{
+		Body:           base64.StdEncoding.EncodeToString(body),
+		IntegratedTime: integratedTime.Unix(),
+		LogID:          "c0d23d6ad406973f9559f3ba2d1ca01f84147d8ffc5b8445c224f98b9591801d",
+		LogIndex:       1,
+	})
+	require.NoError(t, err)
+	bundle := &rekorBundle{}
+	require.NoError(t, json.Unmarshal(payload, &bundle.Payload))
+
+	h := sha256.Sum256(payload)
+	bundle.SignedEntryTimestamp, err = ecdsa.SignASN1(rand.Reader, r.key, h[:])
+	require.NoError(t, err)
+	return bundle
+}
+
+func (s testSigner) sign(t *testing.T, digest, predicateType, builderID string) *dsse.Envelope {
+	statement := in_toto.Statement{
+		StatementHeader: in_toto.StatementHeader{
+			Type:          in_toto.StatementInTotoV01,
+			PredicateType: predicateType,
+			Subject: []in_toto.Subject{
+				{
+					Name:   "ghcr.io/acme/app",
+					Digest: map[string]string{"sha256": digest[len("sha256:"):]},
+				},
+			},
+		},
+		Predicate: map[string]any{
+			"builder": map[string]any{"id": builderID},
+		},
+	}
+	payload, err := json.Marshal(statement)
+	require.NoError(t, err)
+
+	h := sha256.Sum256(dsse.PAE(in_toto.PayloadType, payload))
+	sig, err := ecdsa.SignASN1(rand.Reader, s.key, h[:])
+	require.NoError(t, err)
+	return &dsse.Envelope{
+		PayloadType: in_toto.PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures: []dsse.Signature{
+			{Sig: base64.StdEncoding.EncodeToString(sig)},
+		},
+	}
+}
+
+func (s testSigner) publicKeyPEM(t *testing.T) []byte {
+	der, err := x509.MarshalPKIXPublicKey(&s.key.PublicKey)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func (s testSigner) publicKey(t *testing.T, path string) publicKey {
+	der, err := x509.MarshalPKIXPublicKey(&s.key.PublicKey)
+	require.NoError(t, err)
+	return publicKey{
+		path: path,
+		der:  der,
+		pub:  &s.key.PublicKey,
+	}
+}
+
+func TestPolicy_verify(t *testing.T) {
+	signer := newTestSigner(t, testSubject)
+	otherSigner := newTestSigner(t, testSubject)
+	rekor := newTestRekor(t)
+	otherRekor := newTestRekor(t)
+
+	policy := Policy{
+		Identities: []Identity{
+			{
+				Issuer:        testIssuer,
+				SubjectRegExp: "^https://github.com/acme/app/",
+				subjectRegExp: regexp.MustCompile("^https://github.com/acme/app/"),
+			},
+		},
+		Builders: []string{testBuilder},
+		roots:    signer.rootPool,
+		rekorKey: &rekor.key.PublicKey,
+	}
+
+	keyPolicy := Policy{
+		Keys:     []string{"cosign.pub"},
+		keys:     []publicKey{signer.publicKey(t, "cosign.pub")},
+		rekorKey: &rekor.key.PublicKey,
+	}
+
+	// signed returns the attestation in OCI with the entry in Rekor integrated at the time
+	signed := func(s testSigner, envelope *dsse.Envelope, r testRekor, integratedTime time.Time) attestation {
+		return attestation{
+			source:    types.ProvenanceSourceOCI,
+			envelope:  envelope,
+			publicKey: s.certPEM,
+			bundle:    r.bundle(t, "intoto", s.certPEM, decodePayload(t, envelope), integratedTime),
+		}
+	}
+	// logged returns the attestation in Rekor, which has verified the signature
+	logged := func(signerPEM []byte, envelope *dsse.Envelope, r testRekor) attestation {
+		payload := decodePayload(t, envelope)
+		return attestation{
+			source:    types.ProvenanceSourceRekor,
+			statement: payload,
+			publicKey: signerPEM,
+			bundle:    r.bundle(t, "intoto", signerPEM, payload, time.Now()),
+		}
+	}
+
+	tamperedEnvelope := signer.sign(t, testDigest, slsaV02, testBuilder)
+	tamperedEnvelope.Signatures = otherSigner.sign(t, testDigest, slsaV02, testBuilder).Signatures
+
+	anotherPayload := signed(signer, signer.sign(t, testDigest, slsaV02, testBuilder), rekor, time.Now())
+	anotherPayload.envelope = signer.sign(t, testDigest, slsaV02, otherBuilder)
+
+	noBundle := signed(signer, signer.sign(t, testDigest, slsaV02, testBuilder), rekor, time.Now())
+	noBundle.bundle = nil
+
+	tests := []struct {
+		name   string
+		policy Policy
+		att    attestation
+		want   *types.ProvenanceVerification
+	}{
+		{
+			name:   "happy path",
+			policy: policy,
+			att:    signed(signer, signer.sign(t, testDigest, slsaV02, testBuilder), rekor, time.Now()),
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenancePassed,
+				Source:        types.ProvenanceSourceOCI,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Issuer:        testIssuer,
+				Subject:       testSubject,
+			},
+		},
+		{
+			name:   "not provenance",
+			policy: policy,
+			att:    signed(signer, signer.sign(t, testDigest, in_toto.PredicateCycloneDX, testBuilder), rekor, time.Now()),
+			want:   nil,
+		},
+		{
+			name:   "another image",
+			policy: policy,
+			att: signed(signer, signer.sign(t, "sha256:0000000000000000000000000000000000000000000000000000000000000000", slsaV02, testBuilder),
+				rekor, time.Now()),
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenanceFailed,
+				Source:        types.ProvenanceSourceOCI,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Message:       "the subject doesn't include " + testDigest,
+			},
+		},
+		{
+			name:   "invalid signature",
+			policy: policy,
+			att:    signed(signer, tamperedEnvelope, rekor, time.Now()),
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenanceFailed,
+				Source:        types.ProvenanceSourceOCI,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Issuer:        testIssuer,
+				Subject:       testSubject,
+				Message:       "no valid signature found",
+			},
+		},
+		{
+			name:   "untrusted certificate",
+			policy: policy,
+			att:    signed(otherSigner, otherSigner.sign(t, testDigest, slsaV02, testBuilder), rekor, time.Now()),
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenanceFailed,
+				Source:        types.ProvenanceSourceOCI,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Issuer:        testIssuer,
+				Subject:       testSubject,
+				Message:       "the signing certificate is not issued by the trusted roots",
+			},
+		},
+		{
+			name:   "signed after the certificate expired",
+			policy: policy,
+			att:    signed(signer, signer.sign(t, testDigest, slsaV02, testBuilder), rekor, time.Now().Add(time.Hour)),
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenanceFailed,
+				Source:        types.ProvenanceSourceOCI,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Issuer:        testIssuer,
+				Subject:       testSubject,
+				Message:       "the signing certificate is not issued by the trusted roots",
+			},
+		},
+		{
+			name:   "no transparency log entry",
+			policy: policy,
+			att:    noBundle,
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenanceFailed,
+				Source:        types.ProvenanceSourceOCI,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Issuer:        testIssuer,
+				Subject:       testSubject,
+				Message:       "no transparency log entry found",
+			},
+		},
+		{
+			name:   "entry not signed by Rekor",
+			policy: policy,
+			att:    signed(signer, signer.sign(t, testDigest, slsaV02, testBuilder), otherRekor, time.Now()),
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenanceFailed,
+				Source:        types.ProvenanceSourceOCI,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Issuer:        testIssuer,
+				Subject:       testSubject,
+				Message:       "the transparency log entry is not signed by the trusted Rekor",
+			},
+		},
+		{
+			name:   "entry for another payload",
+			policy: policy,
+			att:    anotherPayload,
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenanceFailed,
+				Source:        types.ProvenanceSourceOCI,
+				PredicateType: slsaV02,
+				BuilderID:     otherBuilder,
+				Issuer:        testIssuer,
+				Subject:       testSubject,
+				Message:       "the transparency log entry is not for the signed payload",
+			},
+		},
+		{
+			name: "no roots",
+			policy: Policy{
+				Identities: policy.Identities,
+				rekorKey:   &rekor.key.PublicKey,
+			},
+			att: signed(signer, signer.sign(t, testDigest, slsaV02, testBuilder), rekor, time.Now()),
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenanceFailed,
+				Source:        types.ProvenanceSourceOCI,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Issuer:        testIssuer,
+				Subject:       testSubject,
+				Message:       "signing certificates are not trusted without roots in the policy",
+			},
+		},
+		{
+			name: "signer not allowed",
+			policy: Policy{
+				Identities: []Identity{
+					{
+						Issuer:  testIssuer,
+						Subject: "https://github.com/acme/other/.github/workflows/release.yml@refs/heads/main",
+					},
+				},
+				roots:    signer.rootPool,
+				rekorKey: &rekor.key.PublicKey,
+			},
+			att: signed(signer, signer.sign(t, testDigest, slsaV02, testBuilder), rekor, time.Now()),
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenanceFailed,
+				Source:        types.ProvenanceSourceOCI,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Issuer:        testIssuer,
+				Subject:       testSubject,
+				Message:       "the signer " + testSubject + " (" + testIssuer + ") is not allowed",
+			},
+		},
+		{
+			name:   "builder not allowed",
+			policy: policy,
+			att:    signed(signer, signer.sign(t, testDigest, slsaV02, otherBuilder), rekor, time.Now()),
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenanceFailed,
+				Source:        types.ProvenanceSourceOCI,
+				PredicateType: slsaV02,
+				BuilderID:     otherBuilder,
+				Issuer:        testIssuer,
+				Subject:       testSubject,
+				Message:       `the builder "https://example.com/builder" is not allowed`,
+			},
+		},
+		{
+			name:   "public key not allowed",
+			policy: policy,
+			att:    logged(otherSigner.publicKeyPEM(t), signer.sign(t, testDigest, slsaV02, testBuilder), rekor),
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenanceFailed,
+				Source:        types.ProvenanceSourceRekor,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Message:       "the public key is not allowed",
+			},
+		},
+		{
+			name:   "signed with an allowed key",
+			policy: keyPolicy,
+			att: attestation{
+				source:   types.ProvenanceSourceOCI,
+				envelope: signer.sign(t, testDigest, slsaV02, testBuilder),
+			},
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenancePassed,
+				Source:        types.ProvenanceSourceOCI,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Subject:       "cosign.pub",
+			},
+		},
+		{
+			name:   "signed with another key",
+			policy: keyPolicy,
+			att: attestation{
+				source:   types.ProvenanceSourceOCI,
+				envelope: otherSigner.sign(t, testDigest, slsaV02, testBuilder),
+			},
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenanceFailed,
+				Source:        types.ProvenanceSourceOCI,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Message:       "no valid signature by the allowed keys found",
+			},
+		},
+		{
+			name:   "verified by Rekor",
+			policy: policy,
+			att:    logged(signer.certPEM, signer.sign(t, testDigest, slsaV02, testBuilder), rekor),
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenancePassed,
+				Source:        types.ProvenanceSourceRekor,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Issuer:        testIssuer,
+				Subject:       testSubject,
+			},
+		},
+		{
+			name:   "key verified by Rekor",
+			policy: keyPolicy,
+			att:    logged(signer.publicKeyPEM(t), signer.sign(t, testDigest, slsaV02, testBuilder), rekor),
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenancePassed,
+				Source:        types.ProvenanceSourceRekor,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Subject:       "cosign.pub",
+			},
+		},
+		{
+			name:   "entry in another Rekor",
+			policy: policy,
+			att:    logged(signer.certPEM, signer.sign(t, testDigest, slsaV02, testBuilder), otherRekor),
+			want: &types.ProvenanceVerification{
+				Status:        types.ProvenanceFailed,
+				Source:        types.ProvenanceSourceRekor,
+				PredicateType: slsaV02,
+				BuilderID:     testBuilder,
+				Message:       "the transparency log entry is not signed by the trusted Rekor",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.verify(tt.att, testDigest)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func decodePayload(t *testing.T, envelope *dsse.Envelope) []byte {
+	payload, err := envelope.DecodeB64Payload()
+	require.NoError(t, err)
+	return payload
+}
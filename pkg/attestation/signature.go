@@ -0,0 +1,26 @@
+package attestation
+
+import (
+	"encoding/json"
+
+	"golang.org/x/xerrors"
+)
+
+// VerifyPayloadDigest checks that the simple signing payload signed by cosign is for the manifest digest.
+// cf. https://github.com/containers/image/blob/main/docs/containers-signature.5.md
+func VerifyPayloadDigest(payload []byte, digest string) error {
+	var simpleSigning struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+	if err := json.Unmarshal(payload, &simpleSigning); err != nil {
+		return xerrors.Errorf("signature payload decode error: %w", err)
+	}
+	if signed := simpleSigning.Critical.Image.DockerManifestDigest; signed != digest {
+		return xerrors.Errorf("the signature is for %s, not %s", signed, digest)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package attestation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zhanglimao/trivy/pkg/attestation"
+)
+
+func TestVerifyPayloadDigest(t *testing.T) {
+	const digest = "sha256:72c42ed48c3a2db31b7dafe17d275b634664a708d901ec9fd57b1529280f01fb"
+	tests := []struct {
+		name    string
+		payload string
+		wantErr string
+	}{
+		{
+			name:    "happy path",
+			payload: `{"critical":{"image":{"docker-manifest-digest":"` + digest + `"},"type":"cosign container image signature"}}`,
+		},
+		{
+			name:    "another image",
+			payload: `{"critical":{"image":{"docker-manifest-digest":"sha256:0123"}}}`,
+			wantErr: "the signature is for sha256:0123, not " + digest,
+		},
+		{
+			name:    "invalid payload",
+			payload: `{`,
+			wantErr: "signature payload decode error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := attestation.VerifyPayloadDigest([]byte(tt.payload), digest)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package baseimage
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/image"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/remote"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// Pre-defined annotation keys for the base image in the OCI image spec
+// cf. https://github.com/opencontainers/image-spec/blob/main/annotations.md#pre-defined-annotation-keys
+const (
+	annotationBaseName   = types.OCIImageAnnotationPrefix + "base.name"
+	annotationBaseDigest = types.OCIImageAnnotationPrefix + "base.digest"
+)
+
+type Option struct {
+	RegistryOptions ftypes.RegistryOptions
+	Offline         bool // don't look up the base image in the registry
+}
+
+// Detect guesses the base image of the scanned image.
+// The base layers are guessed from the image history first.
+// When the image declares its base image with the OCI annotations,
+// the base image is looked up in the registry and its diff IDs are compared with the scanned image.
+func Detect(ctx context.Context, metadata types.Metadata, opt Option) *types.BaseImage {
+	baseImage := &types.BaseImage{
+		DiffIDs: image.GuessBaseDiffIDs(metadata.DiffIDs, &metadata.ImageConfig),
+	}
+
+	annotations := metadata.OCIImageAnnotations()
+	baseImage.Name = annotations[annotationBaseName]
+	baseImage.Digest = annotations[annotationBaseDigest]
+
+	if baseImage.Name != "" && !opt.Offline {
+		diffIDs, err := lookup(ctx, baseImage.Name, baseImage.Digest, metadata.ImageConfig, opt.RegistryOptions)
+		switch {
+		case err != nil:
+			log.Logger.Debugf("Unable to look up the base image %s: %s", baseImage.Name, err)
+		case !hasPrefix(metadata.DiffIDs, diffIDs):
+			log.Logger.Warnf("The layers of the base image %s don't match the scanned image", baseImage.Name)
+		default:
+			baseImage.DiffIDs = diffIDs
+			baseImage.Verified = true
+		}
+	}
+
+	if baseImage.Name == "" && len(baseImage.DiffIDs) == 0 {
+		return nil
+	}
+	return baseImage
+}
+
+// lookup returns the diff IDs of the base image in the registry
+func lookup(ctx context.Context, baseName, digest string, config v1.ConfigFile, option ftypes.RegistryOptions) ([]string, error) {
+	ref, err := name.ParseReference(baseName)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to parse the base image name: %w", err)
+	}
+	if digest != "" {
+		if ref, err = name.NewDigest(ref.Context().Name() + "@" + digest); err != nil {
+			return nil, xerrors.Errorf("failed to parse the base image digest: %w", err)
+		}
+	}
+
+	// The base image can be multi-arch
+	if config.OS != "" && config.Architecture != "" {
+		option.Platform = ftypes.Platform{
+			Platform: &v1.Platform{
+				OS:           config.OS,
+				Architecture: config.Architecture,
+				Variant:      config.Variant,
+			},
+		}
+	}
+
+	desc, err := remote.Get(ctx, ref, option)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get the base image: %w", err)
+	}
+	img, err := desc.Image()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get the base image: %w", err)
+	}
+	return image.LayerIDs(img)
+}
+
+func hasPrefix(diffIDs, baseDiffIDs []string) bool {
+	if len(baseDiffIDs) == 0 || len(baseDiffIDs) > len(diffIDs) {
+		return false
+	}
+	return slices.Equal(diffIDs[:len(baseDiffIDs)], baseDiffIDs)
+}
+
+// Annotate marks the layers of findings and packages originating in the base image
+func Annotate(results types.Results, baseImage *types.BaseImage) {
+	if baseImage == nil || len(baseImage.DiffIDs) == 0 {
+		return
+	}
+
+	mark := func(layer *ftypes.Layer) {
+		if layer.DiffID != "" && slices.Contains(baseImage.DiffIDs, layer.DiffID) {
+			layer.BaseLayer = true
+		}
+	}
+
+	for i := range results {
+		r := &results[i]
+		for j := range r.Packages {
+			mark(&r.Packages[j].Layer)
+		}
+		for j := range r.Vulnerabilities {
+			mark(&r.Vulnerabilities[j].Layer)
+			for k := range r.Vulnerabilities[j].Locations {
+				mark(&r.Vulnerabilities[j].Locations[k].Layer)
+			}
+		}
+		for j := range r.Misconfigurations {
+			mark(&r.Misconfigurations[j].Layer)
+		}
+		for j := range r.Secrets {
+			mark(&r.Secrets[j].Layer)
+		}
+		for j := range r.Licenses {
+			mark(&r.Licenses[j].Layer)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package baseimage_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/baseimage"
+	fimage "github.com/zhanglimao/trivy/pkg/fanal/image"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func TestDetect(t *testing.T) {
+	ts := httptest.NewServer(registry.New())
+	defer ts.Close()
+	host := strings.TrimPrefix(ts.URL, "http://")
+
+	// Push the base image with 2 layers
+	baseImg, err := random.Image(100, 2)
+	require.NoError(t, err)
+	baseRef, err := name.ParseReference(host + "/library/base:1.0")
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(baseRef, baseImg))
+	baseDigest, err := baseImg.Digest()
+	require.NoError(t, err)
+	baseDiffIDs, err := fimage.LayerIDs(baseImg)
+	require.NoError(t, err)
+
+	// The application image adds a layer on top of the base image
+	appLayer, err := random.Layer(100, "")
+	require.NoError(t, err)
+	appImg, err := mutate.AppendLayers(baseImg, appLayer)
+	require.NoError(t, err)
+	appDiffIDs, err := fimage.LayerIDs(appImg)
+	require.NoError(t, err)
+
+	// An unrelated image
+	otherImg, err := random.Image(100, 3)
+	require.NoError(t, err)
+	otherDiffIDs, err := fimage.LayerIDs(otherImg)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		metadata types.Metadata
+		offline  bool
+		want     *types.BaseImage
+	}{
+		{
+			name: "verified with the registry",
+			metadata: types.Metadata{
+				DiffIDs: appDiffIDs,
+				ImageAnnotations: map[string]string{
+					"org.opencontainers.image.base.name":   baseRef.Name(),
+					"org.opencontainers.image.base.digest": baseDigest.String(),
+				},
+			},
+			want: &types.BaseImage{
+				Name:     baseRef.Name(),
+				Digest:   baseDigest.String(),
+				DiffIDs:  baseDiffIDs,
+				Verified: true,
+			},
+		},
+		{
+			name: "layers don't match",
+			metadata: types.Metadata{
+				DiffIDs: otherDiffIDs,
+				ImageConfig: v1.ConfigFile{
+					Config: v1.Config{
+						Labels: map[string]string{
+							"org.opencontainers.image.base.name": baseRef.Name(),
+						},
+					},
+				},
+			},
+			want: &types.BaseImage{
+				Name: baseRef.Name(),
+			},
+		},
+		{
+			name: "offline",
+			metadata: types.Metadata{
+				DiffIDs: appDiffIDs,
+				ImageAnnotations: map[string]string{
+					"org.opencontainers.image.base.name": baseRef.Name(),
+				},
+			},
+			offline: true,
+			want: &types.BaseImage{
+				Name: baseRef.Name(),
+			},
+		},
+		{
+			name: "guessed from history",
+			metadata: types.Metadata{
+				DiffIDs: appDiffIDs,
+				ImageConfig: v1.ConfigFile{
+					History: []v1.History{
+						{
+							CreatedBy: "/bin/sh -c #(nop) ADD file:e4d600fc4c9c293efe360be7b30ee96579925d1b4634c94332e2ec73f7d8eca1 in / ",
+						},
+						{
+							CreatedBy: "/bin/sh -c apk add --no-cache curl",
+						},
+						{
+							CreatedBy:  `/bin/sh -c #(nop)  CMD ["/bin/sh"]`,
+							EmptyLayer: true,
+						},
+						{
+							CreatedBy: "/bin/sh -c #(nop) COPY file:5d673d25da3a14ce1f6cf66e4c7fd4f4b85a3759a9d93efb3fd9ff852b5b56e4 in /app ",
+						},
+					},
+				},
+			},
+			want: &types.BaseImage{
+				DiffIDs: baseDiffIDs,
+			},
+		},
+		{
+			name: "no base image",
+			metadata: types.Metadata{
+				DiffIDs: appDiffIDs,
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := baseimage.Detect(context.Background(), tt.metadata, baseimage.Option{
+				RegistryOptions: ftypes.RegistryOptions{Insecure: true},
+				Offline:         tt.offline,
+			})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	results := types.Results{
+		{
+			Target: "alpine:3.17 (alpine 3.17.0)",
+			Packages: []ftypes.Package{
+				{
+					Name:  "musl",
+					Layer: ftypes.Layer{DiffID: "sha256:base"},
+				},
+				{
+					Name:  "curl",
+					Layer: ftypes.Layer{DiffID: "sha256:app"},
+				},
+			},
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2022-0001",
+					Layer:           ftypes.Layer{DiffID: "sha256:base"},
+				},
+				{
+					VulnerabilityID: "CVE-2022-0002",
+					Layer:           ftypes.Layer{DiffID: "sha256:app"},
+				},
+			},
+		},
+		{
+			Target: "/app/.env",
+			Secrets: []ftypes.SecretFinding{
+				{
+					RuleID: "aws-access-key-id",
+					Layer:  ftypes.Layer{DiffID: "sha256:app"},
+				},
+			},
+		},
+	}
+	baseimage.Annotate(results, &types.BaseImage{DiffIDs: []string{"sha256:base"}})
+
+	want := types.Results{
+		{
+			Target: "alpine:3.17 (alpine 3.17.0)",
+			Packages: []ftypes.Package{
+				{
+					Name: "musl",
+					Layer: ftypes.Layer{
+						DiffID:    "sha256:base",
+						BaseLayer: true,
+					},
+				},
+				{
+					Name:  "curl",
+					Layer: ftypes.Layer{DiffID: "sha256:app"},
+				},
+			},
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2022-0001",
+					Layer: ftypes.Layer{
+						DiffID:    "sha256:base",
+						BaseLayer: true,
+					},
+				},
+				{
+					VulnerabilityID: "CVE-2022-0002",
+					Layer:           ftypes.Layer{DiffID: "sha256:app"},
+				},
+			},
+		},
+		{
+			Target: "/app/.env",
+			Secrets: []ftypes.SecretFinding{
+				{
+					RuleID: "aws-access-key-id",
+					Layer:  ftypes.Layer{DiffID: "sha256:app"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, want, results)
+}
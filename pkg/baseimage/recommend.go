@@ -0,0 +1,137 @@
+package baseimage
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/go-version/pkg/version"
+	"github.com/google/go-containerregistry/pkg/name"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/remote"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// maxNewerTags is the maximum number of newer tags shown in the recommendation
+const maxNewerTags = 10
+
+// Recommend suggests rebasing onto a newer tag of the detected base image.
+// Newer tags are looked up in the registry and the number of vulnerabilities resolved by rebasing is estimated
+// from the vulnerabilities in the base layers, as the newer base image isn't scanned.
+// It returns nil when the base image is unknown or no newer tag is found.
+func Recommend(ctx context.Context, report types.Report, opt Option) (*types.Recommendation, error) {
+	baseImage := report.Metadata.BaseImage
+	if baseImage == nil || baseImage.Name == "" || opt.Offline {
+		return nil, nil
+	}
+
+	tag, err := name.NewTag(baseImage.Name)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to parse the base image name: %w", err)
+	}
+
+	current, ok := parseTag(tag.TagStr())
+	if !ok {
+		log.Logger.Debugf("The tag of the base image is not a version: %s", tag.TagStr())
+		return nil, nil
+	}
+
+	tags, err := remote.Tags(ctx, tag, opt.RegistryOptions)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list tags of %s: %w", tag.Context().Name(), err)
+	}
+
+	newer := newerTags(current, tags)
+	if len(newer) == 0 {
+		return nil, nil
+	}
+
+	recommended := newer[0]
+	for _, t := range newer {
+		// Prefer the same major version to avoid breaking changes
+		if t.major == current.major {
+			recommended = t
+			break
+		}
+	}
+
+	rec := &types.Recommendation{
+		Type:        types.RecommendationRebase,
+		BaseImage:   tag.Name(),
+		Recommended: tag.Context().Tag(recommended.tag).Name(),
+	}
+	for i, t := range newer {
+		if i == maxNewerTags {
+			break
+		}
+		rec.NewerTags = append(rec.NewerTags, t.tag)
+	}
+	rec.Vulnerabilities, rec.ResolvedVulnerabilities = countBaseVulnerabilities(report.Results)
+
+	return rec, nil
+}
+
+type versionTag struct {
+	tag     string
+	version version.Version
+	major   string
+	suffix  string // variant such as "slim" and "alpine3.18"
+	parts   int    // number of version components, e.g. 2 for "3.18"
+}
+
+// parseTag parses a tag such as "3.17.0", "1.21-alpine3.18" and "v2.1"
+func parseTag(tag string) (versionTag, bool) {
+	ver, suffix, _ := strings.Cut(tag, "-")
+	v, err := version.Parse(ver)
+	if err != nil {
+		return versionTag{}, false
+	}
+	major, _, _ := strings.Cut(strings.TrimPrefix(ver, "v"), ".")
+	return versionTag{
+		tag:     tag,
+		version: v,
+		major:   major,
+		suffix:  suffix,
+		parts:   strings.Count(ver, ".") + 1,
+	}, true
+}
+
+// newerTags returns tags newer than the current one, newest first.
+// Only tags with the same variant and precision are compared,
+// e.g. "3.18" is newer than "3.17", but "3.17.1" and "3.18-slim" are not compared with "3.17".
+func newerTags(current versionTag, tags []string) []versionTag {
+	var newer []versionTag
+	for _, tag := range tags {
+		t, ok := parseTag(tag)
+		if !ok || t.suffix != current.suffix || t.parts != current.parts {
+			continue
+		}
+		if t.version.GreaterThan(current.version) {
+			newer = append(newer, t)
+		}
+	}
+	sort.Slice(newer, func(i, j int) bool {
+		return newer[i].version.GreaterThan(newer[j].version)
+	})
+	return newer
+}
+
+// countBaseVulnerabilities counts vulnerabilities originating in the base layers.
+// Vulnerabilities with a fixed version are likely to be resolved by rebasing.
+func countBaseVulnerabilities(results types.Results) (int, int) {
+	var total, resolved int
+	for _, r := range results {
+		for _, v := range r.Vulnerabilities {
+			if !v.Layer.BaseLayer {
+				continue
+			}
+			total++
+			if v.FixedVersion != "" {
+				resolved++
+			}
+		}
+	}
+	return total, resolved
+}
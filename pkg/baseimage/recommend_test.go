@@ -0,0 +1,130 @@
+package baseimage_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/baseimage"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func TestRecommend(t *testing.T) {
+	ts := httptest.NewServer(registry.New())
+	defer ts.Close()
+	host := strings.TrimPrefix(ts.URL, "http://")
+
+	img, err := random.Image(100, 1)
+	require.NoError(t, err)
+	for _, tag := range []string{"3.17.0", "3.17.3", "3.18.0", "3.18", "3.18.0-slim", "4.0.0", "latest"} {
+		ref, err := name.ParseReference(host + "/library/base:" + tag)
+		require.NoError(t, err)
+		require.NoError(t, remote.Write(ref, img))
+	}
+
+	results := types.Results{
+		{
+			Target: "app (alpine 3.17.0)",
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2022-0001",
+					FixedVersion:    "1.2.4",
+					Layer: ftypes.Layer{
+						DiffID:    "sha256:base",
+						BaseLayer: true,
+					},
+				},
+				{
+					VulnerabilityID: "CVE-2022-0002",
+					Layer: ftypes.Layer{
+						DiffID:    "sha256:base",
+						BaseLayer: true,
+					},
+				},
+				{
+					VulnerabilityID: "CVE-2022-0003",
+					FixedVersion:    "2.0.0",
+					Layer: ftypes.Layer{
+						DiffID: "sha256:app",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		baseImage *types.BaseImage
+		offline   bool
+		want      *types.Recommendation
+	}{
+		{
+			name: "newer tags",
+			baseImage: &types.BaseImage{
+				Name: host + "/library/base:3.17.0",
+			},
+			want: &types.Recommendation{
+				Type:                    types.RecommendationRebase,
+				BaseImage:               host + "/library/base:3.17.0",
+				Recommended:             host + "/library/base:3.18.0",
+				NewerTags:               []string{"4.0.0", "3.18.0", "3.17.3"},
+				Vulnerabilities:         2,
+				ResolvedVulnerabilities: 1,
+			},
+		},
+		{
+			name: "latest version",
+			baseImage: &types.BaseImage{
+				Name: host + "/library/base:4.0.0",
+			},
+			want: nil,
+		},
+		{
+			name: "not a version",
+			baseImage: &types.BaseImage{
+				Name: host + "/library/base:latest",
+			},
+			want: nil,
+		},
+		{
+			name: "offline",
+			baseImage: &types.BaseImage{
+				Name: host + "/library/base:3.17.0",
+			},
+			offline: true,
+			want:    nil,
+		},
+		{
+			name: "unknown base image",
+			baseImage: &types.BaseImage{
+				DiffIDs: []string{"sha256:base"},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := types.Report{
+				Metadata: types.Metadata{
+					BaseImage: tt.baseImage,
+				},
+				Results: results,
+			}
+			got, err := baseimage.Recommend(context.Background(), report, baseimage.Option{
+				RegistryOptions: ftypes.RegistryOptions{Insecure: true},
+				Offline:         tt.offline,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Package bundle packages the databases and the checks bundle in the cache directory into a single archive
+// so that they can be carried into air-gapped environments.
+//
+// The archive is a gzip-compressed tarball starting with the manifest and its signature,
+// followed by the files listed in the manifest.
+//
+//	manifest.json
+//	manifest.json.sig   # optional
+//	db/trivy.db
+//	db/metadata.json
+//	java-db/trivy-java.db
+//	java-db/metadata.json
+//	policy/...
+//	fanal/fanal.db      # optional
+package bundle
+
+import (
+	"time"
+)
+
+const (
+	// SchemaVersion is the version of the manifest
+	SchemaVersion = 1
+
+	manifestFile  = "manifest.json"
+	signatureFile = manifestFile + ".sig"
+)
+
+// Component is a directory in the cache directory
+type Component string
+
+const (
+	ComponentDB     Component = "db"
+	ComponentJavaDB Component = "java-db"
+	ComponentChecks Component = "policy"
+	ComponentCache  Component = "fanal"
+)
+
+// Manifest lists the files in the bundle with their digests
+type Manifest struct {
+	SchemaVersion int         `json:"schema_version"`
+	CreatedAt     time.Time   `json:"created_at"`
+	TrivyVersion  string      `json:"trivy_version"`
+	Components    []Component `json:"components"`
+	Files         []File      `json:"files"`
+}
+
+// File is a file in the bundle. The path is relative to the cache directory.
+type File struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
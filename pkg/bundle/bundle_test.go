@@ -0,0 +1,253 @@
+package bundle_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/bundle"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+// newCacheDir returns a cache directory with dummy databases and checks
+func newCacheDir(t *testing.T) string {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "db", "trivy.db"), "vulnerability DB")
+	writeFile(t, filepath.Join(dir, "db", "metadata.json"), `{"Version":2}`)
+	writeFile(t, filepath.Join(dir, "java-db", "trivy-java.db"), "Java DB")
+	writeFile(t, filepath.Join(dir, "policy", "content", "policies", "docker.rego"), "package docker")
+	writeFile(t, filepath.Join(dir, "fanal", "fanal.db"), "cache")
+	return dir
+}
+
+func newKeys(t *testing.T) (string, string) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	dir := t.TempDir()
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	privPath := filepath.Join(dir, "bundle.key")
+	require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600))
+
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	pubPath := filepath.Join(dir, "bundle.pub")
+	require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0600))
+	return privPath, pubPath
+}
+
+// rewrite copies the bundle, applying fn to each entry. The entry is dropped if fn returns nil.
+func rewrite(t *testing.T, src string, fn func(hdr *tar.Header, b []byte) []byte) string {
+	f, err := os.Open(src)
+	require.NoError(t, err)
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	dst := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	out, err := os.Create(dst)
+	require.NoError(t, err)
+	defer out.Close()
+	gw := gzip.NewWriter(out)
+	tw := tar.NewWriter(gw)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		b, err := io.ReadAll(tr)
+		require.NoError(t, err)
+
+		if b = fn(hdr, b); b == nil {
+			continue
+		}
+		hdr.Size = int64(len(b))
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err = tw.Write(b)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return dst
+}
+
+func TestCreateAndImport(t *testing.T) {
+	privKey, pubKey := newKeys(t)
+	_, otherPubKey := newKeys(t)
+
+	tests := []struct {
+		name       string
+		components []bundle.Component
+		signingKey string
+		publicKey  string
+		tamper     func(hdr *tar.Header, b []byte) []byte
+		wantFiles  []string
+		wantErr    string
+	}{
+		{
+			name: "happy path",
+			components: []bundle.Component{
+				bundle.ComponentDB,
+				bundle.ComponentJavaDB,
+				bundle.ComponentChecks,
+			},
+			signingKey: privKey,
+			publicKey:  pubKey,
+			wantFiles: []string{
+				"db/metadata.json",
+				"db/trivy.db",
+				"java-db/trivy-java.db",
+				"policy/content/policies/docker.rego",
+			},
+		},
+		{
+			name: "with cache, unsigned",
+			components: []bundle.Component{
+				bundle.ComponentDB,
+				bundle.ComponentCache,
+			},
+			wantFiles: []string{
+				"db/metadata.json",
+				"db/trivy.db",
+				"fanal/fanal.db",
+			},
+		},
+		{
+			name:       "unsigned",
+			components: []bundle.Component{bundle.ComponentDB},
+			publicKey:  pubKey,
+			wantErr:    "the bundle is not signed",
+		},
+		{
+			name:       "wrong key",
+			components: []bundle.Component{bundle.ComponentDB},
+			signingKey: privKey,
+			publicKey:  otherPubKey,
+			wantErr:    "signature verification error",
+		},
+		{
+			name:       "tampered manifest",
+			components: []bundle.Component{bundle.ComponentDB},
+			signingKey: privKey,
+			publicKey:  pubKey,
+			tamper: func(hdr *tar.Header, b []byte) []byte {
+				if hdr.Name == "manifest.json" {
+					return append(b, '\n')
+				}
+				return b
+			},
+			wantErr: "signature verification error",
+		},
+		{
+			name:       "tampered file",
+			components: []bundle.Component{bundle.ComponentDB},
+			tamper: func(hdr *tar.Header, b []byte) []byte {
+				if hdr.Name == "db/trivy.db" {
+					return []byte("vulnerability DX")
+				}
+				return b
+			},
+			wantErr: "digest mismatch",
+		},
+		{
+			name:       "missing file",
+			components: []bundle.Component{bundle.ComponentDB},
+			tamper: func(hdr *tar.Header, b []byte) []byte {
+				if hdr.Name == "db/trivy.db" {
+					return nil
+				}
+				return b
+			},
+			wantErr: "1 files listed in the manifest are missing",
+		},
+		{
+			name:       "unlisted file",
+			components: []bundle.Component{bundle.ComponentDB},
+			tamper: func(hdr *tar.Header, b []byte) []byte {
+				if hdr.Name == "db/trivy.db" {
+					hdr.Name = "../trivy.db"
+				}
+				return b
+			},
+			wantErr: "../trivy.db is not listed in the manifest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+			err := bundle.Create(output, bundle.CreateOption{
+				CacheDir:     newCacheDir(t),
+				Components:   tt.components,
+				TrivyVersion: "dev",
+				SigningKey:   tt.signingKey,
+			})
+			require.NoError(t, err)
+
+			if tt.tamper != nil {
+				output = rewrite(t, output, tt.tamper)
+			}
+
+			// Existing data must be kept on failure and replaced on success
+			cacheDir := t.TempDir()
+			writeFile(t, filepath.Join(cacheDir, "db", "trivy.db"), "old")
+			writeFile(t, filepath.Join(cacheDir, "db", "old.db"), "old")
+
+			manifest, err := bundle.Import(output, bundle.ImportOption{
+				CacheDir:  cacheDir,
+				PublicKey: tt.publicKey,
+			})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				b, err := os.ReadFile(filepath.Join(cacheDir, "db", "trivy.db"))
+				require.NoError(t, err)
+				assert.Equal(t, "old", string(b))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "dev", manifest.TrivyVersion)
+			assert.Equal(t, tt.components, manifest.Components)
+
+			var got []string
+			err = filepath.WalkDir(cacheDir, func(path string, d os.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				rel, err := filepath.Rel(cacheDir, path)
+				got = append(got, filepath.ToSlash(rel))
+				return err
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFiles, got)
+
+			b, err := os.ReadFile(filepath.Join(cacheDir, "db", "trivy.db"))
+			require.NoError(t, err)
+			assert.Equal(t, "vulnerability DB", string(b))
+		})
+	}
+}
+
+func TestCreate_MissingComponent(t *testing.T) {
+	err := bundle.Create(filepath.Join(t.TempDir(), "bundle.tar.gz"), bundle.CreateOption{
+		CacheDir:   t.TempDir(),
+		Components: []bundle.Component{bundle.ComponentDB},
+	})
+	require.ErrorContains(t, err, "db error")
+}
@@ -0,0 +1,198 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/signature"
+)
+
+// CreateOption represents options for creating a bundle
+type CreateOption struct {
+	CacheDir     string
+	Components   []Component
+	TrivyVersion string
+
+	// SigningKey is the path to a PEM-encoded private key to sign the manifest with
+	SigningKey string
+}
+
+// Create writes the bundle of the components in the cache directory to the output path.
+// The output is written to a temporary file first so that a failure doesn't leave a partial bundle.
+func Create(output string, opt CreateOption) (err error) {
+	manifest := Manifest{
+		SchemaVersion: SchemaVersion,
+		CreatedAt:     time.Now().UTC(),
+		TrivyVersion:  opt.TrivyVersion,
+		Components:    opt.Components,
+	}
+	for _, c := range opt.Components {
+		files, err := listFiles(opt.CacheDir, c)
+		if err != nil {
+			return xerrors.Errorf("%s error: %w", c, err)
+		}
+		manifest.Files = append(manifest.Files, files...)
+	}
+
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("json marshal error: %w", err)
+	}
+
+	var sig []byte
+	if opt.SigningKey != "" {
+		if sig, err = signature.Sign(opt.SigningKey, b); err != nil {
+			return xerrors.Errorf("manifest signing error: %w", err)
+		}
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(output), ".bundle-*.tmp")
+	if err != nil {
+		return xerrors.Errorf("file create error: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+
+	if err = writeBytes(tw, manifestFile, b); err != nil {
+		return err
+	}
+	if sig != nil {
+		if err = writeBytes(tw, signatureFile, sig); err != nil {
+			return err
+		}
+	}
+	for _, file := range manifest.Files {
+		if err = writeFile(tw, opt.CacheDir, file); err != nil {
+			return xerrors.Errorf("%s: %w", file.Path, err)
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return xerrors.Errorf("tar close error: %w", err)
+	}
+	if err = gw.Close(); err != nil {
+		return xerrors.Errorf("gzip close error: %w", err)
+	}
+	if err = f.Chmod(0644); err != nil {
+		return xerrors.Errorf("chmod error: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return xerrors.Errorf("file close error: %w", err)
+	}
+	if err = os.Rename(f.Name(), output); err != nil {
+		return xerrors.Errorf("rename error: %w", err)
+	}
+	log.Logger.Infof("Bundle created: %s (%d files)", output, len(manifest.Files))
+	return nil
+}
+
+// listFiles returns the regular files in the component directory with the digests
+func listFiles(cacheDir string, c Component) ([]File, error) {
+	var files []File
+	root := filepath.Join(cacheDir, string(c))
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(cacheDir, path)
+		if err != nil {
+			return err
+		}
+		size, digest, err := fileDigest(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, File{
+			Path:   filepath.ToSlash(rel),
+			Size:   size,
+			SHA256: digest,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("walk error: %w", err)
+	} else if len(files) == 0 {
+		return nil, xerrors.Errorf("no files found in %s", root)
+	}
+	return files, nil
+}
+
+func fileDigest(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func writeBytes(tw *tar.Writer, name string, b []byte) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(b)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return xerrors.Errorf("tar header error: %w", err)
+	}
+	if _, err := tw.Write(b); err != nil {
+		return xerrors.Errorf("tar write error: %w", err)
+	}
+	return nil
+}
+
+func writeFile(tw *tar.Writer, cacheDir string, file File) error {
+	f, err := os.Open(filepath.Join(cacheDir, filepath.FromSlash(file.Path)))
+	if err != nil {
+		return xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return xerrors.Errorf("stat error: %w", err)
+	}
+	hdr := &tar.Header{
+		Name:    file.Path,
+		Mode:    0644,
+		Size:    file.Size,
+		ModTime: fi.ModTime(),
+	}
+	if err = tw.WriteHeader(hdr); err != nil {
+		return xerrors.Errorf("tar header error: %w", err)
+	}
+
+	// The file must not change after the digest is calculated
+	if _, err = io.CopyN(tw, f, file.Size); err != nil {
+		return xerrors.Errorf("tar write error: %w", err)
+	}
+	return nil
+}
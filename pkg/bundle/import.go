@@ -0,0 +1,239 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/signature"
+)
+
+// ImportOption represents options for importing a bundle
+type ImportOption struct {
+	CacheDir string
+
+	// PublicKey is the path to a PEM-encoded public key.
+	// The signature of the manifest is required and verified if it is specified.
+	PublicKey string
+}
+
+// Import verifies the bundle and extracts it into the cache directory.
+// Components in the bundle replace the existing ones only after all the files are verified.
+func Import(bundlePath string, opt ImportOption) (*Manifest, error) {
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, xerrors.Errorf("gzip error: %w", err)
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+
+	manifest, hdr, err := readManifest(tr, opt.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = os.MkdirAll(opt.CacheDir, 0700); err != nil {
+		return nil, xerrors.Errorf("mkdir error: %w", err)
+	}
+	// The staging directory is created in the cache directory so that components can be renamed
+	staging, err := os.MkdirTemp(opt.CacheDir, ".bundle-")
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	files := map[string]File{}
+	for _, file := range manifest.Files {
+		files[file.Path] = file
+	}
+
+	for ; hdr != nil; hdr, err = tr.Next() {
+		file, ok := files[hdr.Name]
+		if !ok {
+			return nil, xerrors.Errorf("%s is not listed in the manifest", hdr.Name)
+		} else if hdr.Typeflag != tar.TypeReg {
+			return nil, xerrors.Errorf("%s is not a regular file", hdr.Name)
+		}
+		if err = extract(tr, staging, file); err != nil {
+			return nil, xerrors.Errorf("%s: %w", file.Path, err)
+		}
+		delete(files, hdr.Name)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, xerrors.Errorf("tar error: %w", err)
+	} else if len(files) > 0 {
+		return nil, xerrors.Errorf("%d files listed in the manifest are missing in the bundle", len(files))
+	}
+
+	// The existing components are moved aside so that they are restored if any of the components fails to be replaced.
+	// They are removed together with the staging directory.
+	backup := filepath.Join(staging, ".old")
+	if err = os.Mkdir(backup, 0700); err != nil {
+		return nil, xerrors.Errorf("mkdir error: %w", err)
+	}
+	var replaced []Component
+	for _, c := range manifest.Components {
+		if err = replace(opt.CacheDir, staging, backup, c); err != nil {
+			restore(opt.CacheDir, backup, replaced)
+			return nil, xerrors.Errorf("failed to import %s: %w", c, err)
+		}
+		replaced = append(replaced, c)
+	}
+	for _, c := range replaced {
+		log.Logger.Infof("Imported %s", c)
+	}
+	return manifest, nil
+}
+
+// replace moves the existing component into the backup directory and the staged one into the cache directory
+func replace(cacheDir, staging, backup string, c Component) error {
+	dst := filepath.Join(cacheDir, string(c))
+	old := filepath.Join(backup, string(c))
+	if err := os.Rename(dst, old); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return xerrors.Errorf("failed to move %s aside: %w", dst, err)
+	}
+	if err := os.Rename(filepath.Join(staging, string(c)), dst); err != nil {
+		restore(cacheDir, backup, []Component{c})
+		return xerrors.Errorf("rename error: %w", err)
+	}
+	return nil
+}
+
+// restore puts the components moved into the backup directory back
+func restore(cacheDir, backup string, components []Component) {
+	for _, c := range components {
+		dst := filepath.Join(cacheDir, string(c))
+		old := filepath.Join(backup, string(c))
+		if err := os.RemoveAll(dst); err != nil {
+			log.Logger.Warnf("Failed to remove %s: %s", dst, err)
+			continue
+		}
+		if err := os.Rename(old, dst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			log.Logger.Warnf("Failed to restore %s: %s", dst, err)
+		}
+	}
+}
+
+// readManifest reads and verifies the manifest at the beginning of the bundle.
+// It returns the header of the first file following the manifest.
+func readManifest(tr *tar.Reader, publicKey string) (*Manifest, *tar.Header, error) {
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, nil, xerrors.Errorf("tar error: %w", err)
+	} else if hdr.Name != manifestFile {
+		return nil, nil, xerrors.Errorf("%s must be the first file in the bundle", manifestFile)
+	}
+	b, err := io.ReadAll(tr)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("read error: %w", err)
+	}
+
+	var sig []byte
+	if hdr, err = tr.Next(); err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, xerrors.Errorf("tar error: %w", err)
+	} else if hdr != nil && hdr.Name == signatureFile {
+		if sig, err = io.ReadAll(tr); err != nil {
+			return nil, nil, xerrors.Errorf("read error: %w", err)
+		}
+		if hdr, err = tr.Next(); err != nil && !errors.Is(err, io.EOF) {
+			return nil, nil, xerrors.Errorf("tar error: %w", err)
+		}
+	}
+
+	switch {
+	case publicKey != "" && sig == nil:
+		return nil, nil, xerrors.New("the bundle is not signed")
+	case publicKey != "":
+		if err = signature.Verify(publicKey, b, sig); err != nil {
+			return nil, nil, xerrors.Errorf("signature verification error: %w", err)
+		}
+		log.Logger.Info("Verified the signature of the bundle")
+	case sig != nil:
+		log.Logger.Warn("The bundle is signed, but the signature is not verified. Specify the public key to verify it.")
+	}
+
+	var manifest Manifest
+	if err = json.Unmarshal(b, &manifest); err != nil {
+		return nil, nil, xerrors.Errorf("manifest decode error: %w", err)
+	} else if manifest.SchemaVersion != SchemaVersion {
+		return nil, nil, xerrors.Errorf("unsupported schema version %d (expected: %d)", manifest.SchemaVersion, SchemaVersion)
+	}
+	if err = validate(manifest); err != nil {
+		return nil, nil, xerrors.Errorf("invalid manifest: %w", err)
+	}
+	return &manifest, hdr, nil
+}
+
+// validate ensures all the files are within the listed components
+func validate(manifest Manifest) error {
+	// component => number of files
+	components := map[string]int{}
+	for _, c := range manifest.Components {
+		switch c {
+		case ComponentDB, ComponentJavaDB, ComponentChecks, ComponentCache:
+		default:
+			return xerrors.Errorf("unknown component: %s", c)
+		}
+		components[string(c)] = 0
+	}
+	for _, file := range manifest.Files {
+		if path.Clean(file.Path) != file.Path || path.IsAbs(file.Path) || strings.HasPrefix(file.Path, "../") {
+			return xerrors.Errorf("invalid path: %s", file.Path)
+		}
+		c, _, found := strings.Cut(file.Path, "/")
+		if _, ok := components[c]; !ok || !found {
+			return xerrors.Errorf("%s is not in the components", file.Path)
+		}
+		components[c]++
+	}
+	for c, n := range components {
+		if n == 0 {
+			return xerrors.Errorf("no files in %s", c)
+		}
+	}
+	return nil
+}
+
+// extract writes the file into the directory and verifies the size and digest
+func extract(r io.Reader, dir string, file File) error {
+	dst := filepath.Join(dir, filepath.FromSlash(file.Path))
+	if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return xerrors.Errorf("mkdir error: %w", err)
+	}
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return xerrors.Errorf("file create error: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(r, file.Size+1))
+	if err != nil {
+		return xerrors.Errorf("write error: %w", err)
+	}
+	if n != file.Size {
+		return xerrors.Errorf("size mismatch: expected %d, got %d", file.Size, n)
+	}
+	if digest := hex.EncodeToString(h.Sum(nil)); digest != file.SHA256 {
+		return xerrors.Errorf("digest mismatch: expected %s, got %s", file.SHA256, digest)
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/zhanglimao/trivy/pkg/cloud"
 	"github.com/zhanglimao/trivy/pkg/cloud/aws/scanner"
 	"github.com/zhanglimao/trivy/pkg/cloud/report"
+	"github.com/zhanglimao/trivy/pkg/commands/artifact"
 	"github.com/zhanglimao/trivy/pkg/commands/operation"
 	cr "github.com/zhanglimao/trivy/pkg/compliance/report"
 	"github.com/zhanglimao/trivy/pkg/flag"
@@ -55,10 +56,14 @@ func processOptions(ctx context.Context, opt *flag.Options) error {
 	}
 	opt.Services = splitServices
 
-	if len(opt.Services) != 1 && opt.ARN != "" {
+	if len(opt.Services) != 1 && opt.ARN != "" && !cloud.IsResourcePattern(opt.ARN) {
 		return fmt.Errorf("you must specify the single --service which the --arn relates to")
 	}
 
+	if _, err := scanner.ParseTagFilters(opt.Tags); err != nil {
+		return err
+	}
+
 	if opt.Account == "" || opt.Region == "" {
 		var err error
 		opt.Account, opt.Region, err = getAccountIDAndRegion(ctx, opt.Region)
@@ -110,6 +115,10 @@ func Run(ctx context.Context, opt flag.Options) error {
 		return err
 	}
 
+	if err := artifact.LoadRemoteCompliance(ctx, &opt); err != nil {
+		return fmt.Errorf("compliance spec error: %w", err)
+	}
+
 	results, cached, err := scanner.NewScanner().Scan(ctx, opt)
 	if err != nil {
 		var aerr errs.AdapterError
@@ -79,6 +79,7 @@ func Test_Run(t *testing.T) {
 			},
 			cacheContent: exampleS3Cache,
 			want: `{
+  "SchemaVersion": 2,
   "ArtifactName": "12345678",
   "ArtifactType": "aws_account",
   "Metadata": {
@@ -370,6 +371,7 @@ deny[res] {
 `,
 			cacheContent: exampleS3Cache,
 			want: `{
+  "SchemaVersion": 2,
   "ArtifactName": "12345678",
   "ArtifactType": "aws_account",
   "Metadata": {
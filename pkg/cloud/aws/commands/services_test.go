@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/defsec/pkg/providers/aws/eks"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/elasticache"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/msk"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/cloud"
+	"github.com/zhanglimao/trivy/pkg/cloud/cache"
+	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func Test_Run_Services(t *testing.T) {
+	eksARN := "arn:aws:eks:us-east-1:12345678:cluster/example"
+	mskARN := "arn:aws:kafka:us-east-1:12345678:cluster/example/1"
+	elastiCacheARN := "arn:aws:elasticache:us-east-1:12345678:replicationgroup:example"
+
+	eksMeta := defsecTypes.NewRemoteMetadata(eksARN)
+	mskMeta := defsecTypes.NewRemoteMetadata(mskARN)
+	elastiCacheMeta := defsecTypes.NewRemoteMetadata(elastiCacheARN)
+
+	s := &state.State{}
+	s.AWS.EKS.Clusters = []eks.Cluster{
+		{
+			Metadata: eksMeta,
+			Logging: eks.Logging{
+				Metadata:          eksMeta,
+				API:               defsecTypes.Bool(false, eksMeta),
+				Audit:             defsecTypes.Bool(false, eksMeta),
+				Authenticator:     defsecTypes.Bool(false, eksMeta),
+				ControllerManager: defsecTypes.Bool(false, eksMeta),
+				Scheduler:         defsecTypes.Bool(false, eksMeta),
+			},
+			Encryption: eks.Encryption{
+				Metadata: eksMeta,
+				Secrets:  defsecTypes.Bool(false, eksMeta),
+				KMSKeyID: defsecTypes.String("", eksMeta),
+			},
+			PublicAccessEnabled: defsecTypes.Bool(true, eksMeta),
+			PublicAccessCIDRs:   []defsecTypes.StringValue{defsecTypes.String("0.0.0.0/0", eksMeta)},
+		},
+	}
+	s.AWS.MSK.Clusters = []msk.Cluster{
+		{
+			Metadata: mskMeta,
+			EncryptionInTransit: msk.EncryptionInTransit{
+				Metadata:     mskMeta,
+				ClientBroker: defsecTypes.String(msk.ClientBrokerEncryptionPlaintext, mskMeta),
+			},
+			EncryptionAtRest: msk.EncryptionAtRest{
+				Metadata:  mskMeta,
+				KMSKeyARN: defsecTypes.String("", mskMeta),
+				Enabled:   defsecTypes.Bool(false, mskMeta),
+			},
+			Logging: msk.Logging{
+				Metadata: mskMeta,
+				Broker: msk.BrokerLogging{
+					Metadata:   mskMeta,
+					S3:         msk.S3Logging{Metadata: mskMeta, Enabled: defsecTypes.Bool(false, mskMeta)},
+					Cloudwatch: msk.CloudwatchLogging{Metadata: mskMeta, Enabled: defsecTypes.Bool(false, mskMeta)},
+					Firehose:   msk.FirehoseLogging{Metadata: mskMeta, Enabled: defsecTypes.Bool(false, mskMeta)},
+				},
+			},
+		},
+	}
+	s.AWS.ElastiCache.ReplicationGroups = []elasticache.ReplicationGroup{
+		{
+			Metadata:                 elastiCacheMeta,
+			TransitEncryptionEnabled: defsecTypes.Bool(false, elastiCacheMeta),
+			AtRestEncryptionEnabled:  defsecTypes.Bool(false, elastiCacheMeta),
+		},
+	}
+
+	services := []string{"eks", "msk", "elasticache"}
+	cacheDir := t.TempDir()
+	c := cache.New(cacheDir, time.Hour, cloud.ProviderAWS, "12345678", "us-east-1")
+	require.NoError(t, c.AddServices(s, services))
+
+	buf := new(bytes.Buffer)
+	opts := flag.Options{
+		GlobalOptions: flag.GlobalOptions{
+			CacheDir: cacheDir,
+			Timeout:  time.Minute,
+		},
+		RegoOptions: flag.RegoOptions{SkipPolicyUpdate: true},
+		AWSOptions: flag.AWSOptions{
+			Region:   "us-east-1",
+			Services: services,
+			Account:  "12345678",
+		},
+		CloudOptions: flag.CloudOptions{
+			MaxCacheAge: time.Hour,
+		},
+		ReportOptions: flag.ReportOptions{
+			Format: "json",
+			Output: buf,
+			Severities: []dbTypes.Severity{
+				dbTypes.SeverityLow,
+				dbTypes.SeverityMedium,
+				dbTypes.SeverityHigh,
+				dbTypes.SeverityCritical,
+			},
+		},
+	}
+	require.NoError(t, Run(context.Background(), opts))
+
+	var report types.Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+
+	// Service -> the resource failing checks
+	got := map[string]string{}
+	for _, result := range report.Results {
+		for _, m := range result.Misconfigurations {
+			if m.Status == types.StatusFailure {
+				got[m.CauseMetadata.Service] = m.CauseMetadata.Resource
+			}
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"eks":         eksARN,
+		"msk":         mskARN,
+		"elasticache": elastiCacheARN,
+	}, got)
+}
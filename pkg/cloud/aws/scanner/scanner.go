@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/config"
+	"golang.org/x/xerrors"
+
 	"github.com/aquasecurity/defsec/pkg/framework"
 	"github.com/aquasecurity/defsec/pkg/scan"
 	"github.com/aquasecurity/defsec/pkg/scanners/cloud/aws"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 	"github.com/aquasecurity/defsec/pkg/state"
-	"github.com/zhanglimao/trivy/pkg/cloud/aws/cache"
+	"github.com/zhanglimao/trivy/pkg/cloud"
+	"github.com/zhanglimao/trivy/pkg/cloud/cache"
 	"github.com/zhanglimao/trivy/pkg/commands/operation"
 	"github.com/zhanglimao/trivy/pkg/flag"
 	"github.com/zhanglimao/trivy/pkg/log"
@@ -25,20 +29,13 @@ func NewScanner() *AWSScanner {
 
 func (s *AWSScanner) Scan(ctx context.Context, option flag.Options) (scan.Results, bool, error) {
 
-	awsCache := cache.New(option.CacheDir, option.MaxCacheAge, option.Account, option.Region)
-	included, missing := awsCache.ListServices(option.Services)
-
-	var scannerOpts []options.ScannerOption
-	if !option.NoProgress {
-		tracker := newProgressTracker()
-		defer tracker.Finish()
-		scannerOpts = append(scannerOpts, aws.ScannerWithProgressTracker(tracker))
-	}
-
-	if len(missing) > 0 {
-		scannerOpts = append(scannerOpts, aws.ScannerWithAWSServices(missing...))
+	awsCache, err := cache.NewFromOptions(option, cloud.ProviderAWS, option.Account, option.Region)
+	if err != nil {
+		return nil, false, xerrors.Errorf("cache error: %w", err)
 	}
+	included, services := awsCache.ServicesToQuery(option.Services, option.CloudOptions)
 
+	var scannerOpts []options.ScannerOption
 	if option.Debug {
 		scannerOpts = append(scannerOpts, options.ScannerWithDebug(&defsecLogger{}))
 	}
@@ -61,9 +58,17 @@ func (s *AWSScanner) Scan(ctx context.Context, option flag.Options) (scan.Result
 		)
 	}
 
+	var freshState *state.State
+	var fetched []string
+	if len(services) > 0 {
+		freshState, fetched, err = createState(ctx, services, scannerOpts, option)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
 	var policyPaths []string
 	var downloadedPolicyPaths []string
-	var err error
 	downloadedPolicyPaths, err = operation.InitBuiltinPolicies(context.Background(), option.CacheDir, option.Quiet, option.SkipPolicyUpdate)
 	if err != nil {
 		if !option.SkipPolicyUpdate {
@@ -95,15 +100,6 @@ func (s *AWSScanner) Scan(ctx context.Context, option flag.Options) (scan.Result
 
 	scanner := aws.New(scannerOpts...)
 
-	var freshState *state.State
-	if len(missing) > 0 || option.CloudOptions.UpdateCache {
-		var err error
-		freshState, err = scanner.CreateState(ctx)
-		if err != nil {
-			return nil, false, err
-		}
-	}
-
 	var fullState *state.State
 	if previousState, err := awsCache.LoadState(); err == nil {
 		if freshState != nil {
@@ -122,7 +118,7 @@ func (s *AWSScanner) Scan(ctx context.Context, option flag.Options) (scan.Result
 		return nil, false, fmt.Errorf("no resultant state found")
 	}
 
-	if err := awsCache.AddServices(fullState, missing); err != nil {
+	if err := awsCache.AddServices(fullState, fetched); err != nil {
 		return nil, false, err
 	}
 
@@ -131,9 +127,42 @@ func (s *AWSScanner) Scan(ctx context.Context, option flag.Options) (scan.Result
 		return nil, false, err
 	}
 
+	if defsecResults, err = selectResults(ctx, defsecResults, option); err != nil {
+		return nil, false, err
+	}
+
 	return defsecResults, len(included) > 0, nil
 }
 
+// selectResults limits the results to the resources matching the ARN pattern and the tags
+func selectResults(ctx context.Context, results scan.Results, option flag.Options) (scan.Results, error) {
+	var pattern string
+	if cloud.IsResourcePattern(option.ARN) {
+		pattern = option.ARN
+	}
+	if pattern == "" && len(option.Tags) == 0 {
+		return results, nil
+	}
+
+	var tagged map[string]struct{}
+	if len(option.Tags) > 0 {
+		filters, err := ParseTagFilters(option.Tags)
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(option.Region))
+		if err != nil {
+			return nil, xerrors.Errorf("aws config error: %w", err)
+		}
+		if tagged, err = newTaggingClient(cfg, option.Endpoint).taggedResources(ctx, filters); err != nil {
+			return nil, err
+		}
+		log.Logger.Debugf("%d resources found with the tags", len(tagged))
+	}
+
+	return cloud.SelectResults(results, pattern, tagged), nil
+}
+
 type defsecLogger struct {
 }
 
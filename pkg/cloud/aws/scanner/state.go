@@ -0,0 +1,162 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/defsec/pkg/progress"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/aws"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/aquasecurity/defsec/pkg/state"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+var errServiceTimeout = xerrors.New("timed out")
+
+type serviceState struct {
+	service string
+	state   *state.State
+	err     error
+}
+
+// createState queries the services from AWS concurrently and merges their states.
+// Services exceeding the timeout are skipped and returned neither in the state nor as fetched services,
+// so that they are queried again in the next scan.
+func createState(ctx context.Context, services []string, scannerOpts []options.ScannerOption,
+	option flag.Options) (*state.State, []string, error) {
+	concurrency := option.CloudOptions.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	// The progress bar can be shown only when services are queried one by one
+	var tracker progress.Tracker = progress.NoProgress
+	if !option.NoProgress && concurrency == 1 {
+		t := newProgressTracker()
+		t.SetTotalServices(len(services))
+		defer t.Finish()
+		tracker = &sequentialTracker{progressTracker: t}
+	}
+
+	ch := make(chan string)
+	results := make(chan serviceState, len(services))
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for service := range ch {
+				st, err := createServiceState(ctx, service, scannerOpts, tracker, option)
+				results <- serviceState{service: service, state: st, err: err}
+			}
+		}()
+	}
+	for _, service := range services {
+		ch <- service
+	}
+	close(ch)
+	wg.Wait()
+	close(results)
+
+	var states []serviceState
+	for r := range results {
+		states = append(states, r)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].service < states[j].service })
+
+	merged := &state.State{}
+	var fetched []string
+	for _, s := range states {
+		if s.err != nil {
+			if !errors.Is(s.err, errServiceTimeout) || ctx.Err() != nil {
+				return nil, nil, xerrors.Errorf("failed to query %s: %w", s.service, s.err)
+			}
+			log.Logger.Warnf("Skipping %s as it %s", s.service, s.err)
+			continue
+		}
+		var err error
+		if merged, err = merged.Merge(s.state); err != nil {
+			return nil, nil, xerrors.Errorf("failed to merge the state of %s: %w", s.service, err)
+		}
+		fetched = append(fetched, s.service)
+	}
+	return merged, fetched, nil
+}
+
+// createServiceState queries the single service with the timeout
+func createServiceState(ctx context.Context, service string, scannerOpts []options.ScannerOption,
+	tracker progress.Tracker, option flag.Options) (*state.State, error) {
+	if option.CloudOptions.ServiceTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, option.CloudOptions.ServiceTimeout)
+		defer cancel()
+	}
+
+	counter := &resourceCounter{Tracker: tracker}
+	opts := append(scannerOpts[:len(scannerOpts):len(scannerOpts)],
+		aws.ScannerWithAWSServices(service),
+		aws.ScannerWithProgressTracker(counter),
+	)
+	scanner := aws.New(opts...)
+
+	start := time.Now()
+	log.Logger.Debugf("Querying %s...", service)
+
+	// Adapters might not stop immediately on cancellation
+	done := make(chan serviceState, 1)
+	go func() {
+		st, err := scanner.CreateState(ctx)
+		done <- serviceState{state: st, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, xerrors.Errorf("%w after %s", errServiceTimeout, time.Since(start).Round(time.Second))
+	case r := <-done:
+		if r.err != nil {
+			return nil, r.err
+		}
+		// Adapter errors are not returned by defsec, so check the deadline
+		if ctx.Err() != nil {
+			return nil, xerrors.Errorf("%w after %s", errServiceTimeout, time.Since(start).Round(time.Second))
+		}
+		if option.CloudOptions.Concurrency > 1 && !option.Quiet {
+			log.Logger.Infof("Queried %s: %d resources in %s", service, counter.count(), time.Since(start).Round(time.Millisecond))
+		}
+		return r.state, nil
+	}
+}
+
+// sequentialTracker ignores the number of services reported by defsec,
+// as a scanner is created for each service.
+type sequentialTracker struct {
+	*progressTracker
+}
+
+func (t *sequentialTracker) SetTotalServices(_ int) {}
+
+// resourceCounter counts the resources of a service for reporting
+type resourceCounter struct {
+	progress.Tracker
+	mu        sync.Mutex
+	resources int
+}
+
+func (c *resourceCounter) IncrementResource() {
+	c.mu.Lock()
+	c.resources++
+	c.mu.Unlock()
+	c.Tracker.IncrementResource()
+}
+
+func (c *resourceCounter) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.resources
+}
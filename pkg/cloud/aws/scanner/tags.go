@@ -0,0 +1,138 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"golang.org/x/xerrors"
+)
+
+const getResourcesTarget = "ResourceGroupsTaggingAPI_20170126.GetResources"
+
+// TagFilter selects resources with the tag, e.g. "Environment=prod".
+// Resources with any value of the key are selected if Values is empty.
+type TagFilter struct {
+	Key    string   `json:"Key"`
+	Values []string `json:"Values,omitempty"`
+}
+
+// ParseTagFilters parses "Key=Value" and "Key" selectors.
+// Values of the same key are merged so that resources with any of them are selected.
+func ParseTagFilters(tags []string) ([]TagFilter, error) {
+	var filters []TagFilter
+	index := make(map[string]int)
+	for _, tag := range tags {
+		key, value, found := strings.Cut(tag, "=")
+		if key = strings.TrimSpace(key); key == "" {
+			return nil, xerrors.Errorf("invalid tag selector %q, must be 'Key=Value' or 'Key'", tag)
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(filters)
+			index[key] = i
+			filters = append(filters, TagFilter{Key: key})
+		}
+		if found {
+			filters[i].Values = append(filters[i].Values, strings.TrimSpace(value))
+		}
+	}
+	return filters, nil
+}
+
+// taggingClient calls the Resource Groups Tagging API, which returns the resources with the tags across services
+type taggingClient struct {
+	cfg      aws.Config
+	endpoint string
+	client   *http.Client
+}
+
+func newTaggingClient(cfg aws.Config, endpoint string) *taggingClient {
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://tagging.%s.amazonaws.com", cfg.Region)
+	}
+	return &taggingClient{
+		cfg:      cfg,
+		endpoint: endpoint,
+		client:   http.DefaultClient,
+	}
+}
+
+// taggedResources returns the ARNs of the resources matching all the filters
+func (c *taggingClient) taggedResources(ctx context.Context, filters []TagFilter) (map[string]struct{}, error) {
+	arns := make(map[string]struct{})
+	var token string
+	for {
+		var out struct {
+			PaginationToken        string `json:"PaginationToken"`
+			ResourceTagMappingList []struct {
+				ResourceARN string `json:"ResourceARN"`
+			} `json:"ResourceTagMappingList"`
+		}
+		in := struct {
+			TagFilters      []TagFilter `json:"TagFilters"`
+			PaginationToken string      `json:"PaginationToken,omitempty"`
+		}{
+			TagFilters:      filters,
+			PaginationToken: token,
+		}
+		if err := c.call(ctx, getResourcesTarget, in, &out); err != nil {
+			return nil, xerrors.Errorf("failed to get tagged resources: %w", err)
+		}
+		for _, m := range out.ResourceTagMappingList {
+			arns[m.ResourceARN] = struct{}{}
+		}
+		if token = out.PaginationToken; token == "" {
+			return arns, nil
+		}
+	}
+}
+
+func (c *taggingClient) call(ctx context.Context, target string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return xerrors.Errorf("json encode error: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return xerrors.Errorf("request error: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", target)
+
+	creds, err := c.cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return xerrors.Errorf("credentials error: %w", err)
+	}
+	hash := sha256.Sum256(body)
+	if err = v4.NewSigner().SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), "tagging", c.cfg.Region, time.Now()); err != nil {
+		return xerrors.Errorf("signing error: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return xerrors.Errorf("http error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return xerrors.Errorf("read error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return xerrors.Errorf("unexpected status code %d: %s", resp.StatusCode, string(b))
+	}
+	if err = json.Unmarshal(b, out); err != nil {
+		return xerrors.Errorf("json decode error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTagFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []TagFilter
+		wantErr string
+	}{
+		{
+			name: "key and value",
+			tags: []string{"Environment=prod", "Team=security"},
+			want: []TagFilter{
+				{Key: "Environment", Values: []string{"prod"}},
+				{Key: "Team", Values: []string{"security"}},
+			},
+		},
+		{
+			name: "same key",
+			tags: []string{"Environment=prod", "Environment=staging"},
+			want: []TagFilter{
+				{Key: "Environment", Values: []string{"prod", "staging"}},
+			},
+		},
+		{
+			name: "key only",
+			tags: []string{"Owner"},
+			want: []TagFilter{
+				{Key: "Owner"},
+			},
+		},
+		{
+			name:    "empty key",
+			tags:    []string{"=prod"},
+			wantErr: "invalid tag selector",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTagFilters(tt.tags)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTaggingClient_taggedResources(t *testing.T) {
+	pages := map[string]string{
+		"":     `{"PaginationToken": "next", "ResourceTagMappingList": [{"ResourceARN": "arn:aws:s3:::prod-logs"}]}`,
+		"next": `{"PaginationToken": "", "ResourceTagMappingList": [{"ResourceARN": "arn:aws:ec2:us-east-1:123456789012:instance/i-1234"}]}`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, getResourcesTarget, r.Header.Get("X-Amz-Target"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-east-1/tagging/aws4_request")
+
+		var in struct {
+			TagFilters      []TagFilter
+			PaginationToken string
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		assert.Equal(t, []TagFilter{{Key: "Environment", Values: []string{"prod"}}}, in.TagFilters)
+		_, _ = w.Write([]byte(pages[in.PaginationToken]))
+	}))
+	defer ts.Close()
+
+	cfg := aws.Config{
+		Region: "us-east-1",
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+		}),
+	}
+	got, err := newTaggingClient(cfg, ts.URL).taggedResources(context.Background(),
+		[]TagFilter{{Key: "Environment", Values: []string{"prod"}}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{
+		"arn:aws:s3:::prod-logs":                             {},
+		"arn:aws:ec2:us-east-1:123456789012:instance/i-1234": {},
+	}, got)
+}
@@ -0,0 +1,153 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/xerrors"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/state"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+const subscriptionsAPIVersion = "2020-01-01"
+
+type adaptFunc func(ctx context.Context, a *Adapter, st *state.State) error
+
+var adapters = map[string]adaptFunc{
+	"network": adaptNetwork,
+	"storage": adaptStorage,
+}
+
+// AllSupportedServices returns the Azure services which can be scanned
+func AllSupportedServices() []string {
+	services := maps.Keys(adapters)
+	sort.Strings(services)
+	return services
+}
+
+// Adapter queries Azure Resource Manager to populate the state of the subscription
+type Adapter struct {
+	subscriptionID string
+	endpoint       string
+	pipeline       runtime.Pipeline
+}
+
+// NewAdapter authenticates in the same way as the Azure CLI and SDKs.
+// The subscription is looked up if it is not specified.
+func NewAdapter(ctx context.Context, subscriptionID string) (*Adapter, error) {
+	log.Logger.Debug("Looking for Azure credentials...")
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to find Azure credentials: %w", err)
+	}
+	client, err := arm.NewClient("trivy", "v1", cred, nil)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to create an Azure Resource Manager client: %w", err)
+	}
+
+	a := &Adapter{
+		subscriptionID: subscriptionID,
+		endpoint:       client.Endpoint(),
+		pipeline:       client.Pipeline(),
+	}
+	if a.subscriptionID == "" {
+		a.subscriptionID = os.Getenv("AZURE_SUBSCRIPTION_ID")
+	}
+	if a.subscriptionID == "" {
+		if a.subscriptionID, err = a.defaultSubscription(ctx); err != nil {
+			return nil, err
+		}
+	}
+	log.Logger.Debugf("Scanning Azure subscription %s", a.subscriptionID)
+	return a, nil
+}
+
+func (a *Adapter) Provider() providers.Provider {
+	return providers.AzureProvider
+}
+
+func (a *Adapter) AccountID() string {
+	return a.subscriptionID
+}
+
+func (a *Adapter) SupportedServices() []string {
+	return AllSupportedServices()
+}
+
+func (a *Adapter) Adapt(ctx context.Context, service string, st *state.State) error {
+	adapt, ok := adapters[service]
+	if !ok {
+		return xerrors.Errorf("unsupported service: %s", service)
+	}
+	return adapt(ctx, a, st)
+}
+
+// defaultSubscription returns the only subscription the credentials can access
+func (a *Adapter) defaultSubscription(ctx context.Context) (string, error) {
+	var subscriptions []string
+	err := list(ctx, a, "/subscriptions", subscriptionsAPIVersion, func(s struct {
+		SubscriptionID string `json:"subscriptionId"`
+	}) {
+		subscriptions = append(subscriptions, s.SubscriptionID)
+	})
+	if err != nil {
+		return "", xerrors.Errorf("failed to list subscriptions: %w", err)
+	}
+	switch len(subscriptions) {
+	case 0:
+		return "", xerrors.New("no Azure subscription found")
+	case 1:
+		return subscriptions[0], nil
+	default:
+		return "", xerrors.Errorf("multiple Azure subscriptions found, specify one of them with --subscription: %q", subscriptions)
+	}
+}
+
+// list returns all the resources of the collection following "nextLink"
+func list[T any](ctx context.Context, a *Adapter, path, apiVersion string, fn func(T)) error {
+	u, err := url.Parse(runtime.JoinPaths(a.endpoint, path))
+	if err != nil {
+		return xerrors.Errorf("invalid path %q: %w", path, err)
+	}
+	query := u.Query()
+	query.Set("api-version", apiVersion)
+	u.RawQuery = query.Encode()
+
+	next := u.String()
+	for next != "" {
+		req, err := runtime.NewRequest(ctx, http.MethodGet, next)
+		if err != nil {
+			return xerrors.Errorf("request error: %w", err)
+		}
+		resp, err := a.pipeline.Do(req)
+		if err != nil {
+			return xerrors.Errorf("request error (%s): %w", path, err)
+		}
+		if !runtime.HasStatusCode(resp, http.StatusOK) {
+			return runtime.NewResponseError(resp)
+		}
+
+		var page struct {
+			Value    []T    `json:"value"`
+			NextLink string `json:"nextLink"`
+		}
+		if err = runtime.UnmarshalAsJSON(resp, &page); err != nil {
+			return xerrors.Errorf("response decode error (%s): %w", path, err)
+		}
+		for _, v := range page.Value {
+			fn(v)
+		}
+		next = page.NextLink
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+func newTestAdapter(t *testing.T, files map[string]string) *Adapter {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Path
+		if token := r.URL.Query().Get("$skiptoken"); token != "" {
+			key += "?" + token
+		}
+		file, ok := files[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		b, err := os.ReadFile(file)
+		require.NoError(t, err)
+		_, _ = w.Write([]byte(strings.ReplaceAll(string(b), "{{ .URL }}", "http://"+r.Host)))
+	}))
+	t.Cleanup(ts.Close)
+
+	return &Adapter{
+		subscriptionID: "sub",
+		endpoint:       ts.URL,
+		pipeline:       runtime.NewPipeline("trivy", "test", runtime.PipelineOptions{}, nil),
+	}
+}
+
+func TestAdapter_Adapt(t *testing.T) {
+	const (
+		accountID    = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account"
+		containersID = accountID + "/blobServices/default/containers"
+		nsgID        = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/nsg"
+	)
+
+	a := newTestAdapter(t, map[string]string{
+		"/subscriptions/sub/providers/Microsoft.Storage/storageAccounts": "testdata/storage-accounts.json",
+		containersID:           "testdata/containers.json",
+		containersID + "?next": "testdata/containers-next.json",
+		"/subscriptions/sub/providers/Microsoft.Network/networkSecurityGroups": "testdata/network-security-groups.json",
+	})
+
+	t.Run("storage", func(t *testing.T) {
+		st := &state.State{}
+		require.NoError(t, a.Adapt(context.Background(), "storage", st))
+		require.Len(t, st.Azure.Storage.Accounts, 1)
+
+		account := st.Azure.Storage.Accounts[0]
+		assert.Equal(t, accountID, account.Metadata.Reference())
+		assert.True(t, account.EnforceHTTPS.IsFalse())
+		assert.Equal(t, "TLS1_0", account.MinimumTLSVersion.Value())
+		require.Len(t, account.NetworkRules, 1)
+		assert.True(t, account.NetworkRules[0].AllowByDefault.IsTrue())
+		assert.Equal(t, []string{"AzureServices", "Logging"}, defsecTypes.StringValueList(account.NetworkRules[0].Bypass).AsStrings())
+
+		// The containers are paged
+		require.Len(t, account.Containers, 2)
+		assert.Equal(t, "off", account.Containers[0].PublicAccess.Value())
+		assert.Equal(t, "container", account.Containers[1].PublicAccess.Value())
+	})
+
+	t.Run("network", func(t *testing.T) {
+		st := &state.State{}
+		require.NoError(t, a.Adapt(context.Background(), "network", st))
+		require.Len(t, st.Azure.Network.SecurityGroups, 1)
+
+		sg := st.Azure.Network.SecurityGroups[0]
+		assert.Equal(t, nsgID, sg.Metadata.Reference())
+		require.Len(t, sg.Rules, 1)
+
+		rule := sg.Rules[0]
+		assert.True(t, rule.Allow.IsTrue())
+		assert.True(t, rule.Outbound.IsFalse())
+		assert.Equal(t, []string{"*"}, defsecTypes.StringValueList(rule.SourceAddresses).AsStrings())
+		assert.Equal(t, []string{"10.0.0.0/24"}, defsecTypes.StringValueList(rule.DestinationAddresses).AsStrings())
+		require.Len(t, rule.SourcePorts, 1)
+		assert.True(t, rule.SourcePorts[0].Includes(443))
+		require.Len(t, rule.DestinationPorts, 2)
+		assert.True(t, rule.DestinationPorts[0].Includes(22))
+		assert.True(t, rule.DestinationPorts[1].Includes(8080))
+		assert.False(t, rule.DestinationPorts[1].Includes(8081))
+	})
+
+	t.Run("unsupported service", func(t *testing.T) {
+		err := a.Adapt(context.Background(), "unknown", &state.State{})
+		assert.ErrorContains(t, err, "unsupported service")
+	})
+}
+
+func TestAdapter_defaultSubscription(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "single subscription",
+			body: `{"value": [{"subscriptionId": "sub"}]}`,
+			want: "sub",
+		},
+		{
+			name:    "multiple subscriptions",
+			body:    `{"value": [{"subscriptionId": "sub1"}, {"subscriptionId": "sub2"}]}`,
+			wantErr: "multiple Azure subscriptions found",
+		},
+		{
+			name:    "no subscription",
+			body:    `{"value": []}`,
+			wantErr: "no Azure subscription found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/subscriptions", r.URL.Path)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+
+			a := &Adapter{
+				endpoint: ts.URL,
+				pipeline: runtime.NewPipeline("trivy", "test", runtime.PipelineOptions{}, nil),
+			}
+			got, err := a.defaultSubscription(context.Background())
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
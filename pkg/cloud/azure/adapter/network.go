@@ -0,0 +1,110 @@
+package adapter
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/defsec/pkg/providers/azure/network"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+const networkAPIVersion = "2022-09-01"
+
+type securityGroup struct {
+	ID         string `json:"id"`
+	Properties struct {
+		SecurityRules []securityRule `json:"securityRules"`
+	} `json:"properties"`
+}
+
+type securityRule struct {
+	ID         string `json:"id"`
+	Properties struct {
+		Direction                  string   `json:"direction"`
+		Access                     string   `json:"access"`
+		Protocol                   string   `json:"protocol"`
+		SourceAddressPrefix        string   `json:"sourceAddressPrefix"`
+		SourceAddressPrefixes      []string `json:"sourceAddressPrefixes"`
+		SourcePortRange            string   `json:"sourcePortRange"`
+		SourcePortRanges           []string `json:"sourcePortRanges"`
+		DestinationAddressPrefix   string   `json:"destinationAddressPrefix"`
+		DestinationAddressPrefixes []string `json:"destinationAddressPrefixes"`
+		DestinationPortRange       string   `json:"destinationPortRange"`
+		DestinationPortRanges      []string `json:"destinationPortRanges"`
+	} `json:"properties"`
+}
+
+func adaptNetwork(ctx context.Context, a *Adapter, st *state.State) error {
+	err := list(ctx, a, "/subscriptions/"+a.subscriptionID+"/providers/Microsoft.Network/networkSecurityGroups",
+		networkAPIVersion, func(sg securityGroup) {
+			st.Azure.Network.SecurityGroups = append(st.Azure.Network.SecurityGroups, adaptSecurityGroup(sg))
+		})
+	if err != nil {
+		return xerrors.Errorf("failed to list network security groups: %w", err)
+	}
+	return nil
+}
+
+func adaptSecurityGroup(sg securityGroup) network.SecurityGroup {
+	adapted := network.SecurityGroup{
+		Metadata: defsecTypes.NewRemoteMetadata(sg.ID),
+	}
+	for _, rule := range sg.Properties.SecurityRules {
+		metadata := defsecTypes.NewRemoteMetadata(rule.ID)
+		p := rule.Properties
+		adapted.Rules = append(adapted.Rules, network.SecurityGroupRule{
+			Metadata:             metadata,
+			Outbound:             defsecTypes.Bool(strings.EqualFold(p.Direction, "Outbound"), metadata),
+			Allow:                defsecTypes.Bool(strings.EqualFold(p.Access, "Allow"), metadata),
+			SourceAddresses:      adaptAddresses(p.SourceAddressPrefix, p.SourceAddressPrefixes, metadata),
+			SourcePorts:          adaptPortRanges(p.SourcePortRange, p.SourcePortRanges, metadata),
+			DestinationAddresses: adaptAddresses(p.DestinationAddressPrefix, p.DestinationAddressPrefixes, metadata),
+			DestinationPorts:     adaptPortRanges(p.DestinationPortRange, p.DestinationPortRanges, metadata),
+			Protocol:             defsecTypes.String(p.Protocol, metadata),
+		})
+	}
+	return adapted
+}
+
+// adaptAddresses merges the single prefix and the list of prefixes, only one of which is set
+func adaptAddresses(prefix string, prefixes []string, metadata defsecTypes.Metadata) []defsecTypes.StringValue {
+	var addresses []defsecTypes.StringValue
+	for _, p := range append([]string{prefix}, prefixes...) {
+		if p != "" {
+			addresses = append(addresses, defsecTypes.String(p, metadata))
+		}
+	}
+	return addresses
+}
+
+// adaptPortRanges parses port ranges such as "*", "22" and "1000-2000"
+func adaptPortRanges(portRange string, portRanges []string, metadata defsecTypes.Metadata) []network.PortRange {
+	var adapted []network.PortRange
+	for _, r := range append([]string{portRange}, portRanges...) {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		if r == "*" {
+			adapted = append(adapted, network.PortRange{Metadata: metadata, Start: 0, End: 65535})
+			continue
+		}
+		start, end, _ := strings.Cut(r, "-")
+		s, err := strconv.Atoi(start)
+		if err != nil {
+			continue
+		}
+		e := s
+		if end != "" {
+			if e, err = strconv.Atoi(end); err != nil {
+				continue
+			}
+		}
+		adapted = append(adapted, network.PortRange{Metadata: metadata, Start: s, End: e})
+	}
+	return adapted
+}
@@ -0,0 +1,111 @@
+package adapter
+
+import (
+	"context"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/defsec/pkg/providers/azure/storage"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+const storageAPIVersion = "2022-09-01"
+
+type storageAccount struct {
+	ID         string `json:"id"`
+	Properties struct {
+		SupportsHTTPSTrafficOnly *bool  `json:"supportsHttpsTrafficOnly"`
+		MinimumTLSVersion        string `json:"minimumTlsVersion"`
+		NetworkACLs              *struct {
+			Bypass        string `json:"bypass"`
+			DefaultAction string `json:"defaultAction"`
+		} `json:"networkAcls"`
+	} `json:"properties"`
+}
+
+type blobContainer struct {
+	ID         string `json:"id"`
+	Properties struct {
+		PublicAccess string `json:"publicAccess"`
+	} `json:"properties"`
+}
+
+func adaptStorage(ctx context.Context, a *Adapter, st *state.State) error {
+	var accounts []storageAccount
+	err := list(ctx, a, "/subscriptions/"+a.subscriptionID+"/providers/Microsoft.Storage/storageAccounts",
+		storageAPIVersion, func(account storageAccount) {
+			accounts = append(accounts, account)
+		})
+	if err != nil {
+		return xerrors.Errorf("failed to list storage accounts: %w", err)
+	}
+
+	for _, account := range accounts {
+		adapted := adaptStorageAccount(account)
+
+		var containers []blobContainer
+		err = list(ctx, a, account.ID+"/blobServices/default/containers", storageAPIVersion, func(c blobContainer) {
+			containers = append(containers, c)
+		})
+		if err != nil {
+			return xerrors.Errorf("failed to list containers of %s: %w", account.ID, err)
+		}
+		for _, c := range containers {
+			adapted.Containers = append(adapted.Containers, adaptContainer(c))
+		}
+
+		st.Azure.Storage.Accounts = append(st.Azure.Storage.Accounts, adapted)
+	}
+	return nil
+}
+
+func adaptStorageAccount(account storageAccount) storage.Account {
+	metadata := defsecTypes.NewRemoteMetadata(account.ID)
+
+	// HTTPS only is enabled by default
+	enforceHTTPS := defsecTypes.BoolDefault(true, metadata)
+	if account.Properties.SupportsHTTPSTrafficOnly != nil {
+		enforceHTTPS = defsecTypes.Bool(*account.Properties.SupportsHTTPSTrafficOnly, metadata)
+	}
+
+	adapted := storage.Account{
+		Metadata:          metadata,
+		EnforceHTTPS:      enforceHTTPS,
+		MinimumTLSVersion: defsecTypes.String(account.Properties.MinimumTLSVersion, metadata),
+		// Queue logging is configured via the data plane, which is not queried
+		QueueProperties: storage.QueueProperties{
+			Metadata:      metadata,
+			EnableLogging: defsecTypes.BoolUnresolvable(metadata),
+		},
+	}
+
+	if acls := account.Properties.NetworkACLs; acls != nil {
+		rule := storage.NetworkRule{
+			Metadata:       metadata,
+			AllowByDefault: defsecTypes.Bool(strings.EqualFold(acls.DefaultAction, "Allow"), metadata),
+		}
+		for _, bypass := range strings.Split(acls.Bypass, ",") {
+			if bypass = strings.TrimSpace(bypass); bypass != "" && bypass != "None" {
+				rule.Bypass = append(rule.Bypass, defsecTypes.String(bypass, metadata))
+			}
+		}
+		adapted.NetworkRules = append(adapted.NetworkRules, rule)
+	}
+	return adapted
+}
+
+func adaptContainer(c blobContainer) storage.Container {
+	metadata := defsecTypes.NewRemoteMetadata(c.ID)
+
+	// The API returns "None", "Blob" or "Container" while defsec uses "off", "blob" and "container"
+	publicAccess := strings.ToLower(c.Properties.PublicAccess)
+	if publicAccess == "" || publicAccess == "none" {
+		publicAccess = storage.PublicAccessOff
+	}
+	return storage.Container{
+		Metadata:     metadata,
+		PublicAccess: defsecTypes.String(publicAccess, metadata),
+	}
+}
@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"golang.org/x/xerrors"
+)
+
+const redisKeyPrefix = "cloud"
+
+// backend stores cache records of cloud accounts
+type backend interface {
+	// get returns ErrCacheNotFound if the record doesn't exist
+	get(key string) ([]byte, error)
+	put(key string, b []byte) error
+}
+
+// fsBackend stores records in "<cache dir>/cloud/<key>/data.json"
+type fsBackend struct {
+	dir string
+}
+
+func newFSBackend(cacheDir string) fsBackend {
+	return fsBackend{dir: filepath.Join(cacheDir, "cloud")}
+}
+
+func (b fsBackend) path(key string) string {
+	return filepath.Join(b.dir, filepath.FromSlash(key), "data.json")
+}
+
+func (b fsBackend) get(key string) ([]byte, error) {
+	data, err := os.ReadFile(b.path(key))
+	if err != nil {
+		return nil, ErrCacheNotFound
+	}
+	return data, nil
+}
+
+func (b fsBackend) put(key string, data []byte) error {
+	p := b.path(key)
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0600)
+}
+
+// redisBackend stores records in "cloud::<key>", which expire after the TTL
+type redisBackend struct {
+	client     *redis.Client
+	expiration time.Duration
+}
+
+func newRedisBackend(options *redis.Options, expiration time.Duration) redisBackend {
+	return redisBackend{
+		client:     redis.NewClient(options),
+		expiration: expiration,
+	}
+}
+
+func (b redisBackend) get(key string) ([]byte, error) {
+	data, err := b.client.Get(context.TODO(), redisKeyPrefix+"::"+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCacheNotFound
+	} else if err != nil {
+		return nil, xerrors.Errorf("failed to get the cloud cache from redis: %w", err)
+	}
+	return data, nil
+}
+
+func (b redisBackend) put(key string, data []byte) error {
+	if err := b.client.Set(context.TODO(), redisKeyPrefix+"::"+key, data, b.expiration).Err(); err != nil {
+		return xerrors.Errorf("failed to store the cloud cache in redis: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/defsec/pkg/state"
+	"github.com/zhanglimao/trivy/pkg/commands/operation"
+	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// Cache stores the state of cloud accounts per provider, account and region
+type Cache struct {
+	backend   backend
+	key       string
+	accountID string
+	region    string
+	maxAge    time.Duration
+}
+
+const SchemaVersion = 2
+
+type CacheData struct {
+	SchemaVersion int                        `json:"schema_version"`
+	State         *state.State               `json:"state"`
+	Services      map[string]ServiceMetadata `json:"service_metadata"`
+	Updated       time.Time                  `json:"updated"`
+}
+
+type ServiceMetadata struct {
+	Name    string    `json:"name"`
+	Updated time.Time `json:"updated"`
+}
+
+var ErrCacheNotFound = fmt.Errorf("cache record not found")
+var ErrCacheIncompatible = fmt.Errorf("cache record used incomatible schema")
+var ErrCacheExpired = fmt.Errorf("cache record expired")
+
+// New returns the cache of the account stored in the cache directory,
+// e.g. an AWS account, an Azure subscription or a Google Cloud project.
+// The region is empty for providers whose accounts are not scanned per region.
+func New(cacheDir string, maxCacheAge time.Duration, provider, accountID, region string) *Cache {
+	return newCache(newFSBackend(cacheDir), maxCacheAge, provider, accountID, region)
+}
+
+// NewFromOptions returns the cache of the account stored in the cache backend, which is shared with image scans
+func NewFromOptions(opt flag.Options, provider, accountID, region string) (*Cache, error) {
+	if !strings.HasPrefix(opt.CacheBackend, "redis://") {
+		if opt.CacheTTL != 0 {
+			log.Logger.Warn("'--cache-ttl' is only available with Redis cache backend")
+		}
+		return New(opt.CacheDir, opt.MaxCacheAge, provider, accountID, region), nil
+	}
+
+	log.Logger.Infof("Redis cache: %s", opt.CacheBackendMasked())
+	options, err := operation.NewRedisOptions(opt.CacheOptions)
+	if err != nil {
+		return nil, xerrors.Errorf("redis option error: %w", err)
+	}
+	return newCache(newRedisBackend(options, opt.CacheTTL), opt.MaxCacheAge, provider, accountID, region), nil
+}
+
+func newCache(b backend, maxCacheAge time.Duration, provider, accountID, region string) *Cache {
+	return &Cache{
+		backend:   b,
+		key:       path.Join(strings.ToLower(provider), accountID, strings.ToLower(region)),
+		accountID: accountID,
+		region:    region,
+		maxAge:    maxCacheAge,
+	}
+}
+
+func (c *Cache) load() (*CacheData, error) {
+	b, err := c.backend.get(c.key)
+	if err != nil {
+		return nil, err
+	}
+
+	var data CacheData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+
+	if data.SchemaVersion != SchemaVersion {
+		return nil, ErrCacheIncompatible
+	}
+
+	if time.Since(data.Updated) > c.maxAge {
+		return nil, ErrCacheExpired
+	}
+
+	return &data, nil
+}
+
+func (c *Cache) ListServices(required []string) (included []string, missing []string) {
+
+	data, err := c.load()
+	if err != nil {
+		return nil, required
+	}
+
+	for _, service := range required {
+		metadata, ok := data.Services[service]
+		if !ok {
+			missing = append(missing, service)
+			continue
+		}
+		if time.Since(metadata.Updated) > c.maxAge {
+			missing = append(missing, service)
+			continue
+		}
+		included = append(included, service)
+	}
+
+	return included, missing
+}
+
+// ServicesToQuery returns the services loaded from the cache and the services to be queried from the cloud provider.
+// Cached services are queried again if "--update-cache" is specified or they are listed in "--refresh-service".
+func (c *Cache) ServicesToQuery(required []string, opt flag.CloudOptions) (cached []string, query []string) {
+	if opt.UpdateCache {
+		return nil, required
+	}
+
+	for _, service := range opt.RefreshServices {
+		if !slices.Contains(required, service) {
+			log.Logger.Warnf("'--refresh-service %s' is ignored as the service is not scanned", service)
+		}
+	}
+
+	included, missing := c.ListServices(required)
+	for _, service := range included {
+		if slices.Contains(opt.RefreshServices, service) {
+			missing = append(missing, service)
+			continue
+		}
+		cached = append(cached, service)
+	}
+	return cached, missing
+}
+
+func (c *Cache) LoadState() (*state.State, error) {
+	data, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return data.State, nil
+}
+
+func (c *Cache) AddServices(state *state.State, includedServices []string) error {
+
+	data := &CacheData{
+		SchemaVersion: SchemaVersion,
+		State:         state,
+		Services:      map[string]ServiceMetadata{},
+		Updated:       time.Now(),
+	}
+
+	if previous, err := c.load(); err == nil {
+		data.Services = previous.Services
+	}
+
+	for _, service := range includedServices {
+		data.Services[service] = ServiceMetadata{
+			Name:    service,
+			Updated: time.Now(),
+		}
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return c.backend.put(c.key, b)
+}
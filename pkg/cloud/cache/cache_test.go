@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/defsec/pkg/state"
+	"github.com/zhanglimao/trivy/pkg/flag"
+)
+
+func TestCache_ServicesToQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		cached     []string
+		opt        flag.CloudOptions
+		wantCached []string
+		wantQuery  []string
+	}{
+		{
+			name:      "no cache",
+			wantQuery: []string{"ec2", "s3"},
+		},
+		{
+			name:       "partially cached",
+			cached:     []string{"s3"},
+			wantCached: []string{"s3"},
+			wantQuery:  []string{"ec2"},
+		},
+		{
+			name:       "refresh service",
+			cached:     []string{"ec2", "s3"},
+			opt:        flag.CloudOptions{RefreshServices: []string{"s3", "rds"}},
+			wantCached: []string{"ec2"},
+			wantQuery:  []string{"s3"},
+		},
+		{
+			name:      "update cache",
+			cached:    []string{"ec2", "s3"},
+			opt:       flag.CloudOptions{UpdateCache: true},
+			wantQuery: []string{"ec2", "s3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(t.TempDir(), time.Hour, "AWS", "12345678", "us-east-1")
+			if len(tt.cached) > 0 {
+				require.NoError(t, c.AddServices(&state.State{}, tt.cached))
+			}
+
+			gotCached, gotQuery := c.ServicesToQuery([]string{"ec2", "s3"}, tt.opt)
+			assert.Equal(t, tt.wantCached, gotCached)
+			assert.Equal(t, tt.wantQuery, gotQuery)
+		})
+	}
+}
+
+func TestNewFromOptions(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+
+	opt := flag.Options{
+		GlobalOptions: flag.GlobalOptions{CacheDir: t.TempDir()},
+		CacheOptions: flag.CacheOptions{
+			CacheBackend: "redis://" + s.Addr(),
+			CacheTTL:     time.Hour,
+		},
+		CloudOptions: flag.CloudOptions{MaxCacheAge: time.Hour},
+	}
+
+	c, err := NewFromOptions(opt, "Azure", "sub", "")
+	require.NoError(t, err)
+	require.NoError(t, c.AddServices(&state.State{}, []string{"storage"}))
+
+	// The cache is shared via redis
+	key := "cloud::azure/sub"
+	assert.True(t, s.Exists(key))
+	assert.Equal(t, time.Hour, s.TTL(key))
+
+	c, err = NewFromOptions(opt, "Azure", "sub", "")
+	require.NoError(t, err)
+	included, missing := c.ListServices([]string{"storage", "network"})
+	assert.Equal(t, []string{"storage"}, included)
+	assert.Equal(t, []string{"network"}, missing)
+
+	// The record expires
+	s.FastForward(2 * time.Hour)
+	included, _ = c.ListServices([]string{"storage"})
+	assert.Empty(t, included)
+}
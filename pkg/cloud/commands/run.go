@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/cloud"
+	"github.com/zhanglimao/trivy/pkg/cloud/report"
+	"github.com/zhanglimao/trivy/pkg/cloud/scanner"
+	"github.com/zhanglimao/trivy/pkg/commands/operation"
+	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// Run scans the cloud account with the adapter and writes the report in the same way as AWS.
+// "opt.Services" and "opt.ARN" hold the services to scan and the resource to show results for.
+func Run(ctx context.Context, opt flag.Options, provider string, adapter scanner.Adapter) error {
+	defer func() {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			log.Logger.Warn("Increase --timeout value")
+		}
+	}()
+
+	if err := processServices(&opt, provider, adapter.SupportedServices()); err != nil {
+		return err
+	}
+
+	results, cached, err := scanner.NewScanner(adapter).Scan(ctx, opt, opt.Services)
+	if err != nil {
+		return xerrors.Errorf("%s scan error: %w", strings.ToLower(provider), err)
+	}
+
+	log.Logger.Debug("Writing report to output...")
+	r := report.New(provider, adapter.AccountID(), "", results.GetFailed(), opt.Services)
+	if err := report.Write(r, opt, cached); err != nil {
+		return xerrors.Errorf("unable to write results: %w", err)
+	}
+
+	operation.Exit(opt, r.Failed())
+	return nil
+}
+
+func processServices(opt *flag.Options, provider string, supported []string) error {
+	// support comma separated services too
+	var splitServices []string
+	for _, service := range opt.Services {
+		splitServices = append(splitServices, strings.Split(service, ",")...)
+	}
+	opt.Services = splitServices
+
+	if len(opt.Services) != 1 && opt.ARN != "" && !cloud.IsResourcePattern(opt.ARN) {
+		return xerrors.New("you must specify the single --service which the resource relates to")
+	}
+
+	if len(opt.Services) == 0 {
+		log.Logger.Debug("No service(s) specified, scanning all services...")
+		opt.Services = supported
+		return nil
+	}
+
+	log.Logger.Debugf("Specific services were requested: [%s]...", strings.Join(opt.Services, ", "))
+	for _, service := range opt.Services {
+		if !slices.Contains(supported, service) {
+			return xerrors.Errorf("service '%s' is not currently supported by %s - supported services are: %s",
+				service, provider, strings.Join(supported, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package adapter
+
+import (
+	"context"
+	"sort"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/oauth2/google"
+	"golang.org/x/xerrors"
+	"google.golang.org/api/option"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/state"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+type adaptFunc func(ctx context.Context, a *Adapter, st *state.State) error
+
+var adapters = map[string]adaptFunc{
+	"compute": adaptCompute,
+	"storage": adaptStorage,
+}
+
+// AllSupportedServices returns the Google Cloud services which can be scanned
+func AllSupportedServices() []string {
+	services := maps.Keys(adapters)
+	sort.Strings(services)
+	return services
+}
+
+// Adapter queries the Google Cloud APIs to populate the state of the project
+type Adapter struct {
+	projectID string
+
+	// clientOptions is used to create the client of each service
+	clientOptions []option.ClientOption
+}
+
+// NewAdapter authenticates with the Application Default Credentials.
+// The project of the credentials is scanned if it is not specified.
+func NewAdapter(ctx context.Context, projectID string, opts ...option.ClientOption) (*Adapter, error) {
+	if projectID == "" {
+		log.Logger.Debug("Looking for Google Cloud credentials...")
+		cred, err := google.FindDefaultCredentials(ctx)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to find Google Cloud credentials: %w", err)
+		}
+		if cred.ProjectID == "" {
+			return nil, xerrors.New("no Google Cloud project found in the credentials, specify it with --project")
+		}
+		projectID = cred.ProjectID
+	}
+	log.Logger.Debugf("Scanning Google Cloud project %s", projectID)
+	return &Adapter{
+		projectID:     projectID,
+		clientOptions: opts,
+	}, nil
+}
+
+func (a *Adapter) Provider() providers.Provider {
+	return providers.GoogleProvider
+}
+
+func (a *Adapter) AccountID() string {
+	return a.projectID
+}
+
+func (a *Adapter) SupportedServices() []string {
+	return AllSupportedServices()
+}
+
+func (a *Adapter) Adapt(ctx context.Context, service string, st *state.State) error {
+	adapt, ok := adapters[service]
+	if !ok {
+		return xerrors.Errorf("unsupported service: %s", service)
+	}
+	return adapt(ctx, a, st)
+}
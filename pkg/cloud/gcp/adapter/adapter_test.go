@@ -0,0 +1,86 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+func newTestAdapter(t *testing.T, files map[string]string) *Adapter {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeFile(w, r, file)
+	}))
+	t.Cleanup(ts.Close)
+
+	a, err := NewAdapter(context.Background(), "my-project",
+		option.WithEndpoint(ts.URL+"/"), option.WithoutAuthentication())
+	require.NoError(t, err)
+	return a
+}
+
+func TestAdapter_Adapt(t *testing.T) {
+	a := newTestAdapter(t, map[string]string{
+		"/b":                                    "testdata/buckets.json",
+		"/b/example/iam":                        "testdata/bucket-iam.json",
+		"/projects/my-project/global/firewalls": "testdata/firewalls.json",
+	})
+
+	t.Run("storage", func(t *testing.T) {
+		st := &state.State{}
+		require.NoError(t, a.Adapt(context.Background(), "storage", st))
+		require.Len(t, st.Google.Storage.Buckets, 1)
+
+		bucket := st.Google.Storage.Buckets[0]
+		assert.Equal(t, "https://www.googleapis.com/storage/v1/b/example", bucket.Metadata.Reference())
+		assert.Equal(t, "example", bucket.Name.Value())
+		assert.True(t, bucket.EnableUniformBucketLevelAccess.IsFalse())
+		assert.Empty(t, bucket.Encryption.DefaultKMSKeyName.Value())
+		require.Len(t, bucket.Bindings, 1)
+		assert.Equal(t, "roles/storage.objectViewer", bucket.Bindings[0].Role.Value())
+		assert.Equal(t, []string{"allUsers"}, defsecTypes.StringValueList(bucket.Bindings[0].Members).AsStrings())
+	})
+
+	t.Run("compute", func(t *testing.T) {
+		st := &state.State{}
+		require.NoError(t, a.Adapt(context.Background(), "compute", st))
+		require.Len(t, st.Google.Compute.Networks, 1)
+
+		network := st.Google.Compute.Networks[0]
+		assert.Equal(t, "https://www.googleapis.com/compute/v1/projects/my-project/global/networks/default",
+			network.Metadata.Reference())
+		require.NotNil(t, network.Firewall)
+		assert.Equal(t, "default", network.Firewall.Name.Value())
+		assert.Equal(t, []string{"ssh"}, defsecTypes.StringValueList(network.Firewall.TargetTags).AsStrings())
+
+		require.Len(t, network.Firewall.IngressRules, 1)
+		ingress := network.Firewall.IngressRules[0]
+		assert.True(t, ingress.IsAllow.IsTrue())
+		assert.True(t, ingress.Enforced.IsTrue())
+		assert.Equal(t, "tcp", ingress.Protocol.Value())
+		assert.Equal(t, []string{"0.0.0.0/0"}, defsecTypes.StringValueList(ingress.SourceRanges).AsStrings())
+		var ports []int
+		for _, p := range ingress.Ports {
+			ports = append(ports, p.Value())
+		}
+		assert.Equal(t, []int{22, 8000, 8001, 8002}, ports)
+
+		require.Len(t, network.Firewall.EgressRules, 1)
+		egress := network.Firewall.EgressRules[0]
+		assert.True(t, egress.IsAllow.IsFalse())
+		assert.True(t, egress.Enforced.IsFalse())
+		assert.Equal(t, []string{"10.0.0.0/8"}, defsecTypes.StringValueList(egress.DestinationRanges).AsStrings())
+	})
+}
@@ -0,0 +1,139 @@
+package adapter
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+	gce "google.golang.org/api/compute/v1"
+
+	"github.com/aquasecurity/defsec/pkg/providers/google/compute"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+func adaptCompute(ctx context.Context, a *Adapter, st *state.State) error {
+	svc, err := gce.NewService(ctx, a.clientOptions...)
+	if err != nil {
+		return xerrors.Errorf("failed to create a compute client: %w", err)
+	}
+
+	var firewalls []*gce.Firewall
+	err = svc.Firewalls.List(a.projectID).Pages(ctx, func(page *gce.FirewallList) error {
+		firewalls = append(firewalls, page.Items...)
+		return nil
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to list firewalls: %w", err)
+	}
+
+	st.Google.Compute.Networks = append(st.Google.Compute.Networks, adaptNetworks(firewalls)...)
+	return nil
+}
+
+// adaptNetworks groups the firewall rules by the network they apply to
+func adaptNetworks(firewalls []*gce.Firewall) []compute.Network {
+	networks := make(map[string]*compute.Network)
+	for _, fw := range firewalls {
+		network, ok := networks[fw.Network]
+		if !ok {
+			metadata := defsecTypes.NewRemoteMetadata(fw.Network)
+			network = &compute.Network{
+				Metadata: metadata,
+				Firewall: &compute.Firewall{
+					Metadata: metadata,
+					Name:     defsecTypes.String(fw.Network[strings.LastIndex(fw.Network, "/")+1:], metadata),
+				},
+			}
+			networks[fw.Network] = network
+		}
+		adaptFirewall(network.Firewall, fw)
+	}
+
+	var adapted []compute.Network
+	for _, network := range networks {
+		adapted = append(adapted, *network)
+	}
+	sort.Slice(adapted, func(i, j int) bool {
+		return adapted[i].Metadata.Reference() < adapted[j].Metadata.Reference()
+	})
+	return adapted
+}
+
+func adaptFirewall(firewall *compute.Firewall, fw *gce.Firewall) {
+	metadata := defsecTypes.NewRemoteMetadata(fw.SelfLink)
+	for _, tag := range fw.SourceTags {
+		firewall.SourceTags = append(firewall.SourceTags, defsecTypes.String(tag, metadata))
+	}
+	for _, tag := range fw.TargetTags {
+		firewall.TargetTags = append(firewall.TargetTags, defsecTypes.String(tag, metadata))
+	}
+
+	var rules []compute.FirewallRule
+	for _, allowed := range fw.Allowed {
+		rules = append(rules, adaptFirewallRule(metadata, true, !fw.Disabled, allowed.IPProtocol, allowed.Ports))
+	}
+	for _, denied := range fw.Denied {
+		rules = append(rules, adaptFirewallRule(metadata, false, !fw.Disabled, denied.IPProtocol, denied.Ports))
+	}
+
+	for _, rule := range rules {
+		if fw.Direction == "EGRESS" {
+			firewall.EgressRules = append(firewall.EgressRules, compute.EgressRule{
+				Metadata:          metadata,
+				FirewallRule:      rule,
+				DestinationRanges: stringValues(fw.DestinationRanges, metadata),
+			})
+			continue
+		}
+		firewall.IngressRules = append(firewall.IngressRules, compute.IngressRule{
+			Metadata:     metadata,
+			FirewallRule: rule,
+			SourceRanges: stringValues(fw.SourceRanges, metadata),
+		})
+	}
+}
+
+func adaptFirewallRule(metadata defsecTypes.Metadata, allow, enforced bool, protocol string, ports []string) compute.FirewallRule {
+	rule := compute.FirewallRule{
+		Metadata: metadata,
+		Enforced: defsecTypes.Bool(enforced, metadata),
+		IsAllow:  defsecTypes.Bool(allow, metadata),
+		Protocol: defsecTypes.String(protocol, metadata),
+	}
+	for _, p := range ports {
+		rule.Ports = append(rule.Ports, expandPorts(p, metadata)...)
+	}
+	return rule
+}
+
+// expandPorts expands port ranges such as "1000-2000" in the same way as the Terraform adapter of defsec
+func expandPorts(ports string, metadata defsecTypes.Metadata) []defsecTypes.IntValue {
+	start, end, found := strings.Cut(strings.ReplaceAll(ports, " ", ""), "-")
+	s, err := strconv.Atoi(start)
+	if err != nil {
+		return nil
+	}
+	if !found {
+		return []defsecTypes.IntValue{defsecTypes.Int(s, metadata)}
+	}
+	e, err := strconv.Atoi(end)
+	if err != nil {
+		return nil
+	}
+	var expanded []defsecTypes.IntValue
+	for i := s; i <= e; i++ {
+		expanded = append(expanded, defsecTypes.Int(i, metadata))
+	}
+	return expanded
+}
+
+func stringValues(values []string, metadata defsecTypes.Metadata) []defsecTypes.StringValue {
+	var adapted []defsecTypes.StringValue
+	for _, v := range values {
+		adapted = append(adapted, defsecTypes.String(v, metadata))
+	}
+	return adapted
+}
@@ -0,0 +1,77 @@
+package adapter
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+	gcs "google.golang.org/api/storage/v1"
+
+	"github.com/aquasecurity/defsec/pkg/providers/google/iam"
+	"github.com/aquasecurity/defsec/pkg/providers/google/storage"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+func adaptStorage(ctx context.Context, a *Adapter, st *state.State) error {
+	svc, err := gcs.NewService(ctx, a.clientOptions...)
+	if err != nil {
+		return xerrors.Errorf("failed to create a storage client: %w", err)
+	}
+
+	var buckets []*gcs.Bucket
+	err = svc.Buckets.List(a.projectID).Pages(ctx, func(page *gcs.Buckets) error {
+		buckets = append(buckets, page.Items...)
+		return nil
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to list buckets: %w", err)
+	}
+
+	for _, bucket := range buckets {
+		policy, err := svc.Buckets.GetIamPolicy(bucket.Name).Context(ctx).Do()
+		if err != nil {
+			return xerrors.Errorf("failed to get the IAM policy of %s: %w", bucket.Name, err)
+		}
+		st.Google.Storage.Buckets = append(st.Google.Storage.Buckets, adaptBucket(bucket, policy))
+	}
+	return nil
+}
+
+func adaptBucket(bucket *gcs.Bucket, policy *gcs.Policy) storage.Bucket {
+	metadata := defsecTypes.NewRemoteMetadata(bucket.SelfLink)
+
+	var uniformAccess bool
+	if c := bucket.IamConfiguration; c != nil && c.UniformBucketLevelAccess != nil {
+		uniformAccess = c.UniformBucketLevelAccess.Enabled
+	}
+	var kmsKeyName string
+	if bucket.Encryption != nil {
+		kmsKeyName = bucket.Encryption.DefaultKmsKeyName
+	}
+
+	adapted := storage.Bucket{
+		Metadata:                       metadata,
+		Name:                           defsecTypes.String(bucket.Name, metadata),
+		Location:                       defsecTypes.String(bucket.Location, metadata),
+		EnableUniformBucketLevelAccess: defsecTypes.Bool(uniformAccess, metadata),
+		Encryption: storage.BucketEncryption{
+			Metadata:          metadata,
+			DefaultKMSKeyName: defsecTypes.String(kmsKeyName, metadata),
+		},
+	}
+	if policy == nil {
+		return adapted
+	}
+	for _, binding := range policy.Bindings {
+		b := iam.Binding{
+			Metadata:                      metadata,
+			Role:                          defsecTypes.String(binding.Role, metadata),
+			IncludesDefaultServiceAccount: defsecTypes.BoolDefault(false, metadata),
+		}
+		for _, member := range binding.Members {
+			b.Members = append(b.Members, defsecTypes.String(member, metadata))
+		}
+		adapted.Bindings = append(adapted.Bindings, b)
+	}
+	return adapted
+}
@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/cloud"
+	"github.com/zhanglimao/trivy/pkg/cloud/commands"
+	"github.com/zhanglimao/trivy/pkg/cloud/gcp/adapter"
+	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+func Run(ctx context.Context, opt flag.Options) error {
+	ctx, cancel := context.WithTimeout(ctx, opt.GlobalOptions.Timeout)
+	defer cancel()
+
+	if err := log.InitLogger(opt.Debug, false); err != nil {
+		return xerrors.Errorf("logger error: %w", err)
+	}
+
+	a, err := adapter.NewAdapter(ctx, opt.Project)
+	if err != nil {
+		return xerrors.Errorf("gcp error: %w", err)
+	}
+
+	// The cloud report filters results by the services and the resource in the same way as AWS
+	opt.Services = opt.GoogleServices
+	opt.ARN = opt.GoogleResource
+
+	return commands.Run(ctx, opt, cloud.ProviderGoogle, a)
+}
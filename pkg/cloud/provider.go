@@ -1,5 +1,7 @@
 package cloud
 
 const (
-	ProviderAWS = "AWS"
+	ProviderAWS    = "AWS"
+	ProviderAzure  = "Azure"
+	ProviderGoogle = "Google"
 )
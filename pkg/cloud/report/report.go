@@ -8,6 +8,7 @@ import (
 
 	"github.com/aquasecurity/defsec/pkg/scan"
 	"github.com/aquasecurity/tml"
+	"github.com/zhanglimao/trivy/pkg/cloud"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/flag"
 	pkgReport "github.com/zhanglimao/trivy/pkg/report"
@@ -19,7 +20,7 @@ const (
 	tableFormat = "table"
 )
 
-// Report represents an AWS scan report
+// Report represents a cloud account scan report
 type Report struct {
 	Provider        string
 	AccountID       string
@@ -43,7 +44,7 @@ func New(provider, accountID, region string, defsecResults scan.Results, scopedS
 	}
 }
 
-// Failed returns whether the aws report includes any "failed" results
+// Failed returns whether the cloud report includes any "failed" results
 func (r *Report) Failed() bool {
 	for _, set := range r.Results {
 		if set.Results.Failed() {
@@ -78,9 +79,10 @@ func Write(rep *Report, opt flag.Options, fromCache bool) error {
 	})
 
 	base := types.Report{
-		ArtifactName: rep.AccountID,
-		ArtifactType: ftypes.ArtifactAWSAccount,
-		Results:      filtered,
+		SchemaVersion: pkgReport.SchemaVersion,
+		ArtifactName:  rep.AccountID,
+		ArtifactType:  artifactType(rep.Provider),
+		Results:       filtered,
 	}
 
 	switch opt.Format {
@@ -97,12 +99,14 @@ func Write(rep *Report, opt flag.Options, fromCache bool) error {
 			tml.DisableFormatting()
 		}
 
+		// Wildcards in the ARN select resources rather than showing the results of a single resource
+		singleARN := opt.ARN != "" && !cloud.IsResourcePattern(opt.ARN)
 		switch {
-		case len(opt.Services) == 1 && opt.ARN == "":
+		case len(opt.Services) == 1 && !singleARN:
 			if err := writeResourceTable(rep, filtered, opt.Output, opt.Services[0]); err != nil {
 				return err
 			}
-		case len(opt.Services) == 1 && opt.ARN != "":
+		case len(opt.Services) == 1 && singleARN:
 			if err := writeResultsForARN(rep, filtered, opt.Output, opt.Services[0], opt.ARN, opt.Severities); err != nil {
 				return err
 			}
@@ -129,3 +133,14 @@ func Write(rep *Report, opt flag.Options, fromCache bool) error {
 		})
 	}
 }
+
+func artifactType(provider string) ftypes.ArtifactType {
+	switch provider {
+	case cloud.ProviderAzure:
+		return ftypes.ArtifactAzureSubscription
+	case cloud.ProviderGoogle:
+		return ftypes.ArtifactGoogleProject
+	default:
+		return ftypes.ArtifactAWSAccount
+	}
+}
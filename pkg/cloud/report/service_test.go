@@ -150,6 +150,7 @@ Scan Overview for AWS Account
 			},
 			fromCache: false,
 			expected: `{
+  "SchemaVersion": 2,
   "ArtifactType": "aws_account",
   "Metadata": {
     "ImageConfig": {
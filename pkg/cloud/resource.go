@@ -0,0 +1,46 @@
+package cloud
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+// IsResourcePattern returns whether the resource filter, such as "--arn", contains wildcards
+func IsResourcePattern(s string) bool {
+	return strings.ContainsAny(s, "*?")
+}
+
+// MatchResource returns whether the resource ID matches the pattern.
+// '*' matches any sequence of characters including '/' and ':', and '?' matches any single character,
+// e.g. "arn:aws:s3:::prod-*" matches all the S3 buckets prefixed with "prod-".
+func MatchResource(pattern, resource string) bool {
+	if !IsResourcePattern(pattern) {
+		return pattern == resource
+	}
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	matched, _ := regexp.MatchString("^"+expr+"$", resource)
+	return matched
+}
+
+// SelectResults returns the results of the resources matching the pattern.
+// If resources is not nil, the results are also limited to them, e.g. resources with the specified tags.
+func SelectResults(results scan.Results, pattern string, resources map[string]struct{}) scan.Results {
+	var selected scan.Results
+	for _, result := range results {
+		resource := result.Flatten().Resource
+		if pattern != "" && !MatchResource(pattern, resource) {
+			continue
+		}
+		if resources != nil {
+			if _, ok := resources[resource]; !ok {
+				continue
+			}
+		}
+		selected = append(selected, result)
+	}
+	return selected
+}
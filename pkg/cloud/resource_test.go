@@ -0,0 +1,115 @@
+package cloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+func TestMatchResource(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		resource string
+		want     bool
+	}{
+		{
+			name:     "exact match",
+			pattern:  "arn:aws:s3:::example",
+			resource: "arn:aws:s3:::example",
+			want:     true,
+		},
+		{
+			name:     "exact mismatch",
+			pattern:  "arn:aws:s3:::example",
+			resource: "arn:aws:s3:::example-2",
+			want:     false,
+		},
+		{
+			name:     "prefix wildcard",
+			pattern:  "arn:aws:s3:::prod-*",
+			resource: "arn:aws:s3:::prod-logs",
+			want:     true,
+		},
+		{
+			name:     "wildcard across separators",
+			pattern:  "arn:aws:ec2:*:123456789012:*",
+			resource: "arn:aws:ec2:us-east-1:123456789012:security-group/sg-1234",
+			want:     true,
+		},
+		{
+			name:     "single character",
+			pattern:  "arn:aws:s3:::app-?",
+			resource: "arn:aws:s3:::app-12",
+			want:     false,
+		},
+		{
+			name:     "meta characters are literal",
+			pattern:  "arn:aws:s3:::app.*",
+			resource: "arn:aws:s3:::app-1",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchResource(tt.pattern, tt.resource))
+		})
+	}
+}
+
+func TestSelectResults(t *testing.T) {
+	var results scan.Results
+	for _, arn := range []string{
+		"arn:aws:s3:::prod-logs",
+		"arn:aws:s3:::prod-data",
+		"arn:aws:s3:::dev-logs",
+	} {
+		results.Add("issue", defsecTypes.NewRemoteMetadata(arn))
+	}
+
+	tests := []struct {
+		name      string
+		pattern   string
+		resources map[string]struct{}
+		want      []string
+	}{
+		{
+			name:    "pattern",
+			pattern: "arn:aws:s3:::prod-*",
+			want:    []string{"arn:aws:s3:::prod-logs", "arn:aws:s3:::prod-data"},
+		},
+		{
+			name: "resources",
+			resources: map[string]struct{}{
+				"arn:aws:s3:::prod-data": {},
+				"arn:aws:s3:::dev-logs":  {},
+			},
+			want: []string{"arn:aws:s3:::prod-data", "arn:aws:s3:::dev-logs"},
+		},
+		{
+			name:    "pattern and resources",
+			pattern: "arn:aws:s3:::*-logs",
+			resources: map[string]struct{}{
+				"arn:aws:s3:::prod-data": {},
+				"arn:aws:s3:::dev-logs":  {},
+			},
+			want: []string{"arn:aws:s3:::dev-logs"},
+		},
+		{
+			name:      "no tagged resources",
+			resources: map[string]struct{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, result := range SelectResults(results, tt.pattern, tt.resources) {
+				got = append(got, result.Flatten().Resource)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package scanner
+
+import (
+	"context"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/defsec/pkg/framework"
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/aws"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/aquasecurity/defsec/pkg/state"
+	"github.com/zhanglimao/trivy/pkg/cloud"
+	"github.com/zhanglimao/trivy/pkg/cloud/cache"
+	"github.com/zhanglimao/trivy/pkg/commands/operation"
+	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// Adapter queries the services of a cloud account and populates the state with their resources.
+// It is implemented per cloud provider which defsec has no cloud scanner for, such as Azure and Google Cloud.
+type Adapter interface {
+	// Provider returns the cloud provider, which the results are filtered by
+	Provider() providers.Provider
+
+	// AccountID returns the ID of the account, e.g. an Azure subscription or a Google Cloud project
+	AccountID() string
+
+	// SupportedServices returns the services the adapter can query
+	SupportedServices() []string
+
+	// Adapt queries the service and adds its resources to the state
+	Adapt(ctx context.Context, service string, st *state.State) error
+}
+
+// Scanner scans a cloud account with the adapter.
+// The state of the account is cached in the same way as AWS.
+type Scanner struct {
+	adapter Adapter
+}
+
+func NewScanner(adapter Adapter) *Scanner {
+	return &Scanner{adapter: adapter}
+}
+
+// Scan returns the results of the services and whether the cached state is used
+func (s *Scanner) Scan(ctx context.Context, option flag.Options, services []string) (scan.Results, bool, error) {
+	provider := s.adapter.Provider()
+	cloudCache, err := cache.NewFromOptions(option, string(provider), s.adapter.AccountID(), "")
+	if err != nil {
+		return nil, false, xerrors.Errorf("cache error: %w", err)
+	}
+	included, missing := cloudCache.ServicesToQuery(services, option.CloudOptions)
+
+	freshState := &state.State{}
+	var fetched []string
+	for _, service := range missing {
+		if err := s.adapt(ctx, service, freshState, option); err != nil {
+			return nil, false, xerrors.Errorf("failed to query %s: %w", service, err)
+		}
+		fetched = append(fetched, service)
+	}
+
+	fullState := freshState
+	if previousState, err := cloudCache.LoadState(); err == nil {
+		if fullState, err = previousState.Merge(freshState); err != nil {
+			return nil, false, xerrors.Errorf("failed to merge the cached state: %w", err)
+		}
+	}
+
+	if err := cloudCache.AddServices(fullState, fetched); err != nil {
+		return nil, false, xerrors.Errorf("failed to cache the state: %w", err)
+	}
+
+	results, err := evaluate(ctx, fullState, option)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to evaluate policies: %w", err)
+	}
+
+	// The policies of the other providers are evaluated against the empty state as well
+	var filtered scan.Results
+	for _, result := range results {
+		if result.Rule().Provider == provider {
+			filtered = append(filtered, result)
+		}
+	}
+
+	// Wildcards select the matching resources
+	if cloud.IsResourcePattern(option.ARN) {
+		filtered = cloud.SelectResults(filtered, option.ARN, nil)
+	}
+	return filtered, len(included) > 0, nil
+}
+
+func (s *Scanner) adapt(ctx context.Context, service string, st *state.State, option flag.Options) error {
+	if option.CloudOptions.ServiceTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, option.CloudOptions.ServiceTimeout)
+		defer cancel()
+	}
+	log.Logger.Debugf("Querying %s...", service)
+	return s.adapter.Adapt(ctx, service, st)
+}
+
+// evaluate evaluates the built-in and custom policies against the state.
+// The evaluation of the defsec AWS scanner does not depend on the provider.
+func evaluate(ctx context.Context, st *state.State, option flag.Options) (scan.Results, error) {
+	var scannerOpts []options.ScannerOption
+	if option.Debug {
+		scannerOpts = append(scannerOpts, options.ScannerWithDebug(&defsecLogger{}))
+	}
+	if option.Trace {
+		scannerOpts = append(scannerOpts, options.ScannerWithTrace(&defsecLogger{}))
+	}
+
+	var policyPaths []string
+	downloadedPolicyPaths, err := operation.InitBuiltinPolicies(ctx, option.CacheDir, option.Quiet, option.SkipPolicyUpdate)
+	if err != nil {
+		if !option.SkipPolicyUpdate {
+			log.Logger.Errorf("Falling back to embedded policies: %s", err)
+		}
+	} else {
+		log.Logger.Debug("Policies successfully loaded from disk")
+		policyPaths = append(policyPaths, downloadedPolicyPaths...)
+		scannerOpts = append(scannerOpts, options.ScannerWithEmbeddedPolicies(false))
+	}
+	policyPaths = append(policyPaths, option.RegoOptions.PolicyPaths...)
+	scannerOpts = append(scannerOpts, options.ScannerWithPolicyDirs(policyPaths...))
+
+	if len(option.RegoOptions.PolicyNamespaces) > 0 {
+		scannerOpts = append(scannerOpts, options.ScannerWithPolicyNamespaces(option.RegoOptions.PolicyNamespaces...))
+	}
+
+	scannerOpts = append(scannerOpts, options.ScannerWithFrameworks(framework.Default))
+
+	return aws.New(scannerOpts...).Scan(ctx, st)
+}
+
+type defsecLogger struct {
+}
+
+func (d *defsecLogger) Write(p []byte) (n int, err error) {
+	log.Logger.Debug("[defsec] " + strings.TrimSpace(string(p)))
+	return len(p), nil
+}
@@ -0,0 +1,99 @@
+package scanner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/providers/azure/storage"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/flag"
+)
+
+const accountID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account"
+
+type fakeAdapter struct {
+	adapted []string
+}
+
+func (a *fakeAdapter) Provider() providers.Provider {
+	return providers.AzureProvider
+}
+
+func (a *fakeAdapter) AccountID() string {
+	return "sub"
+}
+
+func (a *fakeAdapter) SupportedServices() []string {
+	return []string{"storage"}
+}
+
+func (a *fakeAdapter) Adapt(_ context.Context, service string, st *state.State) error {
+	a.adapted = append(a.adapted, service)
+	metadata := defsecTypes.NewRemoteMetadata(accountID)
+	st.Azure.Storage.Accounts = append(st.Azure.Storage.Accounts, storage.Account{
+		Metadata:          metadata,
+		EnforceHTTPS:      defsecTypes.Bool(false, metadata),
+		MinimumTLSVersion: defsecTypes.String("TLS1_2", metadata),
+		QueueProperties: storage.QueueProperties{
+			Metadata:      metadata,
+			EnableLogging: defsecTypes.BoolUnresolvable(metadata),
+		},
+	})
+	return nil
+}
+
+func TestScanner_Scan(t *testing.T) {
+	opt := flag.Options{
+		GlobalOptions: flag.GlobalOptions{
+			CacheDir: t.TempDir(),
+		},
+		CloudOptions: flag.CloudOptions{
+			MaxCacheAge: time.Hour,
+		},
+		RegoOptions: flag.RegoOptions{SkipPolicyUpdate: true},
+	}
+
+	adapter := &fakeAdapter{}
+	s := NewScanner(adapter)
+
+	// The first scan queries the service
+	results, cached, err := s.Scan(context.Background(), opt, []string{"storage"})
+	require.NoError(t, err)
+	assert.False(t, cached)
+	assert.Equal(t, []string{"storage"}, adapter.adapted)
+
+	var failed []string
+	for _, result := range results.GetFailed() {
+		assert.Equal(t, providers.AzureProvider, result.Rule().Provider)
+		failed = append(failed, result.Rule().AVDID)
+	}
+	assert.Contains(t, failed, "AVD-AZU-0008") // HTTPS is not enforced
+
+	// The second scan uses the cache
+	cachedResults, cached, err := s.Scan(context.Background(), opt, []string{"storage"})
+	require.NoError(t, err)
+	assert.True(t, cached)
+	assert.Equal(t, []string{"storage"}, adapter.adapted)
+	assert.Len(t, cachedResults, len(results))
+
+	// The service is queried again when it is refreshed
+	opt.CloudOptions.RefreshServices = []string{"storage"}
+	_, cached, err = s.Scan(context.Background(), opt, []string{"storage"})
+	require.NoError(t, err)
+	assert.False(t, cached)
+	assert.Equal(t, []string{"storage", "storage"}, adapter.adapted)
+
+	// All the services are queried again when the cache is updated
+	opt.CloudOptions.RefreshServices = nil
+	opt.CloudOptions.UpdateCache = true
+	_, cached, err = s.Scan(context.Background(), opt, []string{"storage"})
+	require.NoError(t, err)
+	assert.False(t, cached)
+	assert.Equal(t, []string{"storage", "storage", "storage"}, adapter.adapted)
+}
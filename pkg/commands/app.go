@@ -18,16 +18,27 @@ import (
 	"github.com/aquasecurity/trivy-db/pkg/metadata"
 	javadb "github.com/aquasecurity/trivy-java-db/pkg/db"
 	awscommands "github.com/zhanglimao/trivy/pkg/cloud/aws/commands"
+	azureAdapter "github.com/zhanglimao/trivy/pkg/cloud/azure/adapter"
+	azurecommands "github.com/zhanglimao/trivy/pkg/cloud/azure/commands"
+	gcpAdapter "github.com/zhanglimao/trivy/pkg/cloud/gcp/adapter"
+	gcpcommands "github.com/zhanglimao/trivy/pkg/cloud/gcp/commands"
 	"github.com/zhanglimao/trivy/pkg/commands/artifact"
+	"github.com/zhanglimao/trivy/pkg/commands/attest"
+	"github.com/zhanglimao/trivy/pkg/commands/bundle"
 	"github.com/zhanglimao/trivy/pkg/commands/convert"
+	"github.com/zhanglimao/trivy/pkg/commands/history"
+	"github.com/zhanglimao/trivy/pkg/commands/sbom"
 	"github.com/zhanglimao/trivy/pkg/commands/server"
 	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
 	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/i18n"
 	k8scommands "github.com/zhanglimao/trivy/pkg/k8s/commands"
 	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/metrics"
 	"github.com/zhanglimao/trivy/pkg/module"
 	"github.com/zhanglimao/trivy/pkg/plugin"
 	"github.com/zhanglimao/trivy/pkg/policy"
+	"github.com/zhanglimao/trivy/pkg/tracing"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
@@ -101,19 +112,26 @@ func NewApp(version string) *cobra.Command {
 	rootCmd.SetHelpCommandGroupID(groupUtility)
 	rootCmd.AddCommand(
 		NewImageCommand(globalFlags),
+		NewContainerCommand(globalFlags),
 		NewFilesystemCommand(globalFlags),
 		NewRootfsCommand(globalFlags),
 		NewRepositoryCommand(globalFlags),
+		NewPurlCommand(globalFlags),
 		NewClientCommand(globalFlags),
 		NewServerCommand(globalFlags),
 		NewConfigCommand(globalFlags),
 		NewConvertCommand(globalFlags),
+		NewHistoryCommand(globalFlags),
+		NewAttestCommand(globalFlags),
 		NewPluginCommand(),
 		NewModuleCommand(globalFlags),
+		NewBundleCommand(globalFlags),
 		NewKubernetesCommand(globalFlags),
 		NewSBOMCommand(globalFlags),
 		NewVersionCommand(globalFlags),
 		NewAWSCommand(globalFlags),
+		NewAzureCommand(globalFlags),
+		NewGCPCommand(globalFlags),
 		NewVMCommand(globalFlags),
 	)
 
@@ -213,10 +231,27 @@ func NewRootCommand(version string, globalFlags *flag.GlobalFlagGroup) *cobra.Co
 			globalOptions := globalFlags.ToOptions()
 
 			// Initialize logger
+			if err := log.SetFormat(globalOptions.LogFormat); err != nil {
+				return err
+			}
 			if err := log.InitLogger(globalOptions.Debug, globalOptions.Quiet); err != nil {
 				return err
 			}
 
+			// Set the language of the table output
+			if err := i18n.Init(globalOptions.Locale); err != nil {
+				return xerrors.Errorf("locale error: %w", err)
+			}
+
+			if globalOptions.MetricsListen != "" {
+				metrics.Serve(globalOptions.MetricsListen)
+			}
+
+			// Spans are flushed by tracing.Shutdown when the command finishes
+			if err := tracing.Init(cmd.Context(), globalOptions.OTLPEndpoint, version); err != nil {
+				return xerrors.Errorf("tracing error: %w", err)
+			}
+
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -243,8 +278,8 @@ func NewImageCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	reportFlagGroup := flag.NewReportFlagGroup()
 
 	report := flag.ReportFormatFlag
-	report.Value = "summary"                                     // override the default value as the summary is preferred for the compliance report
-	report.Usage = "specify a format for the compliance report." // "--report" works only with "--compliance"
+	report.Value = "summary" // override the default value as the summary is preferred for the compliance report
+	report.Usage += " The default applies only to the compliance report and the others default to \"all\"."
 	reportFlagGroup.ReportFormat = &report
 
 	compliance := flag.ComplianceFlag
@@ -252,18 +287,24 @@ func NewImageCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	reportFlagGroup.Compliance = &compliance // override usage as the accepted values differ for each subcommand.
 
 	imageFlags := &flag.Flags{
+		AttestFlagGroup: &flag.AttestFlagGroup{
+			SignerPolicy:     &flag.SignerPolicyFlag,
+			RequireSignature: &flag.RequireSignatureFlag,
+		},
 		CacheFlagGroup:         flag.NewCacheFlagGroup(),
 		DBFlagGroup:            flag.NewDBFlagGroup(),
 		ImageFlagGroup:         flag.NewImageFlagGroup(), // container image specific
 		LicenseFlagGroup:       flag.NewLicenseFlagGroup(),
 		MisconfFlagGroup:       flag.NewMisconfFlagGroup(),
 		ModuleFlagGroup:        flag.NewModuleFlagGroup(),
+		NotificationFlagGroup:  flag.NewNotificationFlagGroup(),
 		RemoteFlagGroup:        flag.NewClientFlags(), // for client/server mode
 		RegistryFlagGroup:      flag.NewRegistryFlagGroup(),
 		RegoFlagGroup:          flag.NewRegoFlagGroup(),
 		ReportFlagGroup:        reportFlagGroup,
 		ScanFlagGroup:          flag.NewScanFlagGroup(),
 		SecretFlagGroup:        flag.NewSecretFlagGroup(),
+		SupplyChainFlagGroup:   flag.NewSupplyChainFlagGroup(),
 		VulnerabilityFlagGroup: flag.NewVulnerabilityFlagGroup(),
 	}
 
@@ -291,7 +332,10 @@ func NewImageCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
   $ trivy image --format json --output result.json alpine:3.15
 
   # Generate a report in the CycloneDX format
-  $ trivy image --format cyclonedx --output result.cdx alpine:3.15`,
+  $ trivy image --format cyclonedx --output result.cdx alpine:3.15
+
+  # Fail unless the image is signed by a trusted signer
+  $ trivy image --require-signature --signer-policy policy.yaml --exit-code 1 ghcr.io/acme/app:v1.0.0`,
 
 		// 'Args' cannot be used since it is called before PreRunE and viper is not configured yet.
 		// cmd.Args     -> cannot validate args here
@@ -324,11 +368,87 @@ func NewImageCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	return cmd
 }
 
+func NewContainerCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	reportFlagGroup := flag.NewReportFlagGroup()
+	reportFlagGroup.Compliance = nil          // disable '--compliance'
+	reportFlagGroup.CompliancePublicKey = nil // disable '--compliance-public-key'
+
+	containerFlags := &flag.Flags{
+		CacheFlagGroup:     flag.NewCacheFlagGroup(),
+		ContainerFlagGroup: flag.NewContainerFlagGroup(),
+		DBFlagGroup:        flag.NewDBFlagGroup(),
+		DriftFlagGroup: &flag.DriftFlagGroup{
+			// The writable layer is compared with the image of the container
+			ExitOnDrift: &flag.ExitOnDriftFlag,
+		},
+		ImageFlagGroup: &flag.ImageFlagGroup{
+			ImageConfigScanners: &flag.ImageConfigScannersFlag,
+			ScanRemovedPkgs:     &flag.ScanRemovedPkgsFlag,
+			DockerHost:          &flag.DockerHostFlag,
+			// The followings are used for containers of runtimes other than Docker, whose images are scanned alone.
+			PodmanHost:          &flag.PodmanHostFlag,
+			ContainerdAddress:   &flag.ContainerdAddressFlag,
+			ContainerdNamespace: &flag.ContainerdNamespaceFlag,
+			CRIOStorageRoot:     &flag.CRIOStorageRootFlag,
+			ImageSources:        &flag.SourceFlag,
+		},
+		K8sFlagGroup: &flag.K8sFlagGroup{
+			// used only with '--pod'
+			ClusterContext: &flag.ClusterContextFlag,
+			KubeConfig:     &flag.KubeConfigFlag,
+		},
+		LicenseFlagGroup:       flag.NewLicenseFlagGroup(),
+		MisconfFlagGroup:       flag.NewMisconfFlagGroup(),
+		ModuleFlagGroup:        flag.NewModuleFlagGroup(),
+		NotificationFlagGroup:  flag.NewNotificationFlagGroup(),
+		RemoteFlagGroup:        flag.NewClientFlags(), // for client/server mode
+		RegistryFlagGroup:      flag.NewRegistryFlagGroup(),
+		RegoFlagGroup:          flag.NewRegoFlagGroup(),
+		ReportFlagGroup:        reportFlagGroup,
+		ScanFlagGroup:          flag.NewScanFlagGroup(),
+		SecretFlagGroup:        flag.NewSecretFlagGroup(),
+		VulnerabilityFlagGroup: flag.NewVulnerabilityFlagGroup(),
+	}
+
+	cmd := &cobra.Command{
+		Use:     "container [flags] CONTAINER_ID",
+		Aliases: []string{"ctr"},
+		GroupID: groupScanning,
+		Short:   "[EXPERIMENTAL] Scan a running container including its writable layer",
+		Example: `  # Scan a running container
+  $ trivy container 6e4ba2b0c1f0
+
+  # Scan all containers of a running pod
+  $ trivy container --pod default/nginx-7bb7cd8db5-qbmrj
+
+  # Scan a running container in client mode
+  $ trivy container --server http://127.0.0.1:4954 6e4ba2b0c1f0`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := containerFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return validateArgs(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options, err := containerFlags.ToOptions(cmd.Version, args, globalFlags, outputWriter)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return artifact.Run(cmd.Context(), options, artifact.TargetContainer)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	containerFlags.AddFlags(cmd)
+	cmd.SetFlagErrorFunc(flagErrorFunc)
+	cmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, containerFlags.Usages(cmd)))
+
+	return cmd
+}
+
 func NewFilesystemCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	reportFlagGroup := flag.NewReportFlagGroup()
-	reportFormat := flag.ReportFormatFlag
-	reportFormat.Usage = "specify a compliance report format for the output. (all,summary)" //@TODO: support --report summary for non compliance reports
-	reportFlagGroup.ReportFormat = &reportFormat
 	reportFlagGroup.ExitOnEOL = nil // disable '--exit-on-eol'
 
 	fsFlags := &flag.Flags{
@@ -337,12 +457,15 @@ func NewFilesystemCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 		LicenseFlagGroup:       flag.NewLicenseFlagGroup(),
 		MisconfFlagGroup:       flag.NewMisconfFlagGroup(),
 		ModuleFlagGroup:        flag.NewModuleFlagGroup(),
+		NotificationFlagGroup:  flag.NewNotificationFlagGroup(),
 		RemoteFlagGroup:        flag.NewClientFlags(), // for client/server mode
 		RegistryFlagGroup:      flag.NewRegistryFlagGroup(),
 		RegoFlagGroup:          flag.NewRegoFlagGroup(),
 		ReportFlagGroup:        reportFlagGroup,
 		ScanFlagGroup:          flag.NewScanFlagGroup(),
 		SecretFlagGroup:        flag.NewSecretFlagGroup(),
+		SupplyChainFlagGroup:   flag.NewSupplyChainFlagGroup(),
+		SSHFlagGroup:           flag.NewSSHFlagGroup(),
 		VulnerabilityFlagGroup: flag.NewVulnerabilityFlagGroup(),
 	}
 
@@ -355,7 +478,10 @@ func NewFilesystemCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
   $ trivy fs /path/to/your_project
 
   # Scan a single file
-  $ trivy fs ./trivy-ci-test/Pipfile.lock`,
+  $ trivy fs ./trivy-ci-test/Pipfile.lock
+
+  # Scan a directory on a remote host over SSH
+  $ trivy fs ssh://user@host/path/to/your_project`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if err := fsFlags.Bind(cmd); err != nil {
 				return xerrors.Errorf("flag bind error: %w", err)
@@ -385,21 +511,24 @@ func NewFilesystemCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 
 func NewRootfsCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	reportFlagGroup := flag.NewReportFlagGroup()
-	reportFlagGroup.ReportFormat = nil // TODO: support --report summary
-	reportFlagGroup.Compliance = nil   // disable '--compliance'
+	reportFlagGroup.Compliance = nil          // disable '--compliance'
+	reportFlagGroup.CompliancePublicKey = nil // disable '--compliance-public-key'
 
 	rootfsFlags := &flag.Flags{
 		CacheFlagGroup:         flag.NewCacheFlagGroup(),
 		DBFlagGroup:            flag.NewDBFlagGroup(),
+		DriftFlagGroup:         flag.NewDriftFlagGroup(),
 		LicenseFlagGroup:       flag.NewLicenseFlagGroup(),
 		MisconfFlagGroup:       flag.NewMisconfFlagGroup(),
 		ModuleFlagGroup:        flag.NewModuleFlagGroup(),
+		NotificationFlagGroup:  flag.NewNotificationFlagGroup(),
 		RemoteFlagGroup:        flag.NewClientFlags(), // for client/server mode
 		RegistryFlagGroup:      flag.NewRegistryFlagGroup(),
 		RegoFlagGroup:          flag.NewRegoFlagGroup(),
 		ReportFlagGroup:        reportFlagGroup,
 		ScanFlagGroup:          flag.NewScanFlagGroup(),
 		SecretFlagGroup:        flag.NewSecretFlagGroup(),
+		SupplyChainFlagGroup:   flag.NewSupplyChainFlagGroup(),
 		VulnerabilityFlagGroup: flag.NewVulnerabilityFlagGroup(),
 	}
 
@@ -414,7 +543,10 @@ func NewRootfsCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
   # Scan from inside a container
   $ docker run --rm -it alpine:3.11
   / # curl -sfL https://raw.githubusercontent.com/aquasecurity/trivy/main/contrib/install.sh | sh -s -- -b /usr/local/bin
-  / # trivy rootfs /`,
+  / # trivy rootfs /
+
+  # Report packages and executables added or modified since the image
+  $ trivy rootfs --baseline-image alpine:3.17 /tmp/rootfs`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if err := rootfsFlags.Bind(cmd); err != nil {
 				return xerrors.Errorf("flag bind error: %w", err)
@@ -443,9 +575,9 @@ func NewRootfsCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 
 func NewRepositoryCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	reportFlagGroup := flag.NewReportFlagGroup()
-	reportFlagGroup.ReportFormat = nil // TODO: support --report summary
-	reportFlagGroup.Compliance = nil   // disable '--compliance'
-	reportFlagGroup.ExitOnEOL = nil    // disable '--exit-on-eol'
+	reportFlagGroup.Compliance = nil          // disable '--compliance'
+	reportFlagGroup.CompliancePublicKey = nil // disable '--compliance-public-key'
+	reportFlagGroup.ExitOnEOL = nil           // disable '--exit-on-eol'
 
 	repoFlags := &flag.Flags{
 		CacheFlagGroup:         flag.NewCacheFlagGroup(),
@@ -453,12 +585,14 @@ func NewRepositoryCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 		LicenseFlagGroup:       flag.NewLicenseFlagGroup(),
 		MisconfFlagGroup:       flag.NewMisconfFlagGroup(),
 		ModuleFlagGroup:        flag.NewModuleFlagGroup(),
+		NotificationFlagGroup:  flag.NewNotificationFlagGroup(),
 		RegistryFlagGroup:      flag.NewRegistryFlagGroup(),
 		RegoFlagGroup:          flag.NewRegoFlagGroup(),
 		RemoteFlagGroup:        flag.NewClientFlags(), // for client/server mode
 		ReportFlagGroup:        reportFlagGroup,
 		ScanFlagGroup:          flag.NewScanFlagGroup(),
 		SecretFlagGroup:        flag.NewSecretFlagGroup(),
+		SupplyChainFlagGroup:   flag.NewSupplyChainFlagGroup(),
 		VulnerabilityFlagGroup: flag.NewVulnerabilityFlagGroup(),
 		RepoFlagGroup:          flag.NewRepoFlagGroup(),
 	}
@@ -469,7 +603,10 @@ func NewRepositoryCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 		GroupID: groupScanning,
 		Short:   "Scan a remote repository",
 		Example: `  # Scan your remote git repository
-  $ trivy repo https://github.com/knqyf263/trivy-ci-test`,
+  $ trivy repo https://github.com/knqyf263/trivy-ci-test
+
+  # Scan only some directories of a monorepo
+  $ trivy repo --sparse-checkout services/api,libs https://github.com/your/monorepo`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if err := repoFlags.Bind(cmd); err != nil {
 				return xerrors.Errorf("flag bind error: %w", err)
@@ -496,10 +633,97 @@ func NewRepositoryCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	return cmd
 }
 
+func NewPurlCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	reportFlagGroup := flag.NewReportFlagGroup()
+	reportFlagGroup.Compliance = nil          // disable '--compliance'
+	reportFlagGroup.CompliancePublicKey = nil // disable '--compliance-public-key'
+	reportFlagGroup.ExitOnEOL = nil           // disable '--exit-on-eol'
+	reportFlagGroup.DependencyTree = nil      // disable '--dependency-tree'
+
+	scanners := flag.ScannersFlag
+	scanners.Value = types.Scanners{ // overwrite the default value
+		types.VulnerabilityScanner,
+		types.LicenseScanner,
+	}.StringSlice()
+
+	// Files of the package are not walked, so most of scan flags are not available
+	scanFlags := &flag.ScanFlagGroup{
+		OfflineScan: &flag.OfflineScanFlag,
+		Scanners:    &scanners,
+	}
+
+	purlFlags := &flag.Flags{
+		CacheFlagGroup:         flag.NewCacheFlagGroup(),
+		DBFlagGroup:            flag.NewDBFlagGroup(),
+		LicenseFlagGroup:       flag.NewLicenseFlagGroup(),
+		NotificationFlagGroup:  flag.NewNotificationFlagGroup(),
+		RemoteFlagGroup:        flag.NewClientFlags(), // for client/server mode
+		ReportFlagGroup:        reportFlagGroup,
+		ScanFlagGroup:          scanFlags,
+		VulnerabilityFlagGroup: flag.NewVulnerabilityFlagGroup(),
+	}
+
+	cmd := &cobra.Command{
+		Use:     "purl [flags] PACKAGE_URL",
+		GroupID: groupScanning,
+		Short:   "Scan a package in its registry by package URL",
+		Long: `Scan a package in its registry by package URL (purl).
+The package is downloaded from the registry of the ecosystem and analyzed without installation.
+Supported types are npm, pypi and maven. A registry mirror can be specified with the "repository_url" qualifier.`,
+		Example: `  # Scan an npm package
+  $ trivy purl pkg:npm/lodash@4.17.20
+
+  # Scan a Maven package in a private repository
+  $ trivy purl "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1?repository_url=https://maven.example.com/releases"`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := purlFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return validateArgs(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := purlFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			options, err := purlFlags.ToOptions(cmd.Version, args, globalFlags, outputWriter)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return artifact.Run(cmd.Context(), options, artifact.TargetPurl)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	cmd.SetFlagErrorFunc(flagErrorFunc)
+	purlFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, purlFlags.Usages(cmd)))
+
+	return cmd
+}
+
 func NewConvertCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	reportFlagGroup := flag.NewReportFlagGroup()
+	reportFlagGroup.Record = nil // disable '--record'
+	reportFlagGroup.SLA = nil    // disable '--sla'
+	reportFlagGroup.Validate = &flag.ValidateFlag
+
+	// Only the flags for filtering are available as the report is not rescanned
 	convertFlags := &flag.Flags{
-		ScanFlagGroup:   &flag.ScanFlagGroup{},
-		ReportFlagGroup: flag.NewReportFlagGroup(),
+		LicenseFlagGroup: &flag.LicenseFlagGroup{
+			IgnoredLicenses: &flag.IgnoredLicenses,
+		},
+		MisconfFlagGroup: &flag.MisconfFlagGroup{
+			IncludeNonFailures: &flag.IncludeNonFailuresFlag,
+		},
+		ReportFlagGroup: reportFlagGroup,
+		SBOMFlagGroup: &flag.SBOMFlagGroup{
+			VEXPath: &flag.VEXFlag,
+		},
+		ScanFlagGroup: &flag.ScanFlagGroup{},
+		VulnerabilityFlagGroup: &flag.VulnerabilityFlagGroup{
+			IgnoreUnfixed: &flag.IgnoreUnfixedFlag,
+			ESMFixed:      &flag.ESMFixedFlag,
+		},
 	}
 	cmd := &cobra.Command{
 		Use:     "convert [flags] RESULT_JSON",
@@ -509,6 +733,12 @@ func NewConvertCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 		Example: `  # report conversion
   $ trivy image --format json --output result.json --list-all-pkgs debian:11
   $ trivy convert --format cyclonedx --output result.cdx result.json
+
+  # re-render the report with filters, e.g. after triage
+  $ trivy convert --format table --ignore-unfixed --ignorefile .trivyignore --vex openvex.json result.json
+
+  # validate the report against the JSON schema
+  $ trivy convert --validate result.json
 `,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if err := convertFlags.Bind(cmd); err != nil {
@@ -537,6 +767,119 @@ func NewConvertCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	return cmd
 }
 
+func NewHistoryCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	format := flag.FormatFlag
+	format.Usage = "format (table, json)" // override usage as only table and json are supported
+	historyFlags := &flag.Flags{
+		ScanFlagGroup: &flag.ScanFlagGroup{},
+		ReportFlagGroup: &flag.ReportFlagGroup{
+			Format: &format,
+			Output: &flag.OutputFlag,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "history [flags] [ARTIFACT]",
+		GroupID: groupUtility,
+		Short:   "Show finding trends of artifacts scanned with '--record'",
+		Long: `Show finding trends of artifacts scanned with '--record'.
+Without arguments, the recorded artifacts are listed.
+With an artifact name, the number of findings per scan, new and fixed findings,
+and the mean time to remediate (MTTR) fixed vulnerabilities are shown.`,
+		Example: `  # Record scan results
+  $ trivy image --record alpine:3.17
+
+  # List recorded artifacts
+  $ trivy history
+
+  # Show the trend of the artifact
+  $ trivy history alpine:3.17
+`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := historyFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := historyFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			opts, err := historyFlags.ToOptions(cmd.Version, args, globalFlags, outputWriter)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+
+			return history.Run(cmd.Context(), opts)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	cmd.SetFlagErrorFunc(flagErrorFunc)
+	historyFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, historyFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func NewAttestCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	format := flag.FormatFlag
+	format.Usage = "format (table, json)" // override usage as only table and json are supported
+	verifyFlags := &flag.Flags{
+		AttestFlagGroup:   flag.NewAttestFlagGroup(),
+		RegistryFlagGroup: flag.NewRegistryFlagGroup(),
+		ReportFlagGroup: &flag.ReportFlagGroup{
+			Format:   &format,
+			Output:   &flag.OutputFlag,
+			ExitCode: &flag.ExitCodeFlag,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:           "attest subcommand",
+		GroupID:       groupUtility,
+		Short:         "Verify attestations of artifacts",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify [flags] IMAGE_NAME",
+		Short: "Verify SLSA provenance attestations of an image against a signer policy",
+		Long: `Verify SLSA provenance attestations of an image against a signer policy.
+Attestations are looked up in the OCI referrers and the ".att" tag of cosign, and in Rekor.
+The result passes if any provenance is signed by an identity allowed in the policy.`,
+		Example: `  # Verify provenance
+  $ trivy attest verify --signer-policy policy.yaml ghcr.io/acme/app:v1.0.0
+
+  # Fail CI if no trusted provenance is found
+  $ trivy attest verify --signer-policy policy.yaml --exit-code 1 ghcr.io/acme/app:v1.0.0
+
+  # Output the result in JSON to merge it into the scan report
+  $ trivy attest verify --signer-policy policy.yaml --format json --output provenance.json ghcr.io/acme/app:v1.0.0`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := verifyFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := verifyFlags.ToOptions(cmd.Version, args, globalFlags, outputWriter)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return attest.Verify(cmd.Context(), opts)
+		},
+	}
+	verifyCmd.SetFlagErrorFunc(flagErrorFunc)
+	verifyFlags.AddFlags(verifyCmd)
+	verifyCmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, verifyFlags.Usages(verifyCmd)))
+
+	cmd.AddCommand(verifyCmd)
+	return cmd
+}
+
 // NewClientCommand returns the 'client' subcommand that is deprecated
 func NewClientCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	remoteFlags := flag.NewClientFlags()
@@ -553,6 +896,7 @@ func NewClientCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 		CacheFlagGroup:         flag.NewCacheFlagGroup(),
 		DBFlagGroup:            flag.NewDBFlagGroup(),
 		MisconfFlagGroup:       flag.NewMisconfFlagGroup(),
+		NotificationFlagGroup:  flag.NewNotificationFlagGroup(),
 		RegistryFlagGroup:      flag.NewRegistryFlagGroup(),
 		RegoFlagGroup:          flag.NewRegoFlagGroup(),
 		RemoteFlagGroup:        remoteFlags,
@@ -594,9 +938,14 @@ func NewClientCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 }
 
 func NewServerCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	// Malicious packages cannot be returned to clients yet
+	dbFlagGroup := flag.NewDBFlagGroup()
+	dbFlagGroup.MaliciousDBDir = nil  // disable '--malicious-db-dir'
+	dbFlagGroup.MaliciousDBRepo = nil // disable '--malicious-db-repository'
+
 	serverFlags := &flag.Flags{
 		CacheFlagGroup:    flag.NewCacheFlagGroup(),
-		DBFlagGroup:       flag.NewDBFlagGroup(),
+		DBFlagGroup:       dbFlagGroup,
 		ModuleFlagGroup:   flag.NewModuleFlagGroup(),
 		RemoteFlagGroup:   flag.NewServerFlags(),
 		RegistryFlagGroup: flag.NewRegistryFlagGroup(),
@@ -636,13 +985,12 @@ func NewServerCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 
 func NewConfigCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	reportFlagGroup := flag.NewReportFlagGroup()
-	reportFlagGroup.DependencyTree = nil // disable '--dependency-tree'
-	reportFlagGroup.IgnorePolicy = nil   // disable '--ignore-policy'
-	reportFlagGroup.ListAllPkgs = nil    // disable '--list-all-pkgs'
-	reportFlagGroup.ExitOnEOL = nil      // disable '--exit-on-eol'
-	reportFormat := flag.ReportFormatFlag
-	reportFormat.Usage = "specify a compliance report format for the output. (all,summary)" //@TODO: support --report summary for non compliance reports
-	reportFlagGroup.ReportFormat = &reportFormat
+	reportFlagGroup.DependencyTree = nil  // disable '--dependency-tree'
+	reportFlagGroup.IgnorePolicy = nil    // disable '--ignore-policy'
+	reportFlagGroup.ListAllPkgs = nil     // disable '--list-all-pkgs'
+	reportFlagGroup.ExitOnEOL = nil       // disable '--exit-on-eol'
+	reportFlagGroup.ExitOnMalicious = nil // disable '--exit-on-malicious'
+	reportFlagGroup.GroupBy = nil         // disable '--group-by'
 
 	scanFlags := &flag.ScanFlagGroup{
 		// Enable only '--skip-dirs' and '--skip-files' and disable other flags
@@ -651,12 +999,16 @@ func NewConfigCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 		FilePatterns: &flag.FilePatternsFlag,
 	}
 
+	regoFlagGroup := flag.NewRegoFlagGroup()
+	regoFlagGroup.PackagePolicies = nil // disable '--package-policy'
+
 	configFlags := &flag.Flags{
-		CacheFlagGroup:    flag.NewCacheFlagGroup(),
-		MisconfFlagGroup:  flag.NewMisconfFlagGroup(),
-		ModuleFlagGroup:   flag.NewModuleFlagGroup(),
-		RegistryFlagGroup: flag.NewRegistryFlagGroup(),
-		RegoFlagGroup:     flag.NewRegoFlagGroup(),
+		CacheFlagGroup:        flag.NewCacheFlagGroup(),
+		MisconfFlagGroup:      flag.NewMisconfFlagGroup(),
+		ModuleFlagGroup:       flag.NewModuleFlagGroup(),
+		NotificationFlagGroup: flag.NewNotificationFlagGroup(),
+		RegistryFlagGroup:     flag.NewRegistryFlagGroup(),
+		RegoFlagGroup:         regoFlagGroup,
 		K8sFlagGroup: &flag.K8sFlagGroup{
 			// disable unneeded flags
 			K8sVersion: &flag.K8sVersionFlag,
@@ -875,6 +1227,94 @@ func NewModuleCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	return cmd
 }
 
+func NewBundleCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	createFlags := &flag.Flags{
+		DBFlagGroup: &flag.DBFlagGroup{
+			SkipDBUpdate:     &flag.SkipDBUpdateFlag,
+			SkipJavaDBUpdate: &flag.SkipJavaDBUpdateFlag,
+			NoProgress:       &flag.NoProgressFlag,
+			DBRepository:     &flag.DBRepositoryFlag,
+			JavaDBRepository: &flag.JavaDBRepositoryFlag,
+		},
+		RegistryFlagGroup: flag.NewRegistryFlagGroup(),
+		RegoFlagGroup: &flag.RegoFlagGroup{
+			SkipPolicyUpdate: &flag.SkipPolicyUpdateFlag,
+		},
+		BundleFlagGroup: &flag.BundleFlagGroup{
+			IncludeCache: &flag.BundleIncludeCacheFlag,
+			SigningKey:   &flag.BundleSigningKeyFlag,
+		},
+	}
+	importFlags := &flag.Flags{
+		BundleFlagGroup: &flag.BundleFlagGroup{
+			PublicKey: &flag.BundlePublicKeyFlag,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:           "bundle subcommand",
+		GroupID:       groupManagement,
+		Short:         "Manage offline bundles for air-gapped environments",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create [flags] OUTPUT",
+		Short: "Create a bundle of the databases and the checks bundle",
+		Example: `  # Create a bundle
+  $ trivy bundle create trivy-bundle.tar.gz
+
+  # Create a signed bundle with the scan cache
+  $ trivy bundle create --include-cache --signing-key bundle.key trivy-bundle.tar.gz`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := createFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := createFlags.ToOptions(cmd.Version, args, globalFlags, outputWriter)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return bundle.Create(cmd.Context(), opts, args[0])
+		},
+	}
+	createCmd.SetFlagErrorFunc(flagErrorFunc)
+	createFlags.AddFlags(createCmd)
+
+	importCmd := &cobra.Command{
+		Use:   "import [flags] BUNDLE",
+		Short: "Verify a bundle and import it into the cache directory",
+		Example: `  # Import a bundle
+  $ trivy bundle import trivy-bundle.tar.gz
+
+  # Verify the signature and import a bundle
+  $ trivy bundle import --public-key bundle.pub trivy-bundle.tar.gz`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := importFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := importFlags.ToOptions(cmd.Version, args, globalFlags, outputWriter)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return bundle.Import(cmd.Context(), opts, args[0])
+		},
+	}
+	importCmd.SetFlagErrorFunc(flagErrorFunc)
+	importFlags.AddFlags(importCmd)
+
+	cmd.AddCommand(createCmd, importCmd)
+	return cmd
+}
+
 func NewKubernetesCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	scanFlags := flag.NewScanFlagGroup()
 	scanners := flag.ScannersFlag
@@ -895,6 +1335,15 @@ func NewKubernetesCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	compliance.Usage += fmt.Sprintf(" (%s,%s, %s, %s)", types.ComplianceK8sNsa, types.ComplianceK8sCIS, types.ComplianceK8sPSSBaseline, types.ComplianceK8sPSSRestricted)
 	reportFlagGroup.Compliance = &compliance // override usage as the accepted values differ for each subcommand.
 	reportFlagGroup.ExitOnEOL = nil          // disable '--exit-on-eol'
+	reportFlagGroup.ExitOnMalicious = nil    // disable '--exit-on-malicious'
+	reportFlagGroup.Record = nil             // disable '--record'
+	reportFlagGroup.SLA = nil                // disable '--sla'
+	reportFlagGroup.SummaryTop = nil         // '--report summary' has its own format
+	reportFlagGroup.GroupBy = nil            // disable '--group-by'
+	reportFlagGroup.OwnersFile = nil         // disable '--owners-file'
+
+	regoFlagGroup := flag.NewRegoFlagGroup()
+	regoFlagGroup.PackagePolicies = nil // disable '--package-policy'
 
 	k8sFlags := &flag.Flags{
 		CacheFlagGroup:         flag.NewCacheFlagGroup(),
@@ -902,7 +1351,7 @@ func NewKubernetesCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 		ImageFlagGroup:         imageFlags,
 		K8sFlagGroup:           flag.NewK8sFlagGroup(), // kubernetes-specific flags
 		MisconfFlagGroup:       flag.NewMisconfFlagGroup(),
-		RegoFlagGroup:          flag.NewRegoFlagGroup(),
+		RegoFlagGroup:          regoFlagGroup,
 		ReportFlagGroup:        reportFlagGroup,
 		ScanFlagGroup:          scanFlags,
 		SecretFlagGroup:        flag.NewSecretFlagGroup(),
@@ -959,12 +1408,25 @@ func NewAWSCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	compliance.Usage += fmt.Sprintf(" (%s, %s)", types.ComplianceAWSCIS12, types.ComplianceAWSCIS14)
 	reportFlagGroup.Compliance = &compliance // override usage as the accepted values differ for each subcommand.
 	reportFlagGroup.ExitOnEOL = nil          // disable '--exit-on-eol'
+	reportFlagGroup.ExitOnMalicious = nil    // disable '--exit-on-malicious'
+	reportFlagGroup.Record = nil             // disable '--record'
+	reportFlagGroup.SLA = nil                // disable '--sla'
+	reportFlagGroup.SummaryTop = nil         // '--report summary' has its own format
+	reportFlagGroup.GroupBy = nil            // disable '--group-by'
+	reportFlagGroup.OwnersFile = nil         // disable '--owners-file'
+
+	cacheFlagGroup := flag.NewCacheFlagGroup()
+	cacheFlagGroup.ClearCache = nil // disable '--clear-cache'
+
+	regoFlagGroup := flag.NewRegoFlagGroup()
+	regoFlagGroup.PackagePolicies = nil // disable '--package-policy'
 
 	awsFlags := &flag.Flags{
+		CacheFlagGroup:   cacheFlagGroup,
 		AWSFlagGroup:     flag.NewAWSFlagGroup(),
 		CloudFlagGroup:   flag.NewCloudFlagGroup(),
 		MisconfFlagGroup: flag.NewMisconfFlagGroup(),
-		RegoFlagGroup:    flag.NewRegoFlagGroup(),
+		RegoFlagGroup:    regoFlagGroup,
 		ReportFlagGroup:  reportFlagGroup,
 	}
 
@@ -1020,19 +1482,172 @@ The following services are supported:
 	return cmd
 }
 
+func NewAzureCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	reportFlagGroup := flag.NewReportFlagGroup()
+	reportFlagGroup.ExitOnEOL = nil           // disable '--exit-on-eol'
+	reportFlagGroup.ExitOnMalicious = nil     // disable '--exit-on-malicious'
+	reportFlagGroup.Compliance = nil          // disable '--compliance'
+	reportFlagGroup.CompliancePublicKey = nil // disable '--compliance-public-key'
+	reportFlagGroup.Record = nil              // disable '--record'
+	reportFlagGroup.SLA = nil                 // disable '--sla'
+	reportFlagGroup.SummaryTop = nil          // '--report summary' has its own format
+	reportFlagGroup.GroupBy = nil             // disable '--group-by'
+	reportFlagGroup.OwnersFile = nil          // disable '--owners-file'
+
+	cacheFlagGroup := flag.NewCacheFlagGroup()
+	cacheFlagGroup.ClearCache = nil // disable '--clear-cache'
+
+	regoFlagGroup := flag.NewRegoFlagGroup()
+	regoFlagGroup.PackagePolicies = nil // disable '--package-policy'
+
+	azureFlags := &flag.Flags{
+		CacheFlagGroup:   cacheFlagGroup,
+		AzureFlagGroup:   flag.NewAzureFlagGroup(),
+		CloudFlagGroup:   flag.NewCloudFlagGroup(),
+		MisconfFlagGroup: flag.NewMisconfFlagGroup(),
+		RegoFlagGroup:    regoFlagGroup,
+		ReportFlagGroup:  reportFlagGroup,
+	}
+	// Services are queried one by one
+	azureFlags.CloudFlagGroup.Concurrency = nil
+
+	cmd := &cobra.Command{
+		Use:     "azure [flags]",
+		Aliases: []string{},
+		GroupID: groupScanning,
+		Args:    cobra.ExactArgs(0),
+		Short:   "[EXPERIMENTAL] Scan Azure subscription",
+		Long: fmt.Sprintf(`Scan an Azure subscription for misconfigurations. Trivy uses the same authentication methods as the Azure SDKs, such as environment variables, managed identities and the Azure CLI. See https://learn.microsoft.com/en-us/azure/developer/go/azure-sdk-authentication
+
+The following services are supported:
+- %s
+`, strings.Join(azureAdapter.AllSupportedServices(), "\n- ")),
+		Example: `  # basic scanning
+  $ trivy azure --subscription 00000000-0000-0000-0000-000000000000
+
+  # limit scan to a single service:
+  $ trivy azure --service storage
+
+  # force refresh of cache for fresh results
+  $ trivy azure --update-cache
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := azureFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := azureFlags.ToOptions(cmd.Version, args, globalFlags, outputWriter)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			if opts.Timeout < time.Hour {
+				opts.Timeout = time.Hour
+				log.Logger.Debug("Timeout is set to less than 1 hour - upgrading to 1 hour for this command.")
+			}
+			return azurecommands.Run(cmd.Context(), opts)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	cmd.SetFlagErrorFunc(flagErrorFunc)
+	azureFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, azureFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func NewGCPCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	reportFlagGroup := flag.NewReportFlagGroup()
+	reportFlagGroup.ExitOnEOL = nil           // disable '--exit-on-eol'
+	reportFlagGroup.ExitOnMalicious = nil     // disable '--exit-on-malicious'
+	reportFlagGroup.Compliance = nil          // disable '--compliance'
+	reportFlagGroup.CompliancePublicKey = nil // disable '--compliance-public-key'
+	reportFlagGroup.Record = nil              // disable '--record'
+	reportFlagGroup.SLA = nil                 // disable '--sla'
+	reportFlagGroup.SummaryTop = nil          // '--report summary' has its own format
+	reportFlagGroup.GroupBy = nil             // disable '--group-by'
+	reportFlagGroup.OwnersFile = nil          // disable '--owners-file'
+
+	cacheFlagGroup := flag.NewCacheFlagGroup()
+	cacheFlagGroup.ClearCache = nil // disable '--clear-cache'
+
+	regoFlagGroup := flag.NewRegoFlagGroup()
+	regoFlagGroup.PackagePolicies = nil // disable '--package-policy'
+
+	gcpFlags := &flag.Flags{
+		CacheFlagGroup:   cacheFlagGroup,
+		GoogleFlagGroup:  flag.NewGoogleFlagGroup(),
+		CloudFlagGroup:   flag.NewCloudFlagGroup(),
+		MisconfFlagGroup: flag.NewMisconfFlagGroup(),
+		RegoFlagGroup:    regoFlagGroup,
+		ReportFlagGroup:  reportFlagGroup,
+	}
+	// Services are queried one by one
+	gcpFlags.CloudFlagGroup.Concurrency = nil
+
+	cmd := &cobra.Command{
+		Use:     "gcp [flags]",
+		Aliases: []string{},
+		GroupID: groupScanning,
+		Args:    cobra.ExactArgs(0),
+		Short:   "[EXPERIMENTAL] Scan Google Cloud project",
+		Long: fmt.Sprintf(`Scan a Google Cloud project for misconfigurations. Trivy uses the Application Default Credentials. See https://cloud.google.com/docs/authentication/application-default-credentials
+
+The following services are supported:
+- %s
+`, strings.Join(gcpAdapter.AllSupportedServices(), "\n- ")),
+		Example: `  # basic scanning
+  $ trivy gcp --project my-project
+
+  # limit scan to a single service:
+  $ trivy gcp --project my-project --service storage
+
+  # force refresh of cache for fresh results
+  $ trivy gcp --project my-project --update-cache
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := gcpFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := gcpFlags.ToOptions(cmd.Version, args, globalFlags, outputWriter)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			if opts.Timeout < time.Hour {
+				opts.Timeout = time.Hour
+				log.Logger.Debug("Timeout is set to less than 1 hour - upgrading to 1 hour for this command.")
+			}
+			return gcpcommands.Run(cmd.Context(), opts)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	cmd.SetFlagErrorFunc(flagErrorFunc)
+	gcpFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, gcpFlags.Usages(cmd)))
+
+	return cmd
+}
+
 func NewVMCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	reportFlagGroup := flag.NewReportFlagGroup()
-	reportFlagGroup.ReportFormat = nil // TODO: support --report summary
 
 	vmFlags := &flag.Flags{
 		CacheFlagGroup:         flag.NewCacheFlagGroup(),
 		DBFlagGroup:            flag.NewDBFlagGroup(),
 		MisconfFlagGroup:       flag.NewMisconfFlagGroup(),
 		ModuleFlagGroup:        flag.NewModuleFlagGroup(),
+		NotificationFlagGroup:  flag.NewNotificationFlagGroup(),
 		RemoteFlagGroup:        flag.NewClientFlags(), // for client/server mode
 		ReportFlagGroup:        reportFlagGroup,
 		ScanFlagGroup:          flag.NewScanFlagGroup(),
 		SecretFlagGroup:        flag.NewSecretFlagGroup(),
+		SupplyChainFlagGroup:   flag.NewSupplyChainFlagGroup(),
 		VulnerabilityFlagGroup: flag.NewVulnerabilityFlagGroup(),
 		AWSFlagGroup: &flag.AWSFlagGroup{
 			Region: &flag.Flag{
@@ -1088,7 +1703,6 @@ func NewVMCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 func NewSBOMCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	reportFlagGroup := flag.NewReportFlagGroup()
 	reportFlagGroup.DependencyTree = nil // disable '--dependency-tree'
-	reportFlagGroup.ReportFormat = nil   // TODO: support --report summary
 
 	scanFlags := flag.NewScanFlagGroup()
 	scanFlags.Scanners = nil // disable '--scanners' as it always scans for vulnerabilities
@@ -1096,9 +1710,11 @@ func NewSBOMCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	sbomFlags := &flag.Flags{
 		CacheFlagGroup:         flag.NewCacheFlagGroup(),
 		DBFlagGroup:            flag.NewDBFlagGroup(),
+		NotificationFlagGroup:  flag.NewNotificationFlagGroup(),
 		RemoteFlagGroup:        flag.NewClientFlags(), // for client/server mode
 		ReportFlagGroup:        reportFlagGroup,
 		ScanFlagGroup:          flag.NewScanFlagGroup(),
+		SupplyChainFlagGroup:   flag.NewSupplyChainFlagGroup(),
 		SBOMFlagGroup:          flag.NewSBOMFlagGroup(),
 		VulnerabilityFlagGroup: flag.NewVulnerabilityFlagGroup(),
 	}
@@ -1140,6 +1756,56 @@ func NewSBOMCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	sbomFlags.AddFlags(cmd)
 	cmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, sbomFlags.Usages(cmd)))
 
+	cmd.AddCommand(newSBOMDiffCommand(globalFlags))
+	return cmd
+}
+
+func newSBOMDiffCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	format := flag.FormatFlag
+	format.Usage = "format (table, json)" // override usage as only table and json are supported
+	diffFlags := &flag.Flags{
+		ReportFlagGroup: &flag.ReportFlagGroup{
+			Format:   &format,
+			Output:   &flag.OutputFlag,
+			ExitCode: &flag.ExitCodeFlag,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff [flags] OLD_SBOM NEW_SBOM",
+		Short: "Compare components of two CycloneDX SBOMs",
+		Long: `Compare components of two CycloneDX SBOMs.
+Components are matched by the package URL without the version, or by the type, group and name if they don't have one.
+Added, removed and changed components are shown with upgrades and downgrades of versions.`,
+		Example: `  # Show component changes between builds
+  $ trivy sbom diff old.cdx.json new.cdx.json
+
+  # Output the changes in JSON for release notes
+  $ trivy sbom diff --format json --output changes.json old.cdx.json new.cdx.json
+
+  # Fail if any component changed
+  $ trivy sbom diff --exit-code 1 old.cdx.json new.cdx.json`,
+		Args: cobra.ExactArgs(2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := diffFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := diffFlags.ToOptions(cmd.Version, args, globalFlags, outputWriter)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return sbom.Diff(cmd.Context(), opts, args[0], args[1])
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	cmd.SetFlagErrorFunc(flagErrorFunc)
+	diffFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, diffFlags.Usages(cmd)))
+
 	return cmd
 }
 
@@ -1246,15 +1912,22 @@ func validateArgs(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	if len(args) == 0 && viper.GetString(flag.InputFlag.ConfigName) == "" {
+	if len(args) == 0 && viper.GetString(flag.InputFlag.ConfigName) == "" && viper.GetString(flag.PodFlag.ConfigName) == "" {
 		if err := cmd.Help(); err != nil {
 			return err
 		}
 
 		if f := cmd.Flags().Lookup(flag.InputFlag.ConfigName); f != nil {
 			return xerrors.New(`Require at least 1 argument or --input option`)
+		} else if f = cmd.Flags().Lookup(flag.PodFlag.Name); f != nil {
+			return xerrors.New(`Require at least 1 argument or --pod option`)
 		}
 		return xerrors.New(`Require at least 1 argument`)
+	} else if len(args) > 0 && viper.GetString(flag.PodFlag.ConfigName) != "" {
+		if err := cmd.Help(); err != nil {
+			return err
+		}
+		return xerrors.New(`a container ID and --pod cannot be specified together`)
 	} else if cmd.Name() != "kubernetes" && len(args) > 1 {
 		if err := cmd.Help(); err != nil {
 			return err
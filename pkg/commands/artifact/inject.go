@@ -28,6 +28,14 @@ func initializeDockerScanner(ctx context.Context, imageName string, artifactCach
 	return scanner.Scanner{}, nil, nil
 }
 
+// initializeContainerScanner is for running container scanning in standalone mode
+func initializeContainerScanner(ctx context.Context, containerID string, artifactCache cache.ArtifactCache,
+	localArtifactCache cache.LocalArtifactCache, imageOpt types.ImageOptions, artifactOption artifact.Option) (
+	scanner.Scanner, func(), error) {
+	wire.Build(scanner.StandaloneContainerSet)
+	return scanner.Scanner{}, nil, nil
+}
+
 // initializeArchiveScanner is for container image archive scanning in standalone mode
 // e.g. docker save -o alpine.tar alpine:3.15
 func initializeArchiveScanner(ctx context.Context, filePath string, artifactCache cache.ArtifactCache,
@@ -49,6 +57,20 @@ func initializeRepositoryScanner(ctx context.Context, url string, artifactCache
 	return scanner.Scanner{}, nil, nil
 }
 
+// initializeSSHScanner is for remote filesystem scanning over SSH in standalone mode
+func initializeSSHScanner(ctx context.Context, target string, artifactCache cache.ArtifactCache,
+	localArtifactCache cache.LocalArtifactCache, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
+	wire.Build(scanner.StandaloneSSHSet)
+	return scanner.Scanner{}, nil, nil
+}
+
+// initializePurlScanner is for package URL scanning in standalone mode
+func initializePurlScanner(ctx context.Context, target string, artifactCache cache.ArtifactCache,
+	localArtifactCache cache.LocalArtifactCache, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
+	wire.Build(scanner.StandalonePurlSet)
+	return scanner.Scanner{}, nil, nil
+}
+
 func initializeSBOMScanner(ctx context.Context, filePath string, artifactCache cache.ArtifactCache,
 	localArtifactCache cache.LocalArtifactCache, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
 	wire.Build(scanner.StandaloneSBOMSet)
@@ -75,6 +97,14 @@ func initializeRemoteDockerScanner(ctx context.Context, imageName string, artifa
 	return scanner.Scanner{}, nil, nil
 }
 
+// initializeRemoteContainerScanner is for running container scanning in client/server mode
+func initializeRemoteContainerScanner(ctx context.Context, containerID string, artifactCache cache.ArtifactCache,
+	remoteScanOptions client.ScannerOption, imageOpt types.ImageOptions, artifactOption artifact.Option) (
+	scanner.Scanner, func(), error) {
+	wire.Build(scanner.RemoteContainerSet)
+	return scanner.Scanner{}, nil, nil
+}
+
 // initializeRemoteArchiveScanner is for container image archive scanning in client/server mode
 // e.g. docker save -o alpine.tar alpine:3.15
 func initializeRemoteArchiveScanner(ctx context.Context, filePath string, artifactCache cache.ArtifactCache,
@@ -98,6 +128,20 @@ func initializeRemoteRepositoryScanner(ctx context.Context, url string, artifact
 	return scanner.Scanner{}, nil, nil
 }
 
+// initializeRemoteSSHScanner is for remote filesystem scanning over SSH in client/server mode
+func initializeRemoteSSHScanner(ctx context.Context, target string, artifactCache cache.ArtifactCache,
+	remoteScanOptions client.ScannerOption, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
+	wire.Build(scanner.RemoteSSHSet)
+	return scanner.Scanner{}, nil, nil
+}
+
+// initializeRemotePurlScanner is for package URL scanning in client/server mode
+func initializeRemotePurlScanner(ctx context.Context, target string, artifactCache cache.ArtifactCache,
+	remoteScanOptions client.ScannerOption, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
+	wire.Build(scanner.RemotePurlSet)
+	return scanner.Scanner{}, nil, nil
+}
+
 // initializeRemoteSBOMScanner is for sbom scanning in client/server mode
 func initializeRemoteSBOMScanner(ctx context.Context, path string, artifactCache cache.ArtifactCache,
 	remoteScanOptions client.ScannerOption, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
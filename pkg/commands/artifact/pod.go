@@ -0,0 +1,74 @@
+package artifact
+
+import (
+	"context"
+	"strings"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aquasecurity/trivy-kubernetes/pkg/k8s"
+	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+const dockerRuntime = "docker"
+
+// podContainer represents a running container of a pod
+type podContainer struct {
+	name    string
+	runtime string // e.g. docker, containerd and cri-o
+	id      string
+	image   string
+}
+
+// podContainers returns the running containers of the pod specified by "--pod"
+func podContainers(ctx context.Context, opts flag.Options) ([]podContainer, error) {
+	cluster, err := k8s.GetCluster(
+		k8s.WithContext(opts.K8sOptions.ClusterContext),
+		k8s.WithKubeConfig(opts.K8sOptions.KubeConfig),
+	)
+	if err != nil {
+		return nil, xerrors.Errorf("failed getting k8s cluster: %w", err)
+	}
+
+	pod, err := cluster.GetK8sClientSet().CoreV1().Pods(opts.PodNamespace).Get(ctx, opts.PodName, metav1.GetOptions{})
+	if err != nil {
+		return nil, xerrors.Errorf("unable to get the pod: %w", err)
+	}
+	return runningContainers(pod), nil
+}
+
+func runningContainers(pod *corev1.Pod) []podContainer {
+	var containers []podContainer
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Running == nil || status.ContainerID == "" {
+			log.Logger.Debugf("Skipping the container %q as it is not running", status.Name)
+			continue
+		}
+
+		// e.g. containerd://3f0e7bd0e6b23a5cad2b2d4ab6c6cdf7a6f1e1ae1b1e9c4f5b0f6c24b5c8c3d0
+		runtime, id, _ := strings.Cut(status.ContainerID, "://")
+		containers = append(containers, podContainer{
+			name:    status.Name,
+			runtime: runtime,
+			id:      id,
+			image:   containerImage(status),
+		})
+	}
+	return containers
+}
+
+// containerImage returns the image of the container, preferring the digest as the tag may have been moved.
+func containerImage(status corev1.ContainerStatus) string {
+	// e.g. docker-pullable://alpine@sha256:124c7d2707904eea7431fffe91522a01e5a861a624ee31d03372cc1d138a3126
+	imageID := status.ImageID
+	if _, after, ok := strings.Cut(imageID, "://"); ok {
+		imageID = after
+	}
+	if strings.Contains(imageID, "@") {
+		return imageID
+	}
+	return status.Image
+}
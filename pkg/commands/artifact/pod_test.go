@@ -0,0 +1,58 @@
+package artifact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_runningContainers(t *testing.T) {
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:        "nginx",
+					Image:       "nginx:1.25",
+					ImageID:     "docker-pullable://nginx@sha256:6926dd802f40e5e7257fded83e0d8030039642e4e10c4a98a6478e9c6fe06153",
+					ContainerID: "docker://6e4ba2b0c1f0d9a4c0f6f6d2d6b2c1a0e8e6b8c1e4b1c6d9a4a3e2f1d0c9b8a7",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+				{
+					Name:        "sidecar",
+					Image:       "busybox:1.36",
+					ImageID:     "sha256:a416a98b71e224a31ee99cff8e16063554498227d2b696152a9c3e0aa65e5824",
+					ContainerID: "containerd://3f0e7bd0e6b23a5cad2b2d4ab6c6cdf7a6f1e1ae1b1e9c4f5b0f6c24b5c8c3d0",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+				{
+					Name:  "crashed",
+					Image: "alpine:3.18",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+				},
+			},
+		},
+	}
+
+	want := []podContainer{
+		{
+			name:    "nginx",
+			runtime: "docker",
+			id:      "6e4ba2b0c1f0d9a4c0f6f6d2d6b2c1a0e8e6b8c1e4b1c6d9a4a3e2f1d0c9b8a7",
+			image:   "nginx@sha256:6926dd802f40e5e7257fded83e0d8030039642e4e10c4a98a6478e9c6fe06153",
+		},
+		{
+			name:    "sidecar",
+			runtime: "containerd",
+			id:      "3f0e7bd0e6b23a5cad2b2d4ab6c6cdf7a6f1e1ae1b1e9c4f5b0f6c24b5c8c3d0",
+			image:   "busybox:1.36",
+		},
+	}
+	assert.Equal(t, want, runningContainers(pod))
+}
@@ -1,32 +1,62 @@
 package artifact
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/hashicorp/go-multierror"
+	"github.com/samber/lo"
 	"github.com/spf13/viper"
 	"golang.org/x/exp/slices"
+	"golang.org/x/term"
 	"golang.org/x/xerrors"
 
 	"github.com/aquasecurity/go-version/pkg/semver"
 	"github.com/aquasecurity/trivy-db/pkg/db"
+	"github.com/aquasecurity/trivy-db/pkg/metadata"
+	"github.com/zhanglimao/trivy/pkg/attestation/provenance"
+	"github.com/zhanglimao/trivy/pkg/baseimage"
 	tcache "github.com/zhanglimao/trivy/pkg/cache"
+	"github.com/zhanglimao/trivy/pkg/clock"
 	"github.com/zhanglimao/trivy/pkg/commands/operation"
+	"github.com/zhanglimao/trivy/pkg/compliance/spec"
 	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
 	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
+	aimage "github.com/zhanglimao/trivy/pkg/fanal/artifact/image"
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact/ssh"
 	"github.com/zhanglimao/trivy/pkg/fanal/cache"
+	"github.com/zhanglimao/trivy/pkg/fanal/image"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/history"
 	"github.com/zhanglimao/trivy/pkg/javadb"
 	"github.com/zhanglimao/trivy/pkg/log"
 	"github.com/zhanglimao/trivy/pkg/misconf"
 	"github.com/zhanglimao/trivy/pkg/module"
+	"github.com/zhanglimao/trivy/pkg/notification"
+	"github.com/zhanglimao/trivy/pkg/oci"
+	"github.com/zhanglimao/trivy/pkg/owner"
+	"github.com/zhanglimao/trivy/pkg/pkgpolicy"
 	"github.com/zhanglimao/trivy/pkg/policy"
+	"github.com/zhanglimao/trivy/pkg/progress"
+	"github.com/zhanglimao/trivy/pkg/remote"
 	pkgReport "github.com/zhanglimao/trivy/pkg/report"
 	"github.com/zhanglimao/trivy/pkg/result"
 	"github.com/zhanglimao/trivy/pkg/rpc/client"
 	"github.com/zhanglimao/trivy/pkg/scanner"
+	"github.com/zhanglimao/trivy/pkg/scanner/post"
 	"github.com/zhanglimao/trivy/pkg/types"
 	"github.com/zhanglimao/trivy/pkg/utils/fsutils"
 )
@@ -36,9 +66,11 @@ type TargetKind string
 
 const (
 	TargetContainerImage TargetKind = "image"
+	TargetContainer      TargetKind = "container"
 	TargetFilesystem     TargetKind = "fs"
 	TargetRootfs         TargetKind = "rootfs"
 	TargetRepository     TargetKind = "repo"
+	TargetPurl           TargetKind = "purl"
 	TargetImageArchive   TargetKind = "archive"
 	TargetSBOM           TargetKind = "sbom"
 	TargetVM             TargetKind = "vm"
@@ -76,6 +108,8 @@ type ScannerConfig struct {
 type Runner interface {
 	// ScanImage scans an image
 	ScanImage(ctx context.Context, opts flag.Options) (types.Report, error)
+	// ScanContainer scans a running container or all containers of a pod
+	ScanContainer(ctx context.Context, opts flag.Options) (types.Report, error)
 	// ScanFilesystem scans a filesystem
 	ScanFilesystem(ctx context.Context, opts flag.Options) (types.Report, error)
 	// ScanRootfs scans rootfs
@@ -84,6 +118,8 @@ type Runner interface {
 	ScanPacket(ctx context.Context, opts flag.Options) (types.Report, error)
 	// ScanRepository scans repository
 	ScanRepository(ctx context.Context, opts flag.Options) (types.Report, error)
+	// ScanPurl scans the package referenced by a package URL
+	ScanPurl(ctx context.Context, opts flag.Options) (types.Report, error)
 	// ScanSBOM scans SBOM
 	ScanSBOM(ctx context.Context, opts flag.Options) (types.Report, error)
 	// ScanVM scans VM
@@ -133,8 +169,9 @@ func NewRunner(ctx context.Context, cliOptions flag.Options, opts ...runnerOptio
 
 	// Initialize WASM modules
 	m, err := module.NewManager(ctx, module.Options{
-		Dir:            cliOptions.ModuleDir,
-		EnabledModules: cliOptions.EnabledModules,
+		Dir:               cliOptions.ModuleDir,
+		EnabledModules:    cliOptions.EnabledModules,
+		AnalyzerPluginDir: cliOptions.AnalyzerPluginDir,
 	})
 	if err != nil {
 		return nil, xerrors.Errorf("WASM module error: %w", err)
@@ -142,6 +179,14 @@ func NewRunner(ctx context.Context, cliOptions flag.Options, opts ...runnerOptio
 	m.Register()
 	r.module = m
 
+	// Analyzers of WASM modules and plugins can be specified as well
+	if err = analyzer.ValidateTypes(cliOptions.EnableAnalyzers); err != nil {
+		return nil, xerrors.Errorf("unable to parse analyzers to enable: %w", err)
+	}
+	if err = analyzer.ValidateTypes(cliOptions.DisableAnalyzers); err != nil {
+		return nil, xerrors.Errorf("unable to parse analyzers to disable: %w", err)
+	}
+
 	return r, nil
 }
 
@@ -168,6 +213,16 @@ func (r *runner) ScanImage(ctx context.Context, opts flag.Options) (types.Report
 	// Disable the lock file scanning
 	opts.DisabledAnalyzers = analyzer.TypeLockfiles
 
+	if opts.ServerAddr != "" && opts.VerifyIntegrity {
+		// The server cannot verify files as digests of package databases and system files are not sent via RPC
+		return types.Report{}, xerrors.New("integrity verification is not supported in client/server mode")
+	}
+
+	if opts.ServerAddr != "" && opts.LayerSize {
+		// The server cannot calculate layer sizes as sizes of files are not sent via RPC
+		return types.Report{}, xerrors.New("layer size analysis is not supported in client/server mode")
+	}
+
 	var s InitializeScanner
 	switch {
 	case opts.Input != "" && opts.ServerAddr == "":
@@ -184,14 +239,153 @@ func (r *runner) ScanImage(ctx context.Context, opts flag.Options) (types.Report
 		s = imageRemoteScanner
 	}
 
+	if opts.Input == "" && (opts.AllPlatforms || len(opts.Platforms) > 1) {
+		return r.scanImagePlatforms(ctx, opts, s)
+	}
+
 	return r.scanArtifact(ctx, opts, s)
 }
 
+// scanImagePlatforms scans each platform of a multi-arch image and merges the results into one report.
+// The target of each result is suffixed with the platform, e.g. "alpine:3.17 (alpine 3.17.3) (linux/arm64)".
+func (r *runner) scanImagePlatforms(ctx context.Context, opts flag.Options, s InitializeScanner) (types.Report, error) {
+	platforms := opts.Platforms
+	if opts.AllPlatforms {
+		ref, err := name.ParseReference(opts.Target)
+		if err != nil {
+			return types.Report{}, xerrors.Errorf("failed to parse the image name: %w", err)
+		}
+		ps, err := remote.Platforms(ctx, ref, opts.RegistryOpts())
+		if err != nil {
+			return types.Report{}, xerrors.Errorf("unable to list platforms: %w", err)
+		}
+		if len(ps) == 0 {
+			log.Logger.Debug("Ignore '--platform all' as the image is not multi-arch")
+			return r.scanArtifact(ctx, opts, s)
+		}
+		platforms = lo.Map(ps, func(p v1.Platform, _ int) ftypes.Platform {
+			return ftypes.Platform{Platform: lo.ToPtr(p)}
+		})
+	}
+
+	// Container runtimes store a single platform per image, so only registries can serve each platform.
+	opts.ImageSources = ftypes.ImageSources{ftypes.RemoteImageSource}
+
+	var merged types.Report
+	for i, platform := range platforms {
+		log.Logger.Infof("Scanning the platform %q...", platform)
+
+		// The specified platform must exist in the image index
+		platform.Force = true
+		opts.Platform = platform
+
+		report, err := r.scanArtifact(ctx, opts, s)
+		if err != nil {
+			return types.Report{}, xerrors.Errorf("platform %q: %w", platform, err)
+		}
+		for j := range report.Results {
+			report.Results[j].Target = fmt.Sprintf("%s (%s)", report.Results[j].Target, platform)
+		}
+
+		if i == 0 {
+			merged = report
+			continue
+		}
+		merged.Results = append(merged.Results, report.Results...)
+	}
+	return merged, nil
+}
+
+func (r *runner) ScanContainer(ctx context.Context, opts flag.Options) (types.Report, error) {
+	// Disable the lock file scanning
+	opts.DisabledAnalyzers = analyzer.TypeLockfiles
+
+	if opts.PodName != "" {
+		return r.scanPod(ctx, opts)
+	}
+	return r.scanArtifact(ctx, opts, containerScanner(opts))
+}
+
+// scanPod scans each running container of the pod and merges the results into one report.
+// The target of each result is suffixed with the container name, e.g. "6e4ba2b0c1f0 (alpine 3.17.3) (nginx)".
+func (r *runner) scanPod(ctx context.Context, opts flag.Options) (types.Report, error) {
+	pod := opts.PodNamespace + "/" + opts.PodName
+	containers, err := podContainers(ctx, opts)
+	if err != nil {
+		return types.Report{}, xerrors.Errorf("pod error: %w", err)
+	}
+	if len(containers) == 0 {
+		return types.Report{}, xerrors.Errorf("no running containers in the pod %s", pod)
+	}
+
+	var merged types.Report
+	for i, c := range containers {
+		log.Logger.Infof("Scanning the container %q of the pod %s...", c.name, pod)
+
+		s := containerScanner(opts)
+		opts.Target = c.id
+		unsupported := c.runtime != dockerRuntime
+		if unsupported {
+			log.Logger.Warnf("The writable layer of the container %q is not scanned as %q is not supported, "+
+				"scanning its image %s only", c.name, c.runtime, c.image)
+			s = imageScanner(opts)
+			opts.Target = c.image
+		}
+
+		report, err := r.scanArtifact(ctx, opts, s)
+		if err != nil {
+			return types.Report{}, xerrors.Errorf("container %q: %w", c.name, err)
+		}
+		if unsupported {
+			// Make the incomplete scan visible in the report, not only in the logs
+			report.Results = append(report.Results, types.Result{
+				Target: c.image,
+				Class:  types.ClassWarning,
+				Warnings: []ftypes.Warning{{
+					Message: fmt.Sprintf("the writable layer is not scanned as the container runtime %q is not supported", c.runtime),
+				}},
+			})
+		}
+		for j := range report.Results {
+			report.Results[j].Target = fmt.Sprintf("%s (%s)", report.Results[j].Target, c.name)
+		}
+
+		if i == 0 {
+			merged = report
+			merged.ArtifactName = pod
+			continue
+		}
+		merged.Results = append(merged.Results, report.Results...)
+	}
+	return merged, nil
+}
+
+func containerScanner(opts flag.Options) InitializeScanner {
+	if opts.ServerAddr == "" {
+		// Scan running container in standalone mode
+		return containerStandaloneScanner
+	}
+	// Scan running container in client/server mode
+	return containerRemoteScanner
+}
+
+func imageScanner(opts flag.Options) InitializeScanner {
+	if opts.ServerAddr == "" {
+		return imageStandaloneScanner
+	}
+	return imageRemoteScanner
+}
+
 func (r *runner) ScanFilesystem(ctx context.Context, opts flag.Options) (types.Report, error) {
 	// Disable scanning of individual package and SBOM files
 	opts.DisabledAnalyzers = append(opts.DisabledAnalyzers, analyzer.TypeIndividualPkgs...)
 	opts.DisabledAnalyzers = append(opts.DisabledAnalyzers, analyzer.TypeSBOM)
 
+	// e.g. ssh://user@host/path
+	if strings.HasPrefix(opts.Target, ssh.Scheme+"://") {
+		return r.scanSSH(ctx, opts)
+	}
+
 	return r.scanFS(ctx, opts)
 }
 
@@ -199,9 +393,51 @@ func (r *runner) ScanRootfs(ctx context.Context, opts flag.Options) (types.Repor
 	// Disable the lock file scanning
 	opts.DisabledAnalyzers = append(opts.DisabledAnalyzers, analyzer.TypeLockfiles...)
 
+	if opts.BaselineImage != "" {
+		baseline, err := r.inspectBaseline(ctx, opts)
+		if err != nil {
+			return types.Report{}, xerrors.Errorf("baseline image error: %w", err)
+		}
+		opts.DriftBaseline = &baseline
+	}
+
 	return r.scanFS(ctx, opts)
 }
 
+// inspectBaseline analyzes the image specified by '--baseline-image' and stores the result in the cache,
+// so that the scanner can compare the filesystem with it.
+func (r *runner) inspectBaseline(ctx context.Context, opts flag.Options) (ftypes.ArtifactReference, error) {
+	if opts.ServerAddr != "" {
+		// The server cannot compare digests of executables as they are not sent via RPC
+		return ftypes.ArtifactReference{}, xerrors.New("drift detection is not supported in client/server mode")
+	}
+	log.Logger.Infof("Analyzing the baseline image %s...", opts.BaselineImage)
+
+	conf, _, err := initScannerConfig(opts, r.cache)
+	if err != nil {
+		return ftypes.ArtifactReference{}, err
+	}
+	if len(conf.ArtifactOption.ImageOption.ImageSources) == 0 {
+		conf.ArtifactOption.ImageOption.ImageSources = ftypes.DefaultImageSources
+	}
+
+	img, cleanup, err := image.NewContainerImage(ctx, opts.BaselineImage, conf.ArtifactOption.ImageOption)
+	if err != nil {
+		return ftypes.ArtifactReference{}, xerrors.Errorf("unable to get the image: %w", err)
+	}
+	defer cleanup()
+
+	art, err := aimage.NewArtifact(img, r.cache, conf.ArtifactOption)
+	if err != nil {
+		return ftypes.ArtifactReference{}, xerrors.Errorf("unable to initialize the image artifact: %w", err)
+	}
+	ref, err := art.Inspect(ctx)
+	if err != nil {
+		return ftypes.ArtifactReference{}, xerrors.Errorf("image analysis error: %w", err)
+	}
+	return ref, nil
+}
+
 func (r *runner) scanFS(ctx context.Context, opts flag.Options) (types.Report, error) {
 	var s InitializeScanner
 	if opts.ServerAddr == "" {
@@ -215,6 +451,19 @@ func (r *runner) scanFS(ctx context.Context, opts flag.Options) (types.Report, e
 	return r.scanArtifact(ctx, opts, s)
 }
 
+func (r *runner) scanSSH(ctx context.Context, opts flag.Options) (types.Report, error) {
+	var s InitializeScanner
+	if opts.ServerAddr == "" {
+		// Scan the remote filesystem in standalone mode
+		s = sshStandaloneScanner
+	} else {
+		// Scan the remote filesystem in client/server mode
+		s = sshRemoteScanner
+	}
+
+	return r.scanArtifact(ctx, opts, s)
+}
+
 func (r *runner) ScanPacket(ctx context.Context, opts flag.Options) (types.Report, error) {
 
 	return r.scanPacket(ctx, opts)
@@ -253,6 +502,26 @@ func (r *runner) ScanRepository(ctx context.Context, opts flag.Options) (types.R
 	return r.scanArtifact(ctx, opts, s)
 }
 
+func (r *runner) ScanPurl(ctx context.Context, opts flag.Options) (types.Report, error) {
+	// Only language-specific packages can be downloaded
+	opts.VulnType = []string{types.VulnTypeLibrary}
+
+	// Disable the OS analyzers, lock file analyzers and SBOM analyzer
+	opts.DisabledAnalyzers = append(opts.DisabledAnalyzers, analyzer.TypeLockfiles...)
+	opts.DisabledAnalyzers = append(opts.DisabledAnalyzers, analyzer.TypeOSes...)
+	opts.DisabledAnalyzers = append(opts.DisabledAnalyzers, analyzer.TypeSBOM)
+
+	var s InitializeScanner
+	if opts.ServerAddr == "" {
+		// Scan the package in standalone mode
+		s = purlStandaloneScanner
+	} else {
+		// Scan the package in client/server mode
+		s = purlRemoteScanner
+	}
+	return r.scanArtifact(ctx, opts, s)
+}
+
 func (r *runner) ScanSBOM(ctx context.Context, opts flag.Options) (types.Report, error) {
 	var s InitializeScanner
 	if opts.ServerAddr == "" {
@@ -298,6 +567,11 @@ func (r *runner) Filter(ctx context.Context, opts flag.Options, report types.Rep
 		return types.Report{}, xerrors.Errorf("filtering error: %w", err)
 	}
 
+	// Modules can add, annotate or suppress findings in the filtered report
+	if report, err = post.Report(ctx, report); err != nil {
+		return types.Report{}, xerrors.Errorf("post report error: %w", err)
+	}
+
 	return report, nil
 }
 
@@ -314,6 +588,11 @@ func (r *runner) initDB(ctx context.Context, opts flag.Options) error {
 		return err
 	}
 
+	if opts.ServerAddr != "" && (len(opts.MaliciousDBDirs) > 0 || opts.MaliciousDBRepo != "") {
+		// The server detects vulnerabilities, and it doesn't load malicious package feeds
+		return xerrors.New("malicious package feeds are not supported in client/server mode")
+	}
+
 	// When scanning config files or running as client mode, it doesn't need to download the vulnerability database.
 	if opts.ServerAddr != "" || !opts.Scanners.Enabled(types.VulnerabilityScanner) {
 		return nil
@@ -321,7 +600,7 @@ func (r *runner) initDB(ctx context.Context, opts flag.Options) error {
 
 	// download the database file
 	noProgress := opts.Quiet || opts.NoProgress
-	if err := operation.DownloadDB(ctx, opts.AppVersion, opts.CacheDir, opts.DBRepository, noProgress, opts.SkipDBUpdate, opts.RegistryOpts()); err != nil {
+	if err := operation.DownloadDB(ctx, opts.AppVersion, opts.CacheDir, opts.DBRepositories, noProgress, opts.SkipDBUpdate, opts.RegistryOpts()); err != nil {
 		return err
 	}
 
@@ -334,6 +613,14 @@ func (r *runner) initDB(ctx context.Context, opts flag.Options) error {
 	}
 	r.dbOpen = true
 
+	if err := operation.InitCustomAdvisories(ctx, opts); err != nil {
+		return xerrors.Errorf("custom advisory error: %w", err)
+	}
+
+	if err := operation.InitMaliciousPackages(ctx, opts); err != nil {
+		return xerrors.Errorf("malicious package feed error: %w", err)
+	}
+
 	return nil
 }
 
@@ -349,6 +636,21 @@ func (r *runner) initJavaDB(opts flag.Options) error {
 		return nil
 	}
 
+	// Look up JAR files against the remote lookup API instead of downloading the Java DB
+	if opts.JavaDBURL != "" {
+		tlsConfig, err := client.NewTLSConfig(opts.Insecure, opts.TLSCert, opts.TLSKey, opts.TLSCA)
+		if err != nil {
+			return xerrors.Errorf("TLS error: %w", err)
+		}
+		javadb.InitRemote(javadb.RemoteOptions{
+			URL:       opts.JavaDBURL,
+			Headers:   opts.CustomHeaders,
+			TLSConfig: tlsConfig,
+		})
+		log.Logger.Debugf("Java DB lookup API: %s", opts.JavaDBURL)
+		return nil
+	}
+
 	// Update the Java DB
 	noProgress := opts.Quiet || opts.NoProgress
 	javadb.Init(opts.CacheDir, opts.JavaDBRepository, opts.SkipJavaDBUpdate, noProgress, opts.Insecure)
@@ -370,7 +672,11 @@ func (r *runner) initCache(opts flag.Options) error {
 
 	// client/server mode
 	if opts.ServerAddr != "" {
-		remoteCache := tcache.NewRemoteCache(opts.ServerAddr, opts.CustomHeaders, opts.Insecure)
+		tlsConfig, err := client.NewTLSConfig(opts.Insecure, opts.TLSCert, opts.TLSKey, opts.TLSCA)
+		if err != nil {
+			return xerrors.Errorf("TLS error: %w", err)
+		}
+		remoteCache := tcache.NewRemoteCache(opts.ServerAddr, opts.CustomHeaders, tlsConfig)
 		r.cache = tcache.NopCache(remoteCache)
 		return nil
 	}
@@ -430,6 +736,21 @@ func Run(ctx context.Context, opts flag.Options, targetKind TargetKind) (err err
 		return viper.SafeWriteConfigAs("trivy-default.yaml")
 	}
 
+	if opts.MaxMemory > 0 {
+		// Make the GC work harder as the heap approaches the budget
+		debug.SetMemoryLimit(opts.MaxMemory)
+	}
+
+	if err = LoadRemoteCompliance(ctx, &opts); err != nil {
+		return xerrors.Errorf("compliance spec error: %w", err)
+	}
+
+	if opts.PushReferrer {
+		if err = validatePushReferrer(opts); err != nil {
+			return xerrors.Errorf("push referrer error: %w", err)
+		}
+	}
+
 	r, err := NewRunner(ctx, opts)
 	if err != nil {
 		if errors.Is(err, SkipScan) {
@@ -439,31 +760,85 @@ func Run(ctx context.Context, opts flag.Options, targetKind TargetKind) (err err
 	}
 	defer r.Close(ctx)
 
-	var report types.Report
 	switch targetKind {
 	case TargetContainerImage, TargetImageArchive:
-		if report, err = r.ScanImage(ctx, opts); err != nil {
-			return xerrors.Errorf("image scan error: %w", err)
+		opts.LayerProgress = layerProgress(opts)
+	}
+
+	var report types.Report
+	var reused bool
+	if opts.ReuseResults && targetKind == TargetContainerImage {
+		report, reused = reuseReport(ctx, opts)
+	}
+
+	if !reused {
+		switch targetKind {
+		case TargetContainerImage, TargetImageArchive:
+			if report, err = r.ScanImage(ctx, opts); err != nil {
+				return xerrors.Errorf("image scan error: %w", err)
+			}
+		case TargetContainer:
+			if report, err = r.ScanContainer(ctx, opts); err != nil {
+				return xerrors.Errorf("container scan error: %w", err)
+			}
+		case TargetFilesystem:
+			if report, err = r.ScanFilesystem(ctx, opts); err != nil {
+				return xerrors.Errorf("filesystem scan error: %w", err)
+			}
+		case TargetRootfs:
+			if report, err = r.ScanRootfs(ctx, opts); err != nil {
+				return xerrors.Errorf("rootfs scan error: %w", err)
+			}
+		case TargetRepository:
+			if report, err = r.ScanRepository(ctx, opts); err != nil {
+				return xerrors.Errorf("repository scan error: %w", err)
+			}
+		case TargetPurl:
+			if report, err = r.ScanPurl(ctx, opts); err != nil {
+				return xerrors.Errorf("purl scan error: %w", err)
+			}
+		case TargetSBOM:
+			if report, err = r.ScanSBOM(ctx, opts); err != nil {
+				return xerrors.Errorf("sbom scan error: %w", err)
+			}
+		case TargetVM:
+			if report, err = r.ScanVM(ctx, opts); err != nil {
+				return xerrors.Errorf("vm scan error: %w", err)
+			}
 		}
-	case TargetFilesystem:
-		if report, err = r.ScanFilesystem(ctx, opts); err != nil {
-			return xerrors.Errorf("filesystem scan error: %w", err)
+	}
+
+	switch targetKind {
+	case TargetContainerImage, TargetImageArchive, TargetContainer:
+		if err = reconstructDockerfile(opts, &report); err != nil {
+			return xerrors.Errorf("dockerfile reconstruction error: %w", err)
 		}
-	case TargetRootfs:
-		if report, err = r.ScanRootfs(ctx, opts); err != nil {
-			return xerrors.Errorf("rootfs scan error: %w", err)
+	}
+
+	if opts.RequireSignature && (targetKind == TargetContainerImage || targetKind == TargetImageArchive) {
+		if err = verifySignature(ctx, opts, &report); err != nil {
+			return xerrors.Errorf("signature verification error: %w", err)
 		}
-	case TargetRepository:
-		if report, err = r.ScanRepository(ctx, opts); err != nil {
-			return xerrors.Errorf("repository scan error: %w", err)
+	}
+
+	var drift string
+	if targetKind == TargetContainerImage {
+		if drift, err = checkTagDrift(opts, &report); err != nil {
+			return xerrors.Errorf("tag drift error: %w", err)
 		}
-	case TargetSBOM:
-		if report, err = r.ScanSBOM(ctx, opts); err != nil {
-			return xerrors.Errorf("sbom scan error: %w", err)
+	}
+
+	// Package policies are evaluated before filtering so that their findings can be ignored as well
+	if len(opts.PackagePolicies) != 0 {
+		if err = scanPackagePolicies(ctx, opts, &report); err != nil {
+			return xerrors.Errorf("package policy error: %w", err)
 		}
-	case TargetVM:
-		if report, err = r.ScanVM(ctx, opts); err != nil {
-			return xerrors.Errorf("vm scan error: %w", err)
+	}
+
+	// Findings are tracked before filtering so that the ignore policy can use their age and due dates
+	if opts.Record || len(opts.SLA) > 0 {
+		if err = trackFindings(opts, &report); err != nil {
+			return xerrors.Errorf("history error: %w", err)
 		}
 	}
 
@@ -472,21 +847,486 @@ func Run(ctx context.Context, opts flag.Options, targetKind TargetKind) (err err
 		return xerrors.Errorf("filter error: %w", err)
 	}
 
+	if opts.OwnersFile != "" {
+		if err = annotateOwners(opts.OwnersFile, &report); err != nil {
+			return xerrors.Errorf("owner annotation error: %w", err)
+		}
+	}
+
+	if opts.DetectBaseImage {
+		detectBaseImage(ctx, opts, &report)
+	}
+
+	// Keep the report as written to push it as is
+	var pushed bytes.Buffer
+	if opts.PushReferrer {
+		opts.Output = io.MultiWriter(opts.Output, &pushed)
+	}
+
 	if err = r.Report(opts, report); err != nil {
 		return xerrors.Errorf("report error: %w", err)
 	}
 
+	// The reused report has been pushed already
+	if opts.PushReferrer && !reused {
+		if err = pushReferrer(ctx, opts, report.Metadata, pushed.Bytes()); err != nil {
+			return xerrors.Errorf("push referrer error: %w", err)
+		}
+	}
+
+	if opts.Record {
+		if err = recordHistory(opts, report); err != nil {
+			return xerrors.Errorf("history error: %w", err)
+		}
+	}
+
+	// Notification failures don't fail the scan
+	if opts.NotifyWebhook != "" {
+		if err := notification.NewWebhook(opts.NotificationOpts()).Notify(ctx, report); err != nil {
+			log.Logger.Warnf("Failed to notify the webhook: %s", err)
+		}
+	}
+	if opts.IssueTracker != "" {
+		if err := notification.FileIssues(ctx, opts.IssueOpts(), report); err != nil {
+			log.Logger.Warnf("Failed to file issues: %s", err)
+		}
+	}
+	for _, p := range notification.NewPublishers(opts.PublishOpts()) {
+		if err := p.Publish(ctx, report); err != nil {
+			log.Logger.Warnf("Failed to publish the report to %s: %s", p.Name(), err)
+		}
+	}
+
+	if drift != "" {
+		if opts.TagDrift == flag.TagDriftFail {
+			return xerrors.Errorf("tag drift: %s", drift)
+		}
+		log.Logger.Warnf("Tag drift: %s", drift)
+	}
+
+	operation.ExitOnMalicious(opts, report.Results)
+	operation.ExitOnDrift(opts, report.Results)
 	operation.ExitOnEOL(opts, report.Metadata)
 	operation.Exit(opts, report.Results.Failed())
 
 	return nil
 }
 
+// LoadRemoteCompliance fetches the compliance spec from the URL or OCI registry specified by '--compliance'.
+// It is done at run time rather than while parsing flags as it accesses the network.
+func LoadRemoteCompliance(ctx context.Context, opts *flag.Options) error {
+	if opts.ComplianceRef == "" {
+		return nil
+	}
+
+	cs, err := spec.GetRemoteComplianceSpec(ctx, opts.ComplianceRef, spec.RemoteOption{
+		CacheDir:        opts.CacheDir,
+		PublicKey:       opts.CompliancePublicKey,
+		RegistryOptions: opts.RegistryOpts(),
+	})
+	if err != nil {
+		return xerrors.Errorf("remote spec loading error: %w", err)
+	}
+	opts.Compliance = cs
+	return nil
+}
+
+// reconstructDockerfile reconstructs the Dockerfile from the image history
+// so that users can review how the image was built.
+func reconstructDockerfile(opts flag.Options, report *types.Report) error {
+	if !opts.ReconstructDockerfile && opts.DockerfileOutput == "" {
+		return nil
+	}
+
+	dockerfile := image.ReconstructDockerfile(&report.Metadata.ImageConfig)
+	if opts.ReconstructDockerfile {
+		report.Metadata.ReconstructedDockerfile = string(dockerfile)
+	}
+
+	if opts.DockerfileOutput != "" {
+		if err := os.WriteFile(opts.DockerfileOutput, dockerfile, 0644); err != nil {
+			return xerrors.Errorf("unable to write the Dockerfile: %w", err)
+		}
+		log.Logger.Infof("The reconstructed Dockerfile was written to %s", opts.DockerfileOutput)
+	}
+	return nil
+}
+
+// verifySignature adds the supply chain control that the image is signed by a trusted signer with cosign.
+// Only the repo digests of the scanned image are checked, i.e. the index for multi-arch images in registries,
+// as the image in the registry under the same tag may differ from the scanned one, e.g. in the local daemon.
+func verifySignature(ctx context.Context, opts flag.Options, report *types.Report) error {
+	policy, err := provenance.LoadPolicy(opts.SignerPolicy)
+	if err != nil {
+		return xerrors.Errorf("unable to load the signer policy: %w", err)
+	}
+
+	registryOpts := opts.RegistryOpts()
+	registryOpts.Platform = ftypes.Platform{}
+
+	var digests []name.Digest
+	if opts.Input == "" {
+		nameOpts := lo.Ternary(opts.Insecure, []name.Option{name.Insecure}, nil)
+		ref, err := name.ParseReference(opts.Target, nameOpts...)
+		if err != nil {
+			return xerrors.Errorf("image name parse error: %w", err)
+		}
+
+		for _, rd := range report.Metadata.RepoDigests {
+			d, err := name.NewDigest(rd, nameOpts...)
+			if err != nil || d.Context().String() != ref.Context().String() {
+				continue
+			}
+			if !slices.ContainsFunc(digests, func(digest name.Digest) bool {
+				return digest.DigestStr() == d.DigestStr()
+			}) {
+				digests = append(digests, d)
+			}
+		}
+	}
+
+	control, err := provenance.VerifySignature(ctx, digests, policy, registryOpts)
+	if err != nil {
+		return err
+	}
+
+	addControl(report, control)
+	return nil
+}
+
+// addControl adds the supply chain control to the report, replacing the same control in reused reports
+func addControl(report *types.Report, control types.SupplyChainControl) {
+	for i, r := range report.Results {
+		if r.Class != types.ClassSupplyChain || len(r.Controls) == 0 {
+			continue
+		}
+		report.Results[i].Controls = append(lo.Reject(r.Controls, func(c types.SupplyChainControl, _ int) bool {
+			return c.ID == control.ID
+		}), control)
+		return
+	}
+	report.Results = append(report.Results, types.Result{
+		Target:   report.ArtifactName,
+		Class:    types.ClassSupplyChain,
+		Controls: []types.SupplyChainControl{control},
+	})
+}
+
+// annotateOwners sets the owners of results so that findings can be routed to the teams in large organizations
+func annotateOwners(ownersFile string, report *types.Report) error {
+	rules, err := owner.Load(ownersFile)
+	if err != nil {
+		return xerrors.Errorf("unable to load %s: %w", ownersFile, err)
+	}
+	rules.Annotate(report)
+	return nil
+}
+
+// scanPackagePolicies evaluates the package inventory against package policies
+// and adds the findings to the report as misconfigurations.
+func scanPackagePolicies(ctx context.Context, opts flag.Options, report *types.Report) error {
+	results, err := pkgpolicy.Scan(ctx, *report, misconf.ScannerOption{
+		Trace:       opts.Trace,
+		Namespaces:  append([]string{"user"}, opts.PolicyNamespaces...),
+		PolicyPaths: opts.PackagePolicies,
+		DataPaths:   opts.DataPaths,
+	})
+	if err != nil {
+		return err
+	}
+	report.Results = append(report.Results, results...)
+	return nil
+}
+
+// detectBaseImage detects the base image and attributes findings to base layers.
+// Rebasing is recommended based on the filtered findings.
+func detectBaseImage(ctx context.Context, opts flag.Options, report *types.Report) {
+	baseOpt := baseimage.Option{
+		RegistryOptions: opts.RegistryOpts(),
+		Offline:         opts.OfflineScan,
+	}
+	report.Metadata.BaseImage = baseimage.Detect(ctx, report.Metadata, baseOpt)
+	baseimage.Annotate(report.Results, report.Metadata.BaseImage)
+
+	if !opts.RecommendRebase {
+		return
+	}
+	rec, err := baseimage.Recommend(ctx, *report, baseOpt)
+	if err != nil {
+		// The recommendation is best-effort
+		log.Logger.Warnf("Unable to recommend rebasing: %s", err)
+	} else if rec != nil {
+		report.Recommendations = append(report.Recommendations, *rec)
+	}
+}
+
+// referrers holds the artifact types and file names of reports pushed as OCI referrers per format
+var referrers = map[string]oci.Referrer{
+	pkgReport.FormatJSON: {
+		ArtifactType: oci.TrivyReportArtifactType,
+		FileName:     "trivy-report.json",
+	},
+	pkgReport.FormatSarif: {
+		ArtifactType: oci.SARIFArtifactType,
+		FileName:     "trivy-report.sarif",
+	},
+	pkgReport.FormatCycloneDX: {
+		ArtifactType: oci.CycloneDXArtifactType,
+		FileName:     "trivy-report.cdx.json",
+	},
+	pkgReport.FormatSPDXJSON: {
+		ArtifactType: oci.SPDXArtifactType,
+		FileName:     "trivy-report.spdx.json",
+	},
+}
+
+func validatePushReferrer(opts flag.Options) error {
+	if _, ok := referrers[opts.Format]; !ok {
+		return xerrors.Errorf("'--format %s' is not supported", opts.Format)
+	} else if opts.Compliance.Spec.ID != "" {
+		return xerrors.New("compliance reports are not supported")
+	} else if opts.Input != "" {
+		return xerrors.New("image archives are not supported as the report must be attached to an image in a registry")
+	} else if opts.AllPlatforms || len(opts.Platforms) > 1 {
+		return xerrors.New("multiple platforms are not supported")
+	}
+	return nil
+}
+
+// pushReferrer pushes the report as an OCI referrer of the scanned image digest,
+// so that other tools can discover the scan result of the exact image.
+func pushReferrer(ctx context.Context, opts flag.Options, metadata types.Metadata, content []byte) error {
+	ref, err := name.ParseReference(opts.Target)
+	if err != nil {
+		return xerrors.Errorf("image name parse error: %w", err)
+	}
+
+	nameOpts := lo.Ternary(opts.Insecure, []name.Option{name.Insecure}, nil)
+	var subject *name.Digest
+	for _, rd := range metadata.RepoDigests {
+		d, err := name.NewDigest(rd, nameOpts...)
+		if err != nil {
+			continue
+		}
+		if d.Context().String() == ref.Context().String() {
+			subject = &d
+			break
+		}
+	}
+	if subject == nil {
+		return xerrors.Errorf("no digest of %s in the registry found", opts.Target)
+	}
+
+	referrer := referrers[opts.Format]
+	referrer.Content = content
+	referrer.Annotations = scanAnnotations(opts)
+	pushed, err := oci.PushReferrer(ctx, *subject, referrer, opts.RegistryOpts())
+	if err != nil {
+		return xerrors.Errorf("unable to push the report: %w", err)
+	}
+	log.Logger.Infof("The report was pushed as a referrer of %s: %s", subject.String(), pushed.DigestStr())
+	return nil
+}
+
+// Annotations of pushed reports to tell whether the report can be reused
+const (
+	annotationScanners    = "dev.trivy.scanners"
+	annotationDBVersion   = "dev.trivy.db.version"
+	annotationDBUpdatedAt = "dev.trivy.db.updated-at"
+)
+
+func scanAnnotations(opts flag.Options) map[string]string {
+	annotations := map[string]string{
+		annotationScanners: strings.Join(opts.Scanners.StringSlice(), ","),
+	}
+	// The DB is on the server in client/server mode
+	if opts.ServerAddr == "" {
+		if meta, err := metadata.NewClient(opts.CacheDir).Get(); err == nil {
+			annotations[annotationDBVersion] = strconv.Itoa(meta.Version)
+			annotations[annotationDBUpdatedAt] = meta.UpdatedAt.UTC().Format(time.RFC3339)
+		}
+	}
+	return annotations
+}
+
+// reuseReport returns the JSON report pushed with '--push-referrer' for the same image digest,
+// so that the image is not scanned again. The report is reused only when it was generated with the same DB
+// and all the current scanners. Any error falls back to scanning.
+func reuseReport(ctx context.Context, opts flag.Options) (types.Report, bool) {
+	if opts.Input != "" || opts.ServerAddr != "" || opts.AllPlatforms || len(opts.Platforms) > 1 {
+		log.Logger.Warn("'--reuse-results' is not supported with image archives, client/server mode and multiple platforms")
+		return types.Report{}, false
+	}
+
+	nameOpts := lo.Ternary(opts.Insecure, []name.Option{name.Insecure}, nil)
+	ref, err := name.ParseReference(opts.Target, nameOpts...)
+	if err != nil {
+		log.Logger.Debugf("Unable to parse the image name: %s", err)
+		return types.Report{}, false
+	}
+	desc, err := remote.Get(ctx, ref, opts.RegistryOpts())
+	if err != nil {
+		log.Logger.Debugf("Unable to get the digest of %s: %s", opts.Target, err)
+		return types.Report{}, false
+	}
+	subject := ref.Context().Digest(desc.Digest.String())
+
+	want := scanAnnotations(opts)
+	match := func(annotations map[string]string) bool {
+		if opts.Scanners.Enabled(types.VulnerabilityScanner) {
+			if want[annotationDBUpdatedAt] == "" || annotations[annotationDBVersion] != want[annotationDBVersion] ||
+				annotations[annotationDBUpdatedAt] != want[annotationDBUpdatedAt] {
+				return false
+			}
+		}
+		scanners := strings.Split(annotations[annotationScanners], ",")
+		for _, s := range opts.Scanners.StringSlice() {
+			if !slices.Contains(scanners, s) {
+				return false
+			}
+		}
+		return true
+	}
+
+	referrer, err := oci.FindReferrer(ctx, subject, oci.TrivyReportArtifactType, match, opts.RegistryOpts())
+	if err != nil {
+		log.Logger.Warnf("Unable to look up the previous result: %s", err)
+		return types.Report{}, false
+	} else if referrer == nil {
+		log.Logger.Infof("No reusable result of %s found, scanning the image", subject.String())
+		return types.Report{}, false
+	}
+
+	var report types.Report
+	if err = json.Unmarshal(referrer.Content, &report); err != nil {
+		log.Logger.Warnf("Unable to decode the previous result: %s", err)
+		return types.Report{}, false
+	}
+
+	// The subject can be a multi-arch image
+	if p := opts.Platform.Platform; p != nil && !lo.FromPtr(report.Metadata.ImageConfig.Platform()).Satisfies(*p) {
+		log.Logger.Infof("The previous result of %s is for another platform, scanning the image", subject.String())
+		return types.Report{}, false
+	}
+
+	log.Logger.Infof("Reusing the result of %s pushed at %s", subject.String(), referrer.Annotations[oci.CreatedAnnotation])
+	return report, true
+}
+
+// recordHistory records the scan result so that finding trends can be shown with 'trivy history'
+func recordHistory(opts flag.Options, report types.Report) error {
+	store, err := history.Open(opts.CacheDir)
+	if err != nil {
+		return xerrors.Errorf("unable to open the history: %w", err)
+	}
+	defer store.Close()
+
+	if err = store.Put(history.NewRecord(report, clock.Now())); err != nil {
+		return xerrors.Errorf("unable to record the scan result: %w", err)
+	}
+	return nil
+}
+
+// trackFindings sets when findings were first and last seen in the history with '--record',
+// and their due dates from when they were first seen with '--sla'.
+// Findings are regarded as new without '--record' as the history is not updated.
+func trackFindings(opts flag.Options, report *types.Report) error {
+	store, err := history.Open(opts.CacheDir)
+	if err != nil {
+		return xerrors.Errorf("unable to open the history: %w", err)
+	}
+	defer store.Close()
+
+	records, err := store.Records(report.ArtifactName)
+	if err != nil {
+		return xerrors.Errorf("unable to get the history of %s: %w", report.ArtifactName, err)
+	}
+
+	now := clock.Now()
+	if opts.Record {
+		history.Track(report, records, now)
+	}
+	history.SLA(opts.SLA).Stamp(report, records, now)
+	return nil
+}
+
+// checkTagDrift compares the digest resolved from the scanned tag with the one recorded in the previous scan,
+// so that CI doesn't silently scan a different image under the same tag.
+// The result is added to the report as a supply chain control, and the returned message is not empty if the tag drifted.
+func checkTagDrift(opts flag.Options, report *types.Report) (string, error) {
+	digest := report.Metadata.ImageDigest
+	if opts.TagDrift == "" || opts.Input != "" || digest == "" {
+		return "", nil
+	} else if opts.AllPlatforms || len(opts.Platforms) > 1 {
+		log.Logger.Debug("Tag drift is not checked when scanning multiple platforms")
+		return "", nil
+	}
+
+	// Images referenced by digest never drift
+	tag, err := name.NewTag(opts.Target)
+	if err != nil {
+		return "", nil
+	}
+
+	key := tag.Name()
+	if opts.Platform.Platform != nil {
+		// The same tag resolves to a different digest per platform
+		key = fmt.Sprintf("%s (%s)", key, opts.Platform)
+	}
+
+	store := cache.NewTagDigestStore(opts.CacheDir)
+	prev, err := store.Get(key)
+	if err != nil {
+		return "", xerrors.Errorf("unable to get the previous digest: %w", err)
+	}
+
+	// The first scan of the tag has nothing to compare
+	if prev == "" {
+		return "", putTagDigest(store, key, digest)
+	}
+
+	control := types.SupplyChainControl{
+		ID:     types.SupplyChainControlTagDrift,
+		Title:  "The tag resolves to the same digest as the previous scan",
+		Status: types.StatusPassed,
+	}
+	var drift string
+	if prev != digest {
+		drift = fmt.Sprintf("the digest of %s changed since the previous scan (%s => %s)", opts.Target, prev, digest)
+		control.Status = types.StatusFailure
+		control.Message = drift
+	}
+	addControl(report, control)
+
+	// The new digest is not recorded when failing, so that the drift keeps failing until it is accepted with "warn"
+	if drift != "" && opts.TagDrift == flag.TagDriftFail {
+		return drift, nil
+	}
+	return drift, putTagDigest(store, key, digest)
+}
+
+func putTagDigest(store cache.TagDigestStore, key, digest string) error {
+	if err := store.Put(key, digest); err != nil {
+		return xerrors.Errorf("unable to record the digest: %w", err)
+	}
+	return nil
+}
+
 func disabledAnalyzers(opts flag.Options) []analyzer.Type {
 	// Specified analyzers to be disabled depending on scanning modes
 	// e.g. The 'image' subcommand should disable the lock file scanning.
 	analyzers := opts.DisabledAnalyzers
 
+	// Analyzers specified by users
+	analyzers = append(analyzers, opts.DisableAnalyzers...)
+	if len(opts.EnableAnalyzers) > 0 {
+		for _, t := range analyzer.RegisteredTypes() {
+			if !slices.Contains(opts.EnableAnalyzers, t) {
+				analyzers = append(analyzers, t)
+			}
+		}
+	}
+
 	// It doesn't analyze apk commands by default.
 	if !opts.ScanRemovedPkgs {
 		analyzers = append(analyzers, analyzer.TypeApkCommand)
@@ -524,16 +1364,40 @@ func disabledAnalyzers(opts flag.Options) []analyzer.Type {
 	// Do not perform misconfiguration scanning on container image config
 	// when it is not specified.
 	if !opts.ImageConfigScanners.Enabled(types.MisconfigScanner) {
-		analyzers = append(analyzers, analyzer.TypeHistoryDockerfile)
+		analyzers = append(analyzers, analyzer.TypeHistoryDockerfile, analyzer.TypeImageConfigCheck)
 	}
 
-	if len(opts.SBOMSources) == 0 {
+	// Digests of executables are needed for SBOM attestations and drift detection
+	if len(opts.SBOMSources) == 0 && opts.BaselineImage == "" {
 		analyzers = append(analyzers, analyzer.TypeExecutable)
 	}
 
+	// Recording sizes of all files is needed only for the layer size analysis
+	if !opts.LayerSize {
+		analyzers = append(analyzers, analyzer.TypeLayerSize)
+	}
+
+	// Digests of system files are needed only for the integrity verification
+	if !opts.VerifyIntegrity {
+		analyzers = append(analyzers, analyzer.TypeIntegrity)
+	}
+
+	// Auditing file permissions is performed only when '--scanners misconfig' and '--audit-permissions' are specified together.
+	if !opts.Scanners.Enabled(types.MisconfigScanner) || !opts.AuditPermissions {
+		analyzers = append(analyzers, analyzer.TypePermission)
+	}
+
 	return analyzers
 }
 
+// layerProgress returns the progress bars of layers, or nil if the progress should not be shown
+func layerProgress(opts flag.Options) artifact.Progress {
+	if opts.Quiet || opts.NoProgress || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return nil
+	}
+	return progress.NewLayers(os.Stderr)
+}
+
 func initScannerConfig(opts flag.Options, cacheClient cache.Cache) (ScannerConfig, types.ScanOptions, error) {
 	target := opts.Target
 	if opts.Input != "" {
@@ -570,6 +1434,23 @@ func initScannerConfig(opts flag.Options, cacheClient cache.Cache) (ScannerConfi
 		LicenseCategories:   opts.LicenseCategories,
 		FilePatterns:        opts.FilePatterns,
 		Packages:            opts.Packages,
+		DriftBaseline:       opts.DriftBaseline,
+	}
+
+	if opts.DetectTyposquatting || opts.DetectInstallScripts || len(opts.InternalPackages) != 0 {
+		log.Logger.Info("Supply chain heuristics are enabled")
+		scanOptions.SupplyChain = &types.SupplyChainOptions{
+			Typosquatting:    opts.DetectTyposquatting,
+			InternalPackages: opts.InternalPackages,
+			InstallScripts:   opts.DetectInstallScripts,
+			Offline:          opts.OfflineScan,
+		}
+	}
+
+	// Package policies need all the packages
+	if len(opts.PackagePolicies) != 0 && !scanOptions.ListAllPackages {
+		log.Logger.Debug("'--package-policy' enables '--list-all-pkgs'.")
+		scanOptions.ListAllPackages = true
 	}
 
 	if len(opts.ImageConfigScanners) != 0 {
@@ -640,6 +1521,14 @@ func initScannerConfig(opts flag.Options, cacheClient cache.Cache) (ScannerConfi
 		fileChecksum = true
 	}
 
+	var tlsConfig *tls.Config
+	if opts.ServerAddr != "" {
+		var err error
+		if tlsConfig, err = client.NewTLSConfig(opts.Insecure, opts.TLSCert, opts.TLSKey, opts.TLSCA); err != nil {
+			return ScannerConfig{}, types.ScanOptions{}, xerrors.Errorf("TLS error: %w", err)
+		}
+	}
+
 	return ScannerConfig{
 		Target:             target,
 		ArtifactCache:      cacheClient,
@@ -648,11 +1537,15 @@ func initScannerConfig(opts flag.Options, cacheClient cache.Cache) (ScannerConfi
 			RemoteURL:     opts.ServerAddr,
 			CustomHeaders: opts.CustomHeaders,
 			Insecure:      opts.Insecure,
+			TLSConfig:     tlsConfig,
+			Progress:      opts.ServerProgress && !opts.NoProgress && !opts.Quiet,
 		},
 		ArtifactOption: artifact.Option{
 			DisabledAnalyzers: disabledAnalyzers(opts),
 			SkipFiles:         opts.SkipFiles,
 			SkipDirs:          opts.SkipDirs,
+			IncludeFiles:      opts.IncludeFiles,
+			MaxFileSize:       opts.MaxFileSize,
 			FilePatterns:      opts.FilePatterns,
 			Offline:           opts.OfflineScan,
 			NoProgress:        opts.NoProgress || opts.Quiet,
@@ -660,12 +1553,21 @@ func initScannerConfig(opts flag.Options, cacheClient cache.Cache) (ScannerConfi
 			RepoBranch:        opts.RepoBranch,
 			RepoCommit:        opts.RepoCommit,
 			RepoTag:           opts.RepoTag,
+			RepoSparsePaths:   opts.RepoSparsePaths,
 			SBOMSources:       opts.SBOMSources,
 			RekorURL:          opts.RekorURL,
 			//Platform:          opts.Platform,
-			Slow:         opts.Slow,
-			AWSRegion:    opts.Region,
-			FileChecksum: fileChecksum,
+			Slow:            opts.Slow,
+			MaxWorkers:      opts.MaxWorkers,
+			MaxHeavyWorkers: opts.MaxHeavyWorkers,
+			MaxMemory:       opts.MaxMemory,
+			AnalyzerTimeout: opts.AnalyzerTimeout,
+			KeepGoing:       opts.KeepGoing,
+			AWSRegion:       opts.Region,
+			FileChecksum:    fileChecksum,
+			SSHKey:          opts.SSHKey,
+			SymlinkOption:   opts.SymlinkOption,
+			Progress:        opts.LayerProgress,
 
 			// For image scanning
 			ImageOption: ftypes.ImageOptions{
@@ -673,6 +1575,16 @@ func initScannerConfig(opts flag.Options, cacheClient cache.Cache) (ScannerConfi
 				DockerOptions: ftypes.DockerOptions{
 					Host: opts.DockerHost,
 				},
+				PodmanOptions: ftypes.PodmanOptions{
+					Host: opts.PodmanHost,
+				},
+				ContainerdOptions: ftypes.ContainerdOptions{
+					Address:   opts.ContainerdAddress,
+					Namespace: opts.ContainerdNamespace,
+				},
+				CRIOOptions: ftypes.CRIOOptions{
+					StorageRoot: opts.CRIOStorageRoot,
+				},
 				ImageSources: opts.ImageSources,
 			},
 
@@ -689,6 +1601,16 @@ func initScannerConfig(opts flag.Options, cacheClient cache.Cache) (ScannerConfi
 				Full:                      opts.LicenseFull,
 				ClassifierConfidenceLevel: opts.LicenseConfidenceLevel,
 			},
+
+			// For custom resources
+			CustomResourceOption: analyzer.CustomResourceOption{
+				ConfigPath: opts.CustomResourceConfigPath,
+			},
+
+			// For auditing file permissions
+			PermissionOption: analyzer.PermissionOption{
+				AllowlistPath: opts.PermissionAllowlist,
+			},
 		},
 	}, scanOptions, nil
 }
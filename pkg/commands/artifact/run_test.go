@@ -3,7 +3,12 @@ package artifact
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/cache"
+	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/types"
 )
 
 func TestCanonicalVersion(t *testing.T) {
@@ -46,3 +51,120 @@ func TestCanonicalVersion(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkTagDrift(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		tagDrift   string
+		prev       string
+		want       []types.Result
+		wantDrift  string
+		wantDigest string
+	}{
+		{
+			name:       "first scan",
+			target:     "alpine:3.17",
+			wantDigest: "sha256:aaa",
+		},
+		{
+			name:   "same digest",
+			target: "alpine:3.17",
+			prev:   "sha256:aaa",
+			want: []types.Result{
+				{
+					Target: "alpine:3.17",
+					Class:  types.ClassSupplyChain,
+					Controls: []types.SupplyChainControl{
+						{
+							ID:     types.SupplyChainControlTagDrift,
+							Title:  "The tag resolves to the same digest as the previous scan",
+							Status: types.StatusPassed,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "drifted",
+			target: "alpine:3.17",
+			prev:   "sha256:bbb",
+			want: []types.Result{
+				{
+					Target: "alpine:3.17",
+					Class:  types.ClassSupplyChain,
+					Controls: []types.SupplyChainControl{
+						{
+							ID:      types.SupplyChainControlTagDrift,
+							Title:   "The tag resolves to the same digest as the previous scan",
+							Status:  types.StatusFailure,
+							Message: "the digest of alpine:3.17 changed since the previous scan (sha256:bbb => sha256:aaa)",
+						},
+					},
+				},
+			},
+			wantDrift:  "the digest of alpine:3.17 changed since the previous scan (sha256:bbb => sha256:aaa)",
+			wantDigest: "sha256:aaa",
+		},
+		{
+			name:     "drifted with fail",
+			target:   "alpine:3.17",
+			tagDrift: flag.TagDriftFail,
+			prev:     "sha256:bbb",
+			want: []types.Result{
+				{
+					Target: "alpine:3.17",
+					Class:  types.ClassSupplyChain,
+					Controls: []types.SupplyChainControl{
+						{
+							ID:      types.SupplyChainControlTagDrift,
+							Title:   "The tag resolves to the same digest as the previous scan",
+							Status:  types.StatusFailure,
+							Message: "the digest of alpine:3.17 changed since the previous scan (sha256:bbb => sha256:aaa)",
+						},
+					},
+				},
+			},
+			wantDrift:  "the digest of alpine:3.17 changed since the previous scan (sha256:bbb => sha256:aaa)",
+			wantDigest: "sha256:bbb",
+		},
+		{
+			name:   "digest reference",
+			target: "alpine@sha256:1b6a2b1d8b1e8f8a2d6f9b4b4b1a4e5a3b0c9f1e2d3c4b5a6978877665544332",
+			prev:   "sha256:bbb",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacheDir := t.TempDir()
+			store := cache.NewTagDigestStore(cacheDir)
+			key := "index.docker.io/library/alpine:3.17"
+			if tt.prev != "" {
+				require.NoError(t, store.Put(key, tt.prev))
+			}
+			if tt.tagDrift == "" {
+				tt.tagDrift = flag.TagDriftWarn
+			}
+
+			opts := flag.Options{
+				GlobalOptions: flag.GlobalOptions{CacheDir: cacheDir},
+				ImageOptions:  flag.ImageOptions{TagDrift: tt.tagDrift},
+				ScanOptions:   flag.ScanOptions{Target: tt.target},
+			}
+			report := types.Report{
+				ArtifactName: tt.target,
+				Metadata:     types.Metadata{ImageDigest: "sha256:aaa"},
+			}
+			drift, err := checkTagDrift(opts, &report)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDrift, drift)
+			assert.Equal(t, tt.want, []types.Result(report.Results))
+
+			if tt.wantDigest != "" {
+				got, err := store.Get(key)
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantDigest, got)
+			}
+		})
+	}
+}
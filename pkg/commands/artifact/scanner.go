@@ -52,6 +52,28 @@ func archiveRemoteScanner(ctx context.Context, conf ScannerConfig) (scanner.Scan
 	return s, func() {}, nil
 }
 
+// containerStandaloneScanner initializes a running container scanner in standalone mode
+// $ trivy container 6e4ba2b0c1f0
+func containerStandaloneScanner(ctx context.Context, conf ScannerConfig) (scanner.Scanner, func(), error) {
+	s, cleanup, err := initializeContainerScanner(ctx, conf.Target, conf.ArtifactCache, conf.LocalArtifactCache,
+		conf.ArtifactOption.ImageOption, conf.ArtifactOption)
+	if err != nil {
+		return scanner.Scanner{}, func() {}, xerrors.Errorf("unable to initialize a container scanner: %w", err)
+	}
+	return s, cleanup, nil
+}
+
+// containerRemoteScanner initializes a running container scanner in client/server mode
+// $ trivy container --server localhost:4954 6e4ba2b0c1f0
+func containerRemoteScanner(ctx context.Context, conf ScannerConfig) (scanner.Scanner, func(), error) {
+	s, cleanup, err := initializeRemoteContainerScanner(ctx, conf.Target, conf.ArtifactCache, conf.ServerOption,
+		conf.ArtifactOption.ImageOption, conf.ArtifactOption)
+	if err != nil {
+		return scanner.Scanner{}, func() {}, xerrors.Errorf("unable to initialize a remote container scanner: %w", err)
+	}
+	return s, cleanup, nil
+}
+
 // filesystemStandaloneScanner initializes a filesystem scanner in standalone mode
 func filesystemStandaloneScanner(ctx context.Context, conf ScannerConfig) (scanner.Scanner, func(), error) {
 	s, cleanup, err := initializeFilesystemScanner(ctx, conf.Target, conf.ArtifactCache, conf.LocalArtifactCache, conf.ArtifactOption)
@@ -98,6 +120,42 @@ func repositoryRemoteScanner(ctx context.Context, conf ScannerConfig) (scanner.S
 	return s, cleanup, nil
 }
 
+// sshStandaloneScanner initializes a remote filesystem scanner over SSH in standalone mode
+func sshStandaloneScanner(ctx context.Context, conf ScannerConfig) (scanner.Scanner, func(), error) {
+	s, cleanup, err := initializeSSHScanner(ctx, conf.Target, conf.ArtifactCache, conf.LocalArtifactCache, conf.ArtifactOption)
+	if err != nil {
+		return scanner.Scanner{}, func() {}, xerrors.Errorf("unable to initialize an SSH scanner: %w", err)
+	}
+	return s, cleanup, nil
+}
+
+// sshRemoteScanner initializes a remote filesystem scanner over SSH in client/server mode
+func sshRemoteScanner(ctx context.Context, conf ScannerConfig) (scanner.Scanner, func(), error) {
+	s, cleanup, err := initializeRemoteSSHScanner(ctx, conf.Target, conf.ArtifactCache, conf.ServerOption, conf.ArtifactOption)
+	if err != nil {
+		return scanner.Scanner{}, func() {}, xerrors.Errorf("unable to initialize a remote SSH scanner: %w", err)
+	}
+	return s, cleanup, nil
+}
+
+// purlStandaloneScanner initializes a package URL scanner in standalone mode
+func purlStandaloneScanner(ctx context.Context, conf ScannerConfig) (scanner.Scanner, func(), error) {
+	s, cleanup, err := initializePurlScanner(ctx, conf.Target, conf.ArtifactCache, conf.LocalArtifactCache, conf.ArtifactOption)
+	if err != nil {
+		return scanner.Scanner{}, func() {}, xerrors.Errorf("unable to initialize a purl scanner: %w", err)
+	}
+	return s, cleanup, nil
+}
+
+// purlRemoteScanner initializes a package URL scanner in client/server mode
+func purlRemoteScanner(ctx context.Context, conf ScannerConfig) (scanner.Scanner, func(), error) {
+	s, cleanup, err := initializeRemotePurlScanner(ctx, conf.Target, conf.ArtifactCache, conf.ServerOption, conf.ArtifactOption)
+	if err != nil {
+		return scanner.Scanner{}, func() {}, xerrors.Errorf("unable to initialize a remote purl scanner: %w", err)
+	}
+	return s, cleanup, nil
+}
+
 // sbomStandaloneScanner initializes a SBOM scanner in standalone mode
 func sbomStandaloneScanner(ctx context.Context, conf ScannerConfig) (scanner.Scanner, func(), error) {
 	s, cleanup, err := initializeSBOMScanner(ctx, conf.Target, conf.ArtifactCache, conf.LocalArtifactCache, conf.ArtifactOption)
@@ -13,8 +13,10 @@ import (
 	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
 	image2 "github.com/zhanglimao/trivy/pkg/fanal/artifact/image"
 	local2 "github.com/zhanglimao/trivy/pkg/fanal/artifact/local"
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact/purl"
 	"github.com/zhanglimao/trivy/pkg/fanal/artifact/remote"
 	"github.com/zhanglimao/trivy/pkg/fanal/artifact/sbom"
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact/ssh"
 	"github.com/zhanglimao/trivy/pkg/fanal/artifact/vm"
 	"github.com/zhanglimao/trivy/pkg/fanal/cache"
 	"github.com/zhanglimao/trivy/pkg/fanal/image"
@@ -53,6 +55,29 @@ func initializeDockerScanner(ctx context.Context, imageName string, artifactCach
 	}, nil
 }
 
+// initializeContainerScanner is for running container scanning in standalone mode
+func initializeContainerScanner(ctx context.Context, containerID string, artifactCache cache.ArtifactCache, localArtifactCache cache.LocalArtifactCache, imageOpt types.ImageOptions, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
+	applierApplier := applier.NewApplier(localArtifactCache)
+	ospkgScanner := ospkg.NewScanner()
+	langpkgScanner := langpkg.NewScanner()
+	config := db.Config{}
+	client := vulnerability.NewClient(config)
+	localScanner := local.NewScanner(applierApplier, ospkgScanner, langpkgScanner, client)
+	typesImage, cleanup, err := image.NewRunningContainerImage(ctx, containerID, imageOpt)
+	if err != nil {
+		return scanner.Scanner{}, nil, err
+	}
+	artifactArtifact, err := image2.NewArtifact(typesImage, artifactCache, artifactOption)
+	if err != nil {
+		cleanup()
+		return scanner.Scanner{}, nil, err
+	}
+	scannerScanner := scanner.NewScanner(localScanner, artifactArtifact)
+	return scannerScanner, func() {
+		cleanup()
+	}, nil
+}
+
 // initializeArchiveScanner is for container image archive scanning in standalone mode
 // e.g. docker save -o alpine.tar alpine:3.15
 func initializeArchiveScanner(ctx context.Context, filePath string, artifactCache cache.ArtifactCache, localArtifactCache cache.LocalArtifactCache, artifactOption artifact.Option) (scanner.Scanner, error) {
@@ -108,6 +133,42 @@ func initializeRepositoryScanner(ctx context.Context, url string, artifactCache
 	}, nil
 }
 
+// initializeSSHScanner is for remote filesystem scanning over SSH in standalone mode
+func initializeSSHScanner(ctx context.Context, target string, artifactCache cache.ArtifactCache, localArtifactCache cache.LocalArtifactCache, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
+	applierApplier := applier.NewApplier(localArtifactCache)
+	ospkgScanner := ospkg.NewScanner()
+	langpkgScanner := langpkg.NewScanner()
+	config := db.Config{}
+	client := vulnerability.NewClient(config)
+	localScanner := local.NewScanner(applierApplier, ospkgScanner, langpkgScanner, client)
+	artifactArtifact, cleanup, err := ssh.NewArtifact(target, artifactCache, artifactOption)
+	if err != nil {
+		return scanner.Scanner{}, nil, err
+	}
+	scannerScanner := scanner.NewScanner(localScanner, artifactArtifact)
+	return scannerScanner, func() {
+		cleanup()
+	}, nil
+}
+
+// initializePurlScanner is for package URL scanning in standalone mode
+func initializePurlScanner(ctx context.Context, target string, artifactCache cache.ArtifactCache, localArtifactCache cache.LocalArtifactCache, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
+	applierApplier := applier.NewApplier(localArtifactCache)
+	ospkgScanner := ospkg.NewScanner()
+	langpkgScanner := langpkg.NewScanner()
+	config := db.Config{}
+	client := vulnerability.NewClient(config)
+	localScanner := local.NewScanner(applierApplier, ospkgScanner, langpkgScanner, client)
+	artifactArtifact, cleanup, err := purl.NewArtifact(target, artifactCache, artifactOption)
+	if err != nil {
+		return scanner.Scanner{}, nil, err
+	}
+	scannerScanner := scanner.NewScanner(localScanner, artifactArtifact)
+	return scannerScanner, func() {
+		cleanup()
+	}, nil
+}
+
 func initializeSBOMScanner(ctx context.Context, filePath string, artifactCache cache.ArtifactCache, localArtifactCache cache.LocalArtifactCache, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
 	applierApplier := applier.NewApplier(localArtifactCache)
 	ospkgScanner := ospkg.NewScanner()
@@ -164,6 +225,25 @@ var (
 	_wireValue = []client.Option(nil)
 )
 
+// initializeRemoteContainerScanner is for running container scanning in client/server mode
+func initializeRemoteContainerScanner(ctx context.Context, containerID string, artifactCache cache.ArtifactCache, remoteScanOptions client.ScannerOption, imageOpt types.ImageOptions, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
+	v := _wireValue
+	clientScanner := client.NewScanner(remoteScanOptions, v...)
+	typesImage, cleanup, err := image.NewRunningContainerImage(ctx, containerID, imageOpt)
+	if err != nil {
+		return scanner.Scanner{}, nil, err
+	}
+	artifactArtifact, err := image2.NewArtifact(typesImage, artifactCache, artifactOption)
+	if err != nil {
+		cleanup()
+		return scanner.Scanner{}, nil, err
+	}
+	scannerScanner := scanner.NewScanner(clientScanner, artifactArtifact)
+	return scannerScanner, func() {
+		cleanup()
+	}, nil
+}
+
 // initializeRemoteArchiveScanner is for container image archive scanning in client/server mode
 // e.g. docker save -o alpine.tar alpine:3.15
 func initializeRemoteArchiveScanner(ctx context.Context, filePath string, artifactCache cache.ArtifactCache, remoteScanOptions client.ScannerOption, artifactOption artifact.Option) (scanner.Scanner, error) {
@@ -221,6 +301,34 @@ func initializeRemoteRepositoryScanner(ctx context.Context, url string, artifact
 	}, nil
 }
 
+// initializeRemoteSSHScanner is for remote filesystem scanning over SSH in client/server mode
+func initializeRemoteSSHScanner(ctx context.Context, target string, artifactCache cache.ArtifactCache, remoteScanOptions client.ScannerOption, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
+	v := _wireValue
+	clientScanner := client.NewScanner(remoteScanOptions, v...)
+	artifactArtifact, cleanup, err := ssh.NewArtifact(target, artifactCache, artifactOption)
+	if err != nil {
+		return scanner.Scanner{}, nil, err
+	}
+	scannerScanner := scanner.NewScanner(clientScanner, artifactArtifact)
+	return scannerScanner, func() {
+		cleanup()
+	}, nil
+}
+
+// initializeRemotePurlScanner is for package URL scanning in client/server mode
+func initializeRemotePurlScanner(ctx context.Context, target string, artifactCache cache.ArtifactCache, remoteScanOptions client.ScannerOption, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
+	v := _wireValue
+	clientScanner := client.NewScanner(remoteScanOptions, v...)
+	artifactArtifact, cleanup, err := purl.NewArtifact(target, artifactCache, artifactOption)
+	if err != nil {
+		return scanner.Scanner{}, nil, err
+	}
+	scannerScanner := scanner.NewScanner(clientScanner, artifactArtifact)
+	return scannerScanner, func() {
+		cleanup()
+	}, nil
+}
+
 // initializeRemoteSBOMScanner is for sbom scanning in client/server mode
 func initializeRemoteSBOMScanner(ctx context.Context, path string, artifactCache cache.ArtifactCache, remoteScanOptions client.ScannerOption, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
 	v := _wireValue
@@ -0,0 +1,75 @@
+package attest
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/attestation/provenance"
+	"github.com/zhanglimao/trivy/pkg/commands/operation"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/remote"
+	"github.com/zhanglimao/trivy/pkg/report"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// Verify verifies the provenance attestations of the image and writes the result as a report,
+// so that the result can be merged into the scan report of the image.
+func Verify(ctx context.Context, opts flag.Options) error {
+	policy, err := provenance.LoadPolicy(opts.SignerPolicy)
+	if err != nil {
+		return xerrors.Errorf("unable to load the signer policy: %w", err)
+	}
+
+	nameOpts := lo.Ternary(opts.Insecure, []name.Option{name.Insecure}, nil)
+	ref, err := name.ParseReference(opts.Target, nameOpts...)
+	if err != nil {
+		return xerrors.Errorf("image name parse error: %w", err)
+	}
+
+	// Provenance is usually attached to the image index of multi-arch images
+	registryOpts := opts.RegistryOpts()
+	registryOpts.Platform = ftypes.Platform{}
+	desc, err := remote.Get(ctx, ref, registryOpts)
+	if err != nil {
+		return xerrors.Errorf("unable to get the image: %w", err)
+	}
+	digest := ref.Context().Digest(desc.Digest.String())
+	log.Logger.Debugf("Verifying provenance of %s", digest)
+
+	verifications, err := provenance.Verify(ctx, digest, policy, provenance.Option{
+		Sources:         opts.AttestSources,
+		RekorURL:        opts.AttestRekorURL,
+		RegistryOptions: registryOpts,
+	})
+	if err != nil {
+		return xerrors.Errorf("provenance verification error: %w", err)
+	}
+
+	r := types.Report{
+		SchemaVersion: report.SchemaVersion,
+		ArtifactName:  opts.Target,
+		ArtifactType:  ftypes.ArtifactContainerImage,
+		Metadata: types.Metadata{
+			RepoDigests: []string{digest.String()},
+		},
+		Results: types.Results{
+			{
+				Target:     opts.Target,
+				Class:      types.ClassProvenance,
+				Provenance: verifications,
+			},
+		},
+	}
+
+	if err = report.Write(r, opts.ReportOpts()); err != nil {
+		return xerrors.Errorf("unable to write results: %w", err)
+	}
+
+	operation.Exit(opts, r.Results.Failed())
+	return nil
+}
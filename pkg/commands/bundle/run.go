@@ -0,0 +1,72 @@
+package bundle
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/bundle"
+	"github.com/zhanglimao/trivy/pkg/commands/operation"
+	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/javadb"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// Create downloads the databases and the checks bundle if needed and packages them into the bundle
+func Create(ctx context.Context, opts flag.Options, output string) error {
+	if err := log.InitLogger(opts.Debug, opts.Quiet); err != nil {
+		return xerrors.Errorf("failed to initialize a logger: %w", err)
+	}
+
+	noProgress := opts.Quiet || opts.NoProgress
+	if err := operation.DownloadDB(ctx, opts.AppVersion, opts.CacheDir, opts.DBRepositories, noProgress,
+		opts.SkipDBUpdate, opts.RegistryOpts()); err != nil {
+		return err
+	}
+
+	javadb.Init(opts.CacheDir, opts.JavaDBRepository, opts.SkipJavaDBUpdate, noProgress, opts.Insecure)
+	if err := javadb.Update(); err != nil {
+		return xerrors.Errorf("Java DB error: %w", err)
+	}
+
+	if _, err := operation.InitBuiltinPolicies(ctx, opts.CacheDir, noProgress, opts.SkipPolicyUpdate); err != nil {
+		return xerrors.Errorf("checks bundle error: %w", err)
+	}
+
+	components := []bundle.Component{
+		bundle.ComponentDB,
+		bundle.ComponentJavaDB,
+		bundle.ComponentChecks,
+	}
+	if opts.IncludeCache {
+		components = append(components, bundle.ComponentCache)
+	}
+
+	return bundle.Create(output, bundle.CreateOption{
+		CacheDir:     opts.CacheDir,
+		Components:   components,
+		TrivyVersion: opts.AppVersion,
+		SigningKey:   opts.BundleSigningKey,
+	})
+}
+
+// Import verifies the bundle and extracts it into the cache directory
+func Import(_ context.Context, opts flag.Options, bundlePath string) error {
+	if err := log.InitLogger(opts.Debug, opts.Quiet); err != nil {
+		return xerrors.Errorf("failed to initialize a logger: %w", err)
+	}
+
+	manifest, err := bundle.Import(bundlePath, bundle.ImportOption{
+		CacheDir:  opts.CacheDir,
+		PublicKey: opts.BundlePublicKey,
+	})
+	if err != nil {
+		return xerrors.Errorf("bundle import error: %w", err)
+	}
+
+	log.Logger.Infof("Bundle created at %s by Trivy %s has been imported into %s",
+		manifest.CreatedAt.Format(time.RFC3339), manifest.TrivyVersion, opts.CacheDir)
+	log.Logger.Info("Use '--skip-db-update', '--skip-java-db-update' and '--skip-policy-update' to scan with the imported data")
+	return nil
+}
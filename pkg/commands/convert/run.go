@@ -1,20 +1,34 @@
 package convert
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"os"
 
+	"golang.org/x/exp/slices"
 	"golang.org/x/xerrors"
 
 	"github.com/zhanglimao/trivy/pkg/commands/operation"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/flag"
+	k8sreport "github.com/zhanglimao/trivy/pkg/k8s/report"
 	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/owner"
 	"github.com/zhanglimao/trivy/pkg/report"
+	"github.com/zhanglimao/trivy/pkg/report/schema"
 	"github.com/zhanglimao/trivy/pkg/result"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
+// header holds the top-level fields telling the kind of the report
+type header struct {
+	SchemaVersion int
+	ArtifactType  ftypes.ArtifactType
+	ClusterName   *string // Kubernetes
+}
+
 func Run(ctx context.Context, opts flag.Options) (err error) {
 	f, err := os.Open(opts.Target)
 	if err != nil {
@@ -22,27 +36,97 @@ func Run(ctx context.Context, opts flag.Options) (err error) {
 	}
 	defer f.Close()
 
-	var r types.Report
-	if err = json.NewDecoder(f).Decode(&r); err != nil {
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return xerrors.Errorf("read error: %w", err)
+	}
+
+	if opts.Validate {
+		if err = schema.Validate(b); err != nil {
+			return xerrors.Errorf("validation error: %w", err)
+		}
+		log.Logger.Info("The report is valid")
+		return nil
+	}
+
+	var h header
+	if err = json.Unmarshal(b, &h); err != nil {
 		return xerrors.Errorf("json decode error: %w", err)
 	}
 
-	// "convert" supports JSON results produced by Trivy scanning other than AWS and Kubernetes
-	if r.ArtifactName == "" && r.ArtifactType == "" {
-		return xerrors.New("AWS and Kubernetes scanning reports are not yet supported")
+	var r types.Report
+	switch {
+	case h.ClusterName != nil:
+		k8sReport, err := k8sreport.Decode(b)
+		if err != nil {
+			return xerrors.Errorf("kubernetes report decode error: %w", err)
+		}
+		// The Kubernetes table is rendered per resource
+		if opts.Format == report.FormatTable {
+			return writeK8sTable(ctx, k8sReport, opts)
+		}
+		r = k8sReport.ToReport()
+	case h.SchemaVersion == 0 && slices.Contains(ftypes.CloudArtifactTypes, h.ArtifactType):
+		// Reports of cloud accounts didn't have "SchemaVersion" in older versions
+		if err = json.Unmarshal(b, &r); err != nil {
+			return xerrors.Errorf("cloud report decode error: %w", err)
+		}
+	default:
+		if r, err = schema.Decode(bytes.NewReader(b)); err != nil {
+			return xerrors.Errorf("report decode error: %w", err)
+		}
 	}
 
 	if err = result.Filter(ctx, r, opts.FilterOpts()); err != nil {
 		return xerrors.Errorf("unable to filter results: %w", err)
 	}
 
+	// Owners can be annotated afterwards as well, e.g. with CODEOWNERS of the repository the image was built from
+	if opts.OwnersFile != "" {
+		rules, err := owner.Load(opts.OwnersFile)
+		if err != nil {
+			return xerrors.Errorf("unable to load %s: %w", opts.OwnersFile, err)
+		}
+		rules.Annotate(&r)
+	}
+
 	log.Logger.Debug("Writing report to output...")
 	if err = report.Write(r, opts.ReportOpts()); err != nil {
 		return xerrors.Errorf("unable to write results: %w", err)
 	}
 
+	operation.ExitOnMalicious(opts, r.Results)
 	operation.ExitOnEOL(opts, r.Metadata)
 	operation.Exit(opts, r.Results.Failed())
 
 	return nil
 }
+
+func writeK8sTable(ctx context.Context, r k8sreport.Report, opts flag.Options) error {
+	for _, resource := range r.Resources {
+		if err := result.Filter(ctx, types.Report{Results: resource.Results}, opts.FilterOpts()); err != nil {
+			return xerrors.Errorf("unable to filter results: %w", err)
+		}
+	}
+
+	reportFormat := opts.ReportFormat
+	if reportFormat == "" {
+		reportFormat = "summary"
+	}
+
+	log.Logger.Debug("Writing report to output...")
+	err := k8sreport.Write(r, k8sreport.Option{
+		Format:     opts.Format,
+		Report:     reportFormat,
+		Output:     opts.Output,
+		Severities: opts.Severities,
+		Scanners:   r.Scanners(),
+		Components: k8sreport.Components,
+	})
+	if err != nil {
+		return xerrors.Errorf("unable to write results: %w", err)
+	}
+
+	operation.Exit(opts, r.Failed())
+	return nil
+}
@@ -0,0 +1,152 @@
+package convert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func TestRun_Filter(t *testing.T) {
+	type want struct {
+		vulns    []string
+		misconfs []string
+		licenses []string
+	}
+	tests := []struct {
+		name string
+		opts flag.Options
+		want want
+	}{
+		{
+			name: "no filters",
+			want: want{
+				vulns:    []string{"CVE-2020-0004", "CVE-2020-0003", "CVE-2020-0001", "CVE-2020-0002"},
+				misconfs: []string{"DS001"},
+				licenses: []string{"GPL-3.0", "MIT"},
+			},
+		},
+		{
+			name: "severity",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{
+					Severities: []dbTypes.Severity{dbTypes.SeverityCritical, dbTypes.SeverityHigh},
+				},
+			},
+			want: want{
+				vulns:    []string{"CVE-2020-0004", "CVE-2020-0001"},
+				licenses: []string{"GPL-3.0"},
+			},
+		},
+		{
+			name: "ignore unfixed",
+			opts: flag.Options{
+				VulnerabilityOptions: flag.VulnerabilityOptions{
+					IgnoreUnfixed: true,
+				},
+			},
+			want: want{
+				vulns:    []string{"CVE-2020-0004", "CVE-2020-0003", "CVE-2020-0001"},
+				misconfs: []string{"DS001"},
+				licenses: []string{"GPL-3.0", "MIT"},
+			},
+		},
+		{
+			name: "ignore file",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{
+					IgnoreFile: "testdata/.trivyignore",
+				},
+			},
+			want: want{
+				vulns:    []string{"CVE-2020-0004", "CVE-2020-0003", "CVE-2020-0002"},
+				licenses: []string{"GPL-3.0", "MIT"},
+			},
+		},
+		{
+			name: "ignore policy",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{
+					IgnorePolicy: "testdata/ignore.rego",
+				},
+			},
+			want: want{
+				vulns:    []string{"CVE-2020-0003", "CVE-2020-0001", "CVE-2020-0002"},
+				misconfs: []string{"DS001"},
+				licenses: []string{"GPL-3.0", "MIT"},
+			},
+		},
+		{
+			name: "VEX",
+			opts: flag.Options{
+				SBOMOptions: flag.SBOMOptions{
+					VEXPath: "testdata/openvex.json",
+				},
+			},
+			want: want{
+				vulns:    []string{"CVE-2020-0004", "CVE-2020-0001", "CVE-2020-0002"},
+				misconfs: []string{"DS001"},
+				licenses: []string{"GPL-3.0", "MIT"},
+			},
+		},
+		{
+			name: "ignored licenses",
+			opts: flag.Options{
+				LicenseOptions: flag.LicenseOptions{
+					IgnoredLicenses: []string{"GPL-3.0"},
+				},
+			},
+			want: want{
+				vulns:    []string{"CVE-2020-0004", "CVE-2020-0003", "CVE-2020-0001", "CVE-2020-0002"},
+				misconfs: []string{"DS001"},
+				licenses: []string{"MIT"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := new(bytes.Buffer)
+			opts := tt.opts
+			opts.Target = "testdata/report.json"
+			opts.Format = "json"
+			opts.Output = output
+			if len(opts.Severities) == 0 {
+				opts.Severities = []dbTypes.Severity{
+					dbTypes.SeverityUnknown,
+					dbTypes.SeverityLow,
+					dbTypes.SeverityMedium,
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityCritical,
+				}
+			}
+
+			err := Run(context.Background(), opts)
+			require.NoError(t, err)
+
+			var r types.Report
+			require.NoError(t, json.Unmarshal(output.Bytes(), &r))
+
+			var got want
+			for _, result := range r.Results {
+				got.vulns = append(got.vulns, lo.Map(result.Vulnerabilities, func(v types.DetectedVulnerability, _ int) string {
+					return v.VulnerabilityID
+				})...)
+				got.misconfs = append(got.misconfs, lo.Map(result.Misconfigurations, func(m types.DetectedMisconfiguration, _ int) string {
+					return m.ID
+				})...)
+				got.licenses = append(got.licenses, lo.Map(result.Licenses, func(l types.DetectedLicense, _ int) string {
+					return l.Name
+				})...)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package trivy
+
+default ignore = false
+
+ignore {
+	input.PkgName == "bar"
+	input.VulnerabilityID == "CVE-2020-0004"
+}
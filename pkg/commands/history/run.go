@@ -0,0 +1,132 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/aquasecurity/table"
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/history"
+	"github.com/zhanglimao/trivy/pkg/report"
+	tableReport "github.com/zhanglimao/trivy/pkg/report/table"
+)
+
+const timeFormat = "2006-01-02 15:04:05"
+
+// Run shows the recorded artifacts, or the finding trend of the artifact if specified
+func Run(_ context.Context, opts flag.Options) error {
+	if opts.Format != report.FormatTable && opts.Format != report.FormatJSON {
+		return xerrors.Errorf("unsupported format for history: %s", opts.Format)
+	}
+
+	store, err := history.Open(opts.CacheDir)
+	if err != nil {
+		return xerrors.Errorf("unable to open the history: %w", err)
+	}
+	defer store.Close()
+
+	if opts.Target == "" {
+		artifacts, err := store.Artifacts()
+		if err != nil {
+			return xerrors.Errorf("unable to list artifacts: %w", err)
+		}
+		if opts.Format == report.FormatJSON {
+			return writeJSON(opts.Output, artifacts)
+		}
+		writeArtifacts(opts.Output, artifacts)
+		return nil
+	}
+
+	records, err := store.Records(opts.Target)
+	if err != nil {
+		return xerrors.Errorf("unable to get the history of %s: %w", opts.Target, err)
+	} else if len(records) == 0 {
+		return xerrors.Errorf("no scan of %s is recorded, scan it with '--record'", opts.Target)
+	}
+
+	summary := history.Summarize(opts.Target, records)
+	if opts.Format == report.FormatJSON {
+		return writeJSON(opts.Output, summary)
+	}
+	writeSummary(opts.Output, summary)
+	return nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	output, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("failed to marshal json: %w", err)
+	}
+	if _, err = fmt.Fprintln(w, string(output)); err != nil {
+		return xerrors.Errorf("failed to write json: %w", err)
+	}
+	return nil
+}
+
+func writeArtifacts(w io.Writer, artifacts []history.Artifact) {
+	t := newTableWriter(w)
+	t.SetHeaders("Artifact", "Type", "Scans", "Last Scanned", "Findings")
+	for _, a := range artifacts {
+		t.AddRow(a.Name, string(a.Type), strconv.Itoa(a.Scans), a.LastScannedAt.Local().Format(timeFormat),
+			strconv.Itoa(a.Findings))
+	}
+	t.Render()
+}
+
+func writeSummary(w io.Writer, summary history.Summary) {
+	_, _ = fmt.Fprintf(w, "\n%s\n\n", summary.ArtifactName)
+
+	// The most severe first
+	severities := lo.Reverse(slices.Clone(dbTypes.SeverityNames))
+
+	t := newTableWriter(w)
+	headers := []string{"Scanned At", "Total"}
+	headers = append(headers, severities...)
+	headers = append(headers, "New", "Fixed")
+	t.SetHeaders(headers...)
+	for _, scan := range summary.Scans {
+		row := []string{scan.ScannedAt.Local().Format(timeFormat), strconv.Itoa(scan.Total)}
+		for _, severity := range severities {
+			row = append(row, strconv.Itoa(scan.Severities[severity]))
+		}
+		row = append(row, strconv.Itoa(scan.New), strconv.Itoa(scan.Fixed))
+		t.AddRow(row...)
+	}
+	t.Render()
+
+	mttr := "N/A"
+	if summary.FixedVulnerabilities > 0 {
+		mttr = formatDuration(time.Duration(summary.MTTR))
+	}
+	_, _ = fmt.Fprintf(w, "\nFixed vulnerabilities: %d, MTTR: %s\n", summary.FixedVulnerabilities, mttr)
+}
+
+// formatDuration formats the duration in days and hours, e.g. "3d 4h"
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Hour)
+	days, hours := int(d/(24*time.Hour)), int(d%(24*time.Hour)/time.Hour)
+	if days == 0 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dd %dh", days, hours)
+}
+
+func newTableWriter(w io.Writer) *table.Table {
+	t := table.New(w)
+	if tableReport.IsOutputToTerminal(w) {
+		t.SetHeaderStyle(table.StyleBold)
+		t.SetLineStyle(table.StyleDim)
+	}
+	t.SetBorders(true)
+	t.SetRowLines(false)
+	return t
+}
@@ -14,11 +14,13 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/aquasecurity/trivy-db/pkg/metadata"
+	"github.com/zhanglimao/trivy/pkg/advisory"
 	"github.com/zhanglimao/trivy/pkg/db"
 	"github.com/zhanglimao/trivy/pkg/fanal/cache"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/flag"
 	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/malicious"
 	"github.com/zhanglimao/trivy/pkg/policy"
 	"github.com/zhanglimao/trivy/pkg/types"
 	"github.com/zhanglimao/trivy/pkg/utils/fsutils"
@@ -42,28 +44,11 @@ type Cache struct {
 func NewCache(c flag.CacheOptions) (Cache, error) {
 	if strings.HasPrefix(c.CacheBackend, "redis://") {
 		log.Logger.Infof("Redis cache: %s", c.CacheBackendMasked())
-		options, err := redis.ParseURL(c.CacheBackend)
+		options, err := NewRedisOptions(c)
 		if err != nil {
 			return Cache{}, err
 		}
 
-		if !lo.IsEmpty(c.RedisOptions) {
-			caCert, cert, err := GetTLSConfig(c.RedisCACert, c.RedisCert, c.RedisKey)
-			if err != nil {
-				return Cache{}, err
-			}
-
-			options.TLSConfig = &tls.Config{
-				RootCAs:      caCert,
-				Certificates: []tls.Certificate{cert},
-				MinVersion:   tls.VersionTLS12,
-			}
-		} else if c.RedisTLS {
-			options.TLSConfig = &tls.Config{
-				MinVersion: tls.VersionTLS12,
-			}
-		}
-
 		redisCache := cache.NewRedisCache(options, c.CacheTTL)
 		return Cache{Cache: redisCache}, nil
 	}
@@ -80,6 +65,32 @@ func NewCache(c flag.CacheOptions) (Cache, error) {
 	return Cache{Cache: fsCache}, nil
 }
 
+// NewRedisOptions returns the options to connect to the redis cache backend
+func NewRedisOptions(c flag.CacheOptions) (*redis.Options, error) {
+	options, err := redis.ParseURL(c.CacheBackend)
+	if err != nil {
+		return nil, err
+	}
+
+	if !lo.IsEmpty(c.RedisOptions) {
+		caCert, cert, err := GetTLSConfig(c.RedisCACert, c.RedisCert, c.RedisKey)
+		if err != nil {
+			return nil, err
+		}
+
+		options.TLSConfig = &tls.Config{
+			RootCAs:      caCert,
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+	} else if c.RedisTLS {
+		options.TLSConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+	return options, nil
+}
+
 // Reset resets the cache
 func (c Cache) Reset() (err error) {
 	if err := c.ClearDB(); err != nil {
@@ -110,11 +121,11 @@ func (c Cache) ClearArtifacts() error {
 }
 
 // DownloadDB downloads the DB
-func DownloadDB(ctx context.Context, appVersion, cacheDir, dbRepository string, quiet, skipUpdate bool, opt ftypes.RegistryOptions) error {
+func DownloadDB(ctx context.Context, appVersion, cacheDir string, dbRepositories []string, quiet, skipUpdate bool, opt ftypes.RegistryOptions) error {
 	mu.Lock()
 	defer mu.Unlock()
 
-	client := db.NewClient(cacheDir, quiet, db.WithDBRepository(dbRepository))
+	client := db.NewClient(cacheDir, quiet, db.WithDBRepositories(dbRepositories))
 	needsUpdate, err := client.NeedsUpdate(appVersion, skipUpdate)
 	if err != nil {
 		return xerrors.Errorf("database error: %w", err)
@@ -122,7 +133,7 @@ func DownloadDB(ctx context.Context, appVersion, cacheDir, dbRepository string,
 
 	if needsUpdate {
 		log.Logger.Info("Need to update DB")
-		log.Logger.Infof("DB Repository: %s", dbRepository)
+		log.Logger.Infof("DB Repository: %s", strings.Join(dbRepositories, ", "))
 		log.Logger.Info("Downloading DB...")
 		if err = client.Download(ctx, cacheDir, opt); err != nil {
 			return xerrors.Errorf("failed to download vulnerability DB: %w", err)
@@ -136,6 +147,46 @@ func DownloadDB(ctx context.Context, appVersion, cacheDir, dbRepository string,
 	return nil
 }
 
+// InitCustomAdvisories downloads the custom advisories if needed and loads them
+func InitCustomAdvisories(ctx context.Context, opts flag.Options) error {
+	dirs := opts.CustomAdvisoryDirs
+	if opts.CustomAdvisoryRepo != "" {
+		mu.Lock()
+		defer mu.Unlock()
+
+		noProgress := opts.Quiet || opts.NoProgress
+		dir, err := advisory.Download(ctx, opts.CustomAdvisoryRepo, opts.CacheDir, noProgress, opts.SkipDBUpdate, opts.RegistryOpts())
+		if err != nil {
+			return err
+		}
+		dirs = append(dirs, dir)
+	}
+	if len(dirs) == 0 {
+		return nil
+	}
+	return advisory.Load(dirs...)
+}
+
+// InitMaliciousPackages downloads the malicious package feed if needed and loads it
+func InitMaliciousPackages(ctx context.Context, opts flag.Options) error {
+	dirs := opts.MaliciousDBDirs
+	if opts.MaliciousDBRepo != "" {
+		mu.Lock()
+		defer mu.Unlock()
+
+		noProgress := opts.Quiet || opts.NoProgress
+		dir, err := malicious.Download(ctx, opts.MaliciousDBRepo, opts.CacheDir, noProgress, opts.SkipDBUpdate, opts.RegistryOpts())
+		if err != nil {
+			return err
+		}
+		dirs = append(dirs, dir)
+	}
+	if len(dirs) == 0 {
+		return nil
+	}
+	return malicious.Load(dirs...)
+}
+
 func showDBInfo(cacheDir string) error {
 	m := metadata.NewClient(cacheDir)
 	meta, err := m.Get()
@@ -209,6 +260,20 @@ func Exit(opts flag.Options, failedResults bool) {
 	}
 }
 
+func ExitOnMalicious(opts flag.Options, results types.Results) {
+	if opts.ExitOnMalicious != 0 && results.Malicious() {
+		log.Logger.Error("Detected known-malicious packages")
+		os.Exit(opts.ExitOnMalicious)
+	}
+}
+
+func ExitOnDrift(opts flag.Options, results types.Results) {
+	if opts.ExitOnDrift != 0 && results.Drifted() {
+		log.Logger.Error("Detected drift")
+		os.Exit(opts.ExitOnDrift)
+	}
+}
+
 func ExitOnEOL(opts flag.Options, m types.Metadata) {
 	if opts.ExitOnEOL != 0 && m.OS != nil && m.OS.Eosl {
 		log.Logger.Errorf("Detected EOL OS: %s %s", m.OS.Family, m.OS.Name)
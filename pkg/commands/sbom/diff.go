@@ -0,0 +1,116 @@
+package sbom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/aquasecurity/table"
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/commands/operation"
+	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/report"
+	tableReport "github.com/zhanglimao/trivy/pkg/report/table"
+	"github.com/zhanglimao/trivy/pkg/sbom"
+	"github.com/zhanglimao/trivy/pkg/sbom/cyclonedx"
+	"github.com/zhanglimao/trivy/pkg/sbom/diff"
+)
+
+// Diff compares the components of two CycloneDX SBOMs and writes the added, removed and changed components
+func Diff(_ context.Context, opts flag.Options, oldPath, newPath string) error {
+	if opts.Format != report.FormatTable && opts.Format != report.FormatJSON {
+		return xerrors.Errorf("unsupported format for sbom diff: %s", opts.Format)
+	}
+
+	oldBOM, err := decode(oldPath)
+	if err != nil {
+		return xerrors.Errorf("unable to decode %s: %w", oldPath, err)
+	}
+	newBOM, err := decode(newPath)
+	if err != nil {
+		return xerrors.Errorf("unable to decode %s: %w", newPath, err)
+	}
+
+	d := diff.Compare(oldBOM, newBOM)
+	log.Logger.Debugf("Added: %d, Removed: %d, Changed: %d", len(d.Added), len(d.Removed), len(d.Changed))
+
+	if opts.Format == report.FormatJSON {
+		if err = writeJSON(opts.Output, d); err != nil {
+			return err
+		}
+	} else {
+		writeTable(opts.Output, d)
+	}
+
+	operation.Exit(opts, !d.Empty())
+	return nil
+}
+
+func decode(filePath string) (*cdx.BOM, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	format, err := sbom.DetectFormat(f)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to detect SBOM format: %w", err)
+	} else if format != sbom.FormatCycloneDXJSON {
+		return nil, xerrors.Errorf("only CycloneDX JSON is supported, but got %s", format)
+	}
+	return cyclonedx.DecodeJSON(f)
+}
+
+func writeJSON(w io.Writer, d diff.Diff) error {
+	output, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("failed to marshal json: %w", err)
+	}
+	if _, err = fmt.Fprintln(w, string(output)); err != nil {
+		return xerrors.Errorf("failed to write json: %w", err)
+	}
+	return nil
+}
+
+func writeTable(w io.Writer, d diff.Diff) {
+	_, _ = fmt.Fprintf(w, "\nAdded: %d, Removed: %d, Changed: %d\n\n", len(d.Added), len(d.Removed), len(d.Changed))
+	if d.Empty() {
+		return
+	}
+
+	t := table.New(w)
+	if tableReport.IsOutputToTerminal(w) {
+		t.SetHeaderStyle(table.StyleBold)
+		t.SetLineStyle(table.StyleDim)
+	}
+	t.SetBorders(true)
+	t.SetRowLines(false)
+
+	t.SetHeaders("Component", "Change", "Old Version", "New Version")
+	for _, c := range d.Added {
+		t.AddRow(componentName(c.Group, c.Name), "added", "", c.Version)
+	}
+	for _, c := range d.Removed {
+		t.AddRow(componentName(c.Group, c.Name), "removed", c.Version, "")
+	}
+	for _, c := range d.Changed {
+		change := lo.Ternary(c.Change != "", string(c.Change), "changed")
+		t.AddRow(componentName(c.Group, c.Name), change, c.OldVersion, c.NewVersion)
+	}
+	t.Render()
+}
+
+// componentName returns the name with the group, e.g. "org.apache.logging.log4j/log4j-core"
+func componentName(group, name string) string {
+	if group == "" {
+		return name
+	}
+	return group + "/" + name
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/aquasecurity/trivy-db/pkg/db"
 	"github.com/zhanglimao/trivy/pkg/commands/operation"
 	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/javadb"
 	"github.com/zhanglimao/trivy/pkg/log"
 	"github.com/zhanglimao/trivy/pkg/module"
 	rpcServer "github.com/zhanglimao/trivy/pkg/rpc/server"
@@ -34,7 +35,7 @@ func Run(ctx context.Context, opts flag.Options) (err error) {
 	}
 
 	// download the database file
-	if err = operation.DownloadDB(ctx, opts.AppVersion, opts.CacheDir, opts.DBRepository,
+	if err = operation.DownloadDB(ctx, opts.AppVersion, opts.CacheDir, opts.DBRepositories,
 		true, opts.SkipDBUpdate, opts.RegistryOpts()); err != nil {
 		return err
 	}
@@ -47,17 +48,80 @@ func Run(ctx context.Context, opts flag.Options) (err error) {
 		return xerrors.Errorf("error in vulnerability DB initialize: %w", err)
 	}
 
+	if err = operation.InitCustomAdvisories(ctx, opts); err != nil {
+		return xerrors.Errorf("custom advisory error: %w", err)
+	}
+
+	// The Java DB is downloaded on the first lookup from clients
+	javadb.Init(opts.CacheDir, opts.JavaDBRepository, opts.SkipJavaDBUpdate, true, opts.Insecure)
+
 	// Initialize WASM modules
 	m, err := module.NewManager(ctx, module.Options{
-		Dir:            opts.ModuleDir,
-		EnabledModules: opts.EnabledModules,
+		Dir:               opts.ModuleDir,
+		EnabledModules:    opts.EnabledModules,
+		AnalyzerPluginDir: opts.AnalyzerPluginDir,
 	})
 	if err != nil {
 		return xerrors.Errorf("WASM module error: %w", err)
 	}
 	m.Register()
 
+	serverOpts, err := serverOptions(opts)
+	if err != nil {
+		return xerrors.Errorf("server option error: %w", err)
+	}
+
 	server := rpcServer.NewServer(opts.AppVersion, opts.Listen, opts.CacheDir, opts.Token, opts.TokenHeader,
-		opts.DBRepository, opts.RegistryOpts())
+		opts.DBRepositories, opts.RegistryOpts(), serverOpts...)
 	return server.ListenAndServe(cache, opts.SkipDBUpdate)
 }
+
+func serverOptions(opts flag.Options) ([]rpcServer.Option, error) {
+	if opts.ScanWorkers < 1 || opts.ScanQueueSize < 0 {
+		return nil, xerrors.New("'--scan-workers' must be positive and '--scan-queue-size' must not be negative")
+	}
+	serverOpts := []rpcServer.Option{
+		rpcServer.WithScanQueue(opts.ScanWorkers, opts.ScanQueueSize),
+	}
+
+	if opts.TokensFile != "" {
+		tenants, err := rpcServer.LoadTenants(opts.TokensFile)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to load the tokens file: %w", err)
+		}
+		log.Logger.Infof("%d tenant tokens loaded", len(tenants))
+		serverOpts = append(serverOpts, rpcServer.WithTenants(tenants))
+	}
+
+	if (opts.TLSCert == "") != (opts.TLSKey == "") {
+		return nil, xerrors.New("'--tls-cert' and '--tls-key' must be specified together")
+	} else if opts.TLSCert != "" {
+		serverOpts = append(serverOpts, rpcServer.WithTLS(opts.TLSCert, opts.TLSKey))
+	}
+
+	if opts.TLSCA != "" {
+		if opts.TLSCert == "" {
+			return nil, xerrors.New("'--tls-ca' requires '--tls-cert' and '--tls-key'")
+		}
+		serverOpts = append(serverOpts, rpcServer.WithClientCA(opts.TLSCA))
+	}
+
+	if !opts.Webhook {
+		return serverOpts, nil
+	}
+	if opts.TLSCert == "" {
+		log.Logger.Warn("The Kubernetes API server only calls admission webhooks over HTTPS, use '--tls-cert' and '--tls-key' unless TLS is terminated in front of Trivy")
+	}
+
+	policy := rpcServer.WebhookPolicy{
+		Severities: opts.WebhookSeverity,
+	}
+	if opts.WebhookKEV != "" {
+		kev, err := rpcServer.LoadKEV(opts.WebhookKEV)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to load the KEV catalog: %w", err)
+		}
+		policy.KEV = kev
+	}
+	return append(serverOpts, rpcServer.WithWebhook(policy)), nil
+}
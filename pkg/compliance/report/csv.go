@@ -0,0 +1,49 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+
+	"golang.org/x/xerrors"
+)
+
+type CSVWriter struct {
+	Output io.Writer
+	Report string
+}
+
+// Write writes the control status in CSV format.
+// The "all" report has a row for each finding, while the "summary" report has a row for each control.
+func (cw CSVWriter) Write(report *ComplianceReport) error {
+	w := csv.NewWriter(cw.Output)
+	summary := BuildSummary(report)
+
+	var records [][]string
+	switch cw.Report {
+	case summaryReport:
+		records = append(records, []string{ControlIDColumn, SeverityColumn, ControlNameColumn, StatusColumn, IssuesColumn})
+		for _, c := range summary.SummaryControls {
+			records = append(records, []string{c.ID, c.Severity, c.Name, c.Status(), c.Issues()})
+		}
+	case allReport:
+		records = append(records, []string{ControlIDColumn, ControlNameColumn, StatusColumn,
+			"Target", "Finding ID", "Finding Severity", "Title", "Resource", "Due Date"})
+		for i, c := range summary.SummaryControls {
+			fs := findings(report.Results[i].Results)
+			if len(fs) == 0 {
+				records = append(records, []string{c.ID, c.Name, c.Status(), "", "", "", "", "", ""})
+				continue
+			}
+			for _, f := range fs {
+				records = append(records, []string{c.ID, c.Name, c.Status(), f.Target, f.ID, f.Severity, f.Title, f.Resource, f.DueDate})
+			}
+		}
+	default:
+		return xerrors.Errorf(`report %q not supported. Use "summary" or "all"`, cw.Report)
+	}
+
+	if err := w.WriteAll(records); err != nil {
+		return xerrors.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
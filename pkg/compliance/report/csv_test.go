@@ -0,0 +1,114 @@
+package report_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/compliance/report"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+var exportInput = &report.ComplianceReport{
+	ID:               "1234",
+	Title:            "NSA",
+	Description:      "National Security Agency - Kubernetes Hardening Guidance",
+	Version:          "1.0",
+	RelatedResources: []string{"https://example.com"},
+	Results: []*report.ControlCheckResult{
+		{
+			ID:          "1.0",
+			Name:        "Non-root containers",
+			Description: "Check that container is not running as root",
+			Severity:    "MEDIUM",
+			Results: types.Results{
+				{
+					Target: "Deployment/app",
+					Misconfigurations: []types.DetectedMisconfiguration{
+						{AVDID: "AVD-KSV012", Title: "Runs as root user", Severity: "MEDIUM", Status: types.StatusFailure},
+					},
+				},
+			},
+		},
+		{
+			ID:       "1.1",
+			Name:     "No critical vulnerabilities",
+			Severity: "CRITICAL",
+			Results: types.Results{
+				{
+					Target: "alpine:3.17 (alpine 3.17.0)",
+					Vulnerabilities: []types.DetectedVulnerability{
+						{
+							VulnerabilityID:  "CVE-2022-0001",
+							PkgName:          "openssl",
+							InstalledVersion: "3.0.7-r0",
+							DueDate:          lo.ToPtr(time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)),
+							Vulnerability: dbTypes.Vulnerability{
+								Title:    "openssl: \"quoted\", title",
+								Severity: "CRITICAL",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			ID:       "1.2",
+			Name:     "Immutable container file systems",
+			Severity: "LOW",
+		},
+		{
+			ID:       "1.3",
+			Name:     "Ensure that containers use trusted base images (Manual)",
+			Severity: "HIGH",
+		},
+	},
+}
+
+func TestCSVWriter_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		reportType string
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "summary",
+			reportType: "summary",
+			want:       filepath.Join("testdata", "summary.csv"),
+		},
+		{
+			name:       "all",
+			reportType: "all",
+			want:       filepath.Join("testdata", "all.csv"),
+		},
+		{
+			name:       "unknown report",
+			reportType: "unknown",
+			wantErr:    "not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := report.CSVWriter{Report: tt.reportType, Output: buf}
+			err := w.Write(exportInput)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			want, err := os.ReadFile(tt.want)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), buf.String())
+		})
+	}
+}
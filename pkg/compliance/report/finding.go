@@ -0,0 +1,78 @@
+package report
+
+import (
+	"time"
+
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// Finding is a flattened issue failing a control, used by the HTML and CSV writers
+type Finding struct {
+	Target   string
+	ID       string
+	Severity string
+	Title    string
+	Resource string // e.g. package name, resource name or file path
+	DueDate  string // e.g. 2023-01-31, empty without SLA
+}
+
+// findings flattens vulnerabilities, misconfigurations, secrets and licenses in the results
+func findings(results types.Results) []Finding {
+	var fs []Finding
+	for _, r := range results {
+		for _, v := range r.Vulnerabilities {
+			fs = append(fs, Finding{
+				Target:   r.Target,
+				ID:       v.VulnerabilityID,
+				Severity: v.Severity,
+				Title:    v.Title,
+				Resource: v.PkgName + "@" + v.InstalledVersion,
+				DueDate:  formatDueDate(v.DueDate),
+			})
+		}
+		for _, m := range r.Misconfigurations {
+			if m.Status != types.StatusFailure {
+				continue
+			}
+			fs = append(fs, Finding{
+				Target:   r.Target,
+				ID:       m.AVDID,
+				Severity: m.Severity,
+				Title:    m.Title,
+				Resource: m.CauseMetadata.Resource,
+				DueDate:  formatDueDate(m.DueDate),
+			})
+		}
+		for _, s := range r.Secrets {
+			fs = append(fs, Finding{
+				Target:   r.Target,
+				ID:       s.RuleID,
+				Severity: s.Severity,
+				Title:    s.Title,
+				DueDate:  formatDueDate(s.DueDate),
+			})
+		}
+		for _, l := range r.Licenses {
+			resource := l.PkgName
+			if resource == "" {
+				resource = l.FilePath
+			}
+			fs = append(fs, Finding{
+				Target:   r.Target,
+				ID:       l.Name,
+				Severity: l.Severity,
+				Title:    string(l.Category),
+				Resource: resource,
+				DueDate:  formatDueDate(l.DueDate),
+			})
+		}
+	}
+	return fs
+}
+
+func formatDueDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
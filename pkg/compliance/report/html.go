@@ -0,0 +1,94 @@
+package report
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+//go:embed html.tpl
+var htmlTemplate string
+
+type HTMLWriter struct {
+	Output io.Writer
+	Report string
+
+	// Now is used for the generation time. time.Now is used if not specified.
+	Now func() time.Time
+}
+
+type htmlControl struct {
+	ControlCheckSummary
+	Result      string // "PASS", "FAIL" or "MANUAL"
+	Description string
+	Findings    []Finding
+}
+
+type htmlReport struct {
+	ID               string
+	Title            string
+	Description      string
+	Version          string
+	RelatedResources []string
+	GeneratedAt      string
+	Detailed         bool
+	Passed           int
+	Failed           int
+	Manual           int
+	Controls         []htmlControl
+}
+
+// Write writes the results in HTML format, which can be printed as PDF from browsers.
+// The "all" report adds the details of each control to the summary.
+func (hw HTMLWriter) Write(report *ComplianceReport) error {
+	if hw.Report != summaryReport && hw.Report != allReport {
+		return xerrors.Errorf(`report %q not supported. Use "summary" or "all"`, hw.Report)
+	}
+
+	now := time.Now
+	if hw.Now != nil {
+		now = hw.Now
+	}
+
+	r := htmlReport{
+		ID:               report.ID,
+		Title:            report.Title,
+		Description:      report.Description,
+		Version:          report.Version,
+		RelatedResources: report.RelatedResources,
+		GeneratedAt:      now().UTC().Format(time.RFC3339),
+		Detailed:         hw.Report == allReport,
+	}
+	for i, c := range BuildSummary(report).SummaryControls {
+		hc := htmlControl{
+			ControlCheckSummary: c,
+			Result:              c.Status(),
+			Description:         report.Results[i].Description,
+		}
+		switch hc.Result {
+		case "PASS":
+			r.Passed++
+		case "FAIL":
+			r.Failed++
+		default:
+			hc.Result = "MANUAL"
+			r.Manual++
+		}
+		if r.Detailed {
+			hc.Findings = findings(report.Results[i].Results)
+		}
+		r.Controls = append(r.Controls, hc)
+	}
+
+	tmpl, err := template.New("compliance").Parse(htmlTemplate)
+	if err != nil {
+		return xerrors.Errorf("template parse error: %w", err)
+	}
+	if err = tmpl.Execute(hw.Output, r); err != nil {
+		return xerrors.Errorf("failed to write html: %w", err)
+	}
+	return nil
+}
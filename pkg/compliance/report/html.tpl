@@ -0,0 +1,66 @@
+<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <title>{{ .Title }}</title>
+  <style>
+    body { font-family: Arial, Helvetica, sans-serif; font-size: 12px; margin: 2em; color: #222; }
+    h1 { font-size: 20px; }
+    h2 { font-size: 16px; margin-top: 2em; }
+    table { border-collapse: collapse; width: 100%; margin-bottom: 1em; }
+    th, td { border: 1px solid #ccc; padding: 4px 6px; text-align: left; vertical-align: top; }
+    th { background-color: #eee; }
+    .PASS { color: #1a7f37; font-weight: bold; }
+    .FAIL { color: #cf222e; font-weight: bold; }
+    .MANUAL { color: #6e7781; }
+    .control { page-break-inside: avoid; }
+    @media print {
+      body { margin: 0; }
+      .details { page-break-before: always; }
+    }
+  </style>
+</head>
+<body>
+  <h1>{{ .Title }}</h1>
+  <table>
+    <tr><th>ID</th><td>{{ .ID }}</td></tr>
+    <tr><th>Version</th><td>{{ .Version }}</td></tr>
+    {{- if .Description }}
+    <tr><th>Description</th><td>{{ .Description }}</td></tr>
+    {{- end }}
+    {{- if .RelatedResources }}
+    <tr><th>Related Resources</th><td>{{ range .RelatedResources }}<a href="{{ . }}">{{ . }}</a><br>{{ end }}</td></tr>
+    {{- end }}
+    <tr><th>Generated At</th><td>{{ .GeneratedAt }}</td></tr>
+    <tr><th>Result</th><td>{{ .Passed }} passed, {{ .Failed }} failed, {{ .Manual }} manual</td></tr>
+  </table>
+
+  <h2>Summary</h2>
+  <table>
+    <tr><th>ID</th><th>Severity</th><th>Control Name</th><th>Status</th><th>Issues</th></tr>
+    {{- range .Controls }}
+    <tr><td>{{ .ID }}</td><td>{{ .Severity }}</td><td>{{ .Name }}</td><td class="{{ .Result }}">{{ .Result }}</td><td>{{ .Issues }}</td></tr>
+    {{- end }}
+  </table>
+{{- if .Detailed }}
+
+  <h2 class="details">Details</h2>
+  {{- range .Controls }}
+  <div class="control">
+    <h3>{{ .ID }} {{ .Name }} <span class="{{ .Result }}">{{ .Result }}</span></h3>
+    {{- if .Description }}
+    <p>{{ .Description }}</p>
+    {{- end }}
+    {{- if .Findings }}
+    <table>
+      <tr><th>Target</th><th>ID</th><th>Severity</th><th>Title</th><th>Resource</th><th>Due Date</th></tr>
+      {{- range .Findings }}
+      <tr><td>{{ .Target }}</td><td>{{ .ID }}</td><td>{{ .Severity }}</td><td>{{ .Title }}</td><td>{{ .Resource }}</td><td>{{ .DueDate }}</td></tr>
+      {{- end }}
+    </table>
+    {{- end }}
+  </div>
+  {{- end }}
+{{- end }}
+</body>
+</html>
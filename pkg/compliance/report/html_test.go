@@ -0,0 +1,51 @@
+package report_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/compliance/report"
+)
+
+func TestHTMLWriter_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		reportType string
+		want       string
+	}{
+		{
+			name:       "summary",
+			reportType: "summary",
+			want:       filepath.Join("testdata", "summary.html"),
+		},
+		{
+			name:       "all",
+			reportType: "all",
+			want:       filepath.Join("testdata", "all.html"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := report.HTMLWriter{
+				Report: tt.reportType,
+				Output: buf,
+				Now: func() time.Time {
+					return time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+				},
+			}
+			err := w.Write(exportInput)
+			require.NoError(t, err)
+
+			want, err := os.ReadFile(tt.want)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), buf.String())
+		})
+	}
+}
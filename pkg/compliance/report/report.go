@@ -18,8 +18,18 @@ const (
 
 	tableFormat = "table"
 	jsonFormat  = "json"
+	htmlFormat  = "html"
+	csvFormat   = "csv"
 )
 
+// SupportedFormats is the list of formats the compliance report supports
+var SupportedFormats = []string{
+	tableFormat,
+	jsonFormat,
+	htmlFormat,
+	csvFormat,
+}
+
 type Option struct {
 	Format        string
 	Report        string
@@ -86,8 +96,14 @@ func Write(report *ComplianceReport, option Option) error {
 			}
 		}
 		return nil
+	case htmlFormat:
+		hwriter := HTMLWriter{Output: option.Output, Report: option.Report}
+		return hwriter.Write(report)
+	case csvFormat:
+		cwriter := CSVWriter{Output: option.Output, Report: option.Report}
+		return cwriter.Write(report)
 	default:
-		return xerrors.Errorf(`unknown format %q. Use "json" or "table"`, option.Format)
+		return xerrors.Errorf(`unknown format %q. Use "json", "table", "html" or "csv"`, option.Format)
 	}
 }
 
@@ -103,6 +119,7 @@ func buildControlCheckResults(checksMap map[string]types.Results, controls []def
 		for _, c := range control.Checks {
 			results = append(results, checksMap[c.ID]...)
 		}
+		results = append(results, checksMap[spec.ThresholdCheckID(control.ID)]...)
 		complianceResults = append(complianceResults, &ControlCheckResult{
 			Name:          control.Name,
 			ID:            control.ID,
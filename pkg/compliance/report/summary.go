@@ -78,23 +78,33 @@ func (s SummaryWriter) columns() []string {
 
 func (s SummaryWriter) generateSummary(summaryControls ControlCheckSummary) []string {
 	// "-" means manual checks
-	numOfIssues := "-"
-	status := "-"
-	if summaryControls.TotalFail != nil {
-		if *summaryControls.TotalFail == 0 {
-			status = "PASS"
-		} else {
-			status = "FAIL"
-		}
-		numOfIssues = strconv.Itoa(*summaryControls.TotalFail)
-	}
 	return []string{
 		summaryControls.ID,
 		summaryControls.Severity,
 		summaryControls.Name,
-		status,
-		numOfIssues,
+		summaryControls.Status(),
+		summaryControls.Issues(),
+	}
+}
+
+// Status returns "PASS" or "FAIL", or "-" for manual checks
+func (s ControlCheckSummary) Status() string {
+	switch {
+	case s.TotalFail == nil:
+		return "-"
+	case *s.TotalFail == 0:
+		return "PASS"
+	default:
+		return "FAIL"
+	}
+}
+
+// Issues returns the number of failures, or "-" for manual checks
+func (s ControlCheckSummary) Issues() string {
+	if s.TotalFail == nil {
+		return "-"
 	}
+	return strconv.Itoa(*s.TotalFail)
 }
 
 func configureHeader(t *table.Table, columnHeading []string) {
@@ -0,0 +1,20 @@
+package spec
+
+import (
+	"embed"
+	"path"
+)
+
+// builtinSpecs holds compliance specs bundled with Trivy.
+// They take precedence over the specs in defsec so that checks can be added without waiting for defsec.
+//
+//go:embed builtin/*.yaml
+var builtinSpecs embed.FS
+
+func builtinSpec(name string) ([]byte, bool) {
+	b, err := builtinSpecs.ReadFile(path.Join("builtin", name+".yaml"))
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
@@ -19,6 +19,19 @@ type Severity string
 // ComplianceSpec represent the compliance specification
 type ComplianceSpec struct {
 	Spec defsecTypes.Spec `yaml:"spec"`
+
+	// Thresholds holds severity thresholds by control ID
+	Thresholds map[string][]Threshold `yaml:"-"`
+}
+
+// thresholdSpec is used to decode thresholds of controls which are not defined in defsec
+type thresholdSpec struct {
+	Spec struct {
+		Controls []struct {
+			ID         string      `yaml:"id"`
+			Thresholds []Threshold `yaml:"thresholds"`
+		} `yaml:"controls"`
+	} `yaml:"spec"`
 }
 
 const (
@@ -39,6 +52,11 @@ func (cs *ComplianceSpec) Scanners() (types.Scanners, error) {
 			scannerTypes[scannerType] = struct{}{}
 		}
 	}
+	for _, thresholds := range cs.Thresholds {
+		for _, t := range thresholds {
+			scannerTypes[t.Scanner] = struct{}{}
+		}
+	}
 	return maps.Keys(scannerTypes), nil
 }
 
@@ -79,15 +97,46 @@ func GetComplianceSpec(specNameOrPath string) (ComplianceSpec, error) {
 		if err != nil {
 			return ComplianceSpec{}, fmt.Errorf("error retrieving compliance spec from path: %w", err)
 		}
+	} else if bs, ok := builtinSpec(specNameOrPath); ok {
+		b = bs
 	} else {
 		// TODO: GetSpecByName() should return []byte
 		b = []byte(sp.NewSpecLoader().GetSpecByName(specNameOrPath))
 	}
 
+	return parseComplianceSpec(b)
+}
+
+func parseComplianceSpec(b []byte) (ComplianceSpec, error) {
 	var complianceSpec ComplianceSpec
-	if err = yaml.Unmarshal(b, &complianceSpec); err != nil {
+	if err := yaml.Unmarshal(b, &complianceSpec); err != nil {
 		return ComplianceSpec{}, xerrors.Errorf("spec yaml decode error: %w", err)
 	}
+
+	var err error
+	if complianceSpec.Thresholds, err = parseThresholds(b); err != nil {
+		return ComplianceSpec{}, xerrors.Errorf("threshold error: %w", err)
+	}
 	return complianceSpec, nil
+}
+
+func parseThresholds(b []byte) (map[string][]Threshold, error) {
+	var ts thresholdSpec
+	if err := yaml.Unmarshal(b, &ts); err != nil {
+		return nil, xerrors.Errorf("spec yaml decode error: %w", err)
+	}
 
+	thresholds := map[string][]Threshold{}
+	for _, control := range ts.Spec.Controls {
+		for _, t := range control.Thresholds {
+			if err := t.validate(); err != nil {
+				return nil, xerrors.Errorf("control %s: %w", control.ID, err)
+			}
+			thresholds[control.ID] = append(thresholds[control.ID], t)
+		}
+	}
+	if len(thresholds) == 0 {
+		return nil, nil
+	}
+	return thresholds, nil
 }
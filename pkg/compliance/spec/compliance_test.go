@@ -3,6 +3,7 @@ package spec_test
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"testing"
 
 	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
@@ -241,3 +242,72 @@ func TestComplianceSpec_CheckIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestGetComplianceSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    map[string][]spec.Threshold
+		wantErr string
+	}{
+		{
+			name: "thresholds",
+			path: "@testdata/thresholds.yaml",
+			want: map[string][]spec.Threshold{
+				"1.0": {
+					{
+						Scanner:  types.VulnerabilityScanner,
+						Severity: "CRITICAL",
+						Class:    types.ClassOSPkg,
+					},
+				},
+				"2.0": {
+					{
+						Scanner:  types.SecretScanner,
+						Severity: "high",
+						Max:      1,
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid severity",
+			path:    "@testdata/invalid-threshold.yaml",
+			wantErr: "invalid severity",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := spec.GetComplianceSpec(tt.path)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Thresholds)
+
+			scanners, err := got.Scanners()
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, types.Scanners{types.VulnerabilityScanner, types.SecretScanner}, scanners)
+		})
+	}
+}
+
+func TestGetComplianceSpec_Builtin(t *testing.T) {
+	cs, err := spec.GetComplianceSpec("docker-cis")
+	assert.NoError(t, err)
+	assert.Equal(t, "docker-cis", cs.Spec.ID)
+
+	checkIDs := cs.CheckIDs()
+	assert.Contains(t, checkIDs[types.MisconfigScanner], "AVD-DS-0002") // USER
+	assert.Contains(t, checkIDs[types.MisconfigScanner], "AVD-DS-0004") // EXPOSE 22
+	assert.Contains(t, checkIDs[types.MisconfigScanner], "AVD-DS-0005") // ADD
+	assert.Contains(t, checkIDs[types.MisconfigScanner], "AVD-DS-0026") // HEALTHCHECK
+
+	// Controls which can't be automated have no checks
+	for _, control := range cs.Spec.Controls {
+		if strings.Contains(control.Name, "(Manual)") {
+			assert.Empty(t, control.Checks, control.ID)
+		}
+	}
+}
@@ -67,5 +67,11 @@ func AggregateAllChecksBySpecID(multiResults []types.Results, cs ComplianceSpec)
 			}
 		}
 	}
+
+	for controlID, thresholds := range cs.Thresholds {
+		if failed := evaluateThresholds(multiResults, thresholds); len(failed) > 0 {
+			complianceArr[ThresholdCheckID(controlID)] = failed
+		}
+	}
 	return complianceArr
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	"github.com/zhanglimao/trivy/pkg/compliance/spec"
 	"github.com/zhanglimao/trivy/pkg/fanal/secret"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
@@ -163,3 +164,78 @@ func TestMapSpecCheckIDToFilteredResults(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregateAllChecksBySpecID_Thresholds(t *testing.T) {
+	results := []types.Results{
+		{
+			{
+				Target: "alpine:3.17 (alpine 3.17.0)",
+				Class:  types.ClassOSPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{VulnerabilityID: "CVE-2022-0001", Vulnerability: dbTypes.Vulnerability{Severity: "CRITICAL"}},
+					{VulnerabilityID: "CVE-2022-0002", Vulnerability: dbTypes.Vulnerability{Severity: "LOW"}},
+				},
+			},
+			{
+				Target: "app/package-lock.json",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{VulnerabilityID: "CVE-2022-0003", Vulnerability: dbTypes.Vulnerability{Severity: "CRITICAL"}},
+				},
+			},
+			{
+				Target: "app/.env",
+				Class:  types.ClassSecret,
+				Secrets: []ftypes.SecretFinding{
+					{RuleID: "aws-access-key-id", Severity: "CRITICAL"},
+				},
+			},
+		},
+	}
+	cs := spec.ComplianceSpec{
+		Thresholds: map[string][]spec.Threshold{
+			"1.0": {
+				{
+					Scanner:  types.VulnerabilityScanner,
+					Severity: "CRITICAL",
+					Class:    types.ClassOSPkg,
+				},
+			},
+			"2.0": {
+				{
+					Scanner:  types.SecretScanner,
+					Severity: "HIGH",
+					Max:      1,
+				},
+			},
+			"3.0": {
+				{
+					Scanner: types.VulnerabilityScanner,
+					Target:  "app/*.json",
+				},
+			},
+		},
+	}
+	want := map[string]types.Results{
+		"THRESHOLD-1.0": {
+			{
+				Target: "alpine:3.17 (alpine 3.17.0)",
+				Class:  types.ClassOSPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{VulnerabilityID: "CVE-2022-0001", Vulnerability: dbTypes.Vulnerability{Severity: "CRITICAL"}},
+				},
+			},
+		},
+		"THRESHOLD-3.0": {
+			{
+				Target: "app/package-lock.json",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{VulnerabilityID: "CVE-2022-0003", Vulnerability: dbTypes.Vulnerability{Severity: "CRITICAL"}},
+				},
+			},
+		},
+	}
+	got := spec.AggregateAllChecksBySpecID(results, cs)
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,237 @@
+package spec
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/attestation"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/remote"
+	"github.com/zhanglimao/trivy/pkg/signature"
+)
+
+const (
+	ociScheme = "oci://"
+
+	// cacheTTL is how long a fetched spec is used without fetching it again
+	cacheTTL = 24 * time.Hour
+
+	// cosignSignatureAnnotation holds the signature of the payload in cosign signature images
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+)
+
+// RemoteOption represents options for fetching compliance specs from URLs and OCI registries
+type RemoteOption struct {
+	CacheDir string
+
+	// PublicKey is the path to a PEM-encoded public key.
+	// Signatures of specs are verified if it is specified.
+	PublicKey string
+
+	RegistryOptions ftypes.RegistryOptions
+}
+
+// IsRemote returns true if the compliance spec should be fetched from a URL or OCI registry
+func IsRemote(specRef string) bool {
+	return strings.HasPrefix(specRef, "http://") || strings.HasPrefix(specRef, "https://") ||
+		strings.HasPrefix(specRef, ociScheme)
+}
+
+// GetRemoteComplianceSpec fetches the compliance spec from a URL or OCI registry.
+// The spec is cached in the cache directory and re-fetched after the cache expires.
+func GetRemoteComplianceSpec(ctx context.Context, specRef string, opt RemoteOption) (ComplianceSpec, error) {
+	cachePath := filepath.Join(opt.CacheDir, "compliance", cacheKey(specRef, opt.PublicKey)+".yaml")
+	if fi, err := os.Stat(cachePath); err == nil && time.Since(fi.ModTime()) < cacheTTL {
+		log.Logger.Debugf("Using the cached compliance spec: %s", cachePath)
+		return loadCachedSpec(cachePath)
+	}
+
+	b, err := fetchSpec(ctx, specRef, opt)
+	if err != nil {
+		if _, statErr := os.Stat(cachePath); statErr == nil {
+			log.Logger.Warnf("Unable to fetch the compliance spec, falling back to the cached spec: %s", err)
+			return loadCachedSpec(cachePath)
+		}
+		return ComplianceSpec{}, xerrors.Errorf("unable to fetch the compliance spec (%s): %w", specRef, err)
+	}
+
+	cs, err := parseComplianceSpec(b)
+	if err != nil {
+		return ComplianceSpec{}, err
+	}
+
+	// The spec is cached only when it is valid and verified
+	if err = os.MkdirAll(filepath.Dir(cachePath), 0700); err != nil {
+		return ComplianceSpec{}, xerrors.Errorf("failed to create a cache dir: %w", err)
+	}
+	if err = os.WriteFile(cachePath, b, 0600); err != nil {
+		return ComplianceSpec{}, xerrors.Errorf("failed to cache the compliance spec: %w", err)
+	}
+	return cs, nil
+}
+
+// cacheKey distinguishes cached specs by the public key
+// so that specs verified with another key or not verified are not used.
+func cacheKey(specRef, publicKey string) string {
+	h := sha256.Sum256([]byte(specRef + "\n" + publicKey))
+	return hex.EncodeToString(h[:])
+}
+
+func loadCachedSpec(cachePath string) (ComplianceSpec, error) {
+	b, err := os.ReadFile(cachePath)
+	if err != nil {
+		return ComplianceSpec{}, xerrors.Errorf("failed to read the cached compliance spec: %w", err)
+	}
+	return parseComplianceSpec(b)
+}
+
+func fetchSpec(ctx context.Context, specRef string, opt RemoteOption) ([]byte, error) {
+	if strings.HasPrefix(specRef, ociScheme) {
+		return fetchOCISpec(ctx, strings.TrimPrefix(specRef, ociScheme), opt)
+	}
+	return fetchHTTPSpec(ctx, specRef, opt)
+}
+
+// fetchHTTPSpec downloads the spec from the URL.
+// The signature is downloaded from "<URL>.sig" as "cosign sign-blob" generates.
+func fetchHTTPSpec(ctx context.Context, url string, opt RemoteOption) ([]byte, error) {
+	b, err := httpGet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if opt.PublicKey == "" {
+		return b, nil
+	}
+
+	sig, err := httpGet(ctx, url+".sig")
+	if err != nil {
+		return nil, xerrors.Errorf("signature error: %w", err)
+	}
+	if err = signature.Verify(opt.PublicKey, b, sig); err != nil {
+		return nil, xerrors.Errorf("signature verification error: %w", err)
+	}
+	return b, nil
+}
+
+func httpGet(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, xerrors.Errorf("http request error: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, xerrors.Errorf("http error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, xerrors.Errorf("unexpected status code (%s): %d", url, resp.StatusCode)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, xerrors.Errorf("read error: %w", err)
+	}
+	return b, nil
+}
+
+// fetchOCISpec downloads the spec stored as a single layer of the OCI artifact.
+// The signature is looked up in the signature image that cosign pushes.
+func fetchOCISpec(ctx context.Context, repo string, opt RemoteOption) ([]byte, error) {
+	ref, err := name.ParseReference(repo)
+	if err != nil {
+		return nil, xerrors.Errorf("repository name error (%s): %w", repo, err)
+	}
+	img, err := remote.Image(ctx, ref, opt.RegistryOptions)
+	if err != nil {
+		return nil, xerrors.Errorf("OCI repository error: %w", err)
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, xerrors.Errorf("OCI layer error: %w", err)
+	} else if len(layers) != 1 {
+		return nil, xerrors.New("OCI artifact must be a single layer")
+	}
+	rc, err := layers[0].Uncompressed()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to fetch the layer: %w", err)
+	}
+	defer rc.Close()
+
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, xerrors.Errorf("read error: %w", err)
+	}
+	if opt.PublicKey == "" {
+		return b, nil
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, xerrors.Errorf("digest error: %w", err)
+	}
+	if err = verifyOCISignature(ctx, ref, digest.String(), opt); err != nil {
+		return nil, xerrors.Errorf("signature verification error: %w", err)
+	}
+	return b, nil
+}
+
+// verifyOCISignature verifies the cosign signature of the artifact.
+// The signed payload must refer to the digest of the artifact.
+func verifyOCISignature(ctx context.Context, ref name.Reference, digest string, opt RemoteOption) error {
+	sigTag := strings.Replace(digest, ":", "-", 1) + ".sig"
+	sigRef := ref.Context().Tag(sigTag)
+	sigImg, err := remote.Image(ctx, sigRef, opt.RegistryOptions)
+	if err != nil {
+		return xerrors.Errorf("signature image error (%s): %w", sigRef.String(), err)
+	}
+
+	manifest, err := sigImg.Manifest()
+	if err != nil {
+		return xerrors.Errorf("signature manifest error: %w", err)
+	}
+	layers, err := sigImg.Layers()
+	if err != nil {
+		return xerrors.Errorf("signature layer error: %w", err)
+	}
+
+	for i, layer := range layers {
+		sig, ok := manifest.Layers[i].Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		rc, err := layer.Compressed()
+		if err != nil {
+			return xerrors.Errorf("failed to fetch the signature payload: %w", err)
+		}
+		payload, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return xerrors.Errorf("read error: %w", err)
+		}
+
+		if err = signature.Verify(opt.PublicKey, payload, []byte(sig)); err != nil {
+			log.Logger.Debugf("Signature mismatch: %s", err)
+			continue
+		}
+		if err = attestation.VerifyPayloadDigest(payload, digest); err != nil {
+			return err
+		}
+		return nil
+	}
+	return xerrors.New("no valid signature found")
+}
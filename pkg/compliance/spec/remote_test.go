@@ -0,0 +1,121 @@
+package spec_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/compliance/spec"
+)
+
+func TestGetRemoteComplianceSpec(t *testing.T) {
+	content, err := os.ReadFile("testdata/thresholds.yaml")
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	digest := sha256.Sum256(content)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicKey := filepath.Join(t.TempDir(), "cosign.pub")
+	err = os.WriteFile(publicKey, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0600)
+	require.NoError(t, err)
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherSig, err := ecdsa.SignASN1(rand.Reader, otherKey, digest[:])
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/spec.yaml", "/invalid-sig.yaml":
+			_, _ = w.Write(content)
+		case "/spec.yaml.sig":
+			_, _ = w.Write([]byte(base64.StdEncoding.EncodeToString(sig)))
+		case "/invalid-sig.yaml.sig":
+			_, _ = w.Write([]byte(base64.StdEncoding.EncodeToString(otherSig)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name      string
+		path      string
+		publicKey string
+		wantID    string
+		wantErr   string
+	}{
+		{
+			name:   "without verification",
+			path:   "/spec.yaml",
+			wantID: "0001",
+		},
+		{
+			name:      "valid signature",
+			path:      "/spec.yaml",
+			publicKey: publicKey,
+			wantID:    "0001",
+		},
+		{
+			name:      "invalid signature",
+			path:      "/invalid-sig.yaml",
+			publicKey: publicKey,
+			wantErr:   "signature verification error",
+		},
+		{
+			name:    "not found",
+			path:    "/missing.yaml",
+			wantErr: "unexpected status code",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := spec.GetRemoteComplianceSpec(context.Background(), ts.URL+tt.path, spec.RemoteOption{
+				CacheDir:  t.TempDir(),
+				PublicKey: tt.publicKey,
+			})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, got.Spec.ID)
+			assert.Len(t, got.Thresholds, 2)
+		})
+	}
+
+	t.Run("cached", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(content)
+		}))
+		url := srv.URL + "/spec.yaml"
+
+		_, err := spec.GetRemoteComplianceSpec(context.Background(), url, spec.RemoteOption{CacheDir: cacheDir})
+		require.NoError(t, err)
+
+		// The cached spec should be used without fetching
+		srv.Close()
+		got, err := spec.GetRemoteComplianceSpec(context.Background(), url, spec.RemoteOption{CacheDir: cacheDir})
+		require.NoError(t, err)
+		assert.Equal(t, "0001", got.Spec.ID)
+	})
+}
@@ -0,0 +1,152 @@
+package spec
+
+import (
+	"path"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+const thresholdCheckIDPrefix = "THRESHOLD-"
+
+var thresholdScanners = []types.Scanner{
+	types.VulnerabilityScanner,
+	types.MisconfigScanner,
+	types.SecretScanner,
+	types.LicenseScanner,
+}
+
+// Threshold fails a control when findings matching the conditions exceed the limit,
+// e.g. no CRITICAL vulnerabilities in OS packages or no HIGH secrets.
+//
+//	thresholds:
+//	  - scanner: vuln
+//	    severity: CRITICAL
+//	    class: os-pkgs
+type Threshold struct {
+	Scanner  types.Scanner     `yaml:"scanner"`
+	Severity string            `yaml:"severity"` // The minimum severity of findings to count
+	Class    types.ResultClass `yaml:"class"`    // Optional
+	Target   string            `yaml:"target"`   // Optional glob pattern of targets, e.g. "app/*/package-lock.json"
+	Max      int               `yaml:"max"`      // The number of findings allowed, 0 by default
+}
+
+// ThresholdCheckID returns the pseudo check ID which the results failing thresholds of the control are mapped to
+func ThresholdCheckID(controlID string) string {
+	return thresholdCheckIDPrefix + controlID
+}
+
+func (t Threshold) validate() error {
+	if !slices.Contains(thresholdScanners, t.Scanner) {
+		return xerrors.Errorf("unsupported scanner %q, must be one of %q", t.Scanner, thresholdScanners)
+	}
+	if t.Severity != "" {
+		if _, err := dbTypes.NewSeverity(strings.ToUpper(t.Severity)); err != nil {
+			return xerrors.Errorf("invalid severity: %w", err)
+		}
+	}
+	if t.Target != "" {
+		if _, err := path.Match(t.Target, ""); err != nil {
+			return xerrors.Errorf("invalid target pattern %q: %w", t.Target, err)
+		}
+	}
+	if t.Max < 0 {
+		return xerrors.Errorf("max must not be negative: %d", t.Max)
+	}
+	return nil
+}
+
+// evaluate returns the results holding the matching findings if they exceed the limit
+func (t Threshold) evaluate(multiResults []types.Results) types.Results {
+	var filtered types.Results
+	var count int
+	for _, results := range multiResults {
+		for _, result := range results {
+			if !t.matchResult(result) {
+				continue
+			}
+			r, n := t.filter(result)
+			if n == 0 {
+				continue
+			}
+			filtered = append(filtered, r)
+			count += n
+		}
+	}
+	if count <= t.Max {
+		return nil
+	}
+	return filtered
+}
+
+func (t Threshold) matchResult(result types.Result) bool {
+	if t.Class != "" && t.Class != result.Class {
+		return false
+	}
+	if t.Target != "" {
+		if matched, _ := path.Match(t.Target, result.Target); !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// filter returns the result with the findings to count and the number of them
+func (t Threshold) filter(result types.Result) (types.Result, int) {
+	filtered := types.Result{
+		Target: result.Target,
+		Class:  result.Class,
+		Type:   result.Type,
+	}
+	switch t.Scanner {
+	case types.VulnerabilityScanner:
+		filtered.Vulnerabilities = lo.Filter(result.Vulnerabilities, func(v types.DetectedVulnerability, _ int) bool {
+			return t.severityMatch(v.Severity)
+		})
+		return filtered, len(filtered.Vulnerabilities)
+	case types.MisconfigScanner:
+		filtered.Misconfigurations = lo.Filter(result.Misconfigurations, func(m types.DetectedMisconfiguration, _ int) bool {
+			return m.Status == types.StatusFailure && t.severityMatch(m.Severity)
+		})
+		filtered.MisconfSummary = &types.MisconfSummary{Failures: len(filtered.Misconfigurations)}
+		return filtered, len(filtered.Misconfigurations)
+	case types.SecretScanner:
+		filtered.Secrets = lo.Filter(result.Secrets, func(s ftypes.SecretFinding, _ int) bool {
+			return t.severityMatch(s.Severity)
+		})
+		return filtered, len(filtered.Secrets)
+	case types.LicenseScanner:
+		filtered.Licenses = lo.Filter(result.Licenses, func(l types.DetectedLicense, _ int) bool {
+			return t.severityMatch(l.Severity)
+		})
+		return filtered, len(filtered.Licenses)
+	}
+	return filtered, 0
+}
+
+func (t Threshold) severityMatch(severity string) bool {
+	if t.Severity == "" {
+		return true
+	}
+	minimum, _ := dbTypes.NewSeverity(strings.ToUpper(t.Severity)) // Validated when loading the spec
+	s, err := dbTypes.NewSeverity(severity)
+	if err != nil {
+		s = dbTypes.SeverityUnknown
+	}
+	return s >= minimum
+}
+
+// evaluateThresholds returns the results failing any of the thresholds
+func evaluateThresholds(multiResults []types.Results, thresholds []Threshold) types.Results {
+	var failed types.Results
+	for _, t := range thresholds {
+		failed = append(failed, t.evaluate(multiResults)...)
+	}
+	return failed
+}
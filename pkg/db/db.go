@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/hashicorp/go-multierror"
 	"golang.org/x/xerrors"
 	"k8s.io/utils/clock"
 
@@ -29,9 +30,9 @@ type Operation interface {
 }
 
 type options struct {
-	artifact     *oci.Artifact
-	clock        clock.Clock
-	dbRepository string
+	artifact       *oci.Artifact
+	clock          clock.Clock
+	dbRepositories []string
 }
 
 // Option is a functional option
@@ -44,10 +45,12 @@ func WithOCIArtifact(art *oci.Artifact) Option {
 	}
 }
 
-// WithDBRepository takes a dbRepository
-func WithDBRepository(dbRepository string) Option {
+// WithDBRepositories takes DB repositories tried in order, e.g. mirrors
+func WithDBRepositories(dbRepositories []string) Option {
 	return func(opts *options) {
-		opts.dbRepository = dbRepository
+		if len(dbRepositories) > 0 {
+			opts.dbRepositories = dbRepositories
+		}
 	}
 }
 
@@ -70,8 +73,8 @@ type Client struct {
 // NewClient is the factory method for DB client
 func NewClient(cacheDir string, quiet bool, opts ...Option) *Client {
 	o := &options{
-		clock:        clock.RealClock{},
-		dbRepository: defaultDBRepository,
+		clock:          clock.RealClock{},
+		dbRepositories: []string{defaultDBRepository},
 	}
 
 	for _, opt := range opts {
@@ -149,21 +152,40 @@ func (c *Client) Download(ctx context.Context, dst string, opt types.RegistryOpt
 		log.Logger.Debug("no metadata file")
 	}
 
-	art, err := c.initOCIArtifact(opt)
-	if err != nil {
-		return xerrors.Errorf("OCI artifact error: %w", err)
-	}
-
-	if err = art.Download(ctx, db.Dir(dst), oci.DownloadOption{MediaType: dbMediaType}); err != nil {
+	if err := c.download(ctx, dst, opt); err != nil {
 		return xerrors.Errorf("database download error: %w", err)
 	}
 
-	if err = c.updateDownloadedAt(dst); err != nil {
+	if err := c.updateDownloadedAt(dst); err != nil {
 		return xerrors.Errorf("failed to update downloaded_at: %w", err)
 	}
 	return nil
 }
 
+// download tries the repositories in order until the download succeeds
+func (c *Client) download(ctx context.Context, dst string, opt types.RegistryOptions) error {
+	if c.artifact != nil {
+		return c.artifact.Download(ctx, db.Dir(dst), oci.DownloadOption{MediaType: dbMediaType})
+	}
+
+	var errs error
+	for _, dbRepository := range c.dbRepositories {
+		art, err := c.initOCIArtifact(dbRepository, opt)
+		if err != nil {
+			return err
+		}
+		if err = art.Download(ctx, db.Dir(dst), oci.DownloadOption{MediaType: dbMediaType}); err != nil {
+			if len(c.dbRepositories) > 1 {
+				log.Logger.Warnf("Failed to download the DB from %s: %s", dbRepository, err)
+			}
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		return nil
+	}
+	return errs
+}
+
 func (c *Client) updateDownloadedAt(dst string) error {
 	log.Logger.Debug("Updating database metadata...")
 
@@ -183,13 +205,9 @@ func (c *Client) updateDownloadedAt(dst string) error {
 	return nil
 }
 
-func (c *Client) initOCIArtifact(opt types.RegistryOptions) (*oci.Artifact, error) {
-	if c.artifact != nil {
-		return c.artifact, nil
-	}
-
-	repo := fmt.Sprintf("%s:%d", c.dbRepository, db.SchemaVersion)
-	art, err := oci.NewArtifact(repo, c.quiet, opt)
+func (c *Client) initOCIArtifact(dbRepository string, opt types.RegistryOptions) (*oci.Artifact, error) {
+	repo := fmt.Sprintf("%s:%d", dbRepository, db.SchemaVersion)
+	art, err := oci.NewArtifact(repo, c.quiet, opt, oci.WithCacheDir(c.cacheDir))
 	if err != nil {
 		var terr *transport.Error
 		if errors.As(err, &terr) {
@@ -3,11 +3,20 @@ package db_test
 import (
 	"context"
 	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
 	fakei "github.com/google/go-containerregistry/pkg/v1/fake"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/stretchr/testify/assert"
@@ -20,6 +29,7 @@ import (
 	"github.com/zhanglimao/trivy/pkg/db"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/oci"
+	"github.com/zhanglimao/trivy/pkg/utils/fsutils"
 )
 
 const mediaType = "application/vnd.aquasec.trivy.db.layer.v1.tar+gzip"
@@ -242,3 +252,67 @@ func TestClient_Download(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Download_Mirrors(t *testing.T) {
+	// A registry serving the DB
+	ts := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer ts.Close()
+	mirror := strings.TrimPrefix(ts.URL, "http://")
+
+	img, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:     newFakeLayer(t, "testdata/db.tar.gz"),
+		MediaType: mediaType,
+		Annotations: map[string]string{
+			"org.opencontainers.image.title": "db.tar.gz",
+		},
+	})
+	require.NoError(t, err)
+	ref, err := name.ParseReference(fmt.Sprintf("%s/db:%d", mirror, tdb.SchemaVersion))
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+
+	tests := []struct {
+		name         string
+		repositories []string
+		wantErr      string
+	}{
+		{
+			name:         "first repository",
+			repositories: []string{mirror + "/db"},
+		},
+		{
+			name: "fallback to the mirror",
+			repositories: []string{
+				mirror + "/unknown",
+				mirror + "/db",
+			},
+		},
+		{
+			name: "all failed",
+			repositories: []string{
+				mirror + "/unknown",
+				mirror + "/missing",
+			},
+			wantErr: "2 errors occurred",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacheDir := t.TempDir()
+			fsutils.SetCacheDir(cacheDir)
+
+			client := db.NewClient(cacheDir, true, db.WithDBRepositories(tt.repositories))
+			err := client.Download(context.Background(), cacheDir, ftypes.RegistryOptions{})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := metadata.NewClient(cacheDir).Get()
+			require.NoError(t, err)
+			assert.Equal(t, 1, got.Version)
+		})
+	}
+}
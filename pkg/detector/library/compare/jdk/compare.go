@@ -0,0 +1,60 @@
+package jdk
+
+import (
+	"regexp"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/go-version/pkg/version"
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/detector/library/compare"
+)
+
+var (
+	// The legacy versioning before JEP 322, e.g. "1.8.0_352" and "1.8.0_352-b08"
+	legacyVersion = regexp.MustCompile(`\b1\.([5-8])\.0(?:_(\d+))?(?:-b\d+)?`)
+	// The update notation, e.g. "8u352"
+	updateVersion = regexp.MustCompile(`\b([5-8])u(\d+)(?:-b\d+)?`)
+)
+
+// Comparer represents a comparer for JDK versions
+type Comparer struct{}
+
+// IsVulnerable checks if the package version is vulnerable to the advisory.
+func (n Comparer) IsVulnerable(ver string, advisory dbTypes.Advisory) bool {
+	return compare.IsVulnerable(ver, advisory, n.matchVersion)
+}
+
+// matchVersion checks if the package version satisfies the given constraint.
+// Both are normalized to the JEP 322 versioning, so that "1.8.0_352", "8u352" and "8.0.352" are the same.
+func (n Comparer) matchVersion(currentVersion, constraint string) (bool, error) {
+	v, err := version.Parse(normalize(currentVersion))
+	if err != nil {
+		return false, xerrors.Errorf("jdk version error (%s): %s", currentVersion, err)
+	}
+
+	c, err := version.NewConstraints(normalize(constraint))
+	if err != nil {
+		return false, xerrors.Errorf("jdk constraint error (%s): %s", constraint, err)
+	}
+
+	return c.Check(v), nil
+}
+
+func normalize(s string) string {
+	s = legacyVersion.ReplaceAllStringFunc(s, func(m string) string {
+		sub := legacyVersion.FindStringSubmatch(m)
+		return jep322(sub[1], sub[2])
+	})
+	return updateVersion.ReplaceAllStringFunc(s, func(m string) string {
+		sub := updateVersion.FindStringSubmatch(m)
+		return jep322(sub[1], sub[2])
+	})
+}
+
+func jep322(feature, update string) string {
+	if update == "" {
+		update = "0"
+	}
+	return feature + ".0." + update
+}
@@ -0,0 +1,92 @@
+package jdk_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/detector/library/compare/jdk"
+)
+
+func TestComparer_IsVulnerable(t *testing.T) {
+	type args struct {
+		currentVersion string
+		advisory       dbTypes.Advisory
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "happy path",
+			args: args{
+				currentVersion: "17.0.5",
+				advisory: dbTypes.Advisory{
+					VulnerableVersions: []string{">=17, <17.0.6"},
+					PatchedVersions:    []string{"17.0.6"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "patched",
+			args: args{
+				currentVersion: "17.0.6",
+				advisory: dbTypes.Advisory{
+					VulnerableVersions: []string{">=17, <17.0.6"},
+					PatchedVersions:    []string{"17.0.6"},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "legacy version",
+			args: args{
+				currentVersion: "1.8.0_352",
+				advisory: dbTypes.Advisory{
+					VulnerableVersions: []string{">=1.8.0, <1.8.0_362"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "legacy version with the update notation",
+			args: args{
+				currentVersion: "1.8.0_362",
+				advisory: dbTypes.Advisory{
+					VulnerableVersions: []string{">=8, <8u362"},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "another feature release",
+			args: args{
+				currentVersion: "11.0.17",
+				advisory: dbTypes.Advisory{
+					VulnerableVersions: []string{">=1.8.0, <1.8.0_362"},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "invalid version",
+			args: args{
+				currentVersion: "invalid",
+				advisory: dbTypes.Advisory{
+					VulnerableVersions: []string{"<17.0.6"},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := jdk.Comparer{}
+			got := c.IsVulnerable(tt.args.currentVersion, tt.args.advisory)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
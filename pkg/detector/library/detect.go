@@ -6,6 +6,7 @@ import (
 	"golang.org/x/xerrors"
 
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/malicious"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
@@ -24,9 +25,42 @@ func Detect(libType string, pkgs []ftypes.Package) ([]types.DetectedVulnerabilit
 		return nil, xerrors.Errorf("failed to scan %s vulnerabilities: %w", driver.Type(), err)
 	}
 
+	// Runtimes such as Node.js and Python have the end of life
+	for _, pkg := range pkgs {
+		if eol := detectEOL(libType, pkg); eol != nil {
+			vulns = append(vulns, *eol)
+		}
+	}
+
 	return vulns, nil
 }
 
+// DetectMalicious returns library versions reported as malicious in the malicious package feeds
+func DetectMalicious(libType string, pkgs []ftypes.Package) ([]types.DetectedMaliciousPackage, error) {
+	if !malicious.Enabled() {
+		return nil, nil
+	}
+
+	driver, err := NewDriver(libType)
+	if err != nil {
+		if errors.Is(err, ErrSBOMSupportOnly) {
+			return nil, nil
+		}
+		return nil, xerrors.Errorf("failed to initialize a driver: %w", err)
+	}
+
+	var detected []types.DetectedMaliciousPackage
+	for _, pkg := range pkgs {
+		found := driver.DetectMalicious(pkg.ID, pkg.Name, pkg.Version)
+		for i := range found {
+			found[i].Layer = pkg.Layer
+			found[i].PkgPath = pkg.FilePath
+		}
+		detected = append(detected, found...)
+	}
+	return detected, nil
+}
+
 func detect(driver Driver, libs []ftypes.Package) ([]types.DetectedVulnerability, error) {
 	var vulnerabilities []types.DetectedVulnerability
 	for _, lib := range libs {
@@ -37,6 +71,7 @@ func detect(driver Driver, libs []ftypes.Package) ([]types.DetectedVulnerability
 
 		for i := range vulns {
 			vulns[i].Layer = lib.Layer
+			vulns[i].AnalyzedBy = lib.AnalyzedBy
 			vulns[i].PkgPath = lib.FilePath
 			vulns[i].PkgRef = lib.Ref
 		}
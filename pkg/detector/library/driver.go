@@ -4,23 +4,33 @@ import (
 	"fmt"
 	"strings"
 
+	"golang.org/x/exp/slices"
 	"golang.org/x/xerrors"
 
 	"github.com/aquasecurity/trivy-db/pkg/db"
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	"github.com/aquasecurity/trivy-db/pkg/vulnsrc/vulnerability"
+	"github.com/zhanglimao/trivy/pkg/advisory"
 	"github.com/zhanglimao/trivy/pkg/detector/library/compare"
+	"github.com/zhanglimao/trivy/pkg/detector/library/compare/jdk"
 	"github.com/zhanglimao/trivy/pkg/detector/library/compare/maven"
 	"github.com/zhanglimao/trivy/pkg/detector/library/compare/npm"
 	"github.com/zhanglimao/trivy/pkg/detector/library/compare/pep440"
 	"github.com/zhanglimao/trivy/pkg/detector/library/compare/rubygems"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/malicious"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
 var ErrSBOMSupportOnly = xerrors.New("SBOM support only")
 
+// jdkEcosystem is the ecosystem of JDK/JRE advisories, e.g. "jdk::" buckets and "jdk" in custom advisories
+const jdkEcosystem dbTypes.Ecosystem = "jdk"
+
+// serverEcosystem is the ecosystem of web server and middleware advisories
+const serverEcosystem dbTypes.Ecosystem = "server"
+
 // NewDriver returns a driver according to the library type
 func NewDriver(libType string) (Driver, error) {
 	var ecosystem dbTypes.Ecosystem
@@ -42,13 +52,25 @@ func NewDriver(libType string) (Driver, error) {
 	case ftypes.Jar, ftypes.Pom, ftypes.Gradle:
 		ecosystem = vulnerability.Maven
 		comparer = maven.Comparer{}
+	case ftypes.JDK:
+		// There is no JDK data source in trivy-db yet, but custom advisories can be used.
+		ecosystem = jdkEcosystem
+		comparer = jdk.Comparer{}
+	case ftypes.NodeRuntime, ftypes.PythonRuntime, ftypes.RubyRuntime, ftypes.PHPRuntime:
+		// The same as JDK, runtime advisories are given by custom advisories, e.g. "node-runtime".
+		ecosystem = dbTypes.Ecosystem(libType)
+		comparer = compare.GenericComparer{}
+	case ftypes.Server:
+		// Advisories of web servers are given by custom advisories, e.g. "server" > "nginx".
+		ecosystem = serverEcosystem
+		comparer = compare.GenericComparer{}
 	case ftypes.Npm, ftypes.Yarn, ftypes.Pnpm, ftypes.NodePkg, ftypes.JavaScript:
 		ecosystem = vulnerability.Npm
 		comparer = npm.Comparer{}
 	case ftypes.NuGet, ftypes.DotNetCore:
 		ecosystem = vulnerability.NuGet
 		comparer = compare.GenericComparer{}
-	case ftypes.Pipenv, ftypes.Poetry, ftypes.Pip, ftypes.PythonPkg:
+	case ftypes.Pipenv, ftypes.Poetry, ftypes.Pip, ftypes.PythonPkg, ftypes.Jupyter:
 		ecosystem = vulnerability.Pip
 		comparer = pep440.Comparer{}
 	case ftypes.Pub:
@@ -68,6 +90,9 @@ func NewDriver(libType string) (Driver, error) {
 	case ftypes.CondaPkg:
 		log.Logger.Warn("Conda package is supported for SBOM, not for vulnerability scanning")
 		return Driver{}, ErrSBOMSupportOnly
+	case ftypes.Snap, ftypes.Flatpak, ftypes.Homebrew:
+		log.Logger.Warnf("%s package is supported for SBOM, not for vulnerability scanning", libType)
+		return Driver{}, ErrSBOMSupportOnly
 	default:
 		return Driver{}, xerrors.Errorf("unsupported type %s", libType)
 	}
@@ -119,7 +144,71 @@ func (d *Driver) DetectVulnerabilities(pkgID, pkgName, pkgVer string) ([]types.D
 		vulns = append(vulns, vuln)
 	}
 
-	return vulns, nil
+	return append(vulns, d.detectCustom(pkgID, pkgName, pkgVer, vulns)...), nil
+}
+
+// detectCustom detects vulnerabilities in the custom advisories.
+// Vulnerabilities already detected with the same ID are skipped so that trivy-db takes precedence.
+func (d *Driver) detectCustom(pkgID, pkgName, pkgVer string, detected []types.DetectedVulnerability) []types.DetectedVulnerability {
+	var vulns []types.DetectedVulnerability
+	for _, adv := range advisory.Get(string(d.ecosystem), pkgName) {
+		if slices.ContainsFunc(detected, func(v types.DetectedVulnerability) bool {
+			return v.VulnerabilityID == adv.ID
+		}) {
+			continue
+		}
+
+		dbAdv := dbTypes.Advisory{
+			VulnerabilityID:    adv.ID,
+			VulnerableVersions: adv.VulnerableVersions,
+			PatchedVersions:    adv.PatchedVersions,
+		}
+		if !d.comparer.IsVulnerable(pkgVer, dbAdv) {
+			continue
+		}
+
+		vulns = append(vulns, types.DetectedVulnerability{
+			VulnerabilityID:  adv.ID,
+			PkgID:            pkgID,
+			PkgName:          pkgName,
+			InstalledVersion: pkgVer,
+			FixedVersion:     createFixedVersions(dbAdv),
+			DataSource:       adv.DataSource,
+		})
+	}
+	return vulns
+}
+
+// DetectMalicious detects the package version in the malicious package feeds
+func (d *Driver) DetectMalicious(pkgID, pkgName, pkgVer string) []types.DetectedMaliciousPackage {
+	var detected []types.DetectedMaliciousPackage
+	for _, report := range malicious.Get(string(d.ecosystem), pkgName) {
+		if !d.isMalicious(pkgVer, report) {
+			continue
+		}
+		detected = append(detected, types.DetectedMaliciousPackage{
+			ID:               report.ID,
+			Aliases:          report.Aliases,
+			PkgID:            pkgID,
+			PkgName:          pkgName,
+			InstalledVersion: pkgVer,
+			Summary:          report.Summary,
+			Severity:         report.Severity,
+			References:       report.References,
+			DataSource:       report.DataSource,
+		})
+	}
+	return detected
+}
+
+func (d *Driver) isMalicious(pkgVer string, report malicious.Report) bool {
+	switch {
+	case report.AllVersions, slices.Contains(report.Versions, pkgVer):
+		return true
+	case len(report.VulnerableVersions) != 0:
+		return d.comparer.IsVulnerable(pkgVer, dbTypes.Advisory{VulnerableVersions: report.VulnerableVersions})
+	}
+	return false
 }
 
 func createFixedVersions(advisory dbTypes.Advisory) string {
@@ -9,9 +9,11 @@ import (
 	"github.com/aquasecurity/trivy-db/pkg/db"
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	"github.com/aquasecurity/trivy-db/pkg/vulnsrc/vulnerability"
+	"github.com/zhanglimao/trivy/pkg/advisory"
 	"github.com/zhanglimao/trivy/pkg/dbtest"
 	"github.com/zhanglimao/trivy/pkg/detector/library"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/malicious"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
@@ -21,12 +23,13 @@ func TestDriver_Detect(t *testing.T) {
 		pkgVer  string
 	}
 	tests := []struct {
-		name     string
-		fixtures []string
-		libType  string
-		args     args
-		want     []types.DetectedVulnerability
-		wantErr  string
+		name             string
+		fixtures         []string
+		customAdvisories string
+		libType          string
+		args             args
+		want             []types.DetectedVulnerability
+		wantErr          string
 	}{
 		{
 			name: "happy path",
@@ -138,6 +141,43 @@ func TestDriver_Detect(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "custom advisories",
+			fixtures: []string{
+				"testdata/fixtures/php.yaml",
+				"testdata/fixtures/data-source.yaml",
+			},
+			customAdvisories: "testdata/custom-advisories",
+			libType:          ftypes.Composer,
+			args: args{
+				pkgName: "symfony/symfony",
+				pkgVer:  "4.2.6",
+			},
+			want: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "CVE-2019-10909",
+					PkgName:          "symfony/symfony",
+					InstalledVersion: "4.2.6",
+					FixedVersion:     "4.2.7",
+					DataSource: &dbTypes.DataSource{
+						ID:   vulnerability.GLAD,
+						Name: "GitLab Advisory Database Community",
+						URL:  "https://gitlab.com/gitlab-org/advisories-community",
+					},
+				},
+				{
+					VulnerabilityID:  "ACME-2023-0001",
+					PkgName:          "symfony/symfony",
+					InstalledVersion: "4.2.6",
+					FixedVersion:     "4.3.0",
+					DataSource: &dbTypes.DataSource{
+						ID:   "acme",
+						Name: "ACME Security Advisories",
+						URL:  "https://security.acme.example",
+					},
+				},
+			},
+		},
 		{
 			name:     "no vulnerability",
 			fixtures: []string{"testdata/fixtures/php.yaml"},
@@ -164,6 +204,11 @@ func TestDriver_Detect(t *testing.T) {
 			_ = dbtest.InitDB(t, tt.fixtures)
 			defer db.Close()
 
+			if tt.customAdvisories != "" {
+				require.NoError(t, advisory.Load(tt.customAdvisories))
+				defer advisory.Reset()
+			}
+
 			driver, err := library.NewDriver(tt.libType)
 			require.NoError(t, err)
 
@@ -180,3 +225,70 @@ func TestDriver_Detect(t *testing.T) {
 		})
 	}
 }
+
+func TestDriver_DetectMalicious(t *testing.T) {
+	require.NoError(t, malicious.Load("testdata/malicious"))
+	defer malicious.Reset()
+
+	tests := []struct {
+		name    string
+		libType string
+		pkgName string
+		pkgVer  string
+		want    []types.DetectedMaliciousPackage
+	}{
+		{
+			name:    "exact version",
+			libType: ftypes.Npm,
+			pkgName: "flatmap-stream",
+			pkgVer:  "0.1.1",
+			want: []types.DetectedMaliciousPackage{
+				{
+					ID:               "MAL-2022-1001",
+					PkgName:          "flatmap-stream",
+					InstalledVersion: "0.1.1",
+					Summary:          "Malicious code in flatmap-stream (npm)",
+					Severity:         "CRITICAL",
+					DataSource:       malicious.OpenSSF,
+				},
+			},
+		},
+		{
+			name:    "version range",
+			libType: ftypes.Yarn,
+			pkgName: "event-stream",
+			pkgVer:  "3.3.6",
+			want: []types.DetectedMaliciousPackage{
+				{
+					ID:               "GHSA-mh6f-8j2x-4483",
+					PkgName:          "event-stream",
+					InstalledVersion: "3.3.6",
+					Summary:          "Critical severity vulnerability that affects event-stream and flatmap-stream",
+					Severity:         "CRITICAL",
+					DataSource:       malicious.OSV,
+				},
+			},
+		},
+		{
+			name:    "benign version",
+			libType: ftypes.Npm,
+			pkgName: "event-stream",
+			pkgVer:  "4.0.1",
+		},
+		{
+			name:    "another ecosystem",
+			libType: ftypes.Pip,
+			pkgName: "flatmap-stream",
+			pkgVer:  "0.1.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := library.NewDriver(tt.libType)
+			require.NoError(t, err)
+
+			got := driver.DetectMalicious("", tt.pkgName, tt.pkgVer)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
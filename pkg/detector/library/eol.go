@@ -0,0 +1,154 @@
+package library
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/clock"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// EOLSource is the data source of end-of-life findings
+const EOLSource dbTypes.SourceID = "endoflife.date"
+
+type runtimeLifecycle struct {
+	displayName string
+	product     string // the product name in endoflife.date
+	cycleParts  int    // the number of version parts identifying the release cycle, e.g. 1 for "18" and 2 for "3.11"
+	eolDates    map[string]time.Time
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 23, 59, 59, 0, time.UTC)
+}
+
+// runtimeLifecycles has the end-of-life dates of language runtimes.
+// Release cycles not in the list are treated as supported.
+var runtimeLifecycles = map[string]runtimeLifecycle{
+	ftypes.NodeRuntime: {
+		displayName: "Node.js",
+		product:     "nodejs",
+		cycleParts:  1,
+		eolDates: map[string]time.Time{
+			"6":  date(2019, 4, 30),
+			"8":  date(2019, 12, 31),
+			"10": date(2021, 4, 30),
+			"11": date(2019, 6, 1),
+			"12": date(2022, 4, 30),
+			"13": date(2020, 6, 1),
+			"14": date(2023, 4, 30),
+			"15": date(2021, 6, 1),
+			"16": date(2023, 9, 11),
+			"17": date(2022, 6, 1),
+			"18": date(2025, 4, 30),
+			"19": date(2023, 6, 1),
+			"20": date(2026, 4, 30),
+			"21": date(2024, 6, 1),
+			"22": date(2027, 4, 30),
+			"23": date(2025, 6, 1),
+			"24": date(2028, 4, 30),
+		},
+	},
+	ftypes.PythonRuntime: {
+		displayName: "Python",
+		product:     "python",
+		cycleParts:  2,
+		eolDates: map[string]time.Time{
+			"2.7":  date(2020, 1, 1),
+			"3.4":  date(2019, 3, 18),
+			"3.5":  date(2020, 9, 30),
+			"3.6":  date(2021, 12, 23),
+			"3.7":  date(2023, 6, 27),
+			"3.8":  date(2024, 10, 7),
+			"3.9":  date(2025, 10, 31),
+			"3.10": date(2026, 10, 31),
+			"3.11": date(2027, 10, 31),
+			"3.12": date(2028, 10, 31),
+			"3.13": date(2029, 10, 31),
+		},
+	},
+	ftypes.RubyRuntime: {
+		displayName: "Ruby",
+		product:     "ruby",
+		cycleParts:  2,
+		eolDates: map[string]time.Time{
+			"2.4": date(2020, 3, 31),
+			"2.5": date(2021, 3, 31),
+			"2.6": date(2022, 3, 31),
+			"2.7": date(2023, 3, 31),
+			"3.0": date(2024, 4, 23),
+			"3.1": date(2025, 3, 26),
+			"3.2": date(2026, 3, 31),
+			"3.3": date(2027, 3, 31),
+		},
+	},
+	ftypes.PHPRuntime: {
+		displayName: "PHP",
+		product:     "php",
+		cycleParts:  2,
+		eolDates: map[string]time.Time{
+			"5.6": date(2018, 12, 31),
+			"7.0": date(2019, 1, 10),
+			"7.1": date(2019, 12, 1),
+			"7.2": date(2020, 11, 30),
+			"7.3": date(2021, 12, 6),
+			"7.4": date(2022, 11, 28),
+			"8.0": date(2023, 11, 26),
+			"8.1": date(2025, 12, 31),
+			"8.2": date(2026, 12, 31),
+			"8.3": date(2027, 12, 31),
+		},
+	},
+}
+
+// detectEOL returns a finding if the runtime has reached the end of life, similar to EOSL of OSes.
+func detectEOL(libType string, pkg ftypes.Package) *types.DetectedVulnerability {
+	lifecycle, ok := runtimeLifecycles[libType]
+	if !ok {
+		return nil
+	}
+
+	parts := strings.SplitN(pkg.Version, ".", lifecycle.cycleParts+1)
+	if len(parts) < lifecycle.cycleParts {
+		return nil
+	}
+	cycle := strings.Join(parts[:lifecycle.cycleParts], ".")
+	eolDate, ok := lifecycle.eolDates[cycle]
+	if !ok || clock.Now().Before(eolDate) {
+		return nil
+	}
+
+	log.Logger.Warnf("This %s version is no longer supported: %s (EOL: %s)", lifecycle.displayName, pkg.Version,
+		eolDate.Format("2006-01-02"))
+
+	url := fmt.Sprintf("https://endoflife.date/%s", lifecycle.product)
+	return &types.DetectedVulnerability{
+		VulnerabilityID:  fmt.Sprintf("EOL-%s-%s", lifecycle.product, cycle),
+		PkgID:            pkg.ID,
+		PkgName:          pkg.Name,
+		InstalledVersion: pkg.Version,
+		PkgPath:          pkg.FilePath,
+		PkgRef:           pkg.Ref,
+		Layer:            pkg.Layer,
+		AnalyzedBy:       pkg.AnalyzedBy,
+		SeveritySource:   EOLSource,
+		PrimaryURL:       url,
+		DataSource: &dbTypes.DataSource{
+			ID:   EOLSource,
+			Name: "endoflife.date",
+			URL:  "https://endoflife.date",
+		},
+		Vulnerability: dbTypes.Vulnerability{
+			Title: fmt.Sprintf("%s %s reached the end of life on %s", lifecycle.displayName, cycle,
+				eolDate.Format("2006-01-02")),
+			Description: fmt.Sprintf("%s %s no longer receives security updates. Upgrade to a supported release.",
+				lifecycle.displayName, cycle),
+			Severity:   dbTypes.SeverityHigh.String(),
+			References: []string{url},
+		},
+	}
+}
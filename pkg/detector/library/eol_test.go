@@ -0,0 +1,114 @@
+package library_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy-db/pkg/db"
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/clock"
+	"github.com/zhanglimao/trivy/pkg/dbtest"
+	"github.com/zhanglimao/trivy/pkg/detector/library"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func TestDetect_EOL(t *testing.T) {
+	tests := []struct {
+		name    string
+		libType string
+		pkg     ftypes.Package
+		want    []types.DetectedVulnerability
+	}{
+		{
+			name:    "EOL Node.js",
+			libType: ftypes.NodeRuntime,
+			pkg: ftypes.Package{
+				ID:       "node@16.20.1",
+				Name:     "node",
+				Version:  "16.20.1",
+				FilePath: "usr/local/include/node/node_version.h",
+			},
+			want: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "EOL-nodejs-16",
+					PkgID:            "node@16.20.1",
+					PkgName:          "node",
+					InstalledVersion: "16.20.1",
+					PkgPath:          "usr/local/include/node/node_version.h",
+					SeveritySource:   library.EOLSource,
+					PrimaryURL:       "https://endoflife.date/nodejs",
+					DataSource: &dbTypes.DataSource{
+						ID:   library.EOLSource,
+						Name: "endoflife.date",
+						URL:  "https://endoflife.date",
+					},
+					Vulnerability: dbTypes.Vulnerability{
+						Title:       "Node.js 16 reached the end of life on 2023-09-11",
+						Description: "Node.js 16 no longer receives security updates. Upgrade to a supported release.",
+						Severity:    "HIGH",
+						References:  []string{"https://endoflife.date/nodejs"},
+					},
+				},
+			},
+		},
+		{
+			name:    "EOL Python",
+			libType: ftypes.PythonRuntime,
+			pkg: ftypes.Package{
+				Name:    "python",
+				Version: "3.7.17",
+			},
+			want: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "EOL-python-3.7",
+					PkgName:          "python",
+					InstalledVersion: "3.7.17",
+					SeveritySource:   library.EOLSource,
+					PrimaryURL:       "https://endoflife.date/python",
+					DataSource: &dbTypes.DataSource{
+						ID:   library.EOLSource,
+						Name: "endoflife.date",
+						URL:  "https://endoflife.date",
+					},
+					Vulnerability: dbTypes.Vulnerability{
+						Title:       "Python 3.7 reached the end of life on 2023-06-27",
+						Description: "Python 3.7 no longer receives security updates. Upgrade to a supported release.",
+						Severity:    "HIGH",
+						References:  []string{"https://endoflife.date/python"},
+					},
+				},
+			},
+		},
+		{
+			name:    "supported PHP",
+			libType: ftypes.PHPRuntime,
+			pkg: ftypes.Package{
+				Name:    "php",
+				Version: "8.2.7",
+			},
+		},
+		{
+			name:    "unknown release cycle",
+			libType: ftypes.RubyRuntime,
+			pkg: ftypes.Package{
+				Name:    "ruby",
+				Version: "3.4.1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = dbtest.InitDB(t, nil)
+			defer db.Close()
+			clock.SetFakeTime(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+			got, err := library.Detect(tt.libType, []ftypes.Package{tt.pkg})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -49,6 +49,9 @@ var (
 	}
 )
 
+// testingRepository is the repository of the packages under development in edge
+const testingRepository = "testing"
+
 type options struct {
 	clock clock.Clock
 }
@@ -88,7 +91,10 @@ func (s *Scanner) Detect(osVer string, repo *ftypes.Repository, pkgs []ftypes.Pa
 	if strings.Count(osVer, ".") > 1 {
 		osVer = osVer[:strings.LastIndex(osVer, ".")]
 	}
-	repoRelease := s.repoRelease(repo)
+	var repoRelease string
+	if repo != nil {
+		repoRelease = trimRelease(repo.Release)
+	}
 
 	log.Logger.Debugf("alpine: os version: %s", osVer)
 	log.Logger.Debugf("alpine: package repository: %s", repoRelease)
@@ -104,12 +110,25 @@ func (s *Scanner) Detect(osVer string, repo *ftypes.Repository, pkgs []ftypes.Pa
 	}
 
 	var vulns []types.DetectedVulnerability
+	var testingPkgs []string
 	for _, pkg := range pkgs {
+		pkgStream := stream
+		if tagged, ok := s.taggedRepository(repo, pkg); ok {
+			// The testing repository is not covered by the Alpine security database
+			if tagged.Name == testingRepository {
+				testingPkgs = append(testingPkgs, pkg.Name)
+				continue
+			}
+			if tagged.Release != "" {
+				pkgStream = trimRelease(tagged.Release)
+			}
+		}
+
 		srcName := pkg.SrcName
 		if srcName == "" {
 			srcName = pkg.Name
 		}
-		advisories, err := s.vs.Get(stream, srcName)
+		advisories, err := s.vs.Get(pkgStream, srcName)
 		if err != nil {
 			return nil, xerrors.Errorf("failed to get alpine advisories: %w", err)
 		}
@@ -131,15 +150,33 @@ func (s *Scanner) Detect(osVer string, repo *ftypes.Repository, pkgs []ftypes.Pa
 				InstalledVersion: utils.FormatVersion(pkg),
 				FixedVersion:     adv.FixedVersion,
 				Layer:            pkg.Layer,
+				AnalyzedBy:       pkg.AnalyzedBy,
 				PkgRef:           pkg.Ref,
 				Custom:           adv.Custom,
 				DataSource:       adv.DataSource,
 			})
 		}
 	}
+
+	if len(testingPkgs) > 0 {
+		log.Logger.Warnf("Vulnerabilities of packages from the testing repository are not detected "+
+			"as the repository has no security support: %s", strings.Join(testingPkgs, ", "))
+	}
 	return vulns, nil
 }
 
+// taggedRepository returns the repository the package is pinned to, e.g. "apk add curl@edge"
+func (s *Scanner) taggedRepository(repo *ftypes.Repository, pkg ftypes.Package) (ftypes.TaggedRepository, bool) {
+	if repo == nil || pkg.RepositoryTag == "" {
+		return ftypes.TaggedRepository{}, false
+	}
+	tagged, ok := repo.Tags[pkg.RepositoryTag]
+	if ok {
+		log.Logger.Debugf("alpine: %s is pinned to %s/%s", pkg.Name, tagged.Release, tagged.Name)
+	}
+	return tagged, ok
+}
+
 func (s *Scanner) isVulnerable(installedVersion version.Version, adv dbTypes.Advisory) bool {
 	// This logic is for unfixed vulnerabilities, but Trivy DB doesn't have advisories for unfixed vulnerabilities for now
 	// because Alpine just provides potentially vulnerable packages. It will cause a lot of false positives.
@@ -188,11 +225,7 @@ func (s *Scanner) IsSupportedVersion(osFamily, osVer string) bool {
 	return s.clock.Now().Before(eol)
 }
 
-func (s *Scanner) repoRelease(repo *ftypes.Repository) string {
-	if repo == nil {
-		return ""
-	}
-	release := repo.Release
+func trimRelease(release string) string {
 	if strings.Count(release, ".") > 1 {
 		release = release[:strings.LastIndex(release, ".")]
 	}
@@ -181,6 +181,56 @@ func TestScanner_Detect(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "tagged repositories",
+			fixtures: []string{"testdata/fixtures/alpine.yaml", "testdata/fixtures/data-source.yaml"},
+			args: args{
+				osVer: "3.10.2",
+				repo: &ftypes.Repository{
+					Family:  os.Alpine,
+					Release: "3.10",
+					Tags: map[string]ftypes.TaggedRepository{
+						"edge": {
+							Release: "edge",
+							Name:    "main",
+						},
+						"testing": {
+							Release: "edge",
+							Name:    "testing",
+						},
+					},
+				},
+				pkgs: []ftypes.Package{
+					{
+						Name:          "curl",
+						Version:       "8.3.0-r0",
+						SrcName:       "curl",
+						SrcVersion:    "8.3.0-r0",
+						RepositoryTag: "edge",
+					},
+					{
+						Name:          "ansible",
+						Version:       "2.6.4",
+						SrcName:       "ansible",
+						SrcVersion:    "2.6.4",
+						RepositoryTag: "testing", // skipped
+					},
+				},
+			},
+			want: []types.DetectedVulnerability{
+				{
+					PkgName:          "curl",
+					VulnerabilityID:  "CVE-2023-38545",
+					InstalledVersion: "8.3.0-r0",
+					FixedVersion:     "8.4.0-r0",
+					DataSource: &dbTypes.DataSource{
+						ID:   vulnerability.Alpine,
+						Name: "Alpine Secdb",
+						URL:  "https://secdb.alpinelinux.org/",
+					},
+				},
+			},
+		},
 		{
 			name:     "Get returns an error",
 			fixtures: []string{"testdata/fixtures/invalid.yaml", "testdata/fixtures/data-source.yaml"},
@@ -1,6 +1,7 @@
 package amazon
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/xerrors"
 
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	"github.com/aquasecurity/trivy-db/pkg/vulnsrc/amazon"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/log"
@@ -27,6 +29,17 @@ var (
 	}
 )
 
+const (
+	// extrasRepoPrefix is the prefix of Amazon Linux 2 Extras repositories, e.g. "amzn2extra-docker"
+	extrasRepoPrefix = "amzn2extra-"
+
+	// extrasFormat is the version of Amazon Linux 2 Extras advisories, e.g. "2 extras docker"
+	extrasFormat = "%s extras %s"
+
+	// latestReleasever means the release of the repositories is not locked
+	latestReleasever = "latest"
+)
+
 type options struct {
 	clock clock.Clock
 	l     *zap.SugaredLogger
@@ -63,19 +76,21 @@ func NewScanner(opts ...option) *Scanner {
 }
 
 // Detect scans the packages using amazon scanner
-func (s *Scanner) Detect(osVer string, _ *ftypes.Repository, pkgs []ftypes.Package) ([]types.DetectedVulnerability, error) {
+func (s *Scanner) Detect(osVer string, repo *ftypes.Repository, pkgs []ftypes.Package) ([]types.DetectedVulnerability, error) {
 	log.Logger.Info("Detecting Amazon Linux vulnerabilities...")
 
-	osVer = strings.Fields(osVer)[0]
-	if osVer != "2" && osVer != "2022" && osVer != "2023" {
-		osVer = "1"
-	}
+	osVer = majorVersion(osVer)
 	log.Logger.Debugf("amazon: os version: %s", osVer)
 	log.Logger.Debugf("amazon: the number of packages: %d", len(pkgs))
 
+	if repo != nil && repo.Release != "" && repo.Release != latestReleasever {
+		log.Logger.Infof("The release of Amazon Linux repositories is locked to %s. "+
+			"Some fixed versions might be available only in newer releases.", repo.Release)
+	}
+
 	var vulns []types.DetectedVulnerability
 	for _, pkg := range pkgs {
-		advisories, err := s.ac.Get(osVer, pkg.Name)
+		advisories, err := s.advisories(osVer, repo, pkg)
 		if err != nil {
 			return nil, xerrors.Errorf("failed to get amazon advisories: %w", err)
 		}
@@ -107,6 +122,7 @@ func (s *Scanner) Detect(osVer string, _ *ftypes.Repository, pkgs []ftypes.Packa
 					FixedVersion:     adv.FixedVersion,
 					PkgRef:           pkg.Ref,
 					Layer:            pkg.Layer,
+					AnalyzedBy:       pkg.AnalyzedBy,
 					Custom:           adv.Custom,
 					DataSource:       adv.DataSource,
 				}
@@ -117,12 +133,44 @@ func (s *Scanner) Detect(osVer string, _ *ftypes.Repository, pkgs []ftypes.Packa
 	return vulns, nil
 }
 
+// advisories returns the advisories of the repository the package is installed from.
+// Packages from Amazon Linux 2 Extras are looked up in the advisories of the topic
+// since the versions differ from those in the core repository.
+func (s *Scanner) advisories(osVer string, repo *ftypes.Repository, pkg ftypes.Package) ([]dbTypes.Advisory, error) {
+	if repo != nil && osVer == "2" {
+		if repoID := repo.Packages[pkg.Name]; strings.HasPrefix(repoID, extrasRepoPrefix) {
+			topic := strings.TrimPrefix(repoID, extrasRepoPrefix)
+			advisories, err := s.ac.Get(fmt.Sprintf(extrasFormat, osVer, topic), pkg.Name)
+			if err != nil {
+				return nil, err
+			} else if len(advisories) > 0 {
+				log.Logger.Debugf("amazon: %s is installed from %s", pkg.Name, repoID)
+				return advisories, nil
+			}
+		}
+	}
+	return s.ac.Get(osVer, pkg.Name)
+}
+
+// majorVersion returns the major version used in the advisories,
+// e.g. "2023.1.20230719 (Amazon Linux)" => "2023", "2018.03" => "1"
+func majorVersion(osVer string) string {
+	fields := strings.Fields(osVer)
+	if len(fields) == 0 {
+		return "1"
+	}
+	major, _, _ := strings.Cut(fields[0], ".")
+	switch major {
+	case "2", "2022", "2023":
+		return major
+	default:
+		return "1"
+	}
+}
+
 // IsSupportedVersion checks if os can be scanned using amazon scanner
 func (s *Scanner) IsSupportedVersion(osFamily, osVer string) bool {
-	osVer = strings.Fields(osVer)[0]
-	if osVer != "2" && osVer != "2022" && osVer != "2023" {
-		osVer = "1"
-	}
+	osVer = majorVersion(osVer)
 	eol, ok := eolDates[osVer]
 	if !ok {
 		log.Logger.Warnf("This OS version is not on the EOL list: %s %s", osFamily, osVer)
@@ -20,6 +20,7 @@ import (
 func TestScanner_Detect(t *testing.T) {
 	type args struct {
 		osVer string
+		repo  *ftypes.Repository
 		pkgs  []ftypes.Package
 	}
 	tests := []struct {
@@ -127,6 +128,101 @@ func TestScanner_Detect(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "amazon linux 2023 with the release",
+			fixtures: []string{"testdata/fixtures/amazon.yaml", "testdata/fixtures/data-source.yaml"},
+			args: args{
+				osVer: "2023.1.20230719 (Amazon Linux)",
+				repo: &ftypes.Repository{
+					Release: "2023.1.20230719",
+				},
+				pkgs: []ftypes.Package{
+					{
+						Name:    "protobuf",
+						Version: "3.14.0-7.amzn2023.0.3",
+					},
+				},
+			},
+			want: []types.DetectedVulnerability{
+				{
+					PkgName:          "protobuf",
+					VulnerabilityID:  "CVE-2022-1941",
+					InstalledVersion: "3.14.0-7.amzn2023.0.3",
+					FixedVersion:     "3.19.6-1.amzn2023.0.1",
+					DataSource: &dbTypes.DataSource{
+						ID:   vulnerability.Amazon,
+						Name: "Amazon Linux Security Center",
+						URL:  "https://alas.aws.amazon.com/",
+					},
+				},
+			},
+		},
+		{
+			name:     "amazon linux 2 extras",
+			fixtures: []string{"testdata/fixtures/amazon.yaml", "testdata/fixtures/data-source.yaml"},
+			args: args{
+				osVer: "2",
+				repo: &ftypes.Repository{
+					Packages: map[string]string{
+						"nginx": "amzn2extra-nginx1",
+					},
+				},
+				pkgs: []ftypes.Package{
+					{
+						Name:    "nginx",
+						Epoch:   1,
+						Version: "1.20.0",
+						Release: "2.amzn2.0.4",
+					},
+				},
+			},
+			want: []types.DetectedVulnerability{
+				{
+					PkgName:          "nginx",
+					VulnerabilityID:  "CVE-2021-23017",
+					InstalledVersion: "1:1.20.0-2.amzn2.0.4",
+					FixedVersion:     "1:1.20.0-2.amzn2.0.5",
+					DataSource: &dbTypes.DataSource{
+						ID:   vulnerability.Amazon,
+						Name: "Amazon Linux Security Center",
+						URL:  "https://alas.aws.amazon.com/",
+					},
+				},
+			},
+		},
+		{
+			name:     "amazon linux 2 core",
+			fixtures: []string{"testdata/fixtures/amazon.yaml", "testdata/fixtures/data-source.yaml"},
+			args: args{
+				osVer: "2",
+				repo: &ftypes.Repository{
+					Packages: map[string]string{
+						"nginx": "amzn2-core",
+					},
+				},
+				pkgs: []ftypes.Package{
+					{
+						Name:    "nginx",
+						Epoch:   1,
+						Version: "1.20.0",
+						Release: "2.amzn2.0.4",
+					},
+				},
+			},
+			want: []types.DetectedVulnerability{
+				{
+					PkgName:          "nginx",
+					VulnerabilityID:  "CVE-2022-41741",
+					InstalledVersion: "1:1.20.0-2.amzn2.0.4",
+					FixedVersion:     "1:1.22.1-1.amzn2.0.1",
+					DataSource: &dbTypes.DataSource{
+						ID:   vulnerability.Amazon,
+						Name: "Amazon Linux Security Center",
+						URL:  "https://alas.aws.amazon.com/",
+					},
+				},
+			},
+		},
 		{
 			name:     "empty version",
 			fixtures: []string{"testdata/fixtures/amazon.yaml", "testdata/fixtures/data-source.yaml"},
@@ -162,7 +258,7 @@ func TestScanner_Detect(t *testing.T) {
 			defer db.Close()
 
 			s := amazon.NewScanner()
-			got, err := s.Detect(tt.args.osVer, nil, tt.args.pkgs)
+			got, err := s.Detect(tt.args.osVer, tt.args.repo, tt.args.pkgs)
 			if tt.wantErr != "" {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.wantErr)
@@ -212,6 +308,15 @@ func TestScanner_IsSupportedVersion(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "amazon linux 2023 with the release",
+			now:  time.Date(2023, 7, 19, 0, 0, 0, 0, time.UTC),
+			args: args{
+				osFamily: "amazon",
+				osVer:    "2023.1.20230719",
+			},
+			want: true,
+		},
 		{
 			name: "amazon linux 2022",
 			now:  time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC),
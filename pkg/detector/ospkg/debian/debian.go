@@ -38,8 +38,18 @@ var (
 		"11":  time.Date(2026, 8, 14, 23, 59, 59, 0, time.UTC),
 		"12":  time.Date(3000, 1, 1, 23, 59, 59, 0, time.UTC),
 	}
+	// Freexian Extended LTS: https://www.freexian.com/lts/extended/
+	eltsEolDates = map[string]time.Time{
+		"8":  time.Date(2025, 6, 30, 23, 59, 59, 0, time.UTC),
+		"9":  time.Date(2027, 6, 30, 23, 59, 59, 0, time.UTC),
+		"10": time.Date(2029, 6, 30, 23, 59, 59, 0, time.UTC),
+		"11": time.Date(2031, 6, 30, 23, 59, 59, 0, time.UTC),
+	}
 )
 
+// eltsSuffix is appended to the OS version when Extended LTS is enabled
+const eltsSuffix = "-ELTS"
+
 type options struct {
 	clock clock.Clock
 }
@@ -77,10 +87,15 @@ func NewScanner(opts ...option) *Scanner {
 func (s *Scanner) Detect(osVer string, _ *ftypes.Repository, pkgs []ftypes.Package) ([]types.DetectedVulnerability, error) {
 	log.Logger.Info("Detecting Debian vulnerabilities...")
 
+	osVer, elts := trimELTS(osVer)
 	if strings.Count(osVer, ".") > 0 {
 		osVer = osVer[:strings.Index(osVer, ".")]
 	}
 	log.Logger.Debugf("debian: os version: %s", osVer)
+	if elts {
+		// The Debian security tracker doesn't cover ELTS
+		log.Logger.Info("Debian Extended LTS is enabled. Vulnerabilities fixed only in Extended LTS are reported as unfixed.")
+	}
 	log.Logger.Debugf("debian: the number of packages: %d", len(pkgs))
 
 	var vulns []types.DetectedVulnerability
@@ -106,6 +121,7 @@ func (s *Scanner) Detect(osVer string, _ *ftypes.Repository, pkgs []ftypes.Packa
 				FixedVersion:     adv.FixedVersion,
 				PkgRef:           pkg.Ref,
 				Layer:            pkg.Layer,
+				AnalyzedBy:       pkg.AnalyzedBy,
 				Custom:           adv.Custom,
 				DataSource:       adv.DataSource,
 			}
@@ -141,10 +157,16 @@ func (s *Scanner) Detect(osVer string, _ *ftypes.Repository, pkgs []ftypes.Packa
 
 // IsSupportedVersion checks is OSFamily can be scanned using Debian
 func (s *Scanner) IsSupportedVersion(osFamily, osVer string) bool {
+	osVer, elts := trimELTS(osVer)
 	if strings.Count(osVer, ".") > 0 {
 		osVer = osVer[:strings.Index(osVer, ".")]
 	}
 
+	// Extended LTS continues after the end of LTS
+	if eol, ok := eltsEolDates[osVer]; elts && ok {
+		return s.clock.Now().Before(eol)
+	}
+
 	eol, ok := eolDates[osVer]
 	if !ok {
 		log.Logger.Warnf("This OS version is not on the EOL list: %s %s", osFamily, osVer)
@@ -152,3 +174,11 @@ func (s *Scanner) IsSupportedVersion(osFamily, osVer string) bool {
 	}
 	return s.clock.Now().Before(eol)
 }
+
+// trimELTS removes the Extended LTS suffix from the OS version
+func trimELTS(osVer string) (string, bool) {
+	if !strings.HasSuffix(osVer, eltsSuffix) {
+		return osVer, false
+	}
+	return strings.TrimSuffix(osVer, eltsSuffix), true
+}
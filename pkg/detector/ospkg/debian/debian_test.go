@@ -153,6 +153,24 @@ func TestScanner_IsSupportedVersion(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "debian 8 with ELTS",
+			now:  time.Date(2020, 7, 31, 23, 59, 59, 0, time.UTC),
+			args: args{
+				osFamily: "debian",
+				osVer:    "8.2-ELTS",
+			},
+			want: true,
+		},
+		{
+			name: "debian 8 ELTS EOL",
+			now:  time.Date(2025, 7, 31, 23, 59, 59, 0, time.UTC),
+			args: args{
+				osFamily: "debian",
+				osVer:    "8.2-ELTS",
+			},
+			want: false,
+		},
 		{
 			name: "unknown",
 			now:  time.Date(2020, 7, 31, 23, 59, 59, 0, time.UTC),
@@ -5,6 +5,7 @@ import (
 
 	"golang.org/x/xerrors"
 
+	"github.com/zhanglimao/trivy/pkg/advisory"
 	"github.com/zhanglimao/trivy/pkg/detector/ospkg/alma"
 	"github.com/zhanglimao/trivy/pkg/detector/ospkg/alpine"
 	"github.com/zhanglimao/trivy/pkg/detector/ospkg/amazon"
@@ -29,21 +30,23 @@ var (
 	ErrUnsupportedOS = xerrors.New("unsupported os")
 
 	drivers = map[string]Driver{
-		fos.Alpine:       alpine.NewScanner(),
-		fos.Alma:         alma.NewScanner(),
-		fos.Amazon:       amazon.NewScanner(),
-		fos.CBLMariner:   mariner.NewScanner(),
-		fos.Debian:       debian.NewScanner(),
-		fos.Ubuntu:       ubuntu.NewScanner(),
-		fos.RedHat:       redhat.NewScanner(),
-		fos.CentOS:       redhat.NewScanner(),
-		fos.Rocky:        rocky.NewScanner(),
-		fos.Oracle:       oracle.NewScanner(),
-		fos.OpenSUSELeap: suse.NewScanner(suse.OpenSUSE),
-		fos.SLES:         suse.NewScanner(suse.SUSEEnterpriseLinux),
-		fos.Photon:       photon.NewScanner(),
-		fos.Wolfi:        wolfi.NewScanner(),
-		fos.Chainguard:   chainguard.NewScanner(),
+		fos.Alpine:            alpine.NewScanner(),
+		fos.Alma:              alma.NewScanner(),
+		fos.Amazon:            amazon.NewScanner(),
+		fos.CBLMariner:        mariner.NewScanner(),
+		fos.Debian:            debian.NewScanner(),
+		fos.Ubuntu:            ubuntu.NewScanner(),
+		fos.RedHat:            redhat.NewScanner(),
+		fos.CentOS:            redhat.NewScanner(),
+		fos.Rocky:             rocky.NewScanner(),
+		fos.Oracle:            oracle.NewScanner(),
+		fos.OpenSUSELeap:      suse.NewScanner(suse.OpenSUSE),
+		fos.OpenSUSELeapMicro: suse.NewScanner(suse.OpenSUSELeapMicro),
+		fos.SLES:              suse.NewScanner(suse.SUSEEnterpriseLinux),
+		fos.SLEMicro:          suse.NewScanner(suse.SUSEEnterpriseLinuxMicro),
+		fos.Photon:            photon.NewScanner(),
+		fos.Wolfi:             wolfi.NewScanner(),
+		fos.Chainguard:        chainguard.NewScanner(),
 	}
 )
 
@@ -72,6 +75,9 @@ func Detect(_, osFamily, osName string, repo *ftypes.Repository, _ time.Time, pk
 		return nil, false, xerrors.Errorf("failed detection: %w", err)
 	}
 
+	// Merge custom advisories
+	vulns = append(vulns, advisory.DetectOS(osFamily, pkgs, vulns)...)
+
 	return vulns, eosl, nil
 }
 
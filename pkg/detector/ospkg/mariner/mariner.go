@@ -54,6 +54,7 @@ func (s *Scanner) Detect(osVer string, _ *ftypes.Repository, pkgs []ftypes.Packa
 				InstalledVersion: utils.FormatVersion(pkg),
 				PkgRef:           pkg.Ref,
 				Layer:            pkg.Layer,
+				AnalyzedBy:       pkg.AnalyzedBy,
 				DataSource:       adv.DataSource,
 			}
 
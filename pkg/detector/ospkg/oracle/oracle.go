@@ -90,6 +90,7 @@ func (s *Scanner) Detect(osVer string, _ *ftypes.Repository, pkgs []ftypes.Packa
 				InstalledVersion: installed,
 				PkgRef:           pkg.Ref,
 				Layer:            pkg.Layer,
+				AnalyzedBy:       pkg.AnalyzedBy,
 				Custom:           adv.Custom,
 				DataSource:       adv.DataSource,
 			}
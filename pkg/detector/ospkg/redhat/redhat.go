@@ -98,7 +98,7 @@ func NewScanner(opts ...option) *Scanner {
 }
 
 // Detect scans and returns redhat vulnerabilities
-func (s *Scanner) Detect(osVer string, _ *ftypes.Repository, pkgs []ftypes.Package) ([]types.DetectedVulnerability, error) {
+func (s *Scanner) Detect(osVer string, repo *ftypes.Repository, pkgs []ftypes.Package) ([]types.DetectedVulnerability, error) {
 	log.Logger.Info("Detecting RHEL/CentOS vulnerabilities...")
 	if strings.Count(osVer, ".") > 0 {
 		osVer = osVer[:strings.Index(osVer, ".")]
@@ -107,24 +107,34 @@ func (s *Scanner) Detect(osVer string, _ *ftypes.Repository, pkgs []ftypes.Packa
 	log.Logger.Debugf("Red Hat: the number of packages: %d", len(pkgs))
 
 	var vulns []types.DetectedVulnerability
+	var skipPkgs []string
 	for _, pkg := range pkgs {
 		if !isFromSupportedVendor(pkg) {
 			log.Logger.Debugf("Skipping %s: unsupported vendor", pkg.Name)
 			continue
 		}
 
-		detectedVulns, err := s.detect(osVer, pkg)
+		label, ok := modularityLabel(pkg, repo)
+		if !ok {
+			skipPkgs = append(skipPkgs, pkg.Name)
+			continue
+		}
+
+		detectedVulns, err := s.detect(osVer, pkg, label)
 		if err != nil {
 			return nil, xerrors.Errorf("redhat vulnerability detection error: %w", err)
 		}
 		vulns = append(vulns, detectedVulns...)
 	}
+	if len(skipPkgs) > 0 {
+		log.Logger.Infof("Skipped detection of these packages: %q because the module stream of modular packages could not be identified", skipPkgs)
+	}
 	return vulns, nil
 }
 
-func (s *Scanner) detect(osVer string, pkg ftypes.Package) ([]types.DetectedVulnerability, error) {
+func (s *Scanner) detect(osVer string, pkg ftypes.Package, label string) ([]types.DetectedVulnerability, error) {
 	// For Red Hat OVAL v2 containing only binary package names
-	pkgName := addModularNamespace(pkg.Name, pkg.Modularitylabel)
+	pkgName := addModularNamespace(pkg.Name, label)
 
 	var contentSets []string
 	var nvr string
@@ -160,6 +170,7 @@ func (s *Scanner) detect(osVer string, pkg ftypes.Package) ([]types.DetectedVuln
 			InstalledVersion: utils.FormatVersion(pkg),
 			PkgRef:           pkg.Ref,
 			Layer:            pkg.Layer,
+			AnalyzedBy:       pkg.AnalyzedBy,
 			SeveritySource:   vulnerability.RedHat,
 			Vulnerability: dbTypes.Vulnerability{
 				Severity: adv.Severity.String(),
@@ -237,6 +248,34 @@ func isFromSupportedVendor(pkg ftypes.Package) bool {
 	return true
 }
 
+// modularityLabel returns the modularity label used to match advisories.
+// Modular packages without the label in the RPM header fall back to the module stream enabled in DNF,
+// e.g. "nodejs:18" from etc/dnf/modules.d/nodejs.module.
+// It returns false if the package is modular but the stream cannot be identified,
+// as matching it against the non-modular advisories would report another stream's vulnerabilities.
+func modularityLabel(pkg ftypes.Package, repo *ftypes.Repository) (string, bool) {
+	if pkg.Modularitylabel != "" || !isModular(pkg.Release) {
+		return pkg.Modularitylabel, true
+	}
+	if repo == nil {
+		return "", false
+	}
+
+	// The module name is usually the same as the source package name, e.g. npm => nodejs
+	for _, name := range []string{pkg.SrcName, pkg.Name} {
+		if stream, ok := repo.Modules[name]; ok {
+			// The version and context are not used for the namespace
+			return fmt.Sprintf("%s:%s::", name, stream), true
+		}
+	}
+	return "", false
+}
+
+// isModular checks if the release has the module tag, e.g. "1.module+el8.3.0+8844+e5e7039f"
+func isModular(release string) bool {
+	return strings.Contains(release, ".module+el") || strings.Contains(release, ".module_el")
+}
+
 func addModularNamespace(name, label string) string {
 	// e.g. npm, nodejs:12:8030020201124152102:229f0a1c => nodejs:12::npm
 	var count int
@@ -27,6 +27,7 @@ func TestMain(m *testing.M) {
 func TestScanner_Detect(t *testing.T) {
 	type args struct {
 		osVer string
+		repo  *ftypes.Repository
 		pkgs  []ftypes.Package
 	}
 	tests := []struct {
@@ -319,6 +320,75 @@ func TestScanner_Detect(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "modular packages without modularity label",
+			fixtures: []string{
+				"testdata/fixtures/redhat.yaml",
+				"testdata/fixtures/cpe.yaml",
+			},
+			args: args{
+				osVer: "8.3",
+				repo: &ftypes.Repository{
+					Modules: map[string]string{
+						"php": "7.2",
+					},
+				},
+				pkgs: []ftypes.Package{
+					{
+						Name:       "php",
+						Version:    "7.2.10",
+						Release:    "1.module_el8.2.0+313+b04d0a66",
+						Arch:       "x86_64",
+						SrcName:    "php",
+						SrcVersion: "7.2.10",
+						SrcRelease: "1.module_el8.2.0+313+b04d0a66",
+						BuildInfo: &ftypes.BuildInfo{
+							Nvr:  "ubi8-init-container-8.0-7",
+							Arch: "x86_64",
+						},
+					},
+				},
+			},
+			want: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "CVE-2019-11043",
+					VendorIDs:        []string{"RHSA-2020:0322"},
+					PkgName:          "php",
+					InstalledVersion: "7.2.10-1.module_el8.2.0+313+b04d0a66",
+					FixedVersion:     "7.2.11-1.1.module+el8.0.0+4664+17bd8d65",
+					SeveritySource:   vulnerability.RedHat,
+					Vulnerability: dbTypes.Vulnerability{
+						Severity: dbTypes.SeverityCritical.String(),
+					},
+				},
+			},
+		},
+		{
+			name: "modular packages with unknown stream are skipped",
+			fixtures: []string{
+				"testdata/fixtures/redhat.yaml",
+				"testdata/fixtures/cpe.yaml",
+			},
+			args: args{
+				osVer: "8.3",
+				pkgs: []ftypes.Package{
+					{
+						Name:       "php",
+						Version:    "7.2.10",
+						Release:    "1.module_el8.2.0+313+b04d0a66",
+						Arch:       "x86_64",
+						SrcName:    "php",
+						SrcVersion: "7.2.10",
+						SrcRelease: "1.module_el8.2.0+313+b04d0a66",
+						BuildInfo: &ftypes.BuildInfo{
+							Nvr:  "ubi8-init-container-8.0-7",
+							Arch: "x86_64",
+						},
+					},
+				},
+			},
+			want: []types.DetectedVulnerability(nil),
+		},
 		{
 			name: "packages from remi repository are skipped",
 			args: args{
@@ -366,7 +436,7 @@ func TestScanner_Detect(t *testing.T) {
 			defer func() { _ = dbtest.Close() }()
 
 			s := redhat.NewScanner()
-			got, err := s.Detect(tt.args.osVer, nil, tt.args.pkgs)
+			got, err := s.Detect(tt.args.osVer, tt.args.repo, tt.args.pkgs)
 			require.Equal(t, tt.wantErr, err != nil, err)
 			assert.Equal(t, tt.want, got)
 		})
@@ -93,6 +93,7 @@ func (s *Scanner) Detect(osVer string, _ *ftypes.Repository, pkgs []ftypes.Packa
 					FixedVersion:     fixedVersion.String(),
 					PkgRef:           pkg.Ref,
 					Layer:            pkg.Layer,
+					AnalyzedBy:       pkg.AnalyzedBy,
 					DataSource:       adv.DataSource,
 					Custom:           adv.Custom,
 				}
@@ -1,6 +1,7 @@
 package suse
 
 import (
+	"fmt"
 	"time"
 
 	"golang.org/x/xerrors"
@@ -8,6 +9,8 @@ import (
 
 	version "github.com/knqyf263/go-rpm-version"
 
+	"github.com/aquasecurity/trivy-db/pkg/db"
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	susecvrf "github.com/aquasecurity/trivy-db/pkg/vulnsrc/suse-cvrf"
 	fos "github.com/zhanglimao/trivy/pkg/fanal/analyzer/os"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
@@ -55,6 +58,30 @@ var (
 		"15.3": time.Date(2022, 11, 30, 23, 59, 59, 0, time.UTC),
 		"15.4": time.Date(2023, 11, 30, 23, 59, 59, 0, time.UTC),
 	}
+
+	slemEolDates = map[string]time.Time{
+		// Source: https://www.suse.com/lifecycle/
+		"5.0": time.Date(2022, 12, 31, 23, 59, 59, 0, time.UTC),
+		"5.1": time.Date(2025, 10, 31, 23, 59, 59, 0, time.UTC),
+		"5.2": time.Date(2026, 4, 30, 23, 59, 59, 0, time.UTC),
+		"5.3": time.Date(2026, 10, 31, 23, 59, 59, 0, time.UTC),
+		"5.4": time.Date(2027, 4, 30, 23, 59, 59, 0, time.UTC),
+		"5.5": time.Date(2027, 10, 31, 23, 59, 59, 0, time.UTC),
+	}
+
+	opensuseLeapMicroEolDates = map[string]time.Time{
+		// Source: https://en.opensuse.org/Lifetime
+		"5.2": time.Date(2023, 6, 30, 23, 59, 59, 0, time.UTC),
+		"5.3": time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC),
+		"5.4": time.Date(2024, 6, 30, 23, 59, 59, 0, time.UTC),
+		"5.5": time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC),
+	}
+)
+
+const (
+	// trivy-db stores the advisories of the Micro variants in the following buckets
+	platformSUSELinuxMicroFormat = "SUSE Linux Enterprise Micro %s"
+	platformOpenSUSEMicroFormat  = "openSUSE Leap Micro %s"
 )
 
 type options struct {
@@ -77,11 +104,33 @@ const (
 	SUSEEnterpriseLinux Type = iota
 	// OpenSUSE for open versions
 	OpenSUSE
+	// SUSEEnterpriseLinuxMicro is SUSE Linux Enterprise Micro
+	SUSEEnterpriseLinuxMicro
+	// OpenSUSELeapMicro is openSUSE Leap Micro
+	OpenSUSELeapMicro
 )
 
+type vulnSrc interface {
+	Get(version, pkgName string) ([]dbTypes.Advisory, error)
+}
+
+// microVulnSrc looks up the advisories of the Micro variants, which susecvrf.VulnSrc doesn't support
+type microVulnSrc struct {
+	dbc            db.Config
+	platformFormat string
+}
+
+func (vs microVulnSrc) Get(version, pkgName string) ([]dbTypes.Advisory, error) {
+	advisories, err := vs.dbc.GetAdvisories(fmt.Sprintf(vs.platformFormat, version), pkgName)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get SUSE advisories: %w", err)
+	}
+	return advisories, nil
+}
+
 // Scanner implements the SUSE scanner
 type Scanner struct {
-	vs susecvrf.VulnSrc
+	vs vulnSrc
 	*options
 }
 
@@ -106,6 +155,16 @@ func NewScanner(t Type, opts ...option) *Scanner {
 			vs:      susecvrf.NewVulnSrc(susecvrf.OpenSUSE),
 			options: o,
 		}
+	case SUSEEnterpriseLinuxMicro:
+		return &Scanner{
+			vs:      microVulnSrc{platformFormat: platformSUSELinuxMicroFormat},
+			options: o,
+		}
+	case OpenSUSELeapMicro:
+		return &Scanner{
+			vs:      microVulnSrc{platformFormat: platformOpenSUSEMicroFormat},
+			options: o,
+		}
 	}
 	return nil
 }
@@ -134,6 +193,7 @@ func (s *Scanner) Detect(osVer string, _ *ftypes.Repository, pkgs []ftypes.Packa
 				InstalledVersion: installed,
 				PkgRef:           pkg.Ref,
 				Layer:            pkg.Layer,
+				AnalyzedBy:       pkg.AnalyzedBy,
 				Custom:           adv.Custom,
 				DataSource:       adv.DataSource,
 			}
@@ -151,10 +211,15 @@ func (s *Scanner) IsSupportedVersion(osFamily, osVer string) bool {
 	var eolDate time.Time
 	var ok bool
 
-	if osFamily == fos.SLES {
+	switch osFamily {
+	case fos.SLES:
 		eolDate, ok = slesEolDates[osVer]
-	} else if osFamily == fos.OpenSUSELeap {
+	case fos.SLEMicro:
+		eolDate, ok = slemEolDates[osVer]
+	case fos.OpenSUSELeap:
 		eolDate, ok = opensuseEolDates[osVer]
+	case fos.OpenSUSELeapMicro:
+		eolDate, ok = opensuseLeapMicroEolDates[osVer]
 	}
 
 	if !ok {
@@ -67,6 +67,52 @@ func TestScanner_Detect(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:         "SLE Micro",
+			fixtures:     []string{"testdata/fixtures/suse.yaml", "testdata/fixtures/data-source.yaml"},
+			distribution: suse.SUSEEnterpriseLinuxMicro,
+			args: args{
+				osVer: "5.3",
+				pkgs: []ftypes.Package{
+					{
+						Name:       "openssl-1_1",
+						Version:    "1.1.1l",
+						Release:    "150400.7.22.1",
+						SrcName:    "openssl-1_1",
+						SrcVersion: "1.1.1l",
+						SrcRelease: "150400.7.22.1",
+					},
+				},
+			},
+			want: []types.DetectedVulnerability{
+				{
+					PkgName:          "openssl-1_1",
+					VulnerabilityID:  "SUSE-SU-2023:0311-1",
+					InstalledVersion: "1.1.1l-150400.7.22.1",
+					FixedVersion:     "1.1.1l-150400.7.25.1",
+					DataSource: &dbTypes.DataSource{
+						ID:   vulnerability.SuseCVRF,
+						Name: "SUSE CVRF",
+						URL:  "https://ftp.suse.com/pub/projects/security/cvrf/",
+					},
+				},
+			},
+		},
+		{
+			name:         "openSUSE Leap Micro without advisories",
+			fixtures:     []string{"testdata/fixtures/suse.yaml", "testdata/fixtures/data-source.yaml"},
+			distribution: suse.OpenSUSELeapMicro,
+			args: args{
+				osVer: "5.3",
+				pkgs: []ftypes.Package{
+					{
+						Name:    "openssl-1_1",
+						Version: "1.1.1l",
+						Release: "150400.7.22.1",
+					},
+				},
+			},
+		},
 		{
 			name:         "broken bucket",
 			fixtures:     []string{"testdata/fixtures/invalid.yaml", "testdata/fixtures/data-source.yaml"},
@@ -135,6 +181,26 @@ func TestScanner_IsSupportedVersion(t *testing.T) {
 			distribution: suse.SUSEEnterpriseLinux,
 			want:         false,
 		},
+		{
+			name: "sle-micro5.3",
+			now:  time.Date(2024, 5, 31, 23, 59, 59, 0, time.UTC),
+			args: args{
+				osFamily: "suse linux enterprise micro",
+				osVer:    "5.3",
+			},
+			distribution: suse.SUSEEnterpriseLinuxMicro,
+			want:         true,
+		},
+		{
+			name: "opensuse.leap.micro5.2",
+			now:  time.Date(2024, 5, 31, 23, 59, 59, 0, time.UTC),
+			args: args{
+				osFamily: "opensuse.leap.micro",
+				osVer:    "5.2",
+			},
+			distribution: suse.OpenSUSELeapMicro,
+			want:         false,
+		},
 		{
 			name: "unknown",
 			now:  time.Date(2019, 5, 2, 23, 59, 59, 0, time.UTC),
@@ -8,6 +8,7 @@ import (
 	"golang.org/x/xerrors"
 	"k8s.io/utils/clock"
 
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	"github.com/aquasecurity/trivy-db/pkg/vulnsrc/ubuntu"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/log"
@@ -15,6 +16,9 @@ import (
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
+// esmSuffix is appended to the OS version when ESM is enabled
+const esmSuffix = "-ESM"
+
 var (
 	eolDates = map[string]time.Time{
 		"4.10":      time.Date(2006, 4, 30, 23, 59, 59, 0, time.UTC),
@@ -101,45 +105,50 @@ func (s *Scanner) Detect(osVer string, _ *ftypes.Repository, pkgs []ftypes.Packa
 	log.Logger.Debugf("ubuntu: os version: %s", osVer)
 	log.Logger.Debugf("ubuntu: the number of packages: %d", len(pkgs))
 
+	osVer = s.versionFromEolDates(osVer)
+	esmEnabled := strings.HasSuffix(osVer, esmSuffix)
+	stdVer := strings.TrimSuffix(osVer, esmSuffix)
+
 	var vulns []types.DetectedVulnerability
 	for _, pkg := range pkgs {
-		osVer = s.versionFromEolDates(osVer)
-		advisories, err := s.vs.Get(osVer, pkg.SrcName)
+		stdAdvisories, err := s.vs.Get(stdVer, pkg.SrcName)
 		if err != nil {
 			return nil, xerrors.Errorf("failed to get Ubuntu advisories: %w", err)
 		}
+		esmAdvisories, err := s.vs.Get(stdVer+esmSuffix, pkg.SrcName)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to get Ubuntu ESM advisories: %w", err)
+		}
 
 		sourceVersion, err := version.NewVersion(utils.FormatSrcVersion(pkg))
 		if err != nil {
 			log.Logger.Debugf("failed to parse Ubuntu installed package version: %w", err)
 			continue
 		}
+		if !esmEnabled && strings.Contains(sourceVersion.Revision(), "+esm") {
+			log.Logger.Debugf("ubuntu: %s is installed from ESM, but ESM is not enabled", pkg.Name)
+		}
 
-		for _, adv := range advisories {
+		for _, adv := range mergeAdvisories(stdAdvisories, esmAdvisories, esmEnabled) {
 			vuln := types.DetectedVulnerability{
-				VulnerabilityID:  adv.VulnerabilityID,
-				PkgID:            pkg.ID,
-				PkgName:          pkg.Name,
-				InstalledVersion: utils.FormatVersion(pkg),
-				FixedVersion:     adv.FixedVersion,
-				PkgRef:           pkg.Ref,
-				Layer:            pkg.Layer,
-				Custom:           adv.Custom,
-				DataSource:       adv.DataSource,
-			}
-
-			if adv.FixedVersion == "" {
-				vulns = append(vulns, vuln)
-				continue
+				VulnerabilityID:      adv.VulnerabilityID,
+				PkgID:                pkg.ID,
+				PkgName:              pkg.Name,
+				InstalledVersion:     utils.FormatVersion(pkg),
+				FixedVersion:         adv.FixedVersion,
+				ExtendedFixedVersion: adv.esmFixedVersion,
+				PkgRef:               pkg.Ref,
+				Layer:                pkg.Layer,
+				AnalyzedBy:           pkg.AnalyzedBy,
+				Custom:               adv.Custom,
+				DataSource:           adv.DataSource,
 			}
 
-			fixedVersion, err := version.NewVersion(adv.FixedVersion)
-			if err != nil {
-				log.Logger.Debugf("failed to parse Ubuntu package version: %w", err)
+			// The fix only available in ESM might be installed even though ESM is not enabled now
+			if adv.FixedVersion == "" && adv.esmFixedVersion != "" && !isVulnerable(sourceVersion, adv.esmFixedVersion) {
 				continue
 			}
-
-			if sourceVersion.LessThan(fixedVersion) {
+			if isVulnerable(sourceVersion, adv.FixedVersion) {
 				vulns = append(vulns, vuln)
 			}
 		}
@@ -147,6 +156,75 @@ func (s *Scanner) Detect(osVer string, _ *ftypes.Repository, pkgs []ftypes.Packa
 	return vulns, nil
 }
 
+// advisory holds the fixed version only available in ESM in addition to the advisory
+type advisory struct {
+	dbTypes.Advisory
+	esmFixedVersion string
+}
+
+// mergeAdvisories merges advisories of the standard release and ESM.
+// If ESM is enabled, the advisories of ESM are used and fixes only available in ESM are marked.
+// Otherwise, the advisories of the standard release are used, and vulnerabilities fixed only in ESM
+// are reported as unfixed with the fixed version in ESM.
+func mergeAdvisories(stdAdvisories, esmAdvisories []dbTypes.Advisory, esmEnabled bool) []advisory {
+	stdFixed := map[string]string{}
+	for _, adv := range stdAdvisories {
+		stdFixed[adv.VulnerabilityID] = adv.FixedVersion
+	}
+
+	var advisories []advisory
+	if esmEnabled {
+		for _, adv := range esmAdvisories {
+			a := advisory{Advisory: adv}
+			if stdFixed[adv.VulnerabilityID] == "" {
+				a.esmFixedVersion = adv.FixedVersion
+			}
+			advisories = append(advisories, a)
+		}
+		return advisories
+	}
+
+	esmFixed := map[string]string{}
+	for _, adv := range esmAdvisories {
+		esmFixed[adv.VulnerabilityID] = adv.FixedVersion
+	}
+	for _, adv := range stdAdvisories {
+		a := advisory{Advisory: adv}
+		if adv.FixedVersion == "" {
+			a.esmFixedVersion = esmFixed[adv.VulnerabilityID]
+		}
+		advisories = append(advisories, a)
+	}
+
+	// Vulnerabilities disclosed after the end of standard support are tracked only in ESM
+	for _, adv := range esmAdvisories {
+		if _, ok := stdFixed[adv.VulnerabilityID]; ok {
+			continue
+		}
+		a := advisory{
+			Advisory:        adv,
+			esmFixedVersion: adv.FixedVersion,
+		}
+		a.FixedVersion = ""
+		advisories = append(advisories, a)
+	}
+	return advisories
+}
+
+func isVulnerable(installedVersion version.Version, fixedVersion string) bool {
+	// It means unfixed vulnerability
+	if fixedVersion == "" {
+		return true
+	}
+
+	fixed, err := version.NewVersion(fixedVersion)
+	if err != nil {
+		log.Logger.Debugf("failed to parse Ubuntu package version: %w", err)
+		return false
+	}
+	return installedVersion.LessThan(fixed)
+}
+
 // IsSupportedVersion checks is OSFamily can be scanned using Ubuntu scanner
 func (s *Scanner) IsSupportedVersion(osFamily, osVer string) bool {
 	eol, ok := eolDates[s.versionFromEolDates(osVer)]
@@ -148,6 +148,117 @@ func TestScanner_Detect(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "ubuntu 16.04 without ESM",
+			fixtures: []string{"testdata/fixtures/ubuntu.yaml", "testdata/fixtures/data-source.yaml"},
+			args: args{
+				osVer: "16.04",
+				now:   time.Date(2022, 3, 31, 23, 59, 59, 0, time.UTC),
+				pkgs: []ftypes.Package{
+					{
+						Name:       "openssl",
+						Version:    "1.0.2g-1ubuntu4.20",
+						SrcName:    "openssl",
+						SrcVersion: "1.0.2g-1ubuntu4.20",
+					},
+				},
+			},
+			want: []types.DetectedVulnerability{
+				{
+					PkgName:              "openssl",
+					VulnerabilityID:      "CVE-2021-3711",
+					InstalledVersion:     "1.0.2g-1ubuntu4.20",
+					ExtendedFixedVersion: "1.0.2g-1ubuntu4.20+esm1",
+					DataSource: &dbTypes.DataSource{
+						ID:   vulnerability.Ubuntu,
+						Name: "Ubuntu CVE Tracker",
+						URL:  "https://git.launchpad.net/ubuntu-cve-tracker",
+					},
+				},
+				{
+					PkgName:              "openssl",
+					VulnerabilityID:      "CVE-2022-0778",
+					InstalledVersion:     "1.0.2g-1ubuntu4.20",
+					ExtendedFixedVersion: "1.0.2g-1ubuntu4.20+esm2",
+					DataSource: &dbTypes.DataSource{
+						ID:   vulnerability.Ubuntu,
+						Name: "Ubuntu CVE Tracker",
+						URL:  "https://git.launchpad.net/ubuntu-cve-tracker",
+					},
+				},
+			},
+		},
+		{
+			name:     "ubuntu 16.04 with ESM",
+			fixtures: []string{"testdata/fixtures/ubuntu.yaml", "testdata/fixtures/data-source.yaml"},
+			args: args{
+				osVer: "16.04-ESM",
+				now:   time.Date(2022, 3, 31, 23, 59, 59, 0, time.UTC),
+				pkgs: []ftypes.Package{
+					{
+						Name:       "openssl",
+						Version:    "1.0.2g-1ubuntu4.20",
+						SrcName:    "openssl",
+						SrcVersion: "1.0.2g-1ubuntu4.20",
+					},
+				},
+			},
+			want: []types.DetectedVulnerability{
+				{
+					PkgName:              "openssl",
+					VulnerabilityID:      "CVE-2021-3711",
+					InstalledVersion:     "1.0.2g-1ubuntu4.20",
+					FixedVersion:         "1.0.2g-1ubuntu4.20+esm1",
+					ExtendedFixedVersion: "1.0.2g-1ubuntu4.20+esm1",
+					DataSource: &dbTypes.DataSource{
+						ID:   vulnerability.Ubuntu,
+						Name: "Ubuntu CVE Tracker",
+						URL:  "https://git.launchpad.net/ubuntu-cve-tracker",
+					},
+				},
+				{
+					PkgName:              "openssl",
+					VulnerabilityID:      "CVE-2022-0778",
+					InstalledVersion:     "1.0.2g-1ubuntu4.20",
+					FixedVersion:         "1.0.2g-1ubuntu4.20+esm2",
+					ExtendedFixedVersion: "1.0.2g-1ubuntu4.20+esm2",
+					DataSource: &dbTypes.DataSource{
+						ID:   vulnerability.Ubuntu,
+						Name: "Ubuntu CVE Tracker",
+						URL:  "https://git.launchpad.net/ubuntu-cve-tracker",
+					},
+				},
+			},
+		},
+		{
+			name:     "ubuntu 16.04 without ESM, the ESM fix installed",
+			fixtures: []string{"testdata/fixtures/ubuntu.yaml", "testdata/fixtures/data-source.yaml"},
+			args: args{
+				osVer: "16.04",
+				now:   time.Date(2022, 3, 31, 23, 59, 59, 0, time.UTC),
+				pkgs: []ftypes.Package{
+					{
+						Name:       "openssl",
+						Version:    "1.0.2g-1ubuntu4.20+esm1",
+						SrcName:    "openssl",
+						SrcVersion: "1.0.2g-1ubuntu4.20+esm1",
+					},
+				},
+			},
+			want: []types.DetectedVulnerability{
+				{
+					PkgName:              "openssl",
+					VulnerabilityID:      "CVE-2022-0778",
+					InstalledVersion:     "1.0.2g-1ubuntu4.20+esm1",
+					ExtendedFixedVersion: "1.0.2g-1ubuntu4.20+esm2",
+					DataSource: &dbTypes.DataSource{
+						ID:   vulnerability.Ubuntu,
+						Name: "Ubuntu CVE Tracker",
+						URL:  "https://git.launchpad.net/ubuntu-cve-tracker",
+					},
+				},
+			},
+		},
 		{
 			name:     "broken bucket",
 			fixtures: []string{"testdata/fixtures/invalid.yaml", "testdata/fixtures/data-source.yaml"},
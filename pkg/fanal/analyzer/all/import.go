@@ -3,10 +3,13 @@ package all
 import (
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/buildinfo"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/config/all"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/custom"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/executable"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/imgconf/apk"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/imgconf/config"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/imgconf/dockerfile"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/imgconf/secret"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/integrity"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/c/conan"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/conda/meta"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/dart/pub"
@@ -17,22 +20,30 @@ import (
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/golang/mod"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/java/gradle"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/java/jar"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/java/jdk"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/java/pom"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/nodejs/npm"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/nodejs/pkg"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/nodejs/pnpm"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/nodejs/runtime"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/nodejs/yarn"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/php/composer"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/php/runtime"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/python/jupyter"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/python/packaging"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/python/pip"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/python/pipenv"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/python/poetry"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/python/runtime"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/ruby/bundler"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/ruby/gemspec"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/ruby/runtime"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/rust/binary"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/rust/cargo"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/swift/cocoapods"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/layersize"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/licensing"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/mlmodel"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/os/alpine"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/os/amazonlinux"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/os/debian"
@@ -40,10 +51,16 @@ import (
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/os/redhatbase"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/os/release"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/os/ubuntu"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/permission"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/pkg/apk"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/pkg/dpkg"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/pkg/flatpak"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/pkg/homebrew"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/pkg/rpm"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/pkg/snap"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/repo/apk"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/repo/yum"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/sbom"
 	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/secret"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/server"
 )
@@ -9,19 +9,22 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/exp/slices"
-	"golang.org/x/sync/semaphore"
 	"golang.org/x/xerrors"
 
 	dio "github.com/aquasecurity/go-dep-parser/pkg/io"
+	"github.com/zhanglimao/trivy/pkg/digest"
 	aos "github.com/zhanglimao/trivy/pkg/fanal/analyzer/os"
 	"github.com/zhanglimao/trivy/pkg/fanal/log"
 	"github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/mapfs"
 	"github.com/zhanglimao/trivy/pkg/misconf"
 	"github.com/zhanglimao/trivy/pkg/syncx"
+	"github.com/zhanglimao/trivy/pkg/tracing"
 )
 
 var (
@@ -49,6 +52,16 @@ type AnalyzerOptions struct {
 	MisconfScannerOption misconf.ScannerOption
 	SecretScannerOption  SecretScannerOption
 	LicenseScannerOption LicenseScannerOption
+	CustomResourceOption CustomResourceOption
+	PermissionOption     PermissionOption
+
+	// Timeout limits the time an analyzer takes for a file, or a post-analyzer for all its files.
+	// It is unlimited if zero.
+	Timeout time.Duration
+
+	// KeepGoing records errors of analyzers as warnings instead of discarding them,
+	// and files which cannot be opened are skipped rather than aborting the analysis.
+	KeepGoing bool
 }
 
 type SecretScannerOption struct {
@@ -61,6 +74,16 @@ type LicenseScannerOption struct {
 	ClassifierConfidenceLevel float64
 }
 
+type CustomResourceOption struct {
+	// ConfigPath is the path to the config file declaring collectors of custom resources
+	ConfigPath string
+}
+
+type PermissionOption struct {
+	// AllowlistPath is the path to the allowlist of files expected to have privileges
+	AllowlistPath string
+}
+
 ////////////////
 // Interfaces //
 ////////////////
@@ -77,6 +100,19 @@ type analyzer interface {
 	Required(filePath string, info os.FileInfo) bool
 }
 
+// fileInfoAnalyzer represents analyzers that need only file information such as the size.
+// Files are not opened for them, and AnalysisInput.Content is nil.
+type fileInfoAnalyzer interface {
+	FileInfoOnly()
+}
+
+// dirAnalyzer represents analyzers that need directories as well as files, e.g. for their permissions.
+// Directories are passed only to them, and only with file information.
+type dirAnalyzer interface {
+	fileInfoAnalyzer
+	AnalyzeDirs()
+}
+
 type PostAnalyzer interface {
 	Type() Type
 	Version() int
@@ -108,6 +144,15 @@ func RegisterPostAnalyzer(t Type, initializer postAnalyzerInitialize) {
 	postAnalyzers[t] = initializer
 }
 
+// Registered returns whether an analyzer or a post-analyzer of the type is registered
+func Registered(t Type) bool {
+	_, ok := analyzers[t]
+	if !ok {
+		_, ok = postAnalyzers[t]
+	}
+	return ok
+}
+
 // DeregisterAnalyzer is mainly for testing
 func DeregisterAnalyzer(t Type) {
 	delete(analyzers, t)
@@ -125,6 +170,8 @@ type AnalyzerGroup struct {
 	analyzers     []analyzer
 	postAnalyzers []PostAnalyzer
 	filePatterns  map[Type][]*regexp.Regexp
+	timeout       time.Duration
+	keepGoing     bool
 }
 
 ///////////////////////////
@@ -165,12 +212,27 @@ type AnalysisResult struct {
 	// used to search for SBOM attestation.
 	Digests map[string]string
 
+	// FileSizes contains sizes of files in the layer
+	// used to calculate the wasted space of the image.
+	FileSizes map[string]int64
+
+	// PackageFiles and FileDigests contain digests of files in the package databases and in the layer
+	// used to verify the integrity of files installed by OS packages.
+	PackageFiles []types.PackageFiles
+	FileDigests  map[string][]digest.Digest
+
 	// For Red Hat
 	BuildInfo *types.BuildInfo
 
 	// CustomResources hold analysis results from custom analyzers.
 	// It is for extensibility and not used in OSS.
 	CustomResources []types.CustomResource
+
+	// Warnings hold analyzers which didn't complete, e.g. timed out
+	Warnings []types.Warning
+
+	// spill keeps packages and custom resources on disk under the memory budget
+	spill *spillStore
 }
 
 func NewAnalysisResult() *AnalysisResult {
@@ -181,7 +243,8 @@ func NewAnalysisResult() *AnalysisResult {
 func (r *AnalysisResult) isEmpty() bool {
 	return lo.IsEmpty(r.OS) && r.Repository == nil && len(r.PackageInfos) == 0 && len(r.Applications) == 0 &&
 		len(r.Misconfigurations) == 0 && len(r.Secrets) == 0 && len(r.Licenses) == 0 && len(r.SystemInstalledFiles) == 0 &&
-		r.BuildInfo == nil && len(r.Digests) == 0 && len(r.CustomResources) == 0
+		r.BuildInfo == nil && len(r.Digests) == 0 && len(r.FileSizes) == 0 && len(r.PackageFiles) == 0 &&
+		len(r.FileDigests) == 0 && len(r.CustomResources) == 0 && len(r.Warnings) == 0
 }
 
 func (r *AnalysisResult) Sort() {
@@ -243,6 +306,74 @@ func (r *AnalysisResult) Sort() {
 
 		return r.Licenses[i].Type < r.Licenses[j].Type
 	})
+
+	// Warnings
+	sort.Slice(r.Warnings, func(i, j int) bool {
+		if r.Warnings[i].FilePath != r.Warnings[j].FilePath {
+			return r.Warnings[i].FilePath < r.Warnings[j].FilePath
+		}
+		return r.Warnings[i].Analyzer < r.Warnings[j].Analyzer
+	})
+}
+
+// setAnalyzedBy records the analyzer on the packages, misconfigurations, secrets and licenses in the result.
+// Packages are attributed per file and the applier fills each package in the same way as the layer.
+// Those already attributed, e.g. by a wrapping analyzer, are kept as is.
+func (r *AnalysisResult) setAnalyzedBy(analyzerType Type, version int) {
+	if r == nil {
+		return
+	}
+	analyzedBy := &types.AnalyzedBy{
+		Type:    string(analyzerType),
+		Version: version,
+	}
+	for i := range r.PackageInfos {
+		if r.PackageInfos[i].AnalyzedBy == nil {
+			r.PackageInfos[i].AnalyzedBy = analyzedBy
+		}
+	}
+	for i := range r.Applications {
+		if r.Applications[i].AnalyzedBy == nil {
+			r.Applications[i].AnalyzedBy = analyzedBy
+		}
+	}
+	for i := range r.Misconfigurations {
+		if r.Misconfigurations[i].AnalyzedBy == nil {
+			r.Misconfigurations[i].AnalyzedBy = analyzedBy
+		}
+	}
+	for i := range r.Secrets {
+		if r.Secrets[i].AnalyzedBy == nil {
+			r.Secrets[i].AnalyzedBy = analyzedBy
+		}
+	}
+	for i := range r.Licenses {
+		if r.Licenses[i].AnalyzedBy == nil {
+			r.Licenses[i].AnalyzedBy = analyzedBy
+		}
+	}
+}
+
+// skippedFiles returns the files which post-analyzers don't have to analyze,
+// including those of the applications spilled to disk
+func (r *AnalysisResult) skippedFiles() []string {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	skippedFiles := slices.Clone(r.SystemInstalledFiles)
+	skippedFiles = append(skippedFiles, r.spilledFiles()...)
+	for _, app := range r.Applications {
+		skippedFiles = append(skippedFiles, app.FilePath)
+		for _, lib := range app.Libraries {
+			// The analysis result could contain packages listed in SBOM.
+			// The files of those packages don't have to be analyzed.
+			// This is especially helpful for expensive post-analyzers such as the JAR analyzer.
+			if lib.FilePath != "" {
+				skippedFiles = append(skippedFiles, lib.FilePath)
+			}
+		}
+	}
+	return skippedFiles
 }
 
 func (r *AnalysisResult) Merge(new *AnalysisResult) {
@@ -257,7 +388,7 @@ func (r *AnalysisResult) Merge(new *AnalysisResult) {
 	r.OS.Merge(new.OS)
 
 	if new.Repository != nil {
-		r.Repository = new.Repository
+		r.Repository = r.Repository.Merge(new.Repository)
 	}
 
 	if len(new.PackageInfos) > 0 {
@@ -273,6 +404,26 @@ func (r *AnalysisResult) Merge(new *AnalysisResult) {
 		r.Digests = lo.Assign(r.Digests, new.Digests)
 	}
 
+	// Sizes are merged in place since they are passed for every file in the layer
+	if len(new.FileSizes) > 0 {
+		if r.FileSizes == nil {
+			r.FileSizes = make(map[string]int64, len(new.FileSizes))
+		}
+		for filePath, size := range new.FileSizes {
+			r.FileSizes[filePath] = size
+		}
+	}
+
+	r.PackageFiles = append(r.PackageFiles, new.PackageFiles...)
+	if len(new.FileDigests) > 0 {
+		if r.FileDigests == nil {
+			r.FileDigests = make(map[string][]digest.Digest, len(new.FileDigests))
+		}
+		for filePath, digests := range new.FileDigests {
+			r.FileDigests[filePath] = digests
+		}
+	}
+
 	r.Misconfigurations = append(r.Misconfigurations, new.Misconfigurations...)
 	r.Secrets = append(r.Secrets, new.Secrets...)
 	r.Licenses = append(r.Licenses, new.Licenses...)
@@ -295,6 +446,9 @@ func (r *AnalysisResult) Merge(new *AnalysisResult) {
 	}
 
 	r.CustomResources = append(r.CustomResources, new.CustomResources...)
+	r.Warnings = append(r.Warnings, new.Warnings...)
+
+	r.spillIfNeeded(new)
 }
 
 func belongToGroup(groupName Group, analyzerType Type, disabledAnalyzers []Type, analyzer any) bool {
@@ -323,6 +477,8 @@ func NewAnalyzerGroup(opt AnalyzerOptions) (AnalyzerGroup, error) {
 
 	group := AnalyzerGroup{
 		filePatterns: map[Type][]*regexp.Regexp{},
+		timeout:      opt.Timeout,
+		keepGoing:    opt.KeepGoing,
 	}
 	for _, p := range opt.FilePatterns {
 		// e.g. "dockerfile:my_dockerfile_*"
@@ -395,12 +551,8 @@ func (ag AnalyzerGroup) AnalyzerVersions() Versions {
 // AnalyzeFile determines which files are required by the analyzers based on the file name and attributes,
 // and passes only those files to the analyzer for analysis.
 // This function may be called concurrently and must be thread-safe.
-func (ag AnalyzerGroup) AnalyzeFile(ctx context.Context, wg *sync.WaitGroup, limit *semaphore.Weighted, result *AnalysisResult,
+func (ag AnalyzerGroup) AnalyzeFile(ctx context.Context, wg *sync.WaitGroup, scheduler *Scheduler, result *AnalysisResult,
 	dir, filePath string, info os.FileInfo, opener Opener, disabled []Type, opts AnalysisOptions) error {
-	if info.IsDir() {
-		return nil
-	}
-
 	// filepath extracted from tar file doesn't have the prefix "/"
 	cleanPath := strings.TrimLeft(filePath, "/")
 
@@ -410,38 +562,73 @@ func (ag AnalyzerGroup) AnalyzeFile(ctx context.Context, wg *sync.WaitGroup, lim
 			continue
 		}
 
+		if _, ok := a.(dirAnalyzer); info.IsDir() && !ok {
+			continue
+		}
+
 		if !ag.filePatternMatch(a.Type(), cleanPath) && !a.Required(cleanPath, info) {
 			continue
 		}
+
+		// Analyzers using only file information don't need to open the file
+		if _, ok := a.(fileInfoAnalyzer); ok {
+			ret, err := ag.analyze(ctx, a, AnalysisInput{
+				Dir:      dir,
+				FilePath: filePath,
+				Info:     info,
+				Options:  opts,
+			}, func() {})
+			if errors.Is(err, errTimeout) {
+				result.Merge(ag.timeoutWarning(a.Type(), filePath))
+				continue
+			} else if err != nil {
+				result.Merge(ag.failure(a.Type(), filePath, err))
+				continue
+			}
+			ret.setAnalyzedBy(a.Type(), a.Version())
+			result.Merge(ret)
+			continue
+		}
+
 		rc, err := opener()
 		if errors.Is(err, fs.ErrPermission) {
 			log.Logger.Debugf("Permission error: %s", filePath)
 			break
+		} else if err != nil && ag.keepGoing {
+			result.Merge(ag.failure("", filePath, xerrors.Errorf("unable to open: %w", err)))
+			break
 		} else if err != nil {
 			return xerrors.Errorf("unable to open %s: %w", filePath, err)
 		}
 
-		if err = limit.Acquire(ctx, 1); err != nil {
-			return xerrors.Errorf("semaphore acquire: %w", err)
+		release, err := scheduler.Acquire(ctx, a.Type())
+		if err != nil {
+			return xerrors.Errorf("scheduler acquire: %w", err)
 		}
 		wg.Add(1)
 
 		go func(a analyzer, rc dio.ReadSeekCloserAt) {
-			defer limit.Release(1)
 			defer wg.Done()
-			defer rc.Close()
 
-			ret, err := a.Analyze(ctx, AnalysisInput{
+			// The file and the slot are held until the analyzer returns, even after it times out
+			ret, err := ag.analyze(ctx, a, AnalysisInput{
 				Dir:      dir,
 				FilePath: filePath,
 				Info:     info,
 				Content:  rc,
 				Options:  opts,
+			}, func() {
+				_ = rc.Close()
+				release()
 			})
-			if err != nil && !errors.Is(err, aos.AnalyzeOSError) {
-				log.Logger.Debugf("Analysis error: %s", err)
+			if errors.Is(err, errTimeout) {
+				result.Merge(ag.timeoutWarning(a.Type(), filePath))
+				return
+			} else if err != nil && !errors.Is(err, aos.AnalyzeOSError) {
+				result.Merge(ag.failure(a.Type(), filePath, err))
 				return
 			}
+			ret.setAnalyzedBy(a.Type(), a.Version())
 			result.Merge(ret)
 		}(a, rc)
 	}
@@ -468,38 +655,37 @@ func (ag AnalyzerGroup) RequiredPostAnalyzers(filePath string, info os.FileInfo)
 // The obtained results are merged into the "result".
 // This function may be called concurrently and must be thread-safe.
 func (ag AnalyzerGroup) PostAnalyze(ctx context.Context, files *syncx.Map[Type, *mapfs.FS], result *AnalysisResult, opts AnalysisOptions) error {
+	ctx, span := tracing.Start(ctx, "analyzer.PostAnalyze")
+	defer span.End()
+
 	for _, a := range ag.postAnalyzers {
 		fsys, ok := files.Load(a.Type())
 		if !ok {
 			continue
 		}
 
-		skippedFiles := result.SystemInstalledFiles
-		for _, app := range result.Applications {
-			skippedFiles = append(skippedFiles, app.FilePath)
-			for _, lib := range app.Libraries {
-				// The analysis result could contain packages listed in SBOM.
-				// The files of those packages don't have to be analyzed.
-				// This is especially helpful for expensive post-analyzers such as the JAR analyzer.
-				if lib.FilePath != "" {
-					skippedFiles = append(skippedFiles, lib.FilePath)
-				}
-			}
-		}
-
-		filteredFS, err := fsys.Filter(skippedFiles)
+		filteredFS, err := fsys.Filter(result.skippedFiles())
 		if err != nil {
 			return xerrors.Errorf("unable to filter filesystem: %w", err)
 		}
 
-		res, err := a.PostAnalyze(ctx, PostAnalysisInput{
-			FS:      filteredFS,
-			Options: opts,
-		})
-		if err != nil {
-			xerrors.Errorf("post analysis error: %w", err)
+		actx, aspan := tracing.Start(ctx, "analyzer.PostAnalyze."+string(a.Type()),
+			attribute.String("analyzer.type", string(a.Type())))
+		res, err := withTimeout(actx, ag.timeout, func(ctx context.Context) (*AnalysisResult, error) {
+			return a.PostAnalyze(ctx, PostAnalysisInput{
+				FS:      filteredFS,
+				Options: opts,
+			})
+		}, func() {})
+		tracing.End(aspan, err)
+		if errors.Is(err, errTimeout) {
+			result.Merge(ag.timeoutWarning(a.Type(), ""))
+			continue
+		} else if err != nil {
+			result.Merge(ag.failure(a.Type(), "", err))
 			continue
 		}
+		res.setAnalyzedBy(a.Type(), a.Version())
 		result.Merge(res)
 	}
 	return nil
@@ -9,7 +9,6 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"golang.org/x/sync/semaphore"
 	"golang.org/x/xerrors"
 
 	dio "github.com/aquasecurity/go-dep-parser/pkg/io"
@@ -37,6 +36,7 @@ func TestAnalysisResult_Merge(t *testing.T) {
 	type fields struct {
 		m            sync.Mutex
 		OS           types.OS
+		Repository   *types.Repository
 		PackageInfos []types.PackageInfo
 		Applications []types.Application
 	}
@@ -227,6 +227,30 @@ func TestAnalysisResult_Merge(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "merge debian ELTS",
+			fields: fields{
+				OS: types.OS{
+					Family:   aos.Debian,
+					Extended: true,
+				},
+			},
+			args: args{
+				new: &analyzer.AnalysisResult{
+					OS: types.OS{
+						Family: aos.Debian,
+						Name:   "9.13",
+					},
+				},
+			},
+			want: analyzer.AnalysisResult{
+				OS: types.OS{
+					Family:   aos.Debian,
+					Name:     "9.13",
+					Extended: true,
+				},
+			},
+		},
 		{
 			name: "alpine OS needs to be extended with apk repositories",
 			fields: fields{
@@ -254,6 +278,62 @@ func TestAnalysisResult_Merge(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "repositories of packages are accumulated",
+			fields: fields{
+				Repository: &types.Repository{
+					Release: "2023.1.20230719",
+					Packages: map[string]string{
+						"docker": "amzn2extra-docker",
+					},
+				},
+			},
+			args: args{
+				new: &analyzer.AnalysisResult{
+					Repository: &types.Repository{
+						Packages: map[string]string{
+							"nginx": "amzn2extra-nginx1",
+						},
+					},
+				},
+			},
+			want: analyzer.AnalysisResult{
+				Repository: &types.Repository{
+					Release: "2023.1.20230719",
+					Packages: map[string]string{
+						"docker": "amzn2extra-docker",
+						"nginx":  "amzn2extra-nginx1",
+					},
+				},
+			},
+		},
+		{
+			name: "module streams are accumulated",
+			fields: fields{
+				Repository: &types.Repository{
+					Modules: map[string]string{
+						"nodejs": "18",
+					},
+				},
+			},
+			args: args{
+				new: &analyzer.AnalysisResult{
+					Repository: &types.Repository{
+						Modules: map[string]string{
+							"postgresql": "15",
+						},
+					},
+				},
+			},
+			want: analyzer.AnalysisResult{
+				Repository: &types.Repository{
+					Modules: map[string]string{
+						"nodejs":     "18",
+						"postgresql": "15",
+					},
+				},
+			},
+		},
 		{
 			name: "alpine must not be replaced with oracle",
 			fields: fields{
@@ -282,6 +362,7 @@ func TestAnalysisResult_Merge(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			r := analyzer.AnalysisResult{
 				OS:           tt.fields.OS,
+				Repository:   tt.fields.Repository,
 				PackageInfos: tt.fields.PackageInfos,
 				Applications: tt.fields.Applications,
 			}
@@ -335,7 +416,8 @@ func TestAnalyzerGroup_AnalyzeFile(t *testing.T) {
 			want: &analyzer.AnalysisResult{
 				PackageInfos: []types.PackageInfo{
 					{
-						FilePath: "/lib/apk/db/installed",
+						FilePath:   "/lib/apk/db/installed",
+						AnalyzedBy: &types.AnalyzedBy{Type: "apk", Version: 2},
 						Packages: []types.Package{
 							{
 								ID:         "musl@1.1.24-r2",
@@ -374,8 +456,9 @@ func TestAnalyzerGroup_AnalyzeFile(t *testing.T) {
 			want: &analyzer.AnalysisResult{
 				Applications: []types.Application{
 					{
-						Type:     "bundler",
-						FilePath: "/app/Gemfile.lock",
+						Type:       "bundler",
+						FilePath:   "/app/Gemfile.lock",
+						AnalyzedBy: &types.AnalyzedBy{Type: "bundler", Version: 1},
 						Libraries: []types.Package{
 							{
 								ID:       "actioncable@5.2.3",
@@ -435,8 +518,9 @@ func TestAnalyzerGroup_AnalyzeFile(t *testing.T) {
 			want: &analyzer.AnalysisResult{
 				Applications: []types.Application{
 					{
-						Type:     "bundler",
-						FilePath: "/app/Gemfile-dev.lock",
+						Type:       "bundler",
+						FilePath:   "/app/Gemfile-dev.lock",
+						AnalyzedBy: &types.AnalyzedBy{Type: "bundler", Version: 1},
 						Libraries: []types.Package{
 							{
 								ID:       "actioncable@5.2.3",
@@ -508,7 +592,8 @@ func TestAnalyzerGroup_AnalyzeFile(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var wg sync.WaitGroup
-			limit := semaphore.NewWeighted(3)
+			scheduler := analyzer.NewScheduler(analyzer.SchedulerOption{MaxWorkers: 3})
+			defer scheduler.Close()
 
 			got := new(analyzer.AnalysisResult)
 			a, err := analyzer.NewAnalyzerGroup(analyzer.AnalyzerOptions{
@@ -526,7 +611,7 @@ func TestAnalyzerGroup_AnalyzeFile(t *testing.T) {
 			require.NoError(t, err)
 
 			ctx := context.Background()
-			err = a.AnalyzeFile(ctx, &wg, limit, got, "", tt.args.filePath, info,
+			err = a.AnalyzeFile(ctx, &wg, scheduler, got, "", tt.args.filePath, info,
 				func() (dio.ReadSeekCloserAt, error) {
 					if tt.args.testFilePath == "testdata/error" {
 						return nil, xerrors.New("error")
@@ -568,8 +653,9 @@ func TestAnalyzerGroup_PostAnalyze(t *testing.T) {
 			want: &analyzer.AnalysisResult{
 				Applications: []types.Application{
 					{
-						Type:     string(analyzer.TypeJar),
-						FilePath: "testdata/post-apps/jar/jackson-annotations-2.15.0-rc2.jar",
+						Type:       string(analyzer.TypeJar),
+						FilePath:   "testdata/post-apps/jar/jackson-annotations-2.15.0-rc2.jar",
+						AnalyzedBy: &types.AnalyzedBy{Type: "jar", Version: 1},
 						Libraries: []types.Package{
 							{
 								Name:     "com.fasterxml.jackson.core:jackson-annotations",
@@ -588,8 +674,9 @@ func TestAnalyzerGroup_PostAnalyze(t *testing.T) {
 			want: &analyzer.AnalysisResult{
 				Applications: []types.Application{
 					{
-						Type:     string(analyzer.TypePoetry),
-						FilePath: "testdata/post-apps/poetry/happy/poetry.lock",
+						Type:       string(analyzer.TypePoetry),
+						FilePath:   "testdata/post-apps/poetry/happy/poetry.lock",
+						AnalyzedBy: &types.AnalyzedBy{Type: "poetry", Version: 1},
 						Libraries: []types.Package{
 							{
 								ID:      "certifi@2022.12.7",
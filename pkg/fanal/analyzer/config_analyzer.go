@@ -2,6 +2,7 @@ package analyzer
 
 import (
 	"context"
+	"sort"
 
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"golang.org/x/exp/slices"
@@ -58,7 +59,7 @@ func (r *ConfigAnalysisResult) Merge(new *ConfigAnalysisResult) {
 		return
 	}
 	if new.Misconfiguration != nil {
-		r.Misconfiguration = new.Misconfiguration
+		r.Misconfiguration = mergeMisconfiguration(r.Misconfiguration, new.Misconfiguration)
 	}
 	if new.Secret != nil {
 		r.Secret = new.Secret
@@ -68,6 +69,21 @@ func (r *ConfigAnalysisResult) Merge(new *ConfigAnalysisResult) {
 	}
 }
 
+// mergeMisconfiguration merges the results of several config analyzers
+// since they all are reported for the same target, the container image config.
+// The file type and path of the first result are kept.
+func mergeMisconfiguration(old, new *types.Misconfiguration) *types.Misconfiguration {
+	if old == nil {
+		return new
+	}
+	merged := *old
+	merged.Successes = append(slices.Clone(old.Successes), new.Successes...)
+	merged.Warnings = append(slices.Clone(old.Warnings), new.Warnings...)
+	merged.Failures = append(slices.Clone(old.Failures), new.Failures...)
+	merged.Exceptions = append(slices.Clone(old.Exceptions), new.Exceptions...)
+	return &merged
+}
+
 type ConfigAnalyzerGroup struct {
 	configAnalyzers []ConfigAnalyzer
 }
@@ -87,6 +103,11 @@ func NewConfigAnalyzerGroup(opts ConfigAnalyzerOptions) (ConfigAnalyzerGroup, er
 		g.configAnalyzers = append(g.configAnalyzers, a)
 	}
 
+	// The order must be stable as the results are merged
+	sort.Slice(g.configAnalyzers, func(i, j int) bool {
+		return g.configAnalyzers[i].Type() < g.configAnalyzers[j].Type()
+	})
+
 	return g, nil
 }
 
@@ -23,6 +23,7 @@ const (
 	TypeSUSE       Type = "suse"
 	TypeUbuntu     Type = "ubuntu"
 	TypeUbuntuESM  Type = "ubuntu-esm"
+	TypeDebianELTS Type = "debian-elts"
 
 	// OS Package
 	TypeApk         Type = "apk"
@@ -33,6 +34,7 @@ const (
 
 	// OS Package Repository
 	TypeApkRepo Type = "apk-repo"
+	TypeYumRepo Type = "yum-repo"
 
 	// ============================
 	// Programming Language Package
@@ -53,6 +55,7 @@ const (
 	TypeJar        Type = "jar"
 	TypePom        Type = "pom"
 	TypeGradleLock Type = "gradle-lockfile"
+	TypeJDK        Type = "jdk"
 
 	// Node.js
 	TypeNpmPkgLock Type = "npm"
@@ -72,6 +75,7 @@ const (
 	TypePip       Type = "pip"
 	TypePipenv    Type = "pipenv"
 	TypePoetry    Type = "poetry"
+	TypeJupyter   Type = "jupyter"
 
 	// Go
 	TypeGoBinary Type = "gobinary"
@@ -89,11 +93,44 @@ const (
 	// Dart
 	TypePubSpecLock Type = "pubspec-lock"
 
+	// ========
+	// Runtimes
+	// ========
+	TypeNodeRuntime   Type = "node-runtime"
+	TypePythonRuntime Type = "python-runtime"
+	TypeRubyRuntime   Type = "ruby-runtime"
+	TypePHPRuntime    Type = "php-runtime"
+
+	// ===========
+	// Web Servers
+	// ===========
+	TypeServer Type = "server"
+
+	// ============================
+	// Alternative Package Managers
+	// ============================
+	TypeSnap     Type = "snap"
+	TypeFlatpak  Type = "flatpak"
+	TypeHomebrew Type = "homebrew"
+
+	// ===============
+	// Machine Learning
+	// ===============
+	TypeMLModel Type = "ml-model"
+
+	// ================
+	// Custom resources
+	// ================
+	TypeCustomResource Type = "custom-resource"
+
 	// ============
 	// Non-packaged
 	// ============
 	TypeExecutable Type = "executable"
 	TypeSBOM       Type = "sbom"
+	TypeLayerSize  Type = "layer-size"
+	TypeIntegrity  Type = "integrity"
+	TypePermission Type = "permission"
 
 	// ============
 	// Image Config
@@ -101,6 +138,7 @@ const (
 	TypeApkCommand        Type = "apk-command"
 	TypeHistoryDockerfile Type = "history-dockerfile"
 	TypeImageConfigSecret Type = "image-config-secret"
+	TypeImageConfigCheck  Type = "image-config-check"
 
 	// =================
 	// Structured Config
@@ -138,6 +176,7 @@ var (
 		TypeAmazon,
 		TypeCBLMariner,
 		TypeDebian,
+		TypeDebianELTS,
 		TypePhoton,
 		TypeCentOS,
 		TypeRocky,
@@ -153,6 +192,7 @@ var (
 		TypeRpm,
 		TypeRpmqa,
 		TypeApkRepo,
+		TypeYumRepo,
 	}
 
 	// TypeLanguages has all language analyzers
@@ -164,6 +204,7 @@ var (
 		TypeJar,
 		TypePom,
 		TypeGradleLock,
+		TypeJDK,
 		TypeNpmPkgLock,
 		TypeNodePkg,
 		TypeYarn,
@@ -175,6 +216,7 @@ var (
 		TypePip,
 		TypePipenv,
 		TypePoetry,
+		TypeJupyter,
 		TypeGoBinary,
 		TypeGoMod,
 		TypeRustBinary,
@@ -182,6 +224,14 @@ var (
 		TypeCocoaPods,
 		TypePubSpecLock,
 		TypeMixLock,
+		TypeNodeRuntime,
+		TypePythonRuntime,
+		TypeRubyRuntime,
+		TypePHPRuntime,
+		TypeServer,
+		TypeSnap,
+		TypeFlatpak,
+		TypeHomebrew,
 	}
 
 	// TypeLockfiles has all lock file analyzers
@@ -210,7 +260,16 @@ var (
 		TypePythonPkg,
 		TypeGoBinary,
 		TypeJar,
+		TypeJDK,
 		TypeRustBinary,
+		TypeNodeRuntime,
+		TypePythonRuntime,
+		TypeRubyRuntime,
+		TypePHPRuntime,
+		TypeServer,
+		TypeSnap,
+		TypeFlatpak,
+		TypeHomebrew,
 	}
 
 	// TypeConfigFiles has all config file analyzers
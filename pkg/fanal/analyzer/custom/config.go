@@ -0,0 +1,141 @@
+package custom
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+	"github.com/jmespath/go-jmespath"
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// Formats of files collected as custom resources
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatText = "text"
+)
+
+// Config represents the config file declaring collectors
+type Config struct {
+	Collectors []Collector `yaml:"collectors"`
+}
+
+// Collector extracts a custom resource from files matching the patterns
+type Collector struct {
+	// Type is stored as the type of custom resources, e.g. "nginx-upstream"
+	Type string `yaml:"type"`
+
+	// Patterns are glob patterns of file paths relative to the scan target, e.g. "**/conf.d/*.yaml"
+	Patterns []string `yaml:"patterns"`
+
+	// Format is the format of files. It is guessed from the file extension if empty.
+	Format string `yaml:"format"`
+
+	// Expression is a JMESPath expression evaluated against the parsed file.
+	// The whole file is stored if empty.
+	Expression string `yaml:"expression"`
+
+	expression *jmespath.JMESPath
+}
+
+// ParseConfig parses the config file of collectors
+func ParseConfig(configPath string) (*Config, error) {
+	if configPath == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(configPath)
+	if err != nil {
+		return nil, xerrors.Errorf("file open error %s: %w", configPath, err)
+	}
+	defer f.Close()
+
+	log.Logger.Infof("Loading %s for custom resources...", configPath)
+
+	var config Config
+	if err = yaml.NewDecoder(f).Decode(&config); err != nil {
+		return nil, xerrors.Errorf("custom resource config decode error: %w", err)
+	}
+
+	for i := range config.Collectors {
+		if err = config.Collectors[i].init(); err != nil {
+			return nil, xerrors.Errorf("collector %d: %w", i, err)
+		}
+	}
+	return &config, nil
+}
+
+func (c *Collector) init() error {
+	if c.Type == "" {
+		return xerrors.New("type must be specified")
+	}
+	if len(c.Patterns) == 0 {
+		return xerrors.Errorf("%s: patterns must be specified", c.Type)
+	}
+	for i, pattern := range c.Patterns {
+		// File paths in container images don't have the leading slash
+		pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "/")
+		if _, err := doublestar.Match(pattern, ""); err != nil {
+			return xerrors.Errorf("%s: invalid pattern %q: %w", c.Type, pattern, err)
+		}
+		c.Patterns[i] = pattern
+	}
+
+	switch c.Format {
+	case "", FormatJSON, FormatYAML, FormatText:
+	default:
+		return xerrors.Errorf("%s: unknown format %q", c.Type, c.Format)
+	}
+
+	if c.Expression != "" {
+		expr, err := jmespath.Compile(c.Expression)
+		if err != nil {
+			return xerrors.Errorf("%s: invalid expression %q: %w", c.Type, c.Expression, err)
+		}
+		c.expression = expr
+	}
+	return nil
+}
+
+// Match returns true if the file path matches one of the patterns
+func (c *Collector) Match(filePath string) bool {
+	filePath = filepath.ToSlash(filePath)
+	for _, pattern := range c.Patterns {
+		if ok, _ := doublestar.Match(pattern, filePath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// format returns the format of the file
+func (c *Collector) format(filePath string) string {
+	if c.Format != "" {
+		return c.Format
+	}
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		return FormatJSON
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatText
+	}
+}
+
+// Extract evaluates the expression against the parsed document
+func (c *Collector) Extract(doc interface{}) (interface{}, error) {
+	if c.expression == nil {
+		return doc, nil
+	}
+	res, err := c.expression.Search(doc)
+	if err != nil {
+		return nil, xerrors.Errorf("JMESPath error: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,262 @@
+package custom
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/mapfs"
+	"github.com/zhanglimao/trivy/pkg/misconf"
+)
+
+func init() {
+	// Collectors will be loaded later via Init()
+	analyzer.RegisterAnalyzer(&customResourceAnalyzer{})
+}
+
+const version = 1
+
+// Input is the input of user policies evaluating custom resources
+type Input struct {
+	Type     string
+	FilePath string
+	Data     interface{}
+}
+
+// customResourceAnalyzer extracts custom resources from files with user-defined collectors.
+// The resources are evaluated against user policies when they are passed.
+type customResourceAnalyzer struct {
+	collectors []Collector
+	scanner    *misconf.Scanner
+}
+
+// Init loads collectors from the config file
+func (a *customResourceAnalyzer) Init(opt analyzer.AnalyzerOptions) error {
+	a.collectors, a.scanner = nil, nil
+
+	config, err := ParseConfig(opt.CustomResourceOption.ConfigPath)
+	if err != nil {
+		return xerrors.Errorf("custom resource config error: %w", err)
+	} else if config == nil || len(config.Collectors) == 0 {
+		return nil
+	}
+	a.collectors = config.Collectors
+
+	// Built-in checks don't know custom resources. Skip evaluation if no user policy is passed.
+	if len(opt.MisconfScannerOption.PolicyPaths) == 0 {
+		return nil
+	}
+	if a.scanner, err = misconf.NewCustomResourceScanner(opt.MisconfScannerOption); err != nil {
+		return xerrors.Errorf("misconfiguration scanner error: %w", err)
+	}
+	return nil
+}
+
+func (a *customResourceAnalyzer) Analyze(ctx context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	content, err := io.ReadAll(input.Content)
+	if err != nil {
+		return nil, xerrors.Errorf("read error %s: %w", input.FilePath, err)
+	}
+
+	// Files are parsed once per format since several collectors may match the same file
+	docs := map[string]interface{}{}
+
+	var resources []types.CustomResource
+	for i := range a.collectors {
+		c := &a.collectors[i]
+		if !c.Match(input.FilePath) {
+			continue
+		}
+
+		format := c.format(input.FilePath)
+		doc, ok := docs[format]
+		if !ok {
+			if doc, err = parse(content, format); err != nil {
+				log.Logger.Debugf("Unable to parse %s as %s for %q: %s", input.FilePath, format, c.Type, err)
+			}
+			docs[format] = doc
+		}
+		if doc == nil {
+			continue
+		}
+
+		data, err := c.Extract(doc)
+		if err != nil {
+			log.Logger.Debugf("Unable to extract %q from %s: %s", c.Type, input.FilePath, err)
+			continue
+		} else if data == nil {
+			continue
+		}
+
+		resources = append(resources, types.CustomResource{
+			Type:     c.Type,
+			FilePath: input.FilePath,
+			Data:     data,
+		})
+	}
+	if len(resources) == 0 {
+		return nil, nil
+	}
+
+	misconfs, err := a.evaluate(ctx, resources)
+	if err != nil {
+		return nil, xerrors.Errorf("custom resource scan error %s: %w", input.FilePath, err)
+	}
+
+	return &analyzer.AnalysisResult{
+		CustomResources:   resources,
+		Misconfigurations: misconfs,
+	}, nil
+}
+
+// evaluate passes each resource to user policies and merges the results into one misconfiguration of the file
+func (a *customResourceAnalyzer) evaluate(ctx context.Context, resources []types.CustomResource) ([]types.Misconfiguration, error) {
+	if a.scanner == nil {
+		return nil, nil
+	}
+
+	var merged *types.Misconfiguration
+	for _, res := range resources {
+		b, err := json.Marshal(Input{
+			Type:     res.Type,
+			FilePath: res.FilePath,
+			Data:     res.Data,
+		})
+		if err != nil {
+			return nil, xerrors.Errorf("json marshal error: %w", err)
+		}
+
+		fsys := mapfs.New()
+		if err = fsys.MkdirAll(filepath.Dir(res.FilePath), os.ModePerm); err != nil {
+			return nil, xerrors.Errorf("mapfs mkdir error: %w", err)
+		}
+		if err = fsys.WriteVirtualFile(res.FilePath, b, 0600); err != nil {
+			return nil, xerrors.Errorf("mapfs write error: %w", err)
+		}
+
+		misconfs, err := a.scanner.Scan(ctx, fsys)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range misconfs {
+			if merged == nil {
+				m := m
+				merged = &m
+				continue
+			}
+			merged.Successes = append(merged.Successes, m.Successes...)
+			merged.Warnings = append(merged.Warnings, m.Warnings...)
+			merged.Failures = append(merged.Failures, m.Failures...)
+			merged.Exceptions = append(merged.Exceptions, m.Exceptions...)
+		}
+	}
+	if merged == nil {
+		return nil, nil
+	}
+	return []types.Misconfiguration{*merged}, nil
+}
+
+// parse decodes the content so that JMESPath expressions can be evaluated
+func parse(content []byte, format string) (interface{}, error) {
+	switch format {
+	case FormatJSON:
+		var doc interface{}
+		if err := json.Unmarshal(content, &doc); err != nil {
+			return nil, xerrors.Errorf("json decode error: %w", err)
+		}
+		return doc, nil
+	case FormatYAML:
+		var docs []interface{}
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		for {
+			var doc interface{}
+			if err := decoder.Decode(&doc); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, xerrors.Errorf("yaml decode error: %w", err)
+			}
+			docs = append(docs, normalize(doc))
+		}
+		switch len(docs) {
+		case 0:
+			return nil, nil
+		case 1:
+			return docs[0], nil
+		default:
+			// Multiple documents are passed as a list
+			return docs, nil
+		}
+	default:
+		// Text files are passed as a list of lines
+		var lines []interface{}
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, xerrors.Errorf("read error: %w", err)
+		}
+		return lines, nil
+	}
+}
+
+// normalize converts YAML values into the types decoded from JSON,
+// so that expressions and stored resources behave the same regardless of the format.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			v[key] = normalize(val)
+		}
+		return v
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			m[fmt.Sprint(key)] = normalize(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range v {
+			v[i] = normalize(val)
+		}
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	default:
+		return v
+	}
+}
+
+func (a *customResourceAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	for i := range a.collectors {
+		if a.collectors[i].Match(filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *customResourceAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeCustomResource
+}
+
+func (a *customResourceAnalyzer) Version() int {
+	return version
+}
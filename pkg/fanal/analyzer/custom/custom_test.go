@@ -0,0 +1,230 @@
+package custom
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/misconf"
+)
+
+func Test_customResourceAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		filePath  string
+		inputFile string
+		policies  []string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name:      "yaml",
+			filePath:  "app/docker-compose.yaml",
+			inputFile: "testdata/docker-compose.yaml",
+			want: &analyzer.AnalysisResult{
+				CustomResources: []types.CustomResource{
+					{
+						Type:     "compose-service",
+						FilePath: "app/docker-compose.yaml",
+						Data: []interface{}{
+							map[string]interface{}{
+								"image":      "nginx:1.25",
+								"privileged": true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "json",
+			filePath:  "package.json",
+			inputFile: "testdata/package.json",
+			want: &analyzer.AnalysisResult{
+				CustomResources: []types.CustomResource{
+					{
+						Type:     "package-scripts",
+						FilePath: "package.json",
+						Data: map[string]interface{}{
+							"build":       "tsc",
+							"postinstall": "node scripts/setup.js",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "text",
+			filePath:  "etc/nginx/conf.d/default.conf",
+			inputFile: "testdata/default.conf",
+			want: &analyzer.AnalysisResult{
+				CustomResources: []types.CustomResource{
+					{
+						Type:     "nginx-server-name",
+						FilePath: "etc/nginx/conf.d/default.conf",
+						Data: []interface{}{
+							"    server_name example.com;",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "no match of expression",
+			filePath:  "package.json",
+			inputFile: "testdata/no-scripts.json",
+			want:      nil,
+		},
+		{
+			name:      "invalid file",
+			filePath:  "package.json",
+			inputFile: "testdata/default.conf",
+			want:      nil,
+		},
+		{
+			name:      "with policies",
+			filePath:  "app/docker-compose.yaml",
+			inputFile: "testdata/docker-compose.yaml",
+			policies:  []string{"testdata/policies"},
+			want: &analyzer.AnalysisResult{
+				CustomResources: []types.CustomResource{
+					{
+						Type:     "compose-service",
+						FilePath: "app/docker-compose.yaml",
+						Data: []interface{}{
+							map[string]interface{}{
+								"image":      "nginx:1.25",
+								"privileged": true,
+							},
+						},
+					},
+				},
+				Misconfigurations: []types.Misconfiguration{
+					{
+						FileType: types.JSON,
+						FilePath: "app/docker-compose.yaml",
+						Failures: types.MisconfResults{
+							{
+								Namespace: "user.compose.CR001",
+								Query:     "data.user.compose.CR001.deny",
+								Message:   "Service 'nginx:1.25' is privileged",
+								PolicyMetadata: types.PolicyMetadata{
+									ID:          "CR001",
+									Type:        "JSON Security Check",
+									Title:       "Compose services should not be privileged",
+									Description: "Privileged containers have access to all devices of the host.",
+									Severity:    "HIGH",
+								},
+								CauseMetadata: types.CauseMetadata{
+									Provider: "Json",
+									Service:  "general",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &customResourceAnalyzer{}
+			err := a.Init(analyzer.AnalyzerOptions{
+				CustomResourceOption: analyzer.CustomResourceOption{ConfigPath: "testdata/config.yaml"},
+				MisconfScannerOption: misconf.ScannerOption{
+					PolicyPaths: tt.policies,
+					Namespaces:  []string{"user"},
+				},
+			})
+			require.NoError(t, err)
+
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.filePath,
+				Content:  f,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_customResourceAnalyzer_Init(t *testing.T) {
+	tests := []struct {
+		name       string
+		configPath string
+		wantErr    string
+	}{
+		{
+			name:       "happy path",
+			configPath: "testdata/config.yaml",
+		},
+		{
+			name: "no config",
+		},
+		{
+			name:       "invalid expression",
+			configPath: "testdata/invalid-expression.yaml",
+			wantErr:    "broken: invalid expression",
+		},
+		{
+			name:       "missing config",
+			configPath: "testdata/missing.yaml",
+			wantErr:    "file open error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &customResourceAnalyzer{}
+			err := a.Init(analyzer.AnalyzerOptions{
+				CustomResourceOption: analyzer.CustomResourceOption{ConfigPath: tt.configPath},
+			})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_customResourceAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{
+			filePath: "docker-compose.yaml",
+			want:     true,
+		},
+		{
+			filePath: "deploy/app/docker-compose.yaml",
+			want:     true,
+		},
+		{
+			filePath: "etc/nginx/nginx.conf",
+			want:     true,
+		},
+		{
+			filePath: "usr/share/nginx/html/index.html",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			a := &customResourceAnalyzer{}
+			err := a.Init(analyzer.AnalyzerOptions{
+				CustomResourceOption: analyzer.CustomResourceOption{ConfigPath: "testdata/config.yaml"},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
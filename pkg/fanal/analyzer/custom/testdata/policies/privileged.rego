@@ -0,0 +1,18 @@
+# METADATA
+# title: "Compose services should not be privileged"
+# description: "Privileged containers have access to all devices of the host."
+# scope: package
+# custom:
+#   id: CR001
+#   severity: HIGH
+#   input:
+#     selector:
+#     - type: json
+package user.compose.CR001
+
+deny[res] {
+	input.Type == "compose-service"
+	service := input.Data[_]
+	service.privileged == true
+	res := result.new(sprintf("Service '%s' is privileged", [service.image]), {})
+}
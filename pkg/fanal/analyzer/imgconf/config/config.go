@@ -0,0 +1,81 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/mapfs"
+	"github.com/zhanglimao/trivy/pkg/misconf"
+)
+
+const analyzerVersion = 1
+
+// configFile is the virtual file name of container image config passed to checks
+const configFile = "config.json"
+
+func init() {
+	analyzer.RegisterConfigAnalyzer(analyzer.TypeImageConfigCheck, newConfigAnalyzer)
+}
+
+// configAnalyzer evaluates container image config such as the user, environment variables,
+// exposed ports and entrypoint against the built-in checks and user policies.
+type configAnalyzer struct {
+	scanner *misconf.Scanner
+}
+
+func newConfigAnalyzer(opts analyzer.ConfigAnalyzerOptions) (analyzer.ConfigAnalyzer, error) {
+	s, err := misconf.NewImageConfigScanner(opts.MisconfScannerOption)
+	if err != nil {
+		return nil, xerrors.Errorf("misconfiguration scanner error: %w", err)
+	}
+	return &configAnalyzer{
+		scanner: s,
+	}, nil
+}
+
+func (a *configAnalyzer) Analyze(ctx context.Context, input analyzer.ConfigAnalysisInput) (*analyzer.
+	ConfigAnalysisResult, error) {
+	if input.Config == nil {
+		return nil, nil
+	}
+
+	// Policies can refer to the whole config, e.g. input.config.User and input.history
+	b, err := json.Marshal(input.Config)
+	if err != nil {
+		return nil, xerrors.Errorf("json marshal error: %w", err)
+	}
+
+	fsys := mapfs.New()
+	if err = fsys.WriteVirtualFile(configFile, b, 0600); err != nil {
+		return nil, xerrors.Errorf("mapfs write error: %w", err)
+	}
+
+	misconfs, err := a.scanner.Scan(ctx, fsys)
+	if err != nil {
+		return nil, xerrors.Errorf("image config scan error: %w", err)
+	}
+	// The result should be a single element as it passes one config.
+	if len(misconfs) != 1 {
+		return nil, nil
+	}
+
+	return &analyzer.ConfigAnalysisResult{
+		Misconfiguration: &misconfs[0],
+	}, nil
+}
+
+func (a *configAnalyzer) Required(_ types.OS) bool {
+	return true
+}
+
+func (a *configAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeImageConfigCheck
+}
+
+func (a *configAnalyzer) Version() int {
+	return analyzerVersion
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/misconf"
+)
+
+func Test_configAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name  string
+		input analyzer.ConfigAnalysisInput
+		want  *analyzer.ConfigAnalysisResult
+	}{
+		{
+			name: "insecure config",
+			input: analyzer.ConfigAnalysisInput{
+				Config: &v1.ConfigFile{
+					Config: v1.Config{
+						User: "root",
+						Env: []string{
+							"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+							"DB_PASSWORD=s3cr3t",
+							"POSTGRES_PASSWORD_FILE=/run/secrets/postgres-password",
+							"API_TOKEN=",
+						},
+						ExposedPorts: map[string]struct{}{
+							"22/tcp":   {},
+							"8080/tcp": {},
+						},
+						Entrypoint: []string{"/bin/sh", "-c", "sudo /usr/local/bin/server"},
+					},
+				},
+			},
+			want: &analyzer.ConfigAnalysisResult{
+				Misconfiguration: &types.Misconfiguration{
+					FileType: types.JSON,
+					FilePath: "config.json",
+					Failures: types.MisconfResults{
+						{
+							Namespace: "builtin.imageconfig.IC001",
+							Query:     "data.builtin.imageconfig.IC001.deny",
+							Message:   "Image user should not be 'root', but got 'root'",
+							PolicyMetadata: types.PolicyMetadata{
+								ID:                 "IC001",
+								Type:               "JSON Security Check",
+								Title:              "Image user should not be 'root'",
+								Description:        "Running containers with 'root' user can lead to a container escape situation. The user is 'root' when it is not set in the image config.",
+								Severity:           "HIGH",
+								RecommendedActions: "Set a non-root user with the 'USER' instruction",
+								References:         []string{"https://docs.docker.com/develop/develop-images/dockerfile_best-practices/#user"},
+							},
+							CauseMetadata: types.CauseMetadata{
+								Provider: "Json",
+								Service:  "general",
+							},
+						},
+						{
+							Namespace: "builtin.imageconfig.IC002",
+							Query:     "data.builtin.imageconfig.IC002.deny",
+							Message:   "Environment variable 'DB_PASSWORD' might contain a secret",
+							PolicyMetadata: types.PolicyMetadata{
+								ID:                 "IC002",
+								Type:               "JSON Security Check",
+								Title:              "Secrets should not be set in environment variables",
+								Description:        "Environment variables are stored in the image config and visible to anyone who can pull the image. Passwords, tokens and keys should be passed at runtime instead.",
+								Severity:           "CRITICAL",
+								RecommendedActions: "Pass secrets at runtime, e.g. with Docker secrets or a '*_FILE' variable pointing to a mounted file",
+								References:         []string{"https://docs.docker.com/engine/swarm/secrets/"},
+							},
+							CauseMetadata: types.CauseMetadata{
+								Provider: "Json",
+								Service:  "general",
+							},
+						},
+						{
+							Namespace: "builtin.imageconfig.IC003",
+							Query:     "data.builtin.imageconfig.IC003.deny",
+							Message:   "Port 22/tcp (SSH) should not be exposed",
+							PolicyMetadata: types.PolicyMetadata{
+								ID:                 "IC003",
+								Type:               "JSON Security Check",
+								Title:              "Ports of remote access services should not be exposed",
+								Description:        "Exposing ports of remote access services such as SSH and the Docker daemon allows attackers to access the container directly.",
+								Severity:           "MEDIUM",
+								RecommendedActions: "Remove the port from the 'EXPOSE' instruction",
+								References:         []string{"https://docs.docker.com/engine/reference/builder/#expose"},
+							},
+							CauseMetadata: types.CauseMetadata{
+								Provider: "Json",
+								Service:  "general",
+							},
+						},
+						{
+							Namespace: "builtin.imageconfig.IC004",
+							Query:     "data.builtin.imageconfig.IC004.deny",
+							Message:   "Entrypoint should not use 'sudo': /bin/sh -c sudo /usr/local/bin/server",
+							PolicyMetadata: types.PolicyMetadata{
+								ID:                 "IC004",
+								Type:               "JSON Security Check",
+								Title:              "Entrypoint should not use 'sudo'",
+								Description:        "Running the entrypoint with 'sudo' gives the process root privileges, which can lead to a container escape situation.",
+								Severity:           "HIGH",
+								RecommendedActions: "Remove 'sudo' from 'ENTRYPOINT' and 'CMD' and run the process as a non-root user",
+								References:         []string{"https://docs.docker.com/develop/develop-images/dockerfile_best-practices/#user"},
+							},
+							CauseMetadata: types.CauseMetadata{
+								Provider: "Json",
+								Service:  "general",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "no config",
+			input: analyzer.ConfigAnalysisInput{},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := newConfigAnalyzer(analyzer.ConfigAnalyzerOptions{
+				MisconfScannerOption: misconf.ScannerOption{},
+			})
+			require.NoError(t, err)
+			got, err := a.Analyze(context.Background(), tt.input)
+			require.NoError(t, err)
+			if got != nil && got.Misconfiguration != nil {
+				got.Misconfiguration.Successes = nil // Not compare successes in this test
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,10 +1,7 @@
 package dockerfile
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"strings"
 
 	"golang.org/x/xerrors"
 
@@ -15,7 +12,7 @@ import (
 	"github.com/zhanglimao/trivy/pkg/misconf"
 )
 
-const analyzerVersion = 1
+const analyzerVersion = 2
 
 func init() {
 	analyzer.RegisterConfigAnalyzer(analyzer.TypeHistoryDockerfile, newHistoryAnalyzer)
@@ -40,45 +37,10 @@ func (a *historyAnalyzer) Analyze(ctx context.Context, input analyzer.ConfigAnal
 	if input.Config == nil {
 		return nil, nil
 	}
-	dockerfile := new(bytes.Buffer)
-	baseLayerIndex := image.GuessBaseImageIndex(input.Config.History)
-	for i := baseLayerIndex + 1; i < len(input.Config.History); i++ {
-		h := input.Config.History[i]
-		var createdBy string
-		switch {
-		case strings.HasPrefix(h.CreatedBy, "/bin/sh -c #(nop)"):
-			// Instruction other than RUN
-			createdBy = strings.TrimPrefix(h.CreatedBy, "/bin/sh -c #(nop)")
-		case strings.HasPrefix(h.CreatedBy, "/bin/sh -c"):
-			// RUN instruction
-			createdBy = strings.ReplaceAll(h.CreatedBy, "/bin/sh -c", "RUN")
-		case strings.HasPrefix(h.CreatedBy, "USER"):
-			// USER instruction
-			createdBy = h.CreatedBy
-		case strings.HasPrefix(h.CreatedBy, "HEALTHCHECK"):
-			// HEALTHCHECK instruction
-			var interval, timeout, startPeriod, retries, command string
-			if input.Config.Config.Healthcheck.Interval != 0 {
-				interval = fmt.Sprintf("--interval=%s ", input.Config.Config.Healthcheck.Interval)
-			}
-			if input.Config.Config.Healthcheck.Timeout != 0 {
-				timeout = fmt.Sprintf("--timeout=%s ", input.Config.Config.Healthcheck.Timeout)
-			}
-			if input.Config.Config.Healthcheck.StartPeriod != 0 {
-				startPeriod = fmt.Sprintf("--startPeriod=%s ", input.Config.Config.Healthcheck.StartPeriod)
-			}
-			if input.Config.Config.Healthcheck.Retries != 0 {
-				retries = fmt.Sprintf("--retries=%d ", input.Config.Config.Healthcheck.Retries)
-			}
-			command = strings.Join(input.Config.Config.Healthcheck.Test, " ")
-			command = strings.ReplaceAll(command, "CMD-SHELL", "CMD")
-			createdBy = fmt.Sprintf("HEALTHCHECK %s%s%s%s%s", interval, timeout, startPeriod, retries, command)
-		}
-		dockerfile.WriteString(strings.TrimSpace(createdBy) + "\n")
-	}
+	dockerfile := image.ReconstructDockerfile(input.Config)
 
 	fsys := mapfs.New()
-	if err := fsys.WriteVirtualFile("Dockerfile", dockerfile.Bytes(), 0600); err != nil {
+	if err := fsys.WriteVirtualFile("Dockerfile", dockerfile, 0600); err != nil {
 		return nil, xerrors.Errorf("mapfs write error: %w", err)
 	}
 
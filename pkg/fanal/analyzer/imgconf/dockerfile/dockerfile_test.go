@@ -156,6 +156,81 @@ func Test_historyAnalyzer_Analyze(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "happy path. Built with BuildKit",
+			input: analyzer.ConfigAnalysisInput{
+				Config: &v1.ConfigFile{
+					Config: v1.Config{
+						User: "nobody",
+						Healthcheck: &v1.HealthConfig{
+							Test: []string{"CMD-SHELL", "curl --fail http://localhost:3000 || exit 1"},
+						},
+					},
+					History: []v1.History{
+						{
+							CreatedBy:  "/bin/sh -c #(nop) ADD file:e4d600fc4c9c293efe360be7b30ee96579925d1b4634c94332e2ec73f7d8eca1 in /",
+							EmptyLayer: false,
+						},
+						{
+							CreatedBy:  `/bin/sh -c #(nop)  CMD [\"/bin/sh\"]`,
+							EmptyLayer: true,
+						},
+						{
+							CreatedBy:  "COPY app /app # buildkit",
+							EmptyLayer: false,
+						},
+						{
+							CreatedBy:  "EXPOSE map[22/tcp:{}]",
+							EmptyLayer: true,
+						},
+						{
+							CreatedBy:  `HEALTHCHECK &{["CMD-SHELL" "curl --fail http://localhost:3000 || exit 1"] "0s" "0s" "0s" '\x00'}`,
+							EmptyLayer: true,
+						},
+					},
+				},
+			},
+			want: &analyzer.ConfigAnalysisResult{
+				Misconfiguration: &types.Misconfiguration{
+					FileType: "dockerfile",
+					FilePath: "Dockerfile",
+					Failures: types.MisconfResults{
+						types.MisconfResult{
+							Namespace: "builtin.dockerfile.DS004",
+							Query:     "data.builtin.dockerfile.DS004.deny",
+							Message:   "Port 22 should not be exposed in Dockerfile",
+							PolicyMetadata: types.PolicyMetadata{
+								ID:                 "DS004",
+								AVDID:              "AVD-DS-0004",
+								Type:               "Dockerfile Security Check",
+								Title:              "Port 22 exposed",
+								Description:        "Exposing port 22 might allow users to SSH into the container.",
+								Severity:           "MEDIUM",
+								RecommendedActions: "Remove 'EXPOSE 22' statement from the Dockerfile",
+							},
+							CauseMetadata: types.CauseMetadata{
+								Provider:  "Dockerfile",
+								Service:   "general",
+								StartLine: 2,
+								EndLine:   2,
+								Code: types.Code{
+									Lines: []types.Line{
+										{
+											Number:      2,
+											Content:     "EXPOSE 22/tcp",
+											IsCause:     true,
+											Highlighted: "\x1b[38;5;64mEXPOSE\x1b[0m\x1b[38;5;37m 22/tcp",
+											FirstCause:  true,
+											LastCause:   true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "nil config",
 			input: analyzer.ConfigAnalysisInput{
@@ -0,0 +1,63 @@
+package secret
+
+import (
+	"github.com/zhanglimao/trivy/pkg/fanal/secret"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+const (
+	categoryImageConfig = types.SecretRuleCategory("ImageConfig")
+
+	// sensitiveKey matches names of environment variables and build arguments holding credentials,
+	// e.g. DB_PASSWORD, NPM_TOKEN and AWS_SECRET_ACCESS_KEY.
+	sensitiveKey = `(?:[A-Za-z0-9_]*_)?(?i:PASSWORD|PASSWD|PASSPHRASE|SECRET|TOKEN|API_?KEY|ACCESS_?KEY|PRIVATE_?KEY|CREDENTIALS?|AUTH)(?:_[A-Za-z0-9]+)*`
+
+	// secretValue excludes references to other variables such as "$PASSWORD" and stops at shell operators
+	secretValue = "(?P<secret>[^\"\\s$\\\\;&|`'][^\"\\s\\\\;&|`']*)"
+)
+
+// imageConfigRules detect credentials in container image config, which are visible to anyone who can pull the image.
+// They are applied in addition to the rules for files as the config has credentials without well-known formats.
+var imageConfigRules = []secret.Rule{
+	{
+		// e.g. "Env": ["DB_PASSWORD=hunter2"]
+		ID:              "image-env-secret",
+		Category:        categoryImageConfig,
+		Title:           "Credential in environment variable",
+		Severity:        "HIGH",
+		Regex:           secret.MustCompile(`"` + sensitiveKey + `=` + secretValue + `"`),
+		SecretGroupName: "secret",
+		AllowRules:      imageConfigAllowRules,
+	},
+	{
+		// e.g. "created_by": "RUN |1 NPM_TOKEN=abc /bin/sh -c npm ci" for build arguments,
+		// and "ENV DB_PASSWORD=hunter2" and "export API_KEY=abc" in build commands
+		ID:              "image-history-secret",
+		Category:        categoryImageConfig,
+		Title:           "Credential in build argument or build command",
+		Severity:        "HIGH",
+		Regex:           secret.MustCompile(`\s` + sensitiveKey + `=` + secretValue),
+		Keywords:        []string{"created_by"},
+		SecretGroupName: "secret",
+		AllowRules:      imageConfigAllowRules,
+	},
+}
+
+// imageConfigAllowRules skip values which are not credentials. They are matched against the whole match, e.g. "TOKEN_FILE=/run/secrets/token".
+var imageConfigAllowRules = secret.AllowRules{
+	{
+		ID:          "path",
+		Description: "File paths, e.g. PASSWORD_FILE=/run/secrets/password",
+		Regex:       secret.MustCompile(`=/`),
+	},
+	{
+		ID:          "url-without-credentials",
+		Description: "URLs without user info, e.g. TOKEN_URL=https://auth.example.com/token",
+		Regex:       secret.MustCompile(`=https?://[^@"\s]*"?$`),
+	},
+	{
+		ID:          "flag",
+		Description: "Booleans and small numbers, e.g. AUTH_ENABLED=true and TOKEN_TTL=3600",
+		Regex:       secret.MustCompile(`(?i)=(true|false|yes|no|on|off|none|null|\d{1,6})"?$`),
+	},
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/samber/lo"
 	"golang.org/x/xerrors"
 
 	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
@@ -12,7 +13,7 @@ import (
 	"github.com/zhanglimao/trivy/pkg/log"
 )
 
-const analyzerVersion = 1
+const analyzerVersion = 2
 
 func init() {
 	analyzer.RegisterConfigAnalyzer(analyzer.TypeImageConfigSecret, newSecretAnalyzer)
@@ -28,7 +29,12 @@ func newSecretAnalyzer(opts analyzer.ConfigAnalyzerOptions) (analyzer.ConfigAnal
 	c, err := secret.ParseConfig(configPath)
 	if err != nil {
 		return nil, xerrors.Errorf("secret config error: %w", err)
+	} else if c == nil {
+		c = &secret.Config{}
 	}
+
+	// The rules for image config can be disabled by "disable-rules" in the config as well
+	c.CustomRules = append(c.CustomRules, imageConfigRules...)
 	scanner := secret.NewScanner(c)
 
 	return &secretAnalyzer{
@@ -51,6 +57,7 @@ func (a *secretAnalyzer) Analyze(_ context.Context, input analyzer.ConfigAnalysi
 		Content:  b,
 	})
 
+	result.Findings = dedupeFindings(result.Findings)
 	if len(result.Findings) == 0 {
 		log.Logger.Debug("No secrets found in container image config")
 		return nil, nil
@@ -72,3 +79,17 @@ func (a *secretAnalyzer) Type() analyzer.Type {
 func (a *secretAnalyzer) Version() int {
 	return analyzerVersion
 }
+
+// dedupeFindings removes findings of the image config rules on lines where other rules found secrets,
+// e.g. "GITHUB_TOKEN=ghp_..." is reported as a GitHub token only.
+func dedupeFindings(findings []types.SecretFinding) []types.SecretFinding {
+	lines := map[int]bool{}
+	for _, f := range findings {
+		if f.Category != categoryImageConfig {
+			lines[f.StartLine] = true
+		}
+	}
+	return lo.Filter(findings, func(f types.SecretFinding, _ int) bool {
+		return f.Category != categoryImageConfig || !lines[f.StartLine]
+	})
+}
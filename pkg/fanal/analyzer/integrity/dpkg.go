@@ -0,0 +1,39 @@
+package integrity
+
+import (
+	"bufio"
+	"io"
+	"path"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/digest"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// parseMD5Sums parses md5sums files of dpkg, which don't include configuration files.
+// e.g. var/lib/dpkg/info/curl.md5sums and var/lib/dpkg/info/libssl3:amd64.md5sums
+//
+//	0a2a0c1a1b1e8a5c1f8a6f2f7a3d8c5b  usr/bin/curl
+func parseMD5Sums(filePath string, r io.Reader) ([]types.PackageFile, error) {
+	pkgName, _, _ := strings.Cut(strings.TrimSuffix(path.Base(filePath), ".md5sums"), ":")
+
+	var files []types.PackageFile
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		sum, installed, ok := strings.Cut(scanner.Text(), "  ")
+		if !ok || sum == "" || installed == "" {
+			continue
+		}
+		files = append(files, types.PackageFile{
+			Path:    strings.TrimPrefix(installed, "/"),
+			PkgName: pkgName,
+			Digest:  digest.NewDigestFromString(digest.MD5, sum),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, xerrors.Errorf("scan error: %w", err)
+	}
+	return files, nil
+}
@@ -0,0 +1,134 @@
+package integrity
+
+import (
+	"context"
+	"crypto/md5" // nolint: gosec
+	"crypto/sha256"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/digest"
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&integrityAnalyzer{})
+}
+
+const version = 1
+
+var (
+	rpmDBFiles = []string{
+		"usr/lib/sysimage/rpm/Packages",
+		"var/lib/rpm/Packages",
+		"usr/lib/sysimage/rpm/Packages.db",
+		"var/lib/rpm/Packages.db",
+		"usr/lib/sysimage/rpm/rpmdb.sqlite",
+		"var/lib/rpm/rpmdb.sqlite",
+	}
+
+	// md5sums files of dpkg, and of distroless images
+	dpkgMD5SumsDirs = []string{
+		"var/lib/dpkg/info/",
+		"var/lib/dpkg/status.d/",
+	}
+
+	// systemDirs are where OS packages install files to be verified.
+	// Files under "etc" and "var" are not verified as they are expected to be changed.
+	systemDirs = []string{
+		"bin/",
+		"sbin/",
+		"lib/",
+		"lib32/",
+		"lib64/",
+		"libx32/",
+		"usr/",
+		"opt/",
+	}
+)
+
+// integrityAnalyzer records the digests of files in the package databases of rpm and dpkg,
+// and the digests of system files in the layer,
+// so that the applier can find files installed by OS packages and modified afterwards, like "rpm -V" and "dpkg --verify".
+type integrityAnalyzer struct{}
+
+func (a integrityAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	var files []types.PackageFile
+	var err error
+	switch {
+	case slices.Contains(rpmDBFiles, input.FilePath):
+		if files, err = parseRpmDB(input.Content); err != nil {
+			return nil, xerrors.Errorf("rpmdb parse error: %w", err)
+		}
+	case isMD5SumsFile(input.FilePath):
+		if files, err = parseMD5Sums(input.FilePath, input.Content); err != nil {
+			return nil, xerrors.Errorf("md5sums parse error: %w", err)
+		}
+	default:
+		digests, err := calcDigests(input.Content)
+		if err != nil {
+			return nil, xerrors.Errorf("digest error: %w", err)
+		}
+		return &analyzer.AnalysisResult{
+			FileDigests: map[string][]digest.Digest{
+				input.FilePath: digests,
+			},
+		}, nil
+	}
+
+	if len(files) == 0 {
+		return nil, nil
+	}
+	return &analyzer.AnalysisResult{
+		PackageFiles: []types.PackageFiles{
+			{
+				FilePath: input.FilePath,
+				Files:    files,
+			},
+		},
+	}, nil
+}
+
+// calcDigests calculates the digests in the algorithms used by rpm and dpkg at once
+func calcDigests(r io.Reader) ([]digest.Digest, error) {
+	md5Hash := md5.New() // nolint: gosec
+	sha256Hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(md5Hash, sha256Hash), r); err != nil {
+		return nil, xerrors.Errorf("read error: %w", err)
+	}
+	return []digest.Digest{
+		digest.NewDigest(digest.MD5, md5Hash),
+		digest.NewDigest(digest.SHA256, sha256Hash),
+	}, nil
+}
+
+func isMD5SumsFile(filePath string) bool {
+	if !strings.HasSuffix(filePath, ".md5sums") {
+		return false
+	}
+	return slices.ContainsFunc(dpkgMD5SumsDirs, func(dir string) bool {
+		return strings.HasPrefix(filePath, dir) && !strings.Contains(filePath[len(dir):], "/")
+	})
+}
+
+func (a integrityAnalyzer) Required(filePath string, fileInfo os.FileInfo) bool {
+	if slices.Contains(rpmDBFiles, filePath) || isMD5SumsFile(filePath) {
+		return true
+	}
+	return fileInfo.Mode().IsRegular() && slices.ContainsFunc(systemDirs, func(dir string) bool {
+		return strings.HasPrefix(filePath, dir)
+	})
+}
+
+func (a integrityAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeIntegrity
+}
+
+func (a integrityAnalyzer) Version() int {
+	return version
+}
@@ -0,0 +1,199 @@
+package integrity
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	rpmdb "github.com/knqyf263/go-rpmdb/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/digest"
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func Test_integrityAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		content  string
+		want     *analyzer.AnalysisResult
+	}{
+		{
+			name:     "md5sums",
+			filePath: "var/lib/dpkg/info/curl.md5sums",
+			content:  "d41d8cd98f00b204e9800998ecf8427e  usr/bin/curl\n",
+			want: &analyzer.AnalysisResult{
+				PackageFiles: []types.PackageFiles{
+					{
+						FilePath: "var/lib/dpkg/info/curl.md5sums",
+						Files: []types.PackageFile{
+							{
+								Path:    "usr/bin/curl",
+								PkgName: "curl",
+								Digest:  "md5:d41d8cd98f00b204e9800998ecf8427e",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "empty md5sums",
+			filePath: "var/lib/dpkg/info/base-files.md5sums",
+		},
+		{
+			name:     "system file",
+			filePath: "usr/bin/curl",
+			content:  "hello",
+			want: &analyzer.AnalysisResult{
+				FileDigests: map[string][]digest.Digest{
+					"usr/bin/curl": {
+						"md5:5d41402abc4b2a76b9719d911017c592",
+						"sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := integrityAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.filePath,
+				Content:  strings.NewReader(tt.content),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_integrityAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "rpmdb",
+			filePath: "var/lib/rpm/rpmdb.sqlite",
+			want:     true,
+		},
+		{
+			name:     "md5sums",
+			filePath: "var/lib/dpkg/info/libssl3:amd64.md5sums",
+			want:     true,
+		},
+		{
+			name:     "md5sums in distroless",
+			filePath: "var/lib/dpkg/status.d/libssl3.md5sums",
+			want:     true,
+		},
+		{
+			name:     "system file",
+			filePath: "usr/lib/x86_64-linux-gnu/libssl.so.3",
+			want:     true,
+		},
+		{
+			name:     "configuration file",
+			filePath: "etc/passwd",
+			want:     false,
+		},
+		{
+			name:     "md5sums in another dir",
+			filePath: "var/lib/dpkg/info/sub/curl.md5sums",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Use a regular file for file info
+			stat, err := os.Stat("integrity.go")
+			require.NoError(t, err)
+
+			a := integrityAnalyzer{}
+			got := a.Required(tt.filePath, stat)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseMD5Sums(t *testing.T) {
+	f, err := os.Open("testdata/curl.md5sums")
+	require.NoError(t, err)
+	defer f.Close()
+
+	got, err := parseMD5Sums("var/lib/dpkg/info/curl:amd64.md5sums", f)
+	require.NoError(t, err)
+
+	want := []types.PackageFile{
+		{
+			Path:    "usr/bin/curl",
+			PkgName: "curl",
+			Digest:  "md5:d41d8cd98f00b204e9800998ecf8427e",
+		},
+		{
+			Path:    "usr/share/doc/curl/copyright",
+			PkgName: "curl",
+			Digest:  "md5:5d41402abc4b2a76b9719d911017c592",
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func Test_rpmPackageFiles(t *testing.T) {
+	pkgs := []*rpmdb.PackageInfo{
+		{
+			Name:            "bash",
+			DigestAlgorithm: rpmdb.PGPHASHALGO_SHA256,
+			BaseNames:       []string{"bash", "bash.bashrc", "bin"},
+			DirIndexes:      []int32{0, 1, 2},
+			DirNames:        []string{"/usr/bin/", "/etc/", "/usr/"},
+			FileDigests: []string{
+				"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+				"486ea46224d1bb4fb680f34f7c9ad96a8f24ec88be73ea8e5a6c65260e9cb8a7",
+				"", // directory
+			},
+			FileFlags: []int32{0, rpmdb.RPMFILE_CONFIG, 0},
+		},
+		{
+			Name:            "legacy",
+			DigestAlgorithm: 0,
+			BaseNames:       []string{"legacy"},
+			DirIndexes:      []int32{0},
+			DirNames:        []string{"/usr/bin/"},
+			FileDigests:     []string{"5d41402abc4b2a76b9719d911017c592"},
+			FileFlags:       []int32{0},
+		},
+		{
+			Name:            "sha1",
+			DigestAlgorithm: rpmdb.PGPHASHALGO_SHA1,
+			BaseNames:       []string{"sha1"},
+			DirIndexes:      []int32{0},
+			DirNames:        []string{"/usr/bin/"},
+			FileDigests:     []string{"aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d"},
+			FileFlags:       []int32{0},
+		},
+	}
+
+	got, err := rpmPackageFiles(pkgs)
+	require.NoError(t, err)
+
+	want := []types.PackageFile{
+		{
+			Path:    "usr/bin/bash",
+			PkgName: "bash",
+			Digest:  "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+		{
+			Path:    "usr/bin/legacy",
+			PkgName: "legacy",
+			Digest:  "md5:5d41402abc4b2a76b9719d911017c592",
+		},
+	}
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,82 @@
+package integrity
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	rpmdb "github.com/knqyf263/go-rpmdb/pkg"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/digest"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// parseRpmDB returns the files of the packages in rpmdb
+func parseRpmDB(r io.Reader) ([]types.PackageFile, error) {
+	// rpmdb can be opened only as a file
+	tmpDir, err := os.MkdirTemp("", "rpm")
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	filePath := filepath.Join(tmpDir, "Packages")
+	f, err := os.Create(filePath)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create a package file: %w", err)
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return nil, xerrors.Errorf("failed to copy a package file: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return nil, xerrors.Errorf("failed to close a temp file: %w", err)
+	}
+
+	db, err := rpmdb.Open(filePath)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to open RPM DB: %w", err)
+	}
+
+	pkgs, err := db.ListPackages()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list packages: %w", err)
+	}
+	return rpmPackageFiles(pkgs)
+}
+
+// rpmPackageFiles returns the files with digests of the packages.
+// Configuration files and ghost files are skipped as they are expected to be changed, as well as "rpm -V" does.
+func rpmPackageFiles(pkgs []*rpmdb.PackageInfo) ([]types.PackageFile, error) {
+	var files []types.PackageFile
+	for _, pkg := range pkgs {
+		var algorithm digest.Algorithm
+		switch pkg.DigestAlgorithm {
+		case 0, rpmdb.PGPHASHALGO_MD5: // MD5 is used without the algorithm tag
+			algorithm = digest.MD5
+		case rpmdb.PGPHASHALGO_SHA256:
+			algorithm = digest.SHA256
+		default:
+			continue
+		}
+
+		installed, err := pkg.InstalledFiles()
+		if err != nil {
+			return nil, xerrors.Errorf("unable to get installed files of %s: %w", pkg.Name, err)
+		}
+		for _, file := range installed {
+			// Directories and symlinks have no digest
+			if file.Digest == "" || int32(file.Flags)&(rpmdb.RPMFILE_CONFIG|rpmdb.RPMFILE_GHOST) != 0 {
+				continue
+			}
+			files = append(files, types.PackageFile{
+				Path:    strings.TrimPrefix(file.Path, "/"),
+				PkgName: pkg.Name,
+				Digest:  digest.NewDigestFromString(algorithm, file.Digest),
+			})
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,34 @@
+package analyzer
+
+import (
+	"github.com/zhanglimao/trivy/pkg/fanal/log"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// failure returns the result holding the warning of the failed analyzer in the keep-going mode.
+// Otherwise, the error is only logged and the analysis of the other files goes on as before.
+// The analyzer type is empty when the file couldn't be opened, and the file path is empty for post-analyzers.
+func (ag AnalyzerGroup) failure(analyzerType Type, filePath string, err error) *AnalysisResult {
+	if !ag.keepGoing {
+		log.Logger.Debugf("Analysis error: %s", err)
+		return nil
+	}
+
+	switch {
+	case analyzerType == "":
+		log.Logger.Warnf("Skipping %s: %s", filePath, err)
+	case filePath == "":
+		log.Logger.Warnf("The %s analyzer failed: %s", analyzerType, err)
+	default:
+		log.Logger.Warnf("The %s analyzer failed on %s: %s", analyzerType, filePath, err)
+	}
+	return &AnalysisResult{
+		Warnings: []types.Warning{
+			{
+				Analyzer: string(analyzerType),
+				FilePath: filePath,
+				Message:  err.Error(),
+			},
+		},
+	}
+}
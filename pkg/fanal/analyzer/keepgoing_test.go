@@ -0,0 +1,123 @@
+package analyzer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	dio "github.com/aquasecurity/go-dep-parser/pkg/io"
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// brokenAnalyzer always fails
+type brokenAnalyzer struct{}
+
+func (a brokenAnalyzer) Analyze(_ context.Context, _ analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	return nil, xerrors.New("unexpected EOF")
+}
+
+func (a brokenAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return filePath == "package-lock.json"
+}
+
+func (a brokenAnalyzer) Type() analyzer.Type {
+	return "broken"
+}
+
+func (a brokenAnalyzer) Version() int {
+	return 1
+}
+
+func TestAnalyzerGroup_AnalyzeFile_KeepGoing(t *testing.T) {
+	tests := []struct {
+		name      string
+		keepGoing bool
+		noFile    bool
+		want      *analyzer.AnalysisResult
+		wantErr   string
+	}{
+		{
+			name:      "analysis error",
+			keepGoing: true,
+			want: &analyzer.AnalysisResult{
+				Warnings: []types.Warning{
+					{
+						Analyzer: "broken",
+						FilePath: "package-lock.json",
+						Message:  "unexpected EOF",
+					},
+				},
+			},
+		},
+		{
+			name:      "open error",
+			keepGoing: true,
+			noFile:    true,
+			want: &analyzer.AnalysisResult{
+				Warnings: []types.Warning{
+					{
+						FilePath: "package-lock.json",
+						Message:  "unable to open: open package-lock.json: no such file or directory",
+					},
+				},
+			},
+		},
+		{
+			name: "analysis error without keep-going",
+			want: &analyzer.AnalysisResult{},
+		},
+		{
+			name:    "open error without keep-going",
+			noFile:  true,
+			wantErr: "unable to open package-lock.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyzer.RegisterAnalyzer(brokenAnalyzer{})
+			defer analyzer.DeregisterAnalyzer("broken")
+
+			a, err := analyzer.NewAnalyzerGroup(analyzer.AnalyzerOptions{
+				KeepGoing: tt.keepGoing,
+			})
+			require.NoError(t, err)
+
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "package-lock.json"), []byte("{"), 0644))
+			info, err := os.Stat(filepath.Join(dir, "package-lock.json"))
+			require.NoError(t, err)
+
+			var wg sync.WaitGroup
+			scheduler := analyzer.NewScheduler(analyzer.SchedulerOption{MaxWorkers: 3})
+			defer scheduler.Close()
+
+			// Disable the other analyzers registered in this package
+			disabled := lo.Without(analyzer.RegisteredTypes(), "broken")
+
+			got := new(analyzer.AnalysisResult)
+			err = a.AnalyzeFile(context.Background(), &wg, scheduler, got, "", "package-lock.json", info,
+				func() (dio.ReadSeekCloserAt, error) {
+					if tt.noFile {
+						return os.Open("package-lock.json") // relative to the package directory
+					}
+					return os.Open(filepath.Join(dir, "package-lock.json"))
+				}, disabled, analyzer.AnalysisOptions{})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			wg.Wait()
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
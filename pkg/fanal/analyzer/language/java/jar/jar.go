@@ -36,7 +36,7 @@ var requiredExtensions = []string{
 // javaLibraryAnalyzer analyzes jar/war/ear/par files
 type javaLibraryAnalyzer struct {
 	once   sync.Once
-	client *javadb.DB
+	client jar.Client
 	slow   bool
 }
 
@@ -70,7 +70,18 @@ func (a *javaLibraryAnalyzer) PostAnalyze(ctx context.Context, input analyzer.Po
 	// It will be called on each JAR file
 	onFile := func(path string, info fs.FileInfo, r dio.ReadSeekerAt) (*types.Application, error) {
 		p := jar.NewParser(a.client, jar.WithSize(info.Size()), jar.WithFilePath(path))
-		return language.ParsePackage(types.Jar, path, r, p, input.Options.FileChecksum)
+		app, err := language.ParsePackage(types.Jar, path, r, p, input.Options.FileChecksum)
+		if err != nil {
+			return nil, err
+		}
+
+		// Fat JARs may hide libraries whose metadata is stripped by shading
+		shaded, err := detectShaded(path, r, info.Size())
+		if err != nil {
+			log.Logger.Debugf("Unable to detect shaded libraries in %s: %s", path, err)
+			return app, nil
+		}
+		return mergeShaded(app, path, shaded), nil
 	}
 
 	var apps []types.Application
@@ -0,0 +1,246 @@
+package jar
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+const (
+	// maxNestedDepth limits the recursion into nested archives, e.g. a JAR in a WAR in an EAR
+	maxNestedDepth = 5
+	// maxNestedSize limits the size of nested archives read into memory
+	maxNestedSize = 100 << 20
+)
+
+var versionPattern = regexp.MustCompile(`\d+\.\d+\.\d+(?:[.-][0-9A-Za-z]+)*`)
+
+// fingerprint identifies a library by its classes so that it can be detected
+// even when the classes are relocated by shading, e.g. "com/example/shaded/org/apache/logging/log4j/core".
+type fingerprint struct {
+	name   string // groupID:artifactID
+	pkg    string // the original package path
+	marker string // the class characteristic of the library, relative to the package
+
+	// The version sources in addition to pom.properties, which shading plugins often keep
+	versionClass string    // the class embedding the version string, relative to the package
+	versionProps [2]string // the properties file and the key of the version
+}
+
+var fingerprints = []fingerprint{
+	{
+		name:   "org.apache.logging.log4j:log4j-core",
+		pkg:    "org/apache/logging/log4j/core",
+		marker: "lookup/JndiLookup.class",
+	},
+	{
+		name:         "com.fasterxml.jackson.core:jackson-databind",
+		pkg:          "com/fasterxml/jackson/databind",
+		marker:       "ObjectMapper.class",
+		versionClass: "cfg/PackageVersion.class",
+	},
+	{
+		name:         "com.fasterxml.jackson.core:jackson-core",
+		pkg:          "com/fasterxml/jackson/core",
+		marker:       "JsonFactory.class",
+		versionClass: "json/PackageVersion.class",
+	},
+	{
+		name:   "org.yaml:snakeyaml",
+		pkg:    "org/yaml/snakeyaml",
+		marker: "Yaml.class",
+	},
+	{
+		name:   "org.apache.commons:commons-text",
+		pkg:    "org/apache/commons/text",
+		marker: "StringSubstitutor.class",
+	},
+	{
+		name:   "commons-collections:commons-collections",
+		pkg:    "org/apache/commons/collections",
+		marker: "functors/InvokerTransformer.class",
+	},
+	{
+		name:   "com.google.guava:guava",
+		pkg:    "com/google/common",
+		marker: "base/Preconditions.class",
+	},
+	{
+		name:         "io.netty:netty-common",
+		pkg:          "io/netty/util",
+		marker:       "Version.class",
+		versionProps: [2]string{"META-INF/io.netty.versions.properties", "netty-common.version"},
+	},
+}
+
+// detectShaded detects libraries whose classes are embedded into the archive with or without relocation.
+// Nested archives such as "BOOT-INF/lib/*.jar" and "WEB-INF/lib/*.jar" are inspected recursively.
+func detectShaded(filePath string, r io.ReaderAt, size int64) ([]types.Package, error) {
+	return detectShadedRecursive(filePath, r, size, 0)
+}
+
+func detectShadedRecursive(filePath string, r io.ReaderAt, size int64, depth int) ([]types.Package, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, xerrors.Errorf("zip error: %w", err)
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var pkgs []types.Package
+	for _, f := range zr.File {
+		if isNestedArchive(f.Name) {
+			nested, err := detectNested(path.Join(filePath, f.Name), f, depth)
+			if err != nil {
+				log.Logger.Debugf("Unable to inspect %s in %s: %s", f.Name, filePath, err)
+				continue
+			}
+			pkgs = append(pkgs, nested...)
+			continue
+		}
+
+		for _, fp := range fingerprints {
+			suffix := path.Join(fp.pkg, fp.marker)
+			if !strings.HasSuffix(f.Name, suffix) {
+				continue
+			}
+			prefix := strings.TrimSuffix(f.Name, suffix)
+			if prefix == "" {
+				// Not shaded. It is detected by the parser.
+				continue
+			}
+
+			ver := fp.version(files, prefix)
+			if ver == "" {
+				log.Logger.Warnf("%s is embedded in %s under %q, but its version is unknown", fp.name, filePath, prefix)
+				continue
+			}
+			log.Logger.Debugf("Shaded library found in %s: %s@%s under %q", filePath, fp.name, ver, prefix)
+			pkgs = append(pkgs, types.Package{
+				Name:     fp.name,
+				Version:  ver,
+				FilePath: filePath,
+			})
+		}
+	}
+	return pkgs, nil
+}
+
+func detectNested(filePath string, f *zip.File, depth int) ([]types.Package, error) {
+	if depth >= maxNestedDepth {
+		return nil, xerrors.Errorf("too deeply nested")
+	} else if f.UncompressedSize64 > maxNestedSize {
+		return nil, xerrors.Errorf("too large archive: %d bytes", f.UncompressedSize64)
+	}
+
+	b, err := readFile(f)
+	if err != nil {
+		return nil, err
+	}
+	return detectShadedRecursive(filePath, bytes.NewReader(b), int64(len(b)), depth+1)
+}
+
+// version returns the version of the embedded library, or empty if not found
+func (fp fingerprint) version(files map[string]*zip.File, prefix string) string {
+	groupID, artifactID, _ := strings.Cut(fp.name, ":")
+	if f, ok := files[path.Join("META-INF/maven", groupID, artifactID, "pom.properties")]; ok {
+		if v := readProperty(f, "version"); v != "" {
+			return v
+		}
+	}
+
+	if fp.versionClass != "" {
+		if f, ok := files[prefix+path.Join(fp.pkg, fp.versionClass)]; ok {
+			if b, err := readFile(f); err == nil {
+				return string(versionPattern.Find(b))
+			}
+		}
+	}
+
+	if fp.versionProps[0] != "" {
+		if f, ok := files[fp.versionProps[0]]; ok {
+			return readProperty(f, fp.versionProps[1])
+		}
+	}
+	return ""
+}
+
+func readProperty(f *zip.File, key string) string {
+	rc, err := f.Open()
+	if err != nil {
+		return ""
+	}
+	defer rc.Close()
+
+	scanner := bufio.NewScanner(rc)
+	for scanner.Scan() {
+		k, v, ok := strings.Cut(scanner.Text(), "=")
+		if ok && strings.TrimSpace(k) == key {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+func readFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, xerrors.Errorf("unable to open %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to read %s: %w", f.Name, err)
+	}
+	return b, nil
+}
+
+func isNestedArchive(name string) bool {
+	ext := path.Ext(name)
+	for _, required := range requiredExtensions {
+		if strings.EqualFold(ext, required) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeShaded appends the shaded libraries not detected by the parser
+func mergeShaded(app *types.Application, filePath string, shaded []types.Package) *types.Application {
+	if len(shaded) == 0 {
+		return app
+	}
+	if app == nil {
+		app = &types.Application{
+			Type:     types.Jar,
+			FilePath: filePath,
+		}
+	}
+
+	found := make(map[string]struct{})
+	for _, lib := range app.Libraries {
+		found[lib.Name+"@"+lib.Version] = struct{}{}
+	}
+	for _, pkg := range shaded {
+		key := pkg.Name + "@" + pkg.Version
+		if _, ok := found[key]; ok {
+			continue
+		}
+		found[key] = struct{}{}
+		app.Libraries = append(app.Libraries, pkg)
+	}
+	return app
+}
@@ -0,0 +1,143 @@
+package jar
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func Test_detectShaded(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string][]byte
+		want  []types.Package
+	}{
+		{
+			name: "relocated with pom.properties",
+			files: map[string][]byte{
+				"com/example/shaded/org/apache/logging/log4j/core/lookup/JndiLookup.class": nil,
+				"META-INF/maven/org.apache.logging.log4j/log4j-core/pom.properties":        []byte("groupId=org.apache.logging.log4j\nversion=2.14.1\n"),
+			},
+			want: []types.Package{
+				{
+					Name:     "org.apache.logging.log4j:log4j-core",
+					Version:  "2.14.1",
+					FilePath: "app.jar",
+				},
+			},
+		},
+		{
+			name: "relocated with the version class",
+			files: map[string][]byte{
+				"shaded/com/fasterxml/jackson/databind/ObjectMapper.class":           nil,
+				"shaded/com/fasterxml/jackson/databind/cfg/PackageVersion.class":     []byte("\xca\xfe\xba\xbe\x00\x062.9.10\x01\x00"),
+				"shaded/com/fasterxml/jackson/databind/cfg/MapperConfig.class":       nil,
+				"shaded/io/netty/util/Version.class":                                 nil,
+				"META-INF/io.netty.versions.properties":                              []byte("netty-common.version=4.1.68.Final\n"),
+				"com/example/Main.class":                                             nil,
+				"org/yaml/snakeyaml/Yaml.class":                                      nil, // not shaded
+				"com/example/shaded/org/apache/commons/text/StringSubstitutor.class": nil, // unknown version
+			},
+			want: []types.Package{
+				{
+					Name:     "io.netty:netty-common",
+					Version:  "4.1.68.Final",
+					FilePath: "app.jar",
+				},
+				{
+					Name:     "com.fasterxml.jackson.core:jackson-databind",
+					Version:  "2.9.10",
+					FilePath: "app.jar",
+				},
+			},
+		},
+		{
+			name: "nested archive",
+			files: map[string][]byte{
+				"BOOT-INF/lib/lib.jar": zipFile(t, map[string][]byte{
+					"lib/shaded/com/google/common/base/Preconditions.class": nil,
+					"META-INF/maven/com.google.guava/guava/pom.properties":  []byte("version=29.0-jre\n"),
+				}),
+			},
+			want: []types.Package{
+				{
+					Name:     "com.google.guava:guava",
+					Version:  "29.0-jre",
+					FilePath: "app.jar/BOOT-INF/lib/lib.jar",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := zipFile(t, tt.files)
+			got, err := detectShaded("app.jar", bytes.NewReader(b), int64(len(b)))
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func Test_mergeShaded(t *testing.T) {
+	app := &types.Application{
+		Type:     types.Jar,
+		FilePath: "app.jar",
+		Libraries: []types.Package{
+			{
+				Name:     "org.apache.logging.log4j:log4j-core",
+				Version:  "2.14.1",
+				FilePath: "app.jar",
+			},
+		},
+	}
+	shaded := []types.Package{
+		{
+			Name:     "org.apache.logging.log4j:log4j-core",
+			Version:  "2.14.1",
+			FilePath: "app.jar",
+		},
+		{
+			Name:     "com.google.guava:guava",
+			Version:  "29.0-jre",
+			FilePath: "app.jar",
+		},
+	}
+	got := mergeShaded(app, "app.jar", shaded)
+	assert.Equal(t, []types.Package{
+		{
+			Name:     "org.apache.logging.log4j:log4j-core",
+			Version:  "2.14.1",
+			FilePath: "app.jar",
+		},
+		{
+			Name:     "com.google.guava:guava",
+			Version:  "29.0-jre",
+			FilePath: "app.jar",
+		},
+	}, got.Libraries)
+
+	got = mergeShaded(nil, "app.jar", shaded[1:])
+	assert.Equal(t, &types.Application{
+		Type:      types.Jar,
+		FilePath:  "app.jar",
+		Libraries: shaded[1:],
+	}, got)
+}
+
+func zipFile(t *testing.T, files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,84 @@
+package jdk
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&jdkAnalyzer{})
+}
+
+const (
+	version = 1
+
+	// The JDK/JRE home has the release file, e.g. "/opt/java/openjdk/release"
+	releaseFile = "release"
+
+	// pkgName is used for all the distributions such as Temurin, Corretto and Zulu,
+	// since they are built from OpenJDK and share its advisories.
+	pkgName = "openjdk"
+)
+
+// jdkAnalyzer detects the JDK/JRE installed without OS package managers
+type jdkAnalyzer struct{}
+
+func (a jdkAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	props := make(map[string]string)
+	scanner := bufio.NewScanner(input.Content)
+	for scanner.Scan() {
+		k, v, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		props[strings.TrimSpace(k)] = strings.Trim(strings.TrimSpace(v), `"`)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, xerrors.Errorf("%s scan error: %w", input.FilePath, err)
+	}
+
+	// e.g. JAVA_VERSION="17.0.5" or JAVA_VERSION="1.8.0_352"
+	ver := props["JAVA_VERSION"]
+	if ver == "" {
+		// Not the release file of JDK
+		return nil, nil
+	}
+
+	return &analyzer.AnalysisResult{
+		Applications: []types.Application{
+			{
+				Type:     types.JDK,
+				FilePath: input.FilePath,
+				Libraries: []types.Package{
+					{
+						ID:       fmt.Sprintf("%s@%s", pkgName, ver),
+						Name:     pkgName,
+						Version:  ver,
+						FilePath: input.FilePath,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (a jdkAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return path.Base(filePath) == releaseFile
+}
+
+func (a jdkAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeJDK
+}
+
+func (a jdkAnalyzer) Version() int {
+	return version
+}
@@ -0,0 +1,111 @@
+package jdk
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func Test_jdkAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		filePath  string
+		inputFile string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name:      "JRE 17",
+			filePath:  "opt/java/openjdk/release",
+			inputFile: "testdata/release",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.JDK,
+						FilePath: "opt/java/openjdk/release",
+						Libraries: []types.Package{
+							{
+								ID:       "openjdk@17.0.5",
+								Name:     "openjdk",
+								Version:  "17.0.5",
+								FilePath: "opt/java/openjdk/release",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "JDK 8",
+			filePath:  "usr/local/openjdk-8/release",
+			inputFile: "testdata/release-8",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.JDK,
+						FilePath: "usr/local/openjdk-8/release",
+						Libraries: []types.Package{
+							{
+								ID:       "openjdk@1.8.0_352",
+								Name:     "openjdk",
+								Version:  "1.8.0_352",
+								FilePath: "usr/local/openjdk-8/release",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "not JDK",
+			filePath:  "opt/acme/release",
+			inputFile: "testdata/release-unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			a := jdkAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.filePath,
+				Content:  f,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_jdkAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{
+			filePath: "opt/java/openjdk/release",
+			want:     true,
+		},
+		{
+			filePath: "usr/lib/jvm/java-17-openjdk-amd64/release",
+			want:     true,
+		},
+		{
+			filePath: "opt/java/openjdk/bin/java",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			a := jdkAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
@@ -1,12 +1,15 @@
 package pom
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 
 	"golang.org/x/xerrors"
 
+	dio "github.com/aquasecurity/go-dep-parser/pkg/io"
 	"github.com/aquasecurity/go-dep-parser/pkg/java/pom"
 
 	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
@@ -18,14 +21,24 @@ func init() {
 	analyzer.RegisterAnalyzer(&pomAnalyzer{})
 }
 
-const version = 1
+const version = 2
 
 // pomAnalyzer analyzes pom.xml
 type pomAnalyzer struct{}
 
 func (a pomAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	var content dio.ReadSeekerAt = input.Content
+	if !input.Options.Offline {
+		// Resolve version ranges that the parser doesn't support
+		b, err := io.ReadAll(input.Content)
+		if err != nil {
+			return nil, xerrors.Errorf("%s read error: %w", input.FilePath, err)
+		}
+		content = bytes.NewReader(newVersionRangeResolver().Resolve(b))
+	}
+
 	p := pom.NewParser(filepath.Join(input.Dir, input.FilePath), pom.WithOffline(input.Options.Offline))
-	res, err := language.Analyze(types.Pom, input.FilePath, input.Content, p)
+	res, err := language.Analyze(types.Pom, input.FilePath, content, p)
 	if err != nil {
 		return nil, xerrors.Errorf("%s parse error: %w", input.FilePath, err)
 	}
@@ -0,0 +1,206 @@
+package pom
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	mvnversion "github.com/masahiro331/go-mvn-version"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+const centralURL = "https://repo.maven.apache.org/maven2/"
+
+var (
+	blockRegexp    = regexp.MustCompile(`(?s)<dependency>.*?</dependency>|<parent>.*?</parent>`)
+	groupIDRegexp  = regexp.MustCompile(`<groupId>\s*(.*?)\s*</groupId>`)
+	artifactRegexp = regexp.MustCompile(`<artifactId>\s*(.*?)\s*</artifactId>`)
+	versionRegexp  = regexp.MustCompile(`<version>\s*(.*?)\s*</version>`)
+	propertyRegexp = regexp.MustCompile(`^\$\{(\S+?)}$`)
+)
+
+// versionRangeResolver replaces version ranges in pom.xml, e.g. [1.0,2.0), with the highest matching version
+// listed in maven-metadata.xml of remote repositories, as Maven does.
+// The POM parser doesn't support version ranges and drops such dependencies otherwise.
+type versionRangeResolver struct {
+	client   *http.Client
+	repos    []string
+	versions map[string][]string // groupID:artifactID => available versions
+}
+
+func newVersionRangeResolver(repos ...string) *versionRangeResolver {
+	if len(repos) == 0 {
+		repos = []string{centralURL}
+	}
+	return &versionRangeResolver{
+		client:   &http.Client{Timeout: 10 * time.Second},
+		repos:    repos,
+		versions: map[string][]string{},
+	}
+}
+
+// Resolve returns the content with version ranges of dependencies and the parent replaced.
+// Ranges that cannot be resolved are left as is.
+func (r *versionRangeResolver) Resolve(content []byte) []byte {
+	p, err := parseRangePOM(content)
+	if err != nil {
+		// The parser will report the error
+		return content
+	}
+	r.repos = append(r.repos, p.Repositories...)
+
+	return blockRegexp.ReplaceAllFunc(content, func(block []byte) []byte {
+		groupID, artifactID, ver := submatch(groupIDRegexp, block), submatch(artifactRegexp, block), submatch(versionRegexp, block)
+		if m := propertyRegexp.FindStringSubmatch(ver); len(m) == 2 {
+			ver = p.Properties[m[1]]
+		}
+		if groupID == "" || artifactID == "" || !isVersionRange(ver) {
+			return block
+		}
+
+		resolved, err := r.resolve(groupID, artifactID, ver)
+		if err != nil {
+			log.Logger.Debugf("Unable to resolve the version range of %s:%s (%s): %s", groupID, artifactID, ver, err)
+			return block
+		}
+		log.Logger.Debugf("The version range of %s:%s (%s) resolved to %s", groupID, artifactID, ver, resolved)
+		return versionRegexp.ReplaceAll(block, []byte(fmt.Sprintf("<version>%s</version>", resolved)))
+	})
+}
+
+func (r *versionRangeResolver) resolve(groupID, artifactID, versionRange string) (string, error) {
+	requirements, err := mvnversion.NewRequirements(versionRange)
+	if err != nil {
+		return "", xerrors.Errorf("version range error: %w", err)
+	}
+
+	versions, err := r.availableVersions(groupID, artifactID)
+	if err != nil {
+		return "", xerrors.Errorf("unable to get available versions: %w", err)
+	}
+
+	var latest *mvnversion.Version
+	var resolved string
+	for _, ver := range versions {
+		if strings.HasSuffix(ver, "-SNAPSHOT") {
+			continue
+		}
+		v, err := mvnversion.NewVersion(ver)
+		if err != nil || !requirements.Check(v) {
+			continue
+		}
+		if latest == nil || v.GreaterThan(*latest) {
+			latest, resolved = &v, ver
+		}
+	}
+	if latest == nil {
+		return "", xerrors.New("no matching version")
+	}
+	return resolved, nil
+}
+
+func (r *versionRangeResolver) availableVersions(groupID, artifactID string) ([]string, error) {
+	name := groupID + ":" + artifactID
+	if versions, ok := r.versions[name]; ok {
+		return versions, nil
+	}
+
+	var versions []string
+	for _, repo := range r.repos {
+		vers, err := r.fetchMetadata(repo, groupID, artifactID)
+		if err != nil {
+			log.Logger.Debugf("Unable to fetch maven-metadata.xml of %s from %s: %s", name, repo, err)
+			continue
+		}
+		versions = append(versions, vers...)
+	}
+	if len(versions) == 0 {
+		return nil, xerrors.Errorf("maven-metadata.xml of %s not found", name)
+	}
+
+	r.versions[name] = versions
+	return versions, nil
+}
+
+func (r *versionRangeResolver) fetchMetadata(repo, groupID, artifactID string) ([]string, error) {
+	u, err := url.Parse(repo)
+	if err != nil {
+		return nil, xerrors.Errorf("url parse error: %w", err)
+	}
+	u.Path = path.Join(u.Path, strings.ReplaceAll(groupID, ".", "/"), artifactID, "maven-metadata.xml")
+
+	resp, err := r.client.Get(u.String())
+	if err != nil {
+		return nil, xerrors.Errorf("http error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, xerrors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var metadata struct {
+		Versions []string `xml:"versioning>versions>version"`
+	}
+	if err = xml.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return nil, xerrors.Errorf("xml decode error: %w", err)
+	}
+	return metadata.Versions, nil
+}
+
+// rangePOM holds the fields of pom.xml needed to resolve version ranges.
+type rangePOM struct {
+	Properties   properties `xml:"properties"`
+	Repositories []string   `xml:"repositories>repository>url"`
+}
+
+type properties map[string]string
+
+func (props *properties) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
+	*props = properties{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err = d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			(*props)[t.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+func parseRangePOM(content []byte) (rangePOM, error) {
+	var p rangePOM
+	if err := xml.Unmarshal(content, &p); err != nil {
+		return rangePOM{}, xerrors.Errorf("xml decode error: %w", err)
+	}
+	return p, nil
+}
+
+// isVersionRange reports whether the version is a range such as [1.0,2.0) or (,1.0],[1.2,).
+// Hard requirements like [1.0] are supported by the parser.
+func isVersionRange(ver string) bool {
+	return (strings.HasPrefix(ver, "[") || strings.HasPrefix(ver, "(")) && strings.Contains(ver, ",")
+}
+
+func submatch(re *regexp.Regexp, b []byte) string {
+	m := re.FindSubmatch(b)
+	if len(m) != 2 {
+		return ""
+	}
+	return string(m[1])
+}
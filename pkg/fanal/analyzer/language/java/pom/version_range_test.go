@@ -0,0 +1,92 @@
+package pom
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_versionRangeResolver_Resolve(t *testing.T) {
+	metadata := map[string][]string{
+		"/maven2/org/example/example-parent/maven-metadata.xml": {"1.0", "1.5", "2.0"},
+		"/maven2/org/example/example-api/maven-metadata.xml":    {"0.9", "1.0", "1.1"},
+		"/maven2/org/example/example-core/maven-metadata.xml":   {"1.1", "1.2", "1.2.1", "1.3-SNAPSHOT"},
+		"/maven2/org/example/example-hard/maven-metadata.xml":   {"1.0", "1.1"},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		versions, ok := metadata[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, "<metadata><versioning><versions>")
+		for _, v := range versions {
+			_, _ = fmt.Fprintf(w, "<version>%s</version>", v)
+		}
+		_, _ = fmt.Fprint(w, "</versions></versioning></metadata>")
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name         string
+		inputFile    string
+		replacements map[string]string
+	}{
+		{
+			name:      "version ranges",
+			inputFile: "testdata/range/pom.xml",
+			replacements: map[string]string{
+				"<version>[1.0,2.0)</version>":      "<version>1.5</version>",
+				"<version>${api.version}</version>": "<version>1.0</version>",
+				"<version>[1.2,)</version>":         "<version>1.2.1</version>",
+			},
+		},
+		{
+			name:      "no range",
+			inputFile: "testdata/happy/pom.xml",
+		},
+		{
+			name:      "broken",
+			inputFile: "testdata/broken/pom.xml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := os.ReadFile(tt.inputFile)
+			require.NoError(t, err)
+
+			want := string(content)
+			for old, n := range tt.replacements {
+				want = strings.Replace(want, old, n, 1)
+			}
+
+			r := newVersionRangeResolver(ts.URL + "/maven2")
+			got := r.Resolve(content)
+			assert.Equal(t, want, string(got))
+		})
+	}
+}
+
+func Test_isVersionRange(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "[1.0,2.0)", want: true},
+		{version: "(,1.0],[1.2,)", want: true},
+		{version: "[1.0]", want: false},
+		{version: "1.0", want: false},
+		{version: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, isVersionRange(tt.version))
+		})
+	}
+}
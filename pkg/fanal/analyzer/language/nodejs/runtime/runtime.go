@@ -0,0 +1,62 @@
+package runtime
+
+import (
+	"context"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer/language"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&nodeRuntimeAnalyzer{})
+}
+
+const (
+	version = 1
+	pkgName = "node"
+
+	// The header installed with Node.js, e.g. "/usr/local/include/node/node_version.h"
+	versionHeader = "include/node/node_version.h"
+)
+
+var defineRegex = regexp.MustCompile(`(?m)^#define NODE_(MAJOR|MINOR|PATCH)_VERSION (\d+)`)
+
+// nodeRuntimeAnalyzer detects the version of Node.js
+type nodeRuntimeAnalyzer struct{}
+
+func (a nodeRuntimeAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	b, err := io.ReadAll(input.Content)
+	if err != nil {
+		return nil, xerrors.Errorf("%s read error: %w", input.FilePath, err)
+	}
+
+	parts := make(map[string]string)
+	for _, m := range defineRegex.FindAllSubmatch(b, -1) {
+		parts[string(m[1])] = string(m[2])
+	}
+	if parts["MAJOR"] == "" || parts["MINOR"] == "" || parts["PATCH"] == "" {
+		return nil, nil
+	}
+	ver := strings.Join([]string{parts["MAJOR"], parts["MINOR"], parts["PATCH"]}, ".")
+
+	return language.AnalyzeRuntime(types.NodeRuntime, input.FilePath, pkgName, ver), nil
+}
+
+func (a nodeRuntimeAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return strings.HasSuffix(filePath, versionHeader)
+}
+
+func (a nodeRuntimeAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeNodeRuntime
+}
+
+func (a nodeRuntimeAnalyzer) Version() int {
+	return version
+}
@@ -0,0 +1,86 @@
+package runtime
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func Test_nodeRuntimeAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		filePath  string
+		inputFile string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name:      "happy path",
+			filePath:  "usr/local/include/node/node_version.h",
+			inputFile: "testdata/node_version.h",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.NodeRuntime,
+						FilePath: "usr/local/include/node/node_version.h",
+						Libraries: []types.Package{
+							{
+								ID:       "node@18.16.0",
+								Name:     "node",
+								Version:  "18.16.0",
+								FilePath: "usr/local/include/node/node_version.h",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "no version",
+			filePath:  "usr/local/include/node/node_version.h",
+			inputFile: "testdata/broken.h",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			a := nodeRuntimeAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.filePath,
+				Content:  f,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_nodeRuntimeAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{
+			filePath: "usr/local/include/node/node_version.h",
+			want:     true,
+		},
+		{
+			filePath: "usr/local/include/node/v8-version.h",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			a := nodeRuntimeAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
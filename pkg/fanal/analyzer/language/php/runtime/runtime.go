@@ -0,0 +1,57 @@
+package runtime
+
+import (
+	"context"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer/language"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&phpRuntimeAnalyzer{})
+}
+
+const (
+	version = 1
+	pkgName = "php"
+
+	// The header installed with PHP, e.g. "/usr/local/include/php/main/php_version.h"
+	versionHeader = "include/php/main/php_version.h"
+)
+
+var versionRegex = regexp.MustCompile(`(?m)^#define PHP_VERSION\s+"([^"]+)"`)
+
+// phpRuntimeAnalyzer detects the version of PHP
+type phpRuntimeAnalyzer struct{}
+
+func (a phpRuntimeAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	b, err := io.ReadAll(input.Content)
+	if err != nil {
+		return nil, xerrors.Errorf("%s read error: %w", input.FilePath, err)
+	}
+
+	m := versionRegex.FindSubmatch(b)
+	if m == nil {
+		return nil, nil
+	}
+	return language.AnalyzeRuntime(types.PHPRuntime, input.FilePath, pkgName, string(m[1])), nil
+}
+
+func (a phpRuntimeAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return strings.HasSuffix(filePath, versionHeader)
+}
+
+func (a phpRuntimeAnalyzer) Type() analyzer.Type {
+	return analyzer.TypePHPRuntime
+}
+
+func (a phpRuntimeAnalyzer) Version() int {
+	return version
+}
@@ -0,0 +1,86 @@
+package runtime
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func Test_phpRuntimeAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		filePath  string
+		inputFile string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name:      "happy path",
+			filePath:  "usr/local/include/php/main/php_version.h",
+			inputFile: "testdata/php_version.h",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.PHPRuntime,
+						FilePath: "usr/local/include/php/main/php_version.h",
+						Libraries: []types.Package{
+							{
+								ID:       "php@8.2.7",
+								Name:     "php",
+								Version:  "8.2.7",
+								FilePath: "usr/local/include/php/main/php_version.h",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "no version",
+			filePath:  "usr/local/include/php/main/php_version.h",
+			inputFile: "testdata/php_version-unknown.h",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			a := phpRuntimeAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.filePath,
+				Content:  f,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_phpRuntimeAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{
+			filePath: "usr/local/include/php/main/php_version.h",
+			want:     true,
+		},
+		{
+			filePath: "usr/local/include/php/main/php.h",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			a := phpRuntimeAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
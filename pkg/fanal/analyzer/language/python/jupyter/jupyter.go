@@ -0,0 +1,190 @@
+package jupyter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/go-dep-parser/pkg/python/pip"
+	godeptypes "github.com/aquasecurity/go-dep-parser/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&jupyterAnalyzer{})
+}
+
+const (
+	version = 1
+
+	notebookExt    = ".ipynb"
+	checkpointsDir = ".ipynb_checkpoints"
+)
+
+var (
+	// e.g. "!pip install requests==2.28.0", "%pip install -q torch==2.0.1" and "!{sys.executable} -m pip install numpy==1.24.0"
+	pipInstallPattern = regexp.MustCompile(`^[!%]\s*(?:(?:\S*python[\d.]*|\{sys\.executable\})\s+-m\s+)?pip[\d.]*\s+install\s+(.+)$`)
+
+	// pipOptionsWithValue are the options of "pip install" taking a value, which is not a requirement
+	pipOptionsWithValue = []string{
+		"-r", "--requirement", "-c", "--constraint", "-e", "--editable", "-i", "--index-url", "--extra-index-url",
+		"-f", "--find-links", "-t", "--target", "--prefix", "--root", "--trusted-host", "--platform",
+		"--python-version", "--implementation", "--abi", "--src", "--upgrade-strategy", "--progress-bar",
+	}
+)
+
+// notebook represents the Jupyter notebook format version 4, and 3 for old notebooks.
+// ref. https://nbformat.readthedocs.io/en/latest/format_description.html
+type notebook struct {
+	Cells      []cell `json:"cells"`
+	Worksheets []struct {
+		Cells []cell `json:"cells"`
+	} `json:"worksheets"`
+}
+
+type cell struct {
+	CellType string `json:"cell_type"`
+	Source   source `json:"source"`
+	Input    source `json:"input"` // nbformat 3
+}
+
+// source is a multi-line string represented as a string or a list of strings
+type source string
+
+func (s *source) UnmarshalJSON(b []byte) error {
+	var lines []string
+	if err := json.Unmarshal(b, &lines); err == nil {
+		*s = source(strings.Join(lines, ""))
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	*s = source(str)
+	return nil
+}
+
+// jupyterAnalyzer detects Python packages installed by "pip install" in notebooks
+type jupyterAnalyzer struct{}
+
+func (a jupyterAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	var nb notebook
+	if err := json.NewDecoder(input.Content).Decode(&nb); err != nil {
+		log.Logger.Debugf("Unable to parse %s as a notebook: %s", input.FilePath, err)
+		return nil, nil
+	}
+
+	cells := nb.Cells
+	for _, ws := range nb.Worksheets {
+		cells = append(cells, ws.Cells...)
+	}
+
+	// Requirements are passed to the requirements.txt parser, one per line
+	var requirements bytes.Buffer
+	for _, c := range cells {
+		if c.CellType != "code" {
+			continue
+		}
+		code := c.Source
+		if code == "" {
+			code = c.Input
+		}
+		for _, req := range pipRequirements(string(code)) {
+			requirements.WriteString(req + "\n")
+		}
+	}
+	if requirements.Len() == 0 {
+		return nil, nil
+	}
+
+	libs, _, err := pip.NewParser().Parse(bytes.NewReader(requirements.Bytes()))
+	if err != nil {
+		return nil, xerrors.Errorf("unable to parse requirements in %s: %w", input.FilePath, err)
+	}
+
+	var pkgs []types.Package
+	for _, lib := range lo.UniqBy(libs, func(lib godeptypes.Library) string { return lib.Name + "@" + lib.Version }) {
+		pkgs = append(pkgs, types.Package{
+			Name:    lib.Name,
+			Version: lib.Version,
+		})
+	}
+	if len(pkgs) == 0 {
+		// Unpinned packages
+		return nil, nil
+	}
+
+	return &analyzer.AnalysisResult{
+		Applications: []types.Application{
+			{
+				Type:      types.Jupyter,
+				FilePath:  input.FilePath,
+				Libraries: pkgs,
+			},
+		},
+	}, nil
+}
+
+// pipRequirements returns the requirements in "pip install" commands of the code
+func pipRequirements(code string) []string {
+	// Join continued lines
+	code = strings.ReplaceAll(code, "\\\n", " ")
+
+	var requirements []string
+	for _, line := range strings.Split(code, "\n") {
+		m := pipInstallPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+
+		args := strings.Fields(m[1])
+		for i := 0; i < len(args); i++ {
+			arg := strings.Trim(args[i], `'"`)
+			switch {
+			case strings.HasPrefix(arg, "#") || arg == "&&" || arg == ";" || arg == "|" || arg == ">":
+				// The rest is a comment or another command
+				i = len(args)
+			case isOptionWithValue(arg):
+				i++
+			case strings.HasPrefix(arg, "-"):
+				// Flags such as "-q" and "--upgrade", or options such as "--index-url=..."
+			default:
+				requirements = append(requirements, arg)
+			}
+		}
+	}
+	return requirements
+}
+
+func isOptionWithValue(arg string) bool {
+	for _, opt := range pipOptionsWithValue {
+		if arg == opt {
+			return true
+		}
+	}
+	return false
+}
+
+func (a jupyterAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	// Jupyter saves copies of notebooks in ".ipynb_checkpoints"
+	dir := filepath.Base(filepath.Dir(filePath))
+	return filepath.Ext(filePath) == notebookExt && dir != checkpointsDir
+}
+
+func (a jupyterAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeJupyter
+}
+
+func (a jupyterAnalyzer) Version() int {
+	return version
+}
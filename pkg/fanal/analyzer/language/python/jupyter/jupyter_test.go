@@ -0,0 +1,119 @@
+package jupyter
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func Test_jupyterAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputFile string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name:      "pip install in code cells",
+			inputFile: "testdata/analysis.ipynb",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Jupyter,
+						FilePath: "testdata/analysis.ipynb",
+						Libraries: []types.Package{
+							{
+								Name:    "requests",
+								Version: "2.28.0",
+							},
+							{
+								Name:    "urllib3",
+								Version: "1.26.5",
+							},
+							{
+								Name:    "torch",
+								Version: "2.0.1",
+							},
+							{
+								Name:    "Jinja2",
+								Version: "2.11.2",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "nbformat 3",
+			inputFile: "testdata/legacy.ipynb",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Jupyter,
+						FilePath: "testdata/legacy.ipynb",
+						Libraries: []types.Package{
+							{
+								Name:    "flask",
+								Version: "1.0.0",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "no pip install",
+			inputFile: "testdata/no-install.ipynb",
+		},
+		{
+			name:      "broken notebook",
+			inputFile: "testdata/broken.ipynb",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			a := jupyterAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.inputFile,
+				Content:  f,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_jupyterAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{
+			filePath: "home/jovyan/work/analysis.ipynb",
+			want:     true,
+		},
+		{
+			filePath: "home/jovyan/work/.ipynb_checkpoints/analysis-checkpoint.ipynb",
+			want:     false,
+		},
+		{
+			filePath: "home/jovyan/work/analysis.py",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			a := jupyterAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package runtime
+
+import (
+	"context"
+	"io"
+	"os"
+	"regexp"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer/language"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&pythonRuntimeAnalyzer{})
+}
+
+const (
+	version = 1
+	pkgName = "python"
+)
+
+var (
+	// The header installed with CPython, e.g. "/usr/local/include/python3.11/patchlevel.h"
+	headerRegex  = regexp.MustCompile(`(?:^|/)include/python\d+\.\d+[a-z]*/patchlevel\.h$`)
+	versionRegex = regexp.MustCompile(`(?m)^#define PY_VERSION\s+"([^"]+)"`)
+)
+
+// pythonRuntimeAnalyzer detects the version of CPython
+type pythonRuntimeAnalyzer struct{}
+
+func (a pythonRuntimeAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	b, err := io.ReadAll(input.Content)
+	if err != nil {
+		return nil, xerrors.Errorf("%s read error: %w", input.FilePath, err)
+	}
+
+	m := versionRegex.FindSubmatch(b)
+	if m == nil {
+		return nil, nil
+	}
+	// e.g. "3.11.4" and "3.12.0rc1"
+	return language.AnalyzeRuntime(types.PythonRuntime, input.FilePath, pkgName, string(m[1])), nil
+}
+
+func (a pythonRuntimeAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return headerRegex.MatchString(filePath)
+}
+
+func (a pythonRuntimeAnalyzer) Type() analyzer.Type {
+	return analyzer.TypePythonRuntime
+}
+
+func (a pythonRuntimeAnalyzer) Version() int {
+	return version
+}
@@ -0,0 +1,90 @@
+package runtime
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func Test_pythonRuntimeAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		filePath  string
+		inputFile string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name:      "happy path",
+			filePath:  "usr/local/include/python3.11/patchlevel.h",
+			inputFile: "testdata/patchlevel.h",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.PythonRuntime,
+						FilePath: "usr/local/include/python3.11/patchlevel.h",
+						Libraries: []types.Package{
+							{
+								ID:       "python@3.11.4",
+								Name:     "python",
+								Version:  "3.11.4",
+								FilePath: "usr/local/include/python3.11/patchlevel.h",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "no version",
+			filePath:  "usr/local/include/python3.11/patchlevel.h",
+			inputFile: "testdata/patchlevel-unknown.h",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			a := pythonRuntimeAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.filePath,
+				Content:  f,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_pythonRuntimeAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{
+			filePath: "usr/local/include/python3.11/patchlevel.h",
+			want:     true,
+		},
+		{
+			filePath: "usr/include/python3.8m/patchlevel.h",
+			want:     true,
+		},
+		{
+			filePath: "usr/local/include/python3.11/Python.h",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			a := pythonRuntimeAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
@@ -0,0 +1 @@
+#define PY_MAJOR_VERSION        3
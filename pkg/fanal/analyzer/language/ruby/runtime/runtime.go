@@ -0,0 +1,57 @@
+package runtime
+
+import (
+	"context"
+	"io"
+	"os"
+	"regexp"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer/language"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&rubyRuntimeAnalyzer{})
+}
+
+const (
+	version = 1
+	pkgName = "ruby"
+)
+
+var (
+	// The configuration installed with Ruby, e.g. "/usr/local/lib/ruby/3.2.0/x86_64-linux/rbconfig.rb"
+	rbconfigRegex = regexp.MustCompile(`(?:^|/)lib/ruby/\d+\.\d+\.\d+/[^/]+/rbconfig\.rb$`)
+	versionRegex  = regexp.MustCompile(`CONFIG\["RUBY_PROGRAM_VERSION"\]\s*=\s*"([^"]+)"`)
+)
+
+// rubyRuntimeAnalyzer detects the version of Ruby
+type rubyRuntimeAnalyzer struct{}
+
+func (a rubyRuntimeAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	b, err := io.ReadAll(input.Content)
+	if err != nil {
+		return nil, xerrors.Errorf("%s read error: %w", input.FilePath, err)
+	}
+
+	m := versionRegex.FindSubmatch(b)
+	if m == nil {
+		return nil, nil
+	}
+	return language.AnalyzeRuntime(types.RubyRuntime, input.FilePath, pkgName, string(m[1])), nil
+}
+
+func (a rubyRuntimeAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return rbconfigRegex.MatchString(filePath)
+}
+
+func (a rubyRuntimeAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeRubyRuntime
+}
+
+func (a rubyRuntimeAnalyzer) Version() int {
+	return version
+}
@@ -0,0 +1,86 @@
+package runtime
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func Test_rubyRuntimeAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		filePath  string
+		inputFile string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name:      "happy path",
+			filePath:  "usr/local/lib/ruby/3.2.0/x86_64-linux/rbconfig.rb",
+			inputFile: "testdata/rbconfig.rb",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.RubyRuntime,
+						FilePath: "usr/local/lib/ruby/3.2.0/x86_64-linux/rbconfig.rb",
+						Libraries: []types.Package{
+							{
+								ID:       "ruby@3.2.2",
+								Name:     "ruby",
+								Version:  "3.2.2",
+								FilePath: "usr/local/lib/ruby/3.2.0/x86_64-linux/rbconfig.rb",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "no version",
+			filePath:  "usr/local/lib/ruby/3.2.0/x86_64-linux/rbconfig.rb",
+			inputFile: "testdata/rbconfig-unknown.rb",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			a := rubyRuntimeAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.filePath,
+				Content:  f,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_rubyRuntimeAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{
+			filePath: "usr/local/lib/ruby/3.2.0/x86_64-linux/rbconfig.rb",
+			want:     true,
+		},
+		{
+			filePath: "usr/local/lib/ruby/gems/3.2.0/gems/rake-13.0.6/rbconfig.rb",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			a := rubyRuntimeAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
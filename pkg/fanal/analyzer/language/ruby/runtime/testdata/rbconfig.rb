@@ -0,0 +1,24 @@
+# frozen-string-literal: false
+#
+# The module storing Ruby interpreter configurations on building.
+#
+# This file was created by mkconfig.rb when ruby was built.  It contains
+# build information for ruby which is used e.g. by mkmf to build
+# compatible native extensions.  Any changes made to this file will be
+# lost the next time ruby is built.
+
+module RbConfig
+  RUBY_VERSION.start_with?("3.2.") or
+    raise "ruby lib version (3.2.2) doesn't match executable version (#{RUBY_VERSION})"
+
+  TOPDIR = File.dirname(__FILE__).chomp!("/lib/ruby/3.2.0/x86_64-linux")
+  DESTDIR = '' unless defined? DESTDIR
+  CONFIG = {}
+  CONFIG["DESTDIR"] = DESTDIR
+  CONFIG["MAJOR"] = "3"
+  CONFIG["MINOR"] = "2"
+  CONFIG["TEENY"] = "2"
+  CONFIG["PATCHLEVEL"] = "53"
+  CONFIG["RUBY_PROGRAM_VERSION"] = "3.2.2"
+  CONFIG["RUBY_API_VERSION"] = "3.2"
+end
@@ -0,0 +1,32 @@
+package language
+
+import (
+	"fmt"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// AnalyzeRuntime returns an analysis result of the language runtime such as Node.js and Python.
+// The runtime is reported as a package, so that runtime advisories and the end-of-life status can be checked.
+func AnalyzeRuntime(fileType, filePath, name, ver string) *analyzer.AnalysisResult {
+	if ver == "" {
+		return nil
+	}
+	return &analyzer.AnalysisResult{
+		Applications: []types.Application{
+			{
+				Type:     fileType,
+				FilePath: filePath,
+				Libraries: []types.Package{
+					{
+						ID:       fmt.Sprintf("%s@%s", name, ver),
+						Name:     name,
+						Version:  ver,
+						FilePath: filePath,
+					},
+				},
+			},
+		},
+	}
+}
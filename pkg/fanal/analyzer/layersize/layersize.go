@@ -0,0 +1,41 @@
+package layersize
+
+import (
+	"context"
+	"os"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&layerSizeAnalyzer{})
+}
+
+const version = 1
+
+// layerSizeAnalyzer records the size of each file in a layer
+// so that the applier can calculate per-layer sizes and the space wasted by removed and overwritten files.
+// It needs only file information and doesn't open files.
+type layerSizeAnalyzer struct{}
+
+func (a layerSizeAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	return &analyzer.AnalysisResult{
+		FileSizes: map[string]int64{
+			input.FilePath: input.Info.Size(),
+		},
+	}, nil
+}
+
+func (a layerSizeAnalyzer) Required(_ string, fileInfo os.FileInfo) bool {
+	return fileInfo.Mode().IsRegular()
+}
+
+func (a layerSizeAnalyzer) FileInfoOnly() {}
+
+func (a layerSizeAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeLayerSize
+}
+
+func (a layerSizeAnalyzer) Version() int {
+	return version
+}
@@ -0,0 +1,31 @@
+package layersize
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+)
+
+func Test_layerSizeAnalyzer_Analyze(t *testing.T) {
+	stat, err := os.Stat("layersize.go")
+	require.NoError(t, err)
+
+	a := layerSizeAnalyzer{}
+	got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+		FilePath: "usr/src/layersize.go",
+		Info:     stat,
+	})
+	require.NoError(t, err)
+
+	want := &analyzer.AnalysisResult{
+		FileSizes: map[string]int64{
+			"usr/src/layersize.go": stat.Size(),
+		},
+	}
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,260 @@
+package mlmodel
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	dio "github.com/aquasecurity/go-dep-parser/pkg/io"
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&mlModelAnalyzer{})
+}
+
+const (
+	version = 2
+
+	// ResourceType is the type of custom resources holding model metadata
+	ResourceType = "ml-model"
+
+	// FileTypePickle is the file type of misconfigurations detected in pickles
+	FileTypePickle = "pickle"
+)
+
+// Formats
+const (
+	FormatPickle      = "pickle"
+	FormatPyTorch     = "pytorch"
+	FormatSafetensors = "safetensors"
+	FormatONNX        = "onnx"
+)
+
+var (
+	pickleExtensions  = []string{".pkl", ".pickle", ".joblib"}
+	pytorchExtensions = []string{".pt", ".pth", ".ckpt"}
+
+	// pytorchFiles are the PyTorch checkpoints with a generic extension, e.g. on Hugging Face Hub
+	pytorchFiles = []string{"pytorch_model.bin"}
+
+	zipMagic = []byte("PK\x03\x04")
+
+	// opProto followed by the protocol, which pickles of protocol 2 or later start with
+	pickleProtocols = []byte{2, 3, 4, 5}
+)
+
+// unsafePickleCheck is the metadata of the check for pickles importing callables which allow arbitrary code execution
+var unsafePickleCheck = types.PolicyMetadata{
+	ID:          "ML001",
+	Type:        "Pickle Security Check",
+	Title:       "Pickle should not import unsafe callables",
+	Description: "Unpickling executes callables imported by the pickle. A model importing modules such as 'os' or 'subprocess' can run arbitrary commands when it is loaded.",
+	Severity:    "CRITICAL",
+	RecommendedActions: "Load models only from trusted sources. " +
+		"Prefer formats without code execution such as safetensors, or use 'torch.load(..., weights_only=True)'.",
+	References: []string{"https://docs.python.org/3/library/pickle.html"},
+}
+
+// Model holds the metadata of a machine-learning model for AI supply-chain inventories
+type Model struct {
+	Format string
+
+	// ONNX
+	IRVersion       int      `json:",omitempty"`
+	Producer        string   `json:",omitempty"`
+	ProducerVersion string   `json:",omitempty"`
+	Domain          string   `json:",omitempty"`
+	ModelVersion    string   `json:",omitempty"`
+	Opsets          []string `json:",omitempty"`
+
+	// safetensors
+	Tensors    int      `json:",omitempty"`
+	Parameters int64    `json:",omitempty"`
+	DTypes     []string `json:",omitempty"`
+
+	// Free-form metadata in safetensors and ONNX
+	Metadata map[string]string `json:",omitempty"`
+
+	// Globals imported by pickles, e.g. "collections.OrderedDict"
+	Imports       []string `json:",omitempty"`
+	UnsafeImports []string `json:",omitempty"`
+
+	// Why the pickles couldn't be fully scanned, which may hide unsafe imports
+	Unscanned string `json:",omitempty"`
+}
+
+// mlModelAnalyzer identifies machine-learning model files and flags unsafe pickles
+type mlModelAnalyzer struct{}
+
+func (a mlModelAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	var model Model
+	var err error
+	switch ext := strings.ToLower(filepath.Ext(input.FilePath)); ext {
+	case ".safetensors":
+		model, err = parseSafetensors(input.Content)
+	case ".onnx":
+		model, err = parseONNX(input.Content, input.Info.Size())
+	default:
+		model, err = parsePickles(input.Content, input.Info.Size(), ext)
+	}
+	if err != nil {
+		// The extensions are also used by other files
+		log.Logger.Debugf("Unable to parse %s as a model: %s", input.FilePath, err)
+		return nil, nil
+	}
+
+	result := &analyzer.AnalysisResult{
+		CustomResources: []types.CustomResource{
+			{
+				Type:     ResourceType,
+				FilePath: input.FilePath,
+				Data:     model,
+			},
+		},
+	}
+	if model.Format == FormatPickle || model.Format == FormatPyTorch {
+		result.Misconfigurations = []types.Misconfiguration{pickleMisconf(input.FilePath, model)}
+	}
+	return result, nil
+}
+
+// parsePickles scans a pickle or the pickles in a PyTorch ZIP archive
+func parsePickles(r dio.ReadSeekerAt, size int64, ext string) (Model, error) {
+	model := Model{Format: FormatPickle}
+	if slices.Contains(pytorchExtensions, ext) || ext == ".bin" {
+		model.Format = FormatPyTorch
+	}
+
+	magic := make([]byte, len(zipMagic))
+	if _, err := r.ReadAt(magic, 0); err != nil {
+		return Model{}, xerrors.Errorf("read error: %w", err)
+	}
+
+	if !bytes.Equal(magic, zipMagic) {
+		imports, err := scanPickle(io.NewSectionReader(r, 0, size))
+		if err != nil {
+			// Files without the pickle header may not be pickles, but broken pickles must not hide unsafe imports
+			if magic[0] != opProto || !slices.Contains(pickleProtocols, magic[1]) {
+				return Model{}, xerrors.Errorf("pickle error: %w", err)
+			}
+			model.setUnscanned(err.Error())
+		}
+		model.setImports(imports)
+		return model, nil
+	}
+
+	// PyTorch saves the pickled object as "<name>/data.pkl" in a ZIP archive with tensors in separate entries
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return Model{}, xerrors.Errorf("zip error: %w", err)
+	}
+	var found bool
+	for _, f := range zr.File {
+		if !strings.HasSuffix(f.Name, ".pkl") {
+			continue
+		}
+		found = true
+		imports, err := scanZipPickle(f)
+		if err != nil {
+			model.setUnscanned(fmt.Sprintf("%s: %s", f.Name, err))
+		}
+		model.setImports(imports)
+	}
+	if !found {
+		return Model{}, xerrors.New("no pickle found in the archive")
+	}
+	model.Format = FormatPyTorch
+	return model, nil
+}
+
+func scanZipPickle(f *zip.File) (pickleImports, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return pickleImports{}, xerrors.Errorf("open error: %w", err)
+	}
+	defer rc.Close()
+	return scanPickle(rc)
+}
+
+func (m *Model) setImports(imports pickleImports) {
+	if imports.unresolved {
+		m.setUnscanned("STACK_GLOBAL imports a computed module or name")
+	}
+	for _, imp := range imports.globals {
+		if slices.Contains(m.Imports, imp) {
+			continue
+		}
+		m.Imports = append(m.Imports, imp)
+
+		i := strings.LastIndex(imp, ".")
+		if i > 0 && isUnsafe(imp[:i], imp[i+1:]) {
+			m.UnsafeImports = append(m.UnsafeImports, imp)
+		}
+	}
+}
+
+// setUnscanned records the first reason why the pickles couldn't be fully scanned
+func (m *Model) setUnscanned(reason string) {
+	if m.Unscanned == "" {
+		m.Unscanned = reason
+	}
+}
+
+func pickleMisconf(filePath string, model Model) types.Misconfiguration {
+	res := types.MisconfResult{
+		Namespace:      "mlmodel." + unsafePickleCheck.ID,
+		PolicyMetadata: unsafePickleCheck,
+	}
+	misconf := types.Misconfiguration{
+		FileType: FileTypePickle,
+		FilePath: filePath,
+	}
+	if len(model.UnsafeImports) == 0 && model.Unscanned == "" {
+		misconf.Successes = types.MisconfResults{res}
+		return misconf
+	}
+
+	if len(model.UnsafeImports) > 0 {
+		log.Logger.Warnf("%s imports unsafe callables: %s", filePath, strings.Join(model.UnsafeImports, ", "))
+	}
+	for _, imp := range model.UnsafeImports {
+		r := res
+		r.Message = fmt.Sprintf("Pickle imports unsafe callable '%s'", imp)
+		misconf.Failures = append(misconf.Failures, r)
+	}
+	if model.Unscanned != "" {
+		log.Logger.Warnf("%s couldn't be fully scanned for unsafe callables: %s", filePath, model.Unscanned)
+		r := res
+		r.Message = fmt.Sprintf("Pickle couldn't be fully scanned for unsafe callables: %s", model.Unscanned)
+		misconf.Failures = append(misconf.Failures, r)
+	}
+	return misconf
+}
+
+func (a mlModelAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	if slices.Contains(pytorchFiles, filepath.Base(filePath)) {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(filePath))
+	return slices.Contains(pickleExtensions, ext) || slices.Contains(pytorchExtensions, ext) ||
+		ext == ".safetensors" || ext == ".onnx"
+}
+
+func (a mlModelAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeMLModel
+}
+
+func (a mlModelAnalyzer) Version() int {
+	return version
+}
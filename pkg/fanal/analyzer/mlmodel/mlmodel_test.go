@@ -0,0 +1,223 @@
+package mlmodel
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func Test_mlModelAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputFile    string
+		want         *Model
+		wantFailures []string
+	}{
+		{
+			name:      "pickle protocol 4",
+			inputFile: "testdata/safe.pkl",
+			want: &Model{
+				Format:  FormatPickle,
+				Imports: []string{"collections.OrderedDict"},
+			},
+		},
+		{
+			name:      "pickle protocol 0",
+			inputFile: "testdata/safe-v0.pickle",
+			want: &Model{
+				Format:  FormatPickle,
+				Imports: []string{"collections.OrderedDict"},
+			},
+		},
+		{
+			name:      "malicious pickle with STACK_GLOBAL",
+			inputFile: "testdata/malicious.pkl",
+			want: &Model{
+				Format:        FormatPickle,
+				Imports:       []string{"collections.OrderedDict", "posix.system"},
+				UnsafeImports: []string{"posix.system"},
+			},
+			wantFailures: []string{"Pickle imports unsafe callable 'posix.system'"},
+		},
+		{
+			name:      "malicious pickle with GLOBAL",
+			inputFile: "testdata/malicious-v2.pkl",
+			want: &Model{
+				Format:        FormatPickle,
+				Imports:       []string{"posix.system"},
+				UnsafeImports: []string{"posix.system"},
+			},
+			wantFailures: []string{"Pickle imports unsafe callable 'posix.system'"},
+		},
+		{
+			name:      "malicious pickle popping a decoy",
+			inputFile: "testdata/pop-bypass.pkl",
+			want: &Model{
+				Format:        FormatPickle,
+				Imports:       []string{"os.system"},
+				UnsafeImports: []string{"os.system"},
+			},
+			wantFailures: []string{"Pickle imports unsafe callable 'os.system'"},
+		},
+		{
+			name:      "pickle with a computed STACK_GLOBAL",
+			inputFile: "testdata/computed-global.pkl",
+			want: &Model{
+				Format:    FormatPickle,
+				Imports:   []string{"builtins.str"},
+				Unscanned: "STACK_GLOBAL imports a computed module or name",
+			},
+			wantFailures: []string{"Pickle couldn't be fully scanned for unsafe callables: STACK_GLOBAL imports a computed module or name"},
+		},
+		{
+			name:      "truncated pickle",
+			inputFile: "testdata/truncated.pkl",
+			want: &Model{
+				Format:    FormatPickle,
+				Imports:   []string{"collections.OrderedDict"},
+				Unscanned: "read error: unexpected EOF",
+			},
+			wantFailures: []string{"Pickle couldn't be fully scanned for unsafe callables: read error: unexpected EOF"},
+		},
+		{
+			name:      "PyTorch",
+			inputFile: "testdata/model.pt",
+			want: &Model{
+				Format:  FormatPyTorch,
+				Imports: []string{"collections.OrderedDict"},
+			},
+		},
+		{
+			name:      "malicious PyTorch",
+			inputFile: "testdata/malicious.pt",
+			want: &Model{
+				Format:        FormatPyTorch,
+				Imports:       []string{"posix.system"},
+				UnsafeImports: []string{"posix.system"},
+			},
+			wantFailures: []string{"Pickle imports unsafe callable 'posix.system'"},
+		},
+		{
+			name:      "safetensors",
+			inputFile: "testdata/model.safetensors",
+			want: &Model{
+				Format:     FormatSafetensors,
+				Tensors:    2,
+				Parameters: 12,
+				DTypes:     []string{"F16", "F32"},
+				Metadata:   map[string]string{"format": "pt"},
+			},
+		},
+		{
+			name:      "ONNX",
+			inputFile: "testdata/model.onnx",
+			want: &Model{
+				Format:          FormatONNX,
+				IRVersion:       8,
+				Producer:        "pytorch",
+				ProducerVersion: "2.0.1",
+				Domain:          "com.example",
+				ModelVersion:    "3",
+				Opsets:          []string{"ai.onnx:17", "com.microsoft:1"},
+				Metadata:        map[string]string{"author": "alice"},
+			},
+		},
+		{
+			name:      "not a pickle",
+			inputFile: "testdata/notes.pkl",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			fi, err := f.Stat()
+			require.NoError(t, err)
+
+			a := mlModelAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.inputFile,
+				Info:     fi,
+				Content:  f,
+			})
+			require.NoError(t, err)
+
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, []types.CustomResource{
+				{
+					Type:     ResourceType,
+					FilePath: tt.inputFile,
+					Data:     *tt.want,
+				},
+			}, got.CustomResources)
+
+			if tt.want.Format != FormatPickle && tt.want.Format != FormatPyTorch {
+				assert.Empty(t, got.Misconfigurations)
+				return
+			}
+			require.Len(t, got.Misconfigurations, 1)
+			misconf := got.Misconfigurations[0]
+			assert.Equal(t, FileTypePickle, misconf.FileType)
+
+			var failures []string
+			for _, f := range misconf.Failures {
+				assert.Equal(t, unsafePickleCheck.ID, f.ID)
+				failures = append(failures, f.Message)
+			}
+			assert.Equal(t, tt.wantFailures, failures)
+			if len(tt.wantFailures) == 0 {
+				assert.Len(t, misconf.Successes, 1)
+			}
+		})
+	}
+}
+
+func Test_mlModelAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{
+			filePath: "models/classifier.pkl",
+			want:     true,
+		},
+		{
+			filePath: "models/resnet50.PTH",
+			want:     true,
+		},
+		{
+			filePath: "models/bert/pytorch_model.bin",
+			want:     true,
+		},
+		{
+			filePath: "models/bert/model.safetensors",
+			want:     true,
+		},
+		{
+			filePath: "models/yolo.onnx",
+			want:     true,
+		},
+		{
+			filePath: "firmware.bin",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			a := mlModelAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package mlmodel
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of ModelProto. The graph holding weights is skipped without being read.
+// ref. https://github.com/onnx/onnx/blob/main/onnx/onnx.proto
+const (
+	onnxIRVersion       protowire.Number = 1
+	onnxProducerName    protowire.Number = 2
+	onnxProducerVersion protowire.Number = 3
+	onnxDomain          protowire.Number = 4
+	onnxModelVersion    protowire.Number = 5
+	onnxDocString       protowire.Number = 6
+	onnxGraph           protowire.Number = 7
+	onnxOpsetImport     protowire.Number = 8
+	onnxMetadataProps   protowire.Number = 14
+
+	// defaultOpsetDomain is the domain of the standard operators, represented by an empty string
+	defaultOpsetDomain = "ai.onnx"
+
+	maxONNXField = 1 << 20
+)
+
+var onnxWireTypes = map[protowire.Number]protowire.Type{
+	onnxIRVersion:       protowire.VarintType,
+	onnxProducerName:    protowire.BytesType,
+	onnxProducerVersion: protowire.BytesType,
+	onnxDomain:          protowire.BytesType,
+	onnxModelVersion:    protowire.VarintType,
+	onnxDocString:       protowire.BytesType,
+	onnxGraph:           protowire.BytesType,
+	onnxOpsetImport:     protowire.BytesType,
+	onnxMetadataProps:   protowire.BytesType,
+}
+
+func parseONNX(r io.ReaderAt, size int64) (Model, error) {
+	model := Model{Format: FormatONNX}
+	var irVersion uint64
+	for off := int64(0); off < size; {
+		// A tag and a length fit in 20 bytes
+		buf := make([]byte, 20)
+		n, err := r.ReadAt(buf, off)
+		if err != nil && err != io.EOF {
+			return Model{}, xerrors.Errorf("read error: %w", err)
+		}
+		buf = buf[:n]
+
+		num, typ, n := protowire.ConsumeTag(buf)
+		if n < 0 {
+			return Model{}, xerrors.Errorf("invalid tag at %d: %w", off, protowire.ParseError(n))
+		} else if want, ok := onnxWireTypes[num]; ok && want != typ {
+			return Model{}, xerrors.Errorf("unexpected wire type of field %d: %d", num, typ)
+		}
+		off += int64(n)
+		buf = buf[n:]
+
+		switch typ {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(buf)
+			if n < 0 {
+				return Model{}, xerrors.Errorf("invalid varint at %d: %w", off, protowire.ParseError(n))
+			}
+			off += int64(n)
+
+			switch num {
+			case onnxIRVersion:
+				irVersion = v
+			case onnxModelVersion:
+				model.ModelVersion = strconv.FormatUint(v, 10)
+			}
+		case protowire.BytesType:
+			l, n := protowire.ConsumeVarint(buf)
+			if n < 0 {
+				return Model{}, xerrors.Errorf("invalid length at %d: %w", off, protowire.ParseError(n))
+			} else if off+int64(n)+int64(l) > size {
+				return Model{}, xerrors.Errorf("field %d exceeds the file", num)
+			}
+			off += int64(n)
+
+			if num != onnxGraph && num != onnxDocString && l <= maxONNXField {
+				b := make([]byte, l)
+				if _, err = r.ReadAt(b, off); err != nil {
+					return Model{}, xerrors.Errorf("read error: %w", err)
+				}
+				if err = model.setONNXField(num, b); err != nil {
+					return Model{}, err
+				}
+			}
+			off += int64(l)
+		case protowire.Fixed32Type:
+			off += 4
+		case protowire.Fixed64Type:
+			off += 8
+		default:
+			return Model{}, xerrors.Errorf("unsupported wire type: %d", typ)
+		}
+	}
+
+	if irVersion == 0 {
+		return Model{}, xerrors.New("IR version not found")
+	}
+	model.IRVersion = int(irVersion)
+	return model, nil
+}
+
+func (m *Model) setONNXField(num protowire.Number, b []byte) error {
+	switch num {
+	case onnxProducerName:
+		m.Producer = string(b)
+	case onnxProducerVersion:
+		m.ProducerVersion = string(b)
+	case onnxDomain:
+		m.Domain = string(b)
+	case onnxOpsetImport:
+		fields, err := parseMessage(b)
+		if err != nil {
+			return xerrors.Errorf("opset import error: %w", err)
+		}
+		domain := string(fields[1].bytes)
+		if domain == "" {
+			domain = defaultOpsetDomain
+		}
+		m.Opsets = append(m.Opsets, fmt.Sprintf("%s:%d", domain, fields[2].varint))
+	case onnxMetadataProps:
+		fields, err := parseMessage(b)
+		if err != nil {
+			return xerrors.Errorf("metadata error: %w", err)
+		}
+		if m.Metadata == nil {
+			m.Metadata = make(map[string]string)
+		}
+		m.Metadata[string(fields[1].bytes)] = string(fields[2].bytes)
+	}
+	return nil
+}
+
+type field struct {
+	varint uint64
+	bytes  []byte
+}
+
+// parseMessage parses a small message such as OperatorSetIdProto and StringStringEntryProto
+func parseMessage(b []byte) (map[protowire.Number]field, error) {
+	fields := make(map[protowire.Number]field)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var f field
+		switch typ {
+		case protowire.VarintType:
+			f.varint, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			f.bytes, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		fields[num] = f
+	}
+	return fields, nil
+}
@@ -0,0 +1,451 @@
+package mlmodel
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+)
+
+// Pickle opcodes
+// ref. https://github.com/python/cpython/blob/main/Lib/pickletools.py
+const (
+	opMark           = '('
+	opStop           = '.'
+	opPop            = '0'
+	opPopMark        = '1'
+	opDup            = '2'
+	opFloat          = 'F'
+	opInt            = 'I'
+	opBinInt         = 'J'
+	opBinInt1        = 'K'
+	opLong           = 'L'
+	opBinInt2        = 'M'
+	opNone           = 'N'
+	opPersID         = 'P'
+	opBinPersID      = 'Q'
+	opReduce         = 'R'
+	opString         = 'S'
+	opBinString      = 'T'
+	opShortBinString = 'U'
+	opUnicode        = 'V'
+	opBinUnicode     = 'X'
+	opAppend         = 'a'
+	opBuild          = 'b'
+	opGlobal         = 'c'
+	opDict           = 'd'
+	opEmptyDict      = '}'
+	opAppends        = 'e'
+	opGet            = 'g'
+	opBinGet         = 'h'
+	opInst           = 'i'
+	opLongBinGet     = 'j'
+	opList           = 'l'
+	opEmptyList      = ']'
+	opObj            = 'o'
+	opPut            = 'p'
+	opBinPut         = 'q'
+	opLongBinPut     = 'r'
+	opSetItem        = 's'
+	opTuple          = 't'
+	opEmptyTuple     = ')'
+	opSetItems       = 'u'
+	opBinFloat       = 'G'
+
+	// Protocol 2
+	opProto    = 0x80
+	opNewObj   = 0x81
+	opExt1     = 0x82
+	opExt2     = 0x83
+	opExt4     = 0x84
+	opTuple1   = 0x85
+	opTuple2   = 0x86
+	opTuple3   = 0x87
+	opNewTrue  = 0x88
+	opNewFalse = 0x89
+	opLong1    = 0x8a
+	opLong4    = 0x8b
+
+	// Protocol 3
+	opBinBytes      = 'B'
+	opShortBinBytes = 'C'
+
+	// Protocol 4
+	opShortBinUnicode = 0x8c
+	opBinUnicode8     = 0x8d
+	opBinBytes8       = 0x8e
+	opEmptySet        = 0x8f
+	opAddItems        = 0x90
+	opFrozenSet       = 0x91
+	opNewObjEx        = 0x92
+	opStackGlobal     = 0x93
+	opMemoize         = 0x94
+	opFrame           = 0x95
+
+	// Protocol 5
+	opByteArray8     = 0x96
+	opNextBuffer     = 0x97
+	opReadOnlyBuffer = 0x98
+)
+
+// maxPickleString limits the length of strings kept in memory. Longer ones are skipped.
+const maxPickleString = 1 << 10
+
+// unsafeGlobals are the callables which allow arbitrary code execution when unpickled.
+// An empty list means all the names in the module.
+var unsafeGlobals = map[string][]string{
+	"os":          nil,
+	"posix":       nil,
+	"nt":          nil,
+	"subprocess":  nil,
+	"sys":         nil,
+	"socket":      nil,
+	"shutil":      nil,
+	"pty":         nil,
+	"runpy":       nil,
+	"importlib":   nil,
+	"code":        nil,
+	"ctypes":      nil,
+	"webbrowser":  nil,
+	"commands":    nil,
+	"builtins":    {"eval", "exec", "compile", "open", "__import__", "getattr", "setattr", "globals", "breakpoint", "input"},
+	"__builtin__": {"eval", "exec", "execfile", "compile", "open", "__import__", "getattr", "setattr", "globals", "input"},
+	"pickle":      {"loads", "load"},
+	"_pickle":     {"loads", "load"},
+}
+
+// isUnsafe returns true if the global, e.g. "os.system", is known to be unsafe
+func isUnsafe(module, name string) bool {
+	for m, names := range unsafeGlobals {
+		if module != m && !strings.HasPrefix(module, m+".") {
+			continue
+		}
+		if len(names) == 0 || slices.Contains(names, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// pickleImports is the result of scanning pickles
+type pickleImports struct {
+	// globals are the globals imported by the pickles, e.g. "collections.OrderedDict"
+	globals []string
+
+	// unresolved is true if STACK_GLOBAL imports a global whose module or name is not a string constant,
+	// which may hide an unsafe callable
+	unresolved bool
+}
+
+// scanPickle returns the globals imported by the pickle stream, e.g. "collections.OrderedDict".
+// Objects are not constructed, but the stack is emulated to resolve the module and the name of STACK_GLOBAL.
+// Multiple pickles in a row are scanned until the data is no longer a pickle,
+// e.g. raw tensors following pickles in legacy PyTorch files.
+// The imports found so far are returned with errors.
+func scanPickle(r io.Reader) (pickleImports, error) {
+	br := bufio.NewReader(r)
+	p := &pickleScanner{
+		r:    br,
+		memo: make(map[int]pickleValue),
+	}
+
+	var imports pickleImports
+	for stopped := false; ; {
+		op, err := br.ReadByte()
+		if err == io.EOF {
+			return imports, nil
+		} else if err != nil {
+			return imports, xerrors.Errorf("read error: %w", err)
+		}
+
+		global, err := p.next(op)
+		if errors.Is(err, errUnresolvedGlobal) {
+			imports.unresolved = true
+		} else if err != nil {
+			if stopped {
+				// Non-pickle data follows the pickles
+				return imports, nil
+			}
+			return imports, err
+		}
+		if global != "" && !slices.Contains(imports.globals, global) {
+			imports.globals = append(imports.globals, global)
+		}
+		if op == opStop {
+			stopped = true
+			p.reset()
+		}
+	}
+}
+
+var errUnresolvedGlobal = xerrors.New("STACK_GLOBAL without module and name strings")
+
+// pickleValue is an object on the stack, where only strings are kept for STACK_GLOBAL
+type pickleValue struct {
+	str      string
+	isString bool
+}
+
+type pickleScanner struct {
+	r *bufio.Reader
+
+	stack []pickleValue
+	marks []int // the lengths of the stack at MARK
+	memo  map[int]pickleValue
+}
+
+// next consumes the opcode with the argument, updates the stack and returns the global if imported
+func (p *pickleScanner) next(op byte) (string, error) {
+	switch op {
+	case opProto:
+		return "", p.skip(1)
+	case opFrame:
+		return "", p.skip(8)
+	case opNone, opNewTrue, opNewFalse, opEmptyDict, opEmptyList, opEmptyTuple, opEmptySet, opNextBuffer:
+		p.push(pickleValue{})
+		return "", nil
+	case opBinInt1, opExt1:
+		return "", p.pushSkipped(1)
+	case opBinInt2, opExt2:
+		return "", p.pushSkipped(2)
+	case opBinInt, opExt4:
+		return "", p.pushSkipped(4)
+	case opBinFloat:
+		return "", p.pushSkipped(8)
+	case opFloat, opInt, opLong, opPersID:
+		_, err := p.line()
+		p.push(pickleValue{})
+		return "", err
+	case opString, opUnicode:
+		s, err := p.line()
+		p.push(pickleValue{
+			str:      strings.Trim(s, `'"`),
+			isString: true,
+		})
+		return "", err
+	case opShortBinString, opShortBinBytes, opShortBinUnicode, opLong1:
+		return "", p.pushString(op, 1)
+	case opBinString, opBinUnicode, opBinBytes, opLong4:
+		return "", p.pushString(op, 4)
+	case opBinUnicode8, opBinBytes8, opByteArray8:
+		return "", p.pushString(op, 8)
+	case opMark:
+		p.marks = append(p.marks, len(p.stack))
+		return "", nil
+	case opPop:
+		// POP discards the MARK if no object is pushed after it
+		if len(p.marks) > 0 && len(p.stack) == p.marks[len(p.marks)-1] {
+			p.marks = p.marks[:len(p.marks)-1]
+			return "", nil
+		}
+		return "", p.pop(1)
+	case opPopMark:
+		return "", p.popMark()
+	case opDup:
+		if len(p.stack) == p.fence() {
+			return "", xerrors.New("stack underflow")
+		}
+		p.push(p.top())
+		return "", nil
+	case opTuple, opList, opDict, opFrozenSet, opObj:
+		if err := p.popMark(); err != nil {
+			return "", err
+		}
+		p.push(pickleValue{})
+		return "", nil
+	case opAppends, opSetItems, opAddItems:
+		return "", p.popMark()
+	case opAppend, opBuild, opStop:
+		return "", p.pop(1)
+	case opSetItem:
+		return "", p.pop(2)
+	case opTuple1, opBinPersID, opReadOnlyBuffer:
+		return "", p.replace(1)
+	case opTuple2, opReduce, opNewObj:
+		return "", p.replace(2)
+	case opTuple3, opNewObjEx:
+		return "", p.replace(3)
+	case opGlobal, opInst:
+		module, err := p.line()
+		if err != nil {
+			return "", err
+		}
+		name, err := p.line()
+		if err != nil {
+			return "", err
+		}
+		if op == opInst {
+			// INST instantiates the class with the arguments after MARK
+			if err = p.popMark(); err != nil {
+				return "", err
+			}
+		}
+		p.push(pickleValue{})
+		return module + "." + name, nil
+	case opStackGlobal:
+		if len(p.stack)-p.fence() < 2 {
+			return "", xerrors.New("stack underflow")
+		}
+		module, name := p.stack[len(p.stack)-2], p.stack[len(p.stack)-1]
+		if err := p.replace(2); err != nil {
+			return "", err
+		}
+		if !module.isString || !name.isString || module.str == "" || name.str == "" {
+			return "", errUnresolvedGlobal
+		}
+		return module.str + "." + name.str, nil
+	case opMemoize:
+		p.memo[len(p.memo)] = p.top()
+		return "", nil
+	case opPut:
+		s, err := p.line()
+		if err != nil {
+			return "", err
+		}
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return "", xerrors.Errorf("invalid PUT index: %w", err)
+		}
+		p.memo[i] = p.top()
+		return "", nil
+	case opBinPut:
+		i, err := p.uint(1)
+		p.memo[i] = p.top()
+		return "", err
+	case opLongBinPut:
+		i, err := p.uint(4)
+		p.memo[i] = p.top()
+		return "", err
+	case opGet:
+		s, err := p.line()
+		if err != nil {
+			return "", err
+		}
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return "", xerrors.Errorf("invalid GET index: %w", err)
+		}
+		p.push(p.memo[i])
+		return "", nil
+	case opBinGet:
+		i, err := p.uint(1)
+		p.push(p.memo[i])
+		return "", err
+	case opLongBinGet:
+		i, err := p.uint(4)
+		p.push(p.memo[i])
+		return "", err
+	}
+	return "", xerrors.Errorf("unknown pickle opcode: 0x%02x", op)
+}
+
+// reset clears the state for the next pickle
+func (p *pickleScanner) reset() {
+	p.stack, p.marks, p.memo = nil, nil, make(map[int]pickleValue)
+}
+
+func (p *pickleScanner) push(values ...pickleValue) {
+	p.stack = append(p.stack, values...)
+}
+
+// fence returns the length of the stack at the last MARK, below which objects cannot be popped
+func (p *pickleScanner) fence() int {
+	if len(p.marks) == 0 {
+		return 0
+	}
+	return p.marks[len(p.marks)-1]
+}
+
+func (p *pickleScanner) pop(n int) error {
+	if len(p.stack)-p.fence() < n {
+		return xerrors.New("stack underflow")
+	}
+	p.stack = p.stack[:len(p.stack)-n]
+	return nil
+}
+
+// replace pops the objects and pushes the object made from them
+func (p *pickleScanner) replace(n int) error {
+	if err := p.pop(n); err != nil {
+		return err
+	}
+	p.push(pickleValue{})
+	return nil
+}
+
+// popMark pops the objects after the last MARK and the MARK
+func (p *pickleScanner) popMark() error {
+	if len(p.marks) == 0 {
+		return xerrors.New("MARK not found")
+	}
+	p.stack = p.stack[:p.fence()]
+	p.marks = p.marks[:len(p.marks)-1]
+	return nil
+}
+
+func (p *pickleScanner) top() pickleValue {
+	if len(p.stack) == 0 {
+		return pickleValue{}
+	}
+	return p.stack[len(p.stack)-1]
+}
+
+// pushSkipped skips the argument of the opcode pushing a number
+func (p *pickleScanner) pushSkipped(n int) error {
+	p.push(pickleValue{})
+	return p.skip(n)
+}
+
+// pushString reads the length-prefixed string and pushes it
+func (p *pickleScanner) pushString(op byte, lenSize int) error {
+	n, err := p.uint(lenSize)
+	if err != nil {
+		return err
+	} else if n < 0 {
+		return xerrors.Errorf("invalid length: %d", n)
+	}
+
+	// Long strings and bytes, such as tensors, are not module names
+	if n > maxPickleString || op == opLong1 || op == opLong4 {
+		p.push(pickleValue{})
+		return p.skip(n)
+	}
+	b := make([]byte, n)
+	if _, err = io.ReadFull(p.r, b); err != nil {
+		return xerrors.Errorf("read error: %w", err)
+	}
+	p.push(pickleValue{
+		str:      string(b),
+		isString: true,
+	})
+	return nil
+}
+
+func (p *pickleScanner) uint(size int) (int, error) {
+	b := make([]byte, 8)
+	if _, err := io.ReadFull(p.r, b[:size]); err != nil {
+		return 0, xerrors.Errorf("read error: %w", err)
+	}
+	return int(binary.LittleEndian.Uint64(b)), nil
+}
+
+func (p *pickleScanner) skip(n int) error {
+	if _, err := p.r.Discard(n); err != nil {
+		return xerrors.Errorf("read error: %w", err)
+	}
+	return nil
+}
+
+func (p *pickleScanner) line() (string, error) {
+	// Lines are short in pickles. Longer ones than the buffer mean the data is not a pickle.
+	b, err := p.r.ReadSlice('\n')
+	if err != nil {
+		return "", xerrors.Errorf("read error: %w", err)
+	}
+	return strings.TrimSuffix(string(b), "\n"), nil
+}
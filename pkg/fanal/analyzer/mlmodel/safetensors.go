@@ -0,0 +1,65 @@
+package mlmodel
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"golang.org/x/xerrors"
+)
+
+// safetensors has an 8-byte little-endian header size followed by a JSON header and tensors.
+// ref. https://github.com/huggingface/safetensors#format
+const (
+	safetensorsMetadataKey = "__metadata__"
+	maxSafetensorsHeader   = 100 << 20
+)
+
+type tensorInfo struct {
+	DType string  `json:"dtype"`
+	Shape []int64 `json:"shape"`
+}
+
+func parseSafetensors(r io.Reader) (Model, error) {
+	var size uint64
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return Model{}, xerrors.Errorf("header size error: %w", err)
+	} else if size > maxSafetensorsHeader {
+		return Model{}, xerrors.Errorf("too large header: %d bytes", size)
+	}
+
+	var header map[string]json.RawMessage
+	if err := json.NewDecoder(io.LimitReader(r, int64(size))).Decode(&header); err != nil {
+		return Model{}, xerrors.Errorf("header decode error: %w", err)
+	}
+
+	model := Model{Format: FormatSafetensors}
+	dtypes := make(map[string]struct{})
+	for name, raw := range header {
+		if name == safetensorsMetadataKey {
+			if err := json.Unmarshal(raw, &model.Metadata); err != nil {
+				return Model{}, xerrors.Errorf("metadata decode error: %w", err)
+			}
+			continue
+		}
+
+		var t tensorInfo
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return Model{}, xerrors.Errorf("tensor %q decode error: %w", name, err)
+		}
+		params := int64(1)
+		for _, dim := range t.Shape {
+			params *= dim
+		}
+		model.Tensors++
+		model.Parameters += params
+		dtypes[t.DType] = struct{}{}
+	}
+
+	for dtype := range dtypes {
+		model.DTypes = append(model.DTypes, dtype)
+	}
+	sort.Strings(model.DTypes)
+	return model, nil
+}
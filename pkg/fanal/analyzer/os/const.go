@@ -49,9 +49,15 @@ const (
 	// OpenSUSETumbleweed is done
 	OpenSUSETumbleweed = "opensuse.tumbleweed"
 
+	// OpenSUSELeapMicro is done
+	OpenSUSELeapMicro = "opensuse.leap.micro"
+
 	// SUSE Linux Enterplise Server is done
 	SLES = "suse linux enterprise server"
 
+	// SLEMicro is done
+	SLEMicro = "suse linux enterprise micro"
+
 	// Photon OS done
 	Photon = "photon"
 
@@ -0,0 +1,72 @@
+package debian
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	aos "github.com/zhanglimao/trivy/pkg/fanal/analyzer/os"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&debianELTSAnalyzer{})
+}
+
+const (
+	eltsAnalyzerVersion = 1
+	sourcesListFile     = "etc/apt/sources.list"
+	sourcesListDir      = "etc/apt/sources.list.d/"
+
+	// Freexian provides Extended LTS for Debian releases after the end of LTS
+	// e.g. "deb http://deb.freexian.com/extended-lts stretch main contrib non-free"
+	eltsRepository = "deb.freexian.com/extended-lts"
+)
+
+// debianELTSAnalyzer detects Debian Extended LTS from the APT sources
+type debianELTSAnalyzer struct{}
+
+func (a debianELTSAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	scanner := bufio.NewScanner(input.Content)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		// Both the one-line style and deb822 style ("URIs: ...") are supported
+		if strings.Contains(line, eltsRepository) {
+			return &analyzer.AnalysisResult{
+				OS: types.OS{
+					Family:   aos.Debian,
+					Extended: true,
+				},
+			}, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, xerrors.Errorf("debian ELTS analyze error: %w", err)
+	}
+	// if ELTS is not configured - return nil to reduce the amount of logic in the OS.Merge function
+	return nil, nil
+}
+
+func (a debianELTSAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	if filePath == sourcesListFile {
+		return true
+	}
+	dir, file := path.Split(filePath)
+	return dir == sourcesListDir && (path.Ext(file) == ".list" || path.Ext(file) == ".sources")
+}
+
+func (a debianELTSAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeDebianELTS
+}
+
+func (a debianELTSAnalyzer) Version() int {
+	return eltsAnalyzerVersion
+}
@@ -0,0 +1,97 @@
+package debian
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	aos "github.com/zhanglimao/trivy/pkg/fanal/analyzer/os"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func Test_debianELTSAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputFile string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name:      "one-line style",
+			inputFile: "testdata/elts.list",
+			want: &analyzer.AnalysisResult{
+				OS: types.OS{
+					Family:   aos.Debian,
+					Extended: true,
+				},
+			},
+		},
+		{
+			name:      "deb822 style",
+			inputFile: "testdata/elts.sources",
+			want: &analyzer.AnalysisResult{
+				OS: types.OS{
+					Family:   aos.Debian,
+					Extended: true,
+				},
+			},
+		},
+		{
+			name:      "ELTS is commented out",
+			inputFile: "testdata/sources.list",
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := debianELTSAnalyzer{}
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: "etc/apt/sources.list",
+				Content:  f,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_debianELTSAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{
+			filePath: "etc/apt/sources.list",
+			want:     true,
+		},
+		{
+			filePath: "etc/apt/sources.list.d/elts.list",
+			want:     true,
+		},
+		{
+			filePath: "etc/apt/sources.list.d/elts.sources",
+			want:     true,
+		},
+		{
+			filePath: "etc/apt/sources.list.d/elts.list.save",
+			want:     false,
+		},
+		{
+			filePath: "etc/apt/sources.list.d/nested/elts.list",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			a := debianELTSAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
@@ -55,8 +55,12 @@ func (a osReleaseAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInp
 			family = aos.OpenSUSETumbleweed
 		case "opensuse-leap", "opensuse": // opensuse for leap:42, opensuse-leap for leap:15
 			family = aos.OpenSUSELeap
+		case "opensuse-leap-micro":
+			family = aos.OpenSUSELeapMicro
 		case "sles":
 			family = aos.SLES
+		case "sle-micro", "suse-microos": // suse-microos for SLE Micro 5.0
+			family = aos.SLEMicro
 		case "photon":
 			family = aos.Photon
 		case "wolfi":
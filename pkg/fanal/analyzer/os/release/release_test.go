@@ -56,6 +56,20 @@ func Test_osReleaseAnalyzer_Analyze(t *testing.T) {
 				OS: types.OS{Family: aos.SLES, Name: "15.3"},
 			},
 		},
+		{
+			name:      "SUSE Linux Enterprise Micro",
+			inputFile: "testdata/slemicro",
+			want: &analyzer.AnalysisResult{
+				OS: types.OS{Family: aos.SLEMicro, Name: "5.3"},
+			},
+		},
+		{
+			name:      "openSUSE Leap Micro",
+			inputFile: "testdata/opensuseleapmicro",
+			want: &analyzer.AnalysisResult{
+				OS: types.OS{Family: aos.OpenSUSELeapMicro, Name: "5.3"},
+			},
+		},
 		{
 			name:      "Photon OS",
 			inputFile: "testdata/photon",
@@ -0,0 +1,112 @@
+package permission
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// Allowlist represents the allowlist of files expected to have privileges or loose permissions
+//
+//	files:
+//	  - path: usr/bin/passwd
+//	    setuid: true
+//	  - path: usr/bin/ping
+//	    capabilities:
+//	      - cap_net_raw
+//	  - path: var/cache/app
+//	    world-writable: true
+type Allowlist struct {
+	Files []AllowedFile `yaml:"files"`
+}
+
+// AllowedFile represents the privileges allowed for files matching the path
+type AllowedFile struct {
+	// Path is a glob pattern of file paths in the image, e.g. "usr/lib/**/dbus-daemon-launch-helper"
+	Path string `yaml:"path"`
+
+	Setuid bool `yaml:"setuid"`
+	Setgid bool `yaml:"setgid"`
+
+	// Capabilities are the names of capabilities, e.g. "cap_net_raw"
+	Capabilities []string `yaml:"capabilities"`
+
+	// WorldWritable allows world-writable files and directories without the sticky bit
+	WorldWritable bool `yaml:"world-writable"`
+
+	// WorldReadable allows sensitive files readable by all, e.g. private keys for tests
+	WorldReadable bool `yaml:"world-readable"`
+}
+
+// parseAllowlist parses the allowlist file. An empty allowlist is returned if the path is empty.
+func parseAllowlist(filePath string) (Allowlist, error) {
+	if filePath == "" {
+		return Allowlist{}, nil
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return Allowlist{}, xerrors.Errorf("file open error %s: %w", filePath, err)
+	}
+	defer f.Close()
+
+	log.Logger.Infof("Loading %s for the permission allowlist...", filePath)
+
+	var allowlist Allowlist
+	if err = yaml.NewDecoder(f).Decode(&allowlist); err != nil {
+		return Allowlist{}, xerrors.Errorf("permission allowlist decode error: %w", err)
+	}
+
+	for i := range allowlist.Files {
+		if err = allowlist.Files[i].init(); err != nil {
+			return Allowlist{}, xerrors.Errorf("file %d: %w", i, err)
+		}
+	}
+	return allowlist, nil
+}
+
+func (f *AllowedFile) init() error {
+	if f.Path == "" {
+		return xerrors.New("path must be specified")
+	}
+	// File paths in container images don't have the leading slash
+	f.Path = strings.TrimPrefix(filepath.ToSlash(f.Path), "/")
+	// doublestar doesn't validate the pattern against an empty path, and the syntax is the same as path.Match
+	if _, err := path.Match(f.Path, ""); err != nil {
+		return xerrors.Errorf("invalid path %q: %w", f.Path, err)
+	}
+
+	// Accept the names in upper case and without the prefix, e.g. "CAP_NET_RAW" and "net_raw"
+	for i, c := range f.Capabilities {
+		c = strings.ToLower(c)
+		if !strings.HasPrefix(c, "cap_") {
+			c = "cap_" + c
+		}
+		f.Capabilities[i] = c
+	}
+	return nil
+}
+
+// allowed returns the privileges allowed for the file, merged across the matching entries
+func (l Allowlist) allowed(filePath string) AllowedFile {
+	allowed := AllowedFile{Path: filePath}
+	for _, f := range l.Files {
+		if ok, _ := doublestar.Match(f.Path, filePath); !ok {
+			continue
+		}
+		allowed.Setuid = allowed.Setuid || f.Setuid
+		allowed.Setgid = allowed.Setgid || f.Setgid
+		allowed.Capabilities = lo.Union(allowed.Capabilities, f.Capabilities)
+		allowed.WorldWritable = allowed.WorldWritable || f.WorldWritable
+		allowed.WorldReadable = allowed.WorldReadable || f.WorldReadable
+	}
+	return allowed
+}
@@ -0,0 +1,117 @@
+package permission
+
+import (
+	"archive/tar"
+	"encoding/binary"
+	"fmt"
+	"os"
+
+	"golang.org/x/xerrors"
+)
+
+// capabilityRecord is the PAX record of the extended attribute holding file capabilities in layer tarballs
+const capabilityRecord = "SCHILY.xattr.security.capability"
+
+// Revisions of vfs_cap_data in linux/capability.h
+const (
+	vfsCapRevisionMask = 0xFF000000
+	vfsCapRevision1    = 0x01000000
+	vfsCapRevision2    = 0x02000000
+	vfsCapRevision3    = 0x03000000
+)
+
+// capabilityNames are the names of capabilities indexed by the bit number
+var capabilityNames = []string{
+	"cap_chown",
+	"cap_dac_override",
+	"cap_dac_read_search",
+	"cap_fowner",
+	"cap_fsetid",
+	"cap_kill",
+	"cap_setgid",
+	"cap_setuid",
+	"cap_setpcap",
+	"cap_linux_immutable",
+	"cap_net_bind_service",
+	"cap_net_broadcast",
+	"cap_net_admin",
+	"cap_net_raw",
+	"cap_ipc_lock",
+	"cap_ipc_owner",
+	"cap_sys_module",
+	"cap_sys_rawio",
+	"cap_sys_chroot",
+	"cap_sys_ptrace",
+	"cap_sys_pacct",
+	"cap_sys_admin",
+	"cap_sys_boot",
+	"cap_sys_nice",
+	"cap_sys_resource",
+	"cap_sys_time",
+	"cap_sys_tty_config",
+	"cap_mknod",
+	"cap_lease",
+	"cap_audit_write",
+	"cap_audit_control",
+	"cap_setfcap",
+	"cap_mac_override",
+	"cap_mac_admin",
+	"cap_syslog",
+	"cap_wake_alarm",
+	"cap_block_suspend",
+	"cap_audit_read",
+	"cap_perfmon",
+	"cap_bpf",
+	"cap_checkpoint_restore",
+}
+
+// capabilityValue returns the raw value of file capabilities.
+// Capabilities are available only in layer tarballs as extended attributes are not read from filesystems.
+func capabilityValue(info os.FileInfo) (string, bool) {
+	hdr, ok := info.Sys().(*tar.Header)
+	if !ok {
+		return "", false
+	}
+	v, ok := hdr.PAXRecords[capabilityRecord]
+	return v, ok
+}
+
+// parseCapabilities returns the names of permitted and inheritable capabilities in vfs_cap_data
+func parseCapabilities(b []byte) ([]string, error) {
+	if len(b) < 4 {
+		return nil, xerrors.New("too short capabilities")
+	}
+
+	var words int
+	switch rev := binary.LittleEndian.Uint32(b) & vfsCapRevisionMask; rev {
+	case vfsCapRevision1:
+		words = 1
+	case vfsCapRevision2, vfsCapRevision3: // revision 3 has the root ID of user namespaces in addition
+		words = 2
+	default:
+		return nil, xerrors.Errorf("unknown capability revision: %#x", rev)
+	}
+	if len(b) < 4+8*words {
+		return nil, xerrors.New("too short capabilities")
+	}
+
+	var caps uint64
+	for i := 0; i < words; i++ {
+		permitted := binary.LittleEndian.Uint32(b[4+8*i:])
+		inheritable := binary.LittleEndian.Uint32(b[8+8*i:])
+		caps |= uint64(permitted|inheritable) << (32 * i)
+	}
+
+	var names []string
+	for bit := 0; bit < 64; bit++ {
+		if caps&(1<<bit) == 0 {
+			continue
+		}
+		if bit < len(capabilityNames) {
+			names = append(names, capabilityNames[bit])
+		} else {
+			names = append(names, fmt.Sprintf("cap_%d", bit))
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,239 @@
+package permission
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&permissionAnalyzer{})
+}
+
+const (
+	version = 2
+
+	// FileTypePermission is the file type of misconfigurations detected in file permissions
+	FileTypePermission = "permission"
+)
+
+var (
+	setuidCheck = types.PolicyMetadata{
+		ID:          "PERM001",
+		Type:        "File Permission Check",
+		Title:       "Executables should not be setuid",
+		Description: "A setuid executable runs with the privileges of its owner, usually root. A vulnerability in it allows privilege escalation inside the container.",
+		Severity:    "HIGH",
+		RecommendedActions: "Remove the setuid bit with 'chmod u-s' if the executable doesn't need it. " +
+			"Otherwise, add it to the permission allowlist.",
+		References: []string{"https://man7.org/linux/man-pages/man2/execve.2.html"},
+	}
+	setgidCheck = types.PolicyMetadata{
+		ID:          "PERM002",
+		Type:        "File Permission Check",
+		Title:       "Executables should not be setgid",
+		Description: "A setgid executable runs with the privileges of its group. A vulnerability in it allows privilege escalation inside the container.",
+		Severity:    "MEDIUM",
+		RecommendedActions: "Remove the setgid bit with 'chmod g-s' if the executable doesn't need it. " +
+			"Otherwise, add it to the permission allowlist.",
+		References: []string{"https://man7.org/linux/man-pages/man2/execve.2.html"},
+	}
+	capabilityCheck = types.PolicyMetadata{
+		ID:          "PERM003",
+		Type:        "File Permission Check",
+		Title:       "Executables should not have file capabilities",
+		Description: "File capabilities grant an executable a subset of root privileges, such as raw sockets or bypassing file permission checks, when it is run.",
+		Severity:    "HIGH",
+		RecommendedActions: "Remove the capabilities with 'setcap -r' if the executable doesn't need them. " +
+			"Otherwise, add them to the permission allowlist.",
+		References: []string{"https://man7.org/linux/man-pages/man7/capabilities.7.html"},
+	}
+	worldWritableDirCheck = types.PolicyMetadata{
+		ID:          "PERM004",
+		Type:        "File Permission Check",
+		Title:       "World-writable directories should have the sticky bit",
+		Description: "Any user can remove and replace files of other users in a world-writable directory without the sticky bit.",
+		Severity:    "MEDIUM",
+		RecommendedActions: "Remove the write permission for others with 'chmod o-w', or set the sticky bit with 'chmod +t' for shared directories such as /tmp. " +
+			"Otherwise, add it to the permission allowlist.",
+		References: []string{"https://man7.org/linux/man-pages/man1/chmod.1.html"},
+	}
+	worldWritableFileCheck = types.PolicyMetadata{
+		ID:          "PERM005",
+		Type:        "File Permission Check",
+		Title:       "Files should not be world-writable",
+		Description: "Any user can modify a world-writable file. Modified executables and configuration files allow privilege escalation when they are used by other users.",
+		Severity:    "HIGH",
+		RecommendedActions: "Remove the write permission for others with 'chmod o-w'. " +
+			"Otherwise, add it to the permission allowlist.",
+		References: []string{"https://man7.org/linux/man-pages/man1/chmod.1.html"},
+	}
+	sensitiveFileCheck = types.PolicyMetadata{
+		ID:          "PERM006",
+		Type:        "File Permission Check",
+		Title:       "Sensitive files should not be readable by all",
+		Description: "Private keys and password hashes readable by all can be stolen by any user or process in the container.",
+		Severity:    "HIGH",
+		RecommendedActions: "Remove the read permission for others with 'chmod o-r', e.g. 0600 for private keys. " +
+			"Otherwise, add it to the permission allowlist.",
+		References: []string{"https://man7.org/linux/man-pages/man1/chmod.1.html"},
+	}
+
+	// sensitiveFiles are glob patterns of files holding credentials which only the owner should read
+	sensitiveFiles = []string{
+		"etc/shadow",
+		"etc/shadow-",
+		"etc/gshadow",
+		"etc/gshadow-",
+		"etc/ssh/ssh_host_*_key",
+		"**/id_rsa",
+		"**/id_dsa",
+		"**/id_ecdsa",
+		"**/id_ed25519",
+		"**/*.key",
+	}
+)
+
+// Permission bits for others
+const (
+	otherRead  os.FileMode = 0004
+	otherWrite os.FileMode = 0002
+)
+
+// permissionAnalyzer audits setuid and setgid executables, file capabilities, world-writable files and directories,
+// and sensitive files readable by all for hardening.
+// Permissions not in the allowlist are reported as misconfigurations of the files,
+// and allowed ones as exceptions. It needs only file information and doesn't open files.
+// Files without findings are reported without results too, so that a file changed in an upper layer,
+// e.g. by chmod, overrides the findings in lower layers.
+type permissionAnalyzer struct {
+	allowlist Allowlist
+}
+
+// Init loads the allowlist
+func (a *permissionAnalyzer) Init(opt analyzer.AnalyzerOptions) error {
+	allowlist, err := parseAllowlist(opt.PermissionOption.AllowlistPath)
+	if err != nil {
+		return xerrors.Errorf("permission allowlist error: %w", err)
+	}
+	a.allowlist = allowlist
+	return nil
+}
+
+func (a *permissionAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	allowed := a.allowlist.allowed(input.FilePath)
+	mode := input.Info.Mode()
+
+	misconf := types.Misconfiguration{
+		FileType: FileTypePermission,
+		FilePath: input.FilePath,
+	}
+	add := func(check types.PolicyMetadata, msg string, ok bool) {
+		res := types.MisconfResult{
+			Namespace:      "permission." + check.ID,
+			Message:        msg,
+			PolicyMetadata: check,
+		}
+		if ok {
+			misconf.Exceptions = append(misconf.Exceptions, res)
+		} else {
+			misconf.Failures = append(misconf.Failures, res)
+		}
+	}
+
+	if mode.IsDir() {
+		if worldWritableDir(mode) {
+			add(worldWritableDirCheck, fmt.Sprintf("Directory is world-writable without the sticky bit with mode %s", octalMode(mode)),
+				allowed.WorldWritable)
+		}
+		return result(misconf), nil
+	}
+
+	if mode&os.ModeSetuid != 0 {
+		add(setuidCheck, fmt.Sprintf("File is setuid with mode %s", octalMode(mode)), allowed.Setuid)
+	}
+	if mode&os.ModeSetgid != 0 {
+		add(setgidCheck, fmt.Sprintf("File is setgid with mode %s", octalMode(mode)), allowed.Setgid)
+	}
+
+	if v, ok := capabilityValue(input.Info); ok {
+		caps, err := parseCapabilities([]byte(v))
+		if err != nil {
+			log.Logger.Debugf("Unable to parse capabilities of %s: %s", input.FilePath, err)
+		}
+		if denied := lo.Without(caps, allowed.Capabilities...); len(denied) > 0 {
+			add(capabilityCheck, fmt.Sprintf("File has capabilities %s", strings.Join(denied, ", ")), false)
+		} else if len(caps) > 0 {
+			add(capabilityCheck, fmt.Sprintf("File has capabilities %s", strings.Join(caps, ", ")), true)
+		}
+	}
+
+	if mode&otherWrite != 0 {
+		add(worldWritableFileCheck, fmt.Sprintf("File is world-writable with mode %s", octalMode(mode)), allowed.WorldWritable)
+	}
+	if mode&otherRead != 0 && sensitive(input.FilePath) {
+		add(sensitiveFileCheck, fmt.Sprintf("Sensitive file is readable by all with mode %s", octalMode(mode)), allowed.WorldReadable)
+	}
+
+	return result(misconf), nil
+}
+
+func result(misconf types.Misconfiguration) *analyzer.AnalysisResult {
+	return &analyzer.AnalysisResult{
+		Misconfigurations: []types.Misconfiguration{misconf},
+	}
+}
+
+// worldWritableDir returns true if any user can remove and replace files of other users in the directory
+func worldWritableDir(mode os.FileMode) bool {
+	return mode&otherWrite != 0 && mode&os.ModeSticky == 0
+}
+
+func sensitive(filePath string) bool {
+	return lo.ContainsBy(sensitiveFiles, func(pattern string) bool {
+		ok, _ := doublestar.Match(pattern, filePath)
+		return ok
+	})
+}
+
+// octalMode returns the mode in the octal notation of chmod, e.g. "4755"
+func octalMode(mode os.FileMode) string {
+	m := uint32(mode.Perm())
+	if mode&os.ModeSetuid != 0 {
+		m |= 04000
+	}
+	if mode&os.ModeSetgid != 0 {
+		m |= 02000
+	}
+	if mode&os.ModeSticky != 0 {
+		m |= 01000
+	}
+	return fmt.Sprintf("%04o", m)
+}
+
+// Required returns true for every file and directory, as the permissions in lower layers may be changed
+func (a *permissionAnalyzer) Required(_ string, fileInfo os.FileInfo) bool {
+	mode := fileInfo.Mode()
+	return mode.IsDir() || mode.IsRegular()
+}
+
+func (a *permissionAnalyzer) FileInfoOnly() {}
+
+func (a *permissionAnalyzer) AnalyzeDirs() {}
+
+func (a *permissionAnalyzer) Type() analyzer.Type {
+	return analyzer.TypePermission
+}
+
+func (a *permissionAnalyzer) Version() int {
+	return version
+}
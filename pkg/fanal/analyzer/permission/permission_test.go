@@ -0,0 +1,431 @@
+package permission
+
+import (
+	"archive/tar"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// netCapabilities is vfs_cap_data revision 2 with cap_net_admin and cap_net_raw permitted and effective
+const netCapabilities = "\x01\x00\x00\x02\x00\x30\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"
+
+func fileInfo(mode int64, caps string) os.FileInfo {
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Mode:     mode,
+	}
+	if caps != "" {
+		hdr.PAXRecords = map[string]string{capabilityRecord: caps}
+	}
+	return hdr.FileInfo()
+}
+
+func dirInfo(mode int64) os.FileInfo {
+	hdr := &tar.Header{
+		Typeflag: tar.TypeDir,
+		Mode:     mode,
+	}
+	return hdr.FileInfo()
+}
+
+func Test_permissionAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowlistPath string
+		filePath      string
+		info          os.FileInfo
+		want          *analyzer.AnalysisResult
+	}{
+		{
+			name:     "setuid and setgid",
+			filePath: "usr/bin/chage",
+			info:     fileInfo(06755, ""),
+			want: &analyzer.AnalysisResult{
+				Misconfigurations: []types.Misconfiguration{
+					{
+						FileType: FileTypePermission,
+						FilePath: "usr/bin/chage",
+						Failures: types.MisconfResults{
+							{
+								Namespace:      "permission.PERM001",
+								Message:        "File is setuid with mode 6755",
+								PolicyMetadata: setuidCheck,
+							},
+							{
+								Namespace:      "permission.PERM002",
+								Message:        "File is setgid with mode 6755",
+								PolicyMetadata: setgidCheck,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:          "allowed setuid",
+			allowlistPath: "testdata/allowlist.yaml",
+			filePath:      "usr/bin/passwd",
+			info:          fileInfo(04755, ""),
+			want: &analyzer.AnalysisResult{
+				Misconfigurations: []types.Misconfiguration{
+					{
+						FileType: FileTypePermission,
+						FilePath: "usr/bin/passwd",
+						Exceptions: types.MisconfResults{
+							{
+								Namespace:      "permission.PERM001",
+								Message:        "File is setuid with mode 4755",
+								PolicyMetadata: setuidCheck,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:          "capabilities partially allowed",
+			allowlistPath: "testdata/allowlist.yaml",
+			filePath:      "usr/bin/ping",
+			info:          fileInfo(0755, netCapabilities),
+			want: &analyzer.AnalysisResult{
+				Misconfigurations: []types.Misconfiguration{
+					{
+						FileType: FileTypePermission,
+						FilePath: "usr/bin/ping",
+						Failures: types.MisconfResults{
+							{
+								Namespace:      "permission.PERM003",
+								Message:        "File has capabilities cap_net_admin",
+								PolicyMetadata: capabilityCheck,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "world-writable directory",
+			filePath: "var/cache/app",
+			info:     dirInfo(0777),
+			want: &analyzer.AnalysisResult{
+				Misconfigurations: []types.Misconfiguration{
+					{
+						FileType: FileTypePermission,
+						FilePath: "var/cache/app",
+						Failures: types.MisconfResults{
+							{
+								Namespace:      "permission.PERM004",
+								Message:        "Directory is world-writable without the sticky bit with mode 0777",
+								PolicyMetadata: worldWritableDirCheck,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:          "allowed world-writable directory",
+			allowlistPath: "testdata/allowlist.yaml",
+			filePath:      "var/cache/app",
+			info:          dirInfo(0777),
+			want: &analyzer.AnalysisResult{
+				Misconfigurations: []types.Misconfiguration{
+					{
+						FileType: FileTypePermission,
+						FilePath: "var/cache/app",
+						Exceptions: types.MisconfResults{
+							{
+								Namespace:      "permission.PERM004",
+								Message:        "Directory is world-writable without the sticky bit with mode 0777",
+								PolicyMetadata: worldWritableDirCheck,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "sticky directory",
+			filePath: "tmp",
+			info:     dirInfo(01777),
+			want: &analyzer.AnalysisResult{
+				Misconfigurations: []types.Misconfiguration{
+					{
+						FileType: FileTypePermission,
+						FilePath: "tmp",
+					},
+				},
+			},
+		},
+		{
+			name:     "world-writable file",
+			filePath: "app/run.sh",
+			info:     fileInfo(0777, ""),
+			want: &analyzer.AnalysisResult{
+				Misconfigurations: []types.Misconfiguration{
+					{
+						FileType: FileTypePermission,
+						FilePath: "app/run.sh",
+						Failures: types.MisconfResults{
+							{
+								Namespace:      "permission.PERM005",
+								Message:        "File is world-writable with mode 0777",
+								PolicyMetadata: worldWritableFileCheck,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "private key readable by all",
+			filePath: "root/.ssh/id_ed25519",
+			info:     fileInfo(0644, ""),
+			want: &analyzer.AnalysisResult{
+				Misconfigurations: []types.Misconfiguration{
+					{
+						FileType: FileTypePermission,
+						FilePath: "root/.ssh/id_ed25519",
+						Failures: types.MisconfResults{
+							{
+								Namespace:      "permission.PERM006",
+								Message:        "Sensitive file is readable by all with mode 0644",
+								PolicyMetadata: sensitiveFileCheck,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "private key readable by the owner",
+			filePath: "etc/ssl/private/server.key",
+			info:     fileInfo(0600, ""),
+			want: &analyzer.AnalysisResult{
+				Misconfigurations: []types.Misconfiguration{
+					{
+						FileType: FileTypePermission,
+						FilePath: "etc/ssl/private/server.key",
+					},
+				},
+			},
+		},
+		{
+			name:     "no privileges",
+			filePath: "usr/bin/ls",
+			info:     fileInfo(0755, ""),
+			want: &analyzer.AnalysisResult{
+				Misconfigurations: []types.Misconfiguration{
+					{
+						FileType: FileTypePermission,
+						FilePath: "usr/bin/ls",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := permissionAnalyzer{}
+			err := a.Init(analyzer.AnalyzerOptions{
+				PermissionOption: analyzer.PermissionOption{
+					AllowlistPath: tt.allowlistPath,
+				},
+			})
+			require.NoError(t, err)
+
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.filePath,
+				Info:     tt.info,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_permissionAnalyzer_Required(t *testing.T) {
+	stat, err := os.Stat("permission.go")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		filePath string
+		info     os.FileInfo
+		want     bool
+	}{
+		{
+			name: "setuid",
+			info: fileInfo(04755, ""),
+			want: true,
+		},
+		{
+			name: "setgid",
+			info: fileInfo(02755, ""),
+			want: true,
+		},
+		{
+			name: "capabilities",
+			info: fileInfo(0755, netCapabilities),
+			want: true,
+		},
+		{
+			name: "world-writable file",
+			info: fileInfo(0666, ""),
+			want: true,
+		},
+		{
+			name:     "shadow readable by all",
+			filePath: "etc/shadow",
+			info:     fileInfo(0644, ""),
+			want:     true,
+		},
+		{
+			name: "world-writable directory",
+			info: dirInfo(0777),
+			want: true,
+		},
+		{
+			name: "sticky directory",
+			info: dirInfo(01777),
+			want: true,
+		},
+		{
+			name: "regular file in filesystem",
+			info: stat,
+			want: true,
+		},
+		{
+			name: "symlink",
+			info: (&tar.Header{Typeflag: tar.TypeSymlink}).FileInfo(),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := permissionAnalyzer{}
+			filePath := tt.filePath
+			if filePath == "" {
+				filePath = "usr/bin/test"
+			}
+			got := a.Required(filePath, tt.info)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseCapabilities(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:  "revision 2",
+			value: netCapabilities,
+			want:  []string{"cap_net_admin", "cap_net_raw"},
+		},
+		{
+			name:  "revision 3 with inheritable capabilities in the upper word",
+			value: "\x00\x00\x00\x03\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x80\x00\x00\x00\x00\x00\x00\x00",
+			want:  []string{"cap_bpf"},
+		},
+		{
+			name:  "revision 1",
+			value: "\x00\x00\x00\x01\x00\x00\x20\x00\x00\x00\x00\x00",
+			want:  []string{"cap_sys_admin"},
+		},
+		{
+			name:    "unknown revision",
+			value:   "\x00\x00\x00\x04\x00\x00\x00\x00",
+			wantErr: "unknown capability revision: 0x4000000",
+		},
+		{
+			name:    "too short",
+			value:   "\x00\x00\x00\x02\x00\x30",
+			wantErr: "too short capabilities",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCapabilities([]byte(tt.value))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseAllowlist(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     Allowlist
+		wantErr  string
+	}{
+		{
+			name:     "happy path",
+			filePath: "testdata/allowlist.yaml",
+			want: Allowlist{
+				Files: []AllowedFile{
+					{
+						Path:   "usr/bin/passwd",
+						Setuid: true,
+					},
+					{
+						Path:         "usr/bin/ping",
+						Capabilities: []string{"cap_net_raw"},
+					},
+					{
+						Path:   "usr/lib/**/dbus-daemon-launch-helper",
+						Setuid: true,
+					},
+					{
+						Path:          "var/cache/*",
+						WorldWritable: true,
+					},
+				},
+			},
+		},
+		{
+			name: "no allowlist",
+		},
+		{
+			name:     "no path",
+			filePath: "testdata/no-path.yaml",
+			wantErr:  "path must be specified",
+		},
+		{
+			name:     "invalid path",
+			filePath: "testdata/invalid-path.yaml",
+			wantErr:  "invalid path",
+		},
+		{
+			name:     "missing file",
+			filePath: "testdata/missing.yaml",
+			wantErr:  "file open error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAllowlist(tt.filePath)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -96,6 +96,8 @@ func (a alpinePkgAnalyzer) parseApkInfo(scanner *bufio.Scanner) ([]types.Package
 			pkg.DependsOn = a.parseDependencies(line)
 		case "A:":
 			pkg.Arch = line[2:]
+		case "s:": // the tag of the repository the package is pinned to, e.g. "apk add curl@testing"
+			pkg.RepositoryTag = line[2:]
 		case "C:":
 			d := decodeChecksumLine(line)
 			if d != "" {
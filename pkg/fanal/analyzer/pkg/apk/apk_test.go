@@ -292,6 +292,25 @@ func TestParseApkInfo(t *testing.T) {
 				"usr/include/sqlite3.h",
 			},
 		},
+		"Tagged": {
+			path: "./testdata/apk-tagged",
+			wantPkgs: []types.Package{
+				{
+					ID:            "curl@8.3.0-r0",
+					Name:          "curl",
+					Version:       "8.3.0-r0",
+					SrcName:       "curl",
+					SrcVersion:    "8.3.0-r0",
+					Licenses:      []string{"curl"},
+					Arch:          "x86_64",
+					RepositoryTag: "edge",
+					Digest:        "sha1:4f49fa3d8ff18eaeaaad7c5f8861831c826e5eee",
+				},
+			},
+			wantFiles: []string{
+				"usr/bin/curl",
+			},
+		},
 	}
 	a := alpinePkgAnalyzer{}
 	for testname, v := range tests {
@@ -53,7 +53,7 @@ var (
 
 func (a dpkgAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAnalysisInput) (*analyzer.AnalysisResult, error) {
 	var systemInstalledFiles []string
-	var packageInfos []types.PackageInfo
+	var statuses []dpkgStatus
 
 	// parse `available` file to get digest for packages
 	digests, err := a.parseDpkgAvailable(input.FS)
@@ -77,12 +77,21 @@ func (a dpkgAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAnalysis
 			systemInstalledFiles = append(systemInstalledFiles, systemFiles...)
 			return nil
 		}
+		// parse md5sums files in distroless images
+		if a.isMD5SumsFile(filepath.Split(path)) {
+			systemFiles, err := a.parseDpkgMD5Sums(bufio.NewScanner(r))
+			if err != nil {
+				return err
+			}
+			systemInstalledFiles = append(systemInstalledFiles, systemFiles...)
+			return nil
+		}
 		// parse status files
-		infos, err := a.parseDpkgStatus(path, r, digests)
+		status, err := a.parseDpkgStatus(path, r, digests)
 		if err != nil {
 			return err
 		}
-		packageInfos = append(packageInfos, infos...)
+		statuses = append(statuses, status)
 		return nil
 	})
 	if err != nil {
@@ -90,7 +99,7 @@ func (a dpkgAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAnalysis
 	}
 
 	return &analyzer.AnalysisResult{
-		PackageInfos:         packageInfos,
+		PackageInfos:         a.consolidatePackages(statuses),
 		SystemInstalledFiles: systemInstalledFiles,
 	}, nil
 
@@ -128,6 +137,25 @@ func (a dpkgAnalyzer) parseDpkgInfoList(scanner *bufio.Scanner) ([]string, error
 	return installedFiles, nil
 }
 
+// parseDpkgMD5Sums parses /var/lib/dpkg/status.d/*.md5sums
+// e.g. 0a2a0c1a1b1e8a5c1f8a6f2f7a3d8c5b  usr/lib/x86_64-linux-gnu/libssl.so.3
+func (a dpkgAnalyzer) parseDpkgMD5Sums(scanner *bufio.Scanner) ([]string, error) {
+	var installedFiles []string
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		installedFiles = append(installedFiles, fields[1])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, xerrors.Errorf("scan error: %w", err)
+	}
+
+	return installedFiles, nil
+}
+
 // parseDpkgAvailable parses /var/lib/dpkg/available
 func (a dpkgAnalyzer) parseDpkgAvailable(fsys fs.FS) (map[string]digest.Digest, error) {
 	f, err := fsys.Open(availableFile)
@@ -157,11 +185,23 @@ func (a dpkgAnalyzer) parseDpkgAvailable(fsys fs.FS) (map[string]digest.Digest,
 	return pkgs, nil
 }
 
+// dpkgStatus holds the packages parsed from a status file.
+// Their dependencies are consolidated after all status files are parsed
+// since each package has its own file in /var/lib/dpkg/status.d/.
+type dpkgStatus struct {
+	filePath string
+	pkgs     []*types.Package
+
+	// Source packages statically linked into the binary packages, keyed by the binary package ID
+	builtUsing map[string][]*types.Package
+}
+
 // parseDpkgStatus parses /var/lib/dpkg/status or /var/lib/dpkg/status/*
-func (a dpkgAnalyzer) parseDpkgStatus(filePath string, r dio.ReadSeekerAt, digests map[string]digest.Digest) ([]types.PackageInfo, error) {
-	var pkg *types.Package
-	pkgs := map[string]*types.Package{}
-	pkgIDs := map[string]string{}
+func (a dpkgAnalyzer) parseDpkgStatus(filePath string, r dio.ReadSeekerAt, digests map[string]digest.Digest) (dpkgStatus, error) {
+	status := dpkgStatus{
+		filePath:   filePath,
+		builtUsing: map[string][]*types.Package{},
+	}
 
 	scanner := NewScanner(r)
 	for scanner.Scan() {
@@ -171,28 +211,72 @@ func (a dpkgAnalyzer) parseDpkgStatus(filePath string, r dio.ReadSeekerAt, diges
 			continue
 		}
 
-		pkg = a.parseDpkgPkg(header)
+		pkg := a.parseDpkgPkg(header)
 		if pkg != nil {
 			pkg.Digest = digests[pkg.ID]
-			pkgs[pkg.ID] = pkg
-			pkgIDs[pkg.Name] = pkg.ID
+			status.pkgs = append(status.pkgs, pkg)
+			if builtUsing := a.parseBuiltUsing(header); len(builtUsing) > 0 {
+				status.builtUsing[pkg.ID] = builtUsing
+			}
 		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, xerrors.Errorf("scan error: %w", err)
+		return dpkgStatus{}, xerrors.Errorf("scan error: %w", err)
+	}
+
+	return status, nil
+}
+
+// consolidatePackages resolves dependencies across all status files
+// and adds source packages in Built-Using as indirect dependencies.
+func (a dpkgAnalyzer) consolidatePackages(statuses []dpkgStatus) []types.PackageInfo {
+	// e.g. libc6 => libc6@2.31-13+deb11u4
+	pkgIDs := map[string]string{}
+	installed := map[string]struct{}{}
+	for _, status := range statuses {
+		for _, pkg := range status.pkgs {
+			pkgIDs[pkg.Name] = pkg.ID
+			installed[pkg.ID] = struct{}{}
+		}
 	}
 
-	a.consolidateDependencies(pkgs, pkgIDs)
+	var pkgInfos []types.PackageInfo
+	seen := map[string]struct{}{}
+	for _, status := range statuses {
+		pkgs := map[string]*types.Package{}
+		for _, pkg := range status.pkgs {
+			pkgs[pkg.ID] = pkg
+		}
+		a.consolidateDependencies(pkgs, pkgIDs)
+
+		for _, pkg := range status.pkgs {
+			for _, src := range status.builtUsing[pkg.ID] {
+				pkg.DependsOn = append(pkg.DependsOn, src.ID)
+
+				// The same source package might be used by several binary packages
+				if _, ok := installed[src.ID]; ok {
+					continue
+				} else if _, ok = seen[src.ID]; ok {
+					continue
+				}
+				seen[src.ID] = struct{}{}
+				pkgs[src.ID] = src
+			}
+			if len(status.builtUsing[pkg.ID]) > 0 {
+				pkg.DependsOn = lo.Uniq(pkg.DependsOn)
+				sort.Strings(pkg.DependsOn)
+			}
+		}
 
-	return []types.PackageInfo{
-		{
-			FilePath: filePath,
+		pkgInfos = append(pkgInfos, types.PackageInfo{
+			FilePath: status.filePath,
 			Packages: lo.MapToSlice(pkgs, func(_ string, p *types.Package) types.Package {
 				return *p
 			}),
-		},
-	}, nil
+		})
+	}
+	return pkgInfos
 }
 
 func (a dpkgAnalyzer) parseDpkgPkg(header textproto.MIMEHeader) *types.Package {
@@ -260,6 +344,43 @@ func (a dpkgAnalyzer) parseDpkgPkg(header textproto.MIMEHeader) *types.Package {
 	return pkg
 }
 
+// parseBuiltUsing parses the source packages statically linked into the binary package.
+// Packages written in Go or Rust have them in Static-Built-Using as well.
+// e.g. Built-Using: golang-1.19 (= 1.19.8-2), libseccomp (= 2.5.4-1)
+func (a dpkgAnalyzer) parseBuiltUsing(header textproto.MIMEHeader) []*types.Package {
+	var pkgs []*types.Package
+	for _, field := range []string{"Built-Using", "Static-Built-Using"} {
+		for _, src := range strings.Split(header.Get(field), ",") {
+			name, ver, ok := strings.Cut(strings.TrimSpace(src), "(=")
+			if !ok {
+				continue
+			}
+			name = strings.TrimSpace(name)
+			ver = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(ver), ")"))
+
+			v, err := debVersion.NewVersion(ver)
+			if name == "" || err != nil {
+				log.Logger.Debugw("Invalid Built-Using", zap.String("package", header.Get("Package")),
+					zap.String("value", src))
+				continue
+			}
+			pkgs = append(pkgs, &types.Package{
+				ID:         a.pkgID(name, ver),
+				Name:       name,
+				Version:    v.Version(),
+				Epoch:      v.Epoch(),
+				Release:    v.Revision(),
+				SrcName:    name,
+				SrcVersion: v.Version(),
+				SrcEpoch:   v.Epoch(),
+				SrcRelease: v.Revision(),
+				Indirect:   true,
+			})
+		}
+	}
+	return pkgs
+}
+
 func (a dpkgAnalyzer) Required(filePath string, _ os.FileInfo) bool {
 	dir, fileName := filepath.Split(filePath)
 	if a.isListFile(dir, fileName) || filePath == statusFile || filePath == availableFile {
@@ -335,6 +456,14 @@ func (a dpkgAnalyzer) isListFile(dir, fileName string) bool {
 	return strings.HasSuffix(fileName, ".list")
 }
 
+func (a dpkgAnalyzer) isMD5SumsFile(dir, fileName string) bool {
+	if dir != statusDir {
+		return false
+	}
+
+	return strings.HasSuffix(fileName, ".md5sums")
+}
+
 func (a dpkgAnalyzer) Type() analyzer.Type {
 	return analyzer.TypeDpkg
 }
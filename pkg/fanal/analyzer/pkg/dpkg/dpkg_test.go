@@ -1315,6 +1315,110 @@ func Test_dpkgAnalyzer_Analyze(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "status.d",
+			testFiles: map[string]string{
+				"./testdata/status.d/libc6":           "var/lib/dpkg/status.d/libc6",
+				"./testdata/status.d/libssl3":         "var/lib/dpkg/status.d/libssl3",
+				"./testdata/status.d/libssl3.md5sums": "var/lib/dpkg/status.d/libssl3.md5sums",
+				"./testdata/status.d/runc":            "var/lib/dpkg/status.d/runc",
+			},
+			want: &analyzer.AnalysisResult{
+				PackageInfos: []types.PackageInfo{
+					{
+						FilePath: "var/lib/dpkg/status.d/libc6",
+						Packages: []types.Package{
+							{
+								ID:         "libc6@2.36-9+deb12u1",
+								Name:       "libc6",
+								Version:    "2.36",
+								Release:    "9+deb12u1",
+								SrcName:    "glibc",
+								SrcVersion: "2.36",
+								SrcRelease: "9+deb12u1",
+								Maintainer: "GNU Libc Maintainers <debian-glibc@lists.debian.org>",
+								Arch:       "amd64",
+							},
+						},
+					},
+					{
+						FilePath: "var/lib/dpkg/status.d/libssl3",
+						Packages: []types.Package{
+							{
+								ID:         "libssl3@3.0.9-1",
+								Name:       "libssl3",
+								Version:    "3.0.9",
+								Release:    "1",
+								SrcName:    "openssl",
+								SrcVersion: "3.0.9",
+								SrcRelease: "1",
+								DependsOn: []string{
+									"libc6@2.36-9+deb12u1",
+								},
+								Maintainer: "Debian OpenSSL Team <pkg-openssl-devel@alioth-lists.debian.net>",
+								Arch:       "amd64",
+							},
+						},
+					},
+					{
+						FilePath: "var/lib/dpkg/status.d/runc",
+						Packages: []types.Package{
+							{
+								ID:         "golang-1.19@1.19.8-2",
+								Name:       "golang-1.19",
+								Version:    "1.19.8",
+								Release:    "2",
+								SrcName:    "golang-1.19",
+								SrcVersion: "1.19.8",
+								SrcRelease: "2",
+								Indirect:   true,
+							},
+							{
+								ID:         "golang-github-opencontainers-selinux@1.10.2+ds1-1",
+								Name:       "golang-github-opencontainers-selinux",
+								Version:    "1.10.2+ds1",
+								Release:    "1",
+								SrcName:    "golang-github-opencontainers-selinux",
+								SrcVersion: "1.10.2+ds1",
+								SrcRelease: "1",
+								Indirect:   true,
+							},
+							{
+								ID:         "libseccomp@2.5.4-1",
+								Name:       "libseccomp",
+								Version:    "2.5.4",
+								Release:    "1",
+								SrcName:    "libseccomp",
+								SrcVersion: "2.5.4",
+								SrcRelease: "1",
+								Indirect:   true,
+							},
+							{
+								ID:         "runc@1.1.5+ds1-1+b1",
+								Name:       "runc",
+								Version:    "1.1.5+ds1",
+								Release:    "1+b1",
+								SrcName:    "runc",
+								SrcVersion: "1.1.5+ds1",
+								SrcRelease: "1",
+								DependsOn: []string{
+									"golang-1.19@1.19.8-2",
+									"golang-github-opencontainers-selinux@1.10.2+ds1-1",
+									"libc6@2.36-9+deb12u1",
+									"libseccomp@2.5.4-1",
+								},
+								Maintainer: "Debian Go Packaging Team <team+pkg-go@tracker.debian.org>",
+								Arch:       "amd64",
+							},
+						},
+					},
+				},
+				SystemInstalledFiles: []string{
+					"usr/lib/x86_64-linux-gnu/libcrypto.so.3",
+					"usr/lib/x86_64-linux-gnu/libssl.so.3",
+				},
+			},
+		},
 		{
 			name:      "info list",
 			testFiles: map[string]string{"./testdata/tar.list": "var/lib/dpkg/info/tar.list"},
@@ -1384,6 +1488,11 @@ func Test_dpkgAnalyzer_Required(t *testing.T) {
 			filePath: "var/lib/dpkg/status.d/gcc",
 			want:     true,
 		},
+		{
+			name:     "md5sums file in status dir",
+			filePath: "var/lib/dpkg/status.d/libssl3.md5sums",
+			want:     true,
+		},
 		{
 			name:     "list file",
 			filePath: "var/lib/dpkg/info/bash.list",
@@ -0,0 +1,208 @@
+package flatpak
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/xerrors"
+
+	dio "github.com/aquasecurity/go-dep-parser/pkg/io"
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/utils/fsutils"
+)
+
+func init() {
+	analyzer.RegisterPostAnalyzer(analyzer.TypeFlatpak, newFlatpakAnalyzer)
+}
+
+const version = 1
+
+// Each deployment is stored in the directory named by the commit,
+// e.g. "var/lib/flatpak/app/org.mozilla.firefox/x86_64/stable/<commit>/metadata"
+// Per-user installations are stored in "~/.local/share/flatpak" with the same layout.
+var (
+	deployRegex   = regexp.MustCompile(`^(.*/)?flatpak/(app|runtime)/([^/]+)/([^/]+)/([^/]+)/[0-9a-f]{64}/`)
+	metainfoRegex = regexp.MustCompile(`^files/share/(?:metainfo|appdata)/[^/]+\.(?:metainfo|appdata)\.xml$`)
+)
+
+// deployment represents an installed app or runtime
+type deployment struct {
+	kind    string // app or runtime
+	ref     string // e.g. org.freedesktop.Platform/x86_64/22.08
+	id      string
+	arch    string
+	branch  string
+	version string
+	runtime string // the ref of the runtime used by the app
+
+	filePath string
+}
+
+type metainfo struct {
+	ID       string `xml:"id"`
+	Releases []struct {
+		Version string `xml:"version,attr"`
+	} `xml:"releases>release"`
+}
+
+// flatpakAnalyzer detects apps and runtimes deployed by Flatpak
+type flatpakAnalyzer struct{}
+
+func newFlatpakAnalyzer(_ analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+	return &flatpakAnalyzer{}, nil
+}
+
+func (a flatpakAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAnalysisInput) (*analyzer.AnalysisResult, error) {
+	required := func(path string, _ fs.DirEntry) bool {
+		return deployRegex.MatchString(path)
+	}
+
+	deployments := map[string]*deployment{}
+	err := fsutils.WalkDir(input.FS, ".", required, func(path string, _ fs.DirEntry, r dio.ReadSeekerAt) error {
+		m := deployRegex.FindStringSubmatch(path)
+		if m == nil {
+			return nil
+		}
+		dir := m[0]
+		d, ok := deployments[dir]
+		if !ok {
+			d = &deployment{
+				kind:   m[2],
+				ref:    strings.Join(m[3:6], "/"),
+				id:     m[3],
+				arch:   m[4],
+				branch: m[5],
+			}
+			deployments[dir] = d
+		}
+
+		switch rel := strings.TrimPrefix(path, dir); {
+		case rel == "metadata":
+			d.filePath = path
+			runtime, err := parseMetadata(r)
+			if err != nil {
+				return xerrors.Errorf("%s parse error: %w", path, err)
+			}
+			d.runtime = runtime
+		case metainfoRegex.MatchString(rel):
+			var info metainfo
+			if err := xml.NewDecoder(r).Decode(&info); err != nil {
+				log.Logger.Debugf("Unable to decode %s: %s", path, err)
+				return nil
+			}
+			// Releases are sorted in descending order
+			if info.ID == d.id && len(info.Releases) > 0 {
+				d.version = info.Releases[0].Version
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("flatpak walk error: %w", err)
+	}
+
+	return a.toResult(maps.Values(deployments)), nil
+}
+
+func (a flatpakAnalyzer) toResult(deployments []*deployment) *analyzer.AnalysisResult {
+	// Runtimes are versioned by the branch, e.g. org.freedesktop.Platform/x86_64/22.08
+	pkgIDs := map[string]string{}
+	for _, d := range deployments {
+		if d.version == "" && d.kind == "runtime" {
+			d.version = d.branch
+		}
+		pkgIDs[d.ref] = pkgID(d.id, d.version)
+	}
+
+	var apps []types.Application
+	for _, d := range deployments {
+		if d.filePath == "" {
+			continue
+		} else if d.version == "" {
+			log.Logger.Debugf("Unable to detect the version of the Flatpak app: %s", d.ref)
+			continue
+		}
+
+		var dependsOn []string
+		if id, ok := pkgIDs[d.runtime]; ok {
+			dependsOn = []string{id}
+		}
+		apps = append(apps, types.Application{
+			Type:     types.Flatpak,
+			FilePath: d.filePath,
+			Libraries: []types.Package{
+				{
+					ID:        pkgIDs[d.ref],
+					Name:      d.id,
+					Version:   d.version,
+					Arch:      d.arch,
+					DependsOn: dependsOn,
+					FilePath:  d.filePath,
+				},
+			},
+		})
+	}
+	if len(apps) == 0 {
+		return nil
+	}
+
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].FilePath < apps[j].FilePath
+	})
+	return &analyzer.AnalysisResult{
+		Applications: apps,
+	}
+}
+
+// parseMetadata returns the runtime of the app from the metadata.
+//
+//	[Application]
+//	name=org.mozilla.firefox
+//	runtime=org.freedesktop.Platform/x86_64/22.08
+func parseMetadata(r dio.ReadSeekerAt) (string, error) {
+	var section string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			section = strings.Trim(line, "[]")
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if ok && section == "Application" && key == "runtime" {
+			return value, nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+func pkgID(name, ver string) string {
+	return fmt.Sprintf("%s@%s", name, ver)
+}
+
+func (a flatpakAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	m := deployRegex.FindStringSubmatch(filePath)
+	if m == nil {
+		return false
+	}
+	rel := strings.TrimPrefix(filePath, m[0])
+	return rel == "metadata" || metainfoRegex.MatchString(rel)
+}
+
+func (a flatpakAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeFlatpak
+}
+
+func (a flatpakAnalyzer) Version() int {
+	return version
+}
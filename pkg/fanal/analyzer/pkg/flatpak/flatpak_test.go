@@ -0,0 +1,133 @@
+package flatpak
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/mapfs"
+)
+
+const (
+	firefoxDir  = "var/lib/flatpak/app/org.mozilla.firefox/x86_64/stable/3a2d1e6c0c1b7f9b4e3e5d6a8f0b2c4d6e8f0a1b3c5d7e9f1a3b5c7d9e1f3a5b/"
+	platformDir = "var/lib/flatpak/runtime/org.freedesktop.Platform/x86_64/22.08/8f0e2d4c6b8a0f1e3d5c7b9a1f3e5d7c9b1a3f5e7d9c1b3a5f7e9d1c3b5a7f9e/"
+	unknownDir  = "home/user/.local/share/flatpak/app/org.example.Unknown/x86_64/stable/0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef/"
+)
+
+func Test_flatpakAnalyzer_PostAnalyze(t *testing.T) {
+	tests := []struct {
+		name string
+		// testFiles contains path in testdata and path in OS
+		testFiles map[string]string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name: "app and runtime",
+			testFiles: map[string]string{
+				"testdata/firefox-metadata":     firefoxDir + "metadata",
+				"testdata/firefox.metainfo.xml": firefoxDir + "files/share/metainfo/org.mozilla.firefox.metainfo.xml",
+				"testdata/platform-metadata":    platformDir + "metadata",
+			},
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Flatpak,
+						FilePath: firefoxDir + "metadata",
+						Libraries: []types.Package{
+							{
+								ID:      "org.mozilla.firefox@115.0.2",
+								Name:    "org.mozilla.firefox",
+								Version: "115.0.2",
+								Arch:    "x86_64",
+								DependsOn: []string{
+									"org.freedesktop.Platform@22.08",
+								},
+								FilePath: firefoxDir + "metadata",
+							},
+						},
+					},
+					{
+						Type:     types.Flatpak,
+						FilePath: platformDir + "metadata",
+						Libraries: []types.Package{
+							{
+								ID:       "org.freedesktop.Platform@22.08",
+								Name:     "org.freedesktop.Platform",
+								Version:  "22.08",
+								Arch:     "x86_64",
+								FilePath: platformDir + "metadata",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "app without version",
+			testFiles: map[string]string{
+				"testdata/unknown-metadata": unknownDir + "metadata",
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := newFlatpakAnalyzer(analyzer.AnalyzerOptions{})
+			require.NoError(t, err)
+
+			mfs := mapfs.New()
+			for testPath, osPath := range tt.testFiles {
+				err = mfs.MkdirAll(filepath.Dir(osPath), os.ModePerm)
+				require.NoError(t, err)
+				err = mfs.WriteFile(osPath, testPath)
+				require.NoError(t, err)
+			}
+
+			got, err := a.PostAnalyze(context.Background(), analyzer.PostAnalysisInput{
+				FS: mfs,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_flatpakAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{
+			filePath: firefoxDir + "metadata",
+			want:     true,
+		},
+		{
+			filePath: firefoxDir + "files/share/metainfo/org.mozilla.firefox.metainfo.xml",
+			want:     true,
+		},
+		{
+			filePath: unknownDir + "metadata",
+			want:     true,
+		},
+		{
+			filePath: firefoxDir + "files/lib/firefox/firefox",
+			want:     false,
+		},
+		{
+			filePath: "var/lib/flatpak/app/org.mozilla.firefox/current/active/metadata",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			a := flatpakAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
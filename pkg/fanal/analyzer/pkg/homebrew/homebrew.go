@@ -0,0 +1,101 @@
+package homebrew
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&homebrewAnalyzer{})
+}
+
+const version = 1
+
+// Each formula installed in the Cellar has an install receipt,
+// e.g. "opt/homebrew/Cellar/openssl@3/3.1.1_1/INSTALL_RECEIPT.json"
+// The Cellar is located in "/opt/homebrew", "/usr/local" or "/home/linuxbrew/.linuxbrew".
+var receiptRegex = regexp.MustCompile(`(?:^|/)Cellar/([^/]+)/([^/]+)/INSTALL_RECEIPT\.json$`)
+
+type installReceipt struct {
+	InstalledOnRequest  bool `json:"installed_on_request"`
+	RuntimeDependencies []struct {
+		FullName   string `json:"full_name"`
+		Version    string `json:"version"`
+		PkgVersion string `json:"pkg_version"`
+	} `json:"runtime_dependencies"`
+}
+
+// homebrewAnalyzer detects formulae installed by Homebrew
+type homebrewAnalyzer struct{}
+
+func (a homebrewAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	m := receiptRegex.FindStringSubmatch(input.FilePath)
+	if m == nil {
+		return nil, nil
+	}
+	name, ver := m[1], m[2]
+
+	var receipt installReceipt
+	if err := json.NewDecoder(input.Content).Decode(&receipt); err != nil {
+		return nil, xerrors.Errorf("%s decode error: %w", input.FilePath, err)
+	}
+
+	var dependsOn []string
+	for _, dep := range receipt.RuntimeDependencies {
+		// "pkg_version" contains the revision, e.g. "3.1.1_1"
+		depVer := dep.PkgVersion
+		if depVer == "" {
+			depVer = dep.Version
+		}
+		if dep.FullName == "" || depVer == "" {
+			continue
+		}
+		dependsOn = append(dependsOn, pkgID(path.Base(dep.FullName), depVer))
+	}
+	sort.Strings(dependsOn)
+
+	return &analyzer.AnalysisResult{
+		Applications: []types.Application{
+			{
+				Type:     types.Homebrew,
+				FilePath: input.FilePath,
+				Libraries: []types.Package{
+					{
+						ID:        pkgID(name, ver),
+						Name:      name,
+						Version:   ver,
+						Indirect:  !receipt.InstalledOnRequest,
+						DependsOn: dependsOn,
+						FilePath:  input.FilePath,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func pkgID(name, ver string) string {
+	return fmt.Sprintf("%s@%s", name, ver)
+}
+
+func (a homebrewAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return receiptRegex.MatchString(filePath)
+}
+
+func (a homebrewAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeHomebrew
+}
+
+func (a homebrewAnalyzer) Version() int {
+	return version
+}
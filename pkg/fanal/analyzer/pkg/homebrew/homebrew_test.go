@@ -0,0 +1,126 @@
+package homebrew
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func Test_homebrewAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		filePath  string
+		inputFile string
+		want      *analyzer.AnalysisResult
+		wantErr   string
+	}{
+		{
+			name:      "installed on request",
+			filePath:  "opt/homebrew/Cellar/curl/8.1.2_1/INSTALL_RECEIPT.json",
+			inputFile: "testdata/INSTALL_RECEIPT.json",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Homebrew,
+						FilePath: "opt/homebrew/Cellar/curl/8.1.2_1/INSTALL_RECEIPT.json",
+						Libraries: []types.Package{
+							{
+								ID:      "curl@8.1.2_1",
+								Name:    "curl",
+								Version: "8.1.2_1",
+								DependsOn: []string{
+									"ca-certificates@2023-05-30",
+									"openssl@3@3.1.1_1",
+								},
+								FilePath: "opt/homebrew/Cellar/curl/8.1.2_1/INSTALL_RECEIPT.json",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "installed as dependency",
+			filePath:  "home/linuxbrew/.linuxbrew/Cellar/ca-certificates/2023-05-30/INSTALL_RECEIPT.json",
+			inputFile: "testdata/dependency.json",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Homebrew,
+						FilePath: "home/linuxbrew/.linuxbrew/Cellar/ca-certificates/2023-05-30/INSTALL_RECEIPT.json",
+						Libraries: []types.Package{
+							{
+								ID:       "ca-certificates@2023-05-30",
+								Name:     "ca-certificates",
+								Version:  "2023-05-30",
+								Indirect: true,
+								FilePath: "home/linuxbrew/.linuxbrew/Cellar/ca-certificates/2023-05-30/INSTALL_RECEIPT.json",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "broken receipt",
+			filePath:  "usr/local/Cellar/curl/8.1.2_1/INSTALL_RECEIPT.json",
+			inputFile: "testdata/broken.json",
+			wantErr:   "decode error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			a := homebrewAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.filePath,
+				Content:  f,
+			})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_homebrewAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{
+			filePath: "opt/homebrew/Cellar/curl/8.1.2_1/INSTALL_RECEIPT.json",
+			want:     true,
+		},
+		{
+			filePath: "usr/local/Cellar/openssl@3/3.1.1_1/INSTALL_RECEIPT.json",
+			want:     true,
+		},
+		{
+			filePath: "opt/homebrew/Cellar/curl/8.1.2_1/bin/curl",
+			want:     false,
+		},
+		{
+			filePath: "opt/homebrew/Caskroom/firefox/115.0/INSTALL_RECEIPT.json",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			a := homebrewAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package snap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+
+	dio "github.com/aquasecurity/go-dep-parser/pkg/io"
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/utils/fsutils"
+)
+
+func init() {
+	analyzer.RegisterPostAnalyzer(analyzer.TypeSnap, newSnapAnalyzer)
+}
+
+const (
+	version = 1
+
+	// stateFile has the revisions of installed snaps
+	stateFile = "var/lib/snapd/state.json"
+)
+
+// Each revision of snaps is mounted with the metadata,
+// e.g. "snap/core20/1974/meta/snap.yaml" or "var/lib/snapd/snap/core20/1974/meta/snap.yaml" on Fedora
+var snapYAMLRegex = regexp.MustCompile(`(?:^|/)snap/([^/]+)/([^/]+)/meta/snap\.yaml$`)
+
+type snapState struct {
+	Data struct {
+		Snaps map[string]struct {
+			Active  bool   `json:"active"`
+			Current string `json:"current"`
+		} `json:"snaps"`
+	} `json:"data"`
+}
+
+type snapYAML struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+}
+
+// snapAnalyzer detects snaps installed by snapd
+type snapAnalyzer struct{}
+
+func newSnapAnalyzer(_ analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+	return &snapAnalyzer{}, nil
+}
+
+func (a snapAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAnalysisInput) (*analyzer.AnalysisResult, error) {
+	// snapd keeps previous revisions for rollback, while only the current one is active.
+	// All revisions are taken if the state is not available.
+	current, err := a.parseState(input.FS)
+	if err != nil {
+		log.Logger.Debugf("Unable to parse %q: %s", stateFile, err)
+	}
+
+	required := func(path string, _ fs.DirEntry) bool {
+		return path != stateFile
+	}
+
+	var apps []types.Application
+	err = fsutils.WalkDir(input.FS, ".", required, func(path string, _ fs.DirEntry, r dio.ReadSeekerAt) error {
+		m := snapYAMLRegex.FindStringSubmatch(path)
+		if m == nil {
+			return nil
+		}
+		name, revision := m[1], m[2]
+		if rev, ok := current[name]; current != nil && (!ok || rev != revision) {
+			return nil
+		}
+
+		var snap snapYAML
+		if err := yaml.NewDecoder(r).Decode(&snap); err != nil {
+			return xerrors.Errorf("%s decode error: %w", path, err)
+		}
+		if snap.Name == "" || snap.Version == "" {
+			return nil
+		}
+
+		apps = append(apps, types.Application{
+			Type:     types.Snap,
+			FilePath: path,
+			Libraries: []types.Package{
+				{
+					ID:       fmt.Sprintf("%s@%s", snap.Name, snap.Version),
+					Name:     snap.Name,
+					Version:  snap.Version,
+					FilePath: path,
+				},
+			},
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("snap walk error: %w", err)
+	}
+
+	if len(apps) == 0 {
+		return nil, nil
+	}
+	return &analyzer.AnalysisResult{
+		Applications: apps,
+	}, nil
+}
+
+// parseState returns the current revisions of active snaps, keyed by the snap name
+func (a snapAnalyzer) parseState(fsys fs.FS) (map[string]string, error) {
+	f, err := fsys.Open(stateFile)
+	if err != nil {
+		return nil, xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	var state snapState
+	if err = json.NewDecoder(f).Decode(&state); err != nil {
+		return nil, xerrors.Errorf("json decode error: %w", err)
+	}
+
+	current := map[string]string{}
+	for name, snap := range state.Data.Snaps {
+		if snap.Active {
+			current[name] = snap.Current
+		}
+	}
+	return current, nil
+}
+
+func (a snapAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	if filePath == stateFile {
+		return true
+	}
+	m := snapYAMLRegex.FindStringSubmatch(filePath)
+	// "current" is a symlink to the current revision
+	return m != nil && m[2] != "current"
+}
+
+func (a snapAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeSnap
+}
+
+func (a snapAnalyzer) Version() int {
+	return version
+}
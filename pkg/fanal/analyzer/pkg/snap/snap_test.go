@@ -0,0 +1,146 @@
+package snap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/mapfs"
+)
+
+func Test_snapAnalyzer_PostAnalyze(t *testing.T) {
+	tests := []struct {
+		name string
+		// testFiles contains path in testdata and path in OS
+		testFiles map[string]string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name: "current revisions",
+			testFiles: map[string]string{
+				"testdata/state.json":       "var/lib/snapd/state.json",
+				"testdata/core20-1891.yaml": "snap/core20/1891/meta/snap.yaml",
+				"testdata/core20-1974.yaml": "snap/core20/1974/meta/snap.yaml",
+				"testdata/lxd-24322.yaml":   "snap/lxd/24322/meta/snap.yaml",
+			},
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Snap,
+						FilePath: "snap/core20/1974/meta/snap.yaml",
+						Libraries: []types.Package{
+							{
+								ID:       "core20@20230622",
+								Name:     "core20",
+								Version:  "20230622",
+								FilePath: "snap/core20/1974/meta/snap.yaml",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "without state",
+			testFiles: map[string]string{
+				"testdata/core20-1974.yaml": "snap/core20/1974/meta/snap.yaml",
+				"testdata/lxd-24322.yaml":   "snap/lxd/24322/meta/snap.yaml",
+			},
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Snap,
+						FilePath: "snap/core20/1974/meta/snap.yaml",
+						Libraries: []types.Package{
+							{
+								ID:       "core20@20230622",
+								Name:     "core20",
+								Version:  "20230622",
+								FilePath: "snap/core20/1974/meta/snap.yaml",
+							},
+						},
+					},
+					{
+						Type:     types.Snap,
+						FilePath: "snap/lxd/24322/meta/snap.yaml",
+						Libraries: []types.Package{
+							{
+								ID:       "lxd@5.0.2-838e1b2",
+								Name:     "lxd",
+								Version:  "5.0.2-838e1b2",
+								FilePath: "snap/lxd/24322/meta/snap.yaml",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no snaps",
+			testFiles: map[string]string{
+				"testdata/state.json": "var/lib/snapd/state.json",
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := newSnapAnalyzer(analyzer.AnalyzerOptions{})
+			require.NoError(t, err)
+
+			mfs := mapfs.New()
+			for testPath, osPath := range tt.testFiles {
+				err = mfs.MkdirAll(filepath.Dir(osPath), os.ModePerm)
+				require.NoError(t, err)
+				err = mfs.WriteFile(osPath, testPath)
+				require.NoError(t, err)
+			}
+
+			got, err := a.PostAnalyze(context.Background(), analyzer.PostAnalysisInput{
+				FS: mfs,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_snapAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{
+			filePath: "var/lib/snapd/state.json",
+			want:     true,
+		},
+		{
+			filePath: "snap/core20/1974/meta/snap.yaml",
+			want:     true,
+		},
+		{
+			filePath: "var/lib/snapd/snap/core20/1974/meta/snap.yaml",
+			want:     true,
+		},
+		{
+			filePath: "snap/core20/current/meta/snap.yaml",
+			want:     false,
+		},
+		{
+			filePath: "snap/core20/1974/meta/gui/icon.png",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			a := snapAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"os"
 	"regexp"
+	"strings"
 
 	"golang.org/x/exp/slices"
 	"golang.org/x/xerrors"
@@ -24,7 +25,7 @@ const edgeVersion = "edge"
 
 var (
 	requiredFiles  = []string{"etc/apk/repositories"}
-	urlParseRegexp = regexp.MustCompile(`(https*|ftp)://[0-9A-Za-z.-]+/([A-Za-z]+)/v?([0-9A-Za-z_.-]+)/`)
+	urlParseRegexp = regexp.MustCompile(`(https*|ftp)://[0-9A-Za-z.-]+/([A-Za-z]+)/v?([0-9A-Za-z_.-]+)/([0-9A-Za-z_-]*)`)
 )
 
 type apkRepoAnalyzer struct{}
@@ -32,11 +33,12 @@ type apkRepoAnalyzer struct{}
 func (a apkRepoAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
 	scanner := bufio.NewScanner(input.Content)
 	var osFamily, repoVer string
+	tags := map[string]types.TaggedRepository{}
 	for scanner.Scan() {
-		line := scanner.Text()
+		line := strings.TrimSpace(scanner.Text())
 
 		m := urlParseRegexp.FindStringSubmatch(line)
-		if len(m) != 4 {
+		if len(m) != 5 {
 			continue
 		}
 
@@ -49,6 +51,18 @@ func (a apkRepoAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput
 		}
 		osFamily = newOSFamily
 
+		// Tagged repositories are used only by the packages pinned to them,
+		// e.g. "@edge https://dl-cdn.alpinelinux.org/alpine/edge/main" for "apk add curl@edge",
+		// so they don't affect the release of the other packages.
+		if strings.HasPrefix(line, "@") {
+			tag := strings.TrimPrefix(strings.Fields(line)[0], "@")
+			tags[tag] = types.TaggedRepository{
+				Release: newVersion,
+				Name:    m[4],
+			}
+			continue
+		}
+
 		// Find max Release version
 		switch {
 		case repoVer == "":
@@ -73,15 +87,19 @@ func (a apkRepoAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput
 	}
 
 	// Currently, we support only Alpine Linux in apk repositories.
-	if osFamily != aos.Alpine || repoVer == "" {
+	if osFamily != aos.Alpine || (repoVer == "" && len(tags) == 0) {
 		return nil, nil
 	}
 
+	repo := &types.Repository{
+		Family:  osFamily,
+		Release: repoVer,
+	}
+	if len(tags) > 0 {
+		repo.Tags = tags
+	}
 	return &analyzer.AnalysisResult{
-		Repository: &types.Repository{
-			Family:  osFamily,
-			Release: repoVer,
-		},
+		Repository: repo,
 	}, nil
 }
 
@@ -114,6 +114,33 @@ https://dl-cdn.alpinelinux.org/alpine/v3.10/main
 				Repository: &types.Repository{Family: aos.Alpine, Release: "edge"},
 			},
 		},
+		{
+			name: "tagged repositories",
+			input: analyzer.AnalysisInput{
+				FilePath: "/etc/apk/repositories",
+				Content: strings.NewReader(`https://dl-cdn.alpinelinux.org/alpine/v3.18/main
+https://dl-cdn.alpinelinux.org/alpine/v3.18/community
+@edge https://dl-cdn.alpinelinux.org/alpine/edge/main
+@testing https://dl-cdn.alpinelinux.org/alpine/edge/testing
+`),
+			},
+			want: &analyzer.AnalysisResult{
+				Repository: &types.Repository{
+					Family:  aos.Alpine,
+					Release: "3.18",
+					Tags: map[string]types.TaggedRepository{
+						"edge": {
+							Release: "edge",
+							Name:    "main",
+						},
+						"testing": {
+							Release: "edge",
+							Name:    "testing",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "sad path",
 			input: analyzer.AnalysisInput{
@@ -0,0 +1,164 @@
+package yum
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&yumRepoAnalyzer{})
+}
+
+const (
+	version = 1
+
+	// releaseverFile locks the release of the repositories, e.g. "2023.1.20230719" on Amazon Linux 2023
+	releaseverFile = "etc/dnf/vars/releasever"
+
+	// yumdb stores the repository each package is installed from,
+	// e.g. "var/lib/yum/yumdb/d/<pkgid>-docker-20.10.23-1.amzn2.0.1-x86_64/from_repo"
+	yumdbDir     = "var/lib/yum/yumdb/"
+	fromRepoFile = "from_repo"
+
+	// modulesDir stores the state of each module, e.g. "etc/dnf/modules.d/nodejs.module"
+	modulesDir      = "etc/dnf/modules.d/"
+	moduleExtension = ".module"
+)
+
+// yumRepoAnalyzer detects the release, repositories of packages and enabled module streams from DNF/yum metadata
+type yumRepoAnalyzer struct{}
+
+func (a yumRepoAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	if strings.HasPrefix(input.FilePath, modulesDir) {
+		modules, err := parseModules(input)
+		if err != nil {
+			return nil, xerrors.Errorf("%s parse error: %w", input.FilePath, err)
+		} else if len(modules) == 0 {
+			return nil, nil
+		}
+		return &analyzer.AnalysisResult{
+			Repository: &types.Repository{Modules: modules},
+		}, nil
+	}
+
+	value, err := readValue(input)
+	if err != nil {
+		return nil, xerrors.Errorf("%s read error: %w", input.FilePath, err)
+	} else if value == "" {
+		return nil, nil
+	}
+
+	if input.FilePath == releaseverFile {
+		return &analyzer.AnalysisResult{
+			Repository: &types.Repository{Release: value},
+		}, nil
+	}
+
+	name := packageName(path.Base(path.Dir(input.FilePath)))
+	if name == "" {
+		return nil, nil
+	}
+	return &analyzer.AnalysisResult{
+		Repository: &types.Repository{
+			Packages: map[string]string{name: value},
+		},
+	}, nil
+}
+
+// readValue returns the first line of the file
+func readValue(input analyzer.AnalysisInput) (string, error) {
+	scanner := bufio.NewScanner(input.Content)
+	if scanner.Scan() {
+		return strings.TrimSpace(scanner.Text()), nil
+	}
+	return "", scanner.Err()
+}
+
+// parseModules returns the enabled streams of the modules, keyed by the module name.
+//
+//	[nodejs]
+//	name=nodejs
+//	stream=18
+//	profiles=
+//	state=enabled
+func parseModules(input analyzer.AnalysisInput) (map[string]string, error) {
+	var name, stream, state string
+	modules := map[string]string{}
+	addModule := func() {
+		if name != "" && stream != "" && state == "enabled" {
+			modules[name] = stream
+		}
+		name, stream, state = "", "", ""
+	}
+
+	scanner := bufio.NewScanner(input.Content)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "["):
+			addModule()
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "name":
+			name = strings.TrimSpace(value)
+		case "stream":
+			stream = strings.TrimSpace(value)
+		case "state":
+			state = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	addModule()
+
+	return modules, nil
+}
+
+// packageName parses the yumdb directory name, "<pkgid>-<name>-<version>-<release>-<arch>"
+func packageName(dir string) string {
+	_, nevra, ok := strings.Cut(dir, "-")
+	if !ok {
+		return ""
+	}
+	// The name might contain hyphens
+	fields := strings.Split(nevra, "-")
+	if len(fields) < 4 {
+		return ""
+	}
+	return strings.Join(fields[:len(fields)-3], "-")
+}
+
+func (a yumRepoAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	if filePath == releaseverFile {
+		return true
+	}
+	if strings.HasPrefix(filePath, modulesDir) {
+		return path.Ext(filePath) == moduleExtension
+	}
+	return strings.HasPrefix(filePath, yumdbDir) && path.Base(filePath) == fromRepoFile
+}
+
+func (a yumRepoAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeYumRepo
+}
+
+func (a yumRepoAnalyzer) Version() int {
+	return version
+}
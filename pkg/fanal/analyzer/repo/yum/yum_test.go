@@ -0,0 +1,130 @@
+package yum
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func Test_yumRepoAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		filePath  string
+		inputFile string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name:      "releasever",
+			filePath:  "etc/dnf/vars/releasever",
+			inputFile: "testdata/releasever",
+			want: &analyzer.AnalysisResult{
+				Repository: &types.Repository{
+					Release: "2023.1.20230719",
+				},
+			},
+		},
+		{
+			name:      "from_repo",
+			filePath:  "var/lib/yum/yumdb/n/5a2ce7dc24f2b4b1c7d1c1b5d5a0d0b8d1e0ec0a-nginx-filesystem-1.20.0-2.amzn2.0.4-noarch/from_repo",
+			inputFile: "testdata/from_repo",
+			want: &analyzer.AnalysisResult{
+				Repository: &types.Repository{
+					Packages: map[string]string{
+						"nginx-filesystem": "amzn2extra-nginx1",
+					},
+				},
+			},
+		},
+		{
+			name:      "module",
+			filePath:  "etc/dnf/modules.d/nodejs.module",
+			inputFile: "testdata/nodejs.module",
+			want: &analyzer.AnalysisResult{
+				Repository: &types.Repository{
+					Modules: map[string]string{
+						"nodejs": "18",
+					},
+				},
+			},
+		},
+		{
+			name:      "disabled modules are ignored",
+			filePath:  "etc/dnf/modules.d/modules.module",
+			inputFile: "testdata/modules.module",
+			want: &analyzer.AnalysisResult{
+				Repository: &types.Repository{
+					Modules: map[string]string{
+						"postgresql": "15",
+					},
+				},
+			},
+		},
+		{
+			name:      "invalid yumdb directory",
+			filePath:  "var/lib/yum/yumdb/n/nginx/from_repo",
+			inputFile: "testdata/from_repo",
+			want:      nil,
+		},
+		{
+			name:      "empty",
+			filePath:  "etc/dnf/vars/releasever",
+			inputFile: "testdata/empty",
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			a := yumRepoAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.filePath,
+				Content:  f,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_yumRepoAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{
+			filePath: "etc/dnf/vars/releasever",
+			want:     true,
+		},
+		{
+			filePath: "var/lib/yum/yumdb/n/5a2ce7dc24f2b4b1c7d1c1b5d5a0d0b8d1e0ec0a-nginx-1.20.0-2.amzn2.0.4-x86_64/from_repo",
+			want:     true,
+		},
+		{
+			filePath: "etc/dnf/modules.d/nodejs.module",
+			want:     true,
+		},
+		{
+			filePath: "etc/dnf/modules.defaults.d/nodejs.yaml",
+			want:     false,
+		},
+		{
+			filePath: "var/lib/yum/yumdb/n/5a2ce7dc24f2b4b1c7d1c1b5d5a0d0b8d1e0ec0a-nginx-1.20.0-2.amzn2.0.4-x86_64/reason",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			a := yumRepoAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package analyzer
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"runtime"
+	"runtime/metrics"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// Cost classifies analyzers by the resources they consume
+type Cost int
+
+const (
+	// CostLight is for analyzers parsing small files such as lock files and package databases
+	CostLight Cost = iota
+
+	// CostHeavy is for analyzers reading large files or running expensive matching, such as archives, binaries and secrets
+	CostHeavy
+)
+
+// heavyAnalyzers is the list of analyzers classified as CostHeavy
+var heavyAnalyzers = []Type{
+	TypeJar,
+	TypeGoBinary,
+	TypeRustBinary,
+	TypeExecutable,
+	TypeMLModel,
+	TypeSBOM,
+	TypeSecret,
+	TypeLicenseFile,
+}
+
+// CostOf returns the cost of the analyzer
+func CostOf(t Type) Cost {
+	if slices.Contains(heavyAnalyzers, t) {
+		return CostHeavy
+	}
+	return CostLight
+}
+
+const (
+	minWorkers = 2
+	maxWorkers = 16
+
+	// heavyWorkerMemory is the memory estimated for an analyzer of CostHeavy to size the pool
+	heavyWorkerMemory = 256 << 20 // 256MB
+
+	// The concurrency of heavy analyzers is reduced when the heap exceeds highWatermark of the memory limit,
+	// and restored when it goes below lowWatermark.
+	highWatermark = 0.8
+	lowWatermark  = 0.6
+
+	pressureCheckInterval = 500 * time.Millisecond
+	heapMetric            = "/memory/classes/heap/objects:bytes"
+)
+
+type SchedulerOption struct {
+	// Slow runs analyzers in series
+	Slow bool
+
+	// MaxWorkers caps the number of analyzers running concurrently.
+	// It is decided from the number of CPUs if zero.
+	MaxWorkers int
+
+	// MaxHeavyWorkers caps the number of analyzers of CostHeavy running concurrently.
+	// It is decided from the memory limit if zero.
+	MaxHeavyWorkers int
+
+	// MemoryLimit is the memory in bytes available for analysis.
+	// It is detected from cgroups or the system if zero.
+	MemoryLimit int64
+}
+
+// Scheduler limits the number of analyzers running concurrently according to their cost.
+// All analyzers share the pool sized from CPUs, and heavy analyzers additionally need a slot of the pool sized from memory.
+// The heavy pool shrinks when memory pressure is detected.
+type Scheduler struct {
+	workers *semaphore.Weighted
+	heavy   *semaphore.Weighted
+
+	workerSize  int64
+	heavySize   int64
+	memoryLimit uint64
+
+	// throttled is the number of slots of the heavy pool held back under memory pressure
+	throttled int64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(opt SchedulerOption) *Scheduler {
+	workers := int64(clamp(runtime.NumCPU(), minWorkers, maxWorkers))
+	if opt.MaxWorkers > 0 {
+		workers = int64(opt.MaxWorkers)
+	}
+
+	memoryLimit := uint64(opt.MemoryLimit)
+	if memoryLimit == 0 {
+		memoryLimit = detectMemoryLimit()
+	}
+
+	// Use half of the workers for heavy analyzers when the memory is unknown
+	heavySize := (workers + 1) / 2
+	if memoryLimit > 0 {
+		heavySize = int64(clamp(int(memoryLimit/heavyWorkerMemory), 1, int(workers)))
+	}
+	if opt.MaxHeavyWorkers > 0 {
+		heavySize = int64(opt.MaxHeavyWorkers)
+	}
+
+	if opt.Slow {
+		// Process in series
+		workers, heavySize = 1, 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Scheduler{
+		workers:     semaphore.NewWeighted(workers),
+		heavy:       semaphore.NewWeighted(heavySize),
+		workerSize:  workers,
+		heavySize:   heavySize,
+		memoryLimit: memoryLimit,
+		cancel:      cancel,
+	}
+	log.Logger.Debugf("Analyzer workers: %d (heavy: %d), memory limit: %d bytes", workers, heavySize, memoryLimit)
+
+	if memoryLimit > 0 && heavySize > 1 {
+		s.wg.Add(1)
+		go s.watchMemory(ctx)
+	}
+	return s
+}
+
+// Workers returns the number of analyzers running concurrently at most
+func (s *Scheduler) Workers() int {
+	return int(s.workerSize)
+}
+
+// Acquire blocks until the analyzer can run, and returns a function releasing the slots.
+func (s *Scheduler) Acquire(ctx context.Context, t Type) (func(), error) {
+	if CostOf(t) == CostHeavy {
+		if err := s.heavy.Acquire(ctx, 1); err != nil {
+			return nil, xerrors.Errorf("heavy analyzer acquire: %w", err)
+		}
+	}
+	if err := s.workers.Acquire(ctx, 1); err != nil {
+		if CostOf(t) == CostHeavy {
+			s.heavy.Release(1)
+		}
+		return nil, xerrors.Errorf("analyzer acquire: %w", err)
+	}
+
+	return func() {
+		s.workers.Release(1)
+		if CostOf(t) == CostHeavy {
+			s.heavy.Release(1)
+		}
+	}, nil
+}
+
+// Close stops watching memory pressure.
+func (s *Scheduler) Close() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// watchMemory adapts the concurrency of heavy analyzers to the heap usage.
+// Slots of the heavy pool are taken back one by one under memory pressure so that running analyzers can finish.
+func (s *Scheduler) watchMemory(ctx context.Context) {
+	defer s.wg.Done()
+
+	sample := []metrics.Sample{{Name: heapMetric}}
+	ticker := time.NewTicker(pressureCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// Return the slots held back
+			s.heavy.Release(s.throttled)
+			s.throttled = 0
+			return
+		case <-ticker.C:
+		}
+
+		metrics.Read(sample)
+		if sample[0].Value.Kind() != metrics.KindUint64 {
+			return
+		}
+		usage := float64(sample[0].Value.Uint64()) / float64(s.memoryLimit)
+
+		switch {
+		case usage > highWatermark && s.throttled < s.heavySize-1:
+			if s.heavy.TryAcquire(1) {
+				s.throttled++
+				log.Logger.Debugf("Memory pressure detected (%.0f%%), reducing heavy analyzers to %d",
+					usage*100, s.heavySize-s.throttled)
+			}
+		case usage < lowWatermark && s.throttled > 0:
+			s.heavy.Release(1)
+			s.throttled--
+			log.Logger.Debugf("Memory pressure relieved (%.0f%%), increasing heavy analyzers to %d",
+				usage*100, s.heavySize-s.throttled)
+		}
+	}
+}
+
+// detectMemoryLimit returns the memory limit of the cgroup or the total memory of the system, or 0 if unknown.
+func detectMemoryLimit() uint64 {
+	// cgroup v2 and v1
+	for _, p := range []string{"/sys/fs/cgroup/memory.max", "/sys/fs/cgroup/memory/memory.limit_in_bytes"} {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		// "max" in cgroup v2 and a huge number in cgroup v1 mean no limit
+		limit, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+		if err == nil && limit < 1<<60 {
+			return limit
+		}
+	}
+
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// e.g. "MemTotal:       16303428 kB"
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 3 && fields[0] == "MemTotal:" && fields[2] == "kB" {
+			kb, err := strconv.ParseUint(fields[1], 10, 64)
+			if err != nil {
+				return 0
+			}
+			return kb << 10
+		}
+	}
+	return 0
+}
+
+func clamp(n, min, max int) int {
+	switch {
+	case n < min:
+		return min
+	case n > max:
+		return max
+	}
+	return n
+}
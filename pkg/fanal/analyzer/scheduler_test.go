@@ -0,0 +1,101 @@
+package analyzer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+)
+
+func TestScheduler_Acquire(t *testing.T) {
+	tests := []struct {
+		name        string
+		opt         analyzer.SchedulerOption
+		running     []analyzer.Type
+		next        analyzer.Type
+		wantWorkers int
+		wantBlocked bool
+	}{
+		{
+			name: "light analyzers",
+			opt: analyzer.SchedulerOption{
+				MaxWorkers:      3,
+				MaxHeavyWorkers: 1,
+			},
+			running: []analyzer.Type{
+				analyzer.TypeNpmPkgLock,
+				analyzer.TypeJar,
+			},
+			next:        analyzer.TypeGoMod,
+			wantWorkers: 3,
+		},
+		{
+			name: "heavy analyzers exceed the limit",
+			opt: analyzer.SchedulerOption{
+				MaxWorkers:      3,
+				MaxHeavyWorkers: 1,
+			},
+			running: []analyzer.Type{
+				analyzer.TypeJar,
+			},
+			next:        analyzer.TypeSecret,
+			wantWorkers: 3,
+			wantBlocked: true,
+		},
+		{
+			name: "all workers are busy",
+			opt: analyzer.SchedulerOption{
+				MaxWorkers:      2,
+				MaxHeavyWorkers: 2,
+			},
+			running: []analyzer.Type{
+				analyzer.TypeApk,
+				analyzer.TypeDpkg,
+			},
+			next:        analyzer.TypeJar,
+			wantWorkers: 2,
+			wantBlocked: true,
+		},
+		{
+			name: "slow",
+			opt: analyzer.SchedulerOption{
+				Slow:       true,
+				MaxWorkers: 5,
+			},
+			running: []analyzer.Type{
+				analyzer.TypeApk,
+			},
+			next:        analyzer.TypeDpkg,
+			wantWorkers: 1,
+			wantBlocked: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := analyzer.NewScheduler(tt.opt)
+			defer s.Close()
+			assert.Equal(t, tt.wantWorkers, s.Workers())
+
+			ctx := context.Background()
+			for _, a := range tt.running {
+				release, err := s.Acquire(ctx, a)
+				require.NoError(t, err)
+				defer release()
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			defer cancel()
+			release, err := s.Acquire(ctx, tt.next)
+			if tt.wantBlocked {
+				assert.ErrorIs(t, err, context.DeadlineExceeded)
+				return
+			}
+			require.NoError(t, err)
+			release()
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package secret
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+const notebookExt = ".ipynb"
+
+// notebookContent converts a Jupyter notebook so that secrets in cells are detected as written in the code.
+// Notebooks store each line of cells as a JSON string, e.g. `"token = \"ghp_...\"\n",`.
+// The strings are unquoted line by line so that line numbers in findings point to the notebook.
+// Images in outputs are removed since base64 data is not a secret but takes time to scan.
+func notebookContent(content []byte) []byte {
+	lines := bytes.Split(content, []byte("\n"))
+	for i, line := range lines {
+		trimmed := bytes.TrimSpace(line)
+		if bytes.HasPrefix(trimmed, []byte(`"image/`)) {
+			lines[i] = nil
+			continue
+		}
+
+		trimmed = bytes.TrimSuffix(trimmed, []byte(","))
+		if len(trimmed) < 2 || trimmed[0] != '"' || trimmed[len(trimmed)-1] != '"' {
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(trimmed, &s); err != nil {
+			continue
+		}
+		indent := line[:bytes.IndexByte(line, '"')]
+
+		// A string may have line breaks when the notebook is not split into lines
+		unquoted := bytes.ReplaceAll(bytes.TrimSuffix([]byte(s), []byte("\n")), []byte("\n"), []byte(" "))
+		lines[i] = append(append([]byte{}, indent...), unquoted...)
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
@@ -89,6 +89,9 @@ func (a *SecretAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput
 	}
 
 	content = bytes.ReplaceAll(content, []byte("\r"), []byte(""))
+	if filepath.Ext(input.FilePath) == notebookExt {
+		content = notebookContent(content)
+	}
 
 	filePath := input.FilePath
 	// Files extracted from the image have an empty input.Dir.
@@ -129,6 +129,57 @@ func TestSecretAnalyzer(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "notebook",
+			configPath: "testdata/config.yaml",
+			filePath:   "testdata/secret.ipynb",
+			dir:        ".",
+			want: &analyzer.AnalysisResult{
+				Secrets: []types.Secret{
+					{
+						FilePath: "testdata/secret.ipynb",
+						Findings: []types.SecretFinding{
+							{
+								RuleID:    "rule1",
+								Category:  "general",
+								Title:     "Generic Rule",
+								Severity:  "HIGH",
+								StartLine: 10,
+								EndLine:   10,
+								Match:     "    secret=\"*********\"",
+								Code: types.Code{
+									Lines: []types.Line{
+										{
+											Number:      8,
+											Content:     "   \"source\": [",
+											Highlighted: "   \"source\": [",
+										},
+										{
+											Number:      9,
+											Content:     "    import requests",
+											Highlighted: "    import requests",
+										},
+										{
+											Number:      10,
+											Content:     "    secret=\"*********\"",
+											IsCause:     true,
+											Highlighted: "    secret=\"*********\"",
+											FirstCause:  true,
+											LastCause:   true,
+										},
+										{
+											Number:      11,
+											Content:     "    requests.get(\"https://example.com\", auth=(\"user\", secret))",
+											Highlighted: "    requests.get(\"https://example.com\", auth=(\"user\", secret))",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:       "image scan return nil",
 			configPath: "testdata/image-config.yaml",
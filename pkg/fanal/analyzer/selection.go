@@ -0,0 +1,106 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/exp/maps"
+	"golang.org/x/xerrors"
+)
+
+// TypeGroups has aliases of analyzers which can be enabled or disabled together
+var TypeGroups = map[string][]Type{
+	"lang":    TypeLanguages,
+	"os":      TypeOSes,
+	"iac":     TypeConfigFiles,
+	"secret":  {TypeSecret},
+	"license": {TypeLicenseFile, TypeDpkgLicense},
+}
+
+// RegisteredTypes returns the types of all the registered analyzers in alphabetical order
+func RegisteredTypes() []Type {
+	registered := maps.Keys(analyzers)
+	registered = append(registered, maps.Keys(postAnalyzers)...)
+	registered = append(registered, maps.Keys(configAnalyzerConstructors)...)
+	registered = lo.Uniq(registered)
+	sort.Slice(registered, func(i, j int) bool {
+		return registered[i] < registered[j]
+	})
+	return registered
+}
+
+// ParseTypes converts analyzer types and group aliases, e.g. "jar" and "lang", into analyzer types.
+// Analyzer types are not validated here as analyzers of WASM modules and plugins are registered later,
+// and ValidateTypes must be called once they are registered.
+func ParseTypes(names []string) []Type {
+	var parsed []Type
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if group, ok := TypeGroups[name]; ok {
+			parsed = append(parsed, group...)
+			continue
+		}
+		parsed = append(parsed, Type(name))
+	}
+	if len(parsed) == 0 {
+		return nil
+	}
+	return lo.Uniq(parsed)
+}
+
+// ValidateTypes validates analyzer types against the registered analyzers
+func ValidateTypes(types []Type) error {
+	registered := RegisteredTypes()
+	for _, t := range types {
+		if !lo.Contains(registered, t) {
+			return unknownTypeError(string(t), registered)
+		}
+	}
+	return nil
+}
+
+// unknownTypeError suggests similar analyzers, or lists all of them if nothing is similar
+func unknownTypeError(name string, registered []Type) error {
+	groups := maps.Keys(TypeGroups)
+	sort.Strings(groups)
+
+	candidates := append(groups, lo.Map(registered, func(t Type, _ int) string { return string(t) })...)
+	similar := lo.Filter(candidates, func(c string, _ int) bool {
+		return strings.Contains(c, name) || strings.Contains(name, c) || editDistance(c, name) <= 2
+	})
+	if len(similar) > 0 {
+		return xerrors.Errorf("unknown analyzer %q, did you mean %s?", name, quoteJoin(lo.Uniq(similar), " or "))
+	}
+	return xerrors.Errorf("unknown analyzer %q, available groups: %s, available analyzers: %s",
+		name, strings.Join(groups, ", "), strings.Join(candidates[len(groups):], ", "))
+}
+
+func quoteJoin(ss []string, sep string) string {
+	return strings.Join(lo.Map(ss, func(s string, _ int) string { return fmt.Sprintf("%q", s) }), sep)
+}
+
+// editDistance returns the Levenshtein distance between the two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = lo.Min([]int{prev[j] + 1, cur[j-1] + 1, prev[j-1] + cost})
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,87 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+)
+
+func TestParseTypes(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  []analyzer.Type
+	}{
+		{
+			name:  "analyzers",
+			names: []string{"jar", " Bundler ", "jar"},
+			want: []analyzer.Type{
+				analyzer.TypeJar,
+				analyzer.TypeBundler,
+			},
+		},
+		{
+			name:  "groups",
+			names: []string{"secret", "license"},
+			want: []analyzer.Type{
+				analyzer.TypeSecret,
+				analyzer.TypeLicenseFile,
+				analyzer.TypeDpkgLicense,
+			},
+		},
+		{
+			name:  "empty",
+			names: []string{""},
+		},
+		{
+			name:  "not registered yet",
+			names: []string{"my-plugin"},
+			want:  []analyzer.Type{"my-plugin"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := analyzer.ParseTypes(tt.names)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		types   []analyzer.Type
+		wantErr string
+	}{
+		{
+			name: "registered",
+			types: []analyzer.Type{
+				analyzer.TypeJar,
+				analyzer.TypeBundler,
+			},
+		},
+		{
+			name:    "typo",
+			types:   []analyzer.Type{"poety"},
+			wantErr: `unknown analyzer "poety", did you mean "poetry"?`,
+		},
+		{
+			name:    "unknown",
+			types:   []analyzer.Type{"zzzzzzzz"},
+			wantErr: `unknown analyzer "zzzzzzzz", available groups: iac, lang, license, os, secret, available analyzers: alpine,`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := analyzer.ValidateTypes(tt.types)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/fanal/utils"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&serverAnalyzer{})
+}
+
+const (
+	version = 1
+
+	// maxBinarySize limits the size of server binaries to be read
+	maxBinarySize = 64 << 20
+
+	// The properties in catalina.jar, which is shown as the server info by Tomcat
+	tomcatServerInfo = "org/apache/catalina/util/ServerInfo.properties"
+)
+
+var (
+	// NGINX_VER, e.g. "nginx/1.25.1"
+	nginxRegex = regexp.MustCompile(`nginx/(\d+\.\d+\.\d+)`)
+	// AP_SERVER_BASEVERSION, e.g. "Apache/2.4.57"
+	httpdRegex = regexp.MustCompile(`Apache/(2\.\d+\.\d+)`)
+	// e.g. "server.info=Apache Tomcat/9.0.76"
+	tomcatRegex = regexp.MustCompile(`Apache Tomcat/(\d+\.\d+\.\d+\S*)`)
+)
+
+// product detects the version of the server software from its file
+type product struct {
+	name     string
+	required func(filePath string, info os.FileInfo) bool
+	version  func(input analyzer.AnalysisInput) (string, error)
+}
+
+var products = []product{
+	{
+		name: "nginx",
+		required: func(filePath string, info os.FileInfo) bool {
+			return path.Base(filePath) == "nginx" && utils.IsExecutable(info)
+		},
+		version: binaryVersion(nginxRegex),
+	},
+	{
+		name: "httpd",
+		required: func(filePath string, info os.FileInfo) bool {
+			base := path.Base(filePath)
+			return (base == "httpd" || base == "apache2") && utils.IsExecutable(info)
+		},
+		version: binaryVersion(httpdRegex),
+	},
+	{
+		name: "tomcat",
+		required: func(filePath string, _ os.FileInfo) bool {
+			// e.g. "/usr/local/tomcat/lib/catalina.jar"
+			return strings.HasSuffix(filePath, "lib/catalina.jar")
+		},
+		version: tomcatVersion,
+	},
+}
+
+// serverAnalyzer detects web servers and middleware installed without OS package managers,
+// e.g. nginx compiled from source under "/usr/local".
+type serverAnalyzer struct{}
+
+func (a serverAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	for _, p := range products {
+		if !p.required(input.FilePath, input.Info) {
+			continue
+		}
+
+		ver, err := p.version(input)
+		if err != nil {
+			return nil, xerrors.Errorf("%s version error (%s): %w", p.name, input.FilePath, err)
+		} else if ver == "" {
+			return nil, nil
+		}
+
+		return &analyzer.AnalysisResult{
+			Applications: []types.Application{
+				{
+					Type:     types.Server,
+					FilePath: input.FilePath,
+					Libraries: []types.Package{
+						{
+							ID:       fmt.Sprintf("%s@%s", p.name, ver),
+							Name:     p.name,
+							Version:  ver,
+							FilePath: input.FilePath,
+						},
+					},
+				},
+			},
+		}, nil
+	}
+	return nil, nil
+}
+
+// binaryVersion returns the first version embedded in the binary
+func binaryVersion(re *regexp.Regexp) func(input analyzer.AnalysisInput) (string, error) {
+	return func(input analyzer.AnalysisInput) (string, error) {
+		if input.Info.Size() > maxBinarySize {
+			return "", nil
+		}
+		b, err := io.ReadAll(input.Content)
+		if err != nil {
+			return "", xerrors.Errorf("read error: %w", err)
+		}
+		if m := re.FindSubmatch(b); m != nil {
+			return string(m[1]), nil
+		}
+		return "", nil
+	}
+}
+
+func tomcatVersion(input analyzer.AnalysisInput) (string, error) {
+	zr, err := zip.NewReader(input.Content, input.Info.Size())
+	if err != nil {
+		return "", xerrors.Errorf("zip error: %w", err)
+	}
+	f, err := zr.Open(tomcatServerInfo)
+	if err != nil {
+		// Not Tomcat
+		return "", nil
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if m := tomcatRegex.FindStringSubmatch(scanner.Text()); m != nil {
+			return m[1], nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+func (a serverAnalyzer) Required(filePath string, info os.FileInfo) bool {
+	for _, p := range products {
+		if p.required(filePath, info) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a serverAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeServer
+}
+
+func (a serverAnalyzer) Version() int {
+	return version
+}
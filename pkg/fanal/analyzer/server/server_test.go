@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func Test_serverAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		filePath  string
+		inputFile string
+		want      *analyzer.AnalysisResult
+		wantErr   string
+	}{
+		{
+			name:      "nginx",
+			filePath:  "usr/local/nginx/sbin/nginx",
+			inputFile: "testdata/nginx",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Server,
+						FilePath: "usr/local/nginx/sbin/nginx",
+						Libraries: []types.Package{
+							{
+								ID:       "nginx@1.25.1",
+								Name:     "nginx",
+								Version:  "1.25.1",
+								FilePath: "usr/local/nginx/sbin/nginx",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "httpd",
+			filePath:  "usr/local/apache2/bin/httpd",
+			inputFile: "testdata/httpd",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Server,
+						FilePath: "usr/local/apache2/bin/httpd",
+						Libraries: []types.Package{
+							{
+								ID:       "httpd@2.4.57",
+								Name:     "httpd",
+								Version:  "2.4.57",
+								FilePath: "usr/local/apache2/bin/httpd",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "tomcat",
+			filePath:  "usr/local/tomcat/lib/catalina.jar",
+			inputFile: "testdata/catalina.jar",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Server,
+						FilePath: "usr/local/tomcat/lib/catalina.jar",
+						Libraries: []types.Package{
+							{
+								ID:       "tomcat@9.0.76",
+								Name:     "tomcat",
+								Version:  "9.0.76",
+								FilePath: "usr/local/tomcat/lib/catalina.jar",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "no version",
+			filePath:  "usr/local/sbin/nginx",
+			inputFile: "testdata/unknown",
+		},
+		{
+			name:      "not tomcat",
+			filePath:  "opt/app/lib/catalina.jar",
+			inputFile: "testdata/other.jar",
+		},
+		{
+			name:      "broken jar",
+			filePath:  "usr/local/tomcat/lib/catalina.jar",
+			inputFile: "testdata/nginx",
+			wantErr:   "zip error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+			info, err := f.Stat()
+			require.NoError(t, err)
+
+			a := serverAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.filePath,
+				Content:  f,
+				Info:     info,
+			})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_serverAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name      string
+		filePath  string
+		inputFile string
+		want      bool
+	}{
+		{
+			name:      "nginx",
+			filePath:  "usr/sbin/nginx",
+			inputFile: "testdata/nginx",
+			want:      true,
+		},
+		{
+			name:      "apache2",
+			filePath:  "usr/sbin/apache2",
+			inputFile: "testdata/httpd",
+			want:      true,
+		},
+		{
+			name:      "not executable",
+			filePath:  "etc/nginx/nginx",
+			inputFile: "testdata/catalina.jar",
+			want:      false,
+		},
+		{
+			name:      "catalina.jar",
+			filePath:  "usr/local/tomcat/lib/catalina.jar",
+			inputFile: "testdata/catalina.jar",
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := os.Stat(tt.inputFile)
+			require.NoError(t, err)
+
+			a := serverAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, info))
+		})
+	}
+}
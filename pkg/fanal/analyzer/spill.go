@@ -0,0 +1,259 @@
+package analyzer
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"unsafe"
+
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+const (
+	packageInfosBucket    = "package-infos"
+	applicationsBucket    = "applications"
+	customResourcesBucket = "custom-resources"
+
+	// resultMemoryRatio is the ratio of the memory budget for resident packages and custom resources.
+	// The rest is left for analyzers and the walker.
+	resultMemoryRatio = 4
+)
+
+// spillStore keeps packages and custom resources in temporary storage
+// when they exceed the memory budget, e.g. in huge monorepos.
+type spillStore struct {
+	dir string
+	db  *bolt.DB
+
+	// threshold is the size in bytes of resident collections which triggers spilling
+	threshold int64
+
+	// resident is the estimated size in bytes of collections in memory, tracked on every merge
+	resident int64
+	seq      uint64
+
+	// spilled is the number of spilled items in each bucket
+	spilled map[string]int
+
+	// files are the paths of spilled applications and their libraries, which post-analyzers skip
+	files []string
+}
+
+// EnableSpill makes the result spill packages and custom resources to temporary storage
+// when they exceed a share of the memory budget in bytes. It does nothing if the budget is not positive.
+// Restore must be called to get them back.
+func (r *AnalysisResult) EnableSpill(budget int64) error {
+	if budget <= 0 {
+		return nil
+	}
+
+	dir, err := os.MkdirTemp("", "analysis-result-*")
+	if err != nil {
+		return xerrors.Errorf("unable to create a temp dir: %w", err)
+	}
+	db, err := bolt.Open(filepath.Join(dir, "result.db"), 0600, &bolt.Options{NoSync: true})
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return xerrors.Errorf("unable to open the temp DB: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range []string{packageInfosBucket, applicationsBucket, customResourcesBucket} {
+			if _, err := tx.CreateBucketIfNotExists([]byte(bucket)); err != nil {
+				return xerrors.Errorf("unable to create a bucket: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+		return xerrors.Errorf("temp DB error: %w", err)
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.spill = &spillStore{
+		dir:       dir,
+		db:        db,
+		threshold: budget / resultMemoryRatio,
+		spilled:   make(map[string]int),
+	}
+	return nil
+}
+
+// Restore loads the spilled collections into the final result and removes the temporary storage.
+// It must be called after post-analysis so that the results of post-analyzers are spilled as well.
+// As the whole result is held in memory again for sorting and caching, spilling only bounds the memory
+// during the walk and analysis.
+func (r *AnalysisResult) Restore() error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.spill == nil {
+		return nil
+	}
+	defer r.closeSpill()
+
+	err := r.spill.db.View(func(tx *bolt.Tx) error {
+		var err error
+		if r.PackageInfos, err = restoreBucket(tx, packageInfosBucket, r.spill.spilled[packageInfosBucket], r.PackageInfos); err != nil {
+			return err
+		}
+		if r.Applications, err = restoreBucket(tx, applicationsBucket, r.spill.spilled[applicationsBucket], r.Applications); err != nil {
+			return err
+		}
+		r.CustomResources, err = restoreBucket(tx, customResourcesBucket, r.spill.spilled[customResourcesBucket], r.CustomResources)
+		return err
+	})
+	if err != nil {
+		return xerrors.Errorf("unable to restore the analysis result: %w", err)
+	}
+	return nil
+}
+
+// Close removes the temporary storage without restoring the collections.
+// It is safe to call it after Restore.
+func (r *AnalysisResult) Close() {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.closeSpill()
+}
+
+func (r *AnalysisResult) closeSpill() {
+	if r.spill == nil {
+		return
+	}
+	if err := r.spill.db.Close(); err != nil {
+		log.Logger.Debugf("Unable to close the temp DB: %s", err)
+	}
+	if err := os.RemoveAll(r.spill.dir); err != nil {
+		log.Logger.Debugf("Unable to remove the temp dir: %s", err)
+	}
+	r.spill = nil
+}
+
+// spillIfNeeded writes the resident collections to the temporary storage when they exceed the threshold.
+// The caller must hold the lock.
+func (r *AnalysisResult) spillIfNeeded(new *AnalysisResult) {
+	if r.spill == nil {
+		return
+	}
+	for _, pi := range new.PackageInfos {
+		r.spill.resident += int64(unsafe.Sizeof(pi)) + int64(len(pi.FilePath)) + packagesSize(pi.Packages)
+	}
+	for _, app := range new.Applications {
+		r.spill.resident += int64(unsafe.Sizeof(app)) + int64(len(app.FilePath)) + packagesSize(app.Libraries)
+	}
+	for _, cr := range new.CustomResources {
+		// The size of the data is unknown
+		r.spill.resident += int64(unsafe.Sizeof(cr)) + int64(len(cr.Type)+len(cr.FilePath))
+	}
+	if r.spill.resident < r.spill.threshold {
+		return
+	}
+
+	log.Logger.Debugf("Spilling the analysis result to disk (%d bytes)", r.spill.resident)
+	seq := r.spill.seq
+	err := r.spill.db.Update(func(tx *bolt.Tx) error {
+		if err := spillBucket(tx, packageInfosBucket, &seq, r.PackageInfos); err != nil {
+			return err
+		}
+		if err := spillBucket(tx, applicationsBucket, &seq, r.Applications); err != nil {
+			return err
+		}
+		return spillBucket(tx, customResourcesBucket, &seq, r.CustomResources)
+	})
+	if err != nil {
+		// Keep them in memory
+		log.Logger.Warnf("Unable to spill the analysis result to disk: %s", err)
+		return
+	}
+
+	r.spill.seq = seq
+	r.spill.spilled[packageInfosBucket] += len(r.PackageInfos)
+	r.spill.spilled[applicationsBucket] += len(r.Applications)
+	r.spill.spilled[customResourcesBucket] += len(r.CustomResources)
+	for _, app := range r.Applications {
+		r.spill.files = append(r.spill.files, app.FilePath)
+		for _, lib := range app.Libraries {
+			if lib.FilePath != "" {
+				r.spill.files = append(r.spill.files, lib.FilePath)
+			}
+		}
+	}
+
+	r.PackageInfos, r.Applications, r.CustomResources = nil, nil, nil
+	r.spill.resident = 0
+}
+
+// packagesSize returns the estimated size in bytes of the packages in memory from their strings,
+// which is cheap enough to be tracked on every merge
+func packagesSize(pkgs []types.Package) int64 {
+	size := int64(len(pkgs)) * int64(unsafe.Sizeof(types.Package{}))
+	for _, pkg := range pkgs {
+		size += int64(len(pkg.ID) + len(pkg.Name) + len(pkg.Version) + len(pkg.Release) + len(pkg.Arch) +
+			len(pkg.SrcName) + len(pkg.SrcVersion) + len(pkg.SrcRelease) + len(pkg.Maintainer) + len(pkg.Ref) +
+			len(pkg.FilePath) + len(pkg.Digest))
+		for _, s := range pkg.Licenses {
+			size += int64(len(s))
+		}
+		for _, s := range pkg.DependsOn {
+			size += int64(len(s))
+		}
+		size += int64(len(pkg.Locations)) * int64(unsafe.Sizeof(types.Location{}))
+	}
+	return size
+}
+
+func spillBucket[T types.PackageInfo | types.Application | types.CustomResource](tx *bolt.Tx, bucket string, seq *uint64, items []T) error {
+	b := tx.Bucket([]byte(bucket))
+	for _, item := range items {
+		v, err := json.Marshal(item)
+		if err != nil {
+			return xerrors.Errorf("json marshal error: %w", err)
+		}
+
+		// Sequential keys keep the order of items
+		*seq++
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, *seq)
+		if err = b.Put(key, v); err != nil {
+			return xerrors.Errorf("unable to put an item in %s: %w", bucket, err)
+		}
+	}
+	return nil
+}
+
+// restoreBucket decodes the spilled items one by one into a slice allocated for all the items,
+// followed by the resident ones, so that the spilled items are not copied again.
+func restoreBucket[T types.PackageInfo | types.Application | types.CustomResource](tx *bolt.Tx, bucket string, n int, resident []T) ([]T, error) {
+	if n == 0 {
+		return resident, nil
+	}
+	items := make([]T, 0, n+len(resident))
+	err := tx.Bucket([]byte(bucket)).ForEach(func(_, v []byte) error {
+		var item T
+		if err := json.Unmarshal(v, &item); err != nil {
+			return xerrors.Errorf("unable to decode an item in %s: %w", bucket, err)
+		}
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(items, resident...), nil
+}
+
+// spilledFiles returns the paths of spilled applications and their libraries.
+// The caller must hold the lock.
+func (r *AnalysisResult) spilledFiles() []string {
+	if r.spill == nil {
+		return nil
+	}
+	return r.spill.files
+}
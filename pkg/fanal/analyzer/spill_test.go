@@ -0,0 +1,110 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func TestAnalysisResult_Restore(t *testing.T) {
+	newResults := func() []*analyzer.AnalysisResult {
+		return []*analyzer.AnalysisResult{
+			{
+				PackageInfos: []types.PackageInfo{
+					{
+						FilePath: "lib/apk/db/installed",
+						Packages: types.Packages{
+							{
+								Name:    "musl",
+								Version: "1.2.3",
+							},
+						},
+					},
+				},
+			},
+			{
+				Applications: []types.Application{
+					{
+						Type:     types.Npm,
+						FilePath: "app/package-lock.json",
+						Libraries: types.Packages{
+							{
+								Name:    "lodash",
+								Version: "4.17.21",
+							},
+						},
+					},
+				},
+				Secrets: []types.Secret{
+					{
+						FilePath: "app/.env",
+					},
+				},
+			},
+			{
+				Applications: []types.Application{
+					{
+						Type:     types.GoModule,
+						FilePath: "go.mod",
+					},
+				},
+				CustomResources: []types.CustomResource{
+					{
+						Type:     "custom",
+						FilePath: "custom.yaml",
+						Data:     "data",
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		budget int64
+	}{
+		{
+			name:   "every merge spills",
+			budget: 4,
+		},
+		{
+			name:   "some merges spill",
+			budget: 1000,
+		},
+		{
+			name:   "no spill",
+			budget: 1 << 30,
+		},
+		{
+			name:   "disabled",
+			budget: 0,
+		},
+	}
+
+	want := analyzer.NewAnalysisResult()
+	for _, r := range newResults() {
+		want.Merge(r)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := analyzer.NewAnalysisResult()
+			require.NoError(t, got.EnableSpill(tt.budget))
+			defer got.Close()
+
+			for _, r := range newResults() {
+				got.Merge(r)
+			}
+			require.NoError(t, got.Restore())
+
+			assert.Equal(t, want.PackageInfos, got.PackageInfos)
+			assert.Equal(t, want.Applications, got.Applications)
+			assert.Equal(t, want.CustomResources, got.CustomResources)
+			assert.Equal(t, want.Secrets, got.Secrets)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/log"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// errTimeout occurs when an analyzer exceeds AnalyzerOptions.Timeout
+var errTimeout = xerrors.New("analyzer timed out")
+
+// withTimeout runs the function until the timeout elapses.
+// Most analyzers parse files without checking the context,
+// so the function is left running in the background after the timeout and its result is discarded.
+// "release" is called once the function returns, so that what the function uses,
+// such as the file content and the worker slot, is held until then even after the timeout.
+// The error of the parent context, such as the global timeout, is returned as is.
+func withTimeout[T any](ctx context.Context, timeout time.Duration, f func(ctx context.Context) (T, error), release func()) (T, error) {
+	if timeout <= 0 {
+		defer release()
+		return f(ctx)
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		v, err := f(tctx)
+		done <- result{value: v, err: err}
+	}()
+
+	var zero T
+	select {
+	case r := <-done:
+		cancel()
+		release()
+		return r.value, r.err
+	case <-tctx.Done():
+		go func() {
+			<-done
+			cancel()
+			release()
+		}()
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+		return zero, errTimeout
+	}
+}
+
+func (ag AnalyzerGroup) analyze(ctx context.Context, a analyzer, input AnalysisInput, release func()) (*AnalysisResult, error) {
+	return withTimeout(ctx, ag.timeout, func(ctx context.Context) (*AnalysisResult, error) {
+		return a.Analyze(ctx, input)
+	}, release)
+}
+
+// timeoutWarning returns the result holding only the warning of the timed-out analyzer.
+// The file path is empty for post-analyzers as they analyze all the required files at once.
+func (ag AnalyzerGroup) timeoutWarning(analyzerType Type, filePath string) *AnalysisResult {
+	msg := fmt.Sprintf("analysis timed out after %s", ag.timeout)
+	if filePath != "" {
+		log.Logger.Warnf("The %s analyzer timed out on %s after %s, increase --analyzer-timeout", analyzerType, filePath, ag.timeout)
+	} else {
+		log.Logger.Warnf("The %s analyzer timed out after %s, increase --analyzer-timeout", analyzerType, ag.timeout)
+	}
+	return &AnalysisResult{
+		Warnings: []types.Warning{
+			{
+				Analyzer: string(analyzerType),
+				FilePath: filePath,
+				Message:  msg,
+			},
+		},
+	}
+}
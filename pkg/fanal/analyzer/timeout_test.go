@@ -0,0 +1,167 @@
+package analyzer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dio "github.com/aquasecurity/go-dep-parser/pkg/io"
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// slowAnalyzer takes the delay ignoring the context as most analyzers do
+type slowAnalyzer struct {
+	delay time.Duration
+}
+
+func (a slowAnalyzer) Analyze(_ context.Context, _ analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	time.Sleep(a.delay)
+	return &analyzer.AnalysisResult{
+		Applications: []types.Application{
+			{
+				Type:     types.Npm,
+				FilePath: "package-lock.json",
+			},
+		},
+	}, nil
+}
+
+func (a slowAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return filePath == "package-lock.json"
+}
+
+func (a slowAnalyzer) Type() analyzer.Type {
+	return "slow"
+}
+
+func (a slowAnalyzer) Version() int {
+	return 1
+}
+
+func TestAnalyzerGroup_AnalyzeFile_Timeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		delay   time.Duration
+		timeout time.Duration
+		want    *analyzer.AnalysisResult
+	}{
+		{
+			name:    "within the timeout",
+			delay:   0,
+			timeout: time.Minute,
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:       types.Npm,
+						FilePath:   "package-lock.json",
+						AnalyzedBy: &types.AnalyzedBy{Type: "slow", Version: 1},
+					},
+				},
+			},
+		},
+		{
+			name:    "timed out",
+			delay:   time.Minute,
+			timeout: 10 * time.Millisecond,
+			want: &analyzer.AnalysisResult{
+				Warnings: []types.Warning{
+					{
+						Analyzer: "slow",
+						FilePath: "package-lock.json",
+						Message:  "analysis timed out after 10ms",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyzer.RegisterAnalyzer(slowAnalyzer{delay: tt.delay})
+			defer analyzer.DeregisterAnalyzer("slow")
+
+			a, err := analyzer.NewAnalyzerGroup(analyzer.AnalyzerOptions{
+				Timeout: tt.timeout,
+			})
+			require.NoError(t, err)
+
+			filePath := filepath.Join(t.TempDir(), "package-lock.json")
+			require.NoError(t, os.WriteFile(filePath, []byte("{}"), 0644))
+			info, err := os.Stat(filePath)
+			require.NoError(t, err)
+
+			var wg sync.WaitGroup
+			scheduler := analyzer.NewScheduler(analyzer.SchedulerOption{MaxWorkers: 3})
+			defer scheduler.Close()
+
+			// Disable the other analyzers registered in this package
+			disabled := lo.Without(analyzer.RegisteredTypes(), "slow")
+
+			got := new(analyzer.AnalysisResult)
+			err = a.AnalyzeFile(context.Background(), &wg, scheduler, got, "", "package-lock.json", info,
+				func() (dio.ReadSeekCloserAt, error) {
+					return os.Open(filePath)
+				}, disabled, analyzer.AnalysisOptions{})
+			require.NoError(t, err)
+
+			wg.Wait()
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// closeRecorder records whether the file is closed
+type closeRecorder struct {
+	*os.File
+	closed atomic.Bool
+}
+
+func (f *closeRecorder) Close() error {
+	f.closed.Store(true)
+	return f.File.Close()
+}
+
+func TestAnalyzerGroup_AnalyzeFile_TimeoutHoldsFile(t *testing.T) {
+	analyzer.RegisterAnalyzer(slowAnalyzer{delay: 200 * time.Millisecond})
+	defer analyzer.DeregisterAnalyzer("slow")
+
+	a, err := analyzer.NewAnalyzerGroup(analyzer.AnalyzerOptions{
+		Timeout: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	filePath := filepath.Join(t.TempDir(), "package-lock.json")
+	require.NoError(t, os.WriteFile(filePath, []byte("{}"), 0644))
+	info, err := os.Stat(filePath)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	scheduler := analyzer.NewScheduler(analyzer.SchedulerOption{MaxWorkers: 3})
+	defer scheduler.Close()
+
+	f, err := os.Open(filePath)
+	require.NoError(t, err)
+	rc := &closeRecorder{File: f}
+
+	got := new(analyzer.AnalysisResult)
+	err = a.AnalyzeFile(context.Background(), &wg, scheduler, got, "", "package-lock.json", info,
+		func() (dio.ReadSeekCloserAt, error) {
+			return rc, nil
+		}, lo.Without(analyzer.RegisteredTypes(), "slow"), analyzer.AnalysisOptions{})
+	require.NoError(t, err)
+
+	// The timed-out analyzer is still reading the file
+	wg.Wait()
+	require.Len(t, got.Warnings, 1)
+	assert.False(t, rc.closed.Load())
+
+	assert.Eventually(t, rc.closed.Load, time.Second, 10*time.Millisecond)
+}
@@ -13,6 +13,14 @@ type Applier interface {
 	ApplyLayers(artifactID string, blobIDs []string) (detail ftypes.ArtifactDetail, err error)
 }
 
+// ProgressFunc is called every time a layer is loaded from the cache
+type ProgressFunc func(applied, total int)
+
+// ProgressApplier is implemented by appliers that can report the progress of applying layers
+type ProgressApplier interface {
+	ApplyLayersWithProgress(artifactID string, blobIDs []string, progress ProgressFunc) (detail ftypes.ArtifactDetail, err error)
+}
+
 type applier struct {
 	cache cache.LocalArtifactCache
 }
@@ -22,13 +30,20 @@ func NewApplier(c cache.LocalArtifactCache) Applier {
 }
 
 func (a *applier) ApplyLayers(imageID string, layerKeys []string) (ftypes.ArtifactDetail, error) {
+	return a.ApplyLayersWithProgress(imageID, layerKeys, nil)
+}
+
+func (a *applier) ApplyLayersWithProgress(imageID string, layerKeys []string, progress ProgressFunc) (ftypes.ArtifactDetail, error) {
 	var layers []ftypes.BlobInfo
-	for _, key := range layerKeys {
+	for i, key := range layerKeys {
 		blob, _ := a.cache.GetBlob(key) // nolint
 		if blob.SchemaVersion == 0 {
 			return ftypes.ArtifactDetail{}, xerrors.Errorf("layer cache missing: %s", key)
 		}
 		layers = append(layers, blob)
+		if progress != nil {
+			progress(i+1, len(layerKeys))
+		}
 	}
 
 	mergedLayer := ApplyLayers(layers)
@@ -25,6 +25,12 @@ type History struct {
 	CreatedBy string `json:"created_by"`
 }
 
+// fileDigest is used to apply digests of executables in the same way as other files, e.g. whiteouts
+type fileDigest struct {
+	filePath string
+	digest   string
+}
+
 func containsPackage(e types.Package, s []types.Package) bool {
 	for _, a := range s {
 		if a.Name == e.Name && a.Version == e.Version && a.Release == e.Release {
@@ -104,7 +110,7 @@ func ApplyLayers(layers []types.BlobInfo) types.ArtifactDetail {
 		mergedLayer.OS.Merge(layer.OS)
 
 		if layer.Repository != nil {
-			mergedLayer.Repository = layer.Repository
+			mergedLayer.Repository = mergedLayer.Repository.Merge(layer.Repository)
 		}
 
 		// Apply OS packages
@@ -125,7 +131,8 @@ func ApplyLayers(layers []types.BlobInfo) types.ArtifactDetail {
 				Digest: layer.Digest,
 				DiffID: layer.DiffID,
 			}
-			key := fmt.Sprintf("%s/type:config", config.FilePath)
+			// Keyed by the file type so that checks of different types on the same file don't override each other
+			key := fmt.Sprintf("%s/type:config,%s", config.FilePath, config.FileType)
 			nestedMap.SetByString(key, sep, config)
 		}
 
@@ -158,21 +165,47 @@ func ApplyLayers(layers []types.BlobInfo) types.ArtifactDetail {
 			}
 			nestedMap.SetByString(key, sep, customResource)
 		}
+
+		// Apply digests of executables
+		for filePath, digest := range layer.Digests {
+			key := fmt.Sprintf("%s/type:digest", filePath)
+			nestedMap.SetByString(key, sep, fileDigest{
+				filePath: filePath,
+				digest:   digest,
+			})
+		}
+
+		// Warnings are kept even if the files are removed in upper layers, as the layers are incompletely analyzed
+		mergedLayer.Warnings = append(mergedLayer.Warnings, layer.Warnings...)
 	}
 
 	// nolint
 	_ = nestedMap.Walk(func(keys []string, value interface{}) error {
 		switch v := value.(type) {
 		case types.PackageInfo:
-			mergedLayer.Packages = append(mergedLayer.Packages, v.Packages...)
+			for _, pkg := range v.Packages {
+				if pkg.AnalyzedBy == nil {
+					pkg.AnalyzedBy = v.AnalyzedBy
+				}
+				mergedLayer.Packages = append(mergedLayer.Packages, pkg)
+			}
 		case types.Application:
 			mergedLayer.Applications = append(mergedLayer.Applications, v)
 		case types.Misconfiguration:
+			// Entries without results only override the findings in lower layers
+			if len(v.Successes)+len(v.Warnings)+len(v.Failures)+len(v.Exceptions) == 0 {
+				return nil
+			}
 			mergedLayer.Misconfigurations = append(mergedLayer.Misconfigurations, v)
 		case types.LicenseFile:
 			mergedLayer.Licenses = append(mergedLayer.Licenses, v)
 		case types.CustomResource:
 			mergedLayer.CustomResources = append(mergedLayer.CustomResources, v)
+		case fileDigest:
+			if mergedLayer.Digests == nil {
+				mergedLayer.Digests = map[string]string{}
+			}
+			mergedLayer.Digests[v.filePath] = v.digest
 		}
 		return nil
 	})
@@ -223,16 +256,22 @@ func ApplyLayers(layers []types.BlobInfo) types.ArtifactDetail {
 				Digest: originLayerDigest,
 				DiffID: originLayerDiffID,
 			}
+			if lib.AnalyzedBy == nil {
+				app.Libraries[i].AnalyzedBy = app.AnalyzedBy
+			}
 		}
 	}
 
 	// Aggregate python/ruby/node.js packages and JAR files
 	aggregate(&mergedLayer)
 
+	mergedLayer.ImageSize = calcImageSize(layers)
+	mergedLayer.ModifiedFiles = verifyIntegrity(layers)
+
 	return mergedLayer
 }
 
-// aggregate merges all packages installed by pip/gem/npm/jar/conda into each application
+// aggregate merges all packages installed by pip/gem/npm/jar/conda/snap/flatpak/homebrew into each application
 func aggregate(detail *types.ArtifactDetail) {
 	var apps []types.Application
 
@@ -242,6 +281,9 @@ func aggregate(detail *types.ArtifactDetail) {
 		types.GemSpec:   {Type: types.GemSpec},
 		types.NodePkg:   {Type: types.NodePkg},
 		types.Jar:       {Type: types.Jar},
+		types.Snap:      {Type: types.Snap},
+		types.Flatpak:   {Type: types.Flatpak},
+		types.Homebrew:  {Type: types.Homebrew},
 	}
 
 	for _, app := range detail.Applications {
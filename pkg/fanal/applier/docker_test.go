@@ -655,6 +655,169 @@ func TestApplyLayers(t *testing.T) {
 			},
 			want: types.ArtifactDetail{},
 		},
+		{
+			name: "happy path with digests of executables",
+			inputLayers: []types.BlobInfo{
+				{
+					SchemaVersion: 1,
+					Digest:        "sha256:932da51564135c98a49a34a193d6cd363d8fa4184d957fde16c9d8527b3f3b02",
+					DiffID:        "sha256:a187dde48cd289ac374ad8539930628314bc581a481cdb41409c9289419ddb72",
+					Digests: map[string]string{
+						"usr/bin/curl": "sha256:9f64a747e1b97f131fabb6b447296c9b6f0201e79fb3c5356e6c77e89b6a806a",
+						"usr/bin/wget": "sha256:c9e4f5e1cab9e5d5f8e2f6d3a9a4e8f0b9e6c0d2a1b3c4d5e6f7a8b9c0d1e2f3",
+					},
+				},
+				{
+					SchemaVersion: 1,
+					Digest:        "sha256:24df0d4e20c0f42d3703bf1f1db2bdd77346c7956f74f423603d651e8e5ae8a7",
+					DiffID:        "sha256:aad63a9339440e7c3e1fff2b988991b9bfb81280042fa7f39a5e327023056819",
+					WhiteoutFiles: []string{"usr/bin/wget"},
+					Digests: map[string]string{
+						"usr/bin/curl": "sha256:0e3c4a9f3b5d7c2b9f8a6e1d4c3b2a1f0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b",
+					},
+				},
+			},
+			want: types.ArtifactDetail{
+				Digests: map[string]string{
+					"usr/bin/curl": "sha256:0e3c4a9f3b5d7c2b9f8a6e1d4c3b2a1f0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b",
+				},
+			},
+		},
+		{
+			name: "happy path with permissions changed in an upper layer",
+			inputLayers: []types.BlobInfo{
+				{
+					SchemaVersion: 1,
+					Digest:        "sha256:932da51564135c98a49a34a193d6cd363d8fa4184d957fde16c9d8527b3f3b02",
+					DiffID:        "sha256:a187dde48cd289ac374ad8539930628314bc581a481cdb41409c9289419ddb72",
+					Misconfigurations: []types.Misconfiguration{
+						{
+							FileType: "permission",
+							FilePath: "app/config.yaml",
+							Failures: types.MisconfResults{
+								{
+									Namespace: "permission.PERM005",
+									Message:   "File is world-writable with mode 0666",
+								},
+							},
+						},
+						{
+							FileType: "yaml",
+							FilePath: "app/config.yaml",
+							Failures: types.MisconfResults{
+								{
+									Namespace: "user.test",
+									Message:   "Config is invalid",
+								},
+							},
+						},
+					},
+				},
+				{
+					SchemaVersion: 1,
+					Digest:        "sha256:24df0d4e20c0f42d3703bf1f1db2bdd77346c7956f74f423603d651e8e5ae8a7",
+					DiffID:        "sha256:aad63a9339440e7c3e1fff2b988991b9bfb81280042fa7f39a5e327023056819",
+					Misconfigurations: []types.Misconfiguration{
+						{
+							FileType: "permission",
+							FilePath: "app/config.yaml",
+						},
+					},
+				},
+			},
+			want: types.ArtifactDetail{
+				Misconfigurations: []types.Misconfiguration{
+					{
+						FileType: "yaml",
+						FilePath: "app/config.yaml",
+						Layer: types.Layer{
+							Digest: "sha256:932da51564135c98a49a34a193d6cd363d8fa4184d957fde16c9d8527b3f3b02",
+							DiffID: "sha256:a187dde48cd289ac374ad8539930628314bc581a481cdb41409c9289419ddb72",
+						},
+						Failures: types.MisconfResults{
+							{
+								Namespace: "user.test",
+								Message:   "Config is invalid",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "happy path with analyzers",
+			inputLayers: []types.BlobInfo{
+				{
+					SchemaVersion: 1,
+					DiffID:        "sha256:a187dde48cd289ac374ad8539930628314bc581a481cdb41409c9289419ddb72",
+					PackageInfos: []types.PackageInfo{
+						{
+							FilePath: "lib/apk/db/installed",
+							Packages: []types.Package{
+								{
+									Name:    "musl",
+									Version: "1.2.4",
+								},
+							},
+							AnalyzedBy: &types.AnalyzedBy{Type: "apk", Version: 2},
+						},
+					},
+					Applications: []types.Application{
+						{
+							Type:     types.Jar,
+							FilePath: "app/app.jar",
+							Libraries: []types.Package{
+								{
+									Name:    "org.example:foo",
+									Version: "1.0.0",
+								},
+								{
+									Name:       "org.example:bar",
+									Version:    "2.0.0",
+									AnalyzedBy: &types.AnalyzedBy{Type: "pom", Version: 1},
+								},
+							},
+							AnalyzedBy: &types.AnalyzedBy{Type: "jar", Version: 1},
+						},
+					},
+				},
+			},
+			want: types.ArtifactDetail{
+				Packages: types.Packages{
+					{
+						Name:    "musl",
+						Version: "1.2.4",
+						Layer: types.Layer{
+							DiffID: "sha256:a187dde48cd289ac374ad8539930628314bc581a481cdb41409c9289419ddb72",
+						},
+						AnalyzedBy: &types.AnalyzedBy{Type: "apk", Version: 2},
+					},
+				},
+				Applications: []types.Application{
+					{
+						Type: types.Jar,
+						Libraries: types.Packages{
+							{
+								Name:    "org.example:bar",
+								Version: "2.0.0",
+								Layer: types.Layer{
+									DiffID: "sha256:a187dde48cd289ac374ad8539930628314bc581a481cdb41409c9289419ddb72",
+								},
+								AnalyzedBy: &types.AnalyzedBy{Type: "pom", Version: 1},
+							},
+							{
+								Name:    "org.example:foo",
+								Version: "1.0.0",
+								Layer: types.Layer{
+									DiffID: "sha256:a187dde48cd289ac374ad8539930628314bc581a481cdb41409c9289419ddb72",
+								},
+								AnalyzedBy: &types.AnalyzedBy{Type: "jar", Version: 1},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "happy path with Red Hat content sets",
 			inputLayers: []types.BlobInfo{
@@ -796,6 +959,93 @@ func TestApplyLayers(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "happy path with file sizes",
+			inputLayers: []types.BlobInfo{
+				{
+					SchemaVersion: 1,
+					DiffID:        "sha256:base",
+					CreatedBy:     "ADD file:0123456789 in /",
+					FileSizes: map[string]int64{
+						"etc/hosts":         100,
+						"var/cache/apk/a":   1000,
+						"var/cache/apk/b":   2000,
+						"usr/lib/libssl.so": 5000,
+					},
+				},
+				{
+					SchemaVersion: 1,
+					DiffID:        "sha256:app",
+					CreatedBy:     "RUN apk upgrade && rm -rf /var/cache/apk",
+					WhiteoutFiles: []string{"var/cache/apk"},
+					FileSizes: map[string]int64{
+						"usr/lib/libssl.so": 6000,
+						"app/server":        10000,
+					},
+				},
+				{
+					SchemaVersion: 1,
+					DiffID:        "sha256:config",
+					CreatedBy:     "COPY config /etc",
+					OpaqueDirs:    []string{"etc/"},
+					FileSizes: map[string]int64{
+						"etc/app.conf": 10,
+					},
+				},
+			},
+			want: types.ArtifactDetail{
+				ImageSize: &types.ImageSize{
+					Layers: []types.LayerSize{
+						{
+							DiffID:     "sha256:base",
+							CreatedBy:  "ADD file:0123456789 in /",
+							AddedSize:  8100,
+							AddedFiles: 4,
+						},
+						{
+							DiffID:       "sha256:app",
+							CreatedBy:    "RUN apk upgrade && rm -rf /var/cache/apk",
+							AddedSize:    16000,
+							AddedFiles:   2,
+							RemovedSize:  8000,
+							RemovedFiles: 3,
+						},
+						{
+							DiffID:       "sha256:config",
+							CreatedBy:    "COPY config /etc",
+							AddedSize:    10,
+							AddedFiles:   1,
+							RemovedSize:  100,
+							RemovedFiles: 1,
+						},
+					},
+					TotalSize:  24110,
+					WastedSize: 8100,
+					WastedFiles: []types.WastedFile{
+						{
+							Path:   "usr/lib/libssl.so",
+							Size:   5000,
+							Copies: 1,
+						},
+						{
+							Path:   "var/cache/apk/b",
+							Size:   2000,
+							Copies: 1,
+						},
+						{
+							Path:   "var/cache/apk/a",
+							Size:   1000,
+							Copies: 1,
+						},
+						{
+							Path:   "etc/hosts",
+							Size:   100,
+							Copies: 1,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,107 @@
+package applier
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/zhanglimao/trivy/pkg/digest"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// usrMergedDirs are symlinks to the directories under "usr" in merged-/usr distributions
+var usrMergedDirs = []string{
+	"bin/",
+	"sbin/",
+	"lib/",
+	"lib32/",
+	"lib64/",
+	"libx32/",
+}
+
+type layerFile struct {
+	digests []digest.Digest
+	layer   types.Layer
+}
+
+// verifyIntegrity compares the digests of files in the image with the package databases,
+// and returns the files installed by OS packages and modified afterwards.
+// Files missing in the image are not reported as slim images often remove them, e.g. documents.
+// It returns nil when the digests are not recorded, i.e. the integrity analyzer is disabled.
+func verifyIntegrity(layers []types.BlobInfo) []types.ModifiedFile {
+	files := map[string]layerFile{}              // files visible at the current layer
+	databases := map[string]types.PackageFiles{} // package databases visible at the current layer
+
+	for _, layer := range layers {
+		// Files in opaque directories and whiteout files are removed from lower layers
+		for _, paths := range [][]string{layer.OpaqueDirs, layer.WhiteoutFiles} {
+			for _, p := range paths {
+				p = strings.TrimSuffix(p, "/")
+				removed := func(filePath string) bool {
+					return p == "" || filePath == p || strings.HasPrefix(filePath, p+"/")
+				}
+				for filePath := range files {
+					if removed(filePath) {
+						delete(files, filePath)
+					}
+				}
+				for filePath := range databases {
+					if removed(filePath) {
+						delete(databases, filePath)
+					}
+				}
+			}
+		}
+
+		for filePath, digests := range layer.FileDigests {
+			files[filePath] = layerFile{
+				digests: digests,
+				layer: types.Layer{
+					Digest: layer.Digest,
+					DiffID: layer.DiffID,
+				},
+			}
+		}
+		for _, db := range layer.PackageFiles {
+			databases[db.FilePath] = db
+		}
+	}
+
+	var modified []types.ModifiedFile
+	for _, db := range databases {
+		for _, pkgFile := range db.Files {
+			file, ok := files[pkgFile.Path]
+			if !ok {
+				// e.g. "bin/bash" is installed to "usr/bin/bash" in merged-/usr distributions
+				for _, dir := range usrMergedDirs {
+					if strings.HasPrefix(pkgFile.Path, dir) {
+						file, ok = files["usr/"+pkgFile.Path]
+						break
+					}
+				}
+			}
+			if !ok {
+				continue
+			}
+
+			for _, d := range file.digests {
+				if d.Algorithm() == pkgFile.Digest.Algorithm() && d != pkgFile.Digest {
+					modified = append(modified, types.ModifiedFile{
+						Path:     pkgFile.Path,
+						PkgName:  pkgFile.PkgName,
+						Expected: pkgFile.Digest,
+						Actual:   d,
+						Layer:    file.layer,
+					})
+				}
+			}
+		}
+	}
+
+	sort.Slice(modified, func(i, j int) bool {
+		if modified[i].Path != modified[j].Path {
+			return modified[i].Path < modified[j].Path
+		}
+		return modified[i].PkgName < modified[j].PkgName
+	})
+	return modified
+}
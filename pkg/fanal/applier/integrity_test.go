@@ -0,0 +1,162 @@
+package applier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zhanglimao/trivy/pkg/digest"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+func Test_verifyIntegrity(t *testing.T) {
+	const (
+		bashMD5      digest.Digest = "md5:5d41402abc4b2a76b9719d911017c592"
+		bashSHA256   digest.Digest = "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+		evilMD5      digest.Digest = "md5:d41d8cd98f00b204e9800998ecf8427e"
+		evilSHA256   digest.Digest = "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+		curlMD5      digest.Digest = "md5:098f6bcd4621d373cade4e832627b4f6"
+		libsslSHA256 digest.Digest = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	)
+
+	tests := []struct {
+		name   string
+		layers []types.BlobInfo
+		want   []types.ModifiedFile
+	}{
+		{
+			name: "modified in an upper layer",
+			layers: []types.BlobInfo{
+				{
+					Digest: "sha256:base",
+					DiffID: "sha256:base-diff",
+					PackageFiles: []types.PackageFiles{
+						{
+							FilePath: "var/lib/dpkg/info/bash.md5sums",
+							Files: []types.PackageFile{
+								{
+									Path:    "bin/bash",
+									PkgName: "bash",
+									Digest:  bashMD5,
+								},
+							},
+						},
+						{
+							FilePath: "var/lib/dpkg/info/curl.md5sums",
+							Files: []types.PackageFile{
+								{
+									Path:    "usr/bin/curl",
+									PkgName: "curl",
+									Digest:  curlMD5,
+								},
+								{
+									// missing files are not reported
+									Path:    "usr/share/doc/curl/copyright",
+									PkgName: "curl",
+									Digest:  curlMD5,
+								},
+							},
+						},
+					},
+					FileDigests: map[string][]digest.Digest{
+						// merged-/usr
+						"usr/bin/bash": {
+							bashMD5,
+							bashSHA256,
+						},
+						"usr/bin/curl": {
+							curlMD5,
+							evilSHA256,
+						},
+					},
+				},
+				{
+					Digest: "sha256:upper",
+					DiffID: "sha256:upper-diff",
+					FileDigests: map[string][]digest.Digest{
+						"usr/bin/bash": {
+							evilMD5,
+							evilSHA256,
+						},
+					},
+				},
+			},
+			want: []types.ModifiedFile{
+				{
+					Path:     "bin/bash",
+					PkgName:  "bash",
+					Expected: bashMD5,
+					Actual:   evilMD5,
+					Layer: types.Layer{
+						Digest: "sha256:upper",
+						DiffID: "sha256:upper-diff",
+					},
+				},
+			},
+		},
+		{
+			name: "removed files and databases",
+			layers: []types.BlobInfo{
+				{
+					Digest: "sha256:base",
+					DiffID: "sha256:base-diff",
+					PackageFiles: []types.PackageFiles{
+						{
+							FilePath: "var/lib/rpm/rpmdb.sqlite",
+							Files: []types.PackageFile{
+								{
+									Path:    "usr/lib64/libssl.so.3",
+									PkgName: "openssl-libs",
+									Digest:  libsslSHA256,
+								},
+							},
+						},
+						{
+							FilePath: "var/lib/dpkg/info/bash.md5sums",
+							Files: []types.PackageFile{
+								{
+									Path:    "usr/bin/bash",
+									PkgName: "bash",
+									Digest:  bashMD5,
+								},
+							},
+						},
+					},
+					FileDigests: map[string][]digest.Digest{
+						"usr/lib64/libssl.so.3": {
+							evilMD5,
+							evilSHA256,
+						},
+						"usr/bin/bash": {
+							evilMD5,
+							evilSHA256,
+						},
+					},
+				},
+				{
+					Digest:        "sha256:upper",
+					DiffID:        "sha256:upper-diff",
+					OpaqueDirs:    []string{"usr/lib64/"},
+					WhiteoutFiles: []string{"var/lib/dpkg/info/bash.md5sums"},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "integrity analyzer disabled",
+			layers: []types.BlobInfo{
+				{
+					Digest: "sha256:base",
+					DiffID: "sha256:base-diff",
+				},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := verifyIntegrity(tt.layers)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
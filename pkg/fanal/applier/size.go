@@ -0,0 +1,93 @@
+package applier
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// maxWastedFiles is the maximum number of files wasting the most space to be reported
+const maxWastedFiles = 10
+
+// calcImageSize calculates per-layer sizes and the space wasted by files
+// removed by whiteouts or overwritten in upper layers.
+// It returns nil when the sizes of files are not recorded, i.e. the layer-size analyzer is disabled.
+func calcImageSize(layers []types.BlobInfo) *types.ImageSize {
+	var recorded bool
+	for _, layer := range layers {
+		if len(layer.FileSizes) > 0 {
+			recorded = true
+			break
+		}
+	}
+	if !recorded {
+		return nil
+	}
+
+	imageSize := &types.ImageSize{}
+	files := map[string]int64{} // files visible at the current layer
+	wasted := map[string]*types.WastedFile{}
+
+	for _, layer := range layers {
+		layerSize := types.LayerSize{
+			DiffID:    layer.DiffID,
+			CreatedBy: layer.CreatedBy,
+		}
+
+		remove := func(filePath string, size int64) {
+			delete(files, filePath)
+			layerSize.RemovedSize += size
+			layerSize.RemovedFiles++
+
+			w, ok := wasted[filePath]
+			if !ok {
+				w = &types.WastedFile{Path: filePath}
+				wasted[filePath] = w
+			}
+			w.Size += size
+			w.Copies++
+		}
+
+		// Files in opaque directories and whiteout files are removed from lower layers
+		for _, paths := range [][]string{layer.OpaqueDirs, layer.WhiteoutFiles} {
+			for _, p := range paths {
+				p = strings.TrimSuffix(p, "/")
+				for filePath, size := range files {
+					if p == "" || filePath == p || strings.HasPrefix(filePath, p+"/") {
+						remove(filePath, size)
+					}
+				}
+			}
+		}
+
+		for filePath, size := range layer.FileSizes {
+			// The file in lower layers is overwritten
+			if prev, ok := files[filePath]; ok {
+				remove(filePath, prev)
+			}
+			files[filePath] = size
+			layerSize.AddedSize += size
+			layerSize.AddedFiles++
+		}
+
+		imageSize.Layers = append(imageSize.Layers, layerSize)
+		imageSize.TotalSize += layerSize.AddedSize
+		imageSize.WastedSize += layerSize.RemovedSize
+	}
+
+	for _, w := range wasted {
+		imageSize.WastedFiles = append(imageSize.WastedFiles, *w)
+	}
+	sort.Slice(imageSize.WastedFiles, func(i, j int) bool {
+		if imageSize.WastedFiles[i].Size != imageSize.WastedFiles[j].Size {
+			return imageSize.WastedFiles[i].Size > imageSize.WastedFiles[j].Size
+		}
+		return imageSize.WastedFiles[i].Path < imageSize.WastedFiles[j].Path
+	})
+	if len(imageSize.WastedFiles) > maxWastedFiles {
+		imageSize.WastedFiles = imageSize.WastedFiles[:maxWastedFiles]
+	}
+
+	return imageSize
+}
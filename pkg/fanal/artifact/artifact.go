@@ -3,6 +3,7 @@ package artifact
 import (
 	"context"
 	"sort"
+	"time"
 
 	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
 	"github.com/zhanglimao/trivy/pkg/fanal/types"
@@ -16,6 +17,8 @@ type Option struct {
 	DisabledHandlers  []types.HandlerType
 	SkipFiles         []string
 	SkipDirs          []string
+	IncludeFiles      []string // Evaluated in order, so they are not sorted
+	MaxFileSize       int64
 	FilePatterns      []string
 	NoProgress        bool
 	Insecure          bool
@@ -23,14 +26,28 @@ type Option struct {
 	AppDirs           []string
 	SBOMSources       []string
 	RekorURL          string
-	Slow              bool // Lower CPU and memory
+	Slow              bool          // Lower CPU and memory
+	MaxWorkers        int           // Automatically decided if zero
+	MaxHeavyWorkers   int           // Automatically decided if zero
+	MaxMemory         int64         // Memory budget in bytes, unlimited if zero
+	AnalyzerTimeout   time.Duration // Per analyzer and file, unlimited if zero
+	KeepGoing         bool          // Record errors as warnings and continue the analysis
 	AWSRegion         string
 	FileChecksum      bool // For SPDX
+	SymlinkOption     walker.SymlinkOption
 
 	// Git repositories
-	RepoBranch string
-	RepoCommit string
-	RepoTag    string
+	RepoBranch      string
+	RepoCommit      string
+	RepoTag         string
+	RepoSparsePaths []string
+
+	// FileHashes maps file paths to hashes of their contents, e.g. git blob hashes.
+	// The analysis results of those files are cached per file and reused across artifacts.
+	FileHashes map[string]string
+
+	// Remote filesystems over SSH
+	SSHKey string // Identity file
 
 	// For image scanning
 	ImageOption types.ImageOptions
@@ -38,9 +55,14 @@ type Option struct {
 	MisconfScannerOption misconf.ScannerOption
 	SecretScannerOption  analyzer.SecretScannerOption
 	LicenseScannerOption analyzer.LicenseScannerOption
+	CustomResourceOption analyzer.CustomResourceOption
+	PermissionOption     analyzer.PermissionOption
 
 	// File walk
 	WalkOption WalkOption
+
+	// Progress receives the progress of the inspection, not shown if nil
+	Progress Progress
 }
 
 // WalkOption is a struct that allows users to define a custom walking behavior.
@@ -58,6 +80,24 @@ func (o *Option) Sort() {
 	sort.Strings(o.FilePatterns)
 }
 
+// FileFilter returns the filter of files applied by walkers
+func (o *Option) FileFilter() walker.FileFilter {
+	return walker.FileFilter{
+		IncludeFiles: o.IncludeFiles,
+		MaxFileSize:  o.MaxFileSize,
+	}
+}
+
+// SchedulerOption returns the option of the scheduler running analyzers
+func (o *Option) SchedulerOption() analyzer.SchedulerOption {
+	return analyzer.SchedulerOption{
+		Slow:            o.Slow,
+		MaxWorkers:      o.MaxWorkers,
+		MaxHeavyWorkers: o.MaxHeavyWorkers,
+		MemoryLimit:     o.MaxMemory,
+	}
+}
+
 type Artifact interface {
 	Inspect(ctx context.Context) (reference types.ArtifactReference, err error)
 	Clean(reference types.ArtifactReference) error
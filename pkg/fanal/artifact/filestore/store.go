@@ -0,0 +1,82 @@
+// Package filestore provides a content-addressed store of temporary files for post-analysis.
+package filestore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync/atomic"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/syncx"
+)
+
+// Store copies files into a temporary directory so that post-analyzers can access them via mapfs.
+// Files are keyed by the digest of the content and the file mode, and identical files are stored only once
+// even if they are found in different paths, e.g. vendored copies of the same file.
+// The stored file is shared by all the post-analyzers and must not be modified.
+type Store struct {
+	dir   string
+	files syncx.Map[string, string] // key => path of the stored file
+
+	// The content is written before the digest is known, so duplicates are written and then removed
+	stored    atomic.Int64 // bytes kept on the disk
+	reclaimed atomic.Int64 // bytes removed as the same content already exists
+}
+
+// New returns a store saving files in the given directory. The caller is responsible for removing the directory.
+func New(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Put copies the content into the store and returns the path of the stored file.
+// When the same content with the same mode is already stored, the new copy is removed and the existing path is returned.
+func (s *Store) Put(r io.Reader, mode fs.FileMode) (string, error) {
+	digest, size, tmpPath, err := s.copy(r, mode)
+	if err != nil {
+		return "", err
+	}
+
+	// The mode is a part of the key as post-analyzers may refer to it, e.g. executable bits
+	key := fmt.Sprintf("%s:%s", digest, mode)
+	if existing, loaded := s.files.LoadOrStore(key, tmpPath); loaded {
+		_ = os.Remove(tmpPath)
+		s.reclaimed.Add(size)
+		return existing, nil
+	}
+	s.stored.Add(size)
+	return tmpPath, nil
+}
+
+// copy writes the content to a new temporary file while calculating the digest
+func (s *Store) copy(r io.Reader, mode fs.FileMode) (string, int64, string, error) {
+	f, err := os.CreateTemp(s.dir, "file-*")
+	if err != nil {
+		return "", 0, "", xerrors.Errorf("create temp error: %w", err)
+	}
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), r)
+	// The file must be closed before being removed on Windows
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		// Keep the file readable by the scanner even if the original file is not, e.g. 0200
+		err = os.Chmod(f.Name(), mode|0o400)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", 0, "", xerrors.Errorf("copy error: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, f.Name(), nil
+}
+
+// Stats returns the number of bytes kept on the disk and the bytes removed as duplicates
+func (s *Store) Stats() (stored, reclaimed int64) {
+	return s.stored.Load(), s.reclaimed.Load()
+}
@@ -0,0 +1,103 @@
+package filestore_test
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact/filestore"
+)
+
+func TestStore_Put(t *testing.T) {
+	type file struct {
+		content string
+		mode    os.FileMode
+	}
+	tests := []struct {
+		name          string
+		files         []file
+		wantSame      [][2]int // indices of files sharing the stored file
+		wantDiff      [][2]int // indices of files stored separately
+		wantStored    int64
+		wantReclaimed int64
+	}{
+		{
+			name: "same content",
+			files: []file{
+				{content: "foo", mode: 0644},
+				{content: "foo", mode: 0644},
+				{content: "bar", mode: 0644},
+			},
+			wantSame:      [][2]int{{0, 1}},
+			wantDiff:      [][2]int{{0, 2}},
+			wantStored:    6,
+			wantReclaimed: 3,
+		},
+		{
+			name: "same content with different modes",
+			files: []file{
+				{content: "foo", mode: 0644},
+				{content: "foo", mode: 0755},
+			},
+			wantDiff:   [][2]int{{0, 1}},
+			wantStored: 6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := filestore.New(t.TempDir())
+
+			var paths []string
+			for _, f := range tt.files {
+				p, err := store.Put(strings.NewReader(f.content), f.mode)
+				require.NoError(t, err)
+
+				got, err := os.ReadFile(p)
+				require.NoError(t, err)
+				assert.Equal(t, f.content, string(got))
+				paths = append(paths, p)
+			}
+			for _, s := range tt.wantSame {
+				assert.Equal(t, paths[s[0]], paths[s[1]])
+			}
+			for _, d := range tt.wantDiff {
+				assert.NotEqual(t, paths[d[0]], paths[d[1]])
+			}
+
+			stored, reclaimed := store.Stats()
+			assert.Equal(t, tt.wantStored, stored)
+			assert.Equal(t, tt.wantReclaimed, reclaimed)
+		})
+	}
+}
+
+func TestStore_Put_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+	store := filestore.New(dir)
+
+	var wg sync.WaitGroup
+	paths := make([]string, 10)
+	for i := range paths {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			p, err := store.Put(strings.NewReader("foo"), 0644)
+			assert.NoError(t, err)
+			paths[i] = p
+		}(i)
+	}
+	wg.Wait()
+
+	for _, p := range paths {
+		assert.Equal(t, paths[0], p)
+	}
+
+	// Only one file should be left
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -3,6 +3,7 @@ package image
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"io/fs"
 	"os"
@@ -10,24 +11,30 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
 	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/exp/slices"
 	"golang.org/x/xerrors"
 
 	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
 	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact/filestore"
 	"github.com/zhanglimao/trivy/pkg/fanal/cache"
 	"github.com/zhanglimao/trivy/pkg/fanal/handler"
 	"github.com/zhanglimao/trivy/pkg/fanal/image"
-	"github.com/zhanglimao/trivy/pkg/fanal/log"
 	"github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/fanal/walker"
+	"github.com/zhanglimao/trivy/pkg/log"
 	"github.com/zhanglimao/trivy/pkg/mapfs"
+	"github.com/zhanglimao/trivy/pkg/metrics"
 	"github.com/zhanglimao/trivy/pkg/parallel"
-	"github.com/zhanglimao/trivy/pkg/semaphore"
 	"github.com/zhanglimao/trivy/pkg/syncx"
+	"github.com/zhanglimao/trivy/pkg/tracing"
 )
 
 type Artifact struct {
@@ -61,6 +68,10 @@ func NewArtifact(img types.Image, c cache.ArtifactCache, opt artifact.Option) (a
 		MisconfScannerOption: opt.MisconfScannerOption,
 		SecretScannerOption:  opt.SecretScannerOption,
 		LicenseScannerOption: opt.LicenseScannerOption,
+		CustomResourceOption: opt.CustomResourceOption,
+		PermissionOption:     opt.PermissionOption,
+		Timeout:              opt.AnalyzerTimeout,
+		KeepGoing:            opt.KeepGoing,
 	})
 	if err != nil {
 		return nil, xerrors.Errorf("analyzer group error: %w", err)
@@ -78,7 +89,7 @@ func NewArtifact(img types.Image, c cache.ArtifactCache, opt artifact.Option) (a
 	return Artifact{
 		image:          img,
 		cache:          c,
-		walker:         walker.NewLayerTar(opt.SkipFiles, opt.SkipDirs, opt.Slow),
+		walker:         walker.NewLayerTar(opt.SkipFiles, opt.SkipDirs, opt.Slow, opt.SymlinkOption, opt.FileFilter()),
 		analyzer:       a,
 		configAnalyzer: ca,
 		handlerManager: handlerManager,
@@ -87,7 +98,13 @@ func NewArtifact(img types.Image, c cache.ArtifactCache, opt artifact.Option) (a
 	}, nil
 }
 
-func (a Artifact) Inspect(ctx context.Context) (types.ArtifactReference, error) {
+func (a Artifact) Inspect(ctx context.Context) (_ types.ArtifactReference, err error) {
+	ctx, span := tracing.Start(ctx, "image.Inspect", attribute.String("image.name", a.image.Name()))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = log.ContextWith(ctx, log.KeyArtifact, a.image.Name())
+	logger := log.WithContext(ctx)
+
 	imageID, err := a.image.ID()
 	if err != nil {
 		return types.ArtifactReference{}, xerrors.Errorf("unable to get the image ID: %w", err)
@@ -101,8 +118,8 @@ func (a Artifact) Inspect(ctx context.Context) (types.ArtifactReference, error)
 	diffIDs := a.diffIDs(configFile)
 
 	// Debug
-	log.Logger.Debugf("Image ID: %s", imageID)
-	log.Logger.Debugf("Diff IDs: %v", diffIDs)
+	logger.Debugf("Image ID: %s", imageID)
+	logger.Debugf("Diff IDs: %v", diffIDs)
 
 	// Try retrieving a remote SBOM document
 	if res, err := a.retrieveRemoteSBOM(ctx); err == nil {
@@ -114,8 +131,9 @@ func (a Artifact) Inspect(ctx context.Context) (types.ArtifactReference, error)
 	}
 
 	// Try to detect base layers.
-	baseDiffIDs := a.guessBaseLayers(diffIDs, configFile)
-	log.Logger.Debugf("Base Layers: %v", baseDiffIDs)
+	baseDiffIDs := image.GuessBaseDiffIDs(diffIDs, configFile)
+	logger.Debugf("Base Layers: %v", baseDiffIDs)
+	span.SetAttributes(attribute.String("image.id", imageID), attribute.Int("image.layers", len(diffIDs)))
 
 	// Convert image ID and layer IDs to cache keys
 	imageKey, layerKeys, err := a.calcCacheKeys(imageID, diffIDs)
@@ -130,33 +148,82 @@ func (a Artifact) Inspect(ctx context.Context) (types.ArtifactReference, error)
 	if err != nil {
 		return types.ArtifactReference{}, xerrors.Errorf("unable to get missing layers: %w", err)
 	}
+	metrics.ObserveCacheLookup(len(layerKeys), len(missingLayers))
+	span.SetAttributes(attribute.Int("image.missing_layers", len(missingLayers)))
 
 	missingImageKey := imageKey
 	if missingImage {
-		log.Logger.Debugf("Missing image ID in cache: %s", imageID)
+		logger.Debugf("Missing image ID in cache: %s", imageID)
 	} else {
 		missingImageKey = ""
 	}
 
-	if err = a.inspect(ctx, missingImageKey, missingLayers, baseDiffIDs, layerKeyMap, configFile); err != nil {
+	failed, err := a.inspect(ctx, missingImageKey, missingLayers, baseDiffIDs, layerKeyMap, configFile)
+	if err != nil {
 		return types.ArtifactReference{}, xerrors.Errorf("analyze error: %w", err)
 	}
 
+	// Failed layers are not cached, so that they are analyzed again in the next scan
+	var warnings []types.Warning
+	for _, layerKey := range layerKeys {
+		if w, ok := failed[layerKey]; ok {
+			warnings = append(warnings, w)
+		}
+	}
+	layerKeys = lo.Reject(layerKeys, func(layerKey string, _ int) bool {
+		_, ok := failed[layerKey]
+		return ok
+	})
+
+	var drift *types.ContainerDrift
+	if c, ok := a.image.(types.RunningContainer); ok {
+		drift = lo.ToPtr(c.ContainerDrift())
+	}
+
 	return types.ArtifactReference{
-		Name:    a.image.Name(),
-		Type:    types.ArtifactContainerImage,
-		ID:      imageKey,
-		BlobIDs: layerKeys,
+		Name:           a.image.Name(),
+		Type:           types.ArtifactContainerImage,
+		ID:             imageKey,
+		BlobIDs:        layerKeys,
+		Warnings:       warnings,
+		ContainerDrift: drift,
 		ImageMetadata: types.ImageMetadata{
 			ID:          imageID,
 			DiffIDs:     diffIDs,
 			RepoTags:    a.image.RepoTags(),
 			RepoDigests: a.image.RepoDigests(),
+			Digest:      resolvedDigest(a.image.Name(), a.image.RepoDigests()),
+			Annotations: annotations(a.image),
 			ConfigFile:  *configFile,
 		},
 	}, nil
 }
 
+// resolvedDigest returns the manifest digest of the repository being scanned.
+// It prefers the repo digest of the same repository as the image name since an image can be pushed to multiple repositories.
+func resolvedDigest(imageName string, repoDigests []string) string {
+	var digests []name.Digest
+	for _, rd := range repoDigests {
+		d, err := name.NewDigest(rd)
+		if err != nil {
+			continue
+		}
+		digests = append(digests, d)
+	}
+	if len(digests) == 0 {
+		return ""
+	}
+
+	if ref, err := name.ParseReference(imageName); err == nil {
+		for _, d := range digests {
+			if d.Context().Name() == ref.Context().Name() {
+				return d.DigestStr()
+			}
+		}
+	}
+	return digests[0].DigestStr()
+}
+
 func (Artifact) Clean(_ types.ArtifactReference) error {
 	return nil
 }
@@ -214,12 +281,29 @@ func (a Artifact) consolidateCreatedBy(diffIDs, layerKeys []string, configFile *
 	return layerKeyMap
 }
 
+// inspect analyzes the missing layers and the config.
+// It returns the warnings of the layers which couldn't be analyzed in the keep-going mode by the layer keys.
 func (a Artifact) inspect(ctx context.Context, missingImage string, layerKeys, baseDiffIDs []string,
-	layerKeyMap map[string]LayerInfo, configFile *v1.ConfigFile) error {
+	layerKeyMap map[string]LayerInfo, configFile *v1.ConfigFile) (map[string]types.Warning, error) {
 
 	var osFound types.OS
-	workers := lo.Ternary(a.artifactOption.Slow, 1, 5)
-	p := parallel.NewPipeline(workers, false, layerKeys, func(ctx context.Context, layerKey string) (any, error) {
+	var mu sync.Mutex
+	failed := make(map[string]types.Warning)
+
+	// Analyzers in all layers share the scheduler so that the total concurrency is bounded
+	scheduler := analyzer.NewScheduler(a.artifactOption.SchedulerOption())
+	defer scheduler.Close()
+
+	// All the layers are registered first so that the total progress can be calculated
+	progress := a.artifactOption.ProgressOrNop()
+	defer progress.Done()
+	layerProgress := make(map[string]artifact.LayerProgress, len(layerKeys))
+	for _, layerKey := range layerKeys {
+		diffID := layerKeyMap[layerKey].DiffID
+		layerProgress[layerKey] = progress.Layer(diffID, a.layerSize(diffID))
+	}
+
+	p := parallel.NewPipeline(scheduler.Workers(), false, layerKeys, func(ctx context.Context, layerKey string) (any, error) {
 		layer := layerKeyMap[layerKey]
 
 		// If it is a base layer, secret scanning should not be performed.
@@ -228,8 +312,13 @@ func (a Artifact) inspect(ctx context.Context, missingImage string, layerKeys, b
 			disabledAnalyzers = append(disabledAnalyzers, analyzer.TypeSecret)
 		}
 
-		layerInfo, err := a.inspectLayer(ctx, layer, disabledAnalyzers)
-		if err != nil {
+		layerInfo, err := a.inspectLayer(ctx, scheduler, layer, disabledAnalyzers, layerProgress[layerKey])
+		if err != nil && a.artifactOption.KeepGoing && ctx.Err() == nil {
+			mu.Lock()
+			failed[layerKey] = failedLayer(ctx, layer, err)
+			mu.Unlock()
+			return nil, nil
+		} else if err != nil {
 			return nil, xerrors.Errorf("failed to analyze layer (%s): %w", layer.DiffID, err)
 		}
 		if err = a.cache.PutBlob(layerKey, layerInfo); err != nil {
@@ -243,26 +332,45 @@ func (a Artifact) inspect(ctx context.Context, missingImage string, layerKeys, b
 	}, nil)
 
 	if err := p.Do(ctx); err != nil {
-		return xerrors.Errorf("pipeline error: %w", err)
+		return nil, xerrors.Errorf("pipeline error: %w", err)
 	}
 
 	if missingImage != "" {
 		if err := a.inspectConfig(ctx, missingImage, osFound, configFile); err != nil {
-			return xerrors.Errorf("unable to analyze config: %w", err)
+			return nil, xerrors.Errorf("unable to analyze config: %w", err)
 		}
 	}
 
-	return nil
+	return failed, nil
 }
 
-func (a Artifact) inspectLayer(ctx context.Context, layerInfo LayerInfo, disabled []analyzer.Type) (types.BlobInfo, error) {
-	log.Logger.Debugf("Missing diff ID in cache: %s", layerInfo.DiffID)
-
-	layerDigest, rc, err := a.uncompressedLayer(layerInfo.DiffID)
-	if err != nil {
-		return types.BlobInfo{}, xerrors.Errorf("unable to get uncompressed layer %s: %w", layerInfo.DiffID, err)
+// failedLayer returns the warning of the layer which couldn't be analyzed in the keep-going mode,
+// so that the findings in the other layers are still reported.
+func failedLayer(ctx context.Context, layer LayerInfo, err error) types.Warning {
+	log.WithContext(ctx).Warnf("Failed to analyze layer (%s), continuing: %s", layer.DiffID, err)
+	return types.Warning{
+		Message: fmt.Sprintf("failed to analyze layer (%s): %s", layer.DiffID, err),
 	}
-	defer rc.Close()
+}
+
+func (a Artifact) inspectLayer(ctx context.Context, scheduler *analyzer.Scheduler, layerInfo LayerInfo,
+	disabled []analyzer.Type, progress artifact.LayerProgress) (_ types.BlobInfo, err error) {
+	ctx = log.ContextWith(ctx, log.KeyLayer, layerInfo.DiffID)
+	log.WithContext(ctx).Debugf("Missing diff ID in cache: %s", layerInfo.DiffID)
+	ctx, span := tracing.Start(ctx, "image.inspectLayer", attribute.String("layer.diff_id", layerInfo.DiffID))
+	defer func() { tracing.End(span, err) }()
+
+	progress.SetStage(artifact.StageAnalyzing)
+	defer func() {
+		if err != nil {
+			progress.SetStage(artifact.StageFailed)
+			return
+		}
+		progress.SetStage(artifact.StageDone)
+	}()
+	defer func(start time.Time) {
+		metrics.LayerAnalysisDuration.Observe(time.Since(start).Seconds())
+	}(time.Now())
 
 	// Prepare variables
 	var wg sync.WaitGroup
@@ -271,7 +379,10 @@ func (a Artifact) inspectLayer(ctx context.Context, layerInfo LayerInfo, disable
 		FileChecksum: a.artifactOption.FileChecksum,
 	}
 	result := analyzer.NewAnalysisResult()
-	limit := semaphore.New(a.artifactOption.Slow)
+	if err := result.EnableSpill(a.artifactOption.MaxMemory); err != nil {
+		return types.BlobInfo{}, xerrors.Errorf("analysis result error: %w", err)
+	}
+	defer result.Close()
 
 	// Prepare filesystem for post analysis
 	files := new(syncx.Map[analyzer.Type, *mapfs.FS])
@@ -280,32 +391,44 @@ func (a Artifact) inspectLayer(ctx context.Context, layerInfo LayerInfo, disable
 		return types.BlobInfo{}, xerrors.Errorf("mkdir temp error: %w", err)
 	}
 	defer os.RemoveAll(tmpDir)
+	store := filestore.New(tmpDir)
 
-	// Walk a tar layer
-	opqDirs, whFiles, err := a.walker.Walk(rc, func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
-		if err = a.analyzer.AnalyzeFile(ctx, &wg, limit, result, "", filePath, info, opener, disabled, opts); err != nil {
+	analyzeFn := func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
+		if err = a.analyzer.AnalyzeFile(ctx, &wg, scheduler, result, "", filePath, info, opener, disabled, opts); err != nil {
 			return xerrors.Errorf("failed to analyze %s: %w", filePath, err)
 		}
 
 		// Build filesystem for post analysis
-		if err = a.buildFS(tmpDir, filePath, info, opener, files); err != nil {
+		if err = a.buildFS(store, filePath, info, opener, files); err != nil {
 			return xerrors.Errorf("failed to build filesystem: %w", err)
 		}
 
 		return nil
-	})
+	}
+
+	layerDigest, opqDirs, whFiles, err := a.walkLayer(ctx, layerInfo.DiffID, analyzeFn, progress)
 	if err != nil {
-		return types.BlobInfo{}, xerrors.Errorf("walk error: %w", err)
+		return types.BlobInfo{}, err
 	}
 
 	// Wait for all the goroutine to finish.
 	wg.Wait()
 
+	if stored, reclaimed := store.Stats(); reclaimed > 0 {
+		log.WithContext(ctx).Debugf("Files for post-analysis (%s): %d bytes stored, %d bytes of duplicates removed", layerInfo.DiffID, stored, reclaimed)
+	}
+
 	// Post-analysis
+	progress.SetStage(artifact.StagePostAnalyzing)
 	if err = a.analyzer.PostAnalyze(ctx, files, result, opts); err != nil {
 		return types.BlobInfo{}, xerrors.Errorf("post analysis error: %w", err)
 	}
 
+	// Load the results spilled to disk, including those of post-analyzers
+	if err = result.Restore(); err != nil {
+		return types.BlobInfo{}, xerrors.Errorf("restore error: %w", err)
+	}
+
 	// Sort the analysis result for consistent results
 	result.Sort()
 
@@ -324,6 +447,11 @@ func (a Artifact) inspectLayer(ctx context.Context, layerInfo LayerInfo, disable
 		Secrets:           result.Secrets,
 		Licenses:          result.Licenses,
 		CustomResources:   result.CustomResources,
+		Warnings:          result.Warnings,
+		Digests:           result.Digests,
+		FileSizes:         result.FileSizes,
+		PackageFiles:      result.PackageFiles,
+		FileDigests:       result.FileDigests,
 
 		// For Red Hat
 		BuildInfo: result.BuildInfo,
@@ -337,8 +465,55 @@ func (a Artifact) inspectLayer(ctx context.Context, layerInfo LayerInfo, disable
 	return blobInfo, nil
 }
 
+// walkLayer walks the files in the layer. If the layer is seekable, e.g. eStargz and zstd:chunked in a registry,
+// only the files required by analyzers are fetched. Otherwise, the whole layer is downloaded.
+func (a Artifact) walkLayer(ctx context.Context, diffID string, analyzeFn walker.WalkFunc,
+	progress artifact.LayerProgress) (string, []string, []string, error) {
+	if seekable := a.seekableLayer(ctx, diffID); seekable != nil {
+		opqDirs, whFiles, err := a.walker.WalkTOC(seekable, analyzeFn)
+		if err != nil {
+			return "", nil, nil, xerrors.Errorf("walk error: %w", err)
+		}
+		return seekable.Digest.String(), opqDirs, whFiles, nil
+	}
+
+	layerDigest, rc, err := a.uncompressedLayer(diffID, progress)
+	if err != nil {
+		return "", nil, nil, xerrors.Errorf("unable to get uncompressed layer %s: %w", diffID, err)
+	}
+	defer rc.Close()
+
+	// Walk a tar layer
+	opqDirs, whFiles, err := a.walker.Walk(rc, analyzeFn)
+	if err != nil {
+		return "", nil, nil, xerrors.Errorf("walk error: %w", err)
+	}
+	return layerDigest, opqDirs, whFiles, nil
+}
+
+// seekableLayer returns nil if the layer is not seekable so that the caller falls back to the whole layer.
+func (a Artifact) seekableLayer(ctx context.Context, diffID string) *image.SeekableLayer {
+	img, ok := a.image.(image.SeekableImage)
+	if !ok {
+		return nil
+	}
+	h, err := v1.NewHash(diffID)
+	if err != nil {
+		return nil
+	}
+	layer, err := img.SeekableLayer(ctx, h)
+	if err != nil {
+		log.WithContext(ctx).Debugf("Unable to fetch the layer partially, falling back to the whole layer (%s): %s", diffID, err)
+		return nil
+	}
+	if layer != nil {
+		log.WithContext(ctx).Debugf("Seekable layer detected, fetching only required files: %s", diffID)
+	}
+	return layer
+}
+
 // buildFS creates filesystem for post analysis
-func (a Artifact) buildFS(tmpDir, filePath string, info os.FileInfo, opener analyzer.Opener,
+func (a Artifact) buildFS(store *filestore.Store, filePath string, info os.FileInfo, opener analyzer.Opener,
 	files *syncx.Map[analyzer.Type, *mapfs.FS]) error {
 	// Get all post-analyzers that want to analyze the file
 	atypes := a.analyzer.RequiredPostAnalyzers(filePath, info)
@@ -346,14 +521,6 @@ func (a Artifact) buildFS(tmpDir, filePath string, info os.FileInfo, opener anal
 		return nil
 	}
 
-	// Create a temporary file to which the file in the layer will be copied
-	// so that all the files will not be loaded into memory
-	f, err := os.CreateTemp(tmpDir, "layer-file-*")
-	if err != nil {
-		return xerrors.Errorf("create temp error: %w", err)
-	}
-	defer f.Close()
-
 	// Open a file in the layer
 	r, err := opener()
 	if err != nil {
@@ -361,13 +528,11 @@ func (a Artifact) buildFS(tmpDir, filePath string, info os.FileInfo, opener anal
 	}
 	defer r.Close()
 
-	// Copy file content into the temporary file
-	if _, err = io.Copy(f, r); err != nil {
-		return xerrors.Errorf("copy error: %w", err)
-	}
-
-	if err = os.Chmod(f.Name(), info.Mode()); err != nil {
-		return xerrors.Errorf("chmod error: %w", err)
+	// Copy the file in the layer to the temporary store so that all the files will not be loaded into memory.
+	// The stored file is shared by all the post-analyzers and files with the same content.
+	storedPath, err := store.Put(r, info.Mode())
+	if err != nil {
+		return xerrors.Errorf("file store error: %w", err)
 	}
 
 	// Create fs.FS for each post-analyzer that wants to analyze the current file
@@ -378,7 +543,7 @@ func (a Artifact) buildFS(tmpDir, filePath string, info os.FileInfo, opener anal
 				return xerrors.Errorf("mapfs mkdir error: %w", err)
 			}
 		}
-		err = fsys.WriteFile(filePath, f.Name())
+		err = fsys.WriteFile(filePath, storedPath)
 		if err != nil {
 			return xerrors.Errorf("mapfs write error: %w", err)
 		}
@@ -395,7 +560,7 @@ func (a Artifact) diffIDs(configFile *v1.ConfigFile) []string {
 	})
 }
 
-func (a Artifact) uncompressedLayer(diffID string) (string, io.ReadCloser, error) {
+func (a Artifact) uncompressedLayer(diffID string, progress artifact.LayerProgress) (string, io.ReadCloser, error) {
 	// diffID is a hash of the uncompressed layer
 	h, err := v1.NewHash(diffID)
 	if err != nil {
@@ -417,13 +582,73 @@ func (a Artifact) uncompressedLayer(diffID string) (string, io.ReadCloser, error
 		digest = d.String()
 	}
 
-	rc, err := layer.Uncompressed()
+	rc, err := a.progressLayer(layer, progress).Uncompressed()
 	if err != nil {
 		return "", nil, xerrors.Errorf("failed to get the layer content (%s): %w", diffID, err)
 	}
+	if a.artifactOption.Progress != nil && !a.isCompressed(layer) {
+		rc = &artifact.ProgressReader{
+			ReadCloser: rc,
+			Progress:   progress,
+		}
+	}
 	return digest, rc, nil
 }
 
+// layerSize returns the compressed size of the layer, or zero if it cannot be known without reading the layer
+func (a Artifact) layerSize(diffID string) int64 {
+	if a.artifactOption.Progress == nil {
+		return 0
+	}
+	h, err := v1.NewHash(diffID)
+	if err != nil {
+		return 0
+	}
+	layer, err := a.image.LayerByDiffID(h)
+	if err != nil || !a.isCompressed(layer) {
+		// The size of uncompressed layers is calculated by compressing the whole layer
+		return 0
+	}
+	size, err := layer.Size()
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// progressLayer counts the compressed bytes of the layer as the progress,
+// which are downloaded from the registry while the layer is read.
+func (a Artifact) progressLayer(layer v1.Layer, progress artifact.LayerProgress) v1.Layer {
+	if a.artifactOption.Progress == nil || !a.isCompressed(layer) {
+		return layer
+	}
+	l, err := partial.CompressedToLayer(countingLayer{
+		Layer:    layer,
+		progress: progress,
+	})
+	if err != nil {
+		return layer
+	}
+	return l
+}
+
+// countingLayer counts the bytes read from the compressed layer
+type countingLayer struct {
+	v1.Layer
+	progress artifact.LayerProgress
+}
+
+func (l countingLayer) Compressed() (io.ReadCloser, error) {
+	rc, err := l.Layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	return &artifact.ProgressReader{
+		ReadCloser: rc,
+		Progress:   l.progress,
+	}, nil
+}
+
 // ref. https://github.com/google/go-containerregistry/issues/701
 func (a Artifact) isCompressed(l v1.Layer) bool {
 	_, uncompressed := reflect.TypeOf(l).Elem().FieldByName("UncompressedLayer")
@@ -451,33 +676,13 @@ func (a Artifact) inspectConfig(ctx context.Context, imageID string, osFound typ
 	return nil
 }
 
-// guessBaseLayers guesses layers in base image (call base layers).
-func (a Artifact) guessBaseLayers(diffIDs []string, configFile *v1.ConfigFile) []string {
-	if configFile == nil {
+// annotations returns the manifest annotations of the image.
+// Only images whose manifest is at hand, e.g. images in a registry or an OCI layout, implement it,
+// since computing a manifest of images from a daemon or a Docker archive requires compressing all layers.
+func annotations(img types.Image) map[string]string {
+	a, ok := img.(interface{ Annotations() map[string]string })
+	if !ok {
 		return nil
 	}
-
-	baseImageIndex := image.GuessBaseImageIndex(configFile.History)
-
-	// Diff IDs don't include empty layers, so the index is different from histories
-	var diffIDIndex int
-	var baseDiffIDs []string
-	for i, h := range configFile.History {
-		// It is no longer base layer.
-		if i > baseImageIndex {
-			break
-		}
-		// Empty layers are not included in diff IDs.
-		if h.EmptyLayer {
-			continue
-		}
-
-		if diffIDIndex >= len(diffIDs) {
-			// something wrong...
-			return nil
-		}
-		baseDiffIDs = append(baseDiffIDs, diffIDs[diffIDIndex])
-		diffIDIndex++
-	}
-	return baseDiffIDs
+	return a.Annotations()
 }
@@ -245,17 +245,17 @@ func TestArtifact_Inspect(t *testing.T) {
 			missingBlobsExpectation: cache.ArtifactCacheMissingBlobsExpectation{
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-					BlobIDs:    []string{"sha256:0366f81c3cbeac047536f9ca133b5632544c8342544922c3ed8a3e51c0250897"},
+					BlobIDs:    []string{"sha256:dd02c8f853531c1ec02aa1a53dcd3997f3273f14580f40b6689a4392dfa686bc"},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingArtifact: true,
-					MissingBlobIDs:  []string{"sha256:0366f81c3cbeac047536f9ca133b5632544c8342544922c3ed8a3e51c0250897"},
+					MissingBlobIDs:  []string{"sha256:dd02c8f853531c1ec02aa1a53dcd3997f3273f14580f40b6689a4392dfa686bc"},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:0366f81c3cbeac047536f9ca133b5632544c8342544922c3ed8a3e51c0250897",
+						BlobID: "sha256:dd02c8f853531c1ec02aa1a53dcd3997f3273f14580f40b6689a4392dfa686bc",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -271,14 +271,16 @@ func TestArtifact_Inspect(t *testing.T) {
 							},
 							PackageInfos: []types.PackageInfo{
 								{
-									FilePath: "lib/apk/db/installed",
-									Packages: alpinePkgs,
+									FilePath:   "lib/apk/db/installed",
+									AnalyzedBy: &types.AnalyzedBy{Type: "apk", Version: 2},
+									Packages:   alpinePkgs,
 								},
 							},
 							Licenses: []types.LicenseFile{
 								{
-									Type:     "header",
-									FilePath: "etc/ssl/misc/CA.pl",
+									Type:       "header",
+									FilePath:   "etc/ssl/misc/CA.pl",
+									AnalyzedBy: &types.AnalyzedBy{Type: "license-file", Version: 1},
 									Findings: []types.LicenseFinding{
 										{
 											Name:       "OpenSSL",
@@ -288,8 +290,9 @@ func TestArtifact_Inspect(t *testing.T) {
 									},
 								},
 								{
-									Type:     "header",
-									FilePath: "etc/ssl/misc/tsget.pl",
+									Type:       "header",
+									FilePath:   "etc/ssl/misc/tsget.pl",
+									AnalyzedBy: &types.AnalyzedBy{Type: "license-file", Version: 1},
 									Findings: []types.LicenseFinding{
 										{
 											Name:       "OpenSSL",
@@ -322,7 +325,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				Name:    "../../test/testdata/alpine-311.tar.gz",
 				Type:    types.ArtifactContainerImage,
 				ID:      "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-				BlobIDs: []string{"sha256:0366f81c3cbeac047536f9ca133b5632544c8342544922c3ed8a3e51c0250897"},
+				BlobIDs: []string{"sha256:dd02c8f853531c1ec02aa1a53dcd3997f3273f14580f40b6689a4392dfa686bc"},
 				ImageMetadata: types.ImageMetadata{
 					ID: "sha256:a187dde48cd289ac374ad8539930628314bc581a481cdb41409c9289419ddb72",
 					DiffIDs: []string{
@@ -381,25 +384,25 @@ func TestArtifact_Inspect(t *testing.T) {
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 					BlobIDs: []string{
-						"sha256:db2b5b2f26f320b80ee549495888f111151fdb44fecb2c432dfd928e96978e74",
-						"sha256:718d6a3d0a24966f2d9f64e0c86695d1bb896fe81df634b85300d34142c80174",
-						"sha256:9f6bc14198fa983fd47079bcd82df1391bee8e529a8a131a0305874eb0a51ca0",
-						"sha256:44264715b7132cd14cd956bf385d5f875603461f5adeafde7fdecabe2e266291",
+						"sha256:e42852a1d7aa19348af2b65be02fa291d4d69bcb7207de31aff742fa2864d7b7",
+						"sha256:478e94d643cde0f8040eae5d3005d052a48735ecba61f4573ea2792f07dbdfeb",
+						"sha256:a26658fd125df1c91569745535f18938c5a7137c5f2732b32d23a5ab179ffac8",
+						"sha256:6beedc074041dc0343998c0c766e832333ee1b2e83c5e9f6c07e804191397ff5",
 					},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingBlobIDs: []string{
-						"sha256:db2b5b2f26f320b80ee549495888f111151fdb44fecb2c432dfd928e96978e74",
-						"sha256:718d6a3d0a24966f2d9f64e0c86695d1bb896fe81df634b85300d34142c80174",
-						"sha256:9f6bc14198fa983fd47079bcd82df1391bee8e529a8a131a0305874eb0a51ca0",
-						"sha256:44264715b7132cd14cd956bf385d5f875603461f5adeafde7fdecabe2e266291",
+						"sha256:e42852a1d7aa19348af2b65be02fa291d4d69bcb7207de31aff742fa2864d7b7",
+						"sha256:478e94d643cde0f8040eae5d3005d052a48735ecba61f4573ea2792f07dbdfeb",
+						"sha256:a26658fd125df1c91569745535f18938c5a7137c5f2732b32d23a5ab179ffac8",
+						"sha256:6beedc074041dc0343998c0c766e832333ee1b2e83c5e9f6c07e804191397ff5",
 					},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:db2b5b2f26f320b80ee549495888f111151fdb44fecb2c432dfd928e96978e74",
+						BlobID: "sha256:e42852a1d7aa19348af2b65be02fa291d4d69bcb7207de31aff742fa2864d7b7",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -411,7 +414,8 @@ func TestArtifact_Inspect(t *testing.T) {
 							},
 							PackageInfos: []types.PackageInfo{
 								{
-									FilePath: "var/lib/dpkg/status.d/base",
+									FilePath:   "var/lib/dpkg/status.d/base",
+									AnalyzedBy: &types.AnalyzedBy{Type: "dpkg", Version: 5},
 									Packages: []types.Package{
 										{
 											ID:         "base-files@9.9+deb9u9",
@@ -425,7 +429,8 @@ func TestArtifact_Inspect(t *testing.T) {
 									},
 								},
 								{
-									FilePath: "var/lib/dpkg/status.d/netbase",
+									FilePath:   "var/lib/dpkg/status.d/netbase",
+									AnalyzedBy: &types.AnalyzedBy{Type: "dpkg", Version: 5},
 									Packages: []types.Package{
 										{
 											ID:         "netbase@5.4",
@@ -439,7 +444,8 @@ func TestArtifact_Inspect(t *testing.T) {
 									},
 								},
 								{
-									FilePath: "var/lib/dpkg/status.d/tzdata",
+									FilePath:   "var/lib/dpkg/status.d/tzdata",
+									AnalyzedBy: &types.AnalyzedBy{Type: "dpkg", Version: 5},
 									Packages: []types.Package{
 										{
 											ID:         "tzdata@2019a-0+deb9u1",
@@ -457,16 +463,18 @@ func TestArtifact_Inspect(t *testing.T) {
 							},
 							Licenses: []types.LicenseFile{
 								{
-									Type:     types.LicenseTypeDpkg,
-									FilePath: "usr/share/doc/base-files/copyright",
+									Type:       types.LicenseTypeDpkg,
+									FilePath:   "usr/share/doc/base-files/copyright",
+									AnalyzedBy: &types.AnalyzedBy{Type: "dpkg-license", Version: 1},
 									Findings: []types.LicenseFinding{
 										{Name: "GPL-3.0"},
 									},
 									PkgName: "base-files",
 								},
 								{
-									Type:     types.LicenseTypeDpkg,
-									FilePath: "usr/share/doc/ca-certificates/copyright",
+									Type:       types.LicenseTypeDpkg,
+									FilePath:   "usr/share/doc/ca-certificates/copyright",
+									AnalyzedBy: &types.AnalyzedBy{Type: "dpkg-license", Version: 1},
 									Findings: []types.LicenseFinding{
 										{Name: "GPL-2.0"},
 										{Name: "MPL-2.0"},
@@ -474,8 +482,9 @@ func TestArtifact_Inspect(t *testing.T) {
 									PkgName: "ca-certificates",
 								},
 								{
-									Type:     types.LicenseTypeDpkg,
-									FilePath: "usr/share/doc/netbase/copyright",
+									Type:       types.LicenseTypeDpkg,
+									FilePath:   "usr/share/doc/netbase/copyright",
+									AnalyzedBy: &types.AnalyzedBy{Type: "dpkg-license", Version: 1},
 									Findings: []types.LicenseFinding{
 										{Name: "GPL-2.0"},
 									},
@@ -487,7 +496,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:718d6a3d0a24966f2d9f64e0c86695d1bb896fe81df634b85300d34142c80174",
+						BlobID: "sha256:478e94d643cde0f8040eae5d3005d052a48735ecba61f4573ea2792f07dbdfeb",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -495,7 +504,8 @@ func TestArtifact_Inspect(t *testing.T) {
 							CreatedBy:     "bazel build ...",
 							PackageInfos: []types.PackageInfo{
 								{
-									FilePath: "var/lib/dpkg/status.d/libc6",
+									FilePath:   "var/lib/dpkg/status.d/libc6",
+									AnalyzedBy: &types.AnalyzedBy{Type: "dpkg", Version: 5},
 									Packages: []types.Package{
 										{
 											ID:         "libc6@2.24-11+deb9u4",
@@ -511,7 +521,8 @@ func TestArtifact_Inspect(t *testing.T) {
 									},
 								},
 								{
-									FilePath: "var/lib/dpkg/status.d/libssl1",
+									FilePath:   "var/lib/dpkg/status.d/libssl1",
+									AnalyzedBy: &types.AnalyzedBy{Type: "dpkg", Version: 5},
 									Packages: []types.Package{
 										{
 											ID:         "libssl1.1@1.1.0k-1~deb9u1",
@@ -521,13 +532,17 @@ func TestArtifact_Inspect(t *testing.T) {
 											Release:    "1~deb9u1",
 											SrcVersion: "1.1.0k",
 											SrcRelease: "1~deb9u1",
+											DependsOn: []string{
+												"libc6@2.24-11+deb9u4",
+											},
 											Maintainer: "Debian OpenSSL Team <pkg-openssl-devel@lists.alioth.debian.org>",
 											Arch:       "amd64",
 										},
 									},
 								},
 								{
-									FilePath: "var/lib/dpkg/status.d/openssl",
+									FilePath:   "var/lib/dpkg/status.d/openssl",
+									AnalyzedBy: &types.AnalyzedBy{Type: "dpkg", Version: 5},
 									Packages: []types.Package{
 										{
 											ID:         "openssl@1.1.0k-1~deb9u1",
@@ -537,6 +552,10 @@ func TestArtifact_Inspect(t *testing.T) {
 											Release:    "1~deb9u1",
 											SrcVersion: "1.1.0k",
 											SrcRelease: "1~deb9u1",
+											DependsOn: []string{
+												"libc6@2.24-11+deb9u4",
+												"libssl1.1@1.1.0k-1~deb9u1",
+											},
 											Maintainer: "Debian OpenSSL Team <pkg-openssl-devel@lists.alioth.debian.org>",
 											Arch:       "amd64",
 										},
@@ -545,8 +564,9 @@ func TestArtifact_Inspect(t *testing.T) {
 							},
 							Licenses: []types.LicenseFile{
 								{
-									Type:     types.LicenseTypeDpkg,
-									FilePath: "usr/share/doc/libc6/copyright",
+									Type:       types.LicenseTypeDpkg,
+									FilePath:   "usr/share/doc/libc6/copyright",
+									AnalyzedBy: &types.AnalyzedBy{Type: "dpkg-license", Version: 1},
 									Findings: []types.LicenseFinding{
 										{Name: "LGPL-2.1"},
 										{Name: "GPL-2.0"},
@@ -554,8 +574,9 @@ func TestArtifact_Inspect(t *testing.T) {
 									PkgName: "libc6",
 								},
 								{
-									Type:     types.LicenseTypeDpkg,
-									FilePath: "usr/share/doc/libssl1.1/copyright",
+									Type:       types.LicenseTypeDpkg,
+									FilePath:   "usr/share/doc/libssl1.1/copyright",
+									AnalyzedBy: &types.AnalyzedBy{Type: "dpkg-license", Version: 1},
 									Findings: []types.LicenseFinding{
 										{
 											Name:       "OpenSSL",
@@ -566,8 +587,9 @@ func TestArtifact_Inspect(t *testing.T) {
 									PkgName: "libssl1.1",
 								},
 								{
-									Type:     types.LicenseTypeDpkg,
-									FilePath: "usr/share/doc/openssl/copyright",
+									Type:       types.LicenseTypeDpkg,
+									FilePath:   "usr/share/doc/openssl/copyright",
+									AnalyzedBy: &types.AnalyzedBy{Type: "dpkg-license", Version: 1},
 									Findings: []types.LicenseFinding{
 										{
 											Name:       "OpenSSL",
@@ -583,7 +605,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:9f6bc14198fa983fd47079bcd82df1391bee8e529a8a131a0305874eb0a51ca0",
+						BlobID: "sha256:a26658fd125df1c91569745535f18938c5a7137c5f2732b32d23a5ab179ffac8",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -591,8 +613,9 @@ func TestArtifact_Inspect(t *testing.T) {
 							CreatedBy:     "COPY file:842584685f26edb24dc305d76894f51cfda2bad0c24a05e727f9d4905d184a70 in /php-app/composer.lock ",
 							Applications: []types.Application{
 								{
-									Type:     "composer",
-									FilePath: "php-app/composer.lock",
+									Type:       "composer",
+									FilePath:   "php-app/composer.lock",
+									AnalyzedBy: &types.AnalyzedBy{Type: "composer", Version: 1},
 									Libraries: []types.Package{
 										{
 											ID:        "guzzlehttp/guzzle@6.2.0",
@@ -707,7 +730,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:44264715b7132cd14cd956bf385d5f875603461f5adeafde7fdecabe2e266291",
+						BlobID: "sha256:6beedc074041dc0343998c0c766e832333ee1b2e83c5e9f6c07e804191397ff5",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -715,8 +738,9 @@ func TestArtifact_Inspect(t *testing.T) {
 							CreatedBy:     "COPY file:c6d0373d380252b91829a5bb3c81d5b1afa574c91cef7752d18170a231c31f6d in /ruby-app/Gemfile.lock ",
 							Applications: []types.Application{
 								{
-									Type:     "bundler",
-									FilePath: "ruby-app/Gemfile.lock",
+									Type:       "bundler",
+									FilePath:   "ruby-app/Gemfile.lock",
+									AnalyzedBy: &types.AnalyzedBy{Type: "bundler", Version: 1},
 									Libraries: []types.Package{
 										{
 											ID:       "actioncable@5.2.3",
@@ -1516,10 +1540,10 @@ func TestArtifact_Inspect(t *testing.T) {
 				Type: types.ArtifactContainerImage,
 				ID:   "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 				BlobIDs: []string{
-					"sha256:db2b5b2f26f320b80ee549495888f111151fdb44fecb2c432dfd928e96978e74",
-					"sha256:718d6a3d0a24966f2d9f64e0c86695d1bb896fe81df634b85300d34142c80174",
-					"sha256:9f6bc14198fa983fd47079bcd82df1391bee8e529a8a131a0305874eb0a51ca0",
-					"sha256:44264715b7132cd14cd956bf385d5f875603461f5adeafde7fdecabe2e266291",
+					"sha256:e42852a1d7aa19348af2b65be02fa291d4d69bcb7207de31aff742fa2864d7b7",
+					"sha256:478e94d643cde0f8040eae5d3005d052a48735ecba61f4573ea2792f07dbdfeb",
+					"sha256:a26658fd125df1c91569745535f18938c5a7137c5f2732b32d23a5ab179ffac8",
+					"sha256:6beedc074041dc0343998c0c766e832333ee1b2e83c5e9f6c07e804191397ff5",
 				},
 				ImageMetadata: types.ImageMetadata{
 					ID: "sha256:58701fd185bda36cab0557bb6438661831267aa4a9e0b54211c4d5317a48aff4",
@@ -1613,25 +1637,25 @@ func TestArtifact_Inspect(t *testing.T) {
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 					BlobIDs: []string{
-						"sha256:dc2c102de2a4dc82bc017564a2c1a46646f071367bd51d3ddd80171dc5a78ffe",
-						"sha256:e6bc45e7459162f5a27dd7bd310a420f27378f2ea24c6ed5f0a083f2377bde24",
-						"sha256:8184bee7b1985e0abd72288946c246b90e1847b36fdcd50ca3d588b9371ec920",
-						"sha256:f1bb0ccf476528c6165a2f1a74d0e5a0cb06c9b254a0def6b1183db662e83dd4",
+						"sha256:a65d3cd77a7c384ccd7df98de4ffc5cdf38c302bbf2b61b02c65a3ec127a5714",
+						"sha256:d131c21060d03842405da73768d32183374296a9268faf66fc1d33c2e1dc1cb5",
+						"sha256:06ba7bc68445ac9206b39e32b6aacbf887fba397b09038b8f68f812777fe8613",
+						"sha256:073810f7ca55b3467b206e0ac9dc364aedbaf67e9f8a95d90c4a2d906ec44107",
 					},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingBlobIDs: []string{
-						"sha256:dc2c102de2a4dc82bc017564a2c1a46646f071367bd51d3ddd80171dc5a78ffe",
-						"sha256:e6bc45e7459162f5a27dd7bd310a420f27378f2ea24c6ed5f0a083f2377bde24",
-						"sha256:8184bee7b1985e0abd72288946c246b90e1847b36fdcd50ca3d588b9371ec920",
-						"sha256:f1bb0ccf476528c6165a2f1a74d0e5a0cb06c9b254a0def6b1183db662e83dd4",
+						"sha256:a65d3cd77a7c384ccd7df98de4ffc5cdf38c302bbf2b61b02c65a3ec127a5714",
+						"sha256:d131c21060d03842405da73768d32183374296a9268faf66fc1d33c2e1dc1cb5",
+						"sha256:06ba7bc68445ac9206b39e32b6aacbf887fba397b09038b8f68f812777fe8613",
+						"sha256:073810f7ca55b3467b206e0ac9dc364aedbaf67e9f8a95d90c4a2d906ec44107",
 					},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:dc2c102de2a4dc82bc017564a2c1a46646f071367bd51d3ddd80171dc5a78ffe",
+						BlobID: "sha256:a65d3cd77a7c384ccd7df98de4ffc5cdf38c302bbf2b61b02c65a3ec127a5714",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1642,7 +1666,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:e6bc45e7459162f5a27dd7bd310a420f27378f2ea24c6ed5f0a083f2377bde24",
+						BlobID: "sha256:d131c21060d03842405da73768d32183374296a9268faf66fc1d33c2e1dc1cb5",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1653,7 +1677,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:8184bee7b1985e0abd72288946c246b90e1847b36fdcd50ca3d588b9371ec920",
+						BlobID: "sha256:06ba7bc68445ac9206b39e32b6aacbf887fba397b09038b8f68f812777fe8613",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1665,7 +1689,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:f1bb0ccf476528c6165a2f1a74d0e5a0cb06c9b254a0def6b1183db662e83dd4",
+						BlobID: "sha256:073810f7ca55b3467b206e0ac9dc364aedbaf67e9f8a95d90c4a2d906ec44107",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1681,10 +1705,10 @@ func TestArtifact_Inspect(t *testing.T) {
 				Type: types.ArtifactContainerImage,
 				ID:   "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 				BlobIDs: []string{
-					"sha256:dc2c102de2a4dc82bc017564a2c1a46646f071367bd51d3ddd80171dc5a78ffe",
-					"sha256:e6bc45e7459162f5a27dd7bd310a420f27378f2ea24c6ed5f0a083f2377bde24",
-					"sha256:8184bee7b1985e0abd72288946c246b90e1847b36fdcd50ca3d588b9371ec920",
-					"sha256:f1bb0ccf476528c6165a2f1a74d0e5a0cb06c9b254a0def6b1183db662e83dd4",
+					"sha256:a65d3cd77a7c384ccd7df98de4ffc5cdf38c302bbf2b61b02c65a3ec127a5714",
+					"sha256:d131c21060d03842405da73768d32183374296a9268faf66fc1d33c2e1dc1cb5",
+					"sha256:06ba7bc68445ac9206b39e32b6aacbf887fba397b09038b8f68f812777fe8613",
+					"sha256:073810f7ca55b3467b206e0ac9dc364aedbaf67e9f8a95d90c4a2d906ec44107",
 				},
 				ImageMetadata: types.ImageMetadata{
 					ID: "sha256:58701fd185bda36cab0557bb6438661831267aa4a9e0b54211c4d5317a48aff4",
@@ -1767,7 +1791,7 @@ func TestArtifact_Inspect(t *testing.T) {
 			missingBlobsExpectation: cache.ArtifactCacheMissingBlobsExpectation{
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-					BlobIDs:    []string{"sha256:0366f81c3cbeac047536f9ca133b5632544c8342544922c3ed8a3e51c0250897"},
+					BlobIDs:    []string{"sha256:dd02c8f853531c1ec02aa1a53dcd3997f3273f14580f40b6689a4392dfa686bc"},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					Err: xerrors.New("MissingBlobs failed"),
@@ -1781,16 +1805,16 @@ func TestArtifact_Inspect(t *testing.T) {
 			missingBlobsExpectation: cache.ArtifactCacheMissingBlobsExpectation{
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-					BlobIDs:    []string{"sha256:0366f81c3cbeac047536f9ca133b5632544c8342544922c3ed8a3e51c0250897"},
+					BlobIDs:    []string{"sha256:dd02c8f853531c1ec02aa1a53dcd3997f3273f14580f40b6689a4392dfa686bc"},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
-					MissingBlobIDs: []string{"sha256:0366f81c3cbeac047536f9ca133b5632544c8342544922c3ed8a3e51c0250897"},
+					MissingBlobIDs: []string{"sha256:dd02c8f853531c1ec02aa1a53dcd3997f3273f14580f40b6689a4392dfa686bc"},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:0366f81c3cbeac047536f9ca133b5632544c8342544922c3ed8a3e51c0250897",
+						BlobID: "sha256:dd02c8f853531c1ec02aa1a53dcd3997f3273f14580f40b6689a4392dfa686bc",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1806,14 +1830,16 @@ func TestArtifact_Inspect(t *testing.T) {
 							},
 							PackageInfos: []types.PackageInfo{
 								{
-									FilePath: "lib/apk/db/installed",
-									Packages: alpinePkgs,
+									FilePath:   "lib/apk/db/installed",
+									AnalyzedBy: &types.AnalyzedBy{Type: "apk", Version: 2},
+									Packages:   alpinePkgs,
 								},
 							},
 							Licenses: []types.LicenseFile{
 								{
-									Type:     "header",
-									FilePath: "etc/ssl/misc/CA.pl",
+									Type:       "header",
+									FilePath:   "etc/ssl/misc/CA.pl",
+									AnalyzedBy: &types.AnalyzedBy{Type: "license-file", Version: 1},
 									Findings: []types.LicenseFinding{
 										{
 											Name:       "OpenSSL",
@@ -1823,8 +1849,9 @@ func TestArtifact_Inspect(t *testing.T) {
 									},
 								},
 								{
-									Type:     "header",
-									FilePath: "etc/ssl/misc/tsget.pl",
+									Type:       "header",
+									FilePath:   "etc/ssl/misc/tsget.pl",
+									AnalyzedBy: &types.AnalyzedBy{Type: "license-file", Version: 1},
 									Findings: []types.LicenseFinding{
 										{
 											Name:       "OpenSSL",
@@ -1853,18 +1880,18 @@ func TestArtifact_Inspect(t *testing.T) {
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 					BlobIDs: []string{
-						"sha256:db2b5b2f26f320b80ee549495888f111151fdb44fecb2c432dfd928e96978e74",
-						"sha256:718d6a3d0a24966f2d9f64e0c86695d1bb896fe81df634b85300d34142c80174",
-						"sha256:9f6bc14198fa983fd47079bcd82df1391bee8e529a8a131a0305874eb0a51ca0",
-						"sha256:44264715b7132cd14cd956bf385d5f875603461f5adeafde7fdecabe2e266291",
+						"sha256:e42852a1d7aa19348af2b65be02fa291d4d69bcb7207de31aff742fa2864d7b7",
+						"sha256:478e94d643cde0f8040eae5d3005d052a48735ecba61f4573ea2792f07dbdfeb",
+						"sha256:a26658fd125df1c91569745535f18938c5a7137c5f2732b32d23a5ab179ffac8",
+						"sha256:6beedc074041dc0343998c0c766e832333ee1b2e83c5e9f6c07e804191397ff5",
 					},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingBlobIDs: []string{
-						"sha256:db2b5b2f26f320b80ee549495888f111151fdb44fecb2c432dfd928e96978e74",
-						"sha256:718d6a3d0a24966f2d9f64e0c86695d1bb896fe81df634b85300d34142c80174",
-						"sha256:9f6bc14198fa983fd47079bcd82df1391bee8e529a8a131a0305874eb0a51ca0",
-						"sha256:44264715b7132cd14cd956bf385d5f875603461f5adeafde7fdecabe2e266291",
+						"sha256:e42852a1d7aa19348af2b65be02fa291d4d69bcb7207de31aff742fa2864d7b7",
+						"sha256:478e94d643cde0f8040eae5d3005d052a48735ecba61f4573ea2792f07dbdfeb",
+						"sha256:a26658fd125df1c91569745535f18938c5a7137c5f2732b32d23a5ab179ffac8",
+						"sha256:6beedc074041dc0343998c0c766e832333ee1b2e83c5e9f6c07e804191397ff5",
 					},
 				},
 			},
@@ -1872,7 +1899,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				{
 
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID:           "sha256:db2b5b2f26f320b80ee549495888f111151fdb44fecb2c432dfd928e96978e74",
+						BlobID:           "sha256:e42852a1d7aa19348af2b65be02fa291d4d69bcb7207de31aff742fa2864d7b7",
 						BlobInfoAnything: true,
 					},
 
@@ -1883,7 +1910,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				{
 
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID:           "sha256:718d6a3d0a24966f2d9f64e0c86695d1bb896fe81df634b85300d34142c80174",
+						BlobID:           "sha256:478e94d643cde0f8040eae5d3005d052a48735ecba61f4573ea2792f07dbdfeb",
 						BlobInfoAnything: true,
 					},
 
@@ -1894,7 +1921,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				{
 
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID:           "sha256:9f6bc14198fa983fd47079bcd82df1391bee8e529a8a131a0305874eb0a51ca0",
+						BlobID:           "sha256:a26658fd125df1c91569745535f18938c5a7137c5f2732b32d23a5ab179ffac8",
 						BlobInfoAnything: true,
 					},
 
@@ -1905,7 +1932,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				{
 
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID:           "sha256:44264715b7132cd14cd956bf385d5f875603461f5adeafde7fdecabe2e266291",
+						BlobID:           "sha256:6beedc074041dc0343998c0c766e832333ee1b2e83c5e9f6c07e804191397ff5",
 						BlobInfoAnything: true,
 					},
 
@@ -1922,17 +1949,17 @@ func TestArtifact_Inspect(t *testing.T) {
 			missingBlobsExpectation: cache.ArtifactCacheMissingBlobsExpectation{
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-					BlobIDs:    []string{"sha256:0366f81c3cbeac047536f9ca133b5632544c8342544922c3ed8a3e51c0250897"},
+					BlobIDs:    []string{"sha256:dd02c8f853531c1ec02aa1a53dcd3997f3273f14580f40b6689a4392dfa686bc"},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingArtifact: true,
-					MissingBlobIDs:  []string{"sha256:0366f81c3cbeac047536f9ca133b5632544c8342544922c3ed8a3e51c0250897"},
+					MissingBlobIDs:  []string{"sha256:dd02c8f853531c1ec02aa1a53dcd3997f3273f14580f40b6689a4392dfa686bc"},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:0366f81c3cbeac047536f9ca133b5632544c8342544922c3ed8a3e51c0250897",
+						BlobID: "sha256:dd02c8f853531c1ec02aa1a53dcd3997f3273f14580f40b6689a4392dfa686bc",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1948,14 +1975,16 @@ func TestArtifact_Inspect(t *testing.T) {
 							},
 							PackageInfos: []types.PackageInfo{
 								{
-									FilePath: "lib/apk/db/installed",
-									Packages: alpinePkgs,
+									FilePath:   "lib/apk/db/installed",
+									AnalyzedBy: &types.AnalyzedBy{Type: "apk", Version: 2},
+									Packages:   alpinePkgs,
 								},
 							},
 							Licenses: []types.LicenseFile{
 								{
-									Type:     "header",
-									FilePath: "etc/ssl/misc/CA.pl",
+									Type:       "header",
+									FilePath:   "etc/ssl/misc/CA.pl",
+									AnalyzedBy: &types.AnalyzedBy{Type: "license-file", Version: 1},
 									Findings: []types.LicenseFinding{
 										{
 											Name:       "OpenSSL",
@@ -1965,8 +1994,9 @@ func TestArtifact_Inspect(t *testing.T) {
 									},
 								},
 								{
-									Type:     "header",
-									FilePath: "etc/ssl/misc/tsget.pl",
+									Type:       "header",
+									FilePath:   "etc/ssl/misc/tsget.pl",
+									AnalyzedBy: &types.AnalyzedBy{Type: "license-file", Version: 1},
 									Findings: []types.LicenseFinding{
 										{
 											Name:       "OpenSSL",
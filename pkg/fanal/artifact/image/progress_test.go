@@ -0,0 +1,135 @@
+package image_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
+	image2 "github.com/zhanglimao/trivy/pkg/fanal/artifact/image"
+	"github.com/zhanglimao/trivy/pkg/fanal/cache"
+	"github.com/zhanglimao/trivy/pkg/fanal/image"
+)
+
+type fakeProgress struct {
+	mu     sync.Mutex
+	layers map[string]*fakeLayerProgress
+	done   bool
+}
+
+func (p *fakeProgress) Layer(id string, size int64) artifact.LayerProgress {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	l := &fakeLayerProgress{size: size}
+	p.layers[id] = l
+	return l
+}
+
+func (p *fakeProgress) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done = true
+}
+
+type fakeLayerProgress struct {
+	mu     sync.Mutex
+	size   int64
+	read   int64
+	stages []artifact.Stage
+}
+
+func (l *fakeLayerProgress) Add(n int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.read += n
+}
+
+func (l *fakeLayerProgress) SetStage(stage artifact.Stage) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stages = append(l.stages, stage)
+}
+
+func TestArtifact_Inspect_Progress(t *testing.T) {
+	type wantLayer struct {
+		size int64
+		read int64 // -1 if unknown
+	}
+	tests := []struct {
+		name      string
+		imagePath string
+		want      map[string]wantLayer
+	}{
+		{
+			name:      "uncompressed layers",
+			imagePath: "../../test/testdata/alpine-311.tar.gz",
+			want: map[string]wantLayer{
+				"sha256:beee9f30bc1f711043e78d4a2be0668955d4b761d587d6f60c2c8dc081efb203": {
+					size: 0,
+					read: -1,
+				},
+			},
+		},
+		{
+			name:      "compressed layers",
+			imagePath: "../../test/testdata/test.oci",
+			want: map[string]wantLayer{
+				"sha256:9c27e219663c25e0f28493790cc0b88bc973ba3b1686355f221c38a36978ac63": {
+					size: 2610,
+					read: 2610,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := image.NewArchiveImage(tt.imagePath)
+			require.NoError(t, err)
+
+			c, err := cache.NewFSCache(t.TempDir())
+			require.NoError(t, err)
+			defer c.Close()
+
+			progress := &fakeProgress{layers: map[string]*fakeLayerProgress{}}
+			a, err := image2.NewArtifact(img, c, artifact.Option{
+				Progress: progress,
+			})
+			require.NoError(t, err)
+
+			_, err = a.Inspect(context.Background())
+			require.NoError(t, err)
+			assert.True(t, progress.done)
+			require.Len(t, progress.layers, len(tt.want))
+
+			for id, want := range tt.want {
+				got, ok := progress.layers[id]
+				require.True(t, ok, id)
+				assert.Equal(t, want.size, got.size)
+				if want.read < 0 {
+					assert.Greater(t, got.read, int64(0))
+				} else {
+					assert.Equal(t, want.read, got.read)
+				}
+				assert.Equal(t, []artifact.Stage{
+					artifact.StageAnalyzing,
+					artifact.StagePostAnalyzing,
+					artifact.StageDone,
+				}, got.stages)
+			}
+
+			// Cached layers are not inspected
+			progress = &fakeProgress{layers: map[string]*fakeLayerProgress{}}
+			a, err = image2.NewArtifact(img, c, artifact.Option{
+				Progress: progress,
+			})
+			require.NoError(t, err)
+
+			_, err = a.Inspect(context.Background())
+			require.NoError(t, err)
+			assert.Empty(t, progress.layers)
+		})
+	}
+}
@@ -69,7 +69,7 @@ func TestArtifact_InspectRekorAttestation(t *testing.T) {
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:9c23872047046e145f49fb5533b63ace0cbf819f5b68e33f69f4e9bbab4c517e",
+						BlobID: "sha256:e7df2a4491f8c30f931ca7b94d116bdf1577001f825dabf7513ebce739ef5fba",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							OS: types.OS{
@@ -104,9 +104,9 @@ func TestArtifact_InspectRekorAttestation(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "test/image:10",
 				Type: types.ArtifactCycloneDX,
-				ID:   "sha256:9c23872047046e145f49fb5533b63ace0cbf819f5b68e33f69f4e9bbab4c517e",
+				ID:   "sha256:e7df2a4491f8c30f931ca7b94d116bdf1577001f825dabf7513ebce739ef5fba",
 				BlobIDs: []string{
-					"sha256:9c23872047046e145f49fb5533b63ace0cbf819f5b68e33f69f4e9bbab4c517e",
+					"sha256:e7df2a4491f8c30f931ca7b94d116bdf1577001f825dabf7513ebce739ef5fba",
 				},
 			},
 		},
@@ -208,7 +208,7 @@ func TestArtifact_inspectOCIReferrerSBOM(t *testing.T) {
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:d07a1894bfd283b4ac26682ab48f12ad22cdc4fef9cf8b4c09056f631d3667a5",
+						BlobID: "sha256:10e4d9318f5464b50e38694d1c9ef2f9656cc99278d9a7d7af685887a6014618",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Applications: []types.Application{
@@ -235,9 +235,9 @@ func TestArtifact_inspectOCIReferrerSBOM(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: registry + "/test/image:10",
 				Type: types.ArtifactCycloneDX,
-				ID:   "sha256:d07a1894bfd283b4ac26682ab48f12ad22cdc4fef9cf8b4c09056f631d3667a5",
+				ID:   "sha256:10e4d9318f5464b50e38694d1c9ef2f9656cc99278d9a7d7af685887a6014618",
 				BlobIDs: []string{
-					"sha256:d07a1894bfd283b4ac26682ab48f12ad22cdc4fef9cf8b4c09056f631d3667a5",
+					"sha256:10e4d9318f5464b50e38694d1c9ef2f9656cc99278d9a7d7af685887a6014618",
 				},
 			},
 		},
@@ -0,0 +1,169 @@
+package local
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/xerrors"
+
+	dio "github.com/aquasecurity/go-dep-parser/pkg/io"
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
+	"github.com/zhanglimao/trivy/pkg/fanal/cache"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// fileCache stores the analysis result of each file with a key derived from the hash of its content,
+// so that unchanged files are not analyzed again, e.g. when scanning another commit of the same repository.
+type fileCache struct {
+	cache   cache.LocalArtifactCache
+	putter  cache.ArtifactCache
+	hashes  map[string]string
+	baseKey string
+}
+
+func newFileCache(c cache.ArtifactCache, a analyzer.AnalyzerGroup, hookVersions map[string]int,
+	opt artifact.Option) (*fileCache, error) {
+	if len(opt.FileHashes) == 0 {
+		return nil, nil
+	}
+
+	// The cached results need to be read, which is not possible with the remote cache
+	localCache, ok := c.(cache.LocalArtifactCache)
+	if !ok {
+		log.Logger.Debug("Per-file cache is not available with this cache backend")
+		return nil, nil
+	}
+
+	baseKey, err := cache.CalcKey("file", a.AnalyzerVersions(), hookVersions, opt)
+	if err != nil {
+		return nil, xerrors.Errorf("cache key: %w", err)
+	}
+
+	// Options changing the results of analyzers but not covered by CalcKey
+	h := sha256.New()
+	keyBase := struct {
+		BaseKey       string
+		Offline       bool
+		FileChecksum  bool
+		LicenseOption analyzer.LicenseScannerOption
+	}{baseKey, opt.Offline, opt.FileChecksum, opt.LicenseScannerOption}
+	if err = json.NewEncoder(h).Encode(keyBase); err != nil {
+		return nil, xerrors.Errorf("json encode error: %w", err)
+	}
+	if p := opt.SecretScannerOption.ConfigPath; p != "" {
+		b, err := os.ReadFile(p)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, xerrors.Errorf("secret config read error: %w", err)
+		}
+		h.Write(b)
+	}
+
+	return &fileCache{
+		cache:   localCache,
+		putter:  c,
+		hashes:  opt.FileHashes,
+		baseKey: fmt.Sprintf("%x", h.Sum(nil)),
+	}, nil
+}
+
+// key returns the cache key of the file, or false if the hash of the file is unknown.
+func (c *fileCache) key(filePath string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	hash, ok := c.hashes[filePath]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(c.baseKey+"\x00"+filePath+"\x00"+hash))), true
+}
+
+// analyzeFile merges the cached result of the file into the result if any,
+// otherwise analyzes the file and caches the result.
+func (a Artifact) analyzeFile(ctx context.Context, wg *sync.WaitGroup, scheduler *analyzer.Scheduler,
+	result *analyzer.AnalysisResult, dir, filePath string, info os.FileInfo, opener analyzer.Opener,
+	opts analyzer.AnalysisOptions) error {
+	key, ok := a.fileCache.key(filePath)
+	if !ok {
+		return a.analyzer.AnalyzeFile(ctx, wg, scheduler, result, dir, filePath, info, opener, nil, opts)
+	}
+
+	if blobInfo, err := a.fileCache.cache.GetBlob(key); err == nil {
+		result.Merge(fileResult(blobInfo))
+		return nil
+	}
+
+	// Track whether any analyzer opens the file so that irrelevant files are not cached.
+	var opened atomic.Bool
+	trackedOpener := func() (dio.ReadSeekCloserAt, error) {
+		opened.Store(true)
+		return opener()
+	}
+
+	var fileWG sync.WaitGroup
+	r := analyzer.NewAnalysisResult()
+	if err := a.analyzer.AnalyzeFile(ctx, &fileWG, scheduler, r, dir, filePath, info, trackedOpener, nil, opts); err != nil {
+		return err
+	}
+	if !opened.Load() {
+		return nil
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		fileWG.Wait()
+
+		result.Merge(r)
+		blobInfo, ok := fileBlobInfo(r)
+		if !ok {
+			return
+		}
+		if err := a.fileCache.putter.PutBlob(key, blobInfo); err != nil {
+			log.Logger.Debugf("Unable to cache the analysis result of %s: %s", filePath, err)
+		}
+	}()
+	return nil
+}
+
+// fileBlobInfo converts the analysis result of a single file into BlobInfo to be cached.
+// It returns false if the result contains what BlobInfo cannot hold, or if the result is incomplete.
+func fileBlobInfo(r *analyzer.AnalysisResult) (types.BlobInfo, bool) {
+	if len(r.SystemInstalledFiles) > 0 || r.BuildInfo != nil || len(r.Warnings) > 0 {
+		return types.BlobInfo{}, false
+	}
+	return types.BlobInfo{
+		SchemaVersion:     types.BlobJSONSchemaVersion,
+		OS:                r.OS,
+		Repository:        r.Repository,
+		PackageInfos:      r.PackageInfos,
+		Applications:      r.Applications,
+		Misconfigurations: r.Misconfigurations,
+		Secrets:           r.Secrets,
+		Licenses:          r.Licenses,
+		CustomResources:   r.CustomResources,
+		Digests:           r.Digests,
+	}, true
+}
+
+func fileResult(blobInfo types.BlobInfo) *analyzer.AnalysisResult {
+	return &analyzer.AnalysisResult{
+		OS:                blobInfo.OS,
+		Repository:        blobInfo.Repository,
+		PackageInfos:      blobInfo.PackageInfos,
+		Applications:      blobInfo.Applications,
+		Misconfigurations: blobInfo.Misconfigurations,
+		Secrets:           blobInfo.Secrets,
+		Licenses:          blobInfo.Licenses,
+		CustomResources:   blobInfo.CustomResources,
+		Digests:           blobInfo.Digests,
+	}
+}
@@ -12,18 +12,20 @@ import (
 	"sync"
 
 	"github.com/opencontainers/go-digest"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/xerrors"
 
 	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
 	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
 	"github.com/zhanglimao/trivy/pkg/fanal/cache"
+	"github.com/zhanglimao/trivy/pkg/fanal/firmware"
 	"github.com/zhanglimao/trivy/pkg/fanal/handler"
 	"github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/fanal/walker"
 	"github.com/zhanglimao/trivy/pkg/log"
 	"github.com/zhanglimao/trivy/pkg/mapfs"
-	"github.com/zhanglimao/trivy/pkg/semaphore"
 	"github.com/zhanglimao/trivy/pkg/syncx"
+	"github.com/zhanglimao/trivy/pkg/tracing"
 )
 
 type Artifact struct {
@@ -32,6 +34,8 @@ type Artifact struct {
 	walker         walker.FS
 	analyzer       analyzer.AnalyzerGroup
 	handlerManager handler.Manager
+	fileCache      *fileCache
+	walkWarnings   *walkWarnings // Only in the keep-going mode
 
 	artifactOption artifact.Option
 }
@@ -50,23 +54,63 @@ func NewArtifact(rootPath string, c cache.ArtifactCache, opt artifact.Option) (a
 		MisconfScannerOption: opt.MisconfScannerOption,
 		SecretScannerOption:  opt.SecretScannerOption,
 		LicenseScannerOption: opt.LicenseScannerOption,
+		CustomResourceOption: opt.CustomResourceOption,
+		PermissionOption:     opt.PermissionOption,
+		Timeout:              opt.AnalyzerTimeout,
+		KeepGoing:            opt.KeepGoing,
 	})
 	if err != nil {
 		return nil, xerrors.Errorf("analyzer group error: %w", err)
 	}
 
+	fc, err := newFileCache(c, a, handlerManager.Versions(), opt)
+	if err != nil {
+		return nil, xerrors.Errorf("file cache error: %w", err)
+	}
+
+	filter := opt.FileFilter()
+	filter.IncludeFiles = buildIncludePaths(rootPath, opt.IncludeFiles)
+
+	// A custom error callback given by library users takes precedence
+	errCallback := opt.WalkOption.ErrorCallback
+	var ww *walkWarnings
+	if opt.KeepGoing {
+		ww = new(walkWarnings)
+		if errCallback == nil {
+			errCallback = ww.errorCallback
+		}
+	}
+
 	return Artifact{
 		rootPath: filepath.Clean(rootPath),
 		cache:    c,
 		walker: walker.NewFS(buildPathsToSkip(rootPath, opt.SkipFiles), buildPathsToSkip(rootPath, opt.SkipDirs),
-			opt.Slow, opt.WalkOption.ErrorCallback),
+			opt.Slow, errCallback, opt.SymlinkOption, filter),
 		analyzer:       a,
 		handlerManager: handlerManager,
+		fileCache:      fc,
+		walkWarnings:   ww,
 
 		artifactOption: opt,
 	}, nil
 }
 
+// buildIncludePaths builds correct paths for include patterns in the same way as skipFiles.
+// The "!" prefix of exclusions is kept.
+func buildIncludePaths(base string, patterns []string) []string {
+	var paths []string
+	for _, pattern := range patterns {
+		var prefix string
+		if strings.HasPrefix(pattern, "!") {
+			prefix, pattern = "!", strings.TrimPrefix(pattern, "!")
+		}
+		for _, p := range buildPathsToSkip(base, []string{pattern}) {
+			paths = append(paths, prefix+p)
+		}
+	}
+	return paths
+}
+
 // buildPathsToSkip builds correct patch for skipDirs and skipFiles
 func buildPathsToSkip(base string, paths []string) []string {
 	var relativePaths []string
@@ -98,18 +142,26 @@ func buildPathsToSkip(base string, paths []string) []string {
 			log.Logger.Warnf("Failed to get an absolute path of %s: %s", base, err)
 			continue
 		}
-		rel, err := filepath.Rel(absBase, absSkipPath)
-		if err != nil {
-			log.Logger.Warnf("Failed to get a relative path from %s to %s: %s", base, path, err)
+		// On Windows, paths on another drive or share than the root directory cannot be made relative.
+		// Such absolute paths are ignored as they never match, and relative paths are used as is (#1).
+		rel := ".."
+		if strings.EqualFold(filepath.VolumeName(absBase), filepath.VolumeName(absSkipPath)) {
+			if rel, err = filepath.Rel(absBase, absSkipPath); err != nil {
+				log.Logger.Warnf("Failed to get a relative path from %s to %s: %s", base, path, err)
+				continue
+			}
+		} else if filepath.IsAbs(path) {
+			log.Logger.Warnf("Ignoring %s on a different volume from %s", path, base)
 			continue
 		}
 
 		var relPath string
 		switch {
-		case !filepath.IsAbs(path) && strings.HasPrefix(rel, ".."):
-			// #1: Use the path as is
-			relPath = path
-		case !filepath.IsAbs(path) && !strings.HasPrefix(rel, ".."):
+		case !filepath.IsAbs(path) && isOutside(rel):
+			// #1: Use the path as is.
+			// The volume name of drive-relative paths on Windows such as "C:foo" is trimmed.
+			relPath = strings.TrimPrefix(path, filepath.VolumeName(path))
+		case !filepath.IsAbs(path) && !isOutside(rel):
 			// #2: Use the relative path from the root directory
 			relPath = rel
 		case filepath.IsAbs(path):
@@ -122,10 +174,31 @@ func buildPathsToSkip(base string, paths []string) []string {
 	return relativePaths
 }
 
-func (a Artifact) Inspect(ctx context.Context) (types.ArtifactReference, error) {
+// isOutside reports whether the relative path points outside the base directory.
+// A file name starting with dots such as "..foo" is inside.
+func isOutside(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func (a Artifact) Inspect(ctx context.Context) (_ types.ArtifactReference, err error) {
+	ctx, span := tracing.Start(ctx, "filesystem.Inspect", attribute.String("filesystem.path", a.rootPath))
+	defer func() { tracing.End(span, err) }()
+
+	// Firmware images are scanned as the filesystems embedded in them
+	rootPath, cleanup, err := a.extractFirmware()
+	if err != nil {
+		return types.ArtifactReference{}, xerrors.Errorf("firmware error: %w", err)
+	}
+	defer cleanup()
+
 	var wg sync.WaitGroup
 	result := analyzer.NewAnalysisResult()
-	limit := semaphore.New(a.artifactOption.Slow)
+	if err = result.EnableSpill(a.artifactOption.MaxMemory); err != nil {
+		return types.ArtifactReference{}, xerrors.Errorf("analysis result error: %w", err)
+	}
+	defer result.Close()
+	scheduler := analyzer.NewScheduler(a.artifactOption.SchedulerOption())
+	defer scheduler.Close()
 	opts := analyzer.AnalysisOptions{
 		Offline:      a.artifactOption.Offline,
 		FileChecksum: a.artifactOption.FileChecksum,
@@ -134,21 +207,23 @@ func (a Artifact) Inspect(ctx context.Context) (types.ArtifactReference, error)
 	// Prepare filesystem for post analysis
 	files := new(syncx.Map[analyzer.Type, *mapfs.FS])
 
-	err := a.walker.Walk(a.rootPath, func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
-		dir := a.rootPath
+	err = a.walker.Walk(rootPath, func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
+		dir := rootPath
 
 		// When the directory is the same as the filePath, a file was given
 		// instead of a directory, rewrite the file path and directory in this case.
 		if filePath == "." {
-			dir, filePath = filepath.Split(a.rootPath)
+			dir, filePath = filepath.Split(rootPath)
 		}
 
-		if err := a.analyzer.AnalyzeFile(ctx, &wg, limit, result, dir, filePath, info, opener, nil, opts); err != nil {
+		if err := a.analyzeFile(ctx, &wg, scheduler, result, dir, filePath, info, opener, opts); err != nil {
 			return xerrors.Errorf("analyze file (%s): %w", filePath, err)
 		}
 
 		// Build filesystem for post analysis
-		if err := a.buildFS(dir, filePath, info, files); err != nil {
+		if err := a.buildFS(dir, filePath, info, files); err != nil && a.walkWarnings != nil {
+			a.walkWarnings.add(filePath, xerrors.Errorf("failed to build filesystem: %w", err))
+		} else if err != nil {
 			return xerrors.Errorf("failed to build filesystem: %w", err)
 		}
 
@@ -161,11 +236,20 @@ func (a Artifact) Inspect(ctx context.Context) (types.ArtifactReference, error)
 	// Wait for all the goroutine to finish.
 	wg.Wait()
 
+	if a.walkWarnings != nil {
+		result.Merge(a.walkWarnings.result())
+	}
+
 	// Post-analysis
 	if err = a.analyzer.PostAnalyze(ctx, files, result, opts); err != nil {
 		return types.ArtifactReference{}, xerrors.Errorf("post analysis error: %w", err)
 	}
 
+	// Load the results spilled to disk, including those of post-analyzers
+	if err = result.Restore(); err != nil {
+		return types.ArtifactReference{}, xerrors.Errorf("restore error: %w", err)
+	}
+
 	// Sort the analysis result for consistent results
 	result.Sort()
 
@@ -179,6 +263,8 @@ func (a Artifact) Inspect(ctx context.Context) (types.ArtifactReference, error)
 		Secrets:           result.Secrets,
 		Licenses:          result.Licenses,
 		CustomResources:   result.CustomResources,
+		Warnings:          result.Warnings,
+		Digests:           result.Digests,
 	}
 
 	if err = a.handlerManager.PostHandle(ctx, result, &blobInfo); err != nil {
@@ -196,7 +282,7 @@ func (a Artifact) Inspect(ctx context.Context) (types.ArtifactReference, error)
 
 	// get hostname
 	var hostName string
-	b, err := os.ReadFile(filepath.Join(a.rootPath, "etc", "hostname"))
+	b, err := os.ReadFile(filepath.Join(rootPath, "etc", "hostname"))
 	if err == nil && string(b) != "" {
 		hostName = strings.TrimSpace(string(b))
 	} else {
@@ -212,6 +298,37 @@ func (a Artifact) Inspect(ctx context.Context) (types.ArtifactReference, error)
 	}, nil
 }
 
+// extractFirmware extracts the filesystems when the root path is a firmware image such as a SquashFS image,
+// and returns the directory to be scanned. Otherwise, it returns the root path as is.
+func (a Artifact) extractFirmware() (string, func(), error) {
+	nop := func() {}
+	if fi, err := os.Stat(a.rootPath); err != nil || !fi.Mode().IsRegular() {
+		return a.rootPath, nop, nil
+	}
+	if ok, err := firmware.Detect(a.rootPath); err != nil {
+		return "", nop, xerrors.Errorf("firmware detection error: %w", err)
+	} else if !ok {
+		return a.rootPath, nop, nil
+	}
+
+	tmpDir, err := os.MkdirTemp("", "fanal-firmware-*")
+	if err != nil {
+		return "", nop, xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			log.Logger.Debugf("Failed to remove %s: %s", tmpDir, err)
+		}
+	}
+
+	log.Logger.Infof("Extracting the firmware image %s...", a.rootPath)
+	if err = firmware.Extract(a.rootPath, tmpDir); err != nil {
+		cleanup()
+		return "", nop, err
+	}
+	return tmpDir, cleanup, nil
+}
+
 func (a Artifact) Clean(reference types.ArtifactReference) error {
 	return a.cache.DeleteBlobs(reference.BlobIDs)
 }
@@ -3,6 +3,7 @@ package local
 import (
 	"context"
 	"errors"
+	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
@@ -47,7 +48,7 @@ func TestArtifact_Inspect(t *testing.T) {
 			},
 			putBlobExpectation: cache.ArtifactCachePutBlobExpectation{
 				Args: cache.ArtifactCachePutBlobArgs{
-					BlobID: "sha256:9101fcb54fd63b7dfde027bd669e159ed65aff15842057780f4b0c846bab6369",
+					BlobID: "sha256:51ccfe651c0d4834fe0f06ed93d6f4ab7fc6e9b55e670baed3113c637ce839c4",
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						OS: types.OS{
@@ -56,7 +57,8 @@ func TestArtifact_Inspect(t *testing.T) {
 						},
 						PackageInfos: []types.PackageInfo{
 							{
-								FilePath: "lib/apk/db/installed",
+								FilePath:   "lib/apk/db/installed",
+								AnalyzedBy: &types.AnalyzedBy{Type: "apk", Version: 2},
 								Packages: []types.Package{
 									{
 										ID:         "musl@1.1.24-r2",
@@ -78,9 +80,9 @@ func TestArtifact_Inspect(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "host",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:9101fcb54fd63b7dfde027bd669e159ed65aff15842057780f4b0c846bab6369",
+				ID:   "sha256:51ccfe651c0d4834fe0f06ed93d6f4ab7fc6e9b55e670baed3113c637ce839c4",
 				BlobIDs: []string{
-					"sha256:9101fcb54fd63b7dfde027bd669e159ed65aff15842057780f4b0c846bab6369",
+					"sha256:51ccfe651c0d4834fe0f06ed93d6f4ab7fc6e9b55e670baed3113c637ce839c4",
 				},
 			},
 		},
@@ -98,7 +100,7 @@ func TestArtifact_Inspect(t *testing.T) {
 			},
 			putBlobExpectation: cache.ArtifactCachePutBlobExpectation{
 				Args: cache.ArtifactCachePutBlobArgs{
-					BlobID: "sha256:d5fa75cdac006582a8f6bc4e3fcc8bfb70bd9d0403c24d8c2e3230d3f38a7ff5",
+					BlobID: "sha256:830ab1c52f94d494ea07f2ca0a410e9a595c965cf31fda8aae82b4846600ef35",
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: types.BlobJSONSchemaVersion,
 					},
@@ -108,9 +110,9 @@ func TestArtifact_Inspect(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "host",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:d5fa75cdac006582a8f6bc4e3fcc8bfb70bd9d0403c24d8c2e3230d3f38a7ff5",
+				ID:   "sha256:830ab1c52f94d494ea07f2ca0a410e9a595c965cf31fda8aae82b4846600ef35",
 				BlobIDs: []string{
-					"sha256:d5fa75cdac006582a8f6bc4e3fcc8bfb70bd9d0403c24d8c2e3230d3f38a7ff5",
+					"sha256:830ab1c52f94d494ea07f2ca0a410e9a595c965cf31fda8aae82b4846600ef35",
 				},
 			},
 		},
@@ -121,7 +123,7 @@ func TestArtifact_Inspect(t *testing.T) {
 			},
 			putBlobExpectation: cache.ArtifactCachePutBlobExpectation{
 				Args: cache.ArtifactCachePutBlobArgs{
-					BlobID: "sha256:9101fcb54fd63b7dfde027bd669e159ed65aff15842057780f4b0c846bab6369",
+					BlobID: "sha256:51ccfe651c0d4834fe0f06ed93d6f4ab7fc6e9b55e670baed3113c637ce839c4",
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						OS: types.OS{
@@ -130,7 +132,8 @@ func TestArtifact_Inspect(t *testing.T) {
 						},
 						PackageInfos: []types.PackageInfo{
 							{
-								FilePath: "lib/apk/db/installed",
+								FilePath:   "lib/apk/db/installed",
+								AnalyzedBy: &types.AnalyzedBy{Type: "apk", Version: 2},
 								Packages: []types.Package{
 									{
 										ID:         "musl@1.1.24-r2",
@@ -153,6 +156,51 @@ func TestArtifact_Inspect(t *testing.T) {
 			},
 			wantErr: "failed to store blob",
 		},
+		{
+			name: "happy path with firmware image",
+			fields: fields{
+				dir: "./testdata/alpine.squashfs",
+			},
+			putBlobExpectation: cache.ArtifactCachePutBlobExpectation{
+				Args: cache.ArtifactCachePutBlobArgs{
+					BlobID: "sha256:51ccfe651c0d4834fe0f06ed93d6f4ab7fc6e9b55e670baed3113c637ce839c4",
+					BlobInfo: types.BlobInfo{
+						SchemaVersion: types.BlobJSONSchemaVersion,
+						OS: types.OS{
+							Family: "alpine",
+							Name:   "3.11.6",
+						},
+						PackageInfos: []types.PackageInfo{
+							{
+								FilePath:   "lib/apk/db/installed",
+								AnalyzedBy: &types.AnalyzedBy{Type: "apk", Version: 2},
+								Packages: []types.Package{
+									{
+										ID:         "musl@1.1.24-r2",
+										Name:       "musl",
+										Version:    "1.1.24-r2",
+										SrcName:    "musl",
+										SrcVersion: "1.1.24-r2",
+										Licenses:   []string{"MIT"},
+										Arch:       "x86_64",
+										Digest:     "sha1:cb2316a189ebee5282c4a9bd98794cc2477a74c6",
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: cache.ArtifactCachePutBlobReturns{},
+			},
+			want: types.ArtifactReference{
+				Name: "host",
+				Type: types.ArtifactFilesystem,
+				ID:   "sha256:51ccfe651c0d4834fe0f06ed93d6f4ab7fc6e9b55e670baed3113c637ce839c4",
+				BlobIDs: []string{
+					"sha256:51ccfe651c0d4834fe0f06ed93d6f4ab7fc6e9b55e670baed3113c637ce839c4",
+				},
+			},
+		},
 		{
 			name: "sad path with no such directory",
 			fields: fields{
@@ -167,13 +215,14 @@ func TestArtifact_Inspect(t *testing.T) {
 			},
 			putBlobExpectation: cache.ArtifactCachePutBlobExpectation{
 				Args: cache.ArtifactCachePutBlobArgs{
-					BlobID: "sha256:0e0d362332d8928f71ac2c11e0813e2ec251dca9bdf1a66bd69cad8f2ef66ca1",
+					BlobID: "sha256:cceef572d354532548f81e14418181e49240b3be26e1023cf98e3d7ec5da8e1d",
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						Applications: []types.Application{
 							{
-								Type:     "pip",
-								FilePath: "requirements.txt",
+								Type:       "pip",
+								FilePath:   "requirements.txt",
+								AnalyzedBy: &types.AnalyzedBy{Type: "pip", Version: 1},
 								Libraries: []types.Package{
 									{
 										Name:    "Flask",
@@ -189,9 +238,9 @@ func TestArtifact_Inspect(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/requirements.txt",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:0e0d362332d8928f71ac2c11e0813e2ec251dca9bdf1a66bd69cad8f2ef66ca1",
+				ID:   "sha256:cceef572d354532548f81e14418181e49240b3be26e1023cf98e3d7ec5da8e1d",
 				BlobIDs: []string{
-					"sha256:0e0d362332d8928f71ac2c11e0813e2ec251dca9bdf1a66bd69cad8f2ef66ca1",
+					"sha256:cceef572d354532548f81e14418181e49240b3be26e1023cf98e3d7ec5da8e1d",
 				},
 			},
 		},
@@ -202,13 +251,14 @@ func TestArtifact_Inspect(t *testing.T) {
 			},
 			putBlobExpectation: cache.ArtifactCachePutBlobExpectation{
 				Args: cache.ArtifactCachePutBlobArgs{
-					BlobID: "sha256:0e0d362332d8928f71ac2c11e0813e2ec251dca9bdf1a66bd69cad8f2ef66ca1",
+					BlobID: "sha256:cceef572d354532548f81e14418181e49240b3be26e1023cf98e3d7ec5da8e1d",
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						Applications: []types.Application{
 							{
-								Type:     "pip",
-								FilePath: "requirements.txt",
+								Type:       "pip",
+								FilePath:   "requirements.txt",
+								AnalyzedBy: &types.AnalyzedBy{Type: "pip", Version: 1},
 								Libraries: []types.Package{
 									{
 										Name:    "Flask",
@@ -224,9 +274,9 @@ func TestArtifact_Inspect(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/requirements.txt",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:0e0d362332d8928f71ac2c11e0813e2ec251dca9bdf1a66bd69cad8f2ef66ca1",
+				ID:   "sha256:cceef572d354532548f81e14418181e49240b3be26e1023cf98e3d7ec5da8e1d",
 				BlobIDs: []string{
-					"sha256:0e0d362332d8928f71ac2c11e0813e2ec251dca9bdf1a66bd69cad8f2ef66ca1",
+					"sha256:cceef572d354532548f81e14418181e49240b3be26e1023cf98e3d7ec5da8e1d",
 				},
 			},
 		},
@@ -252,6 +302,39 @@ func TestArtifact_Inspect(t *testing.T) {
 	}
 }
 
+func TestArtifact_InspectWithFileHashes(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "etc"), 0755))
+	release := filepath.Join(dir, "etc", "alpine-release")
+	require.NoError(t, os.WriteFile(release, []byte("3.11.6"), 0644))
+
+	c, err := cache.NewFSCache(t.TempDir())
+	require.NoError(t, err)
+
+	inspect := func(hash string) types.OS {
+		a, err := NewArtifact(dir, c, artifact.Option{
+			FileHashes: map[string]string{"etc/alpine-release": hash},
+		})
+		require.NoError(t, err)
+
+		ref, err := a.Inspect(context.Background())
+		require.NoError(t, err)
+
+		blobInfo, err := c.GetBlob(ref.ID)
+		require.NoError(t, err)
+		return blobInfo.OS
+	}
+
+	assert.Equal(t, types.OS{Family: "alpine", Name: "3.11.6"}, inspect("aaa"))
+
+	// The cached result is used while the hash is the same
+	require.NoError(t, os.WriteFile(release, []byte("3.18.4"), 0644))
+	assert.Equal(t, types.OS{Family: "alpine", Name: "3.11.6"}, inspect("aaa"))
+
+	// The file is analyzed again once the hash changes
+	assert.Equal(t, types.OS{Family: "alpine", Name: "3.18.4"}, inspect("bbb"))
+}
+
 func TestBuildPathsToSkip(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -325,6 +408,16 @@ func TestBuildPathsToSkip(t *testing.T) {
 			paths: []string{"foo/bar"},
 			want:  []string{"foo/bar"},
 		},
+		{
+			name: "path - rel starting with dots, base - dot",
+			oses: []string{
+				"linux",
+				"darwin",
+			},
+			base:  ".",
+			paths: []string{"..foo/bar"},
+			want:  []string{"..foo/bar"},
+		},
 		// Windows
 		{
 			name:  "path - rel, base - rel. Skip common prefix",
@@ -340,6 +433,41 @@ func TestBuildPathsToSkip(t *testing.T) {
 			paths: []string{"foo\\bar"},
 			want:  []string{"foo/bar"},
 		},
+		{
+			name:  "path - abs with drive letter, base - abs with drive letter in different case",
+			oses:  []string{"windows"},
+			base:  "c:\\foo",
+			paths: []string{"C:\\Foo\\bar"},
+			want:  []string{"bar"},
+		},
+		{
+			name:  "path - abs on different drive, base - abs",
+			oses:  []string{"windows"},
+			base:  "C:\\foo",
+			paths: []string{"D:\\foo\\bar"},
+			want:  nil,
+		},
+		{
+			name:  "path - drive-relative on different drive, base - abs",
+			oses:  []string{"windows"},
+			base:  "C:\\foo",
+			paths: []string{"D:bar\\baz"},
+			want:  []string{"bar/baz"},
+		},
+		{
+			name:  "path - UNC, base - UNC",
+			oses:  []string{"windows"},
+			base:  "\\\\server\\share\\foo",
+			paths: []string{"\\\\SERVER\\share\\foo\\bar"},
+			want:  []string{"bar"},
+		},
+		{
+			name:  "path - UNC on different share, base - UNC",
+			oses:  []string{"windows"},
+			base:  "\\\\server\\share\\foo",
+			paths: []string{"\\\\server\\other\\foo\\bar"},
+			want:  nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -380,11 +508,12 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 				Args: cache.ArtifactCachePutBlobArgs{
 					BlobIDAnything: true,
 					BlobInfo: types.BlobInfo{
-						SchemaVersion: 2,
+						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "terraform",
-								FilePath: "main.tf",
+								FileType:   "terraform",
+								FilePath:   "main.tf",
+								AnalyzedBy: &types.AnalyzedBy{Type: "terraform", Version: 1},
 								Failures: types.MisconfResults{
 									{
 										Namespace: "user.something",
@@ -418,9 +547,9 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/terraform/single-failure/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:6988beec13a8762e6bd83c7dc84771acb8adb04431be3180cb6aa09d133fb80d",
+				ID:   "sha256:bf786cfaf6e93ac5d7b7fbfdbacad7c47f620d5d9a80cca9ee0d685507e9ae37",
 				BlobIDs: []string{
-					"sha256:6988beec13a8762e6bd83c7dc84771acb8adb04431be3180cb6aa09d133fb80d",
+					"sha256:bf786cfaf6e93ac5d7b7fbfdbacad7c47f620d5d9a80cca9ee0d685507e9ae37",
 				},
 			},
 		},
@@ -440,11 +569,12 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 				Args: cache.ArtifactCachePutBlobArgs{
 					BlobIDAnything: true,
 					BlobInfo: types.BlobInfo{
-						SchemaVersion: 2,
+						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "terraform",
-								FilePath: "main.tf",
+								FileType:   "terraform",
+								FilePath:   "main.tf",
+								AnalyzedBy: &types.AnalyzedBy{Type: "terraform", Version: 1},
 								Failures: types.MisconfResults{
 									{
 										Namespace: "user.something",
@@ -493,8 +623,9 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 								},
 							},
 							{
-								FileType: "terraform",
-								FilePath: "more.tf",
+								FileType:   "terraform",
+								FilePath:   "more.tf",
+								AnalyzedBy: &types.AnalyzedBy{Type: "terraform", Version: 1},
 								Failures: types.MisconfResults{
 									{
 										Namespace: "user.something",
@@ -528,9 +659,9 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/terraform/multiple-failures/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:89dacc9d8c0f17c7d101adfbfa30085ad1031f9e45f0a74cb2252aefe1437fe5",
+				ID:   "sha256:f0d0ef66c00b8dc9788c0c586eb2d79d03d9eaddb64610667815de7e60515e4d",
 				BlobIDs: []string{
-					"sha256:89dacc9d8c0f17c7d101adfbfa30085ad1031f9e45f0a74cb2252aefe1437fe5",
+					"sha256:f0d0ef66c00b8dc9788c0c586eb2d79d03d9eaddb64610667815de7e60515e4d",
 				},
 			},
 		},
@@ -558,9 +689,9 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/terraform/no-results/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:5cafcecda4322751d7b281d9546f5789a46d82d19cc2adab614122d2ce3420b9",
+				ID:   "sha256:184660fd33a83080c2b2108a11be266b7cd72aed7a61f1fa9d5567b3218e3040",
 				BlobIDs: []string{
-					"sha256:5cafcecda4322751d7b281d9546f5789a46d82d19cc2adab614122d2ce3420b9",
+					"sha256:184660fd33a83080c2b2108a11be266b7cd72aed7a61f1fa9d5567b3218e3040",
 				},
 			},
 		},
@@ -580,11 +711,12 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 				Args: cache.ArtifactCachePutBlobArgs{
 					BlobIDAnything: true,
 					BlobInfo: types.BlobInfo{
-						SchemaVersion: 2,
+						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "terraform",
-								FilePath: ".",
+								FileType:   "terraform",
+								FilePath:   ".",
+								AnalyzedBy: &types.AnalyzedBy{Type: "terraform", Version: 1},
 								Successes: types.MisconfResults{
 									{
 										Namespace: "user.something",
@@ -614,9 +746,9 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/terraform/passed/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:e54f0c7ded39cf2cd57271293cdd4e5d11a591e6f30ce518050b358e4cd6f48d",
+				ID:   "sha256:6ea6bfefb4e728b5975b525d1eada430431af78c74f026bed016dac52eadbfc3",
 				BlobIDs: []string{
-					"sha256:e54f0c7ded39cf2cd57271293cdd4e5d11a591e6f30ce518050b358e4cd6f48d",
+					"sha256:6ea6bfefb4e728b5975b525d1eada430431af78c74f026bed016dac52eadbfc3",
 				},
 			},
 		},
@@ -636,11 +768,12 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 				Args: cache.ArtifactCachePutBlobArgs{
 					BlobIDAnything: true,
 					BlobInfo: types.BlobInfo{
-						SchemaVersion: 2,
+						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "terraform",
-								FilePath: "main.tf",
+								FileType:   "terraform",
+								FilePath:   "main.tf",
+								AnalyzedBy: &types.AnalyzedBy{Type: "terraform", Version: 1},
 								Failures: types.MisconfResults{
 									{
 										Namespace: "user.something",
@@ -696,9 +829,9 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/terraform/busted-relative-paths/src/child/main.tf",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:188f1cdbfc19f60baca159afbc34c05d48c65d3bec5826530363fe81799ee5ad",
+				ID:   "sha256:99be8fc7ddf51e00f4ba2b52b362c79e16dd72eab7caf5db36cd3d76ce40c67a",
 				BlobIDs: []string{
-					"sha256:188f1cdbfc19f60baca159afbc34c05d48c65d3bec5826530363fe81799ee5ad",
+					"sha256:99be8fc7ddf51e00f4ba2b52b362c79e16dd72eab7caf5db36cd3d76ce40c67a",
 				},
 			},
 		},
@@ -747,11 +880,12 @@ func TestCloudFormationMisconfigurationScan(t *testing.T) {
 				Args: cache.ArtifactCachePutBlobArgs{
 					BlobIDAnything: true,
 					BlobInfo: types.BlobInfo{
-						SchemaVersion: 2,
+						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "cloudformation",
-								FilePath: "main.yaml",
+								FileType:   "cloudformation",
+								FilePath:   "main.yaml",
+								AnalyzedBy: &types.AnalyzedBy{Type: "cloudformation", Version: 1},
 								Failures: types.MisconfResults{
 									{
 										Namespace: "user.something",
@@ -785,9 +919,9 @@ func TestCloudFormationMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/cloudformation/single-failure/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:b2ae3759e901c7ba8b0aa690e551e3eec01b6e450533d5444a63969ffbb97adf",
+				ID:   "sha256:b706395406038ab30ce424f9891b177f4a7e700ea602ec36d4a7a07cdb900f6e",
 				BlobIDs: []string{
-					"sha256:b2ae3759e901c7ba8b0aa690e551e3eec01b6e450533d5444a63969ffbb97adf",
+					"sha256:b706395406038ab30ce424f9891b177f4a7e700ea602ec36d4a7a07cdb900f6e",
 				},
 			},
 		},
@@ -807,11 +941,12 @@ func TestCloudFormationMisconfigurationScan(t *testing.T) {
 				Args: cache.ArtifactCachePutBlobArgs{
 					BlobIDAnything: true,
 					BlobInfo: types.BlobInfo{
-						SchemaVersion: 2,
+						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "cloudformation",
-								FilePath: "main.yaml",
+								FileType:   "cloudformation",
+								FilePath:   "main.yaml",
+								AnalyzedBy: &types.AnalyzedBy{Type: "cloudformation", Version: 1},
 								Failures: types.MisconfResults{
 									types.MisconfResult{
 										Namespace: "user.something",
@@ -867,9 +1002,9 @@ func TestCloudFormationMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/cloudformation/multiple-failures/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:410c31b72c1da31a4b2974fe4405820ffe81c65880017491ca63ec6be2cd9424",
+				ID:   "sha256:e712310c520163e3144277ec0d2cf65f842a844dcbf6794b60cc95d3a18456f0",
 				BlobIDs: []string{
-					"sha256:410c31b72c1da31a4b2974fe4405820ffe81c65880017491ca63ec6be2cd9424",
+					"sha256:e712310c520163e3144277ec0d2cf65f842a844dcbf6794b60cc95d3a18456f0",
 				},
 			},
 		},
@@ -897,9 +1032,9 @@ func TestCloudFormationMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/cloudformation/no-results/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:5cafcecda4322751d7b281d9546f5789a46d82d19cc2adab614122d2ce3420b9",
+				ID:   "sha256:184660fd33a83080c2b2108a11be266b7cd72aed7a61f1fa9d5567b3218e3040",
 				BlobIDs: []string{
-					"sha256:5cafcecda4322751d7b281d9546f5789a46d82d19cc2adab614122d2ce3420b9",
+					"sha256:184660fd33a83080c2b2108a11be266b7cd72aed7a61f1fa9d5567b3218e3040",
 				},
 			},
 		},
@@ -919,11 +1054,12 @@ func TestCloudFormationMisconfigurationScan(t *testing.T) {
 				Args: cache.ArtifactCachePutBlobArgs{
 					BlobIDAnything: true,
 					BlobInfo: types.BlobInfo{
-						SchemaVersion: 2,
+						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "cloudformation",
-								FilePath: "main.yaml",
+								FileType:   "cloudformation",
+								FilePath:   "main.yaml",
+								AnalyzedBy: &types.AnalyzedBy{Type: "cloudformation", Version: 1},
 								Successes: types.MisconfResults{
 									{
 										Namespace: "user.something",
@@ -953,9 +1089,9 @@ func TestCloudFormationMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/cloudformation/passed/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:9f9b0773ca1ec4b257aae410798a635076eaed12afefac839b62efdc65d417e1",
+				ID:   "sha256:a72dcb29c726510f4cbe4fbf382a6ac0aab1322ccc001cb85162029fbcc881b3",
 				BlobIDs: []string{
-					"sha256:9f9b0773ca1ec4b257aae410798a635076eaed12afefac839b62efdc65d417e1",
+					"sha256:a72dcb29c726510f4cbe4fbf382a6ac0aab1322ccc001cb85162029fbcc881b3",
 				},
 			},
 		},
@@ -1008,8 +1144,9 @@ func TestDockerfileMisconfigurationScan(t *testing.T) {
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "dockerfile",
-								FilePath: "Dockerfile",
+								FileType:   "dockerfile",
+								FilePath:   "Dockerfile",
+								AnalyzedBy: &types.AnalyzedBy{Type: "dockerfile", Version: 1},
 								Successes: types.MisconfResults{
 									types.MisconfResult{
 										Namespace: "user.something",
@@ -1039,9 +1176,9 @@ func TestDockerfileMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/dockerfile/single-failure/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:a22d246cba3476acf2a3d6cfe88b5a895ab78cb328b76fe070fce9f1c77f80c7",
+				ID:   "sha256:056e25e61641681fededcaf0cfdb054f565f4e61981f300a35e6bd375503d514",
 				BlobIDs: []string{
-					"sha256:a22d246cba3476acf2a3d6cfe88b5a895ab78cb328b76fe070fce9f1c77f80c7",
+					"sha256:056e25e61641681fededcaf0cfdb054f565f4e61981f300a35e6bd375503d514",
 				},
 			},
 		},
@@ -1065,8 +1202,9 @@ func TestDockerfileMisconfigurationScan(t *testing.T) {
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "dockerfile",
-								FilePath: "Dockerfile",
+								FileType:   "dockerfile",
+								FilePath:   "Dockerfile",
+								AnalyzedBy: &types.AnalyzedBy{Type: "dockerfile", Version: 1},
 								Successes: types.MisconfResults{
 									types.MisconfResult{
 										Namespace: "user.something",
@@ -1096,9 +1234,9 @@ func TestDockerfileMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/dockerfile/multiple-failures/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:a22d246cba3476acf2a3d6cfe88b5a895ab78cb328b76fe070fce9f1c77f80c7",
+				ID:   "sha256:056e25e61641681fededcaf0cfdb054f565f4e61981f300a35e6bd375503d514",
 				BlobIDs: []string{
-					"sha256:a22d246cba3476acf2a3d6cfe88b5a895ab78cb328b76fe070fce9f1c77f80c7",
+					"sha256:056e25e61641681fededcaf0cfdb054f565f4e61981f300a35e6bd375503d514",
 				},
 			},
 		},
@@ -1126,9 +1264,9 @@ func TestDockerfileMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/dockerfile/no-results/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:5cafcecda4322751d7b281d9546f5789a46d82d19cc2adab614122d2ce3420b9",
+				ID:   "sha256:184660fd33a83080c2b2108a11be266b7cd72aed7a61f1fa9d5567b3218e3040",
 				BlobIDs: []string{
-					"sha256:5cafcecda4322751d7b281d9546f5789a46d82d19cc2adab614122d2ce3420b9",
+					"sha256:184660fd33a83080c2b2108a11be266b7cd72aed7a61f1fa9d5567b3218e3040",
 				},
 			},
 		},
@@ -1152,8 +1290,9 @@ func TestDockerfileMisconfigurationScan(t *testing.T) {
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "dockerfile",
-								FilePath: "Dockerfile",
+								FileType:   "dockerfile",
+								FilePath:   "Dockerfile",
+								AnalyzedBy: &types.AnalyzedBy{Type: "dockerfile", Version: 1},
 								Successes: []types.MisconfResult{
 									{
 										Namespace: "user.something",
@@ -1185,9 +1324,9 @@ func TestDockerfileMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/dockerfile/passed/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:a9541fe2309a78505f147a688ebf8a2e107bad8351bb22e280f627d5ecf91b16",
+				ID:   "sha256:5171f53343409b1f5f0a1946d2995e1f01156419cb3ffd83da0542da85e164cd",
 				BlobIDs: []string{
-					"sha256:a9541fe2309a78505f147a688ebf8a2e107bad8351bb22e280f627d5ecf91b16",
+					"sha256:5171f53343409b1f5f0a1946d2995e1f01156419cb3ffd83da0542da85e164cd",
 				},
 			},
 		},
@@ -1240,8 +1379,9 @@ func TestKubernetesMisconfigurationScan(t *testing.T) {
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "kubernetes",
-								FilePath: "test.yaml",
+								FileType:   "kubernetes",
+								FilePath:   "test.yaml",
+								AnalyzedBy: &types.AnalyzedBy{Type: "kubernetes", Version: 1},
 								Failures: []types.MisconfResult{
 									{
 										Namespace: "user.something",
@@ -1276,9 +1416,9 @@ func TestKubernetesMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/kubernetes/single-failure/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:ee77eca0b592b90536a467b20629c017b03e627c95427a3e7f4be2a9eb55c710",
+				ID:   "sha256:762a536d97ed57dca772f4030b7f4efd1c8a605bd063fec6e51dfd104efc464f",
 				BlobIDs: []string{
-					"sha256:ee77eca0b592b90536a467b20629c017b03e627c95427a3e7f4be2a9eb55c710",
+					"sha256:762a536d97ed57dca772f4030b7f4efd1c8a605bd063fec6e51dfd104efc464f",
 				},
 			},
 		},
@@ -1302,8 +1442,9 @@ func TestKubernetesMisconfigurationScan(t *testing.T) {
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "kubernetes",
-								FilePath: "test.yaml",
+								FileType:   "kubernetes",
+								FilePath:   "test.yaml",
+								AnalyzedBy: &types.AnalyzedBy{Type: "kubernetes", Version: 1},
 								Failures: []types.MisconfResult{
 									{
 										Namespace: "user.something",
@@ -1361,9 +1502,9 @@ func TestKubernetesMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/kubernetes/multiple-failures/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:1892ab8d28210fe199dbea24de4f2073ddf5d4bf5b33aa32436d2e1f1facb588",
+				ID:   "sha256:968650e27051817d519727f88e7fec6b3ba0ed78966d1ab7d32b261c8df0b6d4",
 				BlobIDs: []string{
-					"sha256:1892ab8d28210fe199dbea24de4f2073ddf5d4bf5b33aa32436d2e1f1facb588",
+					"sha256:968650e27051817d519727f88e7fec6b3ba0ed78966d1ab7d32b261c8df0b6d4",
 				},
 			},
 		},
@@ -1391,9 +1532,9 @@ func TestKubernetesMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/kubernetes/no-results/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:70f3be8d859a21841ac42de298e9e805aa058593cf3e315e8ee0fa1f30ef5107",
+				ID:   "sha256:7ba63cecc93e4a7b76e78db8b566cc1067b166273bef0e15c4c01670d62435dd",
 				BlobIDs: []string{
-					"sha256:70f3be8d859a21841ac42de298e9e805aa058593cf3e315e8ee0fa1f30ef5107",
+					"sha256:7ba63cecc93e4a7b76e78db8b566cc1067b166273bef0e15c4c01670d62435dd",
 				},
 			},
 		},
@@ -1417,8 +1558,9 @@ func TestKubernetesMisconfigurationScan(t *testing.T) {
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "kubernetes",
-								FilePath: "test.yaml",
+								FileType:   "kubernetes",
+								FilePath:   "test.yaml",
+								AnalyzedBy: &types.AnalyzedBy{Type: "kubernetes", Version: 1},
 								Successes: []types.MisconfResult{
 									{
 										Namespace: "user.something",
@@ -1450,9 +1592,9 @@ func TestKubernetesMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/kubernetes/passed/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:f0eb13ac1479d37da2bcfc7964de5f3a4d3f06982ec27f110c97e9c8cf1cde38",
+				ID:   "sha256:4decf7a39992973481dcfa69f981d12974d141435ec65675d6fffbbc369f9adc",
 				BlobIDs: []string{
-					"sha256:f0eb13ac1479d37da2bcfc7964de5f3a4d3f06982ec27f110c97e9c8cf1cde38",
+					"sha256:4decf7a39992973481dcfa69f981d12974d141435ec65675d6fffbbc369f9adc",
 				},
 			},
 		},
@@ -1501,11 +1643,12 @@ func TestAzureARMMisconfigurationScan(t *testing.T) {
 				Args: cache.ArtifactCachePutBlobArgs{
 					BlobIDAnything: true,
 					BlobInfo: types.BlobInfo{
-						SchemaVersion: 2,
+						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "azure-arm",
-								FilePath: "deploy.json",
+								FileType:   "azure-arm",
+								FilePath:   "deploy.json",
+								AnalyzedBy: &types.AnalyzedBy{Type: "azure-arm", Version: 1},
 								Failures: types.MisconfResults{
 									{
 										Namespace: "user.something",
@@ -1539,9 +1682,9 @@ func TestAzureARMMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/azurearm/single-failure/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:ca17bc98d3b2dc8e7b001877324c51d08ba1b2a6764514a9a81adb3fb11f3c08",
+				ID:   "sha256:57cd4f5271632e4f9168c1228c1189a5de220b94ac4b04c8643b6b76390030cf",
 				BlobIDs: []string{
-					"sha256:ca17bc98d3b2dc8e7b001877324c51d08ba1b2a6764514a9a81adb3fb11f3c08",
+					"sha256:57cd4f5271632e4f9168c1228c1189a5de220b94ac4b04c8643b6b76390030cf",
 				},
 			},
 		},
@@ -1561,11 +1704,12 @@ func TestAzureARMMisconfigurationScan(t *testing.T) {
 				Args: cache.ArtifactCachePutBlobArgs{
 					BlobIDAnything: true,
 					BlobInfo: types.BlobInfo{
-						SchemaVersion: 2,
+						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "azure-arm",
-								FilePath: "deploy.json",
+								FileType:   "azure-arm",
+								FilePath:   "deploy.json",
+								AnalyzedBy: &types.AnalyzedBy{Type: "azure-arm", Version: 1},
 								Failures: types.MisconfResults{
 									{
 										Namespace: "user.something",
@@ -1621,9 +1765,9 @@ func TestAzureARMMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/azurearm/multiple-failures/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:2e29f26823faf3042ff25c32734fa96bc24ebb09a4d92d5ea3ea5c60d7204114",
+				ID:   "sha256:87fc3c6fd821745eb3196d6666235546b4598543c181467afd366e01396ae939",
 				BlobIDs: []string{
-					"sha256:2e29f26823faf3042ff25c32734fa96bc24ebb09a4d92d5ea3ea5c60d7204114",
+					"sha256:87fc3c6fd821745eb3196d6666235546b4598543c181467afd366e01396ae939",
 				},
 			},
 		},
@@ -1651,9 +1795,9 @@ func TestAzureARMMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/azurearm/no-results/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:5cafcecda4322751d7b281d9546f5789a46d82d19cc2adab614122d2ce3420b9",
+				ID:   "sha256:184660fd33a83080c2b2108a11be266b7cd72aed7a61f1fa9d5567b3218e3040",
 				BlobIDs: []string{
-					"sha256:5cafcecda4322751d7b281d9546f5789a46d82d19cc2adab614122d2ce3420b9",
+					"sha256:184660fd33a83080c2b2108a11be266b7cd72aed7a61f1fa9d5567b3218e3040",
 				},
 			},
 		},
@@ -1673,11 +1817,12 @@ func TestAzureARMMisconfigurationScan(t *testing.T) {
 				Args: cache.ArtifactCachePutBlobArgs{
 					BlobIDAnything: true,
 					BlobInfo: types.BlobInfo{
-						SchemaVersion: 2,
+						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "azure-arm",
-								FilePath: "deploy.json",
+								FileType:   "azure-arm",
+								FilePath:   "deploy.json",
+								AnalyzedBy: &types.AnalyzedBy{Type: "azure-arm", Version: 1},
 								Successes: types.MisconfResults{
 									{
 										Namespace: "user.something",
@@ -1707,9 +1852,9 @@ func TestAzureARMMisconfigurationScan(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/misconfig/azurearm/passed/src",
 				Type: types.ArtifactFilesystem,
-				ID:   "sha256:a2a781172a5d3a7c70251322696b892cf16da89c937a85f5caf7d2e8c44eede4",
+				ID:   "sha256:7ea5d6abe4cf3e7b2ef68f93d2ab972ee46da738535bd2027eea37bb2ae1e9ca",
 				BlobIDs: []string{
-					"sha256:a2a781172a5d3a7c70251322696b892cf16da89c937a85f5caf7d2e8c44eede4",
+					"sha256:7ea5d6abe4cf3e7b2ef68f93d2ab972ee46da738535bd2027eea37bb2ae1e9ca",
 				},
 			},
 		},
@@ -1758,11 +1903,12 @@ func TestMixedConfigurationScan(t *testing.T) {
 				Args: cache.ArtifactCachePutBlobArgs{
 					BlobIDAnything: true,
 					BlobInfo: types.BlobInfo{
-						SchemaVersion: 2,
+						SchemaVersion: types.BlobJSONSchemaVersion,
 						Misconfigurations: []types.Misconfiguration{
 							{
-								FileType: "terraform",
-								FilePath: "main.tf",
+								FileType:   "terraform",
+								FilePath:   "main.tf",
+								AnalyzedBy: &types.AnalyzedBy{Type: "terraform", Version: 1},
 								Failures: types.MisconfResults{
 									{
 										Namespace: "user.something",
@@ -1789,8 +1935,9 @@ func TestMixedConfigurationScan(t *testing.T) {
 								},
 							},
 							{
-								FileType: "cloudformation",
-								FilePath: "main.yaml",
+								FileType:   "cloudformation",
+								FilePath:   "main.yaml",
+								AnalyzedBy: &types.AnalyzedBy{Type: "cloudformation", Version: 1},
 								Failures: types.MisconfResults{
 									{
 										Namespace: "user.something",
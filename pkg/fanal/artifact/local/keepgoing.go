@@ -0,0 +1,47 @@
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/log"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// walkWarnings collects the errors of files which couldn't be walked in the keep-going mode.
+// They are reported as warnings instead of aborting the scan.
+type walkWarnings struct {
+	mu       sync.Mutex
+	warnings []types.Warning
+}
+
+// errorCallback is passed to the walker. Permission errors are ignored as the default callback does.
+func (w *walkWarnings) errorCallback(pathname string, err error) error {
+	if os.IsPermission(err) {
+		return nil
+	}
+	w.add(filepath.ToSlash(pathname), err)
+	return nil
+}
+
+func (w *walkWarnings) add(filePath string, err error) {
+	log.Logger.Warnf("Skipping %s: %s", filePath, err)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.warnings = append(w.warnings, types.Warning{
+		FilePath: filePath,
+		Message:  err.Error(),
+	})
+}
+
+// result returns the collected warnings and clears them for the next inspection
+func (w *walkWarnings) result() *analyzer.AnalysisResult {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	r := &analyzer.AnalysisResult{Warnings: w.warnings}
+	w.warnings = nil
+	return r
+}
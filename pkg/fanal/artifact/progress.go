@@ -0,0 +1,61 @@
+package artifact
+
+import "io"
+
+// Stage is the stage of the layer inspection
+type Stage string
+
+const (
+	StageWaiting       Stage = "waiting"
+	StageAnalyzing     Stage = "analyzing"      // Downloading and analyzing files as the layer is streamed
+	StagePostAnalyzing Stage = "post-analyzing" // Running post-analyzers after all the files are read
+	StageDone          Stage = "done"
+	StageFailed        Stage = "failed"
+)
+
+// Progress receives the progress of the artifact inspection so that it can be shown, e.g. as progress bars.
+// The implementation must be safe for concurrent use as layers are inspected in parallel.
+type Progress interface {
+	// Layer starts tracking the layer. The size is the compressed size in bytes, zero if unknown.
+	Layer(id string, size int64) LayerProgress
+
+	// Done is called when the inspection of the artifact finishes
+	Done()
+}
+
+// LayerProgress receives the progress of a layer
+type LayerProgress interface {
+	// Add adds the number of bytes read from the layer
+	Add(n int64)
+
+	// SetStage sets the current stage of the layer
+	SetStage(stage Stage)
+}
+
+// NopProgress is used when the progress is not shown
+type NopProgress struct{}
+
+func (NopProgress) Layer(string, int64) LayerProgress { return NopProgress{} }
+func (NopProgress) Done()                             {}
+func (NopProgress) Add(int64)                         {}
+func (NopProgress) SetStage(Stage)                    {}
+
+// ProgressOrNop returns NopProgress if the progress is not set
+func (o *Option) ProgressOrNop() Progress {
+	if o.Progress == nil {
+		return NopProgress{}
+	}
+	return o.Progress
+}
+
+// ProgressReader counts the bytes read as the progress of the layer
+type ProgressReader struct {
+	io.ReadCloser
+	Progress LayerProgress
+}
+
+func (r *ProgressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.Progress.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,87 @@
+package purl
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"os"
+
+	packageurl "github.com/package-url/packageurl-go"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact/local"
+	"github.com/zhanglimao/trivy/pkg/fanal/cache"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// Artifact downloads the package referenced by a package URL from its ecosystem registry
+// and analyzes the package metadata in the same way as packages installed on a filesystem.
+type Artifact struct {
+	purl  packageurl.PackageURL
+	local artifact.Artifact
+}
+
+func NewArtifact(target string, c cache.ArtifactCache, artifactOpt artifact.Option) (
+	artifact.Artifact, func(), error) {
+	cleanup := func() {}
+
+	p, err := packageurl.FromString(target)
+	if err != nil {
+		return nil, cleanup, xerrors.Errorf("purl parse error: %w", err)
+	}
+	if p.Version == "" {
+		return nil, cleanup, xerrors.Errorf("no version in %s", target)
+	}
+
+	download, ok := downloaders[p.Type]
+	if !ok {
+		return nil, cleanup, xerrors.Errorf("unsupported purl type: %s", p.Type)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "fanal-purl")
+	if err != nil {
+		return nil, cleanup, err
+	}
+	cleanup = func() {
+		_ = os.RemoveAll(tmpDir)
+	}
+
+	log.Logger.Infof("Downloading %s...", p.ToString())
+	if err = download(newHTTPClient(artifactOpt.Insecure), p, tmpDir); err != nil {
+		return nil, cleanup, xerrors.Errorf("%s download error: %w", p.Type, err)
+	}
+
+	art, err := local.NewArtifact(tmpDir, c, artifactOpt)
+	if err != nil {
+		return nil, cleanup, xerrors.Errorf("fs artifact: %w", err)
+	}
+
+	return Artifact{
+		purl:  p,
+		local: art,
+	}, cleanup, nil
+}
+
+func (a Artifact) Inspect(ctx context.Context) (types.ArtifactReference, error) {
+	ref, err := a.local.Inspect(ctx)
+	if err != nil {
+		return types.ArtifactReference{}, xerrors.Errorf("package error: %w", err)
+	}
+
+	ref.Name = a.purl.ToString()
+	ref.Type = types.ArtifactPackageURL
+
+	return ref, nil
+}
+
+func (a Artifact) Clean(reference types.ArtifactReference) error {
+	return a.local.Clean(reference)
+}
+
+func newHTTPClient(insecure bool) *http.Client {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecure}
+	return &http.Client{Transport: tr}
+}
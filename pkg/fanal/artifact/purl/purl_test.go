@@ -0,0 +1,239 @@
+package purl
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	packageurl "github.com/package-url/packageurl-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
+	"github.com/zhanglimao/trivy/pkg/fanal/cache"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/nodejs/pkg"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/python/packaging"
+)
+
+const (
+	npmPackageJSON = `{"name": "lodash", "version": "4.17.20", "license": "MIT"}`
+	pypiMetadata   = "Metadata-Version: 2.1\nName: Django\nVersion: 4.2.0\nLicense: BSD-3-Clause\n"
+)
+
+func tarGz(t *testing.T, name, content string) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(content)),
+	}))
+	_, err := tw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func wheel(t *testing.T, name, content string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(name)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func setupRegistry(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	var ts *httptest.Server
+
+	// npm
+	mux.HandleFunc("/lodash/4.17.20", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"dist": {"tarball": "` + ts.URL + `/lodash/-/lodash-4.17.20.tgz"}}`))
+	})
+	mux.HandleFunc("/lodash/-/lodash-4.17.20.tgz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(tarGz(t, "package/package.json", npmPackageJSON))
+	})
+
+	// PyPI
+	mux.HandleFunc("/django/4.2.0/json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"urls": [
+  {"url": "` + ts.URL + `/Django-4.2.0.tar.gz", "packagetype": "sdist", "filename": "Django-4.2.0.tar.gz"},
+  {"url": "` + ts.URL + `/Django-4.2.0-py3-none-any.whl", "packagetype": "bdist_wheel", "filename": "Django-4.2.0-py3-none-any.whl"}
+]}`))
+	})
+	mux.HandleFunc("/Django-4.2.0-py3-none-any.whl", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(wheel(t, "Django-4.2.0.dist-info/METADATA", pypiMetadata))
+	})
+	mux.HandleFunc("/flask/2.3.2/json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"urls": [
+  {"url": "` + ts.URL + `/Flask-2.3.2.tar.gz", "packagetype": "sdist", "filename": "Flask-2.3.2.tar.gz"}
+]}`))
+	})
+	mux.HandleFunc("/Flask-2.3.2.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(tarGz(t, "Flask-2.3.2/PKG-INFO", "Metadata-Version: 2.1\nName: Flask\nVersion: 2.3.2\n"))
+	})
+
+	// Maven
+	mux.HandleFunc("/org/example/foo/1.0.0/foo-1.0.0-tests.jar", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("jar"))
+	})
+
+	ts = httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestDownloaders(t *testing.T) {
+	ts := setupRegistry(t)
+	qualifier := "?repository_url=" + url.QueryEscape(ts.URL)
+
+	tests := []struct {
+		name      string
+		purl      string
+		wantFiles map[string]string
+		wantErr   string
+	}{
+		{
+			name: "npm",
+			purl: "pkg:npm/lodash@4.17.20" + qualifier,
+			wantFiles: map[string]string{
+				"node_modules/lodash/package.json": npmPackageJSON,
+			},
+		},
+		{
+			name: "pypi wheel",
+			purl: "pkg:pypi/django@4.2.0" + qualifier,
+			wantFiles: map[string]string{
+				"Django-4.2.0.dist-info/METADATA": pypiMetadata,
+			},
+		},
+		{
+			name: "pypi sdist",
+			purl: "pkg:pypi/flask@2.3.2" + qualifier,
+			wantFiles: map[string]string{
+				"flask.egg-info/PKG-INFO": "Metadata-Version: 2.1\nName: Flask\nVersion: 2.3.2\n",
+			},
+		},
+		{
+			name: "maven with classifier",
+			purl: "pkg:maven/org.example/foo@1.0.0?classifier=tests&repository_url=" + url.QueryEscape(ts.URL),
+			wantFiles: map[string]string{
+				"foo-1.0.0-tests.jar": "jar",
+			},
+		},
+		{
+			name:    "not found",
+			purl:    "pkg:npm/lodash@0.0.1" + qualifier,
+			wantErr: "404 Not Found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := packageurl.FromString(tt.purl)
+			require.NoError(t, err)
+
+			dir := t.TempDir()
+			err = downloaders[p.Type](http.DefaultClient, p, dir)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			got := map[string]string{}
+			err = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				b, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				rel, err := filepath.Rel(dir, path)
+				if err != nil {
+					return err
+				}
+				got[filepath.ToSlash(rel)] = string(b)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFiles, got)
+		})
+	}
+}
+
+func TestArtifact_Inspect(t *testing.T) {
+	ts := setupRegistry(t)
+
+	c, err := cache.NewFSCache(t.TempDir())
+	require.NoError(t, err)
+
+	target := "pkg:npm/lodash@4.17.20?repository_url=" + url.QueryEscape(ts.URL)
+	a, cleanup, err := NewArtifact(target, c, artifact.Option{})
+	require.NoError(t, err)
+	defer cleanup()
+
+	ref, err := a.Inspect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "pkg:npm/lodash@4.17.20?repository_url="+strings.ReplaceAll(ts.URL, "/", "%2F"), ref.Name)
+	assert.Equal(t, types.ArtifactPackageURL, ref.Type)
+
+	blobInfo, err := c.GetBlob(ref.ID)
+	require.NoError(t, err)
+	require.Len(t, blobInfo.Applications, 1)
+	assert.Equal(t, types.NodePkg, blobInfo.Applications[0].Type)
+	require.Len(t, blobInfo.Applications[0].Libraries, 1)
+
+	lib := blobInfo.Applications[0].Libraries[0]
+	assert.Equal(t, "lodash", lib.Name)
+	assert.Equal(t, "4.17.20", lib.Version)
+	assert.Equal(t, []string{"MIT"}, lib.Licenses)
+}
+
+func TestNewArtifact(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		wantErr string
+	}{
+		{
+			name:    "no version",
+			target:  "pkg:npm/lodash",
+			wantErr: "no version",
+		},
+		{
+			name:    "unsupported type",
+			target:  "pkg:cargo/rand@0.8.5",
+			wantErr: "unsupported purl type: cargo",
+		},
+		{
+			name:    "invalid purl",
+			target:  "npm/lodash@4.17.20",
+			wantErr: "purl parse error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, cleanup, err := NewArtifact(tt.target, nil, artifact.Option{})
+			defer cleanup()
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
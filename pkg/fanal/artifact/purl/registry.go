@@ -0,0 +1,251 @@
+package purl
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	packageurl "github.com/package-url/packageurl-go"
+	"golang.org/x/xerrors"
+)
+
+// downloader fetches the files required by analyzers of the package into dir
+type downloader func(client *http.Client, p packageurl.PackageURL, dir string) error
+
+var downloaders = map[string]downloader{
+	packageurl.TypeNPM:   downloadNpm,
+	packageurl.TypePyPi:  downloadPyPI,
+	packageurl.TypeMaven: downloadMaven,
+}
+
+const (
+	defaultNpmRegistry   = "https://registry.npmjs.org"
+	defaultPyPIRegistry  = "https://pypi.org/pypi"
+	defaultMavenRegistry = "https://repo.maven.apache.org/maven2"
+)
+
+// repositoryURL returns the "repository_url" qualifier of the purl if any, otherwise the default registry
+// cf. https://github.com/package-url/purl-spec/blob/master/PURL-SPECIFICATION.rst#known-qualifiers-keyvalue-pairs
+func repositoryURL(p packageurl.PackageURL, defaultURL string) string {
+	if u := p.Qualifiers.Map()["repository_url"]; u != "" {
+		if !strings.Contains(u, "://") {
+			u = "https://" + u
+		}
+		return strings.TrimSuffix(u, "/")
+	}
+	return defaultURL
+}
+
+// downloadNpm extracts package.json from the tarball as the node-pkg analyzer reads it
+// e.g. pkg:npm/lodash@4.17.20 => node_modules/lodash/package.json
+func downloadNpm(client *http.Client, p packageurl.PackageURL, dir string) error {
+	name := p.Name
+	if p.Namespace != "" {
+		name = p.Namespace + "/" + p.Name // e.g. @babel/core
+	}
+
+	var metadata struct {
+		Dist struct {
+			Tarball string `json:"tarball"`
+		} `json:"dist"`
+	}
+	if err := getJSON(client, repositoryURL(p, defaultNpmRegistry)+"/"+name+"/"+p.Version, &metadata); err != nil {
+		return xerrors.Errorf("metadata error: %w", err)
+	}
+
+	body, err := get(client, metadata.Dist.Tarball)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	dst := filepath.Join(dir, "node_modules", filepath.FromSlash(name), "package.json")
+	return extractTarGz(body, dst, func(filePath string) bool {
+		// The top directory is usually "package", but not always
+		return stripTopDir(filePath) == "package.json"
+	})
+}
+
+// downloadPyPI extracts the metadata from the wheel or the source distribution as the python-pkg analyzer reads it
+// e.g. pkg:pypi/django@4.2.0 => Django-4.2.dist-info/METADATA
+func downloadPyPI(client *http.Client, p packageurl.PackageURL, dir string) error {
+	var metadata struct {
+		URLs []struct {
+			URL         string `json:"url"`
+			PackageType string `json:"packagetype"`
+			Filename    string `json:"filename"`
+		} `json:"urls"`
+	}
+	if err := getJSON(client, repositoryURL(p, defaultPyPIRegistry)+"/"+p.Name+"/"+p.Version+"/json", &metadata); err != nil {
+		return xerrors.Errorf("metadata error: %w", err)
+	}
+
+	var sdist string
+	for _, u := range metadata.URLs {
+		switch {
+		case u.PackageType == "bdist_wheel":
+			return downloadWheel(client, u.URL, dir)
+		case u.PackageType == "sdist" && strings.HasSuffix(u.Filename, ".tar.gz"):
+			sdist = u.URL
+		}
+	}
+	if sdist == "" {
+		return xerrors.Errorf("no wheel or source distribution for %s", p.ToString())
+	}
+
+	body, err := get(client, sdist)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	dst := filepath.Join(dir, p.Name+".egg-info", "PKG-INFO")
+	return extractTarGz(body, dst, func(filePath string) bool {
+		return stripTopDir(filePath) == "PKG-INFO"
+	})
+}
+
+func downloadWheel(client *http.Client, url, dir string) error {
+	// Wheels can be large and are stored on disk as zip needs random access
+	wheel := filepath.Join(dir, path.Base(url))
+	if err := downloadFile(client, url, wheel); err != nil {
+		return err
+	}
+	defer os.Remove(wheel)
+
+	zr, err := zip.OpenReader(wheel)
+	if err != nil {
+		return xerrors.Errorf("zip open error: %w", err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		// e.g. Django-4.2.dist-info/METADATA
+		d, file := path.Split(f.Name)
+		if file != "METADATA" || !strings.HasSuffix(path.Clean(d), ".dist-info") || strings.Count(f.Name, "/") != 1 {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return xerrors.Errorf("zip file open error: %w", err)
+		}
+		defer rc.Close()
+		return writeFile(rc, filepath.Join(dir, filepath.FromSlash(f.Name)))
+	}
+	return xerrors.New("METADATA not found in the wheel")
+}
+
+// downloadMaven downloads the JAR file as the jar analyzer reads it
+// e.g. pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1 => log4j-core-2.14.1.jar
+func downloadMaven(client *http.Client, p packageurl.PackageURL, dir string) error {
+	if p.Namespace == "" {
+		return xerrors.Errorf("no group ID in %s", p.ToString())
+	}
+
+	qualifiers := p.Qualifiers.Map()
+	fileName := p.Name + "-" + p.Version
+	if classifier := qualifiers["classifier"]; classifier != "" {
+		fileName += "-" + classifier
+	}
+	ext := "jar"
+	if t := qualifiers["type"]; t != "" {
+		ext = t
+	}
+	fileName += "." + ext
+
+	url := strings.Join([]string{
+		repositoryURL(p, defaultMavenRegistry),
+		strings.ReplaceAll(p.Namespace, ".", "/"),
+		p.Name,
+		p.Version,
+		fileName,
+	}, "/")
+	return downloadFile(client, url, filepath.Join(dir, fileName))
+}
+
+func get(client *http.Client, url string) (io.ReadCloser, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, xerrors.Errorf("http error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, xerrors.Errorf("unable to get %s: %s", url, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+func getJSON(client *http.Client, url string, v any) error {
+	body, err := get(client, url)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if err = json.NewDecoder(body).Decode(v); err != nil {
+		return xerrors.Errorf("json decode error: %w", err)
+	}
+	return nil
+}
+
+func downloadFile(client *http.Client, url, dst string) error {
+	body, err := get(client, url)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	return writeFile(body, dst)
+}
+
+// extractTarGz writes the first file matching the condition in the tar.gz archive into dst
+func extractTarGz(r io.Reader, dst string, match func(filePath string) bool) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return xerrors.Errorf("gzip error: %w", err)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return xerrors.Errorf("%s not found in the archive", filepath.Base(dst))
+		} else if err != nil {
+			return xerrors.Errorf("tar error: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && match(path.Clean(hdr.Name)) {
+			return writeFile(tr, dst)
+		}
+	}
+}
+
+func writeFile(r io.Reader, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return xerrors.Errorf("mkdir error: %w", err)
+	}
+	f, err := os.Create(dst)
+	if err != nil {
+		return xerrors.Errorf("file create error: %w", err)
+	}
+	defer f.Close()
+
+	if _, err = io.Copy(f, r); err != nil {
+		return xerrors.Errorf("copy error: %w", err)
+	}
+	return nil
+}
+
+// stripTopDir removes the top directory, e.g. "package/package.json" => "package.json"
+func stripTopDir(filePath string) string {
+	_, after, _ := strings.Cut(filePath, "/")
+	return after
+}
@@ -2,11 +2,18 @@ package remote
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/url"
 	"os"
+	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"golang.org/x/xerrors"
 
@@ -35,11 +42,58 @@ func NewArtifact(rawurl string, c cache.ArtifactCache, artifactOpt artifact.Opti
 		return nil, cleanup, err
 	}
 
+	cleanup = func() {
+		_ = os.RemoveAll(tmpDir)
+	}
+
+	r, err := cloneRepo(u, tmpDir, artifactOpt)
+	if err != nil {
+		return nil, cleanup, err
+	}
+
+	// Analysis results are cached per file with git blob hashes so that they can be reused across commits.
+	artifactOpt.FileHashes, err = fileHashes(r)
+	if err != nil {
+		return nil, cleanup, xerrors.Errorf("file hash error: %w", err)
+	}
+
+	art, err := local.NewArtifact(tmpDir, c, artifactOpt)
+	if err != nil {
+		return nil, cleanup, xerrors.Errorf("fs artifact: %w", err)
+	}
+
+	return Artifact{
+		url:   rawurl,
+		local: art,
+	}, cleanup, nil
+}
+
+func (a Artifact) Inspect(ctx context.Context) (types.ArtifactReference, error) {
+	ref, err := a.local.Inspect(ctx)
+	if err != nil {
+		return types.ArtifactReference{}, xerrors.Errorf("remote repository error: %w", err)
+	}
+
+	ref.Name = a.url
+	ref.Type = types.ArtifactRemoteRepository
+
+	return ref, nil
+}
+
+func (Artifact) Clean(_ types.ArtifactReference) error {
+	return nil
+}
+
+// cloneRepo clones the repository as shallow as possible.
+// The whole history of the branch is needed only when a commit is specified.
+func cloneRepo(u *url.URL, dir string, artifactOpt artifact.Option) (*git.Repository, error) {
 	cloneOptions := git.CloneOptions{
 		URL:             u.String(),
 		Auth:            gitAuth(),
 		Progress:        os.Stdout,
 		InsecureSkipTLS: artifactOpt.Insecure,
+		Tags:            git.NoTags,
+		NoCheckout:      len(artifactOpt.RepoSparsePaths) > 0,
 	}
 
 	// suppress clone output if noProgress
@@ -61,54 +115,147 @@ func NewArtifact(rawurl string, c cache.ArtifactCache, artifactOpt artifact.Opti
 		cloneOptions.SingleBranch = true
 	}
 
-	r, err := git.PlainClone(tmpDir, false, &cloneOptions)
+	r, err := git.PlainClone(dir, false, &cloneOptions)
 	if err != nil {
-		return nil, cleanup, xerrors.Errorf("git clone error: %w", err)
+		return nil, xerrors.Errorf("git clone error: %w", err)
+	}
+
+	if len(artifactOpt.RepoSparsePaths) > 0 {
+		if err = sparseCheckout(r, dir, artifactOpt.RepoCommit, artifactOpt.RepoSparsePaths); err != nil {
+			return nil, xerrors.Errorf("git sparse checkout error: %w", err)
+		}
+		return r, nil
 	}
 
 	if artifactOpt.RepoCommit != "" {
 		w, err := r.Worktree()
 		if err != nil {
-			return nil, cleanup, xerrors.Errorf("git worktree error: %w", err)
+			return nil, xerrors.Errorf("git worktree error: %w", err)
 		}
 
 		err = w.Checkout(&git.CheckoutOptions{
 			Hash: plumbing.NewHash(artifactOpt.RepoCommit),
 		})
 		if err != nil {
-			return nil, cleanup, xerrors.Errorf("git checkout error: %w", err)
+			return nil, xerrors.Errorf("git checkout error: %w", err)
 		}
 	}
+	return r, nil
+}
 
-	cleanup = func() {
-		_ = os.RemoveAll(tmpDir)
+// sparseCheckout writes only files under the given directories into the worktree.
+// It is done by hand as the worktree of go-git doesn't skip files outside of the directories.
+func sparseCheckout(r *git.Repository, dir, commit string, paths []string) error {
+	if commit != "" {
+		// Detach HEAD to the commit
+		ref := plumbing.NewHashReference(plumbing.HEAD, plumbing.NewHash(commit))
+		if err := r.Storer.SetReference(ref); err != nil {
+			return xerrors.Errorf("unable to set HEAD: %w", err)
+		}
 	}
 
-	art, err := local.NewArtifact(tmpDir, c, artifactOpt)
+	tree, err := headTree(r)
 	if err != nil {
-		return nil, cleanup, xerrors.Errorf("fs artifact: %w", err)
+		return err
 	}
 
-	return Artifact{
-		url:   rawurl,
-		local: art,
-	}, cleanup, nil
+	dirs := make([]string, len(paths))
+	for i, p := range paths {
+		dirs[i] = strings.Trim(path.Clean("/"+filepath.ToSlash(p)), "/")
+	}
+
+	return tree.Files().ForEach(func(f *object.File) error {
+		if !inSparsePaths(f.Name, dirs) {
+			return nil
+		}
+
+		filePath := filepath.Join(dir, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+			return xerrors.Errorf("mkdir error: %w", err)
+		}
+
+		if f.Mode == filemode.Symlink {
+			target, err := f.Contents()
+			if err != nil {
+				return xerrors.Errorf("unable to read %s: %w", f.Name, err)
+			}
+			return os.Symlink(target, filePath)
+		}
+		return writeFile(f, filePath)
+	})
 }
 
-func (a Artifact) Inspect(ctx context.Context) (types.ArtifactReference, error) {
-	ref, err := a.local.Inspect(ctx)
+func writeFile(f *object.File, filePath string) error {
+	mode := os.FileMode(0644)
+	if f.Mode == filemode.Executable {
+		mode = 0755
+	}
+
+	src, err := f.Reader()
 	if err != nil {
-		return types.ArtifactReference{}, xerrors.Errorf("remote repository error: %w", err)
+		return xerrors.Errorf("unable to read %s: %w", f.Name, err)
 	}
+	defer src.Close()
 
-	ref.Name = a.url
-	ref.Type = types.ArtifactRemoteRepository
+	dst, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return xerrors.Errorf("unable to create %s: %w", filePath, err)
+	}
+	defer dst.Close()
 
-	return ref, nil
+	if _, err = io.Copy(dst, src); err != nil {
+		return xerrors.Errorf("copy error: %w", err)
+	}
+	return nil
 }
 
-func (Artifact) Clean(_ types.ArtifactReference) error {
-	return nil
+func inSparsePaths(name string, paths []string) bool {
+	for _, p := range paths {
+		if p == "" || name == p || strings.HasPrefix(name, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func headTree(r *git.Repository) (*object.Tree, error) {
+	head, err := r.Head()
+	if err != nil {
+		return nil, xerrors.Errorf("git head error: %w", err)
+	}
+	commit, err := r.CommitObject(head.Hash())
+	if err != nil {
+		return nil, xerrors.Errorf("git commit error: %w", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, xerrors.Errorf("git tree error: %w", err)
+	}
+	return tree, nil
+}
+
+// fileHashes returns the blob hashes of files in HEAD
+func fileHashes(r *git.Repository) (map[string]string, error) {
+	tree, err := headTree(r)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make(map[string]string)
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, xerrors.Errorf("tree walk error: %w", err)
+		}
+		if entry.Mode.IsFile() {
+			hashes[name] = entry.Hash.String()
+		}
+	}
+	return hashes, nil
 }
 
 func newURL(rawurl string) (*url.URL, error) {
@@ -5,6 +5,7 @@ package remote
 import (
 	"context"
 	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/sosedoff/gitkit"
@@ -184,9 +185,9 @@ func TestArtifact_Inspect(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: ts.URL + "/test.git",
 				Type: types.ArtifactRemoteRepository,
-				ID:   "sha256:1fa928c33b16a335015ce96e1384127f8463c4f27ed0786806a6d4584b63d091",
+				ID:   "sha256:3f58d76f49b54dc4ee97cc6a8d21c42677a9602f45f3aaac095c1ff0f4d44a20",
 				BlobIDs: []string{
-					"sha256:1fa928c33b16a335015ce96e1384127f8463c4f27ed0786806a6d4584b63d091",
+					"sha256:3f58d76f49b54dc4ee97cc6a8d21c42677a9602f45f3aaac095c1ff0f4d44a20",
 				},
 			},
 		},
@@ -208,6 +209,75 @@ func TestArtifact_Inspect(t *testing.T) {
 	}
 }
 
+func Test_cloneRepo(t *testing.T) {
+	ts, err := setupGitServer()
+	require.NoError(t, err)
+	defer ts.Close()
+
+	tests := []struct {
+		name       string
+		opt        artifact.Option
+		wantFiles  []string
+		wantHashes map[string]string
+	}{
+		{
+			name:      "happy path",
+			wantFiles: []string{"anothertest.txt", "test.txt"},
+			wantHashes: map[string]string{
+				"anothertest.txt": "f4836be6497e83e13dc0cfbce7e6b973b1ea511d",
+				"test.txt":        "c042cd14d2b999cade090785af47e9f8b8e342ff",
+			},
+		},
+		{
+			name: "sparse paths",
+			opt: artifact.Option{
+				RepoSparsePaths: []string{"docs"},
+			},
+			wantHashes: map[string]string{
+				"anothertest.txt": "f4836be6497e83e13dc0cfbce7e6b973b1ea511d",
+				"test.txt":        "c042cd14d2b999cade090785af47e9f8b8e342ff",
+			},
+		},
+		{
+			name: "sparse root",
+			opt: artifact.Option{
+				RepoSparsePaths: []string{"/"},
+			},
+			wantFiles: []string{"anothertest.txt", "test.txt"},
+			wantHashes: map[string]string{
+				"anothertest.txt": "f4836be6497e83e13dc0cfbce7e6b973b1ea511d",
+				"test.txt":        "c042cd14d2b999cade090785af47e9f8b8e342ff",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := newURL(ts.URL + "/test.git")
+			require.NoError(t, err)
+
+			tt.opt.NoProgress = true
+			dir := t.TempDir()
+			r, err := cloneRepo(u, dir, tt.opt)
+			require.NoError(t, err)
+
+			var files []string
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			for _, e := range entries {
+				if e.Name() != ".git" {
+					files = append(files, e.Name())
+				}
+			}
+			assert.Equal(t, tt.wantFiles, files)
+
+			hashes, err := fileHashes(r)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHashes, hashes)
+		})
+	}
+}
+
 func Test_newURL(t *testing.T) {
 	type args struct {
 		rawurl string
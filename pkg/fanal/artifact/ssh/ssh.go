@@ -0,0 +1,340 @@
+package ssh
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/sftp"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact/filestore"
+	"github.com/zhanglimao/trivy/pkg/fanal/cache"
+	"github.com/zhanglimao/trivy/pkg/fanal/handler"
+	"github.com/zhanglimao/trivy/pkg/fanal/sshutil"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/fanal/walker"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/mapfs"
+	"github.com/zhanglimao/trivy/pkg/syncx"
+)
+
+// Scheme is the URL scheme of remote filesystems, e.g. ssh://user@host/path
+const Scheme = "ssh"
+
+// Artifact walks a file tree on a remote host over SFTP so that hosts can be scanned without installing Trivy.
+type Artifact struct {
+	host           string
+	rootPath       string
+	client         *sftp.Client
+	cache          cache.ArtifactCache
+	walker         walker.SFTP
+	analyzer       analyzer.AnalyzerGroup
+	handlerManager handler.Manager
+
+	artifactOption artifact.Option
+}
+
+func NewArtifact(target string, c cache.ArtifactCache, opt artifact.Option) (artifact.Artifact, func(), error) {
+	cleanup := func() {}
+
+	u, err := ParseURL(target)
+	if err != nil {
+		return nil, cleanup, err
+	}
+
+	sshClient, err := sshutil.Dial(u, opt.SSHKey)
+	if err != nil {
+		return nil, cleanup, xerrors.Errorf("unable to connect to %s: %w", u.Host, err)
+	}
+
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		_ = sshClient.Close()
+		return nil, cleanup, xerrors.Errorf("sftp client error: %w", err)
+	}
+
+	cleanup = func() {
+		_ = client.Close()
+		_ = sshClient.Close()
+	}
+
+	art, err := newArtifact(u.Hostname(), u.Path, client, c, opt)
+	if err != nil {
+		cleanup()
+		return nil, func() {}, err
+	}
+	return art, cleanup, nil
+}
+
+func newArtifact(host, rootPath string, client *sftp.Client, c cache.ArtifactCache, opt artifact.Option) (artifact.Artifact, error) {
+	handlerManager, err := handler.NewManager(opt)
+	if err != nil {
+		return nil, xerrors.Errorf("handler initialize error: %w", err)
+	}
+
+	a, err := analyzer.NewAnalyzerGroup(analyzer.AnalyzerOptions{
+		Group:                opt.AnalyzerGroup,
+		Slow:                 opt.Slow,
+		FilePatterns:         opt.FilePatterns,
+		DisabledAnalyzers:    opt.DisabledAnalyzers,
+		MisconfScannerOption: opt.MisconfScannerOption,
+		SecretScannerOption:  opt.SecretScannerOption,
+		LicenseScannerOption: opt.LicenseScannerOption,
+		CustomResourceOption: opt.CustomResourceOption,
+		PermissionOption:     opt.PermissionOption,
+		Timeout:              opt.AnalyzerTimeout,
+		KeepGoing:            opt.KeepGoing,
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("analyzer group error: %w", err)
+	}
+
+	rootPath = path.Clean(rootPath)
+	filter := opt.FileFilter()
+	filter.IncludeFiles = buildIncludePaths(rootPath, opt.IncludeFiles)
+
+	return Artifact{
+		host:     host,
+		rootPath: rootPath,
+		client:   client,
+		cache:    c,
+		walker: walker.NewSFTP(client, buildPathsToSkip(rootPath, opt.SkipFiles),
+			buildPathsToSkip(rootPath, opt.SkipDirs), filter, opt.WalkOption.ErrorCallback),
+		analyzer:       a,
+		handlerManager: handlerManager,
+
+		artifactOption: opt,
+	}, nil
+}
+
+// ParseURL parses the remote target, e.g. ssh://user@host:2222/path
+func ParseURL(target string) (*url.URL, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, xerrors.Errorf("url parse error: %w", err)
+	}
+	if u.Scheme != Scheme {
+		return nil, xerrors.Errorf("unsupported scheme %q: must be %s", u.Scheme, Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, xerrors.Errorf("no host in %s", u.Redacted())
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u, nil
+}
+
+// buildIncludePaths converts include patterns in the same way as paths to skip, keeping the "!" prefix of exclusions.
+func buildIncludePaths(root string, patterns []string) []string {
+	var paths []string
+	for _, pattern := range patterns {
+		var prefix string
+		if strings.HasPrefix(pattern, "!") {
+			prefix, pattern = "!", strings.TrimPrefix(pattern, "!")
+		}
+		for _, p := range buildPathsToSkip(root, []string{pattern}) {
+			paths = append(paths, prefix+p)
+		}
+	}
+	return paths
+}
+
+// buildPathsToSkip converts absolute remote paths into paths relative to the root directory.
+// Relative paths and patterns are used as is.
+func buildPathsToSkip(root string, paths []string) []string {
+	prefix := strings.TrimSuffix(root, "/") + "/"
+
+	var relativePaths []string
+	for _, p := range paths {
+		p = filepath.ToSlash(p)
+		if !path.IsAbs(p) {
+			relativePaths = append(relativePaths, p)
+			continue
+		}
+
+		rel, ok := strings.CutPrefix(path.Clean(p), prefix)
+		if !ok {
+			log.Logger.Debugf("Skipping %s as it is outside of %s", p, root)
+			continue
+		}
+		relativePaths = append(relativePaths, rel)
+	}
+	return relativePaths
+}
+
+func (a Artifact) Inspect(ctx context.Context) (types.ArtifactReference, error) {
+	var wg sync.WaitGroup
+	result := analyzer.NewAnalysisResult()
+	if err := result.EnableSpill(a.artifactOption.MaxMemory); err != nil {
+		return types.ArtifactReference{}, xerrors.Errorf("analysis result error: %w", err)
+	}
+	defer result.Close()
+	scheduler := analyzer.NewScheduler(a.artifactOption.SchedulerOption())
+	defer scheduler.Close()
+	opts := analyzer.AnalysisOptions{
+		Offline:      a.artifactOption.Offline,
+		FileChecksum: a.artifactOption.FileChecksum,
+	}
+
+	// Post-analyzers need fs.FS, so the remote files they require are downloaded to a temporary directory.
+	tmpDir, err := os.MkdirTemp("", "ssh-*")
+	if err != nil {
+		return types.ArtifactReference{}, xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	store := filestore.New(tmpDir)
+
+	// Prepare filesystem for post analysis
+	files := new(syncx.Map[analyzer.Type, *mapfs.FS])
+
+	err = a.walker.Walk(a.rootPath, func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
+		dir := a.rootPath
+
+		// When the directory is the same as the filePath, a file was given
+		// instead of a directory, rewrite the file path and directory in this case.
+		if filePath == "." {
+			dir, filePath = path.Split(a.rootPath)
+		}
+
+		if err := a.analyzer.AnalyzeFile(ctx, &wg, scheduler, result, dir, filePath, info, opener, nil, opts); err != nil {
+			return xerrors.Errorf("analyze file (%s): %w", filePath, err)
+		}
+
+		// Build filesystem for post analysis
+		if err := a.buildFS(store, filePath, info, opener, files); err != nil {
+			return xerrors.Errorf("failed to build filesystem: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return types.ArtifactReference{}, xerrors.Errorf("walk remote filesystem: %w", err)
+	}
+
+	// Wait for all the goroutine to finish.
+	wg.Wait()
+
+	// Post-analysis
+	if err = a.analyzer.PostAnalyze(ctx, files, result, opts); err != nil {
+		return types.ArtifactReference{}, xerrors.Errorf("post analysis error: %w", err)
+	}
+
+	// Load the results spilled to disk, including those of post-analyzers
+	if err = result.Restore(); err != nil {
+		return types.ArtifactReference{}, xerrors.Errorf("restore error: %w", err)
+	}
+
+	// Sort the analysis result for consistent results
+	result.Sort()
+
+	blobInfo := types.BlobInfo{
+		SchemaVersion:     types.BlobJSONSchemaVersion,
+		OS:                result.OS,
+		Repository:        result.Repository,
+		PackageInfos:      result.PackageInfos,
+		Applications:      result.Applications,
+		Misconfigurations: result.Misconfigurations,
+		Secrets:           result.Secrets,
+		Licenses:          result.Licenses,
+		CustomResources:   result.CustomResources,
+		Warnings:          result.Warnings,
+		Digests:           result.Digests,
+	}
+
+	if err = a.handlerManager.PostHandle(ctx, result, &blobInfo); err != nil {
+		return types.ArtifactReference{}, xerrors.Errorf("failed to call hooks: %w", err)
+	}
+
+	cacheKey, err := a.calcCacheKey(blobInfo)
+	if err != nil {
+		return types.ArtifactReference{}, xerrors.Errorf("failed to calculate a cache key: %w", err)
+	}
+
+	if err = a.cache.PutBlob(cacheKey, blobInfo); err != nil {
+		return types.ArtifactReference{}, xerrors.Errorf("failed to store blob (%s) in cache: %w", cacheKey, err)
+	}
+
+	return types.ArtifactReference{
+		Name:    a.host + ":" + a.rootPath, // e.g. web01:/srv/app
+		Type:    types.ArtifactFilesystem,
+		ID:      cacheKey, // use a cache key as pseudo artifact ID
+		BlobIDs: []string{cacheKey},
+	}, nil
+}
+
+func (a Artifact) Clean(reference types.ArtifactReference) error {
+	return a.cache.DeleteBlobs(reference.BlobIDs)
+}
+
+func (a Artifact) calcCacheKey(blobInfo types.BlobInfo) (string, error) {
+	// calculate hash of JSON and use it as pseudo artifactID and blobID
+	h := sha256.New()
+	if err := json.NewEncoder(h).Encode(blobInfo); err != nil {
+		return "", xerrors.Errorf("json error: %w", err)
+	}
+
+	d := digest.NewDigest(digest.SHA256, h)
+	cacheKey, err := cache.CalcKey(d.String(), a.analyzer.AnalyzerVersions(), a.handlerManager.Versions(), a.artifactOption)
+	if err != nil {
+		return "", xerrors.Errorf("cache key: %w", err)
+	}
+
+	return cacheKey, nil
+}
+
+// buildFS downloads the file required by post-analyzers and creates filesystem for post analysis
+func (a Artifact) buildFS(store *filestore.Store, filePath string, info os.FileInfo, opener analyzer.Opener,
+	files *syncx.Map[analyzer.Type, *mapfs.FS]) error {
+	// Get all post-analyzers that want to analyze the file
+	atypes := a.analyzer.RequiredPostAnalyzers(filePath, info)
+	if len(atypes) == 0 {
+		return nil
+	}
+
+	localPath, err := download(store, opener, info)
+	if err != nil {
+		return xerrors.Errorf("download error (%s): %w", filePath, err)
+	}
+
+	// Create fs.FS for each post-analyzer that wants to analyze the current file
+	for _, at := range atypes {
+		mfs, _ := files.LoadOrStore(at, mapfs.New())
+		if d := path.Dir(filePath); d != "." {
+			if err := mfs.MkdirAll(d, os.ModePerm); err != nil && !errors.Is(err, fs.ErrExist) {
+				return xerrors.Errorf("mapfs mkdir error: %w", err)
+			}
+		}
+		if err := mfs.WriteFile(filePath, localPath); err != nil {
+			return xerrors.Errorf("mapfs write error: %w", err)
+		}
+	}
+	return nil
+}
+
+// download copies the remote file into the store, where files with the same content are stored once
+func download(store *filestore.Store, opener analyzer.Opener, info os.FileInfo) (string, error) {
+	src, err := opener()
+	if err != nil {
+		return "", xerrors.Errorf("open error: %w", err)
+	}
+	defer src.Close()
+
+	localPath, err := store.Put(src, info.Mode())
+	if err != nil {
+		return "", xerrors.Errorf("copy error: %w", err)
+	}
+	return localPath, nil
+}
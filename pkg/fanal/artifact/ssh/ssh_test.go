@@ -0,0 +1,200 @@
+package ssh
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
+	"github.com/zhanglimao/trivy/pkg/fanal/cache"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/language/php/composer"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/os/alpine"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/pkg/apk"
+)
+
+// newSFTPClient returns a client connected to an in-process SFTP server serving the local filesystem
+func newSFTPClient(t *testing.T) *sftp.Client {
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+
+	server, err := sftp.NewServer(struct {
+		io.Reader
+		io.WriteCloser
+	}{serverReader, serverWriter})
+	require.NoError(t, err)
+	go server.Serve()
+
+	client, err := sftp.NewClientPipe(clientReader, clientWriter)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = server.Close()
+		_ = client.Close()
+	})
+	return client
+}
+
+func TestArtifact_Inspect(t *testing.T) {
+	root, err := filepath.Abs("testdata/host")
+	require.NoError(t, err)
+	root = filepath.ToSlash(root)
+
+	tests := []struct {
+		name         string
+		rootPath     string
+		artifactOpt  artifact.Option
+		wantName     string
+		wantOS       types.OS
+		wantPackages []string
+		wantApps     []string
+	}{
+		{
+			name:         "happy path",
+			rootPath:     root,
+			wantName:     "web01:" + root,
+			wantOS:       types.OS{Family: "alpine", Name: "3.11.6"},
+			wantPackages: []string{"musl"},
+			wantApps:     []string{"pear/log", "pear/pear_exception"},
+		},
+		{
+			name:     "skip dirs",
+			rootPath: root,
+			artifactOpt: artifact.Option{
+				SkipDirs: []string{root + "/srv"},
+			},
+			wantName:     "web01:" + root,
+			wantOS:       types.OS{Family: "alpine", Name: "3.11.6"},
+			wantPackages: []string{"musl"},
+		},
+		{
+			name:     "sub directory",
+			rootPath: root + "/srv/app/",
+			wantName: "web01:" + root + "/srv/app",
+			wantApps: []string{"pear/log", "pear/pear_exception"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := cache.NewFSCache(t.TempDir())
+			require.NoError(t, err)
+
+			a, err := newArtifact("web01", tt.rootPath, newSFTPClient(t), c, tt.artifactOpt)
+			require.NoError(t, err)
+
+			got, err := a.Inspect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, got.Name)
+			assert.Equal(t, types.ArtifactFilesystem, got.Type)
+
+			blob, err := c.GetBlob(got.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOS, blob.OS)
+
+			var pkgs []string
+			for _, pkgInfo := range blob.PackageInfos {
+				for _, pkg := range pkgInfo.Packages {
+					pkgs = append(pkgs, pkg.Name)
+				}
+			}
+			assert.Equal(t, tt.wantPackages, pkgs)
+
+			var apps []string
+			for _, app := range blob.Applications {
+				for _, pkg := range app.Libraries {
+					apps = append(apps, pkg.Name)
+				}
+			}
+			assert.Equal(t, tt.wantApps, apps)
+		})
+	}
+}
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		wantHost string
+		wantPath string
+		wantErr  string
+	}{
+		{
+			name:     "happy path",
+			target:   "ssh://root@web01:2222/srv/app",
+			wantHost: "web01:2222",
+			wantPath: "/srv/app",
+		},
+		{
+			name:     "no path",
+			target:   "ssh://web01",
+			wantHost: "web01",
+			wantPath: "/",
+		},
+		{
+			name:    "no host",
+			target:  "ssh:///srv/app",
+			wantErr: "no host",
+		},
+		{
+			name:    "wrong scheme",
+			target:  "sftp://web01/srv/app",
+			wantErr: "unsupported scheme",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseURL(tt.target)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHost, got.Host)
+			assert.Equal(t, tt.wantPath, got.Path)
+		})
+	}
+}
+
+func Test_buildPathsToSkip(t *testing.T) {
+	tests := []struct {
+		name  string
+		root  string
+		paths []string
+		want  []string
+	}{
+		{
+			name:  "absolute paths",
+			root:  "/srv/app",
+			paths: []string{"/srv/app/vendor", "/srv/app/node_modules/"},
+			want:  []string{"vendor", "node_modules"},
+		},
+		{
+			name:  "relative paths and patterns",
+			root:  "/srv/app",
+			paths: []string{"vendor", "**/*.log"},
+			want:  []string{"vendor", "**/*.log"},
+		},
+		{
+			name:  "outside of root",
+			root:  "/srv/app",
+			paths: []string{"/var/log"},
+		},
+		{
+			name:  "root directory",
+			root:  "/",
+			paths: []string{"/proc", "/var/lib/docker"},
+			want:  []string{"proc", "var/lib/docker"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildPathsToSkip(tt.root, tt.paths)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -15,7 +15,6 @@ import (
 	"github.com/zhanglimao/trivy/pkg/fanal/handler"
 	"github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/fanal/walker"
-	"github.com/zhanglimao/trivy/pkg/semaphore"
 )
 
 type Type string
@@ -41,14 +40,19 @@ type Storage struct {
 
 func (a *Storage) Analyze(ctx context.Context, r *io.SectionReader) (types.BlobInfo, error) {
 	var wg sync.WaitGroup
-	limit := semaphore.New(a.artifactOption.Slow)
+	scheduler := analyzer.NewScheduler(a.artifactOption.SchedulerOption())
+	defer scheduler.Close()
 	result := analyzer.NewAnalysisResult()
+	if err := result.EnableSpill(a.artifactOption.MaxMemory); err != nil {
+		return types.BlobInfo{}, xerrors.Errorf("analysis result error: %w", err)
+	}
+	defer result.Close()
 
 	// TODO: Always walk from the root directory. Consider whether there is a need to be able to set optional
 	err := a.walker.Walk(r, "/", func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
 		opts := analyzer.AnalysisOptions{Offline: a.artifactOption.Offline}
 		path := strings.TrimPrefix(filePath, "/")
-		if err := a.analyzer.AnalyzeFile(ctx, &wg, limit, result, "/", path, info, opener, nil, opts); err != nil {
+		if err := a.analyzer.AnalyzeFile(ctx, &wg, scheduler, result, "/", path, info, opener, nil, opts); err != nil {
 			return xerrors.Errorf("analyze file (%s): %w", path, err)
 		}
 		return nil
@@ -61,6 +65,11 @@ func (a *Storage) Analyze(ctx context.Context, r *io.SectionReader) (types.BlobI
 		return types.BlobInfo{}, xerrors.Errorf("walk vm error: %w", err)
 	}
 
+	// Load the results spilled to disk
+	if err = result.Restore(); err != nil {
+		return types.BlobInfo{}, xerrors.Errorf("restore error: %w", err)
+	}
+
 	result.Sort()
 
 	blobInfo := types.BlobInfo{
@@ -72,6 +81,7 @@ func (a *Storage) Analyze(ctx context.Context, r *io.SectionReader) (types.BlobI
 		Secrets:         result.Secrets,
 		Licenses:        result.Licenses,
 		CustomResources: result.CustomResources,
+		Warnings:        result.Warnings,
 	}
 
 	if err = a.handlerManager.PostHandle(ctx, result, &blobInfo); err != nil {
@@ -93,6 +103,10 @@ func NewArtifact(target string, c cache.ArtifactCache, opt artifact.Option) (art
 		MisconfScannerOption: opt.MisconfScannerOption,
 		SecretScannerOption:  opt.SecretScannerOption,
 		LicenseScannerOption: opt.LicenseScannerOption,
+		CustomResourceOption: opt.CustomResourceOption,
+		PermissionOption:     opt.PermissionOption,
+		Timeout:              opt.AnalyzerTimeout,
+		KeepGoing:            opt.KeepGoing,
 	})
 	if err != nil {
 		return nil, xerrors.Errorf("analyzer group error: %w", err)
@@ -102,7 +116,7 @@ func NewArtifact(target string, c cache.ArtifactCache, opt artifact.Option) (art
 		cache:          c,
 		analyzer:       a,
 		handlerManager: handlerManager,
-		walker:         walker.NewVM(opt.SkipFiles, opt.SkipDirs, opt.Slow),
+		walker:         walker.NewVM(opt.SkipFiles, opt.SkipDirs, opt.Slow, opt.FileFilter()),
 		artifactOption: opt,
 	}
 
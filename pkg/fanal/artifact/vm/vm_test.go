@@ -86,7 +86,7 @@ func TestArtifact_Inspect(t *testing.T) {
 			filePath: "testdata/AmazonLinux2.img.gz",
 			putBlobExpectation: cache.ArtifactCachePutBlobExpectation{
 				Args: cache.ArtifactCachePutBlobArgs{
-					BlobID: "sha256:b8d4a043e24d47c367a2be5bc9749cded56d858a19ad9b19043d99a151cc0050",
+					BlobID: "sha256:3c9e35cfdcd08bd735d7b3094409a7420723687202ce28eed90922cf6a78908e",
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: types.BlobJSONSchemaVersion,
 						OS: types.OS{
@@ -95,8 +95,9 @@ func TestArtifact_Inspect(t *testing.T) {
 						},
 						PackageInfos: []types.PackageInfo{
 							{
-								FilePath: "var/lib/rpm/Packages",
-								Packages: expectPackages,
+								FilePath:   "var/lib/rpm/Packages",
+								AnalyzedBy: &types.AnalyzedBy{Type: "rpm", Version: 3},
+								Packages:   expectPackages,
 							},
 						},
 					},
@@ -106,7 +107,7 @@ func TestArtifact_Inspect(t *testing.T) {
 			putArtifactExpectations: []cache.ArtifactCachePutArtifactExpectation{
 				{
 					Args: cache.ArtifactCachePutArtifactArgs{
-						ArtifactID: "sha256:b8d4a043e24d47c367a2be5bc9749cded56d858a19ad9b19043d99a151cc0050",
+						ArtifactID: "sha256:3c9e35cfdcd08bd735d7b3094409a7420723687202ce28eed90922cf6a78908e",
 						ArtifactInfo: types.ArtifactInfo{
 							SchemaVersion: types.ArtifactJSONSchemaVersion,
 						},
@@ -117,9 +118,9 @@ func TestArtifact_Inspect(t *testing.T) {
 			want: types.ArtifactReference{
 				Name: "testdata/AmazonLinux2.img.gz",
 				Type: types.ArtifactVM,
-				ID:   "sha256:b8d4a043e24d47c367a2be5bc9749cded56d858a19ad9b19043d99a151cc0050",
+				ID:   "sha256:3c9e35cfdcd08bd735d7b3094409a7420723687202ce28eed90922cf6a78908e",
 				BlobIDs: []string{
-					"sha256:b8d4a043e24d47c367a2be5bc9749cded56d858a19ad9b19043d99a151cc0050",
+					"sha256:3c9e35cfdcd08bd735d7b3094409a7420723687202ce28eed90922cf6a78908e",
 				},
 			},
 		},
@@ -143,8 +144,9 @@ func TestArtifact_Inspect(t *testing.T) {
 						},
 						PackageInfos: []types.PackageInfo{
 							{
-								FilePath: "var/lib/rpm/Packages",
-								Packages: expectPackages,
+								FilePath:   "var/lib/rpm/Packages",
+								AnalyzedBy: &types.AnalyzedBy{Type: "rpm", Version: 3},
+								Packages:   expectPackages,
 							},
 						},
 					},
@@ -167,7 +167,8 @@ func (fs FSCache) MissingBlobs(artifactID string, blobIDs []string) (bool, []str
 				missingBlobIDs = append(missingBlobIDs, blobID)
 				continue
 			}
-			if blobInfo.SchemaVersion != types.BlobJSONSchemaVersion {
+			// Incomplete results, e.g. with timed-out analyzers, are analyzed again
+			if blobInfo.SchemaVersion != types.BlobJSONSchemaVersion || len(blobInfo.Warnings) > 0 {
 				missingBlobIDs = append(missingBlobIDs, blobID)
 			}
 		}
@@ -76,7 +76,7 @@ func TestFSCache_GetBlob(t *testing.T) {
 				layerID: "sha256:24df0d4e20c0f42d3703bf1f1db2bdd77346c7956f74f423603d651e8e5ae8a7/11101",
 			},
 			want: types.BlobInfo{
-				SchemaVersion: 2,
+				SchemaVersion: 3,
 				OS: types.OS{
 					Family: "alpine",
 					Name:   "3.10",
@@ -389,6 +389,7 @@ func TestFSCache_MissingBlobs(t *testing.T) {
 	tests := []struct {
 		name                string
 		dbPath              string
+		blobs               map[string]types.BlobInfo
 		args                args
 		wantMissingImage    bool
 		wantMissingLayerIDs []string
@@ -467,6 +468,33 @@ func TestFSCache_MissingBlobs(t *testing.T) {
 				"sha256:24df0d4e20c0f42d3703bf1f1db2bdd77346c7956f74f423603d651e8e5ae8a7/11102",
 			},
 		},
+		{
+			name:   "missing blobs with warnings",
+			dbPath: "testdata/fanal.db",
+			blobs: map[string]types.BlobInfo{
+				"sha256:5a3f8d6e7ffd9de62d4e7e2a32bb8d4bd6c5d9a0ad1e3e2d7f7ec3c2a3e1b6f0/11101": {
+					SchemaVersion: types.BlobJSONSchemaVersion,
+					Warnings: []types.Warning{
+						{
+							Analyzer: "jar",
+							FilePath: "app/huge.jar",
+							Message:  "analysis timed out after 30s",
+						},
+					},
+				},
+			},
+			args: args{
+				imageID: "sha256:58701fd185bda36cab0557bb6438661831267aa4a9e0b54211c4d5317a48aff4/1",
+				layerIDs: []string{
+					"sha256:24df0d4e20c0f42d3703bf1f1db2bdd77346c7956f74f423603d651e8e5ae8a7/11101",
+					"sha256:5a3f8d6e7ffd9de62d4e7e2a32bb8d4bd6c5d9a0ad1e3e2d7f7ec3c2a3e1b6f0/11101",
+				},
+			},
+			wantMissingImage: false,
+			wantMissingLayerIDs: []string{
+				"sha256:5a3f8d6e7ffd9de62d4e7e2a32bb8d4bd6c5d9a0ad1e3e2d7f7ec3c2a3e1b6f0/11101",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -480,6 +508,10 @@ func TestFSCache_MissingBlobs(t *testing.T) {
 				_ = fs.Close()
 			}()
 
+			for blobID, blobInfo := range tt.blobs {
+				require.NoError(t, fs.PutBlob(blobID, blobInfo))
+			}
+
 			gotMissingImage, gotMissingLayerIDs, err := fs.MissingBlobs(tt.args.imageID, tt.args.layerIDs)
 			if tt.wantErr != "" {
 				assert.ErrorContains(t, err, tt.wantErr, tt.name)
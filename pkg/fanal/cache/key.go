@@ -4,12 +4,14 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"os"
 
 	"golang.org/x/mod/sumdb/dirhash"
 	"golang.org/x/xerrors"
 
 	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
 	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
+	"github.com/zhanglimao/trivy/pkg/fanal/walker"
 )
 
 func CalcKey(id string, analyzerVersions analyzer.Versions, hookVersions map[string]int, artifactOpt artifact.Option) (string, error) {
@@ -19,15 +21,25 @@ func CalcKey(id string, analyzerVersions analyzer.Versions, hookVersions map[str
 
 	h := sha256.New()
 
-	// Write ID, analyzer/handler versions, skipped files/dirs and file patterns
+	// Symbolic links change the files to be analyzed only when they are followed
+	var symlinks *walker.SymlinkOption
+	if opt := artifactOpt.SymlinkOption; opt.Follow() {
+		symlinks = &opt
+	}
+
+	// Write ID, analyzer/handler versions, skipped files/dirs, file patterns and walker options
 	keyBase := struct {
 		ID               string
 		AnalyzerVersions analyzer.Versions
 		HookVersions     map[string]int
 		SkipFiles        []string
 		SkipDirs         []string
-		FilePatterns     []string `json:",omitempty"`
-	}{id, analyzerVersions, hookVersions, artifactOpt.SkipFiles, artifactOpt.SkipDirs, artifactOpt.FilePatterns}
+		FilePatterns     []string              `json:",omitempty"`
+		Symlinks         *walker.SymlinkOption `json:",omitempty"`
+		IncludeFiles     []string              `json:",omitempty"`
+		MaxFileSize      int64                 `json:",omitempty"`
+	}{id, analyzerVersions, hookVersions, artifactOpt.SkipFiles, artifactOpt.SkipDirs, artifactOpt.FilePatterns, symlinks,
+		artifactOpt.IncludeFiles, artifactOpt.MaxFileSize}
 
 	if err := json.NewEncoder(h).Encode(keyBase); err != nil {
 		return "", xerrors.Errorf("json encode error: %w", err)
@@ -47,6 +59,28 @@ func CalcKey(id string, analyzerVersions analyzer.Versions, hookVersions map[str
 		}
 	}
 
+	// Write the config of custom resource collectors
+	if p := artifactOpt.CustomResourceOption.ConfigPath; p != "" {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return "", xerrors.Errorf("custom resource config read error: %w", err)
+		}
+		if _, err = h.Write(b); err != nil {
+			return "", xerrors.Errorf("sha256 write error: %w", err)
+		}
+	}
+
+	// Write the allowlist of files with privileges
+	if p := artifactOpt.PermissionOption.AllowlistPath; p != "" {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return "", xerrors.Errorf("permission allowlist read error: %w", err)
+		}
+		if _, err = h.Write(b); err != nil {
+			return "", xerrors.Errorf("sha256 write error: %w", err)
+		}
+	}
+
 	// TODO: add secret scanner option here
 
 	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
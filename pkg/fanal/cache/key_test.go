@@ -8,6 +8,7 @@ import (
 
 	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
 	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
+	"github.com/zhanglimao/trivy/pkg/fanal/walker"
 	"github.com/zhanglimao/trivy/pkg/misconf"
 )
 
@@ -21,6 +22,7 @@ func TestCalcKey(t *testing.T) {
 		patterns         []string
 		policy           []string
 		data             []string
+		symlinks         walker.SymlinkOption
 	}
 	tests := []struct {
 		name    string
@@ -161,6 +163,43 @@ func TestCalcKey(t *testing.T) {
 			},
 			want: "sha256:363f70f4ee795f250873caea11c2fc94ef12945444327e7e2f8a99e3884695e0",
 		},
+		{
+			name: "never follow symlinks",
+			args: args{
+				key: "sha256:5c534be56eca62e756ef2ef51523feda0f19cd7c15bb0c015e3d6e3ae090bf6e",
+				analyzerVersions: analyzer.Versions{
+					Analyzers: map[string]int{
+						"alpine": 1,
+						"debian": 1,
+					},
+				},
+				hookVersions: map[string]int{
+					"python-pkg": 1,
+				},
+				symlinks: walker.SymlinkOption{Policy: walker.SymlinkNever},
+			},
+			want: "sha256:c720b502991465ea11929cfefc71cf4b5aeaa9a8c0ae59fdaf597f957f5cdb18",
+		},
+		{
+			name: "follow symlinks",
+			args: args{
+				key: "sha256:5c534be56eca62e756ef2ef51523feda0f19cd7c15bb0c015e3d6e3ae090bf6e",
+				analyzerVersions: analyzer.Versions{
+					Analyzers: map[string]int{
+						"alpine": 1,
+						"debian": 1,
+					},
+				},
+				hookVersions: map[string]int{
+					"python-pkg": 1,
+				},
+				symlinks: walker.SymlinkOption{
+					Policy:   walker.SymlinkFollow,
+					MaxDepth: 4,
+				},
+			},
+			want: "sha256:546a2e64f8d2d2fc69f668866c3d2c6d4656eb5fa06b244c003ea2a7bbfaa06f",
+		},
 		{
 			name: "with policy/non-existent dir",
 			args: args{
@@ -179,9 +218,10 @@ func TestCalcKey(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			artifactOpt := artifact.Option{
-				SkipFiles:    tt.args.skipFiles,
-				SkipDirs:     tt.args.skipDirs,
-				FilePatterns: tt.args.patterns,
+				SkipFiles:     tt.args.skipFiles,
+				SkipDirs:      tt.args.skipDirs,
+				FilePatterns:  tt.args.patterns,
+				SymlinkOption: tt.args.symlinks,
 
 				MisconfScannerOption: misconf.ScannerOption{
 					PolicyPaths: tt.args.policy,
@@ -108,7 +108,8 @@ func (c RedisCache) MissingBlobs(artifactID string, blobIDs []string) (bool, []s
 			missingBlobIDs = append(missingBlobIDs, blobID)
 			continue
 		}
-		if blobInfo.SchemaVersion != types.BlobJSONSchemaVersion {
+		// Incomplete results, e.g. with timed-out analyzers, are analyzed again
+		if blobInfo.SchemaVersion != types.BlobJSONSchemaVersion || len(blobInfo.Warnings) > 0 {
 			missingBlobIDs = append(missingBlobIDs, blobID)
 		}
 	}
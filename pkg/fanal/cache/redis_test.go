@@ -412,6 +412,16 @@ func TestRedisCache_MissingBlobs(t *testing.T) {
 			wantMissingArtifact: false,
 			wantMissingBlobIDs:  []string{"sha256:174f5685490326fc0a1c0f5570b8663732189b327007e47ff13d2ca59673db02/11111"},
 		},
+		{
+			name:       "missing blobs with warnings",
+			setupRedis: true,
+			args: args{
+				artifactID: "sha256:8652b9f0cb4c0599575e5a003f5906876e10c1ceb2ab9fe1786712dac14a50cf/1",
+				blobIDs:    []string{"sha256:5a3f8d6e7ffd9de62d4e7e2a32bb8d4bd6c5d9a0ad1e3e2d7f7ec3c2a3e1b6f0/11111"},
+			},
+			wantMissingArtifact: false,
+			wantMissingBlobIDs:  []string{"sha256:5a3f8d6e7ffd9de62d4e7e2a32bb8d4bd6c5d9a0ad1e3e2d7f7ec3c2a3e1b6f0/11111"},
+		},
 		{
 			name:       "different analyzer versions",
 			setupRedis: true,
@@ -437,6 +447,8 @@ func TestRedisCache_MissingBlobs(t *testing.T) {
 		fmt.Sprintf("{\"SchemaVersion\": %d}", types.BlobJSONSchemaVersion))
 	s.Set("fanal::blob::sha256:174f5685490326fc0a1c0f5570b8663732189b327007e47ff13d2ca59673db02/11111",
 		`{"SchemaVersion": 999999}`) // This version should not match the current version
+	s.Set("fanal::blob::sha256:5a3f8d6e7ffd9de62d4e7e2a32bb8d4bd6c5d9a0ad1e3e2d7f7ec3c2a3e1b6f0/11111",
+		fmt.Sprintf(`{"SchemaVersion": %d, "Warnings": [{"Message": "analysis timed out after 30s"}]}`, types.BlobJSONSchemaVersion))
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
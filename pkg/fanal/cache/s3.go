@@ -144,7 +144,8 @@ func (c S3Cache) MissingBlobs(artifactID string, blobIDs []string) (bool, []stri
 		if err != nil {
 			return true, missingBlobIDs, xerrors.Errorf("the blob object (%s) doesn't exist in S3 even though the index file exists: %w", blobID, err)
 		}
-		if blobInfo.SchemaVersion != types.BlobJSONSchemaVersion {
+		// Incomplete results, e.g. with timed-out analyzers, are analyzed again
+		if blobInfo.SchemaVersion != types.BlobJSONSchemaVersion || len(blobInfo.Warnings) > 0 {
 			missingBlobIDs = append(missingBlobIDs, blobID)
 		}
 	}
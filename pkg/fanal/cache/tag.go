@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+)
+
+const tagDigestFile = "tag-digests.json"
+
+// TagDigestStore records the digest resolved from each image tag so that tag drift can be detected across scans.
+// It is stored as a JSON file next to the fanal cache so that it is removed together by --clear-cache.
+type TagDigestStore struct {
+	filePath string
+}
+
+func NewTagDigestStore(cacheDir string) TagDigestStore {
+	return TagDigestStore{
+		filePath: filepath.Join(cacheDir, cacheDirName, tagDigestFile),
+	}
+}
+
+// Get returns the digest recorded for the tag. It returns an empty string if the tag has not been scanned.
+func (s TagDigestStore) Get(tag string) (string, error) {
+	digests, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	return digests[tag], nil
+}
+
+// Put records the digest of the tag
+func (s TagDigestStore) Put(tag, digest string) error {
+	digests, err := s.load()
+	if err != nil {
+		return err
+	}
+	digests[tag] = digest
+
+	b, err := json.MarshalIndent(digests, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("JSON marshal error: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(s.filePath), 0700); err != nil {
+		return xerrors.Errorf("failed to create cache dir: %w", err)
+	}
+	if err = os.WriteFile(s.filePath, b, 0600); err != nil {
+		return xerrors.Errorf("unable to write %s: %w", s.filePath, err)
+	}
+	return nil
+}
+
+func (s TagDigestStore) load() (map[string]string, error) {
+	digests := make(map[string]string)
+	b, err := os.ReadFile(s.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return digests, nil
+	} else if err != nil {
+		return nil, xerrors.Errorf("unable to read %s: %w", s.filePath, err)
+	}
+	if err = json.Unmarshal(b, &digests); err != nil {
+		return nil, xerrors.Errorf("JSON unmarshal error (%s): %w", s.filePath, err)
+	}
+	return digests, nil
+}
@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagDigestStore(t *testing.T) {
+	const (
+		tag     = "index.docker.io/library/alpine:3.17"
+		digest1 = "sha256:e2e16842c9b54d985bf1ef9242a313f36b856181f188de21313820e177002501"
+		digest2 = "sha256:124c7d2707904eea7431fffe91522a01e5a861a624ee31d03372cc1d138a3126"
+	)
+
+	dir := t.TempDir()
+	store := NewTagDigestStore(dir)
+
+	// Not scanned yet
+	got, err := store.Get(tag)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	require.NoError(t, store.Put(tag, digest1))
+	got, err = store.Get(tag)
+	require.NoError(t, err)
+	assert.Equal(t, digest1, got)
+
+	// Overwrite with the new digest
+	require.NoError(t, NewTagDigestStore(dir).Put(tag, digest2))
+	got, err = store.Get(tag)
+	require.NoError(t, err)
+	assert.Equal(t, digest2, got)
+
+	// Broken file
+	require.NoError(t, os.WriteFile(filepath.Join(dir, cacheDirName, tagDigestFile), []byte("broken"), 0600))
+	_, err = store.Get(tag)
+	assert.ErrorContains(t, err, "JSON unmarshal error")
+}
@@ -34,8 +34,9 @@ func TestConfigScanner_Scan(t *testing.T) {
 			inputDir: filepath.Join("testdata", "deny"),
 			want: []types.Misconfiguration{
 				{
-					FileType: "dockerfile",
-					FilePath: "Dockerfile",
+					FileType:   "dockerfile",
+					FilePath:   "Dockerfile",
+					AnalyzedBy: &types.AnalyzedBy{Type: "dockerfile", Version: 1},
 					Failures: types.MisconfResults{
 						types.MisconfResult{
 							Namespace: "testdata.xyz_200",
@@ -101,8 +102,9 @@ func TestConfigScanner_Scan(t *testing.T) {
 			inputDir: filepath.Join("testdata", "allow"),
 			want: []types.Misconfiguration{
 				{
-					FileType: "dockerfile",
-					FilePath: "Dockerfile",
+					FileType:   "dockerfile",
+					FilePath:   "Dockerfile",
+					AnalyzedBy: &types.AnalyzedBy{Type: "dockerfile", Version: 1},
 					Successes: types.MisconfResults{
 						{
 							Namespace: "testdata.xyz_200",
@@ -0,0 +1,145 @@
+package firmware
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// The "newc" format of cpio is used for initramfs.
+// ref. https://www.kernel.org/doc/html/latest/driver-api/early-userspace/buffer-format.html
+
+const (
+	cpioMagicNewc  = "070701"
+	cpioMagicCRC   = "070702"
+	cpioHeaderLen  = 110
+	cpioTrailer    = "TRAILER!!!"
+	cpioMaxNameLen = 4096
+
+	cpioModeType    = 0o170000
+	cpioModeDir     = 0o040000
+	cpioModeFile    = 0o100000
+	cpioModeSymlink = 0o120000
+)
+
+type cpioHeader struct {
+	mode     uint32
+	fileSize uint32
+	nameSize uint32
+}
+
+func isCPIO(r io.ReaderAt) bool {
+	buf := make([]byte, cpioHeaderLen)
+	if _, err := r.ReadAt(buf, 0); err != nil {
+		return false
+	}
+	_, err := parseCPIOHeader(buf)
+	return err == nil
+}
+
+func parseCPIOHeader(buf []byte) (cpioHeader, error) {
+	magic := string(buf[:6])
+	if magic != cpioMagicNewc && magic != cpioMagicCRC {
+		return cpioHeader{}, xerrors.Errorf("invalid cpio magic: %q", magic)
+	}
+
+	// 13 fields of 8 hex digits follow the magic
+	var fields [13]uint32
+	for i := range fields {
+		s := string(buf[6+i*8 : 6+(i+1)*8])
+		v, err := strconv.ParseUint(s, 16, 32)
+		if err != nil {
+			return cpioHeader{}, xerrors.Errorf("invalid cpio header field: %q", s)
+		}
+		fields[i] = uint32(v)
+	}
+
+	h := cpioHeader{
+		mode:     fields[1],
+		fileSize: fields[6],
+		nameSize: fields[11],
+	}
+	if h.nameSize == 0 || h.nameSize > cpioMaxNameLen {
+		return cpioHeader{}, xerrors.Errorf("invalid cpio name size: %d", h.nameSize)
+	}
+	return h, nil
+}
+
+// extractCPIO extracts the cpio archive into the directory
+func extractCPIO(r io.Reader, dst string) error {
+	w := newWriter(dst)
+	var pos int64
+	buf := make([]byte, cpioHeaderLen)
+	for {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return xerrors.Errorf("cpio header error: %w", err)
+		}
+		h, err := parseCPIOHeader(buf)
+		if err != nil {
+			return err
+		}
+		pos += cpioHeaderLen
+
+		// The name is followed by a NUL byte and padded to a multiple of four bytes
+		name := make([]byte, h.nameSize)
+		if _, err = io.ReadFull(r, name); err != nil {
+			return xerrors.Errorf("cpio name error: %w", err)
+		}
+		pos += int64(h.nameSize)
+		if err = skip(r, &pos); err != nil {
+			return err
+		}
+
+		fileName := strings.TrimRight(string(name), "\x00")
+		if fileName == cpioTrailer {
+			break
+		}
+
+		data := io.LimitReader(r, int64(h.fileSize))
+		switch h.mode & cpioModeType {
+		case cpioModeDir:
+			if fileName != "." {
+				err = w.mkdir(fileName)
+			}
+		case cpioModeFile:
+			err = w.writeFile(fileName, os.FileMode(h.mode&0o777), func(f io.Writer) error {
+				_, err := io.Copy(f, data)
+				return err
+			})
+		case cpioModeSymlink:
+			var target []byte
+			if h.fileSize > cpioMaxNameLen {
+				return xerrors.Errorf("too long symlink: %d", h.fileSize)
+			} else if target, err = io.ReadAll(data); err == nil {
+				w.symlink(fileName, string(target))
+			}
+		}
+		// Devices, FIFOs and sockets are skipped
+		if err != nil {
+			return xerrors.Errorf("%s: %w", fileName, err)
+		}
+
+		// Discard the rest of the data, e.g. devices
+		if _, err = io.Copy(io.Discard, data); err != nil {
+			return xerrors.Errorf("cpio data error: %w", err)
+		}
+		pos += int64(h.fileSize)
+		if err = skip(r, &pos); err != nil {
+			return err
+		}
+	}
+	return w.finish()
+}
+
+// skip discards the padding to a multiple of four bytes
+func skip(r io.Reader, pos *int64) error {
+	pad := (4 - *pos%4) % 4
+	if _, err := io.CopyN(io.Discard, r, pad); err != nil {
+		return xerrors.Errorf("cpio padding error: %w", err)
+	}
+	*pos += pad
+	return nil
+}
@@ -0,0 +1,338 @@
+// Package firmware unpacks filesystems embedded in firmware images so that they can be scanned
+// in the same way as a local filesystem.
+package firmware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+const (
+	// maxNestedDepth limits the recursion into nested images, e.g. a SquashFS in a uImage
+	maxNestedDepth = 3
+	// maxDirDepth limits the directory depth of extracted filesystems
+	maxDirDepth = 64
+	// maxImageSize limits the size of payloads read into memory
+	maxImageSize = 512 << 20
+)
+
+// Format represents a firmware container format
+type Format string
+
+const (
+	FormatSquashFS Format = "squashfs"
+	FormatCPIO     Format = "cpio"
+	FormatUImage   Format = "uimage"
+)
+
+// extensions are the file extensions of raw firmware images, which are carved for embedded filesystems
+var extensions = []string{
+	".bin",
+	".img",
+	".trx",
+	".chk",
+	".fw",
+}
+
+// Detect returns true if the file is a firmware image containing a supported filesystem
+func Detect(filePath string) (bool, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false, xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return false, xerrors.Errorf("file stat error: %w", err)
+	}
+
+	if _, ok := detectFormat(f, fi.Size()); ok {
+		return true, nil
+	}
+	if !hasExtension(filePath) {
+		return false, nil
+	}
+	return len(carve(f, fi.Size())) > 0, nil
+}
+
+// Extract unpacks the filesystems found in the firmware image into the directory.
+// A single filesystem is extracted into the directory itself so that OS files such as "etc/os-release"
+// are found at the root. Multiple filesystems embedded in a raw image are extracted into
+// "<format>-0x<offset>" subdirectories.
+func Extract(filePath, dst string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return xerrors.Errorf("file stat error: %w", err)
+	}
+
+	if err = extract(f, fi.Size(), dst, 0); err != nil {
+		return xerrors.Errorf("firmware extraction error (%s): %w", filePath, err)
+	}
+	return nil
+}
+
+func extract(r io.ReaderAt, size int64, dst string, depth int) error {
+	if depth > maxNestedDepth {
+		return xerrors.New("too deeply nested image")
+	}
+
+	if format, ok := detectFormat(r, size); ok {
+		return extractFormat(format, r, size, dst, depth)
+	}
+
+	candidates := carve(r, size)
+	if len(candidates) == 0 {
+		return xerrors.New("no supported filesystem found")
+	}
+
+	var extracted []string
+	for _, c := range candidates {
+		dir := filepath.Join(dst, fmt.Sprintf("%s-0x%x", c.format, c.offset))
+		sr := io.NewSectionReader(r, c.offset, size-c.offset)
+		if err := extractFormat(c.format, sr, sr.Size(), dir, depth); err != nil {
+			log.Logger.Debugf("Unable to extract %s at 0x%x: %s", c.format, c.offset, err)
+			if err = os.RemoveAll(dir); err != nil {
+				return xerrors.Errorf("unable to remove %s: %w", dir, err)
+			}
+			continue
+		}
+		extracted = append(extracted, dir)
+	}
+
+	switch len(extracted) {
+	case 0:
+		return xerrors.New("no filesystem could be extracted")
+	case 1:
+		// e.g. a kernel followed by a root filesystem
+		return hoist(extracted[0], dst)
+	}
+	return nil
+}
+
+// hoist moves the contents of the directory to its parent
+func hoist(dir, parent string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return xerrors.Errorf("read dir error: %w", err)
+	}
+	for _, e := range entries {
+		if err = os.Rename(filepath.Join(dir, e.Name()), filepath.Join(parent, e.Name())); err != nil {
+			return xerrors.Errorf("rename error: %w", err)
+		}
+	}
+	if err = os.Remove(dir); err != nil {
+		return xerrors.Errorf("remove error: %w", err)
+	}
+	return nil
+}
+
+func extractFormat(format Format, r io.ReaderAt, size int64, dst string, depth int) error {
+	log.Logger.Debugf("Extracting %s image...", format)
+	switch format {
+	case FormatSquashFS:
+		return extractSquashfs(r, size, dst)
+	case FormatCPIO:
+		return extractCPIO(io.NewSectionReader(r, 0, size), dst)
+	case FormatUImage:
+		return extractUImage(r, size, dst, depth)
+	}
+	return xerrors.Errorf("unknown format: %s", format)
+}
+
+// detectFormat detects the format by the magic bytes at the beginning
+func detectFormat(r io.ReaderAt, size int64) (Format, bool) {
+	switch {
+	case isSquashfs(r, size):
+		return FormatSquashFS, true
+	case isCPIO(r):
+		return FormatCPIO, true
+	case isUImage(r, size):
+		return FormatUImage, true
+	}
+	return "", false
+}
+
+type candidate struct {
+	format Format
+	offset int64
+}
+
+var magics = []struct {
+	format Format
+	magic  []byte
+}{
+	{format: FormatSquashFS, magic: []byte("hsqs")},
+	{format: FormatCPIO, magic: []byte(cpioMagicNewc)},
+	{format: FormatCPIO, magic: []byte(cpioMagicCRC)},
+	{format: FormatUImage, magic: []byte{0x27, 0x05, 0x19, 0x56}},
+}
+
+// carve scans raw images, such as a bootloader followed by a kernel and a root filesystem, for embedded images.
+func carve(r io.ReaderAt, size int64) []candidate {
+	const chunkSize = 1 << 20
+	const overlap = 8
+
+	var candidates []candidate
+	var skipUntil int64
+	buf := make([]byte, chunkSize+overlap)
+	for pos := int64(0); pos < size; pos += chunkSize {
+		n, err := r.ReadAt(buf, pos)
+		if err != nil && err != io.EOF {
+			break
+		}
+
+		chunk := buf[:n]
+		for i := 0; i < n; i++ {
+			next := indexAny(chunk[i:])
+			if next < 0 {
+				break
+			}
+			i += next
+			if i >= chunkSize {
+				// Found in the overlap, which is scanned in the next chunk
+				break
+			}
+
+			off := pos + int64(i)
+			if off < skipUntil {
+				continue
+			}
+			sr := io.NewSectionReader(r, off, size-off)
+			if format, ok := detectFormat(sr, sr.Size()); ok {
+				candidates = append(candidates, candidate{format: format, offset: off})
+				// Skip the contents so that nested images are not extracted twice
+				skipUntil = off + imageSize(format, sr, sr.Size())
+			}
+		}
+	}
+	return candidates
+}
+
+// indexAny returns the index of the first magic in the buffer, or -1 if not found
+func indexAny(b []byte) int {
+	first := -1
+	for _, m := range magics {
+		if i := bytes.Index(b, m.magic); i >= 0 && (first < 0 || i < first) {
+			first = i
+		}
+	}
+	return first
+}
+
+// imageSize returns the size of the image if known, otherwise the rest of the file
+func imageSize(format Format, r io.ReaderAt, size int64) int64 {
+	switch format {
+	case FormatSquashFS:
+		if sb, ok := readSuperblock(r, size); ok {
+			return int64(sb.BytesUsed)
+		}
+	case FormatUImage:
+		if h, ok := readUImageHeader(r, size); ok {
+			return uimageHeaderLen + int64(h.Size)
+		}
+	}
+	return size
+}
+
+func hasExtension(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	for _, e := range extensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+func isSquashfs(r io.ReaderAt, size int64) bool {
+	_, ok := readSuperblock(r, size)
+	return ok
+}
+
+// writer writes extracted files under the directory, rejecting paths escaping from it.
+// Symbolic links are created after all files are written so that they cannot redirect writes.
+type writer struct {
+	root     string
+	symlinks [][2]string
+}
+
+func newWriter(root string) *writer {
+	return &writer{root: root}
+}
+
+// path returns the destination path, or an error if the name escapes from the root
+func (w *writer) path(name string) (string, error) {
+	name = filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+		return "", xerrors.Errorf("invalid path: %s", name)
+	}
+	return filepath.Join(w.root, name), nil
+}
+
+func (w *writer) mkdir(name string) error {
+	p, err := w.path(name)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(p, 0o755); err != nil {
+		return xerrors.Errorf("mkdir error: %w", err)
+	}
+	return nil
+}
+
+func (w *writer) writeFile(name string, mode os.FileMode, write func(io.Writer) error) error {
+	p, err := w.path(name)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return xerrors.Errorf("mkdir error: %w", err)
+	}
+
+	// Files are always readable by analyzers
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode|0o600)
+	if err != nil {
+		return xerrors.Errorf("file create error: %w", err)
+	}
+	defer f.Close()
+
+	if err = write(f); err != nil {
+		return xerrors.Errorf("file write error: %w", err)
+	}
+	return nil
+}
+
+func (w *writer) symlink(name, target string) {
+	w.symlinks = append(w.symlinks, [2]string{name, target})
+}
+
+func (w *writer) finish() error {
+	for _, link := range w.symlinks {
+		p, err := w.path(link[0])
+		if err != nil {
+			return err
+		}
+		if err = os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return xerrors.Errorf("mkdir error: %w", err)
+		}
+		if err = os.Symlink(link[1], p); err != nil && !os.IsExist(err) {
+			log.Logger.Debugf("Unable to create a symlink %s: %s", link[0], err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package firmware_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/firmware"
+)
+
+const osRelease = "NAME=\"Alpine Linux\"\nID=alpine\nVERSION_ID=3.17.2\n"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "squashfs",
+			filePath: "testdata/rootfs.squashfs",
+			want:     true,
+		},
+		{
+			name:     "cpio",
+			filePath: "testdata/initramfs.cpio",
+			want:     true,
+		},
+		{
+			name:     "uImage",
+			filePath: "testdata/uImage",
+			want:     true,
+		},
+		{
+			name:     "raw image",
+			filePath: "testdata/firmware.bin",
+			want:     true,
+		},
+		{
+			name:     "not firmware",
+			filePath: "firmware.go",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := firmware.Detect(tt.filePath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	busybox := bytes.Repeat(func() []byte {
+		b := make([]byte, 256)
+		for i := range b {
+			b[i] = byte(i)
+		}
+		return b
+	}(), 20)
+	nofrag := strings.Repeat("A", 5000) + strings.Repeat("\x00", 4096) + strings.Repeat("B", 100)
+
+	tests := []struct {
+		name      string
+		filePath  string
+		wantFiles map[string]string
+		wantLinks map[string]string
+		wantErr   string
+	}{
+		{
+			name:     "squashfs",
+			filePath: "testdata/rootfs.squashfs",
+			wantFiles: map[string]string{
+				"etc/os-release": osRelease,
+				"etc/hostname":   "router\n",
+				"bin/busybox":    string(busybox),
+				"lib/nofrag.so":  nofrag,
+			},
+			wantLinks: map[string]string{
+				"bin/sh": "busybox",
+			},
+		},
+		{
+			name:     "cpio",
+			filePath: "testdata/initramfs.cpio",
+			wantFiles: map[string]string{
+				"etc/os-release": osRelease,
+				"init":           "#!/bin/sh\n",
+			},
+			wantLinks: map[string]string{
+				"bin/sh": "busybox",
+			},
+		},
+		{
+			name:     "gzip-compressed uImage",
+			filePath: "testdata/uImage",
+			wantFiles: map[string]string{
+				"etc/os-release": osRelease,
+			},
+		},
+		{
+			name:     "raw image with a kernel and a root filesystem",
+			filePath: "testdata/firmware.bin",
+			wantFiles: map[string]string{
+				"etc/os-release": osRelease,
+				"bin/busybox":    string(busybox),
+			},
+			wantLinks: map[string]string{
+				"bin/sh": "busybox",
+			},
+		},
+		{
+			name:     "path traversal",
+			filePath: "testdata/evil.cpio",
+			wantErr:  "invalid path",
+		},
+		{
+			name:     "not firmware",
+			filePath: "firmware.go",
+			wantErr:  "no supported filesystem found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := t.TempDir()
+			err := firmware.Extract(tt.filePath, dst)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			for name, want := range tt.wantFiles {
+				got, err := os.ReadFile(filepath.Join(dst, name))
+				require.NoError(t, err, name)
+				assert.Equal(t, want, string(got), name)
+			}
+			for name, want := range tt.wantLinks {
+				got, err := os.Readlink(filepath.Join(dst, name))
+				require.NoError(t, err, name)
+				assert.Equal(t, want, got, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,531 @@
+package firmware
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+	"math/bits"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+	"github.com/ulikunitz/xz/lzma"
+	"golang.org/x/xerrors"
+)
+
+// SquashFS 4.0 is the most common root filesystem of embedded Linux.
+// ref. https://dr-emann.github.io/squashfs/squashfs.html
+
+const (
+	squashfsMagic         = 0x73717368 // "hsqs"
+	squashfsSuperblockLen = 96
+
+	metadataBlockSize    = 8192
+	metadataUncompressed = 0x8000
+	dataUncompressed     = 1 << 24
+	noFragment           = 0xffffffff
+	fragmentEntryLen     = 16
+	maxBlockSize         = 1 << 20
+)
+
+// Compressors
+const (
+	compGzip = 1
+	compLZMA = 2
+	compLZO  = 3
+	compXZ   = 4
+	compLZ4  = 5
+	compZstd = 6
+)
+
+// Inode types
+const (
+	inodeDir        = 1
+	inodeFile       = 2
+	inodeSymlink    = 3
+	inodeExtDir     = 8
+	inodeExtFile    = 9
+	inodeExtSymlink = 10
+)
+
+type superblock struct {
+	Magic               uint32
+	InodeCount          uint32
+	ModTime             uint32
+	BlockSize           uint32
+	FragCount           uint32
+	Compressor          uint16
+	BlockLog            uint16
+	Flags               uint16
+	IDCount             uint16
+	VersionMajor        uint16
+	VersionMinor        uint16
+	RootInode           uint64
+	BytesUsed           uint64
+	IDTableStart        uint64
+	XattrIDTableStart   uint64
+	InodeTableStart     uint64
+	DirectoryTableStart uint64
+	FragmentTableStart  uint64
+	ExportTableStart    uint64
+}
+
+type squashfs struct {
+	r          io.ReaderAt
+	sb         superblock
+	fragments  []fragmentEntry
+	decompress func([]byte) ([]byte, error)
+}
+
+type fragmentEntry struct {
+	Start  uint64
+	Size   uint32
+	Unused uint32
+}
+
+type inode struct {
+	typ  uint16
+	mode uint16
+
+	// Directory
+	dirBlock  uint32
+	dirOffset uint16
+	dirSize   uint32
+
+	// Regular file
+	fileSize    uint64
+	blocksStart uint64
+	fragIndex   uint32
+	fragOffset  uint32
+	blockSizes  []uint32
+
+	// Symbolic link
+	target string
+}
+
+type dirEntry struct {
+	name  string
+	inode uint64 // inode reference
+}
+
+// readSuperblock returns the superblock if the reader starts with a valid SquashFS 4.0 image
+func readSuperblock(r io.ReaderAt, size int64) (superblock, bool) {
+	var sb superblock
+	buf := make([]byte, squashfsSuperblockLen)
+	if _, err := r.ReadAt(buf, 0); err != nil {
+		return sb, false
+	}
+	if err := binary.Read(bytes.NewReader(buf), binary.LittleEndian, &sb); err != nil {
+		return sb, false
+	}
+
+	switch {
+	case sb.Magic != squashfsMagic, sb.VersionMajor != 4:
+		return sb, false
+	case sb.BlockSize < 4096 || sb.BlockSize > maxBlockSize || bits.OnesCount32(sb.BlockSize) != 1:
+		return sb, false
+	case sb.Compressor < compGzip || sb.Compressor > compZstd:
+		return sb, false
+	case sb.BytesUsed == 0 || int64(sb.BytesUsed) > size:
+		return sb, false
+	case sb.InodeTableStart >= sb.DirectoryTableStart || sb.DirectoryTableStart >= sb.BytesUsed:
+		return sb, false
+	}
+	return sb, true
+}
+
+func newSquashfs(r io.ReaderAt, size int64) (*squashfs, error) {
+	sb, ok := readSuperblock(r, size)
+	if !ok {
+		return nil, xerrors.New("invalid squashfs superblock")
+	}
+
+	fs := &squashfs{
+		r:  r,
+		sb: sb,
+	}
+	switch sb.Compressor {
+	case compGzip:
+		fs.decompress = decompressWith(func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) })
+	case compLZMA:
+		fs.decompress = decompressWith(func(r io.Reader) (io.Reader, error) { return lzma.NewReader(r) })
+	case compXZ:
+		fs.decompress = decompressWith(func(r io.Reader) (io.Reader, error) { return xz.NewReader(r) })
+	case compZstd:
+		fs.decompress = decompressWith(func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) })
+	case compLZO, compLZ4:
+		return nil, xerrors.Errorf("unsupported squashfs compressor: %d", sb.Compressor)
+	}
+
+	if err := fs.readFragmentTable(); err != nil {
+		return nil, xerrors.Errorf("fragment table error: %w", err)
+	}
+	return fs, nil
+}
+
+func decompressWith(newReader func(io.Reader) (io.Reader, error)) func([]byte) ([]byte, error) {
+	return func(b []byte) ([]byte, error) {
+		r, err := newReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		if c, ok := r.(io.Closer); ok {
+			defer c.Close()
+		}
+		if d, ok := r.(*zstd.Decoder); ok {
+			defer d.Close()
+		}
+		// Neither data nor metadata blocks exceed the maximum block size
+		return io.ReadAll(io.LimitReader(r, maxBlockSize))
+	}
+}
+
+// readMetadataBlock returns the uncompressed metadata block at the position and the position of the next block
+func (fs *squashfs) readMetadataBlock(pos int64) ([]byte, int64, error) {
+	var header [2]byte
+	if _, err := fs.r.ReadAt(header[:], pos); err != nil {
+		return nil, 0, xerrors.Errorf("metadata header error: %w", err)
+	}
+	h := binary.LittleEndian.Uint16(header[:])
+	size := int64(h &^ metadataUncompressed)
+	if size == 0 || size > metadataBlockSize {
+		return nil, 0, xerrors.Errorf("invalid metadata block size: %d", size)
+	}
+
+	b := make([]byte, size)
+	if _, err := fs.r.ReadAt(b, pos+2); err != nil {
+		return nil, 0, xerrors.Errorf("metadata read error: %w", err)
+	}
+	if h&metadataUncompressed == 0 {
+		var err error
+		if b, err = fs.decompress(b); err != nil {
+			return nil, 0, xerrors.Errorf("metadata decompress error: %w", err)
+		}
+	}
+	return b, pos + 2 + size, nil
+}
+
+// metadataReader reads bytes across metadata blocks
+type metadataReader struct {
+	fs   *squashfs
+	next int64
+	buf  []byte
+}
+
+func (fs *squashfs) newMetadataReader(pos int64, offset uint16) (*metadataReader, error) {
+	b, next, err := fs.readMetadataBlock(pos)
+	if err != nil {
+		return nil, err
+	} else if int(offset) > len(b) {
+		return nil, xerrors.Errorf("invalid metadata offset: %d", offset)
+	}
+	return &metadataReader{
+		fs:   fs,
+		next: next,
+		buf:  b[offset:],
+	}, nil
+}
+
+func (m *metadataReader) Read(p []byte) (int, error) {
+	if len(m.buf) == 0 {
+		b, next, err := m.fs.readMetadataBlock(m.next)
+		if err != nil {
+			return 0, err
+		}
+		m.buf, m.next = b, next
+	}
+	n := copy(p, m.buf)
+	m.buf = m.buf[n:]
+	return n, nil
+}
+
+func (fs *squashfs) readFragmentTable() error {
+	if fs.sb.FragCount == 0 || fs.sb.FragmentTableStart >= fs.sb.BytesUsed {
+		return nil
+	}
+
+	// The fragment table is a list of pointers to metadata blocks with fragment entries
+	blocks := (int(fs.sb.FragCount)*fragmentEntryLen + metadataBlockSize - 1) / metadataBlockSize
+	pointers := make([]uint64, blocks)
+	sr := io.NewSectionReader(fs.r, int64(fs.sb.FragmentTableStart), int64(blocks*8))
+	if err := binary.Read(sr, binary.LittleEndian, pointers); err != nil {
+		return xerrors.Errorf("fragment pointers error: %w", err)
+	}
+
+	for _, p := range pointers {
+		b, _, err := fs.readMetadataBlock(int64(p))
+		if err != nil {
+			return err
+		}
+		entries := make([]fragmentEntry, len(b)/fragmentEntryLen)
+		if err = binary.Read(bytes.NewReader(b), binary.LittleEndian, entries); err != nil {
+			return xerrors.Errorf("fragment entry error: %w", err)
+		}
+		fs.fragments = append(fs.fragments, entries...)
+	}
+	return nil
+}
+
+func (fs *squashfs) readInode(ref uint64) (*inode, error) {
+	m, err := fs.newMetadataReader(int64(fs.sb.InodeTableStart+ref>>16), uint16(ref&0xffff))
+	if err != nil {
+		return nil, xerrors.Errorf("inode error: %w", err)
+	}
+
+	var header struct {
+		Type, Mode, UID, GID uint16
+		MTime, InodeNumber   uint32
+	}
+	if err = binary.Read(m, binary.LittleEndian, &header); err != nil {
+		return nil, xerrors.Errorf("inode header error: %w", err)
+	}
+
+	ino := &inode{
+		typ:  header.Type,
+		mode: header.Mode,
+	}
+	switch header.Type {
+	case inodeDir:
+		var d struct {
+			BlockIndex, LinkCount uint32
+			FileSize, BlockOffset uint16
+			ParentInode           uint32
+		}
+		if err = binary.Read(m, binary.LittleEndian, &d); err != nil {
+			return nil, xerrors.Errorf("directory inode error: %w", err)
+		}
+		ino.dirBlock, ino.dirOffset, ino.dirSize = d.BlockIndex, d.BlockOffset, uint32(d.FileSize)
+	case inodeExtDir:
+		var d struct {
+			LinkCount, FileSize, BlockIndex, ParentInode uint32
+			IndexCount, BlockOffset                      uint16
+			XattrIndex                                   uint32
+		}
+		if err = binary.Read(m, binary.LittleEndian, &d); err != nil {
+			return nil, xerrors.Errorf("directory inode error: %w", err)
+		}
+		ino.dirBlock, ino.dirOffset, ino.dirSize = d.BlockIndex, d.BlockOffset, d.FileSize
+	case inodeFile:
+		var f struct {
+			BlocksStart, FragIndex, BlockOffset, FileSize uint32
+		}
+		if err = binary.Read(m, binary.LittleEndian, &f); err != nil {
+			return nil, xerrors.Errorf("file inode error: %w", err)
+		}
+		ino.blocksStart, ino.fragIndex, ino.fragOffset, ino.fileSize = uint64(f.BlocksStart), f.FragIndex, f.BlockOffset, uint64(f.FileSize)
+		err = fs.readBlockSizes(m, ino)
+	case inodeExtFile:
+		var f struct {
+			BlocksStart, FileSize, Sparse                 uint64
+			LinkCount, FragIndex, BlockOffset, XattrIndex uint32
+		}
+		if err = binary.Read(m, binary.LittleEndian, &f); err != nil {
+			return nil, xerrors.Errorf("file inode error: %w", err)
+		}
+		ino.blocksStart, ino.fragIndex, ino.fragOffset, ino.fileSize = f.BlocksStart, f.FragIndex, f.BlockOffset, f.FileSize
+		err = fs.readBlockSizes(m, ino)
+	case inodeSymlink, inodeExtSymlink:
+		var s struct {
+			LinkCount, TargetSize uint32
+		}
+		if err = binary.Read(m, binary.LittleEndian, &s); err != nil {
+			return nil, xerrors.Errorf("symlink inode error: %w", err)
+		} else if s.TargetSize > 4096 {
+			return nil, xerrors.Errorf("too long symlink: %d", s.TargetSize)
+		}
+		target := make([]byte, s.TargetSize)
+		if _, err = io.ReadFull(m, target); err != nil {
+			return nil, xerrors.Errorf("symlink target error: %w", err)
+		}
+		ino.target = string(target)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ino, nil
+}
+
+func (fs *squashfs) readBlockSizes(r io.Reader, ino *inode) error {
+	blockSize := uint64(fs.sb.BlockSize)
+	n := ino.fileSize / blockSize
+	if ino.fragIndex == noFragment && ino.fileSize%blockSize != 0 {
+		n++
+	}
+	if n > fs.sb.BytesUsed {
+		return xerrors.Errorf("invalid file size: %d", ino.fileSize)
+	}
+	ino.blockSizes = make([]uint32, n)
+	if err := binary.Read(r, binary.LittleEndian, ino.blockSizes); err != nil {
+		return xerrors.Errorf("block list error: %w", err)
+	}
+	return nil
+}
+
+func (fs *squashfs) readDir(ino *inode) ([]dirEntry, error) {
+	// The size includes 3 bytes for "." and ".."
+	if ino.dirSize <= 3 {
+		return nil, nil
+	}
+	m, err := fs.newMetadataReader(int64(fs.sb.DirectoryTableStart)+int64(ino.dirBlock), ino.dirOffset)
+	if err != nil {
+		return nil, xerrors.Errorf("directory error: %w", err)
+	}
+
+	var entries []dirEntry
+	for remaining := int(ino.dirSize) - 3; remaining > 0; {
+		var header struct {
+			Count, Start, InodeNumber uint32
+		}
+		if err = binary.Read(m, binary.LittleEndian, &header); err != nil {
+			return nil, xerrors.Errorf("directory header error: %w", err)
+		}
+		remaining -= 12
+
+		for i := 0; i <= int(header.Count) && remaining > 0; i++ {
+			var e struct {
+				Offset      uint16
+				InodeOffset int16
+				Type        uint16
+				NameSize    uint16
+			}
+			if err = binary.Read(m, binary.LittleEndian, &e); err != nil {
+				return nil, xerrors.Errorf("directory entry error: %w", err)
+			}
+			name := make([]byte, int(e.NameSize)+1)
+			if _, err = io.ReadFull(m, name); err != nil {
+				return nil, xerrors.Errorf("directory entry name error: %w", err)
+			}
+			remaining -= 8 + len(name)
+
+			entries = append(entries, dirEntry{
+				name:  string(name),
+				inode: uint64(header.Start)<<16 | uint64(e.Offset),
+			})
+		}
+	}
+	return entries, nil
+}
+
+func (fs *squashfs) writeFile(ino *inode, w io.Writer) error {
+	pos := int64(ino.blocksStart)
+	remaining := ino.fileSize
+	for _, size := range ino.blockSizes {
+		n := uint64(fs.sb.BlockSize)
+		if remaining < n {
+			n = remaining
+		}
+
+		var b []byte
+		if size == 0 {
+			// Sparse block
+			b = make([]byte, n)
+		} else {
+			var err error
+			if b, err = fs.readBlock(pos, size); err != nil {
+				return err
+			}
+			pos += int64(size &^ dataUncompressed)
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+		remaining -= n
+	}
+
+	if ino.fragIndex == noFragment || remaining == 0 {
+		return nil
+	} else if int(ino.fragIndex) >= len(fs.fragments) {
+		return xerrors.Errorf("invalid fragment index: %d", ino.fragIndex)
+	}
+
+	frag := fs.fragments[ino.fragIndex]
+	b, err := fs.readBlock(int64(frag.Start), frag.Size)
+	if err != nil {
+		return xerrors.Errorf("fragment error: %w", err)
+	}
+	end := uint64(ino.fragOffset) + remaining
+	if end > uint64(len(b)) {
+		return xerrors.Errorf("invalid fragment offset: %d", ino.fragOffset)
+	}
+	_, err = w.Write(b[ino.fragOffset:end])
+	return err
+}
+
+func (fs *squashfs) readBlock(pos int64, size uint32) ([]byte, error) {
+	n := int64(size &^ dataUncompressed)
+	if n > int64(fs.sb.BlockSize)*2 {
+		return nil, xerrors.Errorf("invalid block size: %d", n)
+	}
+	b := make([]byte, n)
+	if _, err := fs.r.ReadAt(b, pos); err != nil {
+		return nil, xerrors.Errorf("block read error: %w", err)
+	}
+	if size&dataUncompressed != 0 {
+		return b, nil
+	}
+	b, err := fs.decompress(b)
+	if err != nil {
+		return nil, xerrors.Errorf("block decompress error: %w", err)
+	}
+	return b, nil
+}
+
+// extractSquashfs extracts the SquashFS image into the directory
+func extractSquashfs(r io.ReaderAt, size int64, dst string) error {
+	fs, err := newSquashfs(r, size)
+	if err != nil {
+		return err
+	}
+	root, err := fs.readInode(fs.sb.RootInode)
+	if err != nil {
+		return xerrors.Errorf("root inode error: %w", err)
+	}
+
+	w := newWriter(dst)
+	if err = fs.extractDir(root, "", w, 0); err != nil {
+		return err
+	}
+	return w.finish()
+}
+
+func (fs *squashfs) extractDir(dir *inode, dirPath string, w *writer, depth int) error {
+	if depth > maxDirDepth {
+		return xerrors.Errorf("too deep directory: %s", dirPath)
+	}
+	entries, err := fs.readDir(dir)
+	if err != nil {
+		return xerrors.Errorf("%s: %w", dirPath, err)
+	}
+
+	for _, e := range entries {
+		name := filepath.Join(dirPath, e.name)
+		ino, err := fs.readInode(e.inode)
+		if err != nil {
+			return xerrors.Errorf("%s: %w", name, err)
+		}
+
+		mode := os.FileMode(ino.mode & 0o777)
+		switch ino.typ {
+		case inodeDir, inodeExtDir:
+			if err = w.mkdir(name); err != nil {
+				return err
+			}
+			if err = fs.extractDir(ino, name, w, depth+1); err != nil {
+				return err
+			}
+		case inodeFile, inodeExtFile:
+			err = w.writeFile(name, mode, func(f io.Writer) error {
+				return fs.writeFile(ino, f)
+			})
+			if err != nil {
+				return xerrors.Errorf("%s: %w", name, err)
+			}
+		case inodeSymlink, inodeExtSymlink:
+			w.symlink(name, ino.target)
+		}
+		// Devices, FIFOs and sockets are skipped
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package firmware
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz/lzma"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// The legacy U-Boot image format wraps kernels, ramdisks and filesystems with a 64-byte header.
+// ref. https://github.com/u-boot/u-boot/blob/master/include/image.h
+
+const (
+	uimageMagic     = 0x27051956
+	uimageHeaderLen = 64
+
+	uimageTypeMulti = 4
+
+	uimageCompNone  = 0
+	uimageCompGzip  = 1
+	uimageCompBzip2 = 2
+	uimageCompLZMA  = 3
+	uimageCompZstd  = 6
+)
+
+type uimageHeader struct {
+	Magic       uint32
+	HeaderCRC   uint32
+	Time        uint32
+	Size        uint32
+	LoadAddr    uint32
+	EntryPoint  uint32
+	DataCRC     uint32
+	OS          uint8
+	Arch        uint8
+	Type        uint8
+	Compression uint8
+	Name        [32]byte
+}
+
+// readUImageHeader returns the header if the reader starts with a uImage with a valid header checksum
+func readUImageHeader(r io.ReaderAt, size int64) (uimageHeader, bool) {
+	var h uimageHeader
+	buf := make([]byte, uimageHeaderLen)
+	if _, err := r.ReadAt(buf, 0); err != nil {
+		return h, false
+	}
+	if err := binary.Read(bytes.NewReader(buf), binary.BigEndian, &h); err != nil {
+		return h, false
+	}
+	if h.Magic != uimageMagic || uimageHeaderLen+int64(h.Size) > size {
+		return h, false
+	}
+
+	// The checksum is calculated with the checksum field zeroed
+	binary.BigEndian.PutUint32(buf[4:8], 0)
+	return h, crc32.ChecksumIEEE(buf) == h.HeaderCRC
+}
+
+func isUImage(r io.ReaderAt, size int64) bool {
+	_, ok := readUImageHeader(r, size)
+	return ok
+}
+
+// extractUImage extracts filesystems in the uImage payload into the directory
+func extractUImage(r io.ReaderAt, size int64, dst string, depth int) error {
+	h, ok := readUImageHeader(r, size)
+	if !ok {
+		return xerrors.New("invalid uImage header")
+	} else if h.Size > maxImageSize {
+		return xerrors.Errorf("too large uImage: %d bytes", h.Size)
+	}
+
+	data := make([]byte, h.Size)
+	if _, err := r.ReadAt(data, uimageHeaderLen); err != nil {
+		return xerrors.Errorf("uImage read error: %w", err)
+	}
+	if crc32.ChecksumIEEE(data) != h.DataCRC {
+		return xerrors.New("uImage data checksum mismatch")
+	}
+
+	if h.Type == uimageTypeMulti {
+		return extractMultiImage(data, dst, depth)
+	}
+
+	payload, err := decompressUImage(h.Compression, data)
+	if err != nil {
+		return xerrors.Errorf("uImage decompress error: %w", err)
+	}
+	return extract(bytes.NewReader(payload), int64(len(payload)), dst, depth+1)
+}
+
+// extractMultiImage extracts a multi-file image, which contains a list of image sizes terminated by zero
+// followed by the images padded to a multiple of four bytes, such as a kernel and a ramdisk.
+func extractMultiImage(data []byte, dst string, depth int) error {
+	var sizes []uint32
+	pos := 0
+	for {
+		if pos+4 > len(data) {
+			return xerrors.New("invalid multi-file image")
+		}
+		s := binary.BigEndian.Uint32(data[pos:])
+		pos += 4
+		if s == 0 {
+			break
+		}
+		sizes = append(sizes, s)
+	}
+
+	var images [][]byte
+	for _, s := range sizes {
+		if pos+int(s) > len(data) {
+			return xerrors.New("invalid multi-file image size")
+		}
+		img := data[pos : pos+int(s)]
+		pos += (int(s) + 3) &^ 3
+
+		// Kernels and other images without filesystems are skipped
+		if _, ok := detectFormat(bytes.NewReader(img), int64(len(img))); ok || len(carve(bytes.NewReader(img), int64(len(img)))) > 0 {
+			images = append(images, img)
+		}
+	}
+	if len(images) == 0 {
+		return xerrors.New("no filesystem found in multi-file image")
+	}
+
+	for i, img := range images {
+		dir := dst
+		if len(images) > 1 {
+			dir = filepath.Join(dst, fmt.Sprintf("image-%d", i))
+		}
+		if err := extract(bytes.NewReader(img), int64(len(img)), dir, depth+1); err != nil {
+			log.Logger.Debugf("Unable to extract image %d in multi-file image: %s", i, err)
+		}
+	}
+	return nil
+}
+
+func decompressUImage(comp uint8, data []byte) ([]byte, error) {
+	var r io.Reader
+	var err error
+	switch comp {
+	case uimageCompNone:
+		return data, nil
+	case uimageCompGzip:
+		r, err = gzip.NewReader(bytes.NewReader(data))
+	case uimageCompBzip2:
+		r = bzip2.NewReader(bytes.NewReader(data))
+	case uimageCompLZMA:
+		r, err = lzma.NewReader(bytes.NewReader(data))
+	case uimageCompZstd:
+		var d *zstd.Decoder
+		if d, err = zstd.NewReader(bytes.NewReader(data)); err == nil {
+			defer d.Close()
+			r = d
+		}
+	default:
+		return nil, xerrors.Errorf("unsupported compression: %d", comp)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := io.ReadAll(io.LimitReader(r, maxImageSize+1))
+	if err != nil {
+		return nil, err
+	} else if len(b) > maxImageSize {
+		return nil, xerrors.New("too large payload")
+	}
+	return b, nil
+}
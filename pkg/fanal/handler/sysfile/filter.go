@@ -44,6 +44,18 @@ var (
 
 		// Go binaries
 		types.GoBinary,
+
+		// JDK
+		types.JDK,
+
+		// Runtimes
+		types.NodeRuntime,
+		types.PythonRuntime,
+		types.RubyRuntime,
+		types.PHPRuntime,
+
+		// Web servers
+		types.Server,
 	}
 )
 
@@ -1,6 +1,8 @@
 package image
 
 import (
+	"strings"
+
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/hashicorp/go-multierror"
 
@@ -8,24 +10,32 @@ import (
 )
 
 func NewArchiveImage(fileName string) (types.Image, error) {
-	img, err := newImage(fileName)
+	img, oci, err := newImage(fileName)
 	if err != nil {
 		return nil, err
 	}
 	return archiveImage{
 		name:  fileName,
+		oci:   oci,
 		Image: img,
 	}, nil
 }
 
-func newImage(fileName string) (v1.Image, error) {
+// newImage opens the archive and reports whether it is an OCI layout
+func newImage(fileName string) (v1.Image, bool, error) {
+	// The input is explicitly an OCI layout directory
+	if strings.HasPrefix(fileName, OCIDirPrefix) {
+		img, err := tryOCI(fileName)
+		return img, true, err
+	}
+
 	var errs error
 
 	// Docker archive
 	img, err := tryDockerArchive(fileName)
 	if err == nil {
 		// Return v1.Image if the file can be opened as Docker archive
-		return img, nil
+		return img, false, nil
 	}
 	errs = multierror.Append(errs, err)
 
@@ -33,16 +43,17 @@ func newImage(fileName string) (v1.Image, error) {
 	img, err = tryOCI(fileName)
 	if err == nil {
 		// Return v1.Image if the directory can be opened as OCI Image Format
-		return img, nil
+		return img, true, nil
 	}
 	errs = multierror.Append(errs, err)
 
-	return nil, errs
+	return nil, false, errs
 }
 
 type archiveImage struct {
 	v1.Image
 	name string
+	oci  bool
 }
 
 func (img archiveImage) Name() string {
@@ -62,3 +73,12 @@ func (archiveImage) RepoTags() []string {
 func (archiveImage) RepoDigests() []string {
 	return nil
 }
+
+// Annotations returns annotations in the manifest of an OCI layout.
+// Docker archives have no annotations, and computing their manifest requires compressing all layers.
+func (img archiveImage) Annotations() map[string]string {
+	if !img.oci {
+		return nil
+	}
+	return manifestAnnotations(img)
+}
@@ -0,0 +1,37 @@
+package image
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/image/daemon"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// NewRunningContainerImage returns the image of the running container including its writable layer.
+// Only Docker Engine is supported as other runtimes don't expose the changes of containers via API.
+func NewRunningContainerImage(ctx context.Context, containerID string, opt types.ImageOptions) (types.Image, func(), error) {
+	img, drift, cleanup, err := daemon.DockerContainer(ctx, containerID, opt.DockerOptions.Host)
+	if err != nil {
+		cleanup()
+		return nil, func() {}, xerrors.Errorf("docker container error: %w", err)
+	}
+	return runningContainerImage{
+		daemonImage: daemonImage{
+			Image: img,
+			name:  containerID,
+		},
+		drift: drift,
+	}, cleanup, nil
+}
+
+// runningContainerImage is the image of a running container, which holds the files changed since it started
+type runningContainerImage struct {
+	daemonImage
+	drift types.ContainerDrift
+}
+
+func (img runningContainerImage) ContainerDrift() types.ContainerDrift {
+	return img.drift
+}
@@ -21,8 +21,8 @@ func tryDockerDaemon(_ context.Context, imageName string, ref name.Reference, op
 
 }
 
-func tryPodmanDaemon(_ context.Context, imageName string, _ name.Reference, _ types.ImageOptions) (types.Image, func(), error) {
-	img, cleanup, err := daemon.PodmanImage(imageName)
+func tryPodmanDaemon(_ context.Context, imageName string, _ name.Reference, opt types.ImageOptions) (types.Image, func(), error) {
+	img, cleanup, err := daemon.PodmanImage(imageName, opt.PodmanOptions)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -32,8 +32,20 @@ func tryPodmanDaemon(_ context.Context, imageName string, _ name.Reference, _ ty
 	}, cleanup, nil
 }
 
-func tryContainerdDaemon(ctx context.Context, imageName string, _ name.Reference, _ types.ImageOptions) (types.Image, func(), error) {
-	img, cleanup, err := daemon.ContainerdImage(ctx, imageName)
+func tryContainerdDaemon(ctx context.Context, imageName string, _ name.Reference, opt types.ImageOptions) (types.Image, func(), error) {
+	img, cleanup, err := daemon.ContainerdImage(ctx, imageName, opt.ContainerdOptions)
+	if err != nil {
+		return nil, cleanup, err
+	}
+
+	return daemonImage{
+		Image: img,
+		name:  imageName,
+	}, cleanup, nil
+}
+
+func tryCRIOStorage(_ context.Context, imageName string, ref name.Reference, opt types.ImageOptions) (types.Image, func(), error) {
+	img, cleanup, err := daemon.CRIOImage(ref, opt.CRIOOptions.StorageRoot)
 	if err != nil {
 		return nil, cleanup, err
 	}
@@ -0,0 +1,274 @@
+package daemon
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// Kinds of changes in the writable layer
+// cf. https://docs.docker.com/engine/api/v1.42/#tag/Container/operation/ContainerChanges
+const (
+	changeModify uint8 = iota
+	changeAdd
+	changeDelete
+)
+
+const whiteoutPrefix = ".wh."
+
+type containerCopier interface {
+	CopyFromContainer(ctx context.Context, containerID, srcPath string) (io.ReadCloser, types.ContainerPathStat, error)
+}
+
+// DockerContainer returns the image of the running container with its writable layer appended,
+// so that files modified after the container started are scanned together with the image.
+// It also returns the files changed in the writable layer.
+// The caller must call cleanup() to remove temporary files.
+func DockerContainer(ctx context.Context, containerID, host string) (Image, ftypes.ContainerDrift, func(), error) {
+	cleanup := func() {}
+
+	c, err := newDockerClient(host)
+	if err != nil {
+		return nil, ftypes.ContainerDrift{}, cleanup, xerrors.Errorf("failed to initialize a docker client: %w", err)
+	}
+
+	inspect, err := c.ContainerInspect(ctx, containerID)
+	if err != nil {
+		_ = c.Close()
+		return nil, ftypes.ContainerDrift{}, cleanup, xerrors.Errorf("unable to inspect the container (%s): %w", containerID, err)
+	}
+
+	changes, err := c.ContainerDiff(ctx, inspect.ID)
+	if err != nil {
+		_ = c.Close()
+		return nil, ftypes.ContainerDrift{}, cleanup, xerrors.Errorf("unable to get changes of the container (%s): %w", containerID, err)
+	}
+
+	f, err := os.CreateTemp("", "fanal-container-*")
+	if err != nil {
+		_ = c.Close()
+		return nil, ftypes.ContainerDrift{}, cleanup, xerrors.Errorf("failed to create a temporary file: %w", err)
+	}
+	cleanup = func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}
+
+	drift, err := writeWritableLayer(ctx, c, inspect.ID, changes, f)
+	_ = c.Close()
+	if err != nil {
+		return nil, ftypes.ContainerDrift{}, cleanup, xerrors.Errorf("unable to export the writable layer: %w", err)
+	}
+	if !drift.Empty() {
+		log.Logger.Warnf("The container %s has drifted from the image: %d added, %d modified and %d deleted files",
+			containerID, len(drift.Added), len(drift.Modified), len(drift.Deleted))
+	}
+
+	layer, err := tarball.LayerFromFile(f.Name())
+	if err != nil {
+		return nil, ftypes.ContainerDrift{}, cleanup, xerrors.Errorf("failed to open the writable layer: %w", err)
+	}
+
+	// The image ID is used instead of the name as the tag may have been moved to another image
+	ref, err := name.ParseReference(strings.TrimPrefix(inspect.Image, "sha256:"))
+	if err != nil {
+		return nil, ftypes.ContainerDrift{}, cleanup, xerrors.Errorf("invalid image ID (%s): %w", inspect.Image, err)
+	}
+	base, cleanupBase, err := DockerImage(ref, host)
+	if err != nil {
+		return nil, ftypes.ContainerDrift{}, cleanup, xerrors.Errorf("unable to get the image of the container (%s): %w", inspect.Image, err)
+	}
+
+	img, err := newContainerImage(base, layer, inspect.ID)
+	if err != nil {
+		cleanupBase()
+		return nil, ftypes.ContainerDrift{}, cleanup, err
+	}
+
+	return img, drift, func() {
+		cleanupBase()
+		cleanup()
+	}, nil
+}
+
+// writeWritableLayer writes the changed files into a tar layer. Deleted files are represented as whiteout files.
+func writeWritableLayer(ctx context.Context, c containerCopier, containerID string,
+	changes []container.ContainerChangeResponseItem, w io.Writer) (ftypes.ContainerDrift, error) {
+	// Parent directories must come first
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	var drift ftypes.ContainerDrift
+	tw := tar.NewWriter(w)
+	for _, change := range changes {
+		name := strings.TrimPrefix(path.Clean(change.Path), "/")
+		if name == "" || name == "." {
+			continue
+		}
+
+		if change.Kind == changeDelete {
+			drift.Deleted = append(drift.Deleted, name)
+			dir, base := path.Split(name)
+			err := tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeReg,
+				Name:     dir + whiteoutPrefix + base,
+				Mode:     0644,
+				ModTime:  time.Unix(0, 0), // for the reproducible diff ID
+			})
+			if err != nil {
+				return ftypes.ContainerDrift{}, xerrors.Errorf("tar header error: %w", err)
+			}
+			continue
+		}
+
+		isDir, err := copyContainerFile(ctx, c, containerID, change.Path, name, tw)
+		if err != nil {
+			return ftypes.ContainerDrift{}, err
+		}
+		switch {
+		case isDir:
+			// Only files are reported as drift
+		case change.Kind == changeAdd:
+			drift.Added = append(drift.Added, name)
+		default:
+			drift.Modified = append(drift.Modified, name)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return ftypes.ContainerDrift{}, xerrors.Errorf("tar close error: %w", err)
+	}
+	return drift, nil
+}
+
+// copyContainerFile copies the file from the container into the tar writer.
+// The content of directories is not copied as changed files are listed individually.
+func copyContainerFile(ctx context.Context, c containerCopier, containerID, srcPath, name string,
+	tw *tar.Writer) (bool, error) {
+	rc, _, err := c.CopyFromContainer(ctx, containerID, srcPath)
+	if err != nil {
+		return false, xerrors.Errorf("unable to copy %s from the container: %w", srcPath, err)
+	}
+	defer rc.Close()
+
+	tr := tar.NewReader(rc)
+	hdr, err := tr.Next()
+	if err != nil {
+		return false, xerrors.Errorf("invalid archive of %s: %w", srcPath, err)
+	}
+
+	hdr.Name = name
+	isDir := hdr.Typeflag == tar.TypeDir
+	if isDir {
+		hdr.Name += "/"
+	}
+	if err = tw.WriteHeader(hdr); err != nil {
+		return false, xerrors.Errorf("tar header error: %w", err)
+	}
+	if hdr.Typeflag == tar.TypeReg {
+		if _, err = io.Copy(tw, tr); err != nil {
+			return false, xerrors.Errorf("unable to copy %s: %w", srcPath, err)
+		}
+	}
+	return isDir, nil
+}
+
+// containerImage is the image of the container with the writable layer on top of it
+type containerImage struct {
+	Image
+	layer      v1.Layer
+	configFile *v1.ConfigFile
+}
+
+func newContainerImage(base Image, layer v1.Layer, containerID string) (Image, error) {
+	baseConfig, err := base.ConfigFile()
+	if err != nil {
+		return nil, xerrors.Errorf("unable to get the config of the image: %w", err)
+	}
+	diffID, err := layer.DiffID()
+	if err != nil {
+		return nil, xerrors.Errorf("unable to get the diff ID of the writable layer: %w", err)
+	}
+
+	config := baseConfig.DeepCopy()
+	config.RootFS.DiffIDs = append(config.RootFS.DiffIDs, diffID)
+	config.History = append(config.History, v1.History{
+		Created:   v1.Time{Time: time.Now().UTC()},
+		CreatedBy: fmt.Sprintf("writable layer of container %s", shortID(containerID)),
+		Comment:   "trivy: changes made after the container started",
+	})
+
+	return &containerImage{
+		Image:      base,
+		layer:      layer,
+		configFile: config,
+	}, nil
+}
+
+func (img *containerImage) ConfigFile() (*v1.ConfigFile, error) {
+	return img.configFile.DeepCopy(), nil
+}
+
+func (img *containerImage) RawConfigFile() ([]byte, error) {
+	return json.Marshal(img.configFile)
+}
+
+func (img *containerImage) ConfigName() (v1.Hash, error) {
+	// The creation time of the writable layer is excluded so that the same changes result in the same ID
+	config := img.configFile.DeepCopy()
+	config.History[len(config.History)-1].Created = v1.Time{}
+	b, err := json.Marshal(config)
+	if err != nil {
+		return v1.Hash{}, xerrors.Errorf("json marshal error: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return v1.Hash{
+		Algorithm: "sha256",
+		Hex:       hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+func (img *containerImage) Layers() ([]v1.Layer, error) {
+	layers, err := img.Image.Layers()
+	if err != nil {
+		return nil, err
+	}
+	return append(layers, img.layer), nil
+}
+
+func (img *containerImage) LayerByDiffID(h v1.Hash) (v1.Layer, error) {
+	diffID, err := img.layer.DiffID()
+	if err != nil {
+		return nil, err
+	}
+	if h == diffID {
+		return img.layer, nil
+	}
+	return img.Image.LayerByDiffID(h)
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
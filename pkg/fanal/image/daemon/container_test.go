@@ -0,0 +1,98 @@
+package daemon
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+type fakeCopier map[string]string // path => content ("/" suffix for directories)
+
+func (c fakeCopier) CopyFromContainer(_ context.Context, _, srcPath string) (io.ReadCloser, types.ContainerPathStat, error) {
+	content := c[srcPath]
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     srcPath,
+		Mode:     0644,
+		Size:     int64(len(content)),
+	}
+	if content == "/" {
+		hdr.Typeflag = tar.TypeDir
+		hdr.Size = 0
+		hdr.Mode = 0755
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return nil, types.ContainerPathStat{}, err
+	}
+	if hdr.Typeflag == tar.TypeReg {
+		if _, err := tw.Write([]byte(content)); err != nil {
+			return nil, types.ContainerPathStat{}, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, types.ContainerPathStat{}, err
+	}
+	return io.NopCloser(&buf), types.ContainerPathStat{}, nil
+}
+
+func Test_writeWritableLayer(t *testing.T) {
+	copier := fakeCopier{
+		"/etc":              "/",
+		"/etc/passwd":       "root:x:0:0:root:/root:/bin/sh\n",
+		"/usr/bin/backdoor": "#!/bin/sh\n",
+		"/usr/bin":          "/",
+	}
+	changes := []container.ContainerChangeResponseItem{
+		{Kind: changeAdd, Path: "/usr/bin/backdoor"},
+		{Kind: changeModify, Path: "/etc/passwd"},
+		{Kind: changeModify, Path: "/etc"},
+		{Kind: changeDelete, Path: "/etc/shadow"},
+		{Kind: changeModify, Path: "/usr/bin"},
+	}
+
+	var buf bytes.Buffer
+	got, err := writeWritableLayer(context.Background(), copier, "abc", changes, &buf)
+	require.NoError(t, err)
+
+	want := ftypes.ContainerDrift{
+		Added:    []string{"usr/bin/backdoor"},
+		Modified: []string{"etc/passwd"},
+		Deleted:  []string{"etc/shadow"},
+	}
+	assert.Equal(t, want, got)
+	assert.False(t, got.Empty())
+
+	files := map[string]string{}
+	tr := tar.NewReader(&buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		b, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(b)
+	}
+
+	wantFiles := map[string]string{
+		"etc/":             "",
+		"etc/.wh.shadow":   "",
+		"etc/passwd":       "root:x:0:0:root:/root:/bin/sh\n",
+		"usr/bin/":         "",
+		"usr/bin/backdoor": "#!/bin/sh\n",
+	}
+	assert.Equal(t, wantFiles, files)
+}
@@ -24,6 +24,8 @@ import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/samber/lo"
 	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
 )
 
 const (
@@ -67,10 +69,10 @@ func imageWriter(client *containerd.Client, img containerd.Image) imageSave {
 }
 
 // ContainerdImage implements v1.Image
-func ContainerdImage(ctx context.Context, imageName string) (Image, func(), error) {
+func ContainerdImage(ctx context.Context, imageName string, opts types.ContainerdOptions) (Image, func(), error) {
 	cleanup := func() {}
 
-	addr := os.Getenv("CONTAINERD_ADDRESS")
+	addr, _ := lo.Coalesce(opts.Address, os.Getenv("CONTAINERD_ADDRESS"))
 	if addr == "" {
 		// TODO: support rootless
 		addr = defaultContainerdSocket
@@ -90,9 +92,11 @@ func ContainerdImage(ctx context.Context, imageName string) (Image, func(), erro
 		return nil, cleanup, xerrors.Errorf("failed to initialize a containerd client: %w", err)
 	}
 
-	if _, ok := namespaces.Namespace(ctx); !ok {
-                ctx = namespaces.WithNamespace(ctx, defaultContainerdNamespace)
-        }
+	if ns, ok := lo.Coalesce(opts.Namespace, os.Getenv("CONTAINERD_NAMESPACE")); ok {
+		ctx = namespaces.WithNamespace(ctx, ns)
+	} else if _, ok := namespaces.Namespace(ctx); !ok {
+		ctx = namespaces.WithNamespace(ctx, defaultContainerdNamespace)
+	}
 
 	imgs, err := client.ListImages(ctx, searchFilters...)
 	if err != nil {
@@ -0,0 +1,266 @@
+package daemon
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	v1types "github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/samber/lo"
+	"github.com/vbatts/tar-split/tar/asm"
+	"github.com/vbatts/tar-split/tar/storage"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// defaultCRIOStorageRoot is the default graph root of containers/storage used by CRI-O
+	defaultCRIOStorageRoot = "/var/lib/containers/storage"
+
+	// CRI-O only supports the overlay driver in practice
+	crioStorageDriver = "overlay"
+)
+
+// crioImage represents an entry of "overlay-images/images.json" in containers/storage.
+type crioImage struct {
+	ID     string   `json:"id"`
+	Digest string   `json:"digest,omitempty"`
+	Names  []string `json:"names,omitempty"`
+}
+
+// crioLayer represents an entry of "overlay-layers/layers.json" in containers/storage.
+type crioLayer struct {
+	ID     string `json:"id"`
+	DiffID string `json:"diff-digest,omitempty"`
+}
+
+type crioStore struct {
+	root   string
+	images []crioImage
+	layers map[string]crioLayer // diff ID => layer
+}
+
+func newCRIOStore(root string) (*crioStore, error) {
+	if root == "" {
+		root = os.Getenv("CONTAINERS_STORAGE_ROOT")
+	}
+	if root == "" {
+		root = defaultCRIOStorageRoot
+	}
+
+	var images []crioImage
+	if err := readJSON(filepath.Join(root, crioStorageDriver+"-images", "images.json"), &images); err != nil {
+		return nil, xerrors.Errorf("unable to read CRI-O images: %w", err)
+	}
+
+	var layers []crioLayer
+	if err := readJSON(filepath.Join(root, crioStorageDriver+"-layers", "layers.json"), &layers); err != nil {
+		return nil, xerrors.Errorf("unable to read CRI-O layers: %w", err)
+	}
+
+	return &crioStore{
+		root:   root,
+		images: images,
+		layers: lo.SliceToMap(layers, func(l crioLayer) (string, crioLayer) {
+			return l.DiffID, l
+		}),
+	}, nil
+}
+
+func readJSON(filePath string, v any) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	if err = json.NewDecoder(f).Decode(v); err != nil {
+		return xerrors.Errorf("json decode error (%s): %w", filePath, err)
+	}
+	return nil
+}
+
+// lookup finds the image matching the given reference.
+// Names are normalized so that "alpine:3.17" matches "docker.io/library/alpine:3.17".
+func (s *crioStore) lookup(ref name.Reference) (crioImage, bool) {
+	for _, img := range s.images {
+		if d, ok := ref.(name.Digest); ok && d.DigestStr() == img.Digest {
+			return img, true
+		}
+		for _, n := range img.Names {
+			r, err := name.ParseReference(n)
+			if err != nil {
+				continue
+			}
+			if r.Name() == ref.Name() {
+				return img, true
+			}
+		}
+	}
+	return crioImage{}, false
+}
+
+// bigData returns the content of the item stored along with the image, such as the manifest and the config.
+// Keys that are not safe as file names are encoded in base64 with the "=" prefix by containers/storage.
+func (s *crioStore) bigData(imageID, key string) ([]byte, error) {
+	fileName := key
+	if strings.ContainsAny(key, ":/") || strings.HasPrefix(key, "=") {
+		fileName = "=" + base64.StdEncoding.EncodeToString([]byte(key))
+	}
+	return os.ReadFile(filepath.Join(s.root, crioStorageDriver+"-images", imageID, fileName))
+}
+
+// crioImageCore implements partial.UncompressedImageCore
+type crioImageCore struct {
+	store     *crioStore
+	rawConfig []byte
+}
+
+func (c crioImageCore) RawConfigFile() ([]byte, error) {
+	return c.rawConfig, nil
+}
+
+func (c crioImageCore) MediaType() (v1types.MediaType, error) {
+	return v1types.DockerManifestSchema2, nil
+}
+
+func (c crioImageCore) LayerByDiffID(h v1.Hash) (partial.UncompressedLayer, error) {
+	l, ok := c.store.layers[h.String()]
+	if !ok {
+		return nil, xerrors.Errorf("layer not found in CRI-O storage: %s", h)
+	}
+	return crioUncompressedLayer{
+		store:  c.store,
+		layer:  l,
+		diffID: h,
+	}, nil
+}
+
+// crioUncompressedLayer reassembles the original layer tarball from the unpacked files and tar-split metadata.
+type crioUncompressedLayer struct {
+	store  *crioStore
+	layer  crioLayer
+	diffID v1.Hash
+}
+
+func (l crioUncompressedLayer) DiffID() (v1.Hash, error) {
+	return l.diffID, nil
+}
+
+func (l crioUncompressedLayer) Uncompressed() (io.ReadCloser, error) {
+	tarSplit := filepath.Join(l.store.root, crioStorageDriver+"-layers", l.layer.ID+".tar-split.gz")
+	f, err := os.Open(tarSplit)
+	if err != nil {
+		return nil, xerrors.Errorf("tar-split open error: %w", err)
+	}
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, xerrors.Errorf("gzip error: %w", err)
+	}
+
+	diffDir := filepath.Join(l.store.root, crioStorageDriver, l.layer.ID, "diff")
+	rc := asm.NewOutputTarStream(storage.NewPathFileGetter(diffDir), storage.NewJSONUnpacker(gr))
+	return closer{
+		ReadCloser: rc,
+		closeFunc: func() error {
+			_ = gr.Close()
+			return f.Close()
+		},
+	}, nil
+}
+
+func (l crioUncompressedLayer) MediaType() (v1types.MediaType, error) {
+	return v1types.DockerLayer, nil
+}
+
+type closer struct {
+	io.ReadCloser
+	closeFunc func() error
+}
+
+func (c closer) Close() error {
+	err := c.ReadCloser.Close()
+	if cerr := c.closeFunc(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+type crioDaemonImage struct {
+	v1.Image
+	repoTags    []string
+	repoDigests []string
+}
+
+func (img crioDaemonImage) RepoTags() []string {
+	return img.repoTags
+}
+
+func (img crioDaemonImage) RepoDigests() []string {
+	return img.repoDigests
+}
+
+// CRIOImage implements v1.Image by reading the image from containers/storage used by CRI-O directly,
+// so that it can be scanned on Kubernetes nodes without talking to the CRI-O daemon.
+func CRIOImage(ref name.Reference, storageRoot string) (Image, func(), error) {
+	cleanup := func() {}
+
+	store, err := newCRIOStore(storageRoot)
+	if err != nil {
+		return nil, cleanup, err
+	}
+
+	img, ok := store.lookup(ref)
+	if !ok {
+		return nil, cleanup, xerrors.Errorf("image not found in CRI-O storage: %s", ref.Name())
+	}
+
+	manifest, err := store.bigData(img.ID, "manifest")
+	if err != nil {
+		return nil, cleanup, xerrors.Errorf("unable to read the manifest: %w", err)
+	}
+	m, err := v1.ParseManifest(bytes.NewReader(manifest))
+	if err != nil {
+		return nil, cleanup, xerrors.Errorf("unable to parse the manifest: %w", err)
+	}
+
+	rawConfig, err := store.bigData(img.ID, m.Config.Digest.String())
+	if err != nil {
+		return nil, cleanup, xerrors.Errorf("unable to read the config: %w", err)
+	}
+
+	v1img, err := partial.UncompressedToImage(crioImageCore{
+		store:     store,
+		rawConfig: rawConfig,
+	})
+	if err != nil {
+		return nil, cleanup, xerrors.Errorf("image error: %w", err)
+	}
+
+	var repoTags, repoDigests []string
+	for _, n := range img.Names {
+		r, err := name.ParseReference(n)
+		if err != nil {
+			continue
+		}
+		repoTags = append(repoTags, n)
+		if img.Digest != "" {
+			repoDigests = append(repoDigests, fmt.Sprintf("%s@%s", r.Context().Name(), img.Digest))
+		}
+	}
+
+	return crioDaemonImage{
+		Image:       v1img,
+		repoTags:    repoTags,
+		repoDigests: lo.Uniq(repoDigests),
+	}, cleanup, nil
+}
@@ -0,0 +1,195 @@
+package daemon
+
+import (
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"hash/crc64"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vbatts/tar-split/tar/asm"
+	"github.com/vbatts/tar-split/tar/storage"
+)
+
+// diffPutter writes files into the "diff" directory as the overlay driver does.
+type diffPutter struct {
+	dir string
+}
+
+func (p diffPutter) Put(fileName string, r io.Reader) (int64, []byte, error) {
+	filePath := filepath.Join(p.dir, fileName)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return 0, nil, err
+	}
+	f, err := os.Create(filePath)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer f.Close()
+
+	c := crc64.New(storage.CRCTable)
+	n, err := io.Copy(io.MultiWriter(f, c), r)
+	return n, c.Sum(nil), err
+}
+
+// setupCRIOStorage converts the image archive into containers/storage layout.
+func setupCRIOStorage(t *testing.T, archive string, names []string) string {
+	t.Helper()
+
+	img, err := tarball.Image(func() (io.ReadCloser, error) {
+		f, err := os.Open(archive)
+		if err != nil {
+			return nil, err
+		}
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		return gr, nil
+	}, nil)
+	require.NoError(t, err)
+
+	root := t.TempDir()
+	imageID := "8a1e25ce7c4f75e372e9884f8f7b1bedcfe4a7a7d452eb4b0a1c7477c9a90345"
+	imageDir := filepath.Join(root, "overlay-images", imageID)
+	layerDir := filepath.Join(root, "overlay-layers")
+	require.NoError(t, os.MkdirAll(imageDir, 0755))
+	require.NoError(t, os.MkdirAll(layerDir, 0755))
+
+	layers, err := img.Layers()
+	require.NoError(t, err)
+
+	var layerEntries []crioLayer
+	for i, layer := range layers {
+		diffID, err := layer.DiffID()
+		require.NoError(t, err)
+
+		id := diffID.Hex
+		layerEntries = append(layerEntries, crioLayer{
+			ID:     id,
+			DiffID: diffID.String(),
+		})
+
+		f, err := os.Create(filepath.Join(layerDir, id+".tar-split.gz"))
+		require.NoError(t, err)
+		gw := gzip.NewWriter(f)
+
+		rc, err := layers[i].Uncompressed()
+		require.NoError(t, err)
+
+		r, err := asm.NewInputTarStream(rc, storage.NewJSONPacker(gw), diffPutter{
+			dir: filepath.Join(root, "overlay", id, "diff"),
+		})
+		require.NoError(t, err)
+		_, err = io.Copy(io.Discard, r)
+		require.NoError(t, err)
+
+		require.NoError(t, rc.Close())
+		require.NoError(t, gw.Close())
+		require.NoError(t, f.Close())
+	}
+
+	manifest, err := img.RawManifest()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(imageDir, "manifest"), manifest, 0644))
+
+	configName, err := img.ConfigName()
+	require.NoError(t, err)
+	config, err := img.RawConfigFile()
+	require.NoError(t, err)
+	configFile := "=" + base64.StdEncoding.EncodeToString([]byte(configName.String()))
+	require.NoError(t, os.WriteFile(filepath.Join(imageDir, configFile), config, 0644))
+
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	writeJSON(t, filepath.Join(root, "overlay-images", "images.json"), []crioImage{
+		{
+			ID:     imageID,
+			Digest: digest.String(),
+			Names:  names,
+		},
+	})
+	writeJSON(t, filepath.Join(layerDir, "layers.json"), layerEntries)
+
+	return root
+}
+
+func writeJSON(t *testing.T, filePath string, v any) {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filePath, b, 0644))
+}
+
+func TestCRIOImage(t *testing.T) {
+	root := setupCRIOStorage(t, "../../test/testdata/alpine-311.tar.gz", []string{"docker.io/library/alpine:3.11"})
+
+	tests := []struct {
+		name           string
+		imageName      string
+		wantConfigName string
+		wantDiffIDs    []string
+		wantRepoTags   []string
+		wantErr        string
+	}{
+		{
+			name:           "happy path",
+			imageName:      "alpine:3.11",
+			wantConfigName: "sha256:a187dde48cd289ac374ad8539930628314bc581a481cdb41409c9289419ddb72",
+			wantDiffIDs: []string{
+				"sha256:beee9f30bc1f711043e78d4a2be0668955d4b761d587d6f60c2c8dc081efb203",
+			},
+			wantRepoTags: []string{"docker.io/library/alpine:3.11"},
+		},
+		{
+			name:      "unknown image",
+			imageName: "alpine:3.10",
+			wantErr:   "image not found in CRI-O storage",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := name.ParseReference(tt.imageName)
+			require.NoError(t, err)
+
+			img, cleanup, err := CRIOImage(ref, root)
+			defer cleanup()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			configName, err := img.ConfigName()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantConfigName, configName.String())
+
+			configFile, err := img.ConfigFile()
+			require.NoError(t, err)
+
+			var diffIDs []string
+			for _, d := range configFile.RootFS.DiffIDs {
+				diffIDs = append(diffIDs, d.String())
+
+				// The reassembled layer must match the original diff ID
+				layer, err := img.LayerByDiffID(d)
+				require.NoError(t, err)
+				rc, err := layer.Uncompressed()
+				require.NoError(t, err)
+				got, _, err := v1.SHA256(rc)
+				require.NoError(t, err)
+				require.NoError(t, rc.Close())
+				assert.Equal(t, d, got)
+			}
+			assert.Equal(t, tt.wantDiffIDs, diffIDs)
+			assert.Equal(t, tt.wantRepoTags, img.RepoTags())
+		})
+	}
+}
@@ -14,16 +14,7 @@ import (
 func DockerImage(ref name.Reference, host string) (Image, func(), error) {
 	cleanup := func() {}
 
-	opts := []client.Opt{
-		client.FromEnv,
-		client.WithAPIVersionNegotiation(),
-	}
-	if host != "" {
-		// adding host parameter to the last assuming it will pick up more preference
-		opts = append(opts, client.WithHost(host))
-	}
-	c, err := client.NewClientWithOpts(opts...)
-
+	c, err := newDockerClient(host)
 	if err != nil {
 		return nil, cleanup, xerrors.Errorf("failed to initialize a docker client: %w", err)
 	}
@@ -68,3 +59,15 @@ func DockerImage(ref name.Reference, host string) (Image, func(), error) {
 		history: configHistory(history),
 	}, cleanup, nil
 }
+
+func newDockerClient(host string) (*client.Client, error) {
+	opts := []client.Opt{
+		client.FromEnv,
+		client.WithAPIVersionNegotiation(),
+	}
+	if host != "" {
+		// adding host parameter to the last assuming it will pick up more preference
+		opts = append(opts, client.WithHost(host))
+	}
+	return client.NewClientWithOpts(opts...)
+}
@@ -7,12 +7,16 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
 	api "github.com/docker/docker/api/types"
 	dimage "github.com/docker/docker/api/types/image"
 	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
 )
 
 var (
@@ -21,30 +25,84 @@ var (
 	saveURL    = "http://podman/images/%s/get"
 )
 
+const defaultRootfulPodmanSocket = "/run/podman/podman.sock"
+
 type podmanClient struct {
 	c http.Client
 }
 
-func newPodmanClient() (podmanClient, error) {
-	// Get Podman socket location
-	sockDir := os.Getenv("XDG_RUNTIME_DIR")
-	socket := filepath.Join(sockDir, "podman", "podman.sock")
+type dialFunc func(ctx context.Context) (net.Conn, error)
 
-	if _, err := os.Stat(socket); err != nil {
-		return podmanClient{}, xerrors.Errorf("no podman socket found: %w", err)
+func newPodmanClient(host string) (podmanClient, error) {
+	dial, err := podmanDialer(host)
+	if err != nil {
+		return podmanClient{}, err
 	}
 
 	return podmanClient{
 		c: http.Client{
 			Transport: &http.Transport{
-				DialContext: func(_ context.Context, _, _ string) (net.Conn, error) {
-					return net.Dial("unix", socket)
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return dial(ctx)
 				},
 			},
 		},
 	}, nil
 }
 
+// podmanDialer returns a function connecting to the Podman API socket.
+// The host can be a socket path, "unix:///path/to/podman.sock" or "ssh://user@host[:port]/path/to/podman.sock".
+// If the host is empty, CONTAINER_HOST, the rootless socket and the rootful socket are tried in this order.
+func podmanDialer(host string) (dialFunc, error) {
+	if host == "" {
+		host = os.Getenv("CONTAINER_HOST")
+	}
+	if host == "" {
+		socket, err := defaultPodmanSocket()
+		if err != nil {
+			return nil, err
+		}
+		host = socket
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid podman host (%s): %w", host, err)
+	}
+
+	switch u.Scheme {
+	case "", "unix":
+		socket := u.Path
+		if _, err = os.Stat(socket); err != nil {
+			return nil, xerrors.Errorf("no podman socket found: %w", err)
+		}
+		return func(ctx context.Context) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		}, nil
+	case "ssh":
+		return sshDialer(u, os.Getenv("CONTAINER_SSHKEY"))
+	default:
+		return nil, xerrors.Errorf("unsupported podman host scheme: %s", u.Scheme)
+	}
+}
+
+// defaultPodmanSocket returns the rootless socket if it exists, otherwise the rootful socket.
+func defaultPodmanSocket() (string, error) {
+	var sockets []string
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		sockets = append(sockets, filepath.Join(runtimeDir, "podman", "podman.sock"))
+	}
+	sockets = append(sockets, defaultRootfulPodmanSocket)
+
+	for _, socket := range sockets {
+		if _, err := os.Stat(socket); err == nil {
+			return socket, nil
+		}
+	}
+	return "", xerrors.Errorf("no podman socket found: %s", strings.Join(sockets, ", "))
+}
+
 type errResponse struct {
 	Message string
 }
@@ -109,10 +167,10 @@ func (p podmanClient) imageSave(_ context.Context, imageNames []string) (io.Read
 
 // PodmanImage implements v1.Image by extending daemon.Image.
 // The caller must call cleanup() to remove a temporary file.
-func PodmanImage(ref string) (Image, func(), error) {
+func PodmanImage(ref string, opts types.PodmanOptions) (Image, func(), error) {
 	cleanup := func() {}
 
-	c, err := newPodmanClient()
+	c, err := newPodmanClient(opts.Host)
 	if err != nil {
 		return nil, cleanup, xerrors.Errorf("unable to initialize Podman client: %w", err)
 	}
@@ -0,0 +1,71 @@
+package daemon
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/sshutil"
+)
+
+// sshDialer returns a function connecting to the remote Podman socket over SSH,
+// e.g. ssh://core@localhost:52431/run/user/1000/podman/podman.sock
+// The private key is taken from the given identity file or the SSH agent.
+func sshDialer(u *url.URL, identity string) (dialFunc, error) {
+	if u.Path == "" {
+		return nil, xerrors.Errorf("no socket path in podman host: %s", u.Redacted())
+	}
+
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), sshutil.DefaultPort)
+	}
+
+	return func(ctx context.Context) (net.Conn, error) {
+		// The connection to the SSH agent is needed only during the handshake
+		config, cleanup, err := sshutil.ClientConfig(u, identity)
+		defer cleanup()
+		if err != nil {
+			return nil, xerrors.Errorf("ssh config error: %w", err)
+		}
+
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, xerrors.Errorf("ssh dial error: %w", err)
+		}
+		c, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+		if err != nil {
+			_ = conn.Close()
+			return nil, xerrors.Errorf("ssh handshake error: %w", err)
+		}
+		client := ssh.NewClient(c, chans, reqs)
+
+		socket, err := client.Dial("unix", u.Path)
+		if err != nil {
+			_ = client.Close()
+			return nil, xerrors.Errorf("unable to connect to the remote podman socket: %w", err)
+		}
+		return sshConn{
+			Conn:   socket,
+			client: client,
+		}, nil
+	}, nil
+}
+
+// sshConn closes the SSH client together with the forwarded connection.
+type sshConn struct {
+	net.Conn
+	client *ssh.Client
+}
+
+func (c sshConn) Close() error {
+	err := c.Conn.Close()
+	if cerr := c.client.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
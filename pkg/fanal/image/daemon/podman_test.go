@@ -1,6 +1,7 @@
 package daemon
 
 import (
+	"context"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
@@ -15,6 +16,8 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 )
 
 func setupPodmanSock(t *testing.T) *httptest.Server {
@@ -85,7 +88,7 @@ func TestPodmanImage(t *testing.T) {
 			ref, err := name.ParseReference(tt.imageName)
 			require.NoError(t, err)
 
-			img, cleanup, err := PodmanImage(ref.Name())
+			img, cleanup, err := PodmanImage(ref.Name(), ftypes.PodmanOptions{})
 			defer cleanup()
 
 			if tt.wantErr {
@@ -108,3 +111,67 @@ func TestPodmanImage(t *testing.T) {
 		})
 	}
 }
+
+func Test_podmanDialer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("podman.sock is not available for Windows CI")
+	}
+
+	te := setupPodmanSock(t)
+	defer te.Close()
+	socket := filepath.Join(os.Getenv("XDG_RUNTIME_DIR"), "podman", "podman.sock")
+
+	tests := []struct {
+		name    string
+		host    string
+		env     string
+		wantErr string
+	}{
+		{
+			name: "rootless socket",
+		},
+		{
+			name: "unix scheme",
+			host: "unix://" + socket,
+		},
+		{
+			name: "socket path",
+			host: socket,
+		},
+		{
+			name: "CONTAINER_HOST",
+			env:  "unix://" + socket,
+		},
+		{
+			name:    "missing socket",
+			host:    "unix:///path/to/missing.sock",
+			wantErr: "no podman socket found",
+		},
+		{
+			name:    "ssh without socket path",
+			host:    "ssh://core@localhost:2222",
+			wantErr: "no socket path in podman host",
+		},
+		{
+			name:    "unsupported scheme",
+			host:    "tcp://localhost:8080",
+			wantErr: "unsupported podman host scheme",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CONTAINER_HOST", tt.env)
+
+			dial, err := podmanDialer(tt.host)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			conn, err := dial(context.Background())
+			require.NoError(t, err)
+			require.NoError(t, conn.Close())
+		})
+	}
+}
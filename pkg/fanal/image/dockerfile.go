@@ -0,0 +1,92 @@
+package image
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+// buildkitSuffix is appended to instructions in the history of images built with BuildKit
+const buildkitSuffix = "# buildkit"
+
+// exposedPortsPattern matches EXPOSE instructions recorded by BuildKit, e.g. "EXPOSE map[22/tcp:{} 8080/tcp:{}]"
+var exposedPortsPattern = regexp.MustCompile(`(\d+(?:/\w+)?):\{\}`)
+
+// ReconstructDockerfile reconstructs the Dockerfile from the image history.
+// Layers of the base image are excluded, see GuessBaseImageIndex.
+func ReconstructDockerfile(config *v1.ConfigFile) []byte {
+	if config == nil {
+		return nil
+	}
+
+	dockerfile := new(bytes.Buffer)
+	var hasUser bool
+	baseLayerIndex := GuessBaseImageIndex(config.History)
+	for i := baseLayerIndex + 1; i < len(config.History); i++ {
+		h := config.History[i]
+		var createdBy string
+		switch {
+		case strings.HasPrefix(h.CreatedBy, "/bin/sh -c #(nop)"):
+			// Instruction other than RUN
+			createdBy = strings.TrimPrefix(h.CreatedBy, "/bin/sh -c #(nop)")
+		case strings.HasPrefix(h.CreatedBy, "/bin/sh -c"):
+			// RUN instruction
+			createdBy = strings.ReplaceAll(h.CreatedBy, "/bin/sh -c", "RUN")
+		case strings.HasPrefix(h.CreatedBy, "USER"):
+			// USER instruction
+			createdBy = h.CreatedBy
+		case strings.HasPrefix(h.CreatedBy, "EXPOSE map["):
+			// EXPOSE instruction built with BuildKit
+			var ports []string
+			for _, m := range exposedPortsPattern.FindAllStringSubmatch(h.CreatedBy, -1) {
+				ports = append(ports, m[1])
+			}
+			createdBy = "EXPOSE " + strings.Join(ports, " ")
+		case strings.HasSuffix(h.CreatedBy, buildkitSuffix):
+			// RUN, COPY and ADD instructions built with BuildKit
+			createdBy = strings.TrimSuffix(h.CreatedBy, buildkitSuffix)
+			createdBy = strings.Replace(createdBy, "RUN /bin/sh -c", "RUN", 1)
+		case strings.HasPrefix(h.CreatedBy, "HEALTHCHECK"):
+			// HEALTHCHECK instruction
+			createdBy = healthcheck(config.Config.Healthcheck)
+		}
+		createdBy = strings.TrimSpace(createdBy)
+		if strings.HasPrefix(createdBy, "USER") {
+			hasUser = true
+		}
+		dockerfile.WriteString(createdBy + "\n")
+	}
+
+	// The user might be set in the base image
+	if !hasUser && config.Config.User != "" {
+		dockerfile.WriteString("USER " + config.Config.User + "\n")
+	}
+
+	return dockerfile.Bytes()
+}
+
+func healthcheck(hc *v1.HealthConfig) string {
+	if hc == nil {
+		return "HEALTHCHECK"
+	}
+
+	var interval, timeout, startPeriod, retries, command string
+	if hc.Interval != 0 {
+		interval = fmt.Sprintf("--interval=%s ", hc.Interval)
+	}
+	if hc.Timeout != 0 {
+		timeout = fmt.Sprintf("--timeout=%s ", hc.Timeout)
+	}
+	if hc.StartPeriod != 0 {
+		startPeriod = fmt.Sprintf("--startPeriod=%s ", hc.StartPeriod)
+	}
+	if hc.Retries != 0 {
+		retries = fmt.Sprintf("--retries=%d ", hc.Retries)
+	}
+	command = strings.Join(hc.Test, " ")
+	command = strings.ReplaceAll(command, "CMD-SHELL", "CMD")
+	return fmt.Sprintf("HEALTHCHECK %s%s%s%s%s", interval, timeout, startPeriod, retries, command)
+}
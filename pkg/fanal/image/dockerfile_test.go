@@ -0,0 +1,99 @@
+package image
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconstructDockerfile(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *v1.ConfigFile
+		want   string
+	}{
+		{
+			name: "docker",
+			config: &v1.ConfigFile{
+				History: []v1.History{
+					{
+						CreatedBy: "/bin/sh -c #(nop) ADD file:e4d600fc4c9c293efe360be7b30ee96579925d1b4634c94332e2ec73f7d8eca1 in / ",
+					},
+					{
+						CreatedBy:  `/bin/sh -c #(nop)  CMD ["/bin/sh"]`,
+						EmptyLayer: true,
+					},
+					{
+						CreatedBy: "/bin/sh -c apk add --no-cache curl",
+					},
+					{
+						CreatedBy:  "/bin/sh -c #(nop)  EXPOSE 8080",
+						EmptyLayer: true,
+					},
+					{
+						CreatedBy:  `/bin/sh -c #(nop)  ENTRYPOINT ["/app"]`,
+						EmptyLayer: true,
+					},
+				},
+			},
+			want: `RUN apk add --no-cache curl
+EXPOSE 8080
+ENTRYPOINT ["/app"]
+`,
+		},
+		{
+			name: "buildkit",
+			config: &v1.ConfigFile{
+				Config: v1.Config{
+					User: "nobody",
+					Healthcheck: &v1.HealthConfig{
+						Test:     []string{"CMD-SHELL", "curl -f http://localhost/ || exit 1"},
+						Interval: 30 * time.Second,
+						Retries:  3,
+					},
+				},
+				History: []v1.History{
+					{
+						CreatedBy: "ADD file:e4d600fc4c9c293efe360be7b30ee96579925d1b4634c94332e2ec73f7d8eca1 in / ",
+					},
+					{
+						CreatedBy:  `CMD ["/bin/sh"]`,
+						EmptyLayer: true,
+					},
+					{
+						CreatedBy: "RUN /bin/sh -c apk add --no-cache curl # buildkit",
+					},
+					{
+						CreatedBy: "COPY app /app # buildkit",
+					},
+					{
+						CreatedBy:  "EXPOSE map[22/tcp:{} 8080/tcp:{}]",
+						EmptyLayer: true,
+					},
+					{
+						CreatedBy:  `HEALTHCHECK &{["CMD-SHELL" "curl -f http://localhost/ || exit 1"] "30s" "0s" "0s" '\x03'}`,
+						EmptyLayer: true,
+					},
+				},
+			},
+			want: `RUN apk add --no-cache curl
+COPY app /app
+EXPOSE 22/tcp 8080/tcp
+HEALTHCHECK --interval=30s --retries=3 CMD curl -f http://localhost/ || exit 1
+USER nobody
+`,
+		},
+		{
+			name: "nil config",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ReconstructDockerfile(tt.config)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
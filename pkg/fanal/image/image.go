@@ -18,6 +18,7 @@ type imageSourceFunc func(ctx context.Context, imageName string, ref name.Refere
 var imageSourceFuncs = map[types.ImageSource]imageSourceFunc{
 	types.ContainerdImageSource: tryContainerdDaemon,
 	types.PodmanImageSource:     tryPodmanDaemon,
+	types.CRIOImageSource:       tryCRIOStorage,
 	types.DockerImageSource:     tryDockerDaemon,
 	types.RemoteImageSource:     tryRemote,
 }
@@ -64,6 +65,16 @@ func ID(img v1.Image) (string, error) {
 	return h.String(), nil
 }
 
+// manifestAnnotations returns annotations in the image manifest, e.g. org.opencontainers.image.source
+func manifestAnnotations(img v1.Image) map[string]string {
+	m, err := img.Manifest()
+	if err != nil {
+		log.Logger.Debugf("Unable to get the image manifest: %s", err)
+		return nil
+	}
+	return m.Annotations
+}
+
 func LayerIDs(img v1.Image) ([]string, error) {
 	conf, err := img.ConfigFile()
 	if err != nil {
@@ -133,3 +144,34 @@ func GuessBaseImageIndex(histories []v1.History) int {
 	}
 	return baseImageIndex
 }
+
+// GuessBaseDiffIDs guesses layers in base image (call base layers) and returns their diff IDs.
+func GuessBaseDiffIDs(diffIDs []string, configFile *v1.ConfigFile) []string {
+	if configFile == nil {
+		return nil
+	}
+
+	baseImageIndex := GuessBaseImageIndex(configFile.History)
+
+	// Diff IDs don't include empty layers, so the index is different from histories
+	var diffIDIndex int
+	var baseDiffIDs []string
+	for i, h := range configFile.History {
+		// It is no longer base layer.
+		if i > baseImageIndex {
+			break
+		}
+		// Empty layers are not included in diff IDs.
+		if h.EmptyLayer {
+			continue
+		}
+
+		if diffIDIndex >= len(diffIDs) {
+			// something wrong...
+			return nil
+		}
+		baseDiffIDs = append(baseDiffIDs, diffIDs[diffIDIndex])
+		diffIDIndex++
+	}
+	return baseDiffIDs
+}
@@ -3,28 +3,22 @@ package image
 import (
 	"strings"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/layout"
 	ispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"golang.org/x/xerrors"
-)
-
-func tryOCI(fileName string) (v1.Image, error) {
-	var inputRef, inputFileName string
 
-	// Check if tag is specified in input
-	// e.g. /path/to/oci:0.0.1
-
-	inputFileName, inputRef, found := strings.Cut(fileName, "@")
+	"github.com/zhanglimao/trivy/pkg/fanal/log"
+)
 
-	if !found {
-		inputFileName, inputRef, found = strings.Cut(fileName, ":")
-	}
+// OCIDirPrefix explicitly specifies the input is an OCI layout directory, e.g. oci-dir:/path/to/oci@sha256:...
+const OCIDirPrefix = "oci-dir:"
 
-	if !found {
-		inputFileName = fileName
-		inputRef = ""
-	}
+func tryOCI(fileName string) (v1.Image, error) {
+	// Check if tag or digest is specified in input
+	// e.g. /path/to/oci:0.0.1, /path/to/oci@sha256:...
+	inputFileName, inputRef := splitOCIRef(strings.TrimPrefix(fileName, OCIDirPrefix))
 
 	lp, err := layout.FromPath(inputFileName)
 	if err != nil {
@@ -45,17 +39,34 @@ func tryOCI(fileName string) (v1.Image, error) {
 		return nil, xerrors.New("no valid manifest")
 	}
 
+	if inputRef == "" && len(m.Manifests) > 1 {
+		log.Logger.Warnf("Multiple images found in %s, the first one is scanned. "+
+			"Specify the tag or the digest to select the image, e.g. %s@%s", inputFileName, inputFileName,
+			m.Manifests[0].Digest)
+	}
+
 	// Support image having tag separated by : , otherwise support first image
 	return getOCIImage(m, index, inputRef)
 }
 
+// splitOCIRef splits the input into the path and the tag or digest.
+func splitOCIRef(fileName string) (string, string) {
+	if inputFileName, inputRef, found := strings.Cut(fileName, "@"); found {
+		return inputFileName, inputRef
+	}
+
+	// The tag must not contain a path separator, e.g. ./oci:latest
+	i := strings.LastIndex(fileName, ":")
+	if i < 0 || strings.ContainsAny(fileName[i+1:], `/\`) {
+		return fileName, ""
+	}
+	return fileName[:i], fileName[i+1:]
+}
+
 func getOCIImage(m *v1.IndexManifest, index v1.ImageIndex, inputRef string) (v1.Image, error) {
 	for _, manifest := range m.Manifests {
-		annotation := manifest.Annotations
-		tag := annotation[ispec.AnnotationRefName]
 		if inputRef == "" || // always select the first digest
-			tag == inputRef ||
-			manifest.Digest.String() == inputRef {
+			matchOCIRef(manifest, inputRef) {
 			h := manifest.Digest
 			if manifest.MediaType.IsIndex() {
 				childIndex, err := index.ImageIndex(h)
@@ -78,5 +89,64 @@ func getOCIImage(m *v1.IndexManifest, index v1.ImageIndex, inputRef string) (v1.
 		}
 	}
 
+	// The digest may point to a manifest in a nested index, e.g. a specific platform of a multi-arch image
+	if strings.HasPrefix(inputRef, "sha256:") {
+		for _, manifest := range m.Manifests {
+			if !manifest.MediaType.IsIndex() {
+				continue
+			}
+			childIndex, err := index.ImageIndex(manifest.Digest)
+			if err != nil {
+				continue
+			}
+			childManifest, err := childIndex.IndexManifest()
+			if err != nil {
+				continue
+			}
+			if img, err := getOCIImage(childManifest, childIndex, inputRef); err == nil {
+				return img, nil
+			}
+		}
+	}
+
 	return nil, xerrors.New("invalid OCI image ref")
 }
+
+// matchOCIRef checks if the descriptor matches the digest, the tag or the reference.
+// The tag annotation may hold a full reference, e.g. docker.io/library/alpine:3.17.
+// A full reference in the input is compared by the repository and the tag or digest,
+// while a tag is compared only with the tag of the reference.
+func matchOCIRef(desc v1.Descriptor, inputRef string) bool {
+	if desc.Digest.String() == inputRef {
+		return true
+	}
+	annotation := desc.Annotations[ispec.AnnotationRefName]
+	if annotation == "" {
+		return false
+	} else if annotation == inputRef {
+		return true
+	} else if !isFullRef(annotation) {
+		return false
+	}
+
+	ref, err := name.ParseReference(annotation)
+	if err != nil {
+		return false
+	}
+	if !isFullRef(inputRef) {
+		tag, ok := ref.(name.Tag)
+		return ok && tag.TagStr() == inputRef
+	}
+
+	input, err := name.ParseReference(inputRef)
+	if err != nil {
+		return false
+	}
+	return ref.Context().Name() == input.Context().Name() && ref.Identifier() == input.Identifier()
+}
+
+// isFullRef returns true if the reference has the repository in addition to the tag or digest,
+// e.g. alpine:3.17 and docker.io/library/alpine@sha256:...
+func isFullRef(ref string) bool {
+	return strings.ContainsAny(ref, ":/@")
+}
@@ -4,7 +4,14 @@ import (
 	"path/filepath"
 	"testing"
 
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTryOCI(t *testing.T) {
@@ -55,6 +62,11 @@ func TestTryOCI(t *testing.T) {
 				"single@sha256:1111111111111111111111111111111111111111111111111111111111111111"),
 			wantErr: "invalid OCI image ref",
 		},
+		{
+			name:         "oci-dir prefix with correct tag",
+			ociImagePath: "oci-dir:" + filepath.Join("testdata", "single:3.14"),
+			wantErr:      "",
+		},
 	}
 
 	for _, test := range tests {
@@ -69,3 +81,101 @@ func TestTryOCI(t *testing.T) {
 		})
 	}
 }
+
+func TestTryOCI_MultipleImages(t *testing.T) {
+	img1, err := random.Image(100, 1)
+	require.NoError(t, err)
+	img2, err := random.Image(100, 1)
+	require.NoError(t, err)
+	img3, err := random.Image(100, 1)
+	require.NoError(t, err)
+
+	// A multi-arch index containing img3
+	childIndex := mutate.AppendManifests(empty.Index, mutate.IndexAddendum{
+		Add: img3,
+		Descriptor: v1.Descriptor{
+			Platform: &v1.Platform{OS: "linux", Architecture: "arm64"},
+		},
+	})
+
+	dir := t.TempDir()
+	lp, err := layout.Write(dir, empty.Index)
+	require.NoError(t, err)
+	require.NoError(t, lp.AppendImage(img1, layout.WithAnnotations(map[string]string{
+		ispec.AnnotationRefName: "docker.io/library/alpine:3.17",
+	})))
+	require.NoError(t, lp.AppendImage(img2, layout.WithAnnotations(map[string]string{
+		ispec.AnnotationRefName: "3.18",
+	})))
+	require.NoError(t, lp.AppendIndex(childIndex))
+
+	digest := func(img v1.Image) string {
+		d, err := img.Digest()
+		require.NoError(t, err)
+		return d.String()
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "no reference",
+			input: dir,
+			want:  digest(img1),
+		},
+		{
+			name:  "digest",
+			input: dir + "@" + digest(img2),
+			want:  digest(img2),
+		},
+		{
+			name:  "digest in a nested index",
+			input: dir + "@" + digest(img3),
+			want:  digest(img3),
+		},
+		{
+			name:  "tag in a full reference",
+			input: dir + ":3.17",
+			want:  digest(img1),
+		},
+		{
+			name:  "tag",
+			input: "oci-dir:" + dir + ":3.18",
+			want:  digest(img2),
+		},
+		{
+			name:    "unknown tag",
+			input:   dir + ":3.19",
+			wantErr: "invalid OCI image ref",
+		},
+		{
+			name:  "full reference",
+			input: dir + "@docker.io/library/alpine:3.17",
+			want:  digest(img1),
+		},
+		{
+			name:  "normalized reference",
+			input: dir + "@alpine:3.17",
+			want:  digest(img1),
+		},
+		{
+			name:    "reference in another repository with the same tag",
+			input:   dir + "@docker.io/library/myalpine:3.17",
+			wantErr: "invalid OCI image ref",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := tryOCI(tt.input)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, digest(img))
+		})
+	}
+}
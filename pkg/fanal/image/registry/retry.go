@@ -0,0 +1,125 @@
+package registry
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/log"
+)
+
+const (
+	initialBackoff = 1 * time.Second
+	maxBackoff     = 1 * time.Minute
+
+	// The scan fails without waiting if the registry asks to wait longer than this, e.g. Docker Hub pull limits.
+	maxRetryAfter = 5 * time.Minute
+)
+
+// retryTransport retries requests which are rate-limited (429) or temporarily unavailable (503),
+// respecting the Retry-After header if present, or with jittered exponential backoff otherwise.
+type retryTransport struct {
+	inner      http.RoundTripper
+	maxRetries int
+
+	// for testing
+	sleep func(req *http.Request, d time.Duration) error
+}
+
+// NewRetryTransport wraps the transport so that rate-limited requests are retried up to maxRetries times.
+func NewRetryTransport(inner http.RoundTripper, maxRetries int) http.RoundTripper {
+	if maxRetries <= 0 {
+		return inner
+	}
+	return &retryTransport{
+		inner:      inner,
+		maxRetries: maxRetries,
+		sleep:      sleepWithContext,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.inner.RoundTrip(req)
+		if err != nil || attempt >= t.maxRetries || !retryable(req, resp) {
+			return resp, err
+		}
+
+		wait, ok := retryAfter(resp, time.Now())
+		if !ok {
+			wait = backoff(attempt)
+		} else if wait > maxRetryAfter {
+			log.Logger.Warnf("%s asks to retry after %s, giving up", req.URL.Host, wait)
+			return resp, nil
+		}
+		log.Logger.Warnf("%s returned %d, retrying in %s (%d/%d)...", req.URL.Host, resp.StatusCode,
+			wait.Round(time.Millisecond), attempt+1, t.maxRetries)
+
+		// Drain the body so that the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if err = t.sleep(req, wait); err != nil {
+			return nil, err
+		}
+
+		if req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+func retryable(req *http.Request, resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+	default:
+		return false
+	}
+	// The request body must be rewindable
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// retryAfter parses the Retry-After header, which is either seconds or an HTTP date.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// backoff returns the exponential backoff with jitter, i.e. a random duration between d/2 and d.
+func backoff(attempt int) time.Duration {
+	d := initialBackoff << attempt
+	if d > maxBackoff || d <= 0 {
+		d = maxBackoff
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func sleepWithContext(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,109 @@
+package registry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransport_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		responses  []int
+		retryAfter string
+		wantStatus int
+		wantCalls  int
+		wantWaits  []time.Duration
+	}{
+		{
+			name:       "rate limited once",
+			maxRetries: 3,
+			responses:  []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter: "2",
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+			wantWaits:  []time.Duration{2 * time.Second},
+		},
+		{
+			name:       "service unavailable without Retry-After",
+			maxRetries: 3,
+			responses:  []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  3,
+		},
+		{
+			name:       "exceed max retries",
+			maxRetries: 2,
+			responses:  []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests},
+			retryAfter: "1",
+			wantStatus: http.StatusTooManyRequests,
+			wantCalls:  3,
+			wantWaits:  []time.Duration{time.Second, time.Second},
+		},
+		{
+			name:       "Retry-After too long",
+			maxRetries: 3,
+			responses:  []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter: "21600",
+			wantStatus: http.StatusTooManyRequests,
+			wantCalls:  1,
+		},
+		{
+			name:       "not retryable",
+			maxRetries: 3,
+			responses:  []int{http.StatusNotFound},
+			wantStatus: http.StatusNotFound,
+			wantCalls:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.responses[calls])
+				calls++
+			}))
+			defer ts.Close()
+
+			var waits []time.Duration
+			tr := NewRetryTransport(http.DefaultTransport, tt.maxRetries).(*retryTransport)
+			tr.sleep = func(_ *http.Request, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}
+
+			req, err := http.NewRequest(http.MethodGet, ts.URL, http.NoBody)
+			require.NoError(t, err)
+
+			resp, err := tr.RoundTrip(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantCalls, calls)
+			if tt.wantWaits != nil {
+				assert.Equal(t, tt.wantWaits, waits)
+			}
+		})
+	}
+}
+
+func Test_backoff(t *testing.T) {
+	for attempt := 0; attempt < 10; attempt++ {
+		d := initialBackoff << attempt
+		if d > maxBackoff {
+			d = maxBackoff
+		}
+		got := backoff(attempt)
+		assert.GreaterOrEqual(t, got, d/2)
+		assert.LessOrEqual(t, got, d)
+	}
+}
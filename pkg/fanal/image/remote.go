@@ -31,6 +31,7 @@ func tryRemote(ctx context.Context, imageName string, ref name.Reference, option
 		Image:      img,
 		ref:        implicitReference{ref: ref},
 		descriptor: desc,
+		option:     option.RegistryOptions,
 	}, cleanup, nil
 
 }
@@ -39,6 +40,7 @@ type remoteImage struct {
 	name       string
 	ref        implicitReference
 	descriptor *remote.Descriptor
+	option     types.RegistryOptions
 	v1.Image
 }
 
@@ -63,6 +65,10 @@ func (img remoteImage) RepoDigests() []string {
 	return []string{repoDigest}
 }
 
+func (img remoteImage) Annotations() map[string]string {
+	return manifestAnnotations(img)
+}
+
 type implicitReference struct {
 	ref name.Reference
 }
@@ -0,0 +1,106 @@
+package image
+
+import (
+	"context"
+	"io"
+
+	"github.com/containerd/stargz-snapshotter/estargz"
+	"github.com/containerd/stargz-snapshotter/estargz/zstdchunked"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/remote"
+)
+
+// SeekableImage is implemented by images whose layers can be partially fetched,
+// i.e. eStargz or zstd:chunked layers in a container registry.
+type SeekableImage interface {
+	// SeekableLayer returns a reader of the layer which allows random access to the file entries.
+	// It returns nil if the layer is not seekable.
+	SeekableLayer(ctx context.Context, diffID v1.Hash) (*SeekableLayer, error)
+}
+
+// SeekableLayer represents a layer that can be read per file entry without fetching the whole layer
+type SeekableLayer struct {
+	*estargz.Reader
+
+	// Digest is the digest of the compressed layer
+	Digest v1.Hash
+
+	verifier estargz.TOCEntryVerifier
+}
+
+// Verifier returns a verifier of the given chunk so that the partially fetched content can be verified
+func (l *SeekableLayer) Verifier(ce *estargz.TOCEntry) (digest.Verifier, error) {
+	return l.verifier.Verifier(ce)
+}
+
+func (img remoteImage) SeekableLayer(ctx context.Context, diffID v1.Hash) (*SeekableLayer, error) {
+	desc, err := img.layerDescriptor(diffID)
+	if err != nil {
+		return nil, xerrors.Errorf("layer descriptor error: %w", err)
+	}
+
+	var opts []estargz.OpenOption
+	tocDigest, ok := desc.Annotations[estargz.TOCJSONDigestAnnotation]
+	if !ok {
+		if tocDigest, ok = desc.Annotations[zstdchunked.ManifestChecksumAnnotation]; !ok {
+			// Neither eStargz nor zstd:chunked
+			return nil, nil
+		}
+		opts = append(opts, estargz.WithDecompressors(new(zstdchunked.Decompressor)))
+	}
+
+	tocDgst, err := digest.Parse(tocDigest)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid TOC digest (%s): %w", tocDigest, err)
+	}
+
+	blob := img.ref.ref.Context().Digest(desc.Digest.String())
+	ra, err := remote.BlobReaderAt(ctx, blob, img.option)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to read the blob (%s): %w", desc.Digest, err)
+	}
+
+	r, err := estargz.Open(io.NewSectionReader(ra, 0, desc.Size), opts...)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to open the seekable layer (%s): %w", desc.Digest, err)
+	}
+
+	// The TOC must match the digest in the manifest as the content of the layer is not verified as a whole.
+	verifier, err := r.VerifyTOC(tocDgst)
+	if err != nil {
+		return nil, xerrors.Errorf("TOC verification error (%s): %w", desc.Digest, err)
+	}
+
+	return &SeekableLayer{
+		Reader:   r,
+		Digest:   desc.Digest,
+		verifier: verifier,
+	}, nil
+}
+
+// layerDescriptor returns the manifest descriptor of the layer with the given diff ID.
+func (img remoteImage) layerDescriptor(diffID v1.Hash) (v1.Descriptor, error) {
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return v1.Descriptor{}, xerrors.Errorf("config error: %w", err)
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return v1.Descriptor{}, xerrors.Errorf("manifest error: %w", err)
+	}
+	if len(configFile.RootFS.DiffIDs) != len(manifest.Layers) {
+		return v1.Descriptor{}, xerrors.New("the number of layers doesn't match")
+	}
+
+	for i, d := range configFile.RootFS.DiffIDs {
+		if d == diffID {
+			return manifest.Layers[i], nil
+		}
+	}
+	return v1.Descriptor{}, xerrors.Errorf("layer not found: %s", diffID)
+}
+
+var _ SeekableImage = remoteImage{}
@@ -0,0 +1,92 @@
+package sshutil
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/xerrors"
+)
+
+// DefaultPort is the default port of SSH servers
+const DefaultPort = "22"
+
+// ClientConfig returns the SSH client config for the URL, e.g. ssh://user@host:22/path
+// The private key is taken from the given identity file or the SSH agent, and the host key is verified with known_hosts.
+// The caller must call the returned function to close the connection to the SSH agent once the handshake is done.
+func ClientConfig(u *url.URL, identity string) (*ssh.ClientConfig, func(), error) {
+	cleanup := func() {}
+
+	username := u.User.Username()
+	if username == "" {
+		current, err := user.Current()
+		if err != nil {
+			return nil, cleanup, xerrors.Errorf("unable to get the current user: %w", err)
+		}
+		username = current.Username
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, cleanup, xerrors.Errorf("unable to get the home directory: %w", err)
+	}
+	hostKeyCallback, err := knownhosts.New(filepath.Join(home, ".ssh", "known_hosts"))
+	if err != nil {
+		return nil, cleanup, xerrors.Errorf("unable to read known_hosts: %w", err)
+	}
+
+	var auths []ssh.AuthMethod
+	if identity != "" {
+		key, err := os.ReadFile(identity)
+		if err != nil {
+			return nil, cleanup, xerrors.Errorf("unable to read the identity file: %w", err)
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, cleanup, xerrors.Errorf("unable to parse the identity file: %w", err)
+		}
+		auths = append(auths, ssh.PublicKeys(signer))
+	}
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if conn, err := net.Dial("unix", sock); err == nil {
+			auths = append(auths, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+			cleanup = func() { _ = conn.Close() }
+		}
+	}
+	if password, ok := u.User.Password(); ok {
+		auths = append(auths, ssh.Password(password))
+	}
+	if len(auths) == 0 {
+		return nil, cleanup, xerrors.New("no SSH authentication method available, specify the identity file or set SSH_AUTH_SOCK")
+	}
+
+	return &ssh.ClientConfig{
+		User:            username,
+		Auth:            auths,
+		HostKeyCallback: hostKeyCallback,
+	}, cleanup, nil
+}
+
+// Dial connects to the SSH server specified by the URL
+func Dial(u *url.URL, identity string) (*ssh.Client, error) {
+	config, cleanup, err := ClientConfig(u, identity)
+	defer cleanup()
+	if err != nil {
+		return nil, xerrors.Errorf("ssh client config error: %w", err)
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = DefaultPort
+	}
+	client, err := ssh.Dial("tcp", net.JoinHostPort(u.Hostname(), port), config)
+	if err != nil {
+		return nil, xerrors.Errorf("ssh dial error: %w", err)
+	}
+	return client, nil
+}
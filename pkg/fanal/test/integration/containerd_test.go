@@ -287,6 +287,7 @@ func localImageTestWithNamespace(t *testing.T, namespace string) {
 				},
 				RepoTags:    []string{"ghcr.io/aquasecurity/trivy-test-images:alpine-310"},
 				RepoDigests: []string{"ghcr.io/aquasecurity/trivy-test-images@sha256:f12582b2f2190f350e3904462c1c23aaf366b4f76705e97b199f9bbded1d816a"},
+				Digest:      "sha256:f12582b2f2190f350e3904462c1c23aaf366b4f76705e97b199f9bbded1d816a",
 				ConfigFile: v1.ConfigFile{
 					Architecture: "amd64",
 					Created: v1.Time{
@@ -354,6 +355,7 @@ func localImageTestWithNamespace(t *testing.T, namespace string) {
 				},
 				RepoTags:    []string{"ghcr.io/aquasecurity/trivy-test-images:vulnimage"},
 				RepoDigests: []string{"ghcr.io/aquasecurity/trivy-test-images@sha256:e74abbfd81e00baaf464cf9e09f8b24926e5255171e3150a60aa341ce064688f"},
+				Digest:      "sha256:e74abbfd81e00baaf464cf9e09f8b24926e5255171e3150a60aa341ce064688f",
 				ConfigFile: v1.ConfigFile{
 					Architecture: "amd64",
 					Created: v1.Time{
@@ -743,6 +745,7 @@ func TestContainerd_PullImage(t *testing.T) {
 				},
 				RepoTags:    []string{"ghcr.io/aquasecurity/trivy-test-images:alpine-310"},
 				RepoDigests: []string{"ghcr.io/aquasecurity/trivy-test-images@sha256:72c42ed48c3a2db31b7dafe17d275b634664a708d901ec9fd57b1529280f01fb"},
+				Digest:      "sha256:72c42ed48c3a2db31b7dafe17d275b634664a708d901ec9fd57b1529280f01fb",
 				ConfigFile: v1.ConfigFile{
 					Architecture: "amd64",
 					Created: v1.Time{
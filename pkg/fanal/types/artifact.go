@@ -34,6 +34,14 @@ func (o *OS) Merge(new OS) {
 	// In that case, OS must be overwritten with the content of /etc/oracle-release.
 	// There is the same problem between Debian and Ubuntu.
 	case o.Family == aos.RedHat, o.Family == aos.Debian:
+		// Debian has ELTS program: https://www.freexian.com/lts/extended/
+		// The ELTS status is stored in a different file from the OS version.
+		if new.Family == o.Family {
+			new.Extended = new.Extended || o.Extended
+			if new.Name == "" {
+				new.Name = o.Name
+			}
+		}
 		*o = new
 	default:
 		if o.Family == "" {
@@ -54,12 +62,73 @@ func (o *OS) Merge(new OS) {
 type Repository struct {
 	Family  string `json:",omitempty"`
 	Release string `json:",omitempty"`
+
+	// Repositories pinned with a tag, keyed by the tag (only for Alpine)
+	// e.g. "@testing https://dl-cdn.alpinelinux.org/alpine/edge/testing"
+	Tags map[string]TaggedRepository `json:",omitempty"`
+
+	// Repositories the packages are installed from, keyed by the package name (only for Amazon Linux)
+	// e.g. "docker" => "amzn2extra-docker"
+	Packages map[string]string `json:",omitempty"`
+
+	// Enabled module streams, keyed by the module name (only for Red Hat based distributions)
+	// e.g. "nodejs" => "18"
+	Modules map[string]string `json:",omitempty"`
+}
+
+// Merge returns the repository merged with the one detected in another file or a later layer.
+// The release is overwritten, while the repositories of packages and module streams are accumulated
+// since they are detected file by file.
+func (r *Repository) Merge(new *Repository) *Repository {
+	if r == nil {
+		return new
+	} else if new == nil {
+		return r
+	}
+
+	merged := *new
+	if new.Family == "" && new.Release == "" {
+		merged.Family, merged.Release, merged.Tags = r.Family, r.Release, r.Tags
+	}
+	merged.Packages = mergeMap(r.Packages, new.Packages)
+	merged.Modules = mergeMap(r.Modules, new.Modules)
+	return &merged
+}
+
+func mergeMap(old, new map[string]string) map[string]string {
+	if len(old) == 0 {
+		return new
+	}
+	merged := make(map[string]string, len(old)+len(new))
+	for k, v := range old {
+		merged[k] = v
+	}
+	for k, v := range new {
+		merged[k] = v
+	}
+	return merged
+}
+
+type TaggedRepository struct {
+	Release string `json:",omitempty"` // e.g. edge, 3.18
+	Name    string `json:",omitempty"` // e.g. main, community, testing
+}
+
+// AnalyzedBy represents the analyzer which found the result, so that unexpected findings can be traced
+// and the analyzer can be disabled precisely.
+type AnalyzedBy struct {
+	Type    string `json:",omitempty"` // e.g. "npm", "dpkg"
+	Version int    `json:",omitempty"`
 }
 
 type Layer struct {
 	Digest    string `json:",omitempty"`
 	DiffID    string `json:",omitempty"`
 	CreatedBy string `json:",omitempty"`
+
+	// BaseLayer is true when the layer belongs to the base image.
+	// It is filled only when base image detection is enabled.
+	BaseLayer bool `json:",omitempty"`
 }
 
 type Package struct {
@@ -78,6 +147,11 @@ type Package struct {
 
 	Modularitylabel string     `json:",omitempty"` // only for Red Hat based distributions
 	BuildInfo       *BuildInfo `json:",omitempty"` // only for Red Hat
+	RepositoryTag   string     `json:",omitempty"` // only for Alpine, e.g. "testing" for "curl@testing"
+
+	// InstallScripts holds lifecycle scripts run on installation, e.g. {"postinstall": "node install.js"}.
+	// Only for npm packages under node_modules.
+	InstallScripts map[string]string `json:",omitempty"`
 
 	Ref      string `json:",omitempty"` // identifier which can be used to reference the component elsewhere
 	Indirect bool   `json:",omitempty"` // this package is direct dependency of the project or not
@@ -88,6 +162,10 @@ type Package struct {
 
 	Layer Layer `json:",omitempty"`
 
+	// AnalyzedBy is the analyzer which found the package.
+	// It is not a property of the package and doesn't change the package ID in SPDX.
+	AnalyzedBy *AnalyzedBy `json:",omitempty" hash:"ignore"`
+
 	// Each package metadata have the file path, while the package from lock files does not have.
 	FilePath string `json:",omitempty"`
 
@@ -159,8 +237,9 @@ type SrcPackage struct {
 }
 
 type PackageInfo struct {
-	FilePath string
-	Packages Packages
+	FilePath   string
+	Packages   Packages
+	AnalyzedBy *AnalyzedBy `json:",omitempty"`
 }
 
 type Application struct {
@@ -172,6 +251,9 @@ type Application struct {
 
 	// Libraries is a list of lang-specific packages
 	Libraries []Package
+
+	// AnalyzedBy is the analyzer which found the packages
+	AnalyzedBy *AnalyzedBy `json:",omitempty"`
 }
 
 type File struct {
@@ -184,15 +266,26 @@ type File struct {
 type ArtifactType string
 
 const (
-	ArtifactContainerImage   ArtifactType = "container_image"
-	ArtifactFilesystem       ArtifactType = "filesystem"
-	ArtifactRemoteRepository ArtifactType = "repository"
-	ArtifactCycloneDX        ArtifactType = "cyclonedx"
-	ArtifactSPDX             ArtifactType = "spdx"
-	ArtifactAWSAccount       ArtifactType = "aws_account"
-	ArtifactVM               ArtifactType = "vm"
+	ArtifactContainerImage    ArtifactType = "container_image"
+	ArtifactFilesystem        ArtifactType = "filesystem"
+	ArtifactRemoteRepository  ArtifactType = "repository"
+	ArtifactCycloneDX         ArtifactType = "cyclonedx"
+	ArtifactSPDX              ArtifactType = "spdx"
+	ArtifactAWSAccount        ArtifactType = "aws_account"
+	ArtifactAzureSubscription ArtifactType = "azure_subscription"
+	ArtifactGoogleProject     ArtifactType = "google_project"
+	ArtifactKubernetesCluster ArtifactType = "kubernetes_cluster"
+	ArtifactVM                ArtifactType = "vm"
+	ArtifactPackageURL        ArtifactType = "purl"
 )
 
+// CloudArtifactTypes lists the types of cloud accounts scanned by 'trivy aws', 'trivy azure' and 'trivy gcp'
+var CloudArtifactTypes = []ArtifactType{
+	ArtifactAWSAccount,
+	ArtifactAzureSubscription,
+	ArtifactGoogleProject,
+}
+
 // ArtifactReference represents a reference of container image, local filesystem and repository
 type ArtifactReference struct {
 	Name          string // image name, tar file name, directory or repository name
@@ -203,6 +296,23 @@ type ArtifactReference struct {
 
 	// SBOM
 	CycloneDX *CycloneDX
+
+	// Warnings hold problems which are not recorded in the cached blobs, e.g. layers which couldn't be analyzed
+	Warnings []Warning `json:",omitempty"`
+
+	// ContainerDrift holds the files changed after the container started, only for running containers
+	ContainerDrift *ContainerDrift `json:",omitempty"`
+}
+
+// ContainerDrift represents the files changed in the writable layer of a running container since it started
+type ContainerDrift struct {
+	Added    []string
+	Modified []string
+	Deleted  []string
+}
+
+func (d ContainerDrift) Empty() bool {
+	return len(d.Added) == 0 && len(d.Modified) == 0 && len(d.Deleted) == 0
 }
 
 type ImageMetadata struct {
@@ -210,6 +320,8 @@ type ImageMetadata struct {
 	DiffIDs     []string // uncompressed layer IDs
 	RepoTags    []string
 	RepoDigests []string
+	Digest      string            // manifest digest resolved from the tag
+	Annotations map[string]string // manifest annotations, e.g. org.opencontainers.image.source
 	ConfigFile  v1.ConfigFile
 }
 
@@ -259,6 +371,22 @@ type BlobInfo struct {
 	// CustomResources hold analysis results from custom analyzers.
 	// It is for extensibility and not used in OSS.
 	CustomResources []CustomResource `json:",omitempty"`
+
+	// Digests hold SHA-256 digests of executable files, e.g. "usr/bin/curl" => "sha256:..."
+	Digests map[string]string `json:",omitempty"`
+
+	// FileSizes hold sizes of regular files in the layer, e.g. "usr/bin/curl" => 239080
+	FileSizes map[string]int64 `json:",omitempty"`
+
+	// PackageFiles hold digests of files recorded in the package databases in the layer
+	PackageFiles []PackageFiles `json:",omitempty"`
+
+	// FileDigests hold digests of system files in the layer to be verified against the package databases,
+	// e.g. "usr/bin/curl" => ["md5:...", "sha256:..."]
+	FileDigests map[string][]digest.Digest `json:",omitempty"`
+
+	// Warnings hold problems which made the analysis result incomplete
+	Warnings []Warning `json:",omitempty"`
 }
 
 // ArtifactDetail is generated by applying blobs
@@ -277,6 +405,85 @@ type ArtifactDetail struct {
 	// CustomResources hold analysis results from custom analyzers.
 	// It is for extensibility and not used in OSS.
 	CustomResources []CustomResource `json:",omitempty"`
+
+	// Digests hold SHA-256 digests of executable files
+	Digests map[string]string `json:",omitempty"`
+
+	// ImageSize holds per-layer sizes and the wasted space of the image
+	ImageSize *ImageSize `json:",omitempty"`
+
+	// ModifiedFiles hold files installed by OS packages whose digests differ from the package databases
+	ModifiedFiles []ModifiedFile `json:",omitempty"`
+
+	// Warnings hold problems which made the analysis result incomplete
+	Warnings []Warning `json:",omitempty"`
+}
+
+// ImageSize represents per-layer sizes and the space wasted by files removed or overwritten in upper layers
+type ImageSize struct {
+	Layers []LayerSize
+
+	// TotalSize is the total size of files in all layers
+	TotalSize int64
+
+	// WastedSize is the size of files in lower layers which are removed or overwritten in upper layers.
+	// They are shipped with the image but invisible in the container.
+	WastedSize int64
+
+	// WastedFiles hold the files wasting the most space
+	WastedFiles []WastedFile `json:",omitempty"`
+}
+
+// LayerSize represents sizes of files added and removed in a layer
+type LayerSize struct {
+	DiffID    string
+	CreatedBy string `json:",omitempty"`
+
+	// AddedSize and AddedFiles are for files added or modified in the layer
+	AddedSize  int64
+	AddedFiles int
+
+	// RemovedSize and RemovedFiles are for files in lower layers removed or overwritten in the layer
+	RemovedSize  int64 `json:",omitempty"`
+	RemovedFiles int   `json:",omitempty"`
+}
+
+// WastedFile represents a file whose copies in lower layers are removed or overwritten
+type WastedFile struct {
+	Path string
+
+	// Size is the total size of the removed or overwritten copies
+	Size int64
+
+	// Copies is the number of the removed or overwritten copies
+	Copies int
+}
+
+// PackageFiles holds files installed by OS packages with the digests recorded in a package database file
+type PackageFiles struct {
+	// FilePath holds the package database, e.g. "var/lib/rpm/rpmdb.sqlite" and "var/lib/dpkg/info/curl.md5sums"
+	FilePath string
+	Files    []PackageFile
+}
+
+// PackageFile represents a file installed by an OS package
+type PackageFile struct {
+	Path    string
+	PkgName string
+	Digest  digest.Digest
+}
+
+// ModifiedFile represents a file installed by an OS package and modified afterwards, like "rpm -V" and "dpkg --verify"
+type ModifiedFile struct {
+	Path    string
+	PkgName string
+
+	// Expected holds the digest in the package database, and Actual holds the one of the file in the image
+	Expected digest.Digest
+	Actual   digest.Digest
+
+	// Layer holds the layer where the file was modified
+	Layer Layer `json:",omitempty"`
 }
 
 // ImageConfigDetail has information from container image config
@@ -308,6 +515,7 @@ func (a *ArtifactDetail) ToBlobInfo() BlobInfo {
 		Secrets:           a.Secrets,
 		Licenses:          a.Licenses,
 		CustomResources:   a.CustomResources,
+		Digests:           a.Digests,
 	}
 }
 
@@ -2,7 +2,7 @@ package types
 
 const (
 	ArtifactJSONSchemaVersion = 1
-	BlobJSONSchemaVersion     = 2
+	BlobJSONSchemaVersion     = 3
 )
 
 const (
@@ -17,6 +17,7 @@ const (
 	Pip        = "pip"
 	Pipenv     = "pipenv"
 	Poetry     = "poetry"
+	Jupyter    = "jupyter"
 	CondaPkg   = "conda-pkg"
 	PythonPkg  = "python-pkg"
 	NodePkg    = "node-pkg"
@@ -25,6 +26,7 @@ const (
 	Jar        = "jar"
 	Pom        = "pom"
 	Gradle     = "gradle"
+	JDK        = "jdk"
 	GoBinary   = "gobinary"
 	GoModule   = "gomod"
 	JavaScript = "javascript"
@@ -34,6 +36,20 @@ const (
 	Pub        = "pub"
 	Hex        = "hex"
 
+	// Language runtimes
+	NodeRuntime   = "node-runtime"
+	PythonRuntime = "python-runtime"
+	RubyRuntime   = "ruby-runtime"
+	PHPRuntime    = "php-runtime"
+
+	// Web servers and middleware installed without package managers
+	Server = "server"
+
+	// Packages installed by alternative package managers
+	Snap     = "snap"
+	Flatpak  = "flatpak"
+	Homebrew = "homebrew"
+
 	// Config files
 	YAML           = "yaml"
 	JSON           = "json"
@@ -15,12 +15,24 @@ const (
 	// PodmanImageSource is the podman runtime
 	PodmanImageSource ImageSource = "podman"
 
+	// CRIOImageSource is the storage of the CRI-O runtime
+	CRIOImageSource ImageSource = "crio"
+
 	// RemoteImageSource represents a remote scan
 	RemoteImageSource ImageSource = "remote"
 )
 
 var (
 	AllImageSources = ImageSources{
+		DockerImageSource,
+		ContainerdImageSource,
+		PodmanImageSource,
+		CRIOImageSource,
+		RemoteImageSource,
+	}
+
+	// DefaultImageSources doesn't include CRI-O, as reading its storage requires root and is opt-in via '--image-src crio'
+	DefaultImageSources = ImageSources{
 		DockerImageSource,
 		ContainerdImageSource,
 		PodmanImageSource,
@@ -41,6 +53,11 @@ type Image interface {
 	ImageExtension
 }
 
+// RunningContainer is implemented by images of running containers
+type RunningContainer interface {
+	ContainerDrift() ContainerDrift
+}
+
 type ImageExtension interface {
 	Name() string
 	ID() (string, error)
@@ -53,6 +70,7 @@ type ImageOptions struct {
 	DockerOptions     DockerOptions
 	PodmanOptions     PodmanOptions
 	ContainerdOptions ContainerdOptions
+	CRIOOptions       CRIOOptions
 	ImageSources      ImageSources
 }
 
@@ -61,11 +79,25 @@ type DockerOptions struct {
 }
 
 type PodmanOptions struct {
-	// TODO
+	// Host is the Podman API socket, e.g. "unix:///run/podman/podman.sock" or "ssh://user@host/run/user/1000/podman/podman.sock".
+	// CONTAINER_HOST or the rootless/rootful default socket is used if empty.
+	Host string
 }
 
 type ContainerdOptions struct {
-	// TODO
+	// Address is the path to the containerd socket.
+	// CONTAINERD_ADDRESS or the default socket is used if empty.
+	Address string
+
+	// Namespace is the containerd namespace where images are stored, e.g. "k8s.io" on Kubernetes nodes.
+	// CONTAINERD_NAMESPACE or the default namespace is used if empty.
+	Namespace string
+}
+
+type CRIOOptions struct {
+	// StorageRoot is the root directory of containers/storage used by CRI-O.
+	// CONTAINERS_STORAGE_ROOT or "/var/lib/containers/storage" is used if empty.
+	StorageRoot string
 }
 
 // ImageSource represents the source of an image. It can be a string that identifies
@@ -85,6 +117,9 @@ type RegistryOptions struct {
 	// SSL/TLS
 	Insecure bool
 
+	// MaxRetries is the number of retries when the registry limits the rate
+	MaxRetries int
+
 	// Architecture
 	Platform Platform
 
@@ -21,11 +21,12 @@ const (
 )
 
 type LicenseFile struct {
-	Type     LicenseType
-	FilePath string
-	PkgName  string
-	Findings LicenseFindings
-	Layer    Layer `json:",omitempty"`
+	Type       LicenseType
+	FilePath   string
+	PkgName    string
+	Findings   LicenseFindings
+	Layer      Layer       `json:",omitempty"`
+	AnalyzedBy *AnalyzedBy `json:",omitempty"`
 }
 
 type LicenseFindings []LicenseFinding
@@ -13,6 +13,7 @@ type Misconfiguration struct {
 	Failures   MisconfResults `json:",omitempty"`
 	Exceptions MisconfResults `json:",omitempty"`
 	Layer      Layer          `json:",omitempty"`
+	AnalyzedBy *AnalyzedBy    `json:",omitempty"`
 }
 
 type MisconfResult struct {
@@ -1,20 +1,27 @@
 package types
 
+import "time"
+
 type SecretRuleCategory string
 
 type Secret struct {
-	FilePath string
-	Findings []SecretFinding
+	FilePath   string
+	Findings   []SecretFinding
+	AnalyzedBy *AnalyzedBy `json:",omitempty"`
 }
 
 type SecretFinding struct {
-	RuleID    string
-	Category  SecretRuleCategory
-	Severity  string
-	Title     string
-	StartLine int
-	EndLine   int
-	Code      Code
-	Match     string
-	Layer     Layer `json:",omitempty"`
+	RuleID     string
+	Category   SecretRuleCategory
+	Severity   string
+	Title      string
+	StartLine  int
+	EndLine    int
+	Code       Code
+	Match      string
+	Layer      Layer       `json:",omitempty"`
+	AnalyzedBy *AnalyzedBy `json:",omitempty"`
+	DueDate    *time.Time  `json:",omitempty"` // Deadline according to the SLA of the severity
+	FirstSeen  *time.Time  `json:",omitempty"` // First seen in the recorded history
+	LastSeen   *time.Time  `json:",omitempty"` // Last seen in the recorded history
 }
@@ -0,0 +1,9 @@
+package types
+
+// Warning represents a problem which didn't stop the analysis but may have made the result incomplete,
+// e.g. an analyzer timed out on a huge lock file.
+type Warning struct {
+	Analyzer string `json:",omitempty"` // e.g. "jar"
+	FilePath string `json:",omitempty"`
+	Message  string
+}
@@ -3,9 +3,11 @@ package walker
 import (
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 
 	swalker "github.com/saracen/walker"
+	"golang.org/x/exp/slices"
 	"golang.org/x/xerrors"
 
 	dio "github.com/aquasecurity/go-dep-parser/pkg/io"
@@ -17,48 +19,62 @@ type ErrorCallback func(pathname string, err error) error
 type FS struct {
 	walker
 	errCallback ErrorCallback
+	symlinks    SymlinkOption
 }
 
-func NewFS(skipFiles, skipDirs []string, slow bool, errCallback ErrorCallback) FS {
+func NewFS(skipFiles, skipDirs []string, slow bool, errCallback ErrorCallback, symlinks SymlinkOption, filter FileFilter) FS {
 	if errCallback == nil {
-		errCallback = func(pathname string, err error) error {
-			// ignore permission errors
-			if os.IsPermission(err) {
-				return nil
-			}
-			// halt traversal on any other error
-			return xerrors.Errorf("unknown error with %s: %w", pathname, err)
-		}
+		errCallback = defaultErrorCallback
 	}
 
+	w := newWalker(skipFiles, skipDirs, slow, filter)
+	w.ignoreCase = caseInsensitive
+
 	return FS{
-		walker:      newWalker(skipFiles, skipDirs, slow),
+		walker:      w,
 		errCallback: errCallback,
+		symlinks:    symlinks,
 	}
 }
 
 // Walk walks the file tree rooted at root, calling WalkFunc for each file or
 // directory in the tree, including root, but a directory to be ignored will be skipped.
+// Symbolic links are followed according to the symlink option.
 func (w FS) Walk(root string, fn WalkFunc) error {
+	var realRoot string
+	if w.symlinks.Follow() {
+		var err error
+		if realRoot, err = filepath.EvalSymlinks(root); err != nil {
+			return w.errCallback(root, err)
+		}
+	}
+	return w.walkDir(root, "", realRoot, nil, fn)
+}
+
+// walkDir walks the directory, reporting paths relative to the directory joined with the prefix.
+// The prefix is a link path when the directory is reached through symbolic links, and chain holds the real paths of them.
+func (w FS) walkDir(dir, prefix, realRoot string, chain []string, fn WalkFunc) error {
 	// walk function called for every path found
 	walkFn := func(pathname string, fi os.FileInfo) error {
 		pathname = filepath.Clean(pathname)
 
 		// For exported rootfs (e.g. images/alpine/etc/alpine-release)
-		relPath, err := filepath.Rel(root, pathname)
+		relPath, err := filepath.Rel(dir, pathname)
 		if err != nil {
 			return xerrors.Errorf("filepath rel (%s): %w", relPath, err)
 		}
-		relPath = filepath.ToSlash(relPath)
+		relPath = path.Join(prefix, filepath.ToSlash(relPath))
 
 		if fi.IsDir() {
 			if w.shouldSkipDir(relPath) {
 				return filepath.SkipDir
 			}
 			return nil
+		} else if fi.Mode()&os.ModeSymlink != 0 {
+			return w.followSymlink(pathname, relPath, realRoot, chain, fn)
 		} else if !fi.Mode().IsRegular() {
 			return nil
-		} else if w.shouldSkipFile(relPath) {
+		} else if w.shouldSkipFile(relPath, fi.Size()) {
 			return nil
 		}
 
@@ -70,11 +86,63 @@ func (w FS) Walk(root string, fn WalkFunc) error {
 
 	if w.slow {
 		// In series: fast, with higher CPU/memory
-		return w.walkSlow(root, walkFn)
+		return w.walkSlow(dir, walkFn)
 	}
 
 	// In parallel: slow, with lower CPU/memory
-	return w.walkFast(root, walkFn)
+	return w.walkFast(dir, walkFn)
+}
+
+// followSymlink analyzes the target file or walks the target directory of the symbolic link with the link path.
+func (w FS) followSymlink(pathname, relPath, realRoot string, chain []string, fn WalkFunc) error {
+	if !w.symlinks.Follow() {
+		return nil
+	} else if len(chain) >= w.symlinks.maxDepth() {
+		log.Logger.Debugf("Skipping the symbolic link exceeding the max depth (%d): %s", w.symlinks.maxDepth(), relPath)
+		return nil
+	}
+
+	target, err := filepath.EvalSymlinks(pathname)
+	if err != nil {
+		log.Logger.Debugf("Unable to resolve the symbolic link (%s): %s", relPath, err)
+		return nil
+	} else if w.symlinks.Policy == SymlinkWithinRoot && !within(realRoot, target) {
+		log.Logger.Debugf("Skipping the symbolic link pointing outside the root: %s -> %s", relPath, target)
+		return nil
+	}
+
+	fi, err := os.Stat(target)
+	if err != nil {
+		return w.errCallback(pathname, err)
+	}
+
+	if fi.Mode().IsRegular() {
+		if w.shouldSkipFile(relPath, fi.Size()) {
+			return nil
+		}
+		if err = fn(relPath, fi, w.fileOpener(target)); err != nil {
+			return xerrors.Errorf("failed to analyze file: %w", err)
+		}
+		return nil
+	} else if !fi.IsDir() || w.shouldSkipDir(relPath) {
+		return nil
+	}
+
+	// Detect loops, e.g. a link to the parent directory or links pointing to each other
+	realParent, err := filepath.EvalSymlinks(filepath.Dir(pathname))
+	if err != nil {
+		return w.errCallback(pathname, err)
+	}
+	for _, dir := range append(chain, realParent) {
+		if within(target, dir) {
+			log.Logger.Debugf("Skipping the symbolic link causing a loop: %s -> %s", relPath, target)
+			return nil
+		}
+	}
+
+	// The chain must not be shared with other directories walked in parallel
+	newChain := append(slices.Clone(chain), target)
+	return w.walkDir(target, relPath, realRoot, newChain, fn)
 }
 
 type fastWalkFunc func(pathname string, fi os.FileInfo) error
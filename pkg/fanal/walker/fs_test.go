@@ -4,6 +4,8 @@ import (
 	"errors"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 
@@ -19,6 +21,7 @@ func TestDir_Walk(t *testing.T) {
 		skipFiles   []string
 		skipDirs    []string
 		errCallback walker.ErrorCallback
+		symlinks    walker.SymlinkOption
 	}
 	tests := []struct {
 		name      string
@@ -93,7 +96,7 @@ func TestDir_Walk(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			w := walker.NewFS(tt.fields.skipFiles, tt.fields.skipDirs, true, tt.fields.errCallback)
+			w := walker.NewFS(tt.fields.skipFiles, tt.fields.skipDirs, true, tt.fields.errCallback, tt.fields.symlinks, walker.FileFilter{})
 
 			err := w.Walk(tt.rootDir, tt.analyzeFn)
 			if tt.wantErr != "" {
@@ -105,3 +108,93 @@ func TestDir_Walk(t *testing.T) {
 		})
 	}
 }
+
+func TestFS_Walk_Symlinks(t *testing.T) {
+	// root
+	// ├── app
+	// │   ├── loop -> ..
+	// │   └── package.json
+	// ├── broken -> nosuch
+	// ├── lib -> app
+	// ├── outside -> ../outside
+	// └── pkg.json -> app/package.json
+	// outside
+	// ├── ext.txt
+	// └── more -> ../root/app
+	tmpDir := t.TempDir()
+	root := filepath.Join(tmpDir, "root")
+	outside := filepath.Join(tmpDir, "outside")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "app"), 0755))
+	require.NoError(t, os.MkdirAll(outside, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "app", "package.json"), []byte("{}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "ext.txt"), []byte("ext"), 0644))
+	for link, target := range map[string]string{
+		filepath.Join(root, "app", "loop"): "..",
+		filepath.Join(root, "broken"):      "nosuch",
+		filepath.Join(root, "lib"):         "app",
+		filepath.Join(root, "outside"):     filepath.Join("..", "outside"),
+		filepath.Join(root, "pkg.json"):    filepath.Join("app", "package.json"),
+		filepath.Join(outside, "more"):     filepath.Join("..", "root", "app"),
+	} {
+		require.NoError(t, os.Symlink(target, link))
+	}
+
+	tests := []struct {
+		name     string
+		symlinks walker.SymlinkOption
+		want     []string
+	}{
+		{
+			name: "never",
+			want: []string{"app/package.json"},
+		},
+		{
+			name:     "within root",
+			symlinks: walker.SymlinkOption{Policy: walker.SymlinkWithinRoot},
+			want: []string{
+				"app/package.json",
+				"lib/package.json",
+				"pkg.json",
+			},
+		},
+		{
+			name:     "follow",
+			symlinks: walker.SymlinkOption{Policy: walker.SymlinkFollow},
+			want: []string{
+				"app/package.json",
+				"lib/package.json",
+				"outside/ext.txt",
+				"outside/more/package.json",
+				"pkg.json",
+			},
+		},
+		{
+			name: "follow with max depth",
+			symlinks: walker.SymlinkOption{
+				Policy:   walker.SymlinkFollow,
+				MaxDepth: 1,
+			},
+			want: []string{
+				"app/package.json",
+				"lib/package.json",
+				"outside/ext.txt",
+				"pkg.json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := walker.NewFS(nil, nil, true, nil, tt.symlinks, walker.FileFilter{})
+
+			var got []string
+			err := w.Walk(root, func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
+				got = append(got, filePath)
+				return nil
+			})
+			require.NoError(t, err)
+
+			sort.Strings(got)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package walker
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// caseInsensitive reports whether the local filesystem is matched case-insensitively as NTFS does by default
+var caseInsensitive = runtime.GOOS == "windows"
+
+// cleanPattern normalizes a skip/include pattern into a slash-separated path relative to the root.
+// The volume name such as "C:" and `\\server\share` is trimmed on Windows
+// as walked paths are always relative to the root and have no volume.
+func cleanPattern(pattern string) string {
+	pattern = filepath.Clean(pattern)
+	pattern = strings.TrimPrefix(pattern, filepath.VolumeName(pattern))
+	pattern = filepath.ToSlash(pattern)
+	return strings.TrimLeft(pattern, "/")
+}
+
+// normalizeCase lowercases the path for case-insensitive matching
+func (w *walker) normalizeCase(p string) string {
+	if !w.ignoreCase {
+		return p
+	}
+	return strings.ToLower(p)
+}
@@ -0,0 +1,93 @@
+package walker
+
+import (
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/xerrors"
+
+	dio "github.com/aquasecurity/go-dep-parser/pkg/io"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// SFTP walks a file tree on a remote host over SFTP
+type SFTP struct {
+	walker
+	client      *sftp.Client
+	errCallback ErrorCallback
+}
+
+func NewSFTP(client *sftp.Client, skipFiles, skipDirs []string, filter FileFilter, errCallback ErrorCallback) SFTP {
+	if errCallback == nil {
+		errCallback = defaultErrorCallback
+	}
+
+	return SFTP{
+		// Remote files are always walked in series
+		walker:      newWalker(skipFiles, skipDirs, true, filter),
+		client:      client,
+		errCallback: errCallback,
+	}
+}
+
+// Walk walks the remote file tree rooted at root, calling WalkFunc for each file in the tree.
+// Unlike FS, root and paths passed to WalkFunc are always slash-separated.
+func (w SFTP) Walk(root string, fn WalkFunc) error {
+	root = path.Clean(root)
+	log.Logger.Debugf("Walk the remote file tree rooted at '%s'", root)
+
+	sw := w.client.Walk(root)
+	for sw.Step() {
+		pathname := sw.Path()
+		if err := sw.Err(); err != nil {
+			if err = w.errCallback(pathname, err); err != nil {
+				return xerrors.Errorf("walk error: %w", err)
+			}
+			continue
+		}
+
+		relPath := relativeSlashPath(root, pathname)
+		fi := sw.Stat()
+		if fi.IsDir() {
+			if w.shouldSkipDir(relPath) {
+				sw.SkipDir()
+			}
+			continue
+		} else if !fi.Mode().IsRegular() {
+			continue
+		} else if w.shouldSkipFile(relPath, fi.Size()) {
+			continue
+		}
+
+		if err := fn(relPath, fi, w.remoteFileOpener(pathname)); err != nil {
+			return xerrors.Errorf("failed to analyze file: %w", err)
+		}
+	}
+	return nil
+}
+
+// remoteFileOpener returns a function opening a remote file.
+func (w SFTP) remoteFileOpener(pathname string) func() (dio.ReadSeekCloserAt, error) {
+	return func() (dio.ReadSeekCloserAt, error) {
+		return w.client.Open(pathname)
+	}
+}
+
+// relativeSlashPath returns the path relative to root, or "." when they are the same.
+func relativeSlashPath(root, pathname string) string {
+	if pathname == root {
+		return "."
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(pathname, root), "/")
+}
+
+func defaultErrorCallback(pathname string, err error) error {
+	// ignore permission errors
+	if os.IsPermission(err) {
+		return nil
+	}
+	// halt traversal on any other error
+	return xerrors.Errorf("unknown error with %s: %w", pathname, err)
+}
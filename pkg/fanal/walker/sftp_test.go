@@ -0,0 +1,112 @@
+package walker_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/walker"
+)
+
+// newSFTPClient returns a client connected to an in-process SFTP server serving the local filesystem
+func newSFTPClient(t *testing.T) *sftp.Client {
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+
+	server, err := sftp.NewServer(struct {
+		io.Reader
+		io.WriteCloser
+	}{serverReader, serverWriter})
+	require.NoError(t, err)
+	go server.Serve()
+
+	client, err := sftp.NewClientPipe(clientReader, clientWriter)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = server.Close()
+		_ = client.Close()
+	})
+	return client
+}
+
+func TestSFTP_Walk(t *testing.T) {
+	root, err := filepath.Abs("testdata/fs")
+	require.NoError(t, err)
+	root = filepath.ToSlash(root)
+
+	tests := []struct {
+		name      string
+		rootDir   string
+		skipFiles []string
+		skipDirs  []string
+		want      map[string]string
+		wantErr   string
+	}{
+		{
+			name:    "happy path",
+			rootDir: root,
+			want: map[string]string{
+				"bar":                "bar",
+				"app/myweb/test.txt": "",
+			},
+		},
+		{
+			name:      "skip file",
+			rootDir:   root,
+			skipFiles: []string{"bar"},
+			want: map[string]string{
+				"app/myweb/test.txt": "",
+			},
+		},
+		{
+			name:     "skip dir",
+			rootDir:  root + "/",
+			skipDirs: []string{"app/**"},
+			want: map[string]string{
+				"bar": "bar",
+			},
+		},
+		{
+			name:    "single file",
+			rootDir: root + "/bar",
+			want: map[string]string{
+				".": "bar",
+			},
+		},
+		{
+			name:    "sad path",
+			rootDir: root + "/nosuch",
+			wantErr: "unknown error with",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := walker.NewSFTP(newSFTPClient(t), tt.skipFiles, tt.skipDirs, walker.FileFilter{}, nil)
+
+			got := map[string]string{}
+			err := w.Walk(tt.rootDir, func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
+				f, err := opener()
+				require.NoError(t, err)
+				defer f.Close()
+
+				b, err := io.ReadAll(f)
+				require.NoError(t, err)
+				got[filePath] = string(b)
+				return nil
+			})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
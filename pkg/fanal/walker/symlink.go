@@ -0,0 +1,185 @@
+package walker
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// SymlinkPolicy represents how the walkers handle symbolic links
+type SymlinkPolicy string
+
+const (
+	// SymlinkNever doesn't follow symbolic links
+	SymlinkNever SymlinkPolicy = "never"
+
+	// SymlinkWithinRoot follows symbolic links only when they point to files under the root
+	SymlinkWithinRoot SymlinkPolicy = "within-root"
+
+	// SymlinkFollow follows all symbolic links
+	SymlinkFollow SymlinkPolicy = "follow"
+
+	defaultMaxSymlinkDepth = 8
+)
+
+var SymlinkPolicies = []SymlinkPolicy{
+	SymlinkNever,
+	SymlinkWithinRoot,
+	SymlinkFollow,
+}
+
+type SymlinkOption struct {
+	Policy SymlinkPolicy
+
+	// MaxDepth limits the number of symbolic links followed in a row, e.g. a link in a directory reached through another link.
+	// The default value is used if it is zero.
+	MaxDepth int
+}
+
+// Follow returns true if symbolic links are followed
+func (o SymlinkOption) Follow() bool {
+	return o.Policy == SymlinkWithinRoot || o.Policy == SymlinkFollow
+}
+
+func (o SymlinkOption) maxDepth() int {
+	if o.MaxDepth <= 0 {
+		return defaultMaxSymlinkDepth
+	}
+	return o.MaxDepth
+}
+
+// within returns true if the path is the same as or under the dir
+func within(dir, p string) bool {
+	rel, err := filepath.Rel(dir, p)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, parentDir)
+}
+
+// maxLinkResolution limits the number of links resolved in a chain such as "a -> b", "b -> c", as the kernel does
+const maxLinkResolution = 40
+
+// layerSymlinks holds symbolic links found in a layer so that files behind them can be analyzed with the link paths as well.
+// Only links in the same layer are resolved, and a file is analyzed with the link path only when the link precedes the file
+// in the layer, as the content of files is not kept after they are analyzed.
+type layerSymlinks struct {
+	opt   SymlinkOption
+	links map[string]string // link path => target path
+
+	// index maps resolved target paths to links. It is built lazily as links and files are interleaved in layers.
+	index map[string][]string
+}
+
+func newLayerSymlinks(opt SymlinkOption) *layerSymlinks {
+	return &layerSymlinks{
+		opt:   opt,
+		links: map[string]string{},
+	}
+}
+
+// add records the link. Targets are resolved relative to the directory of the link, or the root if they are absolute.
+func (s *layerSymlinks) add(linkPath, linkName string) {
+	if !s.opt.Follow() {
+		return
+	}
+
+	target := linkName
+	if !path.IsAbs(linkName) {
+		target = path.Join(path.Dir(linkPath), linkName)
+	}
+	target = strings.TrimLeft(path.Clean(target), "/")
+
+	// Links escaping the root, e.g. "../../etc", cannot be resolved in the layer
+	if target == ".." || strings.HasPrefix(target, "../") {
+		log.Logger.Debugf("Skipping the symbolic link pointing outside the root: %s -> %s", linkPath, linkName)
+		return
+	}
+
+	s.links[linkPath] = target
+	s.index = nil
+}
+
+// aliases returns the paths through which the file can be reached via the recorded links
+func (s *layerSymlinks) aliases(filePath string) []string {
+	if len(s.links) == 0 {
+		return nil
+	}
+	if s.index == nil {
+		s.buildIndex()
+	}
+
+	visited := map[string]bool{filePath: true}
+	var aliases []string
+	current := []string{filePath}
+	for depth := 0; depth < s.opt.maxDepth() && len(current) > 0; depth++ {
+		var next []string
+		for _, p := range current {
+			for _, dir := range ancestors(p) {
+				for _, link := range s.index[dir] {
+					alias := link + strings.TrimPrefix(p, dir)
+					if visited[alias] {
+						continue
+					}
+					visited[alias] = true
+					next = append(next, alias)
+				}
+			}
+		}
+		sort.Strings(next)
+		aliases = append(aliases, next...)
+		current = next
+	}
+	return aliases
+}
+
+func (s *layerSymlinks) buildIndex() {
+	s.index = map[string][]string{}
+	for link, target := range s.links {
+		resolved, ok := s.resolve(target)
+		if !ok {
+			log.Logger.Debugf("Unable to resolve the symbolic link: %s", link)
+			continue
+		}
+
+		// Detect loops, e.g. a link to the parent directory
+		if dir := path.Dir(link); resolved == "." || dir == resolved || strings.HasPrefix(dir, resolved+"/") {
+			log.Logger.Debugf("Skipping the symbolic link causing a loop: %s -> %s", link, resolved)
+			continue
+		}
+		s.index[resolved] = append(s.index[resolved], link)
+	}
+	for _, links := range s.index {
+		sort.Strings(links)
+	}
+}
+
+// resolve replaces links in the path with their targets until no link remains
+func (s *layerSymlinks) resolve(p string) (string, bool) {
+	for i := 0; i < maxLinkResolution; i++ {
+		var found bool
+		for _, dir := range ancestors(p) {
+			if target, ok := s.links[dir]; ok {
+				p = path.Join(target, strings.TrimPrefix(p, dir))
+				found = true
+				break
+			}
+		}
+		if !found {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// ancestors returns the path and its parent directories, e.g. "a/b/c", "a/b" and "a"
+func ancestors(p string) []string {
+	var dirs []string
+	for ; p != "." && p != "/" && p != ""; p = path.Dir(p) {
+		dirs = append(dirs, p)
+	}
+	return dirs
+}
@@ -8,9 +8,11 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/samber/lo"
 	"golang.org/x/xerrors"
 
 	"github.com/zhanglimao/trivy/pkg/fanal/utils"
+	"github.com/zhanglimao/trivy/pkg/log"
 )
 
 const (
@@ -23,22 +25,25 @@ var parentDir = ".." + utils.PathSeparator
 type LayerTar struct {
 	walker
 	threshold int64
+	symlinks  SymlinkOption
 }
 
-func NewLayerTar(skipFiles, skipDirs []string, slow bool) LayerTar {
+func NewLayerTar(skipFiles, skipDirs []string, slow bool, symlinks SymlinkOption, filter FileFilter) LayerTar {
 	threshold := defaultSizeThreshold
 	if slow {
 		threshold = slowSizeThreshold
 	}
 
 	return LayerTar{
-		walker:    newWalker(skipFiles, skipDirs, slow),
+		walker:    newWalker(skipFiles, skipDirs, slow, filter),
 		threshold: threshold,
+		symlinks:  symlinks,
 	}
 }
 
 func (w LayerTar) Walk(layer io.Reader, analyzeFn WalkFunc) ([]string, []string, error) {
 	var opqDirs, whFiles, skipDirs []string
+	links := newLayerSymlinks(w.symlinks)
 	tr := tar.NewReader(layer)
 	for {
 		hdr, err := tr.Next()
@@ -73,10 +78,14 @@ func (w LayerTar) Walk(layer io.Reader, analyzeFn WalkFunc) ([]string, []string,
 				continue
 			}
 		case tar.TypeReg:
-			if w.shouldSkipFile(filePath) {
+			if w.shouldSkipFile(filePath, hdr.Size) {
 				continue
 			}
-		// symlinks and hardlinks have no content in reader, skip them
+		case tar.TypeSymlink:
+			// Symlinks have no content in reader, but files behind them can be analyzed with the link paths
+			links.add(filePath, hdr.Linkname)
+			continue
+		// hardlinks have no content in reader, skip them
 		default:
 			continue
 		}
@@ -86,27 +95,50 @@ func (w LayerTar) Walk(layer io.Reader, analyzeFn WalkFunc) ([]string, []string,
 		}
 
 		// A symbolic/hard link or regular file will reach here.
-		if err = w.processFile(filePath, tr, hdr.FileInfo(), analyzeFn); err != nil {
+		filePaths := []string{filePath}
+		if hdr.Typeflag == tar.TypeReg {
+			filePaths = append(filePaths, w.filterAliases(links.aliases(filePath), hdr.Size, skipDirs)...)
+		}
+		if err = w.processFile(filePaths, tr, hdr.FileInfo(), analyzeFn); err != nil {
 			return nil, nil, xerrors.Errorf("failed to process the file: %w", err)
 		}
 	}
 	return opqDirs, whFiles, nil
 }
 
-func (w LayerTar) processFile(filePath string, tr *tar.Reader, fi fs.FileInfo, analyzeFn WalkFunc) error {
+// processFile analyzes the file with the given paths. The content is read once and shared across them.
+func (w LayerTar) processFile(filePaths []string, tr *tar.Reader, fi fs.FileInfo, analyzeFn WalkFunc) error {
 	cf := newCachedFile(fi.Size(), tr, w.threshold)
 	defer func() {
 		// nolint
 		_ = cf.Clean()
 	}()
 
-	if err := analyzeFn(filePath, fi, cf.Open); err != nil {
-		return xerrors.Errorf("failed to analyze file: %w", err)
+	for _, filePath := range filePaths {
+		if err := analyzeFn(filePath, fi, cf.Open); err != nil {
+			return xerrors.Errorf("failed to analyze file: %w", err)
+		}
 	}
 
 	return nil
 }
 
+// filterAliases removes link paths of the file which should be skipped
+func (w LayerTar) filterAliases(aliases []string, size int64, skipDirs []string) []string {
+	return lo.Filter(aliases, func(alias string, _ int) bool {
+		if w.shouldSkipFile(alias, size) || underSkippedDir(alias, skipDirs) {
+			return false
+		}
+		for dir := path.Dir(alias); dir != "."; dir = path.Dir(dir) {
+			if w.shouldSkipDir(dir) {
+				return false
+			}
+		}
+		log.Logger.Debugf("Analyzing %s via the symbolic link", alias)
+		return true
+	})
+}
+
 func underSkippedDir(filePath string, skipDirs []string) bool {
 	for _, skipDir := range skipDirs {
 		rel, err := filepath.Rel(skipDir, filePath)
@@ -1,9 +1,13 @@
 package walker_test
 
 import (
+	"archive/tar"
+	"bytes"
 	"errors"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 
@@ -83,7 +87,7 @@ func TestLayerTar_Walk(t *testing.T) {
 			f, err := os.Open("testdata/test.tar")
 			require.NoError(t, err)
 
-			w := walker.NewLayerTar(tt.fields.skipFiles, tt.fields.skipDirs, true)
+			w := walker.NewLayerTar(tt.fields.skipFiles, tt.fields.skipDirs, true, walker.SymlinkOption{}, walker.FileFilter{})
 
 			gotOpqDirs, gotWhFiles, err := w.Walk(f, tt.analyzeFn)
 			if tt.wantErr != "" {
@@ -97,3 +101,111 @@ func TestLayerTar_Walk(t *testing.T) {
 		})
 	}
 }
+
+func TestLayerTar_Walk_Symlinks(t *testing.T) {
+	// Links precede their targets as in layers created by "docker build"
+	entries := []tar.Header{
+		{Name: "lib", Typeflag: tar.TypeSymlink, Linkname: "usr/lib"},
+		{Name: "etc/os-release", Typeflag: tar.TypeSymlink, Linkname: "../usr/lib/os-release"},
+		{Name: "usr/local/lib", Typeflag: tar.TypeSymlink, Linkname: "/lib"},
+		{Name: "usr/share/escape", Typeflag: tar.TypeSymlink, Linkname: "../../../../tmp"},
+		{Name: "usr/lib/loop", Typeflag: tar.TypeSymlink, Linkname: ".."},
+		{Name: "opt/app", Typeflag: tar.TypeSymlink, Linkname: "/usr/local"},
+		{Name: "usr/lib/os-release", Typeflag: tar.TypeReg, Size: 2},
+		{Name: "usr/lib/apk/db/installed", Typeflag: tar.TypeReg, Size: 2},
+	}
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, hdr := range entries {
+		hdr := hdr
+		require.NoError(t, tw.WriteHeader(&hdr))
+		if hdr.Size > 0 {
+			_, err := tw.Write([]byte("ok"))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+
+	tests := []struct {
+		name     string
+		skipDirs []string
+		symlinks walker.SymlinkOption
+		want     []string
+	}{
+		{
+			name: "never",
+			want: []string{
+				"usr/lib/apk/db/installed",
+				"usr/lib/os-release",
+			},
+		},
+		{
+			name:     "follow",
+			symlinks: walker.SymlinkOption{Policy: walker.SymlinkFollow},
+			want: []string{
+				"etc/os-release",
+				"lib/apk/db/installed",
+				"lib/os-release",
+				"opt/app/lib/apk/db/installed",
+				"opt/app/lib/os-release",
+				"usr/lib/apk/db/installed",
+				"usr/lib/os-release",
+				"usr/local/lib/apk/db/installed",
+				"usr/local/lib/os-release",
+			},
+		},
+		{
+			name: "follow with max depth",
+			symlinks: walker.SymlinkOption{
+				Policy:   walker.SymlinkFollow,
+				MaxDepth: 1,
+			},
+			want: []string{
+				"etc/os-release",
+				"lib/apk/db/installed",
+				"lib/os-release",
+				"usr/lib/apk/db/installed",
+				"usr/lib/os-release",
+				"usr/local/lib/apk/db/installed",
+				"usr/local/lib/os-release",
+			},
+		},
+		{
+			name:     "skip dirs",
+			skipDirs: []string{"usr/local"},
+			symlinks: walker.SymlinkOption{Policy: walker.SymlinkWithinRoot},
+			want: []string{
+				"etc/os-release",
+				"lib/apk/db/installed",
+				"lib/os-release",
+				"opt/app/lib/apk/db/installed",
+				"opt/app/lib/os-release",
+				"usr/lib/apk/db/installed",
+				"usr/lib/os-release",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := walker.NewLayerTar(nil, tt.skipDirs, true, tt.symlinks, walker.FileFilter{})
+
+			var got []string
+			_, _, err := w.Walk(bytes.NewReader(buf.Bytes()), func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
+				f, err := opener()
+				require.NoError(t, err)
+				defer f.Close()
+
+				b, err := io.ReadAll(f)
+				require.NoError(t, err)
+				assert.Equal(t, "ok", string(b))
+
+				got = append(got, filePath)
+				return nil
+			})
+			require.NoError(t, err)
+
+			sort.Strings(got)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
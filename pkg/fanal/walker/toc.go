@@ -0,0 +1,164 @@
+package walker
+
+import (
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/containerd/stargz-snapshotter/estargz"
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/xerrors"
+)
+
+// SeekableLayer represents a layer whose file entries can be read individually, such as eStargz and zstd:chunked.
+type SeekableLayer interface {
+	Lookup(path string) (*estargz.TOCEntry, bool)
+	OpenFile(name string) (*io.SectionReader, error)
+	ChunkEntryForOffset(name string, offset int64) (*estargz.TOCEntry, bool)
+	Verifier(ce *estargz.TOCEntry) (digest.Verifier, error)
+}
+
+// WalkTOC walks the table of contents of the seekable layer instead of the tar stream.
+// The content of a file is fetched only when an analyzer opens it.
+func (w LayerTar) WalkTOC(layer SeekableLayer, analyzeFn WalkFunc) ([]string, []string, error) {
+	root, ok := layer.Lookup("")
+	if !ok {
+		return nil, nil, xerrors.New("no root entry in the TOC")
+	}
+
+	var opqDirs, whFiles []string
+	links := newLayerSymlinks(w.symlinks)
+	var walk func(dir string, e *estargz.TOCEntry) error
+	walk = func(dir string, e *estargz.TOCEntry) error {
+		// Sort entries for consistent results
+		var names []string
+		e.ForeachChild(func(baseName string, _ *estargz.TOCEntry) bool {
+			names = append(names, baseName)
+			return true
+		})
+		sort.Strings(names)
+
+		for _, fileName := range names {
+			ent, _ := e.LookupChild(fileName)
+			filePath := path.Join(dir, fileName)
+
+			switch {
+			case dir == "" && (fileName == estargz.TOCTarName || fileName == estargz.PrefetchLandmark ||
+				fileName == estargz.NoPrefetchLandmark):
+				// Metadata of eStargz
+				continue
+			case fileName == opq:
+				// e.g. etc/.wh..wh..opq
+				opqDirs = append(opqDirs, dir+"/")
+				continue
+			case strings.HasPrefix(fileName, wh):
+				// etc/.wh.hostname
+				whFiles = append(whFiles, path.Join(dir, strings.TrimPrefix(fileName, wh)))
+				continue
+			}
+
+			switch ent.Type {
+			case "dir":
+				if w.shouldSkipDir(filePath) {
+					continue
+				}
+				// Directories are passed without content as in the tar walker, e.g. for their permissions
+				cf := newCachedFile(0, strings.NewReader(""), w.threshold)
+				err := analyzeFn(filePath, ent.Stat(), cf.Open)
+				_ = cf.Clean()
+				if err != nil {
+					return xerrors.Errorf("failed to analyze file: %w", err)
+				}
+				if err = walk(filePath, ent); err != nil {
+					return err
+				}
+				continue
+			case "reg":
+				// Hard links point to the original entry, and they are skipped as in the tar walker.
+				if ent.Name != filePath || w.shouldSkipFile(filePath, ent.Size) {
+					continue
+				}
+			case "symlink":
+				links.add(filePath, ent.LinkName)
+				continue
+			default:
+				continue
+			}
+
+			cf := newCachedFile(ent.Size, &tocFileReader{
+				layer: layer,
+				name:  filePath,
+				size:  ent.Size,
+			}, w.threshold)
+			filePaths := append([]string{filePath}, w.filterAliases(links.aliases(filePath), ent.Size, nil)...)
+			for _, p := range filePaths {
+				if err := analyzeFn(p, ent.Stat(), cf.Open); err != nil {
+					_ = cf.Clean()
+					return xerrors.Errorf("failed to analyze file: %w", err)
+				}
+			}
+			_ = cf.Clean()
+		}
+		return nil
+	}
+
+	if err := walk("", root); err != nil {
+		return nil, nil, err
+	}
+	return opqDirs, whFiles, nil
+}
+
+// tocFileReader lazily fetches the file content chunk by chunk and verifies each chunk with the digest in the TOC.
+type tocFileReader struct {
+	layer SeekableLayer
+	name  string
+	size  int64
+
+	sr       *io.SectionReader
+	offset   int64
+	chunk    io.Reader
+	verifier digest.Verifier
+}
+
+func (r *tocFileReader) Read(p []byte) (int, error) {
+	if r.sr == nil {
+		sr, err := r.layer.OpenFile(r.name)
+		if err != nil {
+			return 0, xerrors.Errorf("unable to open %s: %w", r.name, err)
+		}
+		r.sr = sr
+	}
+
+	for {
+		if r.chunk == nil {
+			if r.offset >= r.size {
+				return 0, io.EOF
+			}
+			ce, ok := r.layer.ChunkEntryForOffset(r.name, r.offset)
+			if !ok {
+				return 0, xerrors.Errorf("no chunk found at offset %d in %s", r.offset, r.name)
+			}
+			v, err := r.layer.Verifier(ce)
+			if err != nil {
+				return 0, xerrors.Errorf("verifier error (%s): %w", r.name, err)
+			}
+			r.verifier = v
+			r.chunk = io.TeeReader(io.NewSectionReader(r.sr, ce.ChunkOffset, ce.ChunkSize), v)
+			r.offset = ce.ChunkOffset + ce.ChunkSize
+		}
+
+		n, err := r.chunk.Read(p)
+		if err == io.EOF {
+			if !r.verifier.Verified() {
+				return n, xerrors.Errorf("digest mismatch: %s", r.name)
+			}
+			r.chunk = nil
+			if n == 0 {
+				continue
+			}
+			return n, nil
+		}
+		return n, err
+	}
+}
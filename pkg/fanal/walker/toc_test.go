@@ -0,0 +1,147 @@
+package walker_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/containerd/stargz-snapshotter/estargz"
+	"github.com/containerd/stargz-snapshotter/estargz/zstdchunked"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/walker"
+)
+
+type seekableLayer struct {
+	*estargz.Reader
+	estargz.TOCEntryVerifier
+}
+
+type zstdChunked struct {
+	zstdchunked.Compressor
+	zstdchunked.Decompressor
+}
+
+func newSeekableLayer(t *testing.T, tarPath string) seekableLayer {
+	f, err := os.Open(tarPath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	fi, err := f.Stat()
+	require.NoError(t, err)
+
+	// zstd:chunked is used as gzip footers of eStargz depend on the Go version
+	blob, err := estargz.Build(io.NewSectionReader(f, 0, fi.Size()),
+		estargz.WithCompression(&zstdChunked{
+			Compressor: zstdchunked.Compressor{CompressionLevel: zstd.SpeedDefault},
+		}))
+	require.NoError(t, err)
+	defer blob.Close()
+
+	b, err := io.ReadAll(blob)
+	require.NoError(t, err)
+
+	r, err := estargz.Open(io.NewSectionReader(bytes.NewReader(b), 0, int64(len(b))),
+		estargz.WithDecompressors(new(zstdchunked.Decompressor)))
+	require.NoError(t, err)
+
+	verifier, err := r.VerifyTOC(blob.TOCDigest())
+	require.NoError(t, err)
+
+	return seekableLayer{
+		Reader:           r,
+		TOCEntryVerifier: verifier,
+	}
+}
+
+func TestLayerTar_WalkTOC(t *testing.T) {
+	type fields struct {
+		skipFiles []string
+		skipDirs  []string
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		analyzeFn   walker.WalkFunc
+		wantFiles   []string
+		wantOpqDirs []string
+		wantWhFiles []string
+		wantErr     string
+	}{
+		{
+			name:        "happy path",
+			wantFiles:   []string{"app", "app/myweb", "app/myweb/index.html", "baz", "etc", "foo", "vendor", "vendor/bar"},
+			wantOpqDirs: []string{"etc/"},
+			wantWhFiles: []string{"foo/foo"},
+		},
+		{
+			name: "skip file",
+			fields: fields{
+				skipFiles: []string{"/app/myweb/index.html"},
+			},
+			wantFiles:   []string{"app", "app/myweb", "baz", "etc", "foo", "vendor", "vendor/bar"},
+			wantOpqDirs: []string{"etc/"},
+			wantWhFiles: []string{"foo/foo"},
+		},
+		{
+			name: "skip dir",
+			fields: fields{
+				skipDirs: []string{"/app"},
+			},
+			wantFiles:   []string{"baz", "etc", "foo", "vendor", "vendor/bar"},
+			wantOpqDirs: []string{"etc/"},
+			wantWhFiles: []string{"foo/foo"},
+		},
+		{
+			name: "sad path",
+			analyzeFn: func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
+				return errors.New("error")
+			},
+			wantErr: "failed to analyze file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layer := newSeekableLayer(t, "testdata/test.tar")
+			w := walker.NewLayerTar(tt.fields.skipFiles, tt.fields.skipDirs, true, walker.SymlinkOption{}, walker.FileFilter{})
+
+			var gotFiles []string
+			analyzeFn := tt.analyzeFn
+			if analyzeFn == nil {
+				analyzeFn = func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
+					gotFiles = append(gotFiles, filePath)
+					if filePath != "baz" {
+						return nil
+					}
+
+					// Only the opened file is fetched
+					f, err := opener()
+					require.NoError(t, err)
+					defer f.Close()
+
+					b, err := io.ReadAll(f)
+					require.NoError(t, err)
+					assert.Equal(t, "baz", strings.TrimSpace(string(b)))
+					return nil
+				}
+			}
+
+			gotOpqDirs, gotWhFiles, err := w.WalkTOC(layer, analyzeFn)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFiles, gotFiles)
+			assert.Equal(t, tt.wantOpqDirs, gotOpqDirs)
+			assert.Equal(t, tt.wantWhFiles, gotWhFiles)
+		})
+	}
+}
@@ -39,14 +39,14 @@ type VM struct {
 	analyzeFn WalkFunc
 }
 
-func NewVM(skipFiles, skipDirs []string, slow bool) VM {
+func NewVM(skipFiles, skipDirs []string, slow bool, filter FileFilter) VM {
 	threshold := defaultSizeThreshold
 	if slow {
 		threshold = slowSizeThreshold
 	}
 
 	return VM{
-		walker:    newWalker(skipFiles, skipDirs, slow),
+		walker:    newWalker(skipFiles, skipDirs, slow, filter),
 		threshold: threshold,
 	}
 }
@@ -130,7 +130,7 @@ func (w *VM) fsWalk(fsys fs.FS, path string, d fs.DirEntry, err error) error {
 		return nil
 	} else if !fi.Mode().IsRegular() {
 		return nil
-	} else if w.shouldSkipFile(pathName) {
+	} else if w.shouldSkipFile(pathName, fi.Size()) {
 		return nil
 	} else if fi.Mode()&0x1000 == 0x1000 ||
 		fi.Mode()&0x2000 == 0x2000 ||
@@ -3,13 +3,12 @@ package walker
 import (
 	"os"
 	"path"
-	"path/filepath"
 	"strings"
 
 	"github.com/bmatcuk/doublestar"
+	"github.com/samber/lo"
 
 	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
-	"github.com/zhanglimao/trivy/pkg/fanal/utils"
 	"github.com/zhanglimao/trivy/pkg/log"
 )
 
@@ -26,39 +25,63 @@ const (
 
 type WalkFunc func(filePath string, info os.FileInfo, opener analyzer.Opener) error
 
+// FileFilter narrows down files to be walked before analyzers run
+type FileFilter struct {
+	// IncludeFiles holds glob patterns of files to be walked. Patterns prefixed with "!" exclude files.
+	// The last matching pattern takes precedence, e.g. "**/*.json" and "!**/testdata/**".
+	// Files matching no pattern are skipped unless all patterns are exclusions.
+	IncludeFiles []string
+
+	// MaxFileSize skips files larger than the size in bytes if it is positive
+	MaxFileSize int64
+}
+
 type walker struct {
-	skipFiles []string
-	skipDirs  []string
-	slow      bool
+	skipFiles    []string
+	skipDirs     []string
+	includeFiles []string
+	maxFileSize  int64
+	slow         bool
+
+	// ignoreCase matches paths case-insensitively, which is enabled only for local filesystems on Windows.
+	// Image layers and remote hosts are case-sensitive regardless of the OS running Trivy.
+	ignoreCase bool
 }
 
-func newWalker(skipFiles, skipDirs []string, slow bool) walker {
-	var cleanSkipFiles, cleanSkipDirs []string
+func newWalker(skipFiles, skipDirs []string, slow bool, filter FileFilter) walker {
+	var cleanSkipFiles, cleanSkipDirs, cleanIncludeFiles []string
 	for _, skipFile := range skipFiles {
-		skipFile = filepath.ToSlash(filepath.Clean(skipFile))
-		skipFile = strings.TrimLeft(skipFile, "/")
-		cleanSkipFiles = append(cleanSkipFiles, skipFile)
+		cleanSkipFiles = append(cleanSkipFiles, cleanPattern(skipFile))
+	}
+
+	for _, includeFile := range filter.IncludeFiles {
+		exclude := strings.HasPrefix(includeFile, "!")
+		pattern := cleanPattern(strings.TrimPrefix(includeFile, "!"))
+		if exclude {
+			pattern = "!" + pattern
+		}
+		cleanIncludeFiles = append(cleanIncludeFiles, pattern)
 	}
 
 	for _, skipDir := range append(skipDirs, SystemDirs...) {
-		skipDir = filepath.ToSlash(filepath.Clean(skipDir))
-		skipDir = strings.TrimLeft(skipDir, "/")
-		cleanSkipDirs = append(cleanSkipDirs, skipDir)
+		cleanSkipDirs = append(cleanSkipDirs, cleanPattern(skipDir))
 	}
 
 	return walker{
-		skipFiles: cleanSkipFiles,
-		skipDirs:  cleanSkipDirs,
-		slow:      slow,
+		skipFiles:    cleanSkipFiles,
+		skipDirs:     cleanSkipDirs,
+		includeFiles: cleanIncludeFiles,
+		maxFileSize:  filter.MaxFileSize,
+		slow:         slow,
 	}
 }
 
-func (w *walker) shouldSkipFile(filePath string) bool {
+func (w *walker) shouldSkipFile(filePath string, size int64) bool {
 	filePath = strings.TrimLeft(filePath, "/")
 
 	// skip files
 	for _, pattern := range w.skipFiles {
-		match, err := doublestar.Match(pattern, filePath)
+		match, err := doublestar.Match(w.normalizeCase(pattern), w.normalizeCase(filePath))
 		if err != nil {
 			return false // return early if bad pattern
 		} else if match {
@@ -66,21 +89,54 @@ func (w *walker) shouldSkipFile(filePath string) bool {
 			return true
 		}
 	}
+
+	if !w.included(filePath) {
+		log.Logger.Debugf("Skipping file not matching the include patterns: %s", filePath)
+		return true
+	}
+
+	if w.maxFileSize > 0 && size > w.maxFileSize {
+		log.Logger.Debugf("Skipping file larger than %d bytes: %s (%d bytes)", w.maxFileSize, filePath, size)
+		return true
+	}
 	return false
 }
 
+// included evaluates the include patterns in order, and the last matching pattern decides.
+func (w *walker) included(filePath string) bool {
+	if len(w.includeFiles) == 0 {
+		return true
+	}
+
+	// Files are included by default when only exclusions are specified
+	included := lo.EveryBy(w.includeFiles, func(pattern string) bool {
+		return strings.HasPrefix(pattern, "!")
+	})
+	for _, pattern := range w.includeFiles {
+		exclude := strings.HasPrefix(pattern, "!")
+		if match, err := doublestar.Match(w.normalizeCase(strings.TrimPrefix(pattern, "!")), w.normalizeCase(filePath)); err != nil {
+			return false // return early if bad pattern
+		} else if match {
+			included = !exclude
+		}
+	}
+	return included
+}
+
 func (w *walker) shouldSkipDir(dir string) bool {
 	dir = strings.TrimLeft(dir, "/")
 
 	// Skip application dirs (relative path)
-	base := filepath.Base(dir)
-	if utils.StringInSlice(base, AppDirs) {
+	base := path.Base(dir)
+	if lo.ContainsBy(AppDirs, func(appDir string) bool {
+		return w.normalizeCase(appDir) == w.normalizeCase(base)
+	}) {
 		return true
 	}
 
 	// Skip system dirs and specified dirs (absolute path)
 	for _, pattern := range w.skipDirs {
-		if match, err := path.Match(pattern, dir); err != nil {
+		if match, err := path.Match(w.normalizeCase(pattern), w.normalizeCase(dir)); err != nil {
 			return false // return early if bad pattern
 		} else if match {
 			log.Logger.Debugf("Skipping directory: %s", dir)
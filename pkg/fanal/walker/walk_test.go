@@ -9,8 +9,12 @@ import (
 )
 
 func Test_shouldSkipFile(t *testing.T) {
+	// All files are assumed to be 100 bytes
+	const size = 100
+
 	testCases := []struct {
 		skipFiles []string
+		filter    FileFilter
 		skipMap   map[string]bool
 	}{
 		{
@@ -50,13 +54,51 @@ func Test_shouldSkipFile(t *testing.T) {
 				filepath.Join("/etc/foo/bar"): false,
 			},
 		},
+		{
+			filter: FileFilter{
+				IncludeFiles: []string{"**/*.json", "!**/testdata/**"},
+			},
+			skipMap: map[string]bool{
+				filepath.Join("/app/package.json"):          false,
+				filepath.Join("/app/testdata/package.json"): true,
+				filepath.Join("/app/main.go"):               true,
+			},
+		},
+		{
+			skipFiles: []string{filepath.Join("/app/vendor/**")},
+			filter: FileFilter{
+				IncludeFiles: []string{"!**/*.bin"},
+			},
+			skipMap: map[string]bool{
+				filepath.Join("/app/assets/model.bin"): true,
+				filepath.Join("/app/vendor/go.mod"):    true,
+				filepath.Join("/app/go.mod"):           false,
+			},
+		},
+		{
+			filter: FileFilter{
+				MaxFileSize: size - 1,
+			},
+			skipMap: map[string]bool{
+				filepath.Join("/app/assets/model.bin"): true,
+			},
+		},
+		{
+			filter: FileFilter{
+				IncludeFiles: []string{"**/*.bin"},
+				MaxFileSize:  size,
+			},
+			skipMap: map[string]bool{
+				filepath.Join("/app/assets/model.bin"): false,
+			},
+		},
 	}
 
 	for i, tc := range testCases {
 		t.Run(fmt.Sprint(i), func(t *testing.T) {
-			w := newWalker(tc.skipFiles, nil, false)
+			w := newWalker(tc.skipFiles, nil, false, tc.filter)
 			for file, skipResult := range tc.skipMap {
-				assert.Equal(t, skipResult, w.shouldSkipFile(filepath.ToSlash(filepath.Clean(file))), fmt.Sprintf("skipFiles: %s, file: %s", tc.skipFiles, file))
+				assert.Equal(t, skipResult, w.shouldSkipFile(filepath.ToSlash(filepath.Clean(file)), size), fmt.Sprintf("skipFiles: %s, file: %s", tc.skipFiles, file))
 			}
 		})
 	}
@@ -108,10 +150,73 @@ func Test_shouldSkipDir(t *testing.T) {
 
 	for i, tc := range testCases {
 		t.Run(fmt.Sprint(i), func(t *testing.T) {
-			w := newWalker(nil, tc.skipDirs, false)
+			w := newWalker(nil, tc.skipDirs, false, FileFilter{})
 			for dir, skipResult := range tc.skipMap {
 				assert.Equal(t, skipResult, w.shouldSkipDir(filepath.ToSlash(filepath.Clean(dir))), fmt.Sprintf("skipDirs: %s, dir: %s", tc.skipDirs, dir))
 			}
 		})
 	}
 }
+
+func Test_ignoreCase(t *testing.T) {
+	tests := []struct {
+		name       string
+		ignoreCase bool
+		skipFiles  []string
+		skipDirs   []string
+		filter     FileFilter
+		files      map[string]bool
+		dirs       map[string]bool
+	}{
+		{
+			name:       "case-insensitive",
+			ignoreCase: true,
+			skipFiles:  []string{"Foo/*.TXT"},
+			skipDirs:   []string{"Node_Modules"},
+			filter: FileFilter{
+				IncludeFiles: []string{"**/*.txt", "**/*.JSON"},
+			},
+			files: map[string]bool{
+				"foo/bar.txt":       true,
+				"FOO/bar.Txt":       true,
+				"baz/package.json":  false,
+				"baz/Bar.TXT":       false,
+				"baz/bar.go":        true,
+				"FOO/bar/qux.txt":   false,
+				"foo/bar/Readme.md": true,
+			},
+			dirs: map[string]bool{
+				"node_modules": true,
+				"NODE_MODULES": true,
+				".GIT":         true,
+				"foo":          false,
+			},
+		},
+		{
+			name:      "case-sensitive",
+			skipFiles: []string{"Foo/*.TXT"},
+			skipDirs:  []string{"Node_Modules"},
+			files: map[string]bool{
+				"Foo/bar.TXT": true,
+				"foo/bar.txt": false,
+			},
+			dirs: map[string]bool{
+				"Node_Modules": true,
+				"node_modules": false,
+				".GIT":         false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newWalker(tt.skipFiles, tt.skipDirs, false, tt.filter)
+			w.ignoreCase = tt.ignoreCase
+			for file, want := range tt.files {
+				assert.Equal(t, want, w.shouldSkipFile(file, 0), file)
+			}
+			for dir, want := range tt.dirs {
+				assert.Equal(t, want, w.shouldSkipDir(dir), dir)
+			}
+		})
+	}
+}
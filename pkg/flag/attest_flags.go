@@ -0,0 +1,96 @@
+package flag
+
+import (
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// e.g. config yaml
+// attest:
+//   signer-policy: "/path/to/policy.yaml"
+//   require-signature: true
+//   sources:
+//     - oci
+//     - rekor
+
+var (
+	SignerPolicyFlag = Flag{
+		Name:       "signer-policy",
+		ConfigName: "attest.signer-policy",
+		Value:      "",
+		Usage:      "path to the policy file defining the identities and keys allowed to sign images and provenance",
+	}
+	RequireSignatureFlag = Flag{
+		Name:       "require-signature",
+		ConfigName: "attest.require-signature",
+		Value:      false,
+		Usage:      "fail the supply chain control unless the image is signed by cosign with an identity or key allowed in '--signer-policy'",
+	}
+	AttestSourcesFlag = Flag{
+		Name:       "sources",
+		ConfigName: "attest.sources",
+		Value:      types.ProvenanceSources,
+		Usage:      "sources to look up provenance attestations in (oci,rekor)",
+	}
+)
+
+// AttestFlagGroup defines flags for verifying attestations and signatures
+type AttestFlagGroup struct {
+	SignerPolicy     *Flag
+	Sources          *Flag
+	RekorURL         *Flag
+	RequireSignature *Flag // used only in scanning images
+}
+
+type AttestOptions struct {
+	SignerPolicy     string
+	AttestSources    []string
+	AttestRekorURL   string
+	RequireSignature bool
+}
+
+func NewAttestFlagGroup() *AttestFlagGroup {
+	return &AttestFlagGroup{
+		SignerPolicy: &SignerPolicyFlag,
+		Sources:      &AttestSourcesFlag,
+		RekorURL:     &RekorURLFlag,
+	}
+}
+
+func (f *AttestFlagGroup) Name() string {
+	return "Attestation"
+}
+
+func (f *AttestFlagGroup) Flags() []*Flag {
+	return []*Flag{
+		f.SignerPolicy,
+		f.Sources,
+		f.RekorURL,
+		f.RequireSignature,
+	}
+}
+
+func (f *AttestFlagGroup) ToOptions() (AttestOptions, error) {
+	policy := getString(f.SignerPolicy)
+	requireSignature := getBool(f.RequireSignature)
+	// The policy is always required for 'trivy attest', while it is required only with '--require-signature' in scanning
+	if policy == "" && (f.RequireSignature == nil || requireSignature) {
+		return AttestOptions{}, xerrors.Errorf("'--%s' must be specified", SignerPolicyFlag.Name)
+	}
+
+	sources := getStringSlice(f.Sources)
+	for _, source := range sources {
+		if !slices.Contains(types.ProvenanceSources, source) {
+			return AttestOptions{}, xerrors.Errorf("unknown attestation source: %s", source)
+		}
+	}
+
+	return AttestOptions{
+		SignerPolicy:     policy,
+		AttestSources:    sources,
+		AttestRekorURL:   getString(f.RekorURL),
+		RequireSignature: requireSignature,
+	}, nil
+}
@@ -29,7 +29,13 @@ var (
 		Name:       "arn",
 		ConfigName: "cloud.aws.arn",
 		Value:      "",
-		Usage:      "The AWS ARN to show results for. Useful to filter results once a scan is cached.",
+		Usage:      "The AWS ARN to show results for. Wildcards such as 'arn:aws:s3:::prod-*' select the matching resources. Useful to filter results once a scan is cached.",
+	}
+	awsTagFlag = Flag{
+		Name:       "tag",
+		ConfigName: "cloud.aws.tag",
+		Value:      []string{},
+		Usage:      "Only show results for AWS resources with the tag(s), e.g. Environment=prod. Can specify multiple tags using --tag A=B --tag C=D, which resources must have all of.",
 	}
 )
 
@@ -39,6 +45,7 @@ type AWSFlagGroup struct {
 	Services *Flag
 	Account  *Flag
 	ARN      *Flag
+	Tags     *Flag
 }
 
 type AWSOptions struct {
@@ -47,6 +54,7 @@ type AWSOptions struct {
 	Services []string
 	Account  string
 	ARN      string
+	Tags     []string
 }
 
 func NewAWSFlagGroup() *AWSFlagGroup {
@@ -56,6 +64,7 @@ func NewAWSFlagGroup() *AWSFlagGroup {
 		Services: &awsServiceFlag,
 		Account:  &awsAccountFlag,
 		ARN:      &awsARNFlag,
+		Tags:     &awsTagFlag,
 	}
 }
 
@@ -64,7 +73,7 @@ func (f *AWSFlagGroup) Name() string {
 }
 
 func (f *AWSFlagGroup) Flags() []*Flag {
-	return []*Flag{f.Region, f.Endpoint, f.Services, f.Account, f.ARN}
+	return []*Flag{f.Region, f.Endpoint, f.Services, f.Account, f.ARN, f.Tags}
 }
 
 func (f *AWSFlagGroup) ToOptions() AWSOptions {
@@ -74,5 +83,6 @@ func (f *AWSFlagGroup) ToOptions() AWSOptions {
 		Services: getStringSlice(f.Services),
 		Account:  getString(f.Account),
 		ARN:      getString(f.ARN),
+		Tags:     getStringSlice(f.Tags),
 	}
 }
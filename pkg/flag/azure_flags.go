@@ -0,0 +1,58 @@
+package flag
+
+var (
+	azureSubscriptionFlag = Flag{
+		Name:       "subscription",
+		ConfigName: "cloud.azure.subscription",
+		Value:      "",
+		Usage:      "The Azure subscription to scan. The only subscription accessible with the credentials is scanned by default.",
+	}
+	azureServiceFlag = Flag{
+		Name:       "service",
+		ConfigName: "cloud.azure.service",
+		Value:      []string{},
+		Usage:      "Only scan Azure Service(s) specified with this flag. Can specify multiple services using --service A --service B etc.",
+	}
+	azureResourceIDFlag = Flag{
+		Name:       "resource-id",
+		ConfigName: "cloud.azure.resource-id",
+		Value:      "",
+		Usage:      "The Azure resource ID to show results for. Useful to filter results once a scan is cached.",
+	}
+)
+
+type AzureFlagGroup struct {
+	Subscription *Flag
+	Services     *Flag
+	ResourceID   *Flag
+}
+
+type AzureOptions struct {
+	Subscription    string
+	AzureServices   []string
+	AzureResourceID string
+}
+
+func NewAzureFlagGroup() *AzureFlagGroup {
+	return &AzureFlagGroup{
+		Subscription: &azureSubscriptionFlag,
+		Services:     &azureServiceFlag,
+		ResourceID:   &azureResourceIDFlag,
+	}
+}
+
+func (f *AzureFlagGroup) Name() string {
+	return "Azure"
+}
+
+func (f *AzureFlagGroup) Flags() []*Flag {
+	return []*Flag{f.Subscription, f.Services, f.ResourceID}
+}
+
+func (f *AzureFlagGroup) ToOptions() AzureOptions {
+	return AzureOptions{
+		Subscription:    getString(f.Subscription),
+		AzureServices:   getStringSlice(f.Services),
+		AzureResourceID: getString(f.ResourceID),
+	}
+}
@@ -0,0 +1,69 @@
+package flag
+
+// e.g. config yaml
+// bundle:
+//   include-cache: true
+//   signing-key: "/path/to/bundle.key"
+//   public-key: "/path/to/bundle.pub"
+
+var (
+	BundleIncludeCacheFlag = Flag{
+		Name:       "include-cache",
+		ConfigName: "bundle.include-cache",
+		Value:      false,
+		Usage:      "include the scan cache so that artifacts scanned before are not analyzed again",
+	}
+	BundleSigningKeyFlag = Flag{
+		Name:       "signing-key",
+		ConfigName: "bundle.signing-key",
+		Value:      "",
+		Usage:      "path to the PEM-encoded private key (ECDSA, Ed25519 or RSA) to sign the bundle with",
+	}
+	BundlePublicKeyFlag = Flag{
+		Name:       "public-key",
+		ConfigName: "bundle.public-key",
+		Value:      "",
+		Usage:      "path to the PEM-encoded public key to verify the signature of the bundle with",
+	}
+)
+
+// BundleFlagGroup defines flags for offline bundles
+type BundleFlagGroup struct {
+	IncludeCache *Flag
+	SigningKey   *Flag
+	PublicKey    *Flag
+}
+
+type BundleOptions struct {
+	IncludeCache     bool
+	BundleSigningKey string
+	BundlePublicKey  string
+}
+
+func NewBundleFlagGroup() *BundleFlagGroup {
+	return &BundleFlagGroup{
+		IncludeCache: &BundleIncludeCacheFlag,
+		SigningKey:   &BundleSigningKeyFlag,
+		PublicKey:    &BundlePublicKeyFlag,
+	}
+}
+
+func (f *BundleFlagGroup) Name() string {
+	return "Bundle"
+}
+
+func (f *BundleFlagGroup) Flags() []*Flag {
+	return []*Flag{
+		f.IncludeCache,
+		f.SigningKey,
+		f.PublicKey,
+	}
+}
+
+func (f *BundleFlagGroup) ToOptions() BundleOptions {
+	return BundleOptions{
+		IncludeCache:     getBool(f.IncludeCache),
+		BundleSigningKey: getString(f.SigningKey),
+		BundlePublicKey:  getString(f.PublicKey),
+	}
+}
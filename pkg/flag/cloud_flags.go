@@ -1,6 +1,9 @@
 package flag
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 var (
 	cloudUpdateCacheFlag = Flag{
@@ -15,22 +18,49 @@ var (
 		Value:      time.Hour * 24,
 		Usage:      "The maximum age of the cloud cache. Cached data will be requeried from the cloud provider if it is older than this.",
 	}
+	cloudRefreshServiceFlag = Flag{
+		Name:       "refresh-service",
+		ConfigName: "cloud.refresh-service",
+		Value:      []string{},
+		Usage:      "Re-query the specified service(s) from the cloud provider even if they are cached. Other services are loaded from the cache.",
+	}
+	cloudConcurrencyFlag = Flag{
+		Name:       "cloud-concurrency",
+		ConfigName: "cloud.concurrency",
+		Value:      1,
+		Usage:      "The number of services to query from the cloud provider concurrently.",
+	}
+	cloudServiceTimeoutFlag = Flag{
+		Name:       "cloud-service-timeout",
+		ConfigName: "cloud.service-timeout",
+		Value:      time.Duration(0),
+		Usage:      "The timeout for querying each service from the cloud provider. Services exceeding it are skipped. (0 means no timeout)",
+	}
 )
 
 type CloudFlagGroup struct {
-	UpdateCache *Flag
-	MaxCacheAge *Flag
+	UpdateCache     *Flag
+	MaxCacheAge     *Flag
+	RefreshServices *Flag
+	Concurrency     *Flag
+	ServiceTimeout  *Flag
 }
 
 type CloudOptions struct {
-	MaxCacheAge time.Duration
-	UpdateCache bool
+	MaxCacheAge     time.Duration
+	UpdateCache     bool
+	RefreshServices []string
+	Concurrency     int
+	ServiceTimeout  time.Duration
 }
 
 func NewCloudFlagGroup() *CloudFlagGroup {
 	return &CloudFlagGroup{
-		UpdateCache: &cloudUpdateCacheFlag,
-		MaxCacheAge: &cloudMaxCacheAgeFlag,
+		UpdateCache:     &cloudUpdateCacheFlag,
+		MaxCacheAge:     &cloudMaxCacheAgeFlag,
+		RefreshServices: &cloudRefreshServiceFlag,
+		Concurrency:     &cloudConcurrencyFlag,
+		ServiceTimeout:  &cloudServiceTimeoutFlag,
 	}
 }
 
@@ -39,12 +69,24 @@ func (f *CloudFlagGroup) Name() string {
 }
 
 func (f *CloudFlagGroup) Flags() []*Flag {
-	return []*Flag{f.UpdateCache, f.MaxCacheAge}
+	return []*Flag{f.UpdateCache, f.MaxCacheAge, f.RefreshServices, f.Concurrency, f.ServiceTimeout}
 }
 
 func (f *CloudFlagGroup) ToOptions() CloudOptions {
 	return CloudOptions{
-		UpdateCache: getBool(f.UpdateCache),
-		MaxCacheAge: getDuration(f.MaxCacheAge),
+		UpdateCache:     getBool(f.UpdateCache),
+		MaxCacheAge:     getDuration(f.MaxCacheAge),
+		RefreshServices: splitServices(getStringSlice(f.RefreshServices)),
+		Concurrency:     getInt(f.Concurrency),
+		ServiceTimeout:  getDuration(f.ServiceTimeout),
+	}
+}
+
+// splitServices supports comma separated services too
+func splitServices(services []string) []string {
+	var split []string
+	for _, service := range services {
+		split = append(split, strings.Split(service, ",")...)
 	}
+	return split
 }
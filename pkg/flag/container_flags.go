@@ -0,0 +1,55 @@
+package flag
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+var (
+	PodFlag = Flag{
+		Name:       "pod",
+		ConfigName: "container.pod",
+		Value:      "",
+		Usage:      "scan all containers of the running pod (NAMESPACE/NAME)",
+	}
+)
+
+type ContainerFlagGroup struct {
+	Pod *Flag
+}
+
+type ContainerOptions struct {
+	PodNamespace string
+	PodName      string
+}
+
+func NewContainerFlagGroup() *ContainerFlagGroup {
+	return &ContainerFlagGroup{
+		Pod: &PodFlag,
+	}
+}
+
+func (f *ContainerFlagGroup) Name() string {
+	return "Container"
+}
+
+func (f *ContainerFlagGroup) Flags() []*Flag {
+	return []*Flag{f.Pod}
+}
+
+func (f *ContainerFlagGroup) ToOptions() (ContainerOptions, error) {
+	pod := getString(f.Pod)
+	if pod == "" {
+		return ContainerOptions{}, nil
+	}
+
+	namespace, podName, ok := strings.Cut(pod, "/")
+	if !ok || namespace == "" || podName == "" || strings.Contains(podName, "/") {
+		return ContainerOptions{}, xerrors.Errorf("invalid pod %q: must be NAMESPACE/NAME", pod)
+	}
+	return ContainerOptions{
+		PodNamespace: namespace,
+		PodName:      podName,
+	}, nil
+}
@@ -2,8 +2,6 @@ package flag
 
 import (
 	"golang.org/x/xerrors"
-
-	"github.com/zhanglimao/trivy/pkg/log"
 )
 
 const defaultDBRepository = "ghcr.io/aquasecurity/trivy-db"
@@ -55,8 +53,8 @@ var (
 	DBRepositoryFlag = Flag{
 		Name:       "db-repository",
 		ConfigName: "db.repository",
-		Value:      defaultDBRepository,
-		Usage:      "OCI repository to retrieve trivy-db from",
+		Value:      []string{defaultDBRepository},
+		Usage:      "OCI repositories to retrieve trivy-db from, tried in order until the download succeeds",
 	}
 	JavaDBRepositoryFlag = Flag{
 		Name:       "java-db-repository",
@@ -64,12 +62,43 @@ var (
 		Value:      defaultJavaDBRepository,
 		Usage:      "OCI repository to retrieve trivy-java-db from",
 	}
+	JavaDBURLFlag = Flag{
+		Name:       "java-db-url",
+		ConfigName: "db.java-url",
+		Value:      "",
+		Usage:      "URL of the Java DB lookup API (e.g. Trivy server) to look up JAR files remotely instead of downloading trivy-java-db",
+	}
+	CustomAdvisoryDirFlag = Flag{
+		Name:       "custom-advisory-dir",
+		ConfigName: "db.custom-advisory-dir",
+		Value:      []string{},
+		Usage:      "directory containing custom advisory files (JSON) merged with trivy-db",
+	}
+	CustomAdvisoryRepositoryFlag = Flag{
+		Name:       "custom-advisory-repository",
+		ConfigName: "db.custom-advisory-repository",
+		Value:      "",
+		Usage:      "OCI repository to retrieve custom advisories from",
+	}
+	MaliciousDBDirFlag = Flag{
+		Name:       "malicious-db-dir",
+		ConfigName: "db.malicious-dir",
+		Value:      []string{},
+		Usage:      "directory containing malicious package reports in the OSV format (e.g. OpenSSF Malicious Packages)",
+	}
+	MaliciousDBRepositoryFlag = Flag{
+		Name:       "malicious-db-repository",
+		ConfigName: "db.malicious-repository",
+		Value:      "",
+		Usage:      "OCI repository to retrieve malicious package reports in the OSV format from",
+	}
 	LightFlag = Flag{
-		Name:       "light",
-		ConfigName: "db.light",
-		Value:      false,
-		Usage:      "deprecated",
-		Deprecated: true,
+		Name:            "light",
+		ConfigName:      "db.light",
+		Value:           false,
+		Usage:           "deprecated",
+		Deprecated:      true,
+		DeprecationNote: "See also: https://github.com/zhanglimao/trivy/discussions/1649",
 	}
 )
 
@@ -83,6 +112,11 @@ type DBFlagGroup struct {
 	NoProgress         *Flag
 	DBRepository       *Flag
 	JavaDBRepository   *Flag
+	JavaDBURL          *Flag
+	CustomAdvisoryDir  *Flag
+	CustomAdvisoryRepo *Flag
+	MaliciousDBDir     *Flag
+	MaliciousDBRepo    *Flag
 	Light              *Flag // deprecated
 }
 
@@ -93,8 +127,13 @@ type DBOptions struct {
 	DownloadJavaDBOnly bool
 	SkipJavaDBUpdate   bool
 	NoProgress         bool
-	DBRepository       string
+	DBRepositories     []string
 	JavaDBRepository   string
+	JavaDBURL          string
+	CustomAdvisoryDirs []string
+	CustomAdvisoryRepo string
+	MaliciousDBDirs    []string
+	MaliciousDBRepo    string
 	Light              bool // deprecated
 }
 
@@ -110,6 +149,11 @@ func NewDBFlagGroup() *DBFlagGroup {
 		NoProgress:         &NoProgressFlag,
 		DBRepository:       &DBRepositoryFlag,
 		JavaDBRepository:   &JavaDBRepositoryFlag,
+		JavaDBURL:          &JavaDBURLFlag,
+		CustomAdvisoryDir:  &CustomAdvisoryDirFlag,
+		CustomAdvisoryRepo: &CustomAdvisoryRepositoryFlag,
+		MaliciousDBDir:     &MaliciousDBDirFlag,
+		MaliciousDBRepo:    &MaliciousDBRepositoryFlag,
 	}
 }
 
@@ -127,6 +171,11 @@ func (f *DBFlagGroup) Flags() []*Flag {
 		f.NoProgress,
 		f.DBRepository,
 		f.JavaDBRepository,
+		f.JavaDBURL,
+		f.CustomAdvisoryDir,
+		f.CustomAdvisoryRepo,
+		f.MaliciousDBDir,
+		f.MaliciousDBRepo,
 		f.Light,
 	}
 }
@@ -144,10 +193,9 @@ func (f *DBFlagGroup) ToOptions() (DBOptions, error) {
 	if downloadJavaDBOnly && skipJavaDBUpdate {
 		return DBOptions{}, xerrors.New("--skip-java-db-update and --download-java-db-only options can not be specified both")
 	}
-	if light {
-		log.Logger.Warn("'--light' option is deprecated and will be removed. See also: https://github.com/zhanglimao/trivy/discussions/1649")
+	if downloadJavaDBOnly && getString(f.JavaDBURL) != "" {
+		return DBOptions{}, xerrors.New("--download-java-db-only and --java-db-url options can not be specified both")
 	}
-
 	return DBOptions{
 		Reset:              getBool(f.Reset),
 		DownloadDBOnly:     downloadDBOnly,
@@ -156,7 +204,12 @@ func (f *DBFlagGroup) ToOptions() (DBOptions, error) {
 		SkipJavaDBUpdate:   skipJavaDBUpdate,
 		Light:              light,
 		NoProgress:         getBool(f.NoProgress),
-		DBRepository:       getString(f.DBRepository),
+		DBRepositories:     getStringSlice(f.DBRepository),
 		JavaDBRepository:   getString(f.JavaDBRepository),
+		JavaDBURL:          getString(f.JavaDBURL),
+		CustomAdvisoryDirs: getStringSlice(f.CustomAdvisoryDir),
+		CustomAdvisoryRepo: getString(f.CustomAdvisoryRepo),
+		MaliciousDBDirs:    getStringSlice(f.MaliciousDBDir),
+		MaliciousDBRepo:    getString(f.MaliciousDBRepo),
 	}, nil
 }
@@ -48,7 +48,7 @@ func TestDBFlagGroup_ToOptions(t *testing.T) {
 				Light: true,
 			},
 			wantLogs: []string{
-				"'--light' option is deprecated and will be removed. See also: https://github.com/zhanglimao/trivy/discussions/1649",
+				"'--light' is deprecated. It will be removed. See also: https://github.com/zhanglimao/trivy/discussions/1649",
 			},
 			assertion: require.NoError,
 		},
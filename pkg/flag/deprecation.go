@@ -0,0 +1,131 @@
+package flag
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// Sources of deprecated names
+const (
+	sourceCLI    = "cli"
+	sourceEnv    = "env"
+	sourceConfig = "config"
+)
+
+// warned holds the deprecated names already warned, as flags are bound and read several times
+var warned sync.Map
+
+// deprecation describes the use of a deprecated flag or alias
+type deprecation struct {
+	// Name is the deprecated name as the user specified, e.g. "--skip-update", "TRIVY_SKIP_UPDATE" or "db.skip-update"
+	Name string
+
+	// Replacement is the name to be used instead. It is empty if the flag has no replacement.
+	Replacement string
+
+	// Source is where the deprecated name is specified, "cli", "env" or "config"
+	Source string
+
+	// RemovedIn is the version in which the deprecated name will be removed
+	RemovedIn string
+
+	// Note explains the deprecation, e.g. a link to the discussion
+	Note string
+}
+
+func (d deprecation) message() string {
+	msg := fmt.Sprintf("'%s' is deprecated.", d.Name)
+	if d.Source == sourceConfig {
+		msg = fmt.Sprintf("'%s' in config file is deprecated.", d.Name)
+	}
+	if d.Replacement != "" {
+		msg += fmt.Sprintf(" Use '%s' instead.", d.Replacement)
+	}
+	if d.RemovedIn != "" {
+		msg += fmt.Sprintf(" It will be removed in %s.", d.RemovedIn)
+	} else if d.Replacement == "" {
+		msg += " It will be removed."
+	}
+	if d.Note != "" {
+		msg += " " + d.Note
+	}
+	return msg
+}
+
+// warn logs the deprecation once with the fields so that the warning can be processed with '--log-format json'
+func (d deprecation) warn() {
+	if _, loaded := warned.LoadOrStore(d.Source+"/"+d.Name, struct{}{}); loaded {
+		return
+	}
+	log.Logger.Warnw(d.message(),
+		"deprecated", d.Name,
+		"replacement", d.Replacement,
+		"source", d.Source,
+		"removed_in", d.RemovedIn,
+	)
+}
+
+// warnDeprecatedFlag warns if the deprecated flag is specified in any of CLI flags, environment variables or the config file
+func warnDeprecatedFlag(flag *Flag, v any) {
+	if !flag.Deprecated || flag.Name == "" || !isSpecified(flag, v) {
+		return
+	}
+	name, source := specifiedName(flag)
+	deprecation{
+		Name:      name,
+		Source:    source,
+		RemovedIn: flag.RemovedIn,
+		Note:      flag.DeprecationNote,
+	}.warn()
+}
+
+// checkRemoved returns an error if the removed flag is specified
+func checkRemoved(flag *Flag) error {
+	if flag == nil || !flag.Removed || !isSpecified(flag, viper.Get(flag.ConfigName)) {
+		return nil
+	}
+	name, _ := specifiedName(flag)
+	msg := fmt.Sprintf("'%s' is no longer available", name)
+	if flag.RemovedIn != "" {
+		msg = fmt.Sprintf("'%s' was removed in %s", name, flag.RemovedIn)
+	}
+	if flag.DeprecationNote != "" {
+		msg += ". " + flag.DeprecationNote
+	}
+	return xerrors.New(msg)
+}
+
+// isSpecified returns true if the flag has a non-zero value set by the user
+func isSpecified(flag *Flag, v any) bool {
+	if !viper.IsSet(flag.ConfigName) || v == nil {
+		return false
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() > 0
+	}
+	return !rv.IsZero()
+}
+
+// specifiedName returns the name of the flag in the form the user specified
+func specifiedName(flag *Flag) (string, string) {
+	if env := envName(flag.Name); os.Getenv(env) != "" {
+		return env, sourceEnv
+	} else if viper.InConfig(flag.ConfigName) {
+		return flag.ConfigName, sourceConfig
+	}
+	return "--" + flag.Name, sourceCLI
+}
+
+func envName(name string) string {
+	return strings.ToUpper("trivy_" + strings.ReplaceAll(name, "-", "_"))
+}
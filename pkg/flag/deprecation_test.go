@@ -0,0 +1,206 @@
+package flag
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+func Test_deprecation(t *testing.T) {
+	renamedFlag := Flag{
+		Name:       "new-name",
+		ConfigName: "test.new-name",
+		Value:      "",
+		Aliases: []Alias{
+			{
+				Name:       "old-name",
+				ConfigName: "test.old-name",
+				Deprecated: true,
+				RemovedIn:  "v1.0.0",
+			},
+		},
+	}
+	deprecatedFlag := Flag{
+		Name:            "deprecated",
+		ConfigName:      "test.deprecated",
+		Value:           false,
+		Deprecated:      true,
+		DeprecationNote: "See the discussion.",
+	}
+
+	tests := []struct {
+		name       string
+		flag       *Flag
+		args       []string
+		env        map[string]string
+		config     map[string]interface{}
+		want       interface{}
+		wantLogs   []string
+		wantFields map[string]interface{}
+	}{
+		{
+			name: "new name",
+			flag: &renamedFlag,
+			args: []string{"--new-name", "foo"},
+			want: "foo",
+		},
+		{
+			name:     "deprecated alias in CLI",
+			flag:     &renamedFlag,
+			args:     []string{"--old-name", "foo"},
+			want:     "foo",
+			wantLogs: []string{"'--old-name' is deprecated. Use '--new-name' instead. It will be removed in v1.0.0."},
+			wantFields: map[string]interface{}{
+				"deprecated":  "--old-name",
+				"replacement": "--new-name",
+				"source":      "cli",
+				"removed_in":  "v1.0.0",
+			},
+		},
+		{
+			name: "deprecated alias in env",
+			flag: &renamedFlag,
+			env: map[string]string{
+				"TRIVY_OLD_NAME": "foo",
+			},
+			want:     "foo",
+			wantLogs: []string{"'TRIVY_OLD_NAME' is deprecated. Use 'TRIVY_NEW_NAME' instead. It will be removed in v1.0.0."},
+			wantFields: map[string]interface{}{
+				"deprecated":  "TRIVY_OLD_NAME",
+				"replacement": "TRIVY_NEW_NAME",
+				"source":      "env",
+				"removed_in":  "v1.0.0",
+			},
+		},
+		{
+			name: "deprecated alias in config",
+			flag: &renamedFlag,
+			config: map[string]interface{}{
+				"test.old-name": "foo",
+			},
+			want:     "foo",
+			wantLogs: []string{"'test.old-name' in config file is deprecated. Use 'test.new-name' instead. It will be removed in v1.0.0."},
+			wantFields: map[string]interface{}{
+				"deprecated":  "test.old-name",
+				"replacement": "test.new-name",
+				"source":      "config",
+				"removed_in":  "v1.0.0",
+			},
+		},
+		{
+			name:     "deprecated flag",
+			flag:     &deprecatedFlag,
+			args:     []string{"--deprecated"},
+			want:     true,
+			wantLogs: []string{"'--deprecated' is deprecated. It will be removed. See the discussion."},
+			wantFields: map[string]interface{}{
+				"deprecated":  "--deprecated",
+				"replacement": "",
+				"source":      "cli",
+				"removed_in":  "",
+			},
+		},
+		{
+			name: "deprecated flag not specified",
+			flag: &deprecatedFlag,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			t.Cleanup(func() {
+				warned.Range(func(k, _ interface{}) bool {
+					warned.Delete(k)
+					return true
+				})
+			})
+
+			core, obs := observer.New(zap.WarnLevel)
+			log.Logger = zap.New(core).Sugar()
+
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			for k, v := range tt.config {
+				viper.Set(k, v)
+			}
+
+			cmd := &cobra.Command{}
+			addFlag(cmd, tt.flag)
+			aliases := make(flagAliases)
+			aliases.Add(tt.flag)
+			cmd.Flags().SetNormalizeFunc(aliases.NormalizeFunc())
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			// Flags are bound twice in commands, but the warning is shown once
+			require.NoError(t, bind(cmd, tt.flag))
+			require.NoError(t, bind(cmd, tt.flag))
+
+			var got interface{}
+			for i := 0; i < 2; i++ {
+				got = getValue(tt.flag)
+			}
+			assert.Equal(t, tt.want, got)
+
+			var gotLogs []string
+			for _, entry := range obs.AllUntimed() {
+				gotLogs = append(gotLogs, entry.Message)
+				if tt.wantFields != nil {
+					assert.Equal(t, tt.wantFields, entry.ContextMap())
+				}
+			}
+			assert.Equal(t, tt.wantLogs, gotLogs)
+		})
+	}
+}
+
+func Test_checkRemoved(t *testing.T) {
+	removedFlag := Flag{
+		Name:            "removed",
+		ConfigName:      "test.removed",
+		Value:           "",
+		Removed:         true,
+		RemovedIn:       "v0.1.0",
+		DeprecationNote: "Use 'trivy sbom' instead",
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "specified",
+			args:    []string{"--removed", "cyclonedx"},
+			wantErr: "'--removed' was removed in v0.1.0. Use 'trivy sbom' instead",
+		},
+		{
+			name: "not specified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+
+			cmd := &cobra.Command{}
+			addFlag(cmd, &removedFlag)
+			require.NoError(t, cmd.ParseFlags(tt.args))
+			require.NoError(t, bind(cmd, &removedFlag))
+
+			err := checkRemoved(&removedFlag)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package flag
+
+var (
+	BaselineImageFlag = Flag{
+		Name:       "baseline-image",
+		ConfigName: "drift.baseline-image",
+		Value:      "",
+		Usage:      "[EXPERIMENTAL] report packages and executables added or modified since the specified image",
+	}
+	ExitOnDriftFlag = Flag{
+		Name:       "exit-on-drift",
+		ConfigName: "drift.exit-on-drift",
+		Value:      0,
+		Usage:      "exit with the specified code when drift is detected, taking precedence over --exit-code",
+	}
+)
+
+type DriftFlagGroup struct {
+	BaselineImage *Flag
+	ExitOnDrift   *Flag
+}
+
+type DriftOptions struct {
+	BaselineImage string
+	ExitOnDrift   int
+}
+
+func NewDriftFlagGroup() *DriftFlagGroup {
+	return &DriftFlagGroup{
+		BaselineImage: &BaselineImageFlag,
+		ExitOnDrift:   &ExitOnDriftFlag,
+	}
+}
+
+func (f *DriftFlagGroup) Name() string {
+	return "Drift"
+}
+
+func (f *DriftFlagGroup) Flags() []*Flag {
+	return []*Flag{
+		f.BaselineImage,
+		f.ExitOnDrift,
+	}
+}
+
+func (f *DriftFlagGroup) ToOptions() DriftOptions {
+	return DriftOptions{
+		BaselineImage: getString(f.BaselineImage),
+		ExitOnDrift:   getInt(f.ExitOnDrift),
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/zhanglimao/trivy/pkg/log"
 	"github.com/zhanglimao/trivy/pkg/utils/fsutils"
 )
 
@@ -56,6 +57,20 @@ var (
 		Usage:      "timeout",
 		Persistent: true,
 	}
+	LogFormatFlag = Flag{
+		Name:       "log-format",
+		ConfigName: "log.format",
+		Value:      string(log.FormatText),
+		Usage:      "log format (text,json)",
+		Persistent: true,
+	}
+	LocaleFlag = Flag{
+		Name:       "locale",
+		ConfigName: "locale",
+		Value:      "",
+		Usage:      "language of the table output (en,zh-CN,ja). Detected from LC_ALL, LC_MESSAGES and LANG if not specified",
+		Persistent: true,
+	}
 	CacheDirFlag = Flag{
 		Name:       "cache-dir",
 		ConfigName: "cache.dir",
@@ -63,6 +78,20 @@ var (
 		Usage:      "cache directory",
 		Persistent: true,
 	}
+	MetricsListenFlag = Flag{
+		Name:       "metrics-listen",
+		ConfigName: "metrics.listen",
+		Value:      "",
+		Usage:      "listen address to expose Prometheus metrics during the scan, e.g. for long-running scans",
+		Persistent: true,
+	}
+	OTLPEndpointFlag = Flag{
+		Name:       "otlp-endpoint",
+		ConfigName: "otlp.endpoint",
+		Value:      "",
+		Usage:      "OTLP/gRPC endpoint to export traces of the scan to, e.g. http://localhost:4317",
+		Persistent: true,
+	}
 	GenerateDefaultConfigFlag = Flag{
 		Name:       "generate-default-config",
 		ConfigName: "generate-default-config",
@@ -80,7 +109,11 @@ type GlobalFlagGroup struct {
 	Debug                 *Flag
 	Insecure              *Flag
 	Timeout               *Flag
+	LogFormat             *Flag
+	Locale                *Flag
 	CacheDir              *Flag
+	MetricsListen         *Flag
+	OTLPEndpoint          *Flag
 	GenerateDefaultConfig *Flag
 }
 
@@ -92,7 +125,11 @@ type GlobalOptions struct {
 	Debug                 bool
 	Insecure              bool
 	Timeout               time.Duration
+	LogFormat             log.Format
+	Locale                string
 	CacheDir              string
+	MetricsListen         string
+	OTLPEndpoint          string
 	GenerateDefaultConfig bool
 	OsName                string
 	OsFamily              string
@@ -106,7 +143,11 @@ func NewGlobalFlagGroup() *GlobalFlagGroup {
 		Debug:                 &DebugFlag,
 		Insecure:              &InsecureFlag,
 		Timeout:               &TimeoutFlag,
+		LogFormat:             &LogFormatFlag,
+		Locale:                &LocaleFlag,
 		CacheDir:              &CacheDirFlag,
+		MetricsListen:         &MetricsListenFlag,
+		OTLPEndpoint:          &OTLPEndpointFlag,
 		GenerateDefaultConfig: &GenerateDefaultConfigFlag,
 	}
 }
@@ -119,7 +160,11 @@ func (f *GlobalFlagGroup) flags() []*Flag {
 		f.Debug,
 		f.Insecure,
 		f.Timeout,
+		f.LogFormat,
+		f.Locale,
 		f.CacheDir,
+		f.MetricsListen,
+		f.OTLPEndpoint,
 		f.GenerateDefaultConfig,
 	}
 }
@@ -150,7 +195,11 @@ func (f *GlobalFlagGroup) ToOptions() GlobalOptions {
 		Debug:                 getBool(f.Debug),
 		Insecure:              insecure,
 		Timeout:               getDuration(f.Timeout),
+		LogFormat:             log.Format(getString(f.LogFormat)),
+		Locale:                getString(f.Locale),
 		CacheDir:              getString(f.CacheDir),
+		MetricsListen:         getString(f.MetricsListen),
+		OTLPEndpoint:          getString(f.OTLPEndpoint),
 		GenerateDefaultConfig: getBool(f.GenerateDefaultConfig),
 	}
 }
@@ -0,0 +1,58 @@
+package flag
+
+var (
+	googleProjectFlag = Flag{
+		Name:       "project",
+		ConfigName: "cloud.google.project",
+		Value:      "",
+		Usage:      "The Google Cloud project to scan. The project of the application default credentials is scanned by default.",
+	}
+	googleServiceFlag = Flag{
+		Name:       "service",
+		ConfigName: "cloud.google.service",
+		Value:      []string{},
+		Usage:      "Only scan Google Cloud Service(s) specified with this flag. Can specify multiple services using --service A --service B etc.",
+	}
+	googleResourceFlag = Flag{
+		Name:       "resource",
+		ConfigName: "cloud.google.resource",
+		Value:      "",
+		Usage:      "The Google Cloud resource URL to show results for. Useful to filter results once a scan is cached.",
+	}
+)
+
+type GoogleFlagGroup struct {
+	Project  *Flag
+	Services *Flag
+	Resource *Flag
+}
+
+type GoogleOptions struct {
+	Project        string
+	GoogleServices []string
+	GoogleResource string
+}
+
+func NewGoogleFlagGroup() *GoogleFlagGroup {
+	return &GoogleFlagGroup{
+		Project:  &googleProjectFlag,
+		Services: &googleServiceFlag,
+		Resource: &googleResourceFlag,
+	}
+}
+
+func (f *GoogleFlagGroup) Name() string {
+	return "Google Cloud"
+}
+
+func (f *GoogleFlagGroup) Flags() []*Flag {
+	return []*Flag{f.Project, f.Services, f.Resource}
+}
+
+func (f *GoogleFlagGroup) ToOptions() GoogleOptions {
+	return GoogleOptions{
+		Project:        getString(f.Project),
+		GoogleServices: getStringSlice(f.Services),
+		GoogleResource: getString(f.Resource),
+	}
+}
@@ -1,6 +1,8 @@
 package flag
 
 import (
+	"strings"
+
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"golang.org/x/exp/slices"
 	"golang.org/x/xerrors"
@@ -9,6 +11,13 @@ import (
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
+const allPlatforms = "all"
+
+const (
+	TagDriftWarn = "warn"
+	TagDriftFail = "fail"
+)
+
 // e.g. config yaml
 // image:
 //   removed-pkgs: true
@@ -36,8 +45,8 @@ var (
 	PlatformFlag = Flag{
 		Name:       "platform",
 		ConfigName: "image.platform",
-		Value:      "",
-		Usage:      "set platform in the form os/arch if image is multi-platform capable",
+		Value:      []string{},
+		Usage:      "set platform in the form os/arch if image is multi-platform capable, repeat the flag or specify 'all' to scan multiple platforms",
 	}
 	DockerHostFlag = Flag{
 		Name:       "docker-host",
@@ -45,40 +54,177 @@ var (
 		Value:      "",
 		Usage:      "unix domain socket path to use for docker scanning",
 	}
+	PodmanHostFlag = Flag{
+		Name:       "podman-host",
+		ConfigName: "image.podman.host",
+		Value:      "",
+		Usage:      "podman API socket to use for podman scanning (unix:// or ssh://)",
+	}
+	ContainerdAddressFlag = Flag{
+		Name:       "containerd-address",
+		ConfigName: "image.containerd.address",
+		Value:      "",
+		Usage:      "containerd socket path to use for containerd scanning",
+	}
+	ContainerdNamespaceFlag = Flag{
+		Name:       "containerd-namespace",
+		ConfigName: "image.containerd.namespace",
+		Value:      "",
+		Usage:      "containerd namespace to look up images in (e.g. k8s.io)",
+	}
+	CRIOStorageRootFlag = Flag{
+		Name:       "crio-storage-root",
+		ConfigName: "image.crio.storage-root",
+		Value:      "",
+		Usage:      "root directory of containers/storage used by CRI-O",
+	}
+	TagDriftFlag = Flag{
+		Name:       "tag-drift",
+		ConfigName: "image.tag-drift",
+		Value:      "",
+		Usage:      "warn or fail if the digest of the scanned tag changed since the previous scan (warn,fail)",
+	}
+	ReconstructDockerfileFlag = Flag{
+		Name:       "reconstruct-dockerfile",
+		ConfigName: "image.reconstruct-dockerfile",
+		Value:      false,
+		Usage:      "include the Dockerfile reconstructed from the image history in the report",
+	}
+	DockerfileOutputFlag = Flag{
+		Name:       "dockerfile-output",
+		ConfigName: "image.dockerfile-output",
+		Value:      "",
+		Usage:      "write the Dockerfile reconstructed from the image history to the file",
+	}
+	DetectBaseImageFlag = Flag{
+		Name:       "detect-base-image",
+		ConfigName: "image.detect-base-image",
+		Value:      false,
+		Usage:      "detect the base image and annotate findings originating in base layers",
+	}
+	RecommendRebaseFlag = Flag{
+		Name:       "recommend-rebase",
+		ConfigName: "image.recommend-rebase",
+		Value:      false,
+		Usage:      "recommend newer tags of the base image and estimate findings resolved by rebasing (implies --detect-base-image)",
+	}
+	LayerSizeFlag = Flag{
+		Name:       "layer-size",
+		ConfigName: "image.layer-size",
+		Value:      false,
+		Usage:      "report per-layer sizes and space wasted by files removed or overwritten in upper layers",
+	}
+	VerifyIntegrityFlag = Flag{
+		Name:       "verify-integrity",
+		ConfigName: "image.verify-integrity",
+		Value:      false,
+		Usage:      "report files of OS packages modified after installation by verifying digests against the rpm and dpkg databases",
+	}
+	AuditPermissionsFlag = Flag{
+		Name:       "audit-permissions",
+		ConfigName: "image.audit-permissions",
+		Value:      false,
+		Usage:      "report setuid/setgid executables, file capabilities, world-writable files and sensitive files readable by all as misconfigurations (requires '--scanners misconfig')",
+	}
+	PermissionAllowlistFlag = Flag{
+		Name:       "permission-allowlist",
+		ConfigName: "image.permission-allowlist",
+		Value:      "",
+		Usage:      "specify the allowlist of files expected to have privileges or loose permissions for --audit-permissions",
+	}
+	PushReferrerFlag = Flag{
+		Name:       "push-referrer",
+		ConfigName: "image.push-referrer",
+		Value:      false,
+		Usage:      "push the report as an OCI referrer of the scanned image digest (json,sarif,cyclonedx,spdx-json)",
+	}
+	ReuseResultsFlag = Flag{
+		Name:       "reuse-results",
+		ConfigName: "image.reuse-results",
+		Value:      false,
+		Usage:      "reuse the JSON report pushed with --push-referrer for the same image digest and DB instead of scanning",
+	}
 	SourceFlag = Flag{
 		Name:       "image-src",
 		ConfigName: "image.source",
-		Value:      ftypes.AllImageSources.StringSlice(),
-		Usage:      "image source(s) to use, in priority order (docker,containerd,podman,remote)",
+		Value:      ftypes.DefaultImageSources.StringSlice(),
+		Usage:      "image source(s) to use, in priority order (docker,containerd,podman,crio,remote)",
 	}
 )
 
 type ImageFlagGroup struct {
-	Input               *Flag // local image archive
-	ImageConfigScanners *Flag
-	ScanRemovedPkgs     *Flag
-	Platform            *Flag
-	DockerHost          *Flag
-	ImageSources        *Flag
+	Input                 *Flag // local image archive
+	ImageConfigScanners   *Flag
+	ScanRemovedPkgs       *Flag
+	Platform              *Flag
+	DockerHost            *Flag
+	PodmanHost            *Flag
+	ContainerdAddress     *Flag
+	ContainerdNamespace   *Flag
+	CRIOStorageRoot       *Flag
+	TagDrift              *Flag
+	ReconstructDockerfile *Flag
+	DockerfileOutput      *Flag
+	DetectBaseImage       *Flag
+	RecommendRebase       *Flag
+	LayerSize             *Flag
+	VerifyIntegrity       *Flag
+	AuditPermissions      *Flag
+	PermissionAllowlist   *Flag
+	PushReferrer          *Flag
+	ReuseResults          *Flag
+	ImageSources          *Flag
 }
 
 type ImageOptions struct {
-	Input               string
-	ImageConfigScanners types.Scanners
-	ScanRemovedPkgs     bool
-	Platform            ftypes.Platform
-	DockerHost          string
-	ImageSources        ftypes.ImageSources
+	Input                 string
+	ImageConfigScanners   types.Scanners
+	ScanRemovedPkgs       bool
+	Platform              ftypes.Platform
+	Platforms             []ftypes.Platform // multiple platforms to be scanned
+	AllPlatforms          bool              // scan all platforms in the image index
+	DockerHost            string
+	PodmanHost            string
+	ContainerdAddress     string
+	ContainerdNamespace   string
+	CRIOStorageRoot       string
+	TagDrift              string // action on tag drift, "warn" or "fail"
+	ReconstructDockerfile bool
+	DockerfileOutput      string // file path to write the reconstructed Dockerfile
+	DetectBaseImage       bool
+	RecommendRebase       bool
+	LayerSize             bool
+	VerifyIntegrity       bool
+	AuditPermissions      bool
+	PermissionAllowlist   string
+	PushReferrer          bool
+	ReuseResults          bool
+	ImageSources          ftypes.ImageSources
 }
 
 func NewImageFlagGroup() *ImageFlagGroup {
 	return &ImageFlagGroup{
-		Input:               &InputFlag,
-		ImageConfigScanners: &ImageConfigScannersFlag,
-		ScanRemovedPkgs:     &ScanRemovedPkgsFlag,
-		Platform:            &PlatformFlag,
-		DockerHost:          &DockerHostFlag,
-		ImageSources:        &SourceFlag,
+		Input:                 &InputFlag,
+		ImageConfigScanners:   &ImageConfigScannersFlag,
+		ScanRemovedPkgs:       &ScanRemovedPkgsFlag,
+		Platform:              &PlatformFlag,
+		DockerHost:            &DockerHostFlag,
+		PodmanHost:            &PodmanHostFlag,
+		ContainerdAddress:     &ContainerdAddressFlag,
+		ContainerdNamespace:   &ContainerdNamespaceFlag,
+		CRIOStorageRoot:       &CRIOStorageRootFlag,
+		TagDrift:              &TagDriftFlag,
+		ReconstructDockerfile: &ReconstructDockerfileFlag,
+		DockerfileOutput:      &DockerfileOutputFlag,
+		DetectBaseImage:       &DetectBaseImageFlag,
+		RecommendRebase:       &RecommendRebaseFlag,
+		LayerSize:             &LayerSizeFlag,
+		VerifyIntegrity:       &VerifyIntegrityFlag,
+		AuditPermissions:      &AuditPermissionsFlag,
+		PermissionAllowlist:   &PermissionAllowlistFlag,
+		PushReferrer:          &PushReferrerFlag,
+		ReuseResults:          &ReuseResultsFlag,
+		ImageSources:          &SourceFlag,
 	}
 }
 
@@ -93,6 +239,21 @@ func (f *ImageFlagGroup) Flags() []*Flag {
 		f.ScanRemovedPkgs,
 		f.Platform,
 		f.DockerHost,
+		f.PodmanHost,
+		f.ContainerdAddress,
+		f.ContainerdNamespace,
+		f.CRIOStorageRoot,
+		f.TagDrift,
+		f.ReconstructDockerfile,
+		f.DockerfileOutput,
+		f.DetectBaseImage,
+		f.RecommendRebase,
+		f.LayerSize,
+		f.VerifyIntegrity,
+		f.AuditPermissions,
+		f.PermissionAllowlist,
+		f.PushReferrer,
+		f.ReuseResults,
 		f.ImageSources,
 	}
 }
@@ -108,25 +269,45 @@ func (f *ImageFlagGroup) ToOptions() (ImageOptions, error) {
 		return ImageOptions{}, xerrors.Errorf("unable to parse image sources: %w", err)
 	}
 
+	platforms, all, err := parsePlatforms(getStringSlice(f.Platform))
+	if err != nil {
+		return ImageOptions{}, xerrors.Errorf("unable to parse platform: %w", err)
+	}
+
+	tagDrift := getString(f.TagDrift)
+	if tagDrift != "" && tagDrift != TagDriftWarn && tagDrift != TagDriftFail {
+		return ImageOptions{}, xerrors.Errorf("unknown tag drift action: %s", tagDrift)
+	}
+
 	var platform ftypes.Platform
-	if p := getString(f.Platform); p != "" {
-		pl, err := v1.ParsePlatform(p)
-		if err != nil {
-			return ImageOptions{}, xerrors.Errorf("unable to parse platform: %w", err)
-		}
-		if pl.OS == "*" {
-			pl.OS = "" // Empty OS means any OS
-		}
-		platform = ftypes.Platform{Platform: pl}
+	if len(platforms) == 1 {
+		platform = platforms[0]
 	}
 
 	return ImageOptions{
-		Input:               getString(f.Input),
-		ImageConfigScanners: scanners,
-		ScanRemovedPkgs:     getBool(f.ScanRemovedPkgs),
-		Platform:            platform,
-		DockerHost:          getString(f.DockerHost),
-		ImageSources:        imageSources,
+		Input:                 getString(f.Input),
+		ImageConfigScanners:   scanners,
+		ScanRemovedPkgs:       getBool(f.ScanRemovedPkgs),
+		Platform:              platform,
+		Platforms:             platforms,
+		AllPlatforms:          all,
+		DockerHost:            getString(f.DockerHost),
+		PodmanHost:            getString(f.PodmanHost),
+		ContainerdAddress:     getString(f.ContainerdAddress),
+		ContainerdNamespace:   getString(f.ContainerdNamespace),
+		CRIOStorageRoot:       getString(f.CRIOStorageRoot),
+		TagDrift:              tagDrift,
+		ReconstructDockerfile: getBool(f.ReconstructDockerfile),
+		DockerfileOutput:      getString(f.DockerfileOutput),
+		DetectBaseImage:       getBool(f.DetectBaseImage) || getBool(f.RecommendRebase),
+		RecommendRebase:       getBool(f.RecommendRebase),
+		LayerSize:             getBool(f.LayerSize),
+		VerifyIntegrity:       getBool(f.VerifyIntegrity),
+		AuditPermissions:      getBool(f.AuditPermissions),
+		PermissionAllowlist:   getString(f.PermissionAllowlist),
+		PushReferrer:          getBool(f.PushReferrer),
+		ReuseResults:          getBool(f.ReuseResults),
+		ImageSources:          imageSources,
 	}, nil
 }
 
@@ -141,3 +322,26 @@ func parseImageSources(srcs []string) (ftypes.ImageSources, error) {
 	}
 	return imageSources, nil
 }
+
+// parsePlatforms parses platforms in the form os/arch.
+// It returns true when all platforms in the image index should be scanned.
+func parsePlatforms(platforms []string) ([]ftypes.Platform, bool, error) {
+	var parsed []ftypes.Platform
+	for _, p := range platforms {
+		switch p = strings.TrimSpace(p); p {
+		case "":
+			continue
+		case allPlatforms:
+			return nil, true, nil
+		}
+		pl, err := v1.ParsePlatform(p)
+		if err != nil {
+			return nil, false, xerrors.Errorf("invalid platform %q: %w", p, err)
+		}
+		if pl.OS == "*" {
+			pl.OS = "" // Empty OS means any OS
+		}
+		parsed = append(parsed, ftypes.Platform{Platform: pl})
+	}
+	return parsed, false, nil
+}
@@ -0,0 +1,119 @@
+package flag_test
+
+import (
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/flag"
+)
+
+func TestImageFlagGroup_ToOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		platforms []string
+		tagDrift  string
+		want      flag.ImageOptions
+		wantErr   string
+	}{
+		{
+			name: "no platform",
+			want: flag.ImageOptions{
+				ImageSources: ftypes.AllImageSources,
+			},
+		},
+		{
+			name:      "single platform",
+			platforms: []string{"linux/arm64"},
+			want: flag.ImageOptions{
+				Platform: ftypes.Platform{
+					Platform: &v1.Platform{
+						OS:           "linux",
+						Architecture: "arm64",
+					},
+				},
+				Platforms: []ftypes.Platform{
+					{
+						Platform: &v1.Platform{
+							OS:           "linux",
+							Architecture: "arm64",
+						},
+					},
+				},
+				ImageSources: ftypes.AllImageSources,
+			},
+		},
+		{
+			name:      "multiple platforms",
+			platforms: []string{"linux/amd64", "*/arm64"},
+			want: flag.ImageOptions{
+				Platforms: []ftypes.Platform{
+					{
+						Platform: &v1.Platform{
+							OS:           "linux",
+							Architecture: "amd64",
+						},
+					},
+					{
+						Platform: &v1.Platform{
+							Architecture: "arm64",
+						},
+					},
+				},
+				ImageSources: ftypes.AllImageSources,
+			},
+		},
+		{
+			name:      "all platforms",
+			platforms: []string{"all"},
+			want: flag.ImageOptions{
+				AllPlatforms: true,
+				ImageSources: ftypes.AllImageSources,
+			},
+		},
+		{
+			name:      "invalid platform",
+			platforms: []string{"linux/amd64/v3/foo"},
+			wantErr:   "unable to parse platform",
+		},
+		{
+			name:     "tag drift",
+			tagDrift: "fail",
+			want: flag.ImageOptions{
+				TagDrift:     flag.TagDriftFail,
+				ImageSources: ftypes.AllImageSources,
+			},
+		},
+		{
+			name:     "invalid tag drift",
+			tagDrift: "error",
+			wantErr:  "unknown tag drift action",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Set(flag.PlatformFlag.ConfigName, tt.platforms)
+			viper.Set(flag.SourceFlag.ConfigName, ftypes.AllImageSources.StringSlice())
+			viper.Set(flag.TagDriftFlag.ConfigName, tt.tagDrift)
+
+			f := &flag.ImageFlagGroup{
+				Platform:     &flag.PlatformFlag,
+				TagDrift:     &flag.TagDriftFlag,
+				ImageSources: &flag.SourceFlag,
+			}
+
+			got, err := f.ToOptions()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
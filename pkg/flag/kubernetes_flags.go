@@ -2,6 +2,7 @@ package flag
 
 import (
 	"strconv"
+	"time"
 
 	"fmt"
 	"strings"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 var (
@@ -56,6 +58,12 @@ var (
 		Value:      5,
 		Usage:      "number (between 1-20) of goroutines enabled for parallel scanning",
 	}
+	TargetTimeoutFlag = Flag{
+		Name:       "target-timeout",
+		ConfigName: "kubernetes.target-timeout",
+		Value:      time.Duration(0),
+		Usage:      "timeout for scanning each image and node, so that a single target can't consume the entire --timeout (0: unlimited)",
+	}
 	TolerationsFlag = Flag{
 		Name:       "tolerations",
 		ConfigName: "kubernetes.tolerations",
@@ -69,6 +77,37 @@ var (
 		Value:      false,
 		Usage:      "fetch resources from all cluster namespaces",
 	}
+	IncludeNamespaces = Flag{
+		Name:       "include-namespaces",
+		ConfigName: "kubernetes.include-namespaces",
+		Value:      []string{},
+		Usage:      "only scan resources in the specified namespaces (example: app,monitoring)",
+	}
+	ExcludeNamespaces = Flag{
+		Name:       "exclude-namespaces",
+		ConfigName: "kubernetes.exclude-namespaces",
+		Value:      []string{},
+		Usage:      "skip resources in the specified namespaces (example: kube-system,kube-public)",
+	}
+	LabelSelector = Flag{
+		Name:       "selector",
+		ConfigName: "kubernetes.selector",
+		Shorthand:  "l",
+		Value:      "",
+		Usage:      "only scan resources matching the label selector (example: app=nginx,tier!=frontend)",
+	}
+	NodeScan = Flag{
+		Name:       "node-scan",
+		ConfigName: "kubernetes.node-scan",
+		Value:      false,
+		Usage:      "[EXPERIMENTAL] scan the OS packages of the cluster nodes by running a privileged job on each node",
+	}
+	NodeScanImage = Flag{
+		Name:       "node-scan-image",
+		ConfigName: "kubernetes.node-scan-image",
+		Value:      "busybox:1.36",
+		Usage:      "image of the node scan jobs, which needs a shell with tar, gzip and base64",
+	}
 	NodeCollectorNamespace = Flag{
 		Name:       "node-collector-namespace",
 		ConfigName: "node.collector.namespace",
@@ -90,8 +129,14 @@ type K8sFlagGroup struct {
 	Components             *Flag
 	K8sVersion             *Flag
 	Parallel               *Flag
+	TargetTimeout          *Flag
 	Tolerations            *Flag
 	AllNamespaces          *Flag
+	IncludeNamespaces      *Flag
+	ExcludeNamespaces      *Flag
+	LabelSelector          *Flag
+	NodeScan               *Flag
+	NodeScanImage          *Flag
 	NodeCollectorNamespace *Flag
 	ExcludeNodes           *Flag
 }
@@ -103,8 +148,14 @@ type K8sOptions struct {
 	Components             []string
 	K8sVersion             string
 	Parallel               int
+	TargetTimeout          time.Duration
 	Tolerations            []corev1.Toleration
 	AllNamespaces          bool
+	IncludeNamespaces      []string
+	ExcludeNamespaces      []string
+	LabelSelector          string
+	NodeScan               bool
+	NodeScanImage          string
 	NodeCollectorNamespace string
 	ExcludeNodes           map[string]string
 }
@@ -117,8 +168,14 @@ func NewK8sFlagGroup() *K8sFlagGroup {
 		Components:             &ComponentsFlag,
 		K8sVersion:             &K8sVersionFlag,
 		Parallel:               &ParallelFlag,
+		TargetTimeout:          &TargetTimeoutFlag,
 		Tolerations:            &TolerationsFlag,
 		AllNamespaces:          &AllNamespaces,
+		IncludeNamespaces:      &IncludeNamespaces,
+		ExcludeNamespaces:      &ExcludeNamespaces,
+		LabelSelector:          &LabelSelector,
+		NodeScan:               &NodeScan,
+		NodeScanImage:          &NodeScanImage,
 		NodeCollectorNamespace: &NodeCollectorNamespace,
 		ExcludeNodes:           &ExcludeNodes,
 	}
@@ -136,8 +193,14 @@ func (f *K8sFlagGroup) Flags() []*Flag {
 		f.Components,
 		f.K8sVersion,
 		f.Parallel,
+		f.TargetTimeout,
 		f.Tolerations,
 		f.AllNamespaces,
+		f.IncludeNamespaces,
+		f.ExcludeNamespaces,
+		f.LabelSelector,
+		f.NodeScan,
+		f.NodeScanImage,
 		f.NodeCollectorNamespace,
 		f.ExcludeNodes,
 	}
@@ -166,6 +229,11 @@ func (f *K8sFlagGroup) ToOptions() (K8sOptions, error) {
 		exludeNodeLabels[excludeNodeParts[0]] = excludeNodeParts[1]
 	}
 
+	selector := getString(f.LabelSelector)
+	if _, err = labels.Parse(selector); err != nil {
+		return K8sOptions{}, xerrors.Errorf("invalid label selector %q: %w", selector, err)
+	}
+
 	return K8sOptions{
 		ClusterContext:         getString(f.ClusterContext),
 		Namespace:              getString(f.Namespace),
@@ -173,8 +241,14 @@ func (f *K8sFlagGroup) ToOptions() (K8sOptions, error) {
 		Components:             getStringSlice(f.Components),
 		K8sVersion:             getString(f.K8sVersion),
 		Parallel:               parallel,
+		TargetTimeout:          getDuration(f.TargetTimeout),
 		Tolerations:            tolerations,
 		AllNamespaces:          getBool(f.AllNamespaces),
+		IncludeNamespaces:      getStringSlice(f.IncludeNamespaces),
+		ExcludeNamespaces:      getStringSlice(f.ExcludeNamespaces),
+		LabelSelector:          selector,
+		NodeScan:               getBool(f.NodeScan),
+		NodeScanImage:          getString(f.NodeScanImage),
 		NodeCollectorNamespace: getString(f.NodeCollectorNamespace),
 		ExcludeNodes:           exludeNodeLabels,
 	}, nil
@@ -2,6 +2,7 @@ package flag
 
 import (
 	"github.com/zhanglimao/trivy/pkg/module"
+	"github.com/zhanglimao/trivy/pkg/module/external"
 )
 
 // e.g. config yaml
@@ -9,6 +10,7 @@ import (
 //   dir: "/path/to/my_modules"
 //   enable-modules:
 //     - spring4shell
+//   analyzer-plugin-dir: "/path/to/my_analyzer_plugins"
 
 var (
 	ModuleDirFlag = Flag{
@@ -25,23 +27,33 @@ var (
 		Usage:      "[EXPERIMENTAL] module names to enable",
 		Persistent: true,
 	}
+	AnalyzerPluginDirFlag = Flag{
+		Name:       "analyzer-plugin-dir",
+		ConfigName: "module.analyzer-plugin-dir",
+		Value:      external.DefaultDir,
+		Usage:      "[EXPERIMENTAL] specify directory to the analyzer plugins running as external processes",
+		Persistent: true,
+	}
 )
 
 // ModuleFlagGroup defines flags for modules
 type ModuleFlagGroup struct {
-	Dir            *Flag
-	EnabledModules *Flag
+	Dir               *Flag
+	EnabledModules    *Flag
+	AnalyzerPluginDir *Flag
 }
 
 type ModuleOptions struct {
-	ModuleDir      string
-	EnabledModules []string
+	ModuleDir         string
+	EnabledModules    []string
+	AnalyzerPluginDir string
 }
 
 func NewModuleFlagGroup() *ModuleFlagGroup {
 	return &ModuleFlagGroup{
-		Dir:            &ModuleDirFlag,
-		EnabledModules: &EnableModulesFlag,
+		Dir:               &ModuleDirFlag,
+		EnabledModules:    &EnableModulesFlag,
+		AnalyzerPluginDir: &AnalyzerPluginDirFlag,
 	}
 }
 
@@ -53,12 +65,14 @@ func (f *ModuleFlagGroup) Flags() []*Flag {
 	return []*Flag{
 		f.Dir,
 		f.EnabledModules,
+		f.AnalyzerPluginDir,
 	}
 }
 
 func (f *ModuleFlagGroup) ToOptions() ModuleOptions {
 	return ModuleOptions{
-		ModuleDir:      getString(f.Dir),
-		EnabledModules: getStringSlice(f.EnabledModules),
+		ModuleDir:         getString(f.Dir),
+		EnabledModules:    getStringSlice(f.EnabledModules),
+		AnalyzerPluginDir: getString(f.AnalyzerPluginDir),
 	}
 }
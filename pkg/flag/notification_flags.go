@@ -0,0 +1,289 @@
+package flag
+
+import (
+	"net/url"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/notification"
+)
+
+var (
+	NotifyWebhookFlag = Flag{
+		Name:       "notify-webhook",
+		ConfigName: "notification.webhook.url",
+		Value:      "",
+		Usage:      "URL to post the summary of the scan to",
+	}
+	NotifyWebhookSecretFlag = Flag{
+		Name:       "notify-webhook-secret",
+		ConfigName: "notification.webhook.secret",
+		Value:      "",
+		Usage:      "secret to sign the webhook payload with HMAC-SHA256",
+	}
+	NotifyReportURLFlag = Flag{
+		Name:       "notify-report-url",
+		ConfigName: "notification.report-url",
+		Value:      "",
+		Usage:      "link to the full report included in the notification, e.g. the CI job",
+	}
+	NotifyInlineReportFlag = Flag{
+		Name:       "notify-inline-report",
+		ConfigName: "notification.inline-report",
+		Value:      false,
+		Usage:      "include the whole report in the notification",
+	}
+	IssueTrackerFlag = Flag{
+		Name:       "issue-tracker",
+		ConfigName: "notification.issue.tracker",
+		Value:      "",
+		Usage:      "open or update issues for vulnerabilities in the tracker (github,jira)",
+	}
+	IssueURLFlag = Flag{
+		Name:       "issue-url",
+		ConfigName: "notification.issue.url",
+		Value:      "",
+		Usage:      "base URL of Jira or API URL of GitHub Enterprise",
+	}
+	IssueProjectFlag = Flag{
+		Name:       "issue-project",
+		ConfigName: "notification.issue.project",
+		Value:      "",
+		Usage:      "GitHub repository (owner/repo) or Jira project key to file issues in",
+	}
+	IssueUserFlag = Flag{
+		Name:       "issue-user",
+		ConfigName: "notification.issue.user",
+		Value:      "",
+		Usage:      "Jira user to authenticate with the token",
+	}
+	IssueTokenFlag = Flag{
+		Name:       "issue-token",
+		ConfigName: "notification.issue.token",
+		Value:      "",
+		Usage:      "GitHub token or Jira API token",
+	}
+	IssuePerFlag = Flag{
+		Name:       "issue-per",
+		ConfigName: "notification.issue.per",
+		Value:      notification.IssuePerVulnerability,
+		Usage:      "file an issue per vulnerability or target (vulnerability,target)",
+	}
+	IssueSeverityFlag = Flag{
+		Name:       "issue-severity",
+		ConfigName: "notification.issue.severity",
+		Value:      []string{dbTypes.SeverityCritical.String(), dbTypes.SeverityHigh.String()},
+		Usage:      "severities of vulnerabilities to file issues for",
+	}
+	DefectDojoURLFlag = Flag{
+		Name:       "defectdojo-url",
+		ConfigName: "notification.defectdojo.url",
+		Value:      "",
+		Usage:      "URL of DefectDojo to import the report into",
+	}
+	DefectDojoTokenFlag = Flag{
+		Name:       "defectdojo-token",
+		ConfigName: "notification.defectdojo.token",
+		Value:      "",
+		Usage:      "DefectDojo API v2 key",
+	}
+	DefectDojoProductFlag = Flag{
+		Name:       "defectdojo-product",
+		ConfigName: "notification.defectdojo.product",
+		Value:      "",
+		Usage:      "DefectDojo product to import the report into (default: artifact name)",
+	}
+	DefectDojoEngagementFlag = Flag{
+		Name:       "defectdojo-engagement",
+		ConfigName: "notification.defectdojo.engagement",
+		Value:      "",
+		Usage:      "DefectDojo engagement to import the report into (default: Trivy)",
+	}
+	DependencyTrackURLFlag = Flag{
+		Name:       "dtrack-url",
+		ConfigName: "notification.dependency-track.url",
+		Value:      "",
+		Usage:      "URL of Dependency-Track to upload the SBOM and VEX to",
+	}
+	DependencyTrackAPIKeyFlag = Flag{
+		Name:       "dtrack-api-key",
+		ConfigName: "notification.dependency-track.api-key",
+		Value:      "",
+		Usage:      "Dependency-Track API key",
+	}
+	DependencyTrackProjectFlag = Flag{
+		Name:       "dtrack-project",
+		ConfigName: "notification.dependency-track.project",
+		Value:      "",
+		Usage:      "Dependency-Track project to upload to (default: artifact name)",
+	}
+	DependencyTrackProjectVersionFlag = Flag{
+		Name:       "dtrack-project-version",
+		ConfigName: "notification.dependency-track.project-version",
+		Value:      "",
+		Usage:      "version of the Dependency-Track project",
+	}
+)
+
+// NotificationFlagGroup composes flags for notifications after scans
+type NotificationFlagGroup struct {
+	Webhook       *Flag
+	WebhookSecret *Flag
+	ReportURL     *Flag
+	InlineReport  *Flag
+
+	IssueTracker  *Flag
+	IssueURL      *Flag
+	IssueProject  *Flag
+	IssueUser     *Flag
+	IssueToken    *Flag
+	IssuePer      *Flag
+	IssueSeverity *Flag
+
+	DefectDojoURL        *Flag
+	DefectDojoToken      *Flag
+	DefectDojoProduct    *Flag
+	DefectDojoEngagement *Flag
+
+	DependencyTrackURL     *Flag
+	DependencyTrackAPIKey  *Flag
+	DependencyTrackProject *Flag
+	DependencyTrackVersion *Flag
+}
+
+type NotificationOptions struct {
+	NotifyWebhook       string
+	NotifyWebhookSecret string
+	NotifyReportURL     string
+	NotifyInlineReport  bool
+
+	IssueTracker  string
+	IssueURL      string
+	IssueProject  string
+	IssueUser     string
+	IssueToken    string
+	IssuePer      string
+	IssueSeverity []string
+
+	DefectDojoURL        string
+	DefectDojoToken      string
+	DefectDojoProduct    string
+	DefectDojoEngagement string
+
+	DependencyTrackURL     string
+	DependencyTrackAPIKey  string
+	DependencyTrackProject string
+	DependencyTrackVersion string
+}
+
+func NewNotificationFlagGroup() *NotificationFlagGroup {
+	return &NotificationFlagGroup{
+		Webhook:       &NotifyWebhookFlag,
+		WebhookSecret: &NotifyWebhookSecretFlag,
+		ReportURL:     &NotifyReportURLFlag,
+		InlineReport:  &NotifyInlineReportFlag,
+		IssueTracker:  &IssueTrackerFlag,
+		IssueURL:      &IssueURLFlag,
+		IssueProject:  &IssueProjectFlag,
+		IssueUser:     &IssueUserFlag,
+		IssueToken:    &IssueTokenFlag,
+		IssuePer:      &IssuePerFlag,
+		IssueSeverity: &IssueSeverityFlag,
+
+		DefectDojoURL:          &DefectDojoURLFlag,
+		DefectDojoToken:        &DefectDojoTokenFlag,
+		DefectDojoProduct:      &DefectDojoProductFlag,
+		DefectDojoEngagement:   &DefectDojoEngagementFlag,
+		DependencyTrackURL:     &DependencyTrackURLFlag,
+		DependencyTrackAPIKey:  &DependencyTrackAPIKeyFlag,
+		DependencyTrackProject: &DependencyTrackProjectFlag,
+		DependencyTrackVersion: &DependencyTrackProjectVersionFlag,
+	}
+}
+
+func (f *NotificationFlagGroup) Name() string {
+	return "Notification"
+}
+
+func (f *NotificationFlagGroup) Flags() []*Flag {
+	return []*Flag{f.Webhook, f.WebhookSecret, f.ReportURL, f.InlineReport,
+		f.IssueTracker, f.IssueURL, f.IssueProject, f.IssueUser, f.IssueToken, f.IssuePer, f.IssueSeverity,
+		f.DefectDojoURL, f.DefectDojoToken, f.DefectDojoProduct, f.DefectDojoEngagement,
+		f.DependencyTrackURL, f.DependencyTrackAPIKey, f.DependencyTrackProject, f.DependencyTrackVersion}
+}
+
+func (f *NotificationFlagGroup) ToOptions() (NotificationOptions, error) {
+	webhook := getString(f.Webhook)
+	if webhook != "" && !isHTTPURL(webhook) {
+		return NotificationOptions{}, xerrors.Errorf("invalid webhook URL: %s", webhook)
+	}
+
+	defectDojoURL := getString(f.DefectDojoURL)
+	defectDojoToken := getString(f.DefectDojoToken)
+	switch {
+	case defectDojoURL != "" && !isHTTPURL(defectDojoURL):
+		return NotificationOptions{}, xerrors.Errorf("invalid DefectDojo URL: %s", defectDojoURL)
+	case defectDojoURL != "" && defectDojoToken == "":
+		return NotificationOptions{}, xerrors.Errorf("'--%s' is required with '--%s'", DefectDojoTokenFlag.Name, DefectDojoURLFlag.Name)
+	}
+
+	dtrackURL := getString(f.DependencyTrackURL)
+	dtrackAPIKey := getString(f.DependencyTrackAPIKey)
+	switch {
+	case dtrackURL != "" && !isHTTPURL(dtrackURL):
+		return NotificationOptions{}, xerrors.Errorf("invalid Dependency-Track URL: %s", dtrackURL)
+	case dtrackURL != "" && dtrackAPIKey == "":
+		return NotificationOptions{}, xerrors.Errorf("'--%s' is required with '--%s'", DependencyTrackAPIKeyFlag.Name, DependencyTrackURLFlag.Name)
+	}
+
+	tracker := getString(f.IssueTracker)
+	project := getString(f.IssueProject)
+	issueURL := getString(f.IssueURL)
+	per := getString(f.IssuePer)
+	severities := getStringSlice(f.IssueSeverity)
+	switch {
+	case tracker != "" && tracker != notification.TrackerGitHub && tracker != notification.TrackerJira:
+		return NotificationOptions{}, xerrors.Errorf("unknown issue tracker: %s", tracker)
+	case tracker != "" && project == "":
+		return NotificationOptions{}, xerrors.Errorf("'--%s' is required with '--%s'", IssueProjectFlag.Name, IssueTrackerFlag.Name)
+	case tracker == notification.TrackerJira && issueURL == "":
+		return NotificationOptions{}, xerrors.Errorf("'--%s' is required for Jira", IssueURLFlag.Name)
+	case !slices.Contains([]string{notification.IssuePerVulnerability, notification.IssuePerTarget}, per):
+		return NotificationOptions{}, xerrors.Errorf("invalid '--%s': %s", IssuePerFlag.Name, per)
+	}
+	for _, s := range severities {
+		if _, err := dbTypes.NewSeverity(s); err != nil {
+			return NotificationOptions{}, xerrors.Errorf("invalid '--%s': %w", IssueSeverityFlag.Name, err)
+		}
+	}
+
+	return NotificationOptions{
+		NotifyWebhook:       webhook,
+		NotifyWebhookSecret: getString(f.WebhookSecret),
+		NotifyReportURL:     getString(f.ReportURL),
+		NotifyInlineReport:  getBool(f.InlineReport),
+		IssueTracker:        tracker,
+		IssueURL:            issueURL,
+		IssueProject:        project,
+		IssueUser:           getString(f.IssueUser),
+		IssueToken:          getString(f.IssueToken),
+		IssuePer:            per,
+		IssueSeverity:       severities,
+
+		DefectDojoURL:          defectDojoURL,
+		DefectDojoToken:        defectDojoToken,
+		DefectDojoProduct:      getString(f.DefectDojoProduct),
+		DefectDojoEngagement:   getString(f.DefectDojoEngagement),
+		DependencyTrackURL:     dtrackURL,
+		DependencyTrackAPIKey:  dtrackAPIKey,
+		DependencyTrackProject: getString(f.DependencyTrackProject),
+		DependencyTrackVersion: getString(f.DependencyTrackVersion),
+	}, nil
+}
+
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https")
+}
@@ -6,7 +6,6 @@ import (
 	"io"
 	"os"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/spf13/cast"
@@ -16,8 +15,10 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/notification"
 	"github.com/zhanglimao/trivy/pkg/report"
 	"github.com/zhanglimao/trivy/pkg/result"
 )
@@ -42,17 +43,34 @@ type Flag struct {
 	// Persistent represents if the flag is persistent
 	Persistent bool
 
-	// Deprecated represents if the flag is deprecated
+	// Deprecated represents if the flag is deprecated.
+	// A warning is shown when the flag is specified.
 	Deprecated bool
 
+	// Removed represents if the flag is no longer available.
+	// The flag is kept hidden so that an error with DeprecationNote is returned when it is specified.
+	Removed bool
+
+	// RemovedIn is the version in which the deprecated flag will be removed or the removed flag was removed, e.g. "v0.50.0"
+	RemovedIn string
+
+	// DeprecationNote explains what to do instead, e.g. a link to the discussion
+	DeprecationNote string
+
 	// Aliases represents aliases
 	Aliases []Alias
 }
 
+// Alias is another name of the flag. The value is mapped to the flag automatically.
 type Alias struct {
 	Name       string
 	ConfigName string
+
+	// Deprecated represents if the alias is deprecated, e.g. the flag was renamed
 	Deprecated bool
+
+	// RemovedIn is the version in which the deprecated alias will be removed
+	RemovedIn string
 }
 
 type FlagGroup interface {
@@ -61,15 +79,22 @@ type FlagGroup interface {
 }
 
 type Flags struct {
+	AttestFlagGroup        *AttestFlagGroup
 	AWSFlagGroup           *AWSFlagGroup
+	AzureFlagGroup         *AzureFlagGroup
+	BundleFlagGroup        *BundleFlagGroup
 	CacheFlagGroup         *CacheFlagGroup
 	CloudFlagGroup         *CloudFlagGroup
+	ContainerFlagGroup     *ContainerFlagGroup
 	DBFlagGroup            *DBFlagGroup
+	DriftFlagGroup         *DriftFlagGroup
+	GoogleFlagGroup        *GoogleFlagGroup
 	ImageFlagGroup         *ImageFlagGroup
 	K8sFlagGroup           *K8sFlagGroup
 	LicenseFlagGroup       *LicenseFlagGroup
 	MisconfFlagGroup       *MisconfFlagGroup
 	ModuleFlagGroup        *ModuleFlagGroup
+	NotificationFlagGroup  *NotificationFlagGroup
 	RemoteFlagGroup        *RemoteFlagGroup
 	RegistryFlagGroup      *RegistryFlagGroup
 	RegoFlagGroup          *RegoFlagGroup
@@ -78,21 +103,30 @@ type Flags struct {
 	SBOMFlagGroup          *SBOMFlagGroup
 	ScanFlagGroup          *ScanFlagGroup
 	SecretFlagGroup        *SecretFlagGroup
+	SSHFlagGroup           *SSHFlagGroup
+	SupplyChainFlagGroup   *SupplyChainFlagGroup
 	VulnerabilityFlagGroup *VulnerabilityFlagGroup
 }
 
 // Options holds all the runtime configuration
 type Options struct {
 	GlobalOptions
+	AttestOptions
 	AWSOptions
+	AzureOptions
+	BundleOptions
 	CacheOptions
 	CloudOptions
+	ContainerOptions
 	DBOptions
+	DriftOptions
+	GoogleOptions
 	ImageOptions
 	K8sOptions
 	LicenseOptions
 	MisconfOptions
 	ModuleOptions
+	NotificationOptions
 	RegistryOptions
 	RegoOptions
 	RemoteOptions
@@ -101,6 +135,8 @@ type Options struct {
 	SBOMOptions
 	ScanOptions
 	SecretOptions
+	SSHOptions
+	SupplyChainOptions
 	PacketOptions
 	VulnerabilityOptions
 
@@ -109,6 +145,12 @@ type Options struct {
 
 	// We don't want to allow disabled analyzers to be passed by users, but it is necessary for internal use.
 	DisabledAnalyzers []analyzer.Type
+
+	// DriftBaseline is the analyzed image of '--baseline-image', not populated via CLI flags
+	DriftBaseline *ftypes.ArtifactReference
+
+	// LayerProgress receives the progress of the layer inspection, not populated via CLI flags
+	LayerProgress artifact.Progress
 }
 
 type PacketOptions struct {
@@ -135,6 +177,7 @@ func (o *Options) RegistryOpts() ftypes.RegistryOptions {
 		Credentials:   o.Credentials,
 		RegistryToken: o.RegistryToken,
 		Insecure:      o.Insecure,
+		MaxRetries:    o.RegistryMaxRetries,
 		Platform:      o.Platform,
 		AWSRegion:     o.AWSOptions.Region,
 	}
@@ -145,11 +188,13 @@ func (o *Options) FilterOpts() result.FilterOption {
 	return result.FilterOption{
 		Severities:         o.Severities,
 		IgnoreUnfixed:      o.IgnoreUnfixed,
+		ESMFixed:           o.ESMFixed,
 		IncludeNonFailures: o.IncludeNonFailures,
 		IgnoreFile:         o.IgnoreFile,
 		PolicyFile:         o.IgnorePolicy,
 		IgnoreLicenses:     o.IgnoredLicenses,
 		VEXPath:            o.VEXPath,
+		Dedupe:             o.Dedupe,
 	}
 }
 
@@ -164,7 +209,48 @@ func (o *Options) ReportOpts() report.Option {
 		IncludeNonFailures: o.IncludeNonFailures,
 		Trace:              o.Trace,
 		Report:             o.ReportFormat,
+		SummaryTop:         o.SummaryTop,
+		GroupBy:            o.GroupBy,
 		Compliance:         o.Compliance,
+		Deterministic:      o.Deterministic,
+	}
+}
+
+// NotificationOpts returns options for notifications
+func (o *Options) NotificationOpts() notification.Option {
+	return notification.Option{
+		WebhookURL:    o.NotifyWebhook,
+		WebhookSecret: o.NotifyWebhookSecret,
+		ReportURL:     o.NotifyReportURL,
+		InlineReport:  o.NotifyInlineReport,
+	}
+}
+
+// IssueOpts returns options for filing issues
+func (o *Options) IssueOpts() notification.IssueOption {
+	return notification.IssueOption{
+		Tracker:    o.IssueTracker,
+		URL:        o.IssueURL,
+		Project:    o.IssueProject,
+		User:       o.IssueUser,
+		Token:      o.IssueToken,
+		Per:        o.IssuePer,
+		Severities: o.IssueSeverity,
+	}
+}
+
+// PublishOpts returns options for publishing reports
+func (o *Options) PublishOpts() notification.PublishOption {
+	return notification.PublishOption{
+		AppVersion:             o.AppVersion,
+		DefectDojoURL:          o.DefectDojoURL,
+		DefectDojoToken:        o.DefectDojoToken,
+		DefectDojoProduct:      o.DefectDojoProduct,
+		DefectDojoEngagement:   o.DefectDojoEngagement,
+		DependencyTrackURL:     o.DependencyTrackURL,
+		DependencyTrackAPIKey:  o.DependencyTrackAPIKey,
+		DependencyTrackProject: o.DependencyTrackProject,
+		DependencyTrackVersion: o.DependencyTrackVersion,
 	}
 }
 
@@ -194,7 +280,7 @@ func addFlag(cmd *cobra.Command, flag *Flag) {
 		flags.Float64P(flag.Name, flag.Shorthand, v, flag.Usage)
 	}
 
-	if flag.Deprecated {
+	if flag.Deprecated || flag.Removed {
 		flags.MarkHidden(flag.Name) // nolint: gosec
 	}
 }
@@ -228,20 +314,25 @@ func bind(cmd *cobra.Command, flag *Flag) error {
 
 func bindEnv(flag *Flag) error {
 	// We don't use viper.AutomaticEnv, so we need to add a prefix manually here.
-	envName := strings.ToUpper("trivy_" + strings.ReplaceAll(flag.Name, "-", "_"))
-	if err := viper.BindEnv(flag.ConfigName, envName); err != nil {
+	env := envName(flag.Name)
+	if err := viper.BindEnv(flag.ConfigName, env); err != nil {
 		return xerrors.Errorf("bind env error: %w", err)
 	}
 
 	// Bind env aliases
 	for _, alias := range flag.Aliases {
-		envAlias := strings.ToUpper("trivy_" + strings.ReplaceAll(alias.Name, "-", "_"))
+		envAlias := envName(alias.Name)
 		if err := viper.BindEnv(flag.ConfigName, envAlias); err != nil {
 			return xerrors.Errorf("bind env error: %w", err)
 		}
 		if alias.Deprecated {
 			if _, ok := os.LookupEnv(envAlias); ok {
-				log.Logger.Warnf("'%s' is deprecated. Use '%s' instead.", envAlias, envName)
+				deprecation{
+					Name:        envAlias,
+					Replacement: env,
+					Source:      sourceEnv,
+					RemovedIn:   alias.RemovedIn,
+				}.warn()
 			}
 		}
 	}
@@ -297,11 +388,19 @@ func getValue(flag *Flag) any {
 		}
 		v = viper.Get(alias.ConfigName)
 		if v != nil {
-			log.Logger.Warnf("'%s' in config file is deprecated. Use '%s' instead.", alias.ConfigName, flag.ConfigName)
+			deprecation{
+				Name:        alias.ConfigName,
+				Replacement: flag.ConfigName,
+				Source:      sourceConfig,
+				RemovedIn:   alias.RemovedIn,
+			}.warn()
 			return v
 		}
 	}
-	return viper.Get(flag.ConfigName)
+
+	v = viper.Get(flag.ConfigName)
+	warnDeprecatedFlag(flag, v)
+	return v
 }
 
 func (f *Flags) groups() []FlagGroup {
@@ -325,6 +424,15 @@ func (f *Flags) groups() []FlagGroup {
 	if f.ImageFlagGroup != nil {
 		groups = append(groups, f.ImageFlagGroup)
 	}
+	if f.ContainerFlagGroup != nil {
+		groups = append(groups, f.ContainerFlagGroup)
+	}
+	if f.DriftFlagGroup != nil {
+		groups = append(groups, f.DriftFlagGroup)
+	}
+	if f.SupplyChainFlagGroup != nil {
+		groups = append(groups, f.SupplyChainFlagGroup)
+	}
 	if f.SBOMFlagGroup != nil {
 		groups = append(groups, f.SBOMFlagGroup)
 	}
@@ -352,6 +460,12 @@ func (f *Flags) groups() []FlagGroup {
 	if f.AWSFlagGroup != nil {
 		groups = append(groups, f.AWSFlagGroup)
 	}
+	if f.AzureFlagGroup != nil {
+		groups = append(groups, f.AzureFlagGroup)
+	}
+	if f.GoogleFlagGroup != nil {
+		groups = append(groups, f.GoogleFlagGroup)
+	}
 	if f.K8sFlagGroup != nil {
 		groups = append(groups, f.K8sFlagGroup)
 	}
@@ -361,6 +475,18 @@ func (f *Flags) groups() []FlagGroup {
 	if f.RepoFlagGroup != nil {
 		groups = append(groups, f.RepoFlagGroup)
 	}
+	if f.SSHFlagGroup != nil {
+		groups = append(groups, f.SSHFlagGroup)
+	}
+	if f.NotificationFlagGroup != nil {
+		groups = append(groups, f.NotificationFlagGroup)
+	}
+	if f.BundleFlagGroup != nil {
+		groups = append(groups, f.BundleFlagGroup)
+	}
+	if f.AttestFlagGroup != nil {
+		groups = append(groups, f.AttestFlagGroup)
+	}
 	return groups
 }
 
@@ -416,6 +542,14 @@ func (f *Flags) Bind(cmd *cobra.Command) error {
 
 // nolint: gocyclo
 func (f *Flags) ToOptions(appVersion string, args []string, globalFlags *GlobalFlagGroup, output io.Writer) (Options, error) {
+	for _, group := range f.groups() {
+		for _, flag := range group.Flags() {
+			if err := checkRemoved(flag); err != nil {
+				return Options{}, xerrors.Errorf("%s flag error: %w", strings.ToLower(group.Name()), err)
+			}
+		}
+	}
+
 	var err error
 	opts := Options{
 		AppVersion:    appVersion,
@@ -426,6 +560,14 @@ func (f *Flags) ToOptions(appVersion string, args []string, globalFlags *GlobalF
 		opts.AWSOptions = f.AWSFlagGroup.ToOptions()
 	}
 
+	if f.AzureFlagGroup != nil {
+		opts.AzureOptions = f.AzureFlagGroup.ToOptions()
+	}
+
+	if f.GoogleFlagGroup != nil {
+		opts.GoogleOptions = f.GoogleFlagGroup.ToOptions()
+	}
+
 	if f.CloudFlagGroup != nil {
 		opts.CloudOptions = f.CloudFlagGroup.ToOptions()
 	}
@@ -437,6 +579,24 @@ func (f *Flags) ToOptions(appVersion string, args []string, globalFlags *GlobalF
 		}
 	}
 
+	if f.BundleFlagGroup != nil {
+		opts.BundleOptions = f.BundleFlagGroup.ToOptions()
+	}
+
+	if f.AttestFlagGroup != nil {
+		opts.AttestOptions, err = f.AttestFlagGroup.ToOptions()
+		if err != nil {
+			return Options{}, xerrors.Errorf("attestation flag error: %w", err)
+		}
+	}
+
+	if f.ContainerFlagGroup != nil {
+		opts.ContainerOptions, err = f.ContainerFlagGroup.ToOptions()
+		if err != nil {
+			return Options{}, xerrors.Errorf("container flag error: %w", err)
+		}
+	}
+
 	if f.DBFlagGroup != nil {
 		opts.DBOptions, err = f.DBFlagGroup.ToOptions()
 		if err != nil {
@@ -444,6 +604,14 @@ func (f *Flags) ToOptions(appVersion string, args []string, globalFlags *GlobalF
 		}
 	}
 
+	if f.DriftFlagGroup != nil {
+		opts.DriftOptions = f.DriftFlagGroup.ToOptions()
+	}
+
+	if f.SupplyChainFlagGroup != nil {
+		opts.SupplyChainOptions = f.SupplyChainFlagGroup.ToOptions()
+	}
+
 	if f.ImageFlagGroup != nil {
 		opts.ImageOptions, err = f.ImageFlagGroup.ToOptions()
 		if err != nil {
@@ -473,6 +641,13 @@ func (f *Flags) ToOptions(appVersion string, args []string, globalFlags *GlobalF
 		opts.ModuleOptions = f.ModuleFlagGroup.ToOptions()
 	}
 
+	if f.NotificationFlagGroup != nil {
+		opts.NotificationOptions, err = f.NotificationFlagGroup.ToOptions()
+		if err != nil {
+			return Options{}, xerrors.Errorf("notification flag error: %w", err)
+		}
+	}
+
 	if f.RegoFlagGroup != nil {
 		opts.RegoOptions, err = f.RegoFlagGroup.ToOptions()
 		if err != nil {
@@ -520,6 +695,10 @@ func (f *Flags) ToOptions(appVersion string, args []string, globalFlags *GlobalF
 		opts.SecretOptions = f.SecretFlagGroup.ToOptions()
 	}
 
+	if f.SSHFlagGroup != nil {
+		opts.SSHOptions = f.SSHFlagGroup.ToOptions()
+	}
+
 	if f.VulnerabilityFlagGroup != nil {
 		opts.VulnerabilityOptions = f.VulnerabilityFlagGroup.ToOptions()
 	}
@@ -532,7 +711,7 @@ func (f *Flags) ToOptions(appVersion string, args []string, globalFlags *GlobalF
 type flagAlias struct {
 	formalName string
 	deprecated bool
-	once       sync.Once
+	removedIn  string
 }
 
 // flagAliases have aliases for CLI flags
@@ -546,6 +725,7 @@ func (a flagAliases) Add(flag *Flag) {
 		a[alias.Name] = &flagAlias{
 			formalName: flag.Name,
 			deprecated: alias.Deprecated,
+			removedIn:  alias.RemovedIn,
 		}
 	}
 }
@@ -554,10 +734,13 @@ func (a flagAliases) NormalizeFunc() func(*pflag.FlagSet, string) pflag.Normaliz
 	return func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
 		if alias, ok := a[name]; ok {
 			if alias.deprecated {
-				// NormalizeFunc is called several times
-				alias.once.Do(func() {
-					log.Logger.Warnf("'--%s' is deprecated. Use '--%s' instead.", name, alias.formalName)
-				})
+				// NormalizeFunc is called several times, but it is warned only once
+				deprecation{
+					Name:        "--" + name,
+					Replacement: "--" + alias.formalName,
+					Source:      sourceCLI,
+					RemovedIn:   alias.removedIn,
+				}.warn()
 			}
 			name = alias.formalName
 		}
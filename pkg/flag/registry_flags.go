@@ -27,17 +27,25 @@ var (
 		Value:      "",
 		Usage:      "registry token",
 	}
+	RegistryMaxRetriesFlag = Flag{
+		Name:       "registry-max-retries",
+		ConfigName: "registry.max-retries",
+		Value:      3,
+		Usage:      "maximum number of retries when the registry limits the rate (0 to disable)",
+	}
 )
 
 type RegistryFlagGroup struct {
 	Username      *Flag
 	Password      *Flag
 	RegistryToken *Flag
+	MaxRetries    *Flag
 }
 
 type RegistryOptions struct {
-	Credentials   []types.Credential
-	RegistryToken string
+	Credentials        []types.Credential
+	RegistryToken      string
+	RegistryMaxRetries int
 }
 
 func NewRegistryFlagGroup() *RegistryFlagGroup {
@@ -45,6 +53,7 @@ func NewRegistryFlagGroup() *RegistryFlagGroup {
 		Username:      &UsernameFlag,
 		Password:      &PasswordFlag,
 		RegistryToken: &RegistryTokenFlag,
+		MaxRetries:    &RegistryMaxRetriesFlag,
 	}
 }
 
@@ -57,6 +66,7 @@ func (f *RegistryFlagGroup) Flags() []*Flag {
 		f.Username,
 		f.Password,
 		f.RegistryToken,
+		f.MaxRetries,
 	}
 }
 
@@ -74,8 +84,14 @@ func (f *RegistryFlagGroup) ToOptions() (RegistryOptions, error) {
 		})
 	}
 
+	maxRetries := getInt(f.MaxRetries)
+	if maxRetries < 0 {
+		return RegistryOptions{}, xerrors.Errorf("invalid registry max retries: %d", maxRetries)
+	}
+
 	return RegistryOptions{
-		Credentials:   credentials,
-		RegistryToken: getString(f.RegistryToken),
+		Credentials:        credentials,
+		RegistryToken:      getString(f.RegistryToken),
+		RegistryMaxRetries: maxRetries,
 	}, nil
 }
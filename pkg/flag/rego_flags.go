@@ -37,6 +37,12 @@ var (
 			{Name: "data"},
 		},
 	}
+	PackagePolicyFlag = Flag{
+		Name:       "package-policy",
+		ConfigName: "rego.package-policy",
+		Value:      []string{},
+		Usage:      "specify paths to the Rego policy files directory, applying the package inventory",
+	}
 	PolicyNamespaceFlag = Flag{
 		Name:       "policy-namespaces",
 		ConfigName: "rego.namespaces",
@@ -55,6 +61,7 @@ type RegoFlagGroup struct {
 	PolicyPaths      *Flag
 	DataPaths        *Flag
 	PolicyNamespaces *Flag
+	PackagePolicies  *Flag
 }
 
 type RegoOptions struct {
@@ -63,6 +70,7 @@ type RegoOptions struct {
 	PolicyPaths      []string
 	DataPaths        []string
 	PolicyNamespaces []string
+	PackagePolicies  []string
 }
 
 func NewRegoFlagGroup() *RegoFlagGroup {
@@ -72,6 +80,7 @@ func NewRegoFlagGroup() *RegoFlagGroup {
 		PolicyPaths:      &ConfigPolicyFlag,
 		DataPaths:        &ConfigDataFlag,
 		PolicyNamespaces: &PolicyNamespaceFlag,
+		PackagePolicies:  &PackagePolicyFlag,
 	}
 }
 
@@ -86,6 +95,7 @@ func (f *RegoFlagGroup) Flags() []*Flag {
 		f.PolicyPaths,
 		f.DataPaths,
 		f.PolicyNamespaces,
+		f.PackagePolicies,
 	}
 }
 
@@ -96,5 +106,6 @@ func (f *RegoFlagGroup) ToOptions() (RegoOptions, error) {
 		PolicyPaths:      getStringSlice(f.PolicyPaths),
 		DataPaths:        getStringSlice(f.DataPaths),
 		PolicyNamespaces: getStringSlice(f.PolicyNamespaces),
+		PackagePolicies:  getStringSlice(f.PackagePolicies),
 	}, nil
 }
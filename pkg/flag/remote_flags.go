@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	"github.com/zhanglimao/trivy/pkg/log"
 )
 
@@ -36,12 +37,72 @@ var (
 		Value:      []string{},
 		Usage:      "custom headers in client mode",
 	}
+	ServerProgressFlag = Flag{
+		Name:       "server-progress",
+		ConfigName: "server.progress",
+		Value:      false,
+		Usage:      "poll the scan progress and partial results from the server in client mode",
+	}
 	ServerListenFlag = Flag{
 		Name:       "listen",
 		ConfigName: "server.listen",
 		Value:      "localhost:4954",
 		Usage:      "listen address in server mode",
 	}
+	ServerScanWorkersFlag = Flag{
+		Name:       "scan-workers",
+		ConfigName: "server.scan-workers",
+		Value:      4,
+		Usage:      "number of scans processed at the same time in server mode",
+	}
+	ServerScanQueueSizeFlag = Flag{
+		Name:       "scan-queue-size",
+		ConfigName: "server.scan-queue-size",
+		Value:      100,
+		Usage:      "number of scans waiting for a worker in server mode before rejecting new ones",
+	}
+	ServerTokensFileFlag = Flag{
+		Name:       "tokens-file",
+		ConfigName: "server.tokens-file",
+		Value:      "",
+		Usage:      "YAML file of tenant tokens with scopes and rate limits in server mode",
+	}
+	ServerTLSCertFlag = Flag{
+		Name:       "tls-cert",
+		ConfigName: "server.tls-cert",
+		Value:      "",
+		Usage:      "certificate file served in server mode, or presented to the server in client mode",
+	}
+	ServerTLSKeyFlag = Flag{
+		Name:       "tls-key",
+		ConfigName: "server.tls-key",
+		Value:      "",
+		Usage:      "private key file of '--tls-cert'",
+	}
+	ServerTLSCAFlag = Flag{
+		Name:       "tls-ca",
+		ConfigName: "server.tls-ca",
+		Value:      "",
+		Usage:      "CA file to verify client certificates in server mode, or the server certificate in client mode",
+	}
+	ServerWebhookFlag = Flag{
+		Name:       "webhook",
+		ConfigName: "server.webhook",
+		Value:      false,
+		Usage:      "[EXPERIMENTAL] serve a Kubernetes validating admission webhook at /validate",
+	}
+	ServerWebhookSeverityFlag = Flag{
+		Name:       "webhook-severity",
+		ConfigName: "server.webhook-severity",
+		Value:      dbTypes.SeverityCritical.String(),
+		Usage:      "severities of vulnerabilities rejected by the admission webhook (comma separated)",
+	}
+	ServerWebhookKEVFlag = Flag{
+		Name:       "webhook-kev",
+		ConfigName: "server.webhook-kev",
+		Value:      "",
+		Usage:      "known exploited vulnerabilities catalog (CISA KEV JSON) whose vulnerabilities are rejected by the admission webhook regardless of severity",
+	}
 )
 
 // RemoteFlagGroup composes common printer flag structs
@@ -51,29 +112,57 @@ type RemoteFlagGroup struct {
 	Token       *Flag
 	TokenHeader *Flag
 
+	// for client/server with TLS
+	TLSCert *Flag
+	TLSKey  *Flag
+	TLSCA   *Flag
+
 	// for client
-	ServerAddr    *Flag
-	CustomHeaders *Flag
+	ServerAddr     *Flag
+	CustomHeaders  *Flag
+	ServerProgress *Flag
 
 	// for server
-	Listen *Flag
+	Listen          *Flag
+	ScanWorkers     *Flag
+	ScanQueueSize   *Flag
+	TokensFile      *Flag
+	Webhook         *Flag
+	WebhookSeverity *Flag
+	WebhookKEV      *Flag
 }
 
 type RemoteOptions struct {
 	Token       string
 	TokenHeader string
 
-	ServerAddr    string
-	Listen        string
-	CustomHeaders http.Header
+	ServerAddr     string
+	Listen         string
+	CustomHeaders  http.Header
+	ServerProgress bool
+
+	TLSCert string
+	TLSKey  string
+	TLSCA   string
+
+	ScanWorkers     int
+	ScanQueueSize   int
+	TokensFile      string
+	Webhook         bool
+	WebhookSeverity []dbTypes.Severity
+	WebhookKEV      string
 }
 
 func NewClientFlags() *RemoteFlagGroup {
 	return &RemoteFlagGroup{
-		Token:         &ServerTokenFlag,
-		TokenHeader:   &ServerTokenHeaderFlag,
-		ServerAddr:    &ServerAddrFlag,
-		CustomHeaders: &ServerCustomHeadersFlag,
+		Token:          &ServerTokenFlag,
+		TokenHeader:    &ServerTokenHeaderFlag,
+		ServerAddr:     &ServerAddrFlag,
+		CustomHeaders:  &ServerCustomHeadersFlag,
+		ServerProgress: &ServerProgressFlag,
+		TLSCert:        &ServerTLSCertFlag,
+		TLSKey:         &ServerTLSKeyFlag,
+		TLSCA:          &ServerTLSCAFlag,
 	}
 }
 
@@ -82,6 +171,16 @@ func NewServerFlags() *RemoteFlagGroup {
 		Token:       &ServerTokenFlag,
 		TokenHeader: &ServerTokenHeaderFlag,
 		Listen:      &ServerListenFlag,
+
+		ScanWorkers:     &ServerScanWorkersFlag,
+		ScanQueueSize:   &ServerScanQueueSizeFlag,
+		TokensFile:      &ServerTokensFileFlag,
+		TLSCert:         &ServerTLSCertFlag,
+		TLSKey:          &ServerTLSKeyFlag,
+		TLSCA:           &ServerTLSCAFlag,
+		Webhook:         &ServerWebhookFlag,
+		WebhookSeverity: &ServerWebhookSeverityFlag,
+		WebhookKEV:      &ServerWebhookKEVFlag,
 	}
 }
 
@@ -90,7 +189,8 @@ func (f *RemoteFlagGroup) Name() string {
 }
 
 func (f *RemoteFlagGroup) Flags() []*Flag {
-	return []*Flag{f.Token, f.TokenHeader, f.ServerAddr, f.CustomHeaders, f.Listen}
+	return []*Flag{f.Token, f.TokenHeader, f.ServerAddr, f.CustomHeaders, f.ServerProgress, f.Listen,
+		f.ScanWorkers, f.ScanQueueSize, f.TokensFile, f.TLSCert, f.TLSKey, f.TLSCA, f.Webhook, f.WebhookSeverity, f.WebhookKEV}
 }
 
 func (f *RemoteFlagGroup) ToOptions() RemoteOptions {
@@ -120,11 +220,23 @@ func (f *RemoteFlagGroup) ToOptions() RemoteOptions {
 	}
 
 	return RemoteOptions{
-		Token:         token,
-		TokenHeader:   tokenHeader,
-		ServerAddr:    serverAddr,
-		CustomHeaders: customHeaders,
-		Listen:        listen,
+		Token:          token,
+		TokenHeader:    tokenHeader,
+		ServerAddr:     serverAddr,
+		CustomHeaders:  customHeaders,
+		ServerProgress: getBool(f.ServerProgress),
+		Listen:         listen,
+
+		TLSCert: getString(f.TLSCert),
+		TLSKey:  getString(f.TLSKey),
+		TLSCA:   getString(f.TLSCA),
+
+		ScanWorkers:     getInt(f.ScanWorkers),
+		ScanQueueSize:   getInt(f.ScanQueueSize),
+		TokensFile:      getString(f.TokensFile),
+		Webhook:         getBool(f.Webhook),
+		WebhookSeverity: splitSeverity(getStringSlice(f.WebhookSeverity)),
+		WebhookKEV:      getString(f.WebhookKEV),
 	}
 }
 
@@ -19,18 +19,28 @@ var (
 		Value:      "",
 		Usage:      "pass the tag name to be scanned",
 	}
+	SparseCheckoutFlag = Flag{
+		Name:       "sparse-checkout",
+		ConfigName: "repository.sparse-checkout",
+		Value:      []string{},
+		Usage:      "check out and scan only the specified directories of the repository",
+	}
 )
 
 type RepoFlagGroup struct {
 	Branch *Flag
 	Commit *Flag
 	Tag    *Flag
+
+	SparseCheckout *Flag
 }
 
 type RepoOptions struct {
 	RepoBranch string
 	RepoCommit string
 	RepoTag    string
+
+	RepoSparsePaths []string
 }
 
 func NewRepoFlagGroup() *RepoFlagGroup {
@@ -38,6 +48,8 @@ func NewRepoFlagGroup() *RepoFlagGroup {
 		Branch: &FetchBranchFlag,
 		Commit: &FetchCommitFlag,
 		Tag:    &FetchTagFlag,
+
+		SparseCheckout: &SparseCheckoutFlag,
 	}
 }
 
@@ -46,7 +58,7 @@ func (f *RepoFlagGroup) Name() string {
 }
 
 func (f *RepoFlagGroup) Flags() []*Flag {
-	return []*Flag{f.Branch, f.Commit, f.Tag}
+	return []*Flag{f.Branch, f.Commit, f.Tag, f.SparseCheckout}
 }
 
 func (f *RepoFlagGroup) ToOptions() RepoOptions {
@@ -54,5 +66,7 @@ func (f *RepoFlagGroup) ToOptions() RepoOptions {
 		RepoBranch: getString(f.Branch),
 		RepoCommit: getString(f.Commit),
 		RepoTag:    getString(f.Tag),
+
+		RepoSparsePaths: getStringSlice(f.SparseCheckout),
 	}
 }
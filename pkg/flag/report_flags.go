@@ -3,12 +3,15 @@ package flag
 import (
 	"io"
 	"os"
+	"strconv"
 	"strings"
 
+	"github.com/spf13/viper"
 	"golang.org/x/exp/slices"
 	"golang.org/x/xerrors"
 
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	cr "github.com/zhanglimao/trivy/pkg/compliance/report"
 	"github.com/zhanglimao/trivy/pkg/compliance/spec"
 	"github.com/zhanglimao/trivy/pkg/log"
 	"github.com/zhanglimao/trivy/pkg/report"
@@ -32,9 +35,21 @@ var (
 	ReportFormatFlag = Flag{
 		Name:       "report",
 		ConfigName: "report",
-		Value:      "all",
+		Value:      report.ReportAll,
 		Usage:      "specify a report format for the output. (all,summary)",
 	}
+	SummaryTopFlag = Flag{
+		Name:       "summary-top",
+		ConfigName: "summary-top",
+		Value:      10,
+		Usage:      "number of the most vulnerable packages shown with '--report summary' in table format (0 to hide)",
+	}
+	GroupByFlag = Flag{
+		Name:       "group-by",
+		ConfigName: "group-by",
+		Value:      report.GroupByTarget,
+		Usage:      "group vulnerabilities in table format (" + strings.Join(report.SupportedGroupBy, ",") + ")",
+	}
 	TemplateFlag = Flag{
 		Name:       "template",
 		ConfigName: "template",
@@ -54,6 +69,12 @@ var (
 		Value:      false,
 		Usage:      "enabling the option will output all packages regardless of vulnerability",
 	}
+	DedupeFlag = Flag{
+		Name:       "dedupe",
+		ConfigName: "dedupe",
+		Value:      false,
+		Usage:      "collapse the same vulnerability found in multiple targets into one finding with multiple locations",
+	}
 	IgnoreFileFlag = Flag{
 		Name:       "ignorefile",
 		ConfigName: "ignorefile",
@@ -78,6 +99,12 @@ var (
 		Value:      0,
 		Usage:      "exit with the specified code when the OS reaches end of service/life",
 	}
+	ExitOnMaliciousFlag = Flag{
+		Name:       "exit-on-malicious",
+		ConfigName: "exit-on-malicious",
+		Value:      0,
+		Usage:      "exit with the specified code when known-malicious packages are found, taking precedence over --exit-code",
+	}
 	OutputFlag = Flag{
 		Name:       "output",
 		ConfigName: "output",
@@ -98,54 +125,125 @@ var (
 		Value:      "",
 		Usage:      "compliance report to generate",
 	}
+	CompliancePublicKeyFlag = Flag{
+		Name:       "compliance-public-key",
+		ConfigName: "scan.compliance-public-key",
+		Value:      "",
+		Usage:      "[EXPERIMENTAL] path to the public key verifying signatures of compliance specs fetched from URLs or OCI registries",
+	}
+	RecordFlag = Flag{
+		Name:       "record",
+		ConfigName: "record",
+		Value:      false,
+		Usage:      "record the scan result in the local history to show trends with 'trivy history'",
+	}
+	SLAFlag = Flag{
+		Name:       "sla",
+		ConfigName: "sla",
+		Value:      []string{},
+		Usage:      "comma-separated days to remediate findings per severity to stamp due dates based on '--record' history, e.g. CRITICAL=7,HIGH=30",
+	}
+	DeterministicFlag = Flag{
+		Name:       "deterministic",
+		ConfigName: "deterministic",
+		Value:      false,
+		Usage:      "produce byte-identical reports for the same artifact by sorting results, zeroing timestamps and deriving UUIDs from the artifact",
+	}
+	OwnersFileFlag = Flag{
+		Name:       "owners-file",
+		ConfigName: "owners-file",
+		Value:      "",
+		Usage:      "path to a CODEOWNERS file or a YAML mapping file to annotate results with owners",
+	}
+	ValidateFlag = Flag{
+		Name:       "validate",
+		ConfigName: "validate",
+		Value:      false,
+		Usage:      "validate the JSON report against the JSON schema of its schema version instead of converting it",
+	}
 )
 
 // ReportFlagGroup composes common printer flag structs
 // used for commands requiring reporting logic.
 type ReportFlagGroup struct {
-	Format         *Flag
-	ReportFormat   *Flag
-	Template       *Flag
-	DependencyTree *Flag
-	ListAllPkgs    *Flag
-	IgnoreFile     *Flag
-	IgnorePolicy   *Flag
-	ExitCode       *Flag
-	ExitOnEOL      *Flag
-	Output         *Flag
-	Severity       *Flag
-	Compliance     *Flag
+	Format          *Flag
+	ReportFormat    *Flag
+	SummaryTop      *Flag
+	GroupBy         *Flag
+	Template        *Flag
+	DependencyTree  *Flag
+	ListAllPkgs     *Flag
+	Dedupe          *Flag
+	IgnoreFile      *Flag
+	IgnorePolicy    *Flag
+	ExitCode        *Flag
+	ExitOnEOL       *Flag
+	ExitOnMalicious *Flag
+	Output          *Flag
+	Severity        *Flag
+	Compliance      *Flag
+	// CompliancePublicKey is only used to load the compliance spec
+	CompliancePublicKey *Flag
+	Record              *Flag
+	SLA                 *Flag
+	Deterministic       *Flag
+	OwnersFile          *Flag
+	// Validate is only available in 'convert'
+	Validate *Flag
 }
 
 type ReportOptions struct {
-	Format         string
-	ReportFormat   string
-	Template       string
-	DependencyTree bool
-	ListAllPkgs    bool
-	IgnoreFile     string
-	ExitCode       int
-	ExitOnEOL      int
-	IgnorePolicy   string
-	Output         io.Writer
-	Severities     []dbTypes.Severity
-	Compliance     spec.ComplianceSpec
+	Format          string
+	ReportFormat    string
+	SummaryTop      int
+	GroupBy         string
+	Template        string
+	DependencyTree  bool
+	ListAllPkgs     bool
+	Dedupe          bool
+	IgnoreFile      string
+	ExitCode        int
+	ExitOnEOL       int
+	ExitOnMalicious int
+	IgnorePolicy    string
+	Output          io.Writer
+	Severities      []dbTypes.Severity
+	Compliance      spec.ComplianceSpec
+	Record          bool
+	SLA             map[string]int // days per severity
+	Deterministic   bool
+	OwnersFile      string
+	Validate        bool
+
+	// ComplianceRef holds the URL or OCI reference of the remote compliance spec, which is fetched at run time
+	ComplianceRef       string
+	CompliancePublicKey string
 }
 
 func NewReportFlagGroup() *ReportFlagGroup {
 	return &ReportFlagGroup{
-		Format:         &FormatFlag,
-		ReportFormat:   &ReportFormatFlag,
-		Template:       &TemplateFlag,
-		DependencyTree: &DependencyTreeFlag,
-		ListAllPkgs:    &ListAllPkgsFlag,
-		IgnoreFile:     &IgnoreFileFlag,
-		IgnorePolicy:   &IgnorePolicyFlag,
-		ExitCode:       &ExitCodeFlag,
-		ExitOnEOL:      &ExitOnEOLFlag,
-		Output:         &OutputFlag,
-		Severity:       &SeverityFlag,
-		Compliance:     &ComplianceFlag,
+		Format:          &FormatFlag,
+		ReportFormat:    &ReportFormatFlag,
+		SummaryTop:      &SummaryTopFlag,
+		GroupBy:         &GroupByFlag,
+		Template:        &TemplateFlag,
+		DependencyTree:  &DependencyTreeFlag,
+		ListAllPkgs:     &ListAllPkgsFlag,
+		Dedupe:          &DedupeFlag,
+		IgnoreFile:      &IgnoreFileFlag,
+		IgnorePolicy:    &IgnorePolicyFlag,
+		ExitCode:        &ExitCodeFlag,
+		ExitOnEOL:       &ExitOnEOLFlag,
+		ExitOnMalicious: &ExitOnMaliciousFlag,
+		Output:          &OutputFlag,
+		Severity:        &SeverityFlag,
+		Compliance:      &ComplianceFlag,
+
+		CompliancePublicKey: &CompliancePublicKeyFlag,
+		Record:              &RecordFlag,
+		SLA:                 &SLAFlag,
+		Deterministic:       &DeterministicFlag,
+		OwnersFile:          &OwnersFileFlag,
 	}
 }
 
@@ -157,16 +255,26 @@ func (f *ReportFlagGroup) Flags() []*Flag {
 	return []*Flag{
 		f.Format,
 		f.ReportFormat,
+		f.SummaryTop,
+		f.GroupBy,
 		f.Template,
 		f.DependencyTree,
 		f.ListAllPkgs,
+		f.Dedupe,
 		f.IgnoreFile,
 		f.IgnorePolicy,
 		f.ExitCode,
 		f.ExitOnEOL,
+		f.ExitOnMalicious,
 		f.Output,
 		f.Severity,
 		f.Compliance,
+		f.CompliancePublicKey,
+		f.Record,
+		f.SLA,
+		f.Deterministic,
+		f.OwnersFile,
+		f.Validate,
 	}
 }
 
@@ -177,7 +285,12 @@ func (f *ReportFlagGroup) ToOptions(out io.Writer) (ReportOptions, error) {
 	listAllPkgs := getBool(f.ListAllPkgs)
 	output := getString(f.Output)
 
-	if format != "" && !slices.Contains(report.SupportedFormats, format) {
+	// The compliance report supports its own formats such as "html" and "csv"
+	supportedFormats := report.SupportedFormats
+	if getString(f.Compliance) != "" {
+		supportedFormats = cr.SupportedFormats
+	}
+	if format != "" && !slices.Contains(supportedFormats, format) {
 		return ReportOptions{}, xerrors.Errorf("unknown format: %v", format)
 	}
 
@@ -193,19 +306,26 @@ func (f *ReportFlagGroup) ToOptions(out io.Writer) (ReportOptions, error) {
 		}
 	}
 
+	groupBy := getString(f.GroupBy)
+	if groupBy != "" && !slices.Contains(report.SupportedGroupBy, groupBy) {
+		return ReportOptions{}, xerrors.Errorf("unknown group-by: %v", groupBy)
+	} else if groupBy == report.GroupByVulnerability && format != report.FormatTable {
+		log.Logger.Warnf(`"--group-by %s" is ignored with "--format %s". It is available only with "--format table".`, groupBy, format)
+	}
+
 	// "--list-all-pkgs" option is unavailable with "--format table".
 	// If user specifies "--list-all-pkgs" with "--format table", we should warn it.
 	if listAllPkgs && format == report.FormatTable {
 		log.Logger.Warn(`"--list-all-pkgs" cannot be used with "--format table". Try "--format json" or other formats.`)
 	}
 
-	// "--dependency-tree" option is available only with "--format table".
+	// "--dependency-tree" option is not available with SBOM formats as they have their own dependency graph.
 	if dependencyTree {
 		log.Logger.Infof(`"--dependency-tree" only shows the dependents of vulnerable packages. ` +
 			`Note that it is the reverse of the usual dependency tree, which shows the packages that depend on the vulnerable package. ` +
 			`It supports limited package managers. Please see the document for the detail.`)
-		if format != report.FormatTable {
-			log.Logger.Warn(`"--dependency-tree" can be used only with "--format table".`)
+		if slices.Contains(report.SupportedSBOMFormats, format) || format == report.FormatCosignVuln {
+			log.Logger.Warnf(`"--dependency-tree" is ignored with "--format %s".`, format)
 		}
 	}
 
@@ -221,24 +341,53 @@ func (f *ReportFlagGroup) ToOptions(out io.Writer) (ReportOptions, error) {
 		}
 	}
 
-	cs, err := loadComplianceTypes(getString(f.Compliance))
-	if err != nil {
+	// Remote compliance specs are fetched at run time so that parsing flags doesn't access the network
+	var cs spec.ComplianceSpec
+	var complianceRef string
+	var err error
+	if compliance := getString(f.Compliance); spec.IsRemote(compliance) {
+		complianceRef = compliance
+	} else if cs, err = loadComplianceTypes(compliance); err != nil {
 		return ReportOptions{}, xerrors.Errorf("unable to load compliance spec: %w", err)
 	}
 
+	sla, err := parseSLA(getStringSlice(f.SLA))
+	if err != nil {
+		return ReportOptions{}, xerrors.Errorf("unable to parse SLA: %w", err)
+	}
+
+	// The default value of '--report' can be overridden for the compliance report, e.g. "summary" in 'trivy image'.
+	// The other reports show all the findings unless '--report' is explicitly specified.
+	reportFormat := getString(f.ReportFormat)
+	if f.ReportFormat != nil && getString(f.Compliance) == "" && !viper.IsSet(f.ReportFormat.ConfigName) {
+		reportFormat = report.ReportAll
+	}
+
 	return ReportOptions{
-		Format:         format,
-		ReportFormat:   getString(f.ReportFormat),
-		Template:       template,
-		DependencyTree: dependencyTree,
-		ListAllPkgs:    listAllPkgs,
-		IgnoreFile:     getString(f.IgnoreFile),
-		ExitCode:       getInt(f.ExitCode),
-		ExitOnEOL:      getInt(f.ExitOnEOL),
-		IgnorePolicy:   getString(f.IgnorePolicy),
-		Output:         out,
-		Severities:     splitSeverity(getStringSlice(f.Severity)),
-		Compliance:     cs,
+		Format:          format,
+		ReportFormat:    reportFormat,
+		SummaryTop:      getInt(f.SummaryTop),
+		GroupBy:         groupBy,
+		Template:        template,
+		DependencyTree:  dependencyTree,
+		ListAllPkgs:     listAllPkgs,
+		Dedupe:          getBool(f.Dedupe),
+		IgnoreFile:      getString(f.IgnoreFile),
+		ExitCode:        getInt(f.ExitCode),
+		ExitOnEOL:       getInt(f.ExitOnEOL),
+		ExitOnMalicious: getInt(f.ExitOnMalicious),
+		IgnorePolicy:    getString(f.IgnorePolicy),
+		Output:          out,
+		Severities:      splitSeverity(getStringSlice(f.Severity)),
+		Compliance:      cs,
+		Record:          getBool(f.Record),
+		SLA:             sla,
+		Deterministic:   getBool(f.Deterministic),
+		OwnersFile:      getString(f.OwnersFile),
+		Validate:        getBool(f.Validate),
+
+		ComplianceRef:       complianceRef,
+		CompliancePublicKey: getString(f.CompliancePublicKey),
 	}, nil
 }
 
@@ -292,3 +441,24 @@ func splitSeverity(severity []string) []dbTypes.Severity {
 	log.Logger.Debugf("Severities: %q", severities)
 	return severities
 }
+
+// parseSLA parses days per severity, e.g. "CRITICAL=7"
+func parseSLA(values []string) (map[string]int, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	sla := map[string]int{}
+	for _, v := range values {
+		severity, days, ok := strings.Cut(v, "=")
+		severity = strings.ToUpper(strings.TrimSpace(severity))
+		if !ok || !slices.Contains(dbTypes.SeverityNames, severity) {
+			return nil, xerrors.Errorf("%q must be SEVERITY=DAYS with one of %s", v, strings.Join(dbTypes.SeverityNames, ", "))
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(days))
+		if err != nil || n < 0 {
+			return nil, xerrors.Errorf("invalid days in %q", v)
+		}
+		sla[severity] = n
+	}
+	return sla, nil
+}
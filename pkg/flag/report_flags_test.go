@@ -32,6 +32,7 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 		output         string
 		severities     string
 		compliane      string
+		sla            []string
 
 		debug bool
 	}
@@ -111,6 +112,36 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 				ListAllPkgs: true,
 			},
 		},
+		{
+			name: "happy path with --dependency-tree and --format json",
+			fields: fields{
+				format:         "json",
+				dependencyTree: true,
+			},
+			want: flag.ReportOptions{
+				Output:         os.Stdout,
+				Format:         report.FormatJSON,
+				DependencyTree: true,
+				ListAllPkgs:    true,
+			},
+		},
+		{
+			name: "invalid option combination: --dependency-tree with --format cyclonedx",
+			fields: fields{
+				format:         "cyclonedx",
+				dependencyTree: true,
+				listAllPkgs:    true,
+			},
+			wantLogs: []string{
+				`"--dependency-tree" is ignored with "--format cyclonedx".`,
+			},
+			want: flag.ReportOptions{
+				Output:         os.Stdout,
+				Format:         report.FormatCycloneDX,
+				DependencyTree: true,
+				ListAllPkgs:    true,
+			},
+		},
 		{
 			name: "invalid option combination: --template enabled without --format",
 			fields: fields{
@@ -175,6 +206,24 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 				ListAllPkgs: true,
 			},
 		},
+		{
+			name: "happy path with SLA",
+			fields: fields{
+				severities: "HIGH,CRITICAL",
+				sla:        []string{"critical=7", "HIGH=30"},
+			},
+			want: flag.ReportOptions{
+				Output: os.Stdout,
+				Severities: []dbTypes.Severity{
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityCritical,
+				},
+				SLA: map[string]int{
+					"CRITICAL": 7,
+					"HIGH":     30,
+				},
+			},
+		},
 		{
 			name: "happy path with compliance",
 			fields: fields{
@@ -205,6 +254,18 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 				Severities: []dbTypes.Severity{dbTypes.SeverityLow},
 			},
 		},
+		{
+			name: "happy path with remote compliance",
+			fields: fields{
+				compliane:  "https://example.com/specs/my-spec.yaml",
+				severities: "low",
+			},
+			want: flag.ReportOptions{
+				Output:        os.Stdout,
+				Severities:    []dbTypes.Severity{dbTypes.SeverityLow},
+				ComplianceRef: "https://example.com/specs/my-spec.yaml",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -227,6 +288,7 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 			viper.Set(flag.OutputFlag.ConfigName, tt.fields.output)
 			viper.Set(flag.SeverityFlag.ConfigName, tt.fields.severities)
 			viper.Set(flag.ComplianceFlag.ConfigName, tt.fields.compliane)
+			viper.Set(flag.SLAFlag.ConfigName, tt.fields.sla)
 
 			// Assert options
 			f := &flag.ReportFlagGroup{
@@ -241,6 +303,7 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 				Output:         &flag.OutputFlag,
 				Severity:       &flag.SeverityFlag,
 				Compliance:     &flag.ComplianceFlag,
+				SLA:            &flag.SLAFlag,
 			}
 
 			got, err := f.ToOptions(os.Stdout)
@@ -1,25 +1,23 @@
 package flag
 
-import (
-	"golang.org/x/xerrors"
-
-	"github.com/zhanglimao/trivy/pkg/log"
-)
-
 var (
 	ArtifactTypeFlag = Flag{
 		Name:       "artifact-type",
 		ConfigName: "sbom.artifact-type",
 		Value:      "",
 		Usage:      "deprecated",
-		Deprecated: true,
+		Removed:    true,
+		DeprecationNote: "'trivy sbom' is now for scanning SBOM. " +
+			"See https://github.com/zhanglimao/trivy/discussions/2407 for the detail",
 	}
 	SBOMFormatFlag = Flag{
 		Name:       "sbom-format",
 		ConfigName: "sbom.format",
 		Value:      "",
 		Usage:      "deprecated",
-		Deprecated: true,
+		Removed:    true,
+		DeprecationNote: "'trivy sbom' is now for scanning SBOM. " +
+			"See https://github.com/zhanglimao/trivy/discussions/2407 for the detail",
 	}
 	VEXFlag = Flag{
 		Name:       "vex",
@@ -60,15 +58,7 @@ func (f *SBOMFlagGroup) Flags() []*Flag {
 }
 
 func (f *SBOMFlagGroup) ToOptions() (SBOMOptions, error) {
-	artifactType := getString(f.ArtifactType)
-	sbomFormat := getString(f.SBOMFormat)
-
-	if artifactType != "" || sbomFormat != "" {
-		log.Logger.Error("'trivy sbom' is now for scanning SBOM. " +
-			"See https://github.com/zhanglimao/trivy/discussions/2407 for the detail")
-		return SBOMOptions{}, xerrors.New("'--artifact-type' and '--sbom-format' are no longer available")
-	}
-
+	// '--artifact-type' and '--sbom-format' are rejected as removed flags
 	return SBOMOptions{
 		VEXPath: getString(f.VEXPath),
 	}, nil
@@ -1,9 +1,14 @@
 package flag
 
 import (
+	"time"
+
+	"github.com/dustin/go-humanize"
 	"golang.org/x/exp/slices"
 	"golang.org/x/xerrors"
 
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/walker"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
@@ -20,6 +25,18 @@ var (
 		Value:      []string{},
 		Usage:      "specify the file paths to skip traversal",
 	}
+	IncludeFilesFlag = Flag{
+		Name:       "include-files",
+		ConfigName: "scan.include-files",
+		Value:      []string{},
+		Usage:      "specify glob patterns of files to traverse, prefix with '!' to exclude (e.g. '**/*.json,!**/testdata/**')",
+	}
+	MaxFileSizeFlag = Flag{
+		Name:       "max-file-size",
+		ConfigName: "scan.max-file-size",
+		Value:      "",
+		Usage:      "skip files larger than the size (e.g. 10MB)",
+	}
 	OfflineScanFlag = Flag{
 		Name:       "offline-scan",
 		ConfigName: "scan.offline",
@@ -48,12 +65,54 @@ var (
 		Value:      []string{},
 		Usage:      "specify config file patterns",
 	}
+	EnableAnalyzersFlag = Flag{
+		Name:       "enable-analyzers",
+		ConfigName: "scan.enable-analyzers",
+		Value:      []string{},
+		Usage:      "comma-separated list of analyzers or groups (lang,os,iac,secret,license) to run exclusively",
+	}
+	DisableAnalyzersFlag = Flag{
+		Name:       "disable-analyzers",
+		ConfigName: "scan.disable-analyzers",
+		Value:      []string{},
+		Usage:      "comma-separated list of analyzers or groups (lang,os,iac,secret,license) not to run",
+	}
+	AnalyzerTimeoutFlag = Flag{
+		Name:       "analyzer-timeout",
+		ConfigName: "scan.analyzer-timeout",
+		Value:      time.Duration(0),
+		Usage:      "timeout for an analyzer to analyze a file, the result is reported as a warning (0: unlimited)",
+	}
+	KeepGoingFlag = Flag{
+		Name:       "keep-going",
+		ConfigName: "scan.keep-going",
+		Value:      false,
+		Usage:      "continue the scan on analysis errors and report them as warnings",
+	}
 	SlowFlag = Flag{
 		Name:       "slow",
 		ConfigName: "scan.slow",
 		Value:      false,
 		Usage:      "scan over time with lower CPU and memory utilization",
 	}
+	MaxWorkersFlag = Flag{
+		Name:       "max-workers",
+		ConfigName: "scan.max-workers",
+		Value:      0,
+		Usage:      "maximum number of analyzers running concurrently (0: decided from CPUs)",
+	}
+	MaxHeavyWorkersFlag = Flag{
+		Name:       "max-heavy-workers",
+		ConfigName: "scan.max-heavy-workers",
+		Value:      0,
+		Usage:      "maximum number of expensive analyzers, such as jar and secret, running concurrently (0: decided from memory)",
+	}
+	MaxMemoryFlag = Flag{
+		Name:       "max-memory",
+		ConfigName: "scan.max-memory",
+		Value:      "",
+		Usage:      "memory budget for the scan, analysis results exceeding a share of it are spilled to disk (e.g. 2GB)",
+	}
 	SBOMSourcesFlag = Flag{
 		Name:       "sbom-sources",
 		ConfigName: "scan.sbom-sources",
@@ -66,41 +125,108 @@ var (
 		Value:      "https://rekor.sigstore.dev",
 		Usage:      "[EXPERIMENTAL] address of rekor STL server",
 	}
+	SymlinkPolicyFlag = Flag{
+		Name:       "symlink-policy",
+		ConfigName: "scan.symlink-policy",
+		Value:      string(walker.SymlinkNever),
+		Usage:      "how to handle symbolic links in the traversal (never,within-root,follow)",
+	}
+	MaxSymlinkDepthFlag = Flag{
+		Name:       "max-symlink-depth",
+		ConfigName: "scan.max-symlink-depth",
+		Value:      8,
+		Usage:      "maximum number of symbolic links followed in a row",
+	}
+	CustomResourceConfigFlag = Flag{
+		Name:       "custom-resource-config",
+		ConfigName: "scan.custom-resource-config",
+		Value:      "",
+		Usage:      "specify a path to config file declaring collectors of custom resources",
+	}
 )
 
 type ScanFlagGroup struct {
 	SkipDirs     *Flag
 	SkipFiles    *Flag
+	IncludeFiles *Flag
+	MaxFileSize  *Flag
 	OfflineScan  *Flag
 	Scanners     *Flag
 	FilePatterns *Flag
 	Slow         *Flag
 	SBOMSources  *Flag
-	RekorURL     *Flag
+
+	EnableAnalyzers  *Flag
+	DisableAnalyzers *Flag
+	AnalyzerTimeout  *Flag
+	KeepGoing        *Flag
+
+	MaxWorkers      *Flag
+	MaxHeavyWorkers *Flag
+	MaxMemory       *Flag
+
+	RekorURL *Flag
+
+	SymlinkPolicy   *Flag
+	MaxSymlinkDepth *Flag
+
+	CustomResourceConfig *Flag
 }
 
 type ScanOptions struct {
 	Target       string
 	SkipDirs     []string
 	SkipFiles    []string
+	IncludeFiles []string
+	MaxFileSize  int64
 	OfflineScan  bool
 	Scanners     types.Scanners
 	FilePatterns []string
 	Slow         bool
 	SBOMSources  []string
-	RekorURL     string
+
+	EnableAnalyzers  []analyzer.Type
+	DisableAnalyzers []analyzer.Type
+	AnalyzerTimeout  time.Duration
+	KeepGoing        bool
+
+	MaxWorkers      int
+	MaxHeavyWorkers int
+	MaxMemory       int64
+
+	RekorURL string
+
+	SymlinkOption walker.SymlinkOption
+
+	CustomResourceConfigPath string
 }
 
 func NewScanFlagGroup() *ScanFlagGroup {
 	return &ScanFlagGroup{
 		SkipDirs:     &SkipDirsFlag,
 		SkipFiles:    &SkipFilesFlag,
+		IncludeFiles: &IncludeFilesFlag,
+		MaxFileSize:  &MaxFileSizeFlag,
 		OfflineScan:  &OfflineScanFlag,
 		Scanners:     &ScannersFlag,
 		FilePatterns: &FilePatternsFlag,
 		Slow:         &SlowFlag,
 		SBOMSources:  &SBOMSourcesFlag,
 		RekorURL:     &RekorURLFlag,
+
+		EnableAnalyzers:  &EnableAnalyzersFlag,
+		DisableAnalyzers: &DisableAnalyzersFlag,
+		AnalyzerTimeout:  &AnalyzerTimeoutFlag,
+		KeepGoing:        &KeepGoingFlag,
+
+		MaxWorkers:      &MaxWorkersFlag,
+		MaxHeavyWorkers: &MaxHeavyWorkersFlag,
+		MaxMemory:       &MaxMemoryFlag,
+
+		SymlinkPolicy:   &SymlinkPolicyFlag,
+		MaxSymlinkDepth: &MaxSymlinkDepthFlag,
+
+		CustomResourceConfig: &CustomResourceConfigFlag,
 	}
 }
 
@@ -112,12 +238,24 @@ func (f *ScanFlagGroup) Flags() []*Flag {
 	return []*Flag{
 		f.SkipDirs,
 		f.SkipFiles,
+		f.IncludeFiles,
+		f.MaxFileSize,
 		f.OfflineScan,
 		f.Scanners,
 		f.FilePatterns,
+		f.EnableAnalyzers,
+		f.DisableAnalyzers,
+		f.AnalyzerTimeout,
+		f.KeepGoing,
 		f.Slow,
+		f.MaxWorkers,
+		f.MaxHeavyWorkers,
+		f.MaxMemory,
 		f.SBOMSources,
 		f.RekorURL,
+		f.SymlinkPolicy,
+		f.MaxSymlinkDepth,
+		f.CustomResourceConfig,
 	}
 }
 
@@ -136,16 +274,53 @@ func (f *ScanFlagGroup) ToOptions(args []string) (ScanOptions, error) {
 		return ScanOptions{}, xerrors.Errorf("unable to parse SBOM sources: %w", err)
 	}
 
+	var maxFileSize uint64
+	if s := getString(f.MaxFileSize); s != "" {
+		if maxFileSize, err = humanize.ParseBytes(s); err != nil {
+			return ScanOptions{}, xerrors.Errorf("unable to parse the max file size: %w", err)
+		}
+	}
+
+	var maxMemory uint64
+	if s := getString(f.MaxMemory); s != "" {
+		if maxMemory, err = humanize.ParseBytes(s); err != nil {
+			return ScanOptions{}, xerrors.Errorf("unable to parse the max memory: %w", err)
+		}
+	}
+
+	symlinkPolicy := walker.SymlinkPolicy(getString(f.SymlinkPolicy))
+	if symlinkPolicy != "" && !slices.Contains(walker.SymlinkPolicies, symlinkPolicy) {
+		return ScanOptions{}, xerrors.Errorf("unknown symlink policy: %s", symlinkPolicy)
+	}
+
 	return ScanOptions{
 		Target:       target,
 		SkipDirs:     getStringSlice(f.SkipDirs),
 		SkipFiles:    getStringSlice(f.SkipFiles),
+		IncludeFiles: getStringSlice(f.IncludeFiles),
+		MaxFileSize:  int64(maxFileSize),
 		OfflineScan:  getBool(f.OfflineScan),
 		Scanners:     scanners,
 		FilePatterns: getStringSlice(f.FilePatterns),
 		Slow:         getBool(f.Slow),
 		SBOMSources:  sbomSources,
 		RekorURL:     getString(f.RekorURL),
+
+		EnableAnalyzers:  analyzer.ParseTypes(getStringSlice(f.EnableAnalyzers)),
+		DisableAnalyzers: analyzer.ParseTypes(getStringSlice(f.DisableAnalyzers)),
+		AnalyzerTimeout:  getDuration(f.AnalyzerTimeout),
+		KeepGoing:        getBool(f.KeepGoing),
+
+		MaxWorkers:      getInt(f.MaxWorkers),
+		MaxHeavyWorkers: getInt(f.MaxHeavyWorkers),
+		MaxMemory:       int64(maxMemory),
+
+		SymlinkOption: walker.SymlinkOption{
+			Policy:   symlinkPolicy,
+			MaxDepth: getInt(f.MaxSymlinkDepth),
+		},
+
+		CustomResourceConfigPath: getString(f.CustomResourceConfig),
 	}, nil
 }
 
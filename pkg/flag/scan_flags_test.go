@@ -2,21 +2,31 @@ package flag_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
 	"github.com/zhanglimao/trivy/pkg/flag"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
 func TestScanFlagGroup_ToOptions(t *testing.T) {
 	type fields struct {
-		skipDirs    []string
-		skipFiles   []string
-		offlineScan bool
-		scanners    string
+		skipDirs     []string
+		skipFiles    []string
+		includeFiles []string
+		maxFileSize  string
+		maxMemory    string
+		offlineScan  bool
+		scanners     string
+
+		enableAnalyzers  []string
+		disableAnalyzers []string
+		analyzerTimeout  string
+		keepGoing        bool
 	}
 	tests := []struct {
 		name      string
@@ -105,6 +115,44 @@ func TestScanFlagGroup_ToOptions(t *testing.T) {
 			},
 			assertion: require.NoError,
 		},
+		{
+			name: "include files with max file size",
+			fields: fields{
+				includeFiles: []string{
+					"**/*.json",
+					"!**/testdata/**",
+				},
+				maxFileSize: "10MB",
+			},
+			want: flag.ScanOptions{
+				IncludeFiles: []string{
+					"**/*.json",
+					"!**/testdata/**",
+				},
+				MaxFileSize: 10000000,
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "with wrong max file size",
+			fields: fields{
+				maxFileSize: "10 apples",
+			},
+			want: flag.ScanOptions{},
+			assertion: func(t require.TestingT, err error, msgs ...interface{}) {
+				require.ErrorContains(t, err, "unable to parse the max file size")
+			},
+		},
+		{
+			name: "max memory",
+			fields: fields{
+				maxMemory: "2GiB",
+			},
+			want: flag.ScanOptions{
+				MaxMemory: 2147483648,
+			},
+			assertion: require.NoError,
+		},
 		{
 			name: "offline scan",
 			fields: fields{
@@ -115,21 +163,81 @@ func TestScanFlagGroup_ToOptions(t *testing.T) {
 			},
 			assertion: require.NoError,
 		},
+		{
+			name: "analyzer groups",
+			fields: fields{
+				enableAnalyzers:  []string{"os", "Secret"},
+				disableAnalyzers: []string{"license"},
+			},
+			want: flag.ScanOptions{
+				EnableAnalyzers: append(append([]analyzer.Type{}, analyzer.TypeOSes...), analyzer.TypeSecret),
+				DisableAnalyzers: []analyzer.Type{
+					analyzer.TypeLicenseFile,
+					analyzer.TypeDpkgLicense,
+				},
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "analyzer timeout",
+			fields: fields{
+				analyzerTimeout: "30s",
+			},
+			want: flag.ScanOptions{
+				AnalyzerTimeout: 30 * time.Second,
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "keep going",
+			fields: fields{
+				keepGoing: true,
+			},
+			want: flag.ScanOptions{
+				KeepGoing: true,
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "analyzer not registered yet",
+			fields: fields{
+				enableAnalyzers: []string{"my-plugin"},
+			},
+			want: flag.ScanOptions{
+				EnableAnalyzers: []analyzer.Type{"my-plugin"},
+			},
+			assertion: require.NoError,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			viper.Set(flag.SkipDirsFlag.ConfigName, tt.fields.skipDirs)
 			viper.Set(flag.SkipFilesFlag.ConfigName, tt.fields.skipFiles)
+			viper.Set(flag.IncludeFilesFlag.ConfigName, tt.fields.includeFiles)
+			viper.Set(flag.MaxFileSizeFlag.ConfigName, tt.fields.maxFileSize)
+			viper.Set(flag.MaxMemoryFlag.ConfigName, tt.fields.maxMemory)
 			viper.Set(flag.OfflineScanFlag.ConfigName, tt.fields.offlineScan)
 			viper.Set(flag.ScannersFlag.ConfigName, tt.fields.scanners)
+			viper.Set(flag.EnableAnalyzersFlag.ConfigName, tt.fields.enableAnalyzers)
+			viper.Set(flag.DisableAnalyzersFlag.ConfigName, tt.fields.disableAnalyzers)
+			viper.Set(flag.AnalyzerTimeoutFlag.ConfigName, tt.fields.analyzerTimeout)
+			viper.Set(flag.KeepGoingFlag.ConfigName, tt.fields.keepGoing)
 
 			// Assert options
 			f := &flag.ScanFlagGroup{
-				SkipDirs:    &flag.SkipDirsFlag,
-				SkipFiles:   &flag.SkipFilesFlag,
-				OfflineScan: &flag.OfflineScanFlag,
-				Scanners:    &flag.ScannersFlag,
+				SkipDirs:     &flag.SkipDirsFlag,
+				SkipFiles:    &flag.SkipFilesFlag,
+				IncludeFiles: &flag.IncludeFilesFlag,
+				MaxFileSize:  &flag.MaxFileSizeFlag,
+				MaxMemory:    &flag.MaxMemoryFlag,
+				OfflineScan:  &flag.OfflineScanFlag,
+				Scanners:     &flag.ScannersFlag,
+
+				EnableAnalyzers:  &flag.EnableAnalyzersFlag,
+				DisableAnalyzers: &flag.DisableAnalyzersFlag,
+				AnalyzerTimeout:  &flag.AnalyzerTimeoutFlag,
+				KeepGoing:        &flag.KeepGoingFlag,
 			}
 
 			got, err := f.ToOptions(tt.args)
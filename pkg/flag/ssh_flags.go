@@ -0,0 +1,38 @@
+package flag
+
+var (
+	SSHKeyFlag = Flag{
+		Name:       "ssh-key",
+		ConfigName: "ssh.key",
+		Value:      "",
+		Usage:      "identity file for scanning remote filesystems over SSH (ssh://user@host/path). The SSH agent is used if not specified",
+	}
+)
+
+type SSHFlagGroup struct {
+	SSHKey *Flag
+}
+
+type SSHOptions struct {
+	SSHKey string
+}
+
+func NewSSHFlagGroup() *SSHFlagGroup {
+	return &SSHFlagGroup{
+		SSHKey: &SSHKeyFlag,
+	}
+}
+
+func (f *SSHFlagGroup) Name() string {
+	return "SSH"
+}
+
+func (f *SSHFlagGroup) Flags() []*Flag {
+	return []*Flag{f.SSHKey}
+}
+
+func (f *SSHFlagGroup) ToOptions() SSHOptions {
+	return SSHOptions{
+		SSHKey: getString(f.SSHKey),
+	}
+}
@@ -0,0 +1,58 @@
+package flag
+
+var (
+	DetectTyposquattingFlag = Flag{
+		Name:       "detect-typosquatting",
+		ConfigName: "supply-chain.typosquatting",
+		Value:      false,
+		Usage:      "[EXPERIMENTAL] report packages whose names are near-misses of popular packages",
+	}
+	DetectInstallScriptsFlag = Flag{
+		Name:       "detect-install-scripts",
+		ConfigName: "supply-chain.install-scripts",
+		Value:      false,
+		Usage:      "[EXPERIMENTAL] report npm packages with obfuscated or network-fetching install scripts",
+	}
+	InternalPackagesFlag = Flag{
+		Name:       "internal-packages",
+		ConfigName: "supply-chain.internal-packages",
+		Value:      []string{},
+		Usage:      "[EXPERIMENTAL] name patterns of internal packages checked for dependency confusion (e.g. @acme/*)",
+	}
+)
+
+type SupplyChainFlagGroup struct {
+	DetectTyposquatting  *Flag
+	DetectInstallScripts *Flag
+	InternalPackages     *Flag
+}
+
+type SupplyChainOptions struct {
+	DetectTyposquatting  bool
+	DetectInstallScripts bool
+	InternalPackages     []string
+}
+
+func NewSupplyChainFlagGroup() *SupplyChainFlagGroup {
+	return &SupplyChainFlagGroup{
+		DetectTyposquatting:  &DetectTyposquattingFlag,
+		DetectInstallScripts: &DetectInstallScriptsFlag,
+		InternalPackages:     &InternalPackagesFlag,
+	}
+}
+
+func (f *SupplyChainFlagGroup) Name() string {
+	return "Supply Chain"
+}
+
+func (f *SupplyChainFlagGroup) Flags() []*Flag {
+	return []*Flag{f.DetectTyposquatting, f.DetectInstallScripts, f.InternalPackages}
+}
+
+func (f *SupplyChainFlagGroup) ToOptions() SupplyChainOptions {
+	return SupplyChainOptions{
+		DetectTyposquatting:  getBool(f.DetectTyposquatting),
+		DetectInstallScripts: getBool(f.DetectInstallScripts),
+		InternalPackages:     getStringSlice(f.InternalPackages),
+	}
+}
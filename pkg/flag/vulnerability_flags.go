@@ -23,22 +23,31 @@ var (
 		Value:      false,
 		Usage:      "display only fixed vulnerabilities",
 	}
+	ESMFixedFlag = Flag{
+		Name:       "esm-fixed",
+		ConfigName: "vulnerability.esm-fixed",
+		Value:      false,
+		Usage:      "treat vulnerabilities fixed only in Ubuntu ESM as fixed",
+	}
 )
 
 type VulnerabilityFlagGroup struct {
 	VulnType      *Flag
 	IgnoreUnfixed *Flag
+	ESMFixed      *Flag
 }
 
 type VulnerabilityOptions struct {
 	VulnType      []string
 	IgnoreUnfixed bool
+	ESMFixed      bool
 }
 
 func NewVulnerabilityFlagGroup() *VulnerabilityFlagGroup {
 	return &VulnerabilityFlagGroup{
 		VulnType:      &VulnTypeFlag,
 		IgnoreUnfixed: &IgnoreUnfixedFlag,
+		ESMFixed:      &ESMFixedFlag,
 	}
 }
 
@@ -50,6 +59,7 @@ func (f *VulnerabilityFlagGroup) Flags() []*Flag {
 	return []*Flag{
 		f.VulnType,
 		f.IgnoreUnfixed,
+		f.ESMFixed,
 	}
 }
 
@@ -57,6 +67,7 @@ func (f *VulnerabilityFlagGroup) ToOptions() VulnerabilityOptions {
 	return VulnerabilityOptions{
 		VulnType:      parseVulnType(getStringSlice(f.VulnType)),
 		IgnoreUnfixed: getBool(f.IgnoreUnfixed),
+		ESMFixed:      getBool(f.ESMFixed),
 	}
 }
 
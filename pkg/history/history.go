@@ -0,0 +1,195 @@
+package history
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+const (
+	// The store is kept out of the fanal cache directory so that it survives --clear-cache
+	historyDirName = "history"
+	historyDBName  = "history.db"
+
+	// keyFormat is a fixed-width time format so that keys are sorted in chronological order
+	keyFormat = "2006-01-02T15:04:05.000000000Z"
+)
+
+type FindingType string
+
+const (
+	FindingVulnerability    FindingType = "vulnerability"
+	FindingMisconfiguration FindingType = "misconfiguration"
+	FindingSecret           FindingType = "secret"
+	FindingLicense          FindingType = "license"
+)
+
+// Record is a scan result of an artifact at a point in time
+type Record struct {
+	ArtifactName string
+	ArtifactType ftypes.ArtifactType
+	ScannedAt    time.Time
+	ImageDigest  string `json:",omitempty"`
+	Findings     []Finding
+}
+
+// Finding is a minimal form of a vulnerability, misconfiguration, secret or license
+// that is enough to track it across scans.
+type Finding struct {
+	Type             FindingType
+	ID               string
+	Target           string
+	PkgName          string `json:",omitempty"`
+	InstalledVersion string `json:",omitempty"`
+	Severity         string
+}
+
+// Key identifies the same finding across scans.
+// The installed version is not a part of the key as the finding remains if the package is upgraded to another vulnerable version.
+func (f Finding) Key() string {
+	return string(f.Type) + "|" + f.Target + "|" + f.PkgName + "|" + f.ID
+}
+
+// NewRecord converts the report into a record.
+// Passed misconfigurations are not recorded.
+func NewRecord(report types.Report, scannedAt time.Time) Record {
+	record := Record{
+		ArtifactName: report.ArtifactName,
+		ArtifactType: report.ArtifactType,
+		ScannedAt:    scannedAt.UTC(),
+		ImageDigest:  report.Metadata.ImageDigest,
+	}
+	for _, f := range trackedFindings(&report) {
+		record.Findings = append(record.Findings, f.Finding)
+	}
+	return record
+}
+
+// recordTarget returns the target tracking the findings of the result across scans
+func recordTarget(r types.Result) string {
+	if r.Class == types.ClassOSPkg {
+		// The target of OS packages contains the OS version, e.g. "alpine:3.17 (alpine 3.17.0)",
+		// which changes when the OS is upgraded.
+		return r.Type
+	}
+	return r.Target
+}
+
+// Artifact is an overview of the recorded scans of an artifact
+type Artifact struct {
+	Name          string
+	Type          ftypes.ArtifactType
+	Scans         int
+	LastScannedAt time.Time
+	Findings      int // the number of findings in the last scan
+}
+
+// Store records scan results in an embedded database under the cache directory.
+// Records are stored in a bucket per artifact and keyed by the scan time.
+type Store struct {
+	db *bolt.DB
+}
+
+func Open(cacheDir string) (*Store, error) {
+	dir := filepath.Join(cacheDir, historyDirName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, xerrors.Errorf("failed to create history dir: %w", err)
+	}
+
+	db, err := bolt.Open(filepath.Join(dir, historyDBName), 0600, nil)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to open DB: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Put records the scan result
+func (s *Store) Put(record Record) error {
+	if record.ArtifactName == "" {
+		return xerrors.New("the artifact name is empty")
+	}
+
+	b, err := json.Marshal(record)
+	if err != nil {
+		return xerrors.Errorf("JSON marshal error: %w", err)
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(record.ArtifactName))
+		if err != nil {
+			return xerrors.Errorf("unable to create a bucket: %w", err)
+		}
+		return bucket.Put([]byte(record.ScannedAt.UTC().Format(keyFormat)), b)
+	})
+	if err != nil {
+		return xerrors.Errorf("DB error: %w", err)
+	}
+	return nil
+}
+
+// Records returns the recorded scans of the artifact, oldest first
+func (s *Store) Records(artifactName string) ([]Record, error) {
+	var records []Record
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(artifactName))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(_, v []byte) error {
+			var record Record
+			if err := json.Unmarshal(v, &record); err != nil {
+				return xerrors.Errorf("JSON unmarshal error: %w", err)
+			}
+			records = append(records, record)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("DB error: %w", err)
+	}
+	return records, nil
+}
+
+// Artifacts returns the overview of recorded artifacts sorted by name
+func (s *Store) Artifacts() ([]Artifact, error) {
+	var artifacts []Artifact
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			_, v := bucket.Cursor().Last()
+			if v == nil {
+				return nil
+			}
+			var last Record
+			if err := json.Unmarshal(v, &last); err != nil {
+				return xerrors.Errorf("JSON unmarshal error: %w", err)
+			}
+			artifacts = append(artifacts, Artifact{
+				Name:          string(name),
+				Type:          last.ArtifactType,
+				Scans:         bucket.Stats().KeyN,
+				LastScannedAt: last.ScannedAt,
+				Findings:      len(last.Findings),
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("DB error: %w", err)
+	}
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].Name < artifacts[j].Name
+	})
+	return artifacts, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
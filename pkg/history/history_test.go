@@ -0,0 +1,168 @@
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/history"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func TestNewRecord(t *testing.T) {
+	report := types.Report{
+		ArtifactName: "alpine:3.17",
+		ArtifactType: ftypes.ArtifactContainerImage,
+		Metadata: types.Metadata{
+			ImageDigest: "sha256:2e7d5a8b0a7c4d0b3b9f2f4c6a8b7d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c",
+		},
+		Results: types.Results{
+			{
+				Target: "alpine:3.17 (alpine 3.17.0)",
+				Class:  types.ClassOSPkg,
+				Type:   "alpine",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2022-0001",
+						PkgName:          "musl",
+						InstalledVersion: "1.2.3-r0",
+						Vulnerability: dbTypes.Vulnerability{
+							Severity: "HIGH",
+						},
+					},
+				},
+			},
+			{
+				Target: "Dockerfile",
+				Class:  types.ClassConfig,
+				Misconfigurations: []types.DetectedMisconfiguration{
+					{
+						AVDID:    "AVD-DS-0002",
+						Severity: "HIGH",
+						Status:   types.StatusFailure,
+					},
+					{
+						AVDID:    "AVD-DS-0001",
+						Severity: "MEDIUM",
+						Status:   types.StatusPassed,
+					},
+				},
+			},
+			{
+				Target: "/app/.env",
+				Class:  types.ClassSecret,
+				Secrets: []ftypes.SecretFinding{
+					{
+						RuleID:   "aws-access-key-id",
+						Severity: "CRITICAL",
+					},
+				},
+			},
+		},
+	}
+	scannedAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	want := history.Record{
+		ArtifactName: "alpine:3.17",
+		ArtifactType: ftypes.ArtifactContainerImage,
+		ScannedAt:    scannedAt,
+		ImageDigest:  "sha256:2e7d5a8b0a7c4d0b3b9f2f4c6a8b7d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c",
+		Findings: []history.Finding{
+			{
+				Type:             history.FindingVulnerability,
+				ID:               "CVE-2022-0001",
+				Target:           "alpine",
+				PkgName:          "musl",
+				InstalledVersion: "1.2.3-r0",
+				Severity:         "HIGH",
+			},
+			{
+				Type:     history.FindingMisconfiguration,
+				ID:       "AVD-DS-0002",
+				Target:   "Dockerfile",
+				Severity: "HIGH",
+			},
+			{
+				Type:     history.FindingSecret,
+				ID:       "aws-access-key-id",
+				Target:   "/app/.env",
+				Severity: "CRITICAL",
+			},
+		},
+	}
+	assert.Equal(t, want, history.NewRecord(report, scannedAt))
+}
+
+func TestStore(t *testing.T) {
+	store, err := history.Open(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	day1 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	vuln := history.Finding{
+		Type:     history.FindingVulnerability,
+		ID:       "CVE-2022-0001",
+		Target:   "alpine",
+		PkgName:  "musl",
+		Severity: "HIGH",
+	}
+
+	// Put the records out of order
+	records := []history.Record{
+		{
+			ArtifactName: "alpine:3.17",
+			ArtifactType: ftypes.ArtifactContainerImage,
+			ScannedAt:    day2,
+		},
+		{
+			ArtifactName: "alpine:3.17",
+			ArtifactType: ftypes.ArtifactContainerImage,
+			ScannedAt:    day1,
+			Findings:     []history.Finding{vuln},
+		},
+		{
+			ArtifactName: "./app",
+			ArtifactType: ftypes.ArtifactFilesystem,
+			ScannedAt:    day1,
+			Findings:     []history.Finding{vuln},
+		},
+	}
+	for _, record := range records {
+		require.NoError(t, store.Put(record))
+	}
+
+	err = store.Put(history.Record{ScannedAt: day1})
+	require.ErrorContains(t, err, "the artifact name is empty")
+
+	got, err := store.Records("alpine:3.17")
+	require.NoError(t, err)
+	assert.Equal(t, []history.Record{records[1], records[0]}, got)
+
+	got, err = store.Records("unknown")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	artifacts, err := store.Artifacts()
+	require.NoError(t, err)
+	assert.Equal(t, []history.Artifact{
+		{
+			Name:          "./app",
+			Type:          ftypes.ArtifactFilesystem,
+			Scans:         1,
+			LastScannedAt: day1,
+			Findings:      1,
+		},
+		{
+			Name:          "alpine:3.17",
+			Type:          ftypes.ArtifactContainerImage,
+			Scans:         2,
+			LastScannedAt: day2,
+			Findings:      0,
+		},
+	}, artifacts)
+}
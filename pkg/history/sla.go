@@ -0,0 +1,32 @@
+package history
+
+import (
+	"time"
+
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// SLA is the number of days to remediate findings per severity, e.g. {"CRITICAL": 7, "HIGH": 30}
+type SLA map[string]int
+
+// Stamp sets the due dates of the findings whose severities have SLAs.
+// The due date is computed from when the finding was first seen in the records, or now if it has never been recorded.
+func (sla SLA) Stamp(report *types.Report, records []Record, now time.Time) {
+	if len(sla) == 0 {
+		return
+	}
+
+	times := seenTimes(records)
+	for _, f := range trackedFindings(report) {
+		days, ok := sla[f.Severity]
+		if !ok {
+			continue
+		}
+		first := times[f.Key()].first
+		if first.IsZero() {
+			first = now
+		}
+		due := first.UTC().AddDate(0, 0, days)
+		*f.dueDate = &due
+	}
+}
@@ -0,0 +1,99 @@
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/history"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func TestSLA_Stamp(t *testing.T) {
+	day1 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	day3 := day1.AddDate(0, 0, 2)
+	now := day1.AddDate(0, 0, 3)
+
+	musl := history.Finding{
+		Type:     history.FindingVulnerability,
+		ID:       "CVE-2022-0001",
+		Target:   "alpine",
+		PkgName:  "musl",
+		Severity: "CRITICAL",
+	}
+	secret := history.Finding{
+		Type:     history.FindingSecret,
+		ID:       "aws-access-key-id",
+		Target:   "app/.env",
+		Severity: "CRITICAL",
+	}
+	records := []history.Record{
+		{
+			ScannedAt: day1,
+			Findings:  []history.Finding{musl, secret},
+		},
+		{
+			// The secret was removed once
+			ScannedAt: day2,
+			Findings:  []history.Finding{musl},
+		},
+		{
+			ScannedAt: day3,
+			Findings:  []history.Finding{musl, secret},
+		},
+	}
+
+	report := types.Report{
+		Results: types.Results{
+			{
+				Target: "alpine:3.17 (alpine 3.17.0)",
+				Class:  types.ClassOSPkg,
+				Type:   "alpine",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID: "CVE-2022-0001",
+						PkgName:         "musl",
+						Vulnerability:   dbTypes.Vulnerability{Severity: "CRITICAL"},
+					},
+					{
+						VulnerabilityID: "CVE-2022-0002",
+						PkgName:         "busybox",
+						Vulnerability:   dbTypes.Vulnerability{Severity: "HIGH"},
+					},
+					{
+						VulnerabilityID: "CVE-2022-0003",
+						PkgName:         "busybox",
+						Vulnerability:   dbTypes.Vulnerability{Severity: "LOW"},
+					},
+				},
+			},
+			{
+				Target: "app/.env",
+				Class:  types.ClassSecret,
+				Secrets: []ftypes.SecretFinding{
+					{
+						RuleID:   "aws-access-key-id",
+						Severity: "CRITICAL",
+					},
+				},
+			},
+		},
+	}
+
+	sla := history.SLA{
+		"CRITICAL": 7,
+		"HIGH":     30,
+	}
+	sla.Stamp(&report, records, now)
+
+	vulns := report.Results[0].Vulnerabilities
+	assert.Equal(t, lo.ToPtr(day1.AddDate(0, 0, 7)), vulns[0].DueDate, "first seen in the first scan")
+	assert.Equal(t, lo.ToPtr(now.AddDate(0, 0, 30)), vulns[1].DueDate, "never recorded")
+	assert.Nil(t, vulns[2].DueDate, "no SLA")
+	assert.Equal(t, lo.ToPtr(day3.AddDate(0, 0, 7)), report.Results[1].Secrets[0].DueDate, "found again")
+}
@@ -0,0 +1,139 @@
+package history
+
+import (
+	"time"
+
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// trackedFinding is a finding in the report with the fields to be set from the history
+type trackedFinding struct {
+	Finding
+
+	firstSeen **time.Time
+	lastSeen  **time.Time
+	dueDate   **time.Time
+}
+
+// trackedFindings returns vulnerabilities, failed misconfigurations, secrets and licenses in the report
+func trackedFindings(report *types.Report) []trackedFinding {
+	var findings []trackedFinding
+	for i := range report.Results {
+		r := &report.Results[i]
+		target := recordTarget(*r)
+		for j := range r.Vulnerabilities {
+			v := &r.Vulnerabilities[j]
+			findings = append(findings, trackedFinding{
+				Finding: Finding{
+					Type:             FindingVulnerability,
+					ID:               v.VulnerabilityID,
+					Target:           target,
+					PkgName:          v.PkgName,
+					InstalledVersion: v.InstalledVersion,
+					Severity:         v.Severity,
+				},
+				firstSeen: &v.FirstSeen,
+				lastSeen:  &v.LastSeen,
+				dueDate:   &v.DueDate,
+			})
+		}
+		for j := range r.Misconfigurations {
+			m := &r.Misconfigurations[j]
+			if m.Status != types.StatusFailure {
+				continue
+			}
+			findings = append(findings, trackedFinding{
+				Finding: Finding{
+					Type:     FindingMisconfiguration,
+					ID:       m.AVDID,
+					Target:   target,
+					Severity: m.Severity,
+				},
+				firstSeen: &m.FirstSeen,
+				lastSeen:  &m.LastSeen,
+				dueDate:   &m.DueDate,
+			})
+		}
+		for j := range r.Secrets {
+			s := &r.Secrets[j]
+			findings = append(findings, trackedFinding{
+				Finding: Finding{
+					Type:     FindingSecret,
+					ID:       s.RuleID,
+					Target:   target,
+					Severity: s.Severity,
+				},
+				firstSeen: &s.FirstSeen,
+				lastSeen:  &s.LastSeen,
+				dueDate:   &s.DueDate,
+			})
+		}
+		for j := range r.Licenses {
+			l := &r.Licenses[j]
+			findings = append(findings, trackedFinding{
+				Finding: Finding{
+					Type:     FindingLicense,
+					ID:       l.Name,
+					Target:   target,
+					PkgName:  l.PkgName,
+					Severity: l.Severity,
+				},
+				firstSeen: &l.FirstSeen,
+				lastSeen:  &l.LastSeen,
+				dueDate:   &l.DueDate,
+			})
+		}
+	}
+	return findings
+}
+
+// seen holds when a finding was seen in the history
+type seen struct {
+	first time.Time // the first scan in the latest streak of scans finding it, zero if the previous scan didn't find it
+	last  time.Time // the last scan finding it
+}
+
+// seenTimes returns when each finding was seen in the records sorted by the scan time.
+// A finding fixed and found again is regarded as a new finding as in Summarize.
+func seenTimes(records []Record) map[string]seen {
+	times := map[string]seen{}
+	for _, record := range records {
+		current := map[string]struct{}{}
+		for _, f := range record.Findings {
+			current[f.Key()] = struct{}{}
+		}
+		for key, t := range times {
+			if _, ok := current[key]; !ok {
+				t.first = time.Time{}
+				times[key] = t
+			}
+		}
+		for key := range current {
+			t := times[key]
+			if t.first.IsZero() {
+				t.first = record.ScannedAt
+			}
+			t.last = record.ScannedAt
+			times[key] = t
+		}
+	}
+	return times
+}
+
+// Track sets when the findings in the report were first and last seen in the records sorted by the scan time.
+// FirstSeen is now for new findings and findings found again, and LastSeen is the last scan finding it before now.
+func Track(report *types.Report, records []Record, now time.Time) {
+	times := seenTimes(records)
+	for _, f := range trackedFindings(report) {
+		t := times[f.Key()]
+		first := now.UTC()
+		if !t.first.IsZero() {
+			first = t.first
+		}
+		*f.firstSeen = &first
+		if !t.last.IsZero() {
+			last := t.last
+			*f.lastSeen = &last
+		}
+	}
+}
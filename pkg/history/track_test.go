@@ -0,0 +1,130 @@
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/history"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func TestTrack(t *testing.T) {
+	day1 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	day3 := day1.AddDate(0, 0, 2)
+	now := day1.AddDate(0, 0, 3)
+
+	musl := history.Finding{
+		Type:    history.FindingVulnerability,
+		ID:      "CVE-2022-0001",
+		Target:  "alpine",
+		PkgName: "musl",
+	}
+	busybox := history.Finding{
+		Type:    history.FindingVulnerability,
+		ID:      "CVE-2022-0003",
+		Target:  "alpine",
+		PkgName: "busybox",
+	}
+	secret := history.Finding{
+		Type:   history.FindingSecret,
+		ID:     "aws-access-key-id",
+		Target: "app/.env",
+	}
+	records := []history.Record{
+		{
+			ScannedAt: day1,
+			Findings:  []history.Finding{musl, busybox, secret},
+		},
+		{
+			// The secret was removed once
+			ScannedAt: day2,
+			Findings:  []history.Finding{musl},
+		},
+		{
+			ScannedAt: day3,
+			Findings:  []history.Finding{musl, secret},
+		},
+	}
+
+	report := types.Report{
+		Results: types.Results{
+			{
+				Target: "alpine:3.17 (alpine 3.17.0)",
+				Class:  types.ClassOSPkg,
+				Type:   "alpine",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID: "CVE-2022-0001",
+						PkgName:         "musl",
+					},
+					{
+						VulnerabilityID: "CVE-2022-0002",
+						PkgName:         "busybox",
+					},
+					{
+						VulnerabilityID: "CVE-2022-0003",
+						PkgName:         "busybox",
+					},
+				},
+			},
+			{
+				Target: "app/.env",
+				Class:  types.ClassSecret,
+				Secrets: []ftypes.SecretFinding{
+					{
+						RuleID: "aws-access-key-id",
+					},
+				},
+			},
+		},
+	}
+
+	history.Track(&report, records, now)
+
+	tests := []struct {
+		name          string
+		gotFirstSeen  *time.Time
+		gotLastSeen   *time.Time
+		wantFirstSeen *time.Time
+		wantLastSeen  *time.Time
+	}{
+		{
+			name:          "long-standing",
+			gotFirstSeen:  report.Results[0].Vulnerabilities[0].FirstSeen,
+			gotLastSeen:   report.Results[0].Vulnerabilities[0].LastSeen,
+			wantFirstSeen: lo.ToPtr(day1),
+			wantLastSeen:  lo.ToPtr(day3),
+		},
+		{
+			name:          "never recorded",
+			gotFirstSeen:  report.Results[0].Vulnerabilities[1].FirstSeen,
+			gotLastSeen:   report.Results[0].Vulnerabilities[1].LastSeen,
+			wantFirstSeen: lo.ToPtr(now),
+		},
+		{
+			name:          "reintroduced",
+			gotFirstSeen:  report.Results[0].Vulnerabilities[2].FirstSeen,
+			gotLastSeen:   report.Results[0].Vulnerabilities[2].LastSeen,
+			wantFirstSeen: lo.ToPtr(now),
+			wantLastSeen:  lo.ToPtr(day1),
+		},
+		{
+			name:          "found again",
+			gotFirstSeen:  report.Results[1].Secrets[0].FirstSeen,
+			gotLastSeen:   report.Results[1].Secrets[0].LastSeen,
+			wantFirstSeen: lo.ToPtr(day3),
+			wantLastSeen:  lo.ToPtr(day3),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantFirstSeen, tt.gotFirstSeen)
+			assert.Equal(t, tt.wantLastSeen, tt.gotLastSeen)
+		})
+	}
+}
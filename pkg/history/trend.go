@@ -0,0 +1,99 @@
+package history
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Scan is a data point of the finding trend
+type Scan struct {
+	ScannedAt   time.Time
+	ImageDigest string `json:",omitempty"`
+	Total       int
+	Severities  map[string]int
+	New         int // findings not found in the previous scan
+	Fixed       int // findings of the previous scan not found any longer
+}
+
+// Summary is the finding trend of an artifact over time
+type Summary struct {
+	ArtifactName string
+	Scans        []Scan
+
+	// FixedVulnerabilities is the number of vulnerabilities that disappeared in a later scan
+	FixedVulnerabilities int
+
+	// MTTR is the mean time to remediate the fixed vulnerabilities,
+	// measured from the first scan finding a vulnerability to the first scan not finding it.
+	MTTR Duration `json:",omitempty"`
+}
+
+// Duration is marshaled into a human-readable string such as "36h0m0s"
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+type openFinding struct {
+	findingType FindingType
+	firstSeen   time.Time
+}
+
+// Summarize computes the trend from the records sorted by the scan time
+func Summarize(artifactName string, records []Record) Summary {
+	summary := Summary{ArtifactName: artifactName}
+
+	var remediation time.Duration
+	open := map[string]openFinding{}
+	for i, record := range records {
+		scan := Scan{
+			ScannedAt:   record.ScannedAt,
+			ImageDigest: record.ImageDigest,
+			Severities:  map[string]int{},
+		}
+
+		current := map[string]Finding{}
+		for _, f := range record.Findings {
+			if _, ok := current[f.Key()]; ok {
+				continue // e.g. the same vulnerability in multiple locations
+			}
+			current[f.Key()] = f
+			scan.Total++
+			scan.Severities[f.Severity]++
+			if _, ok := open[f.Key()]; ok {
+				continue
+			}
+			open[f.Key()] = openFinding{
+				findingType: f.Type,
+				firstSeen:   record.ScannedAt,
+			}
+			if i > 0 {
+				scan.New++
+			}
+		}
+
+		for key, f := range open {
+			if _, ok := current[key]; ok {
+				continue
+			}
+			delete(open, key)
+			scan.Fixed++
+			if f.findingType == FindingVulnerability {
+				summary.FixedVulnerabilities++
+				remediation += record.ScannedAt.Sub(f.firstSeen)
+			}
+		}
+
+		summary.Scans = append(summary.Scans, scan)
+	}
+
+	if summary.FixedVulnerabilities > 0 {
+		summary.MTTR = Duration(remediation / time.Duration(summary.FixedVulnerabilities))
+	}
+	return summary
+}
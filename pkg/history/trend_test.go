@@ -0,0 +1,153 @@
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zhanglimao/trivy/pkg/history"
+)
+
+func TestSummarize(t *testing.T) {
+	day1 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	day4 := day1.Add(72 * time.Hour)
+
+	vuln1 := history.Finding{
+		Type:     history.FindingVulnerability,
+		ID:       "CVE-2022-0001",
+		Target:   "alpine",
+		PkgName:  "musl",
+		Severity: "HIGH",
+	}
+	vuln2 := history.Finding{
+		Type:     history.FindingVulnerability,
+		ID:       "CVE-2022-0002",
+		Target:   "alpine",
+		PkgName:  "busybox",
+		Severity: "CRITICAL",
+	}
+	vuln3 := history.Finding{
+		Type:     history.FindingVulnerability,
+		ID:       "CVE-2022-0003",
+		Target:   "app/package-lock.json",
+		PkgName:  "lodash",
+		Severity: "MEDIUM",
+	}
+	secret := history.Finding{
+		Type:     history.FindingSecret,
+		ID:       "aws-access-key-id",
+		Target:   "/app/.env",
+		Severity: "CRITICAL",
+	}
+
+	tests := []struct {
+		name    string
+		records []history.Record
+		want    history.Summary
+	}{
+		{
+			name: "fixed vulnerabilities",
+			records: []history.Record{
+				{
+					ScannedAt: day1,
+					Findings:  []history.Finding{vuln1, vuln2, vuln2, secret},
+				},
+				{
+					ScannedAt: day2,
+					Findings:  []history.Finding{vuln2, vuln3},
+				},
+				{
+					ScannedAt: day4,
+					Findings:  []history.Finding{vuln3},
+				},
+			},
+			want: history.Summary{
+				ArtifactName: "alpine:3.17",
+				Scans: []history.Scan{
+					{
+						ScannedAt: day1,
+						Total:     3,
+						Severities: map[string]int{
+							"CRITICAL": 2,
+							"HIGH":     1,
+						},
+					},
+					{
+						ScannedAt: day2,
+						Total:     2,
+						Severities: map[string]int{
+							"CRITICAL": 1,
+							"MEDIUM":   1,
+						},
+						New:   1,
+						Fixed: 2,
+					},
+					{
+						ScannedAt: day4,
+						Total:     1,
+						Severities: map[string]int{
+							"MEDIUM": 1,
+						},
+						Fixed: 1,
+					},
+				},
+				// vuln1 is fixed in 1 day and vuln2 in 3 days. The secret is not counted.
+				FixedVulnerabilities: 2,
+				MTTR:                 history.Duration(48 * time.Hour),
+			},
+		},
+		{
+			name: "reintroduced vulnerability",
+			records: []history.Record{
+				{
+					ScannedAt: day1,
+					Findings:  []history.Finding{vuln1},
+				},
+				{
+					ScannedAt: day2,
+				},
+				{
+					ScannedAt: day4,
+					Findings:  []history.Finding{vuln1},
+				},
+			},
+			want: history.Summary{
+				ArtifactName: "alpine:3.17",
+				Scans: []history.Scan{
+					{
+						ScannedAt:  day1,
+						Total:      1,
+						Severities: map[string]int{"HIGH": 1},
+					},
+					{
+						ScannedAt:  day2,
+						Severities: map[string]int{},
+						Fixed:      1,
+					},
+					{
+						ScannedAt:  day4,
+						Total:      1,
+						Severities: map[string]int{"HIGH": 1},
+						New:        1,
+					},
+				},
+				FixedVulnerabilities: 1,
+				MTTR:                 history.Duration(24 * time.Hour),
+			},
+		},
+		{
+			name: "no records",
+			want: history.Summary{
+				ArtifactName: "alpine:3.17",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := history.Summarize("alpine:3.17", tt.records)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Package i18n translates the text of reports for humans, such as table headers and severity labels.
+// Messages are keyed by the English text so that untranslated messages fall back to English.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Locale is a language of report text in the form of BCP 47, e.g. "zh-CN"
+type Locale string
+
+const (
+	English           Locale = "en"
+	ChineseSimplified Locale = "zh-CN"
+	Japanese          Locale = "ja"
+)
+
+var (
+	SupportedLocales = []Locale{
+		English,
+		ChineseSimplified,
+		Japanese,
+	}
+
+	catalogs = map[Locale]map[string]string{
+		ChineseSimplified: zhCN,
+		Japanese:          ja,
+	}
+
+	// current is set once when options are parsed, as with the logger
+	current = English
+)
+
+// Init sets the locale of the report text. The locale is detected from the environment if it is empty.
+func Init(locale string) error {
+	if locale == "" {
+		SetLocale(DetectLocale())
+		return nil
+	}
+	l, err := ParseLocale(locale)
+	if err != nil {
+		return err
+	}
+	SetLocale(l)
+	return nil
+}
+
+// SetLocale sets the locale of the report text. English is used for an empty locale.
+func SetLocale(l Locale) {
+	if l == "" {
+		l = English
+	}
+	current = l
+}
+
+// CurrentLocale returns the locale of the report text
+func CurrentLocale() Locale {
+	return current
+}
+
+// ParseLocale parses a locale in either form of BCP 47 or POSIX, e.g. "zh-CN", "zh_CN.UTF-8" and "ja_JP".
+func ParseLocale(s string) (Locale, error) {
+	// Trim the codeset and modifier of POSIX locales, e.g. "ja_JP.UTF-8@calendar=japanese"
+	s, _, _ = strings.Cut(s, ".")
+	s, _, _ = strings.Cut(s, "@")
+	lang, region, _ := strings.Cut(strings.ReplaceAll(s, "_", "-"), "-")
+	lang = strings.ToLower(lang)
+
+	switch {
+	case lang == "en" || lang == "c" || lang == "posix":
+		return English, nil
+	case lang == "ja":
+		return Japanese, nil
+	// Only Simplified Chinese is supported as of now
+	case lang == "zh" && (region == "" || strings.EqualFold(region, "CN") || strings.EqualFold(region, "Hans")):
+		return ChineseSimplified, nil
+	}
+	return "", xerrors.Errorf("unsupported locale: %s (supported: %s)", s, strings.Join(supportedNames(), ", "))
+}
+
+// DetectLocale returns the locale of the environment in the same order as gettext, or English if it is not supported
+func DetectLocale() Locale {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(env)
+		if v == "" {
+			continue
+		}
+		// The first non-empty variable takes precedence even if it is not supported
+		if l, err := ParseLocale(v); err == nil {
+			return l
+		}
+		return English
+	}
+	return English
+}
+
+// T returns the message translated into the current locale
+func T(msg string) string {
+	if s, ok := catalogs[current][msg]; ok {
+		return s
+	}
+	return msg
+}
+
+// Sprintf formats according to the format translated into the current locale
+func Sprintf(format string, a ...interface{}) string {
+	return fmt.Sprintf(T(format), a...)
+}
+
+// Severity returns the translated label of the severity such as "HIGH"
+func Severity(severity string) string {
+	return T(severity)
+}
+
+func supportedNames() []string {
+	var names []string
+	for _, l := range SupportedLocales {
+		names = append(names, string(l))
+	}
+	return names
+}
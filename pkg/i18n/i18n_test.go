@@ -0,0 +1,153 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/i18n"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		name    string
+		locale  string
+		want    i18n.Locale
+		wantErr string
+	}{
+		{
+			name:   "BCP 47",
+			locale: "zh-CN",
+			want:   i18n.ChineseSimplified,
+		},
+		{
+			name:   "POSIX with codeset",
+			locale: "ja_JP.UTF-8",
+			want:   i18n.Japanese,
+		},
+		{
+			name:   "language only",
+			locale: "zh",
+			want:   i18n.ChineseSimplified,
+		},
+		{
+			name:   "script",
+			locale: "zh-Hans",
+			want:   i18n.ChineseSimplified,
+		},
+		{
+			name:   "C locale",
+			locale: "C.UTF-8",
+			want:   i18n.English,
+		},
+		{
+			name:   "English with region",
+			locale: "en_US",
+			want:   i18n.English,
+		},
+		{
+			name:    "Traditional Chinese",
+			locale:  "zh_TW.UTF-8",
+			wantErr: "unsupported locale: zh_TW (supported: en, zh-CN, ja)",
+		},
+		{
+			name:    "unknown",
+			locale:  "fr",
+			wantErr: "unsupported locale: fr",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := i18n.ParseLocale(tt.locale)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDetectLocale(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want i18n.Locale
+	}{
+		{
+			name: "LANG",
+			env: map[string]string{
+				"LANG": "ja_JP.UTF-8",
+			},
+			want: i18n.Japanese,
+		},
+		{
+			name: "LC_ALL takes precedence",
+			env: map[string]string{
+				"LC_ALL": "zh_CN.UTF-8",
+				"LANG":   "ja_JP.UTF-8",
+			},
+			want: i18n.ChineseSimplified,
+		},
+		{
+			name: "unsupported",
+			env: map[string]string{
+				"LC_MESSAGES": "fr_FR.UTF-8",
+				"LANG":        "ja_JP.UTF-8",
+			},
+			want: i18n.English,
+		},
+		{
+			name: "not set",
+			want: i18n.English,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+				t.Setenv(env, tt.env[env])
+			}
+			assert.Equal(t, tt.want, i18n.DetectLocale())
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	t.Cleanup(func() { i18n.SetLocale(i18n.English) })
+
+	tests := []struct {
+		name   string
+		locale i18n.Locale
+		want   []string
+	}{
+		{
+			name:   "English",
+			locale: i18n.English,
+			want:   []string{"Severity", "HIGH", "Total: 2 (HIGH: 2)", "Untranslated"},
+		},
+		{
+			name:   "Simplified Chinese",
+			locale: i18n.ChineseSimplified,
+			want:   []string{"严重程度", "高危", "合计: 2 (高危: 2)", "Untranslated"},
+		},
+		{
+			name:   "Japanese",
+			locale: i18n.Japanese,
+			want:   []string{"深刻度", "高", "合計: 2 (高: 2)", "Untranslated"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i18n.SetLocale(tt.locale)
+			got := []string{
+				i18n.T("Severity"),
+				i18n.Severity("HIGH"),
+				i18n.Sprintf("Total: %d (%s)", 2, i18n.Severity("HIGH")+": 2"),
+				i18n.T("Untranslated"),
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
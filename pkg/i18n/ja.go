@@ -0,0 +1,71 @@
+package i18n
+
+// ja is the catalog of Japanese
+var ja = map[string]string{
+	// Severities
+	"UNKNOWN":  "不明",
+	"LOW":      "低",
+	"MEDIUM":   "中",
+	"HIGH":     "高",
+	"CRITICAL": "緊急",
+
+	// Titles
+	"Summary":         "概要",
+	"Vulnerabilities": "脆弱性",
+	"Recommendations": "推奨事項",
+
+	// Table headers
+	"Actual":                   "実際",
+	"Added":                    "追加",
+	"Analyzer":                 "アナライザー",
+	"Baseline":                 "ベースライン",
+	"Builder":                  "ビルダー",
+	"Classification":           "分類",
+	"Confidence":               "確度",
+	"Created By":               "作成コマンド",
+	"Current":                  "現在",
+	"Expected":                 "期待値",
+	"File":                     "ファイル",
+	"File Location":            "ファイルの場所",
+	"Finding":                  "検出項目",
+	"Fixed Version":            "修正バージョン",
+	"ID":                       "ID",
+	"Installed Version":        "インストール済みバージョン",
+	"Kind":                     "変更種別",
+	"Layer":                    "レイヤー",
+	"Library":                  "ライブラリ",
+	"License":                  "ライセンス",
+	"Malicious":                "悪意のあるパッケージ",
+	"Message":                  "メッセージ",
+	"Misconfiguration":         "設定ミス",
+	"Newer Tags":               "新しいタグ",
+	"Package":                  "パッケージ",
+	"Package / File":           "パッケージ / ファイル",
+	"Reason":                   "理由",
+	"Recommended":              "推奨",
+	"Removed":                  "削除",
+	"Resolved Vulnerabilities": "解消される脆弱性",
+	"Secret":                   "シークレット",
+	"Severity":                 "深刻度",
+	"Signer":                   "署名者",
+	"Source":                   "ソース",
+	"Status":                   "状態",
+	"Target":                   "対象",
+	"Title":                    "タイトル",
+	"Total":                    "合計",
+	"Type":                     "種類",
+	"Version":                  "バージョン",
+	"Vulnerability":            "脆弱性",
+
+	// Formats
+	"Total: %d (%s)":                                          "合計: %d (%s)",
+	"Total: %d (%s) in %d targets":                            "合計: %d (%s)、対象 %d 件",
+	"Total: %d (added: %d, modified: %d)":                     "合計: %d (追加: %d, 変更: %d)",
+	"Top %d Vulnerable Packages":                              "脆弱性の多いパッケージ上位 %d 件",
+	"Tests: %d (SUCCESSES: %d, FAILURES: %d, EXCEPTIONS: %d)": "テスト: %d (成功: %d, 失敗: %d, 例外: %d)",
+	"Failures: %d (%s)":                                       "失敗: %d (%s)",
+	"%s (%s, malicious packages)":                             "%s (%s, 悪意のあるパッケージ)",
+	"Total: %d (PASS: %d, FAIL: %d)":                          "合計: %d (成功: %d, 失敗: %d)",
+	"Total: %d (packages: %d)":                                "合計: %d (パッケージ: %d)",
+	"Total: %d (results may be incomplete)":                   "合計: %d (結果が不完全な可能性があります)",
+}
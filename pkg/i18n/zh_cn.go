@@ -0,0 +1,71 @@
+package i18n
+
+// zhCN is the catalog of Simplified Chinese
+var zhCN = map[string]string{
+	// Severities
+	"UNKNOWN":  "未知",
+	"LOW":      "低危",
+	"MEDIUM":   "中危",
+	"HIGH":     "高危",
+	"CRITICAL": "严重",
+
+	// Titles
+	"Summary":         "摘要",
+	"Vulnerabilities": "漏洞",
+	"Recommendations": "建议",
+
+	// Table headers
+	"Actual":                   "实际",
+	"Added":                    "新增",
+	"Analyzer":                 "分析器",
+	"Baseline":                 "基线",
+	"Builder":                  "构建器",
+	"Classification":           "分类",
+	"Confidence":               "置信度",
+	"Created By":               "创建命令",
+	"Current":                  "当前",
+	"Expected":                 "预期",
+	"File":                     "文件",
+	"File Location":            "文件位置",
+	"Finding":                  "发现类别",
+	"Fixed Version":            "修复版本",
+	"ID":                       "ID",
+	"Installed Version":        "已安装版本",
+	"Kind":                     "种类",
+	"Layer":                    "层",
+	"Library":                  "库",
+	"License":                  "许可证",
+	"Malicious":                "恶意软件包",
+	"Message":                  "消息",
+	"Misconfiguration":         "配置错误",
+	"Newer Tags":               "更新的标签",
+	"Package":                  "软件包",
+	"Package / File":           "软件包 / 文件",
+	"Reason":                   "原因",
+	"Recommended":              "推荐",
+	"Removed":                  "删除",
+	"Resolved Vulnerabilities": "可修复的漏洞",
+	"Secret":                   "敏感信息",
+	"Severity":                 "严重程度",
+	"Signer":                   "签名者",
+	"Source":                   "来源",
+	"Status":                   "状态",
+	"Target":                   "目标",
+	"Title":                    "标题",
+	"Total":                    "合计",
+	"Type":                     "类型",
+	"Version":                  "版本",
+	"Vulnerability":            "漏洞",
+
+	// Formats
+	"Total: %d (%s)":                                          "合计: %d (%s)",
+	"Total: %d (%s) in %d targets":                            "合计: %d (%s)，涉及 %d 个目标",
+	"Total: %d (added: %d, modified: %d)":                     "合计: %d (新增: %d, 修改: %d)",
+	"Top %d Vulnerable Packages":                              "漏洞最多的前 %d 个软件包",
+	"Tests: %d (SUCCESSES: %d, FAILURES: %d, EXCEPTIONS: %d)": "检查: %d (通过: %d, 失败: %d, 例外: %d)",
+	"Failures: %d (%s)":                                       "失败: %d (%s)",
+	"%s (%s, malicious packages)":                             "%s (%s, 恶意软件包)",
+	"Total: %d (PASS: %d, FAIL: %d)":                          "合计: %d (通过: %d, 失败: %d)",
+	"Total: %d (packages: %d)":                                "合计: %d (软件包: %d)",
+	"Total: %d (results may be incomplete)":                   "合计: %d (结果可能不完整)",
+}
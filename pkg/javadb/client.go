@@ -27,6 +27,7 @@ var updater *Updater
 
 type Updater struct {
 	repo     string
+	cacheDir string
 	dbDir    string
 	skip     bool
 	quiet    bool
@@ -54,7 +55,8 @@ func (u *Updater) Update() error {
 
 		// TODO: support remote options
 		var a *oci.Artifact
-		if a, err = oci.NewArtifact(u.repo, u.quiet, ftypes.RegistryOptions{Insecure: u.insecure}); err != nil {
+		if a, err = oci.NewArtifact(u.repo, u.quiet, ftypes.RegistryOptions{Insecure: u.insecure},
+			oci.WithCacheDir(u.cacheDir)); err != nil {
 			return xerrors.Errorf("oci error: %w", err)
 		}
 		if err = a.Download(context.Background(), dbDir, oci.DownloadOption{MediaType: mediaType}); err != nil {
@@ -82,6 +84,7 @@ func (u *Updater) Update() error {
 func Init(cacheDir string, javaDBRepository string, skip, quiet, insecure bool) {
 	updater = &Updater{
 		repo:     fmt.Sprintf("%s:%d", javaDBRepository, db.SchemaVersion),
+		cacheDir: cacheDir,
 		dbDir:    filepath.Join(cacheDir, "java-db"),
 		skip:     skip,
 		quiet:    quiet,
@@ -103,7 +106,19 @@ type DB struct {
 	driver db.DB
 }
 
-func NewClient() (*DB, error) {
+// NewClient returns the client of the remote lookup API if configured, otherwise the local Java DB
+func NewClient() (jar.Client, error) {
+	if remote != nil {
+		c, err := NewRemoteClient(*remote)
+		if err != nil {
+			return nil, xerrors.Errorf("Java DB remote client error: %w", err)
+		}
+		return c, nil
+	}
+	return newDB()
+}
+
+func newDB() (*DB, error) {
 	if err := Update(); err != nil {
 		return nil, xerrors.Errorf("Java DB update failed: %s", err)
 	}
@@ -0,0 +1,273 @@
+package javadb
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/go-dep-parser/pkg/java/jar"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+const (
+	// LookupPathPrefix is the path prefix of the Java DB lookup API
+	LookupPathPrefix = "/java-db/"
+
+	// cacheSize is the number of lookup results kept in memory for each kind of query
+	cacheSize = 10000
+
+	defaultTimeout = 30 * time.Second
+)
+
+var remote *RemoteOptions
+
+// RemoteOptions represents options for looking up JAR files against a remote lookup API
+type RemoteOptions struct {
+	URL       string
+	Headers   http.Header
+	TLSConfig *tls.Config
+}
+
+// InitRemote configures the Java DB client to look up JAR files against the remote lookup API
+// such as Trivy server, instead of downloading the Java DB.
+func InitRemote(opts RemoteOptions) {
+	remote = &opts
+}
+
+// RemoteClient looks up JAR files against the remote lookup API.
+// The results are cached in memory as the same JAR files often appear many times.
+type RemoteClient struct {
+	url     string
+	headers http.Header
+	client  *http.Client
+
+	sha1s     *lru.Cache[string, jar.Properties]
+	groupIDs  *lru.Cache[string, string]
+	artifacts *lru.Cache[string, bool]
+}
+
+// NewRemoteClient returns a client of the lookup API
+func NewRemoteClient(opts RemoteOptions) (*RemoteClient, error) {
+	u, err := url.Parse(opts.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, xerrors.Errorf("invalid Java DB URL: %s", opts.URL)
+	}
+
+	sha1s, err := lru.New[string, jar.Properties](cacheSize)
+	if err != nil {
+		return nil, xerrors.Errorf("LRU cache error: %w", err)
+	}
+	groupIDs, err := lru.New[string, string](cacheSize)
+	if err != nil {
+		return nil, xerrors.Errorf("LRU cache error: %w", err)
+	}
+	artifacts, err := lru.New[string, bool](cacheSize)
+	if err != nil {
+		return nil, xerrors.Errorf("LRU cache error: %w", err)
+	}
+
+	return &RemoteClient{
+		url:     strings.TrimSuffix(opts.URL, "/") + strings.TrimSuffix(LookupPathPrefix, "/"),
+		headers: opts.Headers,
+		client: &http.Client{
+			Timeout: defaultTimeout,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: opts.TLSConfig,
+			},
+		},
+		sha1s:     sha1s,
+		groupIDs:  groupIDs,
+		artifacts: artifacts,
+	}, nil
+}
+
+func (c *RemoteClient) Exists(groupID, artifactID string) (bool, error) {
+	key := groupID + ":" + artifactID
+	if exists, ok := c.artifacts.Get(key); ok {
+		return exists, nil
+	}
+
+	var res existsResponse
+	if err := c.get(fmt.Sprintf("/exists/%s/%s", url.PathEscape(groupID), url.PathEscape(artifactID)), &res); err != nil {
+		return false, err
+	}
+	c.artifacts.Add(key, res.Exists)
+	return res.Exists, nil
+}
+
+func (c *RemoteClient) SearchBySHA1(sha1 string) (jar.Properties, error) {
+	if props, ok := c.sha1s.Get(sha1); ok {
+		return found(props, props.ArtifactID != "", "digest "+sha1)
+	}
+
+	var res propertiesResponse
+	if err := c.get("/sha1/"+url.PathEscape(sha1), &res); err != nil {
+		return jar.Properties{}, err
+	}
+	props := jar.Properties{
+		GroupID:    res.GroupID,
+		ArtifactID: res.ArtifactID,
+		Version:    res.Version,
+	}
+
+	// Not-found results are cached as well
+	c.sha1s.Add(sha1, props)
+	return found(props, props.ArtifactID != "", "digest "+sha1)
+}
+
+func (c *RemoteClient) SearchByArtifactID(artifactID string) (string, error) {
+	if groupID, ok := c.groupIDs.Get(artifactID); ok {
+		return found(groupID, groupID != "", "artifactID "+artifactID)
+	}
+
+	var res groupIDResponse
+	if err := c.get("/artifact/"+url.PathEscape(artifactID), &res); err != nil {
+		return "", err
+	}
+
+	c.groupIDs.Add(artifactID, res.GroupID)
+	return found(res.GroupID, res.GroupID != "", "artifactID "+artifactID)
+}
+
+// get sends the query and decodes the response. 404 is decoded as an empty response.
+func (c *RemoteClient) get(path string, v any) error {
+	req, err := http.NewRequest(http.MethodGet, c.url+path, http.NoBody)
+	if err != nil {
+		return xerrors.Errorf("new request error: %w", err)
+	}
+	for k, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(k, value)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return xerrors.Errorf("Java DB lookup error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil
+	default:
+		return xerrors.Errorf("Java DB lookup error: %s", resp.Status)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return xerrors.Errorf("json decode error: %w", err)
+	}
+	return nil
+}
+
+func found[T any](v T, ok bool, query string) (T, error) {
+	if !ok {
+		var zero T
+		return zero, xerrors.Errorf("%s: %w", query, jar.ArtifactNotFoundErr)
+	}
+	return v, nil
+}
+
+type propertiesResponse struct {
+	GroupID    string `json:"groupId"`
+	ArtifactID string `json:"artifactId"`
+	Version    string `json:"version"`
+}
+
+type existsResponse struct {
+	Exists bool `json:"exists"`
+}
+
+type groupIDResponse struct {
+	GroupID string `json:"groupId"`
+}
+
+// NewHandler returns the handler of the lookup API backed by the local Java DB.
+// The Java DB is opened on the first request since it might need to be downloaded,
+// and the initialization is retried on the next request if it fails.
+func NewHandler() http.Handler {
+	return newHandler(func() (jar.Client, error) {
+		return newDB()
+	})
+}
+
+func newHandler(open func() (jar.Client, error)) http.Handler {
+	var client jar.Client
+	var mu sync.Mutex
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		if client == nil {
+			c, err := open()
+			if err != nil {
+				mu.Unlock()
+				log.Logger.Errorf("Unable to initialize the Java DB: %s", err)
+				http.Error(w, "Java DB is not available", http.StatusServiceUnavailable)
+				return
+			}
+			client = c
+		}
+		c := client
+		mu.Unlock()
+
+		serveLookup(c, w, r)
+	})
+}
+
+func serveLookup(client jar.Client, w http.ResponseWriter, r *http.Request) {
+	kind, query, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, LookupPathPrefix), "/")
+
+	var res any
+	var err error
+	switch kind {
+	case "sha1":
+		var props jar.Properties
+		props, err = client.SearchBySHA1(query)
+		res = propertiesResponse{
+			GroupID:    props.GroupID,
+			ArtifactID: props.ArtifactID,
+			Version:    props.Version,
+		}
+	case "artifact":
+		var groupID string
+		groupID, err = client.SearchByArtifactID(query)
+		res = groupIDResponse{GroupID: groupID}
+	case "exists":
+		groupID, artifactID, ok := strings.Cut(query, "/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		var exists bool
+		exists, err = client.Exists(groupID, artifactID)
+		res = existsResponse{Exists: exists}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case errors.Is(err, jar.ArtifactNotFoundErr):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		log.Logger.Errorf("Java DB lookup error: %s", err)
+		http.Error(w, "Java DB lookup error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(res); err != nil {
+		log.Logger.Errorf("json encode error: %s", err)
+	}
+}
@@ -0,0 +1,120 @@
+package javadb
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-dep-parser/pkg/java/jar"
+)
+
+type fakeClient struct{}
+
+func (fakeClient) Exists(groupID, artifactID string) (bool, error) {
+	return groupID == "org.example" && artifactID == "example-api", nil
+}
+
+func (fakeClient) SearchBySHA1(sha1 string) (jar.Properties, error) {
+	if sha1 != "2f0eb6a0bc2a2a3a5b8f0e4e36f5b7b0e2c1f0a1" {
+		return jar.Properties{}, jar.ArtifactNotFoundErr
+	}
+	return jar.Properties{
+		GroupID:    "org.example",
+		ArtifactID: "example-api",
+		Version:    "1.2.3",
+	}, nil
+}
+
+func (fakeClient) SearchByArtifactID(artifactID string) (string, error) {
+	if artifactID != "example-api" {
+		return "", jar.ArtifactNotFoundErr
+	}
+	return "org.example", nil
+}
+
+func TestRemoteClient(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "secret", r.Header.Get("Trivy-Token"))
+		serveLookup(fakeClient{}, w, r)
+	}))
+	defer ts.Close()
+
+	c, err := NewRemoteClient(RemoteOptions{
+		URL:     ts.URL,
+		Headers: http.Header{"Trivy-Token": []string{"secret"}},
+	})
+	require.NoError(t, err)
+
+	// Every query is sent only once thanks to the cache
+	for i := 0; i < 2; i++ {
+		props, err := c.SearchBySHA1("2f0eb6a0bc2a2a3a5b8f0e4e36f5b7b0e2c1f0a1")
+		require.NoError(t, err)
+		assert.Equal(t, jar.Properties{
+			GroupID:    "org.example",
+			ArtifactID: "example-api",
+			Version:    "1.2.3",
+		}, props)
+
+		_, err = c.SearchBySHA1("0000000000000000000000000000000000000000")
+		assert.ErrorIs(t, err, jar.ArtifactNotFoundErr)
+
+		groupID, err := c.SearchByArtifactID("example-api")
+		require.NoError(t, err)
+		assert.Equal(t, "org.example", groupID)
+
+		_, err = c.SearchByArtifactID("unknown")
+		assert.ErrorIs(t, err, jar.ArtifactNotFoundErr)
+
+		exists, err := c.Exists("org.example", "example-api")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = c.Exists("org.example", "unknown")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	}
+	assert.Equal(t, 6, requests)
+}
+
+func TestRemoteClient_ServerError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Java DB is not available", http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c, err := NewRemoteClient(RemoteOptions{URL: ts.URL})
+	require.NoError(t, err)
+
+	_, err = c.SearchBySHA1("2f0eb6a0bc2a2a3a5b8f0e4e36f5b7b0e2c1f0a1")
+	assert.ErrorContains(t, err, "503 Service Unavailable")
+}
+
+func TestNewRemoteClient_InvalidURL(t *testing.T) {
+	_, err := NewRemoteClient(RemoteOptions{URL: "trivy-server:4954"})
+	assert.ErrorContains(t, err, "invalid Java DB URL")
+}
+
+func TestNewHandler_Retry(t *testing.T) {
+	var opened int
+	h := newHandler(func() (jar.Client, error) {
+		opened++
+		if opened == 1 {
+			return nil, errors.New("download error")
+		}
+		return fakeClient{}, nil
+	})
+
+	wants := []int{http.StatusServiceUnavailable, http.StatusOK, http.StatusOK}
+	for _, want := range wants {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, LookupPathPrefix+"artifact/example-api", nil))
+		assert.Equal(t, want, rec.Code)
+	}
+	assert.Equal(t, 2, opened)
+}
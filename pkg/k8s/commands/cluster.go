@@ -10,6 +10,8 @@ import (
 	"github.com/aquasecurity/trivy-kubernetes/pkg/k8s"
 	"github.com/aquasecurity/trivy-kubernetes/pkg/trivyk8s"
 	"github.com/zhanglimao/trivy/pkg/flag"
+	"github.com/zhanglimao/trivy/pkg/k8s/node"
+	"github.com/zhanglimao/trivy/pkg/k8s/scanner"
 	"github.com/zhanglimao/trivy/pkg/log"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
@@ -33,6 +35,17 @@ func clusterRun(ctx context.Context, opts flag.Options, cluster k8s.Cluster) err
 		}
 	}
 
-	runner := newRunner(opts, cluster.GetCurrentContext())
+	var scannerOpts []scanner.Option
+	if opts.NodeScan {
+		collector := node.NewCollector(cluster.GetK8sClientSet(),
+			node.WithNamespace(opts.NodeCollectorNamespace),
+			node.WithImage(opts.NodeScanImage),
+			node.WithTolerations(opts.Tolerations),
+		)
+		defer collector.Cleanup(ctx)
+		scannerOpts = append(scannerOpts, scanner.WithNodeCollector(collector))
+	}
+
+	runner := newRunner(opts, cluster.GetCurrentContext(), scannerOpts...)
 	return runner.run(ctx, artifacts)
 }
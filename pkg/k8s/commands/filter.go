@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/aquasecurity/trivy-kubernetes/pkg/artifacts"
+	"github.com/zhanglimao/trivy/pkg/flag"
+)
+
+// filterArtifacts drops the artifacts that don't match the namespace and label selectors.
+// Cluster-scoped resources are kept unless '--include-namespaces' is set.
+func filterArtifacts(arts []*artifacts.Artifact, opts flag.K8sOptions) ([]*artifacts.Artifact, error) {
+	if len(opts.IncludeNamespaces) == 0 && len(opts.ExcludeNamespaces) == 0 && opts.LabelSelector == "" {
+		return arts, nil
+	}
+
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid label selector %q: %w", opts.LabelSelector, err)
+	}
+
+	var filtered []*artifacts.Artifact
+	for _, artifact := range arts {
+		if len(opts.IncludeNamespaces) > 0 && !slices.Contains(opts.IncludeNamespaces, artifact.Namespace) {
+			continue
+		}
+		if artifact.Namespace != "" && slices.Contains(opts.ExcludeNamespaces, artifact.Namespace) {
+			continue
+		}
+		if !selector.Matches(labels.Set(artifactLabels(artifact))) {
+			continue
+		}
+		filtered = append(filtered, artifact)
+	}
+	return filtered, nil
+}
+
+// artifactLabels returns the labels of the resource.
+// trivy-kubernetes fills Labels only for nodes, so they are read from the raw resource otherwise.
+func artifactLabels(artifact *artifacts.Artifact) map[string]string {
+	if len(artifact.Labels) > 0 || artifact.RawResource == nil {
+		return artifact.Labels
+	}
+	return (&unstructured.Unstructured{Object: artifact.RawResource}).GetLabels()
+}
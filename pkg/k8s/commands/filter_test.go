@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy-kubernetes/pkg/artifacts"
+	"github.com/zhanglimao/trivy/pkg/flag"
+)
+
+func Test_filterArtifacts(t *testing.T) {
+	withLabels := func(namespace, kind, name string, labels map[string]interface{}) *artifacts.Artifact {
+		return &artifacts.Artifact{
+			Namespace: namespace,
+			Kind:      kind,
+			Name:      name,
+			RawResource: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name":   name,
+					"labels": labels,
+				},
+			},
+		}
+	}
+	arts := []*artifacts.Artifact{
+		withLabels("default", "Deployment", "nginx", map[string]interface{}{"app": "nginx", "tier": "frontend"}),
+		withLabels("app", "Deployment", "api", map[string]interface{}{"app": "api", "tier": "backend"}),
+		withLabels("kube-system", "DaemonSet", "kube-proxy", map[string]interface{}{"k8s-app": "kube-proxy"}),
+		withLabels("", "ClusterRole", "admin", nil),
+		{
+			Kind:   "Node",
+			Name:   "worker",
+			Labels: map[string]string{"kubernetes.io/os": "linux"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		opts    flag.K8sOptions
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "no filters",
+			want: []string{"nginx", "api", "kube-proxy", "admin", "worker"},
+		},
+		{
+			name: "include namespaces",
+			opts: flag.K8sOptions{
+				IncludeNamespaces: []string{"default", "app"},
+			},
+			want: []string{"nginx", "api"},
+		},
+		{
+			name: "exclude namespaces",
+			opts: flag.K8sOptions{
+				ExcludeNamespaces: []string{"kube-system"},
+			},
+			want: []string{"nginx", "api", "admin", "worker"},
+		},
+		{
+			name: "label selector",
+			opts: flag.K8sOptions{
+				LabelSelector: "tier in (frontend,backend),app!=api",
+			},
+			want: []string{"nginx"},
+		},
+		{
+			name: "node labels",
+			opts: flag.K8sOptions{
+				LabelSelector: "kubernetes.io/os=linux",
+			},
+			want: []string{"worker"},
+		},
+		{
+			name: "invalid selector",
+			opts: flag.K8sOptions{
+				LabelSelector: "app=(",
+			},
+			wantErr: "invalid label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterArtifacts(arts, tt.opts)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, artifact := range got {
+				names = append(names, artifact.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
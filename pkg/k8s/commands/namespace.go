@@ -17,7 +17,8 @@ func namespaceRun(ctx context.Context, opts flag.Options, cluster k8s.Cluster) e
 		return err
 	}
 	var trivyk trivyk8s.TrivyK8S
-	if opts.AllNamespaces {
+	// '--include-namespaces' may span several namespaces, so resources are listed cluster-wide and filtered afterwards
+	if opts.AllNamespaces || len(opts.IncludeNamespaces) > 0 {
 		trivyk = trivyk8s.New(cluster, log.Logger).AllNamespaces()
 	} else {
 		trivyk = trivyk8s.New(cluster, log.Logger).Namespace(getNamespace(opts, cluster.GetCurrentNamespace()))
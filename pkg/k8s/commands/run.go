@@ -42,6 +42,10 @@ func Run(ctx context.Context, args []string, opts flag.Options) error {
 		}
 	}()
 
+	if err = cmd.LoadRemoteCompliance(ctx, &opts); err != nil {
+		return xerrors.Errorf("compliance spec error: %w", err)
+	}
+
 	switch args[0] {
 	case clusterArtifact:
 		return clusterRun(ctx, opts, cluster)
@@ -53,18 +57,25 @@ func Run(ctx context.Context, args []string, opts flag.Options) error {
 }
 
 type runner struct {
-	flagOpts flag.Options
-	cluster  string
+	flagOpts    flag.Options
+	cluster     string
+	scannerOpts []scanner.Option
 }
 
-func newRunner(flagOpts flag.Options, cluster string) *runner {
+func newRunner(flagOpts flag.Options, cluster string, scannerOpts ...scanner.Option) *runner {
 	return &runner{
 		flagOpts,
 		cluster,
+		scannerOpts,
 	}
 }
 
 func (r *runner) run(ctx context.Context, artifacts []*artifacts.Artifact) error {
+	artifacts, err := filterArtifacts(artifacts, r.flagOpts.K8sOptions)
+	if err != nil {
+		return xerrors.Errorf("filter error: %w", err)
+	}
+
 	runner, err := cmd.NewRunner(ctx, r.flagOpts)
 	if err != nil {
 		if errors.Is(err, cmd.SkipScan) {
@@ -78,7 +89,7 @@ func (r *runner) run(ctx context.Context, artifacts []*artifacts.Artifact) error
 		}
 	}()
 
-	s := scanner.NewScanner(r.cluster, runner, r.flagOpts)
+	s := scanner.NewScanner(r.cluster, runner, r.flagOpts, r.scannerOpts...)
 
 	// set scanners types by spec
 	if r.flagOpts.Compliance.Spec.ID != "" {
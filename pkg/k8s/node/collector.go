@@ -0,0 +1,290 @@
+package node
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/aquasecurity/trivy-kubernetes/pkg/jobs"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+const (
+	containerName = "node-scan"
+	hostRoot      = "/host"
+
+	defaultImage   = "busybox:1.36"
+	defaultTimeout = 5 * time.Minute
+)
+
+// packageFiles are the files, relative to the host root, needed by the OS and package analyzers
+var packageFiles = []string{
+	"etc/os-release",
+	"usr/lib/os-release",
+	"etc/lsb-release",
+	"etc/alpine-release",
+	"etc/debian_version",
+	"etc/redhat-release",
+	"etc/system-release",
+	"etc/centos-release",
+	"etc/SuSE-release",
+	"lib/apk/db/installed",
+	"var/lib/dpkg/status",
+	"var/lib/dpkg/status.d",
+	"var/lib/rpm",
+	"usr/lib/sysimage/rpm",
+}
+
+// Collector runs short-lived jobs on cluster nodes to copy their OS package databases
+type Collector struct {
+	clientset   kubernetes.Interface
+	namespace   string
+	image       string
+	tolerations []corev1.Toleration
+	timeout     time.Duration
+
+	// createdNamespace is set when the namespace didn't exist and has to be removed on cleanup
+	createdNamespace bool
+}
+
+type Option func(*Collector)
+
+func WithNamespace(namespace string) Option {
+	return func(c *Collector) {
+		c.namespace = namespace
+	}
+}
+
+func WithImage(image string) Option {
+	return func(c *Collector) {
+		if image != "" {
+			c.image = image
+		}
+	}
+}
+
+func WithTolerations(tolerations []corev1.Toleration) Option {
+	return func(c *Collector) {
+		c.tolerations = tolerations
+	}
+}
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Collector) {
+		c.timeout = timeout
+	}
+}
+
+func NewCollector(clientset kubernetes.Interface, opts ...Option) *Collector {
+	c := &Collector{
+		clientset: clientset,
+		namespace: "trivy-temp",
+		image:     defaultImage,
+		timeout:   defaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Collect runs a privileged job on the node and extracts the package databases of the node into dir
+func (c *Collector) Collect(ctx context.Context, nodeName, dir string) error {
+	if err := c.ensureNamespace(ctx); err != nil {
+		return xerrors.Errorf("namespace error: %w", err)
+	}
+
+	job := c.job(nodeName)
+	if err := jobs.New(jobs.WithTimeout(c.timeout)).Run(ctx, jobs.NewRunnableJob(c.clientset, job)); err != nil {
+		return xerrors.Errorf("node scan job error: %w", err)
+	}
+	defer func() {
+		background := metav1.DeletePropagationBackground
+		if err := c.clientset.BatchV1().Jobs(job.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{
+			PropagationPolicy: &background,
+		}); err != nil {
+			log.Logger.Debugf("Failed to delete the node scan job %s: %s", job.Name, err)
+		}
+	}()
+
+	logs, err := jobs.NewLogsReader(c.clientset).GetLogsByJobAndContainerName(ctx, job, containerName)
+	if err != nil {
+		return xerrors.Errorf("unable to read the node scan job logs: %w", err)
+	}
+	defer logs.Close()
+
+	if err = extract(logs, dir); err != nil {
+		return xerrors.Errorf("unable to extract the node files: %w", err)
+	}
+	return nil
+}
+
+// Cleanup removes the namespace of the jobs if it was created by the collector
+func (c *Collector) Cleanup(ctx context.Context) {
+	if !c.createdNamespace {
+		return
+	}
+	background := metav1.DeletePropagationBackground
+	if err := c.clientset.CoreV1().Namespaces().Delete(ctx, c.namespace, metav1.DeleteOptions{
+		PropagationPolicy: &background,
+	}); err != nil {
+		log.Logger.Debugf("Failed to delete the namespace %s: %s", c.namespace, err)
+	}
+	c.createdNamespace = false
+}
+
+func (c *Collector) ensureNamespace(ctx context.Context) error {
+	_, err := c.clientset.CoreV1().Namespaces().Get(ctx, c.namespace, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	} else if !k8sapierror.IsNotFound(err) {
+		return err
+	}
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: c.namespace}}
+	_, err = c.clientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+	if err != nil && !k8sapierror.IsAlreadyExists(err) {
+		return err
+	}
+	c.createdNamespace = err == nil
+	return nil
+}
+
+// job returns a job pinned to the node, which mounts the host root file system read-only
+// and prints the package databases as a base64-encoded tar.gz archive.
+func (c *Collector) job(nodeName string) *batchv1.Job {
+	labels := map[string]string{
+		jobs.TrivyCollectorName: containerName,
+		jobs.TrivyAutoCreated:   "true",
+		jobs.TrivyResourceName:  nodeName,
+		jobs.TrivyResourceKind:  "Node",
+	}
+	hostPathType := corev1.HostPathDirectory
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", containerName, jobs.ComputeHash(nodeName)),
+			Namespace: c.namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          new(int32),
+			ActiveDeadlineSeconds: jobs.GetActiveDeadlineSeconds(c.timeout),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					NodeName:                     nodeName,
+					RestartPolicy:                corev1.RestartPolicyNever,
+					AutomountServiceAccountToken: new(bool),
+					Tolerations:                  c.tolerations,
+					Containers: []corev1.Container{
+						{
+							Name:    containerName,
+							Image:   c.image,
+							Command: []string{"/bin/sh", "-c", script()},
+							SecurityContext: &corev1.SecurityContext{
+								Privileged:             lo.ToPtr(true),
+								RunAsUser:              new(int64),
+								ReadOnlyRootFilesystem: lo.ToPtr(true),
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "host-root",
+									MountPath: hostRoot,
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "host-root",
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{
+									Path: "/",
+									Type: &hostPathType,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// script archives the package files existing on the node and writes them to stdout
+func script() string {
+	return fmt.Sprintf(`cd %s && files="" && for f in %s; do [ -e "$f" ] && files="$files $f"; done; tar -czf - $files | base64`,
+		hostRoot, strings.Join(packageFiles, " "))
+}
+
+// extract decodes the job output and writes the archived regular files under dir
+func extract(r io.Reader, dir string) error {
+	gr, err := gzip.NewReader(base64.NewDecoder(base64.StdEncoding, r))
+	if err != nil {
+		return xerrors.Errorf("gzip error: %w", err)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return xerrors.Errorf("tar error: %w", err)
+		}
+
+		name := filepath.Clean(filepath.FromSlash(hdr.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return xerrors.Errorf("invalid file path: %s", hdr.Name)
+		}
+		path := filepath.Join(dir, name)
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(path, 0o700); err != nil {
+				return xerrors.Errorf("mkdir error: %w", err)
+			}
+		case tar.TypeReg:
+			if err = writeFile(path, tr); err != nil {
+				return xerrors.Errorf("unable to write %s: %w", hdr.Name, err)
+			}
+		}
+	}
+}
+
+func writeFile(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err = io.Copy(f, r); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package node
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type file struct {
+	name     string
+	typeflag byte
+	content  string
+}
+
+func archive(t *testing.T, files []file) *bytes.Buffer {
+	var buf bytes.Buffer
+	w := base64.NewEncoder(base64.StdEncoding, &buf)
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	for _, f := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     f.name,
+			Typeflag: f.typeflag,
+			Mode:     0o644,
+			Size:     int64(len(f.content)),
+		}))
+		_, err := tw.Write([]byte(f.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	require.NoError(t, w.Close())
+	return &buf
+}
+
+func Test_extract(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []file
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "package databases",
+			files: []file{
+				{
+					name:     "etc/",
+					typeflag: tar.TypeDir,
+				},
+				{
+					name:     "etc/os-release",
+					typeflag: tar.TypeReg,
+					content:  "ID=ubuntu\nVERSION_ID=\"22.04\"\n",
+				},
+				{
+					name:     "var/lib/dpkg/status",
+					typeflag: tar.TypeReg,
+					content:  "Package: bash\n",
+				},
+				{
+					name:     "etc/os-release.link",
+					typeflag: tar.TypeSymlink,
+				},
+			},
+			want: map[string]string{
+				"etc/os-release":      "ID=ubuntu\nVERSION_ID=\"22.04\"\n",
+				"var/lib/dpkg/status": "Package: bash\n",
+			},
+		},
+		{
+			name: "path traversal",
+			files: []file{
+				{
+					name:     "../etc/passwd",
+					typeflag: tar.TypeReg,
+					content:  "root",
+				},
+			},
+			wantErr: "invalid file path",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			err := extract(archive(t, tt.files), dir)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			for name, content := range tt.want {
+				got, err := os.ReadFile(filepath.Join(dir, name))
+				require.NoError(t, err)
+				assert.Equal(t, content, string(got))
+			}
+			assert.NoFileExists(t, filepath.Join(dir, "etc", "os-release.link"))
+		})
+	}
+}
+
+func TestCollector_job(t *testing.T) {
+	c := NewCollector(fake.NewSimpleClientset(), WithNamespace("trivy-temp"), WithImage("registry.local/busybox:1.36"),
+		WithTolerations([]corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}))
+
+	job := c.job("worker-1")
+	assert.Equal(t, "trivy-temp", job.Namespace)
+	assert.Equal(t, "worker-1", job.Labels["trivy.resource.name"])
+
+	spec := job.Spec.Template.Spec
+	assert.Equal(t, "worker-1", spec.NodeName)
+	assert.Equal(t, corev1.RestartPolicyNever, spec.RestartPolicy)
+	assert.Len(t, spec.Tolerations, 1)
+	require.Len(t, spec.Containers, 1)
+	assert.Equal(t, "registry.local/busybox:1.36", spec.Containers[0].Image)
+	assert.Contains(t, spec.Containers[0].Command[2], "var/lib/dpkg/status")
+	assert.True(t, spec.Containers[0].VolumeMounts[0].ReadOnly)
+	assert.Equal(t, "/", spec.Volumes[0].HostPath.Path)
+}
+
+func TestCollector_Cleanup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("created namespace", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+		c := NewCollector(clientset, WithNamespace("trivy-temp"))
+		require.NoError(t, c.ensureNamespace(ctx))
+
+		_, err := clientset.CoreV1().Namespaces().Get(ctx, "trivy-temp", metav1.GetOptions{})
+		require.NoError(t, err)
+
+		c.Cleanup(ctx)
+		_, err = clientset.CoreV1().Namespaces().Get(ctx, "trivy-temp", metav1.GetOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("existing namespace", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "trivy-temp"}})
+		c := NewCollector(clientset, WithNamespace("trivy-temp"))
+		require.NoError(t, c.ensureNamespace(ctx))
+
+		c.Cleanup(ctx)
+		_, err := clientset.CoreV1().Namespaces().Get(ctx, "trivy-temp", metav1.GetOptions{})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,92 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// Decode decodes the JSON report written with either '--report all' or '--report summary'
+func Decode(b []byte) (Report, error) {
+	var r struct {
+		Report
+		Findings []Resource // The consolidated report
+	}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return Report{}, xerrors.Errorf("json decode error: %w", err)
+	}
+	if r.ClusterName == "" {
+		return Report{}, xerrors.New("'ClusterName' is missing, the file is not a Kubernetes scanning report")
+	}
+
+	report := r.Report
+	report.Resources = append(report.Resources, r.Findings...)
+	return report, nil
+}
+
+// ToReport flattens the resources into a regular report so that it can be written in the formats of regular reports.
+// The resource is prepended to the target of each result, e.g. "default/Deployment/app: nginx:1.24 (debian 11.7)".
+func (r Report) ToReport() types.Report {
+	var results types.Results
+	for _, resource := range r.Resources {
+		kindName := fmt.Sprintf("%s/%s", resource.Kind, resource.Name)
+		name := kindName
+		if resource.Namespace != "" {
+			name = fmt.Sprintf("%s/%s", resource.Namespace, kindName)
+		}
+		for _, result := range resource.Results {
+			switch result.Target {
+			case kindName, name:
+				// Misconfigurations of the resource itself
+				result.Target = name
+			default:
+				result.Target = fmt.Sprintf("%s: %s", name, result.Target)
+			}
+			results = append(results, result)
+		}
+	}
+	return types.Report{
+		SchemaVersion: r.SchemaVersion,
+		ArtifactName:  r.ClusterName,
+		ArtifactType:  ftypes.ArtifactKubernetesCluster,
+		Results:       results,
+	}
+}
+
+// Components lists all the components so that the table shows both workload and infra resources
+var Components = []string{
+	workloadComponent,
+	infraComponent,
+}
+
+// Scanners returns the scanners which produced the findings in the report
+func (r Report) Scanners() types.Scanners {
+	var scanners types.Scanners
+	add := func(s types.Scanner) {
+		if !scanners.Enabled(s) {
+			scanners = append(scanners, s)
+		}
+	}
+	for _, resource := range r.Resources {
+		for _, result := range resource.Results {
+			if len(result.Vulnerabilities) > 0 {
+				add(types.VulnerabilityScanner)
+			}
+			if len(result.Secrets) > 0 {
+				add(types.SecretScanner)
+			}
+			if len(result.Misconfigurations) > 0 {
+				if rbacResource(resource) {
+					add(types.RBACScanner)
+				} else {
+					add(types.MisconfigScanner)
+				}
+			}
+		}
+	}
+	return scanners
+}
@@ -0,0 +1,180 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Report
+		wantErr string
+	}{
+		{
+			name: "all",
+			input: `{"SchemaVersion": 2, "ClusterName": "test", "Resources": [
+				{"Namespace": "default", "Kind": "Deployment", "Name": "orion"}
+			]}`,
+			want: Report{
+				SchemaVersion: 2,
+				ClusterName:   "test",
+				Resources: []Resource{
+					{
+						Namespace: "default",
+						Kind:      "Deployment",
+						Name:      "orion",
+					},
+				},
+			},
+		},
+		{
+			name: "summary",
+			input: `{"ClusterName": "test", "Findings": [
+				{"Namespace": "default", "Kind": "Deployment", "Name": "orion"}
+			]}`,
+			want: Report{
+				ClusterName: "test",
+				Resources: []Resource{
+					{
+						Namespace: "default",
+						Kind:      "Deployment",
+						Name:      "orion",
+					},
+				},
+			},
+		},
+		{
+			name:    "no cluster name",
+			input:   `{"SchemaVersion": 2, "ArtifactName": "alpine:3.18"}`,
+			wantErr: "'ClusterName' is missing",
+		},
+		{
+			name:    "broken",
+			input:   `{"ClusterName": `,
+			wantErr: "json decode error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode([]byte(tt.input))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReport_ToReport(t *testing.T) {
+	r := Report{
+		SchemaVersion: 2,
+		ClusterName:   "test",
+		Resources: []Resource{
+			{
+				Namespace: "default",
+				Kind:      "Deployment",
+				Name:      "orion",
+				Results: types.Results{
+					{
+						Target: "nginx:1.24 (debian 11.7)",
+						Class:  types.ClassOSPkg,
+					},
+					{
+						Target: "Deployment/orion",
+						Class:  types.ClassConfig,
+					},
+				},
+			},
+			{
+				Kind: "ClusterRole",
+				Name: "admin",
+				Results: types.Results{
+					{
+						Target: "ClusterRole/admin",
+						Class:  types.ClassConfig,
+					},
+				},
+			},
+		},
+	}
+
+	want := types.Report{
+		SchemaVersion: 2,
+		ArtifactName:  "test",
+		ArtifactType:  ftypes.ArtifactKubernetesCluster,
+		Results: types.Results{
+			{
+				Target: "default/Deployment/orion: nginx:1.24 (debian 11.7)",
+				Class:  types.ClassOSPkg,
+			},
+			{
+				Target: "default/Deployment/orion",
+				Class:  types.ClassConfig,
+			},
+			{
+				Target: "ClusterRole/admin",
+				Class:  types.ClassConfig,
+			},
+		},
+	}
+	assert.Equal(t, want, r.ToReport())
+}
+
+func TestReport_Scanners(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []Resource
+		want      types.Scanners
+	}{
+		{
+			name: "vulnerabilities and misconfigurations",
+			resources: []Resource{
+				deployOrionWithBothVulnsAndMisconfigs,
+			},
+			want: types.Scanners{
+				types.MisconfigScanner,
+				types.VulnerabilityScanner,
+			},
+		},
+		{
+			name: "rbac and secrets",
+			resources: []Resource{
+				roleWithMisconfig,
+				deployLuaWithSecrets,
+			},
+			want: types.Scanners{
+				types.RBACScanner,
+				types.SecretScanner,
+			},
+		},
+		{
+			name: "no findings",
+			resources: []Resource{
+				{
+					Namespace: "default",
+					Kind:      "Deploy",
+					Name:      "orion",
+				},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Report{
+				ClusterName: "test",
+				Resources:   tt.resources,
+			}
+			assert.Equal(t, tt.want, r.Scanners())
+		})
+	}
+}
@@ -3,11 +3,13 @@ package report
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	"github.com/aquasecurity/trivy-kubernetes/pkg/artifacts"
@@ -50,7 +52,17 @@ type Report struct {
 type ConsolidatedReport struct {
 	SchemaVersion int `json:",omitempty"`
 	ClusterName   string
-	Findings      []Resource `json:",omitempty"`
+	Findings      []Resource         `json:",omitempty"`
+	Namespaces    []NamespaceSummary `json:",omitempty"`
+}
+
+// NamespaceSummary represents the number of findings per severity in a namespace
+type NamespaceSummary struct {
+	Namespace         string
+	Resources         int
+	Vulnerabilities   map[string]int `json:",omitempty"`
+	Misconfigurations map[string]int `json:",omitempty"`
+	Secrets           map[string]int `json:",omitempty"`
 }
 
 // Resource represents a kubernetes resource report
@@ -63,10 +75,21 @@ type Resource struct {
 	Results types.Results `json:",omitempty"`
 	Error   string        `json:",omitempty"`
 
+	// NodeInfo is only set for nodes scanned with '--node-scan'
+	NodeInfo *NodeInfo `json:",omitempty"`
+
 	// original report
 	Report types.Report `json:"-"`
 }
 
+// NodeInfo represents the versions reported by a node
+type NodeInfo struct {
+	OSImage                 string `json:",omitempty"`
+	KernelVersion           string `json:",omitempty"`
+	KubeletVersion          string `json:",omitempty"`
+	ContainerRuntimeVersion string `json:",omitempty"`
+}
+
 func (r Resource) fullname() string {
 	return strings.ToLower(fmt.Sprintf("%s/%s/%s", r.Namespace, r.Kind, r.Name))
 }
@@ -107,6 +130,7 @@ func (r Report) consolidate() ConsolidatedReport {
 				Name:      res.Name,
 				Results:   append(res.Results, v.Results...),
 				Error:     res.Error,
+				NodeInfo:  v.NodeInfo,
 			}
 
 			continue
@@ -116,10 +140,53 @@ func (r Report) consolidate() ConsolidatedReport {
 	}
 
 	consolidated.Findings = maps.Values(index)
+	consolidated.Namespaces = summarizeNamespaces(consolidated.Findings)
 
 	return consolidated
 }
 
+// summarizeNamespaces aggregates the findings of namespaced resources per namespace.
+// Cluster-scoped resources are not part of any namespace and are left out.
+func summarizeNamespaces(findings []Resource) []NamespaceSummary {
+	index := make(map[string]*NamespaceSummary)
+	for _, finding := range findings {
+		if finding.Namespace == "" {
+			continue
+		}
+		summary, ok := index[finding.Namespace]
+		if !ok {
+			summary = &NamespaceSummary{
+				Namespace:         finding.Namespace,
+				Vulnerabilities:   make(map[string]int),
+				Misconfigurations: make(map[string]int),
+				Secrets:           make(map[string]int),
+			}
+			index[finding.Namespace] = summary
+		}
+		summary.Resources++
+
+		vCount, mCount, sCount := accumulateSeverityCounts(finding)
+		for sev, count := range vCount {
+			summary.Vulnerabilities[sev] += count
+		}
+		for sev, count := range mCount {
+			summary.Misconfigurations[sev] += count
+		}
+		for sev, count := range sCount {
+			summary.Secrets[sev] += count
+		}
+	}
+
+	summaries := make([]NamespaceSummary, 0, len(index))
+	for _, summary := range index {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Namespace < summaries[j].Namespace
+	})
+	return summaries
+}
+
 // Writer defines the result write operation
 type Writer interface {
 	Write(Report) error
@@ -157,6 +224,16 @@ func Write(report Report, option Option) error {
 			}
 		}
 
+		// the per-namespace breakdown only adds information when several namespaces were scanned
+		if option.Report == summaryReport {
+			if namespaces := report.consolidate().Namespaces; len(namespaces) > 1 {
+				writer := NewSummaryWriter(option.Output, option.Severities, NamespaceColumnHeading(option.Scanners))
+				if err := writer.WriteNamespaces(namespaces); err != nil {
+					return err
+				}
+			}
+		}
+
 		return nil
 	default:
 		return xerrors.Errorf(`unknown format %q. Use "json" or "table"`, option.Format)
@@ -293,6 +370,20 @@ func CreateResource(artifact *artifacts.Artifact, report types.Report, err error
 	return r
 }
 
+// CreateNodeResource creates a resource for the scan of a node file system, along with the node versions
+func CreateNodeResource(node *artifacts.Artifact, report types.Report, err error) Resource {
+	r := CreateResource(node, report, err)
+
+	info, _, _ := unstructured.NestedStringMap(node.RawResource, "status", "nodeInfo")
+	r.NodeInfo = &NodeInfo{
+		OSImage:                 info["osImage"],
+		KernelVersion:           info["kernelVersion"],
+		KubeletVersion:          info["kubeletVersion"],
+		ContainerRuntimeVersion: info["containerRuntimeVersion"],
+	}
+	return r
+}
+
 func (r Report) printErrors() {
 	for _, resource := range r.Resources {
 		if resource.Error != "" {
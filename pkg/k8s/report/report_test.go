@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy-kubernetes/pkg/artifacts"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
@@ -341,6 +342,44 @@ func TestReport_consolidate(t *testing.T) {
 	}
 }
 
+func Test_summarizeNamespaces(t *testing.T) {
+	findings := []Resource{
+		deployOrionWithBothVulnsAndMisconfigs,
+		cronjobHelloWithVulns,
+		apiseverPodWithMisconfigAndInfra,
+		{
+			Kind: "ClusterRole",
+			Name: "admin",
+		},
+	}
+
+	got := summarizeNamespaces(findings)
+	assert.Equal(t, []NamespaceSummary{
+		{
+			Namespace: "default",
+			Resources: 2,
+			Vulnerabilities: map[string]int{
+				"CRITICAL": 2,
+				"HIGH":     1,
+				"MEDIUM":   2,
+				"LOW":      1,
+				"UNKNOWN":  1,
+				"":         1,
+			},
+			Misconfigurations: map[string]int{
+				"CRITICAL": 1,
+				"HIGH":     2,
+				"MEDIUM":   1,
+				"LOW":      2,
+				"UNKNOWN":  1,
+			},
+			Secrets: map[string]int{},
+		},
+	}, got[:1])
+	assert.Len(t, got, 2)
+	assert.Equal(t, "kube-system", got[1].Namespace)
+}
+
 func TestResource_fullname(t *testing.T) {
 	tests := []struct {
 		expected string
@@ -371,6 +410,36 @@ func TestResource_fullname(t *testing.T) {
 	}
 }
 
+func TestCreateNodeResource(t *testing.T) {
+	node := &artifacts.Artifact{
+		Kind: "Node",
+		Name: "worker-1",
+		RawResource: map[string]interface{}{
+			"status": map[string]interface{}{
+				"nodeInfo": map[string]interface{}{
+					"osImage":                 "Ubuntu 22.04.2 LTS",
+					"kernelVersion":           "5.15.0-1034-azure",
+					"kubeletVersion":          "v1.26.3",
+					"containerRuntimeVersion": "containerd://1.6.20",
+				},
+			},
+		},
+	}
+
+	got := CreateNodeResource(node, types.Report{}, nil)
+	assert.Equal(t, Resource{
+		Kind:    "Node",
+		Name:    "worker-1",
+		Results: types.Results{},
+		NodeInfo: &NodeInfo{
+			OSImage:                 "Ubuntu 22.04.2 LTS",
+			KernelVersion:           "5.15.0-1034-azure",
+			KubeletVersion:          "v1.26.3",
+			ContainerRuntimeVersion: "containerd://1.6.20",
+		},
+	}, got)
+}
+
 func TestResourceFailed(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -769,6 +838,46 @@ Infra Assessment
 └─────────────┴────────────────────┴─────┴─────┴─────┴─────┴─────┘
 Severities: C=CRITICAL H=HIGH M=MEDIUM L=LOW U=UNKNOWN`,
 		},
+		{
+			name: "several namespaces, vuln and config",
+			report: Report{
+				ClusterName: "test",
+				Resources: []Resource{
+					deployOrionWithVulns,
+					apiseverPodWithMisconfigAndInfra,
+				},
+			},
+			scanners: types.Scanners{
+				types.VulnerabilityScanner,
+				types.MisconfigScanner,
+			},
+			components: []string{workloadComponent},
+			severities: allSeverities,
+			expectedOutput: `Summary Report for test
+=======================
+
+Workload Assessment
+┌─────────────┬────────────────────┬───────────────────┬───────────────────┐
+│  Namespace  │      Resource      │  Vulnerabilities  │ Misconfigurations │
+│             │                    ├───┬───┬───┬───┬───┼───┬───┬───┬───┬───┤
+│             │                    │ C │ H │ M │ L │ U │ C │ H │ M │ L │ U │
+├─────────────┼────────────────────┼───┼───┼───┼───┼───┼───┼───┼───┼───┼───┤
+│ kube-system │ Pod/kube-apiserver │   │   │   │   │   │   │ 1 │ 1 │ 1 │   │
+│ default     │ Deploy/orion       │ 2 │ 1 │ 2 │ 1 │ 1 │   │   │   │   │   │
+└─────────────┴────────────────────┴───┴───┴───┴───┴───┴───┴───┴───┴───┴───┘
+Severities: C=CRITICAL H=HIGH M=MEDIUM L=LOW U=UNKNOWN
+
+
+Namespace Summary
+┌─────────────┬───────────┬───────────────────┬───────────────────┐
+│  Namespace  │ Resources │  Vulnerabilities  │ Misconfigurations │
+│             │           ├───┬───┬───┬───┬───┼───┬───┬───┬───┬───┤
+│             │           │ C │ H │ M │ L │ U │ C │ H │ M │ L │ U │
+├─────────────┼───────────┼───┼───┼───┼───┼───┼───┼───┼───┼───┼───┤
+│ default     │ 1         │ 2 │ 1 │ 2 │ 1 │ 1 │   │   │   │   │   │
+│ kube-system │ 1         │   │   │   │   │   │   │ 1 │ 2 │ 2 │   │
+└─────────────┴───────────┴───┴───┴───┴───┴───┴───┴───┴───┴───┴───┘`,
+		},
 	}
 
 	for _, tc := range tests {
@@ -67,6 +67,65 @@ func ColumnHeading(scanners types.Scanners, components, availableColumns []strin
 	return columns
 }
 
+// NamespaceColumnHeading returns the columns of the per-namespace summary
+func NamespaceColumnHeading(scanners types.Scanners) []string {
+	columns := []string{
+		NamespaceColumn,
+		ResourcesColumn,
+	}
+	if scanners.Enabled(types.VulnerabilityScanner) {
+		columns = append(columns, VulnerabilitiesColumn)
+	}
+	if scanners.AnyEnabled(types.MisconfigScanner, types.RBACScanner) {
+		columns = append(columns, MisconfigurationsColumn)
+	}
+	if scanners.Enabled(types.SecretScanner) {
+		columns = append(columns, SecretsColumn)
+	}
+	return columns
+}
+
+// WriteNamespaces writes the findings aggregated per namespace in a summarized table format
+func (s SummaryWriter) WriteNamespaces(summaries []NamespaceSummary) error {
+	// no report column to print
+	if len(s.ColumnsHeading) == 2 {
+		return nil
+	}
+
+	if _, err := fmt.Fprintln(s.Output); err != nil {
+		return xerrors.Errorf("failed to write namespace summary: %w", err)
+	}
+
+	if _, err := fmt.Fprintln(s.Output, "Namespace Summary"); err != nil {
+		return xerrors.Errorf("failed to write namespace summary title: %w", err)
+	}
+
+	t := table.New(s.Output)
+	t.SetRowLines(false)
+	configureHeader(s, t, s.ColumnsHeading)
+
+	for _, summary := range summaries {
+		rowParts := []string{
+			summary.Namespace,
+			strconv.Itoa(summary.Resources),
+		}
+		if slices.Contains(s.ColumnsHeading, VulnerabilitiesColumn) {
+			rowParts = append(rowParts, s.generateSummary(summary.Vulnerabilities)...)
+		}
+		if slices.Contains(s.ColumnsHeading, MisconfigurationsColumn) {
+			rowParts = append(rowParts, s.generateSummary(summary.Misconfigurations)...)
+		}
+		if slices.Contains(s.ColumnsHeading, SecretsColumn) {
+			rowParts = append(rowParts, s.generateSummary(summary.Secrets)...)
+		}
+		t.AddRow(rowParts...)
+	}
+
+	t.Render()
+	_, _ = fmt.Fprintln(s.Output)
+	return nil
+}
+
 // Write writes the results in a summarized table format
 func (s SummaryWriter) Write(report Report) error {
 	// no report column to print
@@ -20,6 +20,7 @@ type TableWriter struct {
 const (
 	NamespaceColumn         = "Namespace"
 	ResourceColumn          = "Resource"
+	ResourcesColumn         = "Resources"
 	VulnerabilitiesColumn   = "Vulnerabilities"
 	MisconfigurationsColumn = "Misconfigurations"
 	SecretsColumn           = "Secrets"
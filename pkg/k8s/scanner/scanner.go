@@ -2,6 +2,10 @@ package scanner
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
 
 	"golang.org/x/xerrors"
 
@@ -15,20 +19,41 @@ import (
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
+const nodeKind = "Node"
+
+// NodeCollector copies the OS package databases of a cluster node into a local directory
+type NodeCollector interface {
+	Collect(ctx context.Context, nodeName, dir string) error
+}
+
 type Scanner struct {
-	cluster string
-	runner  cmd.Runner
-	opts    flag.Options
+	cluster       string
+	runner        cmd.Runner
+	opts          flag.Options
+	nodeCollector NodeCollector
 }
 
-func NewScanner(cluster string, runner cmd.Runner, opts flag.Options) *Scanner {
-	return &Scanner{
-		cluster,
-		runner,
-		opts,
+type Option func(*Scanner)
+
+// WithNodeCollector enables the vulnerability scanning of the node file systems
+func WithNodeCollector(c NodeCollector) Option {
+	return func(s *Scanner) {
+		s.nodeCollector = c
 	}
 }
 
+func NewScanner(cluster string, runner cmd.Runner, opts flag.Options, options ...Option) *Scanner {
+	s := &Scanner{
+		cluster: cluster,
+		runner:  runner,
+		opts:    opts,
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
 func (s *Scanner) Scan(ctx context.Context, artifactsData []*artifacts.Artifact) (report.Report, error) {
 
 	// disable logs before scanning
@@ -47,43 +72,51 @@ func (s *Scanner) Scan(ctx context.Context, artifactsData []*artifacts.Artifact)
 			log.Fatal(xerrors.Errorf("can't enable logger error: %w", err))
 		}
 	}()
-	var resources []report.Resource
 
-	type scanResult struct {
-		vulns     []report.Resource
-		misconfig report.Resource
+	var images map[string]imageResult
+	if s.opts.Scanners.AnyEnabled(types.VulnerabilityScanner, types.SecretScanner) {
+		images, err = s.scanImages(ctx, artifactsData)
+		if err != nil {
+			return report.Report{}, xerrors.Errorf("scanning images error: %w", err)
+		}
 	}
 
-	onItem := func(ctx context.Context, artifact *artifacts.Artifact) (scanResult, error) {
-		scanResults := scanResult{}
-		if s.opts.Scanners.AnyEnabled(types.VulnerabilityScanner, types.SecretScanner) {
-			vulns, err := s.scanVulns(ctx, artifact)
-			if err != nil {
-				return scanResult{}, xerrors.Errorf("scanning vulnerabilities error: %w", err)
-			}
-			scanResults.vulns = vulns
+	var resources []report.Resource
+	for _, artifact := range artifactsData {
+		for _, image := range artifact.Images {
+			res := images[image]
+			resources = append(resources, report.CreateResource(artifact, res.report, res.err))
+		}
+	}
+
+	if s.nodeCollector != nil && s.opts.Scanners.Enabled(types.VulnerabilityScanner) {
+		nodes, err := s.scanNodes(ctx, artifactsData)
+		if err != nil {
+			return report.Report{}, xerrors.Errorf("scanning nodes error: %w", err)
 		}
-		if local.ShouldScanMisconfigOrRbac(s.opts.Scanners) {
+		resources = append(resources, nodes...)
+	}
+
+	if local.ShouldScanMisconfigOrRbac(s.opts.Scanners) {
+		onItem := func(ctx context.Context, artifact *artifacts.Artifact) (report.Resource, error) {
 			misconfig, err := s.scanMisconfigs(ctx, artifact)
 			if err != nil {
-				return scanResult{}, xerrors.Errorf("scanning misconfigurations error: %w", err)
+				return report.Resource{}, xerrors.Errorf("scanning misconfigurations error: %w", err)
 			}
-			scanResults.misconfig = misconfig
+			return misconfig, nil
 		}
-		return scanResults, nil
-	}
 
-	onResult := func(result scanResult) error {
-		resources = append(resources, result.vulns...)
-		resources = append(resources, result.misconfig)
-		return nil
-	}
+		onResult := func(misconfig report.Resource) error {
+			resources = append(resources, misconfig)
+			return nil
+		}
 
-	p := parallel.NewPipeline(s.opts.Parallel, !s.opts.Quiet, artifactsData, onItem, onResult)
-	err = p.Do(ctx)
-	if err != nil {
-		return report.Report{}, err
+		p := parallel.NewPipeline(s.opts.Parallel, !s.opts.Quiet, artifactsData, onItem, onResult)
+		if err = p.Do(ctx); err != nil {
+			return report.Report{}, err
+		}
 	}
+
 	return report.Report{
 		SchemaVersion: 0,
 		ClusterName:   s.cluster,
@@ -91,41 +124,161 @@ func (s *Scanner) Scan(ctx context.Context, artifactsData []*artifacts.Artifact)
 	}, nil
 }
 
-func (s *Scanner) scanVulns(ctx context.Context, artifact *artifacts.Artifact) ([]report.Resource, error) {
-	resources := make([]report.Resource, 0, len(artifact.Images))
+type imageResult struct {
+	image  string
+	report types.Report
+	err    error
+}
 
-	for _, image := range artifact.Images {
+// scanImages scans every distinct workload image once, at most '--parallel' images at a time,
+// so that an image shared by several workloads isn't pulled and analyzed repeatedly.
+func (s *Scanner) scanImages(ctx context.Context, artifactsData []*artifacts.Artifact) (map[string]imageResult, error) {
+	var images []string
+	seen := make(map[string]struct{})
+	for _, artifact := range artifactsData {
+		for _, image := range artifact.Images {
+			if _, ok := seen[image]; ok {
+				continue
+			}
+			seen[image] = struct{}{}
+			images = append(images, image)
+		}
+	}
 
-		s.opts.Target = image
+	onItem := func(ctx context.Context, image string) (imageResult, error) {
+		opts := s.opts
+		opts.Target = image
 
-		imageReport, err := s.runner.ScanImage(ctx, s.opts)
+		tctx, cancel := s.withTargetTimeout(ctx)
+		defer cancel()
 
+		imageReport, err := s.runner.ScanImage(tctx, opts)
 		if err != nil {
+			err = s.targetTimeoutError(ctx, err)
 			log.Logger.Warnf("failed to scan image %s: %s", image, err)
-			resources = append(resources, report.CreateResource(artifact, imageReport, err))
-			continue
+			return imageResult{image: image, report: imageReport, err: err}, nil
 		}
 
-		resource, err := s.filter(ctx, imageReport, artifact)
+		imageReport, err = s.runner.Filter(ctx, opts, imageReport)
 		if err != nil {
-			return nil, xerrors.Errorf("filter error: %w", err)
+			return imageResult{}, xerrors.Errorf("filter error: %w", err)
 		}
+		return imageResult{image: image, report: imageReport}, nil
+	}
 
+	results := make(map[string]imageResult, len(images))
+	onResult := func(res imageResult) error {
+		results[res.image] = res
+		return nil
+	}
+
+	p := parallel.NewPipeline(s.opts.Parallel, !s.opts.Quiet, images, onItem, onResult)
+	if err := p.Do(ctx); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// scanNodes collects the package databases of every node with a short-lived job
+// and scans them as a root file system, at most '--parallel' nodes at a time.
+func (s *Scanner) scanNodes(ctx context.Context, artifactsData []*artifacts.Artifact) ([]report.Resource, error) {
+	var nodes []*artifacts.Artifact
+	for _, artifact := range artifactsData {
+		if artifact.Kind == nodeKind && !excludedNode(artifact, s.opts.ExcludeNodes) {
+			nodes = append(nodes, artifact)
+		}
+	}
+
+	onItem := func(ctx context.Context, node *artifacts.Artifact) (report.Resource, error) {
+		dir, err := os.MkdirTemp("", "trivy-node-*")
+		if err != nil {
+			return report.Resource{}, xerrors.Errorf("temp dir error: %w", err)
+		}
+		defer os.RemoveAll(dir)
+
+		if err = s.nodeCollector.Collect(ctx, node.Name, dir); err != nil {
+			log.Logger.Warnf("failed to collect packages of node %s: %s", node.Name, err)
+			return report.CreateNodeResource(node, types.Report{}, err), nil
+		}
+
+		opts := s.opts
+		opts.Target = dir
+
+		tctx, cancel := s.withTargetTimeout(ctx)
+		defer cancel()
+
+		rootfsReport, err := s.runner.ScanRootfs(tctx, opts)
+		if err != nil {
+			err = s.targetTimeoutError(ctx, err)
+			log.Logger.Warnf("failed to scan node %s: %s", node.Name, err)
+			return report.CreateNodeResource(node, rootfsReport, err), nil
+		}
+
+		rootfsReport, err = s.runner.Filter(ctx, opts, rootfsReport)
+		if err != nil {
+			return report.Resource{}, xerrors.Errorf("filter error: %w", err)
+		}
+
+		// hide the temporary directory
+		rootfsReport.ArtifactName = node.Name
+		for i := range rootfsReport.Results {
+			rootfsReport.Results[i].Target = strings.Replace(rootfsReport.Results[i].Target, dir, fmt.Sprintf("%s/%s", node.Kind, node.Name), 1)
+		}
+		return report.CreateNodeResource(node, rootfsReport, nil), nil
+	}
+
+	var resources []report.Resource
+	onResult := func(resource report.Resource) error {
 		resources = append(resources, resource)
+		return nil
 	}
 
+	p := parallel.NewPipeline(s.opts.Parallel, !s.opts.Quiet, nodes, onItem, onResult)
+	if err := p.Do(ctx); err != nil {
+		return nil, err
+	}
 	return resources, nil
 }
 
+// withTargetTimeout limits the time to scan a single image or node with '--target-timeout'
+func (s *Scanner) withTargetTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.opts.TargetTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, s.opts.TargetTimeout)
+}
+
+// targetTimeoutError tells the error is caused by '--target-timeout' rather than '--timeout' given the parent context
+func (s *Scanner) targetTimeoutError(ctx context.Context, err error) error {
+	if s.opts.TargetTimeout > 0 && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		return xerrors.Errorf("timed out after %s, increase --target-timeout: %w", s.opts.TargetTimeout, err)
+	}
+	return err
+}
+
+// excludedNode returns whether the node has all the labels given by '--exclude-nodes'
+func excludedNode(node *artifacts.Artifact, excludeLabels map[string]string) bool {
+	if len(excludeLabels) == 0 {
+		return false
+	}
+	for key, val := range excludeLabels {
+		if node.Labels[key] != val {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *Scanner) scanMisconfigs(ctx context.Context, artifact *artifacts.Artifact) (report.Resource, error) {
 	configFile, err := createTempFile(artifact)
 	if err != nil {
 		return report.Resource{}, xerrors.Errorf("scan error: %w", err)
 	}
 
-	s.opts.Target = configFile
+	opts := s.opts
+	opts.Target = configFile
 
-	configReport, err := s.runner.ScanFilesystem(ctx, s.opts)
+	configReport, err := s.runner.ScanFilesystem(ctx, opts)
 	//remove config file after scanning
 	removeFile(configFile)
 	if err != nil {
@@ -0,0 +1,65 @@
+package log
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Fields correlating the logs of a scan
+const (
+	KeyScanID   = "scan_id"
+	KeyArtifact = "artifact"
+	KeyLayer    = "layer"
+)
+
+type fieldsKey struct{}
+
+type field struct {
+	key   string
+	value any
+}
+
+// ContextWith returns a context carrying the field, replacing the field of the same key if any.
+// The fields are added to the logs of WithContext in the JSON format.
+func ContextWith(ctx context.Context, key string, value any) context.Context {
+	parent := fieldsFrom(ctx)
+	fields := make([]field, 0, len(parent)+1)
+	for _, f := range parent {
+		if f.key != key {
+			fields = append(fields, f)
+		}
+	}
+	fields = append(fields, field{key: key, value: value})
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// ScanID returns the scan ID attached to the context, or an empty string
+func ScanID(ctx context.Context) string {
+	for _, f := range fieldsFrom(ctx) {
+		if id, ok := f.value.(string); ok && f.key == KeyScanID {
+			return id
+		}
+	}
+	return ""
+}
+
+// WithContext returns the logger with the fields attached to the context.
+// The fields are omitted in the text format to keep the output readable.
+func WithContext(ctx context.Context) *zap.SugaredLogger {
+	fields := fieldsFrom(ctx)
+	if format != FormatJSON || len(fields) == 0 {
+		return Logger
+	}
+
+	args := make([]any, 0, len(fields)*2)
+	for _, f := range fields {
+		args = append(args, f.key, f.value)
+	}
+	return Logger.With(args...)
+}
+
+func fieldsFrom(ctx context.Context) []field {
+	fields, _ := ctx.Value(fieldsKey{}).([]field)
+	return fields
+}
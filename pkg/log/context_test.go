@@ -0,0 +1,71 @@
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithContext(t *testing.T) {
+	ctx := ContextWith(context.Background(), KeyScanID, "scan-1")
+	ctx = ContextWith(ctx, KeyArtifact, "alpine:3.17")
+	ctx = ContextWith(ctx, KeyScanID, "scan-2") // replaces the scan ID
+
+	tests := []struct {
+		name   string
+		format Format
+		want   map[string]any
+	}{
+		{
+			name:   "json",
+			format: FormatJSON,
+			want: map[string]any{
+				KeyArtifact: "alpine:3.17",
+				KeyScanID:   "scan-2",
+			},
+		},
+		{
+			name:   "text",
+			format: FormatText,
+			want:   map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			origLogger, origFormat := Logger, format
+			Logger, format = zap.New(core).Sugar(), tt.format
+			t.Cleanup(func() { Logger, format = origLogger, origFormat })
+
+			WithContext(ctx).Info("test")
+
+			entries := logs.All()
+			assert.Len(t, entries, 1)
+			assert.Equal(t, tt.want, entries[0].ContextMap())
+		})
+	}
+}
+
+func TestScanID(t *testing.T) {
+	assert.Empty(t, ScanID(context.Background()))
+
+	ctx := ContextWith(context.Background(), KeyLayer, "sha256:abc")
+	assert.Empty(t, ScanID(ctx))
+
+	ctx = ContextWith(ctx, KeyScanID, "scan-1")
+	assert.Equal(t, "scan-1", ScanID(ctx))
+}
+
+func TestSetFormat(t *testing.T) {
+	t.Cleanup(func() { format = FormatText })
+
+	assert.NoError(t, SetFormat(FormatJSON))
+	assert.Equal(t, FormatJSON, format)
+	assert.NoError(t, SetFormat(""))
+	assert.Equal(t, FormatText, format)
+	assert.ErrorContains(t, SetFormat("xml"), "unknown log format: xml")
+}
@@ -13,10 +13,22 @@ import (
 	flog "github.com/zhanglimao/trivy/pkg/fanal/log"
 )
 
+// Format is the format of logs
+type Format string
+
+const (
+	// FormatText is the human-readable format
+	FormatText Format = "text"
+
+	// FormatJSON writes a JSON object per line with the fields attached to the context, e.g. the scan ID
+	FormatJSON Format = "json"
+)
+
 var (
 	// Logger is the global variable for logging
 	Logger      *zap.SugaredLogger
 	debugOption bool
+	format      = FormatText
 )
 
 func init() {
@@ -24,6 +36,19 @@ func init() {
 	Logger, _ = NewLogger(false, false) // nolint: errcheck
 }
 
+// SetFormat sets the format of the loggers initialized by InitLogger
+func SetFormat(f Format) error {
+	switch f {
+	case FormatText, FormatJSON:
+		format = f
+		return nil
+	case "":
+		format = FormatText
+		return nil
+	}
+	return xerrors.Errorf("unknown log format: %s", f)
+}
+
 // InitLogger initialize the logger variable
 func InitLogger(debug, disable bool) (err error) {
 	debugOption = debug
@@ -79,6 +104,21 @@ func NewLogger(debug, disable bool) (*zap.SugaredLogger, error) {
 	}
 
 	consoleEncoder := zapcore.NewConsoleEncoder(encoderConfig)
+	if format == FormatJSON {
+		consoleEncoder = zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+			TimeKey:        "time",
+			LevelKey:       "level",
+			NameKey:        "logger",
+			CallerKey:      "caller",
+			MessageKey:     "msg",
+			StacktraceKey:  "stacktrace",
+			LineEnding:     zapcore.DefaultLineEnding,
+			EncodeLevel:    zapcore.LowercaseLevelEncoder,
+			EncodeTime:     zapcore.ISO8601TimeEncoder,
+			EncodeDuration: zapcore.StringDurationEncoder,
+			EncodeCaller:   zapcore.ShortCallerEncoder,
+		})
+	}
 
 	// High-priority output should also go to standard error, and low-priority
 	// output should also go to standard out.
@@ -0,0 +1,273 @@
+// Package malicious loads feeds of malicious packages in the OSV format, e.g. OpenSSF Malicious Packages,
+// so that known-malicious package versions are reported separately from vulnerabilities.
+package malicious
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy-db/pkg/vulnsrc/vulnerability"
+	"github.com/zhanglimao/trivy/pkg/advisory"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+var (
+	// OpenSSF is the data source of reports with the "MAL-" prefix
+	OpenSSF = &dbTypes.DataSource{
+		ID:   "ossf-malicious-packages",
+		Name: "OpenSSF Malicious Packages",
+		URL:  "https://github.com/ossf/malicious-packages",
+	}
+
+	// OSV is the data source of the other reports
+	OSV = &dbTypes.DataSource{
+		ID:   "osv",
+		Name: "OSV",
+	}
+)
+
+// ecosystems maps OSV ecosystems to the ecosystems of trivy-db
+var ecosystems = map[string]dbTypes.Ecosystem{
+	"npm":       vulnerability.Npm,
+	"pypi":      vulnerability.Pip,
+	"rubygems":  vulnerability.RubyGems,
+	"crates.io": vulnerability.Cargo,
+	"go":        vulnerability.Go,
+	"maven":     vulnerability.Maven,
+	"nuget":     vulnerability.NuGet,
+	"packagist": vulnerability.Composer,
+	"pub":       vulnerability.Pub,
+	"hex":       vulnerability.Erlang,
+}
+
+// osvEntry is the subset of the OSV schema used for malicious packages.
+// cf. https://ossf.github.io/osv-schema/
+type osvEntry struct {
+	ID         string   `json:"id"`
+	Summary    string   `json:"summary"`
+	Aliases    []string `json:"aliases"`
+	Withdrawn  string   `json:"withdrawn"`
+	References []struct {
+		URL string `json:"url"`
+	} `json:"references"`
+	Affected []struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+		Versions []string `json:"versions"`
+		Ranges   []struct {
+			Type   string     `json:"type"`
+			Events []osvEvent `json:"events"`
+		} `json:"ranges"`
+	} `json:"affected"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+}
+
+type osvEvent struct {
+	Introduced   string `json:"introduced"`
+	Fixed        string `json:"fixed"`
+	LastAffected string `json:"last_affected"`
+}
+
+// Report is a malicious package report for a package
+type Report struct {
+	ID         string
+	Aliases    []string
+	Summary    string
+	Severity   string
+	References []string
+
+	// Versions holds the exact malicious versions
+	Versions []string
+
+	// VulnerableVersions holds version ranges in the same format as trivy-db, e.g. ">=1.0.0, <1.0.3"
+	VulnerableVersions []string
+
+	// AllVersions is true when every version of the package is malicious
+	AllVersions bool
+
+	DataSource *dbTypes.DataSource
+}
+
+var (
+	mu sync.RWMutex
+
+	// ecosystem => package name => reports
+	current map[string]map[string][]Report
+)
+
+// Load loads the OSV files (*.json) in the directories.
+// A file can be passed instead of a directory, which is loaded regardless of the extension.
+// The reports loaded previously are replaced.
+func Load(dirs ...string) error {
+	reports := map[string]map[string][]Report{}
+	var count int
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			} else if d.IsDir() || (path != dir && filepath.Ext(path) != ".json") {
+				return nil
+			}
+			n, err := loadFile(path, reports)
+			if err != nil {
+				return xerrors.Errorf("%s: %w", path, err)
+			}
+			count += n
+			return nil
+		})
+		if err != nil {
+			return xerrors.Errorf("malicious package load error: %w", err)
+		}
+	}
+	log.Logger.Infof("%d malicious package reports loaded", count)
+
+	mu.Lock()
+	defer mu.Unlock()
+	current = reports
+	return nil
+}
+
+// Reset unloads the reports
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	current = nil
+}
+
+// Enabled returns true if any feeds are loaded
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current != nil
+}
+
+func loadFile(path string, reports map[string]map[string][]Report) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	var entry osvEntry
+	if err = json.NewDecoder(f).Decode(&entry); err != nil {
+		return 0, xerrors.Errorf("json decode error: %w", err)
+	} else if entry.ID == "" {
+		return 0, xerrors.New("id is required")
+	} else if entry.Withdrawn != "" {
+		return 0, nil
+	}
+
+	severity := dbTypes.SeverityCritical.String()
+	if entry.DatabaseSpecific.Severity != "" {
+		s, err := dbTypes.NewSeverity(strings.ToUpper(entry.DatabaseSpecific.Severity))
+		if err != nil {
+			return 0, xerrors.Errorf("invalid severity of %s: %w", entry.ID, err)
+		}
+		severity = s.String()
+	}
+
+	source := OSV
+	if strings.HasPrefix(entry.ID, "MAL-") {
+		source = OpenSSF
+	}
+
+	var refs []string
+	for _, ref := range entry.References {
+		refs = append(refs, ref.URL)
+	}
+
+	var count int
+	for _, affected := range entry.Affected {
+		ecosystem, ok := ecosystems[strings.ToLower(affected.Package.Ecosystem)]
+		if !ok {
+			log.Logger.Debugf("Unsupported ecosystem of %s: %s", entry.ID, affected.Package.Ecosystem)
+			continue
+		}
+
+		report := Report{
+			ID:         entry.ID,
+			Aliases:    entry.Aliases,
+			Summary:    entry.Summary,
+			Severity:   severity,
+			References: refs,
+			Versions:   affected.Versions,
+			DataSource: source,
+		}
+		for _, r := range affected.Ranges {
+			if r.Type == "GIT" {
+				continue
+			}
+			constraints, all := convertRange(r.Events)
+			report.VulnerableVersions = append(report.VulnerableVersions, constraints...)
+			report.AllVersions = report.AllVersions || all
+		}
+		if len(report.Versions) == 0 && len(report.VulnerableVersions) == 0 && !report.AllVersions {
+			continue
+		}
+
+		eco := string(ecosystem)
+		name := advisory.NormalizePkgName(eco, affected.Package.Name)
+		if reports[eco] == nil {
+			reports[eco] = map[string][]Report{}
+		}
+		reports[eco][name] = append(reports[eco][name], report)
+		count++
+	}
+	return count, nil
+}
+
+// convertRange converts OSV range events to version constraints.
+// It returns true instead when all the versions are affected, i.e. introduced at "0" without fixes.
+func convertRange(events []osvEvent) ([]string, bool) {
+	var constraints []string
+	var introduced string
+	var open bool
+	for _, e := range events {
+		switch {
+		case e.Introduced != "":
+			introduced, open = e.Introduced, true
+		case e.Fixed != "" && open:
+			constraints = append(constraints, lowerBound(introduced)+fmt.Sprintf("<%s", e.Fixed))
+			open = false
+		case e.LastAffected != "" && open:
+			constraints = append(constraints, lowerBound(introduced)+fmt.Sprintf("<=%s", e.LastAffected))
+			open = false
+		}
+	}
+	if open {
+		if introduced == "0" {
+			return nil, true
+		}
+		constraints = append(constraints, ">="+introduced)
+	}
+	return constraints, false
+}
+
+func lowerBound(introduced string) string {
+	if introduced == "0" {
+		return ""
+	}
+	return fmt.Sprintf(">=%s, ", introduced)
+}
+
+// Get returns the malicious package reports of the package
+func Get(ecosystem, pkgName string) []Report {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil {
+		return nil
+	}
+	return current[ecosystem][advisory.NormalizePkgName(ecosystem, pkgName)]
+}
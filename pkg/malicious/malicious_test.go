@@ -0,0 +1,118 @@
+package malicious_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/malicious"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		dir     string
+		wantErr string
+	}{
+		{
+			name: "happy path",
+			dir:  "testdata/happy",
+		},
+		{
+			name: "file",
+			dir:  "testdata/happy/npm/MAL-2022-1001.json",
+		},
+		{
+			name:    "invalid severity",
+			dir:     "testdata/sad",
+			wantErr: "invalid severity of MAL-2023-3001",
+		},
+		{
+			name:    "no such directory",
+			dir:     "testdata/unknown",
+			wantErr: "malicious package load error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer malicious.Reset()
+
+			err := malicious.Load(tt.dir)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				assert.False(t, malicious.Enabled())
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, malicious.Enabled())
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	require.NoError(t, malicious.Load("testdata/happy"))
+	defer malicious.Reset()
+
+	tests := []struct {
+		name      string
+		ecosystem string
+		pkgName   string
+		want      []malicious.Report
+	}{
+		{
+			name:      "versions",
+			ecosystem: "npm",
+			pkgName:   "flatmap-stream",
+			want: []malicious.Report{
+				{
+					ID:         "MAL-2022-1001",
+					Aliases:    []string{"GHSA-9x64-5r7x-2q53"},
+					Summary:    "Malicious code in flatmap-stream (npm)",
+					Severity:   "CRITICAL",
+					References: []string{"https://github.com/dominictarr/event-stream/issues/116"},
+					Versions:   []string{"0.1.1"},
+					DataSource: malicious.OpenSSF,
+				},
+			},
+		},
+		{
+			name:      "ranges",
+			ecosystem: "npm",
+			pkgName:   "event-stream",
+			want: []malicious.Report{
+				{
+					ID:                 "GHSA-mh6f-8j2x-4483",
+					Summary:            "Critical severity vulnerability that affects event-stream and flatmap-stream",
+					Severity:           "HIGH",
+					VulnerableVersions: []string{">=3.3.6, <4.0.0"},
+					DataSource:         malicious.OSV,
+				},
+			},
+		},
+		{
+			name:      "all versions with the normalized name",
+			ecosystem: "pip",
+			pkgName:   "reqeusts-lib",
+			want: []malicious.Report{
+				{
+					ID:          "MAL-2023-2002",
+					Summary:     "Malicious code in Reqeusts_Lib (PyPI)",
+					Severity:    "CRITICAL",
+					AllVersions: true,
+					DataSource:  malicious.OpenSSF,
+				},
+			},
+		},
+		{
+			name:      "unknown package",
+			ecosystem: "npm",
+			pkgName:   "lodash",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, malicious.Get(tt.ecosystem, tt.pkgName))
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package malicious
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/oci"
+)
+
+// Dir returns the directory where the malicious package feed downloaded from the OCI repository is stored
+func Dir(cacheDir string) string {
+	return filepath.Join(cacheDir, "malicious-packages")
+}
+
+// Download downloads the malicious package feed from the OCI repository into the cache directory.
+// The artifact must have a single layer with an archive of OSV files, e.g. tar.gz.
+// The cached feed is used as is if skipUpdate is true.
+func Download(ctx context.Context, repo, cacheDir string, quiet, skipUpdate bool, opt ftypes.RegistryOptions) (string, error) {
+	dir := Dir(cacheDir)
+	if skipUpdate {
+		if _, err := os.Stat(dir); err != nil {
+			return "", xerrors.Errorf("--skip-db-update cannot be specified on the first run: %w", err)
+		}
+		log.Logger.Debug("Skipping the malicious package feed update")
+		return dir, nil
+	}
+
+	log.Logger.Infof("Downloading the malicious package feed from %s...", repo)
+	art, err := oci.NewArtifact(repo, quiet, opt, oci.WithCacheDir(cacheDir))
+	if err != nil {
+		return "", xerrors.Errorf("OCI artifact error: %w", err)
+	}
+
+	// The feed downloaded previously is replaced
+	if err = art.Download(ctx, dir, oci.DownloadOption{}); err != nil {
+		return "", xerrors.Errorf("malicious package feed download error: %w", err)
+	}
+	return dir, nil
+}
@@ -0,0 +1,187 @@
+package metrics
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+const (
+	namespace = "trivy"
+
+	// Path serves the metrics
+	Path = "/metrics"
+
+	// Timeouts of the metrics server, which serves only small responses
+	readHeaderTimeout = 10 * time.Second
+	readTimeout       = 30 * time.Second
+	writeTimeout      = 30 * time.Second
+	idleTimeout       = 2 * time.Minute
+)
+
+var (
+	// ScanQueueDepth is the number of scans waiting for a worker in server mode
+	ScanQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "server",
+		Name:      "scan_queue_depth",
+		Help:      "Number of scans waiting for a worker.",
+	})
+
+	// ScansRunning is the number of scans being processed by the workers in server mode
+	ScansRunning = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "server",
+		Name:      "scans_running",
+		Help:      "Number of scans being processed by the workers.",
+	})
+
+	// ScansRejected counts the scans rejected because the queue was full
+	ScansRejected = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "server",
+		Name:      "scans_rejected_total",
+		Help:      "Number of scans rejected because the queue was full.",
+	})
+
+	scans = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scans_total",
+		Help:      "Number of scans by artifact type and status.",
+	}, []string{"artifact_type", "status"})
+
+	scanDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "scan_duration_seconds",
+		Help:      "Duration of the vulnerability and misconfiguration detection by artifact type.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+	}, []string{"artifact_type"})
+
+	cacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_lookups_total",
+		Help:      "Number of layers looked up in the cache, by result.",
+	}, []string{"result"})
+
+	// LayerAnalysisDuration is the time to analyze a layer missing in the cache
+	LayerAnalysisDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "layer_analysis_duration_seconds",
+		Help:      "Duration of the analysis of layers missing in the cache.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12),
+	})
+
+	rpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "server",
+		Name:      "rpc_requests_total",
+		Help:      "Number of RPC requests by service, method and status code.",
+	}, []string{"service", "method", "code"})
+
+	rpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "server",
+		Name:      "rpc_duration_seconds",
+		Help:      "Duration of RPC requests by service and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method"})
+)
+
+// ObserveScan records a scan of the artifact type
+func ObserveScan(artifactType string, start time.Time, err error) {
+	if artifactType == "" {
+		artifactType = "unknown"
+	}
+	status := "success"
+	if err != nil {
+		status = "failure"
+	}
+	scans.WithLabelValues(artifactType, status).Inc()
+	scanDuration.WithLabelValues(artifactType).Observe(time.Since(start).Seconds())
+}
+
+// ObserveCacheLookup records the layers found in the cache and the missing ones
+func ObserveCacheLookup(total, missing int) {
+	cacheLookups.WithLabelValues("hit").Add(float64(total - missing))
+	cacheLookups.WithLabelValues("miss").Add(float64(missing))
+}
+
+// ObserveRPC records an RPC request
+func ObserveRPC(service, method, code string, duration time.Duration) {
+	rpcRequests.WithLabelValues(service, method, code).Inc()
+	rpcDuration.WithLabelValues(service, method).Observe(duration.Seconds())
+}
+
+var (
+	dbAgeOnce   sync.Once
+	dbAgeMu     sync.RWMutex
+	dbUpdatedAt func() (time.Time, error)
+)
+
+// RegisterDBAge exposes the time since the vulnerability DB was built.
+// The age is not reported while the DB metadata is unavailable.
+// The collector is registered only once, and the DB of the last started server is reported
+// as servers can be started more than once in the same process, e.g. in tests.
+func RegisterDBAge(updatedAt func() (time.Time, error)) {
+	dbAgeMu.Lock()
+	dbUpdatedAt = updatedAt
+	dbAgeMu.Unlock()
+
+	dbAgeOnce.Do(func() {
+		prometheus.MustRegister(dbAgeCollector{updatedAt: func() (time.Time, error) {
+			dbAgeMu.RLock()
+			defer dbAgeMu.RUnlock()
+			return dbUpdatedAt()
+		}})
+	})
+}
+
+var dbAgeDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "db", "age_seconds"),
+	"Time since the vulnerability DB was built.", nil, nil)
+
+type dbAgeCollector struct {
+	updatedAt func() (time.Time, error)
+}
+
+func (c dbAgeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- dbAgeDesc
+}
+
+func (c dbAgeCollector) Collect(ch chan<- prometheus.Metric) {
+	t, err := c.updatedAt()
+	if err != nil || t.IsZero() {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(dbAgeDesc, prometheus.GaugeValue, time.Since(t).Seconds())
+}
+
+// Handler serves the metrics in the Prometheus format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Serve exposes the metrics in the background, e.g. during long-running scans
+func Serve(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle(Path, Handler())
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+	go func() {
+		log.Logger.Infof("Serving metrics at %s%s", addr, Path)
+		if err := srv.ListenAndServe(); err != nil {
+			log.Logger.Errorf("Metrics server error: %s", err)
+		}
+	}()
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserveScan(t *testing.T) {
+	before := testutil.ToFloat64(scans.WithLabelValues("container_image", "failure"))
+	ObserveScan("container_image", time.Now(), errors.New("error"))
+	assert.Equal(t, before+1, testutil.ToFloat64(scans.WithLabelValues("container_image", "failure")))
+
+	before = testutil.ToFloat64(scans.WithLabelValues("unknown", "success"))
+	ObserveScan("", time.Now(), nil)
+	assert.Equal(t, before+1, testutil.ToFloat64(scans.WithLabelValues("unknown", "success")))
+}
+
+func TestObserveCacheLookup(t *testing.T) {
+	hits := testutil.ToFloat64(cacheLookups.WithLabelValues("hit"))
+	misses := testutil.ToFloat64(cacheLookups.WithLabelValues("miss"))
+
+	ObserveCacheLookup(5, 2)
+	assert.Equal(t, hits+3, testutil.ToFloat64(cacheLookups.WithLabelValues("hit")))
+	assert.Equal(t, misses+2, testutil.ToFloat64(cacheLookups.WithLabelValues("miss")))
+}
+
+func Test_dbAgeCollector(t *testing.T) {
+	tests := []struct {
+		name      string
+		updatedAt func() (time.Time, error)
+		want      int
+	}{
+		{
+			name: "happy path",
+			updatedAt: func() (time.Time, error) {
+				return time.Now().Add(-time.Hour), nil
+			},
+			want: 1,
+		},
+		{
+			name: "no metadata",
+			updatedAt: func() (time.Time, error) {
+				return time.Time{}, errors.New("no such file")
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := dbAgeCollector{updatedAt: tt.updatedAt}
+			assert.Equal(t, tt.want, testutil.CollectAndCount(c))
+		})
+	}
+
+	reg := prometheus.NewRegistry()
+	require.NoError(t, reg.Register(dbAgeCollector{updatedAt: func() (time.Time, error) {
+		return time.Now().Add(-time.Hour), nil
+	}}))
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	assert.Equal(t, "trivy_db_age_seconds", families[0].GetName())
+	assert.InDelta(t, time.Hour.Seconds(), families[0].GetMetric()[0].GetGauge().GetValue(), 60)
+}
+
+func TestRegisterDBAge(t *testing.T) {
+	// Servers can be started more than once in the same process
+	RegisterDBAge(func() (time.Time, error) {
+		return time.Now().Add(-time.Hour), nil
+	})
+	RegisterDBAge(func() (time.Time, error) {
+		return time.Now().Add(-2 * time.Hour), nil
+	})
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() != "trivy_db_age_seconds" {
+			continue
+		}
+		// The DB of the last server is reported
+		assert.InDelta(t, (2 * time.Hour).Seconds(), f.GetMetric()[0].GetGauge().GetValue(), 60)
+		return
+	}
+	assert.Fail(t, "trivy_db_age_seconds not found")
+}
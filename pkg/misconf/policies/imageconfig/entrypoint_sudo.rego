@@ -0,0 +1,25 @@
+# METADATA
+# title: "Entrypoint should not use 'sudo'"
+# description: "Running the entrypoint with 'sudo' gives the process root privileges, which can lead to a container escape situation."
+# scope: package
+# related_resources:
+# - https://docs.docker.com/develop/develop-images/dockerfile_best-practices/#user
+# custom:
+#   id: IC004
+#   severity: HIGH
+#   short_code: no-sudo-entrypoint
+#   recommended_action: "Remove 'sudo' from 'ENTRYPOINT' and 'CMD' and run the process as a non-root user"
+#   input:
+#     selector:
+#     - type: json
+package builtin.imageconfig.IC004
+
+commands := array.concat(
+	[arg | arg := input.config.Entrypoint[_]],
+	[arg | arg := input.config.Cmd[_]],
+)
+
+deny[res] {
+	regex.match(`(^|[\s;&|])sudo(\s|$)`, commands[_])
+	res := result.new(sprintf("Entrypoint should not use 'sudo': %s", [concat(" ", commands)]), {})
+}
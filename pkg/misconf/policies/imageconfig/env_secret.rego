@@ -0,0 +1,54 @@
+# METADATA
+# title: "Secrets should not be set in environment variables"
+# description: "Environment variables are stored in the image config and visible to anyone who can pull the image. Passwords, tokens and keys should be passed at runtime instead."
+# scope: package
+# related_resources:
+# - https://docs.docker.com/engine/swarm/secrets/
+# custom:
+#   id: IC002
+#   severity: CRITICAL
+#   short_code: no-secrets-in-env
+#   recommended_action: "Pass secrets at runtime, e.g. with Docker secrets or a '*_FILE' variable pointing to a mounted file"
+#   input:
+#     selector:
+#     - type: json
+package builtin.imageconfig.IC002
+
+sensitive_keywords := [
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"api_key",
+	"apikey",
+	"access_key",
+	"private_key",
+	"credential",
+]
+
+# Variables pointing to the location of secrets are allowed, e.g. POSTGRES_PASSWORD_FILE
+allowed_suffixes := ["_file", "_path", "_dir"]
+
+env[[name, value]] {
+	e := input.config.Env[_]
+	parts := split(e, "=")
+	name := parts[0]
+	value := concat("=", array.slice(parts, 1, count(parts)))
+}
+
+sensitive(name) {
+	lower_name := lower(name)
+	contains(lower_name, sensitive_keywords[_])
+	not allowed(lower_name)
+}
+
+allowed(lower_name) {
+	endswith(lower_name, allowed_suffixes[_])
+}
+
+deny[res] {
+	[name, value] := env[_]
+	value != ""
+	sensitive(name)
+	res := result.new(sprintf("Environment variable '%s' might contain a secret", [name]), {})
+}
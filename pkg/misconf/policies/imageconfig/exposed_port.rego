@@ -0,0 +1,30 @@
+# METADATA
+# title: "Ports of remote access services should not be exposed"
+# description: "Exposing ports of remote access services such as SSH and the Docker daemon allows attackers to access the container directly."
+# scope: package
+# related_resources:
+# - https://docs.docker.com/engine/reference/builder/#expose
+# custom:
+#   id: IC003
+#   severity: MEDIUM
+#   short_code: no-remote-access-port
+#   recommended_action: "Remove the port from the 'EXPOSE' instruction"
+#   input:
+#     selector:
+#     - type: json
+package builtin.imageconfig.IC003
+
+services := {
+	"22": "SSH",
+	"23": "Telnet",
+	"2375": "Docker daemon",
+	"2376": "Docker daemon",
+	"3389": "RDP",
+	"5900": "VNC",
+}
+
+deny[res] {
+	_ = input.config.ExposedPorts[port]
+	service := services[split(port, "/")[0]]
+	res := result.new(sprintf("Port %s (%s) should not be exposed", [port, service]), {})
+}
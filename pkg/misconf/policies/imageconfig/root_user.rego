@@ -0,0 +1,27 @@
+# METADATA
+# title: "Image user should not be 'root'"
+# description: "Running containers with 'root' user can lead to a container escape situation. The user is 'root' when it is not set in the image config."
+# scope: package
+# related_resources:
+# - https://docs.docker.com/develop/develop-images/dockerfile_best-practices/#user
+# custom:
+#   id: IC001
+#   severity: HIGH
+#   short_code: least-privilege-user
+#   recommended_action: "Set a non-root user with the 'USER' instruction"
+#   input:
+#     selector:
+#     - type: json
+package builtin.imageconfig.IC001
+
+user := object.get(object.get(input, "config", {}), "User", "")
+
+deny[res] {
+	user == ""
+	res := result.new("Specify a non-root user in the image config", {})
+}
+
+deny[res] {
+	regex.match("^(root|0)(:.*)?$", user)
+	res := result.new(sprintf("Image user should not be 'root', but got '%s'", [user]), {})
+}
@@ -2,7 +2,7 @@ package misconf
 
 import (
 	"context"
-	_ "embed"
+	"embed"
 	"fmt"
 	"io"
 	"io/fs"
@@ -21,6 +21,7 @@ import (
 	cfparser "github.com/aquasecurity/defsec/pkg/scanners/cloudformation/parser"
 	dfscanner "github.com/aquasecurity/defsec/pkg/scanners/dockerfile"
 	"github.com/aquasecurity/defsec/pkg/scanners/helm"
+	jsonscanner "github.com/aquasecurity/defsec/pkg/scanners/json"
 	k8sscanner "github.com/aquasecurity/defsec/pkg/scanners/kubernetes"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 	tfscanner "github.com/aquasecurity/defsec/pkg/scanners/terraform"
@@ -38,8 +39,14 @@ var enabledDefsecTypes = map[detection.FileType]string{
 	detection.FileTypeKubernetes:     types.Kubernetes,
 	detection.FileTypeHelm:           types.Helm,
 	detection.FileTypeTerraformPlan:  types.TerraformPlan,
+	detection.FileTypeJSON:           types.JSON,
 }
 
+// imageConfigPolicies are the built-in checks for container image config
+//
+//go:embed policies/imageconfig/*.rego
+var imageConfigPolicies embed.FS
+
 type ScannerOption struct {
 	Trace                   bool
 	RegoOnly                bool
@@ -96,6 +103,37 @@ func NewTerraformPlanScanner(filePatterns []string, opt ScannerOption) (*Scanner
 	return newScanner(detection.FileTypeTerraformPlan, filePatterns, opt)
 }
 
+// NewImageConfigScanner returns a scanner evaluating container image config as JSON
+// against the built-in checks for image config and user policies.
+func NewImageConfigScanner(opt ScannerOption) (*Scanner, error) {
+	return newScanner(detection.FileTypeJSON, nil, opt)
+}
+
+// NewCustomResourceScanner returns a scanner evaluating custom resources as JSON against user policies.
+// Unlike NewImageConfigScanner, the built-in checks are not loaded since they don't know user-defined resources.
+func NewCustomResourceScanner(opt ScannerOption) (*Scanner, error) {
+	return newUserPolicyScanner(opt)
+}
+
+// NewPackagePolicyScanner returns a scanner evaluating the package inventory as JSON against user policies.
+func NewPackagePolicyScanner(opt ScannerOption) (*Scanner, error) {
+	return newUserPolicyScanner(opt)
+}
+
+// newUserPolicyScanner returns a JSON scanner loading only the passed policies
+func newUserPolicyScanner(opt ScannerOption) (*Scanner, error) {
+	opt.DisableEmbeddedPolicies = true
+	opts, err := scannerOptions("", opt)
+	if err != nil {
+		return nil, err
+	}
+	return &Scanner{
+		fileType:       detection.FileTypeJSON,
+		scanner:        jsonscanner.NewScanner(opts...),
+		hasFilePattern: true,
+	}, nil
+}
+
 func newScanner(t detection.FileType, filePatterns []string, opt ScannerOption) (*Scanner, error) {
 	opts, err := scannerOptions(t, opt)
 	if err != nil {
@@ -118,6 +156,8 @@ func newScanner(t detection.FileType, filePatterns []string, opt ScannerOption)
 		scanner = tfscanner.New(opts...)
 	case detection.FileTypeTerraformPlan:
 		scanner = tfpscanner.New(opts...)
+	case detection.FileTypeJSON:
+		scanner = jsonscanner.NewScanner(opts...)
 	}
 
 	return &Scanner{
@@ -240,6 +280,8 @@ func scannerOptions(t detection.FileType, opt ScannerOption) ([]options.ScannerO
 		return addHelmOpts(opts, opt), nil
 	case detection.FileTypeTerraform:
 		return addTFOpts(opts, opt), nil
+	case detection.FileTypeJSON:
+		return addImageConfigOpts(opts)
 	default:
 		return opts, nil
 	}
@@ -282,6 +324,24 @@ func addHelmOpts(opts []options.ScannerOption, scannerOption ScannerOption) []op
 	return opts
 }
 
+func addImageConfigOpts(opts []options.ScannerOption) ([]options.ScannerOption, error) {
+	const dir = "policies/imageconfig"
+	entries, err := imageConfigPolicies.ReadDir(dir)
+	if err != nil {
+		return nil, xerrors.Errorf("built-in policy read error: %w", err)
+	}
+
+	var readers []io.Reader
+	for _, entry := range entries {
+		f, err := imageConfigPolicies.Open(dir + "/" + entry.Name())
+		if err != nil {
+			return nil, xerrors.Errorf("built-in policy open error: %w", err)
+		}
+		readers = append(readers, f)
+	}
+	return append(opts, options.ScannerWithPolicyReader(readers...)), nil
+}
+
 func createPolicyFS(policyPaths []string) (fs.FS, []string, error) {
 	if len(policyPaths) == 0 {
 		return nil, nil, nil
@@ -8,6 +8,10 @@ const (
 	ActionInsert serialize.PostScanAction = "INSERT"
 	ActionUpdate serialize.PostScanAction = "UPDATE"
 	ActionDelete serialize.PostScanAction = "DELETE"
+
+	ActionAdd      serialize.PostReportActionType = "ADD"
+	ActionAnnotate serialize.PostReportActionType = "ANNOTATE"
+	ActionSuppress serialize.PostReportActionType = "SUPPRESS"
 )
 
 type Module interface {
@@ -24,3 +28,8 @@ type PostScanner interface {
 	PostScanSpec() serialize.PostScanSpec
 	PostScan(serialize.Results) (serialize.Results, error)
 }
+
+// PostReporter changes the report after filtering
+type PostReporter interface {
+	PostReport(serialize.PostReportInput) (serialize.PostReportActions, error)
+}
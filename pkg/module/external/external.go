@@ -0,0 +1,321 @@
+// Package external runs custom analyzers as external processes.
+//
+// An analyzer plugin is a directory with plugin.yaml and an executable written in any language.
+// Trivy starts the executable on the first matching file and talks to it over stdio.
+// Each request and response is a JSON object on a single line.
+//
+//	# plugin.yaml
+//	name: dotlock
+//	version: 1        # analyzer version, bump it to invalidate the cache
+//	api_version: 1    # protocol version
+//	command: bin/dotlock
+//	args: ["--stdio"]
+//	required_files:   # regular expressions matching file paths
+//	  - \.lock$
+//
+// Request (stdin):
+//
+//	{"APIVersion":1,"FilePath":"app/foo.lock","Content":"<base64>"}
+//
+// Response (stdout):
+//
+//	{"APIVersion":1,"PackageType":"npm","Packages":[{"Name":"lodash","Version":"4.17.20"}]}
+//
+// Stderr of the plugin is written to the debug log.
+package external
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/utils/fsutils"
+)
+
+const (
+	// APIVersion is the version of the protocol
+	APIVersion = 1
+
+	configFile = "plugin.yaml"
+
+	closeTimeout = 5 * time.Second
+)
+
+var (
+	RelativeDir = filepath.Join(".trivy", "analyzer-plugins")
+
+	DefaultDir = filepath.Join(fsutils.HomeDir(), RelativeDir)
+)
+
+// Manifest is loaded from plugin.yaml
+type Manifest struct {
+	Name          string   `yaml:"name"`
+	Version       int      `yaml:"version"`
+	APIVersion    int      `yaml:"api_version"`
+	Command       string   `yaml:"command"`
+	Args          []string `yaml:"args"`
+	RequiredFiles []string `yaml:"required_files"`
+}
+
+// Request is sent to the plugin per file
+type Request struct {
+	APIVersion int
+	FilePath   string
+	Content    []byte
+}
+
+// Response is returned by the plugin per request
+type Response struct {
+	APIVersion int
+
+	// Error fails the analysis of the file
+	Error string `json:",omitempty"`
+
+	// PackageType is the ecosystem of the packages, e.g. npm and pip, used to detect vulnerabilities
+	PackageType string          `json:",omitempty"`
+	Packages    []types.Package `json:",omitempty"`
+
+	// Misconfigurations holds the failures found in the file
+	Misconfigurations []types.MisconfResult `json:",omitempty"`
+}
+
+// Analyzer implements analyzer.analyzer by running the plugin
+type Analyzer struct {
+	manifest      Manifest
+	dir           string
+	requiredFiles []*regexp.Regexp
+
+	// The plugin process is started lazily and serves requests one by one
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *json.Decoder
+}
+
+// Load loads the plugin in the directory
+func Load(dir string) (*Analyzer, error) {
+	f, err := os.Open(filepath.Join(dir, configFile))
+	if err != nil {
+		return nil, xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	var m Manifest
+	if err = yaml.NewDecoder(f).Decode(&m); err != nil {
+		return nil, xerrors.Errorf("yaml decode error: %w", err)
+	}
+
+	switch {
+	case m.Name == "":
+		return nil, xerrors.New("name is required")
+	case m.Command == "":
+		return nil, xerrors.New("command is required")
+	case m.APIVersion != APIVersion:
+		return nil, xerrors.Errorf("unsupported API version %d (expected: %d)", m.APIVersion, APIVersion)
+	case analyzer.Registered(analyzer.Type(m.Name)):
+		return nil, xerrors.Errorf("name %q conflicts with an existing analyzer", m.Name)
+	}
+
+	var requiredFiles []*regexp.Regexp
+	for _, s := range m.RequiredFiles {
+		r, err := regexp.Compile(s)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid required file %q: %w", s, err)
+		}
+		requiredFiles = append(requiredFiles, r)
+	}
+
+	return &Analyzer{
+		manifest:      m,
+		dir:           dir,
+		requiredFiles: requiredFiles,
+	}, nil
+}
+
+func (a *Analyzer) Name() string {
+	return a.manifest.Name
+}
+
+func (a *Analyzer) Type() analyzer.Type {
+	return analyzer.Type(a.manifest.Name)
+}
+
+func (a *Analyzer) Version() int {
+	return a.manifest.Version
+}
+
+func (a *Analyzer) Required(filePath string, _ os.FileInfo) bool {
+	for _, r := range a.requiredFiles {
+		if r.MatchString(filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Analyzer) Analyze(ctx context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	content, err := io.ReadAll(input.Content)
+	if err != nil {
+		return nil, xerrors.Errorf("read error: %w", err)
+	}
+
+	res, err := a.call(ctx, Request{
+		APIVersion: APIVersion,
+		FilePath:   filepath.ToSlash(input.FilePath),
+		Content:    content,
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("analyzer plugin %s error: %w", a.manifest.Name, err)
+	}
+
+	result := &analyzer.AnalysisResult{}
+	if len(res.Packages) > 0 {
+		result.Applications = []types.Application{
+			{
+				Type:      res.PackageType,
+				FilePath:  input.FilePath,
+				Libraries: res.Packages,
+			},
+		}
+	}
+	if len(res.Misconfigurations) > 0 {
+		result.Misconfigurations = []types.Misconfiguration{
+			{
+				FileType: a.manifest.Name,
+				FilePath: input.FilePath,
+				Failures: res.Misconfigurations,
+			},
+		}
+	}
+	return result, nil
+}
+
+// call sends the request and waits for the response.
+// The process is restarted on the next call if it fails.
+func (a *Analyzer) call(ctx context.Context, req Request) (Response, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.cmd == nil {
+		if err := a.start(); err != nil {
+			return Response{}, xerrors.Errorf("start error: %w", err)
+		}
+	}
+
+	type result struct {
+		res Response
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var r result
+		if err := json.NewEncoder(a.stdin).Encode(req); err != nil {
+			r.err = xerrors.Errorf("write error: %w", err)
+		} else if err = a.stdout.Decode(&r.res); err != nil {
+			r.err = xerrors.Errorf("read error: %w", err)
+		}
+		done <- r
+	}()
+
+	var r result
+	select {
+	case <-ctx.Done():
+		r.err = ctx.Err()
+	case r = <-done:
+	}
+	if r.err != nil {
+		// The stream may be out of sync
+		a.stop()
+		return Response{}, r.err
+	}
+
+	switch {
+	case r.res.APIVersion != APIVersion:
+		return Response{}, xerrors.Errorf("unsupported API version %d in the response", r.res.APIVersion)
+	case r.res.Error != "":
+		return Response{}, xerrors.New(r.res.Error)
+	case len(r.res.Packages) > 0 && r.res.PackageType == "":
+		return Response{}, xerrors.New("PackageType is required with Packages")
+	}
+	return r.res, nil
+}
+
+func (a *Analyzer) start() error {
+	command := a.manifest.Command
+	if !filepath.IsAbs(command) {
+		command = filepath.Join(a.dir, command)
+	}
+
+	cmd := exec.Command(command, a.manifest.Args...)
+	cmd.Dir = a.dir
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err = cmd.Start(); err != nil {
+		return err
+	}
+	log.Logger.Debugf("Analyzer plugin %s started (pid: %d)", a.manifest.Name, cmd.Process.Pid)
+
+	go func() {
+		s := bufio.NewScanner(stderr)
+		for s.Scan() {
+			log.Logger.Debugf("[%s] %s", a.manifest.Name, s.Text())
+		}
+	}()
+
+	a.cmd = cmd
+	a.stdin = stdin
+	a.stdout = json.NewDecoder(stdout)
+	return nil
+}
+
+// stop closes stdin so that the plugin can exit gracefully, and kills it after the timeout
+func (a *Analyzer) stop() {
+	if a.cmd == nil {
+		return
+	}
+	_ = a.stdin.Close()
+
+	done := make(chan struct{})
+	go func() {
+		_ = a.cmd.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(closeTimeout):
+		_ = a.cmd.Process.Kill()
+		<-done
+	}
+	a.cmd = nil
+}
+
+// Close stops the plugin process
+func (a *Analyzer) Close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.stop()
+}
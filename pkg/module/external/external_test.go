@@ -0,0 +1,232 @@
+package external_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	_ "github.com/zhanglimao/trivy/pkg/fanal/analyzer/os/alpine"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/module/external"
+)
+
+const pluginEnv = "TRIVY_TEST_ANALYZER_PLUGIN"
+
+// TestMain runs the test binary as the analyzer plugin when the env is set
+func TestMain(m *testing.M) {
+	if os.Getenv(pluginEnv) != "" {
+		runPlugin()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func runPlugin() {
+	s := bufio.NewScanner(os.Stdin)
+	s.Buffer(nil, 1<<20)
+	enc := json.NewEncoder(os.Stdout)
+	for s.Scan() {
+		var req external.Request
+		if err := json.Unmarshal(s.Bytes(), &req); err != nil {
+			os.Exit(1)
+		}
+		res := external.Response{APIVersion: external.APIVersion}
+		switch filepath.Ext(req.FilePath) {
+		case ".lock":
+			for _, line := range strings.Split(strings.TrimSpace(string(req.Content)), "\n") {
+				name, version, _ := strings.Cut(line, "@")
+				res.PackageType = "npm"
+				res.Packages = append(res.Packages, types.Package{Name: name, Version: version})
+			}
+		case ".conf":
+			res.Misconfigurations = []types.MisconfResult{
+				{
+					Message: string(req.Content),
+					PolicyMetadata: types.PolicyMetadata{
+						ID:       "CUSTOM-001",
+						Severity: "HIGH",
+					},
+				},
+			}
+		case ".crash":
+			os.Exit(1)
+		default:
+			res.Error = "unexpected file"
+		}
+		_ = enc.Encode(res)
+	}
+}
+
+func newPlugin(t *testing.T) *external.Analyzer {
+	t.Setenv(pluginEnv, "1")
+
+	exe, err := os.Executable()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	manifest := `name: dotlock
+version: 2
+api_version: 1
+command: ` + exe + `
+required_files:
+  - \.(lock|conf|crash|txt)$
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plugin.yaml"), []byte(manifest), 0644))
+
+	p, err := external.Load(dir)
+	require.NoError(t, err)
+	t.Cleanup(p.Close)
+	return p
+}
+
+func TestAnalyzer_Analyze(t *testing.T) {
+	p := newPlugin(t)
+	assert.Equal(t, analyzer.Type("dotlock"), p.Type())
+	assert.Equal(t, 2, p.Version())
+	assert.True(t, p.Required("app/foo.lock", nil))
+	assert.False(t, p.Required("app/foo.json", nil))
+
+	tests := []struct {
+		name     string
+		filePath string
+		content  string
+		want     *analyzer.AnalysisResult
+		wantErr  string
+	}{
+		{
+			name:     "packages",
+			filePath: "app/foo.lock",
+			content:  "lodash@4.17.20\nexpress@4.18.2\n",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     "npm",
+						FilePath: "app/foo.lock",
+						Libraries: []types.Package{
+							{
+								Name:    "lodash",
+								Version: "4.17.20",
+							},
+							{
+								Name:    "express",
+								Version: "4.18.2",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "misconfigurations",
+			filePath: "etc/app.conf",
+			content:  "debug=true",
+			want: &analyzer.AnalysisResult{
+				Misconfigurations: []types.Misconfiguration{
+					{
+						FileType: "dotlock",
+						FilePath: "etc/app.conf",
+						Failures: types.MisconfResults{
+							{
+								Message: "debug=true",
+								PolicyMetadata: types.PolicyMetadata{
+									ID:       "CUSTOM-001",
+									Severity: "HIGH",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "plugin error",
+			filePath: "foo.txt",
+			wantErr:  "unexpected file",
+		},
+		{
+			name:     "plugin crash",
+			filePath: "foo.crash",
+			wantErr:  "read error",
+		},
+		{
+			// The plugin is restarted after the crash
+			name:     "restart",
+			filePath: "bar.lock",
+			content:  "lodash@4.17.21",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     "npm",
+						FilePath: "bar.lock",
+						Libraries: []types.Package{
+							{
+								Name:    "lodash",
+								Version: "4.17.21",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.filePath,
+				Content:  bytes.NewReader([]byte(tt.content)),
+			})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+	}{
+		{
+			name:     "no command",
+			manifest: "name: foo\napi_version: 1\n",
+			wantErr:  "command is required",
+		},
+		{
+			name:     "unsupported API version",
+			manifest: "name: foo\napi_version: 2\ncommand: foo\n",
+			wantErr:  "unsupported API version 2",
+		},
+		{
+			name:     "built-in analyzer",
+			manifest: "name: alpine\napi_version: 1\ncommand: foo\n",
+			wantErr:  `name "alpine" conflicts with an existing analyzer`,
+		},
+		{
+			name:     "invalid required file",
+			manifest: "name: foo\napi_version: 1\ncommand: foo\nrequired_files: ['(']\n",
+			wantErr:  "invalid required file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "plugin.yaml"), []byte(tt.manifest), 0644))
+			_, err := external.Load(dir)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
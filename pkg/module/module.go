@@ -20,6 +20,7 @@ import (
 	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
 	"github.com/zhanglimao/trivy/pkg/log"
 	tapi "github.com/zhanglimao/trivy/pkg/module/api"
+	"github.com/zhanglimao/trivy/pkg/module/external"
 	"github.com/zhanglimao/trivy/pkg/module/serialize"
 	"github.com/zhanglimao/trivy/pkg/scanner/post"
 	"github.com/zhanglimao/trivy/pkg/types"
@@ -99,6 +100,9 @@ func readMemory(mem api.Memory, offset, size uint32) []byte {
 type Options struct {
 	Dir            string
 	EnabledModules []string
+
+	// AnalyzerPluginDir holds analyzer plugins running as external processes
+	AnalyzerPluginDir string
 }
 
 type Manager struct {
@@ -106,12 +110,16 @@ type Manager struct {
 	modules        []*wasmModule
 	dir            string
 	enabledModules []string
+
+	analyzerPlugins   []*external.Analyzer
+	analyzerPluginDir string
 }
 
 func NewManager(ctx context.Context, opts Options) (*Manager, error) {
 	m := &Manager{
-		dir:            opts.Dir,
-		enabledModules: opts.EnabledModules,
+		dir:               opts.Dir,
+		enabledModules:    opts.EnabledModules,
+		analyzerPluginDir: opts.AnalyzerPluginDir,
 	}
 
 	// Create a new WebAssembly Runtime.
@@ -122,6 +130,10 @@ func NewManager(ctx context.Context, opts Options) (*Manager, error) {
 		return nil, xerrors.Errorf("module load error: %w", err)
 	}
 
+	if err := m.loadAnalyzerPlugins(); err != nil {
+		return nil, xerrors.Errorf("analyzer plugin load error: %w", err)
+	}
+
 	return m, nil
 }
 
@@ -172,20 +184,71 @@ func (m *Manager) loadModules(ctx context.Context) error {
 	return nil
 }
 
+// loadAnalyzerPlugins loads plugin.yaml in each sub-directory of the analyzer plugin dir
+func (m *Manager) loadAnalyzerPlugins() error {
+	if m.analyzerPluginDir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(m.analyzerPluginDir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return xerrors.Errorf("read dir error: %w", err)
+	}
+	log.Logger.Debugf("Analyzer plugin dir: %s", m.analyzerPluginDir)
+
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		p, err := external.Load(filepath.Join(m.analyzerPluginDir, e.Name()))
+		if err != nil {
+			return xerrors.Errorf("analyzer plugin %s: %w", e.Name(), err)
+		}
+
+		// Skip loading plugins if not in the list of enable modules flag.
+		if len(m.enabledModules) > 0 && !slices.Contains(m.enabledModules, p.Name()) {
+			continue
+		}
+		if slices.ContainsFunc(m.modules, func(mod *wasmModule) bool {
+			return mod.isAnalyzer && mod.Name() == p.Name()
+		}) || slices.ContainsFunc(m.analyzerPlugins, func(loaded *external.Analyzer) bool {
+			return loaded.Name() == p.Name()
+		}) {
+			return xerrors.Errorf("analyzer plugin %s: name %q is used by another module or plugin", e.Name(), p.Name())
+		}
+
+		log.Logger.Infof("Analyzer plugin %s@v%d loaded", p.Name(), p.Version())
+		m.analyzerPlugins = append(m.analyzerPlugins, p)
+	}
+	return nil
+}
+
 func (m *Manager) Register() {
 	for _, mod := range m.modules {
 		mod.Register()
 	}
+	for _, p := range m.analyzerPlugins {
+		log.Logger.Debugf("Registering analyzer plugin: %s@v%d", p.Name(), p.Version())
+		analyzer.RegisterAnalyzer(p)
+	}
 }
 
 func (m *Manager) Deregister() {
 	for _, mod := range m.modules {
 		analyzer.DeregisterAnalyzer(analyzer.Type(mod.Name()))
 		post.DeregisterPostScanner(mod.Name())
+		post.DeregisterPostReporter(mod.Name())
+	}
+	for _, p := range m.analyzerPlugins {
+		analyzer.DeregisterAnalyzer(p.Type())
 	}
 }
 
 func (m *Manager) Close(ctx context.Context) error {
+	for _, p := range m.analyzerPlugins {
+		p.Close()
+	}
 	return m.cache.Close(ctx)
 }
 
@@ -266,15 +329,17 @@ type wasmModule struct {
 	version       int
 	requiredFiles []*regexp.Regexp
 
-	isAnalyzer    bool
-	isPostScanner bool
-	postScanSpec  serialize.PostScanSpec
+	isAnalyzer     bool
+	isPostScanner  bool
+	isPostReporter bool
+	postScanSpec   serialize.PostScanSpec
 
 	// Exported functions
-	analyze  api.Function
-	postScan api.Function
-	malloc   api.Function // TinyGo specific
-	free     api.Function // TinyGo specific
+	analyze    api.Function
+	postScan   api.Function
+	postReport api.Function
+	malloc     api.Function // TinyGo specific
+	free       api.Function // TinyGo specific
 }
 
 func newWASMPlugin(ctx context.Context, ccache wazero.CompilationCache, code []byte) (*wasmModule, error) {
@@ -357,6 +422,11 @@ func newWASMPlugin(ctx context.Context, ccache wazero.CompilationCache, code []b
 		return nil, xerrors.Errorf("failed to check if the module is a post scanner: %w", err)
 	}
 
+	isPostReporter, err := moduleIsPostReporter(ctx, mod)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to check if the module is a post reporter: %w", err)
+	}
+
 	// Get exported functions by WASM module
 	analyzeFunc := mod.ExportedFunction("analyze")
 	if analyzeFunc == nil {
@@ -366,6 +436,10 @@ func newWASMPlugin(ctx context.Context, ccache wazero.CompilationCache, code []b
 	if postScanFunc == nil {
 		return nil, xerrors.New("post_scan() must be exported")
 	}
+	postReportFunc := mod.ExportedFunction("post_report")
+	if isPostReporter && postReportFunc == nil {
+		return nil, xerrors.New("post_report() must be exported")
+	}
 
 	var requiredFiles []*regexp.Regexp
 	if isAnalyzer {
@@ -392,14 +466,16 @@ func newWASMPlugin(ctx context.Context, ccache wazero.CompilationCache, code []b
 		version:       version,
 		requiredFiles: requiredFiles,
 
-		isAnalyzer:    isAnalyzer,
-		isPostScanner: isPostScanner,
-		postScanSpec:  postScanSpec,
+		isAnalyzer:     isAnalyzer,
+		isPostScanner:  isPostScanner,
+		isPostReporter: isPostReporter,
+		postScanSpec:   postScanSpec,
 
-		analyze:  analyzeFunc,
-		postScan: postScanFunc,
-		malloc:   malloc,
-		free:     free,
+		analyze:    analyzeFunc,
+		postScan:   postScanFunc,
+		postReport: postReportFunc,
+		malloc:     malloc,
+		free:       free,
 	}, nil
 }
 
@@ -413,6 +489,10 @@ func (m *wasmModule) Register() {
 		log.Logger.Debugf("Registering custom post scanner in %s@v%d", m.name, m.version)
 		post.RegisterPostScanner(m)
 	}
+	if m.isPostReporter {
+		log.Logger.Debugf("Registering custom post reporter in %s@v%d", m.name, m.version)
+		post.RegisterPostReporter(m)
+	}
 }
 
 func (m *wasmModule) Close(ctx context.Context) error {
@@ -526,6 +606,38 @@ func (m *wasmModule) PostScan(ctx context.Context, results types.Results) (types
 	return results, nil
 }
 
+// PostReport lets the module add, annotate or suppress findings after filtering
+func (m *wasmModule) PostReport(ctx context.Context, report types.Report) (types.Report, error) {
+	arg := serialize.PostReportInput{
+		ArtifactName: report.ArtifactName,
+		ArtifactType: string(report.ArtifactType),
+		Results:      lo.Map(report.Results, func(r types.Result, _ int) serialize.Result { return serialize.Result(r) }),
+	}
+
+	inputPtr, inputSize, err := marshal(ctx, m.mod, m.malloc, arg)
+	if err != nil {
+		return types.Report{}, xerrors.Errorf("post report marshal error: %w", err)
+	}
+	defer m.free.Call(ctx, inputPtr) //nolint: errcheck
+
+	res, err := m.postReport.Call(ctx, inputPtr, inputSize)
+	if err != nil {
+		return types.Report{}, xerrors.Errorf("post report invocation error: %w", err)
+	} else if len(res) != 1 {
+		return types.Report{}, xerrors.New("invalid signature: post_report")
+	}
+
+	var actions serialize.PostReportActions
+	if err = unmarshal(m.mod.Memory(), res[0], &actions); err != nil {
+		return types.Report{}, xerrors.Errorf("post report unmarshal error: %w", err)
+	}
+
+	if err = applyPostReportActions(m.name, report.Results, actions); err != nil {
+		return types.Report{}, xerrors.Errorf("post report action error: %w", err)
+	}
+	return report, nil
+}
+
 func findIDs(ids []string, results types.Results) serialize.Results {
 	var filtered serialize.Results
 	for _, result := range results {
@@ -708,6 +820,14 @@ func moduleIsPostScanner(ctx context.Context, mod api.Module) (bool, error) {
 	return isType(ctx, mod, "is_post_scanner")
 }
 
+// moduleIsPostReporter returns false for modules built before post_report() was introduced
+func moduleIsPostReporter(ctx context.Context, mod api.Module) (bool, error) {
+	if mod.ExportedFunction("is_post_reporter") == nil {
+		return false, nil
+	}
+	return isType(ctx, mod, "is_post_reporter")
+}
+
 func isType(ctx context.Context, mod api.Module, name string) (bool, error) {
 	isFunc := mod.ExportedFunction(name)
 	if isFunc == nil {
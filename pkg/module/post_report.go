@@ -0,0 +1,133 @@
+package module
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+	tapi "github.com/zhanglimao/trivy/pkg/module/api"
+	"github.com/zhanglimao/trivy/pkg/module/serialize"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// applyPostReportActions changes the results in place.
+// An empty target in ANNOTATE and SUPPRESS matches all the results.
+func applyPostReportActions(source string, results types.Results, actions serialize.PostReportActions) error {
+	for _, action := range actions {
+		switch action.Type {
+		case tapi.ActionAdd:
+			if err := addFinding(results, action); err != nil {
+				return err
+			}
+		case tapi.ActionAnnotate:
+			if action.ID == "" {
+				return xerrors.New("ID is required to annotate findings")
+			}
+			for i := range results {
+				if action.Target == "" || action.Target == results[i].Target {
+					annotate(&results[i], action)
+				}
+			}
+		case tapi.ActionSuppress:
+			if action.ID == "" {
+				return xerrors.New("ID is required to suppress findings")
+			} else if action.Reason == "" {
+				return xerrors.Errorf("reason is required to suppress %s", action.ID)
+			}
+			for i := range results {
+				if action.Target == "" || action.Target == results[i].Target {
+					suppress(&results[i], source, action)
+				}
+			}
+		default:
+			return xerrors.Errorf("unknown action: %s", action.Type)
+		}
+	}
+	return nil
+}
+
+func addFinding(results types.Results, action serialize.PostReportAction) error {
+	if (action.Vulnerability == nil) == (action.Misconfiguration == nil) {
+		return xerrors.New("either a vulnerability or a misconfiguration is required to add a finding")
+	}
+	for i := range results {
+		if results[i].Target != action.Target {
+			continue
+		}
+		if action.Vulnerability != nil {
+			results[i].Vulnerabilities = append(results[i].Vulnerabilities, *action.Vulnerability)
+		} else {
+			results[i].Misconfigurations = append(results[i].Misconfigurations, *action.Misconfiguration)
+		}
+		return nil
+	}
+	return xerrors.Errorf("target not found: %s", action.Target)
+}
+
+func matchVulnerability(v types.DetectedVulnerability, action serialize.PostReportAction) bool {
+	return v.VulnerabilityID == action.ID && (action.PkgName == "" || v.PkgName == action.PkgName)
+}
+
+func matchMisconfiguration(m types.DetectedMisconfiguration, action serialize.PostReportAction) bool {
+	return m.ID == action.ID || m.AVDID == action.ID
+}
+
+func annotate(result *types.Result, action serialize.PostReportAction) {
+	for i, v := range result.Vulnerabilities {
+		if matchVulnerability(v, action) {
+			result.Vulnerabilities[i].Annotations = mergeAnnotations(v.Annotations, action.Annotations)
+		}
+	}
+	for i, m := range result.Misconfigurations {
+		if matchMisconfiguration(m, action) {
+			result.Misconfigurations[i].Annotations = mergeAnnotations(m.Annotations, action.Annotations)
+		}
+	}
+}
+
+func mergeAnnotations(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+func suppress(result *types.Result, source string, action serialize.PostReportAction) {
+	var vulns []types.DetectedVulnerability
+	for _, v := range result.Vulnerabilities {
+		if !matchVulnerability(v, action) {
+			vulns = append(vulns, v)
+			continue
+		}
+		log.Logger.Debugf("Module %s suppressed %s in %s: %s", source, v.VulnerabilityID, result.Target, action.Reason)
+		result.Suppressed = append(result.Suppressed, types.SuppressedFinding{
+			Type:    types.FindingVulnerability,
+			ID:      v.VulnerabilityID,
+			PkgName: v.PkgName,
+			Reason:  action.Reason,
+			Source:  source,
+		})
+	}
+	result.Vulnerabilities = vulns
+
+	var misconfs []types.DetectedMisconfiguration
+	for _, m := range result.Misconfigurations {
+		if !matchMisconfiguration(m, action) {
+			misconfs = append(misconfs, m)
+			continue
+		}
+		log.Logger.Debugf("Module %s suppressed %s in %s: %s", source, m.ID, result.Target, action.Reason)
+		result.Suppressed = append(result.Suppressed, types.SuppressedFinding{
+			Type:   types.FindingMisconfiguration,
+			ID:     m.ID,
+			Reason: action.Reason,
+			Source: source,
+		})
+	}
+	result.Misconfigurations = misconfs
+}
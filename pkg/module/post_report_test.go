@@ -0,0 +1,159 @@
+package module
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tapi "github.com/zhanglimao/trivy/pkg/module/api"
+	"github.com/zhanglimao/trivy/pkg/module/serialize"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func Test_applyPostReportActions(t *testing.T) {
+	newResults := func() types.Results {
+		return types.Results{
+			{
+				Target: "alpine:3.17 (alpine 3.17.3)",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID: "CVE-2023-0001",
+						PkgName:         "openssl",
+					},
+					{
+						VulnerabilityID: "CVE-2023-0001",
+						PkgName:         "libssl3",
+					},
+				},
+			},
+			{
+				Target: "Dockerfile",
+				Misconfigurations: []types.DetectedMisconfiguration{
+					{
+						ID:    "DS002",
+						AVDID: "AVD-DS-0002",
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		actions serialize.PostReportActions
+		want    func(results types.Results) types.Results
+		wantErr string
+	}{
+		{
+			name: "add",
+			actions: serialize.PostReportActions{
+				{
+					Type:   tapi.ActionAdd,
+					Target: "Dockerfile",
+					Misconfiguration: &types.DetectedMisconfiguration{
+						ID: "ORG-001",
+					},
+				},
+			},
+			want: func(results types.Results) types.Results {
+				results[1].Misconfigurations = append(results[1].Misconfigurations, types.DetectedMisconfiguration{ID: "ORG-001"})
+				return results
+			},
+		},
+		{
+			name: "annotate all targets",
+			actions: serialize.PostReportActions{
+				{
+					Type:        tapi.ActionAnnotate,
+					ID:          "CVE-2023-0001",
+					PkgName:     "openssl",
+					Annotations: map[string]string{"owner": "team-a"},
+				},
+				{
+					Type:        tapi.ActionAnnotate,
+					ID:          "AVD-DS-0002",
+					Annotations: map[string]string{"ticket": "SEC-1"},
+				},
+			},
+			want: func(results types.Results) types.Results {
+				results[0].Vulnerabilities[0].Annotations = map[string]string{"owner": "team-a"}
+				results[1].Misconfigurations[0].Annotations = map[string]string{"ticket": "SEC-1"}
+				return results
+			},
+		},
+		{
+			name: "suppress",
+			actions: serialize.PostReportActions{
+				{
+					Type:   tapi.ActionSuppress,
+					Target: "alpine:3.17 (alpine 3.17.3)",
+					ID:     "CVE-2023-0001",
+					Reason: "not reachable",
+				},
+			},
+			want: func(results types.Results) types.Results {
+				results[0].Vulnerabilities = nil
+				results[0].Suppressed = []types.SuppressedFinding{
+					{
+						Type:    types.FindingVulnerability,
+						ID:      "CVE-2023-0001",
+						PkgName: "openssl",
+						Reason:  "not reachable",
+						Source:  "test",
+					},
+					{
+						Type:    types.FindingVulnerability,
+						ID:      "CVE-2023-0001",
+						PkgName: "libssl3",
+						Reason:  "not reachable",
+						Source:  "test",
+					},
+				}
+				return results
+			},
+		},
+		{
+			name: "suppress without reason",
+			actions: serialize.PostReportActions{
+				{
+					Type: tapi.ActionSuppress,
+					ID:   "CVE-2023-0001",
+				},
+			},
+			wantErr: "reason is required",
+		},
+		{
+			name: "add to unknown target",
+			actions: serialize.PostReportActions{
+				{
+					Type:          tapi.ActionAdd,
+					Target:        "unknown",
+					Vulnerability: &types.DetectedVulnerability{VulnerabilityID: "ORG-002"},
+				},
+			},
+			wantErr: "target not found",
+		},
+		{
+			name: "unknown action",
+			actions: serialize.PostReportActions{
+				{
+					Type: "UPSERT",
+				},
+			},
+			wantErr: "unknown action",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := newResults()
+			err := applyPostReportActions("test", results, tt.actions)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want(newResults()), results)
+		})
+	}
+}
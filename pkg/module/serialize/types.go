@@ -50,3 +50,41 @@ type Results []Result
 
 //easyjson:json
 type Result types.Result
+
+// PostReportInput is passed to the module after filtering
+//
+//easyjson:json
+type PostReportInput struct {
+	ArtifactName string
+	ArtifactType string
+	Results      Results
+}
+
+type PostReportActionType string
+
+//easyjson:json
+type PostReportActions []PostReportAction
+
+// PostReportAction is returned by the module to change the report.
+type PostReportAction struct {
+	// Type is one of ADD, ANNOTATE and SUPPRESS
+	Type PostReportActionType
+
+	// Target is the result to change
+	Target string
+
+	// ID is the vulnerability or misconfiguration ID to annotate or suppress.
+	// Vulnerabilities can be narrowed down by PkgName.
+	ID      string
+	PkgName string
+
+	// Reason is required to suppress findings and recorded in the report
+	Reason string
+
+	// Annotations are added to the matched findings
+	Annotations map[string]string
+
+	// Vulnerability or Misconfiguration is added to the target
+	Vulnerability    *types.DetectedVulnerability
+	Misconfiguration *types.DetectedMisconfiguration
+}
@@ -4,15 +4,14 @@ package serialize
 
 import (
 	json "encoding/json"
-	time "time"
-
+	types2 "github.com/aquasecurity/trivy-db/pkg/types"
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
-
-	types2 "github.com/aquasecurity/trivy-db/pkg/types"
+	digest "github.com/zhanglimao/trivy/pkg/digest"
 	types1 "github.com/zhanglimao/trivy/pkg/fanal/types"
 	types "github.com/zhanglimao/trivy/pkg/types"
+	time "time"
 )
 
 // suppress unused package warning
@@ -23,7 +22,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize(in *jlexer.Lexer, out *StringSlice) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize(in *jlexer.Lexer, out *StringSlice) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -51,7 +50,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize(out *jwriter.Writer, in StringSlice) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize(out *jwriter.Writer, in StringSlice) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -69,27 +68,27 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v StringSlice) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize(&w, v)
+	easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v StringSlice) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize(w, v)
+	easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *StringSlice) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize(&r, v)
+	easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *StringSlice) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize(l, v)
+	easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize(l, v)
 }
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize1(in *jlexer.Lexer, out *Results) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize1(in *jlexer.Lexer, out *Results) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -117,7 +116,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize1(in *jle
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize1(out *jwriter.Writer, in Results) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize1(out *jwriter.Writer, in Results) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -135,27 +134,27 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize1(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v Results) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize1(&w, v)
+	easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Results) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize1(w, v)
+	easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Results) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize1(&r, v)
+	easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Results) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize1(l, v)
+	easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize1(l, v)
 }
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize2(in *jlexer.Lexer, out *Result) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize2(in *jlexer.Lexer, out *Result) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -197,7 +196,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize2(in *jle
 				}
 				for !in.IsDelim(']') {
 					var v7 types1.Package
-					easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes(in, &v7)
+					easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes(in, &v7)
 					out.Packages = append(out.Packages, v7)
 					in.WantComma()
 				}
@@ -220,7 +219,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize2(in *jle
 				}
 				for !in.IsDelim(']') {
 					var v8 types.DetectedVulnerability
-					easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes(in, &v8)
+					easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes(in, &v8)
 					out.Vulnerabilities = append(out.Vulnerabilities, v8)
 					in.WantComma()
 				}
@@ -234,7 +233,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize2(in *jle
 				if out.MisconfSummary == nil {
 					out.MisconfSummary = new(types.MisconfSummary)
 				}
-				easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes1(in, out.MisconfSummary)
+				easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes1(in, out.MisconfSummary)
 			}
 		case "Misconfigurations":
 			if in.IsNull() {
@@ -253,7 +252,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize2(in *jle
 				}
 				for !in.IsDelim(']') {
 					var v9 types.DetectedMisconfiguration
-					easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes2(in, &v9)
+					easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes2(in, &v9)
 					out.Misconfigurations = append(out.Misconfigurations, v9)
 					in.WantComma()
 				}
@@ -276,12 +275,35 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize2(in *jle
 				}
 				for !in.IsDelim(']') {
 					var v10 types1.SecretFinding
-					easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes1(in, &v10)
+					easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes1(in, &v10)
 					out.Secrets = append(out.Secrets, v10)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "Licenses":
+			if in.IsNull() {
+				in.Skip()
+				out.Licenses = nil
+			} else {
+				in.Delim('[')
+				if out.Licenses == nil {
+					if !in.IsDelim(']') {
+						out.Licenses = make([]types.DetectedLicense, 0, 0)
+					} else {
+						out.Licenses = []types.DetectedLicense{}
+					}
+				} else {
+					out.Licenses = (out.Licenses)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v11 types.DetectedLicense
+					easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes3(in, &v11)
+					out.Licenses = append(out.Licenses, v11)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		case "CustomResources":
 			if in.IsNull() {
 				in.Skip()
@@ -298,9 +320,65 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize2(in *jle
 					out.CustomResources = (out.CustomResources)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v11 types1.CustomResource
-					easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes2(in, &v11)
-					out.CustomResources = append(out.CustomResources, v11)
+					var v12 types1.CustomResource
+					easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes2(in, &v12)
+					out.CustomResources = append(out.CustomResources, v12)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "Drifts":
+			if in.IsNull() {
+				in.Skip()
+				out.Drifts = nil
+			} else {
+				in.Delim('[')
+				if out.Drifts == nil {
+					if !in.IsDelim(']') {
+						out.Drifts = make([]types.DetectedDrift, 0, 0)
+					} else {
+						out.Drifts = []types.DetectedDrift{}
+					}
+				} else {
+					out.Drifts = (out.Drifts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 types.DetectedDrift
+					easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes4(in, &v13)
+					out.Drifts = append(out.Drifts, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "DependencyGraph":
+			if in.IsNull() {
+				in.Skip()
+				out.DependencyGraph = nil
+			} else {
+				if out.DependencyGraph == nil {
+					out.DependencyGraph = new(types.DependencyGraph)
+				}
+				easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes5(in, out.DependencyGraph)
+			}
+		case "Suppressed":
+			if in.IsNull() {
+				in.Skip()
+				out.Suppressed = nil
+			} else {
+				in.Delim('[')
+				if out.Suppressed == nil {
+					if !in.IsDelim(']') {
+						out.Suppressed = make([]types.SuppressedFinding, 0, 0)
+					} else {
+						out.Suppressed = []types.SuppressedFinding{}
+					}
+				} else {
+					out.Suppressed = (out.Suppressed)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v14 types.SuppressedFinding
+					easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes6(in, &v14)
+					out.Suppressed = append(out.Suppressed, v14)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -315,7 +393,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize2(in *jle
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize2(out *jwriter.Writer, in Result) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize2(out *jwriter.Writer, in Result) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -339,11 +417,11 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize2(out *jw
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v12, v13 := range in.Packages {
-				if v12 > 0 {
+			for v15, v16 := range in.Packages {
+				if v15 > 0 {
 					out.RawByte(',')
 				}
-				easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes(out, v13)
+				easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes(out, v16)
 			}
 			out.RawByte(']')
 		}
@@ -353,11 +431,11 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize2(out *jw
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v14, v15 := range in.Vulnerabilities {
-				if v14 > 0 {
+			for v17, v18 := range in.Vulnerabilities {
+				if v17 > 0 {
 					out.RawByte(',')
 				}
-				easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes(out, v15)
+				easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes(out, v18)
 			}
 			out.RawByte(']')
 		}
@@ -365,18 +443,18 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize2(out *jw
 	if in.MisconfSummary != nil {
 		const prefix string = ",\"MisconfSummary\":"
 		out.RawString(prefix)
-		easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes1(out, *in.MisconfSummary)
+		easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes1(out, *in.MisconfSummary)
 	}
 	if len(in.Misconfigurations) != 0 {
 		const prefix string = ",\"Misconfigurations\":"
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v16, v17 := range in.Misconfigurations {
-				if v16 > 0 {
+			for v19, v20 := range in.Misconfigurations {
+				if v19 > 0 {
 					out.RawByte(',')
 				}
-				easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes2(out, v17)
+				easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes2(out, v20)
 			}
 			out.RawByte(']')
 		}
@@ -386,11 +464,25 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize2(out *jw
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v18, v19 := range in.Secrets {
-				if v18 > 0 {
+			for v21, v22 := range in.Secrets {
+				if v21 > 0 {
+					out.RawByte(',')
+				}
+				easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes1(out, v22)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Licenses) != 0 {
+		const prefix string = ",\"Licenses\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v23, v24 := range in.Licenses {
+				if v23 > 0 {
 					out.RawByte(',')
 				}
-				easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes1(out, v19)
+				easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes3(out, v24)
 			}
 			out.RawByte(']')
 		}
@@ -400,11 +492,44 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize2(out *jw
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v20, v21 := range in.CustomResources {
-				if v20 > 0 {
+			for v25, v26 := range in.CustomResources {
+				if v25 > 0 {
+					out.RawByte(',')
+				}
+				easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes2(out, v26)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Drifts) != 0 {
+		const prefix string = ",\"Drifts\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v27, v28 := range in.Drifts {
+				if v27 > 0 {
+					out.RawByte(',')
+				}
+				easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes4(out, v28)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.DependencyGraph != nil {
+		const prefix string = ",\"DependencyGraph\":"
+		out.RawString(prefix)
+		easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes5(out, *in.DependencyGraph)
+	}
+	if len(in.Suppressed) != 0 {
+		const prefix string = ",\"Suppressed\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v29, v30 := range in.Suppressed {
+				if v29 > 0 {
 					out.RawByte(',')
 				}
-				easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes2(out, v21)
+				easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes6(out, v30)
 			}
 			out.RawByte(']')
 		}
@@ -415,27 +540,27 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize2(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v Result) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize2(&w, v)
+	easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Result) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize2(w, v)
+	easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Result) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize2(&r, v)
+	easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Result) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize2(l, v)
+	easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize2(l, v)
 }
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes2(in *jlexer.Lexer, out *types1.CustomResource) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes6(in *jlexer.Lexer, out *types.SuppressedFinding) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -456,18 +581,14 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes2(in *jlexer.L
 		switch key {
 		case "Type":
 			out.Type = string(in.String())
-		case "FilePath":
-			out.FilePath = string(in.String())
-		case "Layer":
-			easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes3(in, &out.Layer)
-		case "Data":
-			if m, ok := out.Data.(easyjson.Unmarshaler); ok {
-				m.UnmarshalEasyJSON(in)
-			} else if m, ok := out.Data.(json.Unmarshaler); ok {
-				_ = m.UnmarshalJSON(in.Raw())
-			} else {
-				out.Data = in.Interface()
-			}
+		case "ID":
+			out.ID = string(in.String())
+		case "PkgName":
+			out.PkgName = string(in.String())
+		case "Reason":
+			out.Reason = string(in.String())
+		case "Source":
+			out.Source = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -478,39 +599,59 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes2(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes2(out *jwriter.Writer, in types1.CustomResource) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes6(out *jwriter.Writer, in types.SuppressedFinding) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
+	if in.Type != "" {
 		const prefix string = ",\"Type\":"
+		first = false
 		out.RawString(prefix[1:])
 		out.String(string(in.Type))
 	}
-	{
-		const prefix string = ",\"FilePath\":"
-		out.RawString(prefix)
-		out.String(string(in.FilePath))
+	if in.ID != "" {
+		const prefix string = ",\"ID\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ID))
 	}
-	{
-		const prefix string = ",\"Layer\":"
-		out.RawString(prefix)
-		easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes3(out, in.Layer)
+	if in.PkgName != "" {
+		const prefix string = ",\"PkgName\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.PkgName))
 	}
-	{
-		const prefix string = ",\"Data\":"
-		out.RawString(prefix)
-		if m, ok := in.Data.(easyjson.Marshaler); ok {
-			m.MarshalEasyJSON(out)
-		} else if m, ok := in.Data.(json.Marshaler); ok {
-			out.Raw(m.MarshalJSON())
+	if in.Reason != "" {
+		const prefix string = ",\"Reason\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
 		} else {
-			out.Raw(json.Marshal(in.Data))
+			out.RawString(prefix)
 		}
+		out.String(string(in.Reason))
+	}
+	if in.Source != "" {
+		const prefix string = ",\"Source\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Source))
 	}
 	out.RawByte('}')
 }
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes3(in *jlexer.Lexer, out *types1.Layer) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes5(in *jlexer.Lexer, out *types.DependencyGraph) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -529,10 +670,52 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes3(in *jlexer.L
 			continue
 		}
 		switch key {
-		case "Digest":
-			out.Digest = string(in.String())
-		case "DiffID":
-			out.DiffID = string(in.String())
+		case "Relationships":
+			if in.IsNull() {
+				in.Skip()
+				out.Relationships = nil
+			} else {
+				in.Delim('[')
+				if out.Relationships == nil {
+					if !in.IsDelim(']') {
+						out.Relationships = make([]types.Relationship, 0, 1)
+					} else {
+						out.Relationships = []types.Relationship{}
+					}
+				} else {
+					out.Relationships = (out.Relationships)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v31 types.Relationship
+					easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes7(in, &v31)
+					out.Relationships = append(out.Relationships, v31)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "VulnerableOrigins":
+			if in.IsNull() {
+				in.Skip()
+				out.VulnerableOrigins = nil
+			} else {
+				in.Delim('[')
+				if out.VulnerableOrigins == nil {
+					if !in.IsDelim(']') {
+						out.VulnerableOrigins = make([]types.VulnerableOrigin, 0, 1)
+					} else {
+						out.VulnerableOrigins = []types.VulnerableOrigin{}
+					}
+				} else {
+					out.VulnerableOrigins = (out.VulnerableOrigins)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v32 types.VulnerableOrigin
+					easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes8(in, &v32)
+					out.VulnerableOrigins = append(out.VulnerableOrigins, v32)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -543,29 +726,47 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes3(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes3(out *jwriter.Writer, in types1.Layer) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes5(out *jwriter.Writer, in types.DependencyGraph) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if in.Digest != "" {
-		const prefix string = ",\"Digest\":"
+	if len(in.Relationships) != 0 {
+		const prefix string = ",\"Relationships\":"
 		first = false
 		out.RawString(prefix[1:])
-		out.String(string(in.Digest))
+		{
+			out.RawByte('[')
+			for v33, v34 := range in.Relationships {
+				if v33 > 0 {
+					out.RawByte(',')
+				}
+				easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes7(out, v34)
+			}
+			out.RawByte(']')
+		}
 	}
-	if in.DiffID != "" {
-		const prefix string = ",\"DiffID\":"
+	if len(in.VulnerableOrigins) != 0 {
+		const prefix string = ",\"VulnerableOrigins\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.DiffID))
+		{
+			out.RawByte('[')
+			for v35, v36 := range in.VulnerableOrigins {
+				if v35 > 0 {
+					out.RawByte(',')
+				}
+				easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes8(out, v36)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes1(in *jlexer.Lexer, out *types1.SecretFinding) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes8(in *jlexer.Lexer, out *types.VulnerableOrigin) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -584,20 +785,33 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes1(in *jlexer.L
 			continue
 		}
 		switch key {
-		case "RuleID":
-			out.RuleID = string(in.String())
-		case "Category":
-			out.Category = types1.SecretRuleCategory(in.String())
-		case "Severity":
-			out.Severity = string(in.String())
-		case "Title":
-			out.Title = string(in.String())
-		case "StartLine":
-			out.StartLine = int(in.Int())
-		case "EndLine":
-			out.EndLine = int(in.Int())
-		case "Match":
-			out.Match = string(in.String())
+		case "PkgID":
+			out.PkgID = string(in.String())
+		case "Direct":
+			out.Direct = bool(in.Bool())
+		case "Origins":
+			if in.IsNull() {
+				in.Skip()
+				out.Origins = nil
+			} else {
+				in.Delim('[')
+				if out.Origins == nil {
+					if !in.IsDelim(']') {
+						out.Origins = make([]string, 0, 4)
+					} else {
+						out.Origins = []string{}
+					}
+				} else {
+					out.Origins = (out.Origins)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v37 string
+					v37 = string(in.String())
+					out.Origins = append(out.Origins, v37)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -608,48 +822,705 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes1(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes1(out *jwriter.Writer, in types1.SecretFinding) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes8(out *jwriter.Writer, in types.VulnerableOrigin) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"RuleID\":"
+		const prefix string = ",\"PkgID\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.RuleID))
+		out.String(string(in.PkgID))
 	}
-	{
-		const prefix string = ",\"Category\":"
+	if in.Direct {
+		const prefix string = ",\"Direct\":"
 		out.RawString(prefix)
-		out.String(string(in.Category))
+		out.Bool(bool(in.Direct))
 	}
-	{
-		const prefix string = ",\"Severity\":"
+	if len(in.Origins) != 0 {
+		const prefix string = ",\"Origins\":"
 		out.RawString(prefix)
-		out.String(string(in.Severity))
+		{
+			out.RawByte('[')
+			for v38, v39 := range in.Origins {
+				if v38 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v39))
+			}
+			out.RawByte(']')
+		}
 	}
-	{
-		const prefix string = ",\"Title\":"
-		out.RawString(prefix)
-		out.String(string(in.Title))
+	out.RawByte('}')
+}
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes7(in *jlexer.Lexer, out *types.Relationship) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "PkgID":
+			out.PkgID = string(in.String())
+		case "DependsOn":
+			if in.IsNull() {
+				in.Skip()
+				out.DependsOn = nil
+			} else {
+				in.Delim('[')
+				if out.DependsOn == nil {
+					if !in.IsDelim(']') {
+						out.DependsOn = make([]string, 0, 4)
+					} else {
+						out.DependsOn = []string{}
+					}
+				} else {
+					out.DependsOn = (out.DependsOn)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v40 string
+					v40 = string(in.String())
+					out.DependsOn = append(out.DependsOn, v40)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes7(out *jwriter.Writer, in types.Relationship) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"PkgID\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.PkgID))
+	}
+	{
+		const prefix string = ",\"DependsOn\":"
+		out.RawString(prefix)
+		if in.DependsOn == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v41, v42 := range in.DependsOn {
+				if v41 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v42))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes4(in *jlexer.Lexer, out *types.DetectedDrift) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Kind":
+			out.Kind = types.DriftKind(in.String())
+		case "Type":
+			out.Type = string(in.String())
+		case "PkgName":
+			out.PkgName = string(in.String())
+		case "FilePath":
+			out.FilePath = string(in.String())
+		case "BaselineVersion":
+			out.BaselineVersion = string(in.String())
+		case "InstalledVersion":
+			out.InstalledVersion = string(in.String())
+		case "BaselineDigest":
+			out.BaselineDigest = string(in.String())
+		case "Digest":
+			out.Digest = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes4(out *jwriter.Writer, in types.DetectedDrift) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Kind\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"Type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	if in.PkgName != "" {
+		const prefix string = ",\"PkgName\":"
+		out.RawString(prefix)
+		out.String(string(in.PkgName))
+	}
+	if in.FilePath != "" {
+		const prefix string = ",\"FilePath\":"
+		out.RawString(prefix)
+		out.String(string(in.FilePath))
+	}
+	if in.BaselineVersion != "" {
+		const prefix string = ",\"BaselineVersion\":"
+		out.RawString(prefix)
+		out.String(string(in.BaselineVersion))
+	}
+	if in.InstalledVersion != "" {
+		const prefix string = ",\"InstalledVersion\":"
+		out.RawString(prefix)
+		out.String(string(in.InstalledVersion))
+	}
+	if in.BaselineDigest != "" {
+		const prefix string = ",\"BaselineDigest\":"
+		out.RawString(prefix)
+		out.String(string(in.BaselineDigest))
+	}
+	if in.Digest != "" {
+		const prefix string = ",\"Digest\":"
+		out.RawString(prefix)
+		out.String(string(in.Digest))
+	}
+	out.RawByte('}')
+}
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes2(in *jlexer.Lexer, out *types1.CustomResource) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Type":
+			out.Type = string(in.String())
+		case "FilePath":
+			out.FilePath = string(in.String())
+		case "Layer":
+			easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes3(in, &out.Layer)
+		case "Data":
+			if m, ok := out.Data.(easyjson.Unmarshaler); ok {
+				m.UnmarshalEasyJSON(in)
+			} else if m, ok := out.Data.(json.Unmarshaler); ok {
+				_ = m.UnmarshalJSON(in.Raw())
+			} else {
+				out.Data = in.Interface()
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes2(out *jwriter.Writer, in types1.CustomResource) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"FilePath\":"
+		out.RawString(prefix)
+		out.String(string(in.FilePath))
+	}
+	{
+		const prefix string = ",\"Layer\":"
+		out.RawString(prefix)
+		easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes3(out, in.Layer)
+	}
+	{
+		const prefix string = ",\"Data\":"
+		out.RawString(prefix)
+		if m, ok := in.Data.(easyjson.Marshaler); ok {
+			m.MarshalEasyJSON(out)
+		} else if m, ok := in.Data.(json.Marshaler); ok {
+			out.Raw(m.MarshalJSON())
+		} else {
+			out.Raw(json.Marshal(in.Data))
+		}
+	}
+	out.RawByte('}')
+}
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes3(in *jlexer.Lexer, out *types1.Layer) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Digest":
+			out.Digest = string(in.String())
+		case "DiffID":
+			out.DiffID = string(in.String())
+		case "CreatedBy":
+			out.CreatedBy = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes3(out *jwriter.Writer, in types1.Layer) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Digest != "" {
+		const prefix string = ",\"Digest\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Digest))
+	}
+	if in.DiffID != "" {
+		const prefix string = ",\"DiffID\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.DiffID))
+	}
+	if in.CreatedBy != "" {
+		const prefix string = ",\"CreatedBy\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CreatedBy))
+	}
+	out.RawByte('}')
+}
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes3(in *jlexer.Lexer, out *types.DetectedLicense) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Severity":
+			out.Severity = string(in.String())
+		case "Category":
+			out.Category = types1.LicenseCategory(in.String())
+		case "PkgName":
+			out.PkgName = string(in.String())
+		case "FilePath":
+			out.FilePath = string(in.String())
+		case "Name":
+			out.Name = string(in.String())
+		case "Confidence":
+			out.Confidence = float64(in.Float64())
+		case "Link":
+			out.Link = string(in.String())
+		case "Layer":
+			easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes3(in, &out.Layer)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes3(out *jwriter.Writer, in types.DetectedLicense) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Severity\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Severity))
+	}
+	{
+		const prefix string = ",\"Category\":"
+		out.RawString(prefix)
+		out.String(string(in.Category))
+	}
+	{
+		const prefix string = ",\"PkgName\":"
+		out.RawString(prefix)
+		out.String(string(in.PkgName))
+	}
+	{
+		const prefix string = ",\"FilePath\":"
+		out.RawString(prefix)
+		out.String(string(in.FilePath))
+	}
+	{
+		const prefix string = ",\"Name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"Confidence\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Confidence))
+	}
+	{
+		const prefix string = ",\"Link\":"
+		out.RawString(prefix)
+		out.String(string(in.Link))
+	}
+	if true {
+		const prefix string = ",\"Layer\":"
+		out.RawString(prefix)
+		easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes3(out, in.Layer)
+	}
+	out.RawByte('}')
+}
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes1(in *jlexer.Lexer, out *types1.SecretFinding) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "RuleID":
+			out.RuleID = string(in.String())
+		case "Category":
+			out.Category = types1.SecretRuleCategory(in.String())
+		case "Severity":
+			out.Severity = string(in.String())
+		case "Title":
+			out.Title = string(in.String())
+		case "StartLine":
+			out.StartLine = int(in.Int())
+		case "EndLine":
+			out.EndLine = int(in.Int())
+		case "Code":
+			easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes4(in, &out.Code)
+		case "Match":
+			out.Match = string(in.String())
+		case "Layer":
+			easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes3(in, &out.Layer)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes1(out *jwriter.Writer, in types1.SecretFinding) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"RuleID\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.RuleID))
+	}
+	{
+		const prefix string = ",\"Category\":"
+		out.RawString(prefix)
+		out.String(string(in.Category))
+	}
+	{
+		const prefix string = ",\"Severity\":"
+		out.RawString(prefix)
+		out.String(string(in.Severity))
+	}
+	{
+		const prefix string = ",\"Title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"StartLine\":"
+		out.RawString(prefix)
+		out.Int(int(in.StartLine))
+	}
+	{
+		const prefix string = ",\"EndLine\":"
+		out.RawString(prefix)
+		out.Int(int(in.EndLine))
+	}
+	{
+		const prefix string = ",\"Code\":"
+		out.RawString(prefix)
+		easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes4(out, in.Code)
+	}
+	{
+		const prefix string = ",\"Match\":"
+		out.RawString(prefix)
+		out.String(string(in.Match))
+	}
+	if true {
+		const prefix string = ",\"Layer\":"
+		out.RawString(prefix)
+		easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes3(out, in.Layer)
+	}
+	out.RawByte('}')
+}
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes4(in *jlexer.Lexer, out *types1.Code) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Lines":
+			if in.IsNull() {
+				in.Skip()
+				out.Lines = nil
+			} else {
+				in.Delim('[')
+				if out.Lines == nil {
+					if !in.IsDelim(']') {
+						out.Lines = make([]types1.Line, 0, 0)
+					} else {
+						out.Lines = []types1.Line{}
+					}
+				} else {
+					out.Lines = (out.Lines)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v43 types1.Line
+					easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes5(in, &v43)
+					out.Lines = append(out.Lines, v43)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes4(out *jwriter.Writer, in types1.Code) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Lines\":"
+		out.RawString(prefix[1:])
+		if in.Lines == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v44, v45 := range in.Lines {
+				if v44 > 0 {
+					out.RawByte(',')
+				}
+				easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes5(out, v45)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes5(in *jlexer.Lexer, out *types1.Line) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Number":
+			out.Number = int(in.Int())
+		case "Content":
+			out.Content = string(in.String())
+		case "IsCause":
+			out.IsCause = bool(in.Bool())
+		case "Annotation":
+			out.Annotation = string(in.String())
+		case "Truncated":
+			out.Truncated = bool(in.Bool())
+		case "Highlighted":
+			out.Highlighted = string(in.String())
+		case "FirstCause":
+			out.FirstCause = bool(in.Bool())
+		case "LastCause":
+			out.LastCause = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
 	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes5(out *jwriter.Writer, in types1.Line) {
+	out.RawByte('{')
+	first := true
+	_ = first
 	{
-		const prefix string = ",\"StartLine\":"
+		const prefix string = ",\"Number\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Number))
+	}
+	{
+		const prefix string = ",\"Content\":"
 		out.RawString(prefix)
-		out.Int(int(in.StartLine))
+		out.String(string(in.Content))
 	}
 	{
-		const prefix string = ",\"EndLine\":"
+		const prefix string = ",\"IsCause\":"
 		out.RawString(prefix)
-		out.Int(int(in.EndLine))
+		out.Bool(bool(in.IsCause))
 	}
 	{
-		const prefix string = ",\"Match\":"
+		const prefix string = ",\"Annotation\":"
 		out.RawString(prefix)
-		out.String(string(in.Match))
+		out.String(string(in.Annotation))
+	}
+	{
+		const prefix string = ",\"Truncated\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Truncated))
+	}
+	if in.Highlighted != "" {
+		const prefix string = ",\"Highlighted\":"
+		out.RawString(prefix)
+		out.String(string(in.Highlighted))
+	}
+	{
+		const prefix string = ",\"FirstCause\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.FirstCause))
+	}
+	{
+		const prefix string = ",\"LastCause\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.LastCause))
 	}
 	out.RawByte('}')
 }
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes2(in *jlexer.Lexer, out *types.DetectedMisconfiguration) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes2(in *jlexer.Lexer, out *types.DetectedMisconfiguration) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -672,6 +1543,8 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes2(in *jlexer.Lexer,
 			out.Type = string(in.String())
 		case "ID":
 			out.ID = string(in.String())
+		case "AVDID":
+			out.AVDID = string(in.String())
 		case "Title":
 			out.Title = string(in.String())
 		case "Description":
@@ -704,9 +1577,9 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes2(in *jlexer.Lexer,
 					out.References = (out.References)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v22 string
-					v22 = string(in.String())
-					out.References = append(out.References, v22)
+					var v46 string
+					v46 = string(in.String())
+					out.References = append(out.References, v46)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -714,9 +1587,29 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes2(in *jlexer.Lexer,
 		case "Status":
 			out.Status = types.MisconfStatus(in.String())
 		case "Layer":
-			easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes3(in, &out.Layer)
+			easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes3(in, &out.Layer)
 		case "CauseMetadata":
-			easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes4(in, &out.CauseMetadata)
+			easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes6(in, &out.CauseMetadata)
+		case "Annotations":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Annotations = make(map[string]string)
+				} else {
+					out.Annotations = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v47 string
+					v47 = string(in.String())
+					(out.Annotations)[key] = v47
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
 		case "Traces":
 			if in.IsNull() {
 				in.Skip()
@@ -733,9 +1626,9 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes2(in *jlexer.Lexer,
 					out.Traces = (out.Traces)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v23 string
-					v23 = string(in.String())
-					out.Traces = append(out.Traces, v23)
+					var v48 string
+					v48 = string(in.String())
+					out.Traces = append(out.Traces, v48)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -750,7 +1643,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes2(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes2(out *jwriter.Writer, in types.DetectedMisconfiguration) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes2(out *jwriter.Writer, in types.DetectedMisconfiguration) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -770,6 +1663,16 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes2(out *jwriter.Writ
 		}
 		out.String(string(in.ID))
 	}
+	if in.AVDID != "" {
+		const prefix string = ",\"AVDID\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.AVDID))
+	}
 	if in.Title != "" {
 		const prefix string = ",\"Title\":"
 		if first {
@@ -860,11 +1763,11 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes2(out *jwriter.Writ
 		}
 		{
 			out.RawByte('[')
-			for v24, v25 := range in.References {
-				if v24 > 0 {
+			for v49, v50 := range in.References {
+				if v49 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v25))
+				out.String(string(v50))
 			}
 			out.RawByte(']')
 		}
@@ -887,7 +1790,7 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes2(out *jwriter.Writ
 		} else {
 			out.RawString(prefix)
 		}
-		easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes3(out, in.Layer)
+		easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes3(out, in.Layer)
 	}
 	if true {
 		const prefix string = ",\"CauseMetadata\":"
@@ -897,7 +1800,31 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes2(out *jwriter.Writ
 		} else {
 			out.RawString(prefix)
 		}
-		easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes4(out, in.CauseMetadata)
+		easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes6(out, in.CauseMetadata)
+	}
+	if len(in.Annotations) != 0 {
+		const prefix string = ",\"Annotations\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v51First := true
+			for v51Name, v51Value := range in.Annotations {
+				if v51First {
+					v51First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v51Name))
+				out.RawByte(':')
+				out.String(string(v51Value))
+			}
+			out.RawByte('}')
+		}
 	}
 	if len(in.Traces) != 0 {
 		const prefix string = ",\"Traces\":"
@@ -909,18 +1836,18 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes2(out *jwriter.Writ
 		}
 		{
 			out.RawByte('[')
-			for v26, v27 := range in.Traces {
-				if v26 > 0 {
+			for v52, v53 := range in.Traces {
+				if v52 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v27))
+				out.String(string(v53))
 			}
 			out.RawByte(']')
 		}
 	}
 	out.RawByte('}')
 }
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes4(in *jlexer.Lexer, out *types1.CauseMetadata) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes6(in *jlexer.Lexer, out *types1.CauseMetadata) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -950,7 +1877,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes4(in *jlexer.L
 		case "EndLine":
 			out.EndLine = int(in.Int())
 		case "Code":
-			easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes5(in, &out.Code)
+			easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes4(in, &out.Code)
 		default:
 			in.SkipRecursive()
 		}
@@ -961,7 +1888,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes4(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes4(out *jwriter.Writer, in types1.CauseMetadata) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes6(out *jwriter.Writer, in types1.CauseMetadata) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -985,210 +1912,45 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes4(out *jwriter
 		const prefix string = ",\"Service\":"
 		if first {
 			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.String(string(in.Service))
-	}
-	if in.StartLine != 0 {
-		const prefix string = ",\"StartLine\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.Int(int(in.StartLine))
-	}
-	if in.EndLine != 0 {
-		const prefix string = ",\"EndLine\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.Int(int(in.EndLine))
-	}
-	if true {
-		const prefix string = ",\"Code\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes5(out, in.Code)
-	}
-	out.RawByte('}')
-}
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes5(in *jlexer.Lexer, out *types1.Code) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "Lines":
-			if in.IsNull() {
-				in.Skip()
-				out.Lines = nil
-			} else {
-				in.Delim('[')
-				if out.Lines == nil {
-					if !in.IsDelim(']') {
-						out.Lines = make([]types1.Line, 0, 0)
-					} else {
-						out.Lines = []types1.Line{}
-					}
-				} else {
-					out.Lines = (out.Lines)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v28 types1.Line
-					easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes6(in, &v28)
-					out.Lines = append(out.Lines, v28)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes5(out *jwriter.Writer, in types1.Code) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"Lines\":"
-		out.RawString(prefix[1:])
-		if in.Lines == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v29, v30 := range in.Lines {
-				if v29 > 0 {
-					out.RawByte(',')
-				}
-				easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes6(out, v30)
-			}
-			out.RawByte(']')
-		}
-	}
-	out.RawByte('}')
-}
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes6(in *jlexer.Lexer, out *types1.Line) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "Number":
-			out.Number = int(in.Int())
-		case "Content":
-			out.Content = string(in.String())
-		case "IsCause":
-			out.IsCause = bool(in.Bool())
-		case "Annotation":
-			out.Annotation = string(in.String())
-		case "Truncated":
-			out.Truncated = bool(in.Bool())
-		case "Highlighted":
-			out.Highlighted = string(in.String())
-		case "FirstCause":
-			out.FirstCause = bool(in.Bool())
-		case "LastCause":
-			out.LastCause = bool(in.Bool())
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes6(out *jwriter.Writer, in types1.Line) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"Number\":"
-		out.RawString(prefix[1:])
-		out.Int(int(in.Number))
-	}
-	{
-		const prefix string = ",\"Content\":"
-		out.RawString(prefix)
-		out.String(string(in.Content))
-	}
-	{
-		const prefix string = ",\"IsCause\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.IsCause))
-	}
-	{
-		const prefix string = ",\"Annotation\":"
-		out.RawString(prefix)
-		out.String(string(in.Annotation))
-	}
-	{
-		const prefix string = ",\"Truncated\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.Truncated))
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Service))
 	}
-	if in.Highlighted != "" {
-		const prefix string = ",\"Highlighted\":"
-		out.RawString(prefix)
-		out.String(string(in.Highlighted))
+	if in.StartLine != 0 {
+		const prefix string = ",\"StartLine\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.StartLine))
 	}
-	{
-		const prefix string = ",\"FirstCause\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.FirstCause))
+	if in.EndLine != 0 {
+		const prefix string = ",\"EndLine\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.EndLine))
 	}
-	{
-		const prefix string = ",\"LastCause\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.LastCause))
+	if true {
+		const prefix string = ",\"Code\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes4(out, in.Code)
 	}
 	out.RawByte('}')
 }
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes1(in *jlexer.Lexer, out *types.MisconfSummary) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes1(in *jlexer.Lexer, out *types.MisconfSummary) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1223,7 +1985,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes1(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes1(out *jwriter.Writer, in types.MisconfSummary) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes1(out *jwriter.Writer, in types.MisconfSummary) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1244,7 +2006,7 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes1(out *jwriter.Writ
 	}
 	out.RawByte('}')
 }
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes(in *jlexer.Lexer, out *types.DetectedVulnerability) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes(in *jlexer.Lexer, out *types.DetectedVulnerability) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1281,9 +2043,9 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes(in *jlexer.Lexer,
 					out.VendorIDs = (out.VendorIDs)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v31 string
-					v31 = string(in.String())
-					out.VendorIDs = append(out.VendorIDs, v31)
+					var v54 string
+					v54 = string(in.String())
+					out.VendorIDs = append(out.VendorIDs, v54)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1299,7 +2061,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes(in *jlexer.Lexer,
 		case "FixedVersion":
 			out.FixedVersion = string(in.String())
 		case "Layer":
-			easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes3(in, &out.Layer)
+			easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes3(in, &out.Layer)
 		case "SeveritySource":
 			out.SeveritySource = types2.SourceID(in.String())
 		case "PrimaryURL":
@@ -1316,6 +2078,49 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes(in *jlexer.Lexer,
 				}
 				easyjson6601e8cdDecodeGithubComAquasecurityTrivyDbPkgTypes(in, out.DataSource)
 			}
+		case "Locations":
+			if in.IsNull() {
+				in.Skip()
+				out.Locations = nil
+			} else {
+				in.Delim('[')
+				if out.Locations == nil {
+					if !in.IsDelim(']') {
+						out.Locations = make([]types.VulnerabilityLocation, 0, 0)
+					} else {
+						out.Locations = []types.VulnerabilityLocation{}
+					}
+				} else {
+					out.Locations = (out.Locations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v55 types.VulnerabilityLocation
+					easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes9(in, &v55)
+					out.Locations = append(out.Locations, v55)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "Annotations":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Annotations = make(map[string]string)
+				} else {
+					out.Annotations = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v56 string
+					v56 = string(in.String())
+					(out.Annotations)[key] = v56
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
 		case "Custom":
 			if m, ok := out.Custom.(easyjson.Unmarshaler); ok {
 				m.UnmarshalEasyJSON(in)
@@ -1346,9 +2151,9 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes(in *jlexer.Lexer,
 					out.CweIDs = (out.CweIDs)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v32 string
-					v32 = string(in.String())
-					out.CweIDs = append(out.CweIDs, v32)
+					var v57 string
+					v57 = string(in.String())
+					out.CweIDs = append(out.CweIDs, v57)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1366,9 +2171,9 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes(in *jlexer.Lexer,
 				for !in.IsDelim('}') {
 					key := types2.SourceID(in.String())
 					in.WantColon()
-					var v33 types2.Severity
-					v33 = types2.Severity(in.Int())
-					(out.VendorSeverity)[key] = v33
+					var v58 types2.Severity
+					v58 = types2.Severity(in.Int())
+					(out.VendorSeverity)[key] = v58
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -1386,9 +2191,9 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes(in *jlexer.Lexer,
 				for !in.IsDelim('}') {
 					key := types2.SourceID(in.String())
 					in.WantColon()
-					var v34 types2.CVSS
-					easyjson6601e8cdDecodeGithubComAquasecurityTrivyDbPkgTypes1(in, &v34)
-					(out.CVSS)[key] = v34
+					var v59 types2.CVSS
+					easyjson6601e8cdDecodeGithubComAquasecurityTrivyDbPkgTypes1(in, &v59)
+					(out.CVSS)[key] = v59
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -1409,9 +2214,9 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes(in *jlexer.Lexer,
 					out.References = (out.References)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v35 string
-					v35 = string(in.String())
-					out.References = append(out.References, v35)
+					var v60 string
+					v60 = string(in.String())
+					out.References = append(out.References, v60)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1450,7 +2255,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgTypes(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes(out *jwriter.Writer, in types.DetectedVulnerability) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes(out *jwriter.Writer, in types.DetectedVulnerability) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1470,11 +2275,11 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes(out *jwriter.Write
 		}
 		{
 			out.RawByte('[')
-			for v36, v37 := range in.VendorIDs {
-				if v36 > 0 {
+			for v61, v62 := range in.VendorIDs {
+				if v61 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v37))
+				out.String(string(v62))
 			}
 			out.RawByte(']')
 		}
@@ -1537,7 +2342,7 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes(out *jwriter.Write
 		} else {
 			out.RawString(prefix)
 		}
-		easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes3(out, in.Layer)
+		easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes3(out, in.Layer)
 	}
 	if in.SeveritySource != "" {
 		const prefix string = ",\"SeveritySource\":"
@@ -1579,6 +2384,49 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes(out *jwriter.Write
 		}
 		easyjson6601e8cdEncodeGithubComAquasecurityTrivyDbPkgTypes(out, *in.DataSource)
 	}
+	if len(in.Locations) != 0 {
+		const prefix string = ",\"Locations\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v63, v64 := range in.Locations {
+				if v63 > 0 {
+					out.RawByte(',')
+				}
+				easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes9(out, v64)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Annotations) != 0 {
+		const prefix string = ",\"Annotations\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v65First := true
+			for v65Name, v65Value := range in.Annotations {
+				if v65First {
+					v65First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v65Name))
+				out.RawByte(':')
+				out.String(string(v65Value))
+			}
+			out.RawByte('}')
+		}
+	}
 	if in.Custom != nil {
 		const prefix string = ",\"Custom\":"
 		if first {
@@ -1635,11 +2483,11 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes(out *jwriter.Write
 		}
 		{
 			out.RawByte('[')
-			for v38, v39 := range in.CweIDs {
-				if v38 > 0 {
+			for v66, v67 := range in.CweIDs {
+				if v66 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v39))
+				out.String(string(v67))
 			}
 			out.RawByte(']')
 		}
@@ -1654,16 +2502,16 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes(out *jwriter.Write
 		}
 		{
 			out.RawByte('{')
-			v40First := true
-			for v40Name, v40Value := range in.VendorSeverity {
-				if v40First {
-					v40First = false
+			v68First := true
+			for v68Name, v68Value := range in.VendorSeverity {
+				if v68First {
+					v68First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v40Name))
+				out.String(string(v68Name))
 				out.RawByte(':')
-				out.Int(int(v40Value))
+				out.Int(int(v68Value))
 			}
 			out.RawByte('}')
 		}
@@ -1678,16 +2526,16 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes(out *jwriter.Write
 		}
 		{
 			out.RawByte('{')
-			v41First := true
-			for v41Name, v41Value := range in.CVSS {
-				if v41First {
-					v41First = false
+			v69First := true
+			for v69Name, v69Value := range in.CVSS {
+				if v69First {
+					v69First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v41Name))
+				out.String(string(v69Name))
 				out.RawByte(':')
-				easyjson6601e8cdEncodeGithubComAquasecurityTrivyDbPkgTypes1(out, v41Value)
+				easyjson6601e8cdEncodeGithubComAquasecurityTrivyDbPkgTypes1(out, v69Value)
 			}
 			out.RawByte('}')
 		}
@@ -1702,11 +2550,11 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgTypes(out *jwriter.Write
 		}
 		{
 			out.RawByte('[')
-			for v42, v43 := range in.References {
-				if v42 > 0 {
+			for v70, v71 := range in.References {
+				if v70 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v43))
+				out.String(string(v71))
 			}
 			out.RawByte(']')
 		}
@@ -1812,6 +2660,73 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyDbPkgTypes1(out *jwriter.Wr
 	}
 	out.RawByte('}')
 }
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes9(in *jlexer.Lexer, out *types.VulnerabilityLocation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Target":
+			out.Target = string(in.String())
+		case "PkgPath":
+			out.PkgPath = string(in.String())
+		case "Layer":
+			easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes3(in, &out.Layer)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes9(out *jwriter.Writer, in types.VulnerabilityLocation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Target != "" {
+		const prefix string = ",\"Target\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Target))
+	}
+	if in.PkgPath != "" {
+		const prefix string = ",\"PkgPath\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.PkgPath))
+	}
+	if true {
+		const prefix string = ",\"Layer\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes3(out, in.Layer)
+	}
+	out.RawByte('}')
+}
 func easyjson6601e8cdDecodeGithubComAquasecurityTrivyDbPkgTypes(in *jlexer.Lexer, out *types2.DataSource) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
@@ -1879,7 +2794,7 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyDbPkgTypes(out *jwriter.Wri
 	}
 	out.RawByte('}')
 }
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes(in *jlexer.Lexer, out *types1.Package) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes(in *jlexer.Lexer, out *types1.Package) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1934,13 +2849,15 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes(in *jlexer.Le
 					out.Licenses = (out.Licenses)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v44 string
-					v44 = string(in.String())
-					out.Licenses = append(out.Licenses, v44)
+					var v72 string
+					v72 = string(in.String())
+					out.Licenses = append(out.Licenses, v72)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "Maintainer":
+			out.Maintainer = string(in.String())
 		case "Modularitylabel":
 			out.Modularitylabel = string(in.String())
 		case "BuildInfo":
@@ -1951,9 +2868,9 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes(in *jlexer.Le
 				if out.BuildInfo == nil {
 					out.BuildInfo = new(types1.BuildInfo)
 				}
-				easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes7(in, out.BuildInfo)
+				easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes7(in, out.BuildInfo)
 			}
-		case "PkgRef":
+		case "Ref":
 			out.Ref = string(in.String())
 		case "Indirect":
 			out.Indirect = bool(in.Bool())
@@ -1973,17 +2890,42 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes(in *jlexer.Le
 					out.DependsOn = (out.DependsOn)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v45 string
-					v45 = string(in.String())
-					out.DependsOn = append(out.DependsOn, v45)
+					var v73 string
+					v73 = string(in.String())
+					out.DependsOn = append(out.DependsOn, v73)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
 		case "Layer":
-			easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes3(in, &out.Layer)
+			easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes3(in, &out.Layer)
 		case "FilePath":
 			out.FilePath = string(in.String())
+		case "Digest":
+			out.Digest = digest.Digest(in.String())
+		case "Locations":
+			if in.IsNull() {
+				in.Skip()
+				out.Locations = nil
+			} else {
+				in.Delim('[')
+				if out.Locations == nil {
+					if !in.IsDelim(']') {
+						out.Locations = make([]types1.Location, 0, 4)
+					} else {
+						out.Locations = []types1.Location{}
+					}
+				} else {
+					out.Locations = (out.Locations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v74 types1.Location
+					easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes8(in, &v74)
+					out.Locations = append(out.Locations, v74)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1994,7 +2936,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes(in *jlexer.Le
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes(out *jwriter.Writer, in types1.Package) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes(out *jwriter.Writer, in types1.Package) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2104,15 +3046,25 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes(out *jwriter.
 		}
 		{
 			out.RawByte('[')
-			for v46, v47 := range in.Licenses {
-				if v46 > 0 {
+			for v75, v76 := range in.Licenses {
+				if v75 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v47))
+				out.String(string(v76))
 			}
 			out.RawByte(']')
 		}
 	}
+	if in.Maintainer != "" {
+		const prefix string = ",\"Maintainer\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Maintainer))
+	}
 	if in.Modularitylabel != "" {
 		const prefix string = ",\"Modularitylabel\":"
 		if first {
@@ -2131,10 +3083,10 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes(out *jwriter.
 		} else {
 			out.RawString(prefix)
 		}
-		easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes7(out, *in.BuildInfo)
+		easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes7(out, *in.BuildInfo)
 	}
 	if in.Ref != "" {
-		const prefix string = ",\"PkgRef\":"
+		const prefix string = ",\"Ref\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
@@ -2161,40 +3113,124 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes(out *jwriter.
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('[')
-			for v48, v49 := range in.DependsOn {
-				if v48 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v49))
-			}
-			out.RawByte(']')
+		{
+			out.RawByte('[')
+			for v77, v78 := range in.DependsOn {
+				if v77 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v78))
+			}
+			out.RawByte(']')
+		}
+	}
+	if true {
+		const prefix string = ",\"Layer\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes3(out, in.Layer)
+	}
+	if in.FilePath != "" {
+		const prefix string = ",\"FilePath\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.FilePath))
+	}
+	if in.Digest != "" {
+		const prefix string = ",\"Digest\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Digest))
+	}
+	if len(in.Locations) != 0 {
+		const prefix string = ",\"Locations\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v79, v80 := range in.Locations {
+				if v79 > 0 {
+					out.RawByte(',')
+				}
+				easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes8(out, v80)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes8(in *jlexer.Lexer, out *types1.Location) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "StartLine":
+			out.StartLine = int(in.Int())
+		case "EndLine":
+			out.EndLine = int(in.Int())
+		default:
+			in.SkipRecursive()
 		}
+		in.WantComma()
 	}
-	if true {
-		const prefix string = ",\"Layer\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes3(out, in.Layer)
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
 	}
-	if in.FilePath != "" {
-		const prefix string = ",\"FilePath\":"
+}
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes8(out *jwriter.Writer, in types1.Location) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.StartLine != 0 {
+		const prefix string = ",\"StartLine\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int(int(in.StartLine))
+	}
+	if in.EndLine != 0 {
+		const prefix string = ",\"EndLine\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.FilePath))
+		out.Int(int(in.EndLine))
 	}
 	out.RawByte('}')
 }
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes7(in *jlexer.Lexer, out *types1.BuildInfo) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgFanalTypes7(in *jlexer.Lexer, out *types1.BuildInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2229,9 +3265,9 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes7(in *jlexer.L
 					out.ContentSets = (out.ContentSets)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v50 string
-					v50 = string(in.String())
-					out.ContentSets = append(out.ContentSets, v50)
+					var v81 string
+					v81 = string(in.String())
+					out.ContentSets = append(out.ContentSets, v81)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2250,7 +3286,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgFanalTypes7(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes7(out *jwriter.Writer, in types1.BuildInfo) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgFanalTypes7(out *jwriter.Writer, in types1.BuildInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2260,11 +3296,11 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes7(out *jwriter
 		out.RawString(prefix[1:])
 		{
 			out.RawByte('[')
-			for v51, v52 := range in.ContentSets {
-				if v51 > 0 {
+			for v82, v83 := range in.ContentSets {
+				if v82 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v52))
+				out.String(string(v83))
 			}
 			out.RawByte(']')
 		}
@@ -2291,7 +3327,7 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgFanalTypes7(out *jwriter
 	}
 	out.RawByte('}')
 }
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize3(in *jlexer.Lexer, out *PostScanSpec) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize3(in *jlexer.Lexer, out *PostScanSpec) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2328,9 +3364,9 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize3(in *jle
 					out.IDs = (out.IDs)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v53 string
-					v53 = string(in.String())
-					out.IDs = append(out.IDs, v53)
+					var v84 string
+					v84 = string(in.String())
+					out.IDs = append(out.IDs, v84)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2345,7 +3381,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize3(in *jle
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize3(out *jwriter.Writer, in PostScanSpec) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize3(out *jwriter.Writer, in PostScanSpec) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2361,11 +3397,11 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize3(out *jw
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v54, v55 := range in.IDs {
-				if v54 > 0 {
+			for v85, v86 := range in.IDs {
+				if v85 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v55))
+				out.String(string(v86))
 			}
 			out.RawByte(']')
 		}
@@ -2376,27 +3412,318 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize3(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v PostScanSpec) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize3(&w, v)
+	easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostScanSpec) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize3(w, v)
+	easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostScanSpec) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize3(&r, v)
+	easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostScanSpec) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize3(l, v)
+	easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize3(l, v)
+}
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize4(in *jlexer.Lexer, out *PostReportInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "ArtifactName":
+			out.ArtifactName = string(in.String())
+		case "ArtifactType":
+			out.ArtifactType = string(in.String())
+		case "Results":
+			(out.Results).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize4(out *jwriter.Writer, in PostReportInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"ArtifactName\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ArtifactName))
+	}
+	{
+		const prefix string = ",\"ArtifactType\":"
+		out.RawString(prefix)
+		out.String(string(in.ArtifactType))
+	}
+	{
+		const prefix string = ",\"Results\":"
+		out.RawString(prefix)
+		(in.Results).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PostReportInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PostReportInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PostReportInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PostReportInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize4(l, v)
+}
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize5(in *jlexer.Lexer, out *PostReportActions) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(PostReportActions, 0, 0)
+			} else {
+				*out = PostReportActions{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v87 PostReportAction
+			easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize6(in, &v87)
+			*out = append(*out, v87)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize5(out *jwriter.Writer, in PostReportActions) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v88, v89 := range in {
+			if v88 > 0 {
+				out.RawByte(',')
+			}
+			easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize6(out, v89)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PostReportActions) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PostReportActions) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PostReportActions) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PostReportActions) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize5(l, v)
+}
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize6(in *jlexer.Lexer, out *PostReportAction) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Type":
+			out.Type = PostReportActionType(in.String())
+		case "Target":
+			out.Target = string(in.String())
+		case "ID":
+			out.ID = string(in.String())
+		case "PkgName":
+			out.PkgName = string(in.String())
+		case "Reason":
+			out.Reason = string(in.String())
+		case "Annotations":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Annotations = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v90 string
+					v90 = string(in.String())
+					(out.Annotations)[key] = v90
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "Vulnerability":
+			if in.IsNull() {
+				in.Skip()
+				out.Vulnerability = nil
+			} else {
+				if out.Vulnerability == nil {
+					out.Vulnerability = new(types.DetectedVulnerability)
+				}
+				easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes(in, out.Vulnerability)
+			}
+		case "Misconfiguration":
+			if in.IsNull() {
+				in.Skip()
+				out.Misconfiguration = nil
+			} else {
+				if out.Misconfiguration == nil {
+					out.Misconfiguration = new(types.DetectedMisconfiguration)
+				}
+				easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgTypes2(in, out.Misconfiguration)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize6(out *jwriter.Writer, in PostReportAction) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"Target\":"
+		out.RawString(prefix)
+		out.String(string(in.Target))
+	}
+	{
+		const prefix string = ",\"ID\":"
+		out.RawString(prefix)
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"PkgName\":"
+		out.RawString(prefix)
+		out.String(string(in.PkgName))
+	}
+	{
+		const prefix string = ",\"Reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	{
+		const prefix string = ",\"Annotations\":"
+		out.RawString(prefix)
+		if in.Annotations == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v91First := true
+			for v91Name, v91Value := range in.Annotations {
+				if v91First {
+					v91First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v91Name))
+				out.RawByte(':')
+				out.String(string(v91Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"Vulnerability\":"
+		out.RawString(prefix)
+		if in.Vulnerability == nil {
+			out.RawString("null")
+		} else {
+			easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes(out, *in.Vulnerability)
+		}
+	}
+	{
+		const prefix string = ",\"Misconfiguration\":"
+		out.RawString(prefix)
+		if in.Misconfiguration == nil {
+			out.RawString("null")
+		} else {
+			easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgTypes2(out, *in.Misconfiguration)
+		}
+	}
+	out.RawByte('}')
 }
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize4(in *jlexer.Lexer, out *AnalysisResult) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize7(in *jlexer.Lexer, out *AnalysisResult) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2431,9 +3758,9 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize4(in *jle
 					out.CustomResources = (out.CustomResources)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v56 CustomResource
-					easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize5(in, &v56)
-					out.CustomResources = append(out.CustomResources, v56)
+					var v92 CustomResource
+					easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize8(in, &v92)
+					out.CustomResources = append(out.CustomResources, v92)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2448,7 +3775,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize4(in *jle
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize4(out *jwriter.Writer, in AnalysisResult) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize7(out *jwriter.Writer, in AnalysisResult) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2459,11 +3786,11 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize4(out *jw
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v57, v58 := range in.CustomResources {
-				if v57 > 0 {
+			for v93, v94 := range in.CustomResources {
+				if v93 > 0 {
 					out.RawByte(',')
 				}
-				easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize5(out, v58)
+				easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize8(out, v94)
 			}
 			out.RawByte(']')
 		}
@@ -2474,27 +3801,27 @@ func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize4(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v AnalysisResult) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize4(&w, v)
+	easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AnalysisResult) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize4(w, v)
+	easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AnalysisResult) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize4(&r, v)
+	easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AnalysisResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize4(l, v)
+	easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize7(l, v)
 }
-func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize5(in *jlexer.Lexer, out *CustomResource) {
+func easyjson6601e8cdDecodeGithubComZhanglimaoTrivyPkgModuleSerialize8(in *jlexer.Lexer, out *CustomResource) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2535,7 +3862,7 @@ func easyjson6601e8cdDecodeGithubComAquasecurityTrivyPkgModuleSerialize5(in *jle
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComAquasecurityTrivyPkgModuleSerialize5(out *jwriter.Writer, in CustomResource) {
+func easyjson6601e8cdEncodeGithubComZhanglimaoTrivyPkgModuleSerialize8(out *jwriter.Writer, in CustomResource) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -134,6 +134,30 @@ func _post_scan(ptr, size uint32) uint64 {
 	return marshal(results)
 }
 
+//export is_post_reporter
+func _isPostReporter() uint64 {
+	if _, ok := module.(api.PostReporter); !ok {
+		return 0
+	}
+	return 1
+}
+
+//export post_report
+func _post_report(ptr, size uint32) uint64 {
+	var input serialize.PostReportInput
+	if err := unmarshal(ptr, size, &input); err != nil {
+		Error(fmt.Sprintf("post report error: %s", err))
+		return 0
+	}
+
+	actions, err := module.(api.PostReporter).PostReport(input)
+	if err != nil {
+		Error(fmt.Sprintf("post report error: %s", err))
+		return 0
+	}
+	return marshal(actions)
+}
+
 func marshal(v easyjson.Marshaler) uint64 {
 	b, err := easyjson.Marshal(v)
 	if err != nil {
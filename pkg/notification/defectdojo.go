@@ -0,0 +1,114 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+const (
+	// defectDojoScanType is the name of the parser for Trivy JSON reports in DefectDojo
+	defectDojoScanType = "Trivy Scan"
+
+	// defectDojoProductType is used when DefectDojo creates the product
+	defectDojoProductType = "Trivy"
+
+	defaultDefectDojoEngagement = "Trivy"
+)
+
+// DefectDojo imports the JSON report with the import-scan API.
+// The product and the engagement are created if they don't exist.
+type DefectDojo struct {
+	url        string
+	token      string
+	product    string
+	engagement string
+	client     *http.Client
+}
+
+// NewDefectDojo returns a DefectDojo publisher
+func NewDefectDojo(opt PublishOption) DefectDojo {
+	engagement := opt.DefectDojoEngagement
+	if engagement == "" {
+		engagement = defaultDefectDojoEngagement
+	}
+	return DefectDojo{
+		url:        strings.TrimSuffix(opt.DefectDojoURL, "/"),
+		token:      opt.DefectDojoToken,
+		product:    opt.DefectDojoProduct,
+		engagement: engagement,
+		client:     &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+func (d DefectDojo) Name() string {
+	return "DefectDojo"
+}
+
+// Publish imports the report into the engagement and closes the findings not found in this scan
+func (d DefectDojo) Publish(ctx context.Context, report types.Report) error {
+	product := d.product
+	if product == "" {
+		product = report.ArtifactName
+	}
+
+	b, err := json.Marshal(report)
+	if err != nil {
+		return xerrors.Errorf("json encode error: %w", err)
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	fields := [][2]string{
+		{"scan_type", defectDojoScanType},
+		{"product_type_name", defectDojoProductType},
+		{"product_name", product},
+		{"engagement_name", d.engagement},
+		{"auto_create_context", "true"},
+		{"close_old_findings", "true"},
+		{"active", "true"},
+		{"verified", "false"},
+	}
+	for _, f := range fields {
+		if err = w.WriteField(f[0], f[1]); err != nil {
+			return xerrors.Errorf("multipart error: %w", err)
+		}
+	}
+	fw, err := w.CreateFormFile("file", "trivy.json")
+	if err != nil {
+		return xerrors.Errorf("multipart error: %w", err)
+	}
+	if _, err = fw.Write(b); err != nil {
+		return xerrors.Errorf("multipart error: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return xerrors.Errorf("multipart error: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url+"/api/v2/import-scan/", &body)
+	if err != nil {
+		return xerrors.Errorf("request error: %w", err)
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("Authorization", "Token "+d.token)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return xerrors.Errorf("DefectDojo API error: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return xerrors.Errorf("DefectDojo API error: %s", resp.Status)
+	}
+	return nil
+}
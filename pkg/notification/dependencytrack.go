@@ -0,0 +1,143 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/sbom/cyclonedx"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// dtrackPollInterval is the interval to check if the BOM has been processed
+var dtrackPollInterval = 2 * time.Second
+
+// DependencyTrack uploads the report as CycloneDX.
+// The BOM is uploaded first so that the project exists, and then the vulnerabilities are uploaded as VEX.
+type DependencyTrack struct {
+	url        string
+	apiKey     string
+	project    string
+	version    string
+	appVersion string
+	client     *http.Client
+}
+
+// NewDependencyTrack returns a Dependency-Track publisher
+func NewDependencyTrack(opt PublishOption) DependencyTrack {
+	return DependencyTrack{
+		url:        strings.TrimSuffix(opt.DependencyTrackURL, "/"),
+		apiKey:     opt.DependencyTrackAPIKey,
+		project:    opt.DependencyTrackProject,
+		version:    opt.DependencyTrackVersion,
+		appVersion: opt.AppVersion,
+		client:     &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+type dtrackBOMRequest struct {
+	ProjectName    string `json:"projectName"`
+	ProjectVersion string `json:"projectVersion,omitempty"`
+	AutoCreate     bool   `json:"autoCreate,omitempty"`
+	BOM            string `json:"bom,omitempty"`
+	VEX            string `json:"vex,omitempty"`
+}
+
+type dtrackTokenResponse struct {
+	Token string `json:"token"`
+}
+
+type dtrackProcessingResponse struct {
+	Processing bool `json:"processing"`
+}
+
+func (d DependencyTrack) Name() string {
+	return "Dependency-Track"
+}
+
+// Publish uploads the SBOM and the VEX
+func (d DependencyTrack) Publish(ctx context.Context, report types.Report) error {
+	project := d.project
+	if project == "" {
+		project = report.ArtifactName
+	}
+
+	bom, err := cyclonedx.NewMarshaler(d.appVersion).Marshal(report)
+	if err != nil {
+		return xerrors.Errorf("CycloneDX marshal error: %w", err)
+	}
+	var buf bytes.Buffer
+	if err = cdx.NewBOMEncoder(&buf, cdx.BOMFileFormatJSON).Encode(bom); err != nil {
+		return xerrors.Errorf("failed to encode bom: %w", err)
+	}
+	doc := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	var res dtrackTokenResponse
+	err = d.do(ctx, http.MethodPut, "/api/v1/bom", dtrackBOMRequest{
+		ProjectName:    project,
+		ProjectVersion: d.version,
+		AutoCreate:     true,
+		BOM:            doc,
+	}, &res)
+	if err != nil {
+		return xerrors.Errorf("unable to upload the BOM: %w", err)
+	}
+
+	if bom.Vulnerabilities == nil || len(*bom.Vulnerabilities) == 0 {
+		return nil
+	}
+
+	// VEX is applied to the components of the project, which are available after the BOM is processed
+	if err = d.wait(ctx, res.Token); err != nil {
+		return xerrors.Errorf("BOM processing error: %w", err)
+	}
+
+	err = d.do(ctx, http.MethodPut, "/api/v1/vex", dtrackBOMRequest{
+		ProjectName:    project,
+		ProjectVersion: d.version,
+		VEX:            doc,
+	}, nil)
+	if err != nil {
+		return xerrors.Errorf("unable to upload the VEX: %w", err)
+	}
+	return nil
+}
+
+func (d DependencyTrack) wait(ctx context.Context, token string) error {
+	if token == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	for {
+		var res dtrackProcessingResponse
+		if err := d.do(ctx, http.MethodGet, fmt.Sprintf("/api/v1/event/token/%s", token), nil, &res); err != nil {
+			return err
+		}
+		if !res.Processing {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(dtrackPollInterval):
+		}
+	}
+}
+
+func (d DependencyTrack) do(ctx context.Context, method, path string, in, out any) error {
+	header := http.Header{}
+	header.Set("X-Api-Key", d.apiKey)
+	if err := sendJSON(ctx, d.client, method, d.url+path, header, in, out); err != nil {
+		return xerrors.Errorf("Dependency-Track API error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+const githubAPIURL = "https://api.github.com"
+
+// The fingerprint is hidden in the issue body
+var githubFingerprintRegexp = regexp.MustCompile(`<!-- trivy-fingerprint: ([0-9a-f]+) -->`)
+
+type githubTracker struct {
+	url    string
+	repo   string
+	token  string
+	client *http.Client
+}
+
+func newGitHubTracker(opt IssueOption) githubTracker {
+	url := opt.URL
+	if url == "" {
+		url = githubAPIURL
+	}
+	return githubTracker{
+		url:    strings.TrimSuffix(url, "/"),
+		repo:   opt.Project,
+		token:  opt.Token,
+		client: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+type githubIssueRequest struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+type githubIssueResponse struct {
+	Number      int       `json:"number"`
+	Body        string    `json:"body"`
+	State       string    `json:"state"`
+	PullRequest *struct{} `json:"pull_request,omitempty"`
+}
+
+func (t githubTracker) find(ctx context.Context) (map[string]existingIssue, error) {
+	issues := map[string]existingIssue{}
+	for page := 1; ; page++ {
+		var res []githubIssueResponse
+		path := fmt.Sprintf("/repos/%s/issues?labels=%s&state=all&per_page=100&page=%d", t.repo, IssueLabel, page)
+		if err := t.do(ctx, http.MethodGet, path, nil, &res); err != nil {
+			return nil, err
+		}
+		if len(res) == 0 {
+			return issues, nil
+		}
+		for _, issue := range res {
+			m := githubFingerprintRegexp.FindStringSubmatch(issue.Body)
+			if issue.PullRequest != nil || len(m) == 0 {
+				continue
+			}
+			issues[m[1]] = existingIssue{
+				ID:   strconv.Itoa(issue.Number),
+				Open: issue.State == "open",
+			}
+		}
+	}
+}
+
+func (t githubTracker) create(ctx context.Context, issue Issue) error {
+	return t.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/issues", t.repo), githubIssueRequest{
+		Title:  issue.Title,
+		Body:   githubBody(issue),
+		Labels: []string{IssueLabel},
+	}, nil)
+}
+
+func (t githubTracker) update(ctx context.Context, id string, issue Issue) error {
+	return t.do(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/issues/%s", t.repo, id), githubIssueRequest{
+		Title: issue.Title,
+		Body:  githubBody(issue),
+	}, nil)
+}
+
+func (t githubTracker) do(ctx context.Context, method, path string, in, out any) error {
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+	if t.token != "" {
+		header.Set("Authorization", "Bearer "+t.token)
+	}
+	if err := sendJSON(ctx, t.client, method, t.url+path, header, in, out); err != nil {
+		return xerrors.Errorf("GitHub API error: %w", err)
+	}
+	return nil
+}
+
+func githubBody(issue Issue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Trivy found the following vulnerabilities in `%s` of `%s`.\n\n", issue.Target, issue.ArtifactName)
+	b.WriteString("| Vulnerability | Severity | Package | Installed | Fixed | Title |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	for _, v := range issue.Findings {
+		id := v.VulnerabilityID
+		if v.PrimaryURL != "" {
+			id = fmt.Sprintf("[%s](%s)", id, v.PrimaryURL)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n", id, v.Severity, v.PkgName,
+			v.InstalledVersion, v.FixedVersion, strings.ReplaceAll(v.Title, "|", `\|`))
+	}
+	fmt.Fprintf(&b, "\n<!-- trivy-fingerprint: %s -->\n", issue.Fingerprint)
+	return b.String()
+}
@@ -0,0 +1,200 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// Issue trackers
+const (
+	TrackerGitHub = "github"
+	TrackerJira   = "jira"
+)
+
+// Issue granularities
+const (
+	IssuePerVulnerability = "vulnerability"
+	IssuePerTarget        = "target"
+)
+
+// IssueLabel is attached to all the issues filed by Trivy
+const IssueLabel = "trivy"
+
+// IssueOption holds the options for filing issues
+type IssueOption struct {
+	Tracker string
+
+	// URL is the API URL of GitHub Enterprise or the base URL of Jira
+	URL string
+
+	// Project is "owner/repo" for GitHub and the project key for Jira
+	Project string
+
+	// User authenticates to Jira with the API token. The token is sent as a bearer token if empty.
+	User  string
+	Token string
+
+	// Per is the granularity of issues, "vulnerability" or "target"
+	Per        string
+	Severities []string
+}
+
+// Issue is opened or updated for findings sharing the fingerprint
+type Issue struct {
+	Fingerprint  string
+	Title        string
+	ArtifactName string
+	Target       string
+	Findings     []types.DetectedVulnerability
+}
+
+// existingIssue is an issue filed in a previous scan
+type existingIssue struct {
+	ID   string
+	Open bool
+}
+
+type tracker interface {
+	// find returns the issues filed by Trivy by fingerprint
+	find(ctx context.Context) (map[string]existingIssue, error)
+	create(ctx context.Context, issue Issue) error
+	update(ctx context.Context, id string, issue Issue) error
+}
+
+// FileIssues opens issues for new findings and updates the open issues of known findings.
+// Closed issues are left as they are so that triage decisions are respected.
+func FileIssues(ctx context.Context, opt IssueOption, report types.Report) error {
+	var t tracker
+	switch opt.Tracker {
+	case TrackerGitHub:
+		t = newGitHubTracker(opt)
+	case TrackerJira:
+		t = newJiraTracker(opt)
+	default:
+		return xerrors.Errorf("unknown issue tracker: %s", opt.Tracker)
+	}
+
+	issues := NewIssues(report, opt.Per, opt.Severities)
+	if len(issues) == 0 {
+		return nil
+	}
+
+	existing, err := t.find(ctx)
+	if err != nil {
+		return xerrors.Errorf("unable to find existing issues: %w", err)
+	}
+
+	var created, updated int
+	for _, issue := range issues {
+		e, ok := existing[issue.Fingerprint]
+		switch {
+		case !ok:
+			if err = t.create(ctx, issue); err != nil {
+				return xerrors.Errorf("unable to create an issue (%s): %w", issue.Title, err)
+			}
+			created++
+		case e.Open:
+			if err = t.update(ctx, e.ID, issue); err != nil {
+				return xerrors.Errorf("unable to update the issue %s: %w", e.ID, err)
+			}
+			updated++
+		default:
+			log.Logger.Debugf("Skipping the closed issue %s: %s", e.ID, issue.Title)
+		}
+	}
+	log.Logger.Infof("Filed issues in %s: %d created, %d updated", opt.Tracker, created, updated)
+	return nil
+}
+
+// NewIssues groups the vulnerabilities with the severities into issues
+func NewIssues(report types.Report, per string, severities []string) []Issue {
+	var issues []Issue
+	for _, r := range report.Results {
+		var vulns []types.DetectedVulnerability
+		for _, v := range r.Vulnerabilities {
+			if slices.Contains(severities, v.Severity) {
+				vulns = append(vulns, v)
+			}
+		}
+		if len(vulns) == 0 {
+			continue
+		}
+
+		if per == IssuePerTarget {
+			issues = append(issues, Issue{
+				Fingerprint:  fingerprint(report.ArtifactName, r.Target),
+				Title:        fmt.Sprintf("%d vulnerabilities in %s", len(vulns), r.Target),
+				ArtifactName: report.ArtifactName,
+				Target:       r.Target,
+				Findings:     vulns,
+			})
+			continue
+		}
+
+		for _, v := range vulns {
+			// The installed version is not a part of the fingerprint so that the issue is updated until the fix
+			issues = append(issues, Issue{
+				Fingerprint:  fingerprint(report.ArtifactName, r.Target, v.PkgName, v.PkgPath, v.VulnerabilityID),
+				Title:        fmt.Sprintf("%s (%s) in %s - %s", v.VulnerabilityID, v.Severity, v.PkgName, r.Target),
+				ArtifactName: report.ArtifactName,
+				Target:       r.Target,
+				Findings:     []types.DetectedVulnerability{v},
+			})
+		}
+	}
+	return issues
+}
+
+func fingerprint(fields ...string) string {
+	h := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return hex.EncodeToString(h[:8])
+}
+
+// sendJSON sends the JSON-encoded input, if any, and decodes the response into the output, if any
+func sendJSON(ctx context.Context, client *http.Client, method, url string, header http.Header, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return xerrors.Errorf("json encode error: %w", err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return xerrors.Errorf("request error: %w", err)
+	}
+	req.Header = header.Clone()
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return xerrors.Errorf("%s %s: %s", method, req.URL.Path, resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return xerrors.Errorf("json decode error: %w", err)
+	}
+	return nil
+}
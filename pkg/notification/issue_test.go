@@ -0,0 +1,214 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+var issueReport = types.Report{
+	ArtifactName: "alpine:3.17",
+	Results: types.Results{
+		{
+			Target: "alpine:3.17 (alpine 3.17.3)",
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "CVE-2023-0001",
+					PkgName:          "openssl",
+					InstalledVersion: "3.0.8-r0",
+					FixedVersion:     "3.0.8-r1",
+					Vulnerability:    dbTypes.Vulnerability{Severity: "CRITICAL"},
+				},
+				{
+					VulnerabilityID:  "CVE-2023-0002",
+					PkgName:          "busybox",
+					InstalledVersion: "1.35.0-r29",
+					Vulnerability:    dbTypes.Vulnerability{Severity: "HIGH"},
+				},
+				{
+					VulnerabilityID:  "CVE-2023-0003",
+					PkgName:          "busybox",
+					InstalledVersion: "1.35.0-r29",
+					Vulnerability:    dbTypes.Vulnerability{Severity: "LOW"},
+				},
+			},
+		},
+	},
+}
+
+func TestNewIssues(t *testing.T) {
+	tests := []struct {
+		name       string
+		per        string
+		wantTitles []string
+	}{
+		{
+			name: "per vulnerability",
+			per:  IssuePerVulnerability,
+			wantTitles: []string{
+				"CVE-2023-0001 (CRITICAL) in openssl - alpine:3.17 (alpine 3.17.3)",
+				"CVE-2023-0002 (HIGH) in busybox - alpine:3.17 (alpine 3.17.3)",
+			},
+		},
+		{
+			name: "per target",
+			per:  IssuePerTarget,
+			wantTitles: []string{
+				"2 vulnerabilities in alpine:3.17 (alpine 3.17.3)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := NewIssues(issueReport, tt.per, []string{"CRITICAL", "HIGH"})
+
+			var titles []string
+			fingerprints := map[string]struct{}{}
+			for _, issue := range issues {
+				titles = append(titles, issue.Title)
+				fingerprints[issue.Fingerprint] = struct{}{}
+			}
+			assert.Equal(t, tt.wantTitles, titles)
+			assert.Len(t, fingerprints, len(issues))
+		})
+	}
+
+	// The fingerprint doesn't depend on the installed version
+	fixed := issueReport
+	fixed.Results = types.Results{{Target: issueReport.Results[0].Target, Vulnerabilities: []types.DetectedVulnerability{issueReport.Results[0].Vulnerabilities[0]}}}
+	fixed.Results[0].Vulnerabilities[0].InstalledVersion = "3.0.8-r2"
+	assert.Equal(t, NewIssues(issueReport, IssuePerVulnerability, []string{"CRITICAL"})[0].Fingerprint,
+		NewIssues(fixed, IssuePerVulnerability, []string{"CRITICAL"})[0].Fingerprint)
+}
+
+// fakeTracker records the requests to the tracker
+type fakeTracker struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func (f *fakeTracker) record(r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+}
+
+func (f *fakeTracker) got() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sort.Strings(f.requests)
+	return f.requests
+}
+
+func TestFileIssues(t *testing.T) {
+	issues := NewIssues(issueReport, IssuePerVulnerability, []string{"CRITICAL", "HIGH"})
+	openssl, busybox := issues[0].Fingerprint, issues[1].Fingerprint
+
+	t.Run("github", func(t *testing.T) {
+		f := &fakeTracker{}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			f.record(r)
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			if r.Method != http.MethodGet {
+				return
+			}
+			var res []githubIssueResponse
+			if r.URL.Query().Get("page") == "1" {
+				assert.Equal(t, IssueLabel, r.URL.Query().Get("labels"))
+				res = []githubIssueResponse{
+					{
+						Number: 1,
+						Body:   "<!-- trivy-fingerprint: " + openssl + " -->",
+						State:  "open",
+					},
+					{
+						Number:      2,
+						Body:        "<!-- trivy-fingerprint: " + busybox + " -->",
+						State:       "open",
+						PullRequest: &struct{}{}, // pull requests are ignored
+					},
+				}
+			}
+			_ = json.NewEncoder(w).Encode(res)
+		}))
+		defer ts.Close()
+
+		err := FileIssues(context.Background(), IssueOption{
+			Tracker:    TrackerGitHub,
+			URL:        ts.URL,
+			Project:    "aquasecurity/trivy",
+			Token:      "token",
+			Per:        IssuePerVulnerability,
+			Severities: []string{"CRITICAL", "HIGH"},
+		}, issueReport)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"GET /repos/aquasecurity/trivy/issues",
+			"GET /repos/aquasecurity/trivy/issues",
+			"PATCH /repos/aquasecurity/trivy/issues/1",
+			"POST /repos/aquasecurity/trivy/issues",
+		}, f.got())
+	})
+
+	t.Run("jira", func(t *testing.T) {
+		f := &fakeTracker{}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			f.record(r)
+			user, token, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "user@example.com", user)
+			assert.Equal(t, "token", token)
+
+			switch r.Method {
+			case http.MethodGet:
+				assert.True(t, strings.Contains(r.URL.Query().Get("jql"), `labels = "trivy"`))
+				_ = json.NewEncoder(w).Encode(jiraSearchResponse{
+					Total: 1,
+					Issues: []jiraIssue{
+						{
+							Key: "SEC-1",
+							Fields: jiraFields{
+								Labels: []string{IssueLabel, jiraFingerprintPrefix + openssl},
+								Status: &jiraState{StatusCategory: jiraKey{Key: "done"}},
+							},
+						},
+					},
+				})
+			case http.MethodPost:
+				var issue jiraIssue
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&issue))
+				assert.Equal(t, "SEC", issue.Fields.Project.Key)
+				assert.Equal(t, []string{IssueLabel, jiraFingerprintPrefix + busybox}, issue.Fields.Labels)
+			}
+		}))
+		defer ts.Close()
+
+		err := FileIssues(context.Background(), IssueOption{
+			Tracker:    TrackerJira,
+			URL:        ts.URL,
+			Project:    "SEC",
+			User:       "user@example.com",
+			Token:      "token",
+			Per:        IssuePerVulnerability,
+			Severities: []string{"CRITICAL", "HIGH"},
+		}, issueReport)
+		require.NoError(t, err)
+
+		// The closed issue is not updated
+		assert.Equal(t, []string{
+			"GET /rest/api/2/search",
+			"POST /rest/api/2/issue",
+		}, f.got())
+	})
+}
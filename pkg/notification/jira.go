@@ -0,0 +1,166 @@
+package notification
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	jiraIssueType = "Bug"
+
+	// jiraFingerprintPrefix prefixes the label holding the fingerprint
+	jiraFingerprintPrefix = "trivy-"
+)
+
+type jiraTracker struct {
+	url     string
+	project string
+	user    string
+	token   string
+	client  *http.Client
+}
+
+func newJiraTracker(opt IssueOption) jiraTracker {
+	return jiraTracker{
+		url:     strings.TrimSuffix(opt.URL, "/"),
+		project: opt.Project,
+		user:    opt.User,
+		token:   opt.Token,
+		client:  &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+type jiraFields struct {
+	Project     *jiraKey   `json:"project,omitempty"`
+	IssueType   *jiraName  `json:"issuetype,omitempty"`
+	Summary     string     `json:"summary,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Labels      []string   `json:"labels,omitempty"`
+	Status      *jiraState `json:"status,omitempty"`
+}
+
+type jiraKey struct {
+	Key string `json:"key"`
+}
+
+type jiraName struct {
+	Name string `json:"name"`
+}
+
+type jiraState struct {
+	StatusCategory jiraKey `json:"statusCategory"`
+}
+
+type jiraIssue struct {
+	Key    string     `json:"key,omitempty"`
+	Fields jiraFields `json:"fields"`
+}
+
+type jiraSearchResponse struct {
+	StartAt    int         `json:"startAt"`
+	MaxResults int         `json:"maxResults"`
+	Total      int         `json:"total"`
+	Issues     []jiraIssue `json:"issues"`
+}
+
+func (t jiraTracker) find(ctx context.Context) (map[string]existingIssue, error) {
+	jql := fmt.Sprintf("project = %q AND labels = %q", t.project, IssueLabel)
+
+	issues := map[string]existingIssue{}
+	for startAt := 0; ; {
+		q := url.Values{}
+		q.Set("jql", jql)
+		q.Set("fields", "labels,status")
+		q.Set("startAt", fmt.Sprint(startAt))
+		q.Set("maxResults", "100")
+
+		var res jiraSearchResponse
+		if err := t.do(ctx, http.MethodGet, "/rest/api/2/search?"+q.Encode(), nil, &res); err != nil {
+			return nil, err
+		}
+		for _, issue := range res.Issues {
+			for _, label := range issue.Fields.Labels {
+				if !strings.HasPrefix(label, jiraFingerprintPrefix) {
+					continue
+				}
+				issues[strings.TrimPrefix(label, jiraFingerprintPrefix)] = existingIssue{
+					ID:   issue.Key,
+					Open: issue.Fields.Status == nil || issue.Fields.Status.StatusCategory.Key != "done",
+				}
+			}
+		}
+
+		startAt += len(res.Issues)
+		if len(res.Issues) == 0 || startAt >= res.Total {
+			return issues, nil
+		}
+	}
+}
+
+func (t jiraTracker) create(ctx context.Context, issue Issue) error {
+	return t.do(ctx, http.MethodPost, "/rest/api/2/issue", jiraIssue{
+		Fields: jiraFields{
+			Project:     &jiraKey{Key: t.project},
+			IssueType:   &jiraName{Name: jiraIssueType},
+			Summary:     issue.Title,
+			Description: jiraDescription(issue),
+			Labels:      []string{IssueLabel, jiraFingerprintPrefix + issue.Fingerprint},
+		},
+	}, nil)
+}
+
+func (t jiraTracker) update(ctx context.Context, id string, issue Issue) error {
+	return t.do(ctx, http.MethodPut, "/rest/api/2/issue/"+id, jiraIssue{
+		Fields: jiraFields{
+			Summary:     issue.Title,
+			Description: jiraDescription(issue),
+		},
+	}, nil)
+}
+
+func (t jiraTracker) do(ctx context.Context, method, path string, in, out any) error {
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	if t.user != "" {
+		// Jira Cloud takes the API token with the user email
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(t.user+":"+t.token)))
+	} else if t.token != "" {
+		// Personal access token of Jira Data Center
+		header.Set("Authorization", "Bearer "+t.token)
+	}
+
+	if err := sendJSON(ctx, t.client, method, t.url+path, header, in, out); err != nil {
+		return xerrors.Errorf("Jira API error: %w", err)
+	}
+	return nil
+}
+
+// jiraDescription renders the findings in the Jira wiki markup
+func jiraDescription(issue Issue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Trivy found the following vulnerabilities in {{%s}} of {{%s}}.\n\n", issue.Target, issue.ArtifactName)
+	b.WriteString("||Vulnerability||Severity||Package||Installed||Fixed||Title||\n")
+	for _, v := range issue.Findings {
+		id := v.VulnerabilityID
+		if v.PrimaryURL != "" {
+			id = fmt.Sprintf("[%s|%s]", id, v.PrimaryURL)
+		}
+		fmt.Fprintf(&b, "|%s|%s|%s|%s|%s|%s|\n", id, v.Severity, v.PkgName,
+			jiraCell(v.InstalledVersion), jiraCell(v.FixedVersion), jiraCell(strings.ReplaceAll(v.Title, "|", "-")))
+	}
+	return b.String()
+}
+
+// jiraCell avoids empty cells, which break the table
+func jiraCell(s string) string {
+	if s == "" {
+		return " "
+	}
+	return s
+}
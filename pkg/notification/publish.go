@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// PublishOption holds the options for publishing reports to vulnerability management platforms
+type PublishOption struct {
+	AppVersion string
+
+	DefectDojoURL        string
+	DefectDojoToken      string
+	DefectDojoProduct    string
+	DefectDojoEngagement string
+
+	DependencyTrackURL     string
+	DependencyTrackAPIKey  string
+	DependencyTrackProject string
+	DependencyTrackVersion string
+}
+
+// Publisher pushes the finished report to a platform
+type Publisher interface {
+	Name() string
+	Publish(ctx context.Context, report types.Report) error
+}
+
+// NewPublishers returns the publishers configured in the option
+func NewPublishers(opt PublishOption) []Publisher {
+	var publishers []Publisher
+	if opt.DefectDojoURL != "" {
+		publishers = append(publishers, NewDefectDojo(opt))
+	}
+	if opt.DependencyTrackURL != "" {
+		publishers = append(publishers, NewDependencyTrack(opt))
+	}
+	return publishers
+}
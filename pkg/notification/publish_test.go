@@ -0,0 +1,183 @@
+package notification_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/notification"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func TestDefectDojo_Publish(t *testing.T) {
+	tests := []struct {
+		name           string
+		option         notification.PublishOption
+		statusCode     int
+		wantProduct    string
+		wantEngagement string
+		wantErr        string
+	}{
+		{
+			name: "happy path",
+			option: notification.PublishOption{
+				DefectDojoProduct:    "web",
+				DefectDojoEngagement: "CI",
+			},
+			statusCode:     http.StatusCreated,
+			wantProduct:    "web",
+			wantEngagement: "CI",
+		},
+		{
+			name:           "defaults",
+			statusCode:     http.StatusCreated,
+			wantProduct:    "alpine:3.17",
+			wantEngagement: "Trivy",
+		},
+		{
+			name:       "sad path",
+			statusCode: http.StatusBadRequest,
+			wantErr:    "DefectDojo API error: 400 Bad Request",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req *http.Request
+			var file []byte
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				req = r
+				require.NoError(t, r.ParseMultipartForm(1<<20))
+				f, _, err := r.FormFile("file")
+				require.NoError(t, err)
+				file, _ = io.ReadAll(f)
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer ts.Close()
+
+			tt.option.DefectDojoURL = ts.URL
+			tt.option.DefectDojoToken = "token"
+			err := notification.NewDefectDojo(tt.option).Publish(context.Background(), testReport)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, "/api/v2/import-scan/", req.URL.Path)
+			assert.Equal(t, "Token token", req.Header.Get("Authorization"))
+			assert.Equal(t, "Trivy Scan", req.FormValue("scan_type"))
+			assert.Equal(t, tt.wantProduct, req.FormValue("product_name"))
+			assert.Equal(t, tt.wantEngagement, req.FormValue("engagement_name"))
+			assert.Equal(t, "true", req.FormValue("auto_create_context"))
+
+			var got types.Report
+			require.NoError(t, json.Unmarshal(file, &got))
+			assert.Equal(t, testReport.ArtifactName, got.ArtifactName)
+		})
+	}
+}
+
+func TestDependencyTrack_Publish(t *testing.T) {
+	tests := []struct {
+		name      string
+		report    types.Report
+		wantPaths []string
+	}{
+		{
+			name: "with vulnerabilities",
+			report: types.Report{
+				ArtifactName: "alpine:3.17",
+				ArtifactType: ftypes.ArtifactContainerImage,
+				Metadata: types.Metadata{
+					OS: &ftypes.OS{
+						Family: "alpine",
+						Name:   "3.17.3",
+					},
+				},
+				Results: types.Results{
+					{
+						Target: "alpine:3.17 (alpine 3.17.3)",
+						Class:  types.ClassOSPkg,
+						Type:   "alpine",
+						Packages: []ftypes.Package{
+							{
+								Name:    "musl",
+								Version: "1.2.3-r4",
+							},
+						},
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2023-0001",
+								PkgName:          "musl",
+								InstalledVersion: "1.2.3-r4",
+								Vulnerability:    dbTypes.Vulnerability{Severity: "CRITICAL"},
+							},
+						},
+					},
+				},
+			},
+			wantPaths: []string{
+				"PUT /api/v1/bom",
+				"GET /api/v1/event/token/abc",
+				"PUT /api/v1/vex",
+			},
+		},
+		{
+			name: "without vulnerabilities",
+			report: types.Report{
+				ArtifactName: "alpine:3.17",
+			},
+			wantPaths: []string{
+				"PUT /api/v1/bom",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.Method+" "+r.URL.Path)
+				assert.Equal(t, "key", r.Header.Get("X-Api-Key"))
+
+				switch r.URL.Path {
+				case "/api/v1/bom":
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, "alpine:3.17", body["projectName"])
+					assert.Equal(t, "1.0", body["projectVersion"])
+					assert.Equal(t, true, body["autoCreate"])
+
+					bom, err := base64.StdEncoding.DecodeString(body["bom"].(string))
+					require.NoError(t, err)
+					assert.Contains(t, string(bom), `"bomFormat":"CycloneDX"`)
+
+					_, _ = w.Write([]byte(`{"token":"abc"}`))
+				case "/api/v1/event/token/abc":
+					_, _ = w.Write([]byte(`{"processing":false}`))
+				case "/api/v1/vex":
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.NotEmpty(t, body["vex"])
+				}
+			}))
+			defer ts.Close()
+
+			err := notification.NewDependencyTrack(notification.PublishOption{
+				DependencyTrackURL:     ts.URL,
+				DependencyTrackAPIKey:  "key",
+				DependencyTrackVersion: "1.0",
+			}).Publish(context.Background(), tt.report)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPaths, paths)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/clock"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+const (
+	// EventScanCompleted is sent after each scan
+	EventScanCompleted = "scan.completed"
+
+	// EventHeader carries the event of the payload
+	EventHeader = "X-Trivy-Event"
+
+	// SignatureHeader carries the HMAC-SHA256 of "<timestamp>.<body>", "sha256=<hex>", when a secret is configured
+	SignatureHeader = "X-Trivy-Signature-256"
+
+	// TimestampHeader carries the Unix time of the request so that the receiver can reject replayed requests
+	TimestampHeader = "X-Trivy-Timestamp"
+
+	defaultTimeout = 30 * time.Second
+)
+
+// Option holds the options for notifications
+type Option struct {
+	WebhookURL    string
+	WebhookSecret string
+
+	// ReportURL is a link to the full report, e.g. the CI job, included in the payload
+	ReportURL string
+
+	// InlineReport includes the whole report in the payload
+	InlineReport bool
+}
+
+// Payload is posted to the webhook
+type Payload struct {
+	Event        string              `json:"event"`
+	ArtifactName string              `json:"artifact_name"`
+	ArtifactType ftypes.ArtifactType `json:"artifact_type"`
+	Failed       bool                `json:"failed"`
+	Summary      Summary             `json:"summary"`
+	ReportURL    string              `json:"report_url,omitempty"`
+	Report       *types.Report       `json:"report,omitempty"`
+}
+
+// Summary holds the number of findings by severity
+type Summary struct {
+	Vulnerabilities   map[string]int `json:"vulnerabilities"`
+	Misconfigurations map[string]int `json:"misconfigurations"`
+	Secrets           map[string]int `json:"secrets"`
+	Licenses          map[string]int `json:"licenses"`
+}
+
+// NewPayload summarizes the report
+func NewPayload(report types.Report, opt Option) Payload {
+	summary := Summary{
+		Vulnerabilities:   map[string]int{},
+		Misconfigurations: map[string]int{},
+		Secrets:           map[string]int{},
+		Licenses:          map[string]int{},
+	}
+	for _, r := range report.Results {
+		for _, v := range r.Vulnerabilities {
+			summary.Vulnerabilities[v.Severity]++
+		}
+		for _, m := range r.Misconfigurations {
+			if m.Status == types.StatusFailure {
+				summary.Misconfigurations[m.Severity]++
+			}
+		}
+		for _, s := range r.Secrets {
+			summary.Secrets[s.Severity]++
+		}
+		for _, l := range r.Licenses {
+			summary.Licenses[l.Severity]++
+		}
+	}
+
+	payload := Payload{
+		Event:        EventScanCompleted,
+		ArtifactName: report.ArtifactName,
+		ArtifactType: report.ArtifactType,
+		Failed:       report.Results.Failed(),
+		Summary:      summary,
+		ReportURL:    opt.ReportURL,
+	}
+	if opt.InlineReport {
+		payload.Report = &report
+	}
+	return payload
+}
+
+// Sign returns the value of the signature header for the timestamp and the body
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Webhook posts the summary of scans
+type Webhook struct {
+	opt    Option
+	client *http.Client
+}
+
+// NewWebhook returns a webhook notifier
+func NewWebhook(opt Option) Webhook {
+	return Webhook{
+		opt:    opt,
+		client: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+// Notify posts the summary of the report
+func (w Webhook) Notify(ctx context.Context, report types.Report) error {
+	body, err := json.Marshal(NewPayload(report, w.opt))
+	if err != nil {
+		return xerrors.Errorf("json encode error: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.opt.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return xerrors.Errorf("request error: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, EventScanCompleted)
+	if w.opt.WebhookSecret != "" {
+		timestamp := strconv.FormatInt(clock.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(w.opt.WebhookSecret, timestamp, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return xerrors.Errorf("webhook error: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return xerrors.Errorf("webhook error: %s", resp.Status)
+	}
+	return nil
+}
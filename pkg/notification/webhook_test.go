@@ -0,0 +1,162 @@
+package notification_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/clock"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/notification"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+var testReport = types.Report{
+	ArtifactName: "alpine:3.17",
+	ArtifactType: ftypes.ArtifactContainerImage,
+	Results: types.Results{
+		{
+			Target: "alpine:3.17 (alpine 3.17.3)",
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2023-0001",
+					Vulnerability:   dbTypes.Vulnerability{Severity: "CRITICAL"},
+				},
+				{
+					VulnerabilityID: "CVE-2023-0002",
+					Vulnerability:   dbTypes.Vulnerability{Severity: "HIGH"},
+				},
+				{
+					VulnerabilityID: "CVE-2023-0003",
+					Vulnerability:   dbTypes.Vulnerability{Severity: "HIGH"},
+				},
+			},
+		},
+		{
+			Target: "Dockerfile",
+			Misconfigurations: []types.DetectedMisconfiguration{
+				{
+					ID:       "DS002",
+					Severity: "HIGH",
+					Status:   types.StatusFailure,
+				},
+				{
+					ID:       "DS005",
+					Severity: "LOW",
+					Status:   types.StatusPassed,
+				},
+			},
+		},
+	},
+}
+
+func TestNewPayload(t *testing.T) {
+	got := notification.NewPayload(testReport, notification.Option{
+		ReportURL: "https://ci.example.com/jobs/1",
+	})
+
+	want := notification.Payload{
+		Event:        notification.EventScanCompleted,
+		ArtifactName: "alpine:3.17",
+		ArtifactType: ftypes.ArtifactContainerImage,
+		Failed:       true,
+		Summary: notification.Summary{
+			Vulnerabilities: map[string]int{
+				"CRITICAL": 1,
+				"HIGH":     2,
+			},
+			Misconfigurations: map[string]int{
+				"HIGH": 1,
+			},
+			Secrets:  map[string]int{},
+			Licenses: map[string]int{},
+		},
+		ReportURL: "https://ci.example.com/jobs/1",
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestWebhook_Notify(t *testing.T) {
+	tests := []struct {
+		name          string
+		option        notification.Option
+		statusCode    int
+		wantSignature bool
+		wantReport    bool
+		wantErr       string
+	}{
+		{
+			name: "signed",
+			option: notification.Option{
+				WebhookSecret: "secret",
+			},
+			statusCode:    http.StatusOK,
+			wantSignature: true,
+		},
+		{
+			name: "inline report",
+			option: notification.Option{
+				InlineReport: true,
+			},
+			statusCode: http.StatusNoContent,
+			wantReport: true,
+		},
+		{
+			name:       "sad path",
+			statusCode: http.StatusInternalServerError,
+			wantErr:    "webhook error: 500 Internal Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock.SetFakeTime(t, time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC))
+
+			var req *http.Request
+			var body []byte
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				req = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer ts.Close()
+
+			tt.option.WebhookURL = ts.URL
+			err := notification.NewWebhook(tt.option).Notify(context.Background(), testReport)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+			assert.Equal(t, notification.EventScanCompleted, req.Header.Get(notification.EventHeader))
+			if tt.wantSignature {
+				assert.Equal(t, "1682942400", req.Header.Get(notification.TimestampHeader))
+				assert.Equal(t, notification.Sign("secret", "1682942400", body), req.Header.Get(notification.SignatureHeader))
+			} else {
+				assert.Empty(t, req.Header.Get(notification.TimestampHeader))
+				assert.Empty(t, req.Header.Get(notification.SignatureHeader))
+			}
+
+			var got notification.Payload
+			require.NoError(t, json.Unmarshal(body, &got))
+			assert.Equal(t, "alpine:3.17", got.ArtifactName)
+			assert.Equal(t, tt.wantReport, got.Report != nil)
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1682942400.{"event":"scan.completed"}' | openssl dgst -sha256 -hmac secret
+	got := notification.Sign("secret", "1682942400", []byte(`{"event":"scan.completed"}`))
+	assert.Equal(t, "sha256=eb8b654b60a58ef1089ff1fb12b1f5fb64eb7fe3a9462e64dd476063a520d454", got)
+}
@@ -2,6 +2,8 @@ package oci
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"os"
 	"path/filepath"
@@ -14,7 +16,9 @@ import (
 
 	"github.com/zhanglimao/trivy/pkg/downloader"
 	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
 	"github.com/zhanglimao/trivy/pkg/remote"
+	"github.com/zhanglimao/trivy/pkg/utils/fsutils"
 )
 
 const (
@@ -44,11 +48,19 @@ func WithImage(img v1.Image) Option {
 	}
 }
 
+// WithCacheDir keeps interrupted downloads under the cache dir so that they can be resumed in the next run
+func WithCacheDir(dir string) Option {
+	return func(a *Artifact) {
+		a.cacheDir = dir
+	}
+}
+
 // Artifact is used to download artifacts such as vulnerability database and policies from OCI registries.
 type Artifact struct {
 	m          sync.Mutex
 	repository string
 	quiet      bool
+	cacheDir   string // downloads are not resumed if empty
 
 	// For OCI registries
 	types.RegistryOptions
@@ -147,47 +159,163 @@ func (a *Artifact) download(ctx context.Context, layer v1.Layer, fileName, dir s
 	if err != nil {
 		return xerrors.Errorf("size error: %w", err)
 	}
-
-	rc, err := layer.Compressed()
+	digest, err := layer.Digest()
 	if err != nil {
-		return xerrors.Errorf("failed to fetch the layer: %w", err)
+		return xerrors.Errorf("digest error: %w", err)
 	}
-	defer rc.Close()
 
-	// Show progress bar
-	bar := pb.Full.Start64(size)
-	if a.quiet {
-		bar.SetWriter(io.Discard)
+	partial, err := a.fetch(ctx, layer, digest, size)
+	if err != nil {
+		return err
 	}
-	pr := bar.NewProxyReader(rc)
-	defer bar.Finish()
+	defer os.Remove(partial)
 
 	// https://github.com/hashicorp/go-getter/issues/326
 	tempDir, err := os.MkdirTemp("", "trivy")
 	if err != nil {
 		return xerrors.Errorf("failed to create a temp dir: %w", err)
 	}
+	defer os.RemoveAll(tempDir)
+
+	// go-getter detects the archive format by the file name
+	f := filepath.Join(tempDir, fileName)
+	if err = os.Rename(partial, f); err != nil {
+		// The temp dir might be on another device
+		if _, err = fsutils.CopyFile(partial, f); err != nil {
+			return xerrors.Errorf("failed to copy the downloaded file: %w", err)
+		}
+	}
+
+	// Decompress the downloaded file if it is compressed and copy it into the dst
+	if err = downloader.Download(ctx, f, dir, dir); err != nil {
+		return xerrors.Errorf("download error: %w", err)
+	}
+
+	return nil
+}
+
+// fetch downloads the layer content into a file owned by this process and verifies the digest.
+// With the cache dir, an interrupted download is kept in the partial dir and resumed with a range request in the next run.
+func (a *Artifact) fetch(ctx context.Context, layer v1.Layer, digest v1.Hash, size int64) (_ string, err error) {
+	dir := os.TempDir()
+	var resumable string
+	if a.cacheDir != "" {
+		dir = filepath.Join(a.cacheDir, "partial")
+		if err = os.MkdirAll(dir, 0700); err != nil {
+			return "", xerrors.Errorf("failed to create a partial dir: %w", err)
+		}
+		resumable = filepath.Join(dir, digest.Hex)
+	}
 
-	f, err := os.Create(filepath.Join(tempDir, fileName))
+	// Concurrent processes download into their own files not to write the same file
+	f, err := os.CreateTemp(dir, digest.Hex+".*")
 	if err != nil {
-		return xerrors.Errorf("failed to create a temp file: %w", err)
+		return "", xerrors.Errorf("failed to create a partial file: %w", err)
+	}
+	partial := f.Name()
+	if resumable != "" {
+		// Take over the interrupted download. Only one process can take it over as the rename is atomic.
+		_ = f.Close()
+		if err = os.Rename(resumable, partial); err == nil {
+			log.Logger.Debugf("Taking over the partial file: %s", resumable)
+		}
+		if f, err = os.OpenFile(partial, os.O_RDWR, 0600); err != nil {
+			_ = os.Remove(partial)
+			return "", xerrors.Errorf("failed to open the partial file: %w", err)
+		}
 	}
+
+	var interrupted bool
 	defer func() {
 		_ = f.Close()
-		_ = os.RemoveAll(tempDir)
+		switch {
+		case err == nil:
+		case interrupted && resumable != "":
+			// Put it back for the next run
+			_ = os.Rename(partial, resumable)
+		default:
+			_ = os.Remove(partial)
+		}
 	}()
 
-	// Download the layer content into a temporal file
-	if _, err = io.Copy(f, pr); err != nil {
-		return xerrors.Errorf("copy error: %w", err)
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", xerrors.Errorf("seek error: %w", err)
 	}
 
-	// Decompress the downloaded file if it is compressed and copy it into the dst
-	if err = downloader.Download(ctx, f.Name(), dir, dir); err != nil {
-		return xerrors.Errorf("download error: %w", err)
+	var rc io.ReadCloser
+	fromScratch := offset == 0
+	if offset > 0 && offset < size {
+		if rc, err = a.resume(ctx, digest, offset); err != nil {
+			log.Logger.Debugf("Unable to resume the download: %s", err)
+		} else {
+			log.Logger.Infof("Resuming the download from %d bytes", offset)
+		}
+	}
+	if rc == nil && offset != size {
+		// Download from scratch
+		offset = 0
+		if err = f.Truncate(0); err != nil {
+			return "", xerrors.Errorf("truncate error: %w", err)
+		} else if _, err = f.Seek(0, io.SeekStart); err != nil {
+			return "", xerrors.Errorf("seek error: %w", err)
+		}
+		fromScratch = true
+		if rc, err = layer.Compressed(); err != nil {
+			return "", xerrors.Errorf("failed to fetch the layer: %w", err)
+		}
 	}
 
-	return nil
+	if rc != nil {
+		defer rc.Close()
+
+		// Show progress bar
+		bar := pb.Full.Start64(size)
+		if a.quiet {
+			bar.SetWriter(io.Discard)
+		}
+		bar.SetCurrent(offset)
+		pr := bar.NewProxyReader(rc)
+		defer bar.Finish()
+
+		// Download the layer content into the partial file
+		if _, err = io.Copy(f, pr); err != nil {
+			interrupted = true
+			if resumable != "" {
+				return "", xerrors.Errorf("download interrupted, run it again to resume: %w", err)
+			}
+			return "", xerrors.Errorf("download interrupted: %w", err)
+		}
+	}
+
+	// Verify the digest of the whole content
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return "", xerrors.Errorf("seek error: %w", err)
+	}
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", xerrors.Errorf("read error: %w", err)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != digest.Hex {
+		if !fromScratch {
+			// The partial file might be broken
+			log.Logger.Debugf("Digest mismatch of the resumed download, downloading it again: %s", got)
+			_ = f.Close()
+			_ = os.Remove(partial)
+			return a.fetch(ctx, layer, digest, size)
+		}
+		return "", xerrors.Errorf("digest mismatch: expected %s, got sha256:%s", digest, got)
+	}
+	return partial, nil
+}
+
+// resume returns the rest of the layer content from the registry
+func (a *Artifact) resume(ctx context.Context, digest v1.Hash, offset int64) (io.ReadCloser, error) {
+	ref, err := name.ParseReference(a.repository)
+	if err != nil {
+		return nil, xerrors.Errorf("repository name error (%s): %w", a.repository, err)
+	}
+	return remote.BlobRange(ctx, ref.Context().Digest(digest.String()), offset, a.RegistryOptions)
 }
 
 func (a *Artifact) Digest(ctx context.Context) (string, error) {
@@ -1,11 +1,19 @@
 package oci_test
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	fakei "github.com/google/go-containerregistry/pkg/v1/fake"
@@ -138,3 +146,151 @@ func TestArtifact_Download(t *testing.T) {
 		})
 	}
 }
+
+func TestArtifact_Download_Resume(t *testing.T) {
+	content, err := os.ReadFile("testdata/test.tar.gz")
+	require.NoError(t, err)
+
+	layer, err := tarball.LayerFromFile("testdata/test.tar.gz")
+	require.NoError(t, err)
+	digest, err := layer.Digest()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		partial       []byte
+		rangeDisabled bool
+		wantRange     string
+	}{
+		{
+			name:      "resume",
+			partial:   content[:len(content)/2],
+			wantRange: fmt.Sprintf("bytes=%d-", len(content)/2),
+		},
+		{
+			name:      "broken partial file",
+			partial:   make([]byte, len(content)/2),
+			wantRange: fmt.Sprintf("bytes=%d-", len(content)/2),
+		},
+		{
+			name:          "range request not supported",
+			partial:       content[:len(content)/2],
+			rangeDisabled: true,
+			wantRange:     fmt.Sprintf("bytes=%d-", len(content)/2),
+		},
+		{
+			name:    "already downloaded",
+			partial: content,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRange string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v2/" {
+					return
+				}
+				require.Equal(t, "/v2/repo/blobs/"+digest.String(), r.URL.Path)
+				gotRange = r.Header.Get("Range")
+				if tt.rangeDisabled {
+					r.Header.Del("Range")
+				}
+				http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+			}))
+			defer ts.Close()
+
+			tempDir := t.TempDir()
+			partial := filepath.Join(tempDir, "partial", digest.Hex)
+			require.NoError(t, os.MkdirAll(filepath.Dir(partial), 0700))
+			require.NoError(t, os.WriteFile(partial, tt.partial, 0600))
+
+			img := new(fakei.FakeImage)
+			img.LayersReturns([]v1.Layer{fakeLayer{layer}}, nil)
+			img.ManifestReturns(&v1.Manifest{
+				Layers: []v1.Descriptor{
+					{
+						MediaType: "application/vnd.cncf.openpolicyagent.layer.v1.tar+gzip",
+						Digest:    digest,
+						Annotations: map[string]string{
+							"org.opencontainers.image.title": "bundle.tar.gz",
+						},
+					},
+				},
+			}, nil)
+
+			repo := strings.TrimPrefix(ts.URL, "http://") + "/repo"
+			artifact, err := oci.NewArtifact(repo, true, ftypes.RegistryOptions{}, oci.WithImage(img),
+				oci.WithCacheDir(tempDir))
+			require.NoError(t, err)
+
+			dst := filepath.Join(tempDir, "dst")
+			err = artifact.Download(context.Background(), dst, oci.DownloadOption{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRange, gotRange)
+
+			got, err := os.ReadFile(filepath.Join(dst, "test.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, "Hello, world", string(got))
+
+			// No partial files are left
+			entries, err := os.ReadDir(filepath.Dir(partial))
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+type interruptedLayer struct {
+	fakeLayer
+	content []byte
+}
+
+// Compressed returns the first half of the content and fails
+func (l interruptedLayer) Compressed() (io.ReadCloser, error) {
+	return io.NopCloser(io.MultiReader(bytes.NewReader(l.content[:len(l.content)/2]),
+		iotest.ErrReader(errors.New("connection reset")))), nil
+}
+
+func TestArtifact_Download_Interrupted(t *testing.T) {
+	content, err := os.ReadFile("testdata/test.tar.gz")
+	require.NoError(t, err)
+
+	layer, err := tarball.LayerFromFile("testdata/test.tar.gz")
+	require.NoError(t, err)
+	digest, err := layer.Digest()
+	require.NoError(t, err)
+
+	img := new(fakei.FakeImage)
+	img.LayersReturns([]v1.Layer{interruptedLayer{fakeLayer{layer}, content}}, nil)
+	img.ManifestReturns(&v1.Manifest{
+		Layers: []v1.Descriptor{
+			{
+				MediaType: "application/vnd.cncf.openpolicyagent.layer.v1.tar+gzip",
+				Digest:    digest,
+				Annotations: map[string]string{
+					"org.opencontainers.image.title": "bundle.tar.gz",
+				},
+			},
+		},
+	}, nil)
+
+	tempDir := t.TempDir()
+	artifact, err := oci.NewArtifact("repo", true, ftypes.RegistryOptions{}, oci.WithImage(img),
+		oci.WithCacheDir(tempDir))
+	require.NoError(t, err)
+
+	err = artifact.Download(context.Background(), filepath.Join(tempDir, "dst"), oci.DownloadOption{})
+	require.ErrorContains(t, err, "run it again to resume")
+
+	// Only the resumable file is left for the next run
+	partialDir := filepath.Join(tempDir, "partial")
+	entries, err := os.ReadDir(partialDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, digest.Hex, entries[0].Name())
+
+	got, err := os.ReadFile(filepath.Join(partialDir, digest.Hex))
+	require.NoError(t, err)
+	assert.Equal(t, content[:len(content)/2], got)
+}
@@ -0,0 +1,147 @@
+package oci
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	v1types "github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/clock"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/remote"
+)
+
+const (
+	// Artifact types of scan reports
+	TrivyReportArtifactType = "application/vnd.trivy.report.v1+json"
+	SARIFArtifactType       = "application/sarif+json"
+
+	CreatedAnnotation = "org.opencontainers.image.created"
+)
+
+// Referrer is an artifact attached to an image
+type Referrer struct {
+	ArtifactType string
+	FileName     string
+	Content      []byte
+	Annotations  map[string]string // manifest annotations
+}
+
+// PushReferrer pushes the artifact as a referrer of the subject, so that it can be discovered by the subject digest.
+// The artifact has a single layer with the artifact type as the media type,
+// which is the same layout as SBOMs fetched from OCI referrers.
+// Registries without the referrers API are supported with the fallback tag.
+func PushReferrer(ctx context.Context, subject name.Digest, referrer Referrer, option types.RegistryOptions) (name.Digest, error) {
+	// The subject is the manifest specified by the digest, not the one of the platform
+	option.Platform = types.Platform{}
+	desc, err := remote.Get(ctx, subject, option)
+	if err != nil {
+		return name.Digest{}, xerrors.Errorf("unable to get the subject: %w", err)
+	}
+
+	artifactType := v1types.MediaType(referrer.ArtifactType)
+	img := mutate.MediaType(empty.Image, v1types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, artifactType) // used as the artifact type by registries
+	img, err = mutate.Append(img, mutate.Addendum{
+		Layer: static.NewLayer(referrer.Content, artifactType),
+		Annotations: map[string]string{
+			titleAnnotation: referrer.FileName,
+		},
+	})
+	if err != nil {
+		return name.Digest{}, xerrors.Errorf("unable to append the layer: %w", err)
+	}
+	annotations := map[string]string{
+		CreatedAnnotation: clock.Now().UTC().Format(time.RFC3339),
+	}
+	for k, v := range referrer.Annotations {
+		annotations[k] = v
+	}
+	img = mutate.Annotations(img, annotations).(v1.Image)
+	img = mutate.Subject(img, desc.Descriptor).(v1.Image)
+
+	digest, err := img.Digest()
+	if err != nil {
+		return name.Digest{}, xerrors.Errorf("digest error: %w", err)
+	}
+	ref := subject.Context().Digest(digest.String())
+	if err = remote.Write(ctx, ref, img, option); err != nil {
+		return name.Digest{}, xerrors.Errorf("unable to push the referrer: %w", err)
+	}
+	return ref, nil
+}
+
+// FindReferrer returns the latest referrer of the subject with the artifact type and the annotations accepted by match.
+// It returns nil if no referrer is found.
+func FindReferrer(ctx context.Context, subject name.Digest, artifactType string, match func(annotations map[string]string) bool,
+	option types.RegistryOptions) (*Referrer, error) {
+	option.Platform = types.Platform{}
+	index, err := remote.Referrers(ctx, subject, option)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to fetch referrers: %w", err)
+	}
+
+	var latest v1.Image
+	var latestCreated string
+	for _, m := range lo.FromPtr(index).Manifests {
+		if m.ArtifactType != artifactType {
+			continue
+		}
+		// Annotations are not always included in the referrers, e.g. with the fallback tag
+		img, err := remote.Image(ctx, subject.Context().Digest(m.Digest.String()), option)
+		if err != nil {
+			log.Logger.Debugf("Unable to get the referrer %s: %s", m.Digest, err)
+			continue
+		}
+		manifest, err := img.Manifest()
+		if err != nil {
+			log.Logger.Debugf("Unable to get the manifest of the referrer %s: %s", m.Digest, err)
+			continue
+		}
+		created := manifest.Annotations[CreatedAnnotation]
+		if !match(manifest.Annotations) || (latest != nil && created <= latestCreated) {
+			continue
+		}
+		latest, latestCreated = img, created
+	}
+	if latest == nil {
+		return nil, nil
+	}
+
+	manifest, err := latest.Manifest()
+	if err != nil {
+		return nil, xerrors.Errorf("OCI manifest error: %w", err)
+	}
+	layers, err := latest.Layers()
+	if err != nil {
+		return nil, xerrors.Errorf("OCI layer error: %w", err)
+	} else if len(layers) != 1 || len(manifest.Layers) != 1 {
+		return nil, xerrors.Errorf("OCI artifact must be a single layer")
+	}
+
+	rc, err := layers[0].Uncompressed()
+	if err != nil {
+		return nil, xerrors.Errorf("OCI layer error: %w", err)
+	}
+	defer rc.Close()
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, xerrors.Errorf("read error: %w", err)
+	}
+
+	return &Referrer{
+		ArtifactType: artifactType,
+		FileName:     manifest.Layers[0].Annotations[titleAnnotation],
+		Content:      content,
+		Annotations:  manifest.Annotations,
+	}, nil
+}
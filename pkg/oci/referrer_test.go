@@ -0,0 +1,207 @@
+package oci_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/clock"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/oci"
+	tremote "github.com/zhanglimao/trivy/pkg/remote"
+)
+
+func TestPushReferrer(t *testing.T) {
+	ts := httptest.NewServer(registry.New())
+	defer ts.Close()
+	host := strings.TrimPrefix(ts.URL, "http://")
+
+	img, err := random.Image(100, 1)
+	require.NoError(t, err)
+	tag, err := name.NewTag(host + "/library/app:1.0")
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(tag, img))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	subject := tag.Context().Digest(digest.String())
+
+	tests := []struct {
+		name     string
+		subject  name.Digest
+		referrer oci.Referrer
+		wantErr  string
+	}{
+		{
+			name:    "json report",
+			subject: subject,
+			referrer: oci.Referrer{
+				ArtifactType: oci.TrivyReportArtifactType,
+				FileName:     "trivy-report.json",
+				Content:      []byte(`{"SchemaVersion": 2}`),
+			},
+		},
+		{
+			name:    "sarif report",
+			subject: subject,
+			referrer: oci.Referrer{
+				ArtifactType: oci.SARIFArtifactType,
+				FileName:     "trivy-report.sarif",
+				Content:      []byte(`{"version": "2.1.0"}`),
+			},
+		},
+		{
+			name:    "unknown subject",
+			subject: tag.Context().Digest("sha256:2e7d5a8b0a7c4d0b3b9f2f4c6a8b7d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c"),
+			referrer: oci.Referrer{
+				ArtifactType: oci.TrivyReportArtifactType,
+				FileName:     "trivy-report.json",
+				Content:      []byte(`{}`),
+			},
+			wantErr: "unable to get the subject",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ref, err := oci.PushReferrer(ctx, tt.subject, tt.referrer, ftypes.RegistryOptions{Insecure: true})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			// The referrer can be discovered by the subject digest
+			index, err := tremote.Referrers(ctx, tt.subject, ftypes.RegistryOptions{Insecure: true})
+			require.NoError(t, err)
+			var found bool
+			for _, m := range index.Manifests {
+				if m.Digest.String() == ref.DigestStr() {
+					assert.Equal(t, tt.referrer.ArtifactType, m.ArtifactType)
+					found = true
+				}
+			}
+			require.True(t, found, "referrer not found")
+
+			// The report can be downloaded in the same way as SBOM referrers
+			referrer, err := remote.Image(ref)
+			require.NoError(t, err)
+			layers, err := referrer.Layers()
+			require.NoError(t, err)
+			require.Len(t, layers, 1)
+			rc, err := layers[0].Uncompressed()
+			require.NoError(t, err)
+			defer rc.Close()
+			got, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			assert.Equal(t, tt.referrer.Content, got)
+		})
+	}
+}
+
+func TestFindReferrer(t *testing.T) {
+	ts := httptest.NewServer(registry.New())
+	defer ts.Close()
+	host := strings.TrimPrefix(ts.URL, "http://")
+
+	img, err := random.Image(100, 1)
+	require.NoError(t, err)
+	tag, err := name.NewTag(host + "/library/app:1.0")
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(tag, img))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	subject := tag.Context().Digest(digest.String())
+
+	ctx := context.Background()
+	opt := ftypes.RegistryOptions{Insecure: true}
+	pushes := []struct {
+		created  time.Time
+		referrer oci.Referrer
+	}{
+		{
+			created: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			referrer: oci.Referrer{
+				ArtifactType: oci.TrivyReportArtifactType,
+				FileName:     "trivy-report.json",
+				Content:      []byte(`{"old": true}`),
+				Annotations:  map[string]string{"db": "1"},
+			},
+		},
+		{
+			created: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+			referrer: oci.Referrer{
+				ArtifactType: oci.TrivyReportArtifactType,
+				FileName:     "trivy-report.json",
+				Content:      []byte(`{"new": true}`),
+				Annotations:  map[string]string{"db": "1"},
+			},
+		},
+		{
+			created: time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+			referrer: oci.Referrer{
+				ArtifactType: oci.TrivyReportArtifactType,
+				FileName:     "trivy-report.json",
+				Content:      []byte(`{"db": 2}`),
+				Annotations:  map[string]string{"db": "2"},
+			},
+		},
+		{
+			created: time.Date(2023, 1, 4, 0, 0, 0, 0, time.UTC),
+			referrer: oci.Referrer{
+				ArtifactType: oci.SARIFArtifactType,
+				FileName:     "trivy-report.sarif",
+				Content:      []byte(`{}`),
+				Annotations:  map[string]string{"db": "1"},
+			},
+		},
+	}
+	for _, p := range pushes {
+		clock.SetFakeTime(t, p.created)
+		_, err = oci.PushReferrer(ctx, subject, p.referrer, opt)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name string
+		db   string
+		want *oci.Referrer
+	}{
+		{
+			name: "latest matching referrer",
+			db:   "1",
+			want: &oci.Referrer{
+				ArtifactType: oci.TrivyReportArtifactType,
+				FileName:     "trivy-report.json",
+				Content:      []byte(`{"new": true}`),
+				Annotations: map[string]string{
+					"db":                               "1",
+					"org.opencontainers.image.created": "2023-01-02T00:00:00Z",
+				},
+			},
+		},
+		{
+			name: "no matching referrer",
+			db:   "3",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := oci.FindReferrer(ctx, subject, oci.TrivyReportArtifactType, func(annotations map[string]string) bool {
+				return annotations["db"] == tt.db
+			}, opt)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
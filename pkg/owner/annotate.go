@@ -0,0 +1,62 @@
+package owner
+
+import (
+	"sort"
+
+	"github.com/samber/lo"
+
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// Annotate sets the owners of each result. The owners are looked up by the following in order:
+//  1. the target, e.g. "services/payments/package-lock.json"
+//  2. the file paths of the findings, e.g. JAR files aggregated into a "Java" result in container images
+//  3. the artifact name, e.g. the image name
+//
+// The lookup stops at the first matching rule even without owners, so that the owners can be unassigned.
+func (rules Rules) Annotate(report *types.Report) {
+	if len(rules) == 0 {
+		return
+	}
+	for i := range report.Results {
+		r := &report.Results[i]
+		if owners, ok := rules.lookup(r.Target); ok {
+			r.Owners = owners
+		} else if owners, ok = rules.lookupAll(filePaths(*r)); ok {
+			r.Owners = owners
+		} else {
+			r.Owners = rules.Match(report.ArtifactName)
+		}
+	}
+}
+
+// lookupAll returns the union of the owners of the paths
+func (rules Rules) lookupAll(paths []string) ([]string, bool) {
+	var owners []string
+	var matched bool
+	for _, p := range paths {
+		o, ok := rules.lookup(p)
+		owners = append(owners, o...)
+		matched = matched || ok
+	}
+	if len(owners) == 0 {
+		return nil, matched
+	}
+	owners = lo.Uniq(owners)
+	sort.Strings(owners)
+	return owners, matched
+}
+
+func filePaths(r types.Result) []string {
+	var paths []string
+	for _, pkg := range r.Packages {
+		paths = append(paths, pkg.FilePath)
+	}
+	for _, vuln := range r.Vulnerabilities {
+		paths = append(paths, vuln.PkgPath)
+	}
+	for _, l := range r.Licenses {
+		paths = append(paths, l.FilePath)
+	}
+	return lo.Uniq(lo.Compact(paths))
+}
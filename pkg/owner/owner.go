@@ -0,0 +1,181 @@
+package owner
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule assigns owners to the paths matching the pattern.
+// Patterns follow the CODEOWNERS syntax, e.g. "/services/payments/", "*.tf" and "docs/**".
+type Rule struct {
+	Pattern string   `yaml:"pattern"`
+	Owners  []string `yaml:"owners"`
+
+	re *regexp.Regexp
+}
+
+// Rules are evaluated in order and the last matching rule takes precedence as in CODEOWNERS
+type Rules []Rule
+
+// mappingFile is a user-supplied mapping file in YAML, e.g.
+//
+//	owners:
+//	  - pattern: "services/payments/"
+//	    owners: ["@acme/payments"]
+type mappingFile struct {
+	Owners []Rule `yaml:"owners"`
+}
+
+// Load loads the rules from a CODEOWNERS file, or from a mapping file if the extension is ".yaml" or ".yml".
+func Load(filePath string) (Rules, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	switch filepath.Ext(filePath) {
+	case ".yaml", ".yml":
+		return parseMapping(f)
+	default:
+		return ParseCODEOWNERS(f)
+	}
+}
+
+// ParseCODEOWNERS parses the CODEOWNERS format of GitHub and GitLab.
+// Sections of GitLab such as "[Documentation]" are ignored and their rules are applied as a whole.
+func ParseCODEOWNERS(r io.Reader) (Rules, error) {
+	var rules Rules
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") ||
+			strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		// Trailing comments
+		if i := strings.Index(line, " #"); i != -1 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		rule, err := newRule(strings.ReplaceAll(fields[0], `\#`, "#"), fields[1:])
+		if err != nil {
+			return nil, xerrors.Errorf("line %d: %w", n, err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, xerrors.Errorf("read error: %w", err)
+	}
+	return rules, nil
+}
+
+func parseMapping(r io.Reader) (Rules, error) {
+	var mapping mappingFile
+	if err := yaml.NewDecoder(r).Decode(&mapping); err != nil && err != io.EOF {
+		return nil, xerrors.Errorf("yaml decode error: %w", err)
+	}
+
+	var rules Rules
+	for i, m := range mapping.Owners {
+		if m.Pattern == "" {
+			return nil, xerrors.Errorf("owners[%d]: empty pattern", i)
+		}
+		rule, err := newRule(m.Pattern, m.Owners)
+		if err != nil {
+			return nil, xerrors.Errorf("owners[%d]: %w", i, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func newRule(pattern string, owners []string) (Rule, error) {
+	re, err := compile(pattern)
+	if err != nil {
+		return Rule{}, xerrors.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return Rule{
+		Pattern: pattern,
+		Owners:  owners,
+		re:      re,
+	}, nil
+}
+
+// compile converts the pattern to a regular expression.
+//   - A pattern starting with or containing "/" is relative to the root, otherwise it matches at any depth.
+//   - A pattern ending with "/" matches only the contents of the directory.
+//   - "*" matches within a path segment, and "**" matches across segments.
+//   - A pattern matches the contents of directories as well, except for "dir/*" which doesn't match nested files.
+func compile(pattern string) (*regexp.Regexp, error) {
+	p := pattern
+	anchored := strings.HasPrefix(p, "/") || strings.Contains(strings.TrimSuffix(p, "/"), "/")
+	p = strings.TrimPrefix(p, "/")
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+
+	var sb strings.Builder
+	sb.WriteString("^")
+	if !anchored {
+		sb.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case p[i] == '*':
+			sb.WriteString("[^/]*")
+		case p[i] == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+
+	switch {
+	case dirOnly:
+		sb.WriteString("/.*")
+	case strings.HasSuffix(p, "/*"):
+		// Nested files are not matched
+	default:
+		sb.WriteString("(?:/.*)?")
+	}
+	sb.WriteString("$")
+
+	return regexp.Compile(sb.String())
+}
+
+// Match returns the owners of the last rule matching the path.
+// It returns nil if no rule matches, or the matching rule has no owners to unassign them.
+func (rules Rules) Match(path string) []string {
+	owners, _ := rules.lookup(path)
+	return owners
+}
+
+// lookup returns the owners and whether any rule matches the path
+func (rules Rules) lookup(path string) ([]string, bool) {
+	path = strings.TrimPrefix(filepath.ToSlash(path), "/")
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].re.MatchString(path) {
+			if len(rules[i].Owners) == 0 {
+				return nil, true
+			}
+			return slices.Clone(rules[i].Owners), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,155 @@
+package owner_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/owner"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func TestRules_Match(t *testing.T) {
+	rules, err := owner.Load("testdata/CODEOWNERS")
+	require.NoError(t, err)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{
+			path: "go.mod",
+			want: []string{"@acme/security"},
+		},
+		{
+			path: "deploy/aws/main.tf",
+			want: []string{"@acme/platform"},
+		},
+		{
+			path: "services/payments/package-lock.json",
+			want: []string{"@acme/payments", "@alice"},
+		},
+		{
+			path: "/services/payments/api/go.sum",
+			want: []string{"@acme/payments", "@alice"},
+		},
+		{
+			path: "services/payments/vendor/modules.txt",
+			want: nil,
+		},
+		{
+			path: "services/payments",
+			want: []string{"@acme/security"},
+		},
+		{
+			path: "docs/index.md",
+			want: []string{"@acme/docs"},
+		},
+		{
+			path: "docs/examples/Dockerfile",
+			want: []string{"@acme/security"},
+		},
+		{
+			path: "app/logs/secret.txt",
+			want: []string{"@acme/sre"},
+		},
+		{
+			path: "README.md",
+			want: []string{"@acme/docs"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, rules.Match(tt.path))
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{
+			name: "mapping file",
+			path: "testdata/owners.yaml",
+		},
+		{
+			name:    "empty pattern",
+			path:    "testdata/invalid.yaml",
+			wantErr: "owners[0]: empty pattern",
+		},
+		{
+			name:    "missing file",
+			path:    "testdata/missing",
+			wantErr: "file open error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := owner.Load(tt.path)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, rules, 2)
+		})
+	}
+}
+
+func TestRules_Annotate(t *testing.T) {
+	rules, err := owner.Load("testdata/owners.yaml")
+	require.NoError(t, err)
+
+	report := types.Report{
+		ArtifactName: "registry.example.com/payments/api:1.0",
+		Results: types.Results{
+			{
+				Target: "registry.example.com/payments/api:1.0 (alpine 3.18.4)",
+				Class:  types.ClassOSPkg,
+			},
+			{
+				Target: "Java",
+				Class:  types.ClassLangPkg,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID: "CVE-2021-44228",
+						PkgPath:         "services/payments/app.jar",
+					},
+				},
+			},
+			{
+				Target: "services/payments/package-lock.json",
+				Class:  types.ClassLangPkg,
+				Packages: []ftypes.Package{
+					{
+						Name:     "lodash",
+						FilePath: "services/payments/package-lock.json",
+					},
+				},
+			},
+		},
+	}
+	rules.Annotate(&report)
+
+	for _, r := range report.Results {
+		assert.Equal(t, []string{"payments"}, r.Owners, r.Target)
+	}
+
+	report.ArtifactName = "alpine:3.18"
+	report.Results = types.Results{{Target: "alpine:3.18 (alpine 3.18.4)"}}
+	rules.Annotate(&report)
+	assert.Nil(t, report.Results[0].Owners)
+
+	// The default owner of the artifact doesn't apply to files whose owners are unassigned
+	rules, err = owner.Load("testdata/CODEOWNERS")
+	require.NoError(t, err)
+	report.ArtifactName = "."
+	report.Results = types.Results{{Target: "services/payments/vendor/modules.txt"}}
+	rules.Annotate(&report)
+	assert.Nil(t, report.Results[0].Owners)
+}
@@ -0,0 +1,86 @@
+package pkgpolicy
+
+import (
+	"context"
+	"encoding/json"
+
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/mapfs"
+	"github.com/zhanglimao/trivy/pkg/misconf"
+	"github.com/zhanglimao/trivy/pkg/scanner/local"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// inputFile is the virtual file name of the package inventory passed to policies
+const inputFile = "packages.json"
+
+// Input is the input of package policies.
+// Policies can see all packages of the artifact at once, e.g. to deny duplicated packages across targets.
+type Input struct {
+	ArtifactName string
+	ArtifactType ftypes.ArtifactType `json:",omitempty"`
+	OS           *ftypes.OS          `json:",omitempty"`
+	Packages     []Package
+}
+
+// Package is a package with the result it belongs to
+type Package struct {
+	Target string
+	Class  types.ResultClass
+	Type   string
+	ftypes.Package
+}
+
+// Scan evaluates the packages in the report against package policies.
+// The findings are returned as misconfigurations of the artifact.
+func Scan(ctx context.Context, report types.Report, opt misconf.ScannerOption) (types.Results, error) {
+	if len(opt.PolicyPaths) == 0 {
+		return nil, nil
+	}
+	log.Logger.Debug("Evaluating package policies...")
+
+	input := Input{
+		ArtifactName: report.ArtifactName,
+		ArtifactType: report.ArtifactType,
+		OS:           report.Metadata.OS,
+		Packages:     []Package{}, // Policies can iterate packages even if the artifact has none
+	}
+	for _, result := range report.Results {
+		for _, pkg := range result.Packages {
+			input.Packages = append(input.Packages, Package{
+				Target:  result.Target,
+				Class:   result.Class,
+				Type:    string(result.Type),
+				Package: pkg,
+			})
+		}
+	}
+
+	b, err := json.Marshal(input)
+	if err != nil {
+		return nil, xerrors.Errorf("json marshal error: %w", err)
+	}
+
+	fsys := mapfs.New()
+	if err = fsys.WriteVirtualFile(inputFile, b, 0600); err != nil {
+		return nil, xerrors.Errorf("mapfs write error: %w", err)
+	}
+
+	scanner, err := misconf.NewPackagePolicyScanner(opt)
+	if err != nil {
+		return nil, xerrors.Errorf("misconfiguration scanner error: %w", err)
+	}
+	misconfs, err := scanner.Scan(ctx, fsys)
+	if err != nil {
+		return nil, xerrors.Errorf("package policy scan error: %w", err)
+	}
+
+	// The inventory is not a real file, so findings are reported to the artifact like image config.
+	for i := range misconfs {
+		misconfs[i].FilePath = report.ArtifactName
+	}
+	return local.Scanner{}.MisconfsToResults(misconfs), nil
+}
@@ -0,0 +1,115 @@
+package pkgpolicy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer/os"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/misconf"
+	"github.com/zhanglimao/trivy/pkg/pkgpolicy"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func TestScan(t *testing.T) {
+	report := types.Report{
+		ArtifactName: "alpine:3.17",
+		ArtifactType: ftypes.ArtifactContainerImage,
+		Metadata: types.Metadata{
+			OS: &ftypes.OS{
+				Family: os.Alpine,
+				Name:   "3.17.3",
+			},
+		},
+		Results: types.Results{
+			{
+				Target: "alpine:3.17 (alpine 3.17.3)",
+				Class:  types.ClassOSPkg,
+				Type:   os.Alpine,
+				Packages: []ftypes.Package{
+					{
+						Name:    "musl",
+						Version: "1.2.3-r4",
+					},
+					{
+						Name:          "curl",
+						Version:       "8.1.0-r0",
+						RepositoryTag: "testing",
+					},
+				},
+			},
+			{
+				Target: "app/package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.Npm,
+				Packages: []ftypes.Package{
+					{
+						Name:    "event-stream",
+						Version: "3.3.6",
+					},
+					{
+						Name:    "express",
+						Version: "4.18.2",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		report types.Report
+		opt    misconf.ScannerOption
+		want   []string
+	}{
+		{
+			name:   "denied packages",
+			report: report,
+			opt: misconf.ScannerOption{
+				Namespaces:  []string{"user"},
+				PolicyPaths: []string{"testdata/policies"},
+				DataPaths:   []string{"testdata/data"},
+			},
+			want: []string{
+				"Package 'curl' is installed from the testing repository",
+				"Package 'event-stream@3.3.6' in 'app/package-lock.json' is denied: yanked",
+			},
+		},
+		{
+			name: "no packages",
+			report: types.Report{
+				ArtifactName: "scratch",
+			},
+			opt: misconf.ScannerOption{
+				Namespaces:  []string{"user"},
+				PolicyPaths: []string{"testdata/policies"},
+				DataPaths:   []string{"testdata/data"},
+			},
+		},
+		{
+			name:   "no policies",
+			report: report,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := pkgpolicy.Scan(context.Background(), tt.report, tt.opt)
+			require.NoError(t, err)
+
+			var got []string
+			for _, result := range results {
+				assert.Equal(t, tt.report.ArtifactName, result.Target)
+				assert.Equal(t, types.ResultClass(types.ClassConfig), result.Class)
+				for _, m := range result.Misconfigurations {
+					if m.Status == types.StatusFailure {
+						got = append(got, m.Message)
+					}
+				}
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
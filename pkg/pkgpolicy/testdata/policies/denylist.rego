@@ -0,0 +1,22 @@
+# METADATA
+# title: "Denied packages should not be used"
+# description: "The package is in the list of denied packages."
+# scope: package
+# custom:
+#   id: PKG001
+#   severity: HIGH
+#   input:
+#     selector:
+#     - type: json
+package user.packages.PKG001
+
+import data.denylist
+
+deny[res] {
+	pkg := input.Packages[_]
+	denied := denylist.packages[_]
+	pkg.Type == denied.type
+	pkg.Name == denied.name
+	pkg.Version == denied.version
+	res := result.new(sprintf("Package '%s@%s' in '%s' is denied: %s", [pkg.Name, pkg.Version, pkg.Target, denied.reason]), {})
+}
@@ -0,0 +1,18 @@
+# METADATA
+# title: "OS packages should be installed from stable repositories"
+# description: "Packages from the testing repository are not supported."
+# scope: package
+# custom:
+#   id: PKG002
+#   severity: MEDIUM
+#   input:
+#     selector:
+#     - type: json
+package user.packages.PKG002
+
+deny[res] {
+	pkg := input.Packages[_]
+	pkg.Class == "os-pkgs"
+	pkg.RepositoryTag == "testing"
+	res := result.new(sprintf("Package '%s' is installed from the testing repository", [pkg.Name]), {})
+}
@@ -49,6 +49,7 @@ type Option func(*options)
 // Client implements policy operations
 type Client struct {
 	*options
+	cacheDir  string
 	policyDir string
 	quiet     bool
 }
@@ -71,6 +72,7 @@ func NewClient(cacheDir string, quiet bool, opts ...Option) (*Client, error) {
 
 	return &Client{
 		options:   o,
+		cacheDir:  cacheDir,
 		policyDir: filepath.Join(cacheDir, "policy"),
 		quiet:     quiet,
 	}, nil
@@ -79,7 +81,7 @@ func NewClient(cacheDir string, quiet bool, opts ...Option) (*Client, error) {
 func (c *Client) populateOCIArtifact() error {
 	if c.artifact == nil {
 		repo := fmt.Sprintf("%s:%d", bundleRepository, bundleVersion)
-		art, err := oci.NewArtifact(repo, c.quiet, types.RegistryOptions{})
+		art, err := oci.NewArtifact(repo, c.quiet, types.RegistryOptions{}, oci.WithCacheDir(c.cacheDir))
 		if err != nil {
 			return xerrors.Errorf("OCI artifact error: %w", err)
 		}
@@ -0,0 +1,136 @@
+package progress
+
+import (
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/cheggaaa/pb/v3"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+const (
+	// sizedTemplate shows the bytes read and ETA of the layer with the known size
+	sizedTemplate pb.ProgressBarTemplate = `{{string . "prefix"}} {{counters . }} {{bar . }} {{percent . }} {{speed . }} {{rtime . "ETA %s"}} {{string . "suffix"}}`
+
+	// unsizedTemplate shows only the bytes read as the size is not known, e.g. images in Docker Engine
+	unsizedTemplate pb.ProgressBarTemplate = `{{string . "prefix"}} {{counters . }} {{speed . }} {{string . "suffix"}}`
+
+	shortIDLength = 12
+)
+
+// Layers shows the progress of the layer inspection as a bar per layer and the total.
+// It implements artifact.Progress.
+type Layers struct {
+	output io.Writer
+
+	mu    sync.Mutex
+	pool  *pb.Pool
+	total *pb.ProgressBar
+}
+
+// NewLayers returns Layers writing to the output, which must be a terminal
+func NewLayers(output io.Writer) *Layers {
+	return &Layers{output: output}
+}
+
+// Layer adds a bar of the layer. The pool of bars starts on the first layer so that nothing is shown
+// when all the layers are cached.
+func (l *Layers) Layer(id string, size int64) artifact.LayerProgress {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.pool == nil {
+		pool := pb.NewPool()
+		pool.Output = l.output
+		if err := pool.Start(); err != nil {
+			log.Logger.Debugf("Unable to show the progress: %s", err)
+			return artifact.NopProgress{}
+		}
+		l.pool = pool
+		l.total = newBar("Total", 0, sizedTemplate)
+		l.pool.Add(l.total)
+	}
+
+	tmpl := sizedTemplate
+	if size == 0 {
+		tmpl = unsizedTemplate
+	}
+	bar := newBar(shortID(id), size, tmpl)
+	bar.Set("suffix", string(artifact.StageWaiting))
+	l.total.AddTotal(size)
+	l.pool.Add(bar)
+
+	return &layer{
+		bar:   bar,
+		total: l.total,
+		size:  size,
+	}
+}
+
+// Done stops showing the progress
+func (l *Layers) Done() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.pool == nil {
+		return
+	}
+	l.total.SetCurrent(l.total.Total())
+	l.total.Finish()
+	if err := l.pool.Stop(); err != nil {
+		log.Logger.Debugf("Unable to stop the progress: %s", err)
+	}
+	l.pool = nil
+	l.total = nil
+}
+
+type layer struct {
+	bar   *pb.ProgressBar
+	total *pb.ProgressBar
+	size  int64
+}
+
+func (l *layer) Add(n int64) {
+	l.bar.Add64(n)
+	if l.size > 0 {
+		l.total.Add64(n)
+	}
+}
+
+func (l *layer) SetStage(stage artifact.Stage) {
+	l.bar.Set("suffix", string(stage))
+	switch stage {
+	case artifact.StageDone:
+		// The trailing bytes such as tar padding might not be read
+		if rest := l.size - l.bar.Current(); rest > 0 {
+			l.bar.SetCurrent(l.size)
+			l.total.Add64(rest)
+		}
+		l.bar.Finish()
+	case artifact.StageFailed:
+		l.bar.Finish()
+	}
+}
+
+func newBar(prefix string, size int64, tmpl pb.ProgressBarTemplate) *pb.ProgressBar {
+	bar := pb.New64(size)
+	bar.SetTemplate(tmpl)
+	bar.Set(pb.Bytes, true)
+	bar.Set("prefix", prefix)
+	return bar
+}
+
+// shortID returns the short ID of the layer like Docker, e.g. "sha256:4fc242d58285..." => "4fc242d58285"
+func shortID(id string) string {
+	_, hex, found := strings.Cut(id, ":")
+	if !found {
+		hex = id
+	}
+	if len(hex) > shortIDLength {
+		hex = hex[:shortIDLength]
+	}
+	return hex
+}
@@ -259,8 +259,11 @@ func parseRPM(fos *ftypes.OS, modularityLabel string) (string, packageurl.Qualif
 
 	// SLES string has whitespace
 	family := fos.Family
-	if fos.Family == os.SLES {
+	switch fos.Family {
+	case os.SLES:
 		family = "sles"
+	case os.SLEMicro:
+		family = "sle-micro"
 	}
 
 	qualifiers := packageurl.Qualifiers{
@@ -321,7 +324,7 @@ func purlType(t string) string {
 		return packageurl.TypeNuget
 	case ftypes.CondaPkg:
 		return packageurl.TypeConda
-	case ftypes.PythonPkg, ftypes.Pip, ftypes.Pipenv, ftypes.Poetry:
+	case ftypes.PythonPkg, ftypes.Pip, ftypes.Pipenv, ftypes.Poetry, ftypes.Jupyter:
 		return packageurl.TypePyPi
 	case ftypes.GoBinary, ftypes.GoModule:
 		return packageurl.TypeGolang
@@ -339,7 +342,7 @@ func purlType(t string) string {
 		return packageurl.TypeDebian
 	case os.RedHat, os.CentOS, os.Rocky, os.Alma,
 		os.Amazon, os.Fedora, os.Oracle, os.OpenSUSE,
-		os.OpenSUSELeap, os.OpenSUSETumbleweed, os.SLES, os.Photon:
+		os.OpenSUSELeap, os.OpenSUSETumbleweed, os.OpenSUSELeapMicro, os.SLES, os.SLEMicro, os.Photon:
 		return packageurl.TypeRPM
 	case TypeOCI:
 		return packageurl.TypeOCI
@@ -258,6 +258,40 @@ func TestNewPackageURL(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "os package with whitespace in family",
+			typ:  os.SLEMicro,
+			pkg: ftypes.Package{
+				Name:    "openssl-1_1",
+				Version: "1.1.1l",
+				Release: "150400.7.22.1",
+				Arch:    "x86_64",
+			},
+			metadata: types.Metadata{
+				OS: &ftypes.OS{
+					Family: os.SLEMicro,
+					Name:   "5.3",
+				},
+			},
+			want: purl.PackageURL{
+				PackageURL: packageurl.PackageURL{
+					Type:      packageurl.TypeRPM,
+					Namespace: "sle-micro",
+					Name:      "openssl-1_1",
+					Version:   "1.1.1l-150400.7.22.1",
+					Qualifiers: packageurl.Qualifiers{
+						{
+							Key:   "arch",
+							Value: "x86_64",
+						},
+						{
+							Key:   "distro",
+							Value: "sle-micro-5.3",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "container",
 			typ:  purl.TypeOCI,
@@ -2,10 +2,13 @@ package rekor
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"net/url"
 
 	httptransport "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/samber/lo"
 	"github.com/sigstore/rekor/pkg/generated/client"
 	eclient "github.com/sigstore/rekor/pkg/generated/client/entries"
 	"github.com/sigstore/rekor/pkg/generated/client/index"
@@ -51,6 +54,63 @@ func (e EntryID) String() string {
 
 type Entry struct {
 	Statement []byte
+
+	// PublicKey holds the PEM-encoded certificate or public key of the signer, which Rekor verified the signature with
+	PublicKey []byte
+
+	// Body, IntegratedTime, LogID and LogIndex are signed by Rekor in SignedEntryTimestamp (SET),
+	// so that the entry can be verified with the public key of Rekor
+	Body                 string
+	IntegratedTime       int64
+	LogID                string
+	LogIndex             int64
+	SignedEntryTimestamp []byte
+}
+
+// intotoBody is the canonicalized body of intoto entries.
+// The public key is in the spec in v0.0.1 and in the signatures of the envelope in v0.0.2.
+type intotoBody struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		PublicKey []byte `json:"publicKey"`
+		Content   struct {
+			Envelope struct {
+				Signatures []struct {
+					PublicKey []byte `json:"publicKey"`
+				} `json:"signatures"`
+			} `json:"envelope"`
+		} `json:"content"`
+	} `json:"spec"`
+}
+
+// parsePublicKey returns the public key of the signer in the entry body.
+// It returns nil for other kinds than intoto.
+func parsePublicKey(body interface{}) ([]byte, error) {
+	s, ok := body.(string)
+	if !ok {
+		return nil, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, xerrors.Errorf("base64 decode error: %w", err)
+	}
+
+	var entry intotoBody
+	if err = json.Unmarshal(b, &entry); err != nil {
+		return nil, xerrors.Errorf("json decode error: %w", err)
+	}
+	if entry.Kind != "intoto" {
+		return nil, nil
+	}
+	if len(entry.Spec.PublicKey) != 0 {
+		return entry.Spec.PublicKey, nil
+	}
+	for _, sig := range entry.Spec.Content.Envelope.Signatures {
+		if len(sig.PublicKey) != 0 {
+			return sig.PublicKey, nil
+		}
+	}
+	return nil, nil
 }
 
 type Client struct {
@@ -126,7 +186,22 @@ func (c *Client) GetEntries(ctx context.Context, entryIDs []EntryID) ([]Entry, e
 			if entry.Attestation == nil {
 				continue
 			}
-			entries = append(entries, Entry{Statement: entry.Attestation.Data})
+			publicKey, err := parsePublicKey(entry.Body)
+			if err != nil {
+				return []Entry{}, xerrors.Errorf("failed to parse the body of %s: %w", id, err)
+			}
+			e := Entry{
+				Statement:      entry.Attestation.Data,
+				PublicKey:      publicKey,
+				IntegratedTime: lo.FromPtr(entry.IntegratedTime),
+				LogID:          lo.FromPtr(entry.LogID),
+				LogIndex:       lo.FromPtr(entry.LogIndex),
+			}
+			e.Body, _ = entry.Body.(string)
+			if entry.Verification != nil {
+				e.SignedEntryTimestamp = entry.Verification.SignedEntryTimestamp
+			}
+			entries = append(entries, e)
 		}
 	}
 
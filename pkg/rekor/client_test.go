@@ -2,10 +2,13 @@ package rekor_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 
+	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -79,6 +82,9 @@ func TestClient_GetEntries(t *testing.T) {
 	type args struct {
 		uuids []rekor.EntryID
 	}
+	certificate, err := os.ReadFile("testdata/certificate.pem")
+	require.NoError(t, err)
+
 	tests := []struct {
 		name             string
 		mockResponseFile string
@@ -102,12 +108,14 @@ func TestClient_GetEntries(t *testing.T) {
 				},
 			},
 			want: []rekor.Entry{
-				{
+				signedEntry(t, "testdata/log-entries.json", "392f8ecba72f43268b5b2debb565fd5cb05ae0d3935351fa3faabce558bede72e197b5722a742b1e", rekor.Entry{
 					Statement: []byte(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"cosign.sigstore.dev/attestation/v1","subject":[{"name":"ghcr.io/aquasecurity/trivy-test-images","digest":{"sha256":"20d3f693dcffa44d6b24eae88783324d25cc132c22089f70e4fbfb858625b062"}}],"predicate":{"Data":"\"foo\\n\"\n","Timestamp":"2022-08-26T01:17:17Z"}}`),
-				},
-				{
+					PublicKey: certificate,
+				}),
+				signedEntry(t, "testdata/log-entries.json", "392f8ecba72f43268b5b2debb565fd5cb05ae0d3935351fa3faabce558bede72e197b5722a741a2f", rekor.Entry{
 					Statement: []byte(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"cosign.sigstore.dev/attestation/v1","subject":[{"name":"ghcr.io/aquasecurity/trivy-test-images","digest":{"sha256":"20d3f693dcffa44d6b24eae88783324d25cc132c22089f70e4fbfb858625b062"}}],"predicate":{"Data":"\"bar\\n\"\n","Timestamp":"2022-08-26T01:17:17Z"}}`),
-				},
+					PublicKey: certificate,
+				}),
 			},
 		},
 		{
@@ -162,3 +170,26 @@ func TestClient_GetEntries(t *testing.T) {
 		})
 	}
 }
+
+// signedEntry fills the fields signed by Rekor in the entry from the response file
+func signedEntry(t *testing.T, filePath, entryID string, entry rekor.Entry) rekor.Entry {
+	b, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+
+	var resp []map[string]models.LogEntryAnon
+	require.NoError(t, json.Unmarshal(b, &resp))
+	for _, entries := range resp {
+		e, ok := entries[entryID]
+		if !ok {
+			continue
+		}
+		entry.Body = e.Body.(string)
+		entry.IntegratedTime = *e.IntegratedTime
+		entry.LogID = *e.LogID
+		entry.LogIndex = *e.LogIndex
+		entry.SignedEntryTimestamp = e.Verification.SignedEntryTimestamp
+		return entry
+	}
+	require.Failf(t, "entry not found", entryID)
+	return entry
+}
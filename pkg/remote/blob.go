@@ -0,0 +1,158 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/hashicorp/go-multierror"
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/image/registry"
+	"github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// BlobReaderAt returns io.ReaderAt that reads the blob with HTTP range requests,
+// so that only the requested parts of the blob are transferred.
+// It tries multiple authentication methods as Get does.
+func BlobReaderAt(ctx context.Context, blob name.Digest, option types.RegistryOptions) (io.ReaderAt, error) {
+	base := httpTransport(option)
+	scopes := []string{blob.Context().Scope(transport.PullScope)}
+	url := fmt.Sprintf("%s://%s/v2/%s/blobs/%s", blob.Context().Scheme(), blob.Context().RegistryStr(),
+		blob.Context().RepositoryStr(), blob.DigestStr())
+
+	var errs error
+	// Try each authentication method until it succeeds
+	for _, auth := range authenticators(ctx, blob, option) {
+		tr, err := transport.NewWithContext(ctx, blob.Context().Registry, auth, base, scopes)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		r := blobReaderAt{
+			ctx:    ctx,
+			client: &http.Client{Transport: tr},
+			url:    url,
+		}
+
+		// Make sure the registry supports range requests with this authentication
+		if _, err = r.ReadAt(make([]byte, 1), 0); err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		return r, nil
+	}
+
+	// No authentication succeeded
+	return nil, errs
+}
+
+// BlobRange returns the blob from the offset with an HTTP range request.
+// It is used to resume interrupted downloads.
+func BlobRange(ctx context.Context, blob name.Digest, offset int64, option types.RegistryOptions) (io.ReadCloser, error) {
+	base := httpTransport(option)
+	scopes := []string{blob.Context().Scope(transport.PullScope)}
+	url := fmt.Sprintf("%s://%s/v2/%s/blobs/%s", blob.Context().Scheme(), blob.Context().RegistryStr(),
+		blob.Context().RepositoryStr(), blob.DigestStr())
+
+	var errs error
+	// Try each authentication method until it succeeds
+	for _, auth := range authenticators(ctx, blob, option) {
+		tr, err := transport.NewWithContext(ctx, blob.Context().Registry, auth, base, scopes)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if err != nil {
+			return nil, xerrors.Errorf("new request error: %w", err)
+		}
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+
+		resp, err := (&http.Client{Transport: tr}).Do(req)
+		if err != nil {
+			errs = multierror.Append(errs, xerrors.Errorf("http error: %w", err))
+			continue
+		} else if resp.StatusCode != http.StatusPartialContent {
+			resp.Body.Close()
+			errs = multierror.Append(errs, xerrors.Errorf("range request not supported (status: %d)", resp.StatusCode))
+			continue
+		}
+		return resp.Body, nil
+	}
+
+	// No authentication succeeded
+	return nil, errs
+}
+
+// authenticators returns authentication methods in the same order as authOptions
+func authenticators(ctx context.Context, ref name.Reference, option types.RegistryOptions) []authn.Authenticator {
+	if option.RegistryToken != "" {
+		return []authn.Authenticator{&authn.Bearer{Token: option.RegistryToken}}
+	}
+
+	var auths []authn.Authenticator
+	for _, cred := range option.Credentials {
+		auths = append(auths, &authn.Basic{
+			Username: cred.Username,
+			Password: cred.Password,
+		})
+	}
+
+	token := registry.GetToken(ctx, ref.Context().RegistryStr(), option)
+	if !lo.IsEmpty(token) {
+		auths = append(auths, &token)
+	}
+
+	// Use the keychain anyway at the end
+	if auth, err := authn.DefaultKeychain.Resolve(ref.Context()); err == nil {
+		auths = append(auths, auth)
+	}
+	return auths
+}
+
+type blobReaderAt struct {
+	ctx    context.Context
+	client *http.Client
+	url    string
+}
+
+func (r blobReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, r.url, http.NoBody)
+	if err != nil {
+		return 0, xerrors.Errorf("new request error: %w", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", off, off+int64(len(p))-1))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, xerrors.Errorf("http error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusRequestedRangeNotSatisfiable:
+		return 0, io.EOF
+	default:
+		return 0, xerrors.Errorf("range request not supported (status: %d)", resp.StatusCode)
+	}
+
+	n, err := io.ReadFull(resp.Body, p)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		// The last range may be shorter than requested
+		return n, io.EOF
+	}
+	return n, err
+}
@@ -14,6 +14,7 @@ import (
 	v1types "github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/hashicorp/go-multierror"
 	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
 	"golang.org/x/xerrors"
 
 	"github.com/zhanglimao/trivy/pkg/fanal/image/registry"
@@ -26,7 +27,7 @@ type Descriptor = remote.Descriptor
 // Get is a wrapper of google/go-containerregistry/pkg/v1/remote.Get
 // so that it can try multiple authentication methods.
 func Get(ctx context.Context, ref name.Reference, option types.RegistryOptions) (*Descriptor, error) {
-	transport := httpTransport(option.Insecure)
+	transport := httpTransport(option)
 
 	var errs error
 	// Try each authentication method until it succeeds
@@ -68,7 +69,7 @@ func Get(ctx context.Context, ref name.Reference, option types.RegistryOptions)
 // Image is a wrapper of google/go-containerregistry/pkg/v1/remote.Image
 // so that it can try multiple authentication methods.
 func Image(ctx context.Context, ref name.Reference, option types.RegistryOptions) (v1.Image, error) {
-	transport := httpTransport(option.Insecure)
+	transport := httpTransport(option)
 
 	var errs error
 	// Try each authentication method until it succeeds
@@ -89,10 +90,51 @@ func Image(ctx context.Context, ref name.Reference, option types.RegistryOptions
 	return nil, errs
 }
 
+// Platforms returns the platforms listed in the image index of the given reference.
+// It returns nil when the reference points to a single-arch image.
+func Platforms(ctx context.Context, ref name.Reference, option types.RegistryOptions) ([]v1.Platform, error) {
+	// Fetch the index itself rather than the manifest of a specific platform
+	option.Platform = types.Platform{}
+	desc, err := Get(ctx, ref, option)
+	if err != nil {
+		return nil, xerrors.Errorf("image get error: %w", err)
+	}
+
+	switch desc.MediaType {
+	case v1types.OCIImageIndex, v1types.DockerManifestList:
+	default:
+		return nil, nil
+	}
+
+	index, err := desc.ImageIndex()
+	if err != nil {
+		return nil, xerrors.Errorf("image index error: %w", err)
+	}
+	m, err := index.IndexManifest()
+	if err != nil {
+		return nil, xerrors.Errorf("remote index manifest error: %w", err)
+	}
+
+	var platforms []v1.Platform
+	for _, manifest := range m.Manifests {
+		// Skip attestation manifests and so on
+		if manifest.Platform == nil || manifest.Platform.OS == "unknown" {
+			continue
+		}
+		if slices.ContainsFunc(platforms, func(p v1.Platform) bool {
+			return p.Equals(*manifest.Platform)
+		}) {
+			continue
+		}
+		platforms = append(platforms, *manifest.Platform)
+	}
+	return platforms, nil
+}
+
 // Referrers is a wrapper of google/go-containerregistry/pkg/v1/remote.Referrers
 // so that it can try multiple authentication methods.
 func Referrers(ctx context.Context, d name.Digest, option types.RegistryOptions) (*v1.IndexManifest, error) {
-	transport := httpTransport(option.Insecure)
+	transport := httpTransport(option)
 
 	var errs error
 	// Try each authentication method until it succeeds
@@ -113,15 +155,66 @@ func Referrers(ctx context.Context, d name.Digest, option types.RegistryOptions)
 	return nil, errs
 }
 
-func httpTransport(insecure bool) *http.Transport {
+// Write is a wrapper of google/go-containerregistry/pkg/v1/remote.Write
+// so that it can try multiple authentication methods.
+func Write(ctx context.Context, ref name.Reference, img v1.Image, option types.RegistryOptions) error {
+	transport := httpTransport(option)
+
+	var errs error
+	// Try each authentication method until it succeeds
+	for _, authOpt := range authOptions(ctx, ref, option) {
+		remoteOpts := []remote.Option{
+			remote.WithTransport(transport),
+			remote.WithContext(ctx),
+			authOpt,
+		}
+		if err := remote.Write(ref, img, remoteOpts...); err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		return nil
+	}
+
+	// No authentication succeeded
+	return errs
+}
+
+// Tags is a wrapper of google/go-containerregistry/pkg/v1/remote.List
+// so that it can try multiple authentication methods.
+// It lists the tags in the repository of the given reference.
+func Tags(ctx context.Context, ref name.Reference, option types.RegistryOptions) ([]string, error) {
+	transport := httpTransport(option)
+
+	var errs error
+	// Try each authentication method until it succeeds
+	for _, authOpt := range authOptions(ctx, ref, option) {
+		remoteOpts := []remote.Option{
+			remote.WithTransport(transport),
+			remote.WithContext(ctx),
+			authOpt,
+		}
+		tags, err := remote.List(ref.Context(), remoteOpts...)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		return tags, nil
+	}
+
+	// No authentication succeeded
+	return nil, errs
+}
+
+func httpTransport(option types.RegistryOptions) http.RoundTripper {
 	d := &net.Dialer{
 		Timeout: 10 * time.Minute,
 	}
 	tr := http.DefaultTransport.(*http.Transport).Clone()
 	tr.DialContext = d.DialContext
-	tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecure}
+	tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: option.Insecure}
 
-	return tr
+	// Retry requests rate-limited by registries such as Docker Hub
+	return registry.NewRetryTransport(tr, option.MaxRetries)
 }
 
 func authOptions(ctx context.Context, ref name.Reference, option types.RegistryOptions) []remote.Option {
@@ -7,6 +7,7 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/zhanglimao/trivy/pkg/sbom/cyclonedx"
+	"github.com/zhanglimao/trivy/pkg/sbom/cyclonedx/core"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
@@ -17,11 +18,11 @@ type Writer struct {
 	marshaler *cyclonedx.Marshaler
 }
 
-func NewWriter(output io.Writer, appVersion string) Writer {
+func NewWriter(output io.Writer, appVersion string, opts ...core.Option) Writer {
 	return Writer{
 		output:    output,
 		format:    cdx.BOMFileFormatJSON,
-		marshaler: cyclonedx.NewMarshaler(appVersion),
+		marshaler: cyclonedx.NewMarshaler(appVersion, opts...),
 	}
 }
 
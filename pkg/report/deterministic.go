@@ -0,0 +1,163 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	k8sclock "k8s.io/utils/clock"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/report/predicate"
+	"github.com/zhanglimao/trivy/pkg/sbom/cyclonedx/core"
+	"github.com/zhanglimao/trivy/pkg/sbom/spdx"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// deterministic holds the clock and the UUID generator making the output reproducible for the same artifact.
+// Timestamps are zeroed, and UUIDs such as SBOM serial numbers are derived from the artifact.
+type deterministic struct {
+	fakeClock *clocktesting.FakeClock
+	newUUID   func() uuid.UUID
+}
+
+func newDeterministic(report types.Report) *deterministic {
+	// The same artifact results in the same seed
+	seed := strings.Join(append([]string{
+		report.ArtifactName,
+		string(report.ArtifactType),
+		report.Metadata.ImageID,
+	}, report.Metadata.RepoDigests...), "\n")
+
+	var n int
+	return &deterministic{
+		fakeClock: clocktesting.NewFakeClock(time.Time{}),
+		newUUID: func() uuid.UUID {
+			n++
+			return uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintf("%s\n%d", seed, n)))
+		},
+	}
+}
+
+// clock returns nil so that writers use the real clock unless the output is deterministic
+func (d *deterministic) clock() k8sclock.PassiveClock {
+	if d == nil {
+		return nil
+	}
+	return d.fakeClock
+}
+
+func (d *deterministic) cycloneDXOptions() []core.Option {
+	if d == nil {
+		return nil
+	}
+	return []core.Option{
+		core.WithClock(d.fakeClock),
+		core.WithNewUUID(d.newUUID),
+	}
+}
+
+func (d *deterministic) spdxOptions() []spdx.MarshalOption {
+	if d == nil {
+		return nil
+	}
+	return []spdx.MarshalOption{
+		spdx.WithClock(d.fakeClock),
+		spdx.WithNewUUID(d.newUUID),
+	}
+}
+
+func (d *deterministic) predicateOptions() []predicate.VulnWriterOption {
+	if d == nil {
+		return nil
+	}
+	return []predicate.VulnWriterOption{
+		predicate.WithClock(d.fakeClock),
+	}
+}
+
+// normalize sorts all slices in the report so that the order doesn't depend on the traversal or concurrency.
+// Items are sorted by their usual order first, and by their JSON representation when the order is not decided.
+func normalize(report *types.Report) {
+	sort.Strings(report.Metadata.RepoTags)
+	sort.Strings(report.Metadata.RepoDigests)
+
+	sortByKey(report.Results, func(r types.Result) string {
+		return joinKey(r.Target, string(r.Class), r.Type)
+	})
+	for i := range report.Results {
+		normalizeResult(&report.Results[i])
+	}
+}
+
+func normalizeResult(r *types.Result) {
+	for i := range r.Packages {
+		sort.Strings(r.Packages[i].DependsOn)
+	}
+	sortByLess(r.Packages, func(x, y ftypes.Package) bool {
+		return ftypes.Packages{x, y}.Less(0, 1)
+	})
+	sortByLess(r.Vulnerabilities, func(x, y types.DetectedVulnerability) bool {
+		return types.BySeverity{x, y}.Less(0, 1)
+	})
+	sortByKey(r.MaliciousPackages, func(m types.DetectedMaliciousPackage) string {
+		return joinKey(m.PkgName, m.InstalledVersion, m.ID)
+	})
+	sortByKey(r.Misconfigurations, func(m types.DetectedMisconfiguration) string {
+		return joinKey(m.ID, string(m.Status))
+	})
+	sortByKey(r.Secrets, func(s ftypes.SecretFinding) string {
+		return joinKey(s.RuleID, fmt.Sprintf("%09d", s.StartLine))
+	})
+	sortByKey(r.Licenses, func(l types.DetectedLicense) string {
+		return joinKey(l.PkgName, l.FilePath, l.Name)
+	})
+	sortByKey(r.CustomResources, func(c ftypes.CustomResource) string {
+		return joinKey(c.Type, c.FilePath)
+	})
+	sortByKey(r.Drifts, func(d types.DetectedDrift) string {
+		return joinKey(string(d.Kind), d.PkgName, d.FilePath)
+	})
+	sortByKey(r.SuspiciousPackages, func(s types.DetectedSuspiciousPackage) string {
+		return joinKey(s.PkgName, string(s.Kind))
+	})
+	sortByKey(r.Suppressed, func(s types.SuppressedFinding) string {
+		return joinKey(s.Type, s.ID, s.PkgName)
+	})
+}
+
+func joinKey(fields ...string) string {
+	return strings.Join(fields, "\x00")
+}
+
+func sortByKey[T any](items []T, key func(T) string) {
+	sortByLess(items, func(x, y T) bool {
+		return key(x) < key(y)
+	})
+}
+
+// sortByLess sorts the items by less, and by their JSON representation when less doesn't decide the order
+func sortByLess[T any](items []T, less func(x, y T) bool) {
+	sort.SliceStable(items, func(i, j int) bool {
+		x, y := items[i], items[j]
+		switch {
+		case less(x, y):
+			return true
+		case less(y, x):
+			return false
+		}
+		return jsonString(x) < jsonString(y)
+	})
+}
+
+func jsonString(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
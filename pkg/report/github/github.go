@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"golang.org/x/xerrors"
+	k8sclock "k8s.io/utils/clock"
 
 	"github.com/zhanglimao/trivy/pkg/clock"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
@@ -69,13 +70,20 @@ type DependencySnapshot struct {
 type Writer struct {
 	Output  io.Writer
 	Version string
+
+	// Clock overrides the scan time if set
+	Clock k8sclock.PassiveClock
 }
 
 func (w Writer) Write(report types.Report) error {
 	snapshot := &DependencySnapshot{}
 
 	//use now() method that can be overwritten while integration tests run
-	snapshot.Scanned = clock.Now().Format(time.RFC3339)
+	now := clock.Now()
+	if w.Clock != nil {
+		now = w.Clock.Now()
+	}
+	snapshot.Scanned = now.Format(time.RFC3339)
 	snapshot.Detector = Detector{
 		Name:    "trivy",
 		Version: w.Version,
@@ -8,6 +8,7 @@ import (
 
 	"github.com/package-url/packageurl-go"
 	"golang.org/x/xerrors"
+	k8sclock "k8s.io/utils/clock"
 
 	"github.com/zhanglimao/trivy/pkg/clock"
 	"github.com/zhanglimao/trivy/pkg/types"
@@ -50,13 +51,27 @@ type Metadata struct {
 type VulnWriter struct {
 	output  io.Writer
 	version string
+	clock   k8sclock.PassiveClock
 }
 
-func NewVulnWriter(output io.Writer, version string) VulnWriter {
-	return VulnWriter{
+type VulnWriterOption func(*VulnWriter)
+
+// WithClock overrides the scan time
+func WithClock(clock k8sclock.PassiveClock) VulnWriterOption {
+	return func(w *VulnWriter) {
+		w.clock = clock
+	}
+}
+
+func NewVulnWriter(output io.Writer, version string, opts ...VulnWriterOption) VulnWriter {
+	w := VulnWriter{
 		output:  output,
 		version: version,
 	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+	return w
 }
 
 func (w VulnWriter) Write(report types.Report) error {
@@ -71,6 +86,9 @@ func (w VulnWriter) Write(report types.Report) error {
 	}
 
 	now := clock.Now()
+	if w.clock != nil {
+		now = w.clock.Now()
+	}
 	predicate.Metadata = Metadata{
 		ScanStartedOn:  now,
 		ScanFinishedOn: now,
@@ -63,6 +63,7 @@ type sarifData struct {
 	message          string
 	cvssScore        string
 	locations        []location
+	properties       sarif.Properties
 }
 
 type location struct {
@@ -105,6 +106,9 @@ func (sw *SarifWriter) addSarifResult(data *sarifData) {
 		WithMessage(sarif.NewTextMessage(data.message)).
 		WithLevel(toSarifErrorLevel(data.severity)).
 		WithLocations(toSarifLocations(data.locations, data.artifactLocation, data.locationMessage))
+	if len(data.properties) > 0 {
+		result.Properties = data.properties
+	}
 	sw.run.AddResult(result)
 }
 
@@ -148,6 +152,13 @@ func (sw *SarifWriter) Write(report types.Report) error {
 			if vuln.PkgPath != "" {
 				path = ToPathUri(vuln.PkgPath, res.Class)
 			}
+			message := fmt.Sprintf("Package: %v\nInstalled Version: %v\nVulnerability %v\nSeverity: %v\nFixed Version: %v\nLink: [%v](%v)",
+				vuln.PkgName, vuln.InstalledVersion, vuln.VulnerabilityID, vuln.Severity, vuln.FixedVersion, vuln.VulnerabilityID, vuln.PrimaryURL)
+			var properties sarif.Properties
+			if origins := res.DependencyGraph.Origins(vuln.PkgID); len(origins) > 0 {
+				message += fmt.Sprintf("\nIntroduced by: %s", strings.Join(origins, ", "))
+				properties = sarif.Properties{"dependencyOrigins": origins}
+			}
 			sw.addSarifResult(&sarifData{
 				title:            "vulnerability",
 				vulnerabilityId:  vuln.VulnerabilityID,
@@ -165,8 +176,8 @@ func (sw *SarifWriter) Write(report types.Report) error {
 					vuln.VulnerabilityID, vuln.Severity, vuln.PkgName, vuln.FixedVersion, vuln.VulnerabilityID, vuln.PrimaryURL, vuln.Description),
 				helpMarkdown: fmt.Sprintf("**Vulnerability %v**\n| Severity | Package | Fixed Version | Link |\n| --- | --- | --- | --- |\n|%v|%v|%v|[%v](%v)|\n\n%v",
 					vuln.VulnerabilityID, vuln.Severity, vuln.PkgName, vuln.FixedVersion, vuln.VulnerabilityID, vuln.PrimaryURL, vuln.Description),
-				message: fmt.Sprintf("Package: %v\nInstalled Version: %v\nVulnerability %v\nSeverity: %v\nFixed Version: %v\nLink: [%v](%v)",
-					vuln.PkgName, vuln.InstalledVersion, vuln.VulnerabilityID, vuln.Severity, vuln.FixedVersion, vuln.VulnerabilityID, vuln.PrimaryURL),
+				message:    message,
+				properties: properties,
 			})
 		}
 		for _, misconf := range res.Misconfigurations {
@@ -0,0 +1,206 @@
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/zhanglimao/trivy/pkg/report"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+const draft = "http://json-schema.org/draft-07/schema#"
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	byteSliceType     = reflect.TypeOf([]byte(nil))
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	// structuralTypes implement json.Marshaler but keep the shape of the struct
+	structuralTypes = []reflect.Type{
+		reflect.TypeOf(types.Result{}),
+	}
+)
+
+// Generate returns the JSON Schema of the report in the current schema version
+func Generate() ([]byte, error) {
+	g := &generator{defs: map[string]interface{}{}}
+
+	root := g.structSchema(reflect.TypeOf(types.Report{}))
+	root["required"] = []string{"SchemaVersion"}
+	props := root["properties"].(map[string]interface{})
+	props["SchemaVersion"] = map[string]interface{}{
+		"const": report.SchemaVersion,
+	}
+
+	s := map[string]interface{}{
+		"$schema": draft,
+		"title":   fmt.Sprintf("Trivy JSON report (schema version %d)", report.SchemaVersion),
+	}
+	for k, v := range root {
+		s[k] = v
+	}
+	s["definitions"] = g.defs
+
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+type generator struct {
+	// defs holds the schemas of named structs, referred to by "$ref"
+	defs map[string]interface{}
+}
+
+func (g *generator) schema(t reflect.Type) map[string]interface{} {
+	switch {
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t == byteSliceType:
+		return nullable(map[string]interface{}{"type": "string"}) // base64
+	case implements(t, jsonMarshalerType) && !isStructural(t):
+		// The shape is decided by the custom marshaler
+		return map[string]interface{}{}
+	case implements(t, textMarshalerType):
+		return map[string]interface{}{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice:
+		return nullable(map[string]interface{}{"type": "array", "items": g.schema(t.Elem())})
+	case reflect.Array:
+		return map[string]interface{}{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return nullable(map[string]interface{}{"type": "object", "additionalProperties": g.schema(t.Elem())})
+	case reflect.Ptr:
+		return nullable(g.schema(t.Elem()))
+	case reflect.Struct:
+		return g.ref(t)
+	}
+	// Interfaces and others
+	return map[string]interface{}{}
+}
+
+// ref registers the struct in the definitions and returns the reference to it
+func (g *generator) ref(t reflect.Type) map[string]interface{} {
+	name := defName(t)
+	if name == "" {
+		// Anonymous structs are inlined
+		return g.structSchema(t)
+	}
+	if _, ok := g.defs[name]; !ok {
+		g.defs[name] = nil // Placeholder for recursive types
+		g.defs[name] = g.structSchema(t)
+	}
+	return map[string]interface{}{"$ref": "#/definitions/" + name}
+}
+
+// structSchema returns the schema of the struct.
+// Unknown properties are allowed as encoding/json ignores them and matches names case-insensitively,
+// e.g. "Eosl" in reports generated by older versions.
+func (g *generator) structSchema(t reflect.Type) map[string]interface{} {
+	props := map[string]interface{}{}
+	g.addFields(t, props)
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": props,
+	}
+}
+
+// addFields adds the fields as encoding/json does, where fields of embedded structs are promoted
+func (g *generator) addFields(t reflect.Type, props map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && !implements(ft, jsonMarshalerType) {
+				g.addFields(ft, props)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		switch ft.Kind() {
+		case reflect.Func, reflect.Chan:
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		if _, ok := props[name]; ok {
+			// Fields of the outer struct take precedence
+			continue
+		}
+		if strings.Contains(opts, "string") {
+			props[name] = map[string]interface{}{"type": "string"}
+			continue
+		}
+		props[name] = g.schema(f.Type)
+	}
+}
+
+func nullable(s map[string]interface{}) map[string]interface{} {
+	if _, ok := s["$ref"]; ok {
+		return map[string]interface{}{
+			"anyOf": []interface{}{s, map[string]interface{}{"type": "null"}},
+		}
+	}
+	typ, ok := s["type"].(string)
+	if !ok {
+		return s
+	}
+	n := map[string]interface{}{}
+	for k, v := range s {
+		n[k] = v
+	}
+	n["type"] = []string{typ, "null"}
+	return n
+}
+
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PtrTo(t).Implements(iface)
+}
+
+func isStructural(t reflect.Type) bool {
+	for _, s := range structuralTypes {
+		if t == s {
+			return true
+		}
+	}
+	return false
+}
+
+// defName returns the name of the definition, e.g. "aquasecurity.trivy-db.pkg.types.Vulnerability"
+func defName(t reflect.Type) string {
+	if t.Name() == "" {
+		return ""
+	}
+	pkg := strings.TrimPrefix(t.PkgPath(), "github.com/")
+	return strings.ReplaceAll(pkg, "/", ".") + "." + t.Name()
+}
@@ -0,0 +1,165 @@
+package schema
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/report"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// ErrNoSchemaVersion is returned when the JSON file doesn't have "SchemaVersion", i.e. it is not a Trivy report
+var ErrNoSchemaVersion = xerrors.New("'SchemaVersion' is missing, the file is not a Trivy JSON report")
+
+// Versions returns the report schema versions which can be validated
+func Versions() []int {
+	entries, err := schemaFS.ReadDir("schemas")
+	if err != nil {
+		return nil
+	}
+	var versions []int
+	for _, e := range entries {
+		var v int
+		if _, err = fmt.Sscanf(e.Name(), "report-v%d.json", &v); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	slices.Sort(versions)
+	return versions
+}
+
+// Schema returns the JSON Schema of the report schema version
+func Schema(version int) ([]byte, error) {
+	b, err := schemaFS.ReadFile(path.Join("schemas", fileName(version)))
+	if err != nil {
+		return nil, xerrors.Errorf("schema version %d is not supported (supported: %s)", version, versionList())
+	}
+	return b, nil
+}
+
+func fileName(version int) string {
+	return fmt.Sprintf("report-v%d.json", version)
+}
+
+func versionList() string {
+	return strings.Trim(fmt.Sprint(Versions()), "[]")
+}
+
+// ValidationError lists the violations of the schema
+type ValidationError struct {
+	Version    int
+	Violations []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("the report doesn't conform to schema version %d:\n  - %s",
+		e.Version, strings.Join(e.Violations, "\n  - "))
+}
+
+// Validate checks the JSON report against the schema of the version in "SchemaVersion"
+func Validate(b []byte) error {
+	version, err := schemaVersion(b)
+	if err != nil {
+		return err
+	}
+
+	s, err := Schema(version)
+	if err != nil {
+		return err
+	}
+
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(s), gojsonschema.NewBytesLoader(b))
+	if err != nil {
+		return xerrors.Errorf("schema validation error: %w", err)
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	verr := &ValidationError{Version: version}
+	for _, e := range result.Errors() {
+		// e.g. "Results.0.Vulnerabilities.1.Severity: Invalid type. Expected: string, given: integer"
+		verr.Violations = append(verr.Violations, fmt.Sprintf("%s: %s", e.Field(), e.Description()))
+	}
+	return verr
+}
+
+// schemaVersion returns "SchemaVersion" of the JSON report
+func schemaVersion(b []byte) (int, error) {
+	var header map[string]json.RawMessage
+	if err := json.Unmarshal(b, &header); err != nil {
+		return 0, decodeError(err)
+	}
+	raw, ok := header["SchemaVersion"]
+	if !ok {
+		if len(header) > 0 {
+			return 0, xerrors.Errorf("%w (found top-level fields: %s)", ErrNoSchemaVersion, strings.Join(sortedKeys(header), ", "))
+		}
+		return 0, ErrNoSchemaVersion
+	}
+
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil {
+		return 0, xerrors.Errorf("'SchemaVersion' must be an integer: %s", raw)
+	}
+	return version, nil
+}
+
+// Decode decodes the JSON report of the current schema version.
+// Errors point to the mismatched field so that users can tell which part of the report is broken.
+func Decode(r io.Reader) (types.Report, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return types.Report{}, xerrors.Errorf("read error: %w", err)
+	}
+
+	version, err := schemaVersion(b)
+	if err != nil {
+		return types.Report{}, err
+	}
+	if version != report.SchemaVersion {
+		return types.Report{}, xerrors.Errorf("the report has schema version %d, but this version of Trivy supports schema version %d, "+
+			"please generate the report with a compatible version of Trivy", version, report.SchemaVersion)
+	}
+
+	var rep types.Report
+	if err = json.Unmarshal(b, &rep); err != nil {
+		if verr := Validate(b); verr != nil {
+			// The schema violations are more helpful
+			return types.Report{}, verr
+		}
+		return types.Report{}, decodeError(err)
+	}
+	return rep, nil
+}
+
+func decodeError(err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return xerrors.Errorf("invalid JSON at offset %d: %w", syntaxErr.Offset, err)
+	case errors.As(err, &typeErr):
+		return xerrors.Errorf("'%s' must be %s, but got %s", typeErr.Field, typeErr.Type, typeErr.Value)
+	}
+	return xerrors.Errorf("json decode error: %w", err)
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := maps.Keys(m)
+	slices.Sort(keys)
+	return keys
+}
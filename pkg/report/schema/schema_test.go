@@ -0,0 +1,135 @@
+package schema_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/report"
+	"github.com/zhanglimao/trivy/pkg/report/schema"
+)
+
+var update = flag.Bool("update", false, "update the schema files")
+
+func TestGenerate(t *testing.T) {
+	got, err := schema.Generate()
+	require.NoError(t, err)
+
+	schemaFile := filepath.Join("schemas", "report-v2.json")
+	if *update {
+		require.NoError(t, os.WriteFile(schemaFile, got, 0644))
+	}
+
+	want, err := os.ReadFile(schemaFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got), "the schema is outdated, run 'mage schema'")
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantErrs []string
+	}{
+		{
+			name:  "valid",
+			input: "testdata/valid.json",
+		},
+		{
+			name:  "invalid types",
+			input: "testdata/invalid-type.json",
+			wantErrs: []string{
+				"doesn't conform to schema version 2",
+				"Results.0.Vulnerabilities.0.PkgName: Invalid type. Expected: string, given: integer",
+				"Results.0.Vulnerabilities.0.CVSS.nvd.V3Score: Invalid type. Expected: number, given: string",
+			},
+		},
+		{
+			name:  "unsupported version",
+			input: "testdata/unsupported-version.json",
+			wantErrs: []string{
+				"schema version 1 is not supported (supported: 2)",
+			},
+		},
+		{
+			name:  "no schema version",
+			input: "testdata/no-schema-version.json",
+			wantErrs: []string{
+				"'SchemaVersion' is missing, the file is not a Trivy JSON report (found top-level fields: ArtifactName, ArtifactType, Metadata, Results)",
+			},
+		},
+		{
+			name:  "broken JSON",
+			input: "testdata/broken.json",
+			wantErrs: []string{
+				"invalid JSON at offset",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := os.ReadFile(tt.input)
+			require.NoError(t, err)
+
+			err = schema.Validate(b)
+			if len(tt.wantErrs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, want := range tt.wantErrs {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            string
+		wantArtifactName string
+		wantErr          string
+	}{
+		{
+			name:             "happy path",
+			input:            "testdata/valid.json",
+			wantArtifactName: "alpine:3.17",
+		},
+		{
+			name:    "invalid types",
+			input:   "testdata/invalid-type.json",
+			wantErr: "Results.0.Vulnerabilities.0.PkgName: Invalid type. Expected: string, given: integer",
+		},
+		{
+			name:    "unsupported version",
+			input:   "testdata/unsupported-version.json",
+			wantErr: "the report has schema version 1, but this version of Trivy supports schema version 2",
+		},
+		{
+			name:    "no schema version",
+			input:   "testdata/no-schema-version.json",
+			wantErr: "'SchemaVersion' is missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.input)
+			require.NoError(t, err)
+			defer f.Close()
+
+			got, err := schema.Decode(f)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, report.SchemaVersion, got.SchemaVersion)
+			assert.Equal(t, tt.wantArtifactName, got.ArtifactName)
+		})
+	}
+}
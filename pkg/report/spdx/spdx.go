@@ -19,12 +19,12 @@ type Writer struct {
 	marshaler *spdx.Marshaler
 }
 
-func NewWriter(output io.Writer, version string, spdxFormat string) Writer {
+func NewWriter(output io.Writer, version string, spdxFormat string, opts ...spdx.MarshalOption) Writer {
 	return Writer{
 		output:    output,
 		version:   version,
 		format:    spdxFormat,
-		marshaler: spdx.NewMarshaler(version),
+		marshaler: spdx.NewMarshaler(version, opts...),
 	}
 }
 
@@ -0,0 +1,52 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/aquasecurity/table"
+	"github.com/aquasecurity/tml"
+	"github.com/samber/lo"
+
+	"github.com/zhanglimao/trivy/pkg/i18n"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// controlRenderer shows results of supply chain controls such as the image signature
+type controlRenderer struct {
+	w           *bytes.Buffer
+	tableWriter *table.Table
+	result      types.Result
+	isTerminal  bool
+}
+
+func NewControlRenderer(result types.Result, isTerminal bool) controlRenderer {
+	buf := bytes.NewBuffer([]byte{})
+	return controlRenderer{
+		w:           buf,
+		tableWriter: newTableWriter(buf, isTerminal),
+		result:      result,
+		isTerminal:  isTerminal,
+	}
+}
+
+func (r controlRenderer) Render() string {
+	r.tableWriter.SetHeaders(i18n.T("ID"), i18n.T("Title"), i18n.T("Status"), i18n.T("Signer"), i18n.T("Message"))
+	for _, c := range r.result.Controls {
+		status := string(c.Status)
+		if r.isTerminal {
+			status = lo.Ternary(c.Status == types.StatusPassed, tml.Sprintf("<green>%s</green>", status),
+				tml.Sprintf("<red>%s</red>", status))
+		}
+		r.tableWriter.AddRow(c.ID, c.Title, status, c.Signer, c.Message)
+	}
+
+	passed := lo.CountBy(r.result.Controls, func(c types.SupplyChainControl) bool {
+		return c.Status == types.StatusPassed
+	})
+	RenderTarget(r.w, r.result.Target+" (supply chain)", r.isTerminal)
+	_, _ = fmt.Fprintf(r.w, i18n.T("Total: %d (PASS: %d, FAIL: %d)")+"\n\n", len(r.result.Controls), passed, len(r.result.Controls)-passed)
+	r.tableWriter.Render()
+
+	return r.w.String()
+}
@@ -0,0 +1,80 @@
+package table
+
+import (
+	"bytes"
+
+	"github.com/aquasecurity/table"
+	"github.com/aquasecurity/tml"
+	"github.com/zhanglimao/trivy/pkg/i18n"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+type driftRenderer struct {
+	w           *bytes.Buffer
+	tableWriter *table.Table
+	result      types.Result
+	isTerminal  bool
+}
+
+func NewDriftRenderer(result types.Result, isTerminal bool) driftRenderer {
+	buf := bytes.NewBuffer([]byte{})
+	return driftRenderer{
+		w:           buf,
+		tableWriter: newTableWriter(buf, isTerminal),
+		result:      result,
+		isTerminal:  isTerminal,
+	}
+}
+
+func (r driftRenderer) Render() string {
+	r.setHeaders()
+	r.setRows()
+
+	var added, modified int
+	for _, d := range r.result.Drifts {
+		if d.Kind == types.DriftAdded {
+			added++
+		} else {
+			modified++
+		}
+	}
+
+	target := r.result.Target + " (drift)"
+	RenderTarget(r.w, target, r.isTerminal)
+	r.printf(i18n.T("Total: %d (added: %d, modified: %d)")+"\n\n", len(r.result.Drifts), added, modified)
+
+	r.tableWriter.Render()
+
+	return r.w.String()
+}
+
+func (r driftRenderer) setHeaders() {
+	header := []string{i18n.T("Type"), i18n.T("Package / File"), i18n.T("Kind"), i18n.T("Baseline"), i18n.T("Current")}
+	r.tableWriter.SetHeaders(header...)
+}
+
+func (r driftRenderer) setRows() {
+	for _, d := range r.result.Drifts {
+		name, baseline, current := d.PkgName, d.BaselineVersion, d.InstalledVersion
+		switch d.Type {
+		case types.DriftTypeExecutable:
+			name, baseline, current = d.FilePath, shortDigest(d.BaselineDigest), shortDigest(d.Digest)
+		case types.DriftTypeFile:
+			name = d.FilePath
+		}
+		r.tableWriter.AddRow(d.Type, name, string(d.Kind), baseline, current)
+	}
+}
+
+func (r *driftRenderer) printf(format string, args ...interface{}) {
+	// nolint
+	_ = tml.Fprintf(r.w, format, args...)
+}
+
+// shortDigest trims the digest for readability, e.g. "sha256:9f64a747e1b9"
+func shortDigest(digest string) string {
+	if len(digest) > 19 {
+		return digest[:19]
+	}
+	return digest
+}
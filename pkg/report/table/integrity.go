@@ -0,0 +1,46 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/aquasecurity/table"
+	"github.com/samber/lo"
+
+	"github.com/zhanglimao/trivy/pkg/i18n"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// integrityRenderer shows files of OS packages modified after installation
+type integrityRenderer struct {
+	w           *bytes.Buffer
+	tableWriter *table.Table
+	result      types.Result
+	isTerminal  bool
+}
+
+func NewIntegrityRenderer(result types.Result, isTerminal bool) integrityRenderer {
+	buf := bytes.NewBuffer([]byte{})
+	return integrityRenderer{
+		w:           buf,
+		tableWriter: newTableWriter(buf, isTerminal),
+		result:      result,
+		isTerminal:  isTerminal,
+	}
+}
+
+func (r integrityRenderer) Render() string {
+	r.tableWriter.SetHeaders(i18n.T("File"), i18n.T("Package"), i18n.T("Expected"), i18n.T("Actual"), i18n.T("Layer"))
+	pkgs := map[string]struct{}{}
+	for _, f := range r.result.ModifiedFiles {
+		pkgs[f.PkgName] = struct{}{}
+		r.tableWriter.AddRow(f.Path, f.PkgName, shortDigest(f.Expected.String()), shortDigest(f.Actual.String()),
+			shortDigest(lo.Ternary(f.Layer.DiffID != "", f.Layer.DiffID, f.Layer.Digest)))
+	}
+
+	RenderTarget(r.w, r.result.Target+" (integrity)", r.isTerminal)
+	_, _ = fmt.Fprintf(r.w, i18n.T("Total: %d (packages: %d)")+"\n\n", len(r.result.ModifiedFiles), len(pkgs))
+	r.tableWriter.Render()
+
+	return r.w.String()
+}
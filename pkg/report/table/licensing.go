@@ -12,6 +12,7 @@ import (
 	"github.com/aquasecurity/table"
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/i18n"
 	"github.com/zhanglimao/trivy/pkg/types"
 
 	"github.com/fatih/color"
@@ -48,7 +49,7 @@ func (r pkgLicenseRenderer) Render() string {
 
 	target := r.result.Target + " (license)"
 	RenderTarget(r.w, target, r.isTerminal)
-	r.printf("Total: %d (%s)\n\n", total, strings.Join(summaries, ", "))
+	r.printf(i18n.T("Total: %d (%s)")+"\n\n", total, strings.Join(summaries, ", "))
 
 	r.tableWriter.Render()
 
@@ -56,7 +57,7 @@ func (r pkgLicenseRenderer) Render() string {
 }
 
 func (r pkgLicenseRenderer) setHeaders() {
-	header := []string{"Package", "License", "Classification", "Severity"}
+	header := []string{i18n.T("Package"), i18n.T("License"), i18n.T("Classification"), i18n.T("Severity")}
 	r.tableWriter.SetHeaders(header...)
 }
 
@@ -65,11 +66,11 @@ func (r pkgLicenseRenderer) setRows() {
 		var row []string
 		if r.isTerminal {
 			row = []string{
-				l.PkgName, l.Name, colorizeLicenseCategory(l.Category), ColorizeSeverity(l.Severity, l.Severity),
+				l.PkgName, l.Name, colorizeLicenseCategory(l.Category), ColorizeSeverity(i18n.Severity(l.Severity), l.Severity),
 			}
 		} else {
 			row = []string{
-				l.PkgName, l.Name, string(l.Category), l.Severity,
+				l.PkgName, l.Name, string(l.Category), i18n.Severity(l.Severity),
 			}
 		}
 		r.tableWriter.AddRow(row...)
@@ -118,7 +119,7 @@ func (r fileLicenseRenderer) Render() string {
 
 	target := r.result.Target + " (license)"
 	RenderTarget(r.w, target, r.isTerminal)
-	r.printf("Total: %d (%s)\n\n", total, strings.Join(summaries, ", "))
+	r.printf(i18n.T("Total: %d (%s)")+"\n\n", total, strings.Join(summaries, ", "))
 
 	r.tableWriter.Render()
 
@@ -126,7 +127,7 @@ func (r fileLicenseRenderer) Render() string {
 }
 
 func (r fileLicenseRenderer) setHeaders() {
-	header := []string{"Classification", "Severity", "License", "File Location"}
+	header := []string{i18n.T("Classification"), i18n.T("Severity"), i18n.T("License"), i18n.T("File Location")}
 	r.tableWriter.SetHeaders(header...)
 }
 
@@ -150,11 +151,11 @@ func (r fileLicenseRenderer) setRows() {
 		var row []string
 		if r.isTerminal {
 			row = []string{
-				colorizeLicenseCategory(l.Category), ColorizeSeverity(l.Severity, l.Severity), l.Name, l.FilePath,
+				colorizeLicenseCategory(l.Category), ColorizeSeverity(i18n.Severity(l.Severity), l.Severity), l.Name, l.FilePath,
 			}
 		} else {
 			row = []string{
-				string(l.Category), l.Severity, l.Name, l.FilePath,
+				string(l.Category), i18n.Severity(l.Severity), l.Name, l.FilePath,
 			}
 		}
 		r.tableWriter.AddRow(row...)
@@ -0,0 +1,76 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquasecurity/table"
+	"github.com/aquasecurity/tml"
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/i18n"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+type maliciousRenderer struct {
+	w           *bytes.Buffer
+	tableWriter *table.Table
+	result      types.Result
+	isTerminal  bool
+	severities  []dbTypes.Severity
+}
+
+func NewMaliciousRenderer(result types.Result, isTerminal bool, severities []dbTypes.Severity) maliciousRenderer {
+	buf := bytes.NewBuffer([]byte{})
+	return maliciousRenderer{
+		w:           buf,
+		tableWriter: newTableWriter(buf, isTerminal),
+		result:      result,
+		isTerminal:  isTerminal,
+		severities:  severities,
+	}
+}
+
+func (r maliciousRenderer) Render() string {
+	r.setHeaders()
+	r.setRows()
+
+	severityCount := map[string]int{}
+	for _, m := range r.result.MaliciousPackages {
+		severityCount[m.Severity]++
+	}
+	total, summaries := summarize(r.severities, severityCount)
+
+	target := i18n.Sprintf("%s (%s, malicious packages)", r.result.Target, r.result.Type)
+	RenderTarget(r.w, target, r.isTerminal)
+	r.printf(i18n.T("Total: %d (%s)")+"\n\n", total, strings.Join(summaries, ", "))
+
+	r.tableWriter.Render()
+
+	return r.w.String()
+}
+
+func (r maliciousRenderer) setHeaders() {
+	header := []string{i18n.T("Library"), i18n.T("ID"), i18n.T("Severity"), i18n.T("Installed Version"), i18n.T("Summary")}
+	r.tableWriter.SetHeaders(header...)
+}
+
+func (r maliciousRenderer) setRows() {
+	for _, m := range r.result.MaliciousPackages {
+		lib := m.PkgName
+		if m.PkgPath != "" {
+			lib = fmt.Sprintf("%s (%s)", m.PkgName, filepath.Base(m.PkgPath))
+		}
+		severity := i18n.Severity(m.Severity)
+		if r.isTerminal {
+			severity = ColorizeSeverity(severity, m.Severity)
+		}
+		r.tableWriter.AddRow(lib, m.ID, severity, m.InstalledVersion, m.Summary)
+	}
+}
+
+func (r *maliciousRenderer) printf(format string, args ...interface{}) {
+	// nolint
+	_ = tml.Fprintf(r.w, format, args...)
+}
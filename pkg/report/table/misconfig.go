@@ -13,6 +13,7 @@ import (
 
 	"github.com/aquasecurity/tml"
 
+	"github.com/zhanglimao/trivy/pkg/i18n"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
@@ -59,9 +60,9 @@ func (r *misconfigRenderer) Render() string {
 	total, summaries := summarize(r.severities, r.countSeverities())
 
 	summary := r.result.MisconfSummary
-	r.printf("Tests: %d (SUCCESSES: %d, FAILURES: %d, EXCEPTIONS: %d)\n",
+	r.printf(i18n.T("Tests: %d (SUCCESSES: %d, FAILURES: %d, EXCEPTIONS: %d)")+"\n",
 		summary.Successes+summary.Failures+summary.Exceptions, summary.Successes, summary.Failures, summary.Exceptions)
-	r.printf("Failures: %d (%s)\n\n", total, strings.Join(summaries, ", "))
+	r.printf(i18n.T("Failures: %d (%s)")+"\n\n", total, strings.Join(summaries, ", "))
 
 	for _, m := range r.result.Misconfigurations {
 		r.renderSingle(m)
@@ -125,15 +126,15 @@ func (r *misconfigRenderer) renderSummary(misconf types.DetectedMisconfiguration
 	// severity
 	switch misconf.Severity {
 	case severityCritical:
-		r.printf("<red><bold>%s: ", misconf.Severity)
+		r.printf("<red><bold>%s: ", i18n.Severity(misconf.Severity))
 	case severityHigh:
-		r.printf("<red>%s: ", misconf.Severity)
+		r.printf("<red>%s: ", i18n.Severity(misconf.Severity))
 	case severityMedium:
-		r.printf("<yellow>%s: ", misconf.Severity)
+		r.printf("<yellow>%s: ", i18n.Severity(misconf.Severity))
 	case severityLow:
-		r.printf("%s: ", misconf.Severity)
+		r.printf("%s: ", i18n.Severity(misconf.Severity))
 	default:
-		r.printf("<blue>%s: ", misconf.Severity)
+		r.printf("<blue>%s: ", i18n.Severity(misconf.Severity))
 	}
 
 	// heading
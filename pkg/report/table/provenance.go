@@ -0,0 +1,60 @@
+package table
+
+import (
+	"bytes"
+
+	"github.com/aquasecurity/table"
+	"github.com/aquasecurity/tml"
+	"github.com/samber/lo"
+
+	"github.com/zhanglimao/trivy/pkg/i18n"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// provenanceRenderer shows verification results of provenance attestations
+type provenanceRenderer struct {
+	w           *bytes.Buffer
+	tableWriter *table.Table
+	result      types.Result
+	isTerminal  bool
+}
+
+func NewProvenanceRenderer(result types.Result, isTerminal bool) provenanceRenderer {
+	buf := bytes.NewBuffer([]byte{})
+	return provenanceRenderer{
+		w:           buf,
+		tableWriter: newTableWriter(buf, isTerminal),
+		result:      result,
+		isTerminal:  isTerminal,
+	}
+}
+
+func (r provenanceRenderer) Render() string {
+	r.tableWriter.SetHeaders(i18n.T("Status"), i18n.T("Source"), i18n.T("Signer"), i18n.T("Builder"), i18n.T("Message"))
+	for _, p := range r.result.Provenance {
+		status := string(p.Status)
+		if r.isTerminal {
+			status = lo.Ternary(p.Status == types.ProvenancePassed, tml.Sprintf("<green>%s</green>", status),
+				tml.Sprintf("<red>%s</red>", status))
+		}
+		var signer string
+		if p.Subject != "" {
+			signer = p.Subject + " (" + p.Issuer + ")"
+		}
+		r.tableWriter.AddRow(status, p.Source, signer, p.BuilderID, p.Message)
+	}
+
+	passed := lo.CountBy(r.result.Provenance, func(p types.ProvenanceVerification) bool {
+		return p.Status == types.ProvenancePassed
+	})
+	RenderTarget(r.w, r.result.Target+" (provenance)", r.isTerminal)
+	r.printf(i18n.T("Total: %d (PASS: %d, FAIL: %d)")+"\n\n", len(r.result.Provenance), passed, len(r.result.Provenance)-passed)
+	r.tableWriter.Render()
+
+	return r.w.String()
+}
+
+func (r *provenanceRenderer) printf(format string, args ...interface{}) {
+	// nolint
+	_ = tml.Fprintf(r.w, format, args...)
+}
@@ -0,0 +1,51 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/table"
+	"github.com/zhanglimao/trivy/pkg/i18n"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+type recommendationRenderer struct {
+	w               *bytes.Buffer
+	tableWriter     *table.Table
+	recommendations []types.Recommendation
+	isTerminal      bool
+}
+
+func NewRecommendationRenderer(recommendations []types.Recommendation, isTerminal bool) recommendationRenderer {
+	buf := bytes.NewBuffer([]byte{})
+	return recommendationRenderer{
+		w:               buf,
+		tableWriter:     newTableWriter(buf, isTerminal),
+		recommendations: recommendations,
+		isTerminal:      isTerminal,
+	}
+}
+
+func (r recommendationRenderer) Render() string {
+	r.setHeaders()
+	r.setRows()
+
+	RenderTarget(r.w, i18n.T("Recommendations"), r.isTerminal)
+	r.w.WriteString("\n")
+	r.tableWriter.Render()
+
+	return r.w.String()
+}
+
+func (r recommendationRenderer) setHeaders() {
+	header := []string{i18n.T("Type"), i18n.T("Current"), i18n.T("Recommended"), i18n.T("Resolved Vulnerabilities"), i18n.T("Newer Tags")}
+	r.tableWriter.SetHeaders(header...)
+}
+
+func (r recommendationRenderer) setRows() {
+	for _, rec := range r.recommendations {
+		resolved := fmt.Sprintf("%d/%d (estimated)", rec.ResolvedVulnerabilities, rec.Vulnerabilities)
+		r.tableWriter.AddRow(string(rec.Type), rec.BaseImage, rec.Recommended, resolved, strings.Join(rec.NewerTags, "\n"))
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/i18n"
 )
 
 type secretRenderer struct {
@@ -47,7 +48,7 @@ func (r *secretRenderer) Render() string {
 	severityCount := r.countSeverities()
 	total, summaries := summarize(r.severities, severityCount)
 
-	r.printf("Total: %d (%s)\n\n", total, strings.Join(summaries, ", "))
+	r.printf(i18n.T("Total: %d (%s)")+"\n\n", total, strings.Join(summaries, ", "))
 
 	for _, m := range r.secrets {
 		r.renderSingle(m)
@@ -88,15 +89,15 @@ func (r *secretRenderer) renderSummary(secret types.SecretFinding) {
 	// severity
 	switch secret.Severity {
 	case severityCritical:
-		r.printf("<red><bold>%s: ", secret.Severity)
+		r.printf("<red><bold>%s: ", i18n.Severity(secret.Severity))
 	case severityHigh:
-		r.printf("<red>%s: ", secret.Severity)
+		r.printf("<red>%s: ", i18n.Severity(secret.Severity))
 	case severityMedium:
-		r.printf("<yellow>%s: ", secret.Severity)
+		r.printf("<yellow>%s: ", i18n.Severity(secret.Severity))
 	case severityLow:
-		r.printf("%s: ", secret.Severity)
+		r.printf("%s: ", i18n.Severity(secret.Severity))
 	default:
-		r.printf("<blue>%s: ", secret.Severity)
+		r.printf("<blue>%s: ", i18n.Severity(secret.Severity))
 	}
 
 	// heading
@@ -0,0 +1,85 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/table"
+	"github.com/zhanglimao/trivy/pkg/i18n"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+type imageSizeRenderer struct {
+	w           *bytes.Buffer
+	tableWriter *table.Table
+	result      types.Result
+	isTerminal  bool
+}
+
+func NewImageSizeRenderer(result types.Result, isTerminal bool) imageSizeRenderer {
+	buf := bytes.NewBuffer([]byte{})
+	return imageSizeRenderer{
+		w:           buf,
+		tableWriter: newTableWriter(buf, isTerminal),
+		result:      result,
+		isTerminal:  isTerminal,
+	}
+}
+
+func (r imageSizeRenderer) Render() string {
+	size := r.result.ImageSize
+	r.setHeaders()
+	r.setRows()
+
+	var ratio float64
+	if size.TotalSize > 0 {
+		ratio = float64(size.WastedSize) / float64(size.TotalSize) * 100
+	}
+
+	target := r.result.Target + " (size)"
+	RenderTarget(r.w, target, r.isTerminal)
+	_, _ = fmt.Fprintf(r.w, "Total: %s, Wasted: %s (%.1f%%)\n\n", formatSize(size.TotalSize), formatSize(size.WastedSize), ratio)
+
+	r.tableWriter.Render()
+
+	return r.w.String()
+}
+
+func (r imageSizeRenderer) setHeaders() {
+	header := []string{i18n.T("Layer"), i18n.T("Created By"), i18n.T("Added"), i18n.T("Removed")}
+	r.tableWriter.SetHeaders(header...)
+}
+
+func (r imageSizeRenderer) setRows() {
+	for _, l := range r.result.ImageSize.Layers {
+		added := fmt.Sprintf("%s (%d files)", formatSize(l.AddedSize), l.AddedFiles)
+		var removed string
+		if l.RemovedFiles > 0 {
+			removed = fmt.Sprintf("%s (%d files)", formatSize(l.RemovedSize), l.RemovedFiles)
+		}
+		r.tableWriter.AddRow(shortDigest(l.DiffID), truncate(l.CreatedBy, 60), added, removed)
+	}
+}
+
+// formatSize formats the size in bytes in binary units, e.g. "1.5 MiB"
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
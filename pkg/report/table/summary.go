@@ -0,0 +1,229 @@
+package table
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+
+	"github.com/aquasecurity/table"
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/i18n"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// Finding classes shown in the summary
+const (
+	findingVulnerability    = "Vulnerability"
+	findingMalicious        = "Malicious"
+	findingMisconfiguration = "Misconfiguration"
+	findingSecret           = "Secret"
+	findingLicense          = "License"
+)
+
+type summaryRenderer struct {
+	w          *bytes.Buffer
+	results    types.Results
+	isTerminal bool
+	severities []string // in the order of dbTypes.SeverityNames
+	top        int
+}
+
+// NewSummaryRenderer returns a renderer showing the number of findings by severity and class per target,
+// followed by the top N vulnerable packages.
+func NewSummaryRenderer(results types.Results, isTerminal bool, severities []dbTypes.Severity, top int) summaryRenderer {
+	var specified []string
+	for _, sev := range severities {
+		specified = append(specified, sev.String())
+	}
+	return summaryRenderer{
+		w:          bytes.NewBuffer([]byte{}),
+		results:    results,
+		isTerminal: isTerminal,
+		severities: lo.Filter(dbTypes.SeverityNames, func(s string, _ int) bool {
+			return slices.Contains(specified, s)
+		}),
+		top: top,
+	}
+}
+
+func (r summaryRenderer) Render() string {
+	r.renderTargets()
+	if r.top > 0 {
+		r.renderTopPackages()
+	}
+	return r.w.String()
+}
+
+// summaryRow holds the number of findings by severity
+type summaryRow struct {
+	counts map[string]int
+	total  int
+}
+
+func (s *summaryRow) add(severity string) {
+	if s.counts == nil {
+		s.counts = make(map[string]int)
+	}
+	s.counts[severity]++
+	s.total++
+}
+
+func (r summaryRenderer) renderTargets() {
+	tw := r.newTableWriter(i18n.T("Target"), i18n.T("Type"), i18n.T("Finding"))
+
+	var rows int
+	for _, result := range r.results {
+		for _, finding := range []struct {
+			class string
+			row   summaryRow
+		}{
+			{findingVulnerability, summarizeVulnerabilities(result)},
+			{findingMalicious, summarizeMalicious(result)},
+			{findingMisconfiguration, summarizeMisconfigurations(result)},
+			{findingSecret, summarizeSecrets(result)},
+			{findingLicense, summarizeLicenses(result)},
+		} {
+			// OS packages are always shown as the table does so that it is clear the OS is scanned
+			if finding.row.total == 0 && (result.Class != types.ClassOSPkg || finding.class != findingVulnerability) {
+				continue
+			}
+			tw.AddRow(r.row([]string{result.Target, result.Type, i18n.T(finding.class)}, finding.row)...)
+			rows++
+		}
+	}
+	if rows == 0 {
+		return
+	}
+
+	RenderTarget(r.w, i18n.T("Summary"), r.isTerminal)
+	tw.Render()
+}
+
+// vulnerablePackage is a package aggregated over the vulnerabilities
+type vulnerablePackage struct {
+	target           string
+	name             string
+	installedVersion string
+	summaryRow
+}
+
+func (r summaryRenderer) renderTopPackages() {
+	var pkgs []*vulnerablePackage
+	index := make(map[[3]string]*vulnerablePackage)
+	for _, result := range r.results {
+		for _, vuln := range result.Vulnerabilities {
+			key := [3]string{result.Target, vuln.PkgName, vuln.InstalledVersion}
+			pkg, ok := index[key]
+			if !ok {
+				pkg = &vulnerablePackage{
+					target:           result.Target,
+					name:             vuln.PkgName,
+					installedVersion: vuln.InstalledVersion,
+				}
+				index[key] = pkg
+				pkgs = append(pkgs, pkg)
+			}
+			pkg.add(vuln.Severity)
+		}
+	}
+	if len(pkgs) == 0 {
+		return
+	}
+
+	// Sort by the number of the most severe vulnerabilities first
+	sort.SliceStable(pkgs, func(i, j int) bool {
+		for k := len(dbTypes.SeverityNames) - 1; k >= 0; k-- {
+			sev := dbTypes.SeverityNames[k]
+			if pkgs[i].counts[sev] != pkgs[j].counts[sev] {
+				return pkgs[i].counts[sev] > pkgs[j].counts[sev]
+			}
+		}
+		if pkgs[i].name != pkgs[j].name {
+			return pkgs[i].name < pkgs[j].name
+		}
+		return pkgs[i].target < pkgs[j].target
+	})
+	if len(pkgs) > r.top {
+		pkgs = pkgs[:r.top]
+	}
+
+	tw := r.newTableWriter(i18n.T("Package"), i18n.T("Installed Version"), i18n.T("Target"))
+	for _, pkg := range pkgs {
+		tw.AddRow(r.row([]string{pkg.name, pkg.installedVersion, pkg.target}, pkg.summaryRow)...)
+	}
+
+	RenderTarget(r.w, i18n.Sprintf("Top %d Vulnerable Packages", len(pkgs)), r.isTerminal)
+	tw.Render()
+}
+
+func (r summaryRenderer) newTableWriter(headers ...string) *table.Table {
+	tw := newTableWriter(r.w, r.isTerminal)
+	// Counts in different columns must not be merged
+	tw.SetAutoMerge(false)
+	tw.SetRowLines(false)
+	for _, sev := range r.severities {
+		headers = append(headers, i18n.Severity(sev))
+	}
+	tw.SetHeaders(append(headers, i18n.T("Total"))...)
+	return tw
+}
+
+// row appends the counts of the specified severities and the sum of them to the columns
+func (r summaryRenderer) row(columns []string, s summaryRow) []string {
+	var total int
+	for _, sev := range r.severities {
+		count := strconv.Itoa(s.counts[sev])
+		if r.isTerminal && s.counts[sev] > 0 {
+			count = ColorizeSeverity(count, sev)
+		}
+		columns = append(columns, count)
+		total += s.counts[sev]
+	}
+	return append(columns, strconv.Itoa(total))
+}
+
+func summarizeVulnerabilities(result types.Result) summaryRow {
+	var s summaryRow
+	for _, vuln := range result.Vulnerabilities {
+		s.add(vuln.Severity)
+	}
+	return s
+}
+
+func summarizeMalicious(result types.Result) summaryRow {
+	var s summaryRow
+	for _, m := range result.MaliciousPackages {
+		s.add(m.Severity)
+	}
+	return s
+}
+
+func summarizeMisconfigurations(result types.Result) summaryRow {
+	var s summaryRow
+	for _, misconf := range result.Misconfigurations {
+		if misconf.Status != types.StatusFailure {
+			continue
+		}
+		s.add(misconf.Severity)
+	}
+	return s
+}
+
+func summarizeSecrets(result types.Result) summaryRow {
+	var s summaryRow
+	for _, secret := range result.Secrets {
+		s.add(secret.Severity)
+	}
+	return s
+}
+
+func summarizeLicenses(result types.Result) summaryRow {
+	var s summaryRow
+	for _, l := range result.Licenses {
+		s.add(l.Severity)
+	}
+	return s
+}
@@ -0,0 +1,191 @@
+package table_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/i18n"
+	"github.com/zhanglimao/trivy/pkg/report"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func TestReportWriter_Summary(t *testing.T) {
+	vuln := func(id, pkgName, version, severity string) types.DetectedVulnerability {
+		return types.DetectedVulnerability{
+			VulnerabilityID:  id,
+			PkgName:          pkgName,
+			InstalledVersion: version,
+			Vulnerability: dbTypes.Vulnerability{
+				Severity: severity,
+			},
+		}
+	}
+	results := types.Results{
+		{
+			Target: "alpine:3.17 (alpine 3.17.3)",
+			Class:  types.ClassOSPkg,
+			Type:   "alpine",
+		},
+		{
+			Target: "app/package-lock.json",
+			Class:  types.ClassLangPkg,
+			Type:   "npm",
+			Vulnerabilities: []types.DetectedVulnerability{
+				vuln("CVE-2023-0001", "lodash", "4.17.4", "CRITICAL"),
+				vuln("CVE-2023-0002", "lodash", "4.17.4", "HIGH"),
+				vuln("CVE-2023-0003", "minimist", "1.2.0", "HIGH"),
+				vuln("CVE-2023-0004", "minimist", "1.2.0", "HIGH"),
+				vuln("CVE-2023-0005", "qs", "6.5.0", "MEDIUM"),
+			},
+			MaliciousPackages: []types.DetectedMaliciousPackage{
+				{
+					ID:       "MAL-2023-0001",
+					PkgName:  "evil",
+					Severity: "CRITICAL",
+				},
+			},
+		},
+		{
+			Target: "Dockerfile",
+			Class:  types.ClassConfig,
+			Type:   "dockerfile",
+			Misconfigurations: []types.DetectedMisconfiguration{
+				{
+					ID:       "DS002",
+					Severity: "HIGH",
+					Status:   types.StatusFailure,
+				},
+				{
+					ID:       "DS001",
+					Severity: "MEDIUM",
+					Status:   types.StatusPassed,
+				},
+			},
+		},
+		{
+			Target: "app/.env",
+			Class:  types.ClassSecret,
+			Secrets: []ftypes.SecretFinding{
+				{
+					RuleID:   "aws-access-key-id",
+					Severity: "CRITICAL",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		top        int
+		severities []dbTypes.Severity
+		locale     i18n.Locale
+		want       string
+	}{
+		{
+			name: "all severities",
+			top:  2,
+			severities: []dbTypes.Severity{
+				dbTypes.SeverityUnknown,
+				dbTypes.SeverityLow,
+				dbTypes.SeverityMedium,
+				dbTypes.SeverityHigh,
+				dbTypes.SeverityCritical,
+			},
+			want: `
+Summary
+=======
+┌─────────────────────────────┬────────────┬──────────────────┬─────────┬─────┬────────┬──────┬──────────┬───────┐
+│           Target            │    Type    │     Finding      │ UNKNOWN │ LOW │ MEDIUM │ HIGH │ CRITICAL │ Total │
+├─────────────────────────────┼────────────┼──────────────────┼─────────┼─────┼────────┼──────┼──────────┼───────┤
+│ alpine:3.17 (alpine 3.17.3) │ alpine     │ Vulnerability    │ 0       │ 0   │ 0      │ 0    │ 0        │ 0     │
+│ app/package-lock.json       │ npm        │ Vulnerability    │ 0       │ 0   │ 1      │ 3    │ 1        │ 5     │
+│ app/package-lock.json       │ npm        │ Malicious        │ 0       │ 0   │ 0      │ 0    │ 1        │ 1     │
+│ Dockerfile                  │ dockerfile │ Misconfiguration │ 0       │ 0   │ 0      │ 1    │ 0        │ 1     │
+│ app/.env                    │            │ Secret           │ 0       │ 0   │ 0      │ 0    │ 1        │ 1     │
+└─────────────────────────────┴────────────┴──────────────────┴─────────┴─────┴────────┴──────┴──────────┴───────┘
+
+Top 2 Vulnerable Packages
+=========================
+┌──────────┬───────────────────┬───────────────────────┬─────────┬─────┬────────┬──────┬──────────┬───────┐
+│ Package  │ Installed Version │        Target         │ UNKNOWN │ LOW │ MEDIUM │ HIGH │ CRITICAL │ Total │
+├──────────┼───────────────────┼───────────────────────┼─────────┼─────┼────────┼──────┼──────────┼───────┤
+│ lodash   │ 4.17.4            │ app/package-lock.json │ 0       │ 0   │ 0      │ 1    │ 1        │ 2     │
+│ minimist │ 1.2.0             │ app/package-lock.json │ 0       │ 0   │ 0      │ 2    │ 0        │ 2     │
+└──────────┴───────────────────┴───────────────────────┴─────────┴─────┴────────┴──────┴──────────┴───────┘
+`,
+		},
+		{
+			name: "specified severities without top packages",
+			top:  0,
+			severities: []dbTypes.Severity{
+				dbTypes.SeverityHigh,
+				dbTypes.SeverityCritical,
+			},
+			want: `
+Summary
+=======
+┌─────────────────────────────┬────────────┬──────────────────┬──────┬──────────┬───────┐
+│           Target            │    Type    │     Finding      │ HIGH │ CRITICAL │ Total │
+├─────────────────────────────┼────────────┼──────────────────┼──────┼──────────┼───────┤
+│ alpine:3.17 (alpine 3.17.3) │ alpine     │ Vulnerability    │ 0    │ 0        │ 0     │
+│ app/package-lock.json       │ npm        │ Vulnerability    │ 3    │ 1        │ 4     │
+│ app/package-lock.json       │ npm        │ Malicious        │ 0    │ 1        │ 1     │
+│ Dockerfile                  │ dockerfile │ Misconfiguration │ 1    │ 0        │ 1     │
+│ app/.env                    │            │ Secret           │ 0    │ 1        │ 1     │
+└─────────────────────────────┴────────────┴──────────────────┴──────┴──────────┴───────┘
+`,
+		},
+		{
+			name: "localized",
+			top:  1,
+			severities: []dbTypes.Severity{
+				dbTypes.SeverityHigh,
+				dbTypes.SeverityCritical,
+			},
+			locale: i18n.ChineseSimplified,
+			want: `
+摘要
+====
+┌─────────────────────────────┬────────────┬────────────┬──────┬──────┬──────┐
+│            目标             │    类型    │  发现类别  │ 高危 │ 严重 │ 合计 │
+├─────────────────────────────┼────────────┼────────────┼──────┼──────┼──────┤
+│ alpine:3.17 (alpine 3.17.3) │ alpine     │ 漏洞       │ 0    │ 0    │ 0    │
+│ app/package-lock.json       │ npm        │ 漏洞       │ 3    │ 1    │ 4    │
+│ app/package-lock.json       │ npm        │ 恶意软件包 │ 0    │ 1    │ 1    │
+│ Dockerfile                  │ dockerfile │ 配置错误   │ 1    │ 0    │ 1    │
+│ app/.env                    │            │ 敏感信息   │ 0    │ 1    │ 1    │
+└─────────────────────────────┴────────────┴────────────┴──────┴──────┴──────┘
+
+漏洞最多的前 1 个软件包
+=======================
+┌────────┬────────────┬───────────────────────┬──────┬──────┬──────┐
+│ 软件包 │ 已安装版本 │         目标          │ 高危 │ 严重 │ 合计 │
+├────────┼────────────┼───────────────────────┼──────┼──────┼──────┤
+│ lodash │ 4.17.4     │ app/package-lock.json │ 1    │ 1    │ 2    │
+└────────┴────────────┴───────────────────────┴──────┴──────┴──────┘
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i18n.SetLocale(tt.locale)
+			t.Cleanup(func() { i18n.SetLocale(i18n.English) })
+
+			got := bytes.Buffer{}
+			err := report.Write(types.Report{Results: results}, report.Option{
+				Format:     report.FormatTable,
+				Report:     report.ReportSummary,
+				Output:     &got,
+				Severities: tt.severities,
+				SummaryTop: tt.top,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
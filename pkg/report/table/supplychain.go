@@ -0,0 +1,75 @@
+package table
+
+import (
+	"bytes"
+
+	"github.com/aquasecurity/table"
+	"github.com/aquasecurity/tml"
+	"github.com/zhanglimao/trivy/pkg/i18n"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+type supplyChainRenderer struct {
+	w           *bytes.Buffer
+	tableWriter *table.Table
+	result      types.Result
+	isTerminal  bool
+}
+
+func NewSupplyChainRenderer(result types.Result, isTerminal bool) supplyChainRenderer {
+	buf := bytes.NewBuffer([]byte{})
+	return supplyChainRenderer{
+		w:           buf,
+		tableWriter: newTableWriter(buf, isTerminal),
+		result:      result,
+		isTerminal:  isTerminal,
+	}
+}
+
+func (r supplyChainRenderer) Render() string {
+	r.setHeaders()
+	r.setRows()
+
+	count := map[types.Confidence]int{}
+	for _, s := range r.result.SuspiciousPackages {
+		count[s.Confidence]++
+	}
+
+	target := r.result.Target + " (supply chain)"
+	RenderTarget(r.w, target, r.isTerminal)
+	r.printf("Total: %d (confidence HIGH: %d, MEDIUM: %d, LOW: %d)\n\n", len(r.result.SuspiciousPackages),
+		count[types.ConfidenceHigh], count[types.ConfidenceMedium], count[types.ConfidenceLow])
+
+	r.tableWriter.Render()
+
+	return r.w.String()
+}
+
+func (r supplyChainRenderer) setHeaders() {
+	header := []string{i18n.T("Type"), i18n.T("Package"), i18n.T("Version"), i18n.T("Kind"), i18n.T("Confidence"), i18n.T("Reason")}
+	r.tableWriter.SetHeaders(header...)
+}
+
+func (r supplyChainRenderer) setRows() {
+	for _, s := range r.result.SuspiciousPackages {
+		reason := s.Reason
+		if s.Script != "" {
+			reason += "\n" + truncateScript(s.Script)
+		}
+		r.tableWriter.AddRow(s.Type, s.PkgName, s.InstalledVersion, string(s.Kind), string(s.Confidence), reason)
+	}
+}
+
+func (r *supplyChainRenderer) printf(format string, args ...interface{}) {
+	// nolint
+	_ = tml.Fprintf(r.w, format, args...)
+}
+
+// truncateScript shortens long scripts such as obfuscated code. The full script is in the JSON output.
+func truncateScript(script string) string {
+	const maxLen = 80
+	if len(script) <= maxLen {
+		return script
+	}
+	return script[:maxLen] + "..."
+}
@@ -9,12 +9,14 @@ import (
 	"sync"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
 	"golang.org/x/exp/slices"
 
 	"github.com/aquasecurity/tml"
 
 	"github.com/aquasecurity/table"
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/i18n"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
@@ -46,6 +48,13 @@ type Writer struct {
 	// For licenses
 	LicenseRiskThreshold int
 	IgnoredLicenses      []string
+
+	// Show only the number of findings per target and the top N vulnerable packages
+	Summary    bool
+	SummaryTop int
+
+	// Show vulnerabilities grouped by ID across targets instead of per target
+	GroupByVulnerability bool
 }
 
 type Renderer interface {
@@ -54,6 +63,15 @@ type Renderer interface {
 
 // Write writes the result on standard output
 func (tw Writer) Write(report types.Report) error {
+	if tw.Summary {
+		_, _ = fmt.Fprint(tw.Output, NewSummaryRenderer(report.Results, tw.isOutputToTerminal(), tw.Severities, tw.SummaryTop).Render())
+		return nil
+	}
+
+	if tw.GroupByVulnerability {
+		_, _ = fmt.Fprint(tw.Output, NewVulnerabilityGroupRenderer(report.Results, tw.isOutputToTerminal(), tw.Severities).Render())
+	}
+
 	for _, result := range report.Results {
 		// Not display a table of custom resources
 		if result.Class == types.ClassCustom {
@@ -61,6 +79,10 @@ func (tw Writer) Write(report types.Report) error {
 		}
 		tw.write(result)
 	}
+
+	if len(report.Recommendations) > 0 {
+		_, _ = fmt.Fprint(tw.Output, NewRecommendationRenderer(report.Recommendations, tw.isOutputToTerminal()).Render())
+	}
 	return nil
 }
 
@@ -73,6 +95,17 @@ func (tw Writer) write(result types.Result) {
 	switch {
 	// vulnerability
 	case result.Class == types.ClassOSPkg || result.Class == types.ClassLangPkg:
+		// Known-malicious packages are shown first and separately from vulnerabilities
+		if len(result.MaliciousPackages) > 0 {
+			_, _ = fmt.Fprint(tw.Output, NewMaliciousRenderer(result, tw.isOutputToTerminal(), tw.Severities).Render())
+			if len(result.Vulnerabilities) == 0 {
+				return
+			}
+		}
+		// Vulnerabilities are already shown across targets
+		if tw.GroupByVulnerability {
+			return
+		}
 		renderer = NewVulnerabilityRenderer(result, tw.isOutputToTerminal(), tw.Tree, tw.Severities)
 	// misconfiguration
 	case result.Class == types.ClassConfig:
@@ -86,6 +119,27 @@ func (tw Writer) write(result types.Result) {
 	// file license
 	case result.Class == types.ClassLicenseFile:
 		renderer = NewFileLicenseRenderer(result, tw.isOutputToTerminal(), tw.Severities)
+	// drift from the baseline image
+	case result.Class == types.ClassDrift:
+		renderer = NewDriftRenderer(result, tw.isOutputToTerminal())
+	// supply chain controls such as the image signature
+	case result.Class == types.ClassSupplyChain && len(result.Controls) > 0:
+		renderer = NewControlRenderer(result, tw.isOutputToTerminal())
+	// packages suspected of typosquatting and dependency confusion
+	case result.Class == types.ClassSupplyChain:
+		renderer = NewSupplyChainRenderer(result, tw.isOutputToTerminal())
+	// per-layer sizes and wasted space
+	case result.Class == types.ClassImageSize:
+		renderer = NewImageSizeRenderer(result, tw.isOutputToTerminal())
+	// problems which made the scan incomplete
+	case result.Class == types.ClassWarning:
+		renderer = NewWarningRenderer(result, tw.isOutputToTerminal())
+	// files of OS packages modified after installation
+	case result.Class == types.ClassIntegrity:
+		renderer = NewIntegrityRenderer(result, tw.isOutputToTerminal())
+	// verification results of provenance attestations
+	case result.Class == types.ClassProvenance:
+		renderer = NewProvenanceRenderer(result, tw.isOutputToTerminal())
 	default:
 		return
 	}
@@ -123,7 +177,7 @@ func summarize(specifiedSeverities []dbTypes.Severity, severityCount map[string]
 			continue
 		}
 		count := severityCount[severity]
-		r := fmt.Sprintf("%s: %d", severity, count)
+		r := fmt.Sprintf("%s: %d", i18n.Severity(severity), count)
 		summaries = append(summaries, r)
 		total += count
 	}
@@ -153,7 +207,7 @@ func RenderTarget(w io.Writer, target string, isTerminal bool) {
 		_ = tml.Fprintf(w, "\n<underline><bold>%s</bold></underline>\n\n", target)
 	} else {
 		_, _ = fmt.Fprintf(w, "\n%s\n", target)
-		_, _ = fmt.Fprintf(w, "%s\n", strings.Repeat("=", len(target)))
+		_, _ = fmt.Fprintf(w, "%s\n", strings.Repeat("=", runewidth.StringWidth(target)))
 	}
 }
 
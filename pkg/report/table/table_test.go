@@ -16,6 +16,7 @@ func TestReportWriter_Table(t *testing.T) {
 	testCases := []struct {
 		name               string
 		results            types.Results
+		recommendations    []types.Recommendation
 		expectedOutput     string
 		includeNonFailures bool
 	}{
@@ -332,6 +333,131 @@ package-lock.json
 └── node-fetch@1.7.3, (MEDIUM: 0, HIGH: 1)
     └── ...(omitted)...
         └── styled-components@3.1.3
+`,
+		},
+		{
+			name: "image size",
+			results: types.Results{
+				{
+					Target: "test",
+					Class:  types.ClassImageSize,
+					ImageSize: &ftypes.ImageSize{
+						Layers: []ftypes.LayerSize{
+							{
+								DiffID:     "sha256:9f64a747e1b97f131fabb6b447296c9b6f0201e79fb3c5356e6c77e89b6a806a",
+								CreatedBy:  "ADD file:e4d600fc4c9c293efe360be7b30ee96579925d1b4634c94332e2ec73f7d8eca1 in /",
+								AddedSize:  7340032,
+								AddedFiles: 512,
+							},
+							{
+								DiffID:       "sha256:ded7a220bb058e28ee3254fbba04ca90b679070424424761a53a043b93b612bf",
+								CreatedBy:    "RUN rm -rf /var/cache/apk",
+								AddedSize:    0,
+								AddedFiles:   0,
+								RemovedSize:  1572864,
+								RemovedFiles: 20,
+							},
+						},
+						TotalSize:  7340032,
+						WastedSize: 1572864,
+					},
+				},
+			},
+			expectedOutput: `
+test (size)
+===========
+Total: 7.0 MiB, Wasted: 1.5 MiB (21.4%)
+
+┌─────────────────────┬──────────────────────────────────────────────────────────────┬─────────────────────┬────────────────────┐
+│        Layer        │                          Created By                          │        Added        │      Removed       │
+├─────────────────────┼──────────────────────────────────────────────────────────────┼─────────────────────┼────────────────────┤
+│ sha256:9f64a747e1b9 │ ADD file:e4d600fc4c9c293efe360be7b30ee96579925d1b4634c943... │ 7.0 MiB (512 files) │                    │
+├─────────────────────┼──────────────────────────────────────────────────────────────┼─────────────────────┼────────────────────┤
+│ sha256:ded7a220bb05 │ RUN rm -rf /var/cache/apk                                    │ 0 B (0 files)       │ 1.5 MiB (20 files) │
+└─────────────────────┴──────────────────────────────────────────────────────────────┴─────────────────────┴────────────────────┘
+`,
+		},
+		{
+			name: "supply chain controls",
+			results: types.Results{
+				{
+					Target: "ghcr.io/acme/app:v1.0.0",
+					Class:  types.ClassSupplyChain,
+					Controls: []types.SupplyChainControl{
+						{
+							ID:      types.SupplyChainControlImageSignature,
+							Title:   "Image is signed by a trusted signer",
+							Status:  types.StatusFailure,
+							Message: "no cosign signature found",
+						},
+					},
+				},
+			},
+			expectedOutput: `
+ghcr.io/acme/app:v1.0.0 (supply chain)
+======================================
+Total: 1 (PASS: 0, FAIL: 1)
+
+┌─────────────────┬─────────────────────────────────────┬────────┬────────┬───────────────────────────┐
+│       ID        │                Title                │ Status │ Signer │          Message          │
+├─────────────────┼─────────────────────────────────────┼────────┼────────┼───────────────────────────┤
+│ image-signature │ Image is signed by a trusted signer │ FAIL   │        │ no cosign signature found │
+└─────────────────┴─────────────────────────────────────┴────────┴────────┴───────────────────────────┘
+`,
+		},
+		{
+			name: "integrity",
+			results: types.Results{
+				{
+					Target: "test",
+					Class:  types.ClassIntegrity,
+					ModifiedFiles: []ftypes.ModifiedFile{
+						{
+							Path:     "usr/bin/curl",
+							PkgName:  "curl",
+							Expected: "md5:2b9f8c7d6e5f4a3b2c1d0e9f8a7b6c5d",
+							Actual:   "md5:8a0e6ff3b2bb2a7bb6e5d0c7a3fb8f2c",
+							Layer: ftypes.Layer{
+								DiffID: "sha256:ded7a220bb058e28ee3254fbba04ca90b679070424424761a53a043b93b612bf",
+							},
+						},
+					},
+				},
+			},
+			expectedOutput: `
+test (integrity)
+================
+Total: 1 (packages: 1)
+
+┌──────────────┬─────────┬─────────────────────┬─────────────────────┬─────────────────────┐
+│     File     │ Package │      Expected       │       Actual        │        Layer        │
+├──────────────┼─────────┼─────────────────────┼─────────────────────┼─────────────────────┤
+│ usr/bin/curl │ curl    │ md5:2b9f8c7d6e5f4a3 │ md5:8a0e6ff3b2bb2a7 │ sha256:ded7a220bb05 │
+└──────────────┴─────────┴─────────────────────┴─────────────────────┴─────────────────────┘
+`,
+		},
+		{
+			name: "recommendations",
+			recommendations: []types.Recommendation{
+				{
+					Type:                    types.RecommendationRebase,
+					BaseImage:               "alpine:3.17.0",
+					Recommended:             "alpine:3.17.3",
+					NewerTags:               []string{"3.17.3", "3.17.2"},
+					Vulnerabilities:         5,
+					ResolvedVulnerabilities: 3,
+				},
+			},
+			expectedOutput: `
+Recommendations
+===============
+
+┌────────┬───────────────┬───────────────┬──────────────────────────┬────────────┐
+│  Type  │    Current    │  Recommended  │ Resolved Vulnerabilities │ Newer Tags │
+├────────┼───────────────┼───────────────┼──────────────────────────┼────────────┤
+│ rebase │ alpine:3.17.0 │ alpine:3.17.3 │ 3/5 (estimated)          │ 3.17.3     │
+│        │               │               │                          │ 3.17.2     │
+└────────┴───────────────┴───────────────┴──────────────────────────┴────────────┘
 `,
 		},
 	}
@@ -339,7 +465,10 @@ package-lock.json
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tableWritten := bytes.Buffer{}
-			err := report.Write(types.Report{Results: tc.results}, report.Option{
+			err := report.Write(types.Report{
+				Results:         tc.results,
+				Recommendations: tc.recommendations,
+			}, report.Option{
 				Format:             report.FormatTable,
 				Output:             &tableWritten,
 				Tree:               true,
@@ -17,6 +17,7 @@ import (
 	"github.com/aquasecurity/tml"
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/i18n"
 	"github.com/zhanglimao/trivy/pkg/log"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
@@ -59,7 +60,7 @@ func (r *vulnerabilityRenderer) Render() string {
 		target += fmt.Sprintf(" (%s)", r.result.Type)
 	}
 	RenderTarget(r.w, target, r.isTerminal)
-	r.printf("Total: %d (%s)\n\n", total, strings.Join(summaries, ", "))
+	r.printf(i18n.T("Total: %d (%s)")+"\n\n", total, strings.Join(summaries, ", "))
 
 	r.tableWriter.Render()
 	if r.tree {
@@ -74,12 +75,12 @@ func (r *vulnerabilityRenderer) setHeaders() {
 		return
 	}
 	header := []string{
-		"Library",
-		"Vulnerability",
-		"Severity",
-		"Installed Version",
-		"Fixed Version",
-		"Title",
+		i18n.T("Library"),
+		i18n.T("Vulnerability"),
+		i18n.T("Severity"),
+		i18n.T("Installed Version"),
+		i18n.T("Fixed Version"),
+		i18n.T("Title"),
 	}
 	r.tableWriter.SetHeaders(header...)
 }
@@ -97,6 +98,10 @@ func (r *vulnerabilityRenderer) setVulnerabilityRows(vulns []types.DetectedVulne
 				log.Logger.Infof("Table result includes only package filenames. Use '--format json' option to get the full path to the package file.")
 			})
 		}
+		if len(v.Locations) > 1 {
+			// Deduplicated findings
+			lib = fmt.Sprintf("%s\n(+%d locations)", lib, len(v.Locations)-1)
+		}
 
 		title := v.Title
 		if title == "" {
@@ -120,7 +125,7 @@ func (r *vulnerabilityRenderer) setVulnerabilityRows(vulns []types.DetectedVulne
 			row = []string{
 				lib,
 				v.VulnerabilityID,
-				ColorizeSeverity(v.Severity, v.Severity),
+				ColorizeSeverity(i18n.Severity(v.Severity), v.Severity),
 				v.InstalledVersion,
 				v.FixedVersion,
 				strings.TrimSpace(title),
@@ -129,7 +134,7 @@ func (r *vulnerabilityRenderer) setVulnerabilityRows(vulns []types.DetectedVulne
 			row = []string{
 				lib,
 				v.VulnerabilityID,
-				v.Severity,
+				i18n.Severity(v.Severity),
 				v.InstalledVersion,
 				v.FixedVersion,
 				strings.TrimSpace(title),
@@ -154,7 +159,7 @@ func (r *vulnerabilityRenderer) renderDependencyTree() {
 	if len(parents) == 0 {
 		return
 	}
-	ancestors := traverseAncestors(r.result.Packages, parents)
+	ancestors := types.Ancestors(r.result.Packages, parents)
 
 	root := treeprint.NewWithRoot(fmt.Sprintf(`
 Dependency Origin Tree (Reversed)
@@ -236,46 +241,6 @@ func addParents(topItem treeprint.Tree, pkg ftypes.Package, parentMap map[string
 	}
 }
 
-func traverseAncestors(pkgs []ftypes.Package, parentMap map[string]ftypes.Packages) map[string][]string {
-	ancestors := map[string][]string{}
-	for _, pkg := range pkgs {
-		ancestors[pkg.ID] = findAncestor(pkg.ID, parentMap, map[string]struct{}{})
-	}
-	return ancestors
-}
-
-func findAncestor(pkgID string, parentMap map[string]ftypes.Packages, seen map[string]struct{}) []string {
-	ancestors := map[string]struct{}{}
-	seen[pkgID] = struct{}{}
-	for _, parent := range parentMap[pkgID] {
-		if _, ok := seen[parent.ID]; ok {
-			continue
-		}
-		if !parent.Indirect {
-			ancestors[parent.ID] = struct{}{}
-		} else if len(parentMap[parent.ID]) == 0 {
-			// Direct dependencies cannot be identified in some package managers like "package-lock.json" v1,
-			// then the "Indirect" field can be always true. We try to guess direct dependencies in this case.
-			// A dependency with no parents must be a direct dependency.
-			//
-			// e.g.
-			//   -> styled-components
-			//     -> fbjs
-			//       -> isomorphic-fetch
-			//         -> node-fetch
-			//
-			// Even if `styled-components` is not marked as a direct dependency, it must be a direct dependency
-			// as it has no parents. Note that it doesn't mean `fbjs` is an indirect dependency.
-			ancestors[parent.ID] = struct{}{}
-		} else {
-			for _, a := range findAncestor(parent.ID, parentMap, seen) {
-				ancestors[a] = struct{}{}
-			}
-		}
-	}
-	return maps.Keys(ancestors)
-}
-
 var jarExtensions = []string{".jar", ".war", ".par", ".ear"}
 
 func rootJarFromPath(path string) string {
@@ -0,0 +1,214 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/table"
+	"github.com/aquasecurity/tml"
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/i18n"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// vulnerabilityGroupRenderer shows vulnerabilities grouped by ID with all the affected targets and packages,
+// so that a single advisory can be triaged across targets.
+type vulnerabilityGroupRenderer struct {
+	w           *bytes.Buffer
+	tableWriter *table.Table
+	results     types.Results
+	isTerminal  bool
+	severities  []dbTypes.Severity
+}
+
+func NewVulnerabilityGroupRenderer(results types.Results, isTerminal bool, severities []dbTypes.Severity) vulnerabilityGroupRenderer {
+	buf := bytes.NewBuffer([]byte{})
+	if !isTerminal {
+		tml.DisableFormatting()
+	}
+	tw := newTableWriter(buf, isTerminal)
+	tw.SetAutoMerge(false)
+	return vulnerabilityGroupRenderer{
+		w:           buf,
+		tableWriter: tw,
+		results:     results,
+		isTerminal:  isTerminal,
+		severities:  severities,
+	}
+}
+
+// affected is a package affected by the vulnerability in a target
+type affected struct {
+	target string
+	vuln   types.DetectedVulnerability
+}
+
+// vulnerabilityGroup holds all the places where the vulnerability is found
+type vulnerabilityGroup struct {
+	types.DetectedVulnerability
+	affected []affected
+}
+
+func (r vulnerabilityGroupRenderer) Render() string {
+	groups := r.group()
+	if len(groups) == 0 {
+		return ""
+	}
+
+	severityCount := map[string]int{}
+	targets := map[string]struct{}{}
+	for _, g := range groups {
+		severityCount[g.Severity]++
+		for _, a := range g.affected {
+			targets[a.target] = struct{}{}
+		}
+	}
+	total, summaries := summarize(r.severities, severityCount)
+
+	RenderTarget(r.w, i18n.T("Vulnerabilities"), r.isTerminal)
+	r.printf(i18n.T("Total: %d (%s) in %d targets")+"\n\n", total, strings.Join(summaries, ", "), len(targets))
+
+	r.setHeaders()
+	r.setRows(groups)
+	r.tableWriter.Render()
+
+	return r.w.String()
+}
+
+// group groups the vulnerabilities by ID in the order of severity
+func (r vulnerabilityGroupRenderer) group() []*vulnerabilityGroup {
+	var groups []*vulnerabilityGroup
+	index := map[string]*vulnerabilityGroup{}
+	for _, result := range r.results {
+		if result.Class != types.ClassOSPkg && result.Class != types.ClassLangPkg {
+			continue
+		}
+		for _, vuln := range result.Vulnerabilities {
+			g, ok := index[vuln.VulnerabilityID]
+			if !ok {
+				g = &vulnerabilityGroup{DetectedVulnerability: vuln}
+				index[vuln.VulnerabilityID] = g
+				groups = append(groups, g)
+			}
+			for _, target := range affectedTargets(result, vuln) {
+				g.affected = append(g.affected, affected{
+					target: target,
+					vuln:   vuln,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		si, _ := dbTypes.NewSeverity(groups[i].Severity)
+		sj, _ := dbTypes.NewSeverity(groups[j].Severity)
+		if si != sj {
+			return si > sj
+		}
+		return groups[i].VulnerabilityID < groups[j].VulnerabilityID
+	})
+	for _, g := range groups {
+		sort.SliceStable(g.affected, func(i, j int) bool {
+			ai, aj := g.affected[i], g.affected[j]
+			switch {
+			case ai.target != aj.target:
+				return ai.target < aj.target
+			case ai.vuln.PkgName != aj.vuln.PkgName:
+				return ai.vuln.PkgName < aj.vuln.PkgName
+			}
+			return ai.vuln.InstalledVersion < aj.vuln.InstalledVersion
+		})
+	}
+	return groups
+}
+
+func (r vulnerabilityGroupRenderer) setHeaders() {
+	header := []string{
+		i18n.T("Vulnerability"),
+		i18n.T("Severity"),
+		i18n.T("Title"),
+		i18n.T("Target"),
+		i18n.T("Library"),
+		i18n.T("Installed Version"),
+		i18n.T("Fixed Version"),
+	}
+	r.tableWriter.SetHeaders(header...)
+}
+
+// setRows adds a row per vulnerability with a line per affected package.
+// Cells are not merged across rows as different vulnerabilities may affect the same targets.
+func (r vulnerabilityGroupRenderer) setRows(groups []*vulnerabilityGroup) {
+	for _, g := range groups {
+		severity := i18n.Severity(g.Severity)
+		if r.isTerminal {
+			severity = ColorizeSeverity(severity, g.Severity)
+		}
+
+		var targets, libs, installed, fixed []string
+		for i, a := range g.affected {
+			target := a.target
+			if i > 0 && g.affected[i-1].target == a.target {
+				target = "" // Show the target once
+			}
+			lib := a.vuln.PkgName
+			if a.vuln.PkgPath != "" {
+				lib = fmt.Sprintf("%s (%s)", a.vuln.PkgName, filepath.Base(rootJarFromPath(a.vuln.PkgPath)))
+			}
+			targets = append(targets, target)
+			libs = append(libs, lib)
+			installed = append(installed, a.vuln.InstalledVersion)
+			fixed = append(fixed, a.vuln.FixedVersion)
+		}
+
+		r.tableWriter.AddRow(g.VulnerabilityID, severity, r.title(g.DetectedVulnerability),
+			strings.Join(targets, "\n"), strings.Join(libs, "\n"), strings.Join(installed, "\n"), strings.Join(fixed, "\n"))
+	}
+}
+
+func (r vulnerabilityGroupRenderer) title(v types.DetectedVulnerability) string {
+	title := v.Title
+	if title == "" {
+		title = v.Description
+	}
+	splitTitle := strings.Split(title, " ")
+	if len(splitTitle) >= 12 {
+		title = strings.Join(splitTitle[:12], " ") + "..."
+	}
+
+	if len(v.PrimaryURL) > 0 {
+		if r.isTerminal {
+			title = tml.Sprintf("%s\n<blue>%s</blue>", title, v.PrimaryURL)
+		} else {
+			title = fmt.Sprintf("%s\n%s", title, v.PrimaryURL)
+		}
+	}
+	return strings.TrimSpace(title)
+}
+
+func (r vulnerabilityGroupRenderer) printf(format string, args ...interface{}) {
+	// nolint
+	_ = tml.Fprintf(r.w, format, args...)
+}
+
+// affectedTargets returns the targets where the vulnerability is found, including the locations of deduplicated findings
+func affectedTargets(result types.Result, vuln types.DetectedVulnerability) []string {
+	if len(vuln.Locations) == 0 {
+		return []string{targetName(result, result.Target)}
+	}
+	var targets []string
+	for _, loc := range vuln.Locations {
+		targets = append(targets, targetName(result, loc.Target))
+	}
+	return targets
+}
+
+// targetName appends the type of language-specific packages to the target as the vulnerability table does
+func targetName(result types.Result, target string) string {
+	if result.Class == types.ClassLangPkg {
+		return fmt.Sprintf("%s (%s)", target, result.Type)
+	}
+	return target
+}
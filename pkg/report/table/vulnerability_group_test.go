@@ -0,0 +1,129 @@
+package table_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/report"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func TestReportWriter_GroupByVulnerability(t *testing.T) {
+	vuln := func(id, pkgName, installed, fixed, severity string) types.DetectedVulnerability {
+		return types.DetectedVulnerability{
+			VulnerabilityID:  id,
+			PkgName:          pkgName,
+			InstalledVersion: installed,
+			FixedVersion:     fixed,
+			PrimaryURL:       "https://avd.aquasec.com/nvd/" + id,
+			Vulnerability: dbTypes.Vulnerability{
+				Title:    "title of " + id,
+				Severity: severity,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		results types.Results
+		want    string
+	}{
+		{
+			name: "multiple targets",
+			results: types.Results{
+				{
+					Target: "alpine:3.17 (alpine 3.17.3)",
+					Class:  types.ClassOSPkg,
+					Type:   "alpine",
+					Vulnerabilities: []types.DetectedVulnerability{
+						vuln("CVE-2023-0002", "libssl3", "3.0.8-r3", "3.0.8-r4", "MEDIUM"),
+						vuln("CVE-2023-0001", "libcrypto3", "3.0.8-r3", "3.0.8-r4", "HIGH"),
+						vuln("CVE-2023-0001", "libssl3", "3.0.8-r3", "3.0.8-r4", "HIGH"),
+					},
+				},
+				{
+					Target: "app/package-lock.json",
+					Class:  types.ClassLangPkg,
+					Type:   ftypes.Npm,
+					Vulnerabilities: []types.DetectedVulnerability{
+						vuln("CVE-2023-0001", "openssl", "1.0.0", "1.0.1", "HIGH"),
+					},
+				},
+			},
+			want: `
+Vulnerabilities
+===============
+Total: 2 (MEDIUM: 1, HIGH: 1) in 2 targets
+
+┌───────────────┬──────────┬───────────────────────────────────────────┬─────────────────────────────┬────────────┬───────────────────┬───────────────┐
+│ Vulnerability │ Severity │                   Title                   │           Target            │  Library   │ Installed Version │ Fixed Version │
+├───────────────┼──────────┼───────────────────────────────────────────┼─────────────────────────────┼────────────┼───────────────────┼───────────────┤
+│ CVE-2023-0001 │ HIGH     │ title of CVE-2023-0001                    │ alpine:3.17 (alpine 3.17.3) │ libcrypto3 │ 3.0.8-r3          │ 3.0.8-r4      │
+│               │          │ https://avd.aquasec.com/nvd/CVE-2023-0001 │                             │ libssl3    │ 3.0.8-r3          │ 3.0.8-r4      │
+│               │          │                                           │ app/package-lock.json (npm) │ openssl    │ 1.0.0             │ 1.0.1         │
+├───────────────┼──────────┼───────────────────────────────────────────┼─────────────────────────────┼────────────┼───────────────────┼───────────────┤
+│ CVE-2023-0002 │ MEDIUM   │ title of CVE-2023-0002                    │ alpine:3.17 (alpine 3.17.3) │ libssl3    │ 3.0.8-r3          │ 3.0.8-r4      │
+│               │          │ https://avd.aquasec.com/nvd/CVE-2023-0002 │                             │            │                   │               │
+└───────────────┴──────────┴───────────────────────────────────────────┴─────────────────────────────┴────────────┴───────────────────┴───────────────┘
+`,
+		},
+		{
+			name: "deduplicated findings",
+			results: types.Results{
+				{
+					Target: "app1/package-lock.json",
+					Class:  types.ClassLangPkg,
+					Type:   ftypes.Npm,
+					Vulnerabilities: []types.DetectedVulnerability{
+						func() types.DetectedVulnerability {
+							v := vuln("CVE-2023-0001", "openssl", "1.0.0", "1.0.1", "HIGH")
+							v.Locations = []types.VulnerabilityLocation{
+								{Target: "app1/package-lock.json"},
+								{Target: "app2/package-lock.json"},
+							}
+							return v
+						}(),
+					},
+				},
+				{
+					Target: "app2/package-lock.json",
+					Class:  types.ClassLangPkg,
+					Type:   ftypes.Npm,
+				},
+			},
+			want: `
+Vulnerabilities
+===============
+Total: 1 (MEDIUM: 0, HIGH: 1) in 2 targets
+
+┌───────────────┬──────────┬───────────────────────────────────────────┬──────────────────────────────┬─────────┬───────────────────┬───────────────┐
+│ Vulnerability │ Severity │                   Title                   │            Target            │ Library │ Installed Version │ Fixed Version │
+├───────────────┼──────────┼───────────────────────────────────────────┼──────────────────────────────┼─────────┼───────────────────┼───────────────┤
+│ CVE-2023-0001 │ HIGH     │ title of CVE-2023-0001                    │ app1/package-lock.json (npm) │ openssl │ 1.0.0             │ 1.0.1         │
+│               │          │ https://avd.aquasec.com/nvd/CVE-2023-0001 │ app2/package-lock.json (npm) │ openssl │ 1.0.0             │ 1.0.1         │
+└───────────────┴──────────┴───────────────────────────────────────────┴──────────────────────────────┴─────────┴───────────────────┴───────────────┘
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bytes.Buffer{}
+			err := report.Write(types.Report{Results: tt.results}, report.Option{
+				Format:  report.FormatTable,
+				Output:  &got,
+				GroupBy: report.GroupByVulnerability,
+				Severities: []dbTypes.Severity{
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityMedium,
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package table
+
+import (
+	"bytes"
+
+	"github.com/aquasecurity/table"
+	"github.com/aquasecurity/tml"
+	"github.com/zhanglimao/trivy/pkg/i18n"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// warningRenderer shows problems which made the scan incomplete, so that missing findings are not overlooked
+type warningRenderer struct {
+	w           *bytes.Buffer
+	tableWriter *table.Table
+	result      types.Result
+	isTerminal  bool
+}
+
+func NewWarningRenderer(result types.Result, isTerminal bool) warningRenderer {
+	buf := bytes.NewBuffer([]byte{})
+	return warningRenderer{
+		w:           buf,
+		tableWriter: newTableWriter(buf, isTerminal),
+		result:      result,
+		isTerminal:  isTerminal,
+	}
+}
+
+func (r warningRenderer) Render() string {
+	r.tableWriter.SetHeaders(i18n.T("Analyzer"), i18n.T("File"), i18n.T("Message"))
+	for _, w := range r.result.Warnings {
+		r.tableWriter.AddRow(w.Analyzer, w.FilePath, w.Message)
+	}
+
+	RenderTarget(r.w, r.result.Target+" (warnings)", r.isTerminal)
+	r.printf(i18n.T("Total: %d (results may be incomplete)")+"\n\n", len(r.result.Warnings))
+	r.tableWriter.Render()
+
+	return r.w.String()
+}
+
+func (r *warningRenderer) printf(format string, args ...interface{}) {
+	// nolint
+	_ = tml.Fprintf(r.w, format, args...)
+}
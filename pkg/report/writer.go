@@ -31,6 +31,12 @@ const (
 	FormatSPDXJSON   = "spdx-json"
 	FormatGitHub     = "github"
 	FormatCosignVuln = "cosign-vuln"
+
+	ReportAll     = "all"
+	ReportSummary = "summary"
+
+	GroupByTarget        = "target"
+	GroupByVulnerability = "vulnerability"
 )
 
 var (
@@ -45,6 +51,11 @@ var (
 		FormatGitHub,
 		FormatCosignVuln,
 	}
+
+	SupportedGroupBy = []string{
+		GroupByTarget,
+		GroupByVulnerability,
+	}
 )
 
 var (
@@ -74,6 +85,15 @@ type Option struct {
 	// For licenses
 	LicenseRiskThreshold int
 	IgnoredLicenses      []string
+
+	// Deterministic makes the output reproducible for the same artifact
+	Deterministic bool
+
+	// SummaryTop is the number of vulnerable packages shown with '--report summary'
+	SummaryTop int
+
+	// GroupBy is how findings are grouped in the table format, "target" or "vulnerability"
+	GroupBy string
 }
 
 // Write writes the result to output, format as passed in argument
@@ -83,6 +103,19 @@ func Write(report types.Report, option Option) error {
 		return complianceWrite(report, option)
 	}
 
+	var d *deterministic
+	if option.Deterministic {
+		d = newDeterministic(report)
+		normalize(&report)
+	}
+
+	// The table writer renders the dependency tree by itself
+	if option.Tree && option.Format != FormatTable {
+		for i, res := range report.Results {
+			report.Results[i].DependencyGraph = types.NewDependencyGraph(res.Packages, res.Vulnerabilities)
+		}
+	}
+
 	var writer Writer
 	switch option.Format {
 	case FormatTable:
@@ -95,6 +128,9 @@ func Write(report types.Report, option Option) error {
 			Trace:                option.Trace,
 			LicenseRiskThreshold: option.LicenseRiskThreshold,
 			IgnoredLicenses:      option.IgnoredLicenses,
+			Summary:              option.Report == ReportSummary,
+			SummaryTop:           option.SummaryTop,
+			GroupByVulnerability: option.GroupBy == GroupByVulnerability,
 		}
 	case FormatJSON:
 		writer = &JSONWriter{Output: option.Output}
@@ -102,12 +138,13 @@ func Write(report types.Report, option Option) error {
 		writer = &github.Writer{
 			Output:  option.Output,
 			Version: option.AppVersion,
+			Clock:   d.clock(),
 		}
 	case FormatCycloneDX:
 		// TODO: support xml format option with cyclonedx writer
-		writer = cyclonedx.NewWriter(option.Output, option.AppVersion)
+		writer = cyclonedx.NewWriter(option.Output, option.AppVersion, d.cycloneDXOptions()...)
 	case FormatSPDX, FormatSPDXJSON:
-		writer = spdx.NewWriter(option.Output, option.AppVersion, option.Format)
+		writer = spdx.NewWriter(option.Output, option.AppVersion, option.Format, d.spdxOptions()...)
 	case FormatTemplate:
 		// We keep `sarif.tpl` template working for backward compatibility for a while.
 		if strings.HasPrefix(option.OutputTemplate, "@") && strings.HasSuffix(option.OutputTemplate, "sarif.tpl") {
@@ -128,7 +165,7 @@ func Write(report types.Report, option Option) error {
 			Version: option.AppVersion,
 		}
 	case FormatCosignVuln:
-		writer = predicate.NewVulnWriter(option.Output, option.AppVersion)
+		writer = predicate.NewVulnWriter(option.Output, option.AppVersion, d.predicateOptions()...)
 	default:
 		return xerrors.Errorf("unknown format: %v", option.Format)
 	}
@@ -1,10 +1,16 @@
 package report_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/report"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
@@ -74,6 +80,38 @@ func TestResults_Failed(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "failed supply chain control",
+			results: types.Results{
+				{
+					Target: "test",
+					Class:  types.ClassSupplyChain,
+					Controls: []types.SupplyChainControl{
+						{
+							ID:     types.SupplyChainControlImageSignature,
+							Status: types.StatusFailure,
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "passed supply chain control",
+			results: types.Results{
+				{
+					Target: "test",
+					Class:  types.ClassSupplyChain,
+					Controls: []types.SupplyChainControl{
+						{
+							ID:     types.SupplyChainControlImageSignature,
+							Status: types.StatusPassed,
+						},
+					},
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -82,3 +120,208 @@ func TestResults_Failed(t *testing.T) {
 		})
 	}
 }
+
+func TestWrite_DependencyGraph(t *testing.T) {
+	results := types.Results{
+		{
+			Target: "package-lock.json",
+			Packages: []ftypes.Package{
+				{
+					ID:        "app@1.0.0",
+					DependsOn: []string{"foo@2.0.0"},
+				},
+				{
+					ID:        "foo@2.0.0",
+					Indirect:  true,
+					DependsOn: []string{"bar@3.0.0"},
+				},
+				{
+					ID:       "bar@3.0.0",
+					Indirect: true,
+				},
+				{
+					ID: "baz@4.0.0",
+				},
+			},
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2020-0001",
+					PkgID:           "bar@3.0.0",
+				},
+				{
+					VulnerabilityID: "CVE-2020-0002",
+					PkgID:           "baz@4.0.0",
+				},
+			},
+		},
+		{
+			Target: "Gemfile.lock",
+			Packages: []ftypes.Package{
+				{
+					ID: "rails@7.0.0",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		tree bool
+		want []*types.DependencyGraph
+	}{
+		{
+			name: "dependency tree",
+			tree: true,
+			want: []*types.DependencyGraph{
+				{
+					Relationships: []types.Relationship{
+						{
+							PkgID:     "app@1.0.0",
+							DependsOn: []string{"foo@2.0.0"},
+						},
+						{
+							PkgID:     "foo@2.0.0",
+							DependsOn: []string{"bar@3.0.0"},
+						},
+					},
+					VulnerableOrigins: []types.VulnerableOrigin{
+						{
+							PkgID:   "bar@3.0.0",
+							Origins: []string{"app@1.0.0"},
+						},
+						{
+							PkgID:  "baz@4.0.0",
+							Direct: true,
+						},
+					},
+				},
+				nil,
+			},
+		},
+		{
+			name: "no dependency tree",
+			want: []*types.DependencyGraph{
+				nil,
+				nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := bytes.NewBuffer(nil)
+			err := report.Write(types.Report{
+				Results: append(types.Results{}, results...),
+			}, report.Option{
+				Format: report.FormatJSON,
+				Output: output,
+				Tree:   tt.tree,
+			})
+			require.NoError(t, err)
+
+			var got types.Report
+			require.NoError(t, json.Unmarshal(output.Bytes(), &got))
+
+			var graphs []*types.DependencyGraph
+			for _, res := range got.Results {
+				graphs = append(graphs, res.DependencyGraph)
+			}
+			assert.Equal(t, tt.want, graphs)
+		})
+	}
+}
+
+func TestWrite_Deterministic(t *testing.T) {
+	newReport := func(reverse bool) types.Report {
+		results := types.Results{
+			{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.Npm,
+				Packages: []ftypes.Package{
+					{
+						ID:        "foo@2.0.0",
+						Name:      "foo",
+						Version:   "2.0.0",
+						DependsOn: []string{"baz@4.0.0", "bar@3.0.0"},
+					},
+					{
+						ID:      "bar@3.0.0",
+						Name:    "bar",
+						Version: "3.0.0",
+					},
+					{
+						ID:      "baz@4.0.0",
+						Name:    "baz",
+						Version: "4.0.0",
+					},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0002",
+						PkgID:            "bar@3.0.0",
+						PkgName:          "bar",
+						InstalledVersion: "3.0.0",
+					},
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgID:            "bar@3.0.0",
+						PkgName:          "bar",
+						InstalledVersion: "3.0.0",
+					},
+				},
+			},
+			{
+				Target: "Gemfile.lock",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.Bundler,
+				Packages: []ftypes.Package{
+					{
+						ID:      "rails@7.0.0",
+						Name:    "rails",
+						Version: "7.0.0",
+					},
+				},
+			},
+		}
+		if reverse {
+			for _, r := range results {
+				lo.Reverse(r.Packages)
+				lo.Reverse(r.Vulnerabilities)
+				lo.Reverse(r.Packages[0].DependsOn)
+			}
+			lo.Reverse(results)
+		}
+		return types.Report{
+			SchemaVersion: 2,
+			ArtifactName:  "app",
+			ArtifactType:  ftypes.ArtifactFilesystem,
+			Results:       results,
+		}
+	}
+
+	for _, format := range []string{
+		report.FormatJSON,
+		report.FormatCycloneDX,
+		report.FormatSPDXJSON,
+		report.FormatGitHub,
+	} {
+		t.Run(format, func(t *testing.T) {
+			var outputs []string
+			for _, reverse := range []bool{false, true} {
+				output := bytes.NewBuffer(nil)
+				err := report.Write(newReport(reverse), report.Option{
+					AppVersion:    "dev",
+					Format:        format,
+					Output:        output,
+					Deterministic: true,
+				})
+				require.NoError(t, err)
+				outputs = append(outputs, output.String())
+			}
+			assert.Equal(t, outputs[0], outputs[1])
+			if format == report.FormatCycloneDX {
+				assert.Contains(t, outputs[0], `"timestamp": "0001-01-01T00:00:00+00:00"`)
+			}
+		})
+	}
+}
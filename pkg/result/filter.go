@@ -30,11 +30,13 @@ const (
 type FilterOption struct {
 	Severities         []dbTypes.Severity
 	IgnoreUnfixed      bool
+	ESMFixed           bool
 	IncludeNonFailures bool
 	IgnoreFile         string
 	PolicyFile         string
 	IgnoreLicenses     []string
 	VEXPath            string
+	Dedupe             bool
 }
 
 // Filter filters out the report
@@ -49,15 +51,82 @@ func Filter(ctx context.Context, report types.Report, opt FilterOption) error {
 			return xerrors.Errorf("unable to filter vulnerabilities: %w", err)
 		}
 	}
+
+	if opt.Dedupe {
+		dedupeVulnerabilities(report.Results)
+	}
 	return nil
 }
 
+// dedupeVulnerabilities collapses the same vulnerability in the same package version found in multiple places,
+// e.g. lockfiles in a monorepo or layers of an image, into the first finding with all the locations.
+func dedupeVulnerabilities(results types.Results) {
+	type key struct {
+		class            types.ResultClass
+		vulnerabilityID  string
+		pkgName          string
+		installedVersion string
+	}
+	type position struct {
+		result int
+		vuln   int
+	}
+
+	firsts := map[key]position{}
+	for i := range results {
+		var vulns []types.DetectedVulnerability
+		for _, vuln := range results[i].Vulnerabilities {
+			k := key{
+				class:            results[i].Class,
+				vulnerabilityID:  vuln.VulnerabilityID,
+				pkgName:          vuln.PkgName,
+				installedVersion: vuln.InstalledVersion,
+			}
+			loc := types.VulnerabilityLocation{
+				Target:  results[i].Target,
+				PkgPath: vuln.PkgPath,
+				Layer:   vuln.Layer,
+			}
+
+			if pos, ok := firsts[k]; ok {
+				first := &results[pos.result].Vulnerabilities[pos.vuln]
+				if pos.result == i {
+					first = &vulns[pos.vuln]
+				}
+				first.Locations = append(first.Locations, loc)
+				continue
+			}
+
+			vuln.Locations = []types.VulnerabilityLocation{loc}
+			firsts[k] = position{
+				result: i,
+				vuln:   len(vulns),
+			}
+			vulns = append(vulns, vuln)
+		}
+		results[i].Vulnerabilities = vulns
+	}
+
+	// Locations are needed only for findings found in multiple places
+	for _, pos := range firsts {
+		vuln := &results[pos.result].Vulnerabilities[pos.vuln]
+		if len(vuln.Locations) == 1 {
+			vuln.Locations = nil
+		}
+	}
+}
+
 // FilterResult filters out the result
 func FilterResult(ctx context.Context, result *types.Result, opt FilterOption) error {
 	ignoredIDs := getIgnoredIDs(opt.IgnoreFile)
 
+	if opt.ESMFixed {
+		fixInExtendedSupport(result.Vulnerabilities)
+	}
+
 	filteredVulns := filterVulnerabilities(result.Vulnerabilities, opt.Severities, opt.IgnoreUnfixed, ignoredIDs, opt.VEXPath)
 	misconfSummary, filteredMisconfs := filterMisconfigurations(result.Misconfigurations, opt.Severities, opt.IncludeNonFailures, ignoredIDs)
+	result.MaliciousPackages = filterMaliciousPackages(result.MaliciousPackages, opt.Severities, ignoredIDs)
 	result.Secrets = filterSecrets(result.Secrets, opt.Severities, ignoredIDs)
 	result.Licenses = filterLicenses(result.Licenses, opt.Severities, opt.IgnoreLicenses)
 
@@ -97,6 +166,15 @@ func filterByVEX(report types.Report, opt FilterOption) error {
 	return nil
 }
 
+// fixInExtendedSupport treats vulnerabilities fixed only in extended support programs such as Ubuntu ESM as fixed
+func fixInExtendedSupport(vulns []types.DetectedVulnerability) {
+	for i := range vulns {
+		if vulns[i].FixedVersion == "" && vulns[i].ExtendedFixedVersion != "" {
+			vulns[i].FixedVersion = vulns[i].ExtendedFixedVersion
+		}
+	}
+}
+
 func filterVulnerabilities(vulns []types.DetectedVulnerability, severities []dbTypes.Severity, ignoreUnfixed bool,
 	ignoredIDs []string, vexPath string) []types.DetectedVulnerability {
 	uniqVulns := make(map[string]types.DetectedVulnerability)
@@ -130,6 +208,24 @@ func filterVulnerabilities(vulns []types.DetectedVulnerability, severities []dbT
 	return maps.Values(uniqVulns)
 }
 
+func filterMaliciousPackages(pkgs []types.DetectedMaliciousPackage, severities []dbTypes.Severity,
+	ignoredIDs []string) []types.DetectedMaliciousPackage {
+	var filtered []types.DetectedMaliciousPackage
+	for _, pkg := range pkgs {
+		// Filter malicious packages by severity
+		for _, s := range severities {
+			if s.String() == pkg.Severity {
+				if slices.Contains(ignoredIDs, pkg.ID) {
+					continue
+				}
+				filtered = append(filtered, pkg)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
 func filterMisconfigurations(misconfs []types.DetectedMisconfiguration, severities []dbTypes.Severity,
 	includeNonFailures bool, ignoredIDs []string) (*types.MisconfSummary, []types.DetectedMisconfiguration) {
 	var filtered []types.DetectedMisconfiguration
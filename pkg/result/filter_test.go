@@ -3,7 +3,9 @@ package result_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -18,6 +20,7 @@ func TestFilter(t *testing.T) {
 		report     types.Report
 		severities []dbTypes.Severity
 		vexPath    string
+		dedupe     bool
 	}
 	tests := []struct {
 		name string
@@ -195,12 +198,189 @@ func TestFilter(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "dedupe",
+			args: args{
+				report: types.Report{
+					Results: types.Results{
+						{
+							Target: "app1/package-lock.json",
+							Class:  types.ClassLangPkg,
+							Vulnerabilities: []types.DetectedVulnerability{
+								{
+									VulnerabilityID:  "CVE-2019-0001",
+									PkgName:          "foo",
+									InstalledVersion: "1.2.3",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityHigh.String(),
+									},
+								},
+								{
+									VulnerabilityID:  "CVE-2019-0002",
+									PkgName:          "bar",
+									InstalledVersion: "1.2.3",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityHigh.String(),
+									},
+								},
+							},
+						},
+						{
+							Target: "app2/package-lock.json",
+							Class:  types.ClassLangPkg,
+							Vulnerabilities: []types.DetectedVulnerability{
+								{
+									VulnerabilityID:  "CVE-2019-0001",
+									PkgName:          "foo",
+									InstalledVersion: "1.2.3",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityHigh.String(),
+									},
+								},
+								{
+									VulnerabilityID:  "CVE-2019-0001",
+									PkgName:          "foo",
+									InstalledVersion: "1.2.4",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityHigh.String(),
+									},
+								},
+							},
+						},
+						{
+							Target: "alpine:3.17 (alpine 3.17.3)",
+							Class:  types.ClassOSPkg,
+							Vulnerabilities: []types.DetectedVulnerability{
+								{
+									VulnerabilityID:  "CVE-2019-0001",
+									PkgName:          "foo",
+									InstalledVersion: "1.2.3",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityHigh.String(),
+									},
+								},
+							},
+						},
+						{
+							Target: "Java",
+							Class:  types.ClassLangPkg,
+							Vulnerabilities: []types.DetectedVulnerability{
+								{
+									VulnerabilityID:  "CVE-2019-0003",
+									PkgName:          "baz",
+									PkgPath:          "app/a.jar",
+									InstalledVersion: "1.2.3",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityHigh.String(),
+									},
+								},
+								{
+									VulnerabilityID:  "CVE-2019-0003",
+									PkgName:          "baz",
+									PkgPath:          "app/b.jar",
+									InstalledVersion: "1.2.3",
+									Vulnerability: dbTypes.Vulnerability{
+										Severity: dbTypes.SeverityHigh.String(),
+									},
+								},
+							},
+						},
+					},
+				},
+				severities: []dbTypes.Severity{dbTypes.SeverityHigh},
+				dedupe:     true,
+			},
+			want: types.Report{
+				Results: types.Results{
+					{
+						Target: "app1/package-lock.json",
+						Class:  types.ClassLangPkg,
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2019-0002",
+								PkgName:          "bar",
+								InstalledVersion: "1.2.3",
+								Vulnerability: dbTypes.Vulnerability{
+									Severity: dbTypes.SeverityHigh.String(),
+								},
+							},
+							{
+								VulnerabilityID:  "CVE-2019-0001",
+								PkgName:          "foo",
+								InstalledVersion: "1.2.3",
+								Locations: []types.VulnerabilityLocation{
+									{Target: "app1/package-lock.json"},
+									{Target: "app2/package-lock.json"},
+								},
+								Vulnerability: dbTypes.Vulnerability{
+									Severity: dbTypes.SeverityHigh.String(),
+								},
+							},
+						},
+					},
+					{
+						Target: "app2/package-lock.json",
+						Class:  types.ClassLangPkg,
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2019-0001",
+								PkgName:          "foo",
+								InstalledVersion: "1.2.4",
+								Vulnerability: dbTypes.Vulnerability{
+									Severity: dbTypes.SeverityHigh.String(),
+								},
+							},
+						},
+					},
+					{
+						Target: "alpine:3.17 (alpine 3.17.3)",
+						Class:  types.ClassOSPkg,
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2019-0001",
+								PkgName:          "foo",
+								InstalledVersion: "1.2.3",
+								Vulnerability: dbTypes.Vulnerability{
+									Severity: dbTypes.SeverityHigh.String(),
+								},
+							},
+						},
+					},
+					{
+						Target: "Java",
+						Class:  types.ClassLangPkg,
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2019-0003",
+								PkgName:          "baz",
+								PkgPath:          "app/a.jar",
+								InstalledVersion: "1.2.3",
+								Locations: []types.VulnerabilityLocation{
+									{
+										Target:  "Java",
+										PkgPath: "app/a.jar",
+									},
+									{
+										Target:  "Java",
+										PkgPath: "app/b.jar",
+									},
+								},
+								Vulnerability: dbTypes.Vulnerability{
+									Severity: dbTypes.SeverityHigh.String(),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := result.Filter(context.Background(), tt.args.report, result.FilterOption{
 				Severities: tt.args.severities,
 				VEXPath:    tt.args.vexPath,
+				Dedupe:     tt.args.dedupe,
 			})
 			require.NoError(t, err)
 			assert.Equal(t, tt.want, tt.args.report)
@@ -213,6 +393,7 @@ func TestFilterResult(t *testing.T) {
 		result         types.Result
 		severities     []dbTypes.Severity
 		ignoreUnfixed  bool
+		esmFixed       bool
 		ignoreFile     string
 		policyFile     string
 		ignoreLicenses []string
@@ -414,6 +595,48 @@ func TestFilterResult(t *testing.T) {
 			},
 			wantVulns: []types.DetectedVulnerability{},
 		},
+		{
+			name: "happy path with esm-fixed",
+			args: args{
+				result: types.Result{
+					Vulnerabilities: []types.DetectedVulnerability{
+						{
+							VulnerabilityID:      "CVE-2019-0001",
+							PkgName:              "foo",
+							InstalledVersion:     "1.2.3",
+							ExtendedFixedVersion: "1.2.3+esm1",
+							Vulnerability: dbTypes.Vulnerability{
+								Severity: dbTypes.SeverityHigh.String(),
+							},
+						},
+						{
+							VulnerabilityID:  "CVE-2018-0002",
+							PkgName:          "bar",
+							InstalledVersion: "1.2.3",
+							FixedVersion:     "",
+							Vulnerability: dbTypes.Vulnerability{
+								Severity: dbTypes.SeverityHigh.String(),
+							},
+						},
+					},
+				},
+				severities:    []dbTypes.Severity{dbTypes.SeverityHigh},
+				ignoreUnfixed: true,
+				esmFixed:      true,
+			},
+			wantVulns: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:      "CVE-2019-0001",
+					PkgName:              "foo",
+					InstalledVersion:     "1.2.3",
+					FixedVersion:         "1.2.3+esm1",
+					ExtendedFixedVersion: "1.2.3+esm1",
+					Vulnerability: dbTypes.Vulnerability{
+						Severity: dbTypes.SeverityHigh.String(),
+					},
+				},
+			},
+		},
 		{
 			name: "happy path with ignore-file",
 			args: args{
@@ -595,6 +818,64 @@ func TestFilterResult(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "happy path with a policy file using the finding age",
+			args: args{
+				result: types.Result{
+					Vulnerabilities: []types.DetectedVulnerability{
+						{
+							VulnerabilityID:  "CVE-2019-0001",
+							PkgName:          "foo",
+							InstalledVersion: "1.2.3",
+							FirstSeen:        lo.ToPtr(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+							Vulnerability: dbTypes.Vulnerability{
+								Severity: dbTypes.SeverityLow.String(),
+							},
+						},
+						{
+							// this vulnerability is ignored
+							VulnerabilityID:  "CVE-2019-0002",
+							PkgName:          "foo",
+							InstalledVersion: "1.2.3",
+							FirstSeen:        lo.ToPtr(time.Now().UTC()),
+							Vulnerability: dbTypes.Vulnerability{
+								Severity: dbTypes.SeverityLow.String(),
+							},
+						},
+						{
+							// this vulnerability has never been recorded
+							VulnerabilityID:  "CVE-2019-0003",
+							PkgName:          "foo",
+							InstalledVersion: "1.2.3",
+							Vulnerability: dbTypes.Vulnerability{
+								Severity: dbTypes.SeverityLow.String(),
+							},
+						},
+					},
+				},
+				severities: []dbTypes.Severity{dbTypes.SeverityLow},
+				policyFile: "./testdata/age.rego",
+			},
+			wantVulns: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "CVE-2019-0001",
+					PkgName:          "foo",
+					InstalledVersion: "1.2.3",
+					FirstSeen:        lo.ToPtr(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+					Vulnerability: dbTypes.Vulnerability{
+						Severity: dbTypes.SeverityLow.String(),
+					},
+				},
+				{
+					VulnerabilityID:  "CVE-2019-0003",
+					PkgName:          "foo",
+					InstalledVersion: "1.2.3",
+					Vulnerability: dbTypes.Vulnerability{
+						Severity: dbTypes.SeverityLow.String(),
+					},
+				},
+			},
+		},
 		{
 			name: "happy path with duplicates, one with empty fixed version",
 			args: args{
@@ -872,6 +1153,7 @@ func TestFilterResult(t *testing.T) {
 			err := result.FilterResult(context.Background(), &tt.args.result, result.FilterOption{
 				Severities:     tt.args.severities,
 				IgnoreUnfixed:  tt.args.ignoreUnfixed,
+				ESMFixed:       tt.args.esmFixed,
 				IgnoreFile:     tt.args.ignoreFile,
 				PolicyFile:     tt.args.policyFile,
 				IgnoreLicenses: tt.args.ignoreLicenses,
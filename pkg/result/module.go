@@ -18,6 +18,11 @@ parse_cvss_vector_v3(cvss) = vector {
 	}
 }
 
+# age_days returns the number of days since the finding was first seen, which requires '--record'
+age_days(finding) = days {
+	days := floor((time.now_ns() - time.parse_rfc3339_ns(finding.FirstSeen)) / (24 * 60 * 60 * 1000000000))
+}
+
 attack_vector := {
 	"AV:N": "Network",
 	"AV:A": "Adjacent",
@@ -0,0 +1,8 @@
+package trivy
+
+import data.lib.trivy
+
+# Give a grace period of 30 days to new vulnerabilities
+ignore {
+	trivy.age_days(input) < 30
+}
@@ -3,17 +3,25 @@ package client
 import (
 	"context"
 	"crypto/tls"
-	"github.com/zhanglimao/trivy/rpc/common"
+	"errors"
 	"net/http"
+	"time"
 
+	"github.com/cheggaaa/pb/v3"
+	"github.com/twitchtv/twirp"
 	"golang.org/x/xerrors"
 
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
 	r "github.com/zhanglimao/trivy/pkg/rpc"
 	"github.com/zhanglimao/trivy/pkg/types"
+	"github.com/zhanglimao/trivy/rpc/common"
 	rpc "github.com/zhanglimao/trivy/rpc/scanner"
 )
 
+// pollInterval is the interval between progress requests
+var pollInterval = 500 * time.Millisecond
+
 type options struct {
 	rpcClient rpc.Scanner
 }
@@ -32,22 +40,34 @@ type ScannerOption struct {
 	RemoteURL     string
 	Insecure      bool
 	CustomHeaders http.Header
+
+	// TLSConfig takes precedence over Insecure when set
+	TLSConfig *tls.Config
+
+	// Progress polls the progress and partial results of the scan instead of waiting for a single response
+	Progress bool
 }
 
 // Scanner implements the RPC scanner
 type Scanner struct {
 	customHeaders http.Header
+	progress      bool
 	client        rpc.Scanner
 }
 
 // NewScanner is the factory method to return RPC Scanner
 func NewScanner(scannerOptions ScannerOption, opts ...Option) Scanner {
+	tlsConfig := scannerOptions.TLSConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: scannerOptions.Insecure,
+		}
+	}
+
 	httpClient := &http.Client{
 		Transport: &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: scannerOptions.Insecure,
-			},
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
 		},
 	}
 
@@ -60,13 +80,21 @@ func NewScanner(scannerOptions ScannerOption, opts ...Option) Scanner {
 
 	return Scanner{
 		customHeaders: scannerOptions.CustomHeaders,
+		progress:      scannerOptions.Progress,
 		client:        o.rpcClient,
 	}
 }
 
 // Scan scans the image
 func (s Scanner) Scan(ctx context.Context, target, artifactKey string, blobKeys []string, opts types.ScanOptions) (types.Results, ftypes.OS, error) {
-	ctx = WithCustomHeaders(ctx, s.customHeaders)
+	headers := s.customHeaders.Clone()
+	if scanID := log.ScanID(ctx); scanID != "" {
+		if headers == nil {
+			headers = http.Header{}
+		}
+		headers.Set(r.ScanIDHeader, scanID)
+	}
+	ctx = WithCustomHeaders(ctx, headers)
 
 	// Convert to the rpc struct
 	licenseCategories := map[string]*rpc.Licenses{}
@@ -74,25 +102,42 @@ func (s Scanner) Scan(ctx context.Context, target, artifactKey string, blobKeys
 		licenseCategories[string(category)] = &rpc.Licenses{Names: names}
 	}
 
+	req := &rpc.ScanRequest{
+		Target:     target,
+		ArtifactId: artifactKey,
+		BlobIds:    blobKeys,
+		Options: &rpc.ScanOptions{
+			VulnType:          opts.VulnType,
+			Scanners:          opts.Scanners.StringSlice(),
+			ListAllPackages:   opts.ListAllPackages,
+			LicenseCategories: licenseCategories,
+			ArtifactType:      string(opts.ArtifactType),
+		},
+		Os: &common.OS{
+			Family: opts.OsFamily,
+			Name:   opts.OsName,
+		},
+		Packages: opts.Packages,
+	}
+
+	if s.progress {
+		res, err := s.scanWithProgress(ctx, req)
+		var twerr twirp.Error
+		switch {
+		case errors.As(err, &twerr) && twerr.Code() == twirp.BadRoute:
+			// Servers prior to the progress API don't know StartScan
+			log.Logger.Debug("The server doesn't support scan progress, waiting for the results...")
+		case err != nil:
+			return nil, ftypes.OS{}, xerrors.Errorf("failed to detect vulnerabilities via RPC: %w", err)
+		default:
+			return r.ConvertFromRPCResults(res.Results), r.ConvertFromRPCOS(res.Os), nil
+		}
+	}
+
 	var res *rpc.ScanResponse
 	err := r.Retry(func() error {
 		var err error
-		res, err = s.client.Scan(ctx, &rpc.ScanRequest{
-			Target:     target,
-			ArtifactId: artifactKey,
-			BlobIds:    blobKeys,
-			Options: &rpc.ScanOptions{
-				VulnType:          opts.VulnType,
-				Scanners:          opts.Scanners.StringSlice(),
-				ListAllPackages:   opts.ListAllPackages,
-				LicenseCategories: licenseCategories,
-			},
-			Os: &common.OS{
-				Family: opts.OsFamily,
-				Name:   opts.OsName,
-			},
-			Packages: opts.Packages,
-		})
+		res, err = s.client.Scan(ctx, req)
 		return err
 	})
 	if err != nil {
@@ -101,3 +146,78 @@ func (s Scanner) Scan(ctx context.Context, target, artifactKey string, blobKeys
 
 	return r.ConvertFromRPCResults(res.Results), r.ConvertFromRPCOS(res.Os), nil
 }
+
+// scanWithProgress starts the scan on the server and polls it until it is done,
+// showing the applied layers and logging partial results as they arrive.
+func (s Scanner) scanWithProgress(ctx context.Context, req *rpc.ScanRequest) (*rpc.ScanResponse, error) {
+	var started *rpc.StartScanResponse
+	err := r.Retry(func() error {
+		var err error
+		started, err = s.client.StartScan(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var bar *pb.ProgressBar
+	defer func() {
+		if bar != nil {
+			bar.Finish()
+		}
+	}()
+
+	var stage string
+	var offset, position int32
+	for {
+		var progress *rpc.GetScanProgressResponse
+		err = r.Retry(func() error {
+			var err error
+			progress, err = s.client.GetScanProgress(ctx, &rpc.GetScanProgressRequest{
+				ScanId:        started.ScanId,
+				ResultsOffset: offset,
+			})
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if progress.LayersTotal > 1 {
+			if bar == nil {
+				bar = pb.StartNew(int(progress.LayersTotal))
+			}
+			bar.SetCurrent(int64(progress.LayersApplied))
+		}
+
+		switch {
+		case progress.Error != "":
+			return nil, xerrors.Errorf("scan error on the server: %s", progress.Error)
+		case progress.Done:
+			return &rpc.ScanResponse{
+				Os:      progress.Os,
+				Results: progress.Results,
+			}, nil
+		}
+
+		if progress.QueuePosition != 0 && progress.QueuePosition != position {
+			log.Logger.Infof("Waiting for the server to start the scan (position %d in the queue)...", progress.QueuePosition)
+		}
+		position = progress.QueuePosition
+
+		if progress.Stage != stage {
+			stage = progress.Stage
+			log.Logger.Debugf("Server scan stage: %s", stage)
+		}
+		for _, res := range progress.Results {
+			log.Logger.Infof("Partial result received: %s (%d vulnerabilities)", res.Target, len(res.Vulnerabilities))
+		}
+		offset += int32(len(progress.Results))
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"path"
 	"testing"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	"github.com/aquasecurity/trivy-db/pkg/utils"
@@ -156,6 +159,56 @@ func TestScanner_Scan(t *testing.T) {
 				Eosl:   true,
 			},
 		},
+		{
+			name: "happy path with warnings",
+			customHeaders: http.Header{
+				"Trivy-Token": []string{"foo"},
+			},
+			args: args{
+				target:   "alpine:3.11",
+				imageID:  "sha256:e7d92cdc71feacf90708cb59182d0df1b911f8ae022d29e8e95d75ca6a99776a",
+				layerIDs: []string{"sha256:5216338b40a7b96416b8b9858974bbe4acc3096ee60acbc4dfb1ee02aecceb10"},
+				options: types.ScanOptions{
+					VulnType: []string{"library"},
+				},
+			},
+			expectation: &rpc.ScanResponse{
+				Os: &common.OS{
+					Family: "alpine",
+					Name:   "3.11",
+				},
+				Results: []*rpc.Result{
+					{
+						Target: "alpine:3.11",
+						Class:  string(types.ClassWarning),
+						Warnings: []*common.Warning{
+							{
+								Analyzer: "jar",
+								FilePath: "app/broken.jar",
+								Message:  "zip: not a valid zip file",
+							},
+						},
+					},
+				},
+			},
+			wantResults: types.Results{
+				{
+					Target: "alpine:3.11",
+					Class:  types.ClassWarning,
+					Warnings: []ftypes.Warning{
+						{
+							Analyzer: "jar",
+							FilePath: "app/broken.jar",
+							Message:  "zip: not a valid zip file",
+						},
+					},
+				},
+			},
+			wantOS: ftypes.OS{
+				Family: "alpine",
+				Name:   "3.11",
+			},
+		},
 		{
 			name: "sad path: Scan returns an error",
 			customHeaders: http.Header{
@@ -253,3 +306,98 @@ func TestScanner_ScanServerInsecure(t *testing.T) {
 		})
 	}
 }
+
+func TestScanner_ScanProgress(t *testing.T) {
+	pollInterval = 0
+
+	tests := []struct {
+		name        string
+		responses   map[string][]proto.Message // per method, the last one is repeated
+		wantResults types.Results
+		wantOS      ftypes.OS
+		wantErr     string
+	}{
+		{
+			name: "happy path",
+			responses: map[string][]proto.Message{
+				"StartScan": {&rpc.StartScanResponse{ScanId: "id"}},
+				"GetScanProgress": {
+					&rpc.GetScanProgressResponse{
+						Stage:         "applying layers",
+						LayersTotal:   2,
+						LayersApplied: 1,
+					},
+					&rpc.GetScanProgressResponse{
+						Stage:         "detecting vulnerabilities",
+						LayersTotal:   2,
+						LayersApplied: 2,
+						Results:       []*rpc.Result{{Target: "alpine:3.11 (alpine 3.11)"}},
+						ResultsTotal:  1,
+					},
+					&rpc.GetScanProgressResponse{
+						Done:         true,
+						Results:      []*rpc.Result{{Target: "alpine:3.11 (alpine 3.11)", Type: "alpine"}},
+						ResultsTotal: 1,
+						Os:           &common.OS{Family: "alpine", Name: "3.11"},
+					},
+				},
+			},
+			wantResults: types.Results{{Target: "alpine:3.11 (alpine 3.11)", Type: "alpine"}},
+			wantOS:      ftypes.OS{Family: "alpine", Name: "3.11"},
+		},
+		{
+			name: "old server",
+			responses: map[string][]proto.Message{
+				"Scan": {&rpc.ScanResponse{
+					Results: []*rpc.Result{{Target: "alpine:3.11 (alpine 3.11)", Type: "alpine"}},
+					Os:      &common.OS{Family: "alpine", Name: "3.11"},
+				}},
+			},
+			wantResults: types.Results{{Target: "alpine:3.11 (alpine 3.11)", Type: "alpine"}},
+			wantOS:      ftypes.OS{Family: "alpine", Name: "3.11"},
+		},
+		{
+			name: "sad path: the scan fails on the server",
+			responses: map[string][]proto.Message{
+				"StartScan": {&rpc.StartScanResponse{ScanId: "id"}},
+				"GetScanProgress": {&rpc.GetScanProgressResponse{
+					Done:  true,
+					Error: "failed scan, alpine:3.11: error",
+				}},
+			},
+			wantErr: "scan error on the server",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method := path.Base(r.URL.Path)
+				responses, ok := tt.responses[method]
+				if !ok {
+					twirp.WriteError(w, twirp.NewError(twirp.BadRoute, "no handler"))
+					return
+				}
+				b, err := protojson.Marshal(responses[0])
+				require.NoError(t, err)
+				if len(responses) > 1 {
+					tt.responses[method] = responses[1:]
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write(b)
+			}))
+			defer ts.Close()
+
+			client := rpc.NewScannerJSONClient(ts.URL, ts.Client())
+			s := NewScanner(ScannerOption{Progress: true}, WithRPCClient(client))
+
+			gotResults, gotOS, err := s.Scan(context.Background(), "alpine:3.11", "", nil, types.ScanOptions{})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResults, gotResults)
+			assert.Equal(t, tt.wantOS, gotOS)
+		})
+	}
+}
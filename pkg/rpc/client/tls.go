@@ -0,0 +1,37 @@
+package client
+
+import (
+	"crypto/tls"
+
+	"golang.org/x/xerrors"
+
+	r "github.com/zhanglimao/trivy/pkg/rpc"
+)
+
+// NewTLSConfig returns the TLS configuration to talk to the server.
+// The client certificate is presented to servers requiring mutual TLS,
+// and the CA file, if any, replaces the system roots to verify the server.
+func NewTLSConfig(insecure bool, certFile, keyFile, caFile string) (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: insecure,
+	}
+
+	if (certFile == "") != (keyFile == "") {
+		return nil, xerrors.New("'--tls-cert' and '--tls-key' must be specified together")
+	} else if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to load the client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if caFile != "" {
+		pool, err := r.LoadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		insecure  bool
+		certFile  string
+		keyFile   string
+		caFile    string
+		wantCerts int
+		wantRoots bool
+		wantErr   string
+	}{
+		{
+			name:     "insecure",
+			insecure: true,
+		},
+		{
+			name:      "mutual TLS",
+			certFile:  "testdata/tls/client.crt",
+			keyFile:   "testdata/tls/client.key",
+			caFile:    "testdata/tls/ca.crt",
+			wantCerts: 1,
+			wantRoots: true,
+		},
+		{
+			name:     "sad path: no key",
+			certFile: "testdata/tls/client.crt",
+			wantErr:  "must be specified together",
+		},
+		{
+			name:    "sad path: not a certificate",
+			caFile:  "testdata/tls/client.key",
+			wantErr: "no certificate found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTLSConfig(tt.insecure, tt.certFile, tt.keyFile, tt.caFile)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.insecure, got.InsecureSkipVerify)
+			assert.Len(t, got.Certificates, tt.wantCerts)
+			assert.Equal(t, tt.wantRoots, got.RootCAs != nil)
+		})
+	}
+}
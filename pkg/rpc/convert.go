@@ -39,6 +39,7 @@ func ConvertToRPCPkgs(pkgs []ftypes.Package) []*common.Package {
 			FilePath:   pkg.FilePath,
 			DependsOn:  pkg.DependsOn,
 			Digest:     pkg.Digest.String(),
+			AnalyzedBy: ConvertToRPCAnalyzedBy(pkg.AnalyzedBy),
 		})
 	}
 	return rpcPkgs
@@ -75,6 +76,7 @@ func ConvertToRPCLicense(resources []types.DetectedLicense) []*common.License {
 			Name:       r.Name,
 			Link:       r.Link,
 			Confidence: r.Confidence,
+			AnalyzedBy: ConvertToRPCAnalyzedBy(r.AnalyzedBy),
 		})
 	}
 	return rpcResources
@@ -103,8 +105,9 @@ func ConvertToRPCSecrets(secrets []ftypes.Secret) []*common.Secret {
 	var rpcSecrets []*common.Secret
 	for _, s := range secrets {
 		rpcSecrets = append(rpcSecrets, &common.Secret{
-			Filepath: s.FilePath,
-			Findings: ConvertToRPCSecretFindings(s.Findings),
+			Filepath:   s.FilePath,
+			Findings:   ConvertToRPCSecretFindings(s.Findings),
+			AnalyzedBy: ConvertToRPCAnalyzedBy(s.AnalyzedBy),
 		})
 	}
 	return rpcSecrets
@@ -114,15 +117,16 @@ func ConvertToRPCSecretFindings(findings []ftypes.SecretFinding) []*common.Secre
 	var rpcFindings []*common.SecretFinding
 	for _, f := range findings {
 		rpcFindings = append(rpcFindings, &common.SecretFinding{
-			RuleId:    f.RuleID,
-			Category:  string(f.Category),
-			Severity:  f.Severity,
-			Title:     f.Title,
-			EndLine:   int32(f.EndLine),
-			StartLine: int32(f.StartLine),
-			Code:      ConvertToRPCCode(f.Code),
-			Match:     f.Match,
-			Layer:     ConvertToRPCLayer(f.Layer),
+			RuleId:     f.RuleID,
+			Category:   string(f.Category),
+			Severity:   f.Severity,
+			Title:      f.Title,
+			EndLine:    int32(f.EndLine),
+			StartLine:  int32(f.StartLine),
+			Code:       ConvertToRPCCode(f.Code),
+			Match:      f.Match,
+			Layer:      ConvertToRPCLayer(f.Layer),
+			AnalyzedBy: ConvertToRPCAnalyzedBy(f.AnalyzedBy),
 		})
 	}
 	return rpcFindings
@@ -139,6 +143,7 @@ func ConvertFromRPCLicense(resources []*common.License) []types.DetectedLicense
 			Name:       r.Name,
 			Link:       r.Link,
 			Confidence: r.Confidence,
+			AnalyzedBy: ConvertFromRPCAnalyzedBy(r.AnalyzedBy),
 		})
 	}
 	return rpcResources
@@ -164,6 +169,7 @@ func ConvertFromRPCPkgs(rpcPkgs []*common.Package) []ftypes.Package {
 			FilePath:   pkg.FilePath,
 			DependsOn:  pkg.DependsOn,
 			Digest:     digest.Digest(pkg.Digest),
+			AnalyzedBy: ConvertFromRPCAnalyzedBy(pkg.AnalyzedBy),
 		})
 	}
 	return pkgs
@@ -231,6 +237,7 @@ func ConvertToRPCVulns(vulns []types.DetectedVulnerability) []*common.Vulnerabil
 			CustomAdvisoryData: customAdvisoryData,
 			CustomVulnData:     customVulnData,
 			DataSource:         ConvertToRPCDataSource(vuln.DataSource),
+			AnalyzedBy:         ConvertToRPCAnalyzedBy(vuln.AnalyzedBy),
 		})
 	}
 	return rpcVulns
@@ -258,6 +265,7 @@ func ConvertToRPCMisconfs(misconfs []types.DetectedMisconfiguration) []*common.D
 			References:  m.References,
 			Status:      string(m.Status),
 			Layer:       ConvertToRPCLayer(m.Layer),
+			AnalyzedBy:  ConvertToRPCAnalyzedBy(m.AnalyzedBy),
 		})
 	}
 	return rpcMisconfs
@@ -272,6 +280,17 @@ func ConvertToRPCLayer(layer ftypes.Layer) *common.Layer {
 	}
 }
 
+// ConvertToRPCAnalyzedBy returns common.AnalyzedBy
+func ConvertToRPCAnalyzedBy(analyzedBy *ftypes.AnalyzedBy) *common.AnalyzedBy {
+	if analyzedBy == nil {
+		return nil
+	}
+	return &common.AnalyzedBy{
+		Type:    analyzedBy.Type,
+		Version: int32(analyzedBy.Version),
+	}
+}
+
 // ConvertToRPCDataSource returns common.DataSource
 func ConvertToRPCDataSource(ds *dbTypes.DataSource) *common.DataSource {
 	if ds == nil {
@@ -298,6 +317,7 @@ func ConvertFromRPCResults(rpcResults []*scanner.Result) []types.Result {
 			Packages:          ConvertFromRPCPkgs(result.Packages),
 			CustomResources:   ConvertFromRPCCustomResources(result.CustomResources),
 			Secrets:           ConvertFromRPCSecretFindings(result.Secrets),
+			Warnings:          ConvertFromRPCWarnings(result.Warnings),
 		})
 	}
 	return results
@@ -356,6 +376,7 @@ func ConvertFromRPCSecretFindings(rpcFindings []*common.SecretFinding) []ftypes.
 				DiffID:    finding.Layer.DiffId,
 				CreatedBy: finding.Layer.CreatedBy,
 			},
+			AnalyzedBy: ConvertFromRPCAnalyzedBy(finding.AnalyzedBy),
 		})
 	}
 	return findings
@@ -365,8 +386,9 @@ func ConvertFromRPCSecrets(recSecrets []*common.Secret) []ftypes.Secret {
 	var secrets []ftypes.Secret
 	for _, secret := range recSecrets {
 		secrets = append(secrets, ftypes.Secret{
-			FilePath: secret.Filepath,
-			Findings: ConvertFromRPCSecretFindings(secret.Findings),
+			FilePath:   secret.Filepath,
+			Findings:   ConvertFromRPCSecretFindings(secret.Findings),
+			AnalyzedBy: ConvertFromRPCAnalyzedBy(secret.AnalyzedBy),
 		})
 	}
 	return secrets
@@ -426,6 +448,7 @@ func ConvertFromRPCVulns(rpcVulns []*common.Vulnerability) []types.DetectedVulne
 			PrimaryURL:     vuln.PrimaryUrl,
 			Custom:         vuln.CustomAdvisoryData.AsInterface(),
 			DataSource:     ConvertFromRPCDataSource(vuln.DataSource),
+			AnalyzedBy:     ConvertFromRPCAnalyzedBy(vuln.AnalyzedBy),
 		})
 	}
 	return vulns
@@ -448,6 +471,7 @@ func ConvertFromRPCMisconfs(rpcMisconfs []*common.DetectedMisconfiguration) []ty
 			References:  rpcMisconf.References,
 			Status:      types.MisconfStatus(rpcMisconf.Status),
 			Layer:       ConvertFromRPCLayer(rpcMisconf.Layer),
+			AnalyzedBy:  ConvertFromRPCAnalyzedBy(rpcMisconf.AnalyzedBy),
 		})
 	}
 	return misconfs
@@ -488,6 +512,17 @@ func ConvertFromRPCRepository(rpcRepo *common.Repository) *ftypes.Repository {
 	}
 }
 
+// ConvertFromRPCAnalyzedBy converts *common.AnalyzedBy to *fanal.AnalyzedBy
+func ConvertFromRPCAnalyzedBy(analyzedBy *common.AnalyzedBy) *ftypes.AnalyzedBy {
+	if analyzedBy == nil {
+		return nil
+	}
+	return &ftypes.AnalyzedBy{
+		Type:    analyzedBy.Type,
+		Version: int(analyzedBy.Version),
+	}
+}
+
 // ConvertFromRPCDataSource converts *common.DataSource to *dbTypes.DataSource
 func ConvertFromRPCDataSource(ds *common.DataSource) *dbTypes.DataSource {
 	if ds == nil {
@@ -505,8 +540,9 @@ func ConvertFromRPCPackageInfos(rpcPkgInfos []*common.PackageInfo) []ftypes.Pack
 	var pkgInfos []ftypes.PackageInfo
 	for _, rpcPkgInfo := range rpcPkgInfos {
 		pkgInfos = append(pkgInfos, ftypes.PackageInfo{
-			FilePath: rpcPkgInfo.FilePath,
-			Packages: ConvertFromRPCPkgs(rpcPkgInfo.Packages),
+			FilePath:   rpcPkgInfo.FilePath,
+			Packages:   ConvertFromRPCPkgs(rpcPkgInfo.Packages),
+			AnalyzedBy: ConvertFromRPCAnalyzedBy(rpcPkgInfo.AnalyzedBy),
 		})
 	}
 	return pkgInfos
@@ -517,9 +553,10 @@ func ConvertFromRPCApplications(rpcApps []*common.Application) []ftypes.Applicat
 	var apps []ftypes.Application
 	for _, rpcApp := range rpcApps {
 		apps = append(apps, ftypes.Application{
-			Type:      rpcApp.Type,
-			FilePath:  rpcApp.FilePath,
-			Libraries: ConvertFromRPCPkgs(rpcApp.Libraries),
+			Type:       rpcApp.Type,
+			FilePath:   rpcApp.FilePath,
+			Libraries:  ConvertFromRPCPkgs(rpcApp.Libraries),
+			AnalyzedBy: ConvertFromRPCAnalyzedBy(rpcApp.AnalyzedBy),
 		})
 	}
 	return apps
@@ -537,6 +574,7 @@ func ConvertFromRPCMisconfigurations(rpcMisconfs []*common.Misconfiguration) []f
 			Failures:   ConvertFromRPCMisconfResults(rpcMisconf.Failures),
 			Exceptions: ConvertFromRPCMisconfResults(rpcMisconf.Exceptions),
 			Layer:      ftypes.Layer{},
+			AnalyzedBy: ConvertFromRPCAnalyzedBy(rpcMisconf.AnalyzedBy),
 		})
 	}
 	return misconfs
@@ -560,6 +598,19 @@ func ConvertFromRPCMisconfResults(rpcResults []*common.MisconfResult) []ftypes.M
 	return results
 }
 
+// ConvertFromRPCWarnings converts common.Warning to fanal.Warning
+func ConvertFromRPCWarnings(rpcWarnings []*common.Warning) []ftypes.Warning {
+	var warnings []ftypes.Warning
+	for _, w := range rpcWarnings {
+		warnings = append(warnings, ftypes.Warning{
+			Analyzer: w.Analyzer,
+			FilePath: w.FilePath,
+			Message:  w.Message,
+		})
+	}
+	return warnings
+}
+
 // ConvertFromRPCPutArtifactRequest converts cache.PutArtifactRequest to fanal.PutArtifactRequest
 func ConvertFromRPCPutArtifactRequest(req *cache.PutArtifactRequest) ftypes.ArtifactInfo {
 	created, _ := ptypes.Timestamp(req.ArtifactInfo.Created) // nolint: errcheck
@@ -588,6 +639,7 @@ func ConvertFromRPCPutBlobRequest(req *cache.PutBlobRequest) ftypes.BlobInfo {
 		WhiteoutFiles:     req.BlobInfo.WhiteoutFiles,
 		CustomResources:   ConvertFromRPCCustomResources(req.BlobInfo.CustomResources),
 		Secrets:           ConvertFromRPCSecrets(req.BlobInfo.Secrets),
+		Warnings:          ConvertFromRPCWarnings(req.BlobInfo.Warnings),
 	}
 }
 
@@ -637,17 +689,19 @@ func ConvertToRPCBlobInfo(diffID string, blobInfo ftypes.BlobInfo) *cache.PutBlo
 	var packageInfos []*common.PackageInfo
 	for _, pkgInfo := range blobInfo.PackageInfos {
 		packageInfos = append(packageInfos, &common.PackageInfo{
-			FilePath: pkgInfo.FilePath,
-			Packages: ConvertToRPCPkgs(pkgInfo.Packages),
+			FilePath:   pkgInfo.FilePath,
+			Packages:   ConvertToRPCPkgs(pkgInfo.Packages),
+			AnalyzedBy: ConvertToRPCAnalyzedBy(pkgInfo.AnalyzedBy),
 		})
 	}
 
 	var applications []*common.Application
 	for _, app := range blobInfo.Applications {
 		applications = append(applications, &common.Application{
-			Type:      app.Type,
-			FilePath:  app.FilePath,
-			Libraries: ConvertToRPCPkgs(app.Libraries),
+			Type:       app.Type,
+			FilePath:   app.FilePath,
+			Libraries:  ConvertToRPCPkgs(app.Libraries),
+			AnalyzedBy: ConvertToRPCAnalyzedBy(app.AnalyzedBy),
 		})
 	}
 
@@ -660,6 +714,7 @@ func ConvertToRPCBlobInfo(diffID string, blobInfo ftypes.BlobInfo) *cache.PutBlo
 			Warnings:   ConvertToMisconfResults(m.Warnings),
 			Failures:   ConvertToMisconfResults(m.Failures),
 			Exceptions: ConvertToMisconfResults(m.Exceptions),
+			AnalyzedBy: ConvertToRPCAnalyzedBy(m.AnalyzedBy),
 		})
 
 	}
@@ -697,10 +752,24 @@ func ConvertToRPCBlobInfo(diffID string, blobInfo ftypes.BlobInfo) *cache.PutBlo
 			WhiteoutFiles:     blobInfo.WhiteoutFiles,
 			CustomResources:   customResources,
 			Secrets:           ConvertToRPCSecrets(blobInfo.Secrets),
+			Warnings:          ConvertToRPCWarnings(blobInfo.Warnings),
 		},
 	}
 }
 
+// ConvertToRPCWarnings returns common.Warning
+func ConvertToRPCWarnings(warnings []ftypes.Warning) []*common.Warning {
+	var rpcWarnings []*common.Warning
+	for _, w := range warnings {
+		rpcWarnings = append(rpcWarnings, &common.Warning{
+			Analyzer: w.Analyzer,
+			FilePath: w.FilePath,
+			Message:  w.Message,
+		})
+	}
+	return rpcWarnings
+}
+
 // ConvertToMisconfResults returns common.MisconfResult
 func ConvertToMisconfResults(results []ftypes.MisconfResult) []*common.MisconfResult {
 	var rpcResults []*common.MisconfResult
@@ -727,6 +796,14 @@ func ConvertToMissingBlobsRequest(imageID string, layerIDs []string) *cache.Miss
 
 // ConvertToRPCScanResponse converts types.Result to ScanResponse
 func ConvertToRPCScanResponse(results types.Results, fos ftypes.OS) *scanner.ScanResponse {
+	return &scanner.ScanResponse{
+		Os:      ConvertToRPCOS(fos),
+		Results: ConvertToRPCResults(results),
+	}
+}
+
+// ConvertToRPCResults converts types.Results to the RPC results
+func ConvertToRPCResults(results types.Results) []*scanner.Result {
 	var rpcResults []*scanner.Result
 	for _, result := range results {
 		rpcResults = append(rpcResults, &scanner.Result{
@@ -739,13 +816,10 @@ func ConvertToRPCScanResponse(results types.Results, fos ftypes.OS) *scanner.Sca
 			CustomResources:   ConvertToRPCCustomResources(result.CustomResources),
 			License:           ConvertToRPCLicense(result.Licenses),
 			Secrets:           ConvertToRPCSecretFindings(result.Secrets),
+			Warnings:          ConvertToRPCWarnings(result.Warnings),
 		})
 	}
-
-	return &scanner.ScanResponse{
-		Os:      ConvertToRPCOS(fos),
-		Results: rpcResults,
-	}
+	return rpcResults
 }
 
 func ConvertToDeleteBlobsRequest(blobIDs []string) *cache.DeleteBlobsRequest {
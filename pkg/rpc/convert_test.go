@@ -45,6 +45,10 @@ func TestConvertToRpcPkgs(t *testing.T) {
 							DiffID: "sha256:39982b2a789afc156fff00c707d0ff1c6ab4af8f1666a8df4787714059ce24e7",
 						},
 						Digest: "SHA1:901a7b55410321c4d35543506cff2a8613ef5aa2",
+						AnalyzedBy: &ftypes.AnalyzedBy{
+							Type:    "apk",
+							Version: 1,
+						},
 					},
 				},
 			},
@@ -65,6 +69,10 @@ func TestConvertToRpcPkgs(t *testing.T) {
 						DiffId: "sha256:39982b2a789afc156fff00c707d0ff1c6ab4af8f1666a8df4787714059ce24e7",
 					},
 					Digest: "SHA1:901a7b55410321c4d35543506cff2a8613ef5aa2",
+					AnalyzedBy: &common.AnalyzedBy{
+						Type:    "apk",
+						Version: 1,
+					},
 				},
 			},
 		},
@@ -105,6 +113,10 @@ func TestConvertFromRpcPkgs(t *testing.T) {
 							DiffId: "sha256:39982b2a789afc156fff00c707d0ff1c6ab4af8f1666a8df4787714059ce24e7",
 						},
 						Digest: "SHA1:901a7b55410321c4d35543506cff2a8613ef5aa2",
+						AnalyzedBy: &common.AnalyzedBy{
+							Type:    "apk",
+							Version: 1,
+						},
 					},
 				},
 			},
@@ -125,6 +137,10 @@ func TestConvertFromRpcPkgs(t *testing.T) {
 						DiffID: "sha256:39982b2a789afc156fff00c707d0ff1c6ab4af8f1666a8df4787714059ce24e7",
 					},
 					Digest: "SHA1:901a7b55410321c4d35543506cff2a8613ef5aa2",
+					AnalyzedBy: &ftypes.AnalyzedBy{
+						Type:    "apk",
+						Version: 1,
+					},
 				},
 			},
 		},
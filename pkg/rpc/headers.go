@@ -0,0 +1,4 @@
+package rpc
+
+// ScanIDHeader carries the scan ID of the client so that the logs of the client and the server can be correlated
+const ScanIDHeader = "Trivy-Scan-Id"
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/twitchtv/twirp"
+	"golang.org/x/exp/slices"
+	"golang.org/x/time/rate"
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/zhanglimao/trivy/pkg/clock"
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+// Scope is what a token is allowed to do
+type Scope string
+
+const (
+	// ScopeScan allows the scanner and cache RPCs
+	ScopeScan Scope = "scan"
+
+	// ScopeDBDownload allows downloading the vulnerability DB from the server
+	ScopeDBDownload Scope = "db-download"
+)
+
+var allScopes = []Scope{ScopeScan, ScopeDBDownload}
+
+// Tenant is an API token shared by a team
+type Tenant struct {
+	Name   string  `yaml:"name"`
+	Token  string  `yaml:"token"`
+	Scopes []Scope `yaml:"scopes"`
+
+	// RateLimit is the number of requests per second, unlimited if zero
+	RateLimit float64 `yaml:"rate-limit"`
+	Burst     int     `yaml:"burst"`
+
+	// DailyQuota is the number of scans per day (UTC), unlimited if zero
+	DailyQuota int `yaml:"daily-quota"`
+}
+
+type tenantsFile struct {
+	Tenants []Tenant `yaml:"tenants"`
+}
+
+// LoadTenants loads the tokens file
+func LoadTenants(filePath string) ([]Tenant, error) {
+	b, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, xerrors.Errorf("file read error: %w", err)
+	}
+
+	var f tenantsFile
+	if err = yaml.Unmarshal(b, &f); err != nil {
+		return nil, xerrors.Errorf("yaml decode error: %w", err)
+	}
+
+	tokens := map[string]struct{}{}
+	for _, t := range f.Tenants {
+		switch {
+		case t.Name == "":
+			return nil, xerrors.New("tenant name is empty")
+		case t.Token == "":
+			return nil, xerrors.Errorf("token is empty: %s", t.Name)
+		case len(t.Scopes) == 0:
+			return nil, xerrors.Errorf("no scopes: %s", t.Name)
+		}
+		for _, s := range t.Scopes {
+			if !slices.Contains(allScopes, s) {
+				return nil, xerrors.Errorf("unknown scope %q: %s", s, t.Name)
+			}
+		}
+		if _, ok := tokens[t.Token]; ok {
+			return nil, xerrors.Errorf("duplicate token: %s", t.Name)
+		}
+		tokens[t.Token] = struct{}{}
+	}
+	return f.Tenants, nil
+}
+
+type tenant struct {
+	Tenant
+	limiter *rate.Limiter
+
+	mu    sync.Mutex
+	day   string
+	scans int
+}
+
+// consumeScan counts a scan against the daily quota
+func (t *tenant) consumeScan() bool {
+	if t.DailyQuota == 0 {
+		return true
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	today := clock.Now().UTC().Format("2006-01-02")
+	if t.day != today {
+		t.day, t.scans = today, 0
+	}
+	if t.scans >= t.DailyQuota {
+		return false
+	}
+	t.scans++
+	return true
+}
+
+// authenticator checks the client certificate and token of requests.
+// Requests are not authenticated when no token is configured.
+type authenticator struct {
+	header  string
+	tenants []*tenant
+
+	// clientCert requires a verified client certificate, as the TLS listener only verifies it if given
+	clientCert bool
+}
+
+// newAuthenticator returns an authenticator for the legacy single token, allowed to do everything, and the tenants
+func newAuthenticator(token, tokenHeader string, tenants []Tenant) *authenticator {
+	a := &authenticator{header: tokenHeader}
+	if token != "" {
+		a.tenants = append(a.tenants, &tenant{
+			Tenant: Tenant{
+				Name:   "default",
+				Token:  token,
+				Scopes: allScopes,
+			},
+		})
+	}
+	for _, t := range tenants {
+		tt := &tenant{Tenant: t}
+		if t.RateLimit > 0 {
+			burst := t.Burst
+			if burst <= 0 {
+				burst = 1
+			}
+			tt.limiter = rate.NewLimiter(rate.Limit(t.RateLimit), burst)
+		}
+		a.tenants = append(a.tenants, tt)
+	}
+	return a
+}
+
+// lookup compares the token with every token in constant time without returning early,
+// so the response time doesn't tell which token matched
+func (a *authenticator) lookup(token string) *tenant {
+	var found *tenant
+	for _, t := range a.tenants {
+		if subtle.ConstantTimeCompare([]byte(t.Token), []byte(token)) == 1 {
+			found = t
+		}
+	}
+	return found
+}
+
+// handler allows requests with a verified client certificate, when required, and a token having the scope
+func (a *authenticator) handler(base http.Handler, scope Scope) http.Handler {
+	tokenHandler := a.tokenHandler(base, scope)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.clientCert && (r.TLS == nil || len(r.TLS.VerifiedChains) == 0) {
+			twirp.WriteError(w, twirp.NewError(twirp.Unauthenticated, "client certificate required"))
+			return
+		}
+		tokenHandler.ServeHTTP(w, r)
+	})
+}
+
+// tokenHandler allows requests with a token having the scope, within the rate limit and quota of its tenant
+func (a *authenticator) tokenHandler(base http.Handler, scope Scope) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(a.tenants) == 0 {
+			base.ServeHTTP(w, r)
+			return
+		}
+
+		t := a.lookup(r.Header.Get(a.header))
+		switch {
+		case t == nil:
+			twirp.WriteError(w, twirp.NewError(twirp.Unauthenticated, "invalid token"))
+			return
+		case !slices.Contains(t.Scopes, scope):
+			twirp.WriteError(w, twirp.NewError(twirp.PermissionDenied, "the token doesn't have the scope: "+string(scope)))
+			return
+		case t.limiter != nil && !t.limiter.Allow():
+			twirp.WriteError(w, twirp.NewError(twirp.ResourceExhausted, "rate limit exceeded"))
+			return
+		case isScanRequest(r) && !t.consumeScan():
+			log.Logger.Debugf("Daily scan quota exhausted: %s", t.Name)
+			twirp.WriteError(w, twirp.NewError(twirp.ResourceExhausted, "daily scan quota exceeded"))
+			return
+		}
+		base.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tenantKey{}, t.Name)))
+	})
+}
+
+type tenantKey struct{}
+
+// tenantName returns the tenant which sent the request, or empty when requests are not authenticated
+func tenantName(ctx context.Context) string {
+	name, _ := ctx.Value(tenantKey{}).(string)
+	return name
+}
+
+// withBearerToken takes the token from the Authorization header when the token header is not set,
+// for clients which can't send custom headers such as the Kubernetes API server
+func withBearerToken(base http.Handler, tokenHeader string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authz := r.Header.Get("Authorization")
+		if strings.HasPrefix(authz, "Bearer ") && r.Header.Get(tokenHeader) == "" {
+			r = r.Clone(r.Context())
+			r.Header.Set(tokenHeader, strings.TrimPrefix(authz, "Bearer "))
+		}
+		base.ServeHTTP(w, r)
+	})
+}
+
+// isScanRequest returns whether the request starts a scan, as opposed to uploading blobs or polling
+func isScanRequest(r *http.Request) bool {
+	switch path.Base(r.URL.Path) {
+	case "Scan", "StartScan":
+		return true
+	}
+	return false
+}
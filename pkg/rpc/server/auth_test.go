@@ -0,0 +1,279 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/zhanglimao/trivy/pkg/clock"
+	"github.com/zhanglimao/trivy/pkg/scanner"
+	rpcScanner "github.com/zhanglimao/trivy/rpc/scanner"
+)
+
+func TestLoadTenants(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		want    []Tenant
+		wantErr string
+	}{
+		{
+			name: "happy path",
+			file: "testdata/tokens/happy.yaml",
+			want: []Tenant{
+				{
+					Name:       "team-a",
+					Token:      "token-a",
+					Scopes:     []Scope{ScopeScan},
+					RateLimit:  5,
+					Burst:      10,
+					DailyQuota: 100,
+				},
+				{
+					Name:   "mirror",
+					Token:  "token-mirror",
+					Scopes: []Scope{ScopeDBDownload},
+				},
+			},
+		},
+		{
+			name:    "sad path: unknown scope",
+			file:    "testdata/tokens/unknown-scope.yaml",
+			wantErr: `unknown scope "admin": team-a`,
+		},
+		{
+			name:    "sad path: duplicate token",
+			file:    "testdata/tokens/duplicate.yaml",
+			wantErr: "duplicate token: team-b",
+		},
+		{
+			name:    "sad path: no such file",
+			file:    "testdata/tokens/missing.yaml",
+			wantErr: "file read error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadTenants(tt.file)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_authenticator_handler(t *testing.T) {
+	clock.SetFakeTime(t, time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	tenants := []Tenant{
+		{
+			Name:       "team-a",
+			Token:      "token-a",
+			Scopes:     []Scope{ScopeScan},
+			DailyQuota: 1,
+		},
+		{
+			Name:      "team-b",
+			Token:     "token-b",
+			Scopes:    []Scope{ScopeScan},
+			RateLimit: 0.001,
+		},
+	}
+	scanPath := path.Join(rpcScanner.ScannerPathPrefix, "Scan")
+
+	tests := []struct {
+		name  string
+		token string
+		scope Scope
+		paths []string
+		want  []int
+	}{
+		{
+			name:  "legacy token",
+			token: "legacy",
+			scope: ScopeDBDownload,
+			paths: []string{"/db/trivy.db"},
+			want:  []int{http.StatusOK},
+		},
+		{
+			name:  "invalid token",
+			token: "invalid",
+			scope: ScopeScan,
+			paths: []string{scanPath},
+			want:  []int{http.StatusUnauthorized},
+		},
+		{
+			name:  "missing scope",
+			token: "token-a",
+			scope: ScopeDBDownload,
+			paths: []string{"/db/trivy.db"},
+			want:  []int{http.StatusForbidden},
+		},
+		{
+			name:  "daily quota",
+			token: "token-a",
+			scope: ScopeScan,
+			paths: []string{scanPath, path.Join(rpcScanner.ScannerPathPrefix, "GetScanProgress"), scanPath},
+			want:  []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			name:  "rate limit",
+			token: "token-b",
+			scope: ScopeScan,
+			paths: []string{scanPath, scanPath},
+			want:  []int{http.StatusOK, http.StatusTooManyRequests},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := newAuthenticator("legacy", "Trivy-Token", tenants)
+			h := auth.handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), tt.scope)
+
+			for i, p := range tt.paths {
+				req := httptest.NewRequest(http.MethodPost, p, nil)
+				req.Header.Set("Trivy-Token", tt.token)
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, req)
+				assert.Equal(t, tt.want[i], rec.Code, p)
+			}
+		})
+	}
+}
+
+func Test_authenticator_clientCert(t *testing.T) {
+	auth := newAuthenticator("", "Trivy-Token", nil)
+	auth.clientCert = true
+	h := auth.handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), ScopeScan)
+
+	// Without a verified chain
+	req := httptest.NewRequest(http.MethodPost, path.Join(rpcScanner.ScannerPathPrefix, "Scan"), nil)
+	req.TLS = &tls.ConnectionState{}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// Verified by the server
+	req.TLS.VerifiedChains = [][]*x509.Certificate{{{}}}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// The webhook only checks the token
+	h = auth.tokenHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), ScopeScan)
+	req = httptest.NewRequest(http.MethodPost, WebhookPath, nil)
+	req.TLS = &tls.ConnectionState{}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func Test_authenticator_scanJobs(t *testing.T) {
+	tenants := []Tenant{
+		{
+			Name:   "team-a",
+			Token:  "token-a",
+			Scopes: []Scope{ScopeScan},
+		},
+		{
+			Name:   "team-b",
+			Token:  "token-b",
+			Scopes: []Scope{ScopeScan},
+		},
+	}
+	mockDriver := new(scanner.MockDriver)
+	mockDriver.ApplyScanExpectation(scanner.DriverScanExpectation{
+		Args: scanner.DriverScanArgs{
+			CtxAnything:     true,
+			Target:          "alpine:3.11",
+			OptionsAnything: true,
+		},
+	})
+	s := NewScanServer(mockDriver)
+
+	auth := newAuthenticator("", "Trivy-Token", tenants)
+	ts := httptest.NewServer(auth.handler(rpcScanner.NewScannerServer(s), ScopeScan))
+	defer ts.Close()
+
+	withToken := func(token string) context.Context {
+		header := make(http.Header)
+		header.Set("Trivy-Token", token)
+		ctx, err := twirp.WithHTTPRequestHeaders(context.Background(), header)
+		require.NoError(t, err)
+		return ctx
+	}
+	client := rpcScanner.NewScannerProtobufClient(ts.URL, ts.Client())
+
+	started, err := client.StartScan(withToken("token-a"), &rpcScanner.ScanRequest{
+		Target:  "alpine:3.11",
+		Options: &rpcScanner.ScanOptions{},
+	})
+	require.NoError(t, err)
+	s.inflight.Wait()
+
+	// Another tenant can't see the scan
+	var twerr twirp.Error
+	_, err = client.GetScanProgress(withToken("token-b"), &rpcScanner.GetScanProgressRequest{ScanId: started.ScanId})
+	require.ErrorAs(t, err, &twerr)
+	assert.Equal(t, twirp.NotFound, twerr.Code())
+
+	_, err = client.GetScanResult(withToken("token-b"), &rpcScanner.GetScanResultRequest{ScanId: started.ScanId})
+	require.ErrorAs(t, err, &twerr)
+	assert.Equal(t, twirp.NotFound, twerr.Code())
+
+	// The tenant which started the scan gets the results
+	got, err := client.GetScanResult(withToken("token-a"), &rpcScanner.GetScanResultRequest{ScanId: started.ScanId})
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+}
+
+func Test_withBearerToken(t *testing.T) {
+	auth := newAuthenticator("legacy", "Trivy-Token", nil)
+	h := withBearerToken(auth.handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), ScopeScan), "Trivy-Token")
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{
+			name:   "bearer token",
+			header: "Authorization",
+			value:  "Bearer legacy",
+			want:   http.StatusOK,
+		},
+		{
+			name:   "invalid bearer token",
+			header: "Authorization",
+			value:  "Bearer invalid",
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name: "no token",
+			want: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, WebhookPath, nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
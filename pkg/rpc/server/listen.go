@@ -2,8 +2,10 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,38 +18,102 @@ import (
 	dbc "github.com/zhanglimao/trivy/pkg/db"
 	"github.com/zhanglimao/trivy/pkg/fanal/cache"
 	"github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/javadb"
 	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/metrics"
+	"github.com/zhanglimao/trivy/pkg/rpc"
 	"github.com/zhanglimao/trivy/pkg/utils/fsutils"
 	rpcCache "github.com/zhanglimao/trivy/rpc/cache"
 	rpcScanner "github.com/zhanglimao/trivy/rpc/scanner"
 )
 
-const updateInterval = 1 * time.Hour
+const (
+	updateInterval = 1 * time.Hour
+
+	// DBPathPrefix serves the vulnerability DB of the server
+	DBPathPrefix = "/db/"
+)
 
 // Server represents Trivy server
 type Server struct {
-	appVersion   string
-	addr         string
-	cacheDir     string
-	token        string
-	tokenHeader  string
-	dbRepository string
+	appVersion     string
+	addr           string
+	cacheDir       string
+	token          string
+	tokenHeader    string
+	dbRepositories []string
+	tlsCert        string
+	tlsKey         string
+	clientCA       string
+	tenants        []Tenant
+
+	scanWorkers   int
+	scanQueueSize int
+
+	// webhook enables the admission webhook when not nil
+	webhook *WebhookPolicy
 
 	// For OCI registries
 	types.RegistryOptions
 }
 
+type Option func(*Server)
+
+// WithTLS serves HTTPS with the given certificate and key files
+func WithTLS(certFile, keyFile string) Option {
+	return func(s *Server) {
+		s.tlsCert = certFile
+		s.tlsKey = keyFile
+	}
+}
+
+// WithClientCA requires the RPC clients to present a certificate signed by the CA
+func WithClientCA(caFile string) Option {
+	return func(s *Server) {
+		s.clientCA = caFile
+	}
+}
+
+// WithScanQueue limits the number of scans processed at the same time and waiting for a worker
+func WithScanQueue(workers, size int) Option {
+	return func(s *Server) {
+		s.scanWorkers = workers
+		s.scanQueueSize = size
+	}
+}
+
+// WithTenants accepts the tokens of the tenants in addition to the token of the server
+func WithTenants(tenants []Tenant) Option {
+	return func(s *Server) {
+		s.tenants = tenants
+	}
+}
+
+// WithWebhook enables the Kubernetes validating admission webhook
+func WithWebhook(policy WebhookPolicy) Option {
+	return func(s *Server) {
+		s.webhook = &policy
+	}
+}
+
 // NewServer returns an instance of Server
-func NewServer(appVersion, addr, cacheDir, token, tokenHeader, dbRepository string, opt types.RegistryOptions) Server {
-	return Server{
+func NewServer(appVersion, addr, cacheDir, token, tokenHeader string, dbRepositories []string, opt types.RegistryOptions,
+	opts ...Option) Server {
+	s := Server{
 		appVersion:      appVersion,
 		addr:            addr,
 		cacheDir:        cacheDir,
 		token:           token,
 		tokenHeader:     tokenHeader,
-		dbRepository:    dbRepository,
+		dbRepositories:  dbRepositories,
+		scanWorkers:     DefaultScanWorkers,
+		scanQueueSize:   DefaultScanQueueSize,
 		RegistryOptions: opt,
 	}
+	for _, o := range opts {
+		o(&s)
+	}
+	return s
 }
 
 // ListenAndServe starts Trivy server
@@ -56,7 +122,7 @@ func (s Server) ListenAndServe(serverCache cache.Cache, skipDBUpdate bool) error
 	dbUpdateWg := &sync.WaitGroup{}
 
 	go func() {
-		worker := newDBWorker(dbc.NewClient(s.cacheDir, true, dbc.WithDBRepository(s.dbRepository)))
+		worker := newDBWorker(dbc.NewClient(s.cacheDir, true, dbc.WithDBRepositories(s.dbRepositories)))
 		ctx := context.Background()
 		for {
 			time.Sleep(updateInterval)
@@ -66,37 +132,79 @@ func (s Server) ListenAndServe(serverCache cache.Cache, skipDBUpdate bool) error
 		}
 	}()
 
-	mux := newServeMux(serverCache, dbUpdateWg, requestWg, s.token, s.tokenHeader)
-	log.Logger.Infof("Listening %s...", s.addr)
-
-	return http.ListenAndServe(s.addr, mux)
-}
+	metrics.RegisterDBAge(func() (time.Time, error) {
+		meta, err := metadata.NewClient(s.cacheDir).Get()
+		return meta.UpdatedAt, err
+	})
 
-func newServeMux(serverCache cache.Cache, dbUpdateWg, requestWg *sync.WaitGroup, token, tokenHeader string) *http.ServeMux {
-	withWaitGroup := func(base http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Stop processing requests during DB update
-			dbUpdateWg.Wait()
+	auth := newAuthenticator(s.token, s.tokenHeader, s.tenants)
+	auth.clientCert = s.clientCA != ""
+	queue := newScanQueue(s.scanWorkers, s.scanQueueSize)
+	mux := newServeMux(serverCache, s.cacheDir, dbUpdateWg, requestWg, auth, queue)
+
+	// The API server sends the token as a bearer token configured in its admission kubeconfig,
+	// and doesn't need a client certificate
+	if s.webhook != nil {
+		handler := webhookHandler{
+			scan:   newImageScanner(serverCache, initializeScanServer(serverCache).localScanner, s.RegistryOptions),
+			policy: *s.webhook,
+		}
+		mux.Handle(WebhookPath, withBearerToken(auth.tokenHandler(withWaitGroup(handler, dbUpdateWg, requestWg), ScopeScan), s.tokenHeader))
+		log.Logger.Infof("Admission webhook is enabled at %s", WebhookPath)
+	}
 
-			// Wait for all requests to be processed before DB update
-			requestWg.Add(1)
-			defer requestWg.Done()
+	log.Logger.Infof("Listening %s...", s.addr)
 
-			base.ServeHTTP(w, r)
+	srv := &http.Server{
+		Addr:    s.addr,
+		Handler: mux,
+	}
+	if s.clientCA != "" {
+		pool, err := rpc.LoadCertPool(s.clientCA)
+		if err != nil {
+			return xerrors.Errorf("client CA error: %w", err)
+		}
+		// The authenticator requires the certificate on the RPC and DB routes,
+		// so the webhook, metrics and health checks are reachable without one
+		srv.TLSConfig = &tls.Config{
+			ClientCAs:  pool,
+			ClientAuth: tls.VerifyClientCertIfGiven,
+		}
+	}
 
-		})
+	if s.tlsCert != "" {
+		return srv.ListenAndServeTLS(s.tlsCert, s.tlsKey)
 	}
+	return srv.ListenAndServe()
+}
 
+func newServeMux(serverCache cache.Cache, cacheDir string, dbUpdateWg, requestWg *sync.WaitGroup,
+	auth *authenticator, queue *scanQueue) *http.ServeMux {
 	mux := http.NewServeMux()
 
-	scanServer := rpcScanner.NewScannerServer(initializeScanServer(serverCache), nil)
-	scanHandler := withToken(withWaitGroup(scanServer), token, tokenHeader)
+	// Scans started by StartScan outlive their request, so they hold the DB update as well
+	scanSrv := initializeScanServer(serverCache)
+	scanSrv.inflight = requestWg
+	scanSrv.queue = queue
+
+	hooks := twirp.WithServerHooks(newMetricsHooks())
+
+	scanServer := rpcScanner.NewScannerServer(scanSrv, hooks)
+	scanHandler := auth.handler(withWaitGroup(withScanID(scanServer), dbUpdateWg, requestWg), ScopeScan)
 	mux.Handle(rpcScanner.ScannerPathPrefix, gziphandler.GzipHandler(scanHandler))
 
-	layerServer := rpcCache.NewCacheServer(NewCacheServer(serverCache), nil)
-	layerHandler := withToken(withWaitGroup(layerServer), token, tokenHeader)
+	layerServer := rpcCache.NewCacheServer(NewCacheServer(serverCache), hooks)
+	layerHandler := auth.handler(withWaitGroup(layerServer, dbUpdateWg, requestWg), ScopeScan)
 	mux.Handle(rpcCache.CachePathPrefix, gziphandler.GzipHandler(layerHandler))
 
+	dbHandler := auth.handler(withWaitGroup(newDBHandler(cacheDir), dbUpdateWg, requestWg), ScopeDBDownload)
+	mux.Handle(DBPathPrefix, dbHandler)
+
+	// The Java DB is not updated by the DB worker, so the lookups don't wait for the DB update
+	mux.Handle(javadb.LookupPathPrefix, auth.handler(javadb.NewHandler(), ScopeScan))
+
+	mux.Handle(metrics.Path, metrics.Handler())
+
 	mux.HandleFunc("/healthz", func(rw http.ResponseWriter, r *http.Request) {
 		if _, err := rw.Write([]byte("ok")); err != nil {
 			log.Logger.Errorf("health check error: %s", err)
@@ -106,16 +214,44 @@ func newServeMux(serverCache cache.Cache, dbUpdateWg, requestWg *sync.WaitGroup,
 	return mux
 }
 
-func withToken(base http.Handler, token, tokenHeader string) http.Handler {
+func withWaitGroup(base http.Handler, dbUpdateWg, requestWg *sync.WaitGroup) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Stop processing requests during DB update
+		dbUpdateWg.Wait()
+
+		// Wait for all requests to be processed before DB update
+		requestWg.Add(1)
+		defer requestWg.Done()
+
+		base.ServeHTTP(w, r)
+
+	})
+}
+
+// withScanID attaches the scan ID sent by the client to the request context for logging
+func withScanID(base http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if token != "" && token != r.Header.Get(tokenHeader) {
-			rpcScanner.WriteError(w, twirp.NewError(twirp.Unauthenticated, "invalid token"))
-			return
+		if scanID := r.Header.Get(rpc.ScanIDHeader); scanID != "" {
+			r = r.WithContext(log.ContextWith(r.Context(), log.KeyScanID, scanID))
 		}
 		base.ServeHTTP(w, r)
 	})
 }
 
+// newDBHandler serves the DB file and its metadata
+func newDBHandler(cacheDir string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, DBPathPrefix) {
+		case "trivy.db":
+			http.ServeFile(w, r, db.Path(cacheDir))
+		case "metadata.json":
+			http.ServeFile(w, r, metadata.Path(cacheDir))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
 type dbWorker struct {
 	dbClient dbc.Operation
 }
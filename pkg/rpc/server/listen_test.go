@@ -20,6 +20,8 @@ import (
 	dbFile "github.com/zhanglimao/trivy/pkg/db"
 	"github.com/zhanglimao/trivy/pkg/fanal/cache"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/rpc"
 	"github.com/zhanglimao/trivy/pkg/utils/fsutils"
 	rpcCache "github.com/zhanglimao/trivy/rpc/cache"
 )
@@ -187,6 +189,7 @@ func Test_newServeMux(t *testing.T) {
 	type args struct {
 		token       string
 		tokenHeader string
+		clientCert  bool
 	}
 	tests := []struct {
 		name   string
@@ -200,6 +203,11 @@ func Test_newServeMux(t *testing.T) {
 			path: "/healthz",
 			want: http.StatusOK,
 		},
+		{
+			name: "metrics",
+			path: "/metrics",
+			want: http.StatusOK,
+		},
 		{
 			name: "cache endpoint",
 			path: path.Join(rpcCache.CachePathPrefix, "MissingBlobs"),
@@ -221,6 +229,25 @@ func Test_newServeMux(t *testing.T) {
 			},
 			want: http.StatusOK,
 		},
+		{
+			name: "metrics without client certificate",
+			args: args{
+				clientCert: true,
+			},
+			path: "/metrics",
+			want: http.StatusOK,
+		},
+		{
+			name: "sad path: cache endpoint without client certificate",
+			args: args{
+				clientCert: true,
+			},
+			path: path.Join(rpcCache.CachePathPrefix, "MissingBlobs"),
+			header: http.Header{
+				"Content-Type": []string{"application/protobuf"},
+			},
+			want: http.StatusUnauthorized,
+		},
 		{
 			name: "sad path: no handler",
 			path: "/sad",
@@ -250,8 +277,12 @@ func Test_newServeMux(t *testing.T) {
 			require.NoError(t, err)
 			defer func() { _ = c.Close() }()
 
+			auth := newAuthenticator(tt.args.token, tt.args.tokenHeader, nil)
+			auth.clientCert = tt.args.clientCert
+
 			ts := httptest.NewServer(newServeMux(
-				c, dbUpdateWg, requestWg, tt.args.token, tt.args.tokenHeader),
+				c, t.TempDir(), dbUpdateWg, requestWg, auth,
+				newScanQueue(DefaultScanWorkers, DefaultScanQueueSize)),
 			)
 			defer ts.Close()
 
@@ -274,3 +305,15 @@ func Test_newServeMux(t *testing.T) {
 		})
 	}
 }
+
+func Test_withScanID(t *testing.T) {
+	var got string
+	h := withScanID(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = log.ScanID(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, path.Join(rpcCache.CachePathPrefix, "MissingBlobs"), nil)
+	req.Header.Set(rpc.ScanIDHeader, "scan-1")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "scan-1", got)
+}
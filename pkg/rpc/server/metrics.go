@@ -0,0 +1,31 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/zhanglimao/trivy/pkg/metrics"
+)
+
+type requestStartKey struct{}
+
+// newMetricsHooks records the status code and duration of RPC requests
+func newMetricsHooks() *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		RequestReceived: func(ctx context.Context) (context.Context, error) {
+			return context.WithValue(ctx, requestStartKey{}, time.Now()), nil
+		},
+		ResponseSent: func(ctx context.Context) {
+			start, ok := ctx.Value(requestStartKey{}).(time.Time)
+			if !ok {
+				return
+			}
+			service, _ := twirp.ServiceName(ctx)
+			method, _ := twirp.MethodName(ctx)
+			code, _ := twirp.StatusCode(ctx)
+			metrics.ObserveRPC(service, method, code, time.Since(start))
+		},
+	}
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/zhanglimao/trivy/pkg/clock"
+	"github.com/zhanglimao/trivy/pkg/rpc"
+	"github.com/zhanglimao/trivy/pkg/types"
+	rpcScanner "github.com/zhanglimao/trivy/rpc/scanner"
+)
+
+const (
+	// scanJobTTL is how long a finished scan is kept for the client to fetch its results
+	scanJobTTL = 10 * time.Minute
+
+	// scanJobTimeout bounds how long a worker spends on a scan started by StartScan
+	scanJobTimeout = 1 * time.Hour
+
+	// stageQueued is reported until a worker starts the scan
+	stageQueued = "queued"
+)
+
+// scanJob tracks a scan started by StartScan and implements local.Progress
+type scanJob struct {
+	mu            sync.Mutex
+	tenant        string // only the tenant which started the scan can fetch its progress and results
+	cancel        context.CancelFunc
+	polledAt      time.Time // the scan is canceled when the client stops polling
+	seq           int64     // sequence number in the queue
+	started       bool
+	stage         string
+	layersApplied int
+	layersTotal   int
+	results       []*rpcScanner.Result // partial results
+	final         *rpcScanner.ScanResponse
+	err           error
+	done          bool
+	finishedAt    time.Time
+}
+
+// queued records the sequence number in the queue, unless a worker has already started the scan
+func (j *scanJob) queued(seq int64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.seq = seq
+	if !j.started {
+		j.stage = stageQueued
+	}
+}
+
+// start is called by the worker picking the scan
+func (j *scanJob) start() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.started = true
+	if j.stage == stageQueued {
+		j.stage = ""
+	}
+}
+
+func (j *scanJob) sequence() int64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.seq
+}
+
+func (j *scanJob) Stage(stage string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.stage = stage
+}
+
+func (j *scanJob) LayersApplied(applied, total int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.layersApplied, j.layersTotal = applied, total
+}
+
+func (j *scanJob) PartialResults(results types.Results) {
+	// Convert them right away as the scanner keeps modifying the results
+	rpcResults := rpc.ConvertToRPCResults(results)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.results = append(j.results, rpcResults...)
+}
+
+func (j *scanJob) finish(res *rpcScanner.ScanResponse, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.final, j.err = res, err
+	j.done = true
+	j.finishedAt = clock.Now()
+}
+
+// result returns the results and whether the scan is done
+func (j *scanJob) result() (*rpcScanner.ScanResponse, error, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.final, j.err, j.done
+}
+
+// progress returns the progress with the partial results after the given offset,
+// or all the results once the scan is done.
+func (j *scanJob) progress(offset int) *rpcScanner.GetScanProgressResponse {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	res := &rpcScanner.GetScanProgressResponse{
+		Stage:         j.stage,
+		LayersTotal:   int32(j.layersTotal),
+		LayersApplied: int32(j.layersApplied),
+		ResultsTotal:  int32(len(j.results)),
+		Done:          j.done,
+	}
+	switch {
+	case j.err != nil:
+		res.Error = j.err.Error()
+	case j.done:
+		res.Results = j.final.Results
+		res.ResultsTotal = int32(len(j.final.Results))
+		res.Os = j.final.Os
+	case offset >= 0 && offset < len(j.results):
+		res.Results = j.results[offset:]
+	}
+	return res
+}
+
+// poll records that the client is still interested in the scan
+func (j *scanJob) poll() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.polledAt = clock.Now()
+}
+
+// expired returns whether the results of a finished scan or a running scan are no longer fetched
+func (j *scanJob) expired(now time.Time) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.done {
+		return now.Sub(j.finishedAt) > scanJobTTL
+	}
+	return now.Sub(j.polledAt) > scanJobTTL
+}
+
+// scanJobs holds the scans running in the background
+type scanJobs struct {
+	mu   sync.Mutex
+	jobs map[string]*scanJob
+}
+
+func newScanJobs() *scanJobs {
+	return &scanJobs{jobs: map[string]*scanJob{}}
+}
+
+// add registers a new job of the tenant canceled by the given function,
+// and drops the jobs nobody fetched, canceling the running ones
+func (s *scanJobs) add(tenant string, cancel context.CancelFunc) (string, *scanJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := clock.Now()
+	for id, job := range s.jobs {
+		if job.expired(now) {
+			job.cancel()
+			delete(s.jobs, id)
+		}
+	}
+
+	id := uuid.New().String()
+	job := &scanJob{
+		tenant:   tenant,
+		cancel:   cancel,
+		polledAt: now,
+	}
+	s.jobs[id] = job
+	return id, job
+}
+
+// get returns the job, which is not found when another tenant started it
+func (s *scanJobs) get(id, tenant string) (*scanJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok || job.tenant != tenant {
+		return nil, false
+	}
+	job.poll()
+	return job, true
+}
+
+func (s *scanJobs) remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.jobs, id)
+}
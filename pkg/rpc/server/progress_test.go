@@ -0,0 +1,192 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/zhanglimao/trivy/pkg/clock"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/scanner"
+	"github.com/zhanglimao/trivy/pkg/types"
+	"github.com/zhanglimao/trivy/rpc/common"
+	rpcScanner "github.com/zhanglimao/trivy/rpc/scanner"
+)
+
+func Test_scanJob_progress(t *testing.T) {
+	job := &scanJob{}
+	job.Stage("applying layers")
+	job.LayersApplied(2, 3)
+	job.PartialResults(types.Results{{Target: "alpine:3.11 (alpine 3.11)", Type: "alpine"}})
+	job.PartialResults(types.Results{{Target: "app/package-lock.json", Type: "npm"}})
+
+	got := job.progress(1)
+	assert.Equal(t, &rpcScanner.GetScanProgressResponse{
+		Stage:         "applying layers",
+		LayersTotal:   3,
+		LayersApplied: 2,
+		Results: []*rpcScanner.Result{
+			{Target: "app/package-lock.json", Type: "npm"},
+		},
+		ResultsTotal: 2,
+	}, got)
+
+	// The offset past the partial results
+	got = job.progress(2)
+	assert.Empty(t, got.Results)
+
+	job.finish(&rpcScanner.ScanResponse{
+		Os:      &common.OS{Family: "alpine", Name: "3.11"},
+		Results: []*rpcScanner.Result{{Target: "final"}},
+	}, nil)
+	got = job.progress(2)
+	assert.True(t, got.Done)
+	assert.Equal(t, []*rpcScanner.Result{{Target: "final"}}, got.Results)
+	assert.Equal(t, &common.OS{Family: "alpine", Name: "3.11"}, got.Os)
+
+	failed := &scanJob{}
+	failed.finish(nil, errors.New("error"))
+	got = failed.progress(0)
+	assert.True(t, got.Done)
+	assert.Equal(t, "error", got.Error)
+}
+
+func Test_scanJobs_add(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock.SetFakeTime(t, now)
+	jobs := newScanJobs()
+
+	polledCtx, polledCancel := context.WithCancel(context.Background())
+	polledID, _ := jobs.add("", polledCancel)
+	abandonedCtx, abandonedCancel := context.WithCancel(context.Background())
+	abandonedID, _ := jobs.add("", abandonedCancel)
+
+	clock.SetFakeTime(t, now.Add(scanJobTTL-time.Minute))
+	_, ok := jobs.get(polledID, "")
+	require.True(t, ok)
+
+	// The running scan nobody polled within the TTL is canceled
+	clock.SetFakeTime(t, now.Add(scanJobTTL+time.Minute))
+	jobs.add("", func() {})
+
+	_, ok = jobs.get(abandonedID, "")
+	assert.False(t, ok)
+	assert.ErrorIs(t, abandonedCtx.Err(), context.Canceled)
+
+	_, ok = jobs.get(polledID, "")
+	assert.True(t, ok)
+	assert.NoError(t, polledCtx.Err())
+}
+
+func TestScanServer_StartScan(t *testing.T) {
+	tests := []struct {
+		name      string
+		returns   scanner.DriverScanReturns
+		want      *rpcScanner.GetScanProgressResponse
+		wantError string
+	}{
+		{
+			name: "happy path",
+			returns: scanner.DriverScanReturns{
+				Results: types.Results{{Target: "alpine:3.11 (alpine 3.11)", Type: "alpine"}},
+				OsFound: ftypes.OS{Family: "alpine", Name: "3.11"},
+			},
+			want: &rpcScanner.GetScanProgressResponse{
+				Results:      []*rpcScanner.Result{{Target: "alpine:3.11 (alpine 3.11)", Type: "alpine"}},
+				ResultsTotal: 1,
+				Done:         true,
+				Os:           &common.OS{Family: "alpine", Name: "3.11"},
+			},
+		},
+		{
+			name: "sad path: Scan returns an error",
+			returns: scanner.DriverScanReturns{
+				Err: errors.New("error"),
+			},
+			wantError: "failed scan, alpine:3.11",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDriver := new(scanner.MockDriver)
+			mockDriver.ApplyScanExpectation(scanner.DriverScanExpectation{
+				Args: scanner.DriverScanArgs{
+					CtxAnything:     true,
+					Target:          "alpine:3.11",
+					ImageID:         "sha256:e7d92cdc71feacf90708cb59182d0df1b911f8ae022d29e8e95d75ca6a99776a",
+					LayerIDs:        []string{"sha256:5216338b40a7b96416b8b9858974bbe4acc3096ee60acbc4dfb1ee02aecceb10"},
+					OptionsAnything: true,
+				},
+				Returns: tt.returns,
+			})
+
+			s := NewScanServer(mockDriver)
+			started, err := s.StartScan(context.Background(), &rpcScanner.ScanRequest{
+				Target:     "alpine:3.11",
+				ArtifactId: "sha256:e7d92cdc71feacf90708cb59182d0df1b911f8ae022d29e8e95d75ca6a99776a",
+				BlobIds:    []string{"sha256:5216338b40a7b96416b8b9858974bbe4acc3096ee60acbc4dfb1ee02aecceb10"},
+				Options:    &rpcScanner.ScanOptions{},
+			})
+			require.NoError(t, err)
+			s.inflight.Wait()
+
+			got, err := s.GetScanProgress(context.Background(), &rpcScanner.GetScanProgressRequest{ScanId: started.ScanId})
+			require.NoError(t, err)
+			if tt.wantError != "" {
+				assert.True(t, got.Done)
+				assert.Contains(t, got.Error, tt.wantError)
+			} else {
+				assert.Equal(t, tt.want, got)
+			}
+
+			// The job is dropped once the client has got the results
+			_, err = s.GetScanProgress(context.Background(), &rpcScanner.GetScanProgressRequest{ScanId: started.ScanId})
+			var twerr twirp.Error
+			require.ErrorAs(t, err, &twerr)
+			assert.Equal(t, twirp.NotFound, twerr.Code())
+		})
+	}
+}
+
+func TestScanServer_GetScanResult(t *testing.T) {
+	mockDriver := new(scanner.MockDriver)
+	mockDriver.ApplyScanExpectation(scanner.DriverScanExpectation{
+		Args: scanner.DriverScanArgs{
+			CtxAnything:     true,
+			Target:          "alpine:3.11",
+			OptionsAnything: true,
+		},
+		Returns: scanner.DriverScanReturns{
+			Results: types.Results{{Target: "alpine:3.11 (alpine 3.11)", Type: "alpine"}},
+			OsFound: ftypes.OS{Family: "alpine", Name: "3.11"},
+		},
+	})
+
+	s := NewScanServer(mockDriver)
+
+	// A job not started by a worker yet
+	id, _ := s.jobs.add("", func() {})
+	_, err := s.GetScanResult(context.Background(), &rpcScanner.GetScanResultRequest{ScanId: id})
+	var twerr twirp.Error
+	require.ErrorAs(t, err, &twerr)
+	assert.Equal(t, twirp.FailedPrecondition, twerr.Code())
+
+	started, err := s.StartScan(context.Background(), &rpcScanner.ScanRequest{
+		Target:  "alpine:3.11",
+		Options: &rpcScanner.ScanOptions{},
+	})
+	require.NoError(t, err)
+	s.inflight.Wait()
+
+	got, err := s.GetScanResult(context.Background(), &rpcScanner.GetScanResultRequest{ScanId: started.ScanId})
+	require.NoError(t, err)
+	assert.Equal(t, &rpcScanner.ScanResponse{
+		Os:      &common.OS{Family: "alpine", Name: "3.11"},
+		Results: []*rpcScanner.Result{{Target: "alpine:3.11 (alpine 3.11)", Type: "alpine"}},
+	}, got)
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/zhanglimao/trivy/pkg/metrics"
+)
+
+const (
+	// DefaultScanWorkers is the number of scans processed at the same time by default
+	DefaultScanWorkers = 4
+
+	// DefaultScanQueueSize is the number of scans waiting for a worker by default
+	DefaultScanQueueSize = 100
+)
+
+var errQueueFull = twirp.NewError(twirp.ResourceExhausted, "too many scans in the queue, retry later")
+
+// scanQueue runs scans with a bounded number of workers so that bursts wait instead of exhausting the memory
+type scanQueue struct {
+	workers int
+	tasks   chan func()
+	once    sync.Once
+
+	mu       sync.Mutex
+	enqueued int64 // sequence number of the last queued task
+	dequeued int64 // sequence number of the last started task
+}
+
+func newScanQueue(workers, size int) *scanQueue {
+	return &scanQueue{
+		workers: workers,
+		tasks:   make(chan func(), size),
+	}
+}
+
+// submit queues the task and returns its sequence number, or false if the queue is full
+func (q *scanQueue) submit(task func()) (int64, bool) {
+	// Start the workers lazily so that unused servers don't hold goroutines
+	q.once.Do(func() {
+		for i := 0; i < q.workers; i++ {
+			go q.work()
+		}
+	})
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	select {
+	case q.tasks <- task:
+	default:
+		metrics.ScansRejected.Inc()
+		return 0, false
+	}
+	q.enqueued++
+	metrics.ScanQueueDepth.Inc()
+	return q.enqueued, true
+}
+
+// position returns the 1-based position of the task in the queue, i.e. 1 for the next task to start, or 0 once started
+func (q *scanQueue) position(seq int64) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if seq <= q.dequeued {
+		return 0
+	}
+	return int(seq - q.dequeued)
+}
+
+func (q *scanQueue) work() {
+	for task := range q.tasks {
+		q.mu.Lock()
+		q.dequeued++
+		q.mu.Unlock()
+
+		metrics.ScanQueueDepth.Dec()
+		metrics.ScansRunning.Inc()
+		task()
+		metrics.ScansRunning.Dec()
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/zhanglimao/trivy/pkg/scanner"
+	rpcScanner "github.com/zhanglimao/trivy/rpc/scanner"
+)
+
+func Test_scanQueue(t *testing.T) {
+	q := newScanQueue(1, 1)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	seq1, ok := q.submit(func() {
+		close(started)
+		<-release
+	})
+	require.True(t, ok)
+	<-started
+	assert.Equal(t, 0, q.position(seq1))
+
+	// The worker is busy
+	done := make(chan struct{})
+	seq2, ok := q.submit(func() { close(done) })
+	require.True(t, ok)
+	// The next task to start
+	assert.Equal(t, 1, q.position(seq2))
+
+	// The queue is full
+	_, ok = q.submit(func() {})
+	assert.False(t, ok)
+
+	close(release)
+	<-done
+	assert.Equal(t, 0, q.position(seq2))
+}
+
+func TestScanServer_queueFull(t *testing.T) {
+	s := NewScanServer(new(scanner.MockDriver))
+	s.queue = newScanQueue(1, 0)
+
+	// Occupy the only worker
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	for {
+		if _, ok := s.queue.submit(func() {
+			close(started)
+			<-release
+		}); ok {
+			break
+		}
+	}
+	<-started
+
+	in := &rpcScanner.ScanRequest{Target: "alpine:3.11", Options: &rpcScanner.ScanOptions{}}
+	_, err := s.Scan(context.Background(), in)
+	var twerr twirp.Error
+	require.ErrorAs(t, err, &twerr)
+	assert.Equal(t, twirp.ResourceExhausted, twerr.Code())
+
+	_, err = s.StartScan(context.Background(), in)
+	require.ErrorAs(t, err, &twerr)
+	assert.Equal(t, twirp.ResourceExhausted, twerr.Code())
+	assert.Empty(t, s.jobs.jobs)
+}
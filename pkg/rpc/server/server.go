@@ -2,14 +2,19 @@ package server
 
 import (
 	"context"
+	"sync"
 
 	google_protobuf "github.com/golang/protobuf/ptypes/empty"
+	"github.com/google/uuid"
 	"github.com/google/wire"
 	"github.com/samber/lo"
+	"github.com/twitchtv/twirp"
 	"golang.org/x/xerrors"
 
 	"github.com/zhanglimao/trivy/pkg/fanal/cache"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/metrics"
 	"github.com/zhanglimao/trivy/pkg/rpc"
 	"github.com/zhanglimao/trivy/pkg/scanner"
 	"github.com/zhanglimao/trivy/pkg/scanner/local"
@@ -28,21 +33,145 @@ var ScanSuperSet = wire.NewSet(
 // ScanServer implements the scanner
 type ScanServer struct {
 	localScanner scanner.Driver
+	jobs         *scanJobs
+	queue        *scanQueue
+
+	// inflight tracks the scans running in the background so that the DB is not swapped under them
+	inflight *sync.WaitGroup
 }
 
 // NewScanServer is the factory method for scanner
 func NewScanServer(s scanner.Driver) *ScanServer {
-	return &ScanServer{localScanner: s}
+	return &ScanServer{
+		localScanner: s,
+		jobs:         newScanJobs(),
+		queue:        newScanQueue(DefaultScanWorkers, DefaultScanQueueSize),
+		inflight:     &sync.WaitGroup{},
+	}
 }
 
 // Log and return an error
-func teeError(err error) error {
-	log.Logger.Errorf("%+v", err)
+func teeError(ctx context.Context, err error) error {
+	log.WithContext(ctx).Errorf("%+v", err)
 	return err
 }
 
+// scanContext attaches the scan ID sent by the client, or the given one, and the target to the context for logging
+func scanContext(ctx context.Context, scanID, target string) context.Context {
+	if log.ScanID(ctx) == "" {
+		ctx = log.ContextWith(ctx, log.KeyScanID, scanID)
+	}
+	return log.ContextWith(ctx, log.KeyArtifact, target)
+}
+
 // Scan scans and return response
 func (s *ScanServer) Scan(ctx context.Context, in *rpcScanner.ScanRequest) (*rpcScanner.ScanResponse, error) {
+	var results types.Results
+	var os ftypes.OS
+	var err error
+
+	ctx = scanContext(ctx, uuid.NewString(), in.Target)
+	done := make(chan struct{})
+	if _, ok := s.queue.submit(func() {
+		defer close(done)
+		if err = ctx.Err(); err != nil {
+			return // The client gave up while waiting
+		}
+		results, os, err = s.localScanner.Scan(ctx, in.Target, in.ArtifactId, in.BlobIds, scanOptions(in))
+	}); !ok {
+		return nil, errQueueFull
+	}
+	<-done
+
+	if err != nil {
+		return nil, teeError(ctx, xerrors.Errorf("failed scan, %s: %w", in.Target, err))
+	}
+
+	return rpc.ConvertToRPCScanResponse(results, os), nil
+}
+
+// StartScan starts a scan in the background so that the client can poll its progress
+func (s *ScanServer) StartScan(reqCtx context.Context, in *rpcScanner.ScanRequest) (*rpcScanner.StartScanResponse, error) {
+	// The request context is canceled as soon as this method returns.
+	// The scan is canceled instead when the client stops polling.
+	ctx, cancel := context.WithCancel(context.Background())
+	id, job := s.jobs.add(tenantName(reqCtx), cancel)
+
+	scanID := log.ScanID(reqCtx)
+	if scanID == "" {
+		scanID = id
+	}
+	ctx = scanContext(local.WithProgress(ctx, job), scanID, in.Target)
+
+	s.inflight.Add(1)
+	seq, ok := s.queue.submit(func() {
+		defer s.inflight.Done()
+		defer cancel()
+		job.start()
+
+		ctx, cancelTimeout := context.WithTimeout(ctx, scanJobTimeout)
+		defer cancelTimeout()
+		if err := ctx.Err(); err != nil {
+			job.finish(nil, teeError(ctx, xerrors.Errorf("scan canceled, %s: %w", in.Target, err)))
+			return // The client gave up while waiting
+		}
+		results, os, err := s.localScanner.Scan(ctx, in.Target, in.ArtifactId, in.BlobIds, scanOptions(in))
+		if err != nil {
+			job.finish(nil, teeError(ctx, xerrors.Errorf("failed scan, %s: %w", in.Target, err)))
+			return
+		}
+		job.finish(rpc.ConvertToRPCScanResponse(results, os), nil)
+	})
+	if !ok {
+		s.inflight.Done()
+		cancel()
+		s.jobs.remove(id)
+		return nil, errQueueFull
+	}
+	job.queued(seq)
+
+	return &rpcScanner.StartScanResponse{ScanId: id}, nil
+}
+
+// GetScanProgress returns the progress of a scan started by StartScan
+func (s *ScanServer) GetScanProgress(ctx context.Context, in *rpcScanner.GetScanProgressRequest) (*rpcScanner.GetScanProgressResponse, error) {
+	job, ok := s.jobs.get(in.ScanId, tenantName(ctx))
+	if !ok {
+		return nil, twirp.NotFoundError("unknown scan ID: " + in.ScanId)
+	}
+
+	res := job.progress(int(in.ResultsOffset))
+	if res.Stage == stageQueued {
+		res.QueuePosition = int32(s.queue.position(job.sequence()))
+	}
+	if res.Done {
+		// The client has got the results
+		s.jobs.remove(in.ScanId)
+	}
+	return res, nil
+}
+
+// GetScanResult returns the results of a scan started by StartScan once it is done
+func (s *ScanServer) GetScanResult(ctx context.Context, in *rpcScanner.GetScanResultRequest) (*rpcScanner.ScanResponse, error) {
+	job, ok := s.jobs.get(in.ScanId, tenantName(ctx))
+	if !ok {
+		return nil, twirp.NotFoundError("unknown scan ID: " + in.ScanId)
+	}
+
+	res, err, done := job.result()
+	if !done {
+		return nil, twirp.NewError(twirp.FailedPrecondition, "the scan is not done yet")
+	}
+
+	// The client has got the results
+	s.jobs.remove(in.ScanId)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func scanOptions(in *rpcScanner.ScanRequest) types.ScanOptions {
 	scanners := lo.Map(in.Options.Scanners, func(s string, index int) types.Scanner {
 		return types.Scanner(s)
 	})
@@ -51,19 +180,14 @@ func (s *ScanServer) Scan(ctx context.Context, in *rpcScanner.ScanRequest) (*rpc
 		Scanners:        scanners,
 		ListAllPackages: in.Options.ListAllPackages,
 		Packages:        in.Packages,
+		ArtifactType:    ftypes.ArtifactType(in.Options.ArtifactType),
 	}
 
 	if in.Os != nil {
 		options.OsFamily = in.Os.Family
 		options.OsName = in.Os.Name
 	}
-
-	results, os, err := s.localScanner.Scan(ctx, in.Target, in.ArtifactId, in.BlobIds, options)
-	if err != nil {
-		return nil, teeError(xerrors.Errorf("failed scan, %s: %w", in.Target, err))
-	}
-
-	return rpc.ConvertToRPCScanResponse(results, os), nil
+	return options
 }
 
 // CacheServer implements the cache
@@ -77,35 +201,36 @@ func NewCacheServer(c cache.Cache) *CacheServer {
 }
 
 // PutArtifact puts the artifacts in cache
-func (s *CacheServer) PutArtifact(_ context.Context, in *rpcCache.PutArtifactRequest) (*google_protobuf.Empty, error) {
+func (s *CacheServer) PutArtifact(ctx context.Context, in *rpcCache.PutArtifactRequest) (*google_protobuf.Empty, error) {
 	if in.ArtifactInfo == nil {
-		return nil, teeError(xerrors.Errorf("empty image info"))
+		return nil, teeError(ctx, xerrors.Errorf("empty image info"))
 	}
 	imageInfo := rpc.ConvertFromRPCPutArtifactRequest(in)
 	if err := s.cache.PutArtifact(in.ArtifactId, imageInfo); err != nil {
-		return nil, teeError(xerrors.Errorf("unable to store image info in cache: %w", err))
+		return nil, teeError(ctx, xerrors.Errorf("unable to store image info in cache: %w", err))
 	}
 	return &google_protobuf.Empty{}, nil
 }
 
 // PutBlob puts the blobs in cache
-func (s *CacheServer) PutBlob(_ context.Context, in *rpcCache.PutBlobRequest) (*google_protobuf.Empty, error) {
+func (s *CacheServer) PutBlob(ctx context.Context, in *rpcCache.PutBlobRequest) (*google_protobuf.Empty, error) {
 	if in.BlobInfo == nil {
-		return nil, teeError(xerrors.Errorf("empty layer info"))
+		return nil, teeError(ctx, xerrors.Errorf("empty layer info"))
 	}
 	layerInfo := rpc.ConvertFromRPCPutBlobRequest(in)
 	if err := s.cache.PutBlob(in.DiffId, layerInfo); err != nil {
-		return nil, teeError(xerrors.Errorf("unable to store layer info in cache: %w", err))
+		return nil, teeError(ctx, xerrors.Errorf("unable to store layer info in cache: %w", err))
 	}
 	return &google_protobuf.Empty{}, nil
 }
 
 // MissingBlobs returns missing blobs from cache
-func (s *CacheServer) MissingBlobs(_ context.Context, in *rpcCache.MissingBlobsRequest) (*rpcCache.MissingBlobsResponse, error) {
+func (s *CacheServer) MissingBlobs(ctx context.Context, in *rpcCache.MissingBlobsRequest) (*rpcCache.MissingBlobsResponse, error) {
 	missingArtifact, blobIDs, err := s.cache.MissingBlobs(in.ArtifactId, in.BlobIds)
 	if err != nil {
-		return nil, teeError(xerrors.Errorf("failed to get missing blobs: %w", err))
+		return nil, teeError(ctx, xerrors.Errorf("failed to get missing blobs: %w", err))
 	}
+	metrics.ObserveCacheLookup(len(in.BlobIds), len(blobIDs))
 	return &rpcCache.MissingBlobsResponse{
 		MissingArtifact: missingArtifact,
 		MissingBlobIds:  blobIDs,
@@ -113,10 +238,10 @@ func (s *CacheServer) MissingBlobs(_ context.Context, in *rpcCache.MissingBlobsR
 }
 
 // DeleteBlobs removes blobs by IDs
-func (s *CacheServer) DeleteBlobs(_ context.Context, in *rpcCache.DeleteBlobsRequest) (*google_protobuf.Empty, error) {
+func (s *CacheServer) DeleteBlobs(ctx context.Context, in *rpcCache.DeleteBlobsRequest) (*google_protobuf.Empty, error) {
 	blobIDs := rpc.ConvertFromDeleteBlobsRequest(in)
 	if err := s.cache.DeleteBlobs(blobIDs); err != nil {
-		return nil, teeError(xerrors.Errorf("failed to remove a blobs: %w", err))
+		return nil, teeError(ctx, xerrors.Errorf("failed to remove a blobs: %w", err))
 	}
 	return &google_protobuf.Empty{}, nil
 }
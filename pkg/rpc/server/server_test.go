@@ -2,7 +2,10 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -17,6 +20,7 @@ import (
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	"github.com/aquasecurity/trivy-db/pkg/utils"
 	"github.com/aquasecurity/trivy-db/pkg/vulnsrc/vulnerability"
+	tcache "github.com/zhanglimao/trivy/pkg/cache"
 	"github.com/zhanglimao/trivy/pkg/fanal/cache"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/scanner"
@@ -579,3 +583,80 @@ func TestCacheServer_MissingBlobs(t *testing.T) {
 		})
 	}
 }
+
+func TestCacheServer_Reanalyze(t *testing.T) {
+	const (
+		artifactID = "sha256:e7d92cdc71feacf90708cb59182d0df1b911f8ae022d29e8e95d75ca6a99776a"
+		blobID     = "sha256:932da51564135c98a49a34a193d6cd363d8fa4184d957fde16c9d8527b3f3b02"
+	)
+	tests := []struct {
+		name        string
+		warnings    []ftypes.Warning
+		wantMissing []string
+	}{
+		{
+			name: "complete blob",
+		},
+		{
+			name: "analyzer timed out",
+			warnings: []ftypes.Warning{
+				{
+					Analyzer: "jar",
+					FilePath: "app/huge.jar",
+					Message:  "analysis timed out after 30s",
+				},
+			},
+			wantMissing: []string{blobID},
+		},
+		{
+			name: "analyzer failed in keep-going mode",
+			warnings: []ftypes.Warning{
+				{
+					Analyzer: "jar",
+					FilePath: "app/broken.jar",
+					Message:  "zip: not a valid zip file",
+				},
+			},
+			wantMissing: []string{blobID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsCache, err := cache.NewFSCache(t.TempDir())
+			require.NoError(t, err)
+			defer fsCache.Close()
+
+			mux := http.NewServeMux()
+			mux.Handle(rpcCache.CachePathPrefix, rpcCache.NewCacheServer(NewCacheServer(fsCache), nil))
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+
+			c := tcache.NewRemoteCache(ts.URL, nil, &tls.Config{})
+			blobInfo := ftypes.BlobInfo{
+				SchemaVersion: ftypes.BlobJSONSchemaVersion,
+				OS: ftypes.OS{
+					Family: "alpine",
+					Name:   "3.11",
+				},
+				Warnings: tt.warnings,
+			}
+			require.NoError(t, c.PutBlob(blobID, blobInfo))
+
+			got, err := fsCache.GetBlob(blobID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.warnings, got.Warnings)
+
+			_, missingBlobIDs, err := c.MissingBlobs(artifactID, []string{blobID})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMissing, missingBlobIDs)
+
+			// The incomplete blob is analyzed again and replaced in the server cache
+			blobInfo.Warnings = nil
+			require.NoError(t, c.PutBlob(blobID, blobInfo))
+
+			_, missingBlobIDs, err = c.MissingBlobs(artifactID, []string{blobID})
+			require.NoError(t, err)
+			assert.Empty(t, missingBlobIDs)
+		})
+	}
+}
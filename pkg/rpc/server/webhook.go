@@ -0,0 +1,218 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy-kubernetes/pkg/artifacts"
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
+	aimage "github.com/zhanglimao/trivy/pkg/fanal/artifact/image"
+	"github.com/zhanglimao/trivy/pkg/fanal/cache"
+	"github.com/zhanglimao/trivy/pkg/fanal/image"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/scanner"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// WebhookPath is the path of the Kubernetes validating admission webhook
+const WebhookPath = "/validate"
+
+// maxListedViolations limits the vulnerabilities listed in a rejection message
+const maxListedViolations = 5
+
+// WebhookPolicy decides which vulnerabilities make the admission webhook reject a workload
+type WebhookPolicy struct {
+	// Severities rejects the vulnerabilities with one of these severities
+	Severities []dbTypes.Severity
+	// KEV rejects the vulnerabilities listed in the known exploited vulnerabilities catalog, regardless of the severity
+	KEV map[string]struct{}
+}
+
+// LoadKEV loads the vulnerability IDs of a catalog in the CISA Known Exploited Vulnerabilities JSON format
+func LoadKEV(path string) (map[string]struct{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	var catalog struct {
+		Vulnerabilities []struct {
+			CveID string `json:"cveID"`
+		} `json:"vulnerabilities"`
+	}
+	if err = json.NewDecoder(f).Decode(&catalog); err != nil {
+		return nil, xerrors.Errorf("KEV catalog decode error: %w", err)
+	}
+
+	kev := make(map[string]struct{}, len(catalog.Vulnerabilities))
+	for _, v := range catalog.Vulnerabilities {
+		kev[v.CveID] = struct{}{}
+	}
+	return kev, nil
+}
+
+// violations returns the IDs of the vulnerabilities rejected by the policy
+func (p WebhookPolicy) violations(report types.Report) []string {
+	var ids []string
+	for _, result := range report.Results {
+		for _, vuln := range result.Vulnerabilities {
+			severity, _ := dbTypes.NewSeverity(vuln.Severity)
+			_, exploited := p.KEV[vuln.VulnerabilityID]
+			if (exploited || slices.Contains(p.Severities, severity)) && !slices.Contains(ids, vuln.VulnerabilityID) {
+				ids = append(ids, vuln.VulnerabilityID)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+type imageScanner func(ctx context.Context, imageName string) (types.Report, error)
+
+// newImageScanner returns a scanner pulling images from their registries.
+// The analysis of layers already in the server cache is reused, so admitting the same image again is cheap.
+func newImageScanner(c cache.Cache, driver scanner.Driver, opt ftypes.RegistryOptions) imageScanner {
+	return func(ctx context.Context, imageName string) (types.Report, error) {
+		imageOpt := ftypes.ImageOptions{
+			RegistryOptions: opt,
+			ImageSources:    ftypes.ImageSources{ftypes.RemoteImageSource},
+		}
+		img, cleanup, err := image.NewContainerImage(ctx, imageName, imageOpt)
+		if err != nil {
+			return types.Report{}, xerrors.Errorf("unable to initialize the image: %w", err)
+		}
+		defer cleanup()
+
+		disabled := append([]analyzer.Type{
+			analyzer.TypeSecret,
+			analyzer.TypeLicenseFile,
+			analyzer.TypeApkCommand,
+			analyzer.TypeHistoryDockerfile,
+			analyzer.TypeImageConfigCheck,
+			analyzer.TypeExecutable,
+			analyzer.TypeLayerSize,
+			analyzer.TypeIntegrity,
+			analyzer.TypePermission,
+			// the server doesn't load the Java index DB
+			analyzer.TypeJar,
+		}, analyzer.TypeConfigFiles...)
+		art, err := aimage.NewArtifact(img, c, artifact.Option{
+			DisabledAnalyzers: disabled,
+			NoProgress:        true,
+			ImageOption:       imageOpt,
+		})
+		if err != nil {
+			return types.Report{}, xerrors.Errorf("unable to initialize the image artifact: %w", err)
+		}
+
+		return scanner.NewScanner(driver, art).ScanArtifact(ctx, types.ScanOptions{
+			VulnType: []string{types.VulnTypeOS, types.VulnTypeLibrary},
+			Scanners: types.Scanners{types.VulnerabilityScanner},
+		})
+	}
+}
+
+// webhookHandler serves the AdmissionReview requests of a Kubernetes ValidatingWebhookConfiguration
+type webhookHandler struct {
+	scan   imageScanner
+	policy WebhookPolicy
+}
+
+func (h webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var review admissionv1.AdmissionReview
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		http.Error(w, fmt.Sprintf("invalid admission review: %s", err), http.StatusBadRequest)
+		return
+	} else if review.Request == nil {
+		http.Error(w, "admission review without request", http.StatusBadRequest)
+		return
+	}
+
+	response := h.review(r.Context(), review.Request)
+	response.UID = review.Request.UID
+	review.Request = nil
+	review.Response = response
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.Logger.Errorf("Admission review encode error: %s", err)
+	}
+}
+
+// review scans the images of the workload and rejects it when any of them violates the policy.
+// Images which can't be scanned are rejected too, the webhook failure policy decides only on timeouts.
+func (h webhookHandler) review(ctx context.Context, req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	// Only created and updated objects run images, e.g. DELETE and CONNECT have no object to scan
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+
+	var obj unstructured.Unstructured
+	if err := obj.UnmarshalJSON(req.Object.Raw); err != nil {
+		return deny(fmt.Sprintf("unable to decode the object: %s", err))
+	}
+
+	workload, err := artifacts.FromResource(obj)
+	if err != nil {
+		return deny(fmt.Sprintf("unable to extract the images: %s", err))
+	}
+
+	var messages []string
+	for _, img := range workload.Images {
+		report, err := h.scan(ctx, img)
+		if err != nil {
+			log.Logger.Errorf("Admission scan error (%s): %s", img, err)
+			messages = append(messages, fmt.Sprintf("%s: scan failed: %s", img, err))
+			continue
+		}
+
+		ids := h.policy.violations(report)
+		if len(ids) == 0 {
+			continue
+		}
+		listed := ids
+		if len(listed) > maxListedViolations {
+			listed = append(listed[:maxListedViolations:maxListedViolations], "...")
+		}
+		messages = append(messages, fmt.Sprintf("%s: %d vulnerabilities violate the policy (%s)",
+			img, len(ids), strings.Join(listed, ", ")))
+	}
+
+	if len(messages) > 0 {
+		log.Logger.Infof("Rejected %s %s/%s", req.Kind.Kind, req.Namespace, req.Name)
+		return deny(strings.Join(messages, "; "))
+	}
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+func deny(message string) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: message,
+			Reason:  metav1.StatusReasonForbidden,
+			Code:    http.StatusForbidden,
+		},
+	}
+}
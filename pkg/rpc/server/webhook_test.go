@@ -0,0 +1,182 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func vulnReport(vulns ...types.DetectedVulnerability) types.Report {
+	return types.Report{
+		Results: types.Results{
+			{
+				Target:          "alpine:3.17 (alpine 3.17.3)",
+				Vulnerabilities: vulns,
+			},
+		},
+	}
+}
+
+func Test_webhookHandler(t *testing.T) {
+	reports := map[string]types.Report{
+		"nginx:1.23": vulnReport(types.DetectedVulnerability{
+			VulnerabilityID: "CVE-2023-0001",
+			Vulnerability:   dbTypes.Vulnerability{Severity: "HIGH"},
+		}),
+		"nginx:1.22": vulnReport(types.DetectedVulnerability{
+			VulnerabilityID: "CVE-2023-0002",
+			Vulnerability:   dbTypes.Vulnerability{Severity: "CRITICAL"},
+		}),
+		"log4j-app:1.0": vulnReport(types.DetectedVulnerability{
+			VulnerabilityID: "CVE-2021-44228",
+			Vulnerability:   dbTypes.Vulnerability{Severity: "MEDIUM"},
+		}),
+	}
+	scan := func(_ context.Context, imageName string) (types.Report, error) {
+		report, ok := reports[imageName]
+		if !ok {
+			return types.Report{}, errors.New("not found")
+		}
+		return report, nil
+	}
+
+	pod := func(images ...string) map[string]interface{} {
+		var containers []interface{}
+		for _, img := range images {
+			containers = append(containers, map[string]interface{}{"name": "app", "image": img})
+		}
+		return map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata":   map[string]interface{}{"name": "app", "namespace": "default"},
+			"spec":       map[string]interface{}{"containers": containers},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		operation   admissionv1.Operation
+		object      map[string]interface{}
+		wantAllowed bool
+		wantMessage string
+	}{
+		{
+			name:        "allowed",
+			object:      pod("nginx:1.23"),
+			wantAllowed: true,
+		},
+		{
+			name:        "critical vulnerability",
+			object:      pod("nginx:1.23", "nginx:1.22"),
+			wantMessage: "nginx:1.22: 1 vulnerabilities violate the policy (CVE-2023-0002)",
+		},
+		{
+			name:        "known exploited vulnerability",
+			object:      pod("log4j-app:1.0"),
+			wantMessage: "log4j-app:1.0: 1 vulnerabilities violate the policy (CVE-2021-44228)",
+		},
+		{
+			name: "deployment",
+			object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]interface{}{"name": "app", "namespace": "default"},
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{"name": "app", "image": "nginx:1.22"},
+							},
+						},
+					},
+				},
+			},
+			wantMessage: "nginx:1.22: 1 vulnerabilities violate the policy (CVE-2023-0002)",
+		},
+		{
+			name:        "scan error",
+			object:      pod("unknown:latest"),
+			wantMessage: "unknown:latest: scan failed: not found",
+		},
+		{
+			name:        "delete",
+			operation:   admissionv1.Delete,
+			wantAllowed: true,
+		},
+	}
+
+	kev, err := LoadKEV("testdata/kev/catalog.json")
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(webhookHandler{
+		scan: scan,
+		policy: WebhookPolicy{
+			Severities: []dbTypes.Severity{dbTypes.SeverityCritical},
+			KEV:        kev,
+		},
+	})
+	defer ts.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operation := tt.operation
+			if operation == "" {
+				operation = admissionv1.Create
+			}
+			var raw []byte
+			if tt.object != nil {
+				var err error
+				raw, err = json.Marshal(tt.object)
+				require.NoError(t, err)
+			}
+
+			review := admissionv1.AdmissionReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+				Request: &admissionv1.AdmissionRequest{
+					UID:       "705ab4f5-6393-11e8-b7cc-42010a800002",
+					Operation: operation,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			}
+			body, err := json.Marshal(review)
+			require.NoError(t, err)
+
+			resp, err := http.Post(ts.URL, "application/json", bytes.NewReader(body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var got admissionv1.AdmissionReview
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+			require.NotNil(t, got.Response)
+			assert.Nil(t, got.Request)
+			assert.Equal(t, "AdmissionReview", got.Kind)
+			assert.Equal(t, review.Request.UID, got.Response.UID)
+			assert.Equal(t, tt.wantAllowed, got.Response.Allowed)
+			if tt.wantMessage != "" {
+				require.NotNil(t, got.Response.Result)
+				assert.Equal(t, tt.wantMessage, got.Response.Result.Message)
+			}
+		})
+	}
+
+	t.Run("sad path: invalid review", func(t *testing.T) {
+		resp, err := http.Post(ts.URL, "application/json", bytes.NewReader([]byte("{")))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
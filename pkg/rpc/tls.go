@@ -0,0 +1,21 @@
+package rpc
+
+import (
+	"crypto/x509"
+	"os"
+
+	"golang.org/x/xerrors"
+)
+
+// LoadCertPool loads PEM certificates into a pool
+func LoadCertPool(caFile string) (*x509.CertPool, error) {
+	b, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to read the CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, xerrors.Errorf("no certificate found in %s", caFile)
+	}
+	return pool, nil
+}
@@ -60,6 +60,8 @@ type Component struct {
 	Supplier   string
 	Properties map[string]string
 
+	ExternalReferences []cdx.ExternalReference
+
 	Components      []*Component
 	Vulnerabilities []types.DetectedVulnerability
 }
@@ -127,6 +129,9 @@ func (c *CycloneDX) MarshalComponent(component *Component, components map[string
 		Licenses:   c.Licenses(component.Licenses),
 		Properties: lo.ToPtr(c.Properties(component.Properties)),
 	}
+	if len(component.ExternalReferences) > 0 {
+		cdxComponent.ExternalReferences = lo.ToPtr(component.ExternalReferences)
+	}
 	components[cdxComponent.BOMRef] = cdxComponent
 
 	for _, v := range component.Vulnerabilities {
@@ -231,7 +236,10 @@ func (c *CycloneDX) Vulnerabilities(uniq map[string]*cdx.Vulnerability) *[]cdx.V
 		return *value
 	})
 	sort.Slice(vulns, func(i, j int) bool {
-		return vulns[i].BOMRef < vulns[j].BOMRef
+		if vulns[i].BOMRef != vulns[j].BOMRef {
+			return vulns[i].BOMRef < vulns[j].BOMRef
+		}
+		return vulns[i].ID < vulns[j].ID
 	})
 	return &vulns
 }
@@ -86,7 +86,8 @@ func (e *Marshaler) MarshalReport(r types.Report) (*core.Component, error) {
 
 func (e *Marshaler) marshalResult(metadata types.Metadata, result types.Result) ([]*core.Component, error) {
 	if result.Type == ftypes.NodePkg || result.Type == ftypes.PythonPkg ||
-		result.Type == ftypes.GemSpec || result.Type == ftypes.Jar || result.Type == ftypes.CondaPkg {
+		result.Type == ftypes.GemSpec || result.Type == ftypes.Jar || result.Type == ftypes.CondaPkg ||
+		result.Type == ftypes.Snap || result.Type == ftypes.Flatpak || result.Type == ftypes.Homebrew {
 		// If a package is language-specific package that isn't associated with a lock file,
 		// it will be a dependency of a component under "metadata".
 		// e.g.
@@ -236,6 +237,8 @@ func (e *Marshaler) rootComponent(r types.Report) (*core.Component, error) {
 		root.Type = cdx.ComponentTypeContainer
 	case ftypes.ArtifactFilesystem, ftypes.ArtifactRemoteRepository:
 		root.Type = cdx.ComponentTypeApplication
+	case ftypes.ArtifactPackageURL:
+		root.Type = cdx.ComponentTypeLibrary
 	}
 
 	if r.Metadata.Size != 0 {
@@ -252,11 +255,39 @@ func (e *Marshaler) rootComponent(r types.Report) (*core.Component, error) {
 		props[PropertyRepoTag] = strings.Join(r.Metadata.RepoTags, ",")
 	}
 
+	// Image labels and annotations to attribute the image to its source repository and build
+	annotations := r.Metadata.OCIImageAnnotations()
+	for k, v := range annotations {
+		props[k] = v
+	}
+	root.ExternalReferences = ociExternalReferences(annotations)
+
 	root.Properties = filterProperties(props)
 
 	return root, nil
 }
 
+// ociExternalReferences converts the OCI annotations pointing to URLs into external references
+func ociExternalReferences(annotations map[string]string) []cdx.ExternalReference {
+	var refs []cdx.ExternalReference
+	for _, ref := range []struct {
+		key     string
+		refType cdx.ExternalReferenceType
+	}{
+		{key: types.OCIImageAnnotationPrefix + "source", refType: cdx.ERTypeVCS},
+		{key: types.OCIImageAnnotationPrefix + "url", refType: cdx.ERTypeWebsite},
+		{key: types.OCIImageAnnotationPrefix + "documentation", refType: cdx.ERTypeDocumentation},
+	} {
+		if u, ok := annotations[ref.key]; ok {
+			refs = append(refs, cdx.ExternalReference{
+				URL:  u,
+				Type: ref.refType,
+			})
+		}
+	}
+	return refs
+}
+
 func (e *Marshaler) resultComponent(r types.Result, osFound *ftypes.OS) *core.Component {
 	component := &core.Component{
 		Name: r.Target,
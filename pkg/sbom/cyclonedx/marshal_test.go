@@ -1223,6 +1223,93 @@ func TestMarshaler_Marshal(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "happy path with image labels and annotations",
+			inputReport: types.Report{
+				SchemaVersion: report.SchemaVersion,
+				ArtifactName:  "ghcr.io/example/app:1.0.0",
+				ArtifactType:  ftypes.ArtifactContainerImage,
+				Metadata: types.Metadata{
+					ImageID: "sha256:5d0da3dc976460b72c77d94c8a1ad043720b0416bfc16c52c45d4847e53fadb6",
+					ImageAnnotations: map[string]string{
+						"org.opencontainers.image.source":   "https://github.com/example/app",
+						"org.opencontainers.image.revision": "e4a5f3c2b1d0",
+					},
+					ImageConfig: v1.ConfigFile{
+						Config: v1.Config{
+							Labels: map[string]string{
+								"org.opencontainers.image.source": "https://github.com/example/old",
+								"org.opencontainers.image.url":    "https://example.com",
+								"maintainer":                      "example",
+							},
+						},
+					},
+				},
+				Results: types.Results{},
+			},
+			want: &cdx.BOM{
+				XMLNS:        "http://cyclonedx.org/schema/bom/1.4",
+				BOMFormat:    "CycloneDX",
+				SpecVersion:  cdx.SpecVersion1_4,
+				SerialNumber: "urn:uuid:3ff14136-e09f-4df9-80ea-000000000001",
+				Version:      1,
+				Metadata: &cdx.Metadata{
+					Timestamp: "2021-08-25T12:20:30+00:00",
+					Tools: &[]cdx.Tool{
+						{
+							Name:    "trivy",
+							Vendor:  "aquasecurity",
+							Version: "dev",
+						},
+					},
+					Component: &cdx.Component{
+						Type:   cdx.ComponentTypeContainer,
+						Name:   "ghcr.io/example/app:1.0.0",
+						BOMRef: "3ff14136-e09f-4df9-80ea-000000000002",
+						ExternalReferences: &[]cdx.ExternalReference{
+							{
+								URL:  "https://github.com/example/app",
+								Type: cdx.ERTypeVCS,
+							},
+							{
+								URL:  "https://example.com",
+								Type: cdx.ERTypeWebsite,
+							},
+						},
+						Properties: &[]cdx.Property{
+							{
+								Name:  "aquasecurity:trivy:ImageID",
+								Value: "sha256:5d0da3dc976460b72c77d94c8a1ad043720b0416bfc16c52c45d4847e53fadb6",
+							},
+							{
+								Name:  "aquasecurity:trivy:SchemaVersion",
+								Value: "2",
+							},
+							{
+								Name:  "aquasecurity:trivy:org.opencontainers.image.revision",
+								Value: "e4a5f3c2b1d0",
+							},
+							{
+								Name:  "aquasecurity:trivy:org.opencontainers.image.source",
+								Value: "https://github.com/example/app",
+							},
+							{
+								Name:  "aquasecurity:trivy:org.opencontainers.image.url",
+								Value: "https://example.com",
+							},
+						},
+					},
+				},
+				Components:      lo.ToPtr([]cdx.Component{}),
+				Vulnerabilities: &[]cdx.Vulnerability{},
+				Dependencies: &[]cdx.Dependency{
+					{
+						Ref:          "3ff14136-e09f-4df9-80ea-000000000002",
+						Dependencies: lo.ToPtr([]string(nil)),
+					},
+				},
+			},
+		},
 		{
 			name: "happy path empty",
 			inputReport: types.Report{
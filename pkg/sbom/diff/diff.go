@@ -0,0 +1,241 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/aquasecurity/go-version/pkg/version"
+	apkver "github.com/knqyf263/go-apk-version"
+	debver "github.com/knqyf263/go-deb-version"
+	rpmver "github.com/knqyf263/go-rpm-version"
+	packageurl "github.com/package-url/packageurl-go"
+	"github.com/samber/lo"
+	"golang.org/x/exp/maps"
+
+	"github.com/zhanglimao/trivy/pkg/purl"
+)
+
+// Change represents the direction of a version change
+type Change string
+
+const (
+	ChangeUpgrade   Change = "upgrade"
+	ChangeDowngrade Change = "downgrade"
+)
+
+// Component represents a component added to or removed from the SBOM
+type Component struct {
+	Type    cdx.ComponentType `json:",omitempty"`
+	Group   string            `json:",omitempty"`
+	Name    string
+	Version string `json:",omitempty"`
+	PURL    string `json:",omitempty"`
+}
+
+// ChangedComponent represents a component whose version changed between the SBOMs
+type ChangedComponent struct {
+	Type       cdx.ComponentType `json:",omitempty"`
+	Group      string            `json:",omitempty"`
+	Name       string
+	OldVersion string
+	NewVersion string
+
+	// Change is empty if the versions are not comparable
+	Change  Change `json:",omitempty"`
+	OldPURL string `json:",omitempty"`
+	NewPURL string `json:",omitempty"`
+}
+
+// Diff represents the component changes from the old SBOM to the new SBOM
+type Diff struct {
+	Added   []Component        `json:",omitempty"`
+	Removed []Component        `json:",omitempty"`
+	Changed []ChangedComponent `json:",omitempty"`
+}
+
+// Empty returns true if the SBOMs have the same components
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Compare compares the components of the SBOMs including nested components.
+// Components are identified by the package URL without the version and qualifiers,
+// or by the type, group and name if they don't have a package URL.
+// When a component has several versions, e.g. in different applications, versions in both SBOMs are unchanged,
+// and the rest are paired in ascending order as changed. Unpaired versions are added or removed.
+func Compare(oldBOM, newBOM *cdx.BOM) Diff {
+	oldComponents, newComponents := components(oldBOM), components(newBOM)
+
+	var diff Diff
+	keys := lo.Uniq(append(maps.Keys(oldComponents), maps.Keys(newComponents)...))
+	for _, key := range keys {
+		olds, news := oldComponents[key], newComponents[key]
+
+		// Versions in both SBOMs are unchanged
+		oldVers := lo.Filter(maps.Keys(olds), func(v string, _ int) bool { _, ok := news[v]; return !ok })
+		newVers := lo.Filter(maps.Keys(news), func(v string, _ int) bool { _, ok := olds[v]; return !ok })
+
+		purlType := key.purlType
+		sortVersions(purlType, oldVers)
+		sortVersions(purlType, newVers)
+
+		n := lo.Min([]int{len(oldVers), len(newVers)})
+		for i := 0; i < n; i++ {
+			o, c := olds[oldVers[i]], news[newVers[i]]
+			diff.Changed = append(diff.Changed, ChangedComponent{
+				Type:       c.Type,
+				Group:      c.Group,
+				Name:       c.Name,
+				OldVersion: o.Version,
+				NewVersion: c.Version,
+				Change:     change(purlType, o.Version, c.Version),
+				OldPURL:    o.PURL,
+				NewPURL:    c.PURL,
+			})
+		}
+		for _, v := range oldVers[n:] {
+			diff.Removed = append(diff.Removed, olds[v])
+		}
+		for _, v := range newVers[n:] {
+			diff.Added = append(diff.Added, news[v])
+		}
+	}
+
+	sortComponents(diff.Added)
+	sortComponents(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		a, b := diff.Changed[i], diff.Changed[j]
+		return less(a.Group, a.Name, a.OldVersion, b.Group, b.Name, b.OldVersion)
+	})
+	return diff
+}
+
+// componentKey identifies the same component across versions
+type componentKey struct {
+	id       string
+	purlType string
+}
+
+// components returns the components keyed by the identity and the version
+func components(bom *cdx.BOM) map[componentKey]map[string]Component {
+	result := make(map[componentKey]map[string]Component)
+	var walk func(cs []cdx.Component)
+	walk = func(cs []cdx.Component) {
+		for _, c := range cs {
+			walk(lo.FromPtr(c.Components))
+			if c.Name == "" {
+				continue
+			}
+
+			key, ver := identify(c)
+			if _, ok := result[key]; !ok {
+				result[key] = make(map[string]Component)
+			}
+			if _, ok := result[key][ver]; ok {
+				continue
+			}
+			result[key][ver] = Component{
+				Type:    c.Type,
+				Group:   c.Group,
+				Name:    c.Name,
+				Version: ver,
+				PURL:    c.PackageURL,
+			}
+		}
+	}
+	if bom != nil {
+		walk(lo.FromPtr(bom.Components))
+	}
+	return result
+}
+
+func identify(c cdx.Component) (componentKey, string) {
+	p, err := packageurl.FromString(c.PackageURL)
+	if c.PackageURL == "" || err != nil {
+		return componentKey{id: fmt.Sprintf("%s:%s/%s", c.Type, c.Group, c.Name)}, c.Version
+	}
+
+	// The version of components includes the epoch of OS packages, while the package URL has it as a qualifier
+	ver := lo.Ternary(c.Version != "", c.Version, p.Version)
+	p.Version = ""
+	p.Qualifiers = nil
+	return componentKey{id: p.ToString(), purlType: p.Type}, ver
+}
+
+// compare compares the versions with the versioning of the package type
+func compare(purlType, v1, v2 string) (int, bool) {
+	switch purlType {
+	case packageurl.TypeDebian:
+		a, err := debver.NewVersion(v1)
+		if err != nil {
+			return 0, false
+		}
+		b, err := debver.NewVersion(v2)
+		if err != nil {
+			return 0, false
+		}
+		return a.Compare(b), true
+	case packageurl.TypeRPM:
+		return rpmver.NewVersion(v1).Compare(rpmver.NewVersion(v2)), true
+	case purl.TypeAPK:
+		a, err := apkver.NewVersion(v1)
+		if err != nil {
+			return 0, false
+		}
+		b, err := apkver.NewVersion(v2)
+		if err != nil {
+			return 0, false
+		}
+		return a.Compare(b), true
+	}
+
+	a, err := version.Parse(v1)
+	if err != nil {
+		return 0, false
+	}
+	b, err := version.Parse(v2)
+	if err != nil {
+		return 0, false
+	}
+	return a.Compare(b), true
+}
+
+func change(purlType, oldVer, newVer string) Change {
+	c, ok := compare(purlType, oldVer, newVer)
+	switch {
+	case !ok:
+		return ""
+	case c < 0:
+		return ChangeUpgrade
+	case c > 0:
+		return ChangeDowngrade
+	}
+	return ""
+}
+
+// sortVersions sorts the versions in ascending order. Versions not comparable are sorted as strings.
+func sortVersions(purlType string, vers []string) {
+	sort.Slice(vers, func(i, j int) bool {
+		if c, ok := compare(purlType, vers[i], vers[j]); ok && c != 0 {
+			return c < 0
+		}
+		return vers[i] < vers[j]
+	})
+}
+
+func sortComponents(cs []Component) {
+	sort.Slice(cs, func(i, j int) bool {
+		return less(cs[i].Group, cs[i].Name, cs[i].Version, cs[j].Group, cs[j].Name, cs[j].Version)
+	})
+}
+
+func less(group1, name1, ver1, group2, name2, ver2 string) bool {
+	if group1 != group2 {
+		return group1 < group2
+	}
+	if name1 != name2 {
+		return name1 < name2
+	}
+	return ver1 < ver2
+}
@@ -0,0 +1,104 @@
+package diff_test
+
+import (
+	"os"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/sbom/cyclonedx"
+	"github.com/zhanglimao/trivy/pkg/sbom/diff"
+)
+
+func decode(t *testing.T, filePath string) *cdx.BOM {
+	f, err := os.Open(filePath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	bom, err := cyclonedx.DecodeJSON(f)
+	require.NoError(t, err)
+	return bom
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldFile string
+		newFile string
+		want    diff.Diff
+	}{
+		{
+			name:    "happy path",
+			oldFile: "testdata/old.cdx.json",
+			newFile: "testdata/new.cdx.json",
+			want: diff.Diff{
+				Added: []diff.Component{
+					{
+						Type:    cdx.ComponentTypeLibrary,
+						Name:    "requests",
+						Version: "2.31.0",
+						PURL:    "pkg:pypi/requests@2.31.0",
+					},
+				},
+				Removed: []diff.Component{
+					{
+						Type:    cdx.ComponentTypeLibrary,
+						Name:    "golang.org/x/text",
+						Version: "v0.3.7",
+						PURL:    "pkg:golang/golang.org/x/text@v0.3.7",
+					},
+				},
+				Changed: []diff.ChangedComponent{
+					{
+						Type:       cdx.ComponentTypeLibrary,
+						Name:       "lodash",
+						OldVersion: "4.17.20",
+						NewVersion: "4.17.21",
+						Change:     diff.ChangeUpgrade,
+						OldPURL:    "pkg:npm/lodash@4.17.20",
+						NewPURL:    "pkg:npm/lodash@4.17.21",
+					},
+					{
+						Type:       cdx.ComponentTypeLibrary,
+						Name:       "minimist",
+						OldVersion: "0.0.8",
+						NewVersion: "1.2.8",
+						Change:     diff.ChangeUpgrade,
+						OldPURL:    "pkg:npm/minimist@0.0.8",
+						NewPURL:    "pkg:npm/minimist@1.2.8",
+					},
+					{
+						Type:       cdx.ComponentTypeLibrary,
+						Name:       "openssl",
+						OldVersion: "3.0.9-1",
+						NewVersion: "3.0.8-1",
+						Change:     diff.ChangeDowngrade,
+						OldPURL:    "pkg:deb/debian/openssl@3.0.9-1?arch=amd64&distro=debian-12.0",
+						NewPURL:    "pkg:deb/debian/openssl@3.0.8-1?arch=amd64&distro=debian-12.1",
+					},
+					{
+						Type:       cdx.ComponentTypeLibrary,
+						Group:      "example",
+						Name:       "vendored-lib",
+						OldVersion: "nightly",
+						NewVersion: "0a1b2c3",
+					},
+				},
+			},
+		},
+		{
+			name:    "same SBOM",
+			oldFile: "testdata/old.cdx.json",
+			newFile: "testdata/old.cdx.json",
+			want:    diff.Diff{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diff.Compare(decode(t, tt.oldFile), decode(t, tt.newFile))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -87,27 +87,27 @@ type Hash func(v interface{}, format hashstructure.Format, opts *hashstructure.H
 
 type newUUID func() uuid.UUID
 
-type marshalOption func(*Marshaler)
+type MarshalOption func(*Marshaler)
 
-func WithClock(clock clock.Clock) marshalOption {
+func WithClock(clock clock.Clock) MarshalOption {
 	return func(opts *Marshaler) {
 		opts.clock = clock
 	}
 }
 
-func WithNewUUID(newUUID newUUID) marshalOption {
+func WithNewUUID(newUUID newUUID) MarshalOption {
 	return func(opts *Marshaler) {
 		opts.newUUID = newUUID
 	}
 }
 
-func WithHasher(hasher Hash) marshalOption {
+func WithHasher(hasher Hash) MarshalOption {
 	return func(opts *Marshaler) {
 		opts.hasher = hasher
 	}
 }
 
-func NewMarshaler(version string, opts ...marshalOption) *Marshaler {
+func NewMarshaler(version string, opts ...MarshalOption) *Marshaler {
 	m := &Marshaler{
 		format:     spdx.Document{},
 		clock:      clock.RealClock{},
@@ -272,6 +272,14 @@ func (m *Marshaler) rootPackage(r types.Report, pkgDownloadLocation string) (*sp
 		attributionTexts = appendAttributionText(attributionTexts, PropertyRepoTag, t)
 	}
 
+	// Image labels and annotations to attribute the image to its source repository and build
+	annotations := r.Metadata.OCIImageAnnotations()
+	keys := maps.Keys(annotations)
+	sort.Strings(keys)
+	for _, k := range keys {
+		attributionTexts = appendAttributionText(attributionTexts, k, annotations[k])
+	}
+
 	pkgID, err := calcPkgID(m.hasher, fmt.Sprintf("%s-%s", r.ArtifactName, r.ArtifactType))
 	if err != nil {
 		return nil, xerrors.Errorf("failed to get %s package ID: %w", err)
@@ -223,7 +223,8 @@ func initApplication(pkg spdx.Package) *ftypes.Application {
 		FilePath: pkg.PackageSourceInfo,
 	}
 	if pkg.PackageName == ftypes.NodePkg || pkg.PackageName == ftypes.PythonPkg ||
-		pkg.PackageName == ftypes.GemSpec || pkg.PackageName == ftypes.Jar || pkg.PackageName == ftypes.CondaPkg {
+		pkg.PackageName == ftypes.GemSpec || pkg.PackageName == ftypes.Jar || pkg.PackageName == ftypes.CondaPkg ||
+		pkg.PackageName == ftypes.Snap || pkg.PackageName == ftypes.Flatpak || pkg.PackageName == ftypes.Homebrew {
 		app.FilePath = ""
 	}
 	return app
@@ -18,6 +18,9 @@ var (
 		ftypes.GemSpec:   "Ruby",
 		ftypes.NodePkg:   "Node.js",
 		ftypes.Jar:       "Java",
+		ftypes.Snap:      "Snap",
+		ftypes.Flatpak:   "Flatpak",
+		ftypes.Homebrew:  "Homebrew",
 	}
 )
 
@@ -78,7 +81,12 @@ func (s *scanner) Scan(detail ftypes.ArtifactDetail, _ types.ScanOptions) (types
 		vulns, err := library.Detect(app.Type, app.Libraries)
 		if err != nil {
 			return nil, xerrors.Errorf("failed vulnerability detection of libraries: %w", err)
-		} else if len(vulns) == 0 {
+		}
+		maliciousPkgs, err := library.DetectMalicious(app.Type, app.Libraries)
+		if err != nil {
+			return nil, xerrors.Errorf("failed malicious package detection of libraries: %w", err)
+		}
+		if len(vulns) == 0 && len(maliciousPkgs) == 0 {
 			continue
 		}
 
@@ -89,10 +97,11 @@ func (s *scanner) Scan(detail ftypes.ArtifactDetail, _ types.ScanOptions) (types
 		}
 
 		results = append(results, types.Result{
-			Target:          target,
-			Vulnerabilities: vulns,
-			Class:           types.ClassLangPkg,
-			Type:            app.Type,
+			Target:            target,
+			Vulnerabilities:   vulns,
+			MaliciousPackages: maliciousPkgs,
+			Class:             types.ClassLangPkg,
+			Type:              app.Type,
 		})
 	}
 	sort.Slice(results, func(i, j int) bool {
@@ -0,0 +1,125 @@
+package local
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/fanal/analyzer"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/scanner/utils"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// scanDrift compares the filesystem with the baseline image, which must be analyzed in advance.
+func (s Scanner) scanDrift(target string, detail ftypes.ArtifactDetail, baseline ftypes.ArtifactReference) (
+	types.Result, error) {
+	baselineDetail, err := s.applier.ApplyLayers(baseline.ID, baseline.BlobIDs)
+	if err != nil && !errors.Is(err, analyzer.ErrUnknownOS) && !errors.Is(err, analyzer.ErrNoPkgsDetected) {
+		return types.Result{}, xerrors.Errorf("failed to apply layers of the baseline image: %w", err)
+	}
+
+	return types.Result{
+		Target: target,
+		Class:  types.ClassDrift,
+		Drifts: detectDrifts(baselineDetail, detail),
+	}, nil
+}
+
+// detectDrifts returns packages and executables added or modified since the baseline.
+// Removed ones are not reported as they don't introduce a new risk.
+func detectDrifts(baseline, detail ftypes.ArtifactDetail) []types.DetectedDrift {
+	var drifts []types.DetectedDrift
+
+	// OS packages
+	osPkgs := map[string]ftypes.Package{}
+	for _, pkg := range baseline.Packages {
+		osPkgs[pkg.Name+"/"+pkg.Arch] = pkg
+	}
+	for _, pkg := range detail.Packages {
+		drift := types.DetectedDrift{
+			Type:             detail.OS.Family,
+			PkgName:          pkg.Name,
+			InstalledVersion: utils.FormatVersion(pkg),
+		}
+		if b, ok := osPkgs[pkg.Name+"/"+pkg.Arch]; !ok {
+			drift.Kind = types.DriftAdded
+		} else if v := utils.FormatVersion(b); v != drift.InstalledVersion {
+			drift.Kind = types.DriftModified
+			drift.BaselineVersion = v
+		} else {
+			continue
+		}
+		drifts = append(drifts, drift)
+	}
+
+	// Language-specific packages
+	libs := map[string]ftypes.Package{}
+	for _, app := range baseline.Applications {
+		for _, lib := range app.Libraries {
+			libs[libKey(app, lib)] = lib
+		}
+	}
+	for _, app := range detail.Applications {
+		for _, lib := range app.Libraries {
+			drift := types.DetectedDrift{
+				Type:             app.Type,
+				PkgName:          lib.Name,
+				FilePath:         libPath(app, lib),
+				InstalledVersion: lib.Version,
+			}
+			if b, ok := libs[libKey(app, lib)]; !ok {
+				drift.Kind = types.DriftAdded
+			} else if b.Version != lib.Version {
+				drift.Kind = types.DriftModified
+				drift.BaselineVersion = b.Version
+			} else {
+				continue
+			}
+			drifts = append(drifts, drift)
+		}
+	}
+
+	// Executables
+	for filePath, digest := range detail.Digests {
+		drift := types.DetectedDrift{
+			Type:     types.DriftTypeExecutable,
+			FilePath: filePath,
+			Digest:   digest,
+		}
+		if b, ok := baseline.Digests[filePath]; !ok {
+			drift.Kind = types.DriftAdded
+		} else if b != digest {
+			drift.Kind = types.DriftModified
+			drift.BaselineDigest = b
+		} else {
+			continue
+		}
+		drifts = append(drifts, drift)
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		if drifts[i].Type != drifts[j].Type {
+			return drifts[i].Type < drifts[j].Type
+		} else if drifts[i].FilePath != drifts[j].FilePath {
+			return drifts[i].FilePath < drifts[j].FilePath
+		}
+		return drifts[i].PkgName < drifts[j].PkgName
+	})
+	return drifts
+}
+
+// libPath returns the file path of the library.
+// Aggregated packages such as Python and Node.js packages have file paths per library.
+func libPath(app ftypes.Application, lib ftypes.Package) string {
+	if lib.FilePath != "" {
+		return lib.FilePath
+	}
+	return app.FilePath
+}
+
+func libKey(app ftypes.Application, lib ftypes.Package) string {
+	return fmt.Sprintf("%s/%s/%s", app.Type, libPath(app, lib), lib.Name)
+}
@@ -0,0 +1,125 @@
+package local
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func Test_detectDrifts(t *testing.T) {
+	baseline := ftypes.ArtifactDetail{
+		OS: ftypes.OS{
+			Family: "alpine",
+			Name:   "3.17.3",
+		},
+		Packages: ftypes.Packages{
+			{
+				Name:    "musl",
+				Version: "1.2.3-r4",
+				Arch:    "x86_64",
+			},
+			{
+				Name:    "openssl",
+				Version: "3.0.8-r3",
+				Arch:    "x86_64",
+			},
+		},
+		Applications: []ftypes.Application{
+			{
+				Type: ftypes.NodePkg,
+				Libraries: ftypes.Packages{
+					{
+						Name:     "lodash",
+						Version:  "4.17.21",
+						FilePath: "app/node_modules/lodash/package.json",
+					},
+				},
+			},
+		},
+		Digests: map[string]string{
+			"bin/busybox":  "sha256:9f64a747e1b97f131fabb6b447296c9b6f0201e79fb3c5356e6c77e89b6a806a",
+			"usr/bin/curl": "sha256:c9e4f5e1cab9e5d5f8e2f6d3a9a4e8f0b9e6c0d2a1b3c4d5e6f7a8b9c0d1e2f3",
+		},
+	}
+	live := ftypes.ArtifactDetail{
+		OS: ftypes.OS{
+			Family: "alpine",
+			Name:   "3.17.3",
+		},
+		Packages: ftypes.Packages{
+			{
+				Name:    "musl",
+				Version: "1.2.3-r4",
+				Arch:    "x86_64",
+			},
+			{
+				Name:    "openssl",
+				Version: "3.0.8-r4",
+				Arch:    "x86_64",
+			},
+			{
+				Name:    "nmap",
+				Version: "7.93-r0",
+				Arch:    "x86_64",
+			},
+		},
+		Applications: []ftypes.Application{
+			{
+				Type: ftypes.NodePkg,
+				Libraries: ftypes.Packages{
+					{
+						Name:     "lodash",
+						Version:  "4.17.20",
+						FilePath: "app/node_modules/lodash/package.json",
+					},
+				},
+			},
+		},
+		Digests: map[string]string{
+			"bin/busybox":  "sha256:9f64a747e1b97f131fabb6b447296c9b6f0201e79fb3c5356e6c77e89b6a806a",
+			"usr/bin/curl": "sha256:0e3c4a9f3b5d7c2b9f8a6e1d4c3b2a1f0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b",
+			"tmp/xmrig":    "sha256:5d2c1b0a9f8e7d6c5b0e3c4a9f3b5d7c2b9f8a6e1d4c3b2a1f0e9d8c7b6a5f4e",
+		},
+	}
+
+	want := []types.DetectedDrift{
+		{
+			Kind:             types.DriftAdded,
+			Type:             "alpine",
+			PkgName:          "nmap",
+			InstalledVersion: "7.93-r0",
+		},
+		{
+			Kind:             types.DriftModified,
+			Type:             "alpine",
+			PkgName:          "openssl",
+			BaselineVersion:  "3.0.8-r3",
+			InstalledVersion: "3.0.8-r4",
+		},
+		{
+			Kind:     types.DriftAdded,
+			Type:     types.DriftTypeExecutable,
+			FilePath: "tmp/xmrig",
+			Digest:   "sha256:5d2c1b0a9f8e7d6c5b0e3c4a9f3b5d7c2b9f8a6e1d4c3b2a1f0e9d8c7b6a5f4e",
+		},
+		{
+			Kind:           types.DriftModified,
+			Type:           types.DriftTypeExecutable,
+			FilePath:       "usr/bin/curl",
+			BaselineDigest: "sha256:c9e4f5e1cab9e5d5f8e2f6d3a9a4e8f0b9e6c0d2a1b3c4d5e6f7a8b9c0d1e2f3",
+			Digest:         "sha256:0e3c4a9f3b5d7c2b9f8a6e1d4c3b2a1f0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b",
+		},
+		{
+			Kind:             types.DriftModified,
+			Type:             ftypes.NodePkg,
+			PkgName:          "lodash",
+			FilePath:         "app/node_modules/lodash/package.json",
+			BaselineVersion:  "4.17.21",
+			InstalledVersion: "4.17.20",
+		},
+	}
+	assert.Equal(t, want, detectDrifts(baseline, live))
+}
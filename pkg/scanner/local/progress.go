@@ -0,0 +1,45 @@
+package local
+
+import (
+	"context"
+
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// Stages reported to Progress
+const (
+	StageApplyingLayers           = "applying layers"
+	StageDetectingVulnerabilities = "detecting vulnerabilities"
+	StageCollectingFindings       = "collecting findings"
+	StagePostScanning             = "post-scanning"
+)
+
+// Progress receives the progress of a scan.
+// The server mode uses it to report the progress and partial results to clients.
+type Progress interface {
+	Stage(stage string)
+	LayersApplied(applied, total int)
+	// PartialResults receives results as soon as they are complete, before post-scanning.
+	// Implementations must not retain the slice.
+	PartialResults(results types.Results)
+}
+
+type progressKey struct{}
+
+// WithProgress attaches the progress receiver to the context
+func WithProgress(ctx context.Context, p Progress) context.Context {
+	return context.WithValue(ctx, progressKey{}, p)
+}
+
+func progressFromContext(ctx context.Context) Progress {
+	if p, ok := ctx.Value(progressKey{}).(Progress); ok && p != nil {
+		return p
+	}
+	return nopProgress{}
+}
+
+type nopProgress struct{}
+
+func (nopProgress) Stage(string)                 {}
+func (nopProgress) LayersApplied(int, int)       {}
+func (nopProgress) PartialResults(types.Results) {}
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/wire"
 	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/exp/slices"
 	"golang.org/x/xerrors"
 
@@ -20,9 +22,12 @@ import (
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/licensing"
 	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/metrics"
 	"github.com/zhanglimao/trivy/pkg/scanner/langpkg"
 	"github.com/zhanglimao/trivy/pkg/scanner/ospkg"
 	"github.com/zhanglimao/trivy/pkg/scanner/post"
+	"github.com/zhanglimao/trivy/pkg/supplychain"
+	"github.com/zhanglimao/trivy/pkg/tracing"
 	"github.com/zhanglimao/trivy/pkg/types"
 	"github.com/zhanglimao/trivy/pkg/vulnerability"
 )
@@ -57,10 +62,23 @@ func NewScanner(applier applier.Applier, osPkgScanner ospkg.Scanner, langPkgScan
 
 // Scan scans the artifact and return results.
 func (s Scanner) Scan(ctx context.Context, target, artifactKey string, blobKeys []string, options types.ScanOptions) (types.Results, ftypes.OS, error) {
-	artifactDetail, err := s.applier.ApplyLayers(artifactKey, blobKeys)
+	ctx, span := tracing.Start(ctx, "local.Scan", attribute.String("target", target))
+	start := time.Now()
+	results, os, err := s.scan(ctx, target, artifactKey, blobKeys, options)
+	metrics.ObserveScan(string(options.ArtifactType), start, err)
+	tracing.End(span, err)
+	return results, os, err
+}
+
+func (s Scanner) scan(ctx context.Context, target, artifactKey string, blobKeys []string, options types.ScanOptions) (types.Results, ftypes.OS, error) {
+	logger := log.WithContext(ctx)
+	progress := progressFromContext(ctx)
+	progress.Stage(StageApplyingLayers)
+
+	artifactDetail, err := s.applyLayers(ctx, artifactKey, blobKeys, progress)
 	switch {
 	case errors.Is(err, analyzer.ErrUnknownOS):
-		log.Logger.Debug("OS is not detected.")
+		logger.Debug("OS is not detected.")
 
 		// Packages may contain OS-independent binary information even though OS is not detected.
 		if len(artifactDetail.Packages) != 0 {
@@ -68,17 +86,17 @@ func (s Scanner) Scan(ctx context.Context, target, artifactKey string, blobKeys
 		}
 
 		// If OS is not detected and repositories are detected, we'll try to use repositories as OS.
-		if artifactDetail.Repository != nil {
-			log.Logger.Debugf("Package repository: %s %s", artifactDetail.Repository.Family, artifactDetail.Repository.Release)
-			log.Logger.Debugf("Assuming OS is %s %s.", artifactDetail.Repository.Family, artifactDetail.Repository.Release)
+		if artifactDetail.Repository != nil && artifactDetail.Repository.Family != "" {
+			logger.Debugf("Package repository: %s %s", artifactDetail.Repository.Family, artifactDetail.Repository.Release)
+			logger.Debugf("Assuming OS is %s %s.", artifactDetail.Repository.Family, artifactDetail.Repository.Release)
 			artifactDetail.OS = ftypes.OS{
 				Family: artifactDetail.Repository.Family,
 				Name:   artifactDetail.Repository.Release,
 			}
 		}
 	case errors.Is(err, analyzer.ErrNoPkgsDetected):
-		log.Logger.Warn("No OS package is detected. Make sure you haven't deleted any files that contain information about the installed packages.")
-		log.Logger.Warn(`e.g. files under "/lib/apk/db/", "/var/lib/dpkg/" and "/var/lib/rpm"`)
+		logger.Warn("No OS package is detected. Make sure you haven't deleted any files that contain information about the installed packages.")
+		logger.Warn(`e.g. files under "/lib/apk/db/", "/var/lib/dpkg/" and "/var/lib/rpm"`)
 	case err != nil:
 		return nil, ftypes.OS{}, xerrors.Errorf("failed to apply layers: %w", err)
 	}
@@ -86,6 +104,18 @@ func (s Scanner) Scan(ctx context.Context, target, artifactKey string, blobKeys
 	var eosl bool
 	var results, pkgResults types.Results
 
+	// Fill vulnerability details of the results not reported yet and pass them as partial results
+	var reported int
+	reportResults := func() {
+		for i := reported; i < len(results); i++ {
+			s.vulnClient.FillInfo(results[i].Vulnerabilities)
+		}
+		if len(results) > reported {
+			progress.PartialResults(results[reported:])
+		}
+		reported = len(results)
+	}
+
 	// Fill OS packages and language-specific packages
 	if options.ListAllPackages {
 		if res := s.osPkgScanner.Packages(target, artifactDetail, options); len(res.Packages) != 0 {
@@ -96,8 +126,9 @@ func (s Scanner) Scan(ctx context.Context, target, artifactKey string, blobKeys
 
 	// Scan packages for vulnerabilities
 	if options.Scanners.Enabled(types.VulnerabilityScanner) {
+		progress.Stage(StageDetectingVulnerabilities)
 		var vulnResults types.Results
-		vulnResults, eosl, err = s.scanVulnerabilities(target, artifactDetail, options)
+		vulnResults, eosl, err = s.scanVulnerabilities(ctx, target, artifactDetail, options)
 		if err != nil {
 			return nil, ftypes.OS{}, xerrors.Errorf("failed to detect vulnerabilities: %w", err)
 		}
@@ -140,13 +171,16 @@ func (s Scanner) Scan(ctx context.Context, target, artifactKey string, blobKeys
 			detail.Packages = detailPackages
 			result, _, err := packetScanner.Scan(packet.Name, detail, packetOpts)
 			if err != nil {
-				log.Logger.Errorf("packetScanner scan err:%s", err)
+				logger.Errorf("packetScanner scan err:%s", err)
 				return nil, ftypes.OS{}, xerrors.Errorf("failed to detect packet vulnerabilities: %w", err)
 			}
 			results = append(results, result)
 		}
 	}
 
+	reportResults()
+	progress.Stage(StageCollectingFindings)
+
 	// Scan IaC config files
 	if ShouldScanMisconfigOrRbac(options.Scanners) {
 		configResults := s.MisconfsToResults(artifactDetail.Misconfigurations)
@@ -188,6 +222,53 @@ func (s Scanner) Scan(ctx context.Context, target, artifactKey string, blobKeys
 		}
 	}
 
+	// Detect drift from the baseline image
+	if options.DriftBaseline != nil {
+		driftResult, err := s.scanDrift(target, artifactDetail, *options.DriftBaseline)
+		if err != nil {
+			return nil, ftypes.OS{}, xerrors.Errorf("drift detection error: %w", err)
+		}
+		results = append(results, driftResult)
+	}
+
+	// Heuristics for malicious packages
+	if options.SupplyChain != nil {
+		if suspicious := supplychain.Detect(ctx, artifactDetail.Applications, *options.SupplyChain); len(suspicious) != 0 {
+			results = append(results, types.Result{
+				Target:             target,
+				Class:              types.ClassSupplyChain,
+				SuspiciousPackages: suspicious,
+			})
+		}
+	}
+
+	// Per-layer sizes and the wasted space of container images
+	if artifactDetail.ImageSize != nil {
+		results = append(results, types.Result{
+			Target:    target,
+			Class:     types.ClassImageSize,
+			ImageSize: artifactDetail.ImageSize,
+		})
+	}
+
+	// Files of OS packages modified after installation, which may be tampered with
+	if len(artifactDetail.ModifiedFiles) != 0 {
+		results = append(results, types.Result{
+			Target:        target,
+			Class:         types.ClassIntegrity,
+			ModifiedFiles: artifactDetail.ModifiedFiles,
+		})
+	}
+
+	// Analyzers which didn't complete, so that users can tell the results may be missing
+	if len(artifactDetail.Warnings) != 0 {
+		results = append(results, types.Result{
+			Target:   target,
+			Class:    types.ClassWarning,
+			Warnings: artifactDetail.Warnings,
+		})
+	}
+
 	// For WASM plugins and custom analyzers
 	if len(artifactDetail.CustomResources) != 0 {
 		results = append(results, types.Result{
@@ -196,13 +277,13 @@ func (s Scanner) Scan(ctx context.Context, target, artifactKey string, blobKeys
 		})
 	}
 
-	for i := range results {
-		// Fill vulnerability details
-		s.vulnClient.FillInfo(results[i].Vulnerabilities)
-	}
+	reportResults()
 
 	// Post scanning
-	results, err = post.Scan(ctx, results)
+	progress.Stage(StagePostScanning)
+	pctx, span := tracing.Start(ctx, "post.Scan")
+	results, err = post.Scan(pctx, results)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, ftypes.OS{}, xerrors.Errorf("post scan error: %w", err)
 	}
@@ -210,13 +291,34 @@ func (s Scanner) Scan(ctx context.Context, target, artifactKey string, blobKeys
 	return results, artifactDetail.OS, nil
 }
 
-func (s Scanner) scanVulnerabilities(target string, detail ftypes.ArtifactDetail, options types.ScanOptions) (
+func (s Scanner) applyLayers(ctx context.Context, artifactKey string, blobKeys []string, progress Progress) (
+	detail ftypes.ArtifactDetail, err error) {
+	_, span := tracing.Start(ctx, "applier.ApplyLayers", attribute.Int("layers", len(blobKeys)))
+	defer func() {
+		// Unknown OS and no packages are not failures of the scan
+		if errors.Is(err, analyzer.ErrUnknownOS) || errors.Is(err, analyzer.ErrNoPkgsDetected) {
+			tracing.End(span, nil)
+			return
+		}
+		tracing.End(span, err)
+	}()
+
+	if a, ok := s.applier.(applier.ProgressApplier); ok {
+		return a.ApplyLayersWithProgress(artifactKey, blobKeys, progress.LayersApplied)
+	}
+	return s.applier.ApplyLayers(artifactKey, blobKeys)
+}
+
+func (s Scanner) scanVulnerabilities(ctx context.Context, target string, detail ftypes.ArtifactDetail, options types.ScanOptions) (
 	types.Results, bool, error) {
 	var eosl bool
 	var results types.Results
 
 	if slices.Contains(options.VulnType, types.VulnTypeOS) {
+		_, span := tracing.Start(ctx, "ospkg.Scan", attribute.String("os.family", string(detail.OS.Family)),
+			attribute.Int("packages", len(detail.Packages)))
 		vuln, detectedEOSL, err := s.osPkgScanner.Scan(target, detail, options)
+		tracing.End(span, err)
 		if err != nil {
 			return nil, false, xerrors.Errorf("unable to scan OS packages: %w", err)
 		} else if vuln.Target != "" {
@@ -226,7 +328,9 @@ func (s Scanner) scanVulnerabilities(target string, detail ftypes.ArtifactDetail
 	}
 
 	if slices.Contains(options.VulnType, types.VulnTypeLibrary) {
+		_, span := tracing.Start(ctx, "langpkg.Scan", attribute.Int("applications", len(detail.Applications)))
 		vulns, err := s.langPkgScanner.Scan(detail, options)
+		tracing.End(span, err)
 		if err != nil {
 			return nil, false, xerrors.Errorf("failed to scan application libraries: %w", err)
 		}
@@ -302,6 +406,10 @@ func (s Scanner) MisconfsToResults(misconfs []ftypes.Misconfiguration) types.Res
 			detected = append(detected, toDetectedMisconfiguration(w, dbTypes.SeverityUnknown, types.StatusException, misconf.Layer))
 		}
 
+		for i := range detected {
+			detected[i].AnalyzedBy = misconf.AnalyzedBy
+		}
+
 		results = append(results, types.Result{
 			Target:            misconf.FilePath,
 			Class:             types.ClassConfig,
@@ -322,6 +430,12 @@ func (s Scanner) secretsToResults(secrets []ftypes.Secret) types.Results {
 	for _, secret := range secrets {
 		log.Logger.Debugf("Secret file: %s", secret.FilePath)
 
+		for i := range secret.Findings {
+			if secret.Findings[i].AnalyzedBy == nil {
+				secret.Findings[i].AnalyzedBy = secret.AnalyzedBy
+			}
+		}
+
 		results = append(results, types.Result{
 			Target:  secret.FilePath,
 			Class:   types.ClassSecret,
@@ -348,6 +462,7 @@ func (s Scanner) scanLicenses(detail ftypes.ArtifactDetail,
 				PkgName:    pkg.Name,
 				Name:       license,
 				Confidence: 1.0,
+				AnalyzedBy: pkg.AnalyzedBy,
 			})
 		}
 
@@ -370,6 +485,7 @@ func (s Scanner) scanLicenses(detail ftypes.ArtifactDetail,
 					PkgName:    lib.Name,
 					Name:       license,
 					Confidence: 1.0,
+					AnalyzedBy: lib.AnalyzedBy,
 				})
 			}
 		}
@@ -399,6 +515,7 @@ func (s Scanner) scanLicenses(detail ftypes.ArtifactDetail,
 				Confidence: finding.Confidence,
 				Link:       finding.Link,
 				Layer:      license.Layer,
+				AnalyzedBy: license.AnalyzedBy,
 			})
 
 		}
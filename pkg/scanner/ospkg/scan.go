@@ -8,6 +8,7 @@ import (
 	"golang.org/x/xerrors"
 
 	ospkgDetector "github.com/zhanglimao/trivy/pkg/detector/ospkg"
+	fos "github.com/zhanglimao/trivy/pkg/fanal/analyzer/os"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 	"github.com/zhanglimao/trivy/pkg/log"
 	"github.com/zhanglimao/trivy/pkg/types"
@@ -56,7 +57,12 @@ func (s *scanner) Scan(target string, detail ftypes.ArtifactDetail, options type
 
 	if detail.OS.Extended {
 		// TODO: move the logic to each detector
-		detail.OS.Name += "-ESM"
+		switch detail.OS.Family {
+		case fos.Ubuntu:
+			detail.OS.Name += "-ESM"
+		case fos.Debian:
+			detail.OS.Name += "-ELTS"
+		}
 	}
 
 	vulns, eosl, err := ospkgDetector.Detect("", detail.OS.Family, detail.OS.Name, detail.Repository, time.Time{}, pkgs)
@@ -0,0 +1,49 @@
+package post
+
+import (
+	"context"
+	"sort"
+
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/tracing"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// Reporter changes the report after filtering, e.g. adds, annotates or suppresses findings
+type Reporter interface {
+	Name() string
+	PostReport(ctx context.Context, report types.Report) (types.Report, error)
+}
+
+var postReporters = map[string]Reporter{}
+
+func RegisterPostReporter(r Reporter) {
+	// Avoid duplication
+	postReporters[r.Name()] = r
+}
+
+func DeregisterPostReporter(name string) {
+	delete(postReporters, name)
+}
+
+// Report runs the post reporters in the order of the names so that the report is deterministic
+func Report(ctx context.Context, report types.Report) (types.Report, error) {
+	names := make([]string, 0, len(postReporters))
+	for name := range postReporters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var err error
+	for _, name := range names {
+		r := postReporters[name]
+		rctx, span := tracing.Start(ctx, "post.Report."+name)
+		report, err = r.PostReport(rctx, report)
+		tracing.End(span, err)
+		if err != nil {
+			return types.Report{}, xerrors.Errorf("%s post report error: %w", name, err)
+		}
+	}
+	return report, nil
+}
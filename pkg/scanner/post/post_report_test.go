@@ -0,0 +1,44 @@
+package post_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/scanner/post"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+type testPostReporter struct {
+	name string
+}
+
+func (r testPostReporter) Name() string {
+	return r.name
+}
+
+func (r testPostReporter) PostReport(_ context.Context, report types.Report) (types.Report, error) {
+	if report.ArtifactName == "bad" {
+		return types.Report{}, errors.New("bad")
+	}
+	report.ArtifactName += "+" + r.name
+	return report, nil
+}
+
+func TestReport(t *testing.T) {
+	for _, name := range []string{"b", "a"} {
+		r := testPostReporter{name: name}
+		post.RegisterPostReporter(r)
+		defer post.DeregisterPostReporter(r.Name())
+	}
+
+	got, err := post.Report(context.Background(), types.Report{ArtifactName: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, "test+a+b", got.ArtifactName)
+
+	_, err = post.Report(context.Background(), types.Report{ArtifactName: "bad"})
+	require.ErrorContains(t, err, "a post report error: bad")
+}
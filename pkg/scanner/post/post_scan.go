@@ -5,6 +5,7 @@ import (
 
 	"golang.org/x/xerrors"
 
+	"github.com/zhanglimao/trivy/pkg/tracing"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
@@ -36,7 +37,9 @@ var postScanners = map[string]Scanner{}
 func Scan(ctx context.Context, results types.Results) (types.Results, error) {
 	var err error
 	for _, s := range postScanners {
-		results, err = s.PostScan(ctx, results)
+		sctx, span := tracing.Start(ctx, "post.Scan."+s.Name())
+		results, err = s.PostScan(sctx, results)
+		tracing.End(span, err)
 		if err != nil {
 			return nil, xerrors.Errorf("%s post scan error: %w", s.Name(), err)
 		}
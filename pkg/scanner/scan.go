@@ -2,15 +2,21 @@ package scanner
 
 import (
 	"context"
+	"sort"
 
+	"github.com/google/uuid"
 	"github.com/google/wire"
+	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/xerrors"
 
 	"github.com/zhanglimao/trivy/pkg/fanal/artifact"
 	aimage "github.com/zhanglimao/trivy/pkg/fanal/artifact/image"
 	flocal "github.com/zhanglimao/trivy/pkg/fanal/artifact/local"
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact/purl"
 	"github.com/zhanglimao/trivy/pkg/fanal/artifact/remote"
 	"github.com/zhanglimao/trivy/pkg/fanal/artifact/sbom"
+	"github.com/zhanglimao/trivy/pkg/fanal/artifact/ssh"
 	"github.com/zhanglimao/trivy/pkg/fanal/artifact/vm"
 	"github.com/zhanglimao/trivy/pkg/fanal/image"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
@@ -18,6 +24,7 @@ import (
 	"github.com/zhanglimao/trivy/pkg/report"
 	"github.com/zhanglimao/trivy/pkg/rpc/client"
 	"github.com/zhanglimao/trivy/pkg/scanner/local"
+	"github.com/zhanglimao/trivy/pkg/tracing"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
 
@@ -39,6 +46,13 @@ var StandaloneDockerSet = wire.NewSet(
 	StandaloneSuperSet,
 )
 
+// StandaloneContainerSet binds running container dependencies
+var StandaloneContainerSet = wire.NewSet(
+	image.NewRunningContainerImage,
+	aimage.NewArtifact,
+	StandaloneSuperSet,
+)
+
 // StandaloneArchiveSet binds archive scan dependencies
 var StandaloneArchiveSet = wire.NewSet(
 	image.NewArchiveImage,
@@ -58,6 +72,18 @@ var StandaloneRepositorySet = wire.NewSet(
 	StandaloneSuperSet,
 )
 
+// StandaloneSSHSet binds remote filesystem dependencies
+var StandaloneSSHSet = wire.NewSet(
+	ssh.NewArtifact,
+	StandaloneSuperSet,
+)
+
+// StandalonePurlSet binds package URL dependencies
+var StandalonePurlSet = wire.NewSet(
+	purl.NewArtifact,
+	StandaloneSuperSet,
+)
+
 // StandaloneSBOMSet binds sbom dependencies
 var StandaloneSBOMSet = wire.NewSet(
 	sbom.NewArtifact,
@@ -94,6 +120,18 @@ var RemoteRepositorySet = wire.NewSet(
 	RemoteSuperSet,
 )
 
+// RemoteSSHSet binds remote filesystem dependencies for client/server mode
+var RemoteSSHSet = wire.NewSet(
+	ssh.NewArtifact,
+	RemoteSuperSet,
+)
+
+// RemotePurlSet binds package URL dependencies for client/server mode
+var RemotePurlSet = wire.NewSet(
+	purl.NewArtifact,
+	RemoteSuperSet,
+)
+
 // RemoteSBOMSet binds sbom dependencies for client/server mode
 var RemoteSBOMSet = wire.NewSet(
 	sbom.NewArtifact,
@@ -113,6 +151,13 @@ var RemoteDockerSet = wire.NewSet(
 	RemoteSuperSet,
 )
 
+// RemoteContainerSet binds remote running container dependencies
+var RemoteContainerSet = wire.NewSet(
+	aimage.NewArtifact,
+	image.NewRunningContainerImage,
+	RemoteSuperSet,
+)
+
 // RemoteArchiveSet binds remote archive dependencies
 var RemoteArchiveSet = wire.NewSet(
 	aimage.NewArtifact,
@@ -138,17 +183,29 @@ func NewScanner(driver Driver, ar artifact.Artifact) Scanner {
 }
 
 // ScanArtifact scans the artifacts and returns results
-func (s Scanner) ScanArtifact(ctx context.Context, options types.ScanOptions) (types.Report, error) {
+func (s Scanner) ScanArtifact(ctx context.Context, options types.ScanOptions) (_ types.Report, err error) {
+	// Correlate the logs of the scan
+	if log.ScanID(ctx) == "" {
+		ctx = log.ContextWith(ctx, log.KeyScanID, uuid.NewString())
+	}
+
+	ctx, span := tracing.Start(ctx, "ScanArtifact", attribute.String("scan.id", log.ScanID(ctx)))
+	defer func() { tracing.End(span, err) }()
+
 	artifactInfo, err := s.artifact.Inspect(ctx)
 	if err != nil {
 		return types.Report{}, xerrors.Errorf("failed analysis: %w", err)
 	}
 	defer func() {
 		if err := s.artifact.Clean(artifactInfo); err != nil {
-			log.Logger.Warnf("Failed to clean the artifact %q: %v", artifactInfo.Name, err)
+			log.WithContext(ctx).Warnf("Failed to clean the artifact %q: %v", artifactInfo.Name, err)
 		}
 	}()
 
+	options.ArtifactType = artifactInfo.Type
+	ctx = log.ContextWith(ctx, log.KeyArtifact, artifactInfo.Name)
+	span.SetAttributes(attribute.String("artifact.name", artifactInfo.Name), attribute.String("artifact.type", string(artifactInfo.Type)))
+
 	results, osFound, err := s.driver.Scan(ctx, artifactInfo.Name, artifactInfo.ID, artifactInfo.BlobIDs, options)
 	if err != nil {
 		return types.Report{}, xerrors.Errorf("scan failed: %w", err)
@@ -156,8 +213,8 @@ func (s Scanner) ScanArtifact(ctx context.Context, options types.ScanOptions) (t
 
 	ptros := &osFound
 	if osFound.Detected() && osFound.Eosl {
-		log.Logger.Warnf("This OS version is no longer supported by the distribution: %s %s", osFound.Family, osFound.Name)
-		log.Logger.Warnf("The vulnerability detection may be insufficient because security updates are not provided")
+		log.WithContext(ctx).Warnf("This OS version is no longer supported by the distribution: %s %s", osFound.Family, osFound.Name)
+		log.WithContext(ctx).Warnf("The vulnerability detection may be insufficient because security updates are not provided")
 	} else if !osFound.Detected() {
 		ptros = nil
 	}
@@ -167,6 +224,9 @@ func (s Scanner) ScanArtifact(ctx context.Context, options types.ScanOptions) (t
 		removeLayer(results)
 	}
 
+	results = addWarnings(results, artifactInfo)
+	results = addContainerDrift(results, artifactInfo)
+
 	return types.Report{
 		SchemaVersion: report.SchemaVersion,
 		ArtifactName:  artifactInfo.Name,
@@ -175,17 +235,72 @@ func (s Scanner) ScanArtifact(ctx context.Context, options types.ScanOptions) (t
 			OS: ptros,
 
 			// Container image
-			ImageID:     artifactInfo.ImageMetadata.ID,
-			DiffIDs:     artifactInfo.ImageMetadata.DiffIDs,
-			RepoTags:    artifactInfo.ImageMetadata.RepoTags,
-			RepoDigests: artifactInfo.ImageMetadata.RepoDigests,
-			ImageConfig: artifactInfo.ImageMetadata.ConfigFile,
+			ImageID:          artifactInfo.ImageMetadata.ID,
+			DiffIDs:          artifactInfo.ImageMetadata.DiffIDs,
+			RepoTags:         artifactInfo.ImageMetadata.RepoTags,
+			RepoDigests:      artifactInfo.ImageMetadata.RepoDigests,
+			ImageDigest:      artifactInfo.ImageMetadata.Digest,
+			ImageAnnotations: artifactInfo.ImageMetadata.Annotations,
+			ImageConfig:      artifactInfo.ImageMetadata.ConfigFile,
 		},
 		CycloneDX: artifactInfo.CycloneDX,
 		Results:   results,
 	}, nil
 }
 
+// addWarnings adds the warnings of the artifact which are not in the cached blobs, e.g. failed layers,
+// to the warnings of the analysis
+func addWarnings(results types.Results, artifactInfo ftypes.ArtifactReference) types.Results {
+	if len(artifactInfo.Warnings) == 0 {
+		return results
+	}
+	for i := range results {
+		if results[i].Class == types.ClassWarning {
+			results[i].Warnings = lo.Flatten([][]ftypes.Warning{artifactInfo.Warnings, results[i].Warnings})
+			return results
+		}
+	}
+	return append(results, types.Result{
+		Target:   artifactInfo.Name,
+		Class:    types.ClassWarning,
+		Warnings: artifactInfo.Warnings,
+	})
+}
+
+// addContainerDrift reports the files added or modified in the writable layer of the running container.
+// Deleted files are not reported as they don't introduce a new risk.
+func addContainerDrift(results types.Results, artifactInfo ftypes.ArtifactReference) types.Results {
+	if artifactInfo.ContainerDrift == nil {
+		return results
+	}
+	var drifts []types.DetectedDrift
+	for _, filePath := range artifactInfo.ContainerDrift.Added {
+		drifts = append(drifts, types.DetectedDrift{
+			Kind:     types.DriftAdded,
+			Type:     types.DriftTypeFile,
+			FilePath: filePath,
+		})
+	}
+	for _, filePath := range artifactInfo.ContainerDrift.Modified {
+		drifts = append(drifts, types.DetectedDrift{
+			Kind:     types.DriftModified,
+			Type:     types.DriftTypeFile,
+			FilePath: filePath,
+		})
+	}
+	if len(drifts) == 0 {
+		return results
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].FilePath < drifts[j].FilePath
+	})
+	return append(results, types.Result{
+		Target: artifactInfo.Name,
+		Class:  types.ClassDrift,
+		Drifts: drifts,
+	})
+}
+
 func removeLayer(results types.Results) {
 	for i := range results {
 		result := results[i]
@@ -55,7 +55,7 @@ func TestScanner_ScanArtifact(t *testing.T) {
 					Target:      "alpine:3.11",
 					ImageID:     "sha256:e7d92cdc71feacf90708cb59182d0df1b911f8ae022d29e8e95d75ca6a99776a",
 					LayerIDs:    []string{"sha256:5216338b40a7b96416b8b9858974bbe4acc3096ee60acbc4dfb1ee02aecceb10"},
-					Options:     types.ScanOptions{VulnType: []string{"os"}},
+					Options:     types.ScanOptions{VulnType: []string{"os"}, ArtifactType: ftypes.ArtifactContainerImage},
 				},
 				Returns: DriverScanReturns{
 					Results: types.Results{
@@ -209,3 +209,121 @@ func TestScanner_ScanArtifact(t *testing.T) {
 		})
 	}
 }
+
+func Test_addWarnings(t *testing.T) {
+	layerWarning := ftypes.Warning{Message: "failed to analyze layer (sha256:a187dde48cd289ac374ad8539930628314bc581a481cdb41409c9289419ddb72): unexpected EOF"}
+	analyzerWarning := ftypes.Warning{
+		Analyzer: "npm",
+		FilePath: "app/package-lock.json",
+		Message:  "decode error",
+	}
+
+	tests := []struct {
+		name    string
+		results types.Results
+		want    types.Results
+	}{
+		{
+			name: "no warnings of analyzers",
+			results: types.Results{
+				{
+					Target: "alpine:3.11 (alpine 3.11.5)",
+					Class:  types.ClassOSPkg,
+				},
+			},
+			want: types.Results{
+				{
+					Target: "alpine:3.11 (alpine 3.11.5)",
+					Class:  types.ClassOSPkg,
+				},
+				{
+					Target:   "alpine:3.11",
+					Class:    types.ClassWarning,
+					Warnings: []ftypes.Warning{layerWarning},
+				},
+			},
+		},
+		{
+			name: "with warnings of analyzers",
+			results: types.Results{
+				{
+					Target:   "alpine:3.11 (alpine 3.11.5)",
+					Class:    types.ClassWarning,
+					Warnings: []ftypes.Warning{analyzerWarning},
+				},
+			},
+			want: types.Results{
+				{
+					Target: "alpine:3.11 (alpine 3.11.5)",
+					Class:  types.ClassWarning,
+					Warnings: []ftypes.Warning{
+						layerWarning,
+						analyzerWarning,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := addWarnings(tt.results, ftypes.ArtifactReference{
+				Name:     "alpine:3.11",
+				Warnings: []ftypes.Warning{layerWarning},
+			})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_addContainerDrift(t *testing.T) {
+	tests := []struct {
+		name  string
+		drift *ftypes.ContainerDrift
+		want  types.Results
+	}{
+		{
+			name: "drifted",
+			drift: &ftypes.ContainerDrift{
+				Added:    []string{"usr/bin/backdoor"},
+				Modified: []string{"etc/passwd"},
+				Deleted:  []string{"etc/shadow"},
+			},
+			want: types.Results{
+				{
+					Target: "6e4ba2b0c1f0",
+					Class:  types.ClassDrift,
+					Drifts: []types.DetectedDrift{
+						{
+							Kind:     types.DriftModified,
+							Type:     types.DriftTypeFile,
+							FilePath: "etc/passwd",
+						},
+						{
+							Kind:     types.DriftAdded,
+							Type:     types.DriftTypeFile,
+							FilePath: "usr/bin/backdoor",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "only deleted files",
+			drift: &ftypes.ContainerDrift{
+				Deleted: []string{"etc/shadow"},
+			},
+		},
+		{
+			name: "not a running container",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := addContainerDrift(nil, ftypes.ArtifactReference{
+				Name:           "6e4ba2b0c1f0",
+				ContainerDrift: tt.drift,
+			})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
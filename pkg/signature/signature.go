@@ -0,0 +1,114 @@
+// Package signature signs and verifies blobs in the same format as "cosign sign-blob",
+// i.e. a base64-encoded signature over the SHA-256 digest of the content.
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Verify verifies the base64-encoded signature of the content with the PEM-encoded public key.
+// ECDSA, Ed25519 and RSA keys are supported as cosign does.
+func Verify(publicKeyPath string, content, b64Sig []byte) error {
+	block, err := readPEM(publicKeyPath)
+	if err != nil {
+		return xerrors.Errorf("invalid public key: %w", err)
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return xerrors.Errorf("public key parse error: %w", err)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b64Sig)))
+	if err != nil {
+		return xerrors.Errorf("signature decode error: %w", err)
+	}
+	return VerifyWithKey(pub, content, sig)
+}
+
+// VerifyWithKey verifies the raw signature of the content with the public key,
+// e.g. the one in a signing certificate.
+func VerifyWithKey(pub crypto.PublicKey, content, sig []byte) error {
+	digest := sha256.Sum256(content)
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], sig) {
+			return xerrors.New("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, content, sig) {
+			return xerrors.New("invalid signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+			return xerrors.Errorf("invalid signature: %w", err)
+		}
+	default:
+		return xerrors.Errorf("unsupported public key type: %T", pub)
+	}
+	return nil
+}
+
+// Sign signs the content with the PEM-encoded private key and returns the base64-encoded signature.
+// The private key must not be encrypted.
+func Sign(privateKeyPath string, content []byte) ([]byte, error) {
+	block, err := readPEM(privateKeyPath)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid private key: %w", err)
+	}
+
+	var key any
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, xerrors.Errorf("unsupported private key type: %s", block.Type)
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("private key parse error: %w", err)
+	}
+
+	var sig []byte
+	digest := sha256.Sum256(content)
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		sig, err = ecdsa.SignASN1(rand.Reader, k, digest[:])
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(k, content)
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	default:
+		return nil, xerrors.Errorf("unsupported private key type: %T", key)
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("sign error: %w", err)
+	}
+	return []byte(base64.StdEncoding.EncodeToString(sig)), nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to read the key: %w", err)
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, xerrors.Errorf("no PEM data found: %s", path)
+	}
+	return block, nil
+}
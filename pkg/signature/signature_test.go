@@ -0,0 +1,75 @@
+package signature_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhanglimao/trivy/pkg/signature"
+)
+
+func writeKeys(t *testing.T, priv crypto.Signer) (string, string) {
+	dir := t.TempDir()
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	privPath := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600))
+
+	pubDER, err := x509.MarshalPKIXPublicKey(priv.Public())
+	require.NoError(t, err)
+	pubPath := filepath.Join(dir, "key.pub")
+	require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0600))
+
+	return privPath, pubPath
+}
+
+func TestSignAndVerify(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		key  crypto.Signer
+	}{
+		{
+			name: "ECDSA",
+			key:  ecKey,
+		},
+		{
+			name: "Ed25519",
+			key:  edKey,
+		},
+		{
+			name: "RSA",
+			key:  rsaKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privPath, pubPath := writeKeys(t, tt.key)
+			content := []byte("content")
+
+			sig, err := signature.Sign(privPath, content)
+			require.NoError(t, err)
+
+			assert.NoError(t, signature.Verify(pubPath, content, sig))
+			assert.ErrorContains(t, signature.Verify(pubPath, []byte("tampered"), sig), "invalid signature")
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package supplychain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/log"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+var (
+	// Public registries, overridden in tests
+	npmRegistry  = "https://registry.npmjs.org"
+	pypiRegistry = "https://pypi.org"
+)
+
+// highMajorVersion is the major version considered unusually high for internal packages.
+// Attackers publish malicious packages with versions like "99.0.0" so that they take precedence over internal ones.
+const highMajorVersion = 90
+
+// publicPackage is the result of a lookup in a public registry
+type publicPackage struct {
+	exists   bool
+	versions []string
+}
+
+// confusionDetector checks if internal packages can be confused with packages in public registries
+type confusionDetector struct {
+	patterns []string
+	offline  bool
+	client   *http.Client
+
+	// Lookup results per ecosystem and name, as the same package is often found in several files
+	cache map[string]*publicPackage
+}
+
+func newConfusionDetector(patterns []string, offline bool) *confusionDetector {
+	return &confusionDetector{
+		patterns: patterns,
+		offline:  offline,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		cache:    map[string]*publicPackage{},
+	}
+}
+
+func (d *confusionDetector) detect(ctx context.Context, eco ecosystem, lib ftypes.Package) (types.DetectedSuspiciousPackage, bool) {
+	if !d.internal(eco, lib.Name) {
+		return types.DetectedSuspiciousPackage{}, false
+	}
+
+	s := types.DetectedSuspiciousPackage{Kind: types.SuspicionDependencyConfusion}
+	installedMajor := majorVersion(lib.Version)
+
+	pub := d.lookup(ctx, eco, lib.Name)
+	switch {
+	case pub != nil && pub.exists && slices.Contains(pub.versions, lib.Version):
+		s.Confidence = types.ConfidenceHigh
+		s.Reason = fmt.Sprintf("Version %s is published in the public registry. It may have been installed from the public registry instead of the internal one.", lib.Version)
+	case pub != nil && pub.exists && maxMajorVersion(pub.versions) > installedMajor:
+		s.Confidence = types.ConfidenceHigh
+		s.Reason = fmt.Sprintf("The public registry has a higher major version %d, which may be installed instead of the internal package", maxMajorVersion(pub.versions))
+	case pub != nil && pub.exists:
+		s.Confidence = types.ConfidenceMedium
+		s.Reason = "The name of the internal package is registered in the public registry"
+	case installedMajor >= highMajorVersion:
+		s.Confidence = types.ConfidenceLow
+		s.Reason = fmt.Sprintf("Version %s is unusually high for an internal package", lib.Version)
+	default:
+		return types.DetectedSuspiciousPackage{}, false
+	}
+	return s, true
+}
+
+// internal returns true if the name matches one of the patterns of internal packages
+func (d *confusionDetector) internal(eco ecosystem, name string) bool {
+	name = normalizeName(eco, name)
+	for _, pattern := range d.patterns {
+		if ok, _ := path.Match(normalizeName(eco, pattern), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns the package in the public registry, or nil if it is unknown
+func (d *confusionDetector) lookup(ctx context.Context, eco ecosystem, name string) *publicPackage {
+	if d.offline {
+		return nil
+	}
+
+	key := string(eco) + "/" + normalizeName(eco, name)
+	if pub, ok := d.cache[key]; ok {
+		return pub
+	}
+
+	var pub *publicPackage
+	var err error
+	switch eco {
+	case npm:
+		pub, err = d.fetch(ctx, fmt.Sprintf("%s/%s", npmRegistry, strings.Replace(name, "/", "%2f", 1)), "versions")
+	case pypi:
+		pub, err = d.fetch(ctx, fmt.Sprintf("%s/pypi/%s/json", pypiRegistry, url.PathEscape(normalizeName(eco, name))), "releases")
+	default:
+		// Other registries are not supported yet
+	}
+	if err != nil {
+		log.Logger.Warnf("Unable to look up %s in the public registry: %s", name, err)
+		pub = nil
+	}
+	d.cache[key] = pub
+	return pub
+}
+
+// fetch gets the package metadata and returns the keys of the versions field
+func (d *confusionDetector) fetch(ctx context.Context, u, versionsField string) (*publicPackage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, xerrors.Errorf("request error: %w", err)
+	}
+	// The abbreviated metadata of npm is enough to list versions
+	req.Header.Set("Accept", "application/vnd.npm.install-v1+json, application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, xerrors.Errorf("http error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return &publicPackage{}, nil
+	default:
+		return nil, xerrors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var metadata map[string]json.RawMessage
+	if err = json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return nil, xerrors.Errorf("json decode error: %w", err)
+	}
+	var versions map[string]json.RawMessage
+	if b, ok := metadata[versionsField]; ok {
+		if err = json.Unmarshal(b, &versions); err != nil {
+			return nil, xerrors.Errorf("json decode error: %w", err)
+		}
+	}
+
+	pub := &publicPackage{exists: true}
+	for v := range versions {
+		pub.versions = append(pub.versions, v)
+	}
+	return pub, nil
+}
+
+// majorVersion returns the leading number of the version, or -1 if it doesn't start with a number
+func majorVersion(v string) int {
+	v = strings.TrimPrefix(v, "v")
+	end := strings.IndexFunc(v, func(r rune) bool { return r < '0' || r > '9' })
+	if end == -1 {
+		end = len(v)
+	}
+	major, err := strconv.Atoi(v[:end])
+	if err != nil {
+		return -1
+	}
+	return major
+}
+
+func maxMajorVersion(versions []string) int {
+	m := -1
+	for _, v := range versions {
+		if major := majorVersion(v); major > m {
+			m = major
+		}
+	}
+	return m
+}
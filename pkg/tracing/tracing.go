@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"net/url"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/xerrors"
+
+	"github.com/zhanglimao/trivy/pkg/log"
+)
+
+const instrumentationName = "github.com/zhanglimao/trivy"
+
+var provider *sdktrace.TracerProvider
+
+// Enabled returns whether spans should be exported.
+// The standard OTEL_EXPORTER_OTLP_* environment variables enable the export as well as the endpoint.
+func Enabled(endpoint string) bool {
+	return endpoint != "" || os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Init exports spans to the OTLP/gRPC endpoint, e.g. "localhost:4317" or "http://localhost:4317".
+// Spans are discarded when the export is not enabled.
+func Init(ctx context.Context, endpoint, version string) error {
+	if !Enabled(endpoint) {
+		return nil
+	}
+
+	var opts []otlptracegrpc.Option
+	if endpoint != "" {
+		// The scheme tells whether the connection must be secure as the OTLP environment variables do
+		if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+			endpoint = u.Host
+			if u.Scheme == "http" {
+				opts = append(opts, otlptracegrpc.WithInsecure())
+			}
+		}
+		opts = append(opts, otlptracegrpc.WithEndpoint(endpoint))
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return xerrors.Errorf("OTLP exporter error: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName("trivy"),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return xerrors.Errorf("resource error: %w", err)
+	}
+
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Logger.Debugf("OpenTelemetry error: %s", err)
+	}))
+	log.Logger.Debug("Exporting traces via OTLP")
+
+	return nil
+}
+
+// Shutdown flushes the spans not exported yet
+func Shutdown(ctx context.Context) {
+	if provider == nil {
+		return
+	}
+	if err := provider.Shutdown(ctx); err != nil {
+		log.Logger.Warnf("Failed to export traces: %s", err)
+	}
+}
+
+// Start starts a span, which must be ended by the caller
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error, if any, and ends the span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
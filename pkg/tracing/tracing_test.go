@@ -0,0 +1,77 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/zhanglimao/trivy/pkg/tracing"
+)
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		env      map[string]string
+		want     bool
+	}{
+		{
+			name:     "endpoint",
+			endpoint: "localhost:4317",
+			want:     true,
+		},
+		{
+			name: "environment variable",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://localhost:4317",
+			},
+			want: true,
+		},
+		{
+			name: "disabled",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+			t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			assert.Equal(t, tt.want, tracing.Enabled(tt.endpoint))
+		})
+	}
+}
+
+func TestStart(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	ctx, parent := tracing.Start(context.Background(), "parent", attribute.String("image", "alpine:3.17"))
+	_, child := tracing.Start(ctx, "child")
+	tracing.End(child, errors.New("error"))
+	tracing.End(parent, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "child", spans[0].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Len(t, spans[0].Events(), 1) // the error
+
+	assert.Equal(t, "parent", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("image", "alpine:3.17")}, spans[1].Attributes())
+}
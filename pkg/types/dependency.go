@@ -0,0 +1,133 @@
+package types
+
+import (
+	"sort"
+
+	"golang.org/x/exp/maps"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// DependencyGraph holds relationships between packages in a result,
+// so that automation can trace how vulnerable packages are introduced.
+type DependencyGraph struct {
+	Relationships     []Relationship     `json:",omitempty"`
+	VulnerableOrigins []VulnerableOrigin `json:",omitempty"`
+}
+
+// Relationship represents the packages a package directly depends on
+type Relationship struct {
+	PkgID     string
+	DependsOn []string
+}
+
+// VulnerableOrigin represents the direct dependencies introducing a vulnerable package
+type VulnerableOrigin struct {
+	PkgID   string
+	Direct  bool     `json:",omitempty"` // The vulnerable package itself is a direct dependency
+	Origins []string `json:",omitempty"`
+}
+
+// NewDependencyGraph builds the dependency graph of the given packages.
+// It returns nil if the packages have no dependency relationships.
+func NewDependencyGraph(pkgs []ftypes.Package, vulns []DetectedVulnerability) *DependencyGraph {
+	parents := ftypes.Packages(pkgs).ParentDeps()
+	if len(parents) == 0 {
+		return nil
+	}
+	ancestors := Ancestors(pkgs, parents)
+
+	graph := &DependencyGraph{}
+	for _, pkg := range pkgs {
+		if len(pkg.DependsOn) == 0 {
+			continue
+		}
+		graph.Relationships = append(graph.Relationships, Relationship{
+			PkgID:     pkg.ID,
+			DependsOn: pkg.DependsOn,
+		})
+	}
+
+	vulnPkgIDs := map[string]struct{}{}
+	for _, vuln := range vulns {
+		vulnPkgIDs[vuln.PkgID] = struct{}{}
+	}
+	for _, pkg := range pkgs {
+		if _, ok := vulnPkgIDs[pkg.ID]; !ok || pkg.ID == "" {
+			continue
+		}
+		origin := VulnerableOrigin{
+			PkgID:  pkg.ID,
+			Direct: !pkg.Indirect,
+		}
+		if pkg.Indirect {
+			origin.Origins = ancestors[pkg.ID]
+		}
+		graph.VulnerableOrigins = append(graph.VulnerableOrigins, origin)
+	}
+
+	sort.Slice(graph.Relationships, func(i, j int) bool {
+		return graph.Relationships[i].PkgID < graph.Relationships[j].PkgID
+	})
+	sort.Slice(graph.VulnerableOrigins, func(i, j int) bool {
+		return graph.VulnerableOrigins[i].PkgID < graph.VulnerableOrigins[j].PkgID
+	})
+	return graph
+}
+
+// Origins returns the direct dependencies introducing the given vulnerable package
+func (g *DependencyGraph) Origins(pkgID string) []string {
+	if g == nil {
+		return nil
+	}
+	for _, origin := range g.VulnerableOrigins {
+		if origin.PkgID == pkgID {
+			return origin.Origins
+		}
+	}
+	return nil
+}
+
+// Ancestors returns the direct dependencies each package is introduced by.
+// The result is sorted.
+func Ancestors(pkgs []ftypes.Package, parentMap map[string]ftypes.Packages) map[string][]string {
+	ancestors := map[string][]string{}
+	for _, pkg := range pkgs {
+		ids := findAncestor(pkg.ID, parentMap, map[string]struct{}{})
+		sort.Strings(ids)
+		ancestors[pkg.ID] = ids
+	}
+	return ancestors
+}
+
+func findAncestor(pkgID string, parentMap map[string]ftypes.Packages, seen map[string]struct{}) []string {
+	ancestors := map[string]struct{}{}
+	seen[pkgID] = struct{}{}
+	for _, parent := range parentMap[pkgID] {
+		if _, ok := seen[parent.ID]; ok {
+			continue
+		}
+		if !parent.Indirect {
+			ancestors[parent.ID] = struct{}{}
+		} else if len(parentMap[parent.ID]) == 0 {
+			// Direct dependencies cannot be identified in some package managers like "package-lock.json" v1,
+			// then the "Indirect" field can be always true. We try to guess direct dependencies in this case.
+			// A dependency with no parents must be a direct dependency.
+			//
+			// e.g.
+			//   -> styled-components
+			//     -> fbjs
+			//       -> isomorphic-fetch
+			//         -> node-fetch
+			//
+			// Even if `styled-components` is not marked as a direct dependency, it must be a direct dependency
+			// as it has no parents. Note that it doesn't mean `fbjs` is an indirect dependency.
+			ancestors[parent.ID] = struct{}{}
+		} else {
+			for _, a := range findAncestor(parent.ID, parentMap, seen) {
+				ancestors[a] = struct{}{}
+			}
+		}
+	}
+	return maps.Keys(ancestors)
+}
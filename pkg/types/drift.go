@@ -0,0 +1,38 @@
+package types
+
+type DriftKind string
+
+const (
+	DriftAdded    DriftKind = "added"
+	DriftModified DriftKind = "modified"
+
+	// DriftTypeExecutable represents drift of executables not tied to any package.
+	// Only executables are compared with the baseline image as other files are not hashed by the analyzers.
+	DriftTypeExecutable = "executable"
+
+	// DriftTypeFile represents drift of any files in the writable layer of a running container
+	DriftTypeFile = "file"
+)
+
+// DetectedDrift represents a package or an executable that differs from the baseline image
+type DetectedDrift struct {
+	// Kind holds whether it is added or modified since the baseline
+	Kind DriftKind
+
+	// Type holds the package type such as "alpine" and "npm", "executable" or "file"
+	Type string
+
+	// PkgName holds a package name. It will be empty for executables and files.
+	PkgName string `json:",omitempty"`
+
+	// FilePath holds a path of the executable, the changed file or the file where the package is detected
+	FilePath string `json:",omitempty"`
+
+	// BaselineVersion and InstalledVersion are filled for packages
+	BaselineVersion  string `json:",omitempty"`
+	InstalledVersion string `json:",omitempty"`
+
+	// BaselineDigest and Digest are filled for executables
+	BaselineDigest string `json:",omitempty"`
+	Digest         string `json:",omitempty"`
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/zhanglimao/trivy/pkg/fanal/types"
 )
 
@@ -27,6 +29,16 @@ type DetectedLicense struct {
 	Confidence float64
 
 	// Link is a SPDX link of the license
-	Link string
+	Link  string
 	Layer types.Layer `json:",omitempty"`
+
+	// AnalyzedBy is the analyzer which found the license
+	AnalyzedBy *types.AnalyzedBy `json:",omitempty"`
+
+	// DueDate is the deadline to remediate the license violation according to the SLA of the severity
+	DueDate *time.Time `json:",omitempty"`
+
+	// FirstSeen and LastSeen hold when the license was first and last seen in the recorded history
+	FirstSeen *time.Time `json:",omitempty"`
+	LastSeen  *time.Time `json:",omitempty"`
 }
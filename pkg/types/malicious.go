@@ -0,0 +1,25 @@
+package types
+
+import (
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+)
+
+// DetectedMaliciousPackage represents a package version reported as malicious by a malicious package feed.
+// It is reported separately from vulnerabilities as the package itself should be removed rather than updated.
+type DetectedMaliciousPackage struct {
+	// ID holds the ID of the report, e.g. MAL-2022-1234
+	ID               string       `json:",omitempty"`
+	Aliases          []string     `json:",omitempty"`
+	PkgID            string       `json:",omitempty"`
+	PkgName          string       `json:",omitempty"`
+	PkgPath          string       `json:",omitempty"`
+	InstalledVersion string       `json:",omitempty"`
+	Layer            ftypes.Layer `json:",omitempty"`
+	Summary          string       `json:",omitempty"`
+	Severity         string       `json:",omitempty"`
+	References       []string     `json:",omitempty"`
+
+	// DataSource holds the feed where the report comes from
+	DataSource *dbTypes.DataSource `json:",omitempty"`
+}
@@ -1,6 +1,10 @@
 package types
 
-import ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+import (
+	"time"
+
+	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
+)
 
 // DetectedMisconfiguration holds detected misconfigurations
 type DetectedMisconfiguration struct {
@@ -19,6 +23,13 @@ type DetectedMisconfiguration struct {
 	Status        MisconfStatus        `json:",omitempty"`
 	Layer         ftypes.Layer         `json:",omitempty"`
 	CauseMetadata ftypes.CauseMetadata `json:",omitempty"`
+	AnalyzedBy    *ftypes.AnalyzedBy   `json:",omitempty"`
+	DueDate       *time.Time           `json:",omitempty"` // Deadline according to the SLA of the severity
+	FirstSeen     *time.Time           `json:",omitempty"` // First seen in the recorded history
+	LastSeen      *time.Time           `json:",omitempty"` // Last seen in the recorded history
+
+	// Annotations are added by modules after filtering
+	Annotations map[string]string `json:",omitempty"`
 
 	// For debugging
 	Traces []string `json:",omitempty"`
@@ -0,0 +1,39 @@
+package types
+
+type ProvenanceStatus string
+
+const (
+	ProvenancePassed ProvenanceStatus = "PASS"
+	ProvenanceFailed ProvenanceStatus = "FAIL"
+
+	// Sources of provenance attestations
+	ProvenanceSourceOCI   = "oci"
+	ProvenanceSourceRekor = "rekor"
+)
+
+var ProvenanceSources = []string{
+	ProvenanceSourceOCI,
+	ProvenanceSourceRekor,
+}
+
+// ProvenanceVerification represents the verification result of a provenance attestation of an image
+type ProvenanceVerification struct {
+	Status ProvenanceStatus
+
+	// Source holds where the attestation comes from, "oci" or "rekor"
+	Source string `json:",omitempty"`
+
+	// PredicateType holds the type of the provenance, e.g. "https://slsa.dev/provenance/v1"
+	PredicateType string `json:",omitempty"`
+
+	// BuilderID holds the builder which generated the provenance
+	BuilderID string `json:",omitempty"`
+
+	// Issuer and Subject hold the identity of the signer in the signing certificate,
+	// e.g. "https://token.actions.githubusercontent.com" and the workflow URI
+	Issuer  string `json:",omitempty"`
+	Subject string `json:",omitempty"`
+
+	// Message holds why the verification failed
+	Message string `json:",omitempty"`
+}
@@ -0,0 +1,29 @@
+package types
+
+type RecommendationType string
+
+const (
+	// RecommendationRebase suggests rebasing the image onto a newer tag of the base image
+	RecommendationRebase RecommendationType = "rebase"
+)
+
+// Recommendation represents a suggestion to reduce findings
+type Recommendation struct {
+	Type RecommendationType
+
+	// BaseImage holds the current base image, e.g. docker.io/library/alpine:3.17.0
+	BaseImage string
+
+	// Recommended holds the newest tag with the same major version, e.g. docker.io/library/alpine:3.17.3
+	Recommended string
+
+	// NewerTags holds newer tags of the base image, newest first
+	NewerTags []string `json:",omitempty"`
+
+	// Vulnerabilities holds the number of vulnerabilities originating in the base layers
+	Vulnerabilities int
+
+	// ResolvedVulnerabilities is the estimated number of vulnerabilities resolved by rebasing.
+	// Vulnerabilities in the base layers having a fixed version are counted.
+	ResolvedVulnerabilities int
+}
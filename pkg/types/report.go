@@ -2,8 +2,10 @@ package types
 
 import (
 	"encoding/json"
+	"strings"
 
 	v1 "github.com/google/go-containerregistry/pkg/v1" // nolint: goimports
+	"golang.org/x/exp/slices"
 
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 )
@@ -26,6 +28,9 @@ type Report struct {
 	Metadata      Metadata            `json:",omitempty"`
 	Results       Results             `json:",omitempty"`
 
+	// Recommendations to reduce findings, e.g. rebasing onto a newer base image
+	Recommendations []Recommendation `json:",omitempty"`
+
 	// SBOM
 	CycloneDX *ftypes.CycloneDX `json:"-"` // Just for internal usage, not exported in JSON
 }
@@ -36,11 +41,46 @@ type Metadata struct {
 	OS   *ftypes.OS `json:",omitempty"`
 
 	// Container image
-	ImageID     string        `json:",omitempty"`
-	DiffIDs     []string      `json:",omitempty"`
-	RepoTags    []string      `json:",omitempty"`
-	RepoDigests []string      `json:",omitempty"`
-	ImageConfig v1.ConfigFile `json:",omitempty"`
+	ImageID          string            `json:",omitempty"`
+	DiffIDs          []string          `json:",omitempty"`
+	RepoTags         []string          `json:",omitempty"`
+	RepoDigests      []string          `json:",omitempty"`
+	ImageDigest      string            `json:",omitempty"`
+	ImageAnnotations map[string]string `json:",omitempty"`
+	ImageConfig      v1.ConfigFile     `json:",omitempty"`
+
+	// ReconstructedDockerfile is reconstructed from the image history when requested
+	ReconstructedDockerfile string `json:",omitempty"`
+
+	// BaseImage is the probable base image when requested
+	BaseImage *BaseImage `json:",omitempty"`
+}
+
+// BaseImage represents the base image of a container image
+type BaseImage struct {
+	Name    string   `json:",omitempty"` // e.g. docker.io/library/alpine:3.17
+	Digest  string   `json:",omitempty"`
+	DiffIDs []string `json:",omitempty"` // diff IDs of the base layers
+
+	// Verified is true when the layers were confirmed by looking up the base image in the registry
+	Verified bool `json:",omitempty"`
+}
+
+// OCIImageAnnotationPrefix is the prefix of the pre-defined annotation keys in the OCI image spec
+const OCIImageAnnotationPrefix = "org.opencontainers.image."
+
+// OCIImageAnnotations returns the pre-defined OCI annotations of the image, e.g. org.opencontainers.image.source.
+// They can be set as either image labels or manifest annotations, and annotations take precedence.
+func (m Metadata) OCIImageAnnotations() map[string]string {
+	annotations := map[string]string{}
+	for _, kv := range []map[string]string{m.ImageConfig.Config.Labels, m.ImageAnnotations} {
+		for k, v := range kv {
+			if strings.HasPrefix(k, OCIImageAnnotationPrefix) && v != "" {
+				annotations[k] = v
+			}
+		}
+	}
+	return annotations
 }
 
 // Results to hold list of Result
@@ -57,6 +97,12 @@ const (
 	ClassLicense     = "license"      // For detected package licenses
 	ClassLicenseFile = "license-file" // For detected licenses in files
 	ClassCustom      = "custom"
+	ClassDrift       = "drift"        // For packages and executables drifted from the baseline image
+	ClassImageSize   = "image-size"   // For per-layer sizes and the wasted space of container images
+	ClassSupplyChain = "supply-chain" // For packages suspected of typosquatting and dependency confusion, and controls such as image signatures
+	ClassWarning     = "warning"      // For problems which made the scan incomplete, e.g. timed-out analyzers
+	ClassProvenance  = "provenance"   // For verification results of provenance attestations
+	ClassIntegrity   = "integrity"    // For files of OS packages modified after installation
 
 	ComplianceK8sNsa           = Compliance("k8s-nsa")
 	ComplianceK8sCIS           = Compliance("k8s-cis")
@@ -69,16 +115,31 @@ const (
 
 // Result holds a target and detected vulnerabilities
 type Result struct {
-	Target            string                     `json:"Target"`
-	Class             ResultClass                `json:"Class,omitempty"`
-	Type              string                     `json:"Type,omitempty"`
-	Packages          []ftypes.Package           `json:"Packages,omitempty"`
-	Vulnerabilities   []DetectedVulnerability    `json:"Vulnerabilities,omitempty"`
-	MisconfSummary    *MisconfSummary            `json:"MisconfSummary,omitempty"`
-	Misconfigurations []DetectedMisconfiguration `json:"Misconfigurations,omitempty"`
-	Secrets           []ftypes.SecretFinding     `json:"Secrets,omitempty"`
-	Licenses          []DetectedLicense          `json:"Licenses,omitempty"`
-	CustomResources   []ftypes.CustomResource    `json:"CustomResources,omitempty"`
+	Target             string                      `json:"Target"`
+	Class              ResultClass                 `json:"Class,omitempty"`
+	Type               string                      `json:"Type,omitempty"`
+	Packages           []ftypes.Package            `json:"Packages,omitempty"`
+	Vulnerabilities    []DetectedVulnerability     `json:"Vulnerabilities,omitempty"`
+	MaliciousPackages  []DetectedMaliciousPackage  `json:"MaliciousPackages,omitempty"`
+	MisconfSummary     *MisconfSummary             `json:"MisconfSummary,omitempty"`
+	Misconfigurations  []DetectedMisconfiguration  `json:"Misconfigurations,omitempty"`
+	Secrets            []ftypes.SecretFinding      `json:"Secrets,omitempty"`
+	Licenses           []DetectedLicense           `json:"Licenses,omitempty"`
+	CustomResources    []ftypes.CustomResource     `json:"CustomResources,omitempty"`
+	Drifts             []DetectedDrift             `json:"Drifts,omitempty"`
+	SuspiciousPackages []DetectedSuspiciousPackage `json:"SuspiciousPackages,omitempty"`
+	ImageSize          *ftypes.ImageSize           `json:"ImageSize,omitempty"`
+	DependencyGraph    *DependencyGraph            `json:"DependencyGraph,omitempty"`
+	Warnings           []ftypes.Warning            `json:"Warnings,omitempty"`
+	Provenance         []ProvenanceVerification    `json:"Provenance,omitempty"`
+	Controls           []SupplyChainControl        `json:"Controls,omitempty"`
+	ModifiedFiles      []ftypes.ModifiedFile       `json:"ModifiedFiles,omitempty"`
+
+	// Owners are the teams or users owning the target, looked up from CODEOWNERS or a mapping file
+	Owners []string `json:"Owners,omitempty"`
+
+	// Suppressed holds the findings removed by modules with the reasons
+	Suppressed []SuppressedFinding `json:"Suppressed,omitempty"`
 }
 
 func (r *Result) MarshalJSON() ([]byte, error) {
@@ -105,8 +166,10 @@ func (r *Result) MarshalJSON() ([]byte, error) {
 }
 
 func (r *Result) IsEmpty() bool {
-	return len(r.Packages) == 0 && len(r.Vulnerabilities) == 0 && len(r.Misconfigurations) == 0 &&
-		len(r.Secrets) == 0 && len(r.Licenses) == 0 && len(r.CustomResources) == 0
+	return len(r.Packages) == 0 && len(r.Vulnerabilities) == 0 && len(r.MaliciousPackages) == 0 && len(r.Misconfigurations) == 0 &&
+		len(r.Secrets) == 0 && len(r.Licenses) == 0 && len(r.CustomResources) == 0 && len(r.Drifts) == 0 &&
+		len(r.SuspiciousPackages) == 0 && r.ImageSize == nil && len(r.Warnings) == 0 &&
+		len(r.Provenance) == 0 && len(r.Controls) == 0 && len(r.ModifiedFiles) == 0
 }
 
 type MisconfSummary struct {
@@ -119,10 +182,30 @@ func (s MisconfSummary) Empty() bool {
 	return s.Successes == 0 && s.Failures == 0 && s.Exceptions == 0
 }
 
+// Malicious returns whether the result includes any known-malicious packages
+func (results Results) Malicious() bool {
+	for _, r := range results {
+		if len(r.MaliciousPackages) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Drifted returns whether the result includes any drift, which is reported via '--exit-on-drift' only
+func (results Results) Drifted() bool {
+	for _, r := range results {
+		if len(r.Drifts) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // Failed returns whether the result includes any vulnerabilities, misconfigurations or secrets
 func (results Results) Failed() bool {
 	for _, r := range results {
-		if len(r.Vulnerabilities) > 0 {
+		if len(r.Vulnerabilities) > 0 || len(r.MaliciousPackages) > 0 {
 			return true
 		}
 		for _, m := range r.Misconfigurations {
@@ -136,6 +219,23 @@ func (results Results) Failed() bool {
 		if len(r.Licenses) > 0 {
 			return true
 		}
+		if len(r.SuspiciousPackages) > 0 {
+			return true
+		}
+		if len(r.ModifiedFiles) > 0 {
+			return true
+		}
+		if slices.ContainsFunc(r.Controls, func(c SupplyChainControl) bool {
+			return c.Status == StatusFailure
+		}) {
+			return true
+		}
+		// A single trusted provenance is enough
+		if len(r.Provenance) > 0 && !slices.ContainsFunc(r.Provenance, func(p ProvenanceVerification) bool {
+			return p.Status == ProvenancePassed
+		}) {
+			return true
+		}
 	}
 	return false
 }
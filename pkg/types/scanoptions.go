@@ -17,4 +17,11 @@ type ScanOptions struct {
 	LicenseCategories   map[types.LicenseCategory][]string
 	FilePatterns        []string
 	Packages            []*common.Package
+	ArtifactType        types.ArtifactType // for metrics
+
+	// DriftBaseline is the image compared with the scanned filesystem
+	DriftBaseline *types.ArtifactReference
+
+	// SupplyChain enables heuristics for typosquatting and dependency confusion
+	SupplyChain *SupplyChainOptions
 }
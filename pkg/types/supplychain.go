@@ -0,0 +1,77 @@
+package types
+
+type SuspicionKind string
+
+const (
+	SuspicionTyposquatting       SuspicionKind = "typosquatting"
+	SuspicionDependencyConfusion SuspicionKind = "dependency-confusion"
+	SuspicionInstallScript       SuspicionKind = "install-script"
+)
+
+// Confidence represents how likely the package is malicious
+type Confidence string
+
+const (
+	ConfidenceHigh   Confidence = "HIGH"
+	ConfidenceMedium Confidence = "MEDIUM"
+	ConfidenceLow    Confidence = "LOW"
+)
+
+// SupplyChainOptions enables heuristics for malicious packages
+type SupplyChainOptions struct {
+	// Typosquatting reports packages whose names are near-misses of popular packages
+	Typosquatting bool
+
+	// InternalPackages holds name patterns of internal packages checked for dependency confusion, e.g. "@acme/*"
+	InternalPackages []string
+
+	// InstallScripts reports npm packages with obfuscated or network-fetching lifecycle scripts
+	InstallScripts bool
+
+	// Offline disables lookups in public registries
+	Offline bool
+}
+
+// DetectedSuspiciousPackage represents a package which may be malicious based on heuristics
+type DetectedSuspiciousPackage struct {
+	Kind SuspicionKind
+
+	// Type holds the package type such as "npm" and "pip"
+	Type string
+
+	PkgName          string
+	InstalledVersion string `json:",omitempty"`
+
+	// FilePath holds the file where the package is detected
+	FilePath string `json:",omitempty"`
+
+	// SimilarTo holds the popular package which the name resembles
+	SimilarTo string `json:",omitempty"`
+
+	// Script holds the suspicious lifecycle script, e.g. "postinstall: curl -s https://... | sh"
+	Script string `json:",omitempty"`
+
+	Reason     string
+	Confidence Confidence
+}
+
+const (
+	// SupplyChainControlImageSignature is the control that the image is signed by a trusted signer
+	SupplyChainControlImageSignature = "image-signature"
+
+	// SupplyChainControlTagDrift is the control that the tag resolves to the same digest as the previous scan
+	SupplyChainControlTagDrift = "tag-drift"
+)
+
+// SupplyChainControl represents the result of a supply chain control of the artifact, e.g. the image signature
+type SupplyChainControl struct {
+	ID     string
+	Title  string
+	Status MisconfStatus
+
+	// Signer holds who signed the artifact, i.e. the subject and the issuer of the signing certificate or the public key
+	Signer string `json:",omitempty"`
+
+	// Message holds why the control failed
+	Message string `json:",omitempty"`
+}
@@ -0,0 +1,18 @@
+package types
+
+// Finding types of suppressed findings
+const (
+	FindingVulnerability    = "vulnerability"
+	FindingMisconfiguration = "misconfiguration"
+)
+
+// SuppressedFinding is a finding removed from the report after filtering, e.g. by a module
+type SuppressedFinding struct {
+	Type    string `json:",omitempty"`
+	ID      string `json:",omitempty"`
+	PkgName string `json:",omitempty"`
+	Reason  string `json:",omitempty"`
+
+	// Source is the name of the module suppressing the finding
+	Source string `json:",omitempty"`
+}
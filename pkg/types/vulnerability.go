@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/aquasecurity/trivy-db/pkg/types"
 	ftypes "github.com/zhanglimao/trivy/pkg/fanal/types"
 )
@@ -18,6 +20,9 @@ type DetectedVulnerability struct {
 	SeveritySource   types.SourceID `json:",omitempty"`
 	PrimaryURL       string         `json:",omitempty"`
 
+	// AnalyzedBy is the analyzer which found the vulnerable package
+	AnalyzedBy *ftypes.AnalyzedBy `json:",omitempty"`
+
 	// PkgRef is populated only when scanning SBOM and contains the reference ID used in the SBOM.
 	// It could be PURL, UUID, etc.
 	// e.g.
@@ -25,9 +30,26 @@ type DetectedVulnerability struct {
 	//    - b2a46a4b-8367-4bae-9820-95557cfe03a8
 	PkgRef string `json:",omitempty"`
 
+	// ExtendedFixedVersion is the version fixed only in extended support programs such as Ubuntu ESM.
+	// FixedVersion is empty if the program is not enabled.
+	ExtendedFixedVersion string `json:",omitempty"`
+
 	// DataSource holds where the advisory comes from
 	DataSource *types.DataSource `json:",omitempty"`
 
+	// DueDate is the deadline to remediate the vulnerability according to the SLA of the severity
+	DueDate *time.Time `json:",omitempty"`
+
+	// FirstSeen and LastSeen hold when the vulnerability was first and last seen in the recorded history
+	FirstSeen *time.Time `json:",omitempty"`
+	LastSeen  *time.Time `json:",omitempty"`
+
+	// Locations holds all the places where the vulnerability is found when findings are deduplicated
+	Locations []VulnerabilityLocation `json:",omitempty"`
+
+	// Annotations are added by modules after filtering
+	Annotations map[string]string `json:",omitempty"`
+
 	// Custom is for extensibility and not supposed to be used in OSS
 	Custom interface{} `json:",omitempty"`
 
@@ -35,6 +57,13 @@ type DetectedVulnerability struct {
 	types.Vulnerability
 }
 
+// VulnerabilityLocation represents a place where a vulnerable package is found
+type VulnerabilityLocation struct {
+	Target  string       `json:",omitempty"`
+	PkgPath string       `json:",omitempty"`
+	Layer   ftypes.Layer `json:",omitempty"`
+}
+
 // GetID retrun Vulnerability ID
 func (vuln *DetectedVulnerability) GetID() string {
 	return vuln.VulnerabilityID
@@ -8,6 +8,8 @@ import (
 	"github.com/aquasecurity/trivy-db/pkg/db"
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	"github.com/aquasecurity/trivy-db/pkg/vulnsrc/vulnerability"
+	"github.com/zhanglimao/trivy/pkg/advisory"
+	"github.com/zhanglimao/trivy/pkg/detector/library"
 	"github.com/zhanglimao/trivy/pkg/log"
 	"github.com/zhanglimao/trivy/pkg/types"
 )
@@ -60,6 +62,24 @@ func NewClient(dbc db.Operation) Client {
 func (c Client) FillInfo(vulns []types.DetectedVulnerability) {
 	for i := range vulns {
 		vulnID := vulns[i].VulnerabilityID
+
+		// End-of-life findings are filled by the detector
+		if vulns[i].DataSource != nil && vulns[i].DataSource.ID == library.EOLSource {
+			continue
+		}
+
+		// Details of custom advisories are not stored in trivy-db
+		if vulns[i].DataSource != nil {
+			if vuln, ok := advisory.Vulnerability(vulns[i].DataSource.ID, vulnID); ok {
+				vulns[i].Vulnerability = vuln
+				vulns[i].SeveritySource = vulns[i].DataSource.ID
+				if len(vuln.References) > 0 {
+					vulns[i].PrimaryURL = vuln.References[0]
+				}
+				continue
+			}
+		}
+
 		vuln, err := c.dbc.GetVulnerability(vulnID)
 		if err != nil {
 			log.Logger.Warnf("Error while getting vulnerability details: %s", err)
@@ -1933,56 +1933,57 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 808 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xef, 0x6e, 0xfb, 0x34,
-	0x14, 0x55, 0xff, 0x6c, 0x6d, 0x6f, 0xff, 0xac, 0x98, 0x1f, 0x5b, 0x56, 0xa6, 0xad, 0x0a, 0x20,
-	0x95, 0x0f, 0x24, 0xa2, 0x80, 0x84, 0x84, 0x40, 0x74, 0x1b, 0xa0, 0x4a, 0x4c, 0x14, 0x0f, 0x21,
-	0xc1, 0x97, 0x92, 0x3a, 0x4e, 0x6b, 0xad, 0x89, 0x33, 0xdb, 0x29, 0xf4, 0x0d, 0x78, 0x27, 0x5e,
-	0x81, 0x87, 0x42, 0x76, 0x92, 0xb6, 0x69, 0xbb, 0x89, 0xdf, 0x97, 0x2a, 0xbe, 0xf7, 0xf8, 0xf8,
-	0xdc, 0xe3, 0x93, 0x14, 0x2e, 0x44, 0x4c, 0x5c, 0xe2, 0x91, 0x05, 0x75, 0x25, 0x15, 0x2b, 0x46,
-	0xa8, 0x13, 0x0b, 0xae, 0x38, 0xea, 0x28, 0xc1, 0x56, 0x6b, 0xc7, 0xb4, 0x9c, 0xd5, 0xa7, 0xbd,
-	0x9b, 0x39, 0xe7, 0xf3, 0x25, 0x75, 0x4d, 0x77, 0x96, 0x04, 0xae, 0x62, 0x21, 0x95, 0xca, 0x0b,
-	0xe3, 0x74, 0x43, 0xcf, 0x32, 0x4c, 0x3c, 0x0c, 0x79, 0x54, 0xa4, 0xea, 0xbd, 0xbf, 0xbf, 0x95,
-	0x86, 0xb1, 0x5a, 0xa7, 0x4d, 0xfb, 0xef, 0x32, 0xb4, 0x46, 0x42, 0xb1, 0xc0, 0x23, 0x6a, 0x1c,
-	0x05, 0x1c, 0x7d, 0x04, 0x1d, 0x49, 0x16, 0x34, 0xf4, 0xa6, 0x2b, 0x2a, 0x24, 0xe3, 0x91, 0x55,
-	0xea, 0x97, 0x06, 0x27, 0xb8, 0x9d, 0x56, 0x7f, 0x4d, 0x8b, 0xc8, 0x86, 0x96, 0x27, 0xc8, 0x82,
-	0x29, 0x4a, 0x54, 0x22, 0xa8, 0x55, 0xee, 0x97, 0x06, 0x0d, 0x5c, 0xa8, 0xa1, 0xcf, 0xa1, 0x46,
-	0x04, 0xf5, 0x14, 0xf5, 0xad, 0x4a, 0xbf, 0x34, 0x68, 0x0e, 0x7b, 0x4e, 0x2a, 0xc5, 0xc9, 0xa5,
-	0x38, 0xbf, 0xe4, 0x53, 0xe0, 0x1c, 0xaa, 0x05, 0xf8, 0x9c, 0x3c, 0x51, 0xb1, 0x11, 0x50, 0x35,
-	0xdc, 0xed, 0xb4, 0x9a, 0x0b, 0xe8, 0x40, 0x99, 0x4b, 0xeb, 0xc4, 0xb4, 0xca, 0x5c, 0xa2, 0x6f,
-	0xa1, 0xbb, 0x60, 0x52, 0x71, 0xb1, 0x9e, 0xc6, 0x1e, 0x79, 0xf2, 0xe6, 0x54, 0x5a, 0xa7, 0xfd,
-	0xca, 0xa0, 0x39, 0x7c, 0xcf, 0xc9, 0xbc, 0x34, 0xe6, 0x38, 0x93, 0xb4, 0x8b, 0xcf, 0x32, 0x78,
-	0xb6, 0x96, 0xf6, 0x5f, 0x80, 0x26, 0x89, 0xca, 0xcd, 0xc0, 0xf4, 0x39, 0xa1, 0x52, 0xa1, 0x1b,
-	0x68, 0x7a, 0x59, 0x69, 0xca, 0x7c, 0x63, 0x46, 0x03, 0x43, 0x5e, 0x1a, 0xfb, 0x68, 0x04, 0xed,
-	0x2d, 0x20, 0x0a, 0xb8, 0xb1, 0xa2, 0x39, 0xbc, 0x72, 0x8a, 0x37, 0xe8, 0xec, 0xba, 0xac, 0x8d,
-	0xda, 0xae, 0xec, 0x7f, 0xab, 0x50, 0xbf, 0x5d, 0xf2, 0xd9, 0xdb, 0x5c, 0x40, 0xdf, 0xcc, 0x9f,
-	0x9e, 0xd5, 0x2d, 0x4e, 0xf8, 0xd3, 0xa3, 0x71, 0xe4, 0x4b, 0x00, 0x41, 0x63, 0x2e, 0x99, 0x9e,
-	0xd2, 0x6a, 0x1a, 0xa4, 0x55, 0x44, 0xe2, 0x4d, 0x1f, 0xef, 0x60, 0xd1, 0x37, 0xd0, 0xce, 0x3c,
-	0x34, 0x13, 0x49, 0xab, 0x62, 0x8c, 0xbc, 0x3c, 0x6a, 0x64, 0x3a, 0x4f, 0xbc, 0x5d, 0x48, 0xf4,
-	0x35, 0xb4, 0xbc, 0x38, 0x5e, 0x32, 0xe2, 0x29, 0xc6, 0x23, 0x69, 0x55, 0x8f, 0x6d, 0x1f, 0x6d,
-	0x11, 0xb8, 0x00, 0x47, 0x3f, 0xc2, 0x3b, 0x21, 0x93, 0x84, 0x47, 0x01, 0x9b, 0x27, 0x22, 0xe3,
-	0x68, 0x18, 0x8e, 0xeb, 0x22, 0xc7, 0xc3, 0x1e, 0x0c, 0x1f, 0x6e, 0xd4, 0x17, 0xc8, 0x63, 0xef,
-	0x39, 0xa1, 0x53, 0x9f, 0x09, 0x9d, 0x98, 0x8a, 0xbe, 0xc0, 0xb4, 0x74, 0xcf, 0x84, 0xd4, 0x86,
-	0xff, 0xa9, 0x43, 0xcb, 0x13, 0x35, 0x0d, 0xd8, 0x32, 0xcb, 0x4d, 0x03, 0xb7, 0xf3, 0xea, 0xf7,
-	0xba, 0x88, 0xce, 0xe1, 0xd4, 0x67, 0x73, 0x2a, 0x95, 0x55, 0x33, 0x19, 0xc8, 0x56, 0xe8, 0x02,
-	0x6a, 0x3e, 0x0b, 0x02, 0x1d, 0x8e, 0x7a, 0xde, 0x08, 0x82, 0xb1, 0x8f, 0x7e, 0x80, 0x2e, 0x49,
-	0xa4, 0xe2, 0xe1, 0x54, 0x50, 0xc9, 0x13, 0x41, 0xa8, 0xb4, 0xc0, 0x4c, 0x71, 0x55, 0x9c, 0xe2,
-	0xce, 0xa0, 0x70, 0x06, 0xc2, 0x67, 0xa4, 0xb0, 0x96, 0xc8, 0x81, 0x9a, 0xa4, 0x44, 0x50, 0x25,
-	0xad, 0x96, 0xd9, 0xff, 0xa6, 0xb8, 0xff, 0xd1, 0x34, 0x71, 0x0e, 0xb2, 0xff, 0x80, 0xce, 0x24,
-	0x51, 0x3a, 0x50, 0x79, 0x88, 0x77, 0x34, 0x96, 0x0a, 0x1a, 0xbf, 0x80, 0xc6, 0x6c, 0xc9, 0x67,
-	0x69, 0x70, 0x2b, 0xc5, 0x88, 0xe4, 0xc1, 0xcd, 0x93, 0x89, 0xeb, 0xb3, 0xec, 0xc9, 0xbe, 0x83,
-	0xe6, 0x24, 0x51, 0x98, 0xca, 0x98, 0x47, 0x92, 0x66, 0x59, 0x2c, 0xbd, 0x92, 0x45, 0x04, 0x55,
-	0xca, 0xe5, 0xd2, 0xe4, 0xb5, 0x8e, 0xcd, 0xb3, 0xfd, 0x33, 0xbc, 0xfb, 0xc0, 0xa4, 0x64, 0xd1,
-	0x5c, 0x9f, 0x20, 0xff, 0xf7, 0x0b, 0x77, 0x09, 0xf5, 0x54, 0xb3, 0xaf, 0xf3, 0xaf, 0x6f, 0xaa,
-	0x66, 0x84, 0xf9, 0xd2, 0x7e, 0x82, 0x37, 0x45, 0xca, 0x4c, 0xe0, 0xc7, 0xd0, 0x0d, 0xd3, 0xfa,
-	0x34, 0x27, 0x32, 0xc4, 0x75, 0x7c, 0x96, 0xd5, 0xf3, 0xb7, 0x13, 0x0d, 0xb6, 0xd0, 0xbd, 0x53,
-	0x3a, 0xe1, 0x96, 0x5a, 0x1f, 0xe6, 0x02, 0xba, 0xa7, 0x4b, 0xaa, 0x68, 0x41, 0xfe, 0xae, 0xba,
-	0x52, 0x41, 0xdd, 0xf0, 0x9f, 0x32, 0x9c, 0xdc, 0x69, 0x57, 0xd1, 0xd8, 0xf8, 0xb7, 0x39, 0xd3,
-	0xde, 0xb7, 0xfc, 0xf0, 0x3b, 0xd4, 0x3b, 0x3f, 0xf8, 0x76, 0x7e, 0xa7, 0x3f, 0xe3, 0x68, 0x04,
-	0xb5, 0xec, 0xb2, 0xd1, 0xf5, 0x11, 0x9a, 0x9d, 0x14, 0xbc, 0x48, 0xf1, 0x1b, 0xb4, 0x76, 0x5d,
-	0x43, 0x1f, 0xec, 0xf3, 0x1c, 0xb9, 0xa6, 0xde, 0x87, 0xaf, 0x83, 0x32, 0xe3, 0xc7, 0xd0, 0xdc,
-	0xf1, 0xe8, 0x70, 0xd0, 0x43, 0x03, 0x5f, 0x52, 0x79, 0xeb, 0xfe, 0xfe, 0xc9, 0x9c, 0xa9, 0x45,
-	0x32, 0xd3, 0xd1, 0x72, 0xbd, 0xe7, 0xc4, 0x93, 0x94, 0x24, 0x82, 0xa9, 0xb5, 0x6b, 0x48, 0xdd,
-	0xcd, 0x5f, 0xe9, 0x57, 0xe6, 0x77, 0x76, 0x6a, 0x08, 0x3e, 0xfb, 0x2f, 0x00, 0x00, 0xff, 0xff,
-	0xd6, 0x90, 0xcf, 0x6c, 0x64, 0x07, 0x00, 0x00,
+	// 827 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x7f, 0x8f, 0xdb, 0x44,
+	0x10, 0x55, 0x7e, 0xdc, 0x25, 0x99, 0xfc, 0xb8, 0xb0, 0x94, 0xd6, 0x0d, 0xa8, 0x8d, 0x0c, 0x48,
+	0x41, 0x08, 0x5b, 0x0d, 0x20, 0x21, 0x21, 0x10, 0xe9, 0x15, 0x50, 0x24, 0x2a, 0xc2, 0x16, 0x81,
+	0xe0, 0x9f, 0xe0, 0xac, 0xd7, 0xce, 0xea, 0x6c, 0xaf, 0xbb, 0xbb, 0x4e, 0x39, 0x3e, 0x01, 0xdf,
+	0x89, 0x0f, 0x07, 0xda, 0xb5, 0x9d, 0xd8, 0x49, 0x7a, 0xa2, 0xff, 0x9c, 0xb2, 0x33, 0x6f, 0xde,
+	0xce, 0xbc, 0x79, 0xeb, 0x83, 0x07, 0x22, 0x25, 0x2e, 0xf1, 0xc8, 0x96, 0xba, 0x92, 0x8a, 0x1d,
+	0x23, 0xd4, 0x49, 0x05, 0x57, 0x1c, 0x8d, 0x94, 0x60, 0xbb, 0x5b, 0xc7, 0xa4, 0x9c, 0xdd, 0x93,
+	0xc9, 0xe3, 0x90, 0xf3, 0x30, 0xa2, 0xae, 0xc9, 0x6e, 0xb2, 0xc0, 0x55, 0x2c, 0xa6, 0x52, 0x79,
+	0x71, 0x9a, 0x17, 0x4c, 0x2c, 0xc3, 0xc4, 0xe3, 0x98, 0x27, 0x75, 0xaa, 0xc9, 0xbb, 0xc7, 0xa5,
+	0x34, 0x4e, 0xd5, 0x6d, 0x9e, 0xb4, 0xff, 0x6e, 0xc2, 0x60, 0x21, 0x14, 0x0b, 0x3c, 0xa2, 0x96,
+	0x49, 0xc0, 0xd1, 0x87, 0x30, 0x92, 0x64, 0x4b, 0x63, 0x6f, 0xbd, 0xa3, 0x42, 0x32, 0x9e, 0x58,
+	0x8d, 0x69, 0x63, 0x76, 0x81, 0x87, 0x79, 0xf4, 0x97, 0x3c, 0x88, 0x6c, 0x18, 0x78, 0x82, 0x6c,
+	0x99, 0xa2, 0x44, 0x65, 0x82, 0x5a, 0xcd, 0x69, 0x63, 0xd6, 0xc3, 0xb5, 0x18, 0xfa, 0x0c, 0x3a,
+	0x44, 0x50, 0x4f, 0x51, 0xdf, 0x6a, 0x4d, 0x1b, 0xb3, 0xfe, 0x7c, 0xe2, 0xe4, 0xad, 0x38, 0x65,
+	0x2b, 0xce, 0xcf, 0xe5, 0x14, 0xb8, 0x84, 0xea, 0x06, 0x7c, 0x4e, 0x6e, 0xa8, 0xd8, 0x37, 0xd0,
+	0x36, 0xdc, 0xc3, 0x3c, 0x5a, 0x36, 0x30, 0x82, 0x26, 0x97, 0xd6, 0x85, 0x49, 0x35, 0xb9, 0x44,
+	0xdf, 0xc0, 0x78, 0xcb, 0xa4, 0xe2, 0xe2, 0x76, 0x9d, 0x7a, 0xe4, 0xc6, 0x0b, 0xa9, 0xb4, 0x2e,
+	0xa7, 0xad, 0x59, 0x7f, 0xfe, 0x8e, 0x53, 0x68, 0x69, 0xc4, 0x71, 0x56, 0x79, 0x16, 0x5f, 0x15,
+	0xf0, 0xe2, 0x2c, 0xed, 0x3f, 0x01, 0xad, 0x32, 0x55, 0x8a, 0x81, 0xe9, 0xcb, 0x8c, 0x4a, 0x85,
+	0x1e, 0x43, 0xdf, 0x2b, 0x42, 0x6b, 0xe6, 0x1b, 0x31, 0x7a, 0x18, 0xca, 0xd0, 0xd2, 0x47, 0x0b,
+	0x18, 0x1e, 0x00, 0x49, 0xc0, 0x8d, 0x14, 0xfd, 0xf9, 0x7b, 0x4e, 0x7d, 0x83, 0x4e, 0x55, 0x65,
+	0x2d, 0xd4, 0xe1, 0x64, 0xff, 0xdb, 0x86, 0xee, 0xd3, 0x88, 0x6f, 0xde, 0x64, 0x01, 0x53, 0x33,
+	0x7f, 0x7e, 0xd7, 0xb8, 0x3e, 0xe1, 0x8f, 0x2f, 0x8c, 0x22, 0x5f, 0x00, 0x08, 0x9a, 0x72, 0xc9,
+	0xf4, 0x94, 0x56, 0xdf, 0x20, 0xad, 0x3a, 0x12, 0xef, 0xf3, 0xb8, 0x82, 0x45, 0x5f, 0xc3, 0xb0,
+	0xd0, 0xd0, 0x4c, 0x24, 0xad, 0x96, 0x11, 0xf2, 0xe1, 0x59, 0x21, 0xf3, 0x79, 0xd2, 0xc3, 0x41,
+	0xa2, 0xaf, 0x60, 0xe0, 0xa5, 0x69, 0xc4, 0x88, 0xa7, 0x18, 0x4f, 0xa4, 0xd5, 0x3e, 0x57, 0xbe,
+	0x38, 0x20, 0x70, 0x0d, 0x8e, 0x7e, 0x80, 0xb7, 0x62, 0x26, 0x09, 0x4f, 0x02, 0x16, 0x66, 0xa2,
+	0xe0, 0xe8, 0x19, 0x8e, 0x47, 0x75, 0x8e, 0xe7, 0x47, 0x30, 0x7c, 0x5a, 0xa8, 0x17, 0xc8, 0x53,
+	0xef, 0x65, 0x46, 0xd7, 0x3e, 0x13, 0xda, 0x31, 0x2d, 0xbd, 0xc0, 0x3c, 0xf4, 0x8c, 0x09, 0xa9,
+	0x05, 0x7f, 0xa5, 0x4d, 0xcb, 0x33, 0xb5, 0x0e, 0x58, 0x54, 0xf8, 0xa6, 0x87, 0x87, 0x65, 0xf4,
+	0x3b, 0x1d, 0x44, 0xf7, 0xe1, 0xd2, 0x67, 0x21, 0x95, 0xca, 0xea, 0x18, 0x0f, 0x14, 0x27, 0xf4,
+	0x00, 0x3a, 0x3e, 0x0b, 0x02, 0x6d, 0x8e, 0x6e, 0x99, 0x08, 0x82, 0xa5, 0x8f, 0xbe, 0x87, 0x31,
+	0xc9, 0xa4, 0xe2, 0xf1, 0x5a, 0x50, 0xc9, 0x33, 0x41, 0xa8, 0xb4, 0x60, 0xda, 0xaa, 0x7a, 0x23,
+	0x9f, 0xe2, 0xda, 0xa0, 0x70, 0x01, 0xc2, 0x57, 0xa4, 0x76, 0x96, 0xc8, 0x81, 0x8e, 0xa4, 0x44,
+	0x50, 0x25, 0xad, 0x81, 0xa9, 0xbf, 0x57, 0xaf, 0x7f, 0x61, 0x92, 0xb8, 0x04, 0xa1, 0x27, 0xd0,
+	0x7d, 0xe5, 0x89, 0x84, 0x25, 0xa1, 0xb4, 0x86, 0xe7, 0x9e, 0xc0, 0xaf, 0x79, 0x16, 0xef, 0x61,
+	0xf6, 0x1f, 0x30, 0x5a, 0x65, 0x4a, 0x7b, 0xb0, 0xf4, 0x7d, 0x65, 0xac, 0x46, 0x6d, 0xac, 0xcf,
+	0xa1, 0xb7, 0x89, 0xf8, 0x26, 0xf7, 0x7a, 0xab, 0xee, 0xaa, 0xd2, 0xeb, 0xa5, 0x99, 0x71, 0x77,
+	0x53, 0xfc, 0xb2, 0xaf, 0xa1, 0xbf, 0xca, 0x14, 0xa6, 0x32, 0xe5, 0x89, 0xa4, 0x85, 0x7d, 0x1b,
+	0x77, 0xd8, 0x17, 0x41, 0x9b, 0x72, 0x19, 0x19, 0x8b, 0x77, 0xb1, 0xf9, 0x6d, 0xff, 0x04, 0x6f,
+	0x3f, 0x67, 0x52, 0xb2, 0x24, 0xd4, 0x37, 0xc8, 0xff, 0xfd, 0x46, 0x1f, 0x42, 0x37, 0xef, 0xd9,
+	0xd7, 0x4f, 0x46, 0x2f, 0xb7, 0x63, 0x1a, 0xf3, 0xa5, 0x7d, 0x03, 0xf7, 0xea, 0x94, 0x45, 0x83,
+	0x1f, 0xc1, 0x38, 0xce, 0xe3, 0xeb, 0x92, 0xc8, 0x10, 0x77, 0xf1, 0x55, 0x11, 0x2f, 0x1f, 0x34,
+	0x9a, 0x1d, 0xa0, 0x47, 0xb7, 0x8c, 0xe2, 0x03, 0xb5, 0xbe, 0xcc, 0x05, 0xf4, 0x8c, 0x46, 0x54,
+	0xd1, 0x5a, 0xfb, 0xd5, 0xee, 0x1a, 0xb5, 0xee, 0xe6, 0xff, 0x34, 0xe1, 0xe2, 0x5a, 0xab, 0x8a,
+	0x96, 0x46, 0xbf, 0xfd, 0x9d, 0xf6, 0xb1, 0xe4, 0xa7, 0x9f, 0xae, 0xc9, 0xfd, 0x93, 0xcf, 0xed,
+	0xb7, 0xfa, 0xcb, 0x8f, 0x16, 0xd0, 0x29, 0x96, 0x8d, 0x1e, 0x9d, 0xa1, 0xa9, 0xb8, 0xe0, 0xb5,
+	0x14, 0xbf, 0xc1, 0xa0, 0xaa, 0x1a, 0x7a, 0xff, 0x98, 0xe7, 0xcc, 0x9a, 0x26, 0x1f, 0xdc, 0x0d,
+	0x2a, 0x84, 0x5f, 0x42, 0xbf, 0xa2, 0xd1, 0xe9, 0xa0, 0xa7, 0x02, 0xbe, 0xae, 0xcb, 0xa7, 0x9f,
+	0xfc, 0xfe, 0x71, 0xc8, 0xd4, 0x36, 0xdb, 0x68, 0x6b, 0xb9, 0x7f, 0x6d, 0xbd, 0x24, 0x8c, 0x58,
+	0xec, 0x71, 0xd7, 0x50, 0xba, 0xfb, 0xff, 0xbd, 0x5f, 0x9a, 0xbf, 0x9b, 0x4b, 0x53, 0xfe, 0xe9,
+	0x7f, 0x03, 0x00, 0x39, 0xc5, 0x35, 0x3b, 0x95, 0x07, 0x00, 0x00,
 }
@@ -33,6 +33,15 @@ const _ = twirp.TwirpPackageMinVersion_8_1_0
 
 type Scanner interface {
 	Scan(context.Context, *ScanRequest) (*ScanResponse, error)
+
+	// StartScan starts a scan in the background and returns its ID without waiting for the results.
+	StartScan(context.Context, *ScanRequest) (*StartScanResponse, error)
+
+	// GetScanProgress returns the progress of a scan started by StartScan and the results found so far.
+	GetScanProgress(context.Context, *GetScanProgressRequest) (*GetScanProgressResponse, error)
+
+	// GetScanResult returns the results of a scan started by StartScan once it is done.
+	GetScanResult(context.Context, *GetScanResultRequest) (*ScanResponse, error)
 }
 
 // =======================
@@ -41,7 +50,7 @@ type Scanner interface {
 
 type scannerProtobufClient struct {
 	client      HTTPClient
-	urls        [1]string
+	urls        [4]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -69,8 +78,11 @@ func NewScannerProtobufClient(baseURL string, client HTTPClient, opts ...twirp.C
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(pathPrefix, "trivy.scanner.v1", "Scanner")
-	urls := [1]string{
+	urls := [4]string{
 		serviceURL + "Scan",
+		serviceURL + "StartScan",
+		serviceURL + "GetScanProgress",
+		serviceURL + "GetScanResult",
 	}
 
 	return &scannerProtobufClient{
@@ -127,13 +139,151 @@ func (c *scannerProtobufClient) callScan(ctx context.Context, in *ScanRequest) (
 	return out, nil
 }
 
+func (c *scannerProtobufClient) StartScan(ctx context.Context, in *ScanRequest) (*StartScanResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "trivy.scanner.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Scanner")
+	ctx = ctxsetters.WithMethodName(ctx, "StartScan")
+	caller := c.callStartScan
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ScanRequest) (*StartScanResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ScanRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ScanRequest) when calling interceptor")
+					}
+					return c.callStartScan(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*StartScanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*StartScanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *scannerProtobufClient) callStartScan(ctx context.Context, in *ScanRequest) (*StartScanResponse, error) {
+	out := new(StartScanResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *scannerProtobufClient) GetScanProgress(ctx context.Context, in *GetScanProgressRequest) (*GetScanProgressResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "trivy.scanner.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Scanner")
+	ctx = ctxsetters.WithMethodName(ctx, "GetScanProgress")
+	caller := c.callGetScanProgress
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetScanProgressRequest) (*GetScanProgressResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetScanProgressRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetScanProgressRequest) when calling interceptor")
+					}
+					return c.callGetScanProgress(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetScanProgressResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetScanProgressResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *scannerProtobufClient) callGetScanProgress(ctx context.Context, in *GetScanProgressRequest) (*GetScanProgressResponse, error) {
+	out := new(GetScanProgressResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *scannerProtobufClient) GetScanResult(ctx context.Context, in *GetScanResultRequest) (*ScanResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "trivy.scanner.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Scanner")
+	ctx = ctxsetters.WithMethodName(ctx, "GetScanResult")
+	caller := c.callGetScanResult
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetScanResultRequest) (*ScanResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetScanResultRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetScanResultRequest) when calling interceptor")
+					}
+					return c.callGetScanResult(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ScanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ScanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *scannerProtobufClient) callGetScanResult(ctx context.Context, in *GetScanResultRequest) (*ScanResponse, error) {
+	out := new(ScanResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ===================
 // Scanner JSON Client
 // ===================
 
 type scannerJSONClient struct {
 	client      HTTPClient
-	urls        [1]string
+	urls        [4]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -161,8 +311,11 @@ func NewScannerJSONClient(baseURL string, client HTTPClient, opts ...twirp.Clien
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(pathPrefix, "trivy.scanner.v1", "Scanner")
-	urls := [1]string{
+	urls := [4]string{
 		serviceURL + "Scan",
+		serviceURL + "StartScan",
+		serviceURL + "GetScanProgress",
+		serviceURL + "GetScanResult",
 	}
 
 	return &scannerJSONClient{
@@ -219,6 +372,144 @@ func (c *scannerJSONClient) callScan(ctx context.Context, in *ScanRequest) (*Sca
 	return out, nil
 }
 
+func (c *scannerJSONClient) StartScan(ctx context.Context, in *ScanRequest) (*StartScanResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "trivy.scanner.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Scanner")
+	ctx = ctxsetters.WithMethodName(ctx, "StartScan")
+	caller := c.callStartScan
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ScanRequest) (*StartScanResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ScanRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ScanRequest) when calling interceptor")
+					}
+					return c.callStartScan(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*StartScanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*StartScanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *scannerJSONClient) callStartScan(ctx context.Context, in *ScanRequest) (*StartScanResponse, error) {
+	out := new(StartScanResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *scannerJSONClient) GetScanProgress(ctx context.Context, in *GetScanProgressRequest) (*GetScanProgressResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "trivy.scanner.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Scanner")
+	ctx = ctxsetters.WithMethodName(ctx, "GetScanProgress")
+	caller := c.callGetScanProgress
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetScanProgressRequest) (*GetScanProgressResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetScanProgressRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetScanProgressRequest) when calling interceptor")
+					}
+					return c.callGetScanProgress(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetScanProgressResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetScanProgressResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *scannerJSONClient) callGetScanProgress(ctx context.Context, in *GetScanProgressRequest) (*GetScanProgressResponse, error) {
+	out := new(GetScanProgressResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *scannerJSONClient) GetScanResult(ctx context.Context, in *GetScanResultRequest) (*ScanResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "trivy.scanner.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Scanner")
+	ctx = ctxsetters.WithMethodName(ctx, "GetScanResult")
+	caller := c.callGetScanResult
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetScanResultRequest) (*ScanResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetScanResultRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetScanResultRequest) when calling interceptor")
+					}
+					return c.callGetScanResult(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ScanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ScanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *scannerJSONClient) callGetScanResult(ctx context.Context, in *GetScanResultRequest) (*ScanResponse, error) {
+	out := new(ScanResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ======================
 // Scanner Server Handler
 // ======================
@@ -319,6 +610,15 @@ func (s *scannerServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	case "Scan":
 		s.serveScan(ctx, resp, req)
 		return
+	case "StartScan":
+		s.serveStartScan(ctx, resp, req)
+		return
+	case "GetScanProgress":
+		s.serveGetScanProgress(ctx, resp, req)
+		return
+	case "GetScanResult":
+		s.serveGetScanResult(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
@@ -506,6 +806,546 @@ func (s *scannerServer) serveScanProtobuf(ctx context.Context, resp http.Respons
 	callResponseSent(ctx, s.hooks)
 }
 
+func (s *scannerServer) serveStartScan(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveStartScanJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveStartScanProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *scannerServer) serveStartScanJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "StartScan")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ScanRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.Scanner.StartScan
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ScanRequest) (*StartScanResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ScanRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ScanRequest) when calling interceptor")
+					}
+					return s.Scanner.StartScan(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*StartScanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*StartScanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *StartScanResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *StartScanResponse and nil error while calling StartScan. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *scannerServer) serveStartScanProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "StartScan")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ScanRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.Scanner.StartScan
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ScanRequest) (*StartScanResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ScanRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ScanRequest) when calling interceptor")
+					}
+					return s.Scanner.StartScan(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*StartScanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*StartScanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *StartScanResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *StartScanResponse and nil error while calling StartScan. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *scannerServer) serveGetScanProgress(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetScanProgressJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetScanProgressProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *scannerServer) serveGetScanProgressJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetScanProgress")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetScanProgressRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.Scanner.GetScanProgress
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetScanProgressRequest) (*GetScanProgressResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetScanProgressRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetScanProgressRequest) when calling interceptor")
+					}
+					return s.Scanner.GetScanProgress(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetScanProgressResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetScanProgressResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetScanProgressResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetScanProgressResponse and nil error while calling GetScanProgress. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *scannerServer) serveGetScanProgressProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetScanProgress")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetScanProgressRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.Scanner.GetScanProgress
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetScanProgressRequest) (*GetScanProgressResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetScanProgressRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetScanProgressRequest) when calling interceptor")
+					}
+					return s.Scanner.GetScanProgress(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetScanProgressResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetScanProgressResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetScanProgressResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetScanProgressResponse and nil error while calling GetScanProgress. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *scannerServer) serveGetScanResult(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetScanResultJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetScanResultProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *scannerServer) serveGetScanResultJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetScanResult")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetScanResultRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.Scanner.GetScanResult
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetScanResultRequest) (*ScanResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetScanResultRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetScanResultRequest) when calling interceptor")
+					}
+					return s.Scanner.GetScanResult(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ScanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ScanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ScanResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ScanResponse and nil error while calling GetScanResult. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *scannerServer) serveGetScanResultProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetScanResult")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetScanResultRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.Scanner.GetScanResult
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetScanResultRequest) (*ScanResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetScanResultRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetScanResultRequest) when calling interceptor")
+					}
+					return s.Scanner.GetScanResult(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ScanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ScanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ScanResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ScanResponse and nil error while calling GetScanResult. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
 func (s *scannerServer) ServiceDescriptor() ([]byte, int) {
 	return twirpFileDescriptor0, 0
 }
@@ -1087,46 +1927,63 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 648 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xcd, 0x6e, 0x13, 0x3b,
-	0x14, 0xc7, 0x35, 0x49, 0xf3, 0x75, 0x72, 0xa5, 0xa6, 0xd6, 0xbd, 0xd5, 0x34, 0xbd, 0x40, 0x94,
-	0x05, 0x8a, 0x58, 0x24, 0x34, 0x80, 0x40, 0xb0, 0x82, 0x52, 0x50, 0x25, 0x50, 0x2b, 0xa7, 0x62,
-	0xc1, 0x26, 0x38, 0x8e, 0x9b, 0x5a, 0x75, 0xec, 0xc1, 0xf6, 0x44, 0x0a, 0x6f, 0xc6, 0x33, 0xf0,
-	0x30, 0xbc, 0x02, 0xf2, 0xc7, 0x54, 0x9d, 0xf4, 0x43, 0xac, 0xc6, 0xc7, 0xe7, 0x77, 0x3c, 0x7f,
-	0x9f, 0xf3, 0x97, 0x61, 0x4f, 0x67, 0x74, 0x64, 0x28, 0x91, 0x92, 0xe9, 0x91, 0x61, 0x7a, 0xc5,
-	0x29, 0x1b, 0x66, 0x5a, 0x59, 0x85, 0x3a, 0x56, 0xf3, 0xd5, 0x7a, 0x18, 0x93, 0xc3, 0xd5, 0x41,
-	0x37, 0x75, 0x30, 0x55, 0xcb, 0xa5, 0x92, 0x65, 0xb6, 0xff, 0x3b, 0x81, 0xf6, 0x84, 0x12, 0x89,
-	0xd9, 0xf7, 0x9c, 0x19, 0x8b, 0x76, 0xa1, 0x6e, 0x89, 0x5e, 0x30, 0x9b, 0x26, 0xbd, 0x64, 0xd0,
-	0xc2, 0x31, 0x42, 0x8f, 0xa0, 0x4d, 0xb4, 0xe5, 0xe7, 0x84, 0xda, 0x29, 0x9f, 0xa7, 0x15, 0x9f,
-	0x84, 0x62, 0xeb, 0x78, 0x8e, 0xf6, 0xa0, 0x39, 0x13, 0x6a, 0x36, 0xe5, 0x73, 0x93, 0x56, 0x7b,
-	0xd5, 0x41, 0x0b, 0x37, 0x5c, 0x7c, 0x3c, 0x37, 0xe8, 0x25, 0x34, 0x54, 0x66, 0xb9, 0x92, 0x26,
-	0xdd, 0xea, 0x25, 0x83, 0xf6, 0xf8, 0xc1, 0x70, 0x53, 0xe1, 0xd0, 0x69, 0x38, 0x09, 0x10, 0x2e,
-	0x68, 0xd4, 0x83, 0x8a, 0x32, 0x69, 0xcd, 0xd7, 0x74, 0x62, 0x4d, 0xb8, 0xc5, 0xf0, 0x64, 0x82,
-	0x2b, 0xca, 0xa0, 0x03, 0x68, 0x66, 0x84, 0x5e, 0x92, 0x05, 0x33, 0x69, 0xbd, 0x57, 0x1d, 0xb4,
-	0xc7, 0xff, 0x95, 0xb9, 0xd3, 0x90, 0xc5, 0x57, 0x58, 0xbf, 0x07, 0xcd, 0x4f, 0x9c, 0x32, 0x69,
-	0x98, 0x41, 0xff, 0x42, 0x4d, 0x92, 0x25, 0x33, 0x69, 0xe2, 0x15, 0x87, 0xa0, 0xff, 0xb3, 0x12,
-	0x7a, 0x12, 0xf5, 0xa0, 0x7d, 0x68, 0xad, 0x72, 0x21, 0xa7, 0x76, 0x9d, 0xb1, 0x48, 0x36, 0xdd,
-	0xc6, 0xd9, 0x3a, 0x63, 0xa8, 0x0b, 0xcd, 0x78, 0x0d, 0x93, 0x56, 0x42, 0xae, 0x88, 0xd1, 0x13,
-	0xd8, 0x11, 0xdc, 0xd8, 0x29, 0x11, 0x62, 0x7a, 0x25, 0xb3, 0xda, 0x4b, 0x06, 0x4d, 0xbc, 0xed,
-	0x12, 0x6f, 0x85, 0x88, 0xfa, 0x0c, 0xa2, 0x80, 0x44, 0x90, 0x35, 0xa5, 0xc4, 0xb2, 0x85, 0xd2,
-	0x9c, 0xb9, 0x7e, 0xb9, 0x3b, 0x3d, 0xbf, 0xb7, 0x5f, 0xc3, 0x78, 0x9d, 0xc3, 0xab, 0xb2, 0x23,
-	0x69, 0xf5, 0x1a, 0xef, 0x88, 0xcd, 0xfd, 0xee, 0x37, 0xd8, 0xbd, 0x1d, 0x46, 0x1d, 0xa8, 0x5e,
-	0xb2, 0x75, 0x1c, 0xba, 0x5b, 0xa2, 0xa7, 0x50, 0x5b, 0x11, 0x91, 0x33, 0x3f, 0xeb, 0xf6, 0xb8,
-	0x7b, 0x53, 0x43, 0xd1, 0x46, 0x1c, 0xc0, 0xd7, 0x95, 0x57, 0x49, 0x7f, 0x0e, 0xff, 0x04, 0x3b,
-	0x99, 0x4c, 0x49, 0xc3, 0xe2, 0x08, 0x93, 0x7b, 0x46, 0x38, 0x86, 0x86, 0x66, 0x26, 0x17, 0x36,
-	0xf8, 0xa6, 0x3d, 0x4e, 0x6f, 0xfe, 0x09, 0x7b, 0x00, 0x17, 0x60, 0xff, 0x57, 0x15, 0xea, 0x61,
-	0xef, 0x4e, 0xc3, 0x1e, 0xc1, 0xb6, 0x9b, 0x11, 0xd3, 0x64, 0xc6, 0x05, 0xb7, 0xae, 0x99, 0x15,
-	0x7f, 0xfc, 0x7e, 0x59, 0xc5, 0x97, 0x6b, 0xd0, 0x1a, 0x6f, 0xd6, 0xa0, 0x33, 0xd8, 0x59, 0x72,
-	0x43, 0x95, 0x3c, 0xe7, 0x8b, 0x5c, 0x93, 0xc2, 0xc5, 0xee, 0xa0, 0xc7, 0xe5, 0x83, 0xde, 0x33,
-	0xcb, 0xa8, 0x65, 0xf3, 0xcf, 0x1b, 0x38, 0xbe, 0x79, 0x80, 0xf3, 0x1d, 0x15, 0xc4, 0x38, 0xcf,
-	0x3a, 0xcd, 0x21, 0x40, 0x08, 0xb6, 0xbc, 0xc5, 0xaa, 0x7e, 0xd3, 0xaf, 0x4b, 0x06, 0xaf, 0xfd,
-	0x95, 0xc1, 0xd1, 0x47, 0xe8, 0xd0, 0xdc, 0x58, 0xb5, 0x9c, 0x6a, 0x66, 0x54, 0xae, 0x29, 0x33,
-	0x69, 0xc3, 0x97, 0xfe, 0x5f, 0x2e, 0x3d, 0xf4, 0x14, 0x8e, 0x10, 0xde, 0xa6, 0xa5, 0xd8, 0xa0,
-	0x17, 0xd0, 0x30, 0x8c, 0x6a, 0x66, 0x4d, 0xda, 0xbc, 0xad, 0x75, 0x13, 0x9f, 0xfc, 0xc0, 0xe5,
-	0x9c, 0xcb, 0x05, 0x2e, 0x58, 0x34, 0x82, 0x46, 0x74, 0x5e, 0xda, 0xba, 0x4d, 0x71, 0xb4, 0x0d,
-	0x2e, 0xa8, 0xf1, 0x29, 0x34, 0x26, 0x61, 0xd6, 0xe8, 0x08, 0xb6, 0xdc, 0x12, 0xdd, 0xf1, 0x42,
-	0xc4, 0x57, 0xaa, 0xfb, 0xf0, 0xae, 0x74, 0x70, 0xdd, 0xbb, 0x83, 0xaf, 0xa3, 0x05, 0xb7, 0x17,
-	0xf9, 0xcc, 0xfd, 0x73, 0xf4, 0xe3, 0x82, 0xc8, 0x85, 0xe0, 0x4b, 0xa2, 0x46, 0xbe, 0x6c, 0x74,
-	0xed, 0xe9, 0x7c, 0x13, 0xbf, 0xb3, 0xba, 0x7f, 0x0f, 0x9f, 0xfd, 0x09, 0x00, 0x00, 0xff, 0xff,
-	0x98, 0xa6, 0xb3, 0x28, 0x58, 0x05, 0x00, 0x00,
+	// 914 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xeb, 0x6e, 0xe3, 0x44,
+	0x14, 0x56, 0x6e, 0x4d, 0x72, 0xdc, 0x6e, 0xdb, 0x51, 0xe9, 0x7a, 0xb3, 0x5c, 0x82, 0x57, 0xbb,
+	0x0a, 0x08, 0x25, 0x34, 0x80, 0x40, 0xf0, 0x6b, 0x59, 0xca, 0xaa, 0x12, 0xa8, 0xd5, 0xa4, 0x62,
+	0x11, 0x7f, 0xcc, 0xc4, 0x9e, 0x78, 0x47, 0xeb, 0x78, 0xdc, 0x99, 0x71, 0x50, 0x78, 0x3f, 0x5e,
+	0x83, 0x1f, 0xbc, 0x00, 0x6f, 0x80, 0xd0, 0x5c, 0x1c, 0xd5, 0xb9, 0xb4, 0xfd, 0xd5, 0x39, 0xdf,
+	0xf9, 0xce, 0x99, 0x73, 0xf9, 0xc6, 0x0d, 0x3c, 0x11, 0x79, 0x34, 0x92, 0x11, 0xc9, 0x32, 0x2a,
+	0x46, 0x92, 0x8a, 0x05, 0x8b, 0xe8, 0x30, 0x17, 0x5c, 0x71, 0x74, 0xa4, 0x04, 0x5b, 0x2c, 0x87,
+	0xce, 0x39, 0x5c, 0x9c, 0xf5, 0x7c, 0x4d, 0x8e, 0xf8, 0x7c, 0xce, 0xb3, 0x2a, 0x37, 0xf8, 0xb7,
+	0x06, 0xde, 0x24, 0x22, 0x19, 0xa6, 0x37, 0x05, 0x95, 0x0a, 0x9d, 0xc2, 0x9e, 0x22, 0x22, 0xa1,
+	0xca, 0xaf, 0xf5, 0x6b, 0x83, 0x2e, 0x76, 0x16, 0xfa, 0x08, 0x3c, 0x22, 0x14, 0x9b, 0x91, 0x48,
+	0x85, 0x2c, 0xf6, 0xeb, 0xc6, 0x09, 0x25, 0x74, 0x11, 0xa3, 0x27, 0xd0, 0x99, 0xa6, 0x7c, 0x1a,
+	0xb2, 0x58, 0xfa, 0x8d, 0x7e, 0x63, 0xd0, 0xc5, 0x6d, 0x6d, 0x5f, 0xc4, 0x12, 0x7d, 0x0d, 0x6d,
+	0x9e, 0x2b, 0xc6, 0x33, 0xe9, 0x37, 0xfb, 0xb5, 0x81, 0x37, 0xfe, 0x60, 0xb8, 0x5e, 0xe1, 0x50,
+	0xd7, 0x70, 0x69, 0x49, 0xb8, 0x64, 0xa3, 0x3e, 0xd4, 0xb9, 0xf4, 0x5b, 0x26, 0xe6, 0xc8, 0xc5,
+	0xd8, 0x2e, 0x86, 0x97, 0x13, 0x5c, 0xe7, 0x12, 0x9d, 0x41, 0x27, 0x27, 0xd1, 0x3b, 0x92, 0x50,
+	0xe9, 0xef, 0xf5, 0x1b, 0x03, 0x6f, 0xfc, 0x5e, 0x95, 0x77, 0x65, 0xbd, 0x78, 0x45, 0x0b, 0xfa,
+	0xd0, 0xf9, 0x89, 0x45, 0x34, 0x93, 0x54, 0xa2, 0x13, 0x68, 0x65, 0x64, 0x4e, 0xa5, 0x5f, 0x33,
+	0x15, 0x5b, 0x23, 0xf8, 0xbb, 0x0e, 0xde, 0xad, 0x7a, 0xd0, 0x53, 0xe8, 0x2e, 0x8a, 0x34, 0x0b,
+	0xd5, 0x32, 0xa7, 0x8e, 0xd9, 0xd1, 0xc0, 0xf5, 0x32, 0xa7, 0xa8, 0x07, 0x1d, 0xd7, 0x86, 0xf4,
+	0xeb, 0xd6, 0x57, 0xda, 0xe8, 0x53, 0x38, 0x4e, 0x99, 0x54, 0x21, 0x49, 0xd3, 0x70, 0x55, 0x66,
+	0xa3, 0x5f, 0x1b, 0x74, 0xf0, 0xa1, 0x76, 0xbc, 0x4c, 0x53, 0x57, 0x9f, 0x44, 0x11, 0xa0, 0xd4,
+	0x96, 0x15, 0x46, 0x44, 0xd1, 0x84, 0x0b, 0x46, 0xf5, 0xbc, 0x74, 0x4f, 0x5f, 0xde, 0x39, 0xaf,
+	0xa1, 0x6b, 0xe7, 0xd5, 0x2a, 0xec, 0x3c, 0x53, 0x62, 0x89, 0x8f, 0xd3, 0x75, 0x1c, 0x3d, 0x83,
+	0x83, 0xd5, 0x16, 0x4d, 0x37, 0x2d, 0xb3, 0xc7, 0xfd, 0x12, 0xd4, 0x1d, 0xf5, 0x7e, 0x87, 0xd3,
+	0xed, 0x19, 0xd1, 0x11, 0x34, 0xde, 0xd1, 0xa5, 0x53, 0x86, 0x3e, 0xa2, 0xcf, 0xa1, 0xb5, 0x20,
+	0x69, 0x41, 0x8d, 0x20, 0xbc, 0x71, 0x6f, 0xb3, 0xd0, 0x72, 0xd6, 0xd8, 0x12, 0xbf, 0xad, 0x7f,
+	0x53, 0x0b, 0x62, 0xd8, 0xb7, 0x9a, 0x93, 0x39, 0xcf, 0x24, 0x75, 0x7b, 0xae, 0xdd, 0xb1, 0xe7,
+	0x31, 0xb4, 0x05, 0x95, 0x45, 0xaa, 0xac, 0xb8, 0xbc, 0xb1, 0xbf, 0x79, 0x13, 0x36, 0x04, 0x5c,
+	0x12, 0x83, 0xff, 0x1a, 0xb0, 0x67, 0xb1, 0x9d, 0xaa, 0x3e, 0x87, 0x43, 0xbd, 0x48, 0x2a, 0xc8,
+	0x94, 0xa5, 0x4c, 0x31, 0x6a, 0x77, 0xe8, 0x8d, 0x9f, 0x56, 0xab, 0xf8, 0xe5, 0x16, 0x69, 0x89,
+	0xd7, 0x63, 0xd0, 0x35, 0x1c, 0xcf, 0x99, 0x8c, 0x78, 0x36, 0x63, 0x49, 0x21, 0x48, 0x29, 0x75,
+	0x9d, 0xe8, 0x45, 0x35, 0xd1, 0x0f, 0x54, 0xd1, 0x48, 0xd1, 0xf8, 0xe7, 0x35, 0x3a, 0xde, 0x4c,
+	0xa0, 0xc5, 0x19, 0xa5, 0x44, 0x6a, 0x61, 0xeb, 0x9a, 0xad, 0x81, 0x10, 0x34, 0xcd, 0xe6, 0x1a,
+	0x06, 0x34, 0xe7, 0xca, 0x2b, 0x68, 0x3d, 0xe8, 0x15, 0xa0, 0xd7, 0x70, 0x14, 0x15, 0x52, 0xf1,
+	0x79, 0x28, 0xa8, 0xe4, 0x85, 0x88, 0xa8, 0xf4, 0xdb, 0x26, 0xf4, 0xfd, 0x6a, 0xe8, 0x2b, 0xc3,
+	0xc2, 0x8e, 0x84, 0x0f, 0xa3, 0x8a, 0x2d, 0xd1, 0x57, 0xd0, 0x96, 0x34, 0x12, 0x54, 0x49, 0xbf,
+	0xb3, 0x6d, 0x74, 0x13, 0xe3, 0xfc, 0x91, 0x65, 0x31, 0xcb, 0x12, 0x5c, 0x72, 0xd1, 0x08, 0xda,
+	0x4e, 0x9e, 0x7e, 0x77, 0x5b, 0xc5, 0x4e, 0x36, 0xb8, 0x64, 0xe9, 0x1e, 0xff, 0x20, 0x22, 0x63,
+	0x59, 0x22, 0x7d, 0xd8, 0x16, 0xf1, 0xc6, 0x7a, 0xf1, 0x8a, 0x16, 0x7c, 0x06, 0xc7, 0x13, 0x45,
+	0x84, 0xaa, 0x68, 0xed, 0x31, 0xb4, 0xb5, 0x66, 0xf4, 0x47, 0xcc, 0x69, 0x41, 0x9b, 0x17, 0x71,
+	0xf0, 0x2b, 0x9c, 0xbe, 0xa6, 0x86, 0x7b, 0x25, 0x78, 0x22, 0xa8, 0x94, 0xe5, 0x37, 0x71, 0x57,
+	0x08, 0x7a, 0x0e, 0x8f, 0x9c, 0xd8, 0x42, 0x3e, 0x9b, 0x49, 0xaa, 0xcc, 0x33, 0x68, 0xe1, 0x03,
+	0x87, 0x5e, 0x1a, 0x30, 0xf8, 0xab, 0x0e, 0x8f, 0x37, 0x52, 0xbb, 0x72, 0x4e, 0xa0, 0x25, 0x15,
+	0x49, 0xa8, 0xcb, 0x6c, 0x0d, 0xf4, 0x31, 0xec, 0xa7, 0x64, 0x49, 0x85, 0x0c, 0x15, 0x57, 0x24,
+	0x75, 0x69, 0x3d, 0x8b, 0x5d, 0x6b, 0x48, 0xdf, 0xed, 0x28, 0x24, 0xcf, 0x53, 0x46, 0x63, 0xa3,
+	0x88, 0x16, 0x3e, 0xb0, 0xe8, 0x4b, 0x0b, 0xde, 0x7e, 0x38, 0xcd, 0x07, 0x3e, 0x1c, 0xfd, 0x95,
+	0x28, 0xdb, 0xb2, 0xd7, 0xb7, 0x4c, 0xe6, 0x7d, 0x07, 0xda, 0xfb, 0x11, 0x34, 0x63, 0x9e, 0x51,
+	0x23, 0xce, 0x0e, 0x36, 0x67, 0xdd, 0x0c, 0x15, 0x82, 0x0b, 0xbf, 0x6d, 0x9b, 0x31, 0x86, 0x7b,
+	0xdd, 0x9d, 0x3b, 0x5e, 0xf7, 0x73, 0x78, 0x74, 0x53, 0xd0, 0x82, 0x86, 0x39, 0x97, 0x4c, 0x8b,
+	0xdf, 0xef, 0xda, 0x5e, 0x0c, 0x7a, 0xe5, 0xc0, 0x60, 0x04, 0x27, 0x6e, 0x8c, 0xae, 0xe2, 0x7b,
+	0xf6, 0x33, 0xfe, 0xa7, 0x0e, 0xed, 0x89, 0xed, 0x13, 0x9d, 0x43, 0x53, 0x1f, 0xd1, 0x8e, 0xff,
+	0x3d, 0x2e, 0x57, 0xef, 0xc3, 0x5d, 0x6e, 0xb7, 0xaf, 0x4b, 0xe8, 0xae, 0x34, 0x75, 0x5f, 0xae,
+	0x67, 0x5b, 0xdc, 0x1b, 0x7a, 0x9c, 0xc1, 0xe1, 0x9a, 0x36, 0xd0, 0x60, 0x33, 0x6e, 0xbb, 0x32,
+	0x7b, 0x9f, 0x3c, 0x80, 0xe9, 0xee, 0x79, 0x03, 0x07, 0x95, 0xe1, 0xa1, 0x17, 0x3b, 0x63, 0x2b,
+	0xd3, 0xbd, 0x6f, 0x22, 0xdf, 0x9f, 0xfd, 0x36, 0x4a, 0x98, 0x7a, 0x5b, 0x4c, 0xf5, 0x52, 0x47,
+	0x7f, 0xbe, 0x25, 0x59, 0x92, 0xb2, 0x39, 0xe1, 0x23, 0x13, 0x36, 0xba, 0xf5, 0x33, 0xe5, 0x3b,
+	0xf7, 0x77, 0xba, 0x67, 0x7e, 0x7b, 0x7c, 0xf1, 0xff, 0x00, 0xd2, 0xff, 0xe4, 0x33, 0xc4, 0x08,
+	0x00, 0x00,
 }